@@ -14,7 +14,6 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
@@ -74,6 +73,11 @@ type BreachConfig struct {
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStore
+
+	// ChannelsChanged is called once the breach arbiter has deleted the
+	// state of a closed channel, so the static channel backup on disk can
+	// be updated.
+	ChannelsChanged func()
 }
 
 // breachArbiter is a special subsystem which is responsible for watching and
@@ -243,6 +247,7 @@ func (b *breachArbiter) Start() error {
 					"state: %v", err)
 				return err
 			}
+			b.cfg.ChannelsChanged()
 
 			// Now that this channel is both breached _and_ closed,
 			// we can skip adding it to the `channelsToWatch` since
@@ -654,8 +659,11 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 		return
 
 	// The channel has been closed by a normal means: force closing with
-	// the latest commitment transaction.
-	case closeInfo := <-contract.UnilateralClose:
+	// the latest commitment transaction. The channel arbitrator of the
+	// channel watches for the remote commitment as well, and will sweep
+	// our output along with any HTLC's within it, so all that's left for
+	// us to do is to stop watching the channel.
+	case <-contract.UnilateralClose:
 		// Launch a goroutine to cancel out this contract within the
 		// breachArbiter's main goroutine.
 		b.wg.Add(1)
@@ -672,61 +680,6 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 		contract.CancelObserver()
 		contract.Stop()
 
-		// Next, we'll launch a goroutine to wait until the closing
-		// transaction has been confirmed so we can mark the contract
-		// as resolved in the database. This go routine is _not_ tracked
-		// by the breach arbiter's wait group since the callback may not
-		// be executed before shutdown, potentially leading to a
-		// deadlocks as the arbiter may not be able to finish shutting
-		// down.
-		//
-		// TODO(roasbeef): also notify utxoNursery, might've had
-		// outbound HTLC's in flight
-		go waitForChanToClose(uint32(closeInfo.SpendingHeight),
-			b.cfg.Notifier, nil, chanPoint, closeInfo.SpenderTxHash,
-			func() {
-				// As we just detected a channel was closed via
-				// a unilateral commitment broadcast by the
-				// remote party, we'll need to sweep our main
-				// commitment output, and any outstanding
-				// outgoing HTLC we had as well.
-				//
-				// TODO(roasbeef): actually sweep HTLC's *
-				// ensure reliable confirmation
-				if closeInfo.SelfOutPoint != nil {
-					sweepTx, err := b.craftCommitSweepTx(
-						closeInfo,
-					)
-					if err != nil {
-						brarLog.Errorf("unable to "+
-							"generate sweep tx: %v",
-							err)
-						goto close
-					}
-
-					brarLog.Infof("Sweeping breached "+
-						"outputs with: %v",
-						spew.Sdump(sweepTx))
-
-					err = b.cfg.PublishTransaction(sweepTx)
-					if err != nil {
-						brarLog.Errorf("unable to "+
-							"broadcast tx: %v", err)
-					}
-				}
-
-			close:
-				brarLog.Infof("Force closed ChannelPoint(%v) "+
-					"is fully closed, updating DB",
-					chanPoint)
-
-				err := b.cfg.DB.MarkChanFullyClosed(chanPoint)
-				if err != nil {
-					brarLog.Errorf("unable to mark chan "+
-						"as closed: %v", err)
-				}
-			})
-
 	// A read from this channel indicates that a channel breach has been
 	// detected! So we notify the main coordination goroutine with the
 	// information needed to bring the counterparty to justice.
@@ -798,6 +751,7 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 			brarLog.Errorf("unable to delete channel state: %v",
 				err)
 		}
+		b.cfg.ChannelsChanged()
 
 		// Finally, we send the retribution information into the
 		// breachArbiter event loop to deal swift justice.
@@ -1031,97 +985,27 @@ func (b *breachArbiter) createJusticeTx(
 	r *retributionInfo) (*wire.MsgTx, error) {
 
 	// We will assemble the breached outputs into a slice of spendable
-	// outputs, while simultaneously computing the estimated weight of the
-	// transaction.
-	var (
-		spendableOutputs []SpendableOutput
-		weightEstimate   lnwallet.TxWeightEstimator
-	)
-
-	// Allocate enough space to potentially hold each of the breached
-	// outputs in the retribution info.
-	spendableOutputs = make([]SpendableOutput, 0, len(r.breachedOutputs))
-
-	// The justice transaction we construct will be a segwit transaction
-	// that pays to a p2wkh output. Components such as the version,
-	// nLockTime, and output are already included in the TxWeightEstimator.
-	weightEstimate.AddP2WKHOutput()
+	// outputs, allocating enough space to potentially hold each of the
+	// breached outputs in the retribution info.
+	spendableOutputs := make([]SpendableOutput, 0, len(r.breachedOutputs))
 
 	// Next, we iterate over the breached outputs contained in the
-	// retribution info.  For each, we switch over the witness type such
-	// that we contribute the appropriate weight for each input and witness,
-	// finally adding to our list of spendable outputs.
+	// retribution info. If the witness type of an output is unrecognized,
+	// we will omit it from the transaction.
 	for i := range r.breachedOutputs {
 		// Grab locally scoped reference to breached output.
 		input := &r.breachedOutputs[i]
 
-		// First, select the appropriate estimated witness weight for
-		// the give witness type of this breached output. If the witness
-		// type is unrecognized, we will omit it from the transaction.
-		var witnessWeight int
-		switch input.WitnessType() {
-		case lnwallet.CommitmentNoDelay:
-			witnessWeight = lnwallet.ToLocalPenaltyWitnessSize
-
-		case lnwallet.CommitmentRevoke:
-			witnessWeight = lnwallet.P2WKHWitnessSize
-
-		case lnwallet.HtlcOfferedRevoke:
-			witnessWeight = lnwallet.OfferedHtlcPenaltyWitnessSize
-
-		case lnwallet.HtlcAcceptedRevoke:
-			witnessWeight = lnwallet.AcceptedHtlcPenaltyWitnessSize
-
-		default:
+		if _, err := sweepWitnessSize(input.WitnessType()); err != nil {
 			brarLog.Warnf("breached output in retribution info "+
 				"contains unexpected witness type: %v",
 				input.WitnessType())
 			continue
 		}
-		weightEstimate.AddWitnessInput(witnessWeight)
 
-		// Finally, append this input to our list of spendable outputs.
 		spendableOutputs = append(spendableOutputs, input)
 	}
 
-	txWeight := uint64(weightEstimate.Weight())
-	return b.sweepSpendableOutputsTxn(txWeight, spendableOutputs...)
-}
-
-// craftCommitmentSweepTx creates a transaction to sweep the non-delayed output
-// within the commitment transaction that pays to us. We must manually sweep
-// this output as it uses a tweaked public key in its pkScript, so the wallet
-// won't immediately be aware of it.
-//
-// TODO(roasbeef): alternative options
-//  * leave the output in the chain, use as input to future funding tx
-//  * leave output in the chain, extend wallet to add knowledge of how to claim
-func (b *breachArbiter) craftCommitSweepTx(
-	closeInfo *lnwallet.UnilateralCloseSummary) (*wire.MsgTx, error) {
-
-	selfOutput := makeBreachedOutput(
-		closeInfo.SelfOutPoint,
-		lnwallet.CommitmentNoDelay,
-		closeInfo.SelfOutputSignDesc,
-	)
-
-	// Compute the transaction weight of the commit sweep transaction, which
-	// includes a single input and output.
-	var weightEstimate lnwallet.TxWeightEstimator
-	weightEstimate.AddP2WKHOutput()
-
-	// Add to_local p2wpkh witness and tx input.
-	weightEstimate.AddP2WKHInput()
-
-	txWeight := uint64(weightEstimate.Weight())
-	return b.sweepSpendableOutputsTxn(txWeight, &selfOutput)
-}
-
-// sweepSpendableOutputsTxn creates a signed transaction from a sequence of
-// spendable outputs by sweeping the funds into a single p2wkh output.
-func (b *breachArbiter) sweepSpendableOutputsTxn(txWeight uint64,
-	inputs ...SpendableOutput) (*wire.MsgTx, error) {
-
 	// First, we obtain a new public key script from the wallet which we'll
 	// sweep the funds to.
 	// TODO(roasbeef): possibly create many outputs to minimize change in
@@ -1131,82 +1015,16 @@ func (b *breachArbiter) sweepSpendableOutputsTxn(txWeight uint64,
 		return nil, err
 	}
 
-	// Compute the total amount contained in the inputs.
-	var totalAmt btcutil.Amount
-	for _, input := range inputs {
-		totalAmt += input.Amount()
-	}
-
 	// We'll actually attempt to target inclusion within the next two
 	// blocks as we'd like to sweep these funds back into our wallet ASAP.
 	feePerWeight, err := b.cfg.Estimator.EstimateFeePerWeight(2)
 	if err != nil {
 		return nil, err
 	}
-	txFee := btcutil.Amount(txWeight * uint64(feePerWeight))
-
-	sweepAmt := int64(totalAmt - txFee)
-
-	// With the fee calculated, we can now create the transaction using the
-	// information gathered above and the provided retribution information.
-	txn := wire.NewMsgTx(2)
-
-	// We begin by adding the output to which our funds will be deposited.
-	txn.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// Next, we add all of the spendable outputs as inputs to the
-	// transaction.
-	for _, input := range inputs {
-		txn.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	btx := btcutil.NewTx(txn)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
 
-	// Create a sighash cache to improve the performance of hashing and
-	// signing SigHashAll inputs.
-	hashCache := txscript.NewTxSigHashes(txn)
-
-	// Create a closure that encapsulates the process of initializing a
-	// particular output's witness generation function, computing the
-	// witness, and attaching it to the transaction. This function accepts
-	// an integer index representing the intended txin index, and the
-	// breached output from which it will spend.
-	addWitness := func(idx int, so SpendableOutput) error {
-		// First, we construct a valid witness for this outpoint and
-		// transaction using the SpendableOutput's witness generation
-		// function.
-		witness, err := so.BuildWitness(b.cfg.Signer, txn, hashCache,
-			idx)
-		if err != nil {
-			return err
-		}
-
-		// Then, we add the witness to the transaction at the
-		// appropriate txin index.
-		txn.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	// Finally, generate a witness for each output and attach it to the
-	// transaction.
-	for i, input := range inputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	return txn, nil
+	return createSweepTx(
+		spendableOutputs, pkScript, feePerWeight, b.cfg.Signer,
+	)
 }
 
 // RetributionStore provides an interface for managing a persistent map from
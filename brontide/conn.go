@@ -20,6 +20,10 @@ import (
 type Conn struct {
 	conn net.Conn
 
+	// remoteAddr is the address we dialed to establish the connection. It
+	// is nil for inbound connections.
+	remoteAddr net.Addr
+
 	noise *Machine
 
 	readBuf bytes.Buffer
@@ -31,17 +35,21 @@ var _ net.Conn = (*Conn)(nil)
 // Dial attempts to establish an encrypted+authenticated connection with the
 // remote peer located at address which has remotePub as its long-term static
 // public key. In the case of a handshake failure, the connection is closed and
-// a non-nil error is returned.
-func Dial(localPriv *btcec.PrivateKey, netAddr *lnwire.NetAddress) (*Conn, error) {
+// a non-nil error is returned. The underlying connection is established using
+// the passed dialer, which allows connections to be routed through a proxy.
+func Dial(localPriv SingleKeyECDH, netAddr *lnwire.NetAddress,
+	dialer func(string, string) (net.Conn, error)) (*Conn, error) {
+
 	ipAddr := netAddr.Address.String()
-	conn, err := net.Dial("tcp", ipAddr)
+	conn, err := dialer("tcp", ipAddr)
 	if err != nil {
 		return nil, err
 	}
 
 	b := &Conn{
-		conn:  conn,
-		noise: NewBrontideMachine(true, localPriv, netAddr.IdentityKey),
+		conn:       conn,
+		remoteAddr: netAddr.Address,
+		noise:      NewBrontideMachine(true, localPriv, netAddr.IdentityKey),
 	}
 
 	// Initiate the handshake by sending the first act to the receiver.
@@ -179,10 +187,17 @@ func (c *Conn) LocalAddr() net.Addr {
 	return c.conn.LocalAddr()
 }
 
-// RemoteAddr returns the remote network address.
+// RemoteAddr returns the remote network address. For outbound connections,
+// this is the address that was dialed rather than that of the underlying
+// connection, as the latter is the address of the proxy when connecting over
+// Tor.
 //
 // Part of the net.Conn interface.
 func (c *Conn) RemoteAddr() net.Addr {
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+
 	return c.conn.RemoteAddr()
 }
 
@@ -4,8 +4,6 @@ import (
 	"io"
 	"net"
 	"time"
-
-	"github.com/roasbeef/btcd/btcec"
 )
 
 // Listener is an implementation of a net.Conn which executes an authenticated
@@ -14,7 +12,7 @@ import (
 // details w.r.t the handshake and encryption scheme used within the
 // connection.
 type Listener struct {
-	localStatic *btcec.PrivateKey
+	localStatic SingleKeyECDH
 
 	tcp *net.TCPListener
 }
@@ -24,7 +22,7 @@ var _ net.Listener = (*Listener)(nil)
 
 // NewListener returns a new net.Listener which enforces the Brontide scheme
 // during both initial connection establishment and data transfer.
-func NewListener(localStatic *btcec.PrivateKey, listenAddr string) (*Listener,
+func NewListener(localStatic SingleKeyECDH, listenAddr string) (*Listener,
 	error) {
 	addr, err := net.ResolveTCPAddr("tcp", listenAddr)
 	if err != nil {
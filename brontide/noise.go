@@ -56,6 +56,47 @@ func ecdh(pub *btcec.PublicKey, priv *btcec.PrivateKey) []byte {
 	return h[:]
 }
 
+// SingleKeyECDH is an abstraction over a single static private key which is
+// able to carry out ECDH operations with it, without exposing the key itself.
+// This allows the static key of a brontide session to be held by an external
+// signer.
+type SingleKeyECDH interface {
+	// PubKey returns the public key of the static private key.
+	PubKey() *btcec.PublicKey
+
+	// ECDH performs an ECDH operation between pub and the static private
+	// key. The returned value is the sha256 of the compressed shared
+	// point.
+	ECDH(pub *btcec.PublicKey) ([32]byte, error)
+}
+
+// PrivKeyECDH is an implementation of the SingleKeyECDH interface which is
+// backed by a private key held in memory.
+type PrivKeyECDH struct {
+	// PrivKey is the private key that is used for the ECDH operations.
+	PrivKey *btcec.PrivateKey
+}
+
+// A compile time check to ensure PrivKeyECDH meets the SingleKeyECDH
+// interface.
+var _ SingleKeyECDH = (*PrivKeyECDH)(nil)
+
+// PubKey returns the public key of the private key.
+//
+// NOTE: Part of the SingleKeyECDH interface.
+func (p *PrivKeyECDH) PubKey() *btcec.PublicKey {
+	return p.PrivKey.PubKey()
+}
+
+// ECDH performs an ECDH operation between pub and the private key.
+//
+// NOTE: Part of the SingleKeyECDH interface.
+func (p *PrivKeyECDH) ECDH(pub *btcec.PublicKey) ([32]byte, error) {
+	var s [32]byte
+	copy(s[:], ecdh(pub, p.PrivKey))
+	return s, nil
+}
+
 // cipherState encapsulates the state for the AEAD which will be used to
 // encrypt+authenticate any payloads sent during the handshake, and messages
 // sent once the handshake has completed.
@@ -264,7 +305,7 @@ type handshakeState struct {
 
 	initiator bool
 
-	localStatic    *btcec.PrivateKey
+	localStatic    SingleKeyECDH
 	localEphemeral *btcec.PrivateKey
 
 	remoteStatic    *btcec.PublicKey
@@ -275,7 +316,7 @@ type handshakeState struct {
 // with the prologue and protocol name. If this is the responder's handshake
 // state, then the remotePub can be nil.
 func newHandshakeState(initiator bool, prologue []byte,
-	localPub *btcec.PrivateKey, remotePub *btcec.PublicKey) handshakeState {
+	localPub SingleKeyECDH, remotePub *btcec.PublicKey) handshakeState {
 
 	h := handshakeState{
 		initiator:    initiator,
@@ -353,7 +394,7 @@ type Machine struct {
 // string "bitcoin" as the prologue. The last parameter is a set of variadic
 // arguments for adding additional options to the brontide Machine
 // initialization.
-func NewBrontideMachine(initiator bool, localPub *btcec.PrivateKey,
+func NewBrontideMachine(initiator bool, localPub SingleKeyECDH,
 	remotePub *btcec.PublicKey, options ...func(*Machine)) *Machine {
 
 	handshake := newHandshakeState(initiator, []byte("lightning"), localPub,
@@ -471,8 +512,11 @@ func (b *Machine) RecvActOne(actOne [ActOneSize]byte) error {
 	b.mixHash(b.remoteEphemeral.SerializeCompressed())
 
 	// es
-	s := ecdh(b.remoteEphemeral, b.localStatic)
-	b.mixKey(s)
+	s, err := b.localStatic.ECDH(b.remoteEphemeral)
+	if err != nil {
+		return err
+	}
+	b.mixKey(s[:])
 
 	// If the initiator doesn't know our static key, then this operation
 	// will fail.
@@ -563,8 +607,11 @@ func (b *Machine) GenActThree() ([ActThreeSize]byte, error) {
 	ourPubkey := b.localStatic.PubKey().SerializeCompressed()
 	ciphertext := b.EncryptAndHash(ourPubkey)
 
-	s := ecdh(b.remoteEphemeral, b.localStatic)
-	b.mixKey(s)
+	s, err := b.localStatic.ECDH(b.remoteEphemeral)
+	if err != nil {
+		return actThree, err
+	}
+	b.mixKey(s[:])
 
 	authPayload := b.EncryptAndHash([]byte{})
 
@@ -30,7 +30,7 @@ func establishTestConnection() (net.Conn, net.Conn, func(), error) {
 	addr := "localhost:0"
 
 	// Our listener will be local, and the connection remote.
-	listener, err := NewListener(localPriv, addr)
+	listener, err := NewListener(&PrivKeyECDH{PrivKey: localPriv}, addr)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -47,7 +47,9 @@ func establishTestConnection() (net.Conn, net.Conn, func(), error) {
 	conErrChan := make(chan error, 1)
 	connChan := make(chan net.Conn, 1)
 	go func() {
-		conn, err := Dial(remotePriv, netAddr)
+		conn, err := Dial(
+			&PrivKeyECDH{PrivKey: remotePriv}, netAddr, net.Dial,
+		)
 
 		conErrChan <- err
 		connChan <- conn
@@ -276,10 +278,14 @@ func TestBolt0008TestVectors(t *testing.T) {
 
 	// Finally, we'll create both brontide state machines, so we can begin
 	// our test.
-	initiator := NewBrontideMachine(true, initiatorPriv, responderPub,
-		initiatorEphemeral)
-	responder := NewBrontideMachine(false, responderPriv, nil,
-		responderEphemeral)
+	initiator := NewBrontideMachine(
+		true, &PrivKeyECDH{PrivKey: initiatorPriv}, responderPub,
+		initiatorEphemeral,
+	)
+	responder := NewBrontideMachine(
+		false, &PrivKeyECDH{PrivKey: responderPriv}, nil,
+		responderEphemeral,
+	)
 
 	// We'll start with the initiator generating the initial payload for
 	// act one. This should consist of exactly 50 bytes. We'll assert that
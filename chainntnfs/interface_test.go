@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -24,6 +26,10 @@ import (
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 
+	// Required to auto-register the bitcoind backed ChainNotifier
+	// implementation.
+	_ "github.com/lightningnetwork/lnd/chainntnfs/bitcoindnotify"
+
 	// Required to auto-register the btcd backed ChainNotifier
 	// implementation.
 	_ "github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
@@ -43,7 +49,9 @@ var (
 		0x1e, 0xb, 0x4c, 0xfd, 0x9e, 0xc5, 0x8c, 0xe9,
 	}
 
-	netParams       = &chaincfg.SimNetParams
+	// netParams is the regtest network, as it's the only test network
+	// which is supported by btcd and bitcoind alike.
+	netParams       = &chaincfg.RegressionNetParams
 	privKey, pubKey = btcec.PrivKeyFromBytes(btcec.S256(), testPrivKey)
 	addrPk, _       = btcutil.NewAddressPubKey(pubKey.SerializeCompressed(),
 		netParams)
@@ -1206,6 +1214,60 @@ func TestInterfaces(t *testing.T) {
 
 		switch notifierType {
 
+		case "bitcoind":
+			// Start a bitcoind instance which syncs from the
+			// miner, and publishes its blocks and transactions
+			// over ZMQ.
+			tempBitcoindDir, err := ioutil.TempDir("", "bitcoind")
+			if err != nil {
+				t.Fatalf("unable to create temp dir: %v", err)
+			}
+			zmqPath := "ipc:///" + tempBitcoindDir + "/weks.socket"
+			rpcPort := rand.Int()%(65536-1024) + 1024
+			bitcoind := exec.Command(
+				"bitcoind",
+				"-datadir="+tempBitcoindDir,
+				"-regtest",
+				"-connect="+p2pAddr,
+				"-txindex",
+				"-rpcuser=weks",
+				"-rpcpassword=weks",
+				fmt.Sprintf("-rpcport=%d", rpcPort),
+				"-disablewallet",
+				"-zmqpubrawblock="+zmqPath,
+				"-zmqpubrawtx="+zmqPath,
+			)
+			if err := bitcoind.Start(); err != nil {
+				os.RemoveAll(tempBitcoindDir)
+				t.Fatalf("couldn't start bitcoind: %v", err)
+			}
+			cleanUp = func() {
+				bitcoind.Process.Kill()
+				bitcoind.Wait()
+				os.RemoveAll(tempBitcoindDir)
+			}
+
+			// Wait for the bitcoind instance to start up.
+			time.Sleep(time.Second)
+
+			notifier, err = notifierDriver.New(
+				&rpcclient.ConnConfig{
+					Host: fmt.Sprintf(
+						"127.0.0.1:%d", rpcPort,
+					),
+					User:                 "weks",
+					Pass:                 "weks",
+					DisableAutoReconnect: false,
+					DisableConnectOnNew:  true,
+					DisableTLS:           true,
+					HTTPPostMode:         true,
+				}, zmqPath, *netParams,
+			)
+			if err != nil {
+				t.Fatalf("unable to create %v notifier: %v",
+					notifierType, err)
+			}
+
 		case "btcd":
 			notifier, err = notifierDriver.New(&rpcConfig)
 			if err != nil {
@@ -6,19 +6,23 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chainntnfs/bitcoindnotify"
 	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
 	"github.com/lightningnetwork/lnd/chainntnfs/neutrinonotify"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/cipherseed"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/remotesigner"
 	"github.com/lightningnetwork/lnd/routing/chainview"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/rpcclient"
@@ -27,6 +31,11 @@ import (
 	"github.com/roasbeef/btcwallet/walletdb"
 )
 
+// defaultRecoveryWindow is the number of addresses past the last used address
+// of each branch that the wallet will scan for when it's restored from a
+// seed. This must be large enough to account for any gaps in address usage.
+const defaultRecoveryWindow = 250
+
 // defaultBitcoinForwardingPolicy is the default forwarding policy used for
 // Bitcoin channels.
 var defaultBitcoinForwardingPolicy = htlcswitch.ForwardingPolicy{
@@ -97,14 +106,22 @@ type chainControl struct {
 	wallet *lnwallet.LightningWallet
 
 	routingPolicy htlcswitch.ForwardingPolicy
+
+	// remoteSigner is the client of the remote signer that holds all
+	// private keys of the node. It's only non-nil if the node is
+	// watch-only.
+	remoteSigner *remotesigner.Client
 }
 
 // newChainControlFromConfig attempts to create a chainControl instance
-// according to the parameters in the passed lnd configuration. Currently two
+// according to the parameters in the passed lnd configuration. Currently three
 // branches of chainControl instances exist: one backed by a running btcd
-// full-node, and the other backed by a running neutrino light client instance.
+// full-node, another backed by a running bitcoind full-node, and the other
+// backed by a running neutrino light client instance. If a wallet seed is
+// specified, then a newly created wallet will derive all of its keys from it.
 func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
-	privateWalletPw, publicWalletPw []byte) (*chainControl, func(), error) {
+	privateWalletPw, publicWalletPw []byte,
+	walletSeed *cipherseed.CipherSeed) (*chainControl, func(), error) {
 
 	// Set the RPC config from the "home" chain. Multi-chain isn't yet
 	// active, so we'll restrict usage to a particular chain for now.
@@ -139,6 +156,17 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 		DataDir:      homeChainConfig.ChainDir,
 		NetParams:    activeNetParams.Params,
 		FeeEstimator: cc.feeEstimator,
+		WatchOnly:    cfg.RemoteSigner.Active,
+	}
+
+	// If the wallet is being created from a cipher seed, then we'll use
+	// its entropy as the HD seed of the wallet. As the seed may be one
+	// that's being restored, we'll also rescan from its birthday, looking
+	// ahead for any addresses that have been used on-chain.
+	if walletSeed != nil {
+		walletConfig.HdSeed = walletSeed.Entropy[:]
+		walletConfig.Birthday = walletSeed.BirthdayTime()
+		walletConfig.RecoveryWindow = defaultRecoveryWindow
 	}
 
 	var (
@@ -196,6 +224,98 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 		cleanUp = func() {
 			defer nodeDatabase.Close()
 		}
+	} else if cfg.BitcoindMode.Active {
+		// Otherwise, if bitcoind mode is active, then we'll be
+		// speaking to bitcoind over JSON-RPC, with new blocks and
+		// transactions being delivered over ZMQ.
+		bitcoindMode := cfg.BitcoindMode
+
+		// If the specified host for the bitcoind RPC server already
+		// has a port specified, then we use that directly. Otherwise,
+		// we assume the default port according to the selected chain
+		// parameters.
+		var bitcoindHost string
+		if strings.Contains(bitcoindMode.RPCHost, ":") {
+			bitcoindHost = bitcoindMode.RPCHost
+		} else {
+			// The RPC ports specified in chainparams.go are those
+			// of btcd, which sits two ports above bitcoind so the
+			// two can run side by side. So we'll map the port
+			// back to the one bitcoind uses by default.
+			rpcPort, err := strconv.Atoi(activeNetParams.rpcPort)
+			if err != nil {
+				return nil, nil, err
+			}
+			rpcPort -= 2
+			bitcoindHost = fmt.Sprintf("%v:%d",
+				bitcoindMode.RPCHost, rpcPort)
+		}
+
+		bitcoindUser := bitcoindMode.RPCUser
+		bitcoindPass := bitcoindMode.RPCPass
+
+		// As bitcoind doesn't support websockets, all of our RPC
+		// requests will be made using HTTP POST.
+		rpcConfig := &rpcclient.ConnConfig{
+			Host:                 bitcoindHost,
+			User:                 bitcoindUser,
+			Pass:                 bitcoindPass,
+			DisableConnectOnNew:  true,
+			DisableAutoReconnect: false,
+			DisableTLS:           true,
+			HTTPPostMode:         true,
+		}
+		cc.chainNotifier, err = bitcoindnotify.New(
+			rpcConfig, bitcoindMode.ZMQPath, *activeNetParams.Params,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Next, we'll create an instance of the bitcoind chain view to
+		// be used within the routing layer.
+		cc.chainView, err = chainview.NewBitcoindFilteredChainView(
+			*rpcConfig, bitcoindMode.ZMQPath,
+			*activeNetParams.Params,
+		)
+		if err != nil {
+			srvrLog.Errorf("unable to create chain view: %v", err)
+			return nil, nil, err
+		}
+
+		// Create a special bitcoind client which will be used by the
+		// wallet for notifications, calls, etc.
+		bitcoindConn, err := chain.NewBitcoindClient(
+			activeNetParams.Params, bitcoindHost, bitcoindUser,
+			bitcoindPass, bitcoindMode.ZMQPath,
+			time.Millisecond*100,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		walletConfig.ChainSource = bitcoindConn
+
+		// If we're not in regtest mode, then we'll attempt to use a
+		// proper fee estimator for testnet.
+		if !cfg.Bitcoin.RegTest {
+			ltndLog.Infof("Initializing bitcoind backed fee estimator")
+
+			// Finally, we'll re-initialize the fee estimator, as
+			// if we're using bitcoind as a backend, then we can
+			// use live fee estimates, rather than a statically
+			// coded value.
+			fallBackFeeRate := btcutil.Amount(25)
+			cc.feeEstimator, err = lnwallet.NewBitcoindFeeEstimator(
+				*rpcConfig, fallBackFeeRate,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := cc.feeEstimator.Start(); err != nil {
+				return nil, nil, err
+			}
+		}
 	} else {
 		// Otherwise, we'll be speaking directly via RPC to a node.
 		//
@@ -304,6 +424,41 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 	cc.signer = wc
 	cc.chainIO = wc
 
+	// If we're a watch-only node, then all of our keys are held by a
+	// remote signer, so we'll derive them, and sign with them, through it
+	// instead. This includes the inputs of our on-chain wallet, which only
+	// holds the public keys exported by the signer.
+	var keyRing lnwallet.KeyRing
+	if cfg.RemoteSigner.Active {
+		signerClient, err := remotesigner.NewClient(
+			&remotesigner.ClientConfig{
+				RPCHost:      cfg.RemoteSigner.RPCHost,
+				TLSCertPath:  cfg.RemoteSigner.TLSCertPath,
+				MacaroonPath: cfg.RemoteSigner.MacaroonPath,
+				Timeout:      cfg.RemoteSigner.Timeout,
+			},
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ltndLog.Infof("Using remote signer at %v",
+			cfg.RemoteSigner.RPCHost)
+
+		cc.msgSigner = signerClient
+		cc.signer = signerClient
+		cc.remoteSigner = signerClient
+		keyRing = signerClient
+
+		chainCleanUp := cleanUp
+		cleanUp = func() {
+			signerClient.Close()
+			if chainCleanUp != nil {
+				chainCleanUp()
+			}
+		}
+	}
+
 	// Create, and start the lnwallet, which handles the core payment
 	// channel logic, and exposes control via proxy state machines.
 	walletCfg := lnwallet.Config{
@@ -311,6 +466,8 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 		Notifier:           cc.chainNotifier,
 		WalletController:   wc,
 		Signer:             cc.signer,
+		KeyRing:            keyRing,
+		WatchOnly:          cfg.RemoteSigner.Active,
 		FeeEstimator:       cc.feeEstimator,
 		ChainIO:            cc.chainIO,
 		DefaultConstraints: defaultChannelConstraints,
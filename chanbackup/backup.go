@@ -0,0 +1,48 @@
+package chanbackup
+
+import (
+	"net"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// LiveChannelSource is an interface that allows us to query for the set of
+// live channels. A live channel is one that is open, and has not had a
+// commitment transaction broadcast.
+type LiveChannelSource interface {
+	// FetchAllChannels returns all known live channels, including those
+	// that are still pending confirmation.
+	FetchAllChannels() ([]*channeldb.OpenChannel, error)
+
+	// AddrsForNode returns all known addresses for the target node public
+	// key.
+	AddrsForNode(nodePub *btcec.PublicKey) ([]net.Addr, error)
+}
+
+// FetchStaticChanBackups will return a plaintext static channel back up for
+// all known active/open channels within the passed channel source.
+func FetchStaticChanBackups(chanSource LiveChannelSource) ([]Single, error) {
+	// First, we'll query the backup source for information concerning all
+	// currently open and available channels.
+	openChans, err := chanSource.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// Now that we have all the channels, we'll use the chanSource to
+	// obtain any auxiliary information we need to craft a backup for each
+	// channel.
+	staticChanBackups := make([]Single, 0, len(openChans))
+	for _, openChan := range openChans {
+		nodeAddrs, err := chanSource.AddrsForNode(openChan.IdentityPub)
+		if err != nil {
+			return nil, err
+		}
+
+		chanBackup := NewSingle(openChan, nodeAddrs)
+		staticChanBackups = append(staticChanBackups, chanBackup)
+	}
+
+	return staticChanBackups, nil
+}
@@ -0,0 +1,121 @@
+package chanbackup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// DefaultBackupFileName is the default name of the auto updated static
+	// channel backup file.
+	DefaultBackupFileName = "channel.backup"
+
+	// DefaultTempBackupFileName is the default name of the temporary SCB
+	// file that we'll use to atomically update the primary back up file
+	// when new channels are detected.
+	DefaultTempBackupFileName = "temp-dont-use.backup"
+)
+
+// MultiFile represents a file on disk that a caller can use to read the packed
+// multi backup into an unpacked one, and also atomically update the contents
+// on disk once new channels have been opened, and old ones closed. This struct
+// relies on an atomic file rename property which most widely used file systems
+// have.
+type MultiFile struct {
+	// fileName is the file name of the main back up file.
+	fileName string
+
+	// tempFileName is the name of the file that we'll use to stage a new
+	// packed multi-chan backup, and the rename to the main back up file.
+	tempFileName string
+}
+
+// NewMultiFile creates a new multi-file instance at the target location on the
+// file system.
+func NewMultiFile(fileName string) *MultiFile {
+	// We'll place our temporary backup file in the very same directory
+	// as the main backup file.
+	backupFileDir := filepath.Dir(fileName)
+	tempFileName := filepath.Join(
+		backupFileDir, DefaultTempBackupFileName,
+	)
+
+	return &MultiFile{
+		fileName:     fileName,
+		tempFileName: tempFileName,
+	}
+}
+
+// UpdateAndSwap will attempt to write a new temporary backup file to disk with
+// the newBackup encoded, then atomically swap (via rename) the old file for
+// the new file by updating the name of the new file to the old.
+func (b *MultiFile) UpdateAndSwap(newBackup PackedMulti) error {
+	// If the main backup file isn't set, then we can't proceed.
+	if b.fileName == "" {
+		return fmt.Errorf("main backup file not set")
+	}
+
+	log.Infof("Updating backup file at %v", b.fileName)
+
+	// If the old back up file still exists, then we'll delete it before
+	// proceeding.
+	if _, err := os.Stat(b.tempFileName); err == nil {
+		log.Infof("Found old temp backup @ %v, removing before swap",
+			b.tempFileName)
+
+		err = os.Remove(b.tempFileName)
+		if err != nil {
+			return fmt.Errorf("unable to remove temp "+
+				"backup file: %v", err)
+		}
+	}
+
+	// Now that we know the staging area is clear, we'll create the new
+	// temporary back up file.
+	tempFile, err := os.Create(b.tempFileName)
+	if err != nil {
+		return err
+	}
+
+	// With the file created, we'll write the new packed multi backup.
+	if _, err := tempFile.Write([]byte(newBackup)); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	// Before we rename the swap (atomic name swap), we'll make sure to
+	// flush to disk, as otherwise the rename may be persisted before the
+	// contents of the file.
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	// Finally, we'll attempt to atomically rename the temporary file to
+	// the main back up file. If this succeeds, then we'll only have a
+	// single file on disk once this method exits.
+	return os.Rename(b.tempFileName, b.fileName)
+}
+
+// ExtractMulti attempts to extract the packed multi backup we currently point
+// to into an unpacked version. This method will fail if no backup file
+// currently exists at the specified location.
+func (b *MultiFile) ExtractMulti(nodeKey *btcec.PrivateKey) (*Multi, error) {
+	// We'll first open up the backup file.
+	packedMulti, err := ioutil.ReadFile(b.fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, we'll attempt to unpack the file and return the unpacked
+	// version to the caller.
+	packed := PackedMulti(packedMulti)
+	return packed.Unpack(nodeKey)
+}
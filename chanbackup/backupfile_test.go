@@ -0,0 +1,58 @@
+package chanbackup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestUpdateAndSwap tests that we're able to properly swap out old backups on
+// disk with new ones, leaving no temporary file behind.
+func TestUpdateAndSwap(t *testing.T) {
+	t.Parallel()
+
+	tempTestDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("unable to make temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempTestDir)
+
+	fileName := filepath.Join(tempTestDir, DefaultBackupFileName)
+	tempFileName := filepath.Join(tempTestDir, DefaultTempBackupFileName)
+
+	// If the main backup file name isn't set, then we should be unable to
+	// swap in a new backup.
+	if err := (&MultiFile{}).UpdateAndSwap(PackedMulti(nil)); err == nil {
+		t.Fatalf("swapped backup without file name")
+	}
+
+	// A temp file left over from a prior crash shouldn't prevent the swap.
+	err = ioutil.WriteFile(tempFileName, []byte("stale"), 0600)
+	if err != nil {
+		t.Fatalf("unable to write temp file: %v", err)
+	}
+
+	backupFile := NewMultiFile(fileName)
+	for _, backup := range [][]byte{{1, 2, 3}, {4, 5, 6, 7}} {
+		err := backupFile.UpdateAndSwap(PackedMulti(backup))
+		if err != nil {
+			t.Fatalf("unable to update and swap: %v", err)
+		}
+
+		// The main backup file should now contain the new backup, and
+		// the temporary file should no longer exist.
+		diskBackup, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			t.Fatalf("unable to read backup file: %v", err)
+		}
+		if !bytes.Equal(diskBackup, backup) {
+			t.Fatalf("backup mismatch: expected %x, got %x",
+				backup, diskBackup)
+		}
+		if _, err := os.Stat(tempFileName); !os.IsNotExist(err) {
+			t.Fatalf("temp file still exists: %v", err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package chanbackup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/roasbeef/btcd/btcec"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// baseEncryptionKeyLoc is a domain separator mixed into the node's identity
+// key in order to derive the key used to encrypt the backups. This ensures
+// the encryption key is unique to its purpose.
+var baseEncryptionKeyLoc = []byte("chanbackup")
+
+var (
+	// ErrCiphertextTooShort is returned when the ciphertext of a backup is
+	// too small to contain the nonce and authentication tag.
+	ErrCiphertextTooShort = errors.New("ciphertext too short")
+)
+
+// genEncryptionKey derives the key that we'll use to encrypt all of our
+// static channel backups. The key is derived from our node's identity key, as
+// it's deterministically derived from the wallet's seed, allowing a user that
+// has lost their channel database to decrypt their backups once they've
+// restored their wallet.
+func genEncryptionKey(nodeKey *btcec.PrivateKey) [32]byte {
+	h := sha256.New()
+	h.Write(baseEncryptionKeyLoc)
+	h.Write(nodeKey.Serialize())
+
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// encryptPayloadToWriter attempts to write the set of bytes contained within
+// the passed bytes.Buffer into the passed io.Writer in an encrypted form. We
+// use a chachapoly AEAD instance with a randomized nonce that's
+// pre-pended to the final payload and used as associated data in the AEAD.
+func encryptPayloadToWriter(payload bytes.Buffer, w io.Writer,
+	nodeKey *btcec.PrivateKey) error {
+
+	encryptionKey := genEncryptionKey(nodeKey)
+	cipher, err := chacha20poly1305.New(encryptionKey[:])
+	if err != nil {
+		return err
+	}
+
+	var nonce [chacha20poly1305.NonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return err
+	}
+
+	ciphertext := cipher.Seal(nil, nonce[:], payload.Bytes(), nonce[:])
+
+	if _, err := w.Write(nonce[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(ciphertext); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// decryptPayloadFromReader attempts to decrypt the encrypted bytes within the
+// passed io.Reader instance using the key derived from the passed node key.
+func decryptPayloadFromReader(payload io.Reader,
+	nodeKey *btcec.PrivateKey) ([]byte, error) {
+
+	// We'll read out the entire blob as we need to isolate the nonce
+	// from the rest of the ciphertext.
+	packedBackup, err := ioutil.ReadAll(payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(packedBackup) < chacha20poly1305.NonceSize+
+		chacha20poly1305.Overhead {
+
+		return nil, ErrCiphertextTooShort
+	}
+
+	encryptionKey := genEncryptionKey(nodeKey)
+	cipher, err := chacha20poly1305.New(encryptionKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := packedBackup[:chacha20poly1305.NonceSize]
+	ciphertext := packedBackup[chacha20poly1305.NonceSize:]
+
+	return cipher.Open(nil, nonce, ciphertext, nonce)
+}
@@ -0,0 +1,172 @@
+package chanbackup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// byteOrder is the byte order used for all integers within a serialized
+// backup.
+var byteOrder = binary.BigEndian
+
+// MultiBackupVersion denotes the version of the multi channel static channel
+// backup. Based on this version, we know how to encode/decode packed/unpacked
+// versions of multi backups.
+type MultiBackupVersion byte
+
+const (
+	// DefaultMultiVersion is the default version of the multi channel
+	// backup. The serialized format for this version is simply: version ||
+	// numBackups || SCBs...
+	DefaultMultiVersion = 0
+)
+
+// Multi is a form of static channel backup that is amenable to being
+// serialized in a single file. Rather than a series of ciphertexts, a
+// multi-chan backup is a single ciphertext of all static channel backups
+// concatenated. This form factor gives users a single blob that they can use
+// to safely copy/obtain at anytime to backup their channels.
+type Multi struct {
+	// Version is the version that should be observed when attempting to
+	// pack the multi backup.
+	Version MultiBackupVersion
+
+	// StaticBackups is the set of single channel backups that this multi
+	// backup is comprised of.
+	StaticBackups []Single
+}
+
+// PackToWriter packs (encrypts+serializes) the target set of static channel
+// backups into a single AEAD ciphertext into the passed io.Writer. This is
+// the opposite of UnpackFromReader. The plaintext form of a multi-chan
+// backup is the following: a version byte, a 4 byte integer denoting the
+// number of static channel backups serialized, and a series of serialized
+// static channel backups concatenated. To pack this payload, we then apply
+// our chacha20 AEAD to the entire payload, using the 12-byte nonce as
+// associated data.
+func (m Multi) PackToWriter(w io.Writer, nodeKey *btcec.PrivateKey) error {
+	// The only version that we know how to pack atm is version 0. Attempts
+	// to pack any other version will result in an error.
+	switch m.Version {
+	case DefaultMultiVersion:
+		break
+
+	default:
+		return fmt.Errorf("unable to pack unknown multi-version "+
+			"of %v", m.Version)
+	}
+
+	var multiBackupBuffer bytes.Buffer
+
+	// First, we'll write out the version of this multi channel backup.
+	err := multiBackupBuffer.WriteByte(byte(m.Version))
+	if err != nil {
+		return err
+	}
+
+	// Now that we've written out the version of this multi-pack format,
+	// we'll now write the total number of backups to expect after this
+	// point.
+	numBackups := uint32(len(m.StaticBackups))
+	err = binary.Write(&multiBackupBuffer, byteOrder, numBackups)
+	if err != nil {
+		return err
+	}
+
+	// Next, we'll serialize the raw plaintext version of each of the
+	// backup into the intermediate buffer.
+	for _, chanBackup := range m.StaticBackups {
+		err := chanBackup.Serialize(&multiBackupBuffer)
+		if err != nil {
+			return fmt.Errorf("unable to serialize backup "+
+				"for %v: %v", chanBackup.FundingOutpoint, err)
+		}
+	}
+
+	// With the plaintext multi backup assembled, we'll now encrypt it
+	// directly to the passed writer.
+	return encryptPayloadToWriter(multiBackupBuffer, w, nodeKey)
+}
+
+// UnpackFromReader attempts to unpack (decrypt+deserialize) a packed
+// multi-chan backup from the passed io.Reader. If we're unable to decrypt
+// any portion of the multi-chan backup, an error will be returned.
+func (m *Multi) UnpackFromReader(r io.Reader,
+	nodeKey *btcec.PrivateKey) error {
+
+	// We'll attempt to read the entire packed backup, and also decrypt it
+	// using the passed node key.
+	plaintextBackup, err := decryptPayloadFromReader(r, nodeKey)
+	if err != nil {
+		return err
+	}
+	backupReader := bytes.NewReader(plaintextBackup)
+
+	// Now that we've decrypted the payload, we'll parse out the version
+	// and number of backups that we've packed into the multi backup.
+	multiVersion, err := backupReader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	// With the version obtained, we'll ensure that we understand the
+	// version.
+	switch MultiBackupVersion(multiVersion) {
+	case DefaultMultiVersion:
+		break
+
+	default:
+		return fmt.Errorf("unable to unpack unknown multi-version "+
+			"of %v", multiVersion)
+	}
+
+	var numBackups uint32
+	err = binary.Read(backupReader, byteOrder, &numBackups)
+	if err != nil {
+		return err
+	}
+
+	// We'll continue to parse out each backup until we've read all that
+	// was indicated from the length prefix.
+	var backups []Single
+	for ; numBackups != 0; numBackups-- {
+		// Attempt to parse out the next static channel backup, if it's
+		// been malformed, then we'll return with an error
+		var chanBackup Single
+		err := chanBackup.Deserialize(backupReader)
+		if err != nil {
+			return err
+		}
+
+		// Collect the next valid chan backup into the main multi
+		// backup slice.
+		backups = append(backups, chanBackup)
+	}
+
+	m.Version = MultiBackupVersion(multiVersion)
+	m.StaticBackups = backups
+
+	return nil
+}
+
+// PackedMulti represents a multi-channel backup that has been packed (and
+// encrypted) into a single byte slice.
+type PackedMulti []byte
+
+// Unpack attempts to unpack (decrypt+deserialize) the target packed
+// multi-channel back up. If we're unable to fully unpack this backup, then an
+// error will be returned.
+func (p *PackedMulti) Unpack(nodeKey *btcec.PrivateKey) (*Multi, error) {
+	var m Multi
+
+	packed := bytes.NewReader(*p)
+	if err := (&m).UnpackFromReader(packed, nodeKey); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,64 @@
+package chanbackup
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestMultiPackUnpack tests that a multi backup is able to be packed, then
+// unpacked using the same node key, while a different node key is unable to
+// decrypt it.
+func TestMultiPackUnpack(t *testing.T) {
+	t.Parallel()
+
+	var multi Multi
+	numSingles := 10
+	for i := 0; i < numSingles; i++ {
+		channel, err := genRandomOpenChannelShell()
+		if err != nil {
+			t.Fatalf("unable to gen channel: %v", err)
+		}
+
+		single := NewSingle(channel, []net.Addr{addr1, addr2})
+		multi.StaticBackups = append(multi.StaticBackups, single)
+	}
+
+	nodeKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to gen node key: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := multi.PackToWriter(&b, nodeKey); err != nil {
+		t.Fatalf("unable to pack multi: %v", err)
+	}
+	packed := PackedMulti(b.Bytes())
+
+	unpacked, err := packed.Unpack(nodeKey)
+	if err != nil {
+		t.Fatalf("unable to unpack multi: %v", err)
+	}
+	if !reflect.DeepEqual(&multi, unpacked) {
+		t.Fatalf("multi backups don't match")
+	}
+
+	// Attempting to unpack the backup with a different node key should
+	// fail, as it'll be unable to decrypt the backup.
+	otherKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to gen node key: %v", err)
+	}
+	if _, err := packed.Unpack(otherKey); err == nil {
+		t.Fatalf("unpacked multi with incorrect key")
+	}
+
+	// Similarly, a backup that's been modified shouldn't be unpacked.
+	packed[len(packed)-1] ^= 1
+	if _, err := packed.Unpack(nodeKey); err == nil {
+		t.Fatalf("unpacked modified multi")
+	}
+}
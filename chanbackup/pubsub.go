@@ -0,0 +1,154 @@
+package chanbackup
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// Swapper is an interface that allows the SubSwapper to update the main
+// multi backup location once it learns of new channels or that prior
+// channels have been closed.
+type Swapper interface {
+	// UpdateAndSwap attempts to atomically update the main multi back up
+	// file location with the new fully packed multi-channel backup.
+	UpdateAndSwap(newBackup PackedMulti) error
+}
+
+// SubSwapper subscribes to changes in the set of live channels, and
+// atomically swaps out the multi channel backup on disk each time a channel
+// is opened or closed. As the full backup is recreated from the channel
+// source on each update, the backup on disk always mirrors the set of
+// channels we know of.
+type SubSwapper struct {
+	started uint32
+	stopped uint32
+
+	// chanSource is the source of the channels that we'll back up.
+	chanSource LiveChannelSource
+
+	// nodeKey is the key used to encrypt the backup.
+	nodeKey *btcec.PrivateKey
+
+	// Swapper is the primary interface the sub-swapper will use to update
+	// the main backup location.
+	Swapper
+
+	// chanEvents is signalled each time the set of live channels changes.
+	// It has a buffer of one, allowing several changes to be coalesced
+	// into a single update.
+	chanEvents chan struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSubSwapper creates a new instance of the SubSwapper given the channel
+// source to back up, the node key used to encrypt the backup, and the
+// backend swapper instance that will be used to atomically update the backup
+// location.
+func NewSubSwapper(chanSource LiveChannelSource, nodeKey *btcec.PrivateKey,
+	backupSwapper Swapper) *SubSwapper {
+
+	return &SubSwapper{
+		chanSource: chanSource,
+		nodeKey:    nodeKey,
+		Swapper:    backupSwapper,
+		chanEvents: make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start starts the chanbackup.SubSwapper. Before the goroutine which watches
+// for channel changes is launched, the backup on disk is brought up to date.
+func (s *SubSwapper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting chanbackup.SubSwapper")
+
+	// As channels may have been opened or closed while we were offline,
+	// we'll write out a fresh backup before we start to watch for
+	// changes.
+	if err := s.updateBackup(); err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go s.backupUpdater()
+
+	return nil
+}
+
+// Stop signals the SubSwapper to begin a graceful shutdown.
+func (s *SubSwapper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping chanbackup.SubSwapper")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// ChannelsChanged signals the SubSwapper that a channel has either been
+// opened or closed, and that the backup on disk should be updated. This
+// method never blocks.
+func (s *SubSwapper) ChannelsChanged() {
+	select {
+	case s.chanEvents <- struct{}{}:
+	default:
+	}
+}
+
+// updateBackup fetches a static backup for each of the live channels, then
+// packs and swaps the new multi backup into place.
+func (s *SubSwapper) updateBackup() error {
+	backups, err := FetchStaticChanBackups(s.chanSource)
+	if err != nil {
+		return err
+	}
+
+	newMulti := Multi{
+		Version:       DefaultMultiVersion,
+		StaticBackups: backups,
+	}
+
+	var b bytes.Buffer
+	if err := newMulti.PackToWriter(&b, s.nodeKey); err != nil {
+		return err
+	}
+
+	log.Debugf("Updating on-disk multi SCB backup: num_chans=%v",
+		len(backups))
+
+	return s.Swapper.UpdateAndSwap(PackedMulti(b.Bytes()))
+}
+
+// backupUpdater is the primary goroutine of the SubSwapper. It updates the
+// backup on disk each time it's signalled that the set of live channels has
+// changed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *SubSwapper) backupUpdater() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.chanEvents:
+			if err := s.updateBackup(); err != nil {
+				log.Errorf("unable to update channel backup: "+
+					"%v", err)
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
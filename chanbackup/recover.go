@@ -0,0 +1,81 @@
+package chanbackup
+
+import (
+	"net"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// ChannelRestorer is an interface that allows the Recover method to hand off
+// the set of single channel backups to be stored persistently. Each stored
+// backup should contain all the information needed to execute the recovery
+// protocol once the channel peer is connected to.
+type ChannelRestorer interface {
+	// RestoreChansFromSingles persistently stores the set of single
+	// channel backups. Once stored, we'll be able to connect to the
+	// channel peer and execute the recovery protocol.
+	RestoreChansFromSingles(...Single) error
+}
+
+// PeerConnector is an interface that allows the Recover method to connect to
+// the target node given the set of possible addresses.
+type PeerConnector interface {
+	// ConnectPeer attempts to connect to the target node at the set of
+	// available addresses. Once this method returns with a nil error,
+	// the connector should continue to attempt to connect to the target
+	// peer in the background as a persistent attempt.
+	ConnectPeer(node *btcec.PublicKey, addrs []net.Addr) error
+}
+
+// Recover attempts to recover the static channel state from a set of static
+// channel backups. Each backup is first persisted by the restorer. A restored
+// channel can't be used to operate the channel as normal, but instead is
+// meant to be used to enter the recovery phase, and recover the settled funds
+// within the channel. Afterwards, we'll connect to each channel peer in order
+// to initiate the recovery protocol.
+func Recover(backups []Single, restorer ChannelRestorer,
+	peerConnector PeerConnector) error {
+
+	for _, backup := range backups {
+		log.Infof("Restoring ChannelPoint(%v) to disk",
+			backup.FundingOutpoint)
+
+		err := restorer.RestoreChansFromSingles(backup)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Attempting to connect to node=%x (addrs=%v) to "+
+			"restore ChannelPoint(%v)",
+			backup.RemoteNodePub.SerializeCompressed(),
+			backup.Addresses, backup.FundingOutpoint)
+
+		err = peerConnector.ConnectPeer(
+			backup.RemoteNodePub, backup.Addresses,
+		)
+		if err != nil {
+			return err
+		}
+
+		// TODO(roasbeef): to handle case where node has changed addrs,
+		// need to subscribe to new updates for target node pub to
+		// attempt to connect to other addrs
+	}
+
+	return nil
+}
+
+// UnpackAndRecoverMulti is a one-shot method, that given a set of packed
+// multi-channel backups, will restore the channel states. This method will
+// also attempt to connect to the channel peers in order to initiate the
+// recovery protocol.
+func UnpackAndRecoverMulti(packedMulti PackedMulti, nodeKey *btcec.PrivateKey,
+	restorer ChannelRestorer, peerConnector PeerConnector) error {
+
+	chanBackups, err := packedMulti.Unpack(nodeKey)
+	if err != nil {
+		return err
+	}
+
+	return Recover(chanBackups.StaticBackups, restorer, peerConnector)
+}
@@ -0,0 +1,333 @@
+package chanbackup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// SingleBackupVersion denotes the version of the single static channel backup.
+// Based on this version, we know how to pack/unpack serialized versions of the
+// backup.
+type SingleBackupVersion byte
+
+const (
+	// DefaultSingleVersion is the default version of the single channel
+	// backup. The serialized version of this static channel backup is
+	// simply: version || SCB. Where SCB is the known format of the
+	// version.
+	DefaultSingleVersion = 0
+)
+
+// maxAddrLen is the maximum length of a single serialized address within a
+// static channel backup.
+const maxAddrLen = 255
+
+// Single is a static description of an existing channel that can be used for
+// the purposes of backing up. The fields in this struct allow a node to
+// recover the settled funds within a channel in the case of partial or
+// complete data loss. We provide the network address that we last used to
+// connect to the peer as well, in case the node stops advertising the IP on
+// the network for whatever reason.
+type Single struct {
+	// Version is the version that should be observed when attempting to
+	// pack the single backup.
+	Version SingleBackupVersion
+
+	// ChainHash is a hash which represents the blockchain that this
+	// channel will be opened within. This value is typically the genesis
+	// hash. In the case that the original chain went through a contentious
+	// hard-fork, then this value will be tweaked using the unique fork
+	// point on each branch.
+	ChainHash chainhash.Hash
+
+	// FundingOutpoint is the outpoint of the final funding transaction.
+	// This value uniquely and globally identities the channel within the
+	// target blockchain as specified by the chain hash parameter.
+	FundingOutpoint wire.OutPoint
+
+	// ShortChannelID encodes the exact location in the chain in which the
+	// channel was initially confirmed. This includes: the block height,
+	// transaction index, and the output within the target transaction. If
+	// the channel was still pending when the backup was made, then this
+	// will be zero.
+	ShortChannelID lnwire.ShortChannelID
+
+	// RemoteNodePub is the identity public key of the remote node this
+	// channel has been established with.
+	RemoteNodePub *btcec.PublicKey
+
+	// Addresses is a list of IP address in which either we were able to
+	// reach the node over in the past, OR we received an incoming
+	// authenticated connection for the stored identity public key.
+	Addresses []net.Addr
+
+	// Capacity is the size of the original channel.
+	Capacity btcutil.Amount
+
+	// LocalChanCfg is our local channel configuration. It contains all the
+	// information we need to re-derive the keys we used within the
+	// channel. Most importantly, it allows to derive the base public key
+	// that's used to deposit our funds into the commitment transaction of
+	// the remote party.
+	LocalChanCfg channeldb.ChannelConfig
+}
+
+// NewSingle creates a new static channel backup based on an existing open
+// channel. We also pass in the set of addresses that we used in the past to
+// connect to the channel peer.
+func NewSingle(channel *channeldb.OpenChannel,
+	nodeAddrs []net.Addr) Single {
+
+	return Single{
+		Version:         DefaultSingleVersion,
+		ChainHash:       channel.ChainHash,
+		FundingOutpoint: channel.FundingOutpoint,
+		ShortChannelID:  channel.ShortChanID,
+		RemoteNodePub:   channel.IdentityPub,
+		Addresses:       nodeAddrs,
+		Capacity:        channel.Capacity,
+		LocalChanCfg:    channel.LocalChanCfg,
+	}
+}
+
+// Serialize attempts to write out the serialized version of the target
+// StaticChannelBackup into the passed io.Writer.
+func (s *Single) Serialize(w io.Writer) error {
+	// Check to ensure that we'll only attempt to serialize a version that
+	// we're aware of.
+	switch s.Version {
+	case DefaultSingleVersion:
+	default:
+		return fmt.Errorf("unable to serialize w/ unknown "+
+			"version: %v", s.Version)
+	}
+
+	// The number of addresses is encoded as a single byte, so we'll
+	// ensure we don't overflow it.
+	if len(s.Addresses) > 255 {
+		return fmt.Errorf("too many addresses: %v", len(s.Addresses))
+	}
+
+	// First we gather the body of the backup, so we're able to prefix it
+	// with its length. This allows future versions to be skipped over by
+	// older nodes.
+	var body bytes.Buffer
+	if _, err := body.Write(s.ChainHash[:]); err != nil {
+		return err
+	}
+	if err := writeOutpoint(&body, &s.FundingOutpoint); err != nil {
+		return err
+	}
+	err := binary.Write(
+		&body, byteOrder, s.ShortChannelID.ToUint64(),
+	)
+	if err != nil {
+		return err
+	}
+	if err := writePubKey(&body, s.RemoteNodePub); err != nil {
+		return err
+	}
+
+	if err := body.WriteByte(byte(len(s.Addresses))); err != nil {
+		return err
+	}
+	for _, addr := range s.Addresses {
+		addrStr := addr.String()
+		if len(addrStr) > maxAddrLen {
+			return fmt.Errorf("address %v too long", addrStr)
+		}
+
+		if err := body.WriteByte(byte(len(addrStr))); err != nil {
+			return err
+		}
+		if _, err := body.WriteString(addrStr); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Write(&body, byteOrder, int64(s.Capacity)); err != nil {
+		return err
+	}
+	if err := writeChanConfig(&body, &s.LocalChanCfg); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{byte(s.Version)}); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, uint16(body.Len())); err != nil {
+		return err
+	}
+	_, err = w.Write(body.Bytes())
+	return err
+}
+
+// Deserialize attempts to read the raw plaintext serialized SCB from the
+// passed io.Reader. If the method is successful, then the target
+// StaticChannelBackup will be fully populated.
+func (s *Single) Deserialize(r io.Reader) error {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return err
+	}
+
+	s.Version = SingleBackupVersion(version[0])
+	switch s.Version {
+	case DefaultSingleVersion:
+	default:
+		return fmt.Errorf("unable to de-serialize w/ unknown "+
+			"version: %v", s.Version)
+	}
+
+	var bodyLen uint16
+	if err := binary.Read(r, byteOrder, &bodyLen); err != nil {
+		return err
+	}
+
+	// We'll read the entire body up front, which ensures a malformed
+	// backup can't cause us to read into the next one.
+	body := make([]byte, bodyLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+	br := bytes.NewReader(body)
+
+	if _, err := io.ReadFull(br, s.ChainHash[:]); err != nil {
+		return err
+	}
+	if err := readOutpoint(br, &s.FundingOutpoint); err != nil {
+		return err
+	}
+
+	var chanID uint64
+	if err := binary.Read(br, byteOrder, &chanID); err != nil {
+		return err
+	}
+	s.ShortChannelID = lnwire.NewShortChanIDFromInt(chanID)
+
+	var err error
+	s.RemoteNodePub, err = readPubKey(br)
+	if err != nil {
+		return err
+	}
+
+	numAddrs, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+	s.Addresses = make([]net.Addr, 0, numAddrs)
+	for i := 0; i < int(numAddrs); i++ {
+		addrLen, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		addrStr := make([]byte, addrLen)
+		if _, err := io.ReadFull(br, addrStr); err != nil {
+			return err
+		}
+
+		addr, err := tor.ParseAddr(string(addrStr), &tor.ClearNet{})
+		if err != nil {
+			return err
+		}
+		s.Addresses = append(s.Addresses, addr)
+	}
+
+	var capacity int64
+	if err := binary.Read(br, byteOrder, &capacity); err != nil {
+		return err
+	}
+	s.Capacity = btcutil.Amount(capacity)
+
+	return readChanConfig(br, &s.LocalChanCfg)
+}
+
+// writeOutpoint serializes the passed outpoint into the target writer.
+func writeOutpoint(w io.Writer, o *wire.OutPoint) error {
+	if _, err := w.Write(o.Hash[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, o.Index)
+}
+
+// readOutpoint reads a serialized outpoint from the passed reader.
+func readOutpoint(r io.Reader, o *wire.OutPoint) error {
+	if _, err := io.ReadFull(r, o.Hash[:]); err != nil {
+		return err
+	}
+
+	return binary.Read(r, byteOrder, &o.Index)
+}
+
+// writePubKey writes the compressed form of the passed public key into the
+// target writer.
+func writePubKey(w io.Writer, pub *btcec.PublicKey) error {
+	_, err := w.Write(pub.SerializeCompressed())
+	return err
+}
+
+// readPubKey reads a compressed public key from the passed reader.
+func readPubKey(r io.Reader) (*btcec.PublicKey, error) {
+	var pubBytes [btcec.PubKeyBytesLenCompressed]byte
+	if _, err := io.ReadFull(r, pubBytes[:]); err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(pubBytes[:], btcec.S256())
+}
+
+// writeChanConfig serializes the CSV delay and base points of the passed
+// channel config into the target writer. The channel constraints are omitted
+// as they aren't needed to recover funds from the channel.
+func writeChanConfig(w io.Writer, cfg *channeldb.ChannelConfig) error {
+	if err := binary.Write(w, byteOrder, cfg.CsvDelay); err != nil {
+		return err
+	}
+
+	keys := []*btcec.PublicKey{
+		cfg.MultiSigKey, cfg.RevocationBasePoint,
+		cfg.PaymentBasePoint, cfg.DelayBasePoint, cfg.HtlcBasePoint,
+	}
+	for _, key := range keys {
+		if err := writePubKey(w, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readChanConfig reads a channel config serialized by writeChanConfig from
+// the passed reader.
+func readChanConfig(r io.Reader, cfg *channeldb.ChannelConfig) error {
+	if err := binary.Read(r, byteOrder, &cfg.CsvDelay); err != nil {
+		return err
+	}
+
+	keys := []**btcec.PublicKey{
+		&cfg.MultiSigKey, &cfg.RevocationBasePoint,
+		&cfg.PaymentBasePoint, &cfg.DelayBasePoint, &cfg.HtlcBasePoint,
+	}
+	for _, key := range keys {
+		var err error
+		*key, err = readPubKey(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package chanbackup
+
+import (
+	"bytes"
+	"math/rand"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	chainHash = chainhash.Hash{
+		0xb7, 0x94, 0x38, 0x5f, 0x2d, 0x1e, 0xf7, 0xab,
+		0x4d, 0x92, 0x73, 0xd1, 0x90, 0x63, 0x81, 0xb4,
+		0x4f, 0x2f, 0x6f, 0x25, 0x18, 0xa3, 0xef, 0xb9,
+		0x64, 0x49, 0x18, 0x83, 0x31, 0x98, 0x47, 0x53,
+	}
+
+	op = wire.OutPoint{
+		Hash:  chainHash,
+		Index: 4,
+	}
+
+	addr1, _ = net.ResolveTCPAddr("tcp", "10.0.0.2:9000")
+	addr2, _ = net.ResolveTCPAddr("tcp", "[2001:db8:85a3::8a2e:370:7334]:80")
+	addr3    = &tor.OnionAddr{OnionService: "3g2upl4pq6kufc4m.onion", Port: 9735}
+)
+
+// randPubKey generates a fresh public key for use within the tests.
+func randPubKey() (*btcec.PublicKey, error) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	return priv.PubKey(), nil
+}
+
+// genRandomOpenChannelShell creates a random channel, populated with the
+// fields required to create a static channel backup.
+func genRandomOpenChannelShell() (*channeldb.OpenChannel, error) {
+	var keys [6]*btcec.PublicKey
+	for i := range keys {
+		pub, err := randPubKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = pub
+	}
+
+	return &channeldb.OpenChannel{
+		ChainHash:       chainHash,
+		FundingOutpoint: op,
+		ShortChanID: lnwire.NewShortChanIDFromInt(
+			uint64(rand.Int63()),
+		),
+		IdentityPub: keys[0],
+		Capacity:    btcutil.Amount(rand.Int63()),
+		LocalChanCfg: channeldb.ChannelConfig{
+			CsvDelay:            uint16(rand.Int63()),
+			MultiSigKey:         keys[1],
+			RevocationBasePoint: keys[2],
+			PaymentBasePoint:    keys[3],
+			DelayBasePoint:      keys[4],
+			HtlcBasePoint:       keys[5],
+		},
+	}, nil
+}
+
+// TestSinglePackUnpack tests that we're able to serialize a static channel
+// backup, then deserialize it back into the very same backup.
+func TestSinglePackUnpack(t *testing.T) {
+	t.Parallel()
+
+	channel, err := genRandomOpenChannelShell()
+	if err != nil {
+		t.Fatalf("unable to gen open channel: %v", err)
+	}
+
+	singleChanBackup := NewSingle(channel, []net.Addr{addr1, addr2, addr3})
+
+	var b bytes.Buffer
+	if err := singleChanBackup.Serialize(&b); err != nil {
+		t.Fatalf("unable to serialize backup: %v", err)
+	}
+
+	var unpackedSingle Single
+	if err := unpackedSingle.Deserialize(&b); err != nil {
+		t.Fatalf("unable to deserialize backup: %v", err)
+	}
+
+	if !reflect.DeepEqual(singleChanBackup, unpackedSingle) {
+		t.Fatalf("backups don't match: expected %v, got %v",
+			spew.Sdump(singleChanBackup),
+			spew.Sdump(unpackedSingle))
+	}
+
+	// A backup of an unknown version shouldn't be serialized.
+	singleChanBackup.Version = 99
+	if err := singleChanBackup.Serialize(&b); err == nil {
+		t.Fatalf("serialized backup with unknown version")
+	}
+}
@@ -65,6 +65,12 @@ var (
 	// channel closure. This key should be accessed from within the
 	// sub-bucket of a target channel, identified by its channel point.
 	revocationLogBucket = []byte("revocation-log-key")
+
+	// dataLossCommitPointKey stores the commitment point received from the
+	// remote party once we've detected that we've lost channel state. The
+	// presence of this key indicates that we must never broadcast our own
+	// commitment transaction, as it's likely been revoked.
+	dataLossCommitPointKey = []byte("data-loss-commit-point-key")
 )
 
 var (
@@ -85,6 +91,10 @@ var (
 	// each time we write a new state in order to be properly fault
 	// tolerant.
 	ErrNoPendingCommit = fmt.Errorf("no pending commits found")
+
+	// ErrNoCommitPoint is returned when no data loss commit point is found
+	// in the database for a channel.
+	ErrNoCommitPoint = fmt.Errorf("no commit point found")
 )
 
 // ChannelType is an enum-like type that describes one of several possible
@@ -587,7 +597,7 @@ func fetchOpenChannel(chanBucket *bolt.Bucket,
 //
 // TODO(roasbeef): addr param should eventually be a lnwire.NetAddress type
 // that includes service bits.
-func (c *OpenChannel) SyncPending(addr *net.TCPAddr, pendingHeight uint32) error {
+func (c *OpenChannel) SyncPending(addr net.Addr, pendingHeight uint32) error {
 	c.Lock()
 	defer c.Unlock()
 
@@ -933,6 +943,72 @@ func (c *OpenChannel) RemoteCommitChainTip() (*CommitDiff, error) {
 	return cd, err
 }
 
+// NextLocalHtlcIndex returns the next unallocated local HTLC index that has
+// been committed to. Adds we've signed for, but which the remote party hasn't
+// yet revoked their prior state for, are part of the pending commitment diff,
+// so it's consulted before falling back to the remote party's current
+// commitment.
+func (c *OpenChannel) NextLocalHtlcIndex() (uint64, error) {
+	pendingRemoteCommit, err := c.RemoteCommitChainTip()
+	switch {
+	case err == nil:
+		return pendingRemoteCommit.Commitment.LocalHtlcIndex, nil
+
+	case err != ErrNoPendingCommit:
+		return 0, err
+	}
+
+	return c.RemoteCommitment.LocalHtlcIndex, nil
+}
+
+// MarkDataLoss marks the channel as having lost channel state, storing the
+// current commitment point of the remote party. As our latest commitment is
+// likely revoked, we must wait for the remote party to close the channel, at
+// which point the stored commitment point allows us to sweep our output.
+func (c *OpenChannel) MarkDataLoss(commitPoint *btcec.PublicKey) error {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Db.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := updateChanBucket(tx, c.IdentityPub,
+			&c.FundingOutpoint, c.ChainHash)
+		if err != nil {
+			return err
+		}
+
+		return chanBucket.Put(
+			dataLossCommitPointKey, commitPoint.SerializeCompressed(),
+		)
+	})
+}
+
+// DataLossCommitPoint retrieves the commitment point of the remote party
+// stored when the channel was marked as having lost state. If the channel
+// hasn't been marked, then ErrNoCommitPoint is returned.
+func (c *OpenChannel) DataLossCommitPoint() (*btcec.PublicKey, error) {
+	var commitPoint *btcec.PublicKey
+	err := c.Db.View(func(tx *bolt.Tx) error {
+		chanBucket, err := readChanBucket(tx, c.IdentityPub,
+			&c.FundingOutpoint, c.ChainHash)
+		if err != nil {
+			return err
+		}
+
+		pointBytes := chanBucket.Get(dataLossCommitPointKey)
+		if pointBytes == nil {
+			return ErrNoCommitPoint
+		}
+
+		commitPoint, err = btcec.ParsePubKey(pointBytes, btcec.S256())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commitPoint, nil
+}
+
 // InsertNextRevocation inserts the _next_ commitment point (revocation) into
 // the database, and also modifies the internal RemoteNextRevocation attribute
 // to point to the passed key. This method is to be using during final channel
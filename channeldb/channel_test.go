@@ -784,3 +784,53 @@ func TestFetchClosedChannels(t *testing.T) {
 			"got %v", 0, len(closed))
 	}
 }
+
+// TestDataLossCommitPoint tests that the commitment point stored once a
+// channel is marked as having lost state can be retrieved.
+func TestDataLossCommitPoint(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// As the channel hasn't been marked yet, no commit point should be
+	// found.
+	if _, err := state.DataLossCommitPoint(); err != ErrNoCommitPoint {
+		t.Fatalf("expected ErrNoCommitPoint, instead got: %v", err)
+	}
+
+	commitKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create new private key: %v", err)
+	}
+	if err := state.MarkDataLoss(commitKey.PubKey()); err != nil {
+		t.Fatalf("unable to mark data loss: %v", err)
+	}
+
+	// We should now be able to retrieve the very same point, even after
+	// fetching the channel from disk once again.
+	openChannels, err := cdb.FetchOpenChannels(state.IdentityPub)
+	if err != nil {
+		t.Fatalf("unable to fetch open channel: %v", err)
+	}
+	commitPoint, err := openChannels[0].DataLossCommitPoint()
+	if err != nil {
+		t.Fatalf("unable to fetch commit point: %v", err)
+	}
+	if !commitPoint.IsEqual(commitKey.PubKey()) {
+		t.Fatalf("commit point mismatch: expected %x, got %x",
+			commitKey.PubKey().SerializeCompressed(),
+			commitPoint.SerializeCompressed())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
@@ -39,6 +40,33 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of the database where invoices and
+			// payments track the HTLCs and shards of multi-path
+			// payments.
+			number:    1,
+			migration: migrateMultiPathRecords,
+		},
+		{
+			// The version of the database where invoices store
+			// their payment hash, as the preimage of a hold
+			// invoice isn't known until it's settled.
+			number:    2,
+			migration: migrateInvoicePaymentHash,
+		},
+		{
+			// The version of the database where invoices store
+			// their expiry, so that it can be enforced.
+			number:    3,
+			migration: migrateInvoiceExpiry,
+		},
+		{
+			// The version of the database where invoices are
+			// indexed by the order in which they were added and
+			// settled.
+			number:    4,
+			migration: migrateInvoiceIndexes,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -524,6 +552,45 @@ func (d *DB) syncVersions(versions []version) error {
 	})
 }
 
+// AddrsForNode consults the graph and channel database for all addresses known
+// to the passed node public key. The addresses we've used to connect to the
+// node in the past are returned first, followed by any additional addresses
+// advertised by the node within the channel graph.
+func (d *DB) AddrsForNode(nodePub *btcec.PublicKey) ([]net.Addr, error) {
+	var addrs []net.Addr
+	seenAddrs := make(map[string]struct{})
+
+	linkNode, err := d.FetchLinkNode(nodePub)
+	switch {
+	case err == ErrNodeNotFound || err == ErrLinkNodesNotFound:
+	case err != nil:
+		return nil, err
+	default:
+		for _, addr := range linkNode.Addresses {
+			seenAddrs[addr.String()] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	graphNode, err := d.ChannelGraph().FetchLightningNode(nodePub)
+	switch {
+	case err == ErrGraphNodeNotFound || err == ErrGraphNotFound:
+	case err != nil:
+		return nil, err
+	default:
+		for _, addr := range graphNode.Addresses {
+			if _, ok := seenAddrs[addr.String()]; ok {
+				continue
+			}
+
+			seenAddrs[addr.String()] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs, nil
+}
+
 // ChannelGraph returns a new instance of the directed channel graph.
 func (d *DB) ChannelGraph() *ChannelGraph {
 	return &ChannelGraph{d}
@@ -2,9 +2,12 @@ package channeldb
 
 import (
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/roasbeef/btcd/wire"
 )
 
 func TestOpenWithCreate(t *testing.T) {
@@ -33,3 +36,77 @@ func TestOpenWithCreate(t *testing.T) {
 		t.Fatalf("channeldb failed to create data directory")
 	}
 }
+
+// TestAddrsForNode tests that we're able to properly obtain all the addresses
+// for a target node, both those we've connected to it at in the past, and
+// those it has advertised within the channel graph.
+func TestAddrsForNode(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	graph := cdb.ChannelGraph()
+
+	// We'll make a test vertex to insert into the channel graph, as this
+	// will also be the node we attempt to obtain the addresses for.
+	testNode, err := createTestVertex(cdb)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+
+	// Before the node is known, no addresses should be returned.
+	nodeAddrs, err := cdb.AddrsForNode(testNode.PubKey)
+	if err != nil {
+		t.Fatalf("unable to obtain node addrs: %v", err)
+	}
+	if len(nodeAddrs) != 0 {
+		t.Fatalf("expected no addrs, instead got %v", nodeAddrs)
+	}
+
+	if err := graph.AddLightningNode(testNode); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	// Next, we'll make a link node with the same pubkey, but with an
+	// additional address, along with one that's also advertised within
+	// the graph.
+	linkAddr, err := net.ResolveTCPAddr("tcp", "10.0.0.9:9735")
+	if err != nil {
+		t.Fatalf("unable to create test addr: %v", err)
+	}
+	linkNode := cdb.NewLinkNode(wire.MainNet, testNode.PubKey, linkAddr)
+	if err := linkNode.AddAddress(testAddr); err != nil {
+		t.Fatalf("unable to add addr: %v", err)
+	}
+	if err := linkNode.Sync(); err != nil {
+		t.Fatalf("unable to sync link node: %v", err)
+	}
+
+	// Now that we've created a link node, as well as a vertex for the
+	// node, we'll query for all its addresses. The addresses of the link
+	// node should be returned first, with the address shared by both
+	// returned only once.
+	nodeAddrs, err = cdb.AddrsForNode(testNode.PubKey)
+	if err != nil {
+		t.Fatalf("unable to obtain node addrs: %v", err)
+	}
+
+	expectedAddrs := []string{
+		linkAddr.String(), testAddr.String(), anotherAddr.String(),
+		testOnionAddr.String(), testV3OnionAddr.String(),
+	}
+	if len(nodeAddrs) != len(expectedAddrs) {
+		t.Fatalf("expected %v addrs, got %v", len(expectedAddrs),
+			len(nodeAddrs))
+	}
+	for i, addr := range nodeAddrs {
+		if addr.String() != expectedAddrs[i] {
+			t.Fatalf("expected addr %v at index %v, got %v",
+				expectedAddrs[i], i, addr)
+		}
+	}
+}
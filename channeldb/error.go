@@ -31,10 +31,50 @@ var (
 	// payment hash already exists.
 	ErrDuplicateInvoice = fmt.Errorf("invoice with payment hash already exists")
 
+	// ErrInvoiceAlreadySettled is returned when an invoice that has
+	// already been settled is to be canceled or settled once again.
+	ErrInvoiceAlreadySettled = fmt.Errorf("invoice already settled")
+
+	// ErrInvoiceAlreadyCanceled is returned when an invoice that has
+	// already been canceled is to be accepted, settled or canceled.
+	ErrInvoiceAlreadyCanceled = fmt.Errorf("invoice already canceled")
+
+	// ErrInvoiceNotAccepted is returned when a hold invoice is to be
+	// settled before an HTLC paying to it has been accepted.
+	ErrInvoiceNotAccepted = fmt.Errorf("invoice has not been accepted")
+
+	// ErrInvoiceAlreadyExpired is returned when an invoice that has
+	// already expired is attempted to be paid, settled or canceled.
+	ErrInvoiceAlreadyExpired = fmt.Errorf("invoice already expired")
+
+	// ErrInvoiceNotOpen is returned when an invoice is to be expired, but
+	// it's no longer open as it has already been paid or canceled.
+	ErrInvoiceNotOpen = fmt.Errorf("invoice is not open")
+
 	// ErrNoPaymentsCreated is returned when bucket of payments hasn't been
 	// created.
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
 
+	// ErrPaymentInFlight is returned when a payment is initiated for a
+	// payment hash that already has a payment in flight.
+	ErrPaymentInFlight = fmt.Errorf("payment is in transition")
+
+	// ErrAlreadyPaid is returned when a payment is initiated for a payment
+	// hash that has already been paid successfully.
+	ErrAlreadyPaid = fmt.Errorf("invoice is already paid")
+
+	// ErrPaymentNotInitiated is returned when a payment hash is looked up
+	// for which no payment has been initiated.
+	ErrPaymentNotInitiated = fmt.Errorf("payment isn't initiated")
+
+	// ErrPaymentNotInFlight is returned when an attempt or a final outcome
+	// is recorded for a payment that is no longer in flight.
+	ErrPaymentNotInFlight = fmt.Errorf("payment isn't in flight")
+
+	// ErrPaymentAttemptNotFound is returned when a payment attempt can't be
+	// found within the set of in-flight attempts of a payment.
+	ErrPaymentAttemptNotFound = fmt.Errorf("payment attempt not found")
+
 	// ErrNodeNotFound is returned when node bucket exists, but node with
 	// specific identity can't be found.
 	ErrNodeNotFound = fmt.Errorf("link node with target identity not found")
@@ -85,4 +125,8 @@ var (
 	// ErrNoClosedChannels is returned when a node is queries for all the
 	// channels it has closed, but it hasn't yet closed any channels.
 	ErrNoClosedChannels = fmt.Errorf("no channel have been closed yet")
+
+	// ErrPreimageNotFound is returned when a preimage for the target
+	// payment hash can't be found within the preimage cache.
+	ErrPreimageNotFound = fmt.Errorf("unable to locate preimage")
 )
@@ -0,0 +1,227 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// forwardingLogBucket is the name of the top-level bucket that stores
+	// the time series log of all HTLCs that were successfully forwarded by
+	// the switch.
+	//
+	// Each event is keyed by the big-endian encoding of its timestamp in
+	// nanoseconds, so a cursor can seek directly to the start of a time
+	// range and walk the events in chronological order.
+	forwardingLogBucket = []byte("forwarding-log")
+)
+
+const (
+	// forwardingEventSize is the size of a serialized forwarding event,
+	// excluding the timestamp which is stored within the key.
+	forwardingEventSize = 8 + 8 + 8 + 8
+
+	// MaxResponseEvents is the max number of forwarding events that will
+	// be returned by a single query of the forwarding log.
+	MaxResponseEvents = 50000
+)
+
+// ForwardingEvent is an event in the forwarding log's time series. Each
+// forwarding event logs the creation and tear-down of a payment circuit. A
+// circuit is created once an incoming HTLC has been fully forwarded, and
+// destroyed once the payment has been settled.
+type ForwardingEvent struct {
+	// Timestamp is the settlement time of this payment circuit.
+	Timestamp time.Time
+
+	// IncomingChanID is the incoming channel ID of the payment circuit.
+	IncomingChanID lnwire.ShortChannelID
+
+	// OutgoingChanID is the outgoing channel ID of the payment circuit.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// AmtIn is the amount of the incoming HTLC. Subtracting AmtOut from
+	// this yields the fee earned for forwarding the payment.
+	AmtIn lnwire.MilliSatoshi
+
+	// AmtOut is the amount of the outgoing HTLC.
+	AmtOut lnwire.MilliSatoshi
+}
+
+// AddForwardingEvents adds a batch of forwarding events to the forwarding
+// log. If two events share the same timestamp, then the latter one is shifted
+// forward by a nanosecond so that no event overwrites another.
+func (d *DB) AddForwardingEvents(events []ForwardingEvent) error {
+	// If we don't have any events, then we can exit early without
+	// grabbing a write lock on the database.
+	if len(events) == 0 {
+		return nil
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		logBucket, err := tx.CreateBucketIfNotExists(forwardingLogBucket)
+		if err != nil {
+			return err
+		}
+
+		// As bolt references the passed keys and values until the
+		// transaction commits, each event gets its own buffers.
+		for _, event := range events {
+			var (
+				eventKey   [8]byte
+				eventBytes [forwardingEventSize]byte
+			)
+
+			timestamp := uint64(event.Timestamp.UnixNano())
+			for {
+				byteOrder.PutUint64(eventKey[:], timestamp)
+				if logBucket.Get(eventKey[:]) == nil {
+					break
+				}
+				timestamp++
+			}
+
+			encodeForwardingEvent(eventBytes[:], &event)
+			if err := logBucket.Put(eventKey[:], eventBytes[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ForwardingEventQuery represents a query to the forwarding log payment
+// circuit time series database. The query allows a caller to retrieve all
+// records for a particular time slice, offset in that time slice, limiting
+// the total number of responses returned.
+type ForwardingEventQuery struct {
+	// StartTime is the start time of the time slice.
+	StartTime time.Time
+
+	// EndTime is the end time of the time slice.
+	EndTime time.Time
+
+	// IndexOffset is the offset within the time slice to start at. This
+	// can be used to start the response at a particular record.
+	IndexOffset uint32
+
+	// NumMaxEvents is the max number of events to return. If zero, or
+	// larger than MaxResponseEvents, then MaxResponseEvents is used.
+	NumMaxEvents uint32
+}
+
+// ForwardingLogTimeSlice is the response to a forwarding query. It includes
+// the original query, the set of events that match the query, and an integer
+// which represents the offset index of the last item in the set of returned
+// events. This integer allows callers to resume their query using this
+// offset in the event that the query's response exceeds the max number of
+// returnable events.
+type ForwardingLogTimeSlice struct {
+	ForwardingEventQuery
+
+	// ForwardingEvents is the set of events in our time series that
+	// answer the query embedded above.
+	ForwardingEvents []ForwardingEvent
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned ForwardingEvents above. Callers can use this to resume
+	// their query in the event that the time slice has too many events to
+	// fit into a single response.
+	LastIndexOffset uint32
+}
+
+// QueryForwardingLog returns a slice of the forwarding log that matches the
+// passed query. The events are returned in chronological order, starting at
+// the query's index offset within the time slice.
+func (d *DB) QueryForwardingLog(
+	q ForwardingEventQuery) (*ForwardingLogTimeSlice, error) {
+
+	numMaxEvents := q.NumMaxEvents
+	if numMaxEvents == 0 || numMaxEvents > MaxResponseEvents {
+		numMaxEvents = MaxResponseEvents
+	}
+
+	resp := &ForwardingLogTimeSlice{
+		ForwardingEventQuery: q,
+		LastIndexOffset:      q.IndexOffset,
+	}
+
+	err := d.View(func(tx *bolt.Tx) error {
+		logBucket := tx.Bucket(forwardingLogBucket)
+		if logBucket == nil {
+			return nil
+		}
+
+		var startKey, endKey [8]byte
+		byteOrder.PutUint64(startKey[:], uint64(q.StartTime.UnixNano()))
+		byteOrder.PutUint64(endKey[:], uint64(q.EndTime.UnixNano()))
+
+		// We'll seek to the start of the time range, then skip events
+		// until we reach the requested offset. Afterwards, events are
+		// collected until we either leave the time range, or have
+		// collected as many events as we're allowed to return.
+		var recordIndex uint32
+		cursor := logBucket.Cursor()
+		for k, v := cursor.Seek(startKey[:]); k != nil &&
+			bytes.Compare(k, endKey[:]) <= 0; k, v = cursor.Next() {
+
+			if recordIndex < q.IndexOffset {
+				recordIndex++
+				continue
+			}
+			if uint32(len(resp.ForwardingEvents)) >= numMaxEvents {
+				break
+			}
+
+			event, err := decodeForwardingEvent(k, v)
+			if err != nil {
+				return err
+			}
+
+			resp.ForwardingEvents = append(resp.ForwardingEvents, event)
+			recordIndex++
+		}
+
+		resp.LastIndexOffset = recordIndex
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// encodeForwardingEvent writes the passed forwarding event, excluding its
+// timestamp, into the target byte slice.
+func encodeForwardingEvent(b []byte, e *ForwardingEvent) {
+	byteOrder.PutUint64(b[0:], e.IncomingChanID.ToUint64())
+	byteOrder.PutUint64(b[8:], e.OutgoingChanID.ToUint64())
+	byteOrder.PutUint64(b[16:], uint64(e.AmtIn))
+	byteOrder.PutUint64(b[24:], uint64(e.AmtOut))
+}
+
+// decodeForwardingEvent reads a forwarding event from its key, which holds
+// the timestamp, and its serialized value.
+func decodeForwardingEvent(k, v []byte) (ForwardingEvent, error) {
+	if len(k) != 8 || len(v) != forwardingEventSize {
+		return ForwardingEvent{}, io.ErrUnexpectedEOF
+	}
+
+	return ForwardingEvent{
+		Timestamp: time.Unix(0, int64(byteOrder.Uint64(k))),
+		IncomingChanID: lnwire.NewShortChanIDFromInt(
+			byteOrder.Uint64(v[0:]),
+		),
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(
+			byteOrder.Uint64(v[8:]),
+		),
+		AmtIn:  lnwire.MilliSatoshi(byteOrder.Uint64(v[16:])),
+		AmtOut: lnwire.MilliSatoshi(byteOrder.Uint64(v[24:])),
+	}, nil
+}
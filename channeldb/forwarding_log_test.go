@@ -0,0 +1,97 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestForwardingLogQuery tests that forwarding events are returned in
+// chronological order, bounded by the queried time range, and that large
+// time slices can be paged through using the returned index offset.
+func TestForwardingLogQuery(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// Querying an empty log should return no events.
+	timeSlice, err := db.QueryForwardingLog(ForwardingEventQuery{
+		StartTime: time.Unix(0, 0),
+		EndTime:   time.Unix(1000, 0),
+	})
+	if err != nil {
+		t.Fatalf("unable to query forwarding log: %v", err)
+	}
+	if len(timeSlice.ForwardingEvents) != 0 {
+		t.Fatalf("expected no events, got %v",
+			len(timeSlice.ForwardingEvents))
+	}
+
+	// We'll add ten events, one second apart. The last two events share
+	// the same timestamp, and should both be retained.
+	events := make([]ForwardingEvent, 10)
+	for i := range events {
+		events[i] = ForwardingEvent{
+			Timestamp:      time.Unix(int64(i+1), 0),
+			IncomingChanID: lnwire.NewShortChanIDFromInt(uint64(i)),
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(uint64(i + 1)),
+			AmtIn:          lnwire.MilliSatoshi(1000 + i),
+			AmtOut:         1000,
+		}
+	}
+	events[9].Timestamp = events[8].Timestamp
+	if err := db.AddForwardingEvents(events); err != nil {
+		t.Fatalf("unable to add events: %v", err)
+	}
+	events[9].Timestamp = events[9].Timestamp.Add(time.Nanosecond)
+
+	// A query restricted to the third through fifth second should only
+	// return the events within that range.
+	timeSlice, err = db.QueryForwardingLog(ForwardingEventQuery{
+		StartTime: time.Unix(3, 0),
+		EndTime:   time.Unix(5, 0),
+	})
+	if err != nil {
+		t.Fatalf("unable to query forwarding log: %v", err)
+	}
+	if !reflect.DeepEqual(timeSlice.ForwardingEvents, events[2:5]) {
+		t.Fatalf("events mismatch: expected %v, got %v",
+			spew.Sdump(events[2:5]),
+			spew.Sdump(timeSlice.ForwardingEvents))
+	}
+
+	// Finally, we'll page through the entire log four events at a time,
+	// which should yield all events in order.
+	var (
+		fetched []ForwardingEvent
+		offset  uint32
+	)
+	for {
+		timeSlice, err := db.QueryForwardingLog(ForwardingEventQuery{
+			StartTime:    time.Unix(0, 0),
+			EndTime:      time.Unix(1000, 0),
+			IndexOffset:  offset,
+			NumMaxEvents: 4,
+		})
+		if err != nil {
+			t.Fatalf("unable to query forwarding log: %v", err)
+		}
+		if len(timeSlice.ForwardingEvents) == 0 {
+			break
+		}
+
+		fetched = append(fetched, timeSlice.ForwardingEvents...)
+		offset = timeSlice.LastIndexOffset
+	}
+	if !reflect.DeepEqual(fetched, events) {
+		t.Fatalf("events mismatch: expected %v, got %v",
+			spew.Sdump(events), spew.Sdump(fetched))
+	}
+}
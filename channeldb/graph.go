@@ -127,16 +127,6 @@ func (c *ChannelGraph) Database() *DB {
 	return c.db
 }
 
-// addressType specifies the network protocol and version that should be used
-// when connecting to a node at a particular address.
-type addressType uint8
-
-const (
-	tcp4Addr  addressType = 0
-	tcp6Addr  addressType = 1
-	onionAddr addressType = 2
-)
-
 // ForEachChannel iterates through all the channel edges stored within the
 // graph and invokes the passed callback for each edge. The callback takes two
 // edges as since this is a directed graph, both the in/out edges are visited.
@@ -1538,6 +1528,282 @@ func (c *ChannelGraph) ChannelView() ([]wire.OutPoint, error) {
 	return chanPoints, nil
 }
 
+// ChannelEdge represents the complete set of information for a channel edge
+// in the known channel graph. This struct couples the core information of the
+// edge as well as each of the known advertised edge policies.
+type ChannelEdge struct {
+	// Info contains all the static information describing the channel.
+	Info *ChannelEdgeInfo
+
+	// Policy1 points to the "first" edge policy of the channel containing
+	// the dynamic information required to properly route through the
+	// edge.
+	Policy1 *ChannelEdgePolicy
+
+	// Policy2 points to the "second" edge policy of the channel containing
+	// the dynamic information required to properly route through the
+	// edge.
+	Policy2 *ChannelEdgePolicy
+}
+
+// HighestChanID returns the "highest" known channel ID in the channel graph.
+// This represents the "newest" channel from the PoV of the chain. This method
+// can be used by peers to quickly determine if they're graphs are in sync. If
+// the graph doesn't yet have any channels, then zero is returned.
+func (c *ChannelGraph) HighestChanID() (uint64, error) {
+	var cid uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// As the edge index is keyed by the big-endian encoding of the
+		// channel ID, the last key within the index is the highest
+		// channel ID.
+		lastChanID, _ := edgeIndex.Cursor().Last()
+		if lastChanID == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cid = byteOrder.Uint64(lastChanID)
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return 0, err
+	}
+
+	return cid, nil
+}
+
+// ChanUpdatesInHorizon returns all the known channel edges which have at least
+// one edge policy with an update timestamp within the specified horizon.
+//
+// TODO(roasbeef): add an index keyed by the update time of each edge policy,
+// so the entire edge index doesn't need to be scanned.
+func (c *ChannelGraph) ChanUpdatesInHorizon(startTime,
+	endTime time.Time) ([]ChannelEdge, error) {
+
+	inHorizon := func(policy *ChannelEdgePolicy) bool {
+		return policy != nil &&
+			!policy.LastUpdate.Before(startTime) &&
+			!policy.LastUpdate.After(endTime)
+	}
+
+	var edgesInHorizon []ChannelEdge
+	err := c.ForEachChannel(func(info *ChannelEdgeInfo,
+		e1, e2 *ChannelEdgePolicy) error {
+
+		if !inHorizon(e1) && !inHorizon(e2) {
+			return nil
+		}
+
+		edgesInHorizon = append(edgesInHorizon, ChannelEdge{
+			Info:    info,
+			Policy1: e1,
+			Policy2: e2,
+		})
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return nil, err
+	}
+
+	return edgesInHorizon, nil
+}
+
+// NodeUpdatesInHorizon returns all the known lightning nodes which have a
+// node announcement with an update timestamp within the specified horizon.
+func (c *ChannelGraph) NodeUpdatesInHorizon(startTime,
+	endTime time.Time) ([]LightningNode, error) {
+
+	var nodesInHorizon []LightningNode
+	err := c.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+		if !node.HaveNodeAnnouncement ||
+			node.LastUpdate.Before(startTime) ||
+			node.LastUpdate.After(endTime) {
+
+			return nil
+		}
+
+		nodesInHorizon = append(nodesInHorizon, *node)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nodesInHorizon, nil
+}
+
+// FilterKnownChanIDs takes a set of channel IDs and return the subset of chan
+// ID's that we don't know of in the passed set. In other words, we perform a
+// set difference of our set of chan ID's and the ones passed in. This method
+// can be used by callers to determine the set of channels another peer knows
+// of that we don't.
+func (c *ChannelGraph) FilterKnownChanIDs(chanIDs []uint64) ([]uint64, error) {
+	var newChanIDs []uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		// If we don't have any edges yet, then all the passed channel
+		// ID's are unknown to us.
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			newChanIDs = chanIDs
+			return nil
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			newChanIDs = chanIDs
+			return nil
+		}
+
+		var cidBytes [8]byte
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			if edgeIndex.Get(cidBytes[:]) == nil {
+				newChanIDs = append(newChanIDs, cid)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newChanIDs, nil
+}
+
+// FilterChannelRange returns the channel ID's of all known channels which were
+// mined in a block height within the passed range. This method can be used to
+// quickly share with a peer the set of channels we know of within a
+// particular range to catch them up after a period of time offline.
+func (c *ChannelGraph) FilterChannelRange(startHeight,
+	endHeight uint32) ([]uint64, error) {
+
+	var chanIDs []uint64
+
+	startChanID := lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+	}
+	endChanID := lnwire.ShortChannelID{
+		BlockHeight: endHeight,
+		TxIndex:     (1 << 24) - 1,
+		TxPosition:  math.MaxUint16,
+	}
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// As the block height makes up the most significant bytes of
+		// the channel ID, the channels within the range are stored
+		// contiguously within the edge index.
+		var chanIDStart, chanIDEnd [8]byte
+		byteOrder.PutUint64(chanIDStart[:], startChanID.ToUint64())
+		byteOrder.PutUint64(chanIDEnd[:], endChanID.ToUint64())
+
+		cursor := edgeIndex.Cursor()
+		for k, _ := cursor.Seek(chanIDStart[:]); k != nil &&
+			bytes.Compare(k, chanIDEnd[:]) <= 0; k, _ = cursor.Next() {
+
+			chanIDs = append(chanIDs, byteOrder.Uint64(k))
+		}
+
+		return nil
+	})
+	switch {
+	// If we don't know of any channels yet, then there's nothing to
+	// filter, so we'll return an empty slice.
+	case err == ErrGraphNoEdgesFound:
+		return chanIDs, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// FetchChanInfos returns the set of channel edges that correspond to the
+// passed channel ID's. If an edge in the query is unknown to the database, it
+// will be skipped and the result will contain only those edges that exist at
+// the time of the query. This can be used to respond to peer queries that are
+// seeking to fill in gaps in their view of the channel graph.
+func (c *ChannelGraph) FetchChanInfos(chanIDs []uint64) ([]ChannelEdge, error) {
+	var chanEdges []ChannelEdge
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		// If we don't have any edges yet, then none of the queried
+		// channels are known to us.
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return nil
+		}
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return nil
+		}
+
+		var cidBytes [8]byte
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			// First, we'll fetch the static edge information. If
+			// the edge is unknown, we will skip the edge and
+			// continue gathering all known edges.
+			edgeInfo, err := fetchChanEdgeInfo(
+				edgeIndex, cidBytes[:],
+			)
+			switch {
+			case err == ErrEdgeNotFound:
+				continue
+			case err != nil:
+				return err
+			}
+
+			// With the static information obtained, we'll now
+			// fetch the dynamic policy info.
+			edge1, edge2, err := fetchChanEdgePolicies(
+				edgeIndex, edges, nodes, cidBytes[:], c.db,
+			)
+			if err != nil {
+				return err
+			}
+
+			chanEdges = append(chanEdges, ChannelEdge{
+				Info:    edgeInfo,
+				Policy1: edge1,
+				Policy2: edge2,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chanEdges, nil
+}
+
 // NewChannelEdgePolicy returns a new blank ChannelEdgePolicy.
 func (c *ChannelGraph) NewChannelEdgePolicy() *ChannelEdgePolicy {
 	return &ChannelEdgePolicy{db: c.db}
@@ -1609,31 +1875,8 @@ func putLightningNode(nodeBucket *bolt.Bucket, aliasBucket *bolt.Bucket, node *L
 	}
 
 	for _, address := range node.Addresses {
-		if address.Network() == "tcp" {
-			if address.(*net.TCPAddr).IP.To4() != nil {
-				scratch[0] = uint8(tcp4Addr)
-				if _, err := b.Write(scratch[:1]); err != nil {
-					return err
-				}
-				copy(scratch[:4], address.(*net.TCPAddr).IP.To4())
-				if _, err := b.Write(scratch[:4]); err != nil {
-					return err
-				}
-			} else {
-				scratch[0] = uint8(tcp6Addr)
-				if _, err := b.Write(scratch[:1]); err != nil {
-					return err
-				}
-				copy(scratch[:], address.(*net.TCPAddr).IP.To16())
-				if _, err := b.Write(scratch[:]); err != nil {
-					return err
-				}
-			}
-			byteOrder.PutUint16(scratch[:2],
-				uint16(address.(*net.TCPAddr).Port))
-			if _, err := b.Write(scratch[:2]); err != nil {
-				return err
-			}
+		if err := serializeAddr(&b, address); err != nil {
+			return err
 		}
 	}
 
@@ -1731,41 +1974,10 @@ func deserializeLightningNode(r io.Reader) (*LightningNode, error) {
 
 	var addresses []net.Addr
 	for i := 0; i < numAddresses; i++ {
-		var address net.Addr
-		if _, err := r.Read(scratch[:1]); err != nil {
+		address, err := deserializeAddr(r)
+		if err != nil {
 			return nil, err
 		}
-
-		// TODO(roasbeef): also add onion addrs
-		switch addressType(scratch[0]) {
-		case tcp4Addr:
-			addr := &net.TCPAddr{}
-			var ip [4]byte
-			if _, err := r.Read(ip[:]); err != nil {
-				return nil, err
-			}
-			addr.IP = (net.IP)(ip[:])
-			if _, err := r.Read(scratch[:2]); err != nil {
-				return nil, err
-			}
-			addr.Port = int(byteOrder.Uint16(scratch[:2]))
-			address = addr
-		case tcp6Addr:
-			addr := &net.TCPAddr{}
-			var ip [16]byte
-			if _, err := r.Read(ip[:]); err != nil {
-				return nil, err
-			}
-			addr.IP = (net.IP)(ip[:])
-			if _, err := r.Read(scratch[:2]); err != nil {
-				return nil, err
-			}
-			addr.Port = int(byteOrder.Uint16(scratch[:2]))
-			address = addr
-		default:
-			return nil, ErrUnknownAddressType
-		}
-
 		addresses = append(addresses, address)
 	}
 	node.Addresses = addresses
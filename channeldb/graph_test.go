@@ -17,6 +17,7 @@ import (
 	"github.com/boltdb/bolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -27,7 +28,17 @@ var (
 		Port: 9000}
 	anotherAddr, _ = net.ResolveTCPAddr("tcp",
 		"[2001:db8:85a3:0:0:8a2e:370:7334]:80")
-	testAddrs = []net.Addr{testAddr, anotherAddr}
+	testOnionAddr = &tor.OnionAddr{
+		OnionService: "3g2upl4pq6kufc4m.onion",
+		Port:         9735,
+	}
+	testV3OnionAddr = &tor.OnionAddr{
+		OnionService: "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion",
+		Port:         80,
+	}
+	testAddrs = []net.Addr{
+		testAddr, anotherAddr, testOnionAddr, testV3OnionAddr,
+	}
 
 	randSource = prand.NewSource(time.Now().Unix())
 	randInts   = prand.New(randSource)
@@ -1147,6 +1158,178 @@ func TestGraphPruning(t *testing.T) {
 	}
 }
 
+// TestGraphRangeQueries tests the queries used to synchronize the channel
+// graph with a peer: locating channels by block height, filtering known
+// channels, and fetching the edges and nodes updated within a time horizon.
+func TestGraphRangeQueries(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// Before any channels are added, the graph should report no channels.
+	highestChanID, err := graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != 0 {
+		t.Fatalf("expected no highest chan ID, got %v", highestChanID)
+	}
+	chanIDs, err := graph.FilterChannelRange(0, math.MaxUint32)
+	if err != nil {
+		t.Fatalf("unable to filter channel range: %v", err)
+	}
+	if len(chanIDs) != 0 {
+		t.Fatalf("expected no channels, got %v", len(chanIDs))
+	}
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	node1.LastUpdate = time.Unix(100, 0)
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	node2.LastUpdate = time.Unix(200, 0)
+	for _, node := range []*LightningNode{node1, node2} {
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	// We'll add a channel in each of the blocks 100 through 104, with the
+	// policy of each channel updated a second after the previous one.
+	const numChannels = 5
+	allChanIDs := make([]uint64, numChannels)
+	for i := 0; i < numChannels; i++ {
+		shortChanID := lnwire.ShortChannelID{
+			BlockHeight: uint32(100 + i),
+			TxIndex:     uint32(i),
+		}
+		chanID := shortChanID.ToUint64()
+		allChanIDs[i] = chanID
+
+		op := wire.OutPoint{
+			Hash: sha256.Sum256([]byte{byte(i)}),
+		}
+		edgeInfo := ChannelEdgeInfo{
+			ChannelID:    chanID,
+			ChainHash:    key,
+			NodeKey1:     node1.PubKey,
+			NodeKey2:     node2.PubKey,
+			BitcoinKey1:  node1.PubKey,
+			BitcoinKey2:  node2.PubKey,
+			ChannelPoint: op,
+			Capacity:     1000,
+		}
+		if err := graph.AddChannelEdge(&edgeInfo); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		edge := randEdgePolicy(chanID, op, db)
+		edge.LastUpdate = time.Unix(int64(1000+i), 0)
+		edge.Flags = 0
+		edge.Node = node2
+		edge.Signature = testSig
+		if err := graph.UpdateEdgePolicy(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	highestChanID, err = graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != allChanIDs[numChannels-1] {
+		t.Fatalf("expected highest chan ID %v, got %v",
+			allChanIDs[numChannels-1], highestChanID)
+	}
+
+	// Only the channels mined within blocks 101 through 103 should be
+	// returned for that range.
+	chanIDs, err = graph.FilterChannelRange(101, 103)
+	if err != nil {
+		t.Fatalf("unable to filter channel range: %v", err)
+	}
+	if !reflect.DeepEqual(chanIDs, allChanIDs[1:4]) {
+		t.Fatalf("expected chan IDs %v, got %v", allChanIDs[1:4],
+			chanIDs)
+	}
+
+	// Filtering a set of partially known channels should only return the
+	// unknown ones.
+	unknownChanID1 := lnwire.ShortChannelID{BlockHeight: 99}
+	unknownChanID2 := lnwire.ShortChannelID{BlockHeight: 200}
+	unknownChanIDs := []uint64{
+		unknownChanID1.ToUint64(), unknownChanID2.ToUint64(),
+	}
+	queryChanIDs := append([]uint64{allChanIDs[0]}, unknownChanIDs...)
+	queryChanIDs = append(queryChanIDs, allChanIDs[2])
+	newChanIDs, err := graph.FilterKnownChanIDs(queryChanIDs)
+	if err != nil {
+		t.Fatalf("unable to filter chan IDs: %v", err)
+	}
+	if !reflect.DeepEqual(newChanIDs, unknownChanIDs) {
+		t.Fatalf("expected unknown chan IDs %v, got %v",
+			unknownChanIDs, newChanIDs)
+	}
+
+	// Fetching the same set of channels should only return the known
+	// ones, along with their policies.
+	chanEdges, err := graph.FetchChanInfos(queryChanIDs)
+	if err != nil {
+		t.Fatalf("unable to fetch chan infos: %v", err)
+	}
+	if len(chanEdges) != 2 {
+		t.Fatalf("expected 2 edges, got %v", len(chanEdges))
+	}
+	for i, expectedChanID := range []uint64{allChanIDs[0], allChanIDs[2]} {
+		if chanEdges[i].Info.ChannelID != expectedChanID {
+			t.Fatalf("expected chan ID %v, got %v",
+				expectedChanID, chanEdges[i].Info.ChannelID)
+		}
+		if chanEdges[i].Policy1 == nil || chanEdges[i].Policy2 != nil {
+			t.Fatalf("expected only the first policy to be " +
+				"known")
+		}
+	}
+
+	// Only the channels updated within the horizon should be returned.
+	chanEdges, err = graph.ChanUpdatesInHorizon(
+		time.Unix(1001, 0), time.Unix(1002, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch chan updates: %v", err)
+	}
+	if len(chanEdges) != 2 ||
+		chanEdges[0].Info.ChannelID != allChanIDs[1] ||
+		chanEdges[1].Info.ChannelID != allChanIDs[2] {
+
+		t.Fatalf("unexpected edges within horizon: %v",
+			spew.Sdump(chanEdges))
+	}
+
+	// Likewise, only the nodes updated within the horizon should be
+	// returned.
+	nodes, err := graph.NodeUpdatesInHorizon(
+		time.Unix(150, 0), time.Unix(250, 0),
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch node updates: %v", err)
+	}
+	if len(nodes) != 1 || !nodes[0].PubKey.IsEqual(node2.PubKey) {
+		t.Fatalf("unexpected nodes within horizon: %v",
+			spew.Sdump(nodes))
+	}
+}
+
 // compareNodes is used to compare two LightningNodes while excluding the
 // Features struct, which cannot be compared as the semantics for reserializing
 // the featuresMap have not been defined.
@@ -0,0 +1,133 @@
+package channeldb
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// heldHTLCBucket is the name of the top-level bucket that stores the
+	// HTLCs paying to our invoices that are currently being held, either
+	// as they're a shard of a multi-path payment, or as they pay to a hold
+	// invoice.
+	//
+	// Within it, a sub-bucket exists for each payment hash that has HTLCs
+	// held for it, which maps the short channel ID and index of each HTLC
+	// to its amount and accept time.
+	heldHTLCBucket = []byte("held-htlcs")
+)
+
+// heldHTLCKey returns the key of the passed HTLC within the sub-bucket of its
+// payment hash: its short channel ID followed by its index.
+func heldHTLCKey(chanID lnwire.ShortChannelID, htlcID uint64) [16]byte {
+	var k [16]byte
+	byteOrder.PutUint64(k[:8], chanID.ToUint64())
+	byteOrder.PutUint64(k[8:], htlcID)
+
+	return k
+}
+
+// AddHeldHTLC persists an HTLC paying to the invoice with the passed payment
+// hash that is being held until its fate has been decided. Adding an HTLC
+// that's already held overwrites it.
+func (d *DB) AddHeldHTLC(paymentHash [32]byte, htlc InvoiceHTLC) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		heldHTLCs, err := tx.CreateBucketIfNotExists(heldHTLCBucket)
+		if err != nil {
+			return err
+		}
+
+		hashBucket, err := heldHTLCs.CreateBucketIfNotExists(
+			paymentHash[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		var v [16]byte
+		byteOrder.PutUint64(v[:8], uint64(htlc.Amt))
+		byteOrder.PutUint64(v[8:], uint64(htlc.AcceptTime.UnixNano()))
+
+		k := heldHTLCKey(htlc.ChanID, htlc.HtlcID)
+
+		return hashBucket.Put(k[:], v[:])
+	})
+}
+
+// DeleteHeldHTLC removes a held HTLC once it has been resolved. Deleting an
+// HTLC that isn't held is a noop.
+func (d *DB) DeleteHeldHTLC(paymentHash [32]byte,
+	chanID lnwire.ShortChannelID, htlcID uint64) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		heldHTLCs := tx.Bucket(heldHTLCBucket)
+		if heldHTLCs == nil {
+			return nil
+		}
+
+		hashBucket := heldHTLCs.Bucket(paymentHash[:])
+		if hashBucket == nil {
+			return nil
+		}
+
+		k := heldHTLCKey(chanID, htlcID)
+		if err := hashBucket.Delete(k[:]); err != nil {
+			return err
+		}
+
+		// Once the last HTLC for the payment hash is gone, we'll
+		// remove its sub-bucket as well.
+		if k, _ := hashBucket.Cursor().First(); k != nil {
+			return nil
+		}
+
+		return heldHTLCs.DeleteBucket(paymentHash[:])
+	})
+}
+
+// FetchHeldHTLCs returns all HTLCs that are currently being held, indexed by
+// the payment hash of the invoice they pay to.
+func (d *DB) FetchHeldHTLCs() (map[[32]byte][]InvoiceHTLC, error) {
+	held := make(map[[32]byte][]InvoiceHTLC)
+
+	err := d.View(func(tx *bolt.Tx) error {
+		heldHTLCs := tx.Bucket(heldHTLCBucket)
+		if heldHTLCs == nil {
+			return nil
+		}
+
+		return heldHTLCs.ForEach(func(paymentHash, _ []byte) error {
+			hashBucket := heldHTLCs.Bucket(paymentHash)
+			if hashBucket == nil {
+				return nil
+			}
+
+			var hash [32]byte
+			copy(hash[:], paymentHash)
+
+			return hashBucket.ForEach(func(k, v []byte) error {
+				held[hash] = append(held[hash], InvoiceHTLC{
+					ChanID: lnwire.NewShortChanIDFromInt(
+						byteOrder.Uint64(k[:8]),
+					),
+					HtlcID: byteOrder.Uint64(k[8:]),
+					Amt: lnwire.MilliSatoshi(
+						byteOrder.Uint64(v[:8]),
+					),
+					AcceptTime: time.Unix(
+						0, int64(byteOrder.Uint64(v[8:])),
+					),
+				})
+
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return held, nil
+}
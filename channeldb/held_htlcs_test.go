@@ -0,0 +1,104 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestHeldHTLCs tests that held HTLCs are returned grouped by their payment
+// hash until they're deleted.
+func TestHeldHTLCs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	held, err := db.FetchHeldHTLCs()
+	if err != nil {
+		t.Fatalf("unable to fetch held htlcs: %v", err)
+	}
+	if len(held) != 0 {
+		t.Fatalf("expected no held htlcs, got %v", len(held))
+	}
+
+	// We'll hold two HTLCs for the first payment hash, arriving over
+	// distinct channels, and a single one for the second.
+	hash1, hash2 := [32]byte{1}, [32]byte{2}
+	htlcs := []InvoiceHTLC{
+		{
+			ChanID:     lnwire.NewShortChanIDFromInt(1),
+			HtlcID:     5,
+			Amt:        1000,
+			AcceptTime: time.Unix(0, int64(time.Second)),
+		},
+		{
+			ChanID:     lnwire.NewShortChanIDFromInt(2),
+			HtlcID:     5,
+			Amt:        2000,
+			AcceptTime: time.Unix(0, int64(2*time.Second)),
+		},
+		{
+			ChanID:     lnwire.NewShortChanIDFromInt(1),
+			HtlcID:     6,
+			Amt:        3000,
+			AcceptTime: time.Unix(0, int64(3*time.Second)),
+		},
+	}
+	for i, hash := range [][32]byte{hash1, hash1, hash2} {
+		if err := db.AddHeldHTLC(hash, htlcs[i]); err != nil {
+			t.Fatalf("unable to add held htlc: %v", err)
+		}
+	}
+
+	assertHeld := func(expected map[[32]byte][]InvoiceHTLC) {
+		held, err := db.FetchHeldHTLCs()
+		if err != nil {
+			t.Fatalf("unable to fetch held htlcs: %v", err)
+		}
+		if !reflect.DeepEqual(held, expected) {
+			t.Fatalf("held htlcs mismatch: expected %v, got %v",
+				spew.Sdump(expected), spew.Sdump(held))
+		}
+	}
+
+	assertHeld(map[[32]byte][]InvoiceHTLC{
+		hash1: htlcs[:2],
+		hash2: htlcs[2:],
+	})
+
+	// Deleting an HTLC under the wrong payment hash shouldn't affect the
+	// HTLCs that are held.
+	err = db.DeleteHeldHTLC(hash2, htlcs[0].ChanID, htlcs[0].HtlcID)
+	if err != nil {
+		t.Fatalf("unable to delete held htlc: %v", err)
+	}
+	assertHeld(map[[32]byte][]InvoiceHTLC{
+		hash1: htlcs[:2],
+		hash2: htlcs[2:],
+	})
+
+	// Once the only HTLC of the second payment hash is deleted, the
+	// payment hash should no longer be returned.
+	err = db.DeleteHeldHTLC(hash2, htlcs[2].ChanID, htlcs[2].HtlcID)
+	if err != nil {
+		t.Fatalf("unable to delete held htlc: %v", err)
+	}
+	assertHeld(map[[32]byte][]InvoiceHTLC{
+		hash1: htlcs[:2],
+	})
+
+	err = db.DeleteHeldHTLC(hash1, htlcs[0].ChanID, htlcs[0].HtlcID)
+	if err != nil {
+		t.Fatalf("unable to delete held htlc: %v", err)
+	}
+	assertHeld(map[[32]byte][]InvoiceHTLC{
+		hash1: htlcs[1:2],
+	})
+}
@@ -97,7 +97,7 @@ func TestInvoiceWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch invoice: %v", err)
 	}
-	if !dbInvoice2.Terms.Settled {
+	if dbInvoice2.Terms.State != ContractSettled {
 		t.Fatalf("invoice should now be settled but isn't")
 	}
 
@@ -155,3 +155,528 @@ func TestInvoiceWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// TestInvoiceHTLCs tests that the HTLCs paying shards of a multi-path payment
+// are recorded with the invoice, and survive it being settled.
+func TestInvoiceHTLCs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	// Recording an HTLC for an unknown invoice should fail.
+	var fakeHash [32]byte
+	err = db.AddInvoiceHTLC(fakeHash, InvoiceHTLC{})
+	if err != ErrInvoiceNotFound {
+		t.Fatalf("expected ErrInvoiceNotFound, got %v", err)
+	}
+
+	// We'll pay the invoice using two HTLCs of half its value each.
+	paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	for i := 0; i < 2; i++ {
+		htlc := InvoiceHTLC{
+			ChanID:     lnwire.NewShortChanIDFromInt(uint64(i + 1)),
+			HtlcID:     uint64(i),
+			Amt:        invoice.Terms.Value / 2,
+			AcceptTime: time.Unix(int64(i+1), 0),
+		}
+		if err := db.AddInvoiceHTLC(paymentHash, htlc); err != nil {
+			t.Fatalf("unable to add htlc: %v", err)
+		}
+		invoice.Htlcs = append(invoice.Htlcs, htlc)
+	}
+
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if !reflect.DeepEqual(invoice, dbInvoice) {
+		t.Fatalf("invoice fetched from db doesn't match original %v vs %v",
+			spew.Sdump(invoice), spew.Sdump(dbInvoice))
+	}
+	if dbInvoice.AmtPaid() != invoice.Terms.Value {
+		t.Fatalf("expected amount paid of %v, got %v",
+			invoice.Terms.Value, dbInvoice.AmtPaid())
+	}
+
+	// Once settled, the HTLCs should still be part of the invoice.
+	if err := db.SettleInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	dbInvoice, err = db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if !reflect.DeepEqual(invoice.Htlcs, dbInvoice.Htlcs) {
+		t.Fatalf("htlcs fetched from db don't match original %v vs %v",
+			spew.Sdump(invoice.Htlcs), spew.Sdump(dbInvoice.Htlcs))
+	}
+}
+
+// TestHoldInvoice tests that a hold invoice is indexed by its payment hash, and
+// can only be settled with its preimage once it has been accepted.
+func TestHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// A hold invoice must be added along with its payment hash, as its
+	// preimage isn't known yet.
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	preimage := invoice.Terms.PaymentPreimage
+	invoice.Terms.PaymentPreimage = UnknownPreimage
+	if err := db.AddInvoice(invoice); err == nil {
+		t.Fatalf("expected hold invoice without payment hash to be " +
+			"rejected")
+	}
+
+	paymentHash := sha256.Sum256(preimage[:])
+	invoice.Terms.PaymentHash = paymentHash
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	// The invoice can't be settled before it has been accepted.
+	if err := db.SettleHoldInvoice(preimage); err != ErrInvoiceNotAccepted {
+		t.Fatalf("expected ErrInvoiceNotAccepted, got %v", err)
+	}
+	if err := db.AcceptInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractAccepted {
+		t.Fatalf("expected invoice to be accepted, is %v",
+			dbInvoice.Terms.State)
+	}
+
+	// Once settled, the preimage should be stored along side the invoice,
+	// and the invoice can no longer be canceled.
+	if err := db.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	dbInvoice, err = db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractSettled {
+		t.Fatalf("expected invoice to be settled, is %v",
+			dbInvoice.Terms.State)
+	}
+	if dbInvoice.Terms.PaymentPreimage != preimage {
+		t.Fatalf("expected preimage %x, got %x", preimage,
+			dbInvoice.Terms.PaymentPreimage)
+	}
+	if err := db.CancelInvoice(paymentHash); err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+}
+
+// TestCancelInvoice tests that a canceled invoice can no longer be accepted or
+// settled, and is no longer returned as a pending invoice.
+func TestCancelInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	paymentHash := invoice.Terms.PaymentHash
+	if err := db.CancelInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+
+	if err := db.CancelInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+	if err := db.AcceptInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+	if err := db.SettleInvoice(paymentHash); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(pending))
+	}
+}
+
+// TestExpireInvoice tests that only open invoices can be expired, and that an
+// expired invoice can no longer be paid or canceled.
+func TestExpireInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	invoice.Expiry = time.Hour
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	// The expiry of the invoice should be stored along side it.
+	paymentHash := invoice.Terms.PaymentHash
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	expiryTime, ok := dbInvoice.ExpiryTime()
+	if !ok || !expiryTime.Equal(invoice.CreationDate.Add(time.Hour)) {
+		t.Fatalf("expected invoice to expire at %v, got %v",
+			invoice.CreationDate.Add(time.Hour), expiryTime)
+	}
+
+	if err := db.ExpireInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to expire invoice: %v", err)
+	}
+	dbInvoice, err = db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if dbInvoice.Terms.State != ContractExpired {
+		t.Fatalf("expected invoice to be expired, is %v",
+			dbInvoice.Terms.State)
+	}
+
+	if err := db.ExpireInvoice(paymentHash); err != ErrInvoiceNotOpen {
+		t.Fatalf("expected ErrInvoiceNotOpen, got %v", err)
+	}
+	if err := db.AcceptInvoice(paymentHash); err != ErrInvoiceAlreadyExpired {
+		t.Fatalf("expected ErrInvoiceAlreadyExpired, got %v", err)
+	}
+	if err := db.SettleInvoice(paymentHash); err != ErrInvoiceAlreadyExpired {
+		t.Fatalf("expected ErrInvoiceAlreadyExpired, got %v", err)
+	}
+	if err := db.CancelInvoice(paymentHash); err != ErrInvoiceAlreadyExpired {
+		t.Fatalf("expected ErrInvoiceAlreadyExpired, got %v", err)
+	}
+
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(pending))
+	}
+}
+
+// TestDeleteCanceledInvoices tests that canceled and expired invoices are
+// deleted, while all other invoices are left untouched.
+func TestDeleteCanceledInvoices(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var invoices []*Invoice
+	for i := 0; i < 4; i++ {
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	// We'll leave the first invoice open, settle the second, cancel the
+	// third and expire the fourth.
+	if err := db.SettleInvoice(invoices[1].Terms.PaymentHash); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	if err := db.CancelInvoice(invoices[2].Terms.PaymentHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	if err := db.ExpireInvoice(invoices[3].Terms.PaymentHash); err != nil {
+		t.Fatalf("unable to expire invoice: %v", err)
+	}
+
+	numDeleted, err := db.DeleteCanceledInvoices()
+	if err != nil {
+		t.Fatalf("unable to delete invoices: %v", err)
+	}
+	if numDeleted != 2 {
+		t.Fatalf("expected 2 deleted invoices, got %v", numDeleted)
+	}
+
+	for i, invoice := range invoices {
+		_, err := db.LookupInvoice(invoice.Terms.PaymentHash)
+		switch {
+		case i < 2 && err != nil:
+			t.Fatalf("unable to fetch invoice %v: %v", i, err)
+		case i >= 2 && err != ErrInvoiceNotFound:
+			t.Fatalf("expected invoice %v to be deleted, got %v",
+				i, err)
+		}
+	}
+
+	all, err := db.FetchAllInvoices(false)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 invoices, got %v", len(all))
+	}
+
+	// Deleting once again should be a no-op.
+	numDeleted, err = db.DeleteCanceledInvoices()
+	if err != nil {
+		t.Fatalf("unable to delete invoices: %v", err)
+	}
+	if numDeleted != 0 {
+		t.Fatalf("expected no deleted invoices, got %v", numDeleted)
+	}
+}
+
+// TestInvoiceIndexes tests that invoices are assigned monotonically increasing
+// add and settle indexes, and that the invoices added or settled since a
+// particular index can be fetched.
+func TestInvoiceIndexes(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	const numInvoices = 5
+	var invoices []*Invoice
+	for i := 0; i < numInvoices; i++ {
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+		if invoice.AddIndex != uint64(i+1) {
+			t.Fatalf("expected add index %v, got %v", i+1,
+				invoice.AddIndex)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	// We'll settle the invoices in reverse order, skipping the first one.
+	for i := numInvoices - 1; i > 0; i-- {
+		paymentHash := invoices[i].Terms.PaymentHash
+		if err := db.SettleInvoice(paymentHash); err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+	}
+
+	added, err := db.InvoicesAddedSince(2)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(added) != numInvoices-2 {
+		t.Fatalf("expected %v invoices, got %v", numInvoices-2,
+			len(added))
+	}
+	for i, invoice := range added {
+		if invoice.AddIndex != uint64(i+3) {
+			t.Fatalf("expected add index %v, got %v", i+3,
+				invoice.AddIndex)
+		}
+	}
+
+	settled, err := db.InvoicesSettledSince(0)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(settled) != numInvoices-1 {
+		t.Fatalf("expected %v invoices, got %v", numInvoices-1,
+			len(settled))
+	}
+	for i, invoice := range settled {
+		if invoice.SettleIndex != uint64(i+1) {
+			t.Fatalf("expected settle index %v, got %v", i+1,
+				invoice.SettleIndex)
+		}
+
+		expectedHash := invoices[numInvoices-1-i].Terms.PaymentHash
+		if invoice.Terms.PaymentHash != expectedHash {
+			t.Fatalf("expected invoice %x to be settled at "+
+				"index %v", expectedHash, i+1)
+		}
+	}
+
+	// There are no invoices settled past the last settle index.
+	settled, err = db.InvoicesSettledSince(numInvoices - 1)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(settled) != 0 {
+		t.Fatalf("expected no invoices, got %v", len(settled))
+	}
+}
+
+// TestQueryInvoices tests that invoices can be paged through both forwards and
+// backwards using the add index.
+func TestQueryInvoices(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// We'll add ten invoices, settling every other one.
+	const numInvoices = 10
+	for i := 0; i < numInvoices; i++ {
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		if i%2 == 0 {
+			continue
+		}
+		err = db.SettleInvoice(invoice.Terms.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query InvoiceQuery
+
+		// expected is the set of add indexes of the invoices that
+		// should be returned.
+		expected []uint64
+	}{
+		{
+			query:    InvoiceQuery{},
+			expected: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset:    3,
+				NumMaxInvoices: 4,
+			},
+			expected: []uint64{4, 5, 6, 7},
+		},
+		{
+			query: InvoiceQuery{
+				NumMaxInvoices: 3,
+				Reversed:       true,
+			},
+			expected: []uint64{8, 9, 10},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset:    8,
+				NumMaxInvoices: 3,
+				Reversed:       true,
+			},
+			expected: []uint64{5, 6, 7},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset: 2,
+				Reversed:    true,
+			},
+			expected: []uint64{1},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset: 100,
+				Reversed:    true,
+				PendingOnly: true,
+			},
+			expected: []uint64{1, 3, 5, 7, 9},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset:    4,
+				NumMaxInvoices: 2,
+				PendingOnly:    true,
+			},
+			expected: []uint64{5, 7},
+		},
+		{
+			query: InvoiceQuery{
+				IndexOffset: numInvoices,
+			},
+			expected: nil,
+		},
+	}
+
+	for i, test := range tests {
+		resp, err := db.QueryInvoices(test.query)
+		if err != nil {
+			t.Fatalf("test #%v: unable to query invoices: %v", i,
+				err)
+		}
+
+		var indexes []uint64
+		for _, invoice := range resp.Invoices {
+			indexes = append(indexes, invoice.AddIndex)
+		}
+		if !reflect.DeepEqual(test.expected, indexes) {
+			t.Fatalf("test #%v: expected add indexes %v, got %v",
+				i, test.expected, indexes)
+		}
+
+		if len(indexes) == 0 {
+			continue
+		}
+		if resp.FirstIndexOffset != indexes[0] ||
+			resp.LastIndexOffset != indexes[len(indexes)-1] {
+
+			t.Fatalf("test #%v: expected index offsets %v and "+
+				"%v, got %v and %v", i, indexes[0],
+				indexes[len(indexes)-1], resp.FirstIndexOffset,
+				resp.LastIndexOffset)
+		}
+	}
+}
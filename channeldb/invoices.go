@@ -34,6 +34,23 @@ var (
 	// stored within the invoiceIndexBucket. Within the invoiceBucket
 	// invoices are uniquely identified by the invoice ID.
 	numInvoicesKey = []byte("nik")
+
+	// addIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all invoices by their add index. The add
+	// index is a monotonically increasing uint64 assigned to each invoice
+	// once it's added, which allows callers to fetch all invoices added
+	// since a particular point in time.
+	//
+	// maps: addIndex => invoiceKey
+	addIndexBucket = []byte("invoice-add-index")
+
+	// settleIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all settled invoices by their settle
+	// index. Similar to the add index, the settle index is a monotonically
+	// increasing uint64 assigned to each invoice once it's settled.
+	//
+	// maps: settleIndex => invoiceKey
+	settleIndexBucket = []byte("invoice-settle-index")
 )
 
 const (
@@ -50,24 +67,78 @@ const (
 	// TODO(halseth): determine the max length payment request when field
 	// lengths are final.
 	MaxPaymentRequestSize = 4096
+
+	// MaxInvoiceHTLCs is the maximum number of HTLCs that may be recorded
+	// as having paid towards a single invoice.
+	MaxInvoiceHTLCs = 1000
+)
+
+// UnknownPreimage is the preimage of a hold invoice, which only becomes known
+// once the invoice is settled.
+var UnknownPreimage [32]byte
+
+// ContractState describes the state the contract of an invoice is in.
+type ContractState uint8
+
+const (
+	// ContractOpen means the invoice has not been paid yet.
+	ContractOpen ContractState = 0
+
+	// ContractSettled means the invoice has been paid in full.
+	ContractSettled ContractState = 1
+
+	// ContractCanceled means the invoice has been canceled, and no HTLC
+	// paying to it will be accepted.
+	ContractCanceled ContractState = 2
+
+	// ContractAccepted means that HTLCs paying the full value of a hold
+	// invoice have been accepted, and are being held until the invoice is
+	// either settled or canceled.
+	ContractAccepted ContractState = 3
+
+	// ContractExpired means the expiry of the invoice has passed before it
+	// was paid, and no HTLC paying to it will be accepted.
+	ContractExpired ContractState = 4
 )
 
+// String returns a human readable identifier for the contract state.
+func (c ContractState) String() string {
+	switch c {
+	case ContractOpen:
+		return "Open"
+	case ContractSettled:
+		return "Settled"
+	case ContractCanceled:
+		return "Canceled"
+	case ContractAccepted:
+		return "Accepted"
+	case ContractExpired:
+		return "Expired"
+	default:
+		return "Unknown"
+	}
+}
+
 // ContractTerm is a companion struct to the Invoice struct. This struct houses
 // the necessary conditions required before the invoice can be considered fully
 // settled by the payee.
 type ContractTerm struct {
 	// PaymentPreimage is the preimage which is to be revealed in the
 	// occasion that an HTLC paying to the hash of this preimage is
-	// extended.
+	// extended. For a hold invoice, this is UnknownPreimage until the
+	// invoice is settled.
 	PaymentPreimage [32]byte
 
+	// PaymentHash is the hash that HTLCs paying to this invoice must be
+	// locked to. For regular invoices this is the hash of the preimage.
+	PaymentHash [32]byte
+
 	// Value is the expected amount of milli-satoshis to be payed to an
 	// HTLC which can be satisfied by the above preimage.
 	Value lnwire.MilliSatoshi
 
-	// Settled indicates if this particular contract term has been fully
-	// settled by the payer.
-	Settled bool
+	// State is the state the contract of the invoice is in.
+	State ContractState
 }
 
 // Invoice is a payment invoice generated by a payee in order to request
@@ -75,12 +146,11 @@ type ContractTerm struct {
 // creates a payment work flow for merchants very similar to that of the
 // existing financial system within PayPal, etc.  Invoices are added to the
 // database when a payment is requested, then can be settled manually once the
-// payment is received at the upper layer. For record keeping purposes,
-// invoices are never deleted from the database, instead a bit is toggled
-// denoting the invoice has been fully settled. Within the database, all
-// invoices must have a unique payment hash which is generated by taking the
-// sha256 of the payment
-// preimage.
+// payment is received at the upper layer. For record keeping purposes, settled
+// invoices are never deleted from the database, while invoices that have been
+// canceled or have expired may be deleted using DeleteCanceledInvoices.
+// Within the database, all invoices must have a unique payment hash which is
+// generated by taking the sha256 of the payment preimage.
 type Invoice struct {
 	// Memo is an optional memo to be stored along side an invoice.  The
 	// memo may contain further details pertaining to the invoice itself,
@@ -103,6 +173,12 @@ type Invoice struct {
 	// SettleDate is the exact time the invoice was settled.
 	SettleDate time.Time
 
+	// Expiry is the duration after the creation date of the invoice for
+	// which it may be paid. Once it has passed, the invoice is expired if
+	// it hasn't been paid yet. An expiry of zero means that the invoice
+	// never expires.
+	Expiry time.Duration
+
 	// Terms are the contractual payment terms of the invoice. Once
 	// all the terms have been satisfied by the payer, then the invoice can
 	// be considered fully fulfilled.
@@ -110,6 +186,76 @@ type Invoice struct {
 	// TODO(roasbeef): later allow for multiple terms to fulfill the final
 	// invoice: payment fragmentation, etc.
 	Terms ContractTerm
+
+	// Htlcs is the set of HTLCs that have been held for this invoice,
+	// either as shards of a multi-path payment, or as it's a hold invoice.
+	// If the invoice was paid using a single HTLC that was settled right
+	// away, then this set is empty.
+	Htlcs []InvoiceHTLC
+
+	// AddIndex is the position of the invoice within the add index. Each
+	// invoice is assigned the next add index once it's added, so an
+	// invoice with a higher add index was added after all invoices with a
+	// lower one.
+	AddIndex uint64
+
+	// SettleIndex is the position of the invoice within the settle index.
+	// Similar to the add index, an invoice is assigned the next settle
+	// index once it's settled. This is zero if the invoice hasn't been
+	// settled yet.
+	SettleIndex uint64
+}
+
+// InvoiceHTLC describes an HTLC that paid a shard of a multi-path payment to
+// an invoice.
+type InvoiceHTLC struct {
+	// ChanID is the short channel ID of the channel the HTLC arrived over.
+	ChanID lnwire.ShortChannelID
+
+	// HtlcID is the index of the HTLC within the channel.
+	HtlcID uint64
+
+	// Amt is the amount paid by the HTLC.
+	Amt lnwire.MilliSatoshi
+
+	// AcceptTime is the time the HTLC was accepted.
+	AcceptTime time.Time
+}
+
+// AmtPaid returns the total amount that has been paid to the invoice. For an
+// invoice paid using a single HTLC, this is the value of the invoice once it
+// has been settled.
+func (i *Invoice) AmtPaid() lnwire.MilliSatoshi {
+	if len(i.Htlcs) == 0 {
+		if i.Terms.State == ContractSettled {
+			return i.Terms.Value
+		}
+		return 0
+	}
+
+	var amtPaid lnwire.MilliSatoshi
+	for _, htlc := range i.Htlcs {
+		amtPaid += htlc.Amt
+	}
+
+	return amtPaid
+}
+
+// ExpiryTime returns the time at which the invoice expires, and whether it
+// expires at all.
+func (i *Invoice) ExpiryTime() (time.Time, bool) {
+	if i.Expiry == 0 {
+		return time.Time{}, false
+	}
+
+	return i.CreationDate.Add(i.Expiry), true
+}
+
+// IsPending returns true if the invoice may still be paid, or is a hold invoice
+// whose HTLCs are being held until it's either settled or canceled.
+func (i *Invoice) IsPending() bool {
+	return i.Terms.State == ContractOpen ||
+		i.Terms.State == ContractAccepted
 }
 
 func validateInvoice(i *Invoice) error {
@@ -133,11 +279,22 @@ func validateInvoice(i *Invoice) error {
 // AddInvoice inserts the targeted invoice into the database. If the invoice
 // has *any* payment hashes which already exists within the database, then the
 // insertion will be aborted and rejected due to the strict policy banning any
-// duplicate payment hashes.
+// duplicate payment hashes. If the preimage of the invoice is known, then its
+// payment hash is set to the hash of the preimage. Otherwise, the invoice is
+// a hold invoice, and its payment hash must already be set. Once added, the
+// AddIndex of the invoice is set to the position it was assigned within the
+// add index.
 func (d *DB) AddInvoice(i *Invoice) error {
 	if err := validateInvoice(i); err != nil {
 		return err
 	}
+
+	if i.Terms.PaymentPreimage != UnknownPreimage {
+		i.Terms.PaymentHash = sha256.Sum256(i.Terms.PaymentPreimage[:])
+	} else if i.Terms.PaymentHash == UnknownPreimage {
+		return fmt.Errorf("hold invoice must have a payment hash")
+	}
+
 	return d.Update(func(tx *bolt.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
 		if err != nil {
@@ -148,11 +305,14 @@ func (d *DB) AddInvoice(i *Invoice) error {
 		if err != nil {
 			return err
 		}
+		addIndex, err := invoices.CreateBucketIfNotExists(addIndexBucket)
+		if err != nil {
+			return err
+		}
 
 		// Ensure that an invoice an identical payment hash doesn't
 		// already exist within the index.
-		paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
-		if invoiceIndex.Get(paymentHash[:]) != nil {
+		if invoiceIndex.Get(i.Terms.PaymentHash[:]) != nil {
 			return ErrDuplicateInvoice
 		}
 
@@ -170,7 +330,7 @@ func (d *DB) AddInvoice(i *Invoice) error {
 			invoiceNum = byteOrder.Uint32(invoiceCounter)
 		}
 
-		return putInvoice(invoices, invoiceIndex, i, invoiceNum)
+		return putInvoice(invoices, invoiceIndex, addIndex, i, invoiceNum)
 	})
 }
 
@@ -217,8 +377,9 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 }
 
 // FetchAllInvoices returns all invoices currently stored within the database.
-// If the pendingOnly param is true, then only unsettled invoices will be
-// returned, skipping all invoices that are fully settled.
+// If the pendingOnly param is true, then only invoices that may still be paid
+// will be returned, skipping all invoices that are fully settled, canceled or
+// expired.
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
@@ -237,12 +398,12 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 			}
 
 			invoiceReader := bytes.NewReader(v)
-			invoice, err := deserializeInvoice(invoiceReader)
+			invoice, err := deserializeInvoiceRecord(invoiceReader)
 			if err != nil {
 				return err
 			}
 
-			if pendingOnly && invoice.Terms.Settled {
+			if pendingOnly && !invoice.IsPending() {
 				return nil
 			}
 
@@ -258,6 +419,197 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	return invoices, nil
 }
 
+// InvoiceQuery represents a query to the invoice database. The query allows a
+// caller to retrieve all invoices starting from a particular add index and
+// limit the number of results returned.
+type InvoiceQuery struct {
+	// IndexOffset is the offset within the add index to start querying
+	// from. The invoice at this add index is excluded from the results.
+	// If Reversed is set, then the query is done backwards from this
+	// offset, and an offset of zero starts from the most recently added
+	// invoice.
+	IndexOffset uint64
+
+	// NumMaxInvoices is the maximum number of invoices that should be
+	// returned. If zero, then all matching invoices are returned.
+	NumMaxInvoices uint64
+
+	// PendingOnly, if set, returns only the invoices that may still be
+	// paid.
+	PendingOnly bool
+
+	// Reversed, if set, queries the invoices that were added before the
+	// index offset, rather than those added after it. This allows a
+	// caller to page through the invoices starting from the most recent.
+	Reversed bool
+}
+
+// InvoiceSlice is the response to an invoice query. It includes the original
+// query, the set of invoices that matched the query, and the add indexes of
+// the first and last invoice within the set, which can be used as the offset
+// of a subsequent query in order to continue paging.
+type InvoiceSlice struct {
+	InvoiceQuery
+
+	// Invoices is the set of invoices that matched the query, ordered by
+	// their add index.
+	Invoices []*Invoice
+
+	// FirstIndexOffset is the add index of the first invoice within the
+	// set.
+	FirstIndexOffset uint64
+
+	// LastIndexOffset is the add index of the last invoice within the set.
+	LastIndexOffset uint64
+}
+
+// QueryInvoices returns the set of invoices that match the passed query,
+// walking the add index either forwards or backwards from the query's index
+// offset.
+func (d *DB) QueryInvoices(q InvoiceQuery) (InvoiceSlice, error) {
+	resp := InvoiceSlice{
+		InvoiceQuery: q,
+	}
+
+	err := d.View(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return nil
+		}
+		addIndex := invoices.Bucket(addIndexBucket)
+		if addIndex == nil {
+			return nil
+		}
+
+		var startKey [8]byte
+		byteOrder.PutUint64(startKey[:], q.IndexOffset)
+
+		// We'll position the cursor at the first invoice past the
+		// index offset, in the direction of the query.
+		c := addIndex.Cursor()
+		var k, v []byte
+		switch {
+		case q.Reversed && q.IndexOffset == 0:
+			k, v = c.Last()
+
+		// Seek positions the cursor at the first invoice at or past the
+		// offset, so we'll step back to the one preceding it. If there
+		// is none, then all invoices precede the offset.
+		case q.Reversed:
+			k, v = c.Seek(startKey[:])
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+
+		default:
+			k, v = c.Seek(startKey[:])
+			if k != nil && byteOrder.Uint64(k) == q.IndexOffset {
+				k, v = c.Next()
+			}
+		}
+
+		for k != nil {
+			if q.NumMaxInvoices != 0 &&
+				uint64(len(resp.Invoices)) >= q.NumMaxInvoices {
+
+				break
+			}
+
+			invoice, err := fetchInvoice(v, invoices)
+			if err != nil {
+				return err
+			}
+
+			if !q.PendingOnly || invoice.IsPending() {
+				resp.Invoices = append(resp.Invoices, invoice)
+			}
+
+			if q.Reversed {
+				k, v = c.Prev()
+			} else {
+				k, v = c.Next()
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	// If the query was reversed, then the invoices were gathered from the
+	// most recent one, so we'll restore their order.
+	if q.Reversed {
+		numInvoices := len(resp.Invoices)
+		for i := 0; i < numInvoices/2; i++ {
+			j := numInvoices - i - 1
+			resp.Invoices[i], resp.Invoices[j] =
+				resp.Invoices[j], resp.Invoices[i]
+		}
+	}
+
+	if len(resp.Invoices) > 0 {
+		resp.FirstIndexOffset = resp.Invoices[0].AddIndex
+		resp.LastIndexOffset = resp.Invoices[len(resp.Invoices)-1].AddIndex
+	}
+
+	return resp, nil
+}
+
+// InvoicesAddedSince returns all invoices with an add index greater than the
+// passed add index, ordered by their add index.
+func (d *DB) InvoicesAddedSince(sinceAddIndex uint64) ([]*Invoice, error) {
+	return d.invoicesSince(addIndexBucket, sinceAddIndex)
+}
+
+// InvoicesSettledSince returns all invoices with a settle index greater than
+// the passed settle index, ordered by their settle index.
+func (d *DB) InvoicesSettledSince(sinceSettleIndex uint64) ([]*Invoice,
+	error) {
+
+	return d.invoicesSince(settleIndexBucket, sinceSettleIndex)
+}
+
+// invoicesSince returns all invoices within the passed index bucket whose
+// index is greater than sinceIndex.
+func (d *DB) invoicesSince(indexBucket []byte,
+	sinceIndex uint64) ([]*Invoice, error) {
+
+	var invoices []*Invoice
+	err := d.View(func(tx *bolt.Tx) error {
+		invoiceB := tx.Bucket(invoiceBucket)
+		if invoiceB == nil {
+			return nil
+		}
+		index := invoiceB.Bucket(indexBucket)
+		if index == nil {
+			return nil
+		}
+
+		var startKey [8]byte
+		byteOrder.PutUint64(startKey[:], sinceIndex+1)
+
+		c := index.Cursor()
+		for k, v := c.Seek(startKey[:]); k != nil; k, v = c.Next() {
+			invoice, err := fetchInvoice(v, invoiceB)
+			if err != nil {
+				return err
+			}
+
+			invoices = append(invoices, invoice)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
 // SettleInvoice attempts to mark an invoice corresponding to the passed
 // payment hash as fully settled. If an invoice matching the passed payment
 // hash doesn't existing within the database, then the action will fail with a
@@ -284,7 +636,257 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 	})
 }
 
-func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
+// AddInvoiceHTLC records an HTLC that has been accepted as a shard of a
+// multi-path payment to the invoice corresponding to the passed payment hash.
+// If an invoice matching the passed payment hash doesn't exist within the
+// database, then the action will fail with a "not found" error.
+func (d *DB) AddInvoiceHTLC(paymentHash [32]byte, htlc InvoiceHTLC) error {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		if len(invoice.Htlcs) >= MaxInvoiceHTLCs {
+			return fmt.Errorf("invoice already has the max number "+
+				"of %v htlcs", MaxInvoiceHTLCs)
+		}
+		invoice.Htlcs = append(invoice.Htlcs, htlc)
+
+		return nil
+	})
+}
+
+// AcceptInvoice marks the hold invoice corresponding to the passed payment
+// hash as accepted, signalling that HTLCs paying its full value are being
+// held until the invoice is either settled or canceled.
+func (d *DB) AcceptInvoice(paymentHash [32]byte) error {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		case ContractExpired:
+			return ErrInvoiceAlreadyExpired
+		}
+
+		invoice.Terms.State = ContractAccepted
+
+		return nil
+	})
+}
+
+// SettleHoldInvoice settles the accepted hold invoice that pays to the hash of
+// the passed preimage, storing the preimage along side the invoice. If the
+// invoice hasn't yet been accepted, then ErrInvoiceNotAccepted is returned.
+func (d *DB) SettleHoldInvoice(preimage [32]byte) error {
+	paymentHash := sha256.Sum256(preimage[:])
+
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		case ContractExpired:
+			return ErrInvoiceAlreadyExpired
+		case ContractOpen:
+			return ErrInvoiceNotAccepted
+		}
+
+		invoice.Terms.PaymentPreimage = preimage
+		invoice.Terms.State = ContractSettled
+		invoice.SettleDate = time.Now()
+
+		return nil
+	})
+}
+
+// CancelInvoice marks the invoice corresponding to the passed payment hash as
+// canceled. Once canceled, an invoice can no longer be paid. Invoices that
+// have already been settled can't be canceled.
+func (d *DB) CancelInvoice(paymentHash [32]byte) error {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		switch invoice.Terms.State {
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		case ContractExpired:
+			return ErrInvoiceAlreadyExpired
+		}
+
+		invoice.Terms.State = ContractCanceled
+
+		return nil
+	})
+}
+
+// ExpireInvoice marks the open invoice corresponding to the passed payment
+// hash as expired. Once expired, an invoice can no longer be paid. If the
+// invoice is no longer open, then ErrInvoiceNotOpen is returned.
+func (d *DB) ExpireInvoice(paymentHash [32]byte) error {
+	return d.updateInvoice(paymentHash, func(invoice *Invoice) error {
+		if invoice.Terms.State != ContractOpen {
+			return ErrInvoiceNotOpen
+		}
+
+		invoice.Terms.State = ContractExpired
+
+		return nil
+	})
+}
+
+// DeleteCanceledInvoices deletes all invoices that have either been canceled
+// or have expired, along with their entries within the payment hash and add
+// indexes.
+// The number of deleted invoices is returned.
+func (d *DB) DeleteCanceledInvoices() (int, error) {
+	var numDeleted int
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return nil
+		}
+		invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+		if invoiceIndex == nil {
+			return nil
+		}
+
+		addIndex := invoices.Bucket(addIndexBucket)
+
+		// We'll first gather the invoices to be deleted, as modifying
+		// a bucket while iterating over it isn't safe.
+		var (
+			invoiceKeys, paymentHashes [][]byte
+			addIndexes                 []uint64
+		)
+		err := invoices.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			invoice, err := deserializeInvoiceRecord(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			switch invoice.Terms.State {
+			case ContractCanceled, ContractExpired:
+			default:
+				return nil
+			}
+
+			paymentHash := invoice.Terms.PaymentHash
+			invoiceKeys = append(invoiceKeys, append([]byte(nil), k...))
+			paymentHashes = append(paymentHashes, paymentHash[:])
+			addIndexes = append(addIndexes, invoice.AddIndex)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// The invoice counter and the sequence of the add index are
+		// left untouched, so the IDs and add indexes of deleted
+		// invoices are never reused. As only settled invoices have a
+		// settle index, the settle index is left untouched as well.
+		for i := range invoiceKeys {
+			if err := invoiceIndex.Delete(paymentHashes[i]); err != nil {
+				return err
+			}
+			if addIndex != nil && addIndexes[i] != 0 {
+				var indexKey [8]byte
+				byteOrder.PutUint64(indexKey[:], addIndexes[i])
+				if err := addIndex.Delete(indexKey[:]); err != nil {
+					return err
+				}
+			}
+			if err := invoices.Delete(invoiceKeys[i]); err != nil {
+				return err
+			}
+		}
+
+		numDeleted = len(invoiceKeys)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
+
+// updateInvoice fetches the invoice corresponding to the passed payment hash,
+// applies the passed modification to it, and writes it back to the database
+// within a single transaction.
+func (d *DB) updateInvoice(paymentHash [32]byte,
+	update func(invoice *Invoice) error) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+		if err != nil {
+			return err
+		}
+		invoiceIndex, err := invoices.CreateBucketIfNotExists(invoiceIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		invoice, err := fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		if err := update(invoice); err != nil {
+			return err
+		}
+
+		return putInvoiceUpdate(invoices, invoiceNum, invoice)
+	})
+}
+
+// putInvoiceUpdate writes the updated invoice back to the invoice bucket. If
+// the invoice has just been settled, then it's assigned the next settle index
+// beforehand.
+func putInvoiceUpdate(invoices *bolt.Bucket, invoiceNum []byte,
+	invoice *Invoice) error {
+
+	if invoice.Terms.State == ContractSettled && invoice.SettleIndex == 0 {
+		settleIndex, err := invoices.CreateBucketIfNotExists(
+			settleIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		nextSettleIndex, err := settleIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+		var indexKey [8]byte
+		byteOrder.PutUint64(indexKey[:], nextSettleIndex)
+		err = settleIndex.Put(indexKey[:], invoiceNum)
+		if err != nil {
+			return err
+		}
+		invoice.SettleIndex = nextSettleIndex
+	}
+
+	var buf bytes.Buffer
+	if err := serializeInvoiceRecord(&buf, invoice); err != nil {
+		return err
+	}
+
+	return invoices.Put(invoiceNum[:], buf.Bytes())
+}
+
+func putInvoice(invoices, invoiceIndex, addIndex *bolt.Bucket,
 	i *Invoice, invoiceNum uint32) error {
 
 	// Create the invoice key which is just the big-endian representation
@@ -304,14 +906,27 @@ func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
 	// Add the payment hash to the invoice index. This'll let us quickly
 	// identify if we can settle an incoming payment, and also to possibly
 	// allow a single invoice to have multiple payment installations.
-	paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
+	paymentHash := i.Terms.PaymentHash
 	if err := invoiceIndex.Put(paymentHash[:], invoiceKey[:]); err != nil {
 		return err
 	}
 
+	// Next, we'll assign the invoice the next add index, so that it can be
+	// found by those fetching the invoices added since a prior index.
+	nextAddIndex, err := addIndex.NextSequence()
+	if err != nil {
+		return err
+	}
+	var indexKey [8]byte
+	byteOrder.PutUint64(indexKey[:], nextAddIndex)
+	if err := addIndex.Put(indexKey[:], invoiceKey[:]); err != nil {
+		return err
+	}
+	i.AddIndex = nextAddIndex
+
 	// Finally, serialize the invoice itself to be written to the disk.
 	var buf bytes.Buffer
-	if err := serializeInvoice(&buf, i); err != nil {
+	if err := serializeInvoiceRecord(&buf, i); err != nil {
 		return nil
 	}
 
@@ -357,13 +972,51 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		return err
 	}
 
-	if err := binary.Write(w, byteOrder, i.Terms.Settled); err != nil {
+	if err := binary.Write(w, byteOrder, uint8(i.Terms.State)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// serializeInvoiceRecord serializes an invoice as it's stored within the
+// invoice bucket: the invoice itself, followed by the HTLCs that paid to it,
+// the payment hash, the expiry, and the add and settle indexes of the invoice.
+func serializeInvoiceRecord(w io.Writer, i *Invoice) error {
+	if err := serializeInvoice(w, i); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, uint16(len(i.Htlcs))); err != nil {
+		return err
+	}
+
+	for _, htlc := range i.Htlcs {
+		var b [32]byte
+		byteOrder.PutUint64(b[0:], htlc.ChanID.ToUint64())
+		byteOrder.PutUint64(b[8:], htlc.HtlcID)
+		byteOrder.PutUint64(b[16:], uint64(htlc.Amt))
+		byteOrder.PutUint64(b[24:], uint64(htlc.AcceptTime.UnixNano()))
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(i.Terms.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, uint64(i.Expiry)); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, i.AddIndex); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, i.SettleIndex)
+}
+
 func fetchInvoice(invoiceNum []byte, invoices *bolt.Bucket) (*Invoice, error) {
 	invoiceBytes := invoices.Get(invoiceNum)
 	if invoiceBytes == nil {
@@ -372,7 +1025,58 @@ func fetchInvoice(invoiceNum []byte, invoices *bolt.Bucket) (*Invoice, error) {
 
 	invoiceReader := bytes.NewReader(invoiceBytes)
 
-	return deserializeInvoice(invoiceReader)
+	return deserializeInvoiceRecord(invoiceReader)
+}
+
+// deserializeInvoiceRecord reads an invoice along with the HTLCs that paid to
+// it, its payment hash, expiry and indexes, as written by
+// serializeInvoiceRecord.
+func deserializeInvoiceRecord(r io.Reader) (*Invoice, error) {
+	invoice, err := deserializeInvoice(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var numHtlcs uint16
+	if err := binary.Read(r, byteOrder, &numHtlcs); err != nil {
+		return nil, err
+	}
+
+	for i := uint16(0); i < numHtlcs; i++ {
+		var b [32]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+
+		invoice.Htlcs = append(invoice.Htlcs, InvoiceHTLC{
+			ChanID: lnwire.NewShortChanIDFromInt(
+				byteOrder.Uint64(b[0:]),
+			),
+			HtlcID:     byteOrder.Uint64(b[8:]),
+			Amt:        lnwire.MilliSatoshi(byteOrder.Uint64(b[16:])),
+			AcceptTime: time.Unix(0, int64(byteOrder.Uint64(b[24:]))),
+		})
+	}
+
+	_, err = io.ReadFull(r, invoice.Terms.PaymentHash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var expiry uint64
+	if err := binary.Read(r, byteOrder, &expiry); err != nil {
+		return nil, err
+	}
+	invoice.Expiry = time.Duration(expiry)
+
+	if err := binary.Read(r, byteOrder, &invoice.AddIndex); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, byteOrder, &invoice.SettleIndex); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
 }
 
 func deserializeInvoice(r io.Reader) (*Invoice, error) {
@@ -419,9 +1123,11 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	}
 	invoice.Terms.Value = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
 
-	if err := binary.Read(r, byteOrder, &invoice.Terms.Settled); err != nil {
+	var state uint8
+	if err := binary.Read(r, byteOrder, &state); err != nil {
 		return nil, err
 	}
+	invoice.Terms.State = ContractState(state)
 
 	return invoice, nil
 }
@@ -432,13 +1138,15 @@ func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
 		return err
 	}
 
-	invoice.Terms.Settled = true
-	invoice.SettleDate = time.Now()
-
-	var buf bytes.Buffer
-	if err := serializeInvoice(&buf, invoice); err != nil {
-		return nil
+	switch invoice.Terms.State {
+	case ContractCanceled:
+		return ErrInvoiceAlreadyCanceled
+	case ContractExpired:
+		return ErrInvoiceAlreadyExpired
 	}
 
-	return invoices.Put(invoiceNum[:], buf.Bytes())
+	invoice.Terms.State = ContractSettled
+	invoice.SettleDate = time.Now()
+
+	return putInvoiceUpdate(invoices, invoiceNum, invoice)
 }
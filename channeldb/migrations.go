@@ -1 +1,260 @@
 package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// migrateMultiPathRecords is the migration function that adds the records of
+// multi-path payments to the existing invoices and outgoing payments. Each
+// stored invoice is extended with an empty set of the HTLCs that paid to it,
+// and each stored payment with an empty set of shards.
+func migrateMultiPathRecords(tx *bolt.Tx) error {
+	// Both the HTLCs of an invoice and the shards of a payment are
+	// serialized as a uint16 count followed by the entries themselves, so
+	// an empty set is just a zero count appended to the existing record.
+	var emptySet [2]byte
+
+	appendEmptySet := func(bucket *bolt.Bucket) error {
+		// We'll first gather all records, as modifying a bucket while
+		// iterating over it isn't safe.
+		var keys, values [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			// Values that are nil are sub-buckets, such as the
+			// invoice index, which we'll leave untouched.
+			if v == nil {
+				return nil
+			}
+
+			keys = append(keys, append([]byte(nil), k...))
+			values = append(values, append(
+				append([]byte(nil), v...), emptySet[:]...,
+			))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i := range keys {
+			if err := bucket.Put(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if invoices := tx.Bucket(invoiceBucket); invoices != nil {
+		log.Infof("Migrating invoices to track multi-path payments")
+
+		if err := appendEmptySet(invoices); err != nil {
+			return err
+		}
+	}
+
+	if payments := tx.Bucket(paymentBucket); payments != nil {
+		log.Infof("Migrating payments to track multi-path payments")
+
+		if err := appendEmptySet(payments); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateInvoicePaymentHash is the migration function that stores the payment
+// hash along side each invoice. Prior to hold invoices, the payment hash could
+// always be derived from the preimage of the invoice, so it wasn't stored.
+func migrateInvoicePaymentHash(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	log.Infof("Migrating invoices to store their payment hash")
+
+	var keys, values [][]byte
+	err := invoices.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		// The payment hash is the last field of the invoice record, so
+		// we only need to read the invoice itself to obtain the
+		// preimage it's derived from.
+		invoice, err := deserializeInvoice(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+		paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+		keys = append(keys, append([]byte(nil), k...))
+		values = append(values, append(
+			append([]byte(nil), v...), paymentHash[:]...,
+		))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range keys {
+		if err := invoices.Put(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateInvoiceExpiry is the migration function that stores the expiry of
+// each invoice. Existing invoices are given an expiry of zero, which means
+// that the expiry stored within their payment request, if any, is used
+// instead.
+func migrateInvoiceExpiry(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	log.Infof("Migrating invoices to store their expiry")
+
+	// The expiry is serialized as a uint64 following the payment hash,
+	// which is the last field of the existing records.
+	var zeroExpiry [8]byte
+
+	var keys, values [][]byte
+	err := invoices.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		keys = append(keys, append([]byte(nil), k...))
+		values = append(values, append(
+			append([]byte(nil), v...), zeroExpiry[:]...,
+		))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range keys {
+		if err := invoices.Put(keys[i], values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateInvoiceIndexes is the migration function that populates the add and
+// settle indexes of invoices. Existing invoices are assigned an add index in
+// the order they were added, and settled invoices a settle index in the order
+// they were settled. Both indexes are appended to each invoice record.
+func migrateInvoiceIndexes(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	log.Infof("Migrating invoices to the add and settle indexes")
+
+	addIndex, err := invoices.CreateBucketIfNotExists(addIndexBucket)
+	if err != nil {
+		return err
+	}
+	settleIndex, err := invoices.CreateBucketIfNotExists(settleIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	// Invoices are keyed by their big-endian invoice ID, so iterating the
+	// bucket yields them in the order they were added.
+	type indexedInvoice struct {
+		key         []byte
+		record      []byte
+		settleDate  time.Time
+		addIndex    uint64
+		settleIndex uint64
+	}
+	var (
+		records []*indexedInvoice
+		settled []*indexedInvoice
+	)
+	err = invoices.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		// Both indexes are the last fields of the record, so reading
+		// up to them is enough to learn the state of the invoice.
+		invoice, err := deserializeInvoice(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		record := &indexedInvoice{
+			key:        append([]byte(nil), k...),
+			record:     append([]byte(nil), v...),
+			settleDate: invoice.SettleDate,
+		}
+		records = append(records, record)
+		if invoice.Terms.State == ContractSettled {
+			settled = append(settled, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(settled, func(i, j int) bool {
+		return settled[i].settleDate.Before(settled[j].settleDate)
+	})
+
+	for _, record := range records {
+		record.addIndex, err = addIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var indexKey [8]byte
+		byteOrder.PutUint64(indexKey[:], record.addIndex)
+		if err := addIndex.Put(indexKey[:], record.key); err != nil {
+			return err
+		}
+	}
+	for _, record := range settled {
+		record.settleIndex, err = settleIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var indexKey [8]byte
+		byteOrder.PutUint64(indexKey[:], record.settleIndex)
+		if err := settleIndex.Put(indexKey[:], record.key); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range records {
+		var indexes [16]byte
+		byteOrder.PutUint64(indexes[:8], record.addIndex)
+		byteOrder.PutUint64(indexes[8:], record.settleIndex)
+
+		value := append(record.record, indexes[:]...)
+		if err := invoices.Put(record.key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
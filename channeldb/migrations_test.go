@@ -0,0 +1,424 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestMigrateMultiPathRecords checks that invoices and payments stored
+// before multi-path payments were tracked can be read after the migration.
+func TestMigrateMultiPathRecords(t *testing.T) {
+	t.Parallel()
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	invoice.Terms.PaymentHash = sha256.Sum256(
+		invoice.Terms.PaymentPreimage[:],
+	)
+	payment := makeFakePayment()
+	payment.Shards = nil
+
+	// Once migrated, the invoice is the first within the add index.
+	invoice.AddIndex = 1
+
+	// Populate the database with an invoice and a payment that are
+	// serialized in the format preceding the migration.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+			if err != nil {
+				return err
+			}
+			invoiceIndex, err := invoices.CreateBucketIfNotExists(
+				invoiceIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			var invoiceKey [4]byte
+			paymentHash := sha256.Sum256(
+				invoice.Terms.PaymentPreimage[:],
+			)
+			err = invoiceIndex.Put(paymentHash[:], invoiceKey[:])
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			if err := serializeInvoice(&b, invoice); err != nil {
+				return err
+			}
+			if err := invoices.Put(invoiceKey[:], b.Bytes()); err != nil {
+				return err
+			}
+
+			// The payment lacks the count of its shards, which is
+			// the last field of its serialization.
+			var p bytes.Buffer
+			if err := serializeOutgoingPayment(&p, payment); err != nil {
+				return err
+			}
+			payments, err := tx.CreateBucketIfNotExists(paymentBucket)
+			if err != nil {
+				return err
+			}
+			var paymentKey [8]byte
+			return payments.Put(
+				paymentKey[:], p.Bytes()[:p.Len()-2],
+			)
+		})
+		if err != nil {
+			t.Fatalf("unable to populate db: %v", err)
+		}
+	}
+
+	// After the migration, both records should be readable once again.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'multi path records' wasn't applied")
+		}
+
+		paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		dbInvoice, err := d.LookupInvoice(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch invoice: %v", err)
+		}
+		if !reflect.DeepEqual(invoice, dbInvoice) {
+			t.Fatalf("invoice mismatch: expected %v, got %v",
+				spew.Sdump(invoice), spew.Sdump(dbInvoice))
+		}
+
+		payments, err := d.FetchAllPayments()
+		if err != nil {
+			t.Fatalf("unable to fetch payments: %v", err)
+		}
+		if len(payments) != 1 {
+			t.Fatalf("expected 1 payment, got %v", len(payments))
+		}
+		if !reflect.DeepEqual(payment, payments[0]) {
+			t.Fatalf("payment mismatch: expected %v, got %v",
+				spew.Sdump(payment), spew.Sdump(payments[0]))
+		}
+	}
+
+	// As the invoice is read in the current format, the later migrations
+	// that store the payment hash, expiry and indexes of invoices are
+	// applied as well.
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		func(tx *bolt.Tx) error {
+			if err := migrateMultiPathRecords(tx); err != nil {
+				return err
+			}
+			if err := migrateInvoicePaymentHash(tx); err != nil {
+				return err
+			}
+			if err := migrateInvoiceExpiry(tx); err != nil {
+				return err
+			}
+			return migrateInvoiceIndexes(tx)
+		},
+		false)
+}
+
+// TestMigrateInvoicePaymentHash checks that invoices stored without their
+// payment hash can be looked up and read after the migration.
+func TestMigrateInvoicePaymentHash(t *testing.T) {
+	t.Parallel()
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	invoice.Terms.PaymentHash = paymentHash
+	invoice.Terms.State = ContractSettled
+	invoice.AddIndex = 1
+	invoice.SettleIndex = 1
+
+	// Populate the database with an invoice that lacks the payment hash,
+	// which is followed only by the expiry and indexes within its
+	// serialization.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+			if err != nil {
+				return err
+			}
+			invoiceIndex, err := invoices.CreateBucketIfNotExists(
+				invoiceIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			var invoiceKey [4]byte
+			err = invoiceIndex.Put(paymentHash[:], invoiceKey[:])
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			if err := serializeInvoiceRecord(&b, invoice); err != nil {
+				return err
+			}
+			return invoices.Put(
+				invoiceKey[:], b.Bytes()[:b.Len()-32-8-16],
+			)
+		})
+		if err != nil {
+			t.Fatalf("unable to populate db: %v", err)
+		}
+	}
+
+	// After the migration, the invoice should be readable once again,
+	// with its payment hash set and its state unchanged.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'invoice payment hash' wasn't applied")
+		}
+
+		dbInvoice, err := d.LookupInvoice(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch invoice: %v", err)
+		}
+		if !reflect.DeepEqual(invoice, dbInvoice) {
+			t.Fatalf("invoice mismatch: expected %v, got %v",
+				spew.Sdump(invoice), spew.Sdump(dbInvoice))
+		}
+	}
+
+	// The later migrations that store the expiry and indexes of invoices
+	// are applied as well, so that the invoice can be read in the current
+	// format.
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		func(tx *bolt.Tx) error {
+			if err := migrateInvoicePaymentHash(tx); err != nil {
+				return err
+			}
+			if err := migrateInvoiceExpiry(tx); err != nil {
+				return err
+			}
+			return migrateInvoiceIndexes(tx)
+		},
+		false)
+}
+
+// TestMigrateInvoiceExpiry checks that invoices stored without their expiry
+// can be read after the migration, and that they're given no expiry.
+func TestMigrateInvoiceExpiry(t *testing.T) {
+	t.Parallel()
+
+	invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	invoice.Terms.PaymentHash = sha256.Sum256(
+		invoice.Terms.PaymentPreimage[:],
+	)
+	invoice.Expiry = 0
+	invoice.AddIndex = 1
+
+	// Populate the database with an invoice that lacks the expiry, which
+	// is followed only by the indexes within its serialization.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+			if err != nil {
+				return err
+			}
+			invoiceIndex, err := invoices.CreateBucketIfNotExists(
+				invoiceIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			var invoiceKey [4]byte
+			err = invoiceIndex.Put(
+				invoice.Terms.PaymentHash[:], invoiceKey[:],
+			)
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			if err := serializeInvoiceRecord(&b, invoice); err != nil {
+				return err
+			}
+			return invoices.Put(
+				invoiceKey[:], b.Bytes()[:b.Len()-8-16],
+			)
+		})
+		if err != nil {
+			t.Fatalf("unable to populate db: %v", err)
+		}
+	}
+
+	// After the migration, the invoice should be readable once again,
+	// without any expiry.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'invoice expiry' wasn't applied")
+		}
+
+		dbInvoice, err := d.LookupInvoice(invoice.Terms.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch invoice: %v", err)
+		}
+		if !reflect.DeepEqual(invoice, dbInvoice) {
+			t.Fatalf("invoice mismatch: expected %v, got %v",
+				spew.Sdump(invoice), spew.Sdump(dbInvoice))
+		}
+	}
+
+	// The later migration that indexes invoices is applied as well, so
+	// that the invoice can be read in the current format.
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		func(tx *bolt.Tx) error {
+			if err := migrateInvoiceExpiry(tx); err != nil {
+				return err
+			}
+			return migrateInvoiceIndexes(tx)
+		},
+		false)
+}
+
+// TestMigrateInvoiceIndexes checks that existing invoices are assigned add
+// indexes in the order they were added, and that settled invoices are assigned
+// settle indexes in the order they were settled.
+func TestMigrateInvoiceIndexes(t *testing.T) {
+	t.Parallel()
+
+	// We'll add three invoices, of which the first and the last are
+	// settled, with the last one being settled first.
+	now := time.Unix(time.Now().Unix(), 0)
+	var invoices []*Invoice
+	for i := 0; i < 3; i++ {
+		invoice, err := randInvoice(lnwire.NewMSatFromSatoshis(1000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		invoice.Terms.PaymentHash = sha256.Sum256(
+			invoice.Terms.PaymentPreimage[:],
+		)
+		invoice.AddIndex = uint64(i + 1)
+		invoices = append(invoices, invoice)
+	}
+	invoices[0].Terms.State = ContractSettled
+	invoices[0].SettleDate = now
+	invoices[0].SettleIndex = 2
+	invoices[2].Terms.State = ContractSettled
+	invoices[2].SettleDate = now.Add(-time.Minute)
+	invoices[2].SettleIndex = 1
+
+	// Populate the database with the invoices, stripped of their
+	// indexes which are the last fields of their serialization.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoiceB, err := tx.CreateBucketIfNotExists(invoiceBucket)
+			if err != nil {
+				return err
+			}
+			invoiceIndex, err := invoiceB.CreateBucketIfNotExists(
+				invoiceIndexBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			for i, invoice := range invoices {
+				var invoiceKey [4]byte
+				byteOrder.PutUint32(invoiceKey[:], uint32(i))
+				err := invoiceIndex.Put(
+					invoice.Terms.PaymentHash[:],
+					invoiceKey[:],
+				)
+				if err != nil {
+					return err
+				}
+
+				var b bytes.Buffer
+				err = serializeInvoiceRecord(&b, invoice)
+				if err != nil {
+					return err
+				}
+				err = invoiceB.Put(
+					invoiceKey[:], b.Bytes()[:b.Len()-16],
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to populate db: %v", err)
+		}
+	}
+
+	// After the migration, the invoices should be returned in the order
+	// of both indexes.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'invoice indexes' wasn't applied")
+		}
+
+		added, err := d.InvoicesAddedSince(0)
+		if err != nil {
+			t.Fatalf("unable to fetch invoices: %v", err)
+		}
+		if !reflect.DeepEqual(invoices, added) {
+			t.Fatalf("invoice mismatch: expected %v, got %v",
+				spew.Sdump(invoices), spew.Sdump(added))
+		}
+
+		settled, err := d.InvoicesSettledSince(0)
+		if err != nil {
+			t.Fatalf("unable to fetch invoices: %v", err)
+		}
+		expected := []*Invoice{invoices[2], invoices[0]}
+		if !reflect.DeepEqual(expected, settled) {
+			t.Fatalf("invoice mismatch: expected %v, got %v",
+				spew.Sdump(expected), spew.Sdump(settled))
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateInvoiceIndexes,
+		false)
+}
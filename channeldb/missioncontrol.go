@@ -0,0 +1,221 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// missionControlBucket is the name of the top-level bucket that stores
+	// the outcome of past payment attempts as reported to the router's
+	// mission control.
+	//
+	// Each result is keyed by a monotonically increasing uint64 obtained
+	// from the bucket's sequence, so a scan of the bucket returns the
+	// results in the order in which they were recorded.
+	missionControlBucket = []byte("mission-control")
+)
+
+// MissionControlResult records the outcome of a single payment attempt,
+// localized to either a channel or a node within the route that was
+// attempted.
+type MissionControlResult struct {
+	// ChanID is the short channel ID of the channel that this result
+	// pertains to. If the result is localized to a node rather than a
+	// channel, then this value is zero.
+	ChanID uint64
+
+	// Node is the compressed public key of the node that this result
+	// pertains to. This is only set if ChanID is zero.
+	Node [33]byte
+
+	// Amt is the amount that we attempted to route through the channel.
+	// For node results this is the amount that was forwarded to the node.
+	Amt lnwire.MilliSatoshi
+
+	// Success is true if the channel was able to carry the HTLC, and false
+	// if a failure was reported for it.
+	Success bool
+
+	// Timestamp is the time at which the result was recorded.
+	Timestamp time.Time
+}
+
+// AddMissionControlResults persists a set of new payment attempt results
+// within a single database transaction. If maxResults is non-zero, then the
+// oldest results are deleted until no more than maxResults results remain in
+// the database.
+func (d *DB) AddMissionControlResults(newResults []*MissionControlResult,
+	maxResults uint32) error {
+
+	encoded := make([][]byte, len(newResults))
+	for i, result := range newResults {
+		var b bytes.Buffer
+		if err := serializeMissionControlResult(&b, result); err != nil {
+			return err
+		}
+		encoded[i] = b.Bytes()
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		results, err := tx.CreateBucketIfNotExists(missionControlBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, resultBytes := range encoded {
+			resultID, err := results.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			var resultKey [8]byte
+			binary.BigEndian.PutUint64(resultKey[:], resultID)
+			if err := results.Put(resultKey[:], resultBytes); err != nil {
+				return err
+			}
+		}
+
+		if maxResults == 0 {
+			return nil
+		}
+
+		// As results are only ever deleted from the start of the
+		// bucket, the keys are consecutive sequence numbers, so the
+		// number of stored results follows from the first and last key
+		// without a scan of the bucket.
+		cursor := results.Cursor()
+		firstKey, _ := cursor.First()
+		lastKey, _ := cursor.Last()
+		if firstKey == nil {
+			return nil
+		}
+
+		numResults := binary.BigEndian.Uint64(lastKey) -
+			binary.BigEndian.Uint64(firstKey) + 1
+		if numResults <= uint64(maxResults) {
+			return nil
+		}
+
+		// We're over the limit, so we'll trim the history by deleting
+		// keys from the start of the bucket until we're back within
+		// it.
+		numExcess := numResults - uint64(maxResults)
+		for k, _ := cursor.First(); k != nil && numExcess > 0; numExcess-- {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+
+			// Deleting the element the cursor points to doesn't
+			// advance it, so we'll need to seek to the new start
+			// of the bucket.
+			k, _ = cursor.First()
+		}
+
+		return nil
+	})
+}
+
+// FetchMissionControlResults returns all stored payment attempt results,
+// ordered from oldest to newest.
+func (d *DB) FetchMissionControlResults() ([]*MissionControlResult, error) {
+	var results []*MissionControlResult
+
+	err := d.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(missionControlBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			result, err := deserializeMissionControlResult(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ResetMissionControl deletes all stored payment attempt results.
+func (d *DB) ResetMissionControl() error {
+	return d.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(missionControlBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func serializeMissionControlResult(w io.Writer,
+	r *MissionControlResult) error {
+
+	var success uint8
+	if r.Success {
+		success = 1
+	}
+
+	if err := binary.Write(w, byteOrder, r.ChanID); err != nil {
+		return err
+	}
+	if _, err := w.Write(r.Node[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, uint64(r.Amt)); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, success); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, uint64(r.Timestamp.UnixNano()))
+}
+
+func deserializeMissionControlResult(r io.Reader) (*MissionControlResult,
+	error) {
+
+	var (
+		result    MissionControlResult
+		amt       uint64
+		success   uint8
+		timestamp uint64
+	)
+
+	if err := binary.Read(r, byteOrder, &result.ChanID); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, result.Node[:]); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, byteOrder, &amt); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, byteOrder, &success); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, byteOrder, &timestamp); err != nil {
+		return nil, err
+	}
+
+	result.Amt = lnwire.MilliSatoshi(amt)
+	result.Success = success == 1
+	result.Timestamp = time.Unix(0, int64(timestamp))
+
+	return &result, nil
+}
@@ -0,0 +1,101 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestMissionControlResults tests that payment attempt results are stored
+// in order, whether added one by one or in a batch, that the history is
+// trimmed to the requested size, and that it can be reset.
+func TestMissionControlResults(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// With no results added, we should get back an empty history.
+	results, err := db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results, got %v", len(results))
+	}
+
+	// We'll create a mix of channel and node results, keeping only the
+	// three most recent ones.
+	const maxResults = 3
+	var added []*MissionControlResult
+	for i := 0; i < 6; i++ {
+		result := &MissionControlResult{
+			Amt:       lnwire.MilliSatoshi(1000 * i),
+			Success:   i%2 == 0,
+			Timestamp: time.Unix(0, int64(i)*int64(time.Second)),
+		}
+		if i%2 == 0 {
+			result.ChanID = uint64(i + 1)
+		} else {
+			result.Node[0] = 0x02
+			result.Node[1] = byte(i)
+		}
+
+		added = append(added, result)
+	}
+
+	// The first two results are added one by one, which shouldn't trim
+	// anything as we're still within the limit.
+	for _, result := range added[:2] {
+		err := db.AddMissionControlResults(
+			[]*MissionControlResult{result}, maxResults,
+		)
+		if err != nil {
+			t.Fatalf("unable to add result: %v", err)
+		}
+	}
+
+	results, err = db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if !reflect.DeepEqual(results, added[:2]) {
+		t.Fatalf("results mismatch: expected %v, got %v",
+			spew.Sdump(added[:2]), spew.Sdump(results))
+	}
+
+	// The remaining results are added as a single batch, which takes us
+	// over the limit, so only the most recent ones should remain.
+	if err := db.AddMissionControlResults(added[2:], maxResults); err != nil {
+		t.Fatalf("unable to add results: %v", err)
+	}
+
+	results, err = db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if !reflect.DeepEqual(results, added[len(added)-maxResults:]) {
+		t.Fatalf("results mismatch: expected %v, got %v",
+			spew.Sdump(added[len(added)-maxResults:]),
+			spew.Sdump(results))
+	}
+
+	// After resetting, the history should once again be empty.
+	if err := db.ResetMissionControl(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
+	results, err = db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results after reset, got %v",
+			len(results))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
 )
@@ -53,10 +54,9 @@ type LinkNode struct {
 
 	// Addresses is a list of IP address in which either we were able to
 	// reach the node over in the past, OR we received an incoming
-	// authenticated connection for the stored identity public key.
-	//
-	// TODO(roasbeef): also need to support hidden service addrs
-	Addresses []*net.TCPAddr
+	// authenticated connection for the stored identity public key. These
+	// may be TCP addresses or onion addresses.
+	Addresses []net.Addr
 
 	db *DB
 }
@@ -64,13 +64,13 @@ type LinkNode struct {
 // NewLinkNode creates a new LinkNode from the provided parameters, which is
 // backed by an instance of channeldb.
 func (db *DB) NewLinkNode(bitNet wire.BitcoinNet, pub *btcec.PublicKey,
-	addr *net.TCPAddr) *LinkNode {
+	addr net.Addr) *LinkNode {
 
 	return &LinkNode{
 		Network:     bitNet,
 		IdentityPub: pub,
 		LastSeen:    time.Now(),
-		Addresses:   []*net.TCPAddr{addr},
+		Addresses:   []net.Addr{addr},
 		db:          db,
 	}
 }
@@ -83,9 +83,9 @@ func (l *LinkNode) UpdateLastSeen(lastSeen time.Time) error {
 	return l.Sync()
 }
 
-// AddAddress appends the specified TCP address to the list of known addresses
-// this node is/was known to be reachable at.
-func (l *LinkNode) AddAddress(addr *net.TCPAddr) error {
+// AddAddress appends the specified address to the list of known addresses this
+// node is/was known to be reachable at.
+func (l *LinkNode) AddAddress(addr net.Addr) error {
 	for _, a := range l.Addresses {
 		if a.String() == addr.String() {
 			return nil
@@ -268,14 +268,14 @@ func deserializeLinkNode(r io.Reader) (*LinkNode, error) {
 	}
 	numAddrs := byteOrder.Uint32(buf[:4])
 
-	node.Addresses = make([]*net.TCPAddr, numAddrs)
+	node.Addresses = make([]net.Addr, numAddrs)
 	for i := uint32(0); i < numAddrs; i++ {
 		addrString, err := wire.ReadVarString(r, 0)
 		if err != nil {
 			return nil, err
 		}
 
-		addr, err := net.ResolveTCPAddr("tcp", addrString)
+		addr, err := tor.ParseAddr(addrString, &tor.ClearNet{})
 		if err != nil {
 			return nil, err
 		}
@@ -82,6 +82,9 @@ func TestLinkNodeEncodeDecode(t *testing.T) {
 	if err := node1.AddAddress(addr2); err != nil {
 		t.Fatalf("unable to update addr: %v", err)
 	}
+	if err := node1.AddAddress(testOnionAddr); err != nil {
+		t.Fatalf("unable to update addr: %v", err)
+	}
 
 	// Fetch the same node from the databse according to its public key.
 	node1DB, err := cdb.FetchLinkNode(pub1)
@@ -95,9 +98,9 @@ func TestLinkNodeEncodeDecode(t *testing.T) {
 		t.Fatalf("last seen timestamps don't match: expected %v got %v",
 			node1.LastSeen.Unix(), node1DB.LastSeen.Unix())
 	}
-	if len(node1DB.Addresses) != 2 {
+	if len(node1DB.Addresses) != 3 {
 		t.Fatalf("wrong length for node1 addrsses: expected %v, got %v",
-			2, len(node1DB.Addresses))
+			3, len(node1DB.Addresses))
 	}
 	if node1DB.Addresses[0].String() != addr1.String() {
 		t.Fatalf("wrong address for node: expected %v, got %v",
@@ -107,4 +110,8 @@ func TestLinkNodeEncodeDecode(t *testing.T) {
 		t.Fatalf("wrong address for node: expected %v, got %v",
 			addr2.String(), node1DB.Addresses[1].String())
 	}
+	if !reflect.DeepEqual(node1DB.Addresses[2], testOnionAddr) {
+		t.Fatalf("wrong address for node: expected %v, got %v",
+			testOnionAddr, node1DB.Addresses[2])
+	}
 }
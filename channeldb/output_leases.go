@@ -0,0 +1,116 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// outputLeaseBucket is the name of the top-level bucket that stores
+	// the wallet outputs which were leased by the user, and thus must not
+	// be selected to fund transactions until the lease expires.
+	//
+	// maps: outpoint -> expiration
+	outputLeaseBucket = []byte("output-leases")
+
+	// ErrOutputLeaseNotFound is returned when releasing an output that
+	// isn't leased.
+	ErrOutputLeaseNotFound = fmt.Errorf("output lease not found")
+)
+
+// OutputLease is a lease of a wallet output, which excludes the output from
+// automatic coin selection until it expires.
+type OutputLease struct {
+	// OutPoint is the leased output.
+	OutPoint wire.OutPoint
+
+	// Expiration is the time at which the lease expires.
+	Expiration time.Time
+}
+
+// AddOutputLease persists the passed lease, replacing any existing lease of
+// the same output.
+func (d *DB) AddOutputLease(lease *OutputLease) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, &lease.OutPoint); err != nil {
+		return err
+	}
+
+	var v [8]byte
+	byteOrder.PutUint64(v[:], uint64(lease.Expiration.Unix()))
+
+	return d.Update(func(tx *bolt.Tx) error {
+		leases, err := tx.CreateBucketIfNotExists(outputLeaseBucket)
+		if err != nil {
+			return err
+		}
+
+		return leases.Put(k.Bytes(), v[:])
+	})
+}
+
+// RemoveOutputLease deletes the lease of the passed output. If the output
+// isn't leased, then ErrOutputLeaseNotFound is returned.
+func (d *DB) RemoveOutputLease(outpoint *wire.OutPoint) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, outpoint); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		leases := tx.Bucket(outputLeaseBucket)
+		if leases == nil || leases.Get(k.Bytes()) == nil {
+			return ErrOutputLeaseNotFound
+		}
+
+		return leases.Delete(k.Bytes())
+	})
+}
+
+// FetchOutputLeases returns all output leases, including the expired ones
+// that haven't been removed yet.
+func (d *DB) FetchOutputLeases() ([]*OutputLease, error) {
+	var leases []*OutputLease
+
+	err := d.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(outputLeaseBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			lease, err := deserializeOutputLease(k, v)
+			if err != nil {
+				return err
+			}
+
+			leases = append(leases, lease)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return leases, nil
+}
+
+func deserializeOutputLease(k, v []byte) (*OutputLease, error) {
+	var lease OutputLease
+	if err := readOutpoint(bytes.NewReader(k), &lease.OutPoint); err != nil {
+		return nil, err
+	}
+
+	if len(v) != 8 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	expiration := byteOrder.Uint64(v)
+	lease.Expiration = time.Unix(int64(expiration), 0)
+
+	return &lease, nil
+}
@@ -0,0 +1,82 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestOutputLeases tests that output leases can be added, extended and
+// removed.
+func TestOutputLeases(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// With no leases added, we should get back an empty set.
+	leases, err := db.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	if len(leases) != 0 {
+		t.Fatalf("expected no leases, got %v", len(leases))
+	}
+
+	// Releasing an output that isn't leased should fail.
+	outpoint := wire.OutPoint{Hash: rev, Index: 1}
+	err = db.RemoveOutputLease(&outpoint)
+	if err != ErrOutputLeaseNotFound {
+		t.Fatalf("expected ErrOutputLeaseNotFound, got %v", err)
+	}
+
+	lease := &OutputLease{
+		OutPoint:   outpoint,
+		Expiration: time.Unix(1000, 0),
+	}
+	otherLease := &OutputLease{
+		OutPoint:   wire.OutPoint{Hash: rev, Index: 2},
+		Expiration: time.Unix(2000, 0),
+	}
+	for _, l := range []*OutputLease{lease, otherLease} {
+		if err := db.AddOutputLease(l); err != nil {
+			t.Fatalf("unable to add lease: %v", err)
+		}
+	}
+
+	// Leasing an output again should only extend its lease.
+	lease.Expiration = time.Unix(3000, 0)
+	if err := db.AddOutputLease(lease); err != nil {
+		t.Fatalf("unable to extend lease: %v", err)
+	}
+
+	leases, err = db.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	expected := []*OutputLease{lease, otherLease}
+	if !reflect.DeepEqual(leases, expected) {
+		t.Fatalf("leases don't match: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(leases))
+	}
+
+	// Finally, once the first lease is removed, only the second one
+	// should remain.
+	if err := db.RemoveOutputLease(&outpoint); err != nil {
+		t.Fatalf("unable to remove lease: %v", err)
+	}
+	leases, err = db.FetchOutputLeases()
+	if err != nil {
+		t.Fatalf("unable to fetch leases: %v", err)
+	}
+	if !reflect.DeepEqual(leases, []*OutputLease{otherLease}) {
+		t.Fatalf("expected only %v, got %v", spew.Sdump(otherLease),
+			spew.Sdump(leases))
+	}
+}
@@ -0,0 +1,684 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+var (
+	// paymentControlBucket is the name of the top-level bucket that tracks
+	// the life cycle of all payments sent by the daemon, whether they
+	// succeeded, failed, or are still in flight.
+	//
+	// Within this bucket, each payment has its own sub-bucket keyed by the
+	// payment hash. The sequence of the top-level bucket is used to assign
+	// a unique ID to each payment attempt, which is also the ID the HTLC
+	// of the attempt is known by within the switch.
+	//
+	// payment-control
+	//   |-- <payment hash>
+	//   |     |-- creation-info: <PaymentCreationInfo>
+	//   |     |-- status: <PaymentStatus>
+	//   |     |-- settle-info: <preimage>
+	//   |     |-- fail-info: <PaymentFailInfo>
+	//   |     |-- attempts
+	//   |           |-- <payment id>: <PaymentAttemptInfo>
+	//   |-- <payment hash>
+	//   ...
+	paymentControlBucket = []byte("payment-control")
+
+	// paymentCreationInfoKey is the key under which the details of a
+	// payment, as known when it was initiated, are stored.
+	paymentCreationInfoKey = []byte("creation-info")
+
+	// paymentStatusKey is the key under which the current status of a
+	// payment is stored.
+	paymentStatusKey = []byte("status")
+
+	// paymentSettleInfoKey is the key under which the preimage of a
+	// successful payment is stored.
+	paymentSettleInfoKey = []byte("settle-info")
+
+	// paymentFailInfoKey is the key under which the reason a payment
+	// failed is stored.
+	paymentFailInfoKey = []byte("fail-info")
+
+	// paymentAttemptsBucket is the name of the sub-bucket that holds the
+	// attempts of a payment whose HTLCs haven't failed, keyed by their
+	// payment ID.
+	paymentAttemptsBucket = []byte("attempts")
+)
+
+// PaymentStatus represents the current status of a payment.
+type PaymentStatus byte
+
+const (
+	// StatusUnknown is the status of a payment that hasn't been initiated.
+	StatusUnknown PaymentStatus = 0
+
+	// StatusInFlight is the status of a payment that has been initiated,
+	// but hasn't yet succeeded or failed.
+	StatusInFlight PaymentStatus = 1
+
+	// StatusSucceeded is the status of a payment that has been settled by
+	// its destination.
+	StatusSucceeded PaymentStatus = 2
+
+	// StatusFailed is the status of a payment that failed, and has no more
+	// HTLCs in flight.
+	StatusFailed PaymentStatus = 3
+)
+
+// String returns a human readable representation of the payment status.
+func (ps PaymentStatus) String() string {
+	switch ps {
+	case StatusUnknown:
+		return "Unknown"
+	case StatusInFlight:
+		return "In Flight"
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// FailureReason describes why a payment failed.
+type FailureReason byte
+
+const (
+	// FailureReasonError indicates that the payment failed due to an
+	// error that isn't covered by one of the more specific reasons.
+	FailureReasonError FailureReason = 0
+
+	// FailureReasonNoRoute indicates that no route to the destination
+	// could be found that was able to carry the payment.
+	FailureReasonNoRoute FailureReason = 1
+
+	// FailureReasonIncorrectPaymentDetails indicates that the destination
+	// rejected the payment, as either the payment hash, the amount or the
+	// final time lock was unexpected.
+	FailureReasonIncorrectPaymentDetails FailureReason = 2
+
+	// FailureReasonInterrupted indicates that the daemon was restarted
+	// while the payment was in flight, at a point where no HTLC of the
+	// payment was outstanding.
+	FailureReasonInterrupted FailureReason = 3
+)
+
+// String returns a human readable representation of the failure reason.
+func (r FailureReason) String() string {
+	switch r {
+	case FailureReasonError:
+		return "error"
+	case FailureReasonNoRoute:
+		return "no route"
+	case FailureReasonIncorrectPaymentDetails:
+		return "incorrect payment details"
+	case FailureReasonInterrupted:
+		return "interrupted"
+	default:
+		return "unknown"
+	}
+}
+
+// PaymentCreationInfo holds the details of a payment that are known when it
+// is initiated.
+type PaymentCreationInfo struct {
+	// PaymentHash is the hash the payment is sent to.
+	PaymentHash [32]byte
+
+	// Value is the amount the payment delivers to the destination.
+	Value lnwire.MilliSatoshi
+
+	// CreationDate is the time at which the payment was initiated.
+	CreationDate time.Time
+
+	// PaymentRequest is the encoded payment request the payment was made
+	// to, if any.
+	PaymentRequest []byte
+}
+
+// PaymentAttemptInfo describes a single attempt to send (a shard of) a
+// payment along a route. It holds all that is needed to decrypt the failure
+// of the attempt's HTLC, so the attempt can be resumed after a restart.
+type PaymentAttemptInfo struct {
+	// PaymentID is the unique ID of the attempt, which the HTLC of the
+	// attempt is known by within the switch. It's assigned when the
+	// attempt is registered.
+	PaymentID uint64
+
+	// SessionKey is the ephemeral key used to construct the onion packet
+	// of the attempt.
+	SessionKey *btcec.PrivateKey
+
+	// Amt is the amount the attempt delivers to the destination.
+	Amt lnwire.MilliSatoshi
+
+	// Fee is the total fee paid to the hops of the route.
+	Fee lnwire.MilliSatoshi
+
+	// TimeLock is the time lock extended to the first hop of the route.
+	TimeLock uint32
+
+	// Path is the set of compressed public keys of the nodes along the
+	// route, excluding our own node.
+	Path [][33]byte
+}
+
+// PaymentFailInfo describes why a payment failed.
+type PaymentFailInfo struct {
+	// Reason is the category of the failure.
+	Reason FailureReason
+
+	// Message is a human readable description of the error that caused
+	// the payment to fail.
+	Message string
+}
+
+// TrackedPayment is a snapshot of the life cycle of a payment, as recorded by
+// the payment control.
+type TrackedPayment struct {
+	// Info holds the details of the payment known when it was initiated.
+	Info *PaymentCreationInfo
+
+	// Status is the current status of the payment.
+	Status PaymentStatus
+
+	// Attempts holds the attempts of the payment whose HTLCs haven't
+	// failed. While the payment is in flight, these are the HTLCs that
+	// are outstanding, and once it has succeeded, these are the HTLCs
+	// that were settled.
+	Attempts []*PaymentAttemptInfo
+
+	// Preimage is the preimage revealed by the destination. It's only set
+	// if the payment succeeded.
+	Preimage *[32]byte
+
+	// Failure describes why the payment failed. It's only set if the
+	// payment failed.
+	Failure *PaymentFailInfo
+}
+
+// InitPayment records that a new payment is being sent to the payment hash of
+// the passed creation info. A payment hash may only have a single payment in
+// flight, and can't be paid again once a payment has succeeded, so
+// ErrPaymentInFlight or ErrAlreadyPaid is returned in these cases. A payment
+// that has failed may be reattempted, in which case the record of the failed
+// payment is replaced.
+func (d *DB) InitPayment(info *PaymentCreationInfo) error {
+	var b bytes.Buffer
+	if err := serializePaymentCreationInfo(&b, info); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		payments, err := tx.CreateBucketIfNotExists(paymentControlBucket)
+		if err != nil {
+			return err
+		}
+
+		hash := info.PaymentHash[:]
+		switch fetchPaymentStatus(payments.Bucket(hash)) {
+		case StatusInFlight:
+			return ErrPaymentInFlight
+
+		case StatusSucceeded:
+			return ErrAlreadyPaid
+
+		case StatusFailed:
+			if err := payments.DeleteBucket(hash); err != nil {
+				return err
+			}
+		}
+
+		payment, err := payments.CreateBucket(hash)
+		if err != nil {
+			return err
+		}
+		if _, err := payment.CreateBucket(paymentAttemptsBucket); err != nil {
+			return err
+		}
+
+		err = payment.Put(paymentCreationInfoKey, b.Bytes())
+		if err != nil {
+			return err
+		}
+
+		return payment.Put(
+			paymentStatusKey, []byte{byte(StatusInFlight)},
+		)
+	})
+}
+
+// RegisterPaymentAttempt records a new attempt of the in-flight payment to the
+// passed payment hash. A unique payment ID is assigned to the attempt, which
+// must be used for the attempt's HTLC. The attempt must be registered before
+// its HTLC is handed to the switch, so that the HTLC can be resumed if we
+// restart before it is resolved.
+func (d *DB) RegisterPaymentAttempt(paymentHash [32]byte,
+	attempt *PaymentAttemptInfo) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		payments, attempts, err := fetchInFlightAttempts(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		paymentID, err := payments.NextSequence()
+		if err != nil {
+			return err
+		}
+		attempt.PaymentID = paymentID
+
+		var b bytes.Buffer
+		if err := serializePaymentAttemptInfo(&b, attempt); err != nil {
+			return err
+		}
+
+		return attempts.Put(serializePaymentID(paymentID), b.Bytes())
+	})
+}
+
+// FailPaymentAttempt removes the attempt with the passed payment ID from the
+// in-flight payment to the passed payment hash, as its HTLC has failed. The
+// payment itself stays in flight, as it may still be reattempted.
+func (d *DB) FailPaymentAttempt(paymentHash [32]byte, paymentID uint64) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		_, attempts, err := fetchInFlightAttempts(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		k := serializePaymentID(paymentID)
+		if attempts.Get(k) == nil {
+			return ErrPaymentAttemptNotFound
+		}
+
+		return attempts.Delete(k)
+	})
+}
+
+// SettlePayment marks the in-flight payment to the passed payment hash as
+// succeeded, recording the preimage that was revealed by the destination.
+func (d *DB) SettlePayment(paymentHash, preimage [32]byte) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		payment, err := fetchInFlightPayment(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		err = payment.Put(paymentSettleInfoKey, preimage[:])
+		if err != nil {
+			return err
+		}
+
+		return payment.Put(
+			paymentStatusKey, []byte{byte(StatusSucceeded)},
+		)
+	})
+}
+
+// FailPayment marks the in-flight payment to the passed payment hash as
+// failed. This should only be called once none of the HTLCs of the payment
+// are outstanding anymore.
+func (d *DB) FailPayment(paymentHash [32]byte, reason FailureReason,
+	msg string) error {
+
+	var b bytes.Buffer
+	if err := serializePaymentFailInfo(&b, reason, msg); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		payment, err := fetchInFlightPayment(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		if err := payment.Put(paymentFailInfoKey, b.Bytes()); err != nil {
+			return err
+		}
+
+		return payment.Put(
+			paymentStatusKey, []byte{byte(StatusFailed)},
+		)
+	})
+}
+
+// FetchTrackedPayment returns the current state of the payment to the passed
+// payment hash. If no payment has been initiated for the hash, then
+// ErrPaymentNotInitiated is returned.
+func (d *DB) FetchTrackedPayment(paymentHash [32]byte) (*TrackedPayment,
+	error) {
+
+	var payment *TrackedPayment
+	err := d.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentControlBucket)
+		if payments == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		bucket := payments.Bucket(paymentHash[:])
+		if bucket == nil {
+			return ErrPaymentNotInitiated
+		}
+
+		var err error
+		payment, err = fetchTrackedPayment(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// FetchTrackedPayments returns the state of all payments known to the payment
+// control, ordered by the time at which they were initiated.
+func (d *DB) FetchTrackedPayments() ([]*TrackedPayment, error) {
+	return d.fetchTrackedPayments(func(*TrackedPayment) bool {
+		return true
+	})
+}
+
+// FetchInFlightPayments returns the state of all payments that are still in
+// flight, ordered by the time at which they were initiated.
+func (d *DB) FetchInFlightPayments() ([]*TrackedPayment, error) {
+	return d.fetchTrackedPayments(func(p *TrackedPayment) bool {
+		return p.Status == StatusInFlight
+	})
+}
+
+// fetchTrackedPayments returns all payments of the payment control for which
+// the filter returns true.
+func (d *DB) fetchTrackedPayments(
+	filter func(*TrackedPayment) bool) ([]*TrackedPayment, error) {
+
+	var payments []*TrackedPayment
+	err := d.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(paymentControlBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			paymentBucket := bucket.Bucket(k)
+			if paymentBucket == nil {
+				return nil
+			}
+
+			payment, err := fetchTrackedPayment(paymentBucket)
+			if err != nil {
+				return err
+			}
+
+			if filter(payment) {
+				payments = append(payments, payment)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].Info.CreationDate.Before(
+			payments[j].Info.CreationDate,
+		)
+	})
+
+	return payments, nil
+}
+
+// deleteCompletedPayments removes all payments from the payment control that
+// are no longer in flight.
+func deleteCompletedPayments(tx *bolt.Tx) error {
+	bucket := tx.Bucket(paymentControlBucket)
+	if bucket == nil {
+		return nil
+	}
+
+	var completed [][]byte
+	err := bucket.ForEach(func(k, v []byte) error {
+		status := fetchPaymentStatus(bucket.Bucket(k))
+		if status == StatusSucceeded || status == StatusFailed {
+			completed = append(completed, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range completed {
+		if err := bucket.DeleteBucket(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchPaymentStatus returns the status of the payment stored within the
+// passed bucket. If the bucket is nil, then StatusUnknown is returned.
+func fetchPaymentStatus(bucket *bolt.Bucket) PaymentStatus {
+	if bucket == nil {
+		return StatusUnknown
+	}
+
+	status := bucket.Get(paymentStatusKey)
+	if len(status) == 0 {
+		return StatusUnknown
+	}
+
+	return PaymentStatus(status[0])
+}
+
+// fetchInFlightPayment returns the bucket of the payment to the passed payment
+// hash, ensuring that the payment is in flight.
+func fetchInFlightPayment(tx *bolt.Tx, paymentHash [32]byte) (*bolt.Bucket,
+	error) {
+
+	payments := tx.Bucket(paymentControlBucket)
+	if payments == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+
+	payment := payments.Bucket(paymentHash[:])
+	switch fetchPaymentStatus(payment) {
+	case StatusUnknown:
+		return nil, ErrPaymentNotInitiated
+
+	case StatusInFlight:
+		return payment, nil
+
+	default:
+		return nil, ErrPaymentNotInFlight
+	}
+}
+
+// fetchInFlightAttempts returns the top-level payment control bucket, along
+// with the attempts bucket of the in-flight payment to the passed payment
+// hash.
+func fetchInFlightAttempts(tx *bolt.Tx, paymentHash [32]byte) (*bolt.Bucket,
+	*bolt.Bucket, error) {
+
+	payment, err := fetchInFlightPayment(tx, paymentHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attempts := payment.Bucket(paymentAttemptsBucket)
+	if attempts == nil {
+		return nil, nil, ErrPaymentNotInitiated
+	}
+
+	return tx.Bucket(paymentControlBucket), attempts, nil
+}
+
+// fetchTrackedPayment reads the payment stored within the passed bucket.
+func fetchTrackedPayment(bucket *bolt.Bucket) (*TrackedPayment, error) {
+	payment := &TrackedPayment{
+		Status: fetchPaymentStatus(bucket),
+	}
+
+	infoBytes := bucket.Get(paymentCreationInfoKey)
+	if infoBytes == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+
+	var err error
+	payment.Info, err = deserializePaymentCreationInfo(
+		bytes.NewReader(infoBytes),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempts := bucket.Bucket(paymentAttemptsBucket); attempts != nil {
+		err := attempts.ForEach(func(k, v []byte) error {
+			attempt, err := deserializePaymentAttemptInfo(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			attempt.PaymentID = byteOrder.Uint64(k)
+
+			payment.Attempts = append(payment.Attempts, attempt)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if preimage := bucket.Get(paymentSettleInfoKey); preimage != nil {
+		payment.Preimage = &[32]byte{}
+		copy(payment.Preimage[:], preimage)
+	}
+
+	if failInfo := bucket.Get(paymentFailInfoKey); failInfo != nil {
+		payment.Failure, err = deserializePaymentFailInfo(
+			bytes.NewReader(failInfo),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return payment, nil
+}
+
+func serializePaymentCreationInfo(w io.Writer, c *PaymentCreationInfo) error {
+	return writeElements(w,
+		c.PaymentHash, c.Value, uint64(c.CreationDate.UnixNano()),
+		c.PaymentRequest,
+	)
+}
+
+func deserializePaymentCreationInfo(r io.Reader) (*PaymentCreationInfo,
+	error) {
+
+	var (
+		c            PaymentCreationInfo
+		creationDate uint64
+	)
+	err := readElements(r,
+		&c.PaymentHash, &c.Value, &creationDate, &c.PaymentRequest,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.CreationDate = time.Unix(0, int64(creationDate))
+
+	return &c, nil
+}
+
+func serializePaymentAttemptInfo(w io.Writer, a *PaymentAttemptInfo) error {
+	var sessionKey [32]byte
+	copy(sessionKey[:], a.SessionKey.Serialize())
+
+	err := writeElements(w,
+		sessionKey, a.Amt, a.Fee, a.TimeLock, uint32(len(a.Path)),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, hop := range a.Path {
+		if _, err := w.Write(hop[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializePaymentAttemptInfo(r io.Reader) (*PaymentAttemptInfo, error) {
+	var (
+		a          PaymentAttemptInfo
+		sessionKey [32]byte
+		pathLen    uint32
+	)
+	err := readElements(r, &sessionKey, &a.Amt, &a.Fee, &a.TimeLock, &pathLen)
+	if err != nil {
+		return nil, err
+	}
+	a.SessionKey, _ = btcec.PrivKeyFromBytes(btcec.S256(), sessionKey[:])
+
+	a.Path = make([][33]byte, pathLen)
+	for i := range a.Path {
+		if _, err := io.ReadFull(r, a.Path[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &a, nil
+}
+
+func serializePaymentFailInfo(w io.Writer, reason FailureReason,
+	msg string) error {
+
+	if _, err := w.Write([]byte{byte(reason)}); err != nil {
+		return err
+	}
+
+	return writeElement(w, []byte(msg))
+}
+
+func deserializePaymentFailInfo(r io.Reader) (*PaymentFailInfo, error) {
+	var reason [1]byte
+	if _, err := io.ReadFull(r, reason[:]); err != nil {
+		return nil, err
+	}
+
+	var msg []byte
+	if err := readElement(r, &msg); err != nil {
+		return nil, err
+	}
+
+	return &PaymentFailInfo{
+		Reason:  FailureReason(reason[0]),
+		Message: string(msg),
+	}, nil
+}
+
+// serializePaymentID returns the key of a payment attempt within the attempts
+// bucket of its payment.
+func serializePaymentID(paymentID uint64) []byte {
+	var k [8]byte
+	byteOrder.PutUint64(k[:], paymentID)
+	return k[:]
+}
@@ -0,0 +1,245 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// makeFakePaymentInfo returns the creation info of a payment to the hash of
+// the passed preimage, along with an attempt to send it.
+func makeFakePaymentInfo(t *testing.T,
+	preimage [32]byte) (*PaymentCreationInfo, *PaymentAttemptInfo) {
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+
+	path := make([][33]byte, 2)
+	for i := range path {
+		copy(path[i][:], bytes.Repeat([]byte{byte(i + 2)}, 33))
+	}
+
+	info := &PaymentCreationInfo{
+		PaymentHash:    sha256.Sum256(preimage[:]),
+		Value:          lnwire.NewMSatFromSatoshis(1000),
+		CreationDate:   time.Unix(time.Now().Unix(), 0),
+		PaymentRequest: []byte("fake payment request"),
+	}
+	attempt := &PaymentAttemptInfo{
+		SessionKey: sessionKey,
+		Amt:        info.Value,
+		Fee:        10,
+		TimeLock:   500,
+		Path:       path,
+	}
+
+	return info, attempt
+}
+
+// assertPaymentStatus asserts that the payment to the passed hash has the
+// expected status.
+func assertPaymentStatus(t *testing.T, db *DB, hash [32]byte,
+	expected PaymentStatus) *TrackedPayment {
+
+	payment, err := db.FetchTrackedPayment(hash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if payment.Status != expected {
+		t.Fatalf("expected payment status %v, got %v", expected,
+			payment.Status)
+	}
+
+	return payment
+}
+
+// TestPaymentControlSuccess tests the life cycle of a payment that succeeds
+// after one of its attempts failed.
+func TestPaymentControlSuccess(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	info, attempt := makeFakePaymentInfo(t, rev)
+	hash := info.PaymentHash
+
+	if _, err := db.FetchTrackedPayment(hash); err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	// Attempts can't be registered before the payment is initiated.
+	if err := db.RegisterPaymentAttempt(hash, attempt); err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	if err := db.InitPayment(info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	payment := assertPaymentStatus(t, db, hash, StatusInFlight)
+	if !reflect.DeepEqual(payment.Info, info) {
+		t.Fatalf("creation info mismatch: expected %v, got %v",
+			spew.Sdump(info), spew.Sdump(payment.Info))
+	}
+
+	// A second payment to the same hash must be rejected while the first
+	// is in flight.
+	if err := db.InitPayment(info); err != ErrPaymentInFlight {
+		t.Fatalf("expected ErrPaymentInFlight, got %v", err)
+	}
+
+	// Register an attempt, which should be assigned a payment ID, then
+	// fail it.
+	if err := db.RegisterPaymentAttempt(hash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	failedID := attempt.PaymentID
+	if failedID == 0 {
+		t.Fatalf("attempt wasn't assigned a payment ID")
+	}
+	if err := db.FailPaymentAttempt(hash, failedID); err != nil {
+		t.Fatalf("unable to fail attempt: %v", err)
+	}
+	if err := db.FailPaymentAttempt(hash, failedID); err != ErrPaymentAttemptNotFound {
+		t.Fatalf("expected ErrPaymentAttemptNotFound, got %v", err)
+	}
+
+	// The next attempt must be assigned a new ID, and should be returned
+	// as the only attempt in flight.
+	if err := db.RegisterPaymentAttempt(hash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	if attempt.PaymentID == failedID {
+		t.Fatalf("payment ID %v reused", failedID)
+	}
+
+	inFlight, err := db.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in-flight payments: %v", err)
+	}
+	if len(inFlight) != 1 {
+		t.Fatalf("expected 1 in-flight payment, got %v", len(inFlight))
+	}
+	expectedAttempts := []*PaymentAttemptInfo{attempt}
+	if !reflect.DeepEqual(inFlight[0].Attempts, expectedAttempts) {
+		t.Fatalf("attempts mismatch: expected %v, got %v",
+			spew.Sdump(expectedAttempts),
+			spew.Sdump(inFlight[0].Attempts))
+	}
+
+	if err := db.SettlePayment(hash, rev); err != nil {
+		t.Fatalf("unable to settle payment: %v", err)
+	}
+	payment = assertPaymentStatus(t, db, hash, StatusSucceeded)
+	if payment.Preimage == nil || *payment.Preimage != rev {
+		t.Fatalf("expected preimage %x, got %v", rev, payment.Preimage)
+	}
+
+	// Once settled, the payment can neither be updated, nor be sent
+	// again.
+	if err := db.FailPayment(hash, FailureReasonError, ""); err != ErrPaymentNotInFlight {
+		t.Fatalf("expected ErrPaymentNotInFlight, got %v", err)
+	}
+	if err := db.InitPayment(info); err != ErrAlreadyPaid {
+		t.Fatalf("expected ErrAlreadyPaid, got %v", err)
+	}
+
+	inFlight, err = db.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in-flight payments: %v", err)
+	}
+	if len(inFlight) != 0 {
+		t.Fatalf("expected no in-flight payments, got %v",
+			len(inFlight))
+	}
+}
+
+// TestPaymentControlFailure tests that a failed payment records its failure,
+// may be reattempted, and is removed along with all other completed payments.
+func TestPaymentControlFailure(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	info, _ := makeFakePaymentInfo(t, rev)
+	hash := info.PaymentHash
+
+	if err := db.InitPayment(info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	const failMsg = "unable to find a path"
+	err = db.FailPayment(hash, FailureReasonNoRoute, failMsg)
+	if err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	payment := assertPaymentStatus(t, db, hash, StatusFailed)
+	expectedFailure := &PaymentFailInfo{
+		Reason:  FailureReasonNoRoute,
+		Message: failMsg,
+	}
+	if !reflect.DeepEqual(payment.Failure, expectedFailure) {
+		t.Fatalf("failure mismatch: expected %v, got %v",
+			spew.Sdump(expectedFailure), spew.Sdump(payment.Failure))
+	}
+
+	// As the payment failed, it may be sent again, which should clear the
+	// failure.
+	if err := db.InitPayment(info); err != nil {
+		t.Fatalf("unable to reinit payment: %v", err)
+	}
+	payment = assertPaymentStatus(t, db, hash, StatusInFlight)
+	if payment.Failure != nil {
+		t.Fatalf("expected failure to be cleared, got %v",
+			spew.Sdump(payment.Failure))
+	}
+
+	// Add a second payment which fails, then delete all payments. Only
+	// the one still in flight should remain.
+	var preimage [32]byte
+	copy(preimage[:], bytes.Repeat([]byte{1}, 32))
+	info2, _ := makeFakePaymentInfo(t, preimage)
+	if err := db.InitPayment(info2); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	err = db.FailPayment(info2.PaymentHash, FailureReasonError, "")
+	if err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+
+	payments, err := db.FetchTrackedPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if len(payments) != 2 {
+		t.Fatalf("expected 2 payments, got %v", len(payments))
+	}
+
+	if err := db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+
+	payments, err = db.FetchTrackedPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if len(payments) != 1 || payments[0].Info.PaymentHash != hash {
+		t.Fatalf("expected only the in-flight payment to remain, "+
+			"got %v", spew.Sdump(payments))
+	}
+}
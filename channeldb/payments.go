@@ -42,6 +42,28 @@ type OutgoingPayment struct {
 	// TODO(roasbeef): weave through preimage on payment success to can
 	// store only supplemental info the embedded Invoice
 	PaymentHash [32]byte
+
+	// Shards is the set of shards the payment was split into, if it was
+	// sent over multiple routes. For a payment sent over a single route,
+	// this set is empty.
+	Shards []PaymentShard
+}
+
+// PaymentShard describes a single shard of a payment that was split across
+// multiple routes.
+type PaymentShard struct {
+	// Amt is the amount the shard delivered to the destination.
+	Amt lnwire.MilliSatoshi
+
+	// Fee is the fee paid for routing the shard.
+	Fee lnwire.MilliSatoshi
+
+	// TimeLockLength is the total time-lock of the shard's route.
+	TimeLockLength uint32
+
+	// Path is the path the shard took through the network, excluding the
+	// outgoing node.
+	Path [][33]byte
 }
 
 // AddPayment saves a successful payment to the database. It is assumed that
@@ -118,7 +140,8 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	return payments, nil
 }
 
-// DeleteAllPayments deletes all payments from DB.
+// DeleteAllPayments deletes all payments from DB. Payments that are still in
+// flight are kept, as they're needed to resolve their outstanding HTLCs.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
 		err := tx.DeleteBucket(paymentBucket)
@@ -126,6 +149,10 @@ func (db *DB) DeleteAllPayments() error {
 			return err
 		}
 
+		if err := deleteCompletedPayments(tx); err != nil {
+			return err
+		}
+
 		_, err = tx.CreateBucket(paymentBucket)
 		return err
 	})
@@ -167,6 +194,46 @@ func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 		return err
 	}
 
+	if err := binary.Write(w, byteOrder, uint16(len(p.Shards))); err != nil {
+		return err
+	}
+	for _, shard := range p.Shards {
+		if err := serializePaymentShard(w, &shard); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func serializePaymentShard(w io.Writer, s *PaymentShard) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Amt))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Fee))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], s.TimeLockLength)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], uint32(len(s.Path)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for _, hop := range s.Path {
+		if _, err := w.Write(hop[:]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -208,5 +275,52 @@ func deserializeOutgoingPayment(r io.Reader) (*OutgoingPayment, error) {
 		return nil, err
 	}
 
+	var numShards uint16
+	if err := binary.Read(r, byteOrder, &numShards); err != nil {
+		return nil, err
+	}
+	for i := uint16(0); i < numShards; i++ {
+		shard, err := deserializePaymentShard(r)
+		if err != nil {
+			return nil, err
+		}
+		p.Shards = append(p.Shards, *shard)
+	}
+
 	return p, nil
 }
+
+func deserializePaymentShard(r io.Reader) (*PaymentShard, error) {
+	var scratch [8]byte
+
+	s := &PaymentShard{}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.Amt = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	s.TimeLockLength = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	pathLen := byteOrder.Uint32(scratch[:4])
+
+	s.Path = make([][33]byte, pathLen)
+	for i := uint32(0); i < pathLen; i++ {
+		if _, err := io.ReadFull(r, s.Path[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
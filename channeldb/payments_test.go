@@ -37,6 +37,20 @@ func makeFakePayment() *OutgoingPayment {
 		Path:           fakePath,
 		TimeLockLength: 1000,
 		PaymentHash:    sha256.Sum256(rev[:]),
+		Shards: []PaymentShard{
+			{
+				Amt:            lnwire.NewMSatFromSatoshis(4000),
+				Fee:            40,
+				TimeLockLength: 1000,
+				Path:           fakePath,
+			},
+			{
+				Amt:            lnwire.NewMSatFromSatoshis(6000),
+				Fee:            61,
+				TimeLockLength: 900,
+				Path:           fakePath[:2],
+			},
+		},
 	}
 }
 
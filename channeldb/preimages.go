@@ -0,0 +1,64 @@
+package channeldb
+
+import (
+	"crypto/sha256"
+
+	"github.com/boltdb/bolt"
+)
+
+// preimageBucket is the name of the bucket within the database that stores
+// all the payment preimages we've learned of, either by settling an invoice,
+// or by having one of our outgoing HTLC's settled by the remote party. Within
+// the bucket, each preimage is keyed by its payment hash.
+var preimageBucket = []byte("preimages")
+
+// AddPreimage adds the passed preimage to the preimage cache. Once added, the
+// preimage can later be retrieved using its payment hash. This allows
+// sub-systems that resolve HTLC's on-chain to claim an incoming HTLC with a
+// preimage we learned of via a forwarded outgoing HTLC.
+func (d *DB) AddPreimage(preimage []byte) error {
+	payHash := sha256.Sum256(preimage)
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		preimages, err := tx.CreateBucketIfNotExists(preimageBucket)
+		if err != nil {
+			return err
+		}
+
+		// If this preimage is already present, then we can exit early
+		// to avoid a needless write.
+		if preimages.Get(payHash[:]) != nil {
+			return nil
+		}
+
+		return preimages.Put(payHash[:], preimage)
+	})
+}
+
+// LookupPreimage attempts to look up the preimage of the target payment hash
+// within the preimage cache. If the preimage isn't known, then
+// ErrPreimageNotFound is returned.
+func (d *DB) LookupPreimage(payHash [32]byte) ([]byte, error) {
+	var preimage []byte
+	err := d.View(func(tx *bolt.Tx) error {
+		preimages := tx.Bucket(preimageBucket)
+		if preimages == nil {
+			return ErrPreimageNotFound
+		}
+
+		p := preimages.Get(payHash[:])
+		if p == nil {
+			return ErrPreimageNotFound
+		}
+
+		preimage = make([]byte, len(p))
+		copy(preimage, p)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preimage, nil
+}
@@ -0,0 +1,45 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+// TestPreimageCache tests that we're able to add preimages to the cache, and
+// later retrieve them using their payment hash.
+func TestPreimageCache(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	preimage := bytes.Repeat([]byte{0x42}, 32)
+	payHash := sha256.Sum256(preimage)
+
+	// Before the preimage has been added, a look up should fail.
+	if _, err := db.LookupPreimage(payHash); err != ErrPreimageNotFound {
+		t.Fatalf("expected ErrPreimageNotFound, got: %v", err)
+	}
+
+	if err := db.AddPreimage(preimage); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+
+	// Adding the same preimage a second time should be a noop.
+	if err := db.AddPreimage(preimage); err != nil {
+		t.Fatalf("unable to re-add preimage: %v", err)
+	}
+
+	dbPreimage, err := db.LookupPreimage(payHash)
+	if err != nil {
+		t.Fatalf("unable to lookup preimage: %v", err)
+	}
+	if !bytes.Equal(dbPreimage, preimage) {
+		t.Fatalf("preimage mismatch: expected %x, got %x", preimage,
+			dbPreimage)
+	}
+}
@@ -0,0 +1,599 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanbackup"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// errRestorerShuttingDown is returned when the sweep of a restored channel is
+// interrupted as the chanRestorer is shutting down.
+var errRestorerShuttingDown = fmt.Errorf("channel restorer shutting down")
+
+// chanRecoveryBucket is the top-level bucket that houses all the channels
+// that have been restored from a static channel backup, and are awaiting the
+// sweep of our funds.
+var chanRecoveryBucket = []byte("chan-recovery")
+
+// recoveredChannel is a channel that has been restored from a static channel
+// backup. As we no longer have any of the channel's state, we can only ask
+// the remote party to force close the channel, then sweep our output from
+// their commitment transaction.
+type recoveredChannel struct {
+	// backup is the static channel backup the channel was restored from.
+	backup chanbackup.Single
+
+	// commitPoint is the remote party's current unrevoked commitment
+	// point, which they send to us within their ChannelReestablish
+	// message. This is needed to locate and sweep our output on the
+	// commitment transaction they'll broadcast. It's nil until we've
+	// received their ChannelReestablish message.
+	commitPoint *btcec.PublicKey
+
+	// newCommitPoint is signalled each time the remote party sends us
+	// their commitment point, such that a failed sweep is retried right
+	// away. It isn't persisted.
+	newCommitPoint chan struct{}
+}
+
+// Encode serializes the recovered channel into the passed byte stream.
+func (r *recoveredChannel) Encode(w io.Writer) error {
+	if err := r.backup.Serialize(w); err != nil {
+		return err
+	}
+
+	if r.commitPoint == nil {
+		return nil
+	}
+
+	_, err := w.Write(r.commitPoint.SerializeCompressed())
+	return err
+}
+
+// Decode deserializes a recovered channel from the passed byte stream.
+func (r *recoveredChannel) Decode(rd io.Reader) error {
+	if err := r.backup.Deserialize(rd); err != nil {
+		return err
+	}
+
+	// The commitment point is optional, so we'll only attempt to parse
+	// it if there are bytes remaining.
+	var pointBytes [btcec.PubKeyBytesLenCompressed]byte
+	_, err := io.ReadFull(rd, pointBytes[:])
+	switch {
+	case err == io.EOF:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	r.commitPoint, err = btcec.ParsePubKey(pointBytes[:], btcec.S256())
+	return err
+}
+
+// chanRecoveryStore persists the set of channels that have been restored from
+// a static channel backup, allowing the recovery process to be resumed after
+// a restart.
+type chanRecoveryStore struct {
+	db *channeldb.DB
+}
+
+// newChanRecoveryStore creates a new instance of a chanRecoveryStore.
+func newChanRecoveryStore(db *channeldb.DB) *chanRecoveryStore {
+	return &chanRecoveryStore{
+		db: db,
+	}
+}
+
+// Add persists the recovered channel, overwriting any existing entry for the
+// same channel.
+func (s *chanRecoveryStore) Add(rc *recoveredChannel) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		recoveryBucket, err := tx.CreateBucketIfNotExists(
+			chanRecoveryBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var outBuf bytes.Buffer
+		err = writeOutpoint(&outBuf, &rc.backup.FundingOutpoint)
+		if err != nil {
+			return err
+		}
+
+		var chanBuf bytes.Buffer
+		if err := rc.Encode(&chanBuf); err != nil {
+			return err
+		}
+
+		return recoveryBucket.Put(outBuf.Bytes(), chanBuf.Bytes())
+	})
+}
+
+// Remove removes a recovered channel from the store.
+func (s *chanRecoveryStore) Remove(chanPoint *wire.OutPoint) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		recoveryBucket := tx.Bucket(chanRecoveryBucket)
+		if recoveryBucket == nil {
+			return nil
+		}
+
+		var outBuf bytes.Buffer
+		if err := writeOutpoint(&outBuf, chanPoint); err != nil {
+			return err
+		}
+
+		return recoveryBucket.Delete(outBuf.Bytes())
+	})
+}
+
+// ForAll iterates through all recovered channels and executes the passed
+// callback function on each one.
+func (s *chanRecoveryStore) ForAll(cb func(*recoveredChannel) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		recoveryBucket := tx.Bucket(chanRecoveryBucket)
+		if recoveryBucket == nil {
+			return nil
+		}
+
+		return recoveryBucket.ForEach(func(_, chanBytes []byte) error {
+			rc := &recoveredChannel{}
+			if err := rc.Decode(bytes.NewReader(chanBytes)); err != nil {
+				return err
+			}
+
+			return cb(rc)
+		})
+	})
+}
+
+// chanRestorerConfig houses the interfaces and closures the chanRestorer
+// requires to recover the funds within channels restored from a backup.
+type chanRestorerConfig struct {
+	// Store persists the set of channels being recovered.
+	Store *chanRecoveryStore
+
+	// Notifier is used to watch for the remote party's commitment
+	// transaction to be broadcast.
+	Notifier chainntnfs.ChainNotifier
+
+	// SendToPeer sends a message to the target peer.
+	SendToPeer func(*btcec.PublicKey, ...lnwire.Message) error
+
+	// SweepInput hands our output to the utxo sweeper, which sweeps it
+	// back into the wallet.
+	SweepInput func(SpendableOutput, uint32) (chan *sweepResult, error)
+}
+
+// chanRestorer drives the recovery of channels restored from a static
+// channel backup. Once the remote party of a restored channel connects to
+// us, and sends their ChannelReestablish message, we'll record their current
+// commitment point, then ask them to force close the channel by sending an
+// error. Once their commitment transaction confirms, our output within it is
+// swept back to our wallet.
+type chanRestorer struct {
+	started uint32
+	stopped uint32
+
+	cfg *chanRestorerConfig
+
+	// channels is the set of channels currently being recovered.
+	channels map[lnwire.ChannelID]*recoveredChannel
+	mu       sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile time check to ensure chanRestorer meets the
+// chanbackup.ChannelRestorer interface.
+var _ chanbackup.ChannelRestorer = (*chanRestorer)(nil)
+
+// newChanRestorer creates a new chanRestorer backed by the passed config.
+func newChanRestorer(cfg *chanRestorerConfig) *chanRestorer {
+	return &chanRestorer{
+		cfg:      cfg,
+		channels: make(map[lnwire.ChannelID]*recoveredChannel),
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start resumes the recovery of any channels which were restored prior to a
+// restart.
+func (c *chanRestorer) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Starting channel restorer")
+
+	var recovered []*recoveredChannel
+	err := c.cfg.Store.ForAll(func(rc *recoveredChannel) error {
+		recovered = append(recovered, rc)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rc := range recovered {
+		if err := c.watchChannel(rc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop signals the chanRestorer to exit, and waits for all goroutines to do
+// so.
+func (c *chanRestorer) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Stopping channel restorer")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// RestoreChansFromSingles persists each of the passed channel backups, and
+// begins to watch for the remote party's commitment transaction.
+//
+// NOTE: Part of the chanbackup.ChannelRestorer interface.
+func (c *chanRestorer) RestoreChansFromSingles(
+	backups ...chanbackup.Single) error {
+
+	// We'll refuse to restore any channel that we still have the full
+	// state of, as asking the remote party to force close it would only
+	// cost us the channel.
+	openChans, err := c.cfg.Store.db.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+	liveChans := make(map[wire.OutPoint]struct{}, len(openChans))
+	for _, openChan := range openChans {
+		liveChans[openChan.FundingOutpoint] = struct{}{}
+	}
+
+	for _, backup := range backups {
+		if _, ok := liveChans[backup.FundingOutpoint]; ok {
+			srvrLog.Infof("ChannelPoint(%v) is still open, skipping "+
+				"restore", backup.FundingOutpoint)
+			continue
+		}
+
+		chanID := lnwire.NewChanIDFromOutPoint(&backup.FundingOutpoint)
+
+		c.mu.Lock()
+		_, ok := c.channels[chanID]
+		c.mu.Unlock()
+		if ok {
+			srvrLog.Infof("ChannelPoint(%v) is already being "+
+				"recovered", backup.FundingOutpoint)
+			continue
+		}
+
+		rc := &recoveredChannel{
+			backup: backup,
+		}
+		if err := c.cfg.Store.Add(rc); err != nil {
+			return err
+		}
+
+		if err := c.watchChannel(rc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessChanReestablish handles a ChannelReestablish message sent by the
+// remote party of a channel. If the channel is being recovered, the remote
+// party's commitment point is stored, and we'll ask them to force close the
+// channel. True is returned if the message was consumed, otherwise it should
+// be dispatched to the channel's link as normal.
+func (c *chanRestorer) ProcessChanReestablish(msg *lnwire.ChannelReestablish,
+	peerPub *btcec.PublicKey) bool {
+
+	c.mu.Lock()
+	rc, ok := c.channels[msg.ChanID]
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	chanPoint := rc.backup.FundingOutpoint
+	if !rc.backup.RemoteNodePub.IsEqual(peerPub) {
+		srvrLog.Warnf("Received ChannelReestablish for restored "+
+			"ChannelPoint(%v) from unexpected peer %x", chanPoint,
+			peerPub.SerializeCompressed())
+		return true
+	}
+
+	// Without the commitment point, we won't be able to sweep our output
+	// once they close the channel. We'll still ask them to close the
+	// channel, as there's no other way to retrieve our funds.
+	if msg.LocalUnrevokedCommitPoint == nil {
+		srvrLog.Warnf("Remote party of restored ChannelPoint(%v) "+
+			"didn't send its commitment point, our funds may be "+
+			"unrecoverable", chanPoint)
+	} else {
+		c.mu.Lock()
+		rc.commitPoint = msg.LocalUnrevokedCommitPoint
+		err := c.cfg.Store.Add(rc)
+		c.mu.Unlock()
+		if err != nil {
+			srvrLog.Errorf("unable to store commitment point for "+
+				"restored ChannelPoint(%v): %v", chanPoint, err)
+			return true
+		}
+
+		select {
+		case rc.newCommitPoint <- struct{}{}:
+		default:
+		}
+	}
+
+	srvrLog.Infof("Requesting remote party to force close restored "+
+		"ChannelPoint(%v)", chanPoint)
+
+	// Per the spec, upon receiving an error tied to a channel, the
+	// receiver must fail the channel, broadcasting its commitment
+	// transaction.
+	err := c.cfg.SendToPeer(peerPub, &lnwire.Error{
+		ChanID: msg.ChanID,
+		Data: lnwire.ErrorData(
+			"channel restored from static backup, please force " +
+				"close",
+		),
+	})
+	if err != nil {
+		srvrLog.Errorf("unable to request force close of restored "+
+			"ChannelPoint(%v): %v", chanPoint, err)
+	}
+
+	return true
+}
+
+// watchChannel begins to track the passed recovered channel, and launches a
+// goroutine which sweeps our funds once the channel is closed.
+func (c *chanRestorer) watchChannel(rc *recoveredChannel) error {
+	chanPoint := rc.backup.FundingOutpoint
+
+	spendNtfn, err := c.cfg.Notifier.RegisterSpendNtfn(
+		&chanPoint, rc.backup.ShortChannelID.BlockHeight,
+	)
+	if err != nil {
+		return err
+	}
+
+	blockEpochs, err := c.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		spendNtfn.Cancel()
+		return err
+	}
+
+	rc.newCommitPoint = make(chan struct{}, 1)
+
+	chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+	c.mu.Lock()
+	c.channels[chanID] = rc
+	c.mu.Unlock()
+
+	srvrLog.Infof("Watching for closure of restored ChannelPoint(%v)",
+		chanPoint)
+
+	c.wg.Add(1)
+	go c.waitForClose(rc, spendNtfn, blockEpochs)
+
+	return nil
+}
+
+// waitForClose waits for the funding output of the restored channel to be
+// spent, then sweeps our output from the remote party's commitment
+// transaction. If the sweep fails, for example as the remote party has yet to
+// send us their commitment point, then it's retried with each new block, or
+// once they reconnect, until the sweep confirms.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *chanRestorer) waitForClose(rc *recoveredChannel,
+	spendNtfn *chainntnfs.SpendEvent,
+	blockEpochs *chainntnfs.BlockEpochEvent) {
+
+	defer c.wg.Done()
+	defer blockEpochs.Cancel()
+
+	chanPoint := rc.backup.FundingOutpoint
+
+	var spend *chainntnfs.SpendDetail
+	select {
+	case s, ok := <-spendNtfn.Spend:
+		if !ok {
+			return
+		}
+		spend = s
+
+	case <-c.quit:
+		return
+	}
+
+	srvrLog.Infof("Restored ChannelPoint(%v) closed by txid=%v",
+		chanPoint, spend.SpenderTxHash)
+
+	for {
+		err := c.sweepChannel(rc, spend.SpendingTx)
+		if err == nil {
+			break
+		}
+		if err == errRestorerShuttingDown {
+			return
+		}
+
+		srvrLog.Errorf("unable to sweep restored ChannelPoint(%v), "+
+			"will retry: %v", chanPoint, err)
+
+		select {
+		case _, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+		case <-rc.newCommitPoint:
+
+		case <-c.quit:
+			return
+		}
+	}
+
+	chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+	c.mu.Lock()
+	delete(c.channels, chanID)
+	c.mu.Unlock()
+
+	if err := c.cfg.Store.Remove(&chanPoint); err != nil {
+		srvrLog.Errorf("unable to remove restored ChannelPoint(%v): "+
+			"%v", chanPoint, err)
+	}
+}
+
+// sweepChannel sweeps our output within the passed commitment transaction
+// broadcast by the remote party of a restored channel, and blocks until the
+// sweep has confirmed.
+func (c *chanRestorer) sweepChannel(rc *recoveredChannel,
+	commitTx *wire.MsgTx) error {
+
+	chanPoint := rc.backup.FundingOutpoint
+
+	c.mu.Lock()
+	commitPoint := rc.commitPoint
+	c.mu.Unlock()
+
+	if commitPoint == nil {
+		return fmt.Errorf("commitment point of remote party unknown")
+	}
+
+	selfPoint, signDesc, err := lnwallet.NewRemoteCommitSelfOutput(
+		commitTx, commitPoint, rc.backup.LocalChanCfg.PaymentBasePoint,
+	)
+	if err != nil {
+		return err
+	}
+
+	// If we didn't have an output above dust on the commitment, then
+	// there's nothing left for us to sweep.
+	if selfPoint == nil {
+		srvrLog.Infof("No output to sweep for restored "+
+			"ChannelPoint(%v)", chanPoint)
+		return nil
+	}
+
+	selfOutput := makeBreachedOutput(
+		selfPoint, lnwallet.CommitmentNoDelay, signDesc,
+	)
+
+	srvrLog.Infof("Sweeping %v from restored ChannelPoint(%v)",
+		signDesc.Output.Value, chanPoint)
+
+	// The sweeper persists the output, so it'll be swept even if we
+	// restart before its sweep confirms. Offering it once more in that
+	// case only attaches another listener.
+	resultChan, err := c.cfg.SweepInput(&selfOutput, 0)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case result := <-resultChan:
+		switch result.Err {
+		case nil:
+			srvrLog.Infof("Swept restored ChannelPoint(%v) with "+
+				"txid=%v", chanPoint, result.Tx.TxHash())
+			return nil
+
+		// Only we hold the key of our output, so if another
+		// transaction spent it, there's nothing left to sweep.
+		case ErrRemoteSpend:
+			srvrLog.Warnf("Output of restored ChannelPoint(%v) "+
+				"spent by txid=%v", chanPoint,
+				result.Tx.TxHash())
+			return nil
+
+		default:
+			return result.Err
+		}
+
+	case <-c.quit:
+		return errRestorerShuttingDown
+	}
+}
+
+// ConnectPeer attempts to connect to the target node at the set of available
+// addresses. A persistent connection is requested, so the connection manager
+// will continue to attempt to connect to the node in the background.
+//
+// NOTE: Part of the chanbackup.PeerConnector interface.
+func (s *server) ConnectPeer(nodePub *btcec.PublicKey, addrs []net.Addr) error {
+	// The remote party only sends its ChannelReestablish message for the
+	// restored channel when a connection is first established, so if
+	// we're already connected to the peer, we'll disconnect before
+	// connecting once again.
+	if _, err := s.FindPeer(nodePub); err == nil {
+		if err := s.DisconnectPeer(nodePub); err != nil {
+			return err
+		}
+	}
+
+	var connErr error
+	for _, addr := range addrs {
+		// Onion services can only be reached if our connections are
+		// routed through Tor.
+		switch addr.(type) {
+		case *net.TCPAddr:
+		case *tor.OnionAddr:
+			if !cfg.Tor.Active {
+				continue
+			}
+		default:
+			continue
+		}
+
+		netAddr := &lnwire.NetAddress{
+			IdentityKey: nodePub,
+			Address:     addr,
+			ChainNet:    activeNetParams.Net,
+		}
+
+		connErr = s.ConnectToPeer(netAddr, true)
+		if connErr == nil {
+			return nil
+		}
+
+		srvrLog.Warnf("unable to connect to %v: %v", netAddr, connErr)
+	}
+
+	if connErr == nil {
+		return fmt.Errorf("no addresses known for node %x",
+			nodePub.SerializeCompressed())
+	}
+
+	return connErr
+}
@@ -0,0 +1,440 @@
+package cipherseed
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/salsa20"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// CipherSeedVersion is the current version of the cipher seed. This is
+	// the version that is encoded within the encrypted plaintext, and
+	// dictates how the entropy of the seed should be used to derive the
+	// keys of the wallet.
+	CipherSeedVersion uint8 = 0
+
+	// EncipheredVersion is the version of the enciphered seed. This is the
+	// first byte of the serialized seed, and dictates how the remainder of
+	// it should be decrypted.
+	EncipheredVersion uint8 = 0
+
+	// EntropySize is the number of bytes of entropy that are used to
+	// generate the root key of the wallet.
+	EntropySize = 16
+
+	// NumMnemonicWords is the number of words that a mnemonic encoding a
+	// cipher seed is made up of.
+	NumMnemonicWords = 24
+
+	// saltSize is the size of the salt that's fed into scrypt along with
+	// the passphrase of the seed.
+	saltSize = 5
+
+	// tagSize is the size of the truncated MAC that authenticates the
+	// ciphertext. A tag of this size is large enough to detect an
+	// incorrect passphrase or a mistyped word, yet still allows the full
+	// seed to fit within 24 words.
+	tagSize = 4
+
+	// checkSumSize is the size of the CRC-32 checksum at the end of the
+	// enciphered seed.
+	checkSumSize = 4
+
+	// decipheredCipherSeedSize is the size of the plaintext seed:
+	//
+	//  * 1 byte internal version || 2 byte birthday || 16 bytes entropy
+	decipheredCipherSeedSize = 1 + 2 + EntropySize
+
+	// cipherTextSize is the size of the encrypted seed along with its
+	// authentication tag.
+	cipherTextSize = decipheredCipherSeedSize + tagSize
+
+	// EncipheredCipherSeedSize is the size of the fully serialized and
+	// encrypted cipher seed:
+	//
+	//  * 1 byte version || 23 bytes ciphertext || 5 bytes salt ||
+	//    4 bytes checksum
+	EncipheredCipherSeedSize = 1 + cipherTextSize + saltSize + checkSumSize
+
+	// bitsPerWord is the number of bits that each word of the mnemonic
+	// encodes.
+	bitsPerWord = 11
+
+	// keyLen is the number of bytes we derive from the passphrase. The
+	// first half is used as the encryption key, and the second half as the
+	// MAC key.
+	keyLen = 64
+
+	// defaultPassphrase is the passphrase that is used to encrypt the
+	// seed if the user doesn't specify one.
+	defaultPassphrase = "cipherseed"
+)
+
+var (
+	// BitcoinGenesisDate is the timestamp of Bitcoin's genesis block.
+	// The birthday of a seed is encoded as the number of days since this
+	// date.
+	BitcoinGenesisDate = time.Unix(1231006505, 0)
+
+	// scryptN, scryptR and scryptP are the parameters of the scrypt KDF
+	// used to derive the encryption and MAC keys from the passphrase.
+	// They're variables so the tests are able to lower the work factor.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// CipherSeed is a fully decoded instance of a seed. A cipher seed is a
+// versioned, encrypted and checksummed seed that also records the birthday of
+// the wallet it was generated for. The birthday allows a wallet that's
+// restored from the seed to only rescan the chain from the point the wallet
+// was created, rather than from genesis.
+type CipherSeed struct {
+	// InternalVersion is the version of the plaintext seed. This dictates
+	// how the entropy is to be used to derive the keys of the wallet.
+	InternalVersion uint8
+
+	// Birthday is the number of days since the Bitcoin genesis block that
+	// the seed was created at.
+	Birthday uint16
+
+	// Entropy is the set of bytes from which the root key of the wallet is
+	// derived.
+	Entropy [EntropySize]byte
+
+	// salt is the salt that's used along with the passphrase to derive
+	// the encryption key of the seed.
+	salt [saltSize]byte
+}
+
+// New generates a new CipherSeed for the target internal version, with the
+// birthday set to the passed time. If entropy isn't specified, then fresh
+// entropy will be read from the system's CSPRNG.
+func New(internalVersion uint8, entropy *[EntropySize]byte,
+	now time.Time) (*CipherSeed, error) {
+
+	// For now, we only have a single internal version, so we'll reject
+	// all others.
+	if internalVersion != CipherSeedVersion {
+		return nil, ErrIncorrectVersion
+	}
+
+	var seed [EntropySize]byte
+	if entropy == nil {
+		if _, err := rand.Read(seed[:]); err != nil {
+			return nil, err
+		}
+	} else {
+		copy(seed[:], entropy[:])
+	}
+
+	// The birthday is expressed as the number of days since the genesis
+	// block, which gives us well over a hundred years of range within two
+	// bytes.
+	birthday := uint16(now.Sub(BitcoinGenesisDate) / (time.Hour * 24))
+
+	c := &CipherSeed{
+		InternalVersion: internalVersion,
+		Birthday:        birthday,
+		Entropy:         seed,
+	}
+
+	// Finally, we'll generate the salt that's used to stretch the
+	// passphrase each time the seed is enciphered.
+	if _, err := rand.Read(c.salt[:]); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// BirthdayTime returns the time at which the seed was created, with a
+// granularity of a day.
+func (c *CipherSeed) BirthdayTime() time.Time {
+	offset := time.Duration(c.Birthday) * time.Hour * 24
+	return BitcoinGenesisDate.Add(offset)
+}
+
+// encode serializes the plaintext of the cipher seed to the passed writer.
+func (c *CipherSeed) encode(w io.Writer) error {
+	if _, err := w.Write([]byte{c.InternalVersion}); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.BigEndian, c.Birthday); err != nil {
+		return err
+	}
+
+	_, err := w.Write(c.Entropy[:])
+	return err
+}
+
+// decode deserializes the plaintext of the cipher seed from the passed
+// reader.
+func (c *CipherSeed) decode(r io.Reader) error {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return err
+	}
+	c.InternalVersion = version[0]
+
+	if err := binary.Read(r, binary.BigEndian, &c.Birthday); err != nil {
+		return err
+	}
+
+	_, err := io.ReadFull(r, c.Entropy[:])
+	return err
+}
+
+// deriveKeys stretches the passphrase using the salt, returning the key used
+// to encrypt the seed, and the key used to authenticate the ciphertext.
+func deriveKeys(pass []byte, salt [saltSize]byte) (*[32]byte, []byte, error) {
+	if len(pass) == 0 {
+		pass = []byte(defaultPassphrase)
+	}
+
+	key, err := scrypt.Key(pass, salt[:], scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var encKey [32]byte
+	copy(encKey[:], key[:32])
+
+	return &encKey, key[32:], nil
+}
+
+// computeTag computes the truncated MAC that authenticates the ciphertext. The
+// version and salt are included as associated data.
+func computeTag(macKey []byte, version uint8, cipherText []byte,
+	salt [saltSize]byte) []byte {
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte{version})
+	mac.Write(cipherText)
+	mac.Write(salt[:])
+
+	return mac.Sum(nil)[:tagSize]
+}
+
+// Encipher encrypts the cipher seed under the passed passphrase, returning
+// the serialized enciphered seed. If the passphrase is empty, then a default
+// passphrase is used. The resulting format is:
+//
+//  * 1 byte version || 23 bytes ciphertext || 5 bytes salt ||
+//    4 bytes checksum
+//
+// The checksum covers all prior bytes, which allows a mistyped mnemonic to be
+// detected before attempting to decrypt it.
+func (c *CipherSeed) Encipher(pass []byte) ([EncipheredCipherSeedSize]byte,
+	error) {
+
+	var cipherSeedBytes [EncipheredCipherSeedSize]byte
+
+	var plainText bytes.Buffer
+	if err := c.encode(&plainText); err != nil {
+		return cipherSeedBytes, err
+	}
+
+	encKey, macKey, err := deriveKeys(pass, c.salt)
+	if err != nil {
+		return cipherSeedBytes, err
+	}
+
+	// As a fresh key is derived for each salt, we're able to use an
+	// all-zero nonce.
+	var nonce [8]byte
+	cipherText := make([]byte, decipheredCipherSeedSize)
+	salsa20.XORKeyStream(cipherText, plainText.Bytes(), nonce[:], encKey)
+
+	tag := computeTag(macKey, EncipheredVersion, cipherText, c.salt)
+
+	offset := 0
+	cipherSeedBytes[offset] = EncipheredVersion
+	offset++
+	offset += copy(cipherSeedBytes[offset:], cipherText)
+	offset += copy(cipherSeedBytes[offset:], tag)
+	offset += copy(cipherSeedBytes[offset:], c.salt[:])
+
+	checkSum := crc32.Checksum(
+		cipherSeedBytes[:offset], crc32.MakeTable(crc32.Castagnoli),
+	)
+	binary.BigEndian.PutUint32(cipherSeedBytes[offset:], checkSum)
+
+	return cipherSeedBytes, nil
+}
+
+// ToMnemonic enciphers the cipher seed under the passed passphrase, and maps
+// the result to a mnemonic of 24 words.
+func (c *CipherSeed) ToMnemonic(pass []byte) (Mnemonic, error) {
+	cipherSeedBytes, err := c.Encipher(pass)
+	if err != nil {
+		return Mnemonic{}, err
+	}
+
+	return cipherTextToMnemonic(cipherSeedBytes), nil
+}
+
+// Decipher attempts to decrypt the serialized enciphered seed using the
+// passed passphrase. ErrIncorrectMnemonic is returned if the checksum doesn't
+// match, and ErrInvalidPass if the passphrase is incorrect.
+func Decipher(cipherSeedBytes [EncipheredCipherSeedSize]byte,
+	pass []byte) (*CipherSeed, error) {
+
+	// Before we attempt to decrypt the seed, we'll ensure that it was
+	// transcribed properly by verifying the checksum.
+	checkSumOffset := EncipheredCipherSeedSize - checkSumSize
+	checkSum := binary.BigEndian.Uint32(cipherSeedBytes[checkSumOffset:])
+	freshCheckSum := crc32.Checksum(
+		cipherSeedBytes[:checkSumOffset],
+		crc32.MakeTable(crc32.Castagnoli),
+	)
+	if checkSum != freshCheckSum {
+		return nil, ErrIncorrectMnemonic
+	}
+
+	// With the checksum verified, we'll ensure we know how to decrypt a
+	// seed of this version.
+	version := cipherSeedBytes[0]
+	if version != EncipheredVersion {
+		return nil, ErrIncorrectVersion
+	}
+
+	cipherText := cipherSeedBytes[1 : 1+decipheredCipherSeedSize]
+	tag := cipherSeedBytes[1+decipheredCipherSeedSize : 1+cipherTextSize]
+
+	var salt [saltSize]byte
+	copy(salt[:], cipherSeedBytes[1+cipherTextSize:checkSumOffset])
+
+	encKey, macKey, err := deriveKeys(pass, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the tag doesn't match, then the passphrase must be incorrect, as
+	// the checksum has already confirmed the mnemonic itself is intact.
+	expectedTag := computeTag(macKey, version, cipherText, salt)
+	if !hmac.Equal(tag, expectedTag) {
+		return nil, ErrInvalidPass
+	}
+
+	var nonce [8]byte
+	plainText := make([]byte, decipheredCipherSeedSize)
+	salsa20.XORKeyStream(plainText, cipherText, nonce[:], encKey)
+
+	c := &CipherSeed{
+		salt: salt,
+	}
+	if err := c.decode(bytes.NewReader(plainText)); err != nil {
+		return nil, err
+	}
+
+	if c.InternalVersion != CipherSeedVersion {
+		return nil, ErrIncorrectVersion
+	}
+
+	return c, nil
+}
+
+// cipherTextToMnemonic maps the enciphered seed to a mnemonic, with each word
+// encoding 11 bits of the enciphered seed.
+func cipherTextToMnemonic(
+	cipherSeedBytes [EncipheredCipherSeedSize]byte) Mnemonic {
+
+	var words Mnemonic
+
+	var (
+		acc     uint32
+		accBits uint
+		i       int
+	)
+	for _, b := range cipherSeedBytes {
+		acc = acc<<8 | uint32(b)
+		accBits += 8
+
+		if accBits >= bitsPerWord {
+			accBits -= bitsPerWord
+			index := (acc >> accBits) & (1<<bitsPerWord - 1)
+
+			words[i] = defaultWordList[index]
+			i++
+		}
+	}
+
+	return words
+}
+
+// mnemonicToCipherText maps the mnemonic back to the enciphered seed it
+// encodes.
+func mnemonicToCipherText(
+	mnemonic *Mnemonic) ([EncipheredCipherSeedSize]byte, error) {
+
+	var cipherSeedBytes [EncipheredCipherSeedSize]byte
+
+	var (
+		acc     uint32
+		accBits uint
+		i       int
+	)
+	for wordIndex, word := range mnemonic {
+		index, ok := reverseWordMap[strings.ToLower(word)]
+		if !ok {
+			return cipherSeedBytes, ErrUnknownMnemonicWord{
+				Word:  word,
+				Index: uint8(wordIndex),
+			}
+		}
+
+		acc = acc<<bitsPerWord | uint32(index)
+		accBits += bitsPerWord
+
+		for accBits >= 8 {
+			accBits -= 8
+			cipherSeedBytes[i] = byte(acc >> accBits)
+			i++
+		}
+	}
+
+	return cipherSeedBytes, nil
+}
+
+// Mnemonic is a 24 word encoding of an enciphered cipher seed.
+type Mnemonic [NumMnemonicWords]string
+
+// ToCipherSeed attempts to map the mnemonic to its enciphered seed, then
+// decrypt it using the passed passphrase.
+func (m *Mnemonic) ToCipherSeed(pass []byte) (*CipherSeed, error) {
+	cipherSeedBytes, err := mnemonicToCipherText(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decipher(cipherSeedBytes, pass)
+}
+
+// ChangePass decrypts the mnemonic using the old passphrase, and returns a
+// new mnemonic that encodes the same seed, encrypted under the new
+// passphrase.
+func (m *Mnemonic) ChangePass(oldPass, newPass []byte) (Mnemonic, error) {
+	cipherSeed, err := m.ToCipherSeed(oldPass)
+	if err != nil {
+		return Mnemonic{}, err
+	}
+
+	// A fresh salt is used for the new passphrase, so the new mnemonic
+	// can't be linked to the old one.
+	if _, err := rand.Read(cipherSeed.salt[:]); err != nil {
+		return Mnemonic{}, err
+	}
+
+	return cipherSeed.ToMnemonic(newPass)
+}
@@ -0,0 +1,255 @@
+package cipherseed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+	"time"
+)
+
+var (
+	testEntropy = [EntropySize]byte{
+		0x81, 0xb6, 0x37, 0xd8,
+		0x63, 0x59, 0xe6, 0x96,
+		0x0d, 0xe7, 0x95, 0xe4,
+		0x1e, 0x0b, 0x4c, 0xfd,
+	}
+
+	testPass = []byte("test")
+)
+
+func init() {
+	// The default work factor of scrypt makes the tests needlessly slow,
+	// so we'll lower it for the tests.
+	scryptN = 16
+}
+
+func assertCipherSeedEqual(t *testing.T, cipherSeed,
+	cipherSeed2 *CipherSeed) {
+
+	if cipherSeed.InternalVersion != cipherSeed2.InternalVersion {
+		t.Fatalf("mismatched versions: expected %v, got %v",
+			cipherSeed.InternalVersion, cipherSeed2.InternalVersion)
+	}
+	if cipherSeed.Birthday != cipherSeed2.Birthday {
+		t.Fatalf("mismatched birthday: expected %v, got %v",
+			cipherSeed.Birthday, cipherSeed2.Birthday)
+	}
+	if cipherSeed.Entropy != cipherSeed2.Entropy {
+		t.Fatalf("mismatched entropy: expected %x, got %x",
+			cipherSeed.Entropy[:], cipherSeed2.Entropy[:])
+	}
+}
+
+// TestCipherSeedMnemonicRoundTrip tests that a cipher seed can be mapped to a
+// mnemonic and back again, with and without a passphrase, and that the
+// birthday survives the round trip.
+func TestCipherSeedMnemonicRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2018, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cipherSeed, err := New(CipherSeedVersion, &testEntropy, now)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	for _, pass := range [][]byte{testPass, nil} {
+		mnemonic, err := cipherSeed.ToMnemonic(pass)
+		if err != nil {
+			t.Fatalf("unable to create mnemonic: %v", err)
+		}
+
+		cipherSeed2, err := mnemonic.ToCipherSeed(pass)
+		if err != nil {
+			t.Fatalf("unable to decipher mnemonic: %v", err)
+		}
+
+		assertCipherSeedEqual(t, cipherSeed, cipherSeed2)
+	}
+
+	birthday := cipherSeed.BirthdayTime()
+	if now.Sub(birthday) < 0 || now.Sub(birthday) >= time.Hour*24 {
+		t.Fatalf("birthday %v should be within a day before %v",
+			birthday, now)
+	}
+}
+
+// TestCipherSeedFreshEntropy tests that if no entropy is specified, then fresh
+// entropy is generated for each new seed.
+func TestCipherSeedFreshEntropy(t *testing.T) {
+	t.Parallel()
+
+	cipherSeed, err := New(CipherSeedVersion, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	cipherSeed2, err := New(CipherSeedVersion, nil, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	if cipherSeed.Entropy == cipherSeed2.Entropy {
+		t.Fatalf("seeds share the same entropy")
+	}
+}
+
+// TestCipherSeedInvalidVersion tests that we refuse to create a seed of an
+// unknown version.
+func TestCipherSeedInvalidVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(CipherSeedVersion+1, &testEntropy, time.Now())
+	if err != ErrIncorrectVersion {
+		t.Fatalf("expected ErrIncorrectVersion, instead got %v", err)
+	}
+}
+
+// TestDecipherIncorrectPass tests that deciphering a mnemonic with the wrong
+// passphrase is detected.
+func TestDecipherIncorrectPass(t *testing.T) {
+	t.Parallel()
+
+	cipherSeed, err := New(CipherSeedVersion, &testEntropy, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	mnemonic, err := cipherSeed.ToMnemonic(testPass)
+	if err != nil {
+		t.Fatalf("unable to create mnemonic: %v", err)
+	}
+
+	_, err = mnemonic.ToCipherSeed([]byte("wrong"))
+	if err != ErrInvalidPass {
+		t.Fatalf("expected ErrInvalidPass, instead got %v", err)
+	}
+}
+
+// TestDecipherIncorrectMnemonic tests that a mnemonic with a swapped or
+// unknown word is rejected.
+func TestDecipherIncorrectMnemonic(t *testing.T) {
+	t.Parallel()
+
+	cipherSeed, err := New(CipherSeedVersion, &testEntropy, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	mnemonic, err := cipherSeed.ToMnemonic(testPass)
+	if err != nil {
+		t.Fatalf("unable to create mnemonic: %v", err)
+	}
+
+	// Swapping two distinct words should be caught by the checksum.
+	swapped := mnemonic
+	for i := 1; i < NumMnemonicWords; i++ {
+		if swapped[i] != swapped[0] {
+			swapped[0], swapped[i] = swapped[i], swapped[0]
+			break
+		}
+	}
+	_, err = swapped.ToCipherSeed(testPass)
+	if err != ErrIncorrectMnemonic {
+		t.Fatalf("expected ErrIncorrectMnemonic, instead got %v", err)
+	}
+
+	// A word that isn't part of the word list should be reported along
+	// with its index.
+	unknown := mnemonic
+	unknown[3] = "kek"
+	_, err = unknown.ToCipherSeed(testPass)
+	wordErr, ok := err.(ErrUnknownMnemonicWord)
+	if !ok {
+		t.Fatalf("expected ErrUnknownMnemonicWord, instead got %v", err)
+	}
+	if wordErr.Index != 3 || wordErr.Word != "kek" {
+		t.Fatalf("unexpected error: %v", wordErr)
+	}
+}
+
+// TestDecipherIncorrectVersion tests that an enciphered seed of an unknown
+// version is rejected.
+func TestDecipherIncorrectVersion(t *testing.T) {
+	t.Parallel()
+
+	cipherSeed, err := New(CipherSeedVersion, &testEntropy, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	cipherSeedBytes, err := cipherSeed.Encipher(testPass)
+	if err != nil {
+		t.Fatalf("unable to encipher seed: %v", err)
+	}
+
+	// We'll bump the version, then recompute the checksum so the modified
+	// seed is otherwise well formed.
+	cipherSeedBytes[0] = EncipheredVersion + 1
+	checkSumOffset := EncipheredCipherSeedSize - checkSumSize
+	checkSum := crc32.Checksum(
+		cipherSeedBytes[:checkSumOffset],
+		crc32.MakeTable(crc32.Castagnoli),
+	)
+	binary.BigEndian.PutUint32(cipherSeedBytes[checkSumOffset:], checkSum)
+
+	_, err = Decipher(cipherSeedBytes, testPass)
+	if err != ErrIncorrectVersion {
+		t.Fatalf("expected ErrIncorrectVersion, instead got %v", err)
+	}
+}
+
+// TestMnemonicEncoding tests that the mapping between an enciphered seed and
+// its mnemonic is reversible.
+func TestMnemonicEncoding(t *testing.T) {
+	t.Parallel()
+
+	var cipherSeedBytes [EncipheredCipherSeedSize]byte
+	for i := range cipherSeedBytes {
+		cipherSeedBytes[i] = byte(i * 7)
+	}
+
+	mnemonic := cipherTextToMnemonic(cipherSeedBytes)
+	mnemonicBytes, err := mnemonicToCipherText(&mnemonic)
+	if err != nil {
+		t.Fatalf("unable to decode mnemonic: %v", err)
+	}
+	if !bytes.Equal(mnemonicBytes[:], cipherSeedBytes[:]) {
+		t.Fatalf("mnemonic mapping isn't reversible: expected %x, "+
+			"got %x", cipherSeedBytes[:], mnemonicBytes[:])
+	}
+}
+
+// TestMnemonicChangePass tests that changing the passphrase of a mnemonic
+// yields a mnemonic that encodes the same seed, and which can only be
+// deciphered with the new passphrase.
+func TestMnemonicChangePass(t *testing.T) {
+	t.Parallel()
+
+	cipherSeed, err := New(CipherSeedVersion, &testEntropy, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+
+	mnemonic, err := cipherSeed.ToMnemonic(testPass)
+	if err != nil {
+		t.Fatalf("unable to create mnemonic: %v", err)
+	}
+
+	newPass := []byte("strongerpassyeh!")
+	newMnemonic, err := mnemonic.ChangePass(testPass, newPass)
+	if err != nil {
+		t.Fatalf("unable to change passphrase: %v", err)
+	}
+
+	if _, err := newMnemonic.ToCipherSeed(testPass); err != ErrInvalidPass {
+		t.Fatalf("expected ErrInvalidPass, instead got %v", err)
+	}
+
+	cipherSeed2, err := newMnemonic.ToCipherSeed(newPass)
+	if err != nil {
+		t.Fatalf("unable to decipher mnemonic: %v", err)
+	}
+
+	assertCipherSeedEqual(t, cipherSeed, cipherSeed2)
+}
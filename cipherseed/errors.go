@@ -0,0 +1,38 @@
+package cipherseed
+
+import "fmt"
+
+var (
+	// ErrIncorrectVersion is returned if a seed bears a mismatched
+	// external version to that of the package executing the cipher seed
+	// scheme.
+	ErrIncorrectVersion = fmt.Errorf("wrong seed version")
+
+	// ErrInvalidPass is returned if the user enters an invalid passphrase
+	// for a particular enciphered mnemonic.
+	ErrInvalidPass = fmt.Errorf("invalid passphrase")
+
+	// ErrIncorrectMnemonic is returned if we detect that the checksum of
+	// the specified mnemonic doesn't match. This indicates the user input
+	// the wrong mnemonic.
+	ErrIncorrectMnemonic = fmt.Errorf("mnemonic phrase checksum doesn't " +
+		"match")
+)
+
+// ErrUnknownMnemonicWord is returned when attempting to decipher a mnemonic
+// that contains a word which isn't within our word list. The index and word
+// are returned so the user is able to locate the mistake.
+type ErrUnknownMnemonicWord struct {
+	// Word is the unknown word in the mnemonic phrase.
+	Word string
+
+	// Index is the index (starting from zero) within the slice of strings
+	// that makes up the mnemonic that points to the incorrect word.
+	Index uint8
+}
+
+// Error returns a human readable string describing the error.
+func (e ErrUnknownMnemonicWord) Error() string {
+	return fmt.Sprintf("word %v isn't a part of default word list "+
+		"(index=%v)", e.Word, e.Index)
+}
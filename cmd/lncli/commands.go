@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -17,6 +19,7 @@ import (
 	"github.com/awalterschulze/gographviz"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/cipherseed"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
@@ -138,6 +141,9 @@ var sendCoinsCommand = cli.Command{
 
 	Fees used when sending the transaction can be specified via the --conf_target, or 
 	--sat_per_byte optional flags.
+
+	The outputs to spend can be chosen via the optional --utxo flag, which
+	may be repeated.
 	
 	Positional arguments and flags can be used interchangeably but not at the same time!
 	`,
@@ -163,6 +169,12 @@ var sendCoinsCommand = cli.Command{
 				"sat/byte that should be used when crafting " +
 				"the transaction",
 		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "(optional) an outpoint of the form txid:index " +
+				"to spend, may be repeated; if set, exactly " +
+				"the passed outputs are spent",
+		},
 	},
 	Action: actionDecorator(sendCoins),
 }
@@ -217,6 +229,7 @@ func sendCoins(ctx *cli.Context) error {
 		Amount:     amt,
 		TargetConf: int32(ctx.Int64("conf_target")),
 		SatPerByte: ctx.Int64("sat_per_byte"),
+		Outpoints:  ctx.StringSlice("utxo"),
 	}
 	txid, err := client.SendCoins(ctxb, req)
 	if err != nil {
@@ -230,7 +243,7 @@ func sendCoins(ctx *cli.Context) error {
 var sendManyCommand = cli.Command{
 	Name:      "sendmany",
 	Usage:     "send bitcoin on-chain to multiple addresses.",
-	ArgsUsage: "send-json-string [--conf_target=N] [--sat_per_byte=P]",
+	ArgsUsage: "send-json-string [--conf_target=N] [--sat_per_byte=P] [--utxo=txid:index...]",
 	Description: `
 	Create and broadcast a transaction paying the specified amount(s) to the passed address(es).
 
@@ -250,6 +263,12 @@ var sendManyCommand = cli.Command{
 			Usage: "(optional) a manual fee expressed in sat/byte that should be " +
 				"used when crafting the transaction",
 		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "(optional) an outpoint of the form txid:index " +
+				"to spend, may be repeated; if set, exactly " +
+				"the passed outputs are spent",
+		},
 	},
 	Action: actionDecorator(sendMany),
 }
@@ -275,6 +294,7 @@ func sendMany(ctx *cli.Context) error {
 		AddrToAmount: amountToAddr,
 		TargetConf:   int32(ctx.Int64("conf_target")),
 		SatPerByte:   ctx.Int64("sat_per_byte"),
+		Outpoints:    ctx.StringSlice("utxo"),
 	})
 	if err != nil {
 		return err
@@ -284,6 +304,230 @@ func sendMany(ctx *cli.Context) error {
 	return nil
 }
 
+var bumpFeeCommand = cli.Command{
+	Name:      "bumpfee",
+	Usage:     "bump the fee of an unconfirmed transaction.",
+	ArgsUsage: "outpoint [--conf_target=N] [--sat_per_byte=P]",
+	Description: `
+	Raise the fee rate of the unconfirmed transaction that created the
+	passed outpoint, such as a stuck funding or closing transaction.
+
+	If the outpoint is an unconfirmed output of the wallet, such as the
+	change output of a funding transaction, then it's spent by a child
+	transaction that pays for both itself and its parent (CPFP). If the
+	outpoint is being swept, then its sweep is replaced by one paying a
+	higher fee rate.
+
+	The outpoint must be of the form txid:index.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the outpoint to spend in the form txid:index",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the transaction *should* " +
+				"confirm in, will be used for fee estimation",
+		},
+		cli.Int64Flag{
+			Name: "sat_per_byte",
+			Usage: "(optional) a manual fee expressed in sat/byte that the " +
+				"transaction and its child should pay",
+		},
+	},
+	Action: actionDecorator(bumpFee),
+}
+
+func bumpFee(ctx *cli.Context) error {
+	var outpoint string
+	switch {
+	case ctx.IsSet("outpoint"):
+		outpoint = ctx.String("outpoint")
+	case ctx.Args().Present():
+		outpoint = ctx.Args().First()
+	default:
+		return fmt.Errorf("outpoint argument missing")
+	}
+
+	if ctx.IsSet("conf_target") && ctx.IsSet("sat_per_byte") {
+		return fmt.Errorf("either conf_target or sat_per_byte should be " +
+			"set, but not both")
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.BumpFee(ctxb, &lnrpc.BumpFeeRequest{
+		Outpoint:   outpoint,
+		TargetConf: int32(ctx.Int64("conf_target")),
+		SatPerByte: ctx.Int64("sat_per_byte"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listUnspentCommand = cli.Command{
+	Name:      "listunspent",
+	Usage:     "list the unspent outputs of the wallet.",
+	ArgsUsage: "[--min_confs=N] [--max_confs=M]",
+	Description: `
+	List the unspent outputs of the wallet that have between min_confs
+	and max_confs confirmations. Leased outputs are listed by listleases
+	instead.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "min_confs",
+			Usage: "the minimum number of confirmations of an output",
+		},
+		cli.Int64Flag{
+			Name: "max_confs",
+			Usage: "(optional) the maximum number of confirmations " +
+				"of an output",
+		},
+	},
+	Action: actionDecorator(listUnspent),
+}
+
+func listUnspent(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListUnspent(ctxb, &lnrpc.ListUnspentRequest{
+		MinConfs: int32(ctx.Int64("min_confs")),
+		MaxConfs: int32(ctx.Int64("max_confs")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var leaseOutputCommand = cli.Command{
+	Name:      "leaseoutput",
+	Usage:     "exclude an output from automatic coin selection.",
+	ArgsUsage: "outpoint [--duration=S]",
+	Description: `
+	Lease the unspent wallet output with the passed outpoint of the form
+	txid:index, excluding it from automatic coin selection until the lease
+	expires or is released. A leased output is only spent when it's
+	explicitly selected via the --utxo flag of sendcoins, sendmany or
+	openchannel. Leasing an output that's already leased extends its
+	lease.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the outpoint to lease in the form txid:index",
+		},
+		cli.Int64Flag{
+			Name: "duration",
+			Usage: "(optional) the duration of the lease in " +
+				"seconds, defaults to 10 minutes",
+		},
+	},
+	Action: actionDecorator(leaseOutput),
+}
+
+func leaseOutput(ctx *cli.Context) error {
+	var outpoint string
+	switch {
+	case ctx.IsSet("outpoint"):
+		outpoint = ctx.String("outpoint")
+	case ctx.Args().Present():
+		outpoint = ctx.Args().First()
+	default:
+		return fmt.Errorf("outpoint argument missing")
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.LeaseOutput(ctxb, &lnrpc.LeaseOutputRequest{
+		Outpoint:        outpoint,
+		DurationSeconds: ctx.Int64("duration"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var releaseOutputCommand = cli.Command{
+	Name:      "releaseoutput",
+	Usage:     "release the lease of an output.",
+	ArgsUsage: "outpoint",
+	Description: `
+	Release the lease of the output with the passed outpoint of the form
+	txid:index, making it eligible for automatic coin selection again.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "outpoint",
+			Usage: "the leased outpoint in the form txid:index",
+		},
+	},
+	Action: actionDecorator(releaseOutput),
+}
+
+func releaseOutput(ctx *cli.Context) error {
+	var outpoint string
+	switch {
+	case ctx.IsSet("outpoint"):
+		outpoint = ctx.String("outpoint")
+	case ctx.Args().Present():
+		outpoint = ctx.Args().First()
+	default:
+		return fmt.Errorf("outpoint argument missing")
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ReleaseOutput(ctxb, &lnrpc.ReleaseOutputRequest{
+		Outpoint: outpoint,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listLeasesCommand = cli.Command{
+	Name:   "listleases",
+	Usage:  "list the currently leased outputs.",
+	Action: actionDecorator(listLeases),
+}
+
+func listLeases(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.ListLeases(ctxb, &lnrpc.ListLeasesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var connectCommand = cli.Command{
 	Name:      "connect",
 	Usage:     "connect to a remote lnd peer",
@@ -385,6 +629,17 @@ var openChannelCommand = cli.Command{
 
 	One can manually set the fee to be used for the funding transaction via either
 	the --conf_target or --sat_per_byte arguments. This is optional.
+
+	The outputs funding the channel can be chosen via the --utxo argument,
+	which may be repeated. This is optional.
+
+	If --psbt is set, then the channel is funded by an external wallet
+	instead. Once the remote peer has accepted the channel, a PSBT that pays
+	to the funding output is printed along with the pending channel ID. Fund
+	and sign it with the external wallet, then pass the signed PSBT to
+	psbtfinalize while this command keeps waiting. The funding flow can be
+	aborted via psbtcancel, and is aborted automatically if no signed PSBT
+	is passed within 10 minutes.
 		
 	NOTE: peer_id and node_key are mutually exclusive, only one should be used, not both.`,
 	ArgsUsage: "node-key local-amt push-amt",
@@ -431,6 +686,18 @@ var openChannelCommand = cli.Command{
 				"must be explicitly told about it to be able " +
 				"to route through it",
 		},
+		cli.StringSliceFlag{
+			Name: "utxo",
+			Usage: "(optional) an outpoint of the form txid:index " +
+				"that should fund the channel, may be " +
+				"repeated; if set, exactly the passed " +
+				"outputs are spent",
+		},
+		cli.BoolFlag{
+			Name: "psbt",
+			Usage: "fund the channel from an external wallet " +
+				"through a PSBT, see psbtfinalize",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -458,6 +725,7 @@ func openChannel(ctx *cli.Context) error {
 	req := &lnrpc.OpenChannelRequest{
 		TargetConf: int32(ctx.Int64("conf_target")),
 		SatPerByte: ctx.Int64("sat_per_byte"),
+		Outpoints:  ctx.StringSlice("utxo"),
 	}
 
 	switch {
@@ -503,6 +771,7 @@ func openChannel(ctx *cli.Context) error {
 	}
 
 	req.Private = ctx.Bool("private")
+	req.Psbt = ctx.Bool("psbt")
 
 	stream, err := client.OpenChannel(ctxb, req)
 	if err != nil {
@@ -518,6 +787,25 @@ func openChannel(ctx *cli.Context) error {
 		}
 
 		switch update := resp.Update.(type) {
+		case *lnrpc.OpenStatusUpdate_PsbtFund:
+			psbtFund := update.PsbtFund
+			printJSON(struct {
+				PendingChanID  string `json:"pending_chan_id"`
+				FundingAddress string `json:"funding_address"`
+				FundingAmount  int64  `json:"funding_amount"`
+				Psbt           string `json:"psbt"`
+			}{
+				PendingChanID: hex.EncodeToString(
+					psbtFund.PendingChanId,
+				),
+				FundingAddress: psbtFund.FundingAddress,
+				FundingAmount:  psbtFund.FundingAmount,
+				Psbt: base64.StdEncoding.EncodeToString(
+					psbtFund.Psbt,
+				),
+			},
+			)
+
 		case *lnrpc.OpenStatusUpdate_ChanPending:
 			txid, err := chainhash.NewHash(update.ChanPending.Txid)
 			if err != nil {
@@ -553,6 +841,137 @@ func openChannel(ctx *cli.Context) error {
 	}
 }
 
+var psbtFinalizeCommand = cli.Command{
+	Name:      "psbtfinalize",
+	Usage:     "resume a channel funding flow with a signed PSBT.",
+	ArgsUsage: "pending_chan_id signed_psbt",
+	Description: `
+	Resume the funding flow of a channel opened via openchannel --psbt,
+	using the base64 encoded PSBT funded and signed by the external wallet.
+	The PSBT must pay the funding amount to the funding address, and all of
+	its inputs must be finalized witness inputs. If the PSBT is rejected,
+	then a corrected one can be passed again.
+
+	The funding transaction is broadcast once the remote peer has signed
+	our commitment transaction.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pending_chan_id",
+			Usage: "the hex encoded pending channel ID",
+		},
+		cli.StringFlag{
+			Name:  "psbt",
+			Usage: "the base64 encoded signed PSBT",
+		},
+	},
+	Action: actionDecorator(psbtFinalize),
+}
+
+func psbtFinalize(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	pendingChanID, args, err := parsePendingChanID(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	var b64Psbt string
+	switch {
+	case ctx.IsSet("psbt"):
+		b64Psbt = ctx.String("psbt")
+	case args.Present():
+		b64Psbt = args.First()
+	default:
+		return fmt.Errorf("psbt argument missing")
+	}
+	signedPsbt, err := base64.StdEncoding.DecodeString(
+		strings.TrimSpace(b64Psbt),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to decode psbt: %v", err)
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.FundingPsbtFinalize(ctxb, &lnrpc.FundingPsbtFinalizeRequest{
+		PendingChanId: pendingChanID,
+		SignedPsbt:    signedPsbt,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var psbtCancelCommand = cli.Command{
+	Name:      "psbtcancel",
+	Usage:     "abort a channel funding flow that's waiting for a PSBT.",
+	ArgsUsage: "pending_chan_id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pending_chan_id",
+			Usage: "the hex encoded pending channel ID",
+		},
+	},
+	Action: actionDecorator(psbtCancel),
+}
+
+func psbtCancel(ctx *cli.Context) error {
+	pendingChanID, _, err := parsePendingChanID(ctx, ctx.Args())
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.FundingPsbtCancel(ctxb, &lnrpc.FundingPsbtCancelRequest{
+		PendingChanId: pendingChanID,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// parsePendingChanID parses the hex encoded pending channel ID passed either
+// via the pending_chan_id flag, or as the first of the passed arguments, and
+// returns the remaining arguments.
+func parsePendingChanID(ctx *cli.Context, args cli.Args) ([]byte, cli.Args,
+	error) {
+
+	var pendingChanIDHex string
+	switch {
+	case ctx.IsSet("pending_chan_id"):
+		pendingChanIDHex = ctx.String("pending_chan_id")
+	case args.Present():
+		pendingChanIDHex = args.First()
+		args = args.Tail()
+	default:
+		return nil, nil, fmt.Errorf("pending_chan_id argument missing")
+	}
+
+	pendingChanID, err := hex.DecodeString(pendingChanIDHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode pending "+
+			"channel ID: %v", err)
+	}
+	if len(pendingChanID) != 32 {
+		return nil, nil, fmt.Errorf("pending channel ID must be 32 " +
+			"bytes")
+	}
+
+	return pendingChanID, args, nil
+}
+
 // TODO(roasbeef): also allow short relative channel ID.
 
 var closeChannelCommand = cli.Command{
@@ -735,8 +1154,22 @@ func listPeers(ctx *cli.Context) error {
 }
 
 var createCommand = cli.Command{
-	Name:   "create",
-	Usage:  "used to set the wallet password at lnd startup",
+	Name:  "create",
+	Usage: "used to set the wallet password at lnd startup",
+	Description: `
+	The create command is used to initialize an lnd wallet from scratch for
+	the very first time. This is an interactive command which will prompt
+	for the wallet password, and optionally an existing cipher seed
+	mnemonic.
+
+	If an existing mnemonic is specified, then the wallet, along with the
+	identity key of the node, will be restored from it, and the wallet
+	will be rescanned from the birthday of the seed in order to recover
+	its on-chain funds. Otherwise, a fresh seed will be generated, which
+	MUST be written down as it's the only way to recover the wallet.
+
+	The seed may be encrypted under an optional passphrase. If specified,
+	the passphrase will be required in order to restore from the seed.`,
 	Action: actionDecorator(create),
 }
 
@@ -763,14 +1196,130 @@ func create(ctx *cli.Context) error {
 		return fmt.Errorf("passwords don't match")
 	}
 
+	// Next, we'll see if the user has an existing cipher seed mnemonic
+	// they'd like to restore the wallet from.
+	stdinReader := bufio.NewReader(os.Stdin)
+	fmt.Printf("\nDo you have an existing cipher seed mnemonic you " +
+		"want to use? (Enter y/n): ")
+	haveSeed, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+
+	var (
+		mnemonic   []string
+		passphrase []byte
+	)
+	switch strings.ToLower(strings.TrimSpace(haveSeed)) {
+	case "y":
+		fmt.Printf("Input your %v-word mnemonic separated by spaces: ",
+			cipherseed.NumMnemonicWords)
+		mnemonicStr, err := stdinReader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+
+		mnemonic = strings.Fields(strings.ToLower(mnemonicStr))
+		if len(mnemonic) != cipherseed.NumMnemonicWords {
+			return fmt.Errorf("mnemonic must be exactly %v words, "+
+				"instead got %v", cipherseed.NumMnemonicWords, len(mnemonic))
+		}
+
+		fmt.Printf("Input your cipher seed passphrase (if you " +
+			"wish to encrypt it): ")
+		passphrase, err = terminal.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+
+	case "n":
+		fmt.Printf("Input your passphrase if you wish to encrypt " +
+			"it (or press enter to proceed without a cipher seed " +
+			"passphrase): ")
+		passphrase, err = terminal.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+
+		if len(passphrase) != 0 {
+			fmt.Printf("Confirm cipher seed passphrase: ")
+			passphrase2, err := terminal.ReadPassword(
+				int(syscall.Stdin),
+			)
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+
+			if !bytes.Equal(passphrase, passphrase2) {
+				return fmt.Errorf("cipher seed pass phrases " +
+					"don't match")
+			}
+		}
+
+		fmt.Println()
+		fmt.Println("Generating fresh cipher seed...")
+		fmt.Println()
+
+		seedResp, err := client.GenSeed(ctxb, &lnrpc.GenSeedRequest{
+			CipherSeedPassphrase: passphrase,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to generate seed: %v", err)
+		}
+
+		mnemonic = seedResp.CipherSeedMnemonic
+
+	default:
+		return fmt.Errorf("please enter either y or n")
+	}
+
 	req := &lnrpc.CreateWalletRequest{
-		Password: pw1,
+		Password:             pw1,
+		CipherSeedMnemonic:   mnemonic,
+		CipherSeedPassphrase: passphrase,
 	}
 	_, err = client.CreateWallet(ctxb, req)
 	if err != nil {
 		return err
 	}
 
+	// If we generated a fresh seed, then we'll display the mnemonic so
+	// the user is able to write it down.
+	if strings.ToLower(strings.TrimSpace(haveSeed)) == "n" {
+		fmt.Println("!!!YOU MUST WRITE DOWN THIS SEED TO BE ABLE TO " +
+			"RESTORE THE WALLET!!!")
+		fmt.Println()
+
+		fmt.Println("---------------BEGIN LND CIPHER SEED---------------")
+		for i := 0; i < len(mnemonic); i += 4 {
+			end := i + 4
+			if end > len(mnemonic) {
+				end = len(mnemonic)
+			}
+
+			var words []string
+			for j := i; j < end; j++ {
+				words = append(
+					words, fmt.Sprintf("%2d. %-10s", j+1,
+						mnemonic[j]),
+				)
+			}
+			fmt.Println(strings.Join(words, " "))
+		}
+		fmt.Println("---------------END LND CIPHER SEED-----------------")
+		fmt.Println()
+
+		fmt.Println("!!!YOU MUST WRITE DOWN THIS SEED TO BE ABLE TO " +
+			"RESTORE THE WALLET!!!")
+	}
+
+	fmt.Println("\nlnd successfully initialized!")
+
 	return nil
 }
 
@@ -912,6 +1461,32 @@ func pendingChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var pendingSweepsCommand = cli.Command{
+	Name:  "pendingsweeps",
+	Usage: "display the outputs currently being swept on-chain",
+	Description: `
+	List all outputs recovered from closed channels which are waiting to be
+	swept back into the wallet, along with the fee rate and broadcast state
+	of their sweep transaction.`,
+	Action: actionDecorator(pendingSweeps),
+}
+
+func pendingSweeps(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PendingSweepsRequest{}
+	resp, err := client.PendingSweeps(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var listChannelsCommand = cli.Command{
 	Name:  "listchannels",
 	Usage: "list all open channels",
@@ -942,10 +1517,55 @@ func listChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var (
+	feeLimitFlag = cli.Int64Flag{
+		Name: "fee_limit",
+		Usage: "maximum fee allowed in satoshis when sending " +
+			"the payment",
+	}
+	feeLimitPercentFlag = cli.Int64Flag{
+		Name: "fee_limit_percent",
+		Usage: "percentage of the payment's amount used as the " +
+			"maximum fee allowed when sending the payment",
+	}
+	cltvLimitFlag = cli.IntFlag{
+		Name: "cltv_limit",
+		Usage: "the maximum total time lock in blocks that may be " +
+			"used for the payment",
+	}
+)
+
+// retrieveFeeLimit retrieves the fee limit, if any, specified by the user
+// through either the fee_limit or fee_limit_percent flag.
+func retrieveFeeLimit(ctx *cli.Context) (*lnrpc.FeeLimit, error) {
+	switch {
+	case ctx.IsSet("fee_limit") && ctx.IsSet("fee_limit_percent"):
+		return nil, fmt.Errorf("either fee_limit or fee_limit_percent " +
+			"can be set, but not both")
+
+	case ctx.IsSet("fee_limit"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Fixed{
+				Fixed: ctx.Int64("fee_limit"),
+			},
+		}, nil
+
+	case ctx.IsSet("fee_limit_percent"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Percent{
+				Percent: ctx.Int64("fee_limit_percent"),
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
 var sendPaymentCommand = cli.Command{
 	Name:  "sendpayment",
 	Usage: "send a payment over lightning",
 	ArgsUsage: "(destination amount payment_hash " +
+		"| destination amount --keysend " +
 		"| --pay_req=[payment request])",
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -965,10 +1585,19 @@ var sendPaymentCommand = cli.Command{
 			Name:  "debug_send",
 			Usage: "use the debug rHash when sending the HTLC",
 		},
+		cli.BoolFlag{
+			Name: "keysend",
+			Usage: "send a spontaneous payment which doesn't " +
+				"require an invoice, the recipient must " +
+				"accept spontaneous payments",
+		},
 		cli.StringFlag{
 			Name:  "pay_req",
 			Usage: "a zpay32 encoded payment request to fulfill",
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
 	},
 	Action: sendPayment,
 }
@@ -1027,9 +1656,18 @@ func sendPayment(ctx *cli.Context) error {
 			Amt:  amount,
 		}
 
-		if ctx.Bool("debug_send") && (ctx.IsSet("payment_hash") || args.Present()) {
+		switch {
+		case ctx.Bool("keysend"):
+			if ctx.IsSet("payment_hash") || args.Present() {
+				return fmt.Errorf("do not provide a payment " +
+					"hash with keysend")
+			}
+			req.KeySend = true
+
+		case ctx.Bool("debug_send") && (ctx.IsSet("payment_hash") || args.Present()):
 			return fmt.Errorf("do not provide a payment hash with debug send")
-		} else if !ctx.Bool("debug_send") {
+
+		case !ctx.Bool("debug_send"):
 			var rHash []byte
 
 			switch {
@@ -1059,6 +1697,13 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+	req.FeeLimit = feeLimit
+	req.CltvLimit = uint32(ctx.Int("cltv_limit"))
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -1097,6 +1742,9 @@ var payInvoiceCommand = cli.Command{
 			Name:  "pay_req",
 			Usage: "a zpay32 encoded payment request to fulfill",
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
 	},
 	Action: actionDecorator(payInvoice),
 }
@@ -1233,19 +1881,259 @@ func addInvoice(ctx *cli.Context) error {
 		Expiry:          ctx.Int64("expiry"),
 	}
 
-	resp, err := client.AddInvoice(context.Background(), invoice)
+	resp, err := client.AddInvoice(context.Background(), invoice)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		RHash  string `json:"r_hash"`
+		PayReq string `json:"pay_req"`
+	}{
+		RHash:  hex.EncodeToString(resp.RHash),
+		PayReq: resp.PaymentRequest,
+	})
+
+	return nil
+}
+
+var addHoldInvoiceCommand = cli.Command{
+	Name:  "addholdinvoice",
+	Usage: "add a new hold invoice.",
+	Description: `
+	Add a new hold invoice, expressing intent for a future payment.
+
+	Only the payment hash of a hold invoice is known. Once paid, the HTLCs
+	paying to it are held until the invoice is either settled using its
+	preimage with settleinvoice, or canceled with cancelinvoice.`,
+	ArgsUsage: "hash value",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "memo",
+			Usage: "a description of the payment to attach along " +
+				"with the invoice (default=\"\")",
+		},
+		cli.StringFlag{
+			Name: "hash",
+			Usage: "the hex-encoded payment hash (32 byte) of the " +
+				"invoice, the preimage of which is only " +
+				"revealed once the invoice is settled",
+		},
+		cli.Int64Flag{
+			Name:  "value",
+			Usage: "the value of this invoice in satoshis",
+		},
+		cli.StringFlag{
+			Name: "description_hash",
+			Usage: "SHA-256 hash of the description of the payment. " +
+				"Used if the purpose of payment cannot naturally " +
+				"fit within the memo. If provided this will be " +
+				"used instead of the description(memo) field in " +
+				"the encoded invoice.",
+		},
+		cli.StringFlag{
+			Name: "fallback_addr",
+			Usage: "fallback on-chain address that can be used in " +
+				"case the lightning payment fails",
+		},
+		cli.Int64Flag{
+			Name: "expiry",
+			Usage: "the invoice's expiry time in seconds. If not " +
+				"specified an expiry of 3600 seconds (1 hour) " +
+				"is implied.",
+		},
+	},
+	Action: actionDecorator(addHoldInvoice),
+}
+
+func addHoldInvoice(ctx *cli.Context) error {
+	var (
+		hash     []byte
+		descHash []byte
+		value    int64
+		err      error
+	)
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("hash"):
+		hash, err = hex.DecodeString(ctx.String("hash"))
+	case args.Present():
+		hash, err = hex.DecodeString(args.First())
+		args = args.Tail()
+	default:
+		return fmt.Errorf("hash argument missing")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to parse hash: %v", err)
+	}
+
+	switch {
+	case ctx.IsSet("value"):
+		value = ctx.Int64("value")
+	case args.Present():
+		value, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode value argument: %v", err)
+		}
+	default:
+		return fmt.Errorf("value argument missing")
+	}
+
+	descHash, err = hex.DecodeString(ctx.String("description_hash"))
+	if err != nil {
+		return fmt.Errorf("unable to parse description_hash: %v", err)
+	}
+
+	req := &lnrpc.AddHoldInvoiceRequest{
+		Memo:            ctx.String("memo"),
+		Hash:            hash,
+		Value:           value,
+		DescriptionHash: descHash,
+		FallbackAddr:    ctx.String("fallback_addr"),
+		Expiry:          ctx.Int64("expiry"),
+	}
+
+	resp, err := client.AddHoldInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		RHash  string `json:"r_hash"`
+		PayReq string `json:"pay_req"`
+	}{
+		RHash:  hex.EncodeToString(resp.RHash),
+		PayReq: resp.PaymentRequest,
+	})
+
+	return nil
+}
+
+var settleInvoiceCommand = cli.Command{
+	Name:      "settleinvoice",
+	Usage:     "Settle an accepted hold invoice.",
+	ArgsUsage: "preimage",
+	Description: `
+	Settle an accepted hold invoice using the preimage of its payment hash,
+	settling all HTLCs that are held for it.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "preimage",
+			Usage: "the hex-encoded preimage (32 byte) of the " +
+				"payment hash of the invoice to settle",
+		},
+	},
+	Action: actionDecorator(settleInvoice),
+}
+
+func settleInvoice(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		preimage []byte
+		err      error
+	)
+
+	switch {
+	case ctx.IsSet("preimage"):
+		preimage, err = hex.DecodeString(ctx.String("preimage"))
+	case ctx.Args().Present():
+		preimage, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("preimage argument missing")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to parse preimage: %v", err)
+	}
+
+	req := &lnrpc.SettleInvoiceMsg{
+		Preimage: preimage,
+	}
+	resp, err := client.SettleInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var cancelInvoiceCommand = cli.Command{
+	Name:      "cancelinvoice",
+	Usage:     "Cancel an invoice that hasn't been settled yet.",
+	ArgsUsage: "rhash",
+	Description: `
+	Cancel an invoice, failing all HTLCs that are held for it. A canceled
+	invoice can no longer be paid.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "rhash",
+			Usage: "the hex-encoded payment hash (32 byte) of the " +
+				"invoice to cancel",
+		},
+	},
+	Action: actionDecorator(cancelInvoice),
+}
+
+func cancelInvoice(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		rHash []byte
+		err   error
+	)
+
+	switch {
+	case ctx.IsSet("rhash"):
+		rHash, err = hex.DecodeString(ctx.String("rhash"))
+	case ctx.Args().Present():
+		rHash, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("rhash argument missing")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to parse rhash: %v", err)
+	}
+
+	req := &lnrpc.CancelInvoiceMsg{
+		PaymentHash: rHash,
+	}
+	resp, err := client.CancelInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var deleteCanceledInvoicesCommand = cli.Command{
+	Name:  "deletecanceledinvoices",
+	Usage: "Delete all canceled and expired invoices.",
+	Description: `
+	Delete all invoices that have either been canceled or have expired from
+	the database. Settled and unpaid invoices are left untouched.`,
+	Action: actionDecorator(deleteCanceledInvoices),
+}
+
+func deleteCanceledInvoices(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DeleteCanceledInvoicesRequest{}
+	resp, err := client.DeleteCanceledInvoices(context.Background(), req)
 	if err != nil {
 		return err
 	}
 
-	printJSON(struct {
-		RHash  string `json:"r_hash"`
-		PayReq string `json:"pay_req"`
-	}{
-		RHash:  hex.EncodeToString(resp.RHash),
-		PayReq: resp.PaymentRequest,
-	})
-
+	printRespJSON(resp)
 	return nil
 }
 
@@ -1308,6 +2196,22 @@ var listInvoicesCommand = cli.Command{
 			Usage: "toggles if all invoices should be returned, or only " +
 				"those that are currently unsettled",
 		},
+		cli.Uint64Flag{
+			Name: "index_offset",
+			Usage: "the add index of the invoice to start querying " +
+				"from, which is excluded from the results",
+		},
+		cli.Uint64Flag{
+			Name: "max_invoices",
+			Usage: "the max number of invoices to return, all " +
+				"invoices are returned if not set",
+		},
+		cli.BoolFlag{
+			Name: "reversed",
+			Usage: "if set, the invoices added before the index " +
+				"offset are returned, starting from the most " +
+				"recent invoice if no offset is set",
+		},
 	},
 	Action: actionDecorator(listInvoices),
 }
@@ -1322,7 +2226,10 @@ func listInvoices(ctx *cli.Context) error {
 	}
 
 	req := &lnrpc.ListInvoiceRequest{
-		PendingOnly: pendingOnly,
+		PendingOnly:    pendingOnly,
+		IndexOffset:    ctx.Uint64("index_offset"),
+		NumMaxInvoices: ctx.Uint64("max_invoices"),
+		Reversed:       ctx.Bool("reversed"),
 	}
 
 	invoices, err := client.ListInvoices(context.Background(), req)
@@ -1531,8 +2438,15 @@ func drawChannelGraph(graph *lnrpc.ChannelGraph) error {
 }
 
 var listPaymentsCommand = cli.Command{
-	Name:   "listpayments",
-	Usage:  "list all outgoing payments",
+	Name:  "listpayments",
+	Usage: "list all outgoing payments",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "include_incomplete",
+			Usage: "if set, payments that failed or are still " +
+				"in flight are listed as well",
+		},
+	},
 	Action: actionDecorator(listPayments),
 }
 
@@ -1540,7 +2454,9 @@ func listPayments(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.ListPaymentsRequest{}
+	req := &lnrpc.ListPaymentsRequest{
+		IncludeIncomplete: ctx.Bool("include_incomplete"),
+	}
 
 	payments, err := client.ListPayments(context.Background(), req)
 	if err != nil {
@@ -1551,6 +2467,56 @@ func listPayments(ctx *cli.Context) error {
 	return nil
 }
 
+var trackPaymentCommand = cli.Command{
+	Name:  "trackpayment",
+	Usage: "follow the state of an outgoing payment",
+	Description: `
+	Prints the current state of an outgoing payment, followed by each
+	update of its state until it has either succeeded or failed.`,
+	ArgsUsage: "payment_hash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash",
+			Usage: "the hex-encoded payment hash of the payment",
+		},
+	},
+	Action: actionDecorator(trackPayment),
+}
+
+func trackPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var paymentHash string
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash = ctx.String("payment_hash")
+	case ctx.Args().Present():
+		paymentHash = ctx.Args().First()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHashStr: paymentHash,
+	}
+	stream, err := client.TrackPayment(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJSON(payment)
+	}
+}
+
 var getChanInfoCommand = cli.Command{
 	Name:  "getchaninfo",
 	Usage: "get the state of a channel",
@@ -1658,6 +2624,9 @@ var queryRoutesCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the amount to send expressed in satoshis",
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -1697,9 +2666,16 @@ func queryRoutes(ctx *cli.Context) error {
 		return fmt.Errorf("amt argument missing")
 	}
 
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey: dest,
-		Amt:    amt,
+		PubKey:    dest,
+		Amt:       amt,
+		FeeLimit:  feeLimit,
+		CltvLimit: uint32(ctx.Int("cltv_limit")),
 	}
 
 	route, err := client.QueryRoutes(ctxb, req)
@@ -1711,6 +2687,106 @@ func queryRoutes(ctx *cli.Context) error {
 	return nil
 }
 
+var queryMissionControlCommand = cli.Command{
+	Name:  "querymc",
+	Usage: "Query the internal mission control state.",
+	Description: "Returns the payment attempt results that the router " +
+		"bases its channel success probabilities on. The output can " +
+		"be imported into another node using importmc.",
+	Action: actionDecorator(queryMissionControl),
+}
+
+func queryMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.QueryMissionControlRequest{}
+	resp, err := client.QueryMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var importMissionControlCommand = cli.Command{
+	Name:      "importmc",
+	Usage:     "Import payment attempt results into mission control.",
+	ArgsUsage: "results_file",
+	Description: `
+	Adds the payment attempt results within the passed file to the history
+	of the router's mission control. The file should contain the JSON
+	output of the querymc command.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "results_file",
+			Usage: "the path to the file containing the results",
+		},
+	},
+	Action: actionDecorator(importMissionControl),
+}
+
+func importMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var resultsFile string
+	switch {
+	case ctx.IsSet("results_file"):
+		resultsFile = ctx.String("results_file")
+	case ctx.Args().Present():
+		resultsFile = ctx.Args().First()
+	default:
+		return fmt.Errorf("results_file argument missing")
+	}
+
+	resultsJSON, err := ioutil.ReadFile(cleanAndExpandPath(resultsFile))
+	if err != nil {
+		return fmt.Errorf("unable to read results file: %v", err)
+	}
+
+	var results lnrpc.QueryMissionControlResponse
+	err = jsonpb.UnmarshalString(string(resultsJSON), &results)
+	if err != nil {
+		return fmt.Errorf("unable to decode results file: %v", err)
+	}
+
+	req := &lnrpc.ImportMissionControlRequest{
+		Results: results.Results,
+	}
+	resp, err := client.ImportMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var resetMissionControlCommand = cli.Command{
+	Name:   "resetmc",
+	Usage:  "Reset the internal mission control state.",
+	Action: actionDecorator(resetMissionControl),
+}
+
+func resetMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ResetMissionControlRequest{}
+	resp, err := client.ResetMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var getNetworkInfoCommand = cli.Command{
 	Name:  "getnetworkinfo",
 	Usage: "getnetworkinfo",
@@ -2116,3 +3192,284 @@ func updateFees(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var forwardingHistoryCommand = cli.Command{
+	Name:      "fwdinghistory",
+	Usage:     "query the history of all forwarded HTLCs",
+	ArgsUsage: "start_time [end_time] [index_offset] [max_events]",
+	Description: `
+	Query the HTLC switch's internal forwarding log for all completed
+	payment circuits (HTLCs) over a particular time range (--start_time and
+	--end_time). The start and end times are meant to be expressed in
+	seconds since the Unix epoch. If a start and end time aren't provided,
+	then events over the past 24 hours are queried for.
+
+	The max number of events returned is 50k. The default number is 100,
+	callers can use the --max_events param to modify this value.
+
+	Finally, callers can skip a series of events using the --index_offset
+	parameter. Each response will contain the offset index of the last
+	entry. Using this callers can manually paginate within a time slice.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start_time",
+			Usage: "the starting time for the query, expressed in " +
+				"seconds since the unix epoch",
+		},
+		cli.Int64Flag{
+			Name: "end_time",
+			Usage: "the end time for the query, expressed in " +
+				"seconds since the unix epoch",
+		},
+		cli.Int64Flag{
+			Name:  "index_offset",
+			Usage: "the number of events to skip",
+		},
+		cli.Int64Flag{
+			Name:  "max_events",
+			Usage: "the max number of events to return",
+			Value: 100,
+		},
+	},
+	Action: actionDecorator(forwardingHistory),
+}
+
+func forwardingHistory(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		startTime, endTime     int64
+		indexOffset, maxEvents int64
+		err                    error
+	)
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("start_time"):
+		startTime = ctx.Int64("start_time")
+	case args.Present():
+		startTime, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode start_time: %v", err)
+		}
+		args = args.Tail()
+	}
+
+	switch {
+	case ctx.IsSet("end_time"):
+		endTime = ctx.Int64("end_time")
+	case args.Present():
+		endTime, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode end_time: %v", err)
+		}
+		args = args.Tail()
+	}
+
+	switch {
+	case ctx.IsSet("index_offset"):
+		indexOffset = ctx.Int64("index_offset")
+	case args.Present():
+		indexOffset, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode index_offset: %v", err)
+		}
+		args = args.Tail()
+	}
+
+	switch {
+	case ctx.IsSet("max_events"):
+		maxEvents = ctx.Int64("max_events")
+	case args.Present():
+		maxEvents, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode max_events: %v", err)
+		}
+	default:
+		maxEvents = ctx.Int64("max_events")
+	}
+
+	if startTime < 0 || endTime < 0 || indexOffset < 0 || maxEvents < 0 {
+		return fmt.Errorf("time range and pagination parameters " +
+			"must not be negative")
+	}
+
+	req := &lnrpc.ForwardingHistoryRequest{
+		StartTime:    uint64(startTime),
+		EndTime:      uint64(endTime),
+		IndexOffset:  uint32(indexOffset),
+		NumMaxEvents: uint32(maxEvents),
+	}
+	resp, err := client.ForwardingHistory(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var exportChanBackupCommand = cli.Command{
+	Name:  "exportchanbackup",
+	Usage: "export an encrypted static backup of all open channels",
+	Description: `
+	Exports an encrypted static channel backup of all currently open
+	channels. The backup can later be used to recover the funds within the
+	channels should the node lose its state, using the restorechanbackup
+	command.
+
+	If the output_file flag is set, then the raw backup will be written to
+	the target file. Otherwise, the backup is printed hex encoded.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file the raw channel backup should be written to",
+		},
+	},
+	Action: actionDecorator(exportChanBackup),
+}
+
+func exportChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ChanBackupExportRequest{}
+	resp, err := client.ExportAllChannelBackups(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("output_file") {
+		return ioutil.WriteFile(
+			cleanAndExpandPath(ctx.String("output_file")),
+			resp.MultiChanBackup, 0600,
+		)
+	}
+
+	chanPoints := make([]string, 0, len(resp.ChanPoints))
+	for _, chanPoint := range resp.ChanPoints {
+		txid, err := chainhash.NewHash(chanPoint.FundingTxid)
+		if err != nil {
+			return err
+		}
+
+		chanPoints = append(chanPoints, fmt.Sprintf("%v:%v", txid,
+			chanPoint.OutputIndex))
+	}
+
+	printJSON(struct {
+		ChanPoints      []string `json:"chan_points"`
+		MultiChanBackup string   `json:"multi_chan_backup"`
+	}{
+		ChanPoints:      chanPoints,
+		MultiChanBackup: hex.EncodeToString(resp.MultiChanBackup),
+	})
+	return nil
+}
+
+// parseChanBackup reads the packed multi channel backup specified by the
+// command line arguments, either hex encoded directly, or as a file.
+func parseChanBackup(ctx *cli.Context) ([]byte, error) {
+	switch {
+	case ctx.IsSet("multi_backup"):
+		return hex.DecodeString(ctx.String("multi_backup"))
+
+	case ctx.IsSet("multi_file"):
+		return ioutil.ReadFile(cleanAndExpandPath(ctx.String("multi_file")))
+
+	case ctx.Args().Present():
+		return hex.DecodeString(ctx.Args().First())
+
+	default:
+		return nil, fmt.Errorf("either multi_backup or multi_file " +
+			"must be set")
+	}
+}
+
+// chanBackupFlags are the flags used to specify the channel backup that the
+// verifychanbackup and restorechanbackup commands operate on.
+var chanBackupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "multi_backup",
+		Usage: "a hex encoded channel backup obtained from exportchanbackup",
+	},
+	cli.StringFlag{
+		Name:  "multi_file",
+		Usage: "the path to a raw channel backup file",
+	},
+}
+
+var verifyChanBackupCommand = cli.Command{
+	Name:      "verifychanbackup",
+	Usage:     "verify that a channel backup can be decrypted and parsed",
+	ArgsUsage: "[multi_backup]",
+	Description: `
+	Verifies the integrity of a static channel backup, ensuring that it
+	can be decrypted using this node's key, and parsed. The backup can be
+	passed either hex encoded, or as the path of the channel.backup file.`,
+	Flags:  chanBackupFlags,
+	Action: actionDecorator(verifyChanBackup),
+}
+
+func verifyChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	multiBackup, err := parseChanBackup(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read channel backup: %v", err)
+	}
+
+	req := &lnrpc.ChanBackupSnapshot{
+		MultiChanBackup: multiBackup,
+	}
+	resp, err := client.VerifyChanBackup(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var restoreChanBackupCommand = cli.Command{
+	Name:      "restorechanbackup",
+	Usage:     "recover the funds within the channels of a channel backup",
+	ArgsUsage: "[multi_backup]",
+	Description: `
+	Restores the channels within a static channel backup. For each channel,
+	lnd will connect to the remote party and ask them to force close the
+	channel, then sweep our funds back into the wallet once their
+	commitment transaction confirms.
+
+	The backup can be passed either hex encoded, or as the path of the
+	channel.backup file.`,
+	Flags:  chanBackupFlags,
+	Action: actionDecorator(restoreChanBackup),
+}
+
+func restoreChanBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	multiBackup, err := parseChanBackup(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read channel backup: %v", err)
+	}
+
+	req := &lnrpc.RestoreChanBackupRequest{
+		MultiChanBackup: multiBackup,
+	}
+	resp, err := client.RestoreChannelBackups(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
@@ -164,27 +164,43 @@ func main() {
 		unlockCommand,
 		newAddressCommand,
 		sendManyCommand,
+		bumpFeeCommand,
+		listUnspentCommand,
+		leaseOutputCommand,
+		releaseOutputCommand,
+		listLeasesCommand,
 		sendCoinsCommand,
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
+		psbtFinalizeCommand,
+		psbtCancelCommand,
 		closeChannelCommand,
 		listPeersCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
 		getInfoCommand,
 		pendingChannelsCommand,
+		pendingSweepsCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
 		addInvoiceCommand,
+		addHoldInvoiceCommand,
+		settleInvoiceCommand,
+		cancelInvoiceCommand,
+		deleteCanceledInvoicesCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
 		listPaymentsCommand,
+		trackPaymentCommand,
 		describeGraphCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
+		queryMissionControlCommand,
+		importMissionControlCommand,
+		resetMissionControlCommand,
 		getNetworkInfoCommand,
 		debugLevelCommand,
 		decodePayReqComamnd,
@@ -194,6 +210,10 @@ func main() {
 		verifyMessageCommand,
 		feeReportCommand,
 		updateFeesCommand,
+		forwardingHistoryCommand,
+		exportChanBackupCommand,
+		verifyChanBackupCommand,
+		restoreChanBackupCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+var (
+	// autogenCertValidity is the validity of the generated certificate.
+	autogenCertValidity = 14 * 30 * 24 * time.Hour
+
+	// endOfTime is the end of ASN.1 time.
+	endOfTime = time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	// serialNumberLimit is the maximum serial number of a certificate.
+	serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// genCertPair generates a self-signed key/cert pair to the paths provided,
+// valid for the loopback addresses and the local host name. It mirrors the
+// certificate generation of lnd.
+func genCertPair(certFile, keyFile string) error {
+	log.Infof("Generating TLS certificates...")
+
+	now := time.Now()
+	validUntil := now.Add(autogenCertValidity)
+	if validUntil.After(endOfTime) {
+		validUntil = endOfTime
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return fmt.Errorf("failed to generate serial number: %s", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	dnsNames := []string{host}
+	if host != "localhost" {
+		dnsNames = append(dnsNames, "localhost")
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"lnsigner autogenerated cert"},
+			CommonName:   host,
+		},
+		NotBefore: now.Add(-time.Hour * 24),
+		NotAfter:  validUntil,
+
+		KeyUsage: x509.KeyUsageKeyEncipherment |
+			x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true, // so can sign self.
+		BasicConstraintsValid: true,
+
+		DNSNames: dnsNames,
+		IPAddresses: []net.IP{
+			net.ParseIP("127.0.0.1"), net.ParseIP("::1"),
+		},
+
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template,
+		&template, &priv.PublicKey, priv)
+	if err != nil {
+		return fmt.Errorf("failed to create certificate: %v", err)
+	}
+
+	certBuf := &bytes.Buffer{}
+	err = pem.Encode(certBuf, &pem.Block{Type: "CERTIFICATE",
+		Bytes: derBytes})
+	if err != nil {
+		return fmt.Errorf("failed to encode certificate: %v", err)
+	}
+
+	keyBuf := &bytes.Buffer{}
+	err = pem.Encode(keyBuf, &pem.Block{Type: "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv)})
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %v", err)
+	}
+
+	if err = ioutil.WriteFile(certFile, certBuf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(keyFile, keyBuf.Bytes(), 0600); err != nil {
+		os.Remove(certFile)
+		return err
+	}
+
+	log.Infof("Done generating TLS certificates")
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	flags "github.com/btcsuite/go-flags"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	defaultTLSCertFilename  = "tls.cert"
+	defaultTLSKeyFilename   = "tls.key"
+	defaultAdminMacFilename = "admin.macaroon"
+	defaultSeedFilename     = "seed"
+	defaultDBFilename       = "signer.db"
+	defaultRPCListen        = "localhost:10019"
+	defaultNetwork          = "testnet"
+)
+
+var (
+	signerHomeDir = btcutil.AppDataDir("lnsigner", false)
+)
+
+// config defines the configuration options for lnsigner.
+type config struct {
+	DataDir      string `long:"datadir" description:"The directory to store the signer's seed, database, TLS certificate and macaroons within"`
+	Network      string `long:"network" description:"The network the watch-only node operates on" choice:"mainnet" choice:"testnet" choice:"simnet" choice:"regtest"`
+	RPCListen    string `long:"rpclisten" description:"The interface/port to serve the RemoteSigner service on"`
+	TLSCertPath  string `long:"tlscertpath" description:"Path to the TLS certificate of the RPC interface, generated if it doesn't exist"`
+	TLSKeyPath   string `long:"tlskeypath" description:"Path to the TLS key of the RPC interface, generated if it doesn't exist"`
+	AdminMacPath string `long:"adminmacaroonpath" description:"Path to the macaroon authenticating the watch-only node, generated if it doesn't exist"`
+	NoMacaroons  bool   `long:"no-macaroons" description:"Disable macaroon authentication"`
+	DebugLevel   string `short:"d" long:"debuglevel" description:"Logging level {trace, debug, info, warn, error, critical}"`
+
+	AllowedPeers []string `long:"allowedpeer" description:"Only allow the watch-only node to hold channels with this node, given by its hex encoded public key. Can be specified multiple times, all nodes are allowed if unset"`
+
+	Create          bool   `long:"create" description:"Create the seed of the signer, print its mnemonic and exit"`
+	ExportWatchOnly string `long:"exportwatchonly" description:"Export the watch-only wallet of the node to the passed lnd chain directory, <lnd datadir>/bitcoin, and exit"`
+
+	// allowedPeers are the parsed public keys of AllowedPeers.
+	allowedPeers []*btcec.PublicKey
+
+	// netParams are the parameters of the selected network.
+	netParams *chaincfg.Params
+}
+
+// loadConfig parses the command line options, and derives the remaining
+// defaults from the data directory.
+func loadConfig() (*config, error) {
+	cfg := &config{
+		DataDir:    signerHomeDir,
+		Network:    defaultNetwork,
+		RPCListen:  defaultRPCListen,
+		DebugLevel: "info",
+	}
+	if _, err := flags.Parse(cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.DataDir = cleanAndExpandPath(cfg.DataDir)
+	if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
+		return nil, err
+	}
+
+	if cfg.TLSCertPath == "" {
+		cfg.TLSCertPath = filepath.Join(cfg.DataDir, defaultTLSCertFilename)
+	}
+	if cfg.TLSKeyPath == "" {
+		cfg.TLSKeyPath = filepath.Join(cfg.DataDir, defaultTLSKeyFilename)
+	}
+	if cfg.AdminMacPath == "" {
+		cfg.AdminMacPath = filepath.Join(
+			cfg.DataDir, defaultAdminMacFilename,
+		)
+	}
+	cfg.TLSCertPath = cleanAndExpandPath(cfg.TLSCertPath)
+	cfg.TLSKeyPath = cleanAndExpandPath(cfg.TLSKeyPath)
+	cfg.AdminMacPath = cleanAndExpandPath(cfg.AdminMacPath)
+	cfg.ExportWatchOnly = cleanAndExpandPath(cfg.ExportWatchOnly)
+
+	switch cfg.Network {
+	case "mainnet":
+		cfg.netParams = &chaincfg.MainNetParams
+	case "testnet":
+		cfg.netParams = &chaincfg.TestNet3Params
+	case "simnet":
+		cfg.netParams = &chaincfg.SimNetParams
+	case "regtest":
+		cfg.netParams = &chaincfg.RegressionNetParams
+	}
+
+	for _, peer := range cfg.AllowedPeers {
+		pubKeyBytes, err := hex.DecodeString(peer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowedpeer %v: %v",
+				peer, err)
+		}
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowedpeer %v: %v",
+				peer, err)
+		}
+
+		cfg.allowedPeers = append(cfg.allowedPeers, pubKey)
+	}
+
+	return cfg, nil
+}
+
+// cleanAndExpandPath expands environment variables and leading ~ in the
+// passed path, cleans the result, and returns it.
+// This function is taken from https://github.com/btcsuite/btcd
+func cleanAndExpandPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	// Expand initial ~ to OS specific home directory.
+	if strings.HasPrefix(path, "~") {
+		homeDir := filepath.Dir(signerHomeDir)
+		path = strings.Replace(path, "~", homeDir, 1)
+	}
+
+	// NOTE: The os.ExpandEnv doesn't work with Windows-style %VARIABLE%,
+	// but the variables can still be expanded via POSIX-style $VARIABLE.
+	return filepath.Clean(os.ExpandEnv(path))
+}
@@ -0,0 +1,329 @@
+// lnsigner holds all private keys of a watch-only lnd node, and serves the
+// RemoteSigner service through which the node derives its keys, and signs and
+// performs ECDH operations with them. The signer only signs for the purposes
+// the keys were issued for, so a compromised node can't use it to sign away
+// the funds of its channels.
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/btcsuite/btclog"
+	flags "github.com/btcsuite/go-flags"
+	"github.com/lightningnetwork/lnd/cipherseed"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/remotesigner"
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+)
+
+var (
+	// backendLog is the logging backend of all subsystem loggers.
+	backendLog = btclog.NewBackend(os.Stdout)
+
+	// log is the logger of the signer itself.
+	log = backendLog.Logger("SGNR")
+
+	// tlsCipherSuites is the set of cipher suites the RPC interface
+	// accepts. Of those lnd accepts, only the forward secret AEAD suites
+	// are kept, as the only client of the signer is lnd itself.
+	tlsCipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	}
+)
+
+// fileExists reports whether the named file or directory exists.
+func fileExists(name string) bool {
+	if _, err := os.Stat(name); err != nil {
+		if os.IsNotExist(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// readPassphrase reads the passphrase of the seed from the terminal. If
+// confirm is set, then the passphrase has to be entered twice.
+func readPassphrase(confirm bool) ([]byte, error) {
+	fmt.Printf("Input seed passphrase: ")
+	pass, err := terminal.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println()
+
+	if !confirm {
+		return pass, nil
+	}
+
+	fmt.Printf("Confirm seed passphrase: ")
+	pass2, err := terminal.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println()
+
+	if !bytes.Equal(pass, pass2) {
+		return nil, fmt.Errorf("passphrases don't match")
+	}
+
+	return pass, nil
+}
+
+// createSeed creates a fresh seed, stores it enciphered within the data
+// directory, and prints its mnemonic so that the user can write it down.
+func createSeed(cfg *config) error {
+	seedPath := filepath.Join(cfg.DataDir, defaultSeedFilename)
+	if fileExists(seedPath) {
+		return fmt.Errorf("seed already exists at %v", seedPath)
+	}
+
+	pass, err := readPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	seed, err := cipherseed.New(
+		cipherseed.CipherSeedVersion, nil, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := seed.ToMnemonic(pass)
+	if err != nil {
+		return err
+	}
+	encipheredSeed, err := seed.Encipher(pass)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(seedPath, encipheredSeed[:], 0600)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("!!!YOU MUST WRITE DOWN THIS SEED TO BE ABLE TO " +
+		"RESTORE THE SIGNER!!!")
+	fmt.Println()
+
+	fmt.Println("-------------BEGIN LNSIGNER CIPHER SEED------------")
+	for i := 0; i < len(mnemonic); i += 4 {
+		end := i + 4
+		if end > len(mnemonic) {
+			end = len(mnemonic)
+		}
+
+		var words []string
+		for j := i; j < end; j++ {
+			words = append(
+				words, fmt.Sprintf("%2d. %-10s", j+1,
+					mnemonic[j]),
+			)
+		}
+		fmt.Println(strings.Join(words, " "))
+	}
+	fmt.Println("-------------END LNSIGNER CIPHER SEED--------------")
+	fmt.Println()
+
+	return nil
+}
+
+// loadSeed reads the enciphered seed from the data directory, and deciphers
+// it with the passphrase read from the terminal.
+func loadSeed(cfg *config) (*cipherseed.CipherSeed, error) {
+	seedPath := filepath.Join(cfg.DataDir, defaultSeedFilename)
+	seedBytes, err := ioutil.ReadFile(seedPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read seed, run with "+
+			"--create to create one: %v", err)
+	}
+
+	var encipheredSeed [cipherseed.EncipheredCipherSeedSize]byte
+	if len(seedBytes) != len(encipheredSeed) {
+		return nil, fmt.Errorf("seed at %v is corrupted", seedPath)
+	}
+	copy(encipheredSeed[:], seedBytes)
+
+	pass, err := readPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipherseed.Decipher(encipheredSeed, pass)
+}
+
+// genMacaroon generates the admin macaroon which authenticates the watch-only
+// node, and writes it to the passed file.
+func genMacaroon(svc *bakery.Service, admFile string) error {
+	admMacaroon, err := svc.NewMacaroon("", nil, nil)
+	if err != nil {
+		return err
+	}
+	admBytes, err := admMacaroon.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(admFile, admBytes, 0600)
+}
+
+// signerMain is the true entry point of lnsigner. This function is required
+// since defers created in the top-level scope of a main method aren't executed
+// if os.Exit() is called.
+func signerMain() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	level, ok := btclog.LevelFromString(cfg.DebugLevel)
+	if !ok {
+		return fmt.Errorf("invalid debug level %v", cfg.DebugLevel)
+	}
+	log.SetLevel(level)
+	signerLog := backendLog.Logger("RSGN")
+	signerLog.SetLevel(level)
+	remotesigner.UseLogger(signerLog)
+
+	if cfg.Create {
+		return createSeed(cfg)
+	}
+
+	seed, err := loadSeed(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The watch-only wallet is created from the same seed, but holds none
+	// of its private keys.
+	if cfg.ExportWatchOnly != "" {
+		err := btcwallet.CreateWatchOnly(
+			cfg.ExportWatchOnly, cfg.netParams, seed.Entropy[:],
+			seed.BirthdayTime(),
+		)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Exported watch-only wallet to %v",
+			btcwallet.NetworkDir(cfg.ExportWatchOnly, cfg.netParams))
+		return nil
+	}
+
+	db, err := bolt.Open(
+		filepath.Join(cfg.DataDir, defaultDBFilename), 0600, nil,
+	)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	keys, err := remotesigner.NewKeyStore(
+		db, seed.Entropy[:], cfg.netParams,
+	)
+	if err != nil {
+		return err
+	}
+
+	var macaroonService *bakery.Service
+	if !cfg.NoMacaroons {
+		macaroonService, err = macaroons.NewService(cfg.DataDir)
+		if err != nil {
+			return fmt.Errorf("unable to create macaroon service: "+
+				"%v", err)
+		}
+
+		if !fileExists(cfg.AdminMacPath) {
+			err := genMacaroon(macaroonService, cfg.AdminMacPath)
+			if err != nil {
+				return fmt.Errorf("unable to create macaroon: "+
+					"%v", err)
+			}
+		}
+	}
+
+	if !fileExists(cfg.TLSCertPath) && !fileExists(cfg.TLSKeyPath) {
+		if err := genCertPair(cfg.TLSCertPath, cfg.TLSKeyPath); err != nil {
+			return err
+		}
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+	if err != nil {
+		return err
+	}
+	tlsConf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		CipherSuites: tlsCipherSuites,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	signerServer, err := remotesigner.NewServer(&remotesigner.ServerConfig{
+		Keys:      keys,
+		NetParams: cfg.netParams,
+		Policy: &remotesigner.Policy{
+			ChainHash:    *cfg.netParams.GenesisHash,
+			AllowedPeers: cfg.allowedPeers,
+		},
+		AuthSvc: macaroonService,
+	})
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConf)),
+	)
+	lnrpc.RegisterRemoteSignerServer(grpcServer, signerServer)
+
+	lis, err := net.Listen("tcp", cfg.RPCListen)
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	// Stop serving once we're interrupted, such that the database is
+	// closed cleanly.
+	interruptChan := make(chan os.Signal, 1)
+	signal.Notify(interruptChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interruptChan
+		log.Infof("Received shutdown request, stopping")
+		grpcServer.GracefulStop()
+	}()
+
+	log.Infof("Serving RemoteSigner service for %v on %v",
+		cfg.netParams.Name, lis.Addr())
+
+	return grpcServer.Serve(lis)
+}
+
+func main() {
+	if err := signerMain(); err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
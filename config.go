@@ -18,8 +18,10 @@ import (
 
 	flags "github.com/btcsuite/go-flags"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/lnwire"
-	"github.com/roasbeef/btcd/btcec"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcutil"
 )
 
@@ -36,11 +38,20 @@ const (
 	defaultRPCPort            = 10009
 	defaultRESTPort           = 8080
 	defaultPeerPort           = 9735
+	defaultWatchtowerPort     = 9911
 	defaultRPCHost            = "localhost"
 	defaultMaxPendingChannels = 1
 	defaultNumChanConfs       = 3
 	defaultNoEncryptWallet    = false
 	defaultTrickleDelay       = 30 * 1000
+
+	defaultRemoteSignerTimeout = 10 * time.Second
+
+	defaultTorSOCKS   = "localhost:9050"
+	defaultTorDNS     = "soa.nodes.lightning.directory:53"
+	defaultTorControl = "localhost:9051"
+
+	defaultOnionKeyFilename = "onion_private_key"
 )
 
 var (
@@ -53,12 +64,15 @@ var (
 	defaultAdminMacPath = filepath.Join(lndHomeDir, defaultAdminMacFilename)
 	defaultReadMacPath  = filepath.Join(lndHomeDir, defaultReadMacFilename)
 	defaultLogDir       = filepath.Join(lndHomeDir, defaultLogDirname)
+	defaultOnionKeyPath = filepath.Join(lndHomeDir, defaultOnionKeyFilename)
 
 	btcdHomeDir            = btcutil.AppDataDir("btcd", false)
 	defaultBtcdRPCCertFile = filepath.Join(btcdHomeDir, "rpc.cert")
 
 	ltcdHomeDir            = btcutil.AppDataDir("ltcd", false)
 	defaultLtcdRPCCertFile = filepath.Join(ltcdHomeDir, "rpc.cert")
+
+	bitcoindHomeDir = btcutil.AppDataDir("bitcoin", false)
 )
 
 type chainConfig struct {
@@ -85,6 +99,14 @@ type neutrinoConfig struct {
 	BanThreshold uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
 }
 
+type bitcoindConfig struct {
+	Active  bool   `long:"active" description:"If bitcoind should be used as the chain backend rather than btcd."`
+	RPCHost string `long:"rpchost" description:"The daemon's rpc listening address. If a port is omitted, then the default port for the selected chain parameters will be used."`
+	RPCUser string `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPass string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	ZMQPath string `long:"zmqpath" description:"The path to the ZMQ socket providing at least raw blocks. Raw transactions can be handled as well."`
+}
+
 type autoPilotConfig struct {
 	// TODO(roasbeef): add
 	Active      bool    `long:"active" description:"If the autopilot agent should be active or not."`
@@ -92,6 +114,38 @@ type autoPilotConfig struct {
 	Allocation  float64 `long:"allocation" description:"The percentage of total funds that should be committed to automatic channel establishment"`
 }
 
+type watchtowerConfig struct {
+	Active    bool     `long:"active" description:"If the watchtower server should be active or not."`
+	Listeners []string `long:"listen" description:"Add an interface/port to listen for watchtower client connections (default all interfaces port: 9911)"`
+}
+
+type wtClientConfig struct {
+	Tower string `long:"tower" description:"The watchtower that revoked states should be backed up to, in the form <pubkey>@<host>[:<port>]"`
+}
+
+type remoteSignerConfig struct {
+	Active       bool          `long:"active" description:"Run as a watch-only node, forwarding the derivation of all keys, and all signing with them, to a remote signer"`
+	RPCHost      string        `long:"rpchost" description:"The host:port of the remote signer's RPC interface"`
+	TLSCertPath  string        `long:"tlscertpath" description:"Path to the TLS certificate of the remote signer's RPC interface"`
+	MacaroonPath string        `long:"macaroonpath" description:"Path to the admin macaroon of the remote signer"`
+	Timeout      time.Duration `long:"timeout" description:"The maximum time to wait for a response of the remote signer"`
+}
+
+type torConfig struct {
+	Active         bool   `long:"active" description:"Allow outbound and inbound connections to be routed through Tor"`
+	SOCKS          string `long:"socks" description:"The host:port that Tor's exposed SOCKS5 proxy is listening on"`
+	DNS            string `long:"dns" description:"The DNS server as host:port that Tor will use for SRV queries - NOTE must have TCP resolution enabled"`
+	Control        string `long:"control" description:"The host:port that Tor is listening on for Tor control connections"`
+	V2             bool   `long:"v2" description:"Automatically set up a v2 onion service to listen for inbound connections"`
+	V3             bool   `long:"v3" description:"Automatically set up a v3 onion service to listen for inbound connections"`
+	PrivateKeyPath string `long:"privatekeypath" description:"The path to the private key of the onion service being created"`
+}
+
+type routingConfig struct {
+	RiskFactor  uint64 `long:"riskfactor" description:"The cost of having a payment locked up for a single block, in parts per billion of the payment amount. Higher values make path finding prefer routes with a lower total time lock over cheaper routes."`
+	AttemptCost int64  `long:"attemptcost" description:"The virtual cost in satoshis of a payment attempt. Path finding weighs this cost, scaled by the estimated success probability of each channel, against the channel's fees."`
+}
+
 // config defines the configuration options for lnd.
 //
 // See loadConfig for further details regarding the configuration
@@ -108,6 +162,8 @@ type config struct {
 	ReadMacPath  string `long:"readonlymacaroonpath" description:"Path to write the read-only macaroon for lnd's RPC and REST services if it doesn't exist"`
 	LogDir       string `long:"logdir" description:"Directory to log output."`
 
+	BackupFilePath string `long:"backupfilepath" description:"The target location of the channel backup file"`
+
 	Listeners   []string `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9735)"`
 	ExternalIPs []string `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
 
@@ -122,6 +178,7 @@ type config struct {
 	RESTPort           int  `long:"restport" description:"The port for the REST server"`
 	DebugHTLC          bool `long:"debughtlc" description:"Activate the debug htlc mode. With the debug HTLC mode, all payments sent use a pre-determined R-Hash. Additionally, all HTLCs sent to a node with the debug HTLC R-Hash are immediately settled in the next available state transition."`
 	HodlHTLC           bool `long:"hodlhtlc" description:"Activate the hodl HTLC mode.  With hodl HTLC mode, all incoming HTLCs will be accepted by the receiving node, but no attempt will be made to settle the payment with the sender."`
+	AcceptKeySend      bool `long:"accept-keysend" description:"Accept spontaneous payments which carry their own preimage within the onion, creating an invoice for each on the fly."`
 	MaxPendingChannels int  `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 
 	Litecoin *chainConfig `group:"Litecoin" namespace:"litecoin"`
@@ -131,13 +188,29 @@ type config struct {
 
 	NeutrinoMode *neutrinoConfig `group:"neutrino" namespace:"neutrino"`
 
+	BitcoindMode *bitcoindConfig `group:"bitcoind" namespace:"bitcoind"`
+
 	Autopilot *autoPilotConfig `group:"autopilot" namespace:"autopilot"`
 
+	Watchtower *watchtowerConfig `group:"watchtower" namespace:"watchtower"`
+
+	WtClient *wtClientConfig `group:"wtclient" namespace:"wtclient"`
+
+	RemoteSigner *remoteSignerConfig `group:"remotesigner" namespace:"remotesigner"`
+
+	Routing *routingConfig `group:"routing" namespace:"routing"`
+
+	Tor *torConfig `group:"Tor" namespace:"tor"`
+
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
 	NoEncryptWallet bool `long:"noencryptwallet" description:"If set, wallet will be encrypted using the default passphrase."`
 
 	TrickleDelay int `long:"trickledelay" description:"Time in milliseconds between each release of announcements to the network"`
+
+	// net is the network that all outbound connections and DNS lookups
+	// are made over, either directly or through Tor.
+	net tor.Net
 }
 
 // loadConfig initializes and parses the config using a config file and command
@@ -172,11 +245,31 @@ func loadConfig() (*config, error) {
 			RPCHost: defaultRPCHost,
 			RPCCert: defaultLtcdRPCCertFile,
 		},
+		BitcoindMode: &bitcoindConfig{
+			RPCHost: defaultRPCHost,
+		},
 		Autopilot: &autoPilotConfig{
 			MaxChannels: 5,
 			Allocation:  0.6,
 		},
+		Watchtower: &watchtowerConfig{},
+		WtClient:   &wtClientConfig{},
+		RemoteSigner: &remoteSignerConfig{
+			Timeout: defaultRemoteSignerTimeout,
+		},
 		TrickleDelay: defaultTrickleDelay,
+		Routing: &routingConfig{
+			RiskFactor: routing.DefaultWeightParams.RiskFactorBillionths,
+			AttemptCost: int64(
+				routing.DefaultWeightParams.AttemptCost.ToSatoshis(),
+			),
+		},
+		Tor: &torConfig{
+			SOCKS:          defaultTorSOCKS,
+			DNS:            defaultTorDNS,
+			Control:        defaultTorControl,
+			PrivateKeyPath: defaultOnionKeyPath,
+		},
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -235,6 +328,61 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// The attempt cost is weighed against fees during path finding, so it
+	// can't be negative.
+	if cfg.Routing.AttemptCost < 0 {
+		str := "%s: routing.attemptcost must not be negative"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+	}
+
+	if cfg.RemoteSigner.Active && (cfg.RemoteSigner.RPCHost == "" ||
+		cfg.RemoteSigner.TLSCertPath == "" ||
+		cfg.RemoteSigner.MacaroonPath == "") {
+
+		str := "%s: remotesigner.rpchost, remotesigner.tlscertpath " +
+			"and remotesigner.macaroonpath must be set to use a " +
+			"remote signer"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+	}
+	if cfg.RemoteSigner.Active {
+		cfg.RemoteSigner.TLSCertPath = cleanAndExpandPath(
+			cfg.RemoteSigner.TLSCertPath,
+		)
+		cfg.RemoteSigner.MacaroonPath = cleanAndExpandPath(
+			cfg.RemoteSigner.MacaroonPath,
+		)
+	}
+
+	// Only a single type of onion service can be created, and doing so
+	// requires our connections to be routed through Tor.
+	switch {
+	case cfg.Tor.V2 && cfg.Tor.V3:
+		str := "%s: Either tor.v2 or tor.v3 can be set, but not both"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+
+	case (cfg.Tor.V2 || cfg.Tor.V3) && !cfg.Tor.Active:
+		str := "%s: Creating an onion service requires tor.active " +
+			"to be set"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+	}
+
+	// With the Tor options validated, we'll now determine the network
+	// that outbound connections will be made over.
+	cfg.net = &tor.ClearNet{}
+	if cfg.Tor.Active {
+		cfg.Tor.PrivateKeyPath = cleanAndExpandPath(
+			cfg.Tor.PrivateKeyPath,
+		)
+		cfg.net = &tor.ProxyNet{
+			SOCKS: cfg.Tor.SOCKS,
+			DNS:   cfg.Tor.DNS,
+		}
+	}
+
 	switch {
 	// The SPV mode implemented currently doesn't support Litecoin, so the
 	// two modes are incompatible.
@@ -244,6 +392,20 @@ func loadConfig() (*config, error) {
 		err := fmt.Errorf(str, funcName)
 		return nil, err
 
+	// Only a single chain backend can be used at a time.
+	case cfg.NeutrinoMode.Active && cfg.BitcoindMode.Active:
+		str := "%s: The light client and bitcoind modes can't be " +
+			"used together -- choose one of the two"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+
+	// The bitcoind backend is only able to serve the Bitcoin network.
+	case cfg.BitcoindMode.Active && cfg.Litecoin.Active:
+		str := "%s: The bitcoind mode doesn't support execution on " +
+			"the Litecoin network"
+		err := fmt.Errorf(str, funcName)
+		return nil, err
+
 	// Either Bitcoin must be active, or Litecoin must be active.
 	// Otherwise, we don't know which chain we're on.
 	case !cfg.Bitcoin.Active && !cfg.Litecoin.Active:
@@ -305,7 +467,20 @@ func loadConfig() (*config, error) {
 			return nil, err
 		}
 
-		if !cfg.NeutrinoMode.Active {
+		switch {
+		case cfg.BitcoindMode.Active:
+			// If needed, we'll attempt to automatically configure
+			// the RPC and ZMQ control plane for the target
+			// bitcoind node.
+			err := parseBitcoindParams(cfg.BitcoindMode, cfg.Bitcoin,
+				funcName)
+			if err != nil {
+				err := fmt.Errorf("unable to load RPC credentials for "+
+					"bitcoind: %v", err)
+				return nil, err
+			}
+
+		case !cfg.NeutrinoMode.Active:
 			// If needed, we'll attempt to automatically configure
 			// the RPC control plan for the target btcd node.
 			err := parseRPCParams(cfg.Bitcoin, bitcoinChain, funcName)
@@ -375,6 +550,17 @@ func loadConfig() (*config, error) {
 	cfg.TLSCertPath = cleanAndExpandPath(cfg.TLSCertPath)
 	cfg.TLSKeyPath = cleanAndExpandPath(cfg.TLSKeyPath)
 
+	// If a custom location for the channel backup file wasn't specified,
+	// then we'll store it alongside the channel database, within the
+	// namespaced data directory.
+	if cfg.BackupFilePath == "" {
+		cfg.BackupFilePath = filepath.Join(
+			cfg.DataDir, chanbackup.DefaultBackupFileName,
+		)
+	} else {
+		cfg.BackupFilePath = cleanAndExpandPath(cfg.BackupFilePath)
+	}
+
 	// Initialize logging at the default logging level.
 	initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename))
 
@@ -496,11 +682,14 @@ func supportedSubsystems() []string {
 }
 
 // noiseDial is a factory function which creates a connmgr compliant dialing
-// function by returning a closure which includes the server's identity key.
-func noiseDial(idPriv *btcec.PrivateKey) func(net.Addr) (net.Conn, error) {
+// function by returning a closure which includes the server's identity key and
+// the network connections should be made over.
+func noiseDial(idKey brontide.SingleKeyECDH,
+	netCfg tor.Net) func(net.Addr) (net.Conn, error) {
+
 	return func(a net.Addr) (net.Conn, error) {
 		lnAddr := a.(*lnwire.NetAddress)
-		return brontide.Dial(idPriv, lnAddr)
+		return brontide.Dial(idKey, lnAddr, netCfg.Dial)
 	}
 }
 
@@ -544,6 +733,99 @@ func parseRPCParams(cConfig *chainConfig, net chainCode, funcName string) error
 	return nil
 }
 
+// parseBitcoindParams attempts to fill in the RPC credentials and ZMQ path of
+// the target bitcoind node from its configuration file if they weren't
+// specified within our own configuration.
+func parseBitcoindParams(bConfig *bitcoindConfig, cConfig *chainConfig,
+	funcName string) error {
+
+	// If all the parameters have already been set, then there's nothing
+	// left for us to do.
+	credsSet := bConfig.RPCUser != "" || bConfig.RPCPass != ""
+	if credsSet && bConfig.ZMQPath != "" {
+		return nil
+	}
+
+	// bitcoind has no simnet mode, so if it's been selected, then we'll
+	// exit early with an error.
+	if cConfig.SimNet {
+		return fmt.Errorf("%v: bitcoind does not support simnet", funcName)
+	}
+
+	fmt.Println("Attempting automatic RPC configuration to bitcoind")
+
+	confFile := filepath.Join(bitcoindHomeDir, "bitcoin.conf")
+	rpcUser, rpcPass, zmqPath, err := extractBitcoindRPCParams(confFile)
+	if err != nil {
+		return fmt.Errorf("unable to extract RPC credentials: %v, "+
+			"cannot start w/o RPC connection", err)
+	}
+
+	fmt.Println("Automatically obtained bitcoind's RPC credentials")
+	if !credsSet {
+		bConfig.RPCUser, bConfig.RPCPass = rpcUser, rpcPass
+	}
+	if bConfig.ZMQPath == "" {
+		bConfig.ZMQPath = zmqPath
+	}
+
+	return nil
+}
+
+// extractBitcoindRPCParams attempts to extract the RPC credentials and ZMQ
+// block notification path of an existing bitcoind instance from the
+// configuration file at the passed path.
+func extractBitcoindRPCParams(bitcoindConfigPath string) (string, string,
+	string, error) {
+
+	bitcoindConfigFile, err := os.Open(bitcoindConfigPath)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer bitcoindConfigFile.Close()
+
+	configContents, err := ioutil.ReadAll(bitcoindConfigFile)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// First, we'll look for the ZMQ socket that raw blocks are published
+	// on, as we're unable to receive new blocks without it.
+	zmqPathRegexp, err := regexp.Compile(`(?m)^\s*zmqpubrawblock=([^\s]+)`)
+	if err != nil {
+		return "", "", "", err
+	}
+	zmqPathSubmatches := zmqPathRegexp.FindSubmatch(configContents)
+	if len(zmqPathSubmatches) < 2 {
+		return "", "", "", fmt.Errorf("unable to find zmqpubrawblock " +
+			"in config")
+	}
+
+	// Next, we'll attempt to locate the RPC user and password. Unlike
+	// btcd, bitcoind names the password option rpcpassword.
+	rpcUserRegexp, err := regexp.Compile(`(?m)^\s*rpcuser=([^\s]+)`)
+	if err != nil {
+		return "", "", "", err
+	}
+	userSubmatches := rpcUserRegexp.FindSubmatch(configContents)
+	if userSubmatches == nil {
+		return "", "", "", fmt.Errorf("unable to find rpcuser in config")
+	}
+
+	rpcPassRegexp, err := regexp.Compile(`(?m)^\s*rpcpassword=([^\s]+)`)
+	if err != nil {
+		return "", "", "", err
+	}
+	passSubmatches := rpcPassRegexp.FindSubmatch(configContents)
+	if passSubmatches == nil {
+		return "", "", "", fmt.Errorf("unable to find rpcpassword in " +
+			"config")
+	}
+
+	return string(userSubmatches[1]), string(passSubmatches[1]),
+		string(zmqPathSubmatches[1]), nil
+}
+
 // extractRPCParams attempts to extract the RPC credentials for an existing
 // btcd instance. The passed path is expected to be the location of btcd's
 // application data directory on the target system.
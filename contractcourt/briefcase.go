@@ -0,0 +1,463 @@
+package contractcourt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// ArbitratorState is an enum that details the current state of the
+// ChannelArbitrator's state machine.
+type ArbitratorState uint8
+
+const (
+	// StateDefault is the default state. In this state, no major actions
+	// need to be executed, as the channel is still open and no HTLC's are
+	// close to expiry.
+	StateDefault ArbitratorState = 0
+
+	// StateBroadcastCommit is a state that indicates that the channel
+	// arbitrator has decided to go on-chain in order to resolve one or
+	// more HTLC's, and is about to broadcast our latest commitment
+	// transaction.
+	StateBroadcastCommit ArbitratorState = 1
+
+	// StateCommitmentBroadcasted is a state that indicates that our
+	// commitment transaction has been broadcast, and the resolvers for
+	// the contracts within it have been launched. Until our commitment
+	// confirms, the remote party may still get their commitment
+	// confirmed instead, in which case the resolvers are replaced by
+	// those for the contracts within the remote commitment.
+	StateCommitmentBroadcasted ArbitratorState = 2
+
+	// StateContractClosed is a state that indicates that the remote
+	// party's commitment transaction has been broadcast, and the
+	// contracts it contains are about to be handed off to their
+	// resolvers.
+	StateContractClosed ArbitratorState = 3
+
+	// StateWaitingFullResolution is a state that indicates that the
+	// commitment transaction has been broadcast, and the resolvers for
+	// all the contracts within it have been launched. We'll remain in
+	// this state until all contracts have been fully resolved.
+	StateWaitingFullResolution ArbitratorState = 4
+
+	// StateFullyResolved is the final state of the channel arbitrator. In
+	// this state, all contracts within the commitment transaction have
+	// been fully resolved, and the arbitrator can exit.
+	StateFullyResolved ArbitratorState = 5
+)
+
+// String returns a human readable string describing the ArbitratorState.
+func (a ArbitratorState) String() string {
+	switch a {
+	case StateDefault:
+		return "StateDefault"
+
+	case StateBroadcastCommit:
+		return "StateBroadcastCommit"
+
+	case StateCommitmentBroadcasted:
+		return "StateCommitmentBroadcasted"
+
+	case StateContractClosed:
+		return "StateContractClosed"
+
+	case StateWaitingFullResolution:
+		return "StateWaitingFullResolution"
+
+	case StateFullyResolved:
+		return "StateFullyResolved"
+
+	default:
+		return "unknown state"
+	}
+}
+
+// resolverType is an enum that enumerates the various types of resolvers. When
+// writing resolvers to disk, we prepend this to the raw bytes stored. This
+// allows us to properly decode the resolver into the proper type.
+type resolverType uint8
+
+const (
+	// resolverTimeout is the type of a resolver that's tasked with
+	// resolving an outgoing HTLC that is very close to timing out.
+	resolverTimeout resolverType = 0
+
+	// resolverSuccess is the type of a resolver that's tasked with
+	// sweeping an incoming HTLC once we know its preimage.
+	resolverSuccess resolverType = 1
+
+	// resolverDust is the type of a resolver that's tasked with failing
+	// an outgoing HTLC that was trimmed as dust backwards once the
+	// commitment transaction confirms.
+	resolverDust resolverType = 2
+)
+
+// ArbitratorLog is the primary source of persistent storage for the
+// ChannelArbitrator. The log stores the current state of the
+// ChannelArbitrator's internal state machine, and also the set of unresolved
+// contracts that the arbitrator is currently watching over. In the case of a
+// restart, the log is used to resume the arbitrator from its prior state.
+type ArbitratorLog interface {
+	// CurrentState returns the current state of the ChannelArbitrator.
+	CurrentState() (ArbitratorState, error)
+
+	// CommitState persists the current state of the ChannelArbitrator.
+	CommitState(ArbitratorState) error
+
+	// FetchUnresolvedContracts returns all unresolved contracts that have
+	// been previously written to the log.
+	FetchUnresolvedContracts() ([]ContractResolver, error)
+
+	// InsertUnresolvedContracts inserts a set of unresolved contracts into
+	// the log. If a contract with the same resolver key is already
+	// present, then it's overwritten, allowing resolvers to checkpoint
+	// their progress.
+	InsertUnresolvedContracts(resolvers ...ContractResolver) error
+
+	// ResolveContract marks a contract as fully resolved within the
+	// database, removing it from the set of unresolved contracts.
+	ResolveContract(ContractResolver) error
+
+	// WipeHistory is to be called ONLY once *all* contracts have been
+	// fully resolved, and the channel closure is finalized. This method
+	// will delete all on-disk state within the persistent log.
+	WipeHistory() error
+}
+
+var (
+	// arbitratorBucket is the top-level bucket that houses the logs of
+	// all active channel arbitrators. Within this bucket, each arbitrator
+	// has its own sub-bucket keyed by the channel point of its channel.
+	arbitratorBucket = []byte("channel-arbitrators")
+
+	// stateKey is the key that we use to store the current state of the
+	// arbitrator.
+	stateKey = []byte("state")
+
+	// contractsBucketKey is the bucket within the arbitrator's bucket
+	// that stores all the active unresolved contracts.
+	contractsBucketKey = []byte("contractkey")
+
+	// errNoContracts is returned when no contracts are found within the
+	// log.
+	errNoContracts = fmt.Errorf("no stored contracts")
+
+	// errScopeBucketNoExist is returned when we can't find the proper
+	// bucket for an arbitrator's scope.
+	errScopeBucketNoExist = fmt.Errorf("scope bucket not found")
+
+	// byteOrder is the default byte order used for all integers
+	// serialized by the contract court.
+	byteOrder = binary.BigEndian
+)
+
+// boltArbitratorLog is an implementation of the ArbitratorLog interface backed
+// by a bolt DB instance.
+type boltArbitratorLog struct {
+	db *channeldb.DB
+
+	cfg ResolverKit
+
+	scopeKey [36]byte
+}
+
+// newBoltArbitratorLog returns a new instance of the boltArbitratorLog given
+// a target channel point, and a resolver kit which is used to re-attach the
+// required resources to any contracts read from disk.
+func newBoltArbitratorLog(db *channeldb.DB, cfg ResolverKit,
+	chanPoint wire.OutPoint) *boltArbitratorLog {
+
+	return &boltArbitratorLog{
+		db:       db,
+		cfg:      cfg,
+		scopeKey: newLogScope(chanPoint),
+	}
+}
+
+// A compile time check to ensure boltArbitratorLog meets the ArbitratorLog
+// interface.
+var _ ArbitratorLog = (*boltArbitratorLog)(nil)
+
+// newLogScope creates the key of the sub-bucket that houses the log of the
+// arbitrator for the target channel point.
+func newLogScope(chanPoint wire.OutPoint) [36]byte {
+	var scope [36]byte
+	copy(scope[:], chanPoint.Hash[:])
+	byteOrder.PutUint32(scope[32:], chanPoint.Index)
+
+	return scope
+}
+
+// fetchScopedLogs returns the channel points of all channel arbitrators
+// that have a log stored within the database.
+func fetchScopedLogs(db *channeldb.DB) ([]wire.OutPoint, error) {
+	var chanPoints []wire.OutPoint
+	err := db.View(func(tx *bolt.Tx) error {
+		arbBucket := tx.Bucket(arbitratorBucket)
+		if arbBucket == nil {
+			return nil
+		}
+
+		return arbBucket.ForEach(func(k, v []byte) error {
+			// We only expect sub-buckets with a properly sized key
+			// within the top-level bucket.
+			if v != nil || len(k) != 36 {
+				return nil
+			}
+
+			var chanPoint wire.OutPoint
+			copy(chanPoint.Hash[:], k[:32])
+			chanPoint.Index = byteOrder.Uint32(k[32:])
+			chanPoints = append(chanPoints, chanPoint)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chanPoints, nil
+}
+
+// CurrentState returns the current state of the ChannelArbitrator. If no
+// state has been committed yet, then StateDefault is returned.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) CurrentState() (ArbitratorState, error) {
+	var s ArbitratorState
+	err := b.db.View(func(tx *bolt.Tx) error {
+		scopeBucket := fetchScopeBucket(tx, b.scopeKey[:])
+		if scopeBucket == nil {
+			return errScopeBucketNoExist
+		}
+
+		stateBytes := scopeBucket.Get(stateKey)
+		if stateBytes == nil {
+			return nil
+		}
+
+		s = ArbitratorState(stateBytes[0])
+		return nil
+	})
+	if err != nil && err != errScopeBucketNoExist {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// CommitState persists the current state of the ChannelArbitrator.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) CommitState(s ArbitratorState) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		scopeBucket, err := createScopeBucket(tx, b.scopeKey[:])
+		if err != nil {
+			return err
+		}
+
+		return scopeBucket.Put(stateKey[:], []byte{uint8(s)})
+	})
+}
+
+// FetchUnresolvedContracts returns all unresolved contracts that have been
+// previously written to the log.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) FetchUnresolvedContracts() ([]ContractResolver, error) {
+	var contracts []ContractResolver
+	err := b.db.View(func(tx *bolt.Tx) error {
+		scopeBucket := fetchScopeBucket(tx, b.scopeKey[:])
+		if scopeBucket == nil {
+			return errScopeBucketNoExist
+		}
+
+		contractBucket := scopeBucket.Bucket(contractsBucketKey)
+		if contractBucket == nil {
+			return errNoContracts
+		}
+
+		return contractBucket.ForEach(func(resKey, resBytes []byte) error {
+			if len(resBytes) == 0 {
+				return fmt.Errorf("empty resolver for key=%x",
+					resKey)
+			}
+
+			var res ContractResolver
+			switch resolverType(resBytes[0]) {
+			case resolverTimeout:
+				res = &htlcTimeoutResolver{}
+			case resolverSuccess:
+				res = &htlcSuccessResolver{}
+			case resolverDust:
+				res = &htlcDustResolver{}
+			default:
+				return fmt.Errorf("unknown resolver type: %v",
+					resBytes[0])
+			}
+
+			r := bytes.NewReader(resBytes[1:])
+			if err := res.Decode(r); err != nil {
+				return err
+			}
+
+			res.AttachResolverKit(b.cfg)
+			contracts = append(contracts, res)
+
+			return nil
+		})
+	})
+	if err != nil && err != errScopeBucketNoExist && err != errNoContracts {
+		return nil, err
+	}
+
+	return contracts, nil
+}
+
+// InsertUnresolvedContracts inserts a set of unresolved contracts into the
+// log. If a contract with the same resolver key is already present, then it's
+// overwritten, allowing resolvers to checkpoint their progress.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) InsertUnresolvedContracts(
+	resolvers ...ContractResolver) error {
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		scopeBucket, err := createScopeBucket(tx, b.scopeKey[:])
+		if err != nil {
+			return err
+		}
+
+		contractBucket, err := scopeBucket.CreateBucketIfNotExists(
+			contractsBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, resolver := range resolvers {
+			if err := writeResolver(contractBucket, resolver); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ResolveContract marks a contract as fully resolved within the database.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) ResolveContract(res ContractResolver) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		scopeBucket := fetchScopeBucket(tx, b.scopeKey[:])
+		if scopeBucket == nil {
+			return errScopeBucketNoExist
+		}
+
+		contractBucket := scopeBucket.Bucket(contractsBucketKey)
+		if contractBucket == nil {
+			return errNoContracts
+		}
+
+		// Once we have the main top-level bucket, we'll delete the key
+		// from the bucket.
+		return contractBucket.Delete(res.ResolverKey())
+	})
+}
+
+// WipeHistory is to be called ONLY once *all* contracts have been fully
+// resolved, and the channel closure is finalized. This method will delete all
+// on-disk state within the persistent log.
+//
+// NOTE: Part of the ArbitratorLog interface.
+func (b *boltArbitratorLog) WipeHistory() error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		arbBucket := tx.Bucket(arbitratorBucket)
+		if arbBucket == nil {
+			return nil
+		}
+
+		err := arbBucket.DeleteBucket(b.scopeKey[:])
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// fetchScopeBucket returns the sub-bucket of the target scope, or nil if it
+// doesn't yet exist.
+func fetchScopeBucket(tx *bolt.Tx, scopeKey []byte) *bolt.Bucket {
+	arbBucket := tx.Bucket(arbitratorBucket)
+	if arbBucket == nil {
+		return nil
+	}
+
+	return arbBucket.Bucket(scopeKey)
+}
+
+// createScopeBucket returns the sub-bucket of the target scope, creating it
+// and the top-level arbitrator bucket if necessary.
+func createScopeBucket(tx *bolt.Tx, scopeKey []byte) (*bolt.Bucket, error) {
+	arbBucket, err := tx.CreateBucketIfNotExists(arbitratorBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return arbBucket.CreateBucketIfNotExists(scopeKey)
+}
+
+// writeResolver serializes the passed resolver, prefixed by its type, and
+// stores it within the contract bucket under its resolver key.
+func writeResolver(contractBucket *bolt.Bucket, res ContractResolver) error {
+	var rType resolverType
+	switch res.(type) {
+	case *htlcTimeoutResolver:
+		rType = resolverTimeout
+	case *htlcSuccessResolver:
+		rType = resolverSuccess
+	case *htlcDustResolver:
+		rType = resolverDust
+	default:
+		return fmt.Errorf("unknown resolver: %T", res)
+	}
+
+	var b bytes.Buffer
+	if err := b.WriteByte(byte(rType)); err != nil {
+		return err
+	}
+	if err := res.Encode(&b); err != nil {
+		return err
+	}
+
+	return contractBucket.Put(res.ResolverKey(), b.Bytes())
+}
+
+// writeOutpoint writes the passed outpoint to the target writer.
+func writeOutpoint(w io.Writer, o *wire.OutPoint) error {
+	if _, err := w.Write(o.Hash[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, o.Index)
+}
+
+// readOutpoint reads an outpoint written by writeOutpoint from the passed
+// reader.
+func readOutpoint(r io.Reader, o *wire.OutPoint) error {
+	if _, err := io.ReadFull(r, o.Hash[:]); err != nil {
+		return err
+	}
+
+	return binary.Read(r, byteOrder, &o.Index)
+}
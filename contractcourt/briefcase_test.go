@@ -0,0 +1,498 @@
+package contractcourt
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	testChanPoint1 = wire.OutPoint{
+		Hash: [32]byte{
+			0x51, 0xb6, 0x37, 0xd8, 0xfc, 0xd2, 0xc6, 0xda,
+			0x48, 0x59, 0xe6, 0x96, 0x31, 0x13, 0xa1, 0x17,
+			0x2d, 0xe7, 0x93, 0xe4, 0xb7, 0x25, 0xb8, 0x4d,
+			0x1f, 0xb, 0x4c, 0xf9, 0x9e, 0xc5, 0x8c, 0xe9,
+		},
+		Index: 1,
+	}
+
+	testChanPoint2 = wire.OutPoint{
+		Hash: [32]byte{
+			0x81, 0xb6, 0x37, 0xd8, 0xfc, 0xd2, 0xc6, 0xda,
+			0x63, 0x59, 0xe6, 0x96, 0x31, 0x13, 0xa1, 0x17,
+			0xd, 0xe7, 0x95, 0xe4, 0xb7, 0x25, 0xb8, 0x4d,
+			0x1e, 0xb, 0x4c, 0xfd, 0x9e, 0xc5, 0x8c, 0xe9,
+		},
+		Index: 0,
+	}
+
+	testPreimage = [32]byte{
+		0x48, 0x59, 0xe6, 0x96, 0x31, 0x13, 0xa1, 0x17,
+		0x2d, 0xe7, 0x93, 0xe4, 0xb7, 0x25, 0xb8, 0x4d,
+		0x1f, 0xb, 0x4c, 0xf9, 0x9e, 0xc5, 0x8c, 0xe9,
+		0x51, 0xb6, 0x37, 0xd8, 0xfc, 0xd2, 0xc6, 0xda,
+	}
+
+	testKey = []byte{
+		0x04, 0x11, 0xdb, 0x93, 0xe1, 0xdc, 0xdb, 0x8a,
+		0x01, 0x6b, 0x49, 0x84, 0x0f, 0x8c, 0x53, 0xbc, 0x1e,
+		0xb6, 0x8a, 0x38, 0x2e, 0x97, 0xb1, 0x48, 0x2e, 0xca,
+		0xd7, 0xb1, 0x48, 0xa6, 0x90, 0x9a, 0x5c, 0xb2, 0xe0,
+		0xea, 0xdd, 0xfb, 0x84, 0xcc, 0xf9, 0x74, 0x44, 0x64,
+		0xf8, 0x2e, 0x16, 0x0b, 0xfa, 0x9b, 0x8b, 0x64, 0xf9,
+		0xd4, 0xc0, 0x3f, 0x99, 0x9b, 0x86, 0x43, 0xf6, 0x56,
+		0xb4, 0x12, 0xa3,
+	}
+
+	testSignDesc = lnwallet.SignDescriptor{
+		SingleTweak: []byte{
+			0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02,
+			0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02,
+			0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02,
+			0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02,
+		},
+		WitnessScript: []byte{
+			0x00, 0x14, 0xee, 0x91, 0x41, 0x7e, 0x85, 0x6c, 0xde,
+			0x10, 0xa2, 0x91, 0x1e, 0xdc, 0xbd, 0xbd, 0x69, 0xe2,
+			0xef, 0xb5, 0x71, 0x48,
+		},
+		Output: &wire.TxOut{
+			Value: 5000000000,
+			PkScript: []byte{
+				0x41, // OP_DATA_65
+				0x04, 0xd6, 0x4b, 0xdf, 0xd0, 0x9e, 0xb1, 0xc5,
+				0xfe, 0x29, 0x5a, 0xbd, 0xeb, 0x1d, 0xca, 0x42,
+				0x81, 0xbe, 0x98, 0x8e, 0x2d, 0xa0, 0xb6, 0xc1,
+				0xc6, 0xa5, 0x9d, 0xc2, 0x26, 0xc2, 0x86, 0x24,
+				0xe1, 0x81, 0x75, 0xe8, 0x51, 0xc9, 0x6b, 0x97,
+				0x3d, 0x81, 0xb0, 0x1c, 0xc3, 0x1f, 0x04, 0x78,
+				0x34, 0xbc, 0x06, 0xd6, 0xd6, 0xed, 0xf6, 0x20,
+				0xd1, 0x84, 0x24, 0x1a, 0x6a, 0xed, 0x8b, 0x63,
+				0xa6, // 65-byte signature
+				0xac, // OP_CHECKSIG
+			},
+		},
+		HashType: txscript.SigHashAll,
+	}
+
+	testTx = &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: testChanPoint2,
+				Witness: [][]byte{
+					{0x01}, {0x02}, {0x03}, {0x04},
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		TxOut: []*wire.TxOut{
+			{
+				Value:    5000000000,
+				PkScript: testSignDesc.Output.PkScript,
+			},
+		},
+		LockTime: 123,
+	}
+)
+
+func init() {
+	testSignDesc.PubKey, _ = btcec.ParsePubKey(testKey, btcec.S256())
+}
+
+func makeTestDB() (*channeldb.DB, func(), error) {
+	// First, create a temporary directory to be used for the duration of
+	// this test.
+	tempDirName, err := ioutil.TempDir("", "arblog")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Next, create channeldb for the first time.
+	db, err := channeldb.Open(tempDirName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		db.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	return db, cleanUp, nil
+}
+
+func newTestBoltArbLog(chanPoint wire.OutPoint) (*boltArbitratorLog, func(),
+	error) {
+
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newBoltArbitratorLog(db, ResolverKit{}, chanPoint), cleanUp, nil
+}
+
+func assertResolversEqual(t *testing.T, originalResolver,
+	diskResolver ContractResolver) {
+
+	switch ogRes := originalResolver.(type) {
+	case *htlcTimeoutResolver:
+		diskRes := diskResolver.(*htlcTimeoutResolver)
+
+		// The resolver kit isn't serialized, so we'll clear it before
+		// comparing the two resolvers.
+		ogRes.ResolverKit = ResolverKit{}
+		if !reflect.DeepEqual(ogRes, diskRes) {
+			t.Fatalf("resolver mismatch: expected %v, got %v",
+				spew.Sdump(ogRes), spew.Sdump(diskRes))
+		}
+
+	case *htlcSuccessResolver:
+		diskRes := diskResolver.(*htlcSuccessResolver)
+
+		ogRes.ResolverKit = ResolverKit{}
+		if !reflect.DeepEqual(ogRes, diskRes) {
+			t.Fatalf("resolver mismatch: expected %v, got %v",
+				spew.Sdump(ogRes), spew.Sdump(diskRes))
+		}
+
+	case *htlcDustResolver:
+		diskRes := diskResolver.(*htlcDustResolver)
+
+		ogRes.ResolverKit = ResolverKit{}
+		if !reflect.DeepEqual(ogRes, diskRes) {
+			t.Fatalf("resolver mismatch: expected %v, got %v",
+				spew.Sdump(ogRes), spew.Sdump(diskRes))
+		}
+
+	default:
+		t.Fatalf("unknown resolver: %T", originalResolver)
+	}
+}
+
+// TestContractInsertionRetrieval tests that were able to insert a set of
+// unresolved contracts into the log, and retrieve the same set properly.
+func TestContractInsertionRetrieval(t *testing.T) {
+	t.Parallel()
+
+	testLog, cleanUp, err := newTestBoltArbLog(testChanPoint1)
+	if err != nil {
+		t.Fatalf("unable to create test log: %v", err)
+	}
+	defer cleanUp()
+
+	// The log shouldn't contain any contracts before we've inserted any.
+	dbContracts, err := testLog.FetchUnresolvedContracts()
+	if err != nil {
+		t.Fatalf("unable to fetch contracts: %v", err)
+	}
+	if len(dbContracts) != 0 {
+		t.Fatalf("expected no contracts, instead got %v",
+			len(dbContracts))
+	}
+
+	// We'll create a timeout resolver for an HTLC on our commitment, and
+	// another for an HTLC on the commitment of the remote party.
+	timeoutResolvers := []*htlcTimeoutResolver{
+		{
+			htlcResolution: lnwallet.OutgoingHtlcResolution{
+				Expiry:          99,
+				PayHash:         sha256.Sum256(testPreimage[:]),
+				HtlcIndex:       5,
+				SignedTimeoutTx: testTx,
+				CsvDelay:        144,
+				ClaimOutpoint:   testChanPoint1,
+				SweepSignDesc:   testSignDesc,
+			},
+			outputIncubating: true,
+			broadcastHeight:  102,
+			shortChanID:      lnwire.NewShortChanIDFromInt(1234),
+		},
+		{
+			htlcResolution: lnwallet.OutgoingHtlcResolution{
+				Expiry:    102,
+				PayHash:   sha256.Sum256(testChanPoint2.Hash[:]),
+				HtlcIndex: 7,
+				ClaimOutpoint: wire.OutPoint{
+					Hash:  testChanPoint1.Hash,
+					Index: 7,
+				},
+				SweepSignDesc: testSignDesc,
+			},
+			resolved:        true,
+			broadcastHeight: 104,
+			shortChanID:     lnwire.NewShortChanIDFromInt(1234),
+		},
+	}
+
+	// We'll also add a success resolver for an incoming HTLC.
+	successTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: testChanPoint1,
+				Witness:          testTx.TxIn[0].Witness,
+			},
+		},
+		TxOut: testTx.TxOut,
+	}
+	successResolver := &htlcSuccessResolver{
+		htlcResolution: lnwallet.IncomingHtlcResolution{
+			PayHash:         sha256.Sum256(testPreimage[:]),
+			Expiry:          111,
+			SignedSuccessTx: successTx,
+			CsvDelay:        144,
+			ClaimOutpoint:   testChanPoint2,
+			SweepSignDesc:   testSignDesc,
+		},
+		outputIncubating: true,
+		broadcastHeight:  109,
+	}
+
+	// Finally, we'll add a resolver for an outgoing HTLC that was trimmed
+	// as dust.
+	dustResolver := &htlcDustResolver{
+		commitHash:      testChanPoint2.Hash,
+		htlcIndex:       9,
+		broadcastHeight: 110,
+		shortChanID:     lnwire.NewShortChanIDFromInt(1234),
+	}
+
+	resolvers := []ContractResolver{
+		timeoutResolvers[0],
+		timeoutResolvers[1],
+		successResolver,
+		dustResolver,
+	}
+
+	// Each of the resolvers has a unique ResolverKey(), so they should
+	// all be stored as distinct contracts.
+	if err := testLog.InsertUnresolvedContracts(resolvers...); err != nil {
+		t.Fatalf("unable to insert resolvers: %v", err)
+	}
+
+	// With the resolvers inserted, we'll now attempt to retrieve them from
+	// the database, so we can compare them to the versions we created
+	// above.
+	diskResolvers, err := testLog.FetchUnresolvedContracts()
+	if err != nil {
+		t.Fatalf("unable to retrieve resolvers: %v", err)
+	}
+	if len(diskResolvers) != len(resolvers) {
+		t.Fatalf("expected %v resolvers, instead got %v",
+			len(resolvers), len(diskResolvers))
+	}
+
+	resolverMap := make(map[string]ContractResolver)
+	for _, resolver := range resolvers {
+		resolverMap[string(resolver.ResolverKey())] = resolver
+	}
+	for _, diskResolver := range diskResolvers {
+		resKey := string(diskResolver.ResolverKey())
+		originalResolver, ok := resolverMap[resKey]
+		if !ok {
+			t.Fatalf("unable to find resolver match for %T: %v",
+				diskResolver, spew.Sdump(diskResolver))
+		}
+
+		assertResolversEqual(t, originalResolver, diskResolver)
+	}
+
+	// We'll now delete one of the resolvers, and ensure that it's no
+	// longer returned.
+	if err := testLog.ResolveContract(resolvers[0]); err != nil {
+		t.Fatalf("unable to resolve contract: %v", err)
+	}
+	diskResolvers, err = testLog.FetchUnresolvedContracts()
+	if err != nil {
+		t.Fatalf("unable to retrieve resolvers: %v", err)
+	}
+	if len(diskResolvers) != len(resolvers)-1 {
+		t.Fatalf("expected %v resolvers, instead got %v",
+			len(resolvers)-1, len(diskResolvers))
+	}
+	for _, diskResolver := range diskResolvers {
+		if reflect.DeepEqual(diskResolver.ResolverKey(),
+			resolvers[0].ResolverKey()) {
+
+			t.Fatalf("resolver was not deleted")
+		}
+	}
+}
+
+// TestContractCheckpoint tests that inserting a resolver that's already
+// present within the log overwrites the prior version, allowing resolvers to
+// checkpoint their progress.
+func TestContractCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	testLog, cleanUp, err := newTestBoltArbLog(testChanPoint1)
+	if err != nil {
+		t.Fatalf("unable to create test log: %v", err)
+	}
+	defer cleanUp()
+
+	timeoutResolver := &htlcTimeoutResolver{
+		htlcResolution: lnwallet.OutgoingHtlcResolution{
+			Expiry:        99,
+			PayHash:       sha256.Sum256(testPreimage[:]),
+			HtlcIndex:     5,
+			ClaimOutpoint: testChanPoint1,
+			SweepSignDesc: testSignDesc,
+		},
+		broadcastHeight: 102,
+	}
+	if err := testLog.InsertUnresolvedContracts(timeoutResolver); err != nil {
+		t.Fatalf("unable to insert resolver: %v", err)
+	}
+
+	// We'll now mutate the state of the resolver, and insert it once
+	// again, simulating a checkpoint.
+	timeoutResolver.outputIncubating = true
+	timeoutResolver.resolved = true
+	if err := testLog.InsertUnresolvedContracts(timeoutResolver); err != nil {
+		t.Fatalf("unable to checkpoint resolver: %v", err)
+	}
+
+	// Only a single resolver should be returned, reflecting the latest
+	// state.
+	diskResolvers, err := testLog.FetchUnresolvedContracts()
+	if err != nil {
+		t.Fatalf("unable to retrieve resolvers: %v", err)
+	}
+	if len(diskResolvers) != 1 {
+		t.Fatalf("expected 1 resolver, instead got %v",
+			len(diskResolvers))
+	}
+	assertResolversEqual(t, timeoutResolver, diskResolvers[0])
+}
+
+// TestStateMutation tests that we're able to properly mutate the state of the
+// log, then retrieve that same mutated state from disk.
+func TestStateMutation(t *testing.T) {
+	t.Parallel()
+
+	testLog, cleanUp, err := newTestBoltArbLog(testChanPoint1)
+	if err != nil {
+		t.Fatalf("unable to create test log: %v", err)
+	}
+	defer cleanUp()
+
+	// The default state of an arbitrator should be StateDefault.
+	arbState, err := testLog.CurrentState()
+	if err != nil {
+		t.Fatalf("unable to read arb state: %v", err)
+	}
+	if arbState != StateDefault {
+		t.Fatalf("state mismatch: expected %v, got %v", StateDefault,
+			arbState)
+	}
+
+	// We should now be able to mutate the state to an arbitrary one of
+	// our choosing, then read that same state back from disk.
+	if err := testLog.CommitState(StateFullyResolved); err != nil {
+		t.Fatalf("unable to write state: %v", err)
+	}
+	arbState, err = testLog.CurrentState()
+	if err != nil {
+		t.Fatalf("unable to read arb state: %v", err)
+	}
+	if arbState != StateFullyResolved {
+		t.Fatalf("state mismatch: expected %v, got %v",
+			StateFullyResolved, arbState)
+	}
+
+	// Next, we'll wipe our state and ensure that if we try to query for
+	// the current state, we get the proper error.
+	if err := testLog.WipeHistory(); err != nil {
+		t.Fatalf("unable to wipe history: %v", err)
+	}
+
+	// If we try to query for the state again, we should get the default
+	// state again.
+	arbState, err = testLog.CurrentState()
+	if err != nil {
+		t.Fatalf("unable to query current state: %v", err)
+	}
+	if arbState != StateDefault {
+		t.Fatalf("state mismatch: expected %v, got %v", StateDefault,
+			arbState)
+	}
+}
+
+// TestScopeIsolation tests the two distinct ArbitratorLog instances with two
+// distinct scopes, don't over write the state of one another, and that the
+// scopes of both are returned by fetchScopedLogs.
+func TestScopeIsolation(t *testing.T) {
+	t.Parallel()
+
+	// We'll create two distinct test logs, each with its own scope.
+	testLog1, cleanUp, err := newTestBoltArbLog(testChanPoint1)
+	if err != nil {
+		t.Fatalf("unable to create test log: %v", err)
+	}
+	defer cleanUp()
+
+	testLog2 := newBoltArbitratorLog(
+		testLog1.db, ResolverKit{}, testChanPoint2,
+	)
+
+	// We'll now update the current state of both the logs to a unique
+	// state.
+	if err := testLog1.CommitState(StateWaitingFullResolution); err != nil {
+		t.Fatalf("unable to write state: %v", err)
+	}
+	if err := testLog2.CommitState(StateContractClosed); err != nil {
+		t.Fatalf("unable to write state: %v", err)
+	}
+
+	// Querying each log, the states should be the prior one we set, and
+	// not the other one.
+	log1State, err := testLog1.CurrentState()
+	if err != nil {
+		t.Fatalf("unable to read arb state: %v", err)
+	}
+	log2State, err := testLog2.CurrentState()
+	if err != nil {
+		t.Fatalf("unable to read arb state: %v", err)
+	}
+	if log1State != StateWaitingFullResolution {
+		t.Fatalf("log state mismatch: expected %v, got %v",
+			StateWaitingFullResolution, log1State)
+	}
+	if log2State != StateContractClosed {
+		t.Fatalf("log state mismatch: expected %v, got %v",
+			StateContractClosed, log2State)
+	}
+
+	// Both scopes should be returned when fetching the set of all logs
+	// within the database.
+	chanPoints, err := fetchScopedLogs(testLog1.db)
+	if err != nil {
+		t.Fatalf("unable to fetch scoped logs: %v", err)
+	}
+	if len(chanPoints) != 2 {
+		t.Fatalf("expected 2 logs, instead got %v", len(chanPoints))
+	}
+	found := make(map[wire.OutPoint]struct{})
+	for _, chanPoint := range chanPoints {
+		found[chanPoint] = struct{}{}
+	}
+	for _, chanPoint := range []wire.OutPoint{testChanPoint1, testChanPoint2} {
+		if _, ok := found[chanPoint]; !ok {
+			t.Fatalf("log for %v not found", chanPoint)
+		}
+	}
+}
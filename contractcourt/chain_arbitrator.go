@@ -0,0 +1,507 @@
+package contractcourt
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// DefaultIncomingBroadcastDelta is the number of blocks before the
+	// expiry of an incoming HTLC that we know the preimage to at which
+	// we'll go on-chain in order to claim it.
+	DefaultIncomingBroadcastDelta = 20
+
+	// DefaultOutgoingBroadcastDelta is the number of blocks before the
+	// expiry of an outgoing HTLC at which we'll go on-chain in order to
+	// time it out, ensuring we're able to cancel the incoming HTLC before
+	// it expires. As other nodes are assumed to use the same delta, it's
+	// also the number of blocks before the expiry of an incoming HTLC at
+	// which the remote party will go on-chain should we not have resolved
+	// it by then.
+	DefaultOutgoingBroadcastDelta = 10
+)
+
+// ResolutionMsg is a message sent by resolvers to outside sub-systems once an
+// outgoing contract has been fully resolved. For multi-hop contracts, if we
+// resolve the outgoing contract, we'll also need to ensure that the incoming
+// contract is resolved as well. We package the items required to resolve the
+// incoming contracts within this message.
+type ResolutionMsg struct {
+	// SourceChan identifies the channel that this message is being sent
+	// from. This is the channel's short channel ID.
+	SourceChan lnwire.ShortChannelID
+
+	// HtlcIndex is the index of the contract within the original
+	// commitment trace.
+	HtlcIndex uint64
+
+	// Failure will be non-nil if the incoming contract should be cancelled
+	// all together. This can happen if the outgoing contract was dust, if
+	// if the outgoing HTLC timed out.
+	Failure lnwire.FailureMessage
+
+	// PreImage will be non-nil if the incoming contract can successfully
+	// be redeemed. This can happen if we learn of the preimage from the
+	// outgoing HTLC on-chain.
+	PreImage *[32]byte
+}
+
+// PreimageDB is a database of all the preimages we know of. Resolvers consult
+// it to determine if an incoming HTLC can be claimed on-chain, and add any
+// preimages they learn of when an outgoing HTLC is claimed by the remote
+// party.
+type PreimageDB interface {
+	// LookupPreimage attempts to look up the preimage for the target
+	// payment hash. If the preimage is known, then it's returned along
+	// with a true value.
+	LookupPreimage(payHash [32]byte) ([]byte, bool)
+
+	// AddPreimage adds a newly found preimage to the database.
+	AddPreimage(preimage []byte) error
+}
+
+// ChainArbitratorConfig is a configuration struct that contains all the
+// function closures and interface that required to arbitrate on-chain
+// contracts for a particular chain.
+type ChainArbitratorConfig struct {
+	// ChainHash is the chain that this arbitrator is to operate within.
+	ChainHash chainhash.Hash
+
+	// IncomingBroadcastDelta is the delta that we'll use to decide when to
+	// broadcast our commitment transaction if we have incoming HTLC's.
+	// This value should be set based on our current fee estimation of the
+	// commitment transaction. We use this to determine when we should
+	// broadcast instead of just the HTLC timeout, as we want to ensure
+	// that the commitment transaction is already confirmed by the time
+	// the HTLC expires. Otherwise we may end up not settling the HTLC
+	// on-chain because the other party managed to time it out.
+	IncomingBroadcastDelta uint32
+
+	// OutgoingBroadcastDelta is the delta that we'll use to decide when to
+	// broadcast our commitment transaction if there are active outgoing
+	// HTLC's. This value can be lower than the incoming broadcast delta.
+	OutgoingBroadcastDelta uint32
+
+	// ForceCloseChan should force close the contract that this attendant
+	// is watching over. We'll use this when we decide that we need to go
+	// to chain. The returned summary contains all items needed to
+	// eventually resolve all outputs on chain.
+	ForceCloseChan func(wire.OutPoint) (*lnwallet.ForceCloseSummary, error)
+
+	// DeliverResolutionMsg is a function that will append an outgoing
+	// message to the "out box" for a ChannelLink. This is used to cancel
+	// backwards any HTLC's that are either dust, we're timing out, or
+	// settling on-chain to the incoming link.
+	DeliverResolutionMsg func(ResolutionMsg) error
+
+	// PreimageDB is a global store of all known pre-images. We'll use this
+	// to decide if we should broadcast a commitment transaction to claim
+	// an HTLC on-chain.
+	PreimageDB PreimageDB
+
+	// GenSweepScript generates the receiving scripts for swept outputs.
+	GenSweepScript func() ([]byte, error)
+
+	// Signer is a signer backed by the active lnd node. This should be
+	// capable of producing a signature as specified by a valid
+	// SignDescriptor.
+	Signer lnwallet.Signer
+
+	// PublishTx reliably broadcasts a transaction to the network. Once
+	// this function exits without an error, then the transaction MUST
+	// continually be rebroadcast if needed.
+	PublishTx func(*wire.MsgTx) error
+
+	// Notifier is an instance of a chain notifier we'll use to watch for
+	// certain on-chain events.
+	Notifier chainntnfs.ChainNotifier
+
+	// ChainIO allows us to query the state of the current main chain.
+	ChainIO lnwallet.BlockChainIO
+
+	// FeeEstimator will be used to return fee estimates.
+	FeeEstimator lnwallet.FeeEstimator
+
+	// DB is the channel database. It's used to fetch the set of open
+	// channels we need to watch, and to house the persistent logs of
+	// each channel arbitrator.
+	DB *channeldb.DB
+
+	// SweepCommitOutput hands our output within a commitment transaction
+	// broadcast by the remote party off to the utxo sweeper, which sweeps
+	// it back into the wallet.
+	SweepCommitOutput func(*lnwallet.UnilateralCloseSummary) error
+
+	// ChannelsChanged is called once the state of a channel closed by the
+	// remote party has been deleted, so the static channel backup on disk
+	// can be updated.
+	ChannelsChanged func()
+}
+
+var (
+	// errChannelNotOpen is returned when the state of a channel is
+	// requested from the database after it has been closed.
+	errChannelNotOpen = fmt.Errorf("channel isn't open")
+)
+
+// ChainArbitrator is a sub-system that oversees the on-chain resolution of all
+// active, and channel that are in the "pending close" state. Within the
+// contractcourt package, the ChainArbitrator manages a set of active
+// ChannelArbitrators. Each ChannelArbitrators is responsible for watching the
+// chain for the settlement of HTLC's on the commitment transaction of its
+// channel, and going on-chain to resolve them once they're about to expire.
+// Once a channel has been closed on-chain, the ChannelArbitrator launches a
+// set of resolvers which drive each of the contracts within the commitment
+// transaction to full resolution.
+type ChainArbitrator struct {
+	started int32
+	stopped int32
+
+	// activeChannels is a map of all the active contracts that are still
+	// open, or not fully resolved.
+	activeChannels map[wire.OutPoint]*ChannelArbitrator
+
+	// cfg is the config struct for the arbitrator that contains all
+	// methods and interface it needs to operate.
+	cfg ChainArbitratorConfig
+
+	sync.Mutex
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewChainArbitrator returns a new instance of the ChainArbitrator using the
+// passed config struct.
+func NewChainArbitrator(cfg ChainArbitratorConfig) *ChainArbitrator {
+	return &ChainArbitrator{
+		cfg:            cfg,
+		activeChannels: make(map[wire.OutPoint]*ChannelArbitrator),
+		quit:           make(chan struct{}),
+	}
+}
+
+// Start launches all goroutines that the ChainArbitrator needs to operate.
+// This includes the ChannelArbitrators of all open channels, as well as those
+// of closed channels with contracts that have yet to be fully resolved.
+func (c *ChainArbitrator) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	log.Tracef("Starting ChainArbitrator")
+
+	c.Lock()
+	defer c.Unlock()
+
+	// First, we'll resume the arbitrators of any channels that were
+	// closed before all their contracts could be resolved.
+	chanPoints, err := fetchScopedLogs(c.cfg.DB)
+	if err != nil {
+		return err
+	}
+	for _, chanPoint := range chanPoints {
+		if _, err := c.fetchOrCreateArbitrator(
+			chanPoint, lnwire.ShortChannelID{},
+		); err != nil {
+			return err
+		}
+	}
+
+	// Next, we'll create an arbitrator for each of our open channels.
+	channels, err := c.cfg.DB.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if channel.IsPending {
+			continue
+		}
+
+		arbitrator, err := c.fetchOrCreateArbitrator(
+			channel.FundingOutpoint, channel.ShortChanID,
+		)
+		if err != nil {
+			return err
+		}
+		if err := arbitrator.watchChannel(channel); err != nil {
+			return err
+		}
+	}
+
+	blockEpochs, err := c.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.watchBlocks(blockEpochs)
+
+	return nil
+}
+
+// Stop signals the ChainArbitrator to trigger a graceful shutdown. Any active
+// channel arbitrators will be signalled to exit, and this method will block
+// until they've all exited.
+func (c *ChainArbitrator) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping ChainArbitrator")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	c.Lock()
+	arbitrators := make([]*ChannelArbitrator, 0, len(c.activeChannels))
+	for _, arbitrator := range c.activeChannels {
+		arbitrators = append(arbitrators, arbitrator)
+	}
+	c.Unlock()
+
+	for _, arbitrator := range arbitrators {
+		if err := arbitrator.Stop(); err != nil {
+			log.Errorf("unable to stop ChannelArbitrator(%v): %v",
+				arbitrator.cfg.ChanPoint, err)
+		}
+	}
+
+	return nil
+}
+
+// fetchOrCreateArbitrator returns the active ChannelArbitrator for the target
+// channel, creating and starting a new one if it doesn't yet exist.
+//
+// NOTE: This method MUST be called with the ChainArbitrator's mutex held.
+func (c *ChainArbitrator) fetchOrCreateArbitrator(chanPoint wire.OutPoint,
+	shortChanID lnwire.ShortChannelID) (*ChannelArbitrator, error) {
+
+	if arbitrator, ok := c.activeChannels[chanPoint]; ok {
+		// If the arbitrator was resumed from its log before we knew
+		// the location of the channel, then we'll populate it now.
+		if arbitrator.cfg.ShortChanID.ToUint64() == 0 {
+			arbitrator.Lock()
+			arbitrator.cfg.ShortChanID = shortChanID
+			arbitrator.Unlock()
+		}
+
+		return arbitrator, nil
+	}
+
+	arbitrator := newBoltChannelArbitrator(ChannelArbitratorConfig{
+		ChanPoint:   chanPoint,
+		ShortChanID: shortChanID,
+		FetchChannel: func() (*channeldb.OpenChannel, error) {
+			return fetchOpenChannel(c.cfg.DB, chanPoint)
+		},
+		ChainArbitratorConfig: c.cfg,
+	}, c.cfg.DB)
+	arbitrator.onFullyResolved = c.markResolved
+
+	if err := arbitrator.Start(); err != nil {
+		return nil, err
+	}
+
+	c.activeChannels[chanPoint] = arbitrator
+
+	return arbitrator, nil
+}
+
+// markResolved is called by a ChannelArbitrator once all the contracts of its
+// channel have been fully resolved.
+func (c *ChainArbitrator) markResolved(chanPoint wire.OutPoint) {
+	// The arbitrator calls this method while holding its own mutex, so
+	// we'll remove it from the set of active channels asynchronously.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		c.Lock()
+		delete(c.activeChannels, chanPoint)
+		c.Unlock()
+
+		log.Infof("ChannelArbitrator(%v) has fully resolved all "+
+			"contracts", chanPoint)
+	}()
+}
+
+// watchBlocks dispatches each new block, along with the latest set of HTLC's
+// of each open channel, to the channel arbitrators. This allows them to
+// decide if they need to go on-chain in order to resolve an HTLC.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChainArbitrator) watchBlocks(blockEpochs *chainntnfs.BlockEpochEvent) {
+	defer c.wg.Done()
+	defer blockEpochs.Cancel()
+
+	for {
+		select {
+		case newBlock, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+			if err := c.dispatchBlock(uint32(newBlock.Height)); err != nil {
+				log.Errorf("unable to dispatch block=%v: %v",
+					newBlock.Height, err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// dispatchBlock notifies each channel arbitrator of the new block height,
+// along with the current set of HTLC's on the commitment transaction of its
+// channel. Arbitrators of channels that have been cooperatively closed are
+// pruned.
+func (c *ChainArbitrator) dispatchBlock(height uint32) error {
+	channels, err := c.cfg.DB.FetchAllChannels()
+	if err != nil {
+		return err
+	}
+
+	// arbitratorTick pairs an arbitrator with the latest state of its
+	// channel, which it's to be notified of for this block.
+	type arbitratorTick struct {
+		arbitrator *ChannelArbitrator
+		channel    *channeldb.OpenChannel
+	}
+
+	c.Lock()
+
+	var ticks []arbitratorTick
+	openChannels := make(map[wire.OutPoint]struct{})
+	for _, channel := range channels {
+		if channel.IsPending {
+			continue
+		}
+		openChannels[channel.FundingOutpoint] = struct{}{}
+
+		arbitrator, err := c.fetchOrCreateArbitrator(
+			channel.FundingOutpoint, channel.ShortChanID,
+		)
+		if err != nil {
+			log.Errorf("unable to create ChannelArbitrator(%v): %v",
+				channel.FundingOutpoint, err)
+			continue
+		}
+
+		ticks = append(ticks, arbitratorTick{
+			arbitrator: arbitrator,
+			channel:    channel,
+		})
+	}
+
+	// Any arbitrator that's still in its default state, and whose channel
+	// is no longer open, has no contracts left to watch over.
+	for chanPoint, arbitrator := range c.activeChannels {
+		if _, ok := openChannels[chanPoint]; ok {
+			continue
+		}
+		if arbitrator.CurrentState() != StateDefault {
+			continue
+		}
+
+		if err := arbitrator.Stop(); err != nil {
+			log.Errorf("unable to stop ChannelArbitrator(%v): %v",
+				chanPoint, err)
+		}
+		delete(c.activeChannels, chanPoint)
+	}
+
+	c.Unlock()
+
+	// The block is dispatched without holding the mutex, as an arbitrator
+	// may synchronously broadcast its commitment transaction in response,
+	// which mustn't stall the other arbitrators, or the resolution of
+	// contracts by other sub-systems.
+	for _, tick := range ticks {
+		if err := tick.arbitrator.watchChannel(tick.channel); err != nil {
+			log.Errorf("unable to watch ChannelPoint(%v): %v",
+				tick.channel.FundingOutpoint, err)
+		}
+
+		tick.arbitrator.blockTick(
+			height, tick.channel.LocalCommitment.Htlcs,
+		)
+	}
+
+	return nil
+}
+
+// ForceCloseContract attempts to force close the channel identified by the
+// passed channel point. The channel's ChannelArbitrator will then resolve all
+// contracts within the broadcast commitment transaction.
+func (c *ChainArbitrator) ForceCloseContract(
+	chanPoint wire.OutPoint) (*lnwallet.ForceCloseSummary, error) {
+
+	c.Lock()
+	arbitrator, err := c.arbitratorForOpenChannel(chanPoint)
+	c.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Attempting to force close ChannelPoint(%v)", chanPoint)
+
+	return arbitrator.ForceClose()
+}
+
+// arbitratorForOpenChannel returns the ChannelArbitrator of the target
+// channel. Arbitrators for newly opened channels are only created with the
+// next block, so if the channel doesn't have one yet, it's created from the
+// channel's state within the database.
+//
+// NOTE: This method MUST be called with the ChainArbitrator's mutex held.
+func (c *ChainArbitrator) arbitratorForOpenChannel(
+	chanPoint wire.OutPoint) (*ChannelArbitrator, error) {
+
+	if arbitrator, ok := c.activeChannels[chanPoint]; ok {
+		return arbitrator, nil
+	}
+
+	channels, err := c.cfg.DB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if channel.IsPending || channel.FundingOutpoint != chanPoint {
+			continue
+		}
+
+		return c.fetchOrCreateArbitrator(
+			channel.FundingOutpoint, channel.ShortChanID,
+		)
+	}
+
+	return nil, fmt.Errorf("unable to find arbitrator for channel %v",
+		chanPoint)
+}
+
+// fetchOpenChannel returns the state of the target channel from the database.
+// If the channel is no longer open, then errChannelNotOpen is returned.
+func fetchOpenChannel(db *channeldb.DB,
+	chanPoint wire.OutPoint) (*channeldb.OpenChannel, error) {
+
+	channels, err := db.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if channel.FundingOutpoint == chanPoint {
+			return channel, nil
+		}
+	}
+
+	return nil, errChannelNotOpen
+}
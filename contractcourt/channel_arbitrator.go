@@ -0,0 +1,871 @@
+package contractcourt
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// errAlreadyClosing is returned when a force close is requested for a
+	// channel whose arbitrator has already moved past its default state.
+	errAlreadyClosing = fmt.Errorf("channel is already being closed " +
+		"on-chain")
+)
+
+// ChannelArbitratorConfig contains all the functionality that the
+// ChannelArbitrator needs in order to properly arbitrate any contract dispute
+// on chain.
+type ChannelArbitratorConfig struct {
+	// ChanPoint is the channel point that uniquely identifies this
+	// channel.
+	ChanPoint wire.OutPoint
+
+	// ShortChanID describes the exact location of the channel within the
+	// chain. We'll use this to address any messages that we need to send
+	// to the switch during contract resolution.
+	ShortChanID lnwire.ShortChannelID
+
+	// FetchChannel returns the latest state of the channel from the
+	// database. If the channel is no longer open, then errChannelNotOpen
+	// is returned.
+	FetchChannel func() (*channeldb.OpenChannel, error)
+
+	// ChainArbitratorConfig is the configuration of the ChainArbitrator
+	// that launched this ChannelArbitrator. It holds all the sub-systems
+	// shared across all channels.
+	ChainArbitratorConfig
+}
+
+// ChannelArbitrator is the on-chain arbitrator for a particular channel. The
+// struct will keep in sync with the current set of HTLC's on the commitment
+// transaction. The job of the attendant is to go on-chain to either settle or
+// cancel an HTLC as necessary iff: an HTLC times out, or we known the
+// pre-image to an HTLC, but it wasn't settled by the link off-chain. The
+// ChannelArbitrator will factor in an expected confirmation delta when
+// broadcasting to ensure that we avoid any possibility of race conditions, and
+// sweep the output(s) without contest.
+type ChannelArbitrator struct {
+	started int32
+	stopped int32
+
+	// log is a persistent log that the attendant will use to checkpoint
+	// its next action, and the state of any unresolved contracts.
+	log ArbitratorLog
+
+	// state is the current state of the arbitrator's state machine.
+	state ArbitratorState
+
+	// activeResolvers is the set of resolvers that are currently
+	// attempting to resolve the contracts of the closed channel.
+	activeResolvers map[string]ContractResolver
+
+	// resolverQuit is closed to signal the active resolvers to exit. It's
+	// replaced each time the active resolvers are abandoned in favor of a
+	// new set of resolvers.
+	resolverQuit chan struct{}
+
+	// onFullyResolved is called once all the contracts of the channel have
+	// been fully resolved, and the log of the arbitrator has been wiped.
+	onFullyResolved func(wire.OutPoint)
+
+	// chanState is the latest snapshot of the state of the channel. Once
+	// our commitment has been broadcast the state of the channel is
+	// deleted, so we'll rely on it to resolve the contracts of the remote
+	// commitment, should it confirm instead of ours.
+	chanState *channeldb.OpenChannel
+
+	// watchingClose is true once we've registered for the spend of the
+	// funding output of the channel.
+	watchingClose bool
+
+	cfg ChannelArbitratorConfig
+
+	sync.Mutex
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewChannelArbitrator returns a new instance of a ChannelArbitrator backed by
+// the passed config struct.
+func NewChannelArbitrator(cfg ChannelArbitratorConfig,
+	log ArbitratorLog) *ChannelArbitrator {
+
+	return &ChannelArbitrator{
+		log:             log,
+		cfg:             cfg,
+		activeResolvers: make(map[string]ContractResolver),
+		resolverQuit:    make(chan struct{}),
+		quit:            make(chan struct{}),
+	}
+}
+
+// newBoltChannelArbitrator returns a new ChannelArbitrator whose log is backed
+// by the channel database.
+func newBoltChannelArbitrator(cfg ChannelArbitratorConfig,
+	db *channeldb.DB) *ChannelArbitrator {
+
+	arbLog := newBoltArbitratorLog(db, ResolverKit{}, cfg.ChanPoint)
+	c := NewChannelArbitrator(cfg, arbLog)
+	arbLog.cfg = c.resolverKit()
+
+	return c
+}
+
+// resolverKit returns the ResolverKit that should be attached to all the
+// resolvers launched by the arbitrator.
+func (c *ChannelArbitrator) resolverKit() ResolverKit {
+	return ResolverKit{
+		ChannelArbitratorConfig: c.cfg,
+		Checkpoint: func(res ContractResolver) error {
+			return c.log.InsertUnresolvedContracts(res)
+		},
+		Quit: c.resolverQuit,
+	}
+}
+
+// Start starts all the goroutines that the ChannelArbitrator needs to operate.
+// If the arbitrator was in the midst of resolving the contracts of a closed
+// channel, then the resolvers of all unresolved contracts are re-launched.
+func (c *ChannelArbitrator) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	var err error
+	c.state, err = c.log.CurrentState()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Starting ChannelArbitrator(%v), state=%v",
+		c.cfg.ChanPoint, c.state)
+
+	switch c.state {
+	// If we crashed before we were able to broadcast our commitment
+	// transaction, we'll attempt to do so again now.
+	case StateBroadcastCommit:
+		height, err := c.resolverKit().currentHeight()
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.broadcastCommit(height); err != nil {
+			log.Errorf("ChannelArbitrator(%v): unable to force "+
+				"close channel: %v", c.cfg.ChanPoint, err)
+		}
+
+	// Otherwise, if a commitment transaction has already been broadcast,
+	// we'll re-launch the resolvers for any contracts that have yet to
+	// be resolved.
+	case StateCommitmentBroadcasted, StateContractClosed,
+		StateWaitingFullResolution:
+
+		resolvers, err := c.log.FetchUnresolvedContracts()
+		if err != nil {
+			return err
+		}
+
+		if c.state == StateContractClosed {
+			err := c.commitState(StateWaitingFullResolution)
+			if err != nil {
+				return err
+			}
+		}
+
+		return c.launchResolvers(resolvers)
+	}
+
+	return nil
+}
+
+// Stop signals the ChannelArbitrator for a graceful shutdown, and waits for
+// all active resolvers to exit.
+func (c *ChannelArbitrator) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Debugf("Stopping ChannelArbitrator(%v)", c.cfg.ChanPoint)
+
+	c.Lock()
+	close(c.resolverQuit)
+	c.Unlock()
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// watchChannel hands the arbitrator the latest state of its open channel. The
+// first time it's called, the arbitrator registers for the spend of the
+// funding output of the channel, so it's able to resolve the contracts within
+// a commitment transaction broadcast by the remote party.
+func (c *ChannelArbitrator) watchChannel(chanState *channeldb.OpenChannel) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.chanState = chanState
+	if c.watchingClose {
+		return nil
+	}
+
+	spendEvent, err := c.cfg.Notifier.RegisterSpendNtfn(
+		&c.cfg.ChanPoint, chanState.ShortChanID.BlockHeight,
+	)
+	if err != nil {
+		return err
+	}
+	c.watchingClose = true
+
+	c.wg.Add(1)
+	go c.closeObserver(spendEvent)
+
+	return nil
+}
+
+// closeObserver waits for the funding output of the channel to be spent, and
+// then resolves the contracts within the spending transaction if it's a
+// commitment broadcast by the remote party.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChannelArbitrator) closeObserver(spendEvent *chainntnfs.SpendEvent) {
+	defer c.wg.Done()
+
+	var commitSpend *chainntnfs.SpendDetail
+	select {
+	case spend, ok := <-spendEvent.Spend:
+		if !ok {
+			return
+		}
+		commitSpend = spend
+
+	case <-c.quit:
+		spendEvent.Cancel()
+		return
+	}
+
+	if err := c.remoteCommitClosed(commitSpend); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to handle spend of "+
+			"funding output: %v", c.cfg.ChanPoint, err)
+	}
+}
+
+// remoteCommitClosed is called once the funding output of the channel has
+// been spent. If the spending transaction is the remote party's commitment,
+// then the contracts within it are handed off to a set of resolvers, and the
+// channel is marked as closed. Cooperative closes, our own commitment, and
+// revoked commitments, which are left to the breach arbiter, are ignored.
+func (c *ChannelArbitrator) remoteCommitClosed(
+	commitSpend *chainntnfs.SpendDetail) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	chanState, err := c.cfg.FetchChannel()
+	isOpen := err == nil
+	switch {
+	// The channel is still open, so we'll resolve the contracts using its
+	// latest state.
+	case isOpen:
+
+	// If we've broadcast our commitment, then the state of the channel has
+	// already been deleted, so we'll use the snapshot we took before.
+	case err == errChannelNotOpen && c.chanState != nil &&
+		c.state == StateCommitmentBroadcasted:
+
+		chanState = c.chanState
+
+	// Otherwise, the channel has been closed cooperatively, leaving us
+	// with no contracts to resolve.
+	case err == errChannelNotOpen:
+		return nil
+
+	default:
+		return err
+	}
+
+	localCommitTxid := chanState.LocalCommitment.CommitTx.TxHash()
+	if commitSpend.SpenderTxHash.IsEqual(&localCommitTxid) {
+		return nil
+	}
+
+	// If we've lost state, then the commitment they've broadcast is newer
+	// than any we know of, so we'll need the commitment point they sent
+	// us in order to locate our output. We're unable to force close a
+	// channel in that case, so it must still be open.
+	var dataLossPoint *btcec.PublicKey
+	if isOpen {
+		dataLossPoint, err = chanState.DataLossCommitPoint()
+		if err != nil && err != channeldb.ErrNoCommitPoint {
+			return err
+		}
+	}
+
+	closeInfo, err := lnwallet.NewUnilateralCloseSummary(
+		chanState, c.cfg.Signer, commitSpend, dataLossPoint,
+	)
+	switch {
+	case err == lnwallet.ErrNotCommitmentTx:
+		return nil
+
+	case err == lnwallet.ErrRevokedCommitment:
+		log.Warnf("ChannelArbitrator(%v): revoked commitment %v "+
+			"broadcast, leaving it to the breach arbiter",
+			c.cfg.ChanPoint, commitSpend.SpenderTxHash)
+		return nil
+
+	case err != nil:
+		return err
+	}
+
+	log.Infof("ChannelPoint(%v) has been closed by the remote party, "+
+		"resolving contracts", c.cfg.ChanPoint)
+
+	// If we crashed after handing off the contracts, but before we could
+	// delete the state of the channel, then there's nothing left to
+	// resolve.
+	switch c.state {
+	case StateContractClosed, StateWaitingFullResolution,
+		StateFullyResolved:
+
+	default:
+		height := uint32(commitSpend.SpendingHeight)
+		resolvers := c.newResolvers(closeInfo.HtlcResolutions, height)
+
+		// We don't know the HTLC's on the commitment if we've lost
+		// state, so we're only able to fail back dust HTLC's if we
+		// haven't.
+		if dataLossPoint == nil {
+			resolvers = append(resolvers, c.newDustResolvers(
+				chanState.RemoteCommitment.Htlcs,
+				*commitSpend.SpenderTxHash, height,
+			)...)
+		}
+
+		if err := c.contractClosed(resolvers); err != nil {
+			return err
+		}
+	}
+
+	// Now that the resolvers have been persisted, we can delete the
+	// state of the channel if it's still open.
+	if isOpen {
+		err := chanState.CloseChannel(&closeInfo.ChannelCloseSummary)
+		if err != nil {
+			return err
+		}
+		c.cfg.ChannelsChanged()
+	}
+
+	c.wg.Add(1)
+	go c.waitForRemoteCommitConf(closeInfo)
+
+	return nil
+}
+
+// waitForRemoteCommitConf waits for the commitment transaction broadcast by
+// the remote party to confirm. Once it has, our output within it is swept,
+// and the channel is marked as fully closed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChannelArbitrator) waitForRemoteCommitConf(
+	closeInfo *lnwallet.UnilateralCloseSummary) {
+
+	defer c.wg.Done()
+
+	confNtfn, err := c.cfg.Notifier.RegisterConfirmationsNtfn(
+		closeInfo.SpenderTxHash, 1, uint32(closeInfo.SpendingHeight),
+	)
+	if err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to register for "+
+			"confirmation of %v: %v", c.cfg.ChanPoint,
+			closeInfo.SpenderTxHash, err)
+		return
+	}
+
+	select {
+	case _, ok := <-confNtfn.Confirmed:
+		if !ok {
+			return
+		}
+
+	case <-c.quit:
+		return
+	}
+
+	if err := c.cfg.SweepCommitOutput(closeInfo); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to sweep commitment "+
+			"output: %v", c.cfg.ChanPoint, err)
+	}
+
+	log.Infof("Force closed ChannelPoint(%v) is fully closed, updating DB",
+		c.cfg.ChanPoint)
+
+	if err := c.cfg.DB.MarkChanFullyClosed(&c.cfg.ChanPoint); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to mark channel as "+
+			"fully closed: %v", c.cfg.ChanPoint, err)
+	}
+}
+
+// CurrentState returns the current state of the arbitrator's state machine.
+func (c *ChannelArbitrator) CurrentState() ArbitratorState {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.state
+}
+
+// shouldGoOnChain takes into account the absolute timeout of the HTLC, if
+// the current height is close enough to the expiry of the HTLC that we're
+// unable to safely settle or cancel it off-chain, then we'll go on-chain.
+func shouldGoOnChain(htlcExpiry, broadcastDelta, currentHeight uint32) bool {
+	// If the broadcast delta is larger than the expiry itself, then the
+	// cut off would underflow. We're past it at any height in that case.
+	if broadcastDelta >= htlcExpiry {
+		return true
+	}
+
+	// We'll calculate the broadcast cut off for this HTLC. This is the
+	// height that (based on our current fee estimation) we should
+	// broadcast in order to ensure the commitment transaction is
+	// confirmed before the HTLC fully expires.
+	broadcastCutOff := htlcExpiry - broadcastDelta
+
+	log.Tracef("Checking broadcast cut off for htlc with expiry=%v, "+
+		"delta=%v: height=%v, cut off=%v", htlcExpiry, broadcastDelta,
+		currentHeight, broadcastCutOff)
+
+	return currentHeight >= broadcastCutOff
+}
+
+// checkChainActions determines if any of the HTLC's on our current commitment
+// transaction require us to go on-chain at the passed height. We'll go
+// on-chain if an outgoing HTLC is about to time out, as otherwise the
+// corresponding incoming HTLC may be timed out before we can cancel it.
+// Similarly, we'll go on-chain if we know the preimage of an incoming HTLC
+// that's about to time out, so we're able to claim it before the remote party
+// can reclaim it.
+func (c *ChannelArbitrator) checkChainActions(height uint32,
+	htlcs []channeldb.HTLC) bool {
+
+	for _, htlc := range htlcs {
+		// HTLC's that were trimmed as dust don't have an output on
+		// the commitment transaction, so there's nothing to gain by
+		// going on-chain for them. If we do go on-chain for another
+		// reason, then they're failed backwards once the commitment
+		// transaction confirms.
+		if htlc.OutputIndex < 0 {
+			continue
+		}
+
+		if !htlc.Incoming {
+			if shouldGoOnChain(htlc.RefundTimeout,
+				c.cfg.OutgoingBroadcastDelta, height) {
+
+				log.Infof("ChannelArbitrator(%v): outgoing "+
+					"htlc %x with expiry=%v is about to "+
+					"time out at height=%v",
+					c.cfg.ChanPoint, htlc.RHash[:],
+					htlc.RefundTimeout, height)
+				return true
+			}
+
+			continue
+		}
+
+		if !shouldGoOnChain(htlc.RefundTimeout,
+			c.cfg.IncomingBroadcastDelta, height) {
+
+			continue
+		}
+
+		// We only need to go on-chain for an incoming HTLC if we
+		// actually know the preimage, otherwise we'd be unable to
+		// claim it.
+		if _, ok := c.cfg.PreimageDB.LookupPreimage(htlc.RHash); ok {
+			log.Infof("ChannelArbitrator(%v): incoming htlc %x "+
+				"with expiry=%v is about to time out at "+
+				"height=%v, and the preimage is known",
+				c.cfg.ChanPoint, htlc.RHash[:],
+				htlc.RefundTimeout, height)
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockTick is called by the ChainArbitrator with each new block, along with
+// the current set of HTLC's on our commitment transaction. If any of the
+// HTLC's require us to go on-chain, then we'll broadcast our commitment
+// transaction and begin to resolve the HTLC's on-chain.
+func (c *ChannelArbitrator) blockTick(height uint32, htlcs []channeldb.HTLC) {
+	c.Lock()
+	defer c.Unlock()
+
+	switch c.state {
+	case StateDefault:
+		if !c.checkChainActions(height, htlcs) {
+			return
+		}
+
+	// If we failed to broadcast our commitment in a prior attempt, we'll
+	// try again with each new block.
+	case StateBroadcastCommit:
+
+	default:
+		return
+	}
+
+	if _, err := c.broadcastCommit(height); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to force close "+
+			"channel: %v", c.cfg.ChanPoint, err)
+	}
+}
+
+// ForceClose executes a unilateral close of the channel, broadcasting our
+// latest commitment transaction. The resolutions of all the HTLC's on the
+// commitment are then handed off to a set of resolvers which will resolve them
+// on-chain.
+func (c *ChannelArbitrator) ForceClose() (*lnwallet.ForceCloseSummary, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.state != StateDefault {
+		return nil, errAlreadyClosing
+	}
+
+	height, err := c.resolverKit().currentHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.broadcastCommit(height)
+}
+
+// broadcastCommit transitions the arbitrator into the StateBroadcastCommit
+// state, then force closes the channel. Once the commitment transaction has
+// been broadcast, the HTLC resolutions are handed off to their resolvers.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) broadcastCommit(
+	height uint32) (*lnwallet.ForceCloseSummary, error) {
+
+	if err := c.commitState(StateBroadcastCommit); err != nil {
+		return nil, err
+	}
+
+	// Until our commitment confirms, the remote party may still get their
+	// own commitment confirmed instead. As the state of the channel is
+	// deleted once ours has been broadcast, we'll take a final snapshot of
+	// it beforehand, so we're able to resolve the contracts within theirs.
+	chanState, err := c.cfg.FetchChannel()
+	switch {
+	case err == nil:
+		c.chanState = chanState
+
+	case err != errChannelNotOpen:
+		return nil, err
+	}
+
+	log.Infof("ChannelArbitrator(%v): broadcasting commitment "+
+		"transaction", c.cfg.ChanPoint)
+
+	closeSummary, err := c.cfg.ForceCloseChan(c.cfg.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll persist the resolvers before transitioning to the next state,
+	// so we're able to resume resolution if we crash.
+	resolvers := c.newResolvers(closeSummary.HtlcResolutions, height)
+	if c.chanState != nil {
+		resolvers = append(resolvers, c.newDustResolvers(
+			c.chanState.LocalCommitment.Htlcs,
+			closeSummary.CloseTx.TxHash(), height,
+		)...)
+	}
+	if len(resolvers) != 0 {
+		if err := c.log.InsertUnresolvedContracts(resolvers...); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.commitState(StateCommitmentBroadcasted); err != nil {
+		return nil, err
+	}
+
+	log.Infof("ChannelArbitrator(%v): commitment broadcast, launching "+
+		"%v resolvers", c.cfg.ChanPoint, len(resolvers))
+
+	if err := c.launchResolvers(resolvers); err != nil {
+		return nil, err
+	}
+
+	return closeSummary, nil
+}
+
+// ContractClosed is called once the remote party has broadcast their
+// commitment transaction. The HTLC resolutions for the remote commitment are
+// handed off to a set of resolvers which will resolve them on-chain.
+func (c *ChannelArbitrator) ContractClosed(
+	htlcResolutions *lnwallet.HtlcResolutions, height uint32) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.contractClosed(c.newResolvers(htlcResolutions, height))
+}
+
+// contractClosed hands the contracts of the remote commitment off to the
+// passed set of resolvers, abandoning those of our own commitment if it was
+// broadcast.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) contractClosed(
+	resolvers []ContractResolver) error {
+
+	switch c.state {
+	case StateDefault, StateBroadcastCommit:
+
+	// If we've already broadcast our commitment, then the remote party's
+	// commitment confirmed before ours did. The outputs our resolvers are
+	// waiting on will never exist, so we abandon them in favor of the
+	// resolvers for the remote commitment.
+	case StateCommitmentBroadcasted:
+		log.Infof("ChannelArbitrator(%v): remote commitment confirmed "+
+			"instead of ours, abandoning %v resolvers",
+			c.cfg.ChanPoint, len(c.activeResolvers))
+
+		if err := c.abandonResolvers(); err != nil {
+			return err
+		}
+
+	default:
+		return errAlreadyClosing
+	}
+
+	return c.resolveContracts(resolvers)
+}
+
+// abandonResolvers signals all active resolvers to exit, and removes them
+// from the log.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) abandonResolvers() error {
+	close(c.resolverQuit)
+	c.resolverQuit = make(chan struct{})
+
+	for key, resolver := range c.activeResolvers {
+		if err := c.log.ResolveContract(resolver); err != nil {
+			return err
+		}
+		delete(c.activeResolvers, key)
+	}
+
+	return nil
+}
+
+// resolveContracts persists the passed resolvers for the contracts of the
+// remote commitment, and then launches them.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) resolveContracts(
+	resolvers []ContractResolver) error {
+
+	log.Infof("ChannelArbitrator(%v): contract closed, launching %v "+
+		"resolvers", c.cfg.ChanPoint, len(resolvers))
+
+	// We'll persist the resolvers before transitioning to the next state,
+	// so we're able to resume resolution if we crash.
+	if len(resolvers) != 0 {
+		if err := c.log.InsertUnresolvedContracts(resolvers...); err != nil {
+			return err
+		}
+	}
+	if err := c.commitState(StateContractClosed); err != nil {
+		return err
+	}
+	if err := c.commitState(StateWaitingFullResolution); err != nil {
+		return err
+	}
+
+	return c.launchResolvers(resolvers)
+}
+
+// newResolvers creates a resolver for each of the passed HTLC resolutions of
+// a commitment transaction broadcast at the passed height.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) newResolvers(
+	htlcResolutions *lnwallet.HtlcResolutions,
+	height uint32) []ContractResolver {
+
+	kit := c.resolverKit()
+
+	var resolvers []ContractResolver
+	if htlcResolutions != nil {
+		for _, res := range htlcResolutions.IncomingHTLCs {
+			resolvers = append(resolvers, &htlcSuccessResolver{
+				htlcResolution:  res,
+				broadcastHeight: height,
+				ResolverKit:     kit,
+			})
+		}
+		for _, res := range htlcResolutions.OutgoingHTLCs {
+			resolvers = append(resolvers, &htlcTimeoutResolver{
+				htlcResolution:  res,
+				broadcastHeight: height,
+				shortChanID:     c.cfg.ShortChanID,
+				ResolverKit:     kit,
+			})
+		}
+	}
+
+	return resolvers
+}
+
+// newDustResolvers creates a resolver for each of the outgoing HTLC's that were
+// trimmed as dust from the commitment transaction with the passed txid, which
+// fails them backwards once it confirms.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) newDustResolvers(htlcs []channeldb.HTLC,
+	commitHash chainhash.Hash, height uint32) []ContractResolver {
+
+	kit := c.resolverKit()
+
+	var resolvers []ContractResolver
+	for _, htlc := range htlcs {
+		if htlc.Incoming || htlc.OutputIndex >= 0 {
+			continue
+		}
+
+		resolvers = append(resolvers, &htlcDustResolver{
+			commitHash:      commitHash,
+			htlcIndex:       htlc.HtlcIndex,
+			broadcastHeight: height,
+			shortChanID:     c.cfg.ShortChanID,
+			ResolverKit:     kit,
+		})
+	}
+
+	return resolvers
+}
+
+// launchResolvers launches a goroutine for each of the passed resolvers. If
+// there aren't any resolvers to launch, then the channel is considered to be
+// fully resolved.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) launchResolvers(
+	resolvers []ContractResolver) error {
+
+	if len(resolvers) == 0 {
+		return c.markFullyResolved()
+	}
+
+	for _, resolver := range resolvers {
+		c.activeResolvers[string(resolver.ResolverKey())] = resolver
+
+		c.wg.Add(1)
+		go c.resolveContract(resolver)
+	}
+
+	return nil
+}
+
+// resolveContract is a goroutine that drives a single resolver to completion.
+// Once the resolver has finished, it's removed from the log, and if it was
+// the last active resolver, the channel is marked as fully resolved.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *ChannelArbitrator) resolveContract(resolver ContractResolver) {
+	defer c.wg.Done()
+
+	err := resolver.Resolve()
+	switch {
+	case err == errResolverShuttingDown:
+		return
+
+	// If we're unable to resolve the contract, then we'll leave it within
+	// the log so it will be re-attempted once we restart.
+	case err != nil:
+		log.Errorf("ChannelArbitrator(%v): unable to resolve "+
+			"contract %T: %v", c.cfg.ChanPoint, resolver, err)
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// If the resolver was abandoned while we were waiting for the mutex,
+	// then it's already been removed from the log.
+	key := string(resolver.ResolverKey())
+	if _, ok := c.activeResolvers[key]; !ok {
+		return
+	}
+
+	if err := c.log.ResolveContract(resolver); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to mark contract "+
+			"%T as resolved: %v", c.cfg.ChanPoint, resolver, err)
+		return
+	}
+	delete(c.activeResolvers, key)
+
+	if len(c.activeResolvers) != 0 {
+		return
+	}
+
+	if err := c.markFullyResolved(); err != nil {
+		log.Errorf("ChannelArbitrator(%v): unable to mark channel as "+
+			"fully resolved: %v", c.cfg.ChanPoint, err)
+	}
+}
+
+// markFullyResolved transitions the arbitrator into its final state, and
+// wipes its persistent log as there's nothing left to resolve.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) markFullyResolved() error {
+	log.Infof("ChannelArbitrator(%v): all contracts fully resolved",
+		c.cfg.ChanPoint)
+
+	c.state = StateFullyResolved
+	if err := c.log.WipeHistory(); err != nil {
+		return err
+	}
+
+	if c.onFullyResolved != nil {
+		c.onFullyResolved(c.cfg.ChanPoint)
+	}
+
+	return nil
+}
+
+// commitState persists the passed state, and transitions the arbitrator to it.
+//
+// NOTE: This method MUST be called with the arbitrator's mutex held.
+func (c *ChannelArbitrator) commitState(state ArbitratorState) error {
+	if err := c.log.CommitState(state); err != nil {
+		return err
+	}
+
+	log.Debugf("ChannelArbitrator(%v): state transition %v -> %v",
+		c.cfg.ChanPoint, c.state, state)
+
+	c.state = state
+	return nil
+}
@@ -0,0 +1,623 @@
+package contractcourt
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockArbitratorLog is an in-memory implementation of the ArbitratorLog
+// interface.
+type mockArbitratorLog struct {
+	state     ArbitratorState
+	resolvers map[string]ContractResolver
+
+	// failCommit, if non-nil, is returned when committing the target
+	// state.
+	failCommit      error
+	failCommitState ArbitratorState
+}
+
+func newMockArbitratorLog() *mockArbitratorLog {
+	return &mockArbitratorLog{
+		resolvers: make(map[string]ContractResolver),
+	}
+}
+
+func (m *mockArbitratorLog) CurrentState() (ArbitratorState, error) {
+	return m.state, nil
+}
+
+func (m *mockArbitratorLog) CommitState(s ArbitratorState) error {
+	if m.failCommit != nil && s == m.failCommitState {
+		return m.failCommit
+	}
+
+	m.state = s
+	return nil
+}
+
+func (m *mockArbitratorLog) FetchUnresolvedContracts() ([]ContractResolver,
+	error) {
+
+	var resolvers []ContractResolver
+	for _, resolver := range m.resolvers {
+		resolvers = append(resolvers, resolver)
+	}
+
+	return resolvers, nil
+}
+
+func (m *mockArbitratorLog) InsertUnresolvedContracts(
+	resolvers ...ContractResolver) error {
+
+	for _, resolver := range resolvers {
+		m.resolvers[string(resolver.ResolverKey())] = resolver
+	}
+
+	return nil
+}
+
+func (m *mockArbitratorLog) ResolveContract(res ContractResolver) error {
+	delete(m.resolvers, string(res.ResolverKey()))
+	return nil
+}
+
+func (m *mockArbitratorLog) WipeHistory() error {
+	m.state = StateDefault
+	m.resolvers = make(map[string]ContractResolver)
+	return nil
+}
+
+var _ ArbitratorLog = (*mockArbitratorLog)(nil)
+
+// mockPreimageDB is a mock implementation of the PreimageDB interface.
+type mockPreimageDB struct {
+	preimages map[[32]byte][]byte
+}
+
+func newMockPreimageDB() *mockPreimageDB {
+	return &mockPreimageDB{
+		preimages: make(map[[32]byte][]byte),
+	}
+}
+
+func (m *mockPreimageDB) LookupPreimage(payHash [32]byte) ([]byte, bool) {
+	preimage, ok := m.preimages[payHash]
+	return preimage, ok
+}
+
+func (m *mockPreimageDB) AddPreimage(preimage []byte) error {
+	m.preimages[sha256.Sum256(preimage)] = preimage
+	return nil
+}
+
+var _ PreimageDB = (*mockPreimageDB)(nil)
+
+// mockChainIO is a mock implementation of the BlockChainIO interface which
+// reports a fixed best height.
+type mockChainIO struct {
+	bestHeight int32
+}
+
+func (m *mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return &chainhash.Hash{}, m.bestHeight, nil
+}
+
+func (*mockChainIO) GetUtxo(op *wire.OutPoint,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return nil, nil
+}
+
+func (*mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	return nil, nil
+}
+
+func (*mockChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return nil, nil
+}
+
+var _ lnwallet.BlockChainIO = (*mockChainIO)(nil)
+
+// mockNotifier is a mock implementation of the ChainNotifier interface whose
+// notifications never fire.
+type mockNotifier struct{}
+
+func (*mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash, numConfs,
+	heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed:    make(chan *chainntnfs.TxConfirmation, 1),
+		NegativeConf: make(chan int32, 1),
+	}, nil
+}
+
+func (*mockNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	return &chainntnfs.SpendEvent{
+		Spend:  make(chan *chainntnfs.SpendDetail, 1),
+		Cancel: func() {},
+	}, nil
+}
+
+func (*mockNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent,
+	error) {
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: make(chan *chainntnfs.BlockEpoch, 1),
+		Cancel: func() {},
+	}, nil
+}
+
+func (*mockNotifier) Start() error {
+	return nil
+}
+
+func (*mockNotifier) Stop() error {
+	return nil
+}
+
+var _ chainntnfs.ChainNotifier = (*mockNotifier)(nil)
+
+// mockConfNotifier is a mock implementation of the ChainNotifier interface
+// which dispatches all confirmation notifications over a single channel.
+type mockConfNotifier struct {
+	mockNotifier
+
+	confChan chan *chainntnfs.TxConfirmation
+}
+
+func (m *mockConfNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed:    m.confChan,
+		NegativeConf: make(chan int32, 1),
+	}, nil
+}
+
+var _ chainntnfs.ChainNotifier = (*mockConfNotifier)(nil)
+
+// createTestChannelArbitrator returns a channel arbitrator backed by a mock
+// log, along with a channel that receives each force close request.
+func createTestChannelArbitrator(arbLog ArbitratorLog) (*ChannelArbitrator,
+	chan wire.OutPoint) {
+
+	forceCloses := make(chan wire.OutPoint, 10)
+	chainCfg := ChainArbitratorConfig{
+		IncomingBroadcastDelta: 20,
+		OutgoingBroadcastDelta: 10,
+		ForceCloseChan: func(op wire.OutPoint) (*lnwallet.ForceCloseSummary,
+			error) {
+
+			forceCloses <- op
+			return &lnwallet.ForceCloseSummary{
+				ChanPoint:       op,
+				CloseTx:         &wire.MsgTx{},
+				HtlcResolutions: &lnwallet.HtlcResolutions{},
+			}, nil
+		},
+		PreimageDB: newMockPreimageDB(),
+		ChainIO:    &mockChainIO{bestHeight: 100},
+	}
+
+	arbCfg := ChannelArbitratorConfig{
+		ChanPoint: testChanPoint1,
+		FetchChannel: func() (*channeldb.OpenChannel, error) {
+			return nil, errChannelNotOpen
+		},
+		ChainArbitratorConfig: chainCfg,
+	}
+
+	return NewChannelArbitrator(arbCfg, arbLog), forceCloses
+}
+
+// TestShouldGoOnChain tests that we'll only go on-chain once the current
+// height is within the broadcast delta of the expiry of an HTLC.
+func TestShouldGoOnChain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expiry  uint32
+		delta   uint32
+		height  uint32
+		onChain bool
+	}{
+		{expiry: 100, delta: 10, height: 80, onChain: false},
+		{expiry: 100, delta: 10, height: 89, onChain: false},
+		{expiry: 100, delta: 10, height: 90, onChain: true},
+		{expiry: 100, delta: 10, height: 101, onChain: true},
+
+		// A delta larger than the expiry must not underflow.
+		{expiry: 5, delta: 10, height: 1, onChain: true},
+	}
+
+	for i, test := range testCases {
+		onChain := shouldGoOnChain(test.expiry, test.delta, test.height)
+		if onChain != test.onChain {
+			t.Fatalf("test #%v: expected onChain=%v, got %v", i,
+				test.onChain, onChain)
+		}
+	}
+}
+
+// TestChannelArbitratorChainActions tests that the channel arbitrator only
+// decides to go on-chain for HTLC's that are close to expiry, and for incoming
+// HTLC's only if the preimage is known.
+func TestChannelArbitratorChainActions(t *testing.T) {
+	t.Parallel()
+
+	chanArb, _ := createTestChannelArbitrator(newMockArbitratorLog())
+
+	preimage := testPreimage
+	payHash := sha256.Sum256(preimage[:])
+
+	incoming := channeldb.HTLC{
+		RHash:         payHash,
+		RefundTimeout: 150,
+		OutputIndex:   1,
+		Incoming:      true,
+	}
+	outgoing := channeldb.HTLC{
+		RefundTimeout: 150,
+		OutputIndex:   2,
+	}
+	dustOutgoing := channeldb.HTLC{
+		RefundTimeout: 100,
+		OutputIndex:   -1,
+	}
+
+	// Well before the expiry of any HTLC's, we shouldn't go on-chain.
+	htlcs := []channeldb.HTLC{incoming, outgoing, dustOutgoing}
+	if chanArb.checkChainActions(100, htlcs) {
+		t.Fatalf("shouldn't go on-chain at height 100")
+	}
+
+	// Once we're within the incoming broadcast delta, we still shouldn't
+	// go on-chain as we don't know the preimage of the incoming HTLC.
+	if chanArb.checkChainActions(130, []channeldb.HTLC{incoming}) {
+		t.Fatalf("shouldn't go on-chain without the preimage")
+	}
+
+	// If we add the preimage, then we should go on-chain in order to
+	// claim the HTLC.
+	if err := chanArb.cfg.PreimageDB.AddPreimage(preimage[:]); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+	if !chanArb.checkChainActions(130, []channeldb.HTLC{incoming}) {
+		t.Fatalf("should go on-chain to claim incoming htlc")
+	}
+
+	// The outgoing HTLC should only trigger an on-chain action once we're
+	// within the outgoing broadcast delta.
+	if chanArb.checkChainActions(139, []channeldb.HTLC{outgoing}) {
+		t.Fatalf("shouldn't go on-chain for outgoing htlc yet")
+	}
+	if !chanArb.checkChainActions(140, []channeldb.HTLC{outgoing}) {
+		t.Fatalf("should go on-chain to time out outgoing htlc")
+	}
+
+	// Dust HTLC's should never trigger an on-chain action.
+	if chanArb.checkChainActions(200, []channeldb.HTLC{dustOutgoing}) {
+		t.Fatalf("shouldn't go on-chain for dust htlc")
+	}
+}
+
+// TestChannelArbitratorBlockTick tests that the channel arbitrator force
+// closes the channel once an HTLC is about to expire, and that the channel
+// is marked as fully resolved once there are no contracts left to resolve.
+func TestChannelArbitratorBlockTick(t *testing.T) {
+	t.Parallel()
+
+	arbLog := newMockArbitratorLog()
+	chanArb, forceCloses := createTestChannelArbitrator(arbLog)
+
+	resolvedChans := make(chan wire.OutPoint, 1)
+	chanArb.onFullyResolved = func(op wire.OutPoint) {
+		resolvedChans <- op
+	}
+
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start arbitrator: %v", err)
+	}
+	defer chanArb.Stop()
+
+	htlcs := []channeldb.HTLC{
+		{
+			RefundTimeout: 150,
+			OutputIndex:   2,
+		},
+	}
+
+	// Well before the expiry of the HTLC, the arbitrator should remain in
+	// the default state.
+	chanArb.blockTick(120, htlcs)
+	if chanArb.CurrentState() != StateDefault {
+		t.Fatalf("expected state %v, instead got %v", StateDefault,
+			chanArb.CurrentState())
+	}
+	select {
+	case <-forceCloses:
+		t.Fatalf("channel shouldn't have been force closed")
+	default:
+	}
+
+	// Once we're close to the expiry of the HTLC, the arbitrator should
+	// force close the channel.
+	chanArb.blockTick(145, htlcs)
+	select {
+	case op := <-forceCloses:
+		if op != testChanPoint1 {
+			t.Fatalf("wrong channel force closed: expected %v, "+
+				"got %v", testChanPoint1, op)
+		}
+	default:
+		t.Fatalf("channel wasn't force closed")
+	}
+
+	// As the commitment transaction didn't contain any HTLC resolutions,
+	// the arbitrator should now be fully resolved, and its log wiped.
+	if chanArb.CurrentState() != StateFullyResolved {
+		t.Fatalf("expected state %v, instead got %v",
+			StateFullyResolved, chanArb.CurrentState())
+	}
+	if arbLog.state != StateDefault {
+		t.Fatalf("log wasn't wiped, state=%v", arbLog.state)
+	}
+	select {
+	case op := <-resolvedChans:
+		if op != testChanPoint1 {
+			t.Fatalf("wrong channel resolved: expected %v, got %v",
+				testChanPoint1, op)
+		}
+	default:
+		t.Fatalf("channel wasn't marked as fully resolved")
+	}
+
+	// Any further attempts to force close the channel should fail.
+	if _, err := chanArb.ForceClose(); err == nil {
+		t.Fatalf("expected force close of resolved channel to fail")
+	}
+}
+
+// TestChannelArbitratorBroadcastRetry tests that if the arbitrator fails to
+// persist its state after deciding to go on-chain, it'll attempt to force
+// close the channel once again when restarted.
+func TestChannelArbitratorBroadcastRetry(t *testing.T) {
+	t.Parallel()
+
+	arbLog := newMockArbitratorLog()
+	arbLog.failCommit = fmt.Errorf("intentional commit failure")
+	arbLog.failCommitState = StateCommitmentBroadcasted
+
+	chanArb, forceCloses := createTestChannelArbitrator(arbLog)
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start arbitrator: %v", err)
+	}
+
+	// The force close should fail, leaving the arbitrator in the
+	// StateBroadcastCommit state.
+	if _, err := chanArb.ForceClose(); err == nil {
+		t.Fatalf("expected force close to fail")
+	}
+	<-forceCloses
+	if arbLog.state != StateBroadcastCommit {
+		t.Fatalf("expected state %v, instead got %v",
+			StateBroadcastCommit, arbLog.state)
+	}
+	chanArb.Stop()
+
+	// We'll now restart the arbitrator with the same log. Upon start up,
+	// it should attempt to force close the channel once again, and then
+	// transition to the fully resolved state.
+	arbLog.failCommit = nil
+	chanArb, forceCloses = createTestChannelArbitrator(arbLog)
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start arbitrator: %v", err)
+	}
+	defer chanArb.Stop()
+
+	select {
+	case <-forceCloses:
+	default:
+		t.Fatalf("channel wasn't force closed on restart")
+	}
+	if chanArb.CurrentState() != StateFullyResolved {
+		t.Fatalf("expected state %v, instead got %v",
+			StateFullyResolved, chanArb.CurrentState())
+	}
+}
+
+// TestChannelArbitratorRemoteCloseAfterBroadcast tests that if the remote
+// party's commitment confirms after we've broadcast our own, the resolvers for
+// our commitment are abandoned in favor of those for the remote commitment.
+func TestChannelArbitratorRemoteCloseAfterBroadcast(t *testing.T) {
+	t.Parallel()
+
+	arbLog := newMockArbitratorLog()
+	chanArb, forceCloses := createTestChannelArbitrator(arbLog)
+	chanArb.cfg.Notifier = &mockNotifier{}
+
+	// Our commitment transaction has a single outgoing HTLC, which will
+	// be timed out by a second-level transaction.
+	timeoutTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: 1},
+		}},
+	}
+	chanArb.cfg.ForceCloseChan = func(op wire.OutPoint) (
+		*lnwallet.ForceCloseSummary, error) {
+
+		forceCloses <- op
+		return &lnwallet.ForceCloseSummary{
+			ChanPoint: op,
+			CloseTx:   &wire.MsgTx{},
+			HtlcResolutions: &lnwallet.HtlcResolutions{
+				OutgoingHTLCs: []lnwallet.OutgoingHtlcResolution{{
+					Expiry:          150,
+					SignedTimeoutTx: timeoutTx,
+				}},
+			},
+		}, nil
+	}
+
+	resolvedChans := make(chan wire.OutPoint, 1)
+	chanArb.onFullyResolved = func(op wire.OutPoint) {
+		resolvedChans <- op
+	}
+
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start arbitrator: %v", err)
+	}
+
+	if _, err := chanArb.ForceClose(); err != nil {
+		t.Fatalf("unable to force close: %v", err)
+	}
+	<-forceCloses
+
+	// The resolver for the outgoing HTLC should be persisted, and the
+	// arbitrator should wait for our commitment to confirm.
+	if chanArb.CurrentState() != StateCommitmentBroadcasted {
+		t.Fatalf("expected state %v, instead got %v",
+			StateCommitmentBroadcasted, chanArb.CurrentState())
+	}
+	if len(arbLog.resolvers) != 1 {
+		t.Fatalf("expected 1 resolver, instead got %v",
+			len(arbLog.resolvers))
+	}
+
+	// The remote party's commitment, which doesn't have any HTLC's,
+	// confirms instead. The resolver for our commitment should be
+	// abandoned, leaving the channel fully resolved.
+	err := chanArb.ContractClosed(&lnwallet.HtlcResolutions{}, 101)
+	if err != nil {
+		t.Fatalf("unable to handle remote close: %v", err)
+	}
+	if chanArb.CurrentState() != StateFullyResolved {
+		t.Fatalf("expected state %v, instead got %v",
+			StateFullyResolved, chanArb.CurrentState())
+	}
+	if len(arbLog.resolvers) != 0 {
+		t.Fatalf("abandoned resolver wasn't removed from the log")
+	}
+	select {
+	case <-resolvedChans:
+	default:
+		t.Fatalf("channel wasn't marked as fully resolved")
+	}
+
+	// Stopping the arbitrator waits for all resolvers to exit, so this
+	// ensures that the abandoned resolver has done so.
+	if err := chanArb.Stop(); err != nil {
+		t.Fatalf("unable to stop arbitrator: %v", err)
+	}
+}
+
+// TestChannelArbitratorDustHtlcFailure tests that once our commitment
+// transaction confirms, any outgoing HTLC's that were trimmed as dust from it
+// are failed backwards.
+func TestChannelArbitratorDustHtlcFailure(t *testing.T) {
+	t.Parallel()
+
+	arbLog := newMockArbitratorLog()
+	chanArb, forceCloses := createTestChannelArbitrator(arbLog)
+
+	notifier := &mockConfNotifier{
+		confChan: make(chan *chainntnfs.TxConfirmation, 1),
+	}
+	chanArb.cfg.Notifier = notifier
+
+	resolutions := make(chan ResolutionMsg, 10)
+	chanArb.cfg.DeliverResolutionMsg = func(msg ResolutionMsg) error {
+		resolutions <- msg
+		return nil
+	}
+
+	// Our commitment has a dust outgoing HTLC, which should be failed
+	// back, along with a dust incoming HTLC and an outgoing HTLC that
+	// isn't dust, which shouldn't.
+	chanArb.cfg.FetchChannel = func() (*channeldb.OpenChannel, error) {
+		return &channeldb.OpenChannel{
+			LocalCommitment: channeldb.ChannelCommitment{
+				Htlcs: []channeldb.HTLC{
+					{
+						HtlcIndex:   1,
+						OutputIndex: -1,
+					},
+					{
+						HtlcIndex:   2,
+						OutputIndex: -1,
+						Incoming:    true,
+					},
+					{
+						HtlcIndex:   3,
+						OutputIndex: 2,
+					},
+				},
+			},
+		}, nil
+	}
+
+	resolvedChans := make(chan wire.OutPoint, 1)
+	chanArb.onFullyResolved = func(op wire.OutPoint) {
+		resolvedChans <- op
+	}
+
+	if err := chanArb.Start(); err != nil {
+		t.Fatalf("unable to start arbitrator: %v", err)
+	}
+	defer chanArb.Stop()
+
+	if _, err := chanArb.ForceClose(); err != nil {
+		t.Fatalf("unable to force close: %v", err)
+	}
+	<-forceCloses
+
+	// A resolver should only have been created for the dust outgoing
+	// HTLC, and it shouldn't fail the HTLC back before our commitment
+	// confirms.
+	if len(arbLog.resolvers) != 1 {
+		t.Fatalf("expected 1 resolver, instead got %v",
+			len(arbLog.resolvers))
+	}
+	select {
+	case msg := <-resolutions:
+		t.Fatalf("htlc %v failed before commitment confirmed",
+			msg.HtlcIndex)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once our commitment confirms, the HTLC should be failed back, and
+	// the channel should be fully resolved.
+	notifier.confChan <- &chainntnfs.TxConfirmation{}
+
+	select {
+	case msg := <-resolutions:
+		if msg.HtlcIndex != 1 {
+			t.Fatalf("expected htlc 1 to be failed, instead "+
+				"got %v", msg.HtlcIndex)
+		}
+		if msg.Failure == nil {
+			t.Fatalf("expected htlc to be failed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("dust htlc wasn't failed back")
+	}
+
+	select {
+	case <-resolvedChans:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("channel wasn't marked as fully resolved")
+	}
+}
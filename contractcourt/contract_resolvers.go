@@ -0,0 +1,335 @@
+package contractcourt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// errResolverShuttingDown is returned when the resolver stops
+	// progressing because the channel arbitrator is shutting down.
+	errResolverShuttingDown = fmt.Errorf("resolver shutting down")
+)
+
+// ContractResolver is an interface which packages a state machine which is
+// able to carry out the necessary steps required to fully resolve a Bitcoin
+// contract on-chain. Resolvers are fully encodable to ensure callers are able
+// to persist them properly. As claiming an HTLC may be a multi-stage process,
+// resolvers check point their state after each stage, allowing them to resume
+// from where they left off after a restart.
+type ContractResolver interface {
+	// ResolverKey returns an identifier which should be globally unique
+	// for this particular resolver within the chain the original contract
+	// resides within.
+	ResolverKey() []byte
+
+	// Resolve instructs the contract resolver to resolve the output
+	// on-chain. This method blocks until the contract has been fully
+	// resolved, or the resolver is signalled to exit.
+	Resolve() error
+
+	// IsResolved returns true if the stored state in the resolver is
+	// fully resolved. In this case the target output can be forgotten.
+	IsResolved() bool
+
+	// Encode writes an encoded version of the ContractResolver into the
+	// passed Writer.
+	Encode(w io.Writer) error
+
+	// Decode attempts to decode an encoded ContractResolver from the
+	// passed Reader instance, returning an active ContractResolver
+	// instance.
+	Decode(r io.Reader) error
+
+	// AttachResolverKit should be called once a resolver is successfully
+	// decoded from its stored format. This struct delivers a generic tool
+	// kit that resolvers need to complete their duty.
+	AttachResolverKit(ResolverKit)
+}
+
+// ResolverKit is meant to be used as a mix-in struct to be embedded within a
+// given ContractResolver implementation. It contains all the items that a
+// resolver requires to carry out its duties.
+type ResolverKit struct {
+	// ChannelArbitratorConfig contains all the interfaces and closures
+	// required for the resolver to interact with outside sub-systems.
+	ChannelArbitratorConfig
+
+	// Checkpoint allows a resolver to check point its state. This will
+	// be called each time the resolver makes progress that it would like
+	// to survive a restart.
+	Checkpoint func(ContractResolver) error
+
+	// Quit is closed once the channel arbitrator is shutting down,
+	// signalling all active resolvers to exit.
+	Quit chan struct{}
+}
+
+// currentHeight returns the height of the current best block.
+func (r ResolverKit) currentHeight() (uint32, error) {
+	_, height, err := r.ChainIO.GetBestBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(height), nil
+}
+
+// extractPreimage scans the witness of the passed input for the preimage of
+// the target payment hash. If found, the preimage is returned along with a
+// true value.
+func extractPreimage(txIn *wire.TxIn, payHash [32]byte) ([32]byte, bool) {
+	var preimage [32]byte
+	for _, item := range txIn.Witness {
+		if len(item) != 32 {
+			continue
+		}
+
+		if sha256.Sum256(item) != payHash {
+			continue
+		}
+
+		copy(preimage[:], item)
+		return preimage, true
+	}
+
+	return preimage, false
+}
+
+// sweepHtlcOutput crafts a transaction that sweeps a single HTLC output,
+// described by the passed sign descriptor, back into the wallet. The witness
+// of the sweeping input is populated using the passed witness function, and
+// the fee is derived from the passed witness size.
+func (r ResolverKit) sweepHtlcOutput(op wire.OutPoint,
+	signDesc *lnwallet.SignDescriptor, witnessSize int, sequence uint32,
+	lockTime uint32, genWitness func(*wire.MsgTx) (wire.TxWitness, error),
+) (*wire.MsgTx, error) {
+
+	// First, we obtain a new public key script from the wallet which we'll
+	// sweep the funds to.
+	pkScript, err := r.GenSweepScript()
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the transaction weight of the sweep transaction, which
+	// includes a single input and output.
+	var weightEstimate lnwallet.TxWeightEstimator
+	weightEstimate.AddP2WKHOutput()
+	weightEstimate.AddWitnessInput(witnessSize)
+
+	// We'll attempt to target inclusion within the next six blocks, as
+	// there's no longer any time pressure once we're able to sweep.
+	feePerWeight, err := r.FeeEstimator.EstimateFeePerWeight(6)
+	if err != nil {
+		return nil, err
+	}
+	txFee := btcutil.Amount(
+		uint64(weightEstimate.Weight()) * uint64(feePerWeight),
+	)
+
+	sweepAmt := signDesc.Output.Value - int64(txFee)
+	if sweepAmt <= 0 {
+		return nil, fmt.Errorf("htlc output %v too small to sweep: "+
+			"value=%v, fee=%v", op, signDesc.Output.Value, txFee)
+	}
+
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.LockTime = lockTime
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: op,
+		Sequence:         sequence,
+	})
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: pkScript,
+		Value:    sweepAmt,
+	})
+
+	witness, err := genWitness(sweepTx)
+	if err != nil {
+		return nil, err
+	}
+	sweepTx.TxIn[0].Witness = witness
+
+	return sweepTx, nil
+}
+
+// encodeOutgoingResolution writes the passed outgoing HTLC resolution to the
+// target writer.
+func encodeOutgoingResolution(w io.Writer,
+	o *lnwallet.OutgoingHtlcResolution) error {
+
+	if err := binary.Write(w, byteOrder, o.Expiry); err != nil {
+		return err
+	}
+	if _, err := w.Write(o.PayHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, o.HtlcIndex); err != nil {
+		return err
+	}
+	if err := encodeOptionalTx(w, o.SignedTimeoutTx); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, o.CsvDelay); err != nil {
+		return err
+	}
+	if err := writeOutpoint(w, &o.ClaimOutpoint); err != nil {
+		return err
+	}
+
+	return lnwallet.WriteSignDescriptor(w, &o.SweepSignDesc)
+}
+
+// decodeOutgoingResolution reads an outgoing HTLC resolution written by
+// encodeOutgoingResolution from the passed reader.
+func decodeOutgoingResolution(r io.Reader,
+	o *lnwallet.OutgoingHtlcResolution) error {
+
+	if err := binary.Read(r, byteOrder, &o.Expiry); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, o.PayHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &o.HtlcIndex); err != nil {
+		return err
+	}
+
+	var err error
+	o.SignedTimeoutTx, err = decodeOptionalTx(r)
+	if err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &o.CsvDelay); err != nil {
+		return err
+	}
+	if err := readOutpoint(r, &o.ClaimOutpoint); err != nil {
+		return err
+	}
+
+	return lnwallet.ReadSignDescriptor(r, &o.SweepSignDesc)
+}
+
+// encodeIncomingResolution writes the passed incoming HTLC resolution to the
+// target writer.
+func encodeIncomingResolution(w io.Writer,
+	i *lnwallet.IncomingHtlcResolution) error {
+
+	if _, err := w.Write(i.PayHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, i.Expiry); err != nil {
+		return err
+	}
+	if err := encodeOptionalTx(w, i.SignedSuccessTx); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, i.CsvDelay); err != nil {
+		return err
+	}
+	if err := writeOutpoint(w, &i.ClaimOutpoint); err != nil {
+		return err
+	}
+
+	return lnwallet.WriteSignDescriptor(w, &i.SweepSignDesc)
+}
+
+// decodeIncomingResolution reads an incoming HTLC resolution written by
+// encodeIncomingResolution from the passed reader.
+func decodeIncomingResolution(r io.Reader,
+	i *lnwallet.IncomingHtlcResolution) error {
+
+	if _, err := io.ReadFull(r, i.PayHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &i.Expiry); err != nil {
+		return err
+	}
+
+	var err error
+	i.SignedSuccessTx, err = decodeOptionalTx(r)
+	if err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &i.CsvDelay); err != nil {
+		return err
+	}
+	if err := readOutpoint(r, &i.ClaimOutpoint); err != nil {
+		return err
+	}
+
+	return lnwallet.ReadSignDescriptor(r, &i.SweepSignDesc)
+}
+
+// encodeOptionalTx writes the passed transaction, which may be nil, to the
+// target writer. A single byte prefix denotes if the transaction is present.
+func encodeOptionalTx(w io.Writer, tx *wire.MsgTx) error {
+	if tx == nil {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+
+	if _, err := w.Write([]byte{1}); err != nil {
+		return err
+	}
+
+	return tx.Serialize(w)
+}
+
+// decodeOptionalTx reads a transaction written by encodeOptionalTx from the
+// passed reader.
+func decodeOptionalTx(r io.Reader) (*wire.MsgTx, error) {
+	var present [1]byte
+	if _, err := io.ReadFull(r, present[:]); err != nil {
+		return nil, err
+	}
+	if present[0] == 0 {
+		return nil, nil
+	}
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(r); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// encodeBools writes the passed set of booleans to the target writer, each
+// as a single byte.
+func encodeBools(w io.Writer, bools ...bool) error {
+	var b bytes.Buffer
+	for _, v := range bools {
+		if v {
+			b.WriteByte(1)
+		} else {
+			b.WriteByte(0)
+		}
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// decodeBools reads a set of booleans written by encodeBools from the passed
+// reader.
+func decodeBools(r io.Reader, bools ...*bool) error {
+	scratch := make([]byte, len(bools))
+	if _, err := io.ReadFull(r, scratch); err != nil {
+		return err
+	}
+
+	for i, v := range scratch {
+		*bools[i] = v == 1
+	}
+
+	return nil
+}
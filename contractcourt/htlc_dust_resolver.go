@@ -0,0 +1,168 @@
+package contractcourt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// htlcDustResolver is a ContractResolver that's capable of resolving an
+// outgoing HTLC that was trimmed as dust from the commitment transaction. As
+// the HTLC doesn't have an output on the commitment transaction, it can never
+// be claimed by the remote party once the commitment transaction confirms, so
+// we'll fail it backwards at that point.
+type htlcDustResolver struct {
+	// commitHash is the txid of the commitment transaction the HTLC was
+	// trimmed from.
+	commitHash chainhash.Hash
+
+	// htlcIndex is the index of the HTLC within the commitment trace of
+	// the channel.
+	htlcIndex uint64
+
+	// resolved reflects if the contract has been fully resolved or not.
+	resolved bool
+
+	// broadcastHeight is the height that the original contract was
+	// broadcast to the main-chain at. We'll use this value to bound any
+	// historical queries to the chain for confirmations.
+	broadcastHeight uint32
+
+	// shortChanID is the short channel ID of the channel the HTLC was
+	// offered over. It's used to identify the HTLC to the switch once
+	// it's resolved.
+	shortChanID lnwire.ShortChannelID
+
+	ResolverKit
+}
+
+// ResolverKey returns an identifier which should be globally unique for this
+// particular resolver within the chain the original contract resides within.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) ResolverKey() []byte {
+	var b bytes.Buffer
+	b.Write(h.commitHash[:])
+	binary.Write(&b, byteOrder, h.htlcIndex)
+
+	return b.Bytes()
+}
+
+// Resolve waits for the commitment transaction the HTLC was trimmed from to
+// confirm, and then fails the HTLC backwards.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) Resolve() error {
+	// If we're already resolved, then we can exit early.
+	if h.resolved {
+		return nil
+	}
+
+	confNtfn, err := h.Notifier.RegisterConfirmationsNtfn(
+		&h.commitHash, 1, h.broadcastHeight,
+	)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case _, ok := <-confNtfn.Confirmed:
+		if !ok {
+			return errResolverShuttingDown
+		}
+
+	case <-h.Quit:
+		return errResolverShuttingDown
+	}
+
+	log.Infof("%T(%v): commitment confirmed, failing dust htlc with "+
+		"index=%v", h, h.commitHash, h.htlcIndex)
+
+	err = h.DeliverResolutionMsg(ResolutionMsg{
+		SourceChan: h.shortChanID,
+		HtlcIndex:  h.htlcIndex,
+		Failure:    &lnwire.FailPermanentChannelFailure{},
+	})
+	if err != nil {
+		return err
+	}
+
+	h.resolved = true
+	return h.Checkpoint(h)
+}
+
+// IsResolved returns true if the stored state in the resolver is fully
+// resolved. In this case the target output can be forgotten.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) IsResolved() bool {
+	return h.resolved
+}
+
+// Encode writes an encoded version of the ContractResolver into the passed
+// Writer.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) Encode(w io.Writer) error {
+	if _, err := w.Write(h.commitHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, h.htlcIndex); err != nil {
+		return err
+	}
+
+	if err := encodeBools(w, h.resolved); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, h.broadcastHeight); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, h.shortChanID.ToUint64())
+}
+
+// Decode attempts to decode an encoded ContractResolver from the passed Reader
+// instance, returning an active ContractResolver instance.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) Decode(r io.Reader) error {
+	if _, err := io.ReadFull(r, h.commitHash[:]); err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &h.htlcIndex); err != nil {
+		return err
+	}
+
+	if err := decodeBools(r, &h.resolved); err != nil {
+		return err
+	}
+
+	if err := binary.Read(r, byteOrder, &h.broadcastHeight); err != nil {
+		return err
+	}
+
+	var shortChanID uint64
+	if err := binary.Read(r, byteOrder, &shortChanID); err != nil {
+		return err
+	}
+	h.shortChanID = lnwire.NewShortChanIDFromInt(shortChanID)
+
+	return nil
+}
+
+// AttachResolverKit should be called once a resolver is successfully decoded
+// from its stored format. This struct delivers a generic tool kit that
+// resolvers need to complete their duty.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcDustResolver) AttachResolverKit(r ResolverKit) {
+	h.ResolverKit = r
+}
+
+// A compile time assertion to ensure htlcDustResolver meets the
+// ContractResolver interface.
+var _ ContractResolver = (*htlcDustResolver)(nil)
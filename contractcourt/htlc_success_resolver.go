@@ -0,0 +1,346 @@
+package contractcourt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// htlcSuccessResolver is a resolver that's capable of sweeping an incoming
+// HTLC output on-chain. If this is the remote party's commitment, we'll sweep
+// it directly from the commitment output *immediately*. If this is our
+// commitment, we'll first broadcast the success transaction, then wait for
+// the CSV delay to expire before sweeping its output. In both cases, we'll
+// only do so once the preimage of the HTLC is known, either via an invoice we
+// created, or by learning it from an outgoing HTLC with the same payment hash.
+type htlcSuccessResolver struct {
+	// htlcResolution is the incoming HTLC resolution for this HTLC. It
+	// contains everything we need to properly resolve this HTLC.
+	htlcResolution lnwallet.IncomingHtlcResolution
+
+	// outputIncubating is true if we've already broadcast the second-level
+	// success transaction in the case of our commitment, or our direct
+	// sweep of the HTLC output in the case of the remote party's
+	// commitment.
+	outputIncubating bool
+
+	// resolved reflects if the contract has been fully resolved or not.
+	resolved bool
+
+	// broadcastHeight is the height that the original contract was
+	// broadcast to the main-chain at. We'll use this value to bound any
+	// historical queries to the chain for spends/confirmations.
+	broadcastHeight uint32
+
+	ResolverKit
+}
+
+// ResolverKey returns an identifier which should be globally unique for this
+// particular resolver within the chain the original contract resides within.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) ResolverKey() []byte {
+	var b bytes.Buffer
+	op := h.htlcOutpoint()
+	writeOutpoint(&b, &op)
+
+	return b.Bytes()
+}
+
+// htlcOutpoint returns the outpoint of the HTLC output on the commitment
+// transaction.
+func (h *htlcSuccessResolver) htlcOutpoint() wire.OutPoint {
+	// If this HTLC is on our commitment, then the outpoint of the HTLC
+	// output is the sole input of the second-level success transaction.
+	if h.htlcResolution.SignedSuccessTx != nil {
+		return h.htlcResolution.SignedSuccessTx.TxIn[0].PreviousOutPoint
+	}
+
+	return h.htlcResolution.ClaimOutpoint
+}
+
+// Resolve attempts to resolve an unresolved incoming HTLC that we know the
+// preimage to. If the HTLC is on the commitment of the remote party, then
+// we'll simply sweep it directly. Otherwise, we'll hand this off to the
+// second-level success transaction, and sweep its output once the CSV delay
+// has passed.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) Resolve() error {
+	// If we're already resolved, then we can exit early.
+	if h.resolved {
+		return nil
+	}
+
+	// We'll watch for a spend of the HTLC output on the commitment
+	// transaction. If the HTLC expires before we learn of the preimage,
+	// then the remote party will sweep it using the timeout clause.
+	htlcOutpoint := h.htlcOutpoint()
+	spendNtfn, err := h.Notifier.RegisterSpendNtfn(
+		&htlcOutpoint, h.broadcastHeight,
+	)
+	if err != nil {
+		return err
+	}
+	defer spendNtfn.Cancel()
+
+	blockEpochNtfn, err := h.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+	defer blockEpochNtfn.Cancel()
+
+	// Until the HTLC output has been spent, we'll poll for the preimage
+	// with each new block, and claim the HTLC as soon as it's known.
+	if err := h.maybeClaim(); err != nil {
+		return err
+	}
+
+	var spend *chainntnfs.SpendDetail
+	for spend == nil {
+		select {
+		case s, ok := <-spendNtfn.Spend:
+			if !ok {
+				return errResolverShuttingDown
+			}
+			spend = s
+
+		case _, ok := <-blockEpochNtfn.Epochs:
+			if !ok {
+				return errResolverShuttingDown
+			}
+
+			if err := h.maybeClaim(); err != nil {
+				return err
+			}
+
+		case <-h.Quit:
+			return errResolverShuttingDown
+		}
+	}
+
+	// If the HTLC output wasn't spent by us, then the remote party has
+	// timed out the HTLC, so there's nothing left for us to do.
+	spenderInput := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
+	if _, ok := extractPreimage(spenderInput, h.htlcResolution.PayHash); !ok {
+		log.Warnf("%T(%v): htlc was timed out by the remote party", h,
+			htlcOutpoint)
+
+		h.resolved = true
+		return h.Checkpoint(h)
+	}
+
+	// If this is the remote party's commitment, then our sweep has now
+	// confirmed, and the HTLC has been fully resolved.
+	if h.htlcResolution.SignedSuccessTx == nil {
+		log.Infof("%T(%v): htlc has been swept", h, htlcOutpoint)
+
+		h.resolved = true
+		return h.Checkpoint(h)
+	}
+
+	// Otherwise, our success transaction has confirmed, so we'll need to
+	// wait for the CSV delay on its output to expire before we can sweep
+	// it back into the wallet.
+	return h.sweepSecondLevel(uint32(spend.SpendingHeight))
+}
+
+// maybeClaim attempts to claim the HTLC if we've yet to do so, and we know
+// the preimage.
+func (h *htlcSuccessResolver) maybeClaim() error {
+	if h.outputIncubating {
+		return nil
+	}
+
+	preimage, ok := h.PreimageDB.LookupPreimage(h.htlcResolution.PayHash)
+	if !ok {
+		return nil
+	}
+
+	var claimTx *wire.MsgTx
+	switch {
+	// If this is our commitment, then we'll populate the preimage within
+	// the witness of the fully signed success transaction.
+	case h.htlcResolution.SignedSuccessTx != nil:
+		claimTx = h.htlcResolution.SignedSuccessTx
+		claimTx.TxIn[0].Witness[3] = preimage
+
+	// Otherwise, we'll sweep the HTLC output directly from the remote
+	// party's commitment transaction.
+	default:
+		signDesc := h.htlcResolution.SweepSignDesc
+		sweepTx, err := h.sweepHtlcOutput(
+			h.htlcResolution.ClaimOutpoint, &signDesc,
+			lnwallet.OfferedHtlcSuccessWitnessSize,
+			wire.MaxTxInSequenceNum, 0,
+			func(tx *wire.MsgTx) (wire.TxWitness, error) {
+				signDesc.SigHashes = txscript.NewTxSigHashes(tx)
+				signDesc.InputIndex = 0
+				return lnwallet.SenderHtlcSpendRedeem(
+					h.Signer, &signDesc, tx, preimage,
+				)
+			},
+		)
+		if err != nil {
+			return err
+		}
+		claimTx = sweepTx
+	}
+
+	log.Infof("%T(%v): claiming htlc with preimage=%x", h,
+		h.htlcOutpoint(), preimage)
+
+	if err := h.PublishTx(claimTx); err != nil {
+		log.Errorf("%T(%v): unable to broadcast claim tx: %v", h,
+			h.htlcOutpoint(), err)
+		return nil
+	}
+
+	h.outputIncubating = true
+	return h.Checkpoint(h)
+}
+
+// sweepSecondLevel waits for the CSV delay of the second-level success
+// transaction confirmed at the passed height to expire, then sweeps its
+// output back into the wallet.
+func (h *htlcSuccessResolver) sweepSecondLevel(confHeight uint32) error {
+	claimOutpoint := h.htlcResolution.ClaimOutpoint
+	spendNtfn, err := h.Notifier.RegisterSpendNtfn(
+		&claimOutpoint, confHeight,
+	)
+	if err != nil {
+		return err
+	}
+	defer spendNtfn.Cancel()
+
+	blockEpochNtfn, err := h.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+	defer blockEpochNtfn.Cancel()
+
+	// The output can be spent within the block that's CsvDelay blocks
+	// after the one that confirmed the success transaction.
+	maturityHeight := confHeight + h.htlcResolution.CsvDelay - 1
+
+	height, err := h.currentHeight()
+	if err != nil {
+		return err
+	}
+
+	swept := false
+	for {
+		if !swept && height >= maturityHeight {
+			swept = h.sweepMatureOutput()
+		}
+
+		select {
+		case _, ok := <-spendNtfn.Spend:
+			if !ok {
+				return errResolverShuttingDown
+			}
+
+			log.Infof("%T(%v): second-level htlc output has been "+
+				"swept", h, claimOutpoint)
+
+			h.resolved = true
+			return h.Checkpoint(h)
+
+		case newBlock, ok := <-blockEpochNtfn.Epochs:
+			if !ok {
+				return errResolverShuttingDown
+			}
+			height = uint32(newBlock.Height)
+
+		case <-h.Quit:
+			return errResolverShuttingDown
+		}
+	}
+}
+
+// sweepMatureOutput broadcasts a transaction sweeping the matured output of
+// the second-level success transaction. It returns true if the sweep was
+// broadcast successfully.
+func (h *htlcSuccessResolver) sweepMatureOutput() bool {
+	signDesc := h.htlcResolution.SweepSignDesc
+	sweepTx, err := h.sweepHtlcOutput(
+		h.htlcResolution.ClaimOutpoint, &signDesc,
+		lnwallet.ToLocalTimeoutWitnessSize, h.htlcResolution.CsvDelay,
+		0, func(tx *wire.MsgTx) (wire.TxWitness, error) {
+			signDesc.SigHashes = txscript.NewTxSigHashes(tx)
+			signDesc.InputIndex = 0
+			return lnwallet.HtlcSpendSuccess(h.Signer, &signDesc, tx)
+		},
+	)
+	if err != nil {
+		log.Errorf("%T(%v): unable to create sweep tx: %v", h,
+			h.htlcResolution.ClaimOutpoint, err)
+		return false
+	}
+
+	if err := h.PublishTx(sweepTx); err != nil {
+		log.Errorf("%T(%v): unable to broadcast sweep tx: %v", h,
+			h.htlcResolution.ClaimOutpoint, err)
+		return false
+	}
+
+	return true
+}
+
+// IsResolved returns true if the stored state in the resolver is fully
+// resolved. In this case the target output can be forgotten.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) IsResolved() bool {
+	return h.resolved
+}
+
+// Encode writes an encoded version of the ContractResolver into the passed
+// Writer.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) Encode(w io.Writer) error {
+	if err := encodeIncomingResolution(w, &h.htlcResolution); err != nil {
+		return err
+	}
+
+	if err := encodeBools(w, h.outputIncubating, h.resolved); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, h.broadcastHeight)
+}
+
+// Decode attempts to decode an encoded ContractResolver from the passed Reader
+// instance, returning an active ContractResolver instance.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) Decode(r io.Reader) error {
+	if err := decodeIncomingResolution(r, &h.htlcResolution); err != nil {
+		return err
+	}
+
+	if err := decodeBools(r, &h.outputIncubating, &h.resolved); err != nil {
+		return err
+	}
+
+	return binary.Read(r, byteOrder, &h.broadcastHeight)
+}
+
+// AttachResolverKit should be called once a resolver is successfully decoded
+// from its stored format. This struct delivers a generic tool kit that
+// resolvers need to complete their duty.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcSuccessResolver) AttachResolverKit(r ResolverKit) {
+	h.ResolverKit = r
+}
+
+// A compile time assertion to ensure htlcSuccessResolver meets the
+// ContractResolver interface.
+var _ ContractResolver = (*htlcSuccessResolver)(nil)
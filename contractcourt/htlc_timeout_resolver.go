@@ -0,0 +1,299 @@
+package contractcourt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// htlcTimeoutResolver is a ContractResolver that's capable of resolving an
+// outgoing HTLC. The HTLC may be on our commitment transaction, or on the
+// commitment transaction of the remote party. An output on our commitment
+// transaction is considered fully resolved once we detect the spend of the
+// HTLC output by our second-level timeout transaction, as the utxo nursery
+// takes care of sweeping its output. An output on the commitment transaction
+// of the remote party is resolved once we detect a spend of the direct HTLC
+// output using the timeout clause.
+//
+// If the remote party instead claims the HTLC with the preimage, then the
+// preimage is extracted from the spending transaction and passed back to the
+// switch, so the corresponding incoming HTLC can be settled.
+type htlcTimeoutResolver struct {
+	// htlcResolution contains all the information required to properly
+	// resolve this outgoing HTLC.
+	htlcResolution lnwallet.OutgoingHtlcResolution
+
+	// outputIncubating is true if we've broadcast our sweep of the HTLC
+	// output on the remote party's commitment transaction. HTLC's on our
+	// own commitment transaction are incubated by the utxo nursery.
+	outputIncubating bool
+
+	// resolved reflects if the contract has been fully resolved or not.
+	resolved bool
+
+	// broadcastHeight is the height that the original contract was
+	// broadcast to the main-chain at. We'll use this value to bound any
+	// historical queries to the chain for spends/confirmations.
+	broadcastHeight uint32
+
+	// shortChanID is the short channel ID of the channel the HTLC was
+	// offered over. It's used to identify the HTLC to the switch once
+	// it's resolved.
+	shortChanID lnwire.ShortChannelID
+
+	ResolverKit
+}
+
+// ResolverKey returns an identifier which should be globally unique for this
+// particular resolver within the chain the original contract resides within.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) ResolverKey() []byte {
+	var b bytes.Buffer
+	op := h.htlcOutpoint()
+	writeOutpoint(&b, &op)
+
+	return b.Bytes()
+}
+
+// htlcOutpoint returns the outpoint of the HTLC output on the commitment
+// transaction.
+func (h *htlcTimeoutResolver) htlcOutpoint() wire.OutPoint {
+	// If this HTLC is on our commitment, then the outpoint of the HTLC
+	// output is the sole input of the second-level timeout transaction.
+	if h.htlcResolution.SignedTimeoutTx != nil {
+		return h.htlcResolution.SignedTimeoutTx.TxIn[0].PreviousOutPoint
+	}
+
+	return h.htlcResolution.ClaimOutpoint
+}
+
+// Resolve kicks off full resolution of an outgoing HTLC output. If it's our
+// commitment, it isn't resolved until we see the second level HTLC txn
+// confirmed. If it's the remote party's commitment, we'll sweep the HTLC
+// output ourselves once it has expired.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) Resolve() error {
+	// If we're already resolved, then we can exit early.
+	if h.resolved {
+		return nil
+	}
+
+	// We'll watch for a spend of the HTLC output on the commitment
+	// transaction. This will either be our own timeout transaction, or
+	// the remote party claiming the HTLC with the preimage.
+	htlcOutpoint := h.htlcOutpoint()
+	spendNtfn, err := h.Notifier.RegisterSpendNtfn(
+		&htlcOutpoint, h.broadcastHeight,
+	)
+	if err != nil {
+		return err
+	}
+	defer spendNtfn.Cancel()
+
+	// If the HTLC is located on our commitment transaction, then the
+	// utxo nursery will broadcast the timeout transaction once the HTLC
+	// has expired, so we only need to wait for the spend. Otherwise,
+	// we're responsible for sweeping the HTLC output once it expires.
+	var blockEpochs <-chan *chainntnfs.BlockEpoch
+	if h.htlcResolution.SignedTimeoutTx == nil && !h.outputIncubating {
+		blockEpochNtfn, err := h.Notifier.RegisterBlockEpochNtfn()
+		if err != nil {
+			return err
+		}
+		defer blockEpochNtfn.Cancel()
+
+		blockEpochs = blockEpochNtfn.Epochs
+
+		// We'll check the current height first, as the HTLC may
+		// already have expired.
+		height, err := h.currentHeight()
+		if err != nil {
+			return err
+		}
+		if err := h.maybeSweep(height); err != nil {
+			return err
+		}
+	}
+
+	var spend *chainntnfs.SpendDetail
+	for spend == nil {
+		select {
+		case s, ok := <-spendNtfn.Spend:
+			if !ok {
+				return errResolverShuttingDown
+			}
+			spend = s
+
+		case newBlock, ok := <-blockEpochs:
+			if !ok {
+				return errResolverShuttingDown
+			}
+
+			if err := h.maybeSweep(uint32(newBlock.Height)); err != nil {
+				return err
+			}
+
+			// Once we've broadcast our sweep, there's no need
+			// for further block notifications.
+			if h.outputIncubating {
+				blockEpochs = nil
+			}
+
+		case <-h.Quit:
+			return errResolverShuttingDown
+		}
+	}
+
+	// Now that the HTLC output has been spent, we'll determine how it was
+	// spent. If the remote party swept the HTLC with the preimage, then
+	// we'll extract it and pass it back to the switch so the incoming
+	// HTLC can be settled. Otherwise, the HTLC has been timed out, so
+	// we'll fail it backwards.
+	spenderInput := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
+	preimage, ok := extractPreimage(spenderInput, h.htlcResolution.PayHash)
+	resolution := ResolutionMsg{
+		SourceChan: h.shortChanID,
+		HtlcIndex:  h.htlcResolution.HtlcIndex,
+	}
+	if ok {
+		log.Infof("%T(%v): remote party claimed htlc with "+
+			"preimage=%x", h, htlcOutpoint, preimage[:])
+
+		// As we've learned of a new preimage, we'll add it to our
+		// cache so any incoming HTLC with the same payment hash can
+		// be claimed on-chain if needed.
+		if err := h.PreimageDB.AddPreimage(preimage[:]); err != nil {
+			return err
+		}
+
+		resolution.PreImage = &preimage
+	} else {
+		log.Infof("%T(%v): htlc has been timed out on-chain", h,
+			htlcOutpoint)
+
+		resolution.Failure = &lnwire.FailPermanentChannelFailure{}
+	}
+
+	if err := h.DeliverResolutionMsg(resolution); err != nil {
+		return err
+	}
+
+	h.resolved = true
+	return h.Checkpoint(h)
+}
+
+// maybeSweep broadcasts a transaction sweeping the HTLC output on the remote
+// party's commitment transaction, if it has expired at the passed height.
+func (h *htlcTimeoutResolver) maybeSweep(height uint32) error {
+	if h.outputIncubating || height < h.htlcResolution.Expiry {
+		return nil
+	}
+
+	log.Infof("%T(%v): htlc has expired at height=%v, sweeping", h,
+		h.htlcResolution.ClaimOutpoint, height)
+
+	// The sweeping transaction must be locked to the expiry of the HTLC
+	// in order to satisfy the absolute timeout within the script. The
+	// sequence of the input mustn't be final for the lock time to apply.
+	signDesc := h.htlcResolution.SweepSignDesc
+	sweepTx, err := h.sweepHtlcOutput(
+		h.htlcResolution.ClaimOutpoint, &signDesc,
+		lnwallet.AcceptedHtlcTimeoutWitnessSize, 0,
+		h.htlcResolution.Expiry,
+		func(tx *wire.MsgTx) (wire.TxWitness, error) {
+			signDesc.SigHashes = txscript.NewTxSigHashes(tx)
+			signDesc.InputIndex = 0
+			return lnwallet.ReceiverHtlcSpendTimeout(
+				h.Signer, &signDesc, tx,
+				h.htlcResolution.Expiry,
+			)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := h.PublishTx(sweepTx); err != nil {
+		log.Errorf("%T(%v): unable to broadcast sweep tx: %v", h,
+			h.htlcResolution.ClaimOutpoint, err)
+		return nil
+	}
+
+	h.outputIncubating = true
+	return h.Checkpoint(h)
+}
+
+// IsResolved returns true if the stored state in the resolver is fully
+// resolved. In this case the target output can be forgotten.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) IsResolved() bool {
+	return h.resolved
+}
+
+// Encode writes an encoded version of the ContractResolver into the passed
+// Writer.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) Encode(w io.Writer) error {
+	if err := encodeOutgoingResolution(w, &h.htlcResolution); err != nil {
+		return err
+	}
+
+	if err := encodeBools(w, h.outputIncubating, h.resolved); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, h.broadcastHeight); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, h.shortChanID.ToUint64())
+}
+
+// Decode attempts to decode an encoded ContractResolver from the passed Reader
+// instance, returning an active ContractResolver instance.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) Decode(r io.Reader) error {
+	if err := decodeOutgoingResolution(r, &h.htlcResolution); err != nil {
+		return err
+	}
+
+	if err := decodeBools(r, &h.outputIncubating, &h.resolved); err != nil {
+		return err
+	}
+
+	if err := binary.Read(r, byteOrder, &h.broadcastHeight); err != nil {
+		return err
+	}
+
+	var shortChanID uint64
+	if err := binary.Read(r, byteOrder, &shortChanID); err != nil {
+		return err
+	}
+	h.shortChanID = lnwire.NewShortChanIDFromInt(shortChanID)
+
+	return nil
+}
+
+// AttachResolverKit should be called once a resolver is successfully decoded
+// from its stored format. This struct delivers a generic tool kit that
+// resolvers need to complete their duty.
+//
+// NOTE: Part of the ContractResolver interface.
+func (h *htlcTimeoutResolver) AttachResolverKit(r ResolverKit) {
+	h.ResolverKit = r
+}
+
+// A compile time assertion to ensure htlcTimeoutResolver meets the
+// ContractResolver interface.
+var _ ContractResolver = (*htlcTimeoutResolver)(nil)
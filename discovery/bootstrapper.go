@@ -12,6 +12,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/miekg/dns"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcutil/bech32"
@@ -247,11 +248,15 @@ type DNSSeedBootstrapper struct {
 	// receive the IP address of the current authoritative DNS server for
 	// the network seed.
 	dnsSeeds [][2]string
+
+	// net is the network that all DNS queries and connections to the DNS
+	// seeds are made over. This allows them to be routed through Tor.
+	net tor.Net
 }
 
 // A compile time assertion to ensure that DNSSeedBootstrapper meets the
 // NetworkPeerjBootstrapper interface.
-var _ NetworkPeerBootstrapper = (*ChannelGraphBootstrapper)(nil)
+var _ NetworkPeerBootstrapper = (*DNSSeedBootstrapper)(nil)
 
 // NewDNSSeedBootstrapper returns a new instance of the DNSSeedBootstrapper.
 // The set of passed seeds should point to DNS servers that properly implement
@@ -259,13 +264,14 @@ var _ NetworkPeerBootstrapper = (*ChannelGraphBootstrapper)(nil)
 // of passed DNS seeds should come in pairs, with the second host name to be
 // used as a fallback for manual TCP resolution in the case of an error
 // receiving the UDP response. The second host should return a single A record
-// with the IP address of the authoritative name server.
-//
-// TODO(roasbeef): add a lookUpFunc param to pass in, so can divert queries
-// over Tor in future
-func NewDNSSeedBootstrapper(seeds [][2]string) (NetworkPeerBootstrapper, error) {
+// with the IP address of the authoritative name server. All lookups are
+// performed over the passed network.
+func NewDNSSeedBootstrapper(seeds [][2]string,
+	net tor.Net) (NetworkPeerBootstrapper, error) {
+
 	return &DNSSeedBootstrapper{
 		dnsSeeds: seeds,
+		net:      net,
 	}, nil
 }
 
@@ -276,12 +282,14 @@ func NewDNSSeedBootstrapper(seeds [][2]string) (NetworkPeerBootstrapper, error)
 // connect manually over TCP to request the SRV record. This is necessary as
 // the records we return are currently too large for a class of resolvers,
 // causing them to be filtered out.
-func fallBackSRVLookup(soaShim string) ([]*net.SRV, error) {
+func (d *DNSSeedBootstrapper) fallBackSRVLookup(soaShim string) ([]*net.SRV,
+	error) {
+
 	log.Tracef("Attempting to query fallback DNS seed")
 
 	// First, we'll lookup the IP address of the server that will act as
 	// our shim.
-	addrs, err := net.LookupHost(soaShim)
+	addrs, err := d.net.LookupHost(soaShim)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +297,7 @@ func fallBackSRVLookup(soaShim string) ([]*net.SRV, error) {
 	// Once we have the IP address, we'll establish a TCP connection using
 	// port 53.
 	dnsServer := net.JoinHostPort(addrs[0], "53")
-	conn, err := net.Dial("tcp", dnsServer)
+	conn, err := d.net.Dial("tcp", dnsServer)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +359,9 @@ search:
 			// can obtain a random sample of the encoded public
 			// keys of nodes.
 			primarySeed := dnsSeedTuple[0]
-			_, addrs, err := net.LookupSRV("nodes", "tcp", primarySeed)
+			_, addrs, err := d.net.LookupSRV(
+				"nodes", "tcp", primarySeed,
+			)
 			if err != nil {
 				log.Tracef("Unable to lookup SRV records via " +
 					"primary seed, falling back to secondary")
@@ -366,7 +376,7 @@ search:
 				// the primary seed, we'll fallback to the
 				// secondary seed before concluding failure.
 				secondarySeed := dnsSeedTuple[1]
-				addrs, err = fallBackSRVLookup(secondarySeed)
+				addrs, err = d.fallBackSRVLookup(secondarySeed)
 				if err != nil {
 					return nil, err
 				}
@@ -389,7 +399,7 @@ search:
 				// address for the matching bech32 encoded node
 				// key.
 				bechNodeHost := nodeSrv.Target
-				addrs, err := net.LookupHost(bechNodeHost)
+				addrs, err := d.net.LookupHost(bechNodeHost)
 				if err != nil {
 					return nil, err
 				}
@@ -444,7 +454,7 @@ search:
 				// lnwire.NetAddress.
 				addr := net.JoinHostPort(addrs[0],
 					strconv.FormatUint(uint64(nodeSrv.Port), 10))
-				tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+				tcpAddr, err := d.net.ResolveTCPAddr("tcp", addr)
 				if err != nil {
 					return nil, err
 				}
@@ -120,6 +120,12 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// ChanSeries is an interfaces that provides access to a time series
+	// view of the current known channel graph. Each gossipSyncer enabled
+	// peer will utilize this in order to create and respond to channel
+	// graph time series queries.
+	ChanSeries ChannelGraphTimeSeries
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -186,6 +192,14 @@ type AuthenticatedGossiper struct {
 	// selfKey is the identity public key of the backing Lighting node.
 	selfKey *btcec.PublicKey
 
+	// peerSyncers keeps track of all the gossip syncers we're maintain for
+	// peers that understand this mode of operation. When we go to send out
+	// new updates, for all peers in the map, we'll send the messages
+	// directly to their gossiper, rather than broadcasting them. With this
+	// change, we ensure we filter out all updates properly.
+	syncerMtx   sync.RWMutex
+	peerSyncers map[routing.Vertex]*gossipSyncer
+
 	sync.Mutex
 }
 
@@ -206,6 +220,7 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		prematureAnnouncements:  make(map[uint32][]*networkMsg),
 		prematureChannelUpdates: make(map[uint64][]*networkMsg),
 		waitingProofs:           storage,
+		peerSyncers:             make(map[routing.Vertex]*gossipSyncer),
 	}, nil
 }
 
@@ -265,19 +280,10 @@ func (d *AuthenticatedGossiper) SynchronizeNode(pub *btcec.PublicKey) error {
 			return nil
 		}
 
-		alias, err := lnwire.NewNodeAlias(node.Alias)
+		ann, err := makeNodeAnn(node)
 		if err != nil {
 			return err
 		}
-		ann := &lnwire.NodeAnnouncement{
-			Signature: node.AuthSig,
-			Timestamp: uint32(node.LastUpdate.Unix()),
-			Addresses: node.Addresses,
-			NodeID:    node.PubKey,
-			Alias:     alias,
-			Features:  node.Features.RawFeatureVector,
-			RGBColor:  node.Color,
-		}
 		announceMessages = append(announceMessages, ann)
 
 		numNodes++
@@ -368,6 +374,90 @@ func (d *AuthenticatedGossiper) Stop() {
 
 	close(d.quit)
 	d.wg.Wait()
+
+	// We'll stop our gossip syncers only after the networkHandler has
+	// exited, as it may be in the middle of handing them new messages.
+	d.syncerMtx.RLock()
+	for _, syncer := range d.peerSyncers {
+		syncer.Stop()
+	}
+	d.syncerMtx.RUnlock()
+}
+
+// InitSyncState is called by outside sub-systems when a connection is
+// established to a new peer that understands how to perform channel range
+// queries. We'll allocate a new gossip syncer for it, and start any goroutines
+// needed to handle new queries. The recvUpdates bool indicates if we should
+// continue to receive real-time updates from the remote peer once we've
+// synchronized channel state.
+func (d *AuthenticatedGossiper) InitSyncState(syncPeer *btcec.PublicKey,
+	recvUpdates bool) error {
+
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	// If we already have a syncer, then we'll exit early as we don't want
+	// to override it.
+	nodeID := routing.NewVertex(syncPeer)
+	if _, ok := d.peerSyncers[nodeID]; ok {
+		return nil
+	}
+
+	log.Infof("Creating new gossipSyncer for peer=%x",
+		syncPeer.SerializeCompressed())
+
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:       d.cfg.ChainHash,
+		peerPub:         nodeID,
+		syncChanUpdates: recvUpdates,
+		channelSeries:   d.cfg.ChanSeries,
+		encodingType:    lnwire.EncodingSortedPlain,
+		chunkSize:       encodingTypeToChunkSize[lnwire.EncodingSortedPlain],
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			return d.cfg.SendToPeer(syncPeer, msgs...)
+		},
+	})
+	d.peerSyncers[nodeID] = syncer
+
+	return syncer.Start()
+}
+
+// PruneSyncState is called by outside sub-systems once a peer that we were
+// previously connected to has been disconnected. In this case we can stop the
+// existing gossipSyncer assigned to the peer and free up resources.
+func (d *AuthenticatedGossiper) PruneSyncState(peer *btcec.PublicKey) {
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	log.Infof("Removing gossipSyncer for peer=%x",
+		peer.SerializeCompressed())
+
+	vertex := routing.NewVertex(peer)
+	syncer, ok := d.peerSyncers[vertex]
+	if !ok {
+		return
+	}
+
+	syncer.Stop()
+
+	delete(d.peerSyncers, vertex)
+}
+
+// findGossipSyncer returns the gossipSyncer assigned to the target peer, if
+// one exists.
+func (d *AuthenticatedGossiper) findGossipSyncer(
+	pub *btcec.PublicKey) (*gossipSyncer, error) {
+
+	d.syncerMtx.RLock()
+	defer d.syncerMtx.RUnlock()
+
+	syncer, ok := d.peerSyncers[routing.NewVertex(pub)]
+	if !ok {
+		return nil, fmt.Errorf("no gossip syncer found for peer=%x",
+			pub.SerializeCompressed())
+	}
+
+	return syncer, nil
 }
 
 // ProcessRemoteAnnouncement sends a new remote announcement message along with
@@ -378,6 +468,40 @@ func (d *AuthenticatedGossiper) Stop() {
 func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	src *btcec.PublicKey) chan error {
 
+	// Gossip queries aren't announcements, so rather than being processed
+	// by the networkHandler, they're handed directly to the gossipSyncer
+	// of the peer that sent them.
+	errChan := make(chan error, 1)
+	switch m := msg.(type) {
+	case *lnwire.QueryShortChanIDs,
+		*lnwire.QueryChannelRange,
+		*lnwire.ReplyChannelRange,
+		*lnwire.ReplyShortChanIDsEnd:
+
+		syncer, err := d.findGossipSyncer(src)
+		if err != nil {
+			errChan <- err
+			return errChan
+		}
+
+		errChan <- syncer.ProcessQueryMsg(m)
+		return errChan
+
+	// If a peer is updating its current update horizon, then we'll dispatch
+	// that directly to the proper gossipSyncer.
+	case *lnwire.GossipTimestampRange:
+		syncer, err := d.findGossipSyncer(src)
+		if err != nil {
+			errChan <- err
+			return errChan
+		}
+
+		// If we've found the message target, then we'll dispatch the
+		// message directly to it.
+		errChan <- syncer.ApplyGossipFilter(m)
+		return errChan
+	}
+
 	nMsg := &networkMsg{
 		msg:      msg,
 		isRemote: true,
@@ -911,18 +1035,43 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			log.Infof("Broadcasting batch of %v new announcements",
 				len(announcementBatch))
 
+			// We'll first obtain the set of peers with an active
+			// gossipSyncer, as these peers will receive the batch
+			// filtered through their update horizon rather than
+			// through the regular broadcast.
+			d.syncerMtx.RLock()
+			syncers := make([]*gossipSyncer, 0, len(d.peerSyncers))
+			for _, syncer := range d.peerSyncers {
+				syncers = append(syncers, syncer)
+			}
+
 			// If we have new things to announce then broadcast
-			// them to all our immediately connected peers.
+			// them to all our immediately connected peers, skipping
+			// the senders of each message along with the peers
+			// handled by a gossipSyncer.
 			for _, msgChunk := range announcementBatch {
-				err := d.cfg.Broadcast(
-					msgChunk.senders, msgChunk.msg,
-				)
+				skips := make(map[routing.Vertex]struct{})
+				for sender := range msgChunk.senders {
+					skips[sender] = struct{}{}
+				}
+				for peer := range d.peerSyncers {
+					skips[peer] = struct{}{}
+				}
+
+				err := d.cfg.Broadcast(skips, msgChunk.msg)
 				if err != nil {
 					log.Errorf("unable to send batch "+
 						"announcements: %v", err)
 					continue
 				}
 			}
+			d.syncerMtx.RUnlock()
+
+			// Finally, each gossipSyncer will only forward the
+			// messages within the update horizon of its peer.
+			for _, syncer := range syncers {
+				syncer.FilterGossipMsgs(announcementBatch...)
+			}
 
 			// If we're able to broadcast the current batch
 			// successfully, then we reset the batch for a new
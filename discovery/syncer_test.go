@@ -0,0 +1,315 @@
+package discovery
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// mockChannelGraphTimeSeries is a mock implementation of the
+// ChannelGraphTimeSeries interface that answers queries from a static set of
+// channels and updates.
+type mockChannelGraphTimeSeries struct {
+	highestID lnwire.ShortChannelID
+
+	knownChans map[lnwire.ShortChannelID]struct{}
+
+	chanRange []lnwire.ShortChannelID
+
+	chanUpdates map[lnwire.ShortChannelID][]*lnwire.ChannelUpdate
+}
+
+func newMockChannelGraphTimeSeries(
+	highestID lnwire.ShortChannelID) *mockChannelGraphTimeSeries {
+
+	return &mockChannelGraphTimeSeries{
+		highestID:   highestID,
+		knownChans:  make(map[lnwire.ShortChannelID]struct{}),
+		chanUpdates: make(map[lnwire.ShortChannelID][]*lnwire.ChannelUpdate),
+	}
+}
+
+func (m *mockChannelGraphTimeSeries) HighestChanID(
+	chain chainhash.Hash) (*lnwire.ShortChannelID, error) {
+
+	return &m.highestID, nil
+}
+
+func (m *mockChannelGraphTimeSeries) UpdatesInHorizon(chain chainhash.Hash,
+	startTime time.Time, endTime time.Time) ([]lnwire.Message, error) {
+
+	return nil, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterKnownChanIDs(chain chainhash.Hash,
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	var newChans []lnwire.ShortChannelID
+	for _, chanID := range superSet {
+		if _, ok := m.knownChans[chanID]; !ok {
+			newChans = append(newChans, chanID)
+		}
+	}
+
+	return newChans, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterChannelRange(chain chainhash.Hash,
+	startHeight, endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	var chans []lnwire.ShortChannelID
+	for _, chanID := range m.chanRange {
+		if chanID.BlockHeight >= startHeight &&
+			chanID.BlockHeight <= endHeight {
+
+			chans = append(chans, chanID)
+		}
+	}
+
+	return chans, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanAnns(chain chainhash.Hash,
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	return nil, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanUpdates(chain chainhash.Hash,
+	shortChanID lnwire.ShortChannelID) ([]*lnwire.ChannelUpdate, error) {
+
+	updates, ok := m.chanUpdates[shortChanID]
+	if !ok {
+		return nil, fmt.Errorf("unknown channel %v",
+			shortChanID.ToUint64())
+	}
+
+	return updates, nil
+}
+
+var _ ChannelGraphTimeSeries = (*mockChannelGraphTimeSeries)(nil)
+
+// newTestSyncer creates a new gossipSyncer backed by the passed time series,
+// which records all the messages it sends to the remote peer. The syncer isn't
+// started, allowing the tests to drive its state directly.
+func newTestSyncer(chunkSize int32,
+	chanSeries ChannelGraphTimeSeries) (*gossipSyncer, *[][]lnwire.Message) {
+
+	var sentMsgs [][]lnwire.Message
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		syncChanUpdates: true,
+		channelSeries:   chanSeries,
+		encodingType:    lnwire.EncodingSortedPlain,
+		chunkSize:       chunkSize,
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			sentMsgs = append(sentMsgs, msgs)
+			return nil
+		},
+	})
+
+	return syncer, &sentMsgs
+}
+
+// TestGossipSyncerFilterGossipMsgs tests that the gossipSyncer only forwards
+// the messages within the update horizon of the remote peer, and that no
+// messages are forwarded before the peer has applied a filter.
+func TestGossipSyncerFilterGossipMsgs(t *testing.T) {
+	t.Parallel()
+
+	chanSeries := newMockChannelGraphTimeSeries(lnwire.ShortChannelID{})
+	syncer, sentMsgs := newTestSyncer(10, chanSeries)
+
+	const startTime = 1000
+
+	inHorizonChan := lnwire.ShortChannelID{BlockHeight: 1}
+	staleChan := lnwire.ShortChannelID{BlockHeight: 2}
+	dbChan := lnwire.ShortChannelID{BlockHeight: 3}
+
+	// The update of the third channel isn't within the batch, so the
+	// syncer will need to consult the time series to decide if the
+	// announcement should be sent.
+	chanSeries.chanUpdates[dbChan] = []*lnwire.ChannelUpdate{
+		{ShortChannelID: dbChan, Timestamp: startTime + 10},
+	}
+
+	msgs := []msgWithSenders{
+		{msg: &lnwire.ChannelAnnouncement{ShortChannelID: inHorizonChan}},
+		{msg: &lnwire.ChannelUpdate{
+			ShortChannelID: inHorizonChan,
+			Timestamp:      startTime + 5,
+		}},
+		{msg: &lnwire.ChannelAnnouncement{ShortChannelID: staleChan}},
+		{msg: &lnwire.ChannelUpdate{
+			ShortChannelID: staleChan,
+			Timestamp:      startTime - 5,
+		}},
+		{msg: &lnwire.ChannelAnnouncement{ShortChannelID: dbChan}},
+		{msg: &lnwire.NodeAnnouncement{Timestamp: startTime}},
+		{msg: &lnwire.NodeAnnouncement{Timestamp: startTime - 1}},
+	}
+
+	// As the remote peer hasn't yet sent us its update horizon, no
+	// messages should be forwarded.
+	syncer.FilterGossipMsgs(msgs...)
+	if len(*sentMsgs) != 0 {
+		t.Fatalf("messages sent without a gossip filter: %v",
+			spew.Sdump(*sentMsgs))
+	}
+
+	syncer.remoteUpdateHorizon = &lnwire.GossipTimestampRange{
+		FirstTimestamp: startTime,
+		TimestampRange: math.MaxUint32,
+	}
+
+	syncer.FilterGossipMsgs(msgs...)
+	if len(*sentMsgs) != 1 {
+		t.Fatalf("expected a single send, instead got %v",
+			len(*sentMsgs))
+	}
+
+	// Only the announcements of the first and the third channel, the
+	// update of the first channel, and the first node announcement fall
+	// within the horizon.
+	expectedMsgs := []lnwire.Message{
+		msgs[0].msg, msgs[1].msg, msgs[4].msg, msgs[5].msg,
+	}
+	sent := (*sentMsgs)[0]
+	if len(sent) != len(expectedMsgs) {
+		t.Fatalf("expected %v messages to be sent, instead got %v",
+			len(expectedMsgs), len(sent))
+	}
+	for i, msg := range expectedMsgs {
+		if sent[i] != msg {
+			t.Fatalf("message #%v mismatch: expected %v, got %v", i,
+				spew.Sdump(msg), spew.Sdump(sent[i]))
+		}
+	}
+}
+
+// TestGossipSyncerReplyChanRangeQuery tests that a channel range query is
+// answered in chunks, with only the final chunk marked as complete.
+func TestGossipSyncerReplyChanRangeQuery(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 2
+
+	chanSeries := newMockChannelGraphTimeSeries(lnwire.ShortChannelID{})
+	for i := uint32(1); i <= 5; i++ {
+		chanSeries.chanRange = append(
+			chanSeries.chanRange, lnwire.ShortChannelID{BlockHeight: i},
+		)
+	}
+	syncer, sentMsgs := newTestSyncer(chunkSize, chanSeries)
+
+	query := &lnwire.QueryChannelRange{
+		FirstBlockHeight: 0,
+		NumBlocks:        math.MaxUint32,
+	}
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to reply to chan range query: %v", err)
+	}
+
+	// We should have received three replies, with two, two, and finally a
+	// single channel.
+	expectedSizes := []int{2, 2, 1}
+	if len(*sentMsgs) != len(expectedSizes) {
+		t.Fatalf("expected %v replies, instead got %v",
+			len(expectedSizes), len(*sentMsgs))
+	}
+
+	var replyChans []lnwire.ShortChannelID
+	for i, msgs := range *sentMsgs {
+		reply, ok := msgs[0].(*lnwire.ReplyChannelRange)
+		if !ok {
+			t.Fatalf("expected ReplyChannelRange, instead got %T",
+				msgs[0])
+		}
+
+		if len(reply.ShortChanIDs) != expectedSizes[i] {
+			t.Fatalf("reply #%v: expected %v chans, got %v", i,
+				expectedSizes[i], len(reply.ShortChanIDs))
+		}
+
+		isFinal := i == len(expectedSizes)-1
+		if isFinal != (reply.Complete == 1) {
+			t.Fatalf("reply #%v: unexpected complete value %v", i,
+				reply.Complete)
+		}
+
+		replyChans = append(replyChans, reply.ShortChanIDs...)
+	}
+
+	for i, chanID := range chanSeries.chanRange {
+		if replyChans[i] != chanID {
+			t.Fatalf("chan #%v mismatch: expected %v, got %v", i,
+				chanID.ToUint64(), replyChans[i].ToUint64())
+		}
+	}
+}
+
+// TestGossipSyncerProcessChanRangeReply tests that once the final reply to
+// our channel range query arrives, the syncer transitions to querying for only
+// the channels it doesn't yet know of, or straight to the synced state if
+// there are none.
+func TestGossipSyncerProcessChanRangeReply(t *testing.T) {
+	t.Parallel()
+
+	chanSeries := newMockChannelGraphTimeSeries(lnwire.ShortChannelID{})
+	syncer, _ := newTestSyncer(10, chanSeries)
+
+	knownChan := lnwire.ShortChannelID{BlockHeight: 1}
+	newChan := lnwire.ShortChannelID{BlockHeight: 2}
+	chanSeries.knownChans[knownChan] = struct{}{}
+
+	// The first, incomplete, reply should only be buffered.
+	err := syncer.processChanRangeReply(&lnwire.ReplyChannelRange{
+		ShortChanIDs: []lnwire.ShortChannelID{knownChan},
+	})
+	if err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.SyncState() != syncingChans {
+		t.Fatalf("unexpected state after partial reply: %v",
+			syncer.SyncState())
+	}
+
+	// Once the final reply arrives, we should only query for the channel
+	// we don't yet know of.
+	err = syncer.processChanRangeReply(&lnwire.ReplyChannelRange{
+		Complete:     1,
+		ShortChanIDs: []lnwire.ShortChannelID{newChan},
+	})
+	if err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.SyncState() != queryNewChannels {
+		t.Fatalf("expected state %v, instead got %v", queryNewChannels,
+			syncer.SyncState())
+	}
+	if len(syncer.newChansToQuery) != 1 ||
+		syncer.newChansToQuery[0] != newChan {
+
+		t.Fatalf("expected to query for %v, instead got %v",
+			newChan.ToUint64(), syncer.newChansToQuery)
+	}
+
+	// If the remote peer only knows of channels we already have, then the
+	// syncer should consider itself synced.
+	syncer, _ = newTestSyncer(10, chanSeries)
+	err = syncer.processChanRangeReply(&lnwire.ReplyChannelRange{
+		Complete:     1,
+		ShortChanIDs: []lnwire.ShortChannelID{knownChan},
+	})
+	if err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.SyncState() != chansSynced {
+		t.Fatalf("expected state %v, instead got %v", chansSynced,
+			syncer.SyncState())
+	}
+}
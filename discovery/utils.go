@@ -74,6 +74,27 @@ func createChanAnnouncement(chanProof *channeldb.ChannelAuthProof,
 	return chanAnn, edge1Ann, edge2Ann
 }
 
+// makeNodeAnn re-creates the authenticated node announcement of the passed
+// node from the information we stored when we originally received it.
+func makeNodeAnn(
+	node *channeldb.LightningNode) (*lnwire.NodeAnnouncement, error) {
+
+	alias, err := lnwire.NewNodeAlias(node.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwire.NodeAnnouncement{
+		Signature: node.AuthSig,
+		Timestamp: uint32(node.LastUpdate.Unix()),
+		Addresses: node.Addresses,
+		NodeID:    node.PubKey,
+		Alias:     alias,
+		Features:  node.Features.RawFeatureVector,
+		RGBColor:  node.Color,
+	}, nil
+}
+
 // copyPubKey performs a copy of the target public key, setting a fresh curve
 // parameter during the process.
 func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
@@ -0,0 +1,79 @@
+package tlvfuzz
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// Fuzz is used by go-fuzz to fuzz for potentially malicious input
+func Fuzz(data []byte) int {
+	// We'll decode the input as a TLV stream containing a record of each
+	// of the primitive types. Any other records are unknown to us, and
+	// will be retained as raw bytes if they're optional.
+	var (
+		u8       uint8
+		u16      uint16
+		u32      uint32
+		u64      uint64
+		b32      [32]byte
+		b33      [33]byte
+		pubKey   *btcec.PublicKey
+		varBytes []byte
+	)
+	records := []tlv.Record{
+		tlv.MakePrimitiveRecord(1, &u8),
+		tlv.MakePrimitiveRecord(2, &u16),
+		tlv.MakePrimitiveRecord(3, &u32),
+		tlv.MakePrimitiveRecord(4, &u64),
+		tlv.MakePrimitiveRecord(5, &b32),
+		tlv.MakePrimitiveRecord(6, &b33),
+		tlv.MakePrimitiveRecord(7, &pubKey),
+		tlv.MakePrimitiveRecord(8, &varBytes),
+	}
+
+	stream := tlv.MustNewStream(records...)
+	parsedTypes, err := stream.DecodeWithParsedTypes(bytes.NewReader(data))
+	if err != nil {
+		// Ignore this input - go-fuzz generated []byte that cannot be
+		// represented as a TLV stream
+		return 0
+	}
+
+	// We'll now re-encode the stream with only the records that were
+	// present in the input, along with the unknown records we retained.
+	var encodeRecords []tlv.Record
+	for _, record := range records {
+		if _, ok := parsedTypes[record.Type()]; ok {
+			encodeRecords = append(encodeRecords, record)
+		}
+	}
+	for typ, value := range parsedTypes {
+		if value == nil {
+			continue
+		}
+
+		value := value
+		encodeRecords = append(
+			encodeRecords, tlv.MakePrimitiveRecord(typ, &value),
+		)
+	}
+	tlv.SortRecords(encodeRecords)
+
+	var b bytes.Buffer
+	if err := tlv.MustNewStream(encodeRecords...).Encode(&b); err != nil {
+		// Could not serialize the stream into bytes buffer, panic
+		panic(err)
+	}
+
+	// As there's only a single valid encoding of a stream, the re-encoded
+	// stream must be identical to the input.
+	if !bytes.Equal(data, b.Bytes()) {
+		panic(fmt.Errorf("Re-encoded stream and original stream " +
+			"are not equal."))
+	}
+
+	return 1
+}
@@ -0,0 +1,666 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// defaultFeeBumpConfTarget is the number of blocks within which a
+	// bumped transaction should confirm, if neither a confirmation target
+	// nor a fee rate is requested.
+	defaultFeeBumpConfTarget = 6
+
+	// minRelayFeePerByte is the fee rate in satoshis per virtual byte a
+	// replacement of a child transaction must pay for its own relay, on
+	// top of the fee of the child it replaces.
+	minRelayFeePerByte = 1
+)
+
+// FeeBumperConfig abstracts the required subsystems used by the fee bumper. An
+// instance of FeeBumperConfig is passed to newFeeBumper during instantiation.
+type FeeBumperConfig struct {
+	// Wallet is used to look up the unconfirmed outputs and transactions
+	// of the wallet, to generate the address the child transactions pay
+	// to, and to publish them.
+	Wallet lnwallet.WalletController
+
+	// Signer is used to sign the wallet output spent by a child
+	// transaction.
+	Signer lnwallet.Signer
+
+	// Estimator is used to determine the fee rate of a package, if it's
+	// requested by confirmation target.
+	Estimator lnwallet.FeeEstimator
+
+	// ChainIO is used to determine the height hint of the confirmation
+	// notifications of child transactions.
+	ChainIO lnwallet.BlockChainIO
+
+	// Notifier is used to track the confirmation of child transactions.
+	Notifier chainntnfs.ChainNotifier
+
+	// BumpSweep replaces the sweep of the passed output, if it's being
+	// swept by the utxo sweeper. Otherwise ErrUnknownSweepInput is
+	// returned.
+	BumpSweep func(wire.OutPoint, uint32,
+		btcutil.Amount) (*pendingSweepReport, error)
+
+	// FetchChannelCapacity returns the capacity of the channel with the
+	// passed channel point. It's used to determine the fee paid by the
+	// commitment and closing transactions of our channels, as they spend
+	// a funding output that doesn't belong to the wallet.
+	FetchChannelCapacity func(wire.OutPoint) (btcutil.Amount, error)
+
+	// Store persists the fee bumps, such that they're still reported, and
+	// their children still tracked, after a restart.
+	Store FeeBumpStore
+}
+
+// feeBump describes a child transaction that was published to raise the fee
+// rate of its unconfirmed parent.
+type feeBump struct {
+	// parentTxid is the txid of the transaction whose fee rate was raised.
+	parentTxid chainhash.Hash
+
+	// childTxid is the txid of the transaction spending an output of the
+	// parent.
+	childTxid chainhash.Hash
+
+	// outpoint is the output of the parent spent by the child.
+	outpoint wire.OutPoint
+
+	// childFee is the fee paid by the child transaction. It's zero if the
+	// bumped output was being swept, in which case the child is the
+	// replacement of its sweep.
+	childFee btcutil.Amount
+
+	// packageFeeRate is the fee rate per weight unit of the parent and
+	// child combined.
+	packageFeeRate btcutil.Amount
+
+	// heightHint is the height at which the child was published, from
+	// which its confirmation is watched for.
+	heightHint uint32
+
+	// confirmed is true once the child transaction has confirmed.
+	confirmed bool
+}
+
+// Encode writes the fee bump to the given io.Writer.
+func (f *feeBump) Encode(w io.Writer) error {
+	if _, err := w.Write(f.parentTxid[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(f.childTxid[:]); err != nil {
+		return err
+	}
+	if err := writeOutpoint(w, &f.outpoint); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	for _, v := range []btcutil.Amount{f.childFee, f.packageFeeRate} {
+		byteOrder.PutUint64(scratch[:], uint64(v))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+	}
+
+	byteOrder.PutUint32(scratch[:4], f.heightHint)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	scratch[0] = 0
+	if f.confirmed {
+		scratch[0] = 1
+	}
+	_, err := w.Write(scratch[:1])
+	return err
+}
+
+// Decode reconstructs a fee bump using the provided io.Reader.
+func (f *feeBump) Decode(r io.Reader) error {
+	if _, err := io.ReadFull(r, f.parentTxid[:]); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, f.childTxid[:]); err != nil {
+		return err
+	}
+	if err := readOutpoint(r, &f.outpoint); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	for _, v := range []*btcutil.Amount{&f.childFee, &f.packageFeeRate} {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return err
+		}
+		*v = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	}
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return err
+	}
+	f.heightHint = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return err
+	}
+	f.confirmed = scratch[0] == 1
+
+	return nil
+}
+
+// feeBumper raises the fee rate of unconfirmed transactions, such as funding
+// and closing transactions that are stuck due to a fee spike, by spending one
+// of their outputs that's controlled by us with a child transaction paying
+// for both (CPFP). Outputs that are being swept by the utxo sweeper are bumped
+// by replacing their sweep instead. Bumping a transaction again replaces its
+// earlier child, if the same output is spent.
+type feeBumper struct {
+	started uint32
+	stopped uint32
+
+	cfg *FeeBumperConfig
+
+	mu sync.Mutex
+
+	// bumps maps the txid of each bumped parent transaction to its most
+	// recent fee bump.
+	bumps map[chainhash.Hash]*feeBump
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newFeeBumper creates a new instance of the feeBumper from the passed config.
+func newFeeBumper(cfg *FeeBumperConfig) *feeBumper {
+	return &feeBumper{
+		cfg:   cfg,
+		bumps: make(map[chainhash.Hash]*feeBump),
+		quit:  make(chan struct{}),
+	}
+}
+
+// Start reloads the fee bumps published before a restart, and resumes tracking
+// the confirmation of their children.
+func (b *feeBumper) Start() error {
+	if !atomic.CompareAndSwapUint32(&b.started, 0, 1) {
+		return nil
+	}
+
+	cpfpLog.Tracef("Starting fee bumper")
+
+	bumps, err := b.cfg.Store.FetchFeeBumps()
+	if err != nil {
+		return err
+	}
+
+	for _, bump := range bumps {
+		b.bumps[bump.parentTxid] = bump
+		if bump.confirmed {
+			continue
+		}
+
+		if err := b.trackChild(bump); err != nil {
+			close(b.quit)
+			b.wg.Wait()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop gracefully shuts down the fee bumper, and stops tracking the
+// confirmation of the child transactions.
+func (b *feeBumper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&b.stopped, 0, 1) {
+		return nil
+	}
+
+	cpfpLog.Infof("Fee bumper shutting down")
+
+	close(b.quit)
+	b.wg.Wait()
+
+	return nil
+}
+
+// BumpFee raises the fee rate of the unconfirmed transaction that created the
+// passed output, such that it's likely to confirm within the passed number of
+// blocks, or pays the passed fee rate per weight unit. If both are zero, the
+// default confirmation target is used. The output must either be an
+// unconfirmed output of the wallet, or be swept by the utxo sweeper.
+func (b *feeBumper) BumpFee(outpoint wire.OutPoint, confTarget uint32,
+	feeRate btcutil.Amount) (*feeBump, error) {
+
+	// Outputs that are being swept are already spent by a transaction
+	// paying to the wallet, so we'll bump that one instead of adding
+	// another.
+	report, err := b.cfg.BumpSweep(outpoint, confTarget, feeRate)
+	switch {
+	case err == nil:
+		return &feeBump{
+			parentTxid:     outpoint.Hash,
+			childTxid:      *report.sweepTxid,
+			outpoint:       outpoint,
+			packageFeeRate: report.feeRate,
+		}, nil
+
+	case err != ErrUnknownSweepInput:
+		return nil, err
+	}
+
+	if feeRate == 0 {
+		if confTarget == 0 {
+			confTarget = defaultFeeBumpConfTarget
+		}
+
+		feeRate, err = b.cfg.Estimator.EstimateFeePerWeight(confTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bump, err := b.publishChild(outpoint, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// As the child has been published already, we'll only log a failure
+	// to persist the bump.
+	if err := b.cfg.Store.AddFeeBump(bump); err != nil {
+		cpfpLog.Errorf("Unable to store fee bump of tx %v: %v",
+			bump.parentTxid, err)
+	}
+
+	b.mu.Lock()
+	b.bumps[bump.parentTxid] = bump
+	b.mu.Unlock()
+
+	if err := b.trackChild(bump); err != nil {
+		cpfpLog.Errorf("Unable to track confirmation of child tx %v: "+
+			"%v", bump.childTxid, err)
+	}
+
+	return bump, nil
+}
+
+// FeeBump returns the most recent fee bump of the transaction with the passed
+// txid, or nil if it wasn't bumped.
+func (b *feeBumper) FeeBump(txid chainhash.Hash) *feeBump {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bump, ok := b.bumps[txid]
+	if !ok {
+		return nil
+	}
+
+	bumpCopy := *bump
+	return &bumpCopy
+}
+
+// publishChild creates and publishes a transaction spending the passed wallet
+// output back to the wallet, paying enough fees to bring the fee rate of the
+// output's parent and the child combined up to the passed fee rate. If the
+// output is already spent by the child of an earlier bump that hasn't
+// confirmed, the new child replaces it.
+func (b *feeBumper) publishChild(outpoint wire.OutPoint,
+	feeRate btcutil.Amount) (*feeBump, error) {
+
+	b.mu.Lock()
+	prevBump, ok := b.bumps[outpoint.Hash]
+	b.mu.Unlock()
+
+	var prevChildFee btcutil.Amount
+	if ok && !prevBump.confirmed && prevBump.outpoint == outpoint {
+		prevChildFee = prevBump.childFee
+	} else {
+		prevBump = nil
+	}
+
+	utxo, err := b.fetchChildInput(outpoint, prevBump != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parentTx, err := b.fetchUnconfirmedTx(outpoint.Hash)
+	if err != nil {
+		return nil, err
+	}
+	parentWeight := blockchain.GetTransactionWeight(btcutil.NewTx(parentTx))
+	parentFee := b.parentFee(parentTx)
+
+	// If the parent already pays the requested fee rate on its own, then
+	// a child would only lower the fee rate of the package.
+	parentFeeRate := parentFee / btcutil.Amount(parentWeight)
+	if parentFeeRate >= feeRate {
+		return nil, fmt.Errorf("tx %v already pays %v sat/kw, which "+
+			"isn't below the requested %v sat/kw", outpoint.Hash,
+			int64(parentFeeRate)*1000, int64(feeRate)*1000)
+	}
+
+	var weightEstimate lnwallet.TxWeightEstimator
+	switch utxo.AddressType {
+	case lnwallet.WitnessPubKey:
+		weightEstimate.AddP2WKHInput()
+
+	case lnwallet.NestedWitnessPubKey:
+		weightEstimate.AddNestedP2WKHInput()
+
+	default:
+		return nil, fmt.Errorf("unable to spend output %v of unknown "+
+			"address type %v", outpoint, utxo.AddressType)
+	}
+	weightEstimate.AddP2WKHOutput()
+	childWeight := int64(weightEstimate.Weight())
+
+	packageWeight := int64(parentWeight) + childWeight
+	childFee := cpfpChildFee(
+		int64(parentWeight), parentFee, childWeight, feeRate,
+		prevChildFee,
+	)
+	childAmt := utxo.Value - childFee
+	if childAmt < lnwallet.DefaultDustLimit() {
+		return nil, fmt.Errorf("value %v of output %v doesn't cover "+
+			"the child fee %v", utxo.Value, outpoint, childFee)
+	}
+
+	addr, err := b.cfg.Wallet.NewAddress(lnwallet.WitnessPubKey, true)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	childTx := wire.NewMsgTx(2)
+	childTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: outpoint,
+	})
+	childTx.AddTxOut(&wire.TxOut{
+		PkScript: pkScript,
+		Value:    int64(childAmt),
+	})
+
+	signDesc := &lnwallet.SignDescriptor{
+		Output: &wire.TxOut{
+			PkScript: utxo.PkScript,
+			Value:    int64(utxo.Value),
+		},
+		HashType:   txscript.SigHashAll,
+		SigHashes:  txscript.NewTxSigHashes(childTx),
+		InputIndex: 0,
+	}
+	inputScript, err := b.cfg.Signer.ComputeInputScript(childTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+	if inputScript == nil {
+		return nil, fmt.Errorf("unable to sign output %v", outpoint)
+	}
+	childTx.TxIn[0].SignatureScript = inputScript.ScriptSig
+	childTx.TxIn[0].Witness = inputScript.Witness
+
+	if err := blockchain.CheckTransactionSanity(
+		btcutil.NewTx(childTx),
+	); err != nil {
+		return nil, err
+	}
+
+	_, bestHeight, err := b.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	bump := &feeBump{
+		parentTxid:     outpoint.Hash,
+		childTxid:      childTx.TxHash(),
+		outpoint:       outpoint,
+		childFee:       childFee,
+		packageFeeRate: (parentFee + childFee) / btcutil.Amount(packageWeight),
+		heightHint:     uint32(bestHeight),
+	}
+
+	if prevBump != nil {
+		cpfpLog.Infof("Replacing child tx %v of tx %v",
+			prevBump.childTxid, bump.parentTxid)
+	}
+	cpfpLog.Infof("Bumping tx %v from %v sat/kw to %v sat/kw with child "+
+		"tx %v: %v", bump.parentTxid, int64(parentFeeRate)*1000,
+		int64(bump.packageFeeRate)*1000, bump.childTxid,
+		newLogClosure(func() string {
+			return spew.Sdump(childTx)
+		}),
+	)
+
+	if err := b.cfg.Wallet.PublishTransaction(childTx); err != nil {
+		return nil, err
+	}
+
+	return bump, nil
+}
+
+// cpfpChildFee returns the fee a child transaction of the passed weight must
+// pay, such that the package of the child and a parent of the passed weight
+// and fee pays the passed fee rate per weight unit. If the child replaces an
+// earlier child paying a non-zero fee, it pays at least the fee of the earlier
+// child plus the minimum relay fee of its own size, as required for the
+// replacement to be accepted.
+func cpfpChildFee(parentWeight int64, parentFee btcutil.Amount,
+	childWeight int64, feeRate, prevChildFee btcutil.Amount) btcutil.Amount {
+
+	// The child pays for the weight of the whole package at the requested
+	// fee rate, minus the fees the parent already pays.
+	packageWeight := parentWeight + childWeight
+	childFee := btcutil.Amount(packageWeight)*feeRate - parentFee
+
+	if prevChildFee == 0 {
+		return childFee
+	}
+
+	childSize := (childWeight + blockchain.WitnessScaleFactor - 1) /
+		blockchain.WitnessScaleFactor
+	minFee := prevChildFee + btcutil.Amount(childSize)*minRelayFeePerByte
+	if childFee < minFee {
+		childFee = minFee
+	}
+
+	return childFee
+}
+
+// fetchChildInput returns the wallet output the child of a fee bump spends. If
+// replacing is true, the output is already spent by the child of an earlier
+// bump, so it's no longer among the unspent outputs of the wallet.
+func (b *feeBumper) fetchChildInput(outpoint wire.OutPoint,
+	replacing bool) (*lnwallet.Utxo, error) {
+
+	if !replacing {
+		// The output must be an unspent output of the wallet, which
+		// we'll find among the unconfirmed ones.
+		utxos, err := b.cfg.Wallet.ListUnspentWitness(0)
+		if err != nil {
+			return nil, err
+		}
+		for _, utxo := range utxos {
+			if utxo.OutPoint == outpoint {
+				return utxo, nil
+			}
+		}
+
+		return nil, fmt.Errorf("output %v is neither an unspent "+
+			"output of the wallet, nor being swept", outpoint)
+	}
+
+	txOut, err := b.cfg.Wallet.FetchInputInfo(&outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var addressType lnwallet.AddressType
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(txOut.PkScript):
+		addressType = lnwallet.WitnessPubKey
+	case txscript.IsPayToScriptHash(txOut.PkScript):
+		addressType = lnwallet.NestedWitnessPubKey
+	default:
+		return nil, fmt.Errorf("output %v is not a witness output",
+			outpoint)
+	}
+
+	return &lnwallet.Utxo{
+		AddressType: addressType,
+		Value:       btcutil.Amount(txOut.Value),
+		PkScript:    txOut.PkScript,
+		OutPoint:    outpoint,
+	}, nil
+}
+
+// fetchUnconfirmedTx returns the unconfirmed wallet transaction with the
+// passed txid.
+func (b *feeBumper) fetchUnconfirmedTx(txid chainhash.Hash) (*wire.MsgTx,
+	error) {
+
+	txDetails, err := b.cfg.Wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txDetail := range txDetails {
+		if txDetail.Hash != txid {
+			continue
+		}
+
+		if txDetail.NumConfirmations > 0 {
+			return nil, fmt.Errorf("tx %v is already confirmed",
+				txid)
+		}
+
+		return txDetail.RawTx, nil
+	}
+
+	return nil, fmt.Errorf("tx %v not found in wallet", txid)
+}
+
+// parentFee returns the fee paid by the passed transaction. The fee can only
+// be determined if all of its inputs belong to the wallet, or are the funding
+// output of one of our channels, as spent by its commitment and closing
+// transactions. Otherwise we'll conservatively assume that it pays no fee at
+// all, leaving the fees of the whole package to the child.
+func (b *feeBumper) parentFee(tx *wire.MsgTx) btcutil.Amount {
+	var inputAmt, outputAmt int64
+	for _, txIn := range tx.TxIn {
+		txOut, err := b.cfg.Wallet.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err == nil {
+			inputAmt += txOut.Value
+			continue
+		}
+
+		capacity, chanErr := b.cfg.FetchChannelCapacity(
+			txIn.PreviousOutPoint,
+		)
+		if chanErr != nil {
+			cpfpLog.Debugf("Unable to determine fee of tx %v, input "+
+				"%v unknown: %v", tx.TxHash(),
+				txIn.PreviousOutPoint, err)
+			return 0
+		}
+
+		inputAmt += int64(capacity)
+	}
+	for _, txOut := range tx.TxOut {
+		outputAmt += txOut.Value
+	}
+
+	if inputAmt < outputAmt {
+		return 0
+	}
+
+	return btcutil.Amount(inputAmt - outputAmt)
+}
+
+// trackChild registers for the confirmation of the child transaction of the
+// passed fee bump, marking the bump as confirmed once it's received.
+func (b *feeBumper) trackChild(bump *feeBump) error {
+	confNtfn, err := b.cfg.Notifier.RegisterConfirmationsNtfn(
+		&bump.childTxid, 1, bump.heightHint,
+	)
+	if err != nil {
+		return err
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		select {
+		case conf, ok := <-confNtfn.Confirmed:
+			if !ok {
+				return
+			}
+
+			cpfpLog.Infof("Child tx %v of tx %v confirmed at "+
+				"height %d", bump.childTxid, bump.parentTxid,
+				conf.BlockHeight)
+
+			b.mu.Lock()
+			bump.confirmed = true
+			bumpCopy := *bump
+			b.mu.Unlock()
+
+			if err := b.cfg.Store.AddFeeBump(&bumpCopy); err != nil {
+				cpfpLog.Errorf("Unable to store fee bump of "+
+					"tx %v: %v", bump.parentTxid, err)
+			}
+
+		case <-b.quit:
+		}
+	}()
+
+	return nil
+}
+
+// fetchChannelCapacity returns the capacity of the channel with the passed
+// channel point, whether it's still open or has been closed.
+func fetchChannelCapacity(db *channeldb.DB,
+	chanPoint wire.OutPoint) (btcutil.Amount, error) {
+
+	channels, err := db.FetchAllChannels()
+	if err != nil {
+		return 0, err
+	}
+	for _, channel := range channels {
+		if channel.FundingOutpoint == chanPoint {
+			return channel.Capacity, nil
+		}
+	}
+
+	closeSummaries, err := db.FetchClosedChannels(false)
+	if err != nil {
+		return 0, err
+	}
+	for _, closeSummary := range closeSummaries {
+		if closeSummary.ChanPoint == chanPoint {
+			return closeSummary.Capacity, nil
+		}
+	}
+
+	return 0, fmt.Errorf("channel %v not found", chanPoint)
+}
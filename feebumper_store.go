@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+var (
+	// feeBumpsBucket stores the most recent fee bump of every transaction
+	// the fee bumper has bumped. Persisting the bumps ensures that they're
+	// still reported, and that their children are still tracked, after the
+	// node restarts.
+	//
+	// maps: parent txid -> feeBump
+	feeBumpsBucket = []byte("fee-bumps")
+)
+
+// FeeBumpStore provides an interface for persisting the fee bumps published by
+// the fee bumper.
+type FeeBumpStore interface {
+	// AddFeeBump persists the passed fee bump, overwriting any earlier bump
+	// of the same parent transaction.
+	AddFeeBump(bump *feeBump) error
+
+	// FetchFeeBumps returns the most recent fee bump of every transaction
+	// that was bumped.
+	FetchFeeBumps() ([]*feeBump, error)
+}
+
+// feeBumpStore is a concrete instantiation of a FeeBumpStore that is backed by
+// a channeldb.DB instance.
+type feeBumpStore struct {
+	db *channeldb.DB
+}
+
+// A compile time check to ensure feeBumpStore meets the FeeBumpStore
+// interface.
+var _ FeeBumpStore = (*feeBumpStore)(nil)
+
+// newFeeBumpStore creates a new instance of a feeBumpStore.
+func newFeeBumpStore(db *channeldb.DB) *feeBumpStore {
+	return &feeBumpStore{
+		db: db,
+	}
+}
+
+// AddFeeBump persists the passed fee bump, overwriting any earlier bump of the
+// same parent transaction.
+//
+// NOTE: Part of the FeeBumpStore interface.
+func (s *feeBumpStore) AddFeeBump(bump *feeBump) error {
+	var v bytes.Buffer
+	if err := bump.Encode(&v); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bumps, err := tx.CreateBucketIfNotExists(feeBumpsBucket)
+		if err != nil {
+			return err
+		}
+
+		return bumps.Put(bump.parentTxid[:], v.Bytes())
+	})
+}
+
+// FetchFeeBumps returns the most recent fee bump of every transaction that was
+// bumped.
+//
+// NOTE: Part of the FeeBumpStore interface.
+func (s *feeBumpStore) FetchFeeBumps() ([]*feeBump, error) {
+	var bumps []*feeBump
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bumpsBucket := tx.Bucket(feeBumpsBucket)
+		if bumpsBucket == nil {
+			return nil
+		}
+
+		return bumpsBucket.ForEach(func(_, v []byte) error {
+			bump := &feeBump{}
+			if err := bump.Decode(bytes.NewReader(v)); err != nil {
+				return err
+			}
+
+			bumps = append(bumps, bump)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bumps, nil
+}
@@ -0,0 +1,503 @@
+// +build !rpctest
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// feeBumperTestWallet is a wallet holding a single unconfirmed transaction,
+// which spends the funding output of a channel.
+type feeBumperTestWallet struct {
+	*mockWalletController
+
+	parentTx *wire.MsgTx
+
+	mu        sync.Mutex
+	utxos     []*lnwallet.Utxo
+	outputs   map[wire.OutPoint]*wire.TxOut
+	published []*wire.MsgTx
+}
+
+func (w *feeBumperTestWallet) FetchInputInfo(
+	prevOut *wire.OutPoint) (*wire.TxOut, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	txOut, ok := w.outputs[*prevOut]
+	if !ok {
+		return nil, lnwallet.ErrNotMine
+	}
+
+	return txOut, nil
+}
+
+func (w *feeBumperTestWallet) ListUnspentWitness(
+	confirms int32) ([]*lnwallet.Utxo, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.utxos, nil
+}
+
+func (w *feeBumperTestWallet) ListTransactionDetails() (
+	[]*lnwallet.TransactionDetail, error) {
+
+	return []*lnwallet.TransactionDetail{
+		{
+			Hash:  w.parentTx.TxHash(),
+			RawTx: w.parentTx,
+		},
+	}, nil
+}
+
+// PublishTransaction records the passed transaction, and removes the outputs
+// it spends from the unspent outputs of the wallet.
+func (w *feeBumperTestWallet) PublishTransaction(tx *wire.MsgTx) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.published = append(w.published, tx)
+
+	var utxos []*lnwallet.Utxo
+	for _, utxo := range w.utxos {
+		if utxo.OutPoint != tx.TxIn[0].PreviousOutPoint {
+			utxos = append(utxos, utxo)
+		}
+	}
+	w.utxos = utxos
+
+	return nil
+}
+
+// feeBumperTestNotifier is a chain notifier that lets the test trigger the
+// confirmation of the transactions the fee bumper tracks.
+type feeBumperTestNotifier struct {
+	mockNotfier
+
+	mu        sync.Mutex
+	confChans map[chainhash.Hash]chan *chainntnfs.TxConfirmation
+}
+
+func (n *feeBumperTestNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	confChan := make(chan *chainntnfs.TxConfirmation, 1)
+	n.confChans[*txid] = confChan
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed: confChan,
+	}, nil
+}
+
+// feeBumperTestContext bundles a fee bumper with the mocked subsystems it's
+// backed by.
+type feeBumperTestContext struct {
+	t *testing.T
+
+	bumper   *feeBumper
+	wallet   *feeBumperTestWallet
+	notifier *feeBumperTestNotifier
+	store    FeeBumpStore
+
+	// sweepReport is returned when bumping the sweep of an output, unless
+	// it's nil, in which case the output isn't being swept.
+	sweepReport *pendingSweepReport
+
+	cleanUp func()
+}
+
+const (
+	// feeBumperTestCapacity is the capacity of the channel whose
+	// commitment is the parent bumped by the tests.
+	feeBumperTestCapacity = 5010000
+
+	// feeBumperTestCommitFee is the fee paid by the commitment.
+	feeBumperTestCommitFee = 10000
+)
+
+// newFeeBumperTestContext creates and starts a fee bumper backed by a wallet
+// whose only unconfirmed transaction is the commitment of a channel, with the
+// first of its outputs paying to the wallet.
+func newFeeBumperTestContext(t *testing.T) *feeBumperTestContext {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+
+	walletScript := append([]byte{0x00, 0x14}, make([]byte, 20)...)
+
+	chanPoint := wire.OutPoint{Hash: chainhash.Hash{0x01}}
+	commitTx := wire.NewMsgTx(2)
+	commitTx.AddTxIn(&wire.TxIn{PreviousOutPoint: chanPoint})
+	commitTx.AddTxOut(&wire.TxOut{
+		PkScript: walletScript,
+		Value:    1000000,
+	})
+	commitTx.AddTxOut(&wire.TxOut{
+		PkScript: walletScript,
+		Value: feeBumperTestCapacity - feeBumperTestCommitFee -
+			1000000,
+	})
+
+	walletOutpoint := wire.OutPoint{Hash: commitTx.TxHash()}
+	walletOutput := commitTx.TxOut[0]
+
+	ctx := &feeBumperTestContext{
+		t: t,
+		wallet: &feeBumperTestWallet{
+			mockWalletController: &mockWalletController{
+				rootKey: sweeperTestPrivKey,
+			},
+			parentTx: commitTx,
+			utxos: []*lnwallet.Utxo{
+				{
+					AddressType: lnwallet.WitnessPubKey,
+					Value: btcutil.Amount(
+						walletOutput.Value,
+					),
+					PkScript: walletOutput.PkScript,
+					OutPoint: walletOutpoint,
+				},
+			},
+			outputs: map[wire.OutPoint]*wire.TxOut{
+				walletOutpoint: walletOutput,
+			},
+		},
+		notifier: &feeBumperTestNotifier{
+			confChans: make(
+				map[chainhash.Hash]chan *chainntnfs.TxConfirmation,
+			),
+		},
+		store:   newFeeBumpStore(cdb),
+		cleanUp: cleanUp,
+	}
+	ctx.startBumper()
+
+	return ctx
+}
+
+// startBumper creates and starts a new fee bumper on top of the store of the
+// test context, stopping the previous one if any.
+func (ctx *feeBumperTestContext) startBumper() {
+	if ctx.bumper != nil {
+		ctx.bumper.Stop()
+	}
+
+	chanPoint := ctx.wallet.parentTx.TxIn[0].PreviousOutPoint
+
+	ctx.bumper = newFeeBumper(&FeeBumperConfig{
+		Wallet: ctx.wallet,
+		Signer: &sweeperTestSigner{},
+		Estimator: lnwallet.StaticFeeEstimator{
+			FeeRate: 400,
+		},
+		ChainIO:  &mockChainIO{},
+		Notifier: ctx.notifier,
+		BumpSweep: func(wire.OutPoint, uint32,
+			btcutil.Amount) (*pendingSweepReport, error) {
+
+			if ctx.sweepReport == nil {
+				return nil, ErrUnknownSweepInput
+			}
+			return ctx.sweepReport, nil
+		},
+		FetchChannelCapacity: func(op wire.OutPoint) (btcutil.Amount,
+			error) {
+
+			if op != chanPoint {
+				return 0, fmt.Errorf("unknown channel")
+			}
+			return feeBumperTestCapacity, nil
+		},
+		Store: ctx.store,
+	})
+	if err := ctx.bumper.Start(); err != nil {
+		ctx.t.Fatalf("unable to start fee bumper: %v", err)
+	}
+}
+
+// stop stops the fee bumper and cleans up its store.
+func (ctx *feeBumperTestContext) stop() {
+	ctx.bumper.Stop()
+	ctx.cleanUp()
+}
+
+// walletOutpoint returns the output of the parent paying to the wallet.
+func (ctx *feeBumperTestContext) walletOutpoint() wire.OutPoint {
+	return wire.OutPoint{Hash: ctx.wallet.parentTx.TxHash()}
+}
+
+// receiveChild returns the child transaction that was published last.
+func (ctx *feeBumperTestContext) receiveChild() *wire.MsgTx {
+	ctx.wallet.mu.Lock()
+	defer ctx.wallet.mu.Unlock()
+
+	if len(ctx.wallet.published) == 0 {
+		ctx.t.Fatalf("no child tx published")
+	}
+
+	return ctx.wallet.published[len(ctx.wallet.published)-1]
+}
+
+// assertFeeBump asserts that the fee bumper reports the passed bump for the
+// parent transaction.
+func (ctx *feeBumperTestContext) assertFeeBump(expected *feeBump) {
+	bump := ctx.bumper.FeeBump(ctx.wallet.parentTx.TxHash())
+	if !reflect.DeepEqual(bump, expected) {
+		ctx.t.Fatalf("expected fee bump %+v, got %+v", expected, bump)
+	}
+}
+
+// childWeight is the weight of a child spending a P2WKH output to another.
+func childWeight() int64 {
+	var weightEstimate lnwallet.TxWeightEstimator
+	weightEstimate.AddP2WKHInput()
+	weightEstimate.AddP2WKHOutput()
+
+	return int64(weightEstimate.Weight())
+}
+
+// TestCpfpChildFee asserts that a child pays for the whole package, minus the
+// fee of its parent, and that a replacement child pays for its own relay on
+// top of the fee of the child it replaces.
+func TestCpfpChildFee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		parentWeight int64
+		parentFee    btcutil.Amount
+		childWeight  int64
+		feeRate      btcutil.Amount
+		prevChildFee btcutil.Amount
+		childFee     btcutil.Amount
+	}{
+		{
+			name:         "parent without fee",
+			parentWeight: 700,
+			childWeight:  441,
+			feeRate:      10,
+			childFee:     11410,
+		},
+		{
+			name:         "parent with fee",
+			parentWeight: 700,
+			parentFee:    3500,
+			childWeight:  441,
+			feeRate:      10,
+			childFee:     7910,
+		},
+		{
+			name:         "replacement paying more than required",
+			parentWeight: 700,
+			parentFee:    3500,
+			childWeight:  441,
+			feeRate:      20,
+			prevChildFee: 7910,
+			childFee:     19320,
+		},
+		{
+			// The replacement pays for its own virtual size of 111
+			// bytes at the minimum relay fee rate.
+			name:         "replacement paying its own relay",
+			parentWeight: 700,
+			parentFee:    3500,
+			childWeight:  441,
+			feeRate:      10,
+			prevChildFee: 7910,
+			childFee:     8021,
+		},
+	}
+
+	for _, test := range tests {
+		childFee := cpfpChildFee(
+			test.parentWeight, test.parentFee, test.childWeight,
+			test.feeRate, test.prevChildFee,
+		)
+		if childFee != test.childFee {
+			t.Fatalf("%v: expected child fee %v, got %v",
+				test.name, test.childFee, childFee)
+		}
+	}
+}
+
+// TestFeeBumpSerialization asserts that a fee bump survives a serialization
+// round trip.
+func TestFeeBumpSerialization(t *testing.T) {
+	t.Parallel()
+
+	bump := &feeBump{
+		parentTxid:     chainhash.Hash{0x01},
+		childTxid:      chainhash.Hash{0x02},
+		outpoint:       wire.OutPoint{Hash: chainhash.Hash{0x03}, Index: 1},
+		childFee:       12345,
+		packageFeeRate: 25,
+		heightHint:     100,
+		confirmed:      true,
+	}
+
+	var b bytes.Buffer
+	if err := bump.Encode(&b); err != nil {
+		t.Fatalf("unable to serialize fee bump: %v", err)
+	}
+
+	var deserializedBump feeBump
+	if err := deserializedBump.Decode(&b); err != nil {
+		t.Fatalf("unable to deserialize fee bump: %v", err)
+	}
+
+	if !reflect.DeepEqual(*bump, deserializedBump) {
+		t.Fatalf("unexpected fee bump, want %+v, got %+v", bump,
+			deserializedBump)
+	}
+}
+
+// TestFeeBumperCPFP asserts that the child of a commitment accounts for the
+// fee the commitment pays, that bumping it again replaces the child, and that
+// the bump is still reported and tracked after a restart.
+func TestFeeBumperCPFP(t *testing.T) {
+	t.Parallel()
+
+	ctx := newFeeBumperTestContext(t)
+	defer ctx.stop()
+
+	parentTxid := ctx.wallet.parentTx.TxHash()
+	parentWeight := blockchain.GetTransactionWeight(
+		btcutil.NewTx(ctx.wallet.parentTx),
+	)
+	walletOutpoint := ctx.walletOutpoint()
+
+	// The commitment spends the funding output, which doesn't belong to
+	// the wallet, so its fee is determined from the channel capacity.
+	bump, err := ctx.bumper.BumpFee(walletOutpoint, 0, 100)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+
+	expectedFee := cpfpChildFee(
+		parentWeight, feeBumperTestCommitFee, childWeight(), 100, 0,
+	)
+	if bump.childFee != expectedFee {
+		t.Fatalf("expected child fee %v, got %v", expectedFee,
+			bump.childFee)
+	}
+
+	child := ctx.receiveChild()
+	if child.TxIn[0].PreviousOutPoint != walletOutpoint {
+		t.Fatalf("child doesn't spend the wallet output")
+	}
+	if child.TxOut[0].Value != 1000000-int64(expectedFee) {
+		t.Fatalf("child pays wrong fee")
+	}
+	ctx.assertFeeBump(bump)
+
+	// Bumping the same output again should replace the child, even though
+	// the output is no longer unspent.
+	replacement, err := ctx.bumper.BumpFee(walletOutpoint, 0, 150)
+	if err != nil {
+		t.Fatalf("unable to bump fee again: %v", err)
+	}
+	if replacement.childFee <= bump.childFee {
+		t.Fatalf("replacement pays %v, not more than the %v of the "+
+			"child it replaces", replacement.childFee,
+			bump.childFee)
+	}
+
+	replacementChild := ctx.receiveChild()
+	if replacementChild.TxHash() == child.TxHash() {
+		t.Fatalf("child wasn't replaced")
+	}
+	if replacementChild.TxIn[0].PreviousOutPoint != walletOutpoint {
+		t.Fatalf("replacement doesn't spend the wallet output")
+	}
+	ctx.assertFeeBump(replacement)
+
+	// After a restart, the replacement should still be reported, and its
+	// confirmation tracked.
+	ctx.startBumper()
+	ctx.assertFeeBump(replacement)
+
+	ctx.notifier.mu.Lock()
+	confChan, ok := ctx.notifier.confChans[replacement.childTxid]
+	ctx.notifier.mu.Unlock()
+	if !ok {
+		t.Fatalf("replacement child not tracked after restart")
+	}
+	confChan <- &chainntnfs.TxConfirmation{}
+
+	confirmed := *replacement
+	confirmed.confirmed = true
+	for i := 0; ; i++ {
+		if ctx.bumper.FeeBump(parentTxid).confirmed {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("fee bump not marked confirmed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Once confirmed, the bump should be reported as such after another
+	// restart.
+	ctx.bumper.Stop()
+	bumps, err := ctx.store.FetchFeeBumps()
+	if err != nil {
+		t.Fatalf("unable to fetch fee bumps: %v", err)
+	}
+	if len(bumps) != 1 || !reflect.DeepEqual(bumps[0], &confirmed) {
+		t.Fatalf("expected stored fee bump %+v, got %v", confirmed,
+			bumps)
+	}
+}
+
+// TestFeeBumperSweep asserts that an output that's being swept is bumped by
+// replacing its sweep, rather than by publishing a child.
+func TestFeeBumperSweep(t *testing.T) {
+	t.Parallel()
+
+	ctx := newFeeBumperTestContext(t)
+	defer ctx.stop()
+
+	sweepTxid := chainhash.Hash{0x02}
+	ctx.sweepReport = &pendingSweepReport{
+		outPoint:  ctx.walletOutpoint(),
+		feeRate:   50,
+		sweepTxid: &sweepTxid,
+	}
+
+	bump, err := ctx.bumper.BumpFee(ctx.walletOutpoint(), 0, 50)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if bump.childTxid != sweepTxid {
+		t.Fatalf("expected sweep %v as child, got %v", sweepTxid,
+			bump.childTxid)
+	}
+	if bump.packageFeeRate != 50 {
+		t.Fatalf("expected fee rate 50, got %v", bump.packageFeeRate)
+	}
+
+	ctx.wallet.mu.Lock()
+	defer ctx.wallet.mu.Unlock()
+	if len(ctx.wallet.published) != 0 {
+		t.Fatalf("child published for swept output")
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -47,6 +49,11 @@ const (
 	// for the funding transaction to be confirmed before forgetting about
 	// the channel. 288 blocks is ~48 hrs
 	maxWaitNumBlocksFundingConf = 288
+
+	// defaultPsbtFundingTimeout is the default time we wait for an
+	// external wallet to return the signed PSBT of a channel funded
+	// through a PSBT, before cancelling the reservation.
+	defaultPsbtFundingTimeout = 10 * time.Minute
 )
 
 // reservationWithCtx encapsulates a pending channel reservation. This wrapper
@@ -64,6 +71,11 @@ type reservationWithCtx struct {
 
 	chanAmt btcutil.Amount
 
+	// remoteContribution is the counterparty's contribution to a channel
+	// funded through a PSBT. It's only set while the funding flow is
+	// paused, waiting for the external wallet to sign the PSBT.
+	remoteContribution *lnwallet.ChannelContribution
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -125,6 +137,31 @@ type fundingErrorMsg struct {
 	peerAddress *lnwire.NetAddress
 }
 
+// psbtFinalizeMsg is sent by an outside subsystem to the funding manager in
+// order to resume a funding workflow that's waiting for an external wallet to
+// fund the channel through a PSBT.
+type psbtFinalizeMsg struct {
+	pendingChanID [32]byte
+	packet        *psbt.Packet
+	resp          chan *chainhash.Hash
+	err           chan error
+}
+
+// psbtCancelMsg is sent by an outside subsystem to the funding manager in
+// order to abort a funding workflow that's waiting for an external wallet to
+// fund the channel through a PSBT.
+type psbtCancelMsg struct {
+	pendingChanID [32]byte
+	err           chan error
+}
+
+// psbtTimeoutMsg is sent to the funding manager once a funding workflow has
+// been waiting for the signed PSBT of an external wallet for longer than the
+// PsbtFundingTimeout.
+type psbtTimeoutMsg struct {
+	pendingChanID [32]byte
+}
+
 // pendingChannels is a map instantiated per-peer which tracks all active
 // pending single funded channels indexed by their pending channel identifier,
 // which is a set of 32-bytes generated via a CSPRNG.
@@ -229,6 +266,17 @@ type fundingConfig struct {
 	// in order to give us more time to claim funds in the case of a
 	// contract breach.
 	RequiredRemoteDelay func(btcutil.Amount) uint16
+
+	// PsbtFundingTimeout is the maximum time a funding workflow waits for
+	// an external wallet to return the signed PSBT of a channel funded
+	// through a PSBT. Once it expires, the reservation is cancelled, such
+	// that it doesn't linger if the external wallet never signs.
+	PsbtFundingTimeout time.Duration
+
+	// ChannelsChanged is called each time a channel is committed to disk,
+	// confirmed, or deleted before it was ever opened. This allows the
+	// on-disk static channel backup to be kept up to date.
+	ChannelsChanged func()
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -410,6 +458,7 @@ func (f *fundingManager) Start() error {
 					fndgLog.Errorf("Failed closing channel "+
 						"%v: %v", ch.FundingOutpoint, err)
 				}
+				f.cfg.ChannelsChanged()
 			case <-f.quit:
 				// The fundingManager is shutting down, and will
 				// resume wait on startup.
@@ -700,6 +749,12 @@ func (f *fundingManager) reservationCoordinator() {
 				go f.handleFundingLocked(fmsg)
 			case *fundingErrorMsg:
 				f.handleErrorMsg(fmsg)
+			case *psbtFinalizeMsg:
+				f.handlePsbtFinalize(fmsg)
+			case *psbtCancelMsg:
+				f.handlePsbtCancel(fmsg)
+			case *psbtTimeoutMsg:
+				f.handlePsbtTimeout(fmsg)
 			}
 		case req := <-f.fundingRequests:
 			f.handleInitFundingMsg(req)
@@ -825,7 +880,7 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	reservation, err := f.cfg.Wallet.InitChannelReservation(amt, 0,
 		msg.PushAmount, btcutil.Amount(msg.FeePerKiloWeight), 0,
 		fmsg.peerAddress.IdentityKey, fmsg.peerAddress.Address,
-		&chainHash, msg.ChannelFlags)
+		&chainHash, msg.ChannelFlags, nil, false)
 	if err != nil {
 		fndgLog.Errorf("Unable to initialize reservation: %v", err)
 		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
@@ -1019,6 +1074,22 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		},
 	}
 	remoteContribution.CsvDelay = f.cfg.RequiredRemoteDelay(resCtx.chanAmt)
+
+	fndgLog.Infof("pendingChan(%x): remote party proposes num_confs=%v, "+
+		"csv_delay=%v", pendingChanID[:], msg.MinAcceptDepth, msg.CsvDelay)
+	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
+		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
+
+	// If the funding transaction is assembled by an external wallet, then
+	// we can't build the commitment transactions until it has signed the
+	// funding transaction. We'll hand it a PSBT paying to the funding
+	// output, and pause the funding flow until the signed PSBT is
+	// returned via FinalizePsbtFunding.
+	if resCtx.reservation.IsExternallyFunded() {
+		f.requestPsbtFunding(resCtx, pendingChanID, remoteContribution)
+		return
+	}
+
 	err = resCtx.reservation.ProcessContribution(remoteContribution)
 	if err != nil {
 		fndgLog.Errorf("Unable to process contribution from %v: %v",
@@ -1029,10 +1100,16 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		return
 	}
 
-	fndgLog.Infof("pendingChan(%x): remote party proposes num_confs=%v, "+
-		"csv_delay=%v", pendingChanID[:], msg.MinAcceptDepth, msg.CsvDelay)
-	fndgLog.Debugf("Remote party accepted commitment constraints: %v",
-		spew.Sdump(remoteContribution.ChannelConfig.ChannelConstraints))
+	f.sendFundingCreated(resCtx, pendingChanID)
+}
+
+// sendFundingCreated sends the funding outpoint along with our signature for
+// the remote party's version of the commitment transaction to the remote
+// peer, once their contribution has been processed.
+func (f *fundingManager) sendFundingCreated(resCtx *reservationWithCtx,
+	pendingChanID [32]byte) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
 
 	// Now that we have their contribution, we can extract, then send over
 	// both the funding out point and our signature for their version of
@@ -1042,8 +1119,7 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 	commitSig, err := btcec.ParseSignature(sig, btcec.S256())
 	if err != nil {
 		fndgLog.Errorf("Unable to parse signature: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, []byte(err.Error()))
+		f.failFundingFlow(peerKey, pendingChanID, []byte(err.Error()))
 		resCtx.err <- err
 		return
 	}
@@ -1074,16 +1150,296 @@ func (f *fundingManager) handleFundingAccept(fmsg *fundingAcceptMsg) {
 		FundingPoint:     *outPoint,
 		CommitSig:        commitSig,
 	}
-	err = f.cfg.SendToPeer(fmsg.peerAddress.IdentityKey, fundingCreated)
+	err = f.cfg.SendToPeer(peerKey, fundingCreated)
 	if err != nil {
 		fndgLog.Errorf("Unable to send funding complete message: %v", err)
-		f.failFundingFlow(fmsg.peerAddress.IdentityKey,
-			msg.PendingChannelID, []byte(err.Error()))
+		f.failFundingFlow(peerKey, pendingChanID, []byte(err.Error()))
 		resCtx.err <- err
 		return
 	}
 }
 
+// requestPsbtFunding hands the external wallet funding the passed reservation
+// a PSBT that pays the full channel capacity to the funding output, and
+// records the remote party's contribution so the funding flow can resume once
+// the signed PSBT is returned.
+func (f *fundingManager) requestPsbtFunding(resCtx *reservationWithCtx,
+	pendingChanID [32]byte,
+	remoteContribution *lnwallet.ChannelContribution) {
+
+	fail := func(err error) {
+		fndgLog.Errorf("Unable to request PSBT funding for "+
+			"pendingID(%x): %v", pendingChanID[:], err)
+		f.failFundingFlow(resCtx.peerAddress.IdentityKey,
+			pendingChanID, []byte(err.Error()))
+		resCtx.err <- err
+	}
+
+	fundingOutput, err := resCtx.reservation.FundingOutput(
+		remoteContribution.MultiSigKey,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	// The template only pays to the funding output. The external wallet
+	// adds its own inputs, and any change outputs.
+	template := wire.NewMsgTx(2)
+	template.AddTxOut(fundingOutput)
+	packet, err := psbt.NewFromUnsignedTx(template)
+	if err != nil {
+		fail(err)
+		return
+	}
+	var b bytes.Buffer
+	if err := packet.Serialize(&b); err != nil {
+		fail(err)
+		return
+	}
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		fundingOutput.PkScript, &f.cfg.Wallet.Cfg.NetParams,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	resCtx.remoteContribution = remoteContribution
+
+	fndgLog.Infof("Waiting for PSBT funding %v to %v for pendingID(%x)",
+		btcutil.Amount(fundingOutput.Value), addrs[0], pendingChanID[:])
+
+	f.wg.Add(1)
+	go f.waitForPsbtFunding(pendingChanID)
+
+	update := &lnrpc.OpenStatusUpdate{
+		Update: &lnrpc.OpenStatusUpdate_PsbtFund{
+			PsbtFund: &lnrpc.ReadyForPsbtFunding{
+				PendingChanId:  pendingChanID[:],
+				FundingAddress: addrs[0].String(),
+				FundingAmount:  fundingOutput.Value,
+				Psbt:           b.Bytes(),
+			},
+		},
+	}
+	select {
+	case resCtx.updates <- update:
+	case <-f.quit:
+	}
+}
+
+// FinalizePsbtFunding resumes the funding workflow of the channel with the
+// passed pending channel ID, which is waiting for the signed PSBT of an
+// external wallet. The PSBT must pay the full channel capacity to the funding
+// output, and all its inputs must be finalized. The funding transaction is
+// only broadcast once the remote party has signed our commitment transaction.
+// The txid of the funding transaction is returned.
+func (f *fundingManager) FinalizePsbtFunding(pendingChanID [32]byte,
+	packet *psbt.Packet) (*chainhash.Hash, error) {
+
+	respChan := make(chan *chainhash.Hash, 1)
+	errChan := make(chan error, 1)
+
+	select {
+	case f.fundingMsgs <- &psbtFinalizeMsg{
+		pendingChanID: pendingChanID,
+		packet:        packet,
+		resp:          respChan,
+		err:           errChan,
+	}:
+	case <-f.quit:
+		return nil, fmt.Errorf("funding manager shutting down")
+	}
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return nil, err
+		}
+		return <-respChan, nil
+	case <-f.quit:
+		return nil, fmt.Errorf("funding manager shutting down")
+	}
+}
+
+// CancelPsbtFunding aborts the funding workflow of the channel with the
+// passed pending channel ID, which is waiting for the signed PSBT of an
+// external wallet.
+func (f *fundingManager) CancelPsbtFunding(pendingChanID [32]byte) error {
+	errChan := make(chan error, 1)
+
+	select {
+	case f.fundingMsgs <- &psbtCancelMsg{
+		pendingChanID: pendingChanID,
+		err:           errChan,
+	}:
+	case <-f.quit:
+		return fmt.Errorf("funding manager shutting down")
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-f.quit:
+		return fmt.Errorf("funding manager shutting down")
+	}
+}
+
+// handlePsbtFinalize processes the signed PSBT of an external wallet, and
+// resumes the paused funding workflow. If the PSBT is rejected, then the
+// workflow stays paused, such that a corrected PSBT can be submitted.
+func (f *fundingManager) handlePsbtFinalize(msg *psbtFinalizeMsg) {
+	pendingChanID := msg.pendingChanID
+
+	resCtx, err := f.getPsbtReservationCtx(pendingChanID)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	fundingTx, err := msg.packet.Extract()
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	// The wallet verifies that the funding transaction pays to the
+	// funding output before building the commitment transactions.
+	err = resCtx.reservation.ProcessExternalContribution(
+		resCtx.remoteContribution, fundingTx,
+	)
+	if err != nil {
+		fndgLog.Errorf("Rejected PSBT funding for pendingID(%x): %v",
+			pendingChanID[:], err)
+		msg.err <- err
+		return
+	}
+	resCtx.remoteContribution = nil
+
+	fundingTxID := fundingTx.TxHash()
+	fndgLog.Infof("Received signed funding tx %v for pendingID(%x)",
+		fundingTxID, pendingChanID[:])
+
+	msg.resp <- &fundingTxID
+	msg.err <- nil
+
+	f.sendFundingCreated(resCtx, pendingChanID)
+}
+
+// handlePsbtCancel aborts a funding workflow that's waiting for the signed
+// PSBT of an external wallet.
+func (f *fundingManager) handlePsbtCancel(msg *psbtCancelMsg) {
+	pendingChanID := msg.pendingChanID
+
+	resCtx, err := f.getPsbtReservationCtx(pendingChanID)
+	if err != nil {
+		msg.err <- err
+		return
+	}
+
+	fndgLog.Infof("Cancelling PSBT funding for pendingID(%x)",
+		pendingChanID[:])
+
+	f.abortPsbtFunding(resCtx, pendingChanID,
+		fmt.Errorf("psbt funding cancelled"))
+
+	msg.err <- nil
+}
+
+// waitForPsbtFunding sends a psbtTimeoutMsg to the reservationCoordinator
+// once the PsbtFundingTimeout has expired. If the signed PSBT has been
+// received, or the workflow was cancelled in the meantime, then the message
+// is ignored.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *fundingManager) waitForPsbtFunding(pendingChanID [32]byte) {
+	defer f.wg.Done()
+
+	select {
+	case <-time.After(f.cfg.PsbtFundingTimeout):
+	case <-f.quit:
+		return
+	}
+
+	select {
+	case f.fundingMsgs <- &psbtTimeoutMsg{pendingChanID}:
+	case <-f.quit:
+	}
+}
+
+// handlePsbtTimeout cancels a funding workflow that's still waiting for the
+// signed PSBT of an external wallet after the PsbtFundingTimeout has expired.
+func (f *fundingManager) handlePsbtTimeout(msg *psbtTimeoutMsg) {
+	pendingChanID := msg.pendingChanID
+
+	// If the workflow isn't waiting anymore, then the signed PSBT has
+	// been received, or the workflow was cancelled before the timeout.
+	resCtx, err := f.getPsbtReservationCtx(pendingChanID)
+	if err != nil {
+		return
+	}
+
+	fndgLog.Warnf("Timed out waiting for PSBT funding for pendingID(%x), "+
+		"cancelling reservation", pendingChanID[:])
+
+	f.abortPsbtFunding(resCtx, pendingChanID,
+		fmt.Errorf("timed out waiting for signed psbt"))
+}
+
+// abortPsbtFunding cancels the reservation of a funding workflow that's
+// waiting for the signed PSBT of an external wallet, informs the remote peer,
+// and fails the local open channel request with the passed error. Unlike
+// failFundingFlow, the reservation is cancelled even if the peer can't be
+// reached, as the workflow can't be resumed anymore.
+func (f *fundingManager) abortPsbtFunding(resCtx *reservationWithCtx,
+	pendingChanID [32]byte, reason error) {
+
+	peerKey := resCtx.peerAddress.IdentityKey
+
+	errMsg := &lnwire.Error{
+		ChanID: pendingChanID,
+		Data:   []byte(reason.Error()),
+	}
+	if err := f.cfg.SendToPeer(peerKey, errMsg); err != nil {
+		fndgLog.Errorf("unable to send error message to peer %v", err)
+	}
+
+	if _, err := f.cancelReservationCtx(peerKey, pendingChanID); err != nil {
+		fndgLog.Errorf("unable to cancel reservation: %v", err)
+	}
+
+	resCtx.err <- reason
+}
+
+// getPsbtReservationCtx returns the reservation context of the funding
+// workflow with the passed pending channel ID, which must be waiting for the
+// signed PSBT of an external wallet.
+func (f *fundingManager) getPsbtReservationCtx(
+	pendingChanID [32]byte) (*reservationWithCtx, error) {
+
+	f.resMtx.RLock()
+	defer f.resMtx.RUnlock()
+
+	for _, nodeReservations := range f.activeReservations {
+		resCtx, ok := nodeReservations[pendingChanID]
+		if !ok {
+			continue
+		}
+
+		if resCtx.remoteContribution == nil {
+			return nil, errors.Errorf("channel (id: %x) isn't "+
+				"waiting for PSBT funding", pendingChanID[:])
+		}
+
+		return resCtx, nil
+	}
+
+	return nil, errors.Errorf("unknown channel (id: %x)",
+		pendingChanID[:])
+}
+
 // processFundingCreated queues a funding complete message coupled with the
 // source peer to the fundingManager.
 func (f *fundingManager) processFundingCreated(msg *lnwire.FundingCreated,
@@ -1151,8 +1507,13 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 			fndgLog.Errorf("Failed closing channel %v: %v",
 				completeChan.FundingOutpoint, err)
 		}
+		f.cfg.ChannelsChanged()
 	}
 
+	// Now that the pending channel has been written to disk, we'll update
+	// our channel backup to include it.
+	f.cfg.ChannelsChanged()
+
 	// A new channel has almost finished the funding process. In order to
 	// properly synchronize with the writeHandler goroutine, we add a new
 	// channel to the barriers map which will be closed once the channel is
@@ -1323,6 +1684,10 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 		return
 	}
 
+	// With the channel committed to disk, we'll update our channel backup
+	// to include it.
+	f.cfg.ChannelsChanged()
+
 	fndgLog.Infof("Finalizing pendingID(%x) over ChannelPoint(%v), "+
 		"waiting for channel open on-chain", pendingChanID[:], fundingPoint)
 
@@ -1579,6 +1944,10 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 		return
 	}
 
+	// The short channel ID of the channel is now known, so we'll update
+	// the backup to reflect it.
+	f.cfg.ChannelsChanged()
+
 	// TODO(roasbeef): ideally persistent state update for chan above
 	// should be abstracted
 
@@ -2286,7 +2655,8 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	// request will fail, and be aborted.
 	reservation, err := f.cfg.Wallet.InitChannelReservation(capacity,
 		localAmt, msg.pushAmt, commitFeePerKw, msg.fundingFeePerWeight,
-		peerKey, msg.peerAddress.Address, &msg.chainHash, channelFlags)
+		peerKey, msg.peerAddress.Address, &msg.chainHash, channelFlags,
+		msg.fundingInputs, msg.psbtFunding)
 	if err != nil {
 		msg.err <- err
 		return
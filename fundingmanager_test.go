@@ -3,6 +3,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -17,6 +18,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	_ "github.com/roasbeef/btcwallet/walletdb/bdb"
@@ -270,6 +272,8 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		RequiredRemoteDelay: func(amt btcutil.Amount) uint16 {
 			return 4
 		},
+		PsbtFundingTimeout: defaultPsbtFundingTimeout,
+		ChannelsChanged:    func() {},
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -339,9 +343,10 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		NotifyWhenOnline: func(peer *btcec.PublicKey, connectedChan chan<- struct{}) {
 			t.Fatalf("did not expect fundingManager to call NotifyWhenOnline")
 		},
-		FindPeer:       oldCfg.FindPeer,
-		TempChanIDSeed: oldCfg.TempChanIDSeed,
-		FindChannel:    oldCfg.FindChannel,
+		FindPeer:        oldCfg.FindPeer,
+		TempChanIDSeed:  oldCfg.TempChanIDSeed,
+		FindChannel:     oldCfg.FindChannel,
+		ChannelsChanged: oldCfg.ChannelsChanged,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -1559,3 +1564,325 @@ func TestFundingManagerPrivateRestart(t *testing.T) {
 	// from the database, as the channel is announced.
 	assertNoChannelState(t, alice, bob, fundingOutPoint)
 }
+
+// startPsbtFunding takes the funding process of a channel funded through a
+// PSBT to the point where Alice waits for the signed PSBT, and returns the
+// PSBT funding request Alice hands to the external wallet.
+func startPsbtFunding(t *testing.T, alice, bob *testNode,
+	localFundingAmt btcutil.Amount, initReq *openChanReq,
+	updateChan chan *lnrpc.OpenStatusUpdate) *lnrpc.ReadyForPsbtFunding {
+
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	// Alice should have sent the OpenChannel message to Bob.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case err := <-initReq.err:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+	openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+	if !ok {
+		t.Fatalf("expected OpenChannel to be sent from alice, "+
+			"instead got %T", aliceMsg)
+	}
+
+	// Let Bob handle the init message, and forward his AcceptChannel to
+	// Alice.
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+
+	var bobMsg lnwire.Message
+	select {
+	case bobMsg = <-bob.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not send AcceptChannel message")
+	}
+	acceptChannelResponse, ok := bobMsg.(*lnwire.AcceptChannel)
+	if !ok {
+		t.Fatalf("expected AcceptChannel to be sent from bob, "+
+			"instead got %T", bobMsg)
+	}
+
+	alice.fundingMgr.processFundingAccept(acceptChannelResponse, bobAddr)
+
+	// Rather than sending FundingCreated, Alice should now ask for the
+	// signed PSBT.
+	var update *lnrpc.OpenStatusUpdate
+	select {
+	case update = <-updateChan:
+	case err := <-initReq.err:
+		t.Fatalf("error awaiting psbt funding: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_PsbtFund")
+	}
+	psbtFund, ok := update.Update.(*lnrpc.OpenStatusUpdate_PsbtFund)
+	if !ok {
+		t.Fatalf("expected OpenStatusUpdate_PsbtFund, instead got %T",
+			update.Update)
+	}
+
+	ready := psbtFund.PsbtFund
+	if ready.FundingAmount != int64(localFundingAmt) {
+		t.Fatalf("expected funding amount %v, got %v",
+			localFundingAmt, ready.FundingAmount)
+	}
+
+	return ready
+}
+
+// fundPsbt funds the PSBT template of the passed request, the way an
+// external wallet would. It adds a single input finalized as described by
+// the passed PInput, along with a change output.
+func fundPsbt(t *testing.T, ready *lnrpc.ReadyForPsbtFunding,
+	input psbt.PInput) *psbt.Packet {
+
+	packet, err := psbt.Parse(bytes.NewReader(ready.Psbt))
+	if err != nil {
+		t.Fatalf("unable to parse psbt template: %v", err)
+	}
+	if len(packet.UnsignedTx.TxOut) != 1 {
+		t.Fatalf("expected template to have 1 output, has %v",
+			len(packet.UnsignedTx.TxOut))
+	}
+
+	packet.UnsignedTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{
+			Hash:  chainhash.Hash{0x01},
+			Index: 1,
+		},
+	})
+	packet.Inputs = append(packet.Inputs, input)
+
+	packet.UnsignedTx.AddTxOut(&wire.TxOut{
+		Value:    100000,
+		PkScript: []byte("change"),
+	})
+	packet.Outputs = append(packet.Outputs, psbt.POutput{})
+
+	return packet
+}
+
+// TestFundingManagerPsbtFunding checks that a channel funded through a PSBT
+// only proceeds once a signed PSBT that pays to the funding output is
+// received, and that the funding transaction of the external wallet is only
+// broadcast after Bob has signed Alice's commitment transaction.
+func TestFundingManagerPsbtFunding(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	localFundingAmt := btcutil.Amount(500000)
+	initReq := &openChanReq{
+		targetPeerID:    int32(1),
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: localFundingAmt,
+		psbtFunding:     true,
+		updates:         updateChan,
+		err:             make(chan error, 1),
+	}
+	ready := startPsbtFunding(
+		t, alice, bob, localFundingAmt, initReq, updateChan,
+	)
+
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], ready.PendingChanId)
+
+	signedInput := psbt.PInput{
+		FinalScriptWitness: wire.TxWitness{[]byte("sig"), []byte("key")},
+	}
+
+	// A PSBT that doesn't pay the channel capacity to the funding output
+	// must be rejected.
+	underpaying := fundPsbt(t, ready, signedInput)
+	underpaying.UnsignedTx.TxOut[0].Value--
+	_, err := alice.fundingMgr.FinalizePsbtFunding(pendingChanID, underpaying)
+	if err == nil {
+		t.Fatalf("expected psbt underpaying the funding output to be " +
+			"rejected")
+	}
+
+	// As must a PSBT whose inputs aren't all finalized.
+	unsigned := fundPsbt(t, ready, psbt.PInput{})
+	_, err = alice.fundingMgr.FinalizePsbtFunding(pendingChanID, unsigned)
+	if err == nil {
+		t.Fatalf("expected psbt with unsigned input to be rejected")
+	}
+
+	// And a PSBT spending a non-witness input, since its txid could be
+	// malleated.
+	nonWitness := fundPsbt(t, ready, psbt.PInput{
+		FinalScriptSig: []byte("sig"),
+	})
+	_, err = alice.fundingMgr.FinalizePsbtFunding(pendingChanID, nonWitness)
+	if err == nil {
+		t.Fatalf("expected psbt with non-witness input to be rejected")
+	}
+
+	// None of the rejected PSBTs should have made Alice proceed.
+	select {
+	case msg := <-alice.msgChan:
+		t.Fatalf("alice unexpectedly sent %T", msg)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	// A correct PSBT resumes the funding flow.
+	packet := fundPsbt(t, ready, signedInput)
+	expectedTx, err := packet.Extract()
+	if err != nil {
+		t.Fatalf("unable to extract funding tx: %v", err)
+	}
+	fundingTxID, err := alice.fundingMgr.FinalizePsbtFunding(
+		pendingChanID, packet,
+	)
+	if err != nil {
+		t.Fatalf("unable to finalize psbt funding: %v", err)
+	}
+	if *fundingTxID != expectedTx.TxHash() {
+		t.Fatalf("expected funding txid %v, got %v",
+			expectedTx.TxHash(), fundingTxID)
+	}
+
+	// Alice should now send FundingCreated, with the funding outpoint of
+	// the external wallet's transaction.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send FundingCreated message")
+	}
+	fundingCreated, ok := aliceMsg.(*lnwire.FundingCreated)
+	if !ok {
+		t.Fatalf("expected FundingCreated to be sent from alice, "+
+			"instead got %T", aliceMsg)
+	}
+	if fundingCreated.FundingPoint.Hash != *fundingTxID {
+		t.Fatalf("expected funding outpoint to spend %v, got %v",
+			fundingTxID, fundingCreated.FundingPoint)
+	}
+
+	// The workflow isn't waiting for a PSBT anymore, so a second one must
+	// be rejected.
+	_, err = alice.fundingMgr.FinalizePsbtFunding(pendingChanID, packet)
+	if err == nil {
+		t.Fatalf("expected second psbt to be rejected")
+	}
+
+	// The funding transaction must not be broadcast before Bob has signed
+	// Alice's commitment transaction.
+	select {
+	case <-alice.publTxChan:
+		t.Fatalf("alice published funding tx before FundingSigned")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	bob.fundingMgr.processFundingCreated(fundingCreated, aliceAddr)
+
+	var bobMsg lnwire.Message
+	select {
+	case bobMsg = <-bob.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not send FundingSigned message")
+	}
+	fundingSigned, ok := bobMsg.(*lnwire.FundingSigned)
+	if !ok {
+		t.Fatalf("expected FundingSigned to be sent from bob, "+
+			"instead got %T", bobMsg)
+	}
+
+	alice.fundingMgr.processFundingSigned(fundingSigned, bobAddr)
+
+	var pendingUpdate *lnrpc.OpenStatusUpdate
+	select {
+	case pendingUpdate = <-updateChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenStatusUpdate_ChanPending")
+	}
+	_, ok = pendingUpdate.Update.(*lnrpc.OpenStatusUpdate_ChanPending)
+	if !ok {
+		t.Fatal("OpenStatusUpdate was not OpenStatusUpdate_ChanPending")
+	}
+
+	// Finally, Alice should broadcast exactly the transaction signed by
+	// the external wallet.
+	select {
+	case publ := <-alice.publTxChan:
+		if publ.TxHash() != *fundingTxID {
+			t.Fatalf("expected funding tx %v to be published, "+
+				"got %v", fundingTxID, publ.TxHash())
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not publish funding tx")
+	}
+}
+
+// TestFundingManagerPsbtFundingTimeout checks that a channel funded through a
+// PSBT is cancelled if the signed PSBT isn't received in time.
+func TestFundingManagerPsbtFundingTimeout(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	alice.fundingMgr.cfg.PsbtFundingTimeout = 500 * time.Millisecond
+
+	// We will consume the channel updates as we go, so no buffering is needed.
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+
+	localFundingAmt := btcutil.Amount(500000)
+	initReq := &openChanReq{
+		targetPeerID:    int32(1),
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: localFundingAmt,
+		psbtFunding:     true,
+		updates:         updateChan,
+		err:             make(chan error, 1),
+	}
+	ready := startPsbtFunding(
+		t, alice, bob, localFundingAmt, initReq, updateChan,
+	)
+
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], ready.PendingChanId)
+
+	// Once the timeout expires, Alice should tell Bob that the funding
+	// flow failed.
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send Error message")
+	}
+	if _, ok := aliceMsg.(*lnwire.Error); !ok {
+		t.Fatalf("expected Error to be sent from alice, instead got %T",
+			aliceMsg)
+	}
+
+	// The open channel request should fail.
+	select {
+	case <-initReq.err:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("open channel request did not fail")
+	}
+
+	// And the reservation should be gone, so a late PSBT is rejected.
+	_, err := alice.fundingMgr.getReservationCtx(bobPubKey, pendingChanID)
+	if err == nil {
+		t.Fatalf("expected reservation to be cancelled")
+	}
+
+	packet := fundPsbt(t, ready, psbt.PInput{
+		FinalScriptWitness: wire.TxWitness{[]byte("sig"), []byte("key")},
+	})
+	_, err = alice.fundingMgr.FinalizePsbtFunding(pendingChanID, packet)
+	if err == nil {
+		t.Fatalf("expected psbt to be rejected after the timeout")
+	}
+}
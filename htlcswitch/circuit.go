@@ -1,13 +1,38 @@
 package htlcswitch
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"sync"
 
+	"github.com/boltdb/bolt"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
+var (
+	// circuitAddKey is the key of the top-level bucket which stores all
+	// half circuits that have been committed by the switch. Each entry is
+	// keyed by the incoming HTLC of the circuit.
+	//
+	// maps: incomingChanID || incomingHTLCID -> paymentHash || encrypter
+	circuitAddKey = []byte("circuit-adds")
+
+	// circuitKeystoneKey is the key of the top-level bucket which stores
+	// the keystones of all opened circuits. A keystone binds the outgoing
+	// HTLC of a circuit to the incoming HTLC that it was forwarded from.
+	//
+	// maps: outgoingChanID || outgoingHTLCID -> incomingChanID || incomingHTLCID
+	circuitKeystoneKey = []byte("circuit-keystones")
+
+	// byteOrder is the byte order used to serialize circuit keys and
+	// values on disk.
+	byteOrder = binary.BigEndian
+)
+
 // PaymentCircuit is used by the HTLC switch subsystem to determine the
 // backwards path for the settle/fail HTLC messages. A payment circuit
 // will be created once a channel link forwards the HTLC add request and
@@ -36,11 +61,107 @@ type PaymentCircuit struct {
 	// outgoing channel.
 	OutgoingHTLCID uint64
 
+	// IncomingAmount is the value of the incoming HTLC.
+	IncomingAmount lnwire.MilliSatoshi
+
+	// OutgoingAmount is the value of the outgoing HTLC. The difference
+	// between the incoming and outgoing amount is the fee we earn once
+	// the circuit is settled.
+	OutgoingAmount lnwire.MilliSatoshi
+
 	// ErrorEncrypter is used to re-encrypt the onion failure before
 	// sending it back to the originator of the payment.
 	ErrorEncrypter ErrorEncrypter
 }
 
+// inKey returns the key of the incoming HTLC of the circuit.
+func (c *PaymentCircuit) inKey() circuitKey {
+	return circuitKey{
+		chanID: c.IncomingChanID,
+		htlcID: c.IncomingHTLCID,
+	}
+}
+
+// outKey returns the key of the outgoing HTLC of the circuit.
+func (c *PaymentCircuit) outKey() circuitKey {
+	return circuitKey{
+		chanID: c.OutgoingChanID,
+		htlcID: c.OutgoingHTLCID,
+	}
+}
+
+// isLocal returns true if the circuit belongs to a payment initiated by this
+// node. The incoming HTLC ID of such circuits is the payment ID the router
+// assigned to the HTLC, which allows the router to wait for the result of the
+// HTLC again after a restart.
+func (c *PaymentCircuit) isLocal() bool {
+	return c.IncomingChanID == (lnwire.ShortChannelID{})
+}
+
+// encodeHalf writes the half of the circuit which is known before the HTLC
+// has been added to the outgoing link: the payment hash, the amounts of the
+// incoming and outgoing HTLCs, and the error encrypter of the incoming HTLC.
+func (c *PaymentCircuit) encodeHalf(w io.Writer) error {
+	if _, err := w.Write(c.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	var amts [16]byte
+	byteOrder.PutUint64(amts[:8], uint64(c.IncomingAmount))
+	byteOrder.PutUint64(amts[8:], uint64(c.OutgoingAmount))
+	if _, err := w.Write(amts[:]); err != nil {
+		return err
+	}
+
+	// A circuit without an error encrypter is marked with a zero type byte
+	// and no payload.
+	if c.ErrorEncrypter == nil {
+		_, err := w.Write([]byte{byte(EncrypterTypeNone)})
+		return err
+	}
+
+	encType := c.ErrorEncrypter.Type()
+	if _, err := w.Write([]byte{byte(encType)}); err != nil {
+		return err
+	}
+
+	return c.ErrorEncrypter.Encode(w)
+}
+
+// decodeHalf reads the half circuit written by encodeHalf.
+func (c *PaymentCircuit) decodeHalf(r io.Reader) error {
+	if _, err := io.ReadFull(r, c.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	var amts [16]byte
+	if _, err := io.ReadFull(r, amts[:]); err != nil {
+		return err
+	}
+	c.IncomingAmount = lnwire.MilliSatoshi(byteOrder.Uint64(amts[:8]))
+	c.OutgoingAmount = lnwire.MilliSatoshi(byteOrder.Uint64(amts[8:]))
+
+	var encType [1]byte
+	if _, err := io.ReadFull(r, encType[:]); err != nil {
+		return err
+	}
+
+	encrypter, err := newErrorEncrypter(EncrypterType(encType[0]))
+	if err != nil {
+		return err
+	}
+	if encrypter == nil {
+		return nil
+	}
+
+	if err := encrypter.Decode(r); err != nil {
+		return err
+	}
+	c.ErrorEncrypter = encrypter
+
+	return nil
+}
+
 // circuitKey is a channel ID, HTLC ID tuple used as an identifying key for a
 // payment circuit. The circuit map is keyed with the idenitifer for the
 // outgoing HTLC
@@ -54,6 +175,27 @@ func (k *circuitKey) String() string {
 	return fmt.Sprintf("(Chan ID=%s, HTLC ID=%d)", k.chanID, k.htlcID)
 }
 
+// bytes returns the 16-byte on-disk representation of the circuitKey.
+func (k *circuitKey) bytes() []byte {
+	var b [16]byte
+	byteOrder.PutUint64(b[:8], k.chanID.ToUint64())
+	byteOrder.PutUint64(b[8:], k.htlcID)
+	return b[:]
+}
+
+// decodeCircuitKey parses the on-disk representation of a circuitKey.
+func decodeCircuitKey(b []byte) (circuitKey, error) {
+	if len(b) != 16 {
+		return circuitKey{}, errors.Errorf("invalid circuit key "+
+			"length: %v", len(b))
+	}
+
+	return circuitKey{
+		chanID: lnwire.NewShortChanIDFromInt(byteOrder.Uint64(b[:8])),
+		htlcID: byteOrder.Uint64(b[8:]),
+	}, nil
+}
+
 // CircuitMap is a data structure that implements thread safe storage of
 // circuit routing information. The switch consults a circuit map to determine
 // where to forward HTLC update messages. Each circuit is stored with it's
@@ -62,19 +204,228 @@ func (k *circuitKey) String() string {
 // same payment hash. Circuits are also indexed to provide fast lookups by
 // payment hash.
 //
-// TODO(andrew.shvv) make it persistent
+// If the circuit map is backed by a channeldb instance, all circuits are
+// persisted so that settles and fails arriving after a restart can still be
+// routed back to the incoming link, or to the router in the case of a locally
+// initiated payment. A circuit goes through three stages on disk:
+//
+//  1. Commit: before an add is handed to the outgoing link, the switch
+//     writes a half circuit keyed by the incoming HTLC.
+//  2. Open: once the outgoing link has assigned an HTLC ID to the add, a
+//     keystone binding the outgoing HTLC to the incoming HTLC is written.
+//  3. Delete: when the settle or fail has been received, the half circuit
+//     and its keystone are removed atomically.
+//
+// Half circuits found without a keystone on start up belong to adds that
+// never made it into the outgoing channel, and can safely be failed back. The
+// same holds for opened circuits whose add was never locked in before we went
+// down, which are trimmed once the outgoing link starts.
 type CircuitMap struct {
 	mtx       sync.RWMutex
 	circuits  map[circuitKey]*PaymentCircuit
 	hashIndex map[[32]byte]map[PaymentCircuit]struct{}
+
+	// committed holds the circuits which have been committed, but have not
+	// yet been opened by the outgoing link.
+	committed map[circuitKey]*PaymentCircuit
+
+	// orphans holds the half circuits found without a keystone during the
+	// last restore, or trimmed since, grouped by the incoming channel.
+	orphans map[lnwire.ShortChannelID][]*PaymentCircuit
+
+	// db is the database the circuits are persisted to. If nil, circuits
+	// are only kept in memory.
+	db *channeldb.DB
+
+	// extractErrorEncrypter is used to re-derive the shared secret of
+	// error encrypters read back from disk.
+	extractErrorEncrypter ErrorEncrypterExtracter
 }
 
-// NewCircuitMap creates a new instance of the CircuitMap.
-func NewCircuitMap() *CircuitMap {
+// NewCircuitMap creates a new instance of the CircuitMap. If db is non-nil,
+// circuits will be written to it, and may be loaded back using
+// Restore.
+func NewCircuitMap(db *channeldb.DB,
+	extracter ErrorEncrypterExtracter) *CircuitMap {
+
 	return &CircuitMap{
-		circuits:  make(map[circuitKey]*PaymentCircuit),
-		hashIndex: make(map[[32]byte]map[PaymentCircuit]struct{}),
+		circuits:              make(map[circuitKey]*PaymentCircuit),
+		hashIndex:             make(map[[32]byte]map[PaymentCircuit]struct{}),
+		committed:             make(map[circuitKey]*PaymentCircuit),
+		orphans:               make(map[lnwire.ShortChannelID][]*PaymentCircuit),
+		db:                    db,
+		extractErrorEncrypter: extracter,
+	}
+}
+
+// Restore loads all circuits persisted to the backing database into memory.
+// Circuits which were opened before shutting down can be looked up again by
+// their outgoing HTLC, while those that were only committed are set aside
+// and can be retrieved with Orphans.
+func (cm *CircuitMap) Restore() error {
+	if cm.db == nil {
+		return nil
+	}
+
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+
+	halves := make(map[circuitKey]*PaymentCircuit)
+	keystones := make(map[circuitKey]circuitKey)
+
+	err := cm.db.Update(func(tx *bolt.Tx) error {
+		addBucket, err := tx.CreateBucketIfNotExists(circuitAddKey)
+		if err != nil {
+			return err
+		}
+		keystoneBucket, err := tx.CreateBucketIfNotExists(
+			circuitKeystoneKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = addBucket.ForEach(func(k, v []byte) error {
+			inKey, err := decodeCircuitKey(k)
+			if err != nil {
+				return err
+			}
+
+			circuit := &PaymentCircuit{
+				IncomingChanID: inKey.chanID,
+				IncomingHTLCID: inKey.htlcID,
+			}
+			if err := circuit.decodeHalf(bytes.NewReader(v)); err != nil {
+				return err
+			}
+
+			halves[inKey] = circuit
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return keystoneBucket.ForEach(func(k, v []byte) error {
+			outKey, err := decodeCircuitKey(k)
+			if err != nil {
+				return err
+			}
+			inKey, err := decodeCircuitKey(v)
+			if err != nil {
+				return err
+			}
+
+			keystones[outKey] = inKey
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Now that we've read everything from disk, we'll re-derive the
+	// encrypters of all half circuits, so that any failures can be
+	// obfuscated properly on the way back.
+	for _, circuit := range halves {
+		sphinxEncrypter, ok := circuit.ErrorEncrypter.(*SphinxErrorEncrypter)
+		if !ok {
+			continue
+		}
+
+		if cm.extractErrorEncrypter == nil {
+			return errors.New("unable to restore circuits: no " +
+				"error encrypter extracter")
+		}
+		if err := sphinxEncrypter.Reextract(
+			cm.extractErrorEncrypter,
+		); err != nil {
+			return err
+		}
+	}
+
+	// Each keystone completes the half circuit it points to. Keystones
+	// without a matching half circuit are a sign of corruption, as both
+	// are always removed in the same transaction.
+	for outKey, inKey := range keystones {
+		circuit, ok := halves[inKey]
+		if !ok {
+			return errors.Errorf("keystone %v references unknown "+
+				"circuit %v", outKey, inKey)
+		}
+		delete(halves, inKey)
+
+		circuit.OutgoingChanID = outKey.chanID
+		circuit.OutgoingHTLCID = outKey.htlcID
+		cm.addToIndexes(circuit)
 	}
+
+	// Whatever is left was committed, but never opened.
+	for inKey, circuit := range halves {
+		cm.committed[inKey] = circuit
+		cm.orphans[inKey.chanID] = append(
+			cm.orphans[inKey.chanID], circuit,
+		)
+	}
+
+	log.Infof("Restored %d open and %d orphaned payment circuits",
+		len(keystones), len(halves))
+
+	return nil
+}
+
+// Orphans returns, and forgets, all restored or trimmed half circuits whose
+// incoming HTLC belongs to the target channel. The adds of these circuits
+// never reached the outgoing channel, so the caller should fail them back and
+// then Delete them. The orphans of locally initiated HTLCs are returned for
+// the zero channel ID.
+func (cm *CircuitMap) Orphans(chanID lnwire.ShortChannelID) []*PaymentCircuit {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+
+	orphans := cm.orphans[chanID]
+	delete(cm.orphans, chanID)
+
+	return orphans
+}
+
+// Commit persists the half circuit of an add that is about to be handed to
+// the outgoing link. It must be called before the outgoing link can assign an
+// HTLC ID to the add.
+func (cm *CircuitMap) Commit(circuit *PaymentCircuit) error {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+
+	if err := cm.commit(circuit); err != nil {
+		return err
+	}
+
+	cm.committed[circuit.inKey()] = circuit
+	return nil
+}
+
+// commit writes the half circuit to disk.
+//
+// NOTE: This MUST be called with the mutex held.
+func (cm *CircuitMap) commit(circuit *PaymentCircuit) error {
+	if cm.db == nil {
+		return nil
+	}
+
+	var b bytes.Buffer
+	if err := circuit.encodeHalf(&b); err != nil {
+		return err
+	}
+
+	inKey := circuit.inKey()
+	return cm.db.Batch(func(tx *bolt.Tx) error {
+		addBucket, err := tx.CreateBucketIfNotExists(circuitAddKey)
+		if err != nil {
+			return err
+		}
+
+		return addBucket.Put(inKey.bytes(), b.Bytes())
+	})
 }
 
 // LookupByHTLC looks up the payment circuit by the outgoing channel and HTLC
@@ -92,6 +443,21 @@ func (cm *CircuitMap) LookupByHTLC(chanID lnwire.ShortChannelID, htlcID uint64)
 	return circuit
 }
 
+// LookupLocal looks up the opened circuit of the locally initiated HTLC with
+// the passed payment ID. Returns nil if there is no such circuit.
+func (cm *CircuitMap) LookupLocal(paymentID uint64) *PaymentCircuit {
+	cm.mtx.RLock()
+	defer cm.mtx.RUnlock()
+
+	for _, circuit := range cm.circuits {
+		if circuit.isLocal() && circuit.IncomingHTLCID == paymentID {
+			return circuit
+		}
+	}
+
+	return nil
+}
+
 // LookupByPaymentHash looks up and returns any payment circuits with a given
 // payment hash.
 func (cm *CircuitMap) LookupByPaymentHash(hash [32]byte) []*PaymentCircuit {
@@ -101,6 +467,7 @@ func (cm *CircuitMap) LookupByPaymentHash(hash [32]byte) []*PaymentCircuit {
 	if circuitSet, ok := cm.hashIndex[hash]; ok {
 		circuits = make([]*PaymentCircuit, 0, len(circuitSet))
 		for circuit := range circuitSet {
+			circuit := circuit
 			circuits = append(circuits, &circuit)
 		}
 	}
@@ -109,26 +476,117 @@ func (cm *CircuitMap) LookupByPaymentHash(hash [32]byte) []*PaymentCircuit {
 	return circuits
 }
 
-// Add adds a new active payment circuit to the CircuitMap.
+// Add opens a new active payment circuit within the CircuitMap. The half
+// circuit and the keystone binding the outgoing HTLC to the incoming HTLC are
+// written in a single transaction, so circuits which weren't committed
+// beforehand are persisted as well.
 func (cm *CircuitMap) Add(circuit *PaymentCircuit) error {
 	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
 
-	key := circuitKey{
-		chanID: circuit.OutgoingChanID,
-		htlcID: circuit.OutgoingHTLCID,
+	if cm.db != nil {
+		var b bytes.Buffer
+		if err := circuit.encodeHalf(&b); err != nil {
+			return err
+		}
+
+		inKey, outKey := circuit.inKey(), circuit.outKey()
+		err := cm.db.Batch(func(tx *bolt.Tx) error {
+			addBucket, err := tx.CreateBucketIfNotExists(
+				circuitAddKey,
+			)
+			if err != nil {
+				return err
+			}
+			keystoneBucket, err := tx.CreateBucketIfNotExists(
+				circuitKeystoneKey,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := addBucket.Put(inKey.bytes(), b.Bytes()); err != nil {
+				return err
+			}
+
+			return keystoneBucket.Put(outKey.bytes(), inKey.bytes())
+		})
+		if err != nil {
+			return err
+		}
 	}
-	cm.circuits[key] = circuit
+
+	delete(cm.committed, circuit.inKey())
+	cm.addToIndexes(circuit)
+
+	return nil
+}
+
+// addToIndexes adds an opened circuit to the in-memory indexes.
+//
+// NOTE: This MUST be called with the mutex held.
+func (cm *CircuitMap) addToIndexes(circuit *PaymentCircuit) {
+	cm.circuits[circuit.outKey()] = circuit
 
 	// Add circuit to the hash index.
 	if _, ok := cm.hashIndex[circuit.PaymentHash]; !ok {
 		cm.hashIndex[circuit.PaymentHash] = make(map[PaymentCircuit]struct{})
 	}
 	cm.hashIndex[circuit.PaymentHash][*circuit] = struct{}{}
+}
+
+// Delete removes a committed circuit which was never opened, identified by
+// its incoming HTLC. Deleting a circuit which isn't known is a noop, as adds
+// rejected by the switch itself are failed back before they're committed.
+func (cm *CircuitMap) Delete(chanID lnwire.ShortChannelID, htlcID uint64) error {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+
+	inKey := circuitKey{
+		chanID: chanID,
+		htlcID: htlcID,
+	}
+	if _, ok := cm.committed[inKey]; !ok {
+		return nil
+	}
 
-	cm.mtx.Unlock()
+	if err := cm.deleteFromDisk(inKey, nil); err != nil {
+		return err
+	}
+
+	delete(cm.committed, inKey)
 	return nil
 }
 
+// deleteFromDisk removes the half circuit with the given incoming key and, if
+// the circuit has been opened, its keystone.
+//
+// NOTE: This MUST be called with the mutex held.
+func (cm *CircuitMap) deleteFromDisk(inKey circuitKey, outKey *circuitKey) error {
+	if cm.db == nil {
+		return nil
+	}
+
+	return cm.db.Batch(func(tx *bolt.Tx) error {
+		addBucket := tx.Bucket(circuitAddKey)
+		if addBucket != nil {
+			if err := addBucket.Delete(inKey.bytes()); err != nil {
+				return err
+			}
+		}
+
+		if outKey == nil {
+			return nil
+		}
+
+		keystoneBucket := tx.Bucket(circuitKeystoneKey)
+		if keystoneBucket == nil {
+			return nil
+		}
+		return keystoneBucket.Delete(outKey.bytes())
+	})
+}
+
 // Remove destroys the target circuit by removing it from the circuit map.
 func (cm *CircuitMap) Remove(chanID lnwire.ShortChannelID, htlcID uint64) error {
 	cm.mtx.Lock()
@@ -143,6 +601,21 @@ func (cm *CircuitMap) Remove(chanID lnwire.ShortChannelID, htlcID uint64) error
 	if !found {
 		return errors.Errorf("Can't find circuit for HTLC %v", key)
 	}
+
+	// The circuit is removed from disk first, such that a failure leaves
+	// it around to be resolved after a restart.
+	if err := cm.deleteFromDisk(circuit.inKey(), &key); err != nil {
+		return err
+	}
+
+	return cm.removeFromIndexes(circuit)
+}
+
+// removeFromIndexes removes an opened circuit from the in-memory indexes.
+//
+// NOTE: This MUST be called with the mutex held.
+func (cm *CircuitMap) removeFromIndexes(circuit *PaymentCircuit) error {
+	key := circuit.outKey()
 	delete(cm.circuits, key)
 
 	// Remove circuit from hash index.
@@ -164,6 +637,78 @@ func (cm *CircuitMap) Remove(chanID lnwire.ShortChannelID, htlcID uint64) error
 	return nil
 }
 
+// TrimOpenCircuits closes the opened circuits of all HTLCs on the target
+// outgoing channel whose HTLC ID is at or above start, which should be the
+// next HTLC ID the channel will assign after being restored from disk. The
+// keystones of these circuits were written before we went down, but their adds
+// never made it into a commitment, so the HTLC IDs will be reused for other
+// adds. The trimmed circuits are turned back into orphans, such that the
+// switch fails back their incoming HTLCs.
+func (cm *CircuitMap) TrimOpenCircuits(chanID lnwire.ShortChannelID,
+	start uint64) error {
+
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+
+	var trimmed []*PaymentCircuit
+	for outKey, circuit := range cm.circuits {
+		if outKey.chanID != chanID || outKey.htlcID < start {
+			continue
+		}
+
+		trimmed = append(trimmed, circuit)
+	}
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	// Only the keystones are removed, as the half circuits are still
+	// needed to fail the incoming HTLCs back.
+	if cm.db != nil {
+		err := cm.db.Batch(func(tx *bolt.Tx) error {
+			keystoneBucket := tx.Bucket(circuitKeystoneKey)
+			if keystoneBucket == nil {
+				return nil
+			}
+
+			for _, circuit := range trimmed {
+				outKey := circuit.outKey()
+				err := keystoneBucket.Delete(outKey.bytes())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, circuit := range trimmed {
+		if err := cm.removeFromIndexes(circuit); err != nil {
+			return err
+		}
+
+		log.Infof("Trimming open circuit for %x: (%s, %d) <-> (%s, %d)",
+			circuit.PaymentHash, circuit.IncomingChanID,
+			circuit.IncomingHTLCID, circuit.OutgoingChanID,
+			circuit.OutgoingHTLCID)
+
+		circuit.OutgoingChanID = lnwire.ShortChannelID{}
+		circuit.OutgoingHTLCID = 0
+
+		inKey := circuit.inKey()
+		cm.committed[inKey] = circuit
+		cm.orphans[inKey.chanID] = append(
+			cm.orphans[inKey.chanID], circuit,
+		)
+	}
+
+	return nil
+}
+
 // pending returns number of circuits which are waiting for to be completed
 // (settle/fail responses to be received).
 func (cm *CircuitMap) pending() int {
@@ -1,8 +1,11 @@
 package htlcswitch_test
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
@@ -20,7 +23,7 @@ func TestCircuitMap(t *testing.T) {
 		chan2 = lnwire.NewShortChanIDFromInt(2)
 	)
 
-	circuitMap := htlcswitch.NewCircuitMap()
+	circuitMap := htlcswitch.NewCircuitMap(nil, nil)
 
 	circuit := circuitMap.LookupByHTLC(chan1, 0)
 	if circuit != nil {
@@ -153,3 +156,220 @@ func TestCircuitMap(t *testing.T) {
 			"hash1: expecected %d, got %d", 0, len(circuits))
 	}
 }
+
+// TestCircuitMapPersistence asserts that opened circuits survive a restart of
+// the circuit map, that circuits which were only committed are reported as
+// orphans, and that removed circuits aren't restored.
+func TestCircuitMapPersistence(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "circuitdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	var hash1, hash2, hash3 [32]byte
+	hash1[0] = 1
+	hash2[0] = 2
+	hash3[0] = 3
+
+	var (
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		chan2 = lnwire.NewShortChanIDFromInt(2)
+	)
+
+	circuitMap := htlcswitch.NewCircuitMap(db, nil)
+	if err := circuitMap.Restore(); err != nil {
+		t.Fatalf("unable to restore empty circuit map: %v", err)
+	}
+
+	// Commit and open two circuits forwarded from chan2 to chan1.
+	for i, hash := range [][32]byte{hash1, hash2} {
+		circuit := &htlcswitch.PaymentCircuit{
+			PaymentHash:    hash,
+			IncomingChanID: chan2,
+			IncomingHTLCID: uint64(i),
+		}
+		if err := circuitMap.Commit(circuit); err != nil {
+			t.Fatalf("unable to commit circuit: %v", err)
+		}
+
+		circuit.OutgoingChanID = chan1
+		circuit.OutgoingHTLCID = uint64(i)
+		if err := circuitMap.Add(circuit); err != nil {
+			t.Fatalf("unable to open circuit: %v", err)
+		}
+	}
+
+	// Commit a third circuit, but never open it, as if we went down before
+	// the outgoing link could add the HTLC.
+	err = circuitMap.Commit(&htlcswitch.PaymentCircuit{
+		PaymentHash:    hash3,
+		IncomingChanID: chan2,
+		IncomingHTLCID: 2,
+	})
+	if err != nil {
+		t.Fatalf("unable to commit circuit: %v", err)
+	}
+
+	// Settle the second circuit, it shouldn't be restored.
+	if err := circuitMap.Remove(chan1, 1); err != nil {
+		t.Fatalf("unable to remove circuit: %v", err)
+	}
+
+	// Now, simulate a restart by creating a fresh circuit map from the
+	// same database.
+	circuitMap = htlcswitch.NewCircuitMap(db, nil)
+	if err := circuitMap.Restore(); err != nil {
+		t.Fatalf("unable to restore circuit map: %v", err)
+	}
+
+	circuit := circuitMap.LookupByHTLC(chan1, 0)
+	if circuit == nil {
+		t.Fatal("opened circuit wasn't restored")
+	}
+	if circuit.PaymentHash != hash1 || circuit.IncomingChanID != chan2 ||
+		circuit.IncomingHTLCID != 0 {
+
+		t.Fatalf("restored unexpected circuit: %v", circuit)
+	}
+
+	if circuit := circuitMap.LookupByHTLC(chan1, 1); circuit != nil {
+		t.Fatalf("removed circuit was restored: %v", circuit)
+	}
+
+	orphans := circuitMap.Orphans(chan2)
+	if len(orphans) != 1 {
+		t.Fatalf("expected 1 orphaned circuit, got %d", len(orphans))
+	}
+	if orphans[0].PaymentHash != hash3 || orphans[0].IncomingHTLCID != 2 {
+		t.Fatalf("unexpected orphaned circuit: %v", orphans[0])
+	}
+	if len(circuitMap.Orphans(chan2)) != 0 {
+		t.Fatal("orphans were returned twice")
+	}
+
+	// Deleting the orphan and removing the last open circuit should leave
+	// nothing to restore.
+	if err := circuitMap.Delete(chan2, 2); err != nil {
+		t.Fatalf("unable to delete orphaned circuit: %v", err)
+	}
+	if err := circuitMap.Remove(chan1, 0); err != nil {
+		t.Fatalf("unable to remove circuit: %v", err)
+	}
+
+	circuitMap = htlcswitch.NewCircuitMap(db, nil)
+	if err := circuitMap.Restore(); err != nil {
+		t.Fatalf("unable to restore circuit map: %v", err)
+	}
+	if circuit := circuitMap.LookupByHTLC(chan1, 0); circuit != nil {
+		t.Fatalf("removed circuit was restored: %v", circuit)
+	}
+	if len(circuitMap.Orphans(chan2)) != 0 {
+		t.Fatal("deleted orphan was restored")
+	}
+}
+
+// TestCircuitMapTrimOpenCircuits asserts that trimming the open circuits of a
+// channel only closes those at or above the given HTLC ID, and that the
+// trimmed circuits are reported as orphans, also after a restart.
+func TestCircuitMapTrimOpenCircuits(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "circuitdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	var (
+		chan1 = lnwire.NewShortChanIDFromInt(1)
+		chan2 = lnwire.NewShortChanIDFromInt(2)
+		chan3 = lnwire.NewShortChanIDFromInt(3)
+	)
+
+	circuitMap := htlcswitch.NewCircuitMap(db, nil)
+	if err := circuitMap.Restore(); err != nil {
+		t.Fatalf("unable to restore empty circuit map: %v", err)
+	}
+
+	// Open three circuits forwarded from chan2 to chan1.
+	for i := uint64(0); i < 3; i++ {
+		var hash [32]byte
+		hash[0] = byte(i)
+
+		circuit := &htlcswitch.PaymentCircuit{
+			PaymentHash:    hash,
+			IncomingChanID: chan2,
+			IncomingHTLCID: i,
+		}
+		if err := circuitMap.Commit(circuit); err != nil {
+			t.Fatalf("unable to commit circuit: %v", err)
+		}
+
+		circuit.OutgoingChanID = chan1
+		circuit.OutgoingHTLCID = i
+		if err := circuitMap.Add(circuit); err != nil {
+			t.Fatalf("unable to open circuit: %v", err)
+		}
+	}
+
+	// Trimming another channel shouldn't affect any of the circuits.
+	if err := circuitMap.TrimOpenCircuits(chan3, 0); err != nil {
+		t.Fatalf("unable to trim circuits: %v", err)
+	}
+	for i := uint64(0); i < 3; i++ {
+		if circuitMap.LookupByHTLC(chan1, i) == nil {
+			t.Fatalf("circuit %d of untrimmed channel was closed", i)
+		}
+	}
+
+	// Only the first HTLC was locked in, so the other two are trimmed.
+	if err := circuitMap.TrimOpenCircuits(chan1, 1); err != nil {
+		t.Fatalf("unable to trim circuits: %v", err)
+	}
+
+	assertTrimmed := func() {
+		if circuitMap.LookupByHTLC(chan1, 0) == nil {
+			t.Fatal("locked in circuit was trimmed")
+		}
+		for i := uint64(1); i < 3; i++ {
+			if circuit := circuitMap.LookupByHTLC(chan1, i); circuit != nil {
+				t.Fatalf("circuit wasn't trimmed: %v", circuit)
+			}
+		}
+
+		orphans := circuitMap.Orphans(chan2)
+		if len(orphans) != 2 {
+			t.Fatalf("expected 2 orphaned circuits, got %d",
+				len(orphans))
+		}
+		for _, orphan := range orphans {
+			if orphan.IncomingHTLCID == 0 {
+				t.Fatalf("unexpected orphaned circuit: %v", orphan)
+			}
+		}
+	}
+	assertTrimmed()
+
+	// The trimmed circuits should be reported as orphans after a restart
+	// as well, as their keystones are gone.
+	circuitMap = htlcswitch.NewCircuitMap(db, nil)
+	if err := circuitMap.Restore(); err != nil {
+		t.Fatalf("unable to restore circuit map: %v", err)
+	}
+	assertTrimmed()
+}
@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -59,14 +60,69 @@ type ErrorEncrypter interface {
 	// in an additional layer of onion encryption. This process repeats
 	// until the error arrives at the source of the payment.
 	IntermediateEncrypt(lnwire.OpaqueReason) lnwire.OpaqueReason
+
+	// Type returns an enum indicating the underlying concrete instance
+	// backing this interface.
+	Type() EncrypterType
+
+	// Encode serializes the encrypter to the given io.Writer, such that it
+	// can be persisted along with the circuit it belongs to.
+	Encode(io.Writer) error
+
+	// Decode deserializes the encrypter from the given io.Reader.
+	Decode(io.Reader) error
+}
+
+// EncrypterType establishes an enum used in serialization to indicate how to
+// decode a concrete instance of the ErrorEncrypter interface.
+type EncrypterType byte
+
+const (
+	// EncrypterTypeNone signals that no error encrypter is present, this
+	// can happen if the htlc is originates in the switch.
+	EncrypterTypeNone EncrypterType = 0
+
+	// EncrypterTypeSphinx is used to identify a sphinx onion error
+	// encrypter instance.
+	EncrypterTypeSphinx = 1
+
+	// EncrypterTypeMock is used to identify a mock obfuscator instance.
+	EncrypterTypeMock = 2
+)
+
+// newErrorEncrypter returns a blank ErrorEncrypter of the given type, ready
+// to be decoded. A nil ErrorEncrypter is returned for EncrypterTypeNone.
+func newErrorEncrypter(encType EncrypterType) (ErrorEncrypter, error) {
+	switch encType {
+	case EncrypterTypeNone:
+		return nil, nil
+	case EncrypterTypeSphinx:
+		return &SphinxErrorEncrypter{}, nil
+	case EncrypterTypeMock:
+		return &mockObfuscator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown error encrypter type: %d",
+			encType)
+	}
 }
 
+// ErrorEncrypterExtracter defines a function signature used to derive an
+// ErrorEncrypter from the ephemeral key of an onion packet. It is used to
+// rebuild the encrypters of circuits which are read back from disk.
+type ErrorEncrypterExtracter func(*btcec.PublicKey) (ErrorEncrypter,
+	lnwire.FailCode)
+
 // SphinxErrorEncrypter is a concrete implementation of both the ErrorEncrypter
 // interface backed by an implementation of the Sphinx packet format. As a
 // result, all errors handled are themselves wrapped in layers of onion
 // encryption and must be treated as such accordingly.
 type SphinxErrorEncrypter struct {
 	*sphinx.OnionErrorEncrypter
+
+	// EphemeralKey is the ephemeral key of the onion packet the encrypter
+	// was derived from. Only the key is persisted, as the shared secret
+	// can be re-derived from it using the node's onion key.
+	EphemeralKey *btcec.PublicKey
 }
 
 // EncryptFirstHop transforms a concrete failure message into an encrypted
@@ -97,6 +153,60 @@ func (s *SphinxErrorEncrypter) IntermediateEncrypt(reason lnwire.OpaqueReason) l
 	return s.EncryptError(false, reason)
 }
 
+// Type returns the identifier for a sphinx error encrypter.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (s *SphinxErrorEncrypter) Type() EncrypterType {
+	return EncrypterTypeSphinx
+}
+
+// Encode serializes the ephemeral key of the error encrypter to the given
+// io.Writer.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (s *SphinxErrorEncrypter) Encode(w io.Writer) error {
+	_, err := w.Write(s.EphemeralKey.SerializeCompressed())
+	return err
+}
+
+// Decode reads the ephemeral key of the error encrypter from the given
+// io.Reader. Reextract MUST be called afterwards before the encrypter can be
+// used.
+//
+// NOTE: Part of the ErrorEncrypter interface.
+func (s *SphinxErrorEncrypter) Decode(r io.Reader) error {
+	var keyBytes [btcec.PubKeyBytesLenCompressed]byte
+	if _, err := io.ReadFull(r, keyBytes[:]); err != nil {
+		return err
+	}
+
+	ephemeralKey, err := btcec.ParsePubKey(keyBytes[:], btcec.S256())
+	if err != nil {
+		return err
+	}
+	s.EphemeralKey = ephemeralKey
+
+	return nil
+}
+
+// Reextract re-derives the shared secret of a decoded error encrypter from its
+// ephemeral key, using the passed extracter.
+func (s *SphinxErrorEncrypter) Reextract(extract ErrorEncrypterExtracter) error {
+	obfuscator, failcode := extract(s.EphemeralKey)
+	if failcode != lnwire.CodeNone {
+		return fmt.Errorf("unable to reconstruct error encrypter: %v",
+			failcode)
+	}
+
+	sphinxEncrypter, ok := obfuscator.(*SphinxErrorEncrypter)
+	if !ok {
+		return fmt.Errorf("incorrect onion error extracter")
+	}
+	s.OnionErrorEncrypter = sphinxEncrypter.OnionErrorEncrypter
+
+	return nil
+}
+
 // A compile time check to ensure SphinxErrorEncrypter implements the
 // ErrorEncrypter interface.
 var _ ErrorEncrypter = (*SphinxErrorEncrypter)(nil)
@@ -0,0 +1,189 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// KeySendType is the type of the custom record within the payload of
+	// the exit hop which carries the preimage of a spontaneous payment.
+	// If present, and the receiver accepts spontaneous payments, then an
+	// invoice is created on the fly in order to settle the HTLC.
+	KeySendType uint64 = 5482373484
+
+	// extraFrameRealm is the realm of the sphinx frames that follow the
+	// frame of the exit hop, which carry its custom records. As each hop
+	// is only given a single fixed size frame, the custom records of the
+	// exit hop are spread across as many additional frames as are
+	// required, each of which are also encrypted to the exit hop.
+	extraFrameRealm byte = 0x01
+
+	// extraFrameSize is the number of bytes of custom records that can be
+	// carried within a single frame: the next address, forward amount,
+	// outgoing CLTV and padding fields.
+	extraFrameSize = 8 + 8 + 4 + 12
+)
+
+// CustomRecords is a set of type-length-value records carried within the
+// onion payload of the exit hop, forming its extension TLV stream. The records
+// are keyed by their type.
+type CustomRecords map[uint64][]byte
+
+// Encode serializes the custom records to the passed writer as a TLV stream.
+func (c CustomRecords) Encode(w io.Writer) error {
+	records := make([]tlv.Record, 0, len(c))
+	for recordType, value := range c {
+		value := value
+		records = append(records, tlv.MakePrimitiveRecord(
+			tlv.Type(recordType), &value,
+		))
+	}
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	return stream.Encode(w)
+}
+
+// Decode deserializes the custom records from the TLV stream read from the
+// passed reader. The stream may carry any number of optional records, but
+// the only required record we understand is the preimage of a spontaneous
+// payment. Whether such a payment is accepted is left to the link.
+func (c CustomRecords) Decode(r io.Reader) error {
+	var preimage []byte
+	stream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(tlv.Type(KeySendType), &preimage),
+	)
+	if err != nil {
+		return err
+	}
+
+	parsedTypes, err := stream.DecodeWithParsedTypes(r)
+	if err != nil {
+		return err
+	}
+
+	for recordType, value := range parsedTypes {
+		c[uint64(recordType)] = value
+	}
+	if _, ok := parsedTypes[tlv.Type(KeySendType)]; ok {
+		c[KeySendType] = preimage
+	}
+
+	return nil
+}
+
+// newExtraFrames returns the set of sphinx frames that carry the passed custom
+// records of the exit hop. These frames are to directly follow the frame of
+// the exit hop, and must be encrypted to the exit hop itself. The serialized
+// records are prefixed with their length, then split across as many frames as
+// are required.
+func newExtraFrames(records CustomRecords) ([]sphinx.HopData, error) {
+	var b bytes.Buffer
+	if err := records.Encode(&b); err != nil {
+		return nil, err
+	}
+
+	var payload bytes.Buffer
+	err := binary.Write(&payload, binary.BigEndian, uint16(b.Len()))
+	if err != nil {
+		return nil, err
+	}
+	payload.Write(b.Bytes())
+
+	numFrames := (payload.Len() + extraFrameSize - 1) / extraFrameSize
+	frames := make([]sphinx.HopData, numFrames)
+	for i := range frames {
+		var chunk [extraFrameSize]byte
+		copy(chunk[:], payload.Next(extraFrameSize))
+
+		frame := &frames[i]
+		frame.Realm = extraFrameRealm
+		copy(frame.NextAddress[:], chunk[:8])
+		frame.ForwardAmount = binary.BigEndian.Uint64(chunk[8:16])
+		frame.OutgoingCltv = binary.BigEndian.Uint32(chunk[16:20])
+		copy(frame.ExtraBytes[:], chunk[20:])
+	}
+
+	return frames, nil
+}
+
+// decodeExtraFrames reassembles the custom records that were spread across
+// the passed frames by newExtraFrames.
+func decodeExtraFrames(frames []sphinx.HopData) (CustomRecords, error) {
+	var payload bytes.Buffer
+	for _, frame := range frames {
+		if frame.Realm != extraFrameRealm {
+			return nil, fmt.Errorf("frame has invalid realm %v",
+				frame.Realm)
+		}
+
+		var chunk [extraFrameSize]byte
+		copy(chunk[:8], frame.NextAddress[:])
+		binary.BigEndian.PutUint64(chunk[8:16], frame.ForwardAmount)
+		binary.BigEndian.PutUint32(chunk[16:20], frame.OutgoingCltv)
+		copy(chunk[20:], frame.ExtraBytes[:])
+
+		payload.Write(chunk[:])
+	}
+
+	var length uint16
+	if err := binary.Read(&payload, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if int(length) > payload.Len() {
+		return nil, fmt.Errorf("custom records of length %v exceed "+
+			"frames", length)
+	}
+
+	records := make(CustomRecords)
+	err := records.Decode(bytes.NewReader(payload.Next(int(length))))
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// AddCustomRecords appends the frames which carry the passed custom records of
+// the exit hop to the set of per-hop payloads of a route. The returned path of
+// the onion repeats the public key of the exit hop for each additional frame.
+func AddCustomRecords(hopPayloads []sphinx.HopData,
+	path []*btcec.PublicKey, records CustomRecords) ([]sphinx.HopData,
+	[]*btcec.PublicKey, error) {
+
+	if len(records) == 0 {
+		return hopPayloads, path, nil
+	}
+
+	frames, err := newExtraFrames(records)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(hopPayloads)+len(frames) > sphinx.NumMaxHops {
+		return nil, nil, fmt.Errorf("route of %v hops can't carry "+
+			"custom records spanning %v frames", len(hopPayloads),
+			len(frames))
+	}
+
+	onionPath := make([]*btcec.PublicKey, len(path), len(path)+len(frames))
+	copy(onionPath, path)
+
+	exitNode := path[len(path)-1]
+	for range frames {
+		onionPath = append(onionPath, exitNode)
+	}
+
+	return append(hopPayloads, frames...), onionPath, nil
+}
@@ -0,0 +1,116 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestCustomRecordsEncodeDecode tests that custom records survive a round trip
+// through their serialization, and that malformed streams are rejected.
+func TestCustomRecordsEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	records := CustomRecords{
+		KeySendType: bytes.Repeat([]byte{0x01}, 32),
+		1:           {0x02, 0x03},
+		0x10001:     {},
+	}
+
+	var b bytes.Buffer
+	if err := records.Encode(&b); err != nil {
+		t.Fatalf("unable to encode records: %v", err)
+	}
+
+	decoded := make(CustomRecords)
+	if err := decoded.Decode(&b); err != nil {
+		t.Fatalf("unable to decode records: %v", err)
+	}
+	if !reflect.DeepEqual(records, decoded) {
+		t.Fatalf("records don't match: expected %v, got %v",
+			records, decoded)
+	}
+
+	// A stream with records out of order isn't canonical, so it should be
+	// rejected.
+	outOfOrder := []byte{0x03, 0x00, 0x01, 0x00}
+	err := make(CustomRecords).Decode(bytes.NewReader(outOfOrder))
+	if err != tlv.ErrStreamNotCanonical {
+		t.Fatalf("expected ErrStreamNotCanonical, instead got %v", err)
+	}
+
+	// A required record other than the preimage of a spontaneous payment
+	// isn't understood, so it should also be rejected.
+	unknownRequired := []byte{0x02, 0x00}
+	err = make(CustomRecords).Decode(bytes.NewReader(unknownRequired))
+	if err != tlv.ErrUnknownRequiredType(2) {
+		t.Fatalf("expected ErrUnknownRequiredType, instead got %v", err)
+	}
+}
+
+// TestAddCustomRecords tests that custom records are spread across additional
+// frames addressed to the exit hop, and that they can be reassembled from
+// those frames.
+func TestAddCustomRecords(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	exitNode := privKey.PubKey()
+
+	hopPayloads := []sphinx.HopData{{}, {}}
+	path := []*btcec.PublicKey{exitNode, exitNode}
+
+	records := CustomRecords{
+		KeySendType: bytes.Repeat([]byte{0xaa}, 32),
+	}
+
+	newPayloads, onionPath, err := AddCustomRecords(
+		hopPayloads, path, records,
+	)
+	if err != nil {
+		t.Fatalf("unable to add custom records: %v", err)
+	}
+
+	// The 32 byte preimage along with its type, length, and the length
+	// prefix of the records requires two additional frames.
+	if len(newPayloads) != 4 || len(onionPath) != 4 {
+		t.Fatalf("expected 4 frames, instead got %v payloads and "+
+			"%v keys", len(newPayloads), len(onionPath))
+	}
+	if len(path) != 2 {
+		t.Fatalf("original path was modified")
+	}
+	for _, key := range onionPath[2:] {
+		if !key.IsEqual(exitNode) {
+			t.Fatalf("extra frame not addressed to exit node")
+		}
+	}
+
+	decoded, err := decodeExtraFrames(newPayloads[2:])
+	if err != nil {
+		t.Fatalf("unable to decode extra frames: %v", err)
+	}
+	if !reflect.DeepEqual(records, decoded) {
+		t.Fatalf("records don't match: expected %v, got %v",
+			records, decoded)
+	}
+
+	// A route which is already at the maximum length can't carry any
+	// custom records.
+	longPayloads := make([]sphinx.HopData, sphinx.NumMaxHops)
+	longPath := make([]*btcec.PublicKey, sphinx.NumMaxHops)
+	for i := range longPath {
+		longPath[i] = exitNode
+	}
+	_, _, err = AddCustomRecords(longPayloads, longPath, records)
+	if err == nil {
+		t.Fatalf("expected route to be too long for custom records")
+	}
+}
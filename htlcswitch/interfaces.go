@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -17,6 +18,85 @@ type InvoiceDatabase interface {
 	// SettleInvoice attempts to mark an invoice corresponding to the
 	// passed payment hash as fully settled.
 	SettleInvoice(chainhash.Hash) error
+
+	// AddInvoice adds a new invoice, identified by the hash of its
+	// preimage. This is used to create an invoice on the fly for a
+	// spontaneous payment.
+	AddInvoice(*channeldb.Invoice) error
+
+	// HoldInvoiceHTLC registers an HTLC paying to the invoice with the
+	// passed payment hash that is to be held, either as it's a shard of a
+	// multi-path payment, or because the invoice is a hold invoice whose
+	// preimage isn't known yet. The resolve callback is invoked once the
+	// fate of the HTLC has been decided. If it returns an error, then the
+	// HTLC remains held until it's resumed.
+	HoldInvoiceHTLC(hash chainhash.Hash, htlc channeldb.InvoiceHTLC,
+		resolve func(HTLCResolution) error) error
+
+	// ResumeHeldHTLCs hands the passed resolve callback to all HTLCs that
+	// are held for the channel with the passed short channel ID, as the
+	// channel's link has been restarted. The payment hash of each of the
+	// HTLCs is returned, indexed by the index of the HTLC.
+	ResumeHeldHTLCs(chanID lnwire.ShortChannelID,
+		resolve func(HTLCResolution) error) (map[uint64]chainhash.Hash,
+		error)
+
+	// CancelInvoice attempts to cancel the invoice corresponding to the
+	// passed payment hash, failing any HTLCs that are held for it.
+	CancelInvoice(chainhash.Hash) error
+}
+
+// HTLCResolution is handed to the link holding an HTLC paying to an invoice
+// once the invoice registry has decided whether the HTLC is to be settled or
+// failed.
+type HTLCResolution struct {
+	// HtlcID is the index of the held HTLC within the channel it was
+	// received over.
+	HtlcID uint64
+
+	// Settle is true if the HTLC should be settled using Preimage.
+	// Otherwise, the HTLC should be failed with Failure.
+	Settle bool
+
+	// Preimage is the payment preimage to settle the HTLC with.
+	Preimage [32]byte
+
+	// Failure is the reason the HTLC is failed with if it isn't settled.
+	Failure lnwire.FailureMessage
+}
+
+// PreimageCache is an interface which represents a persistent store of the
+// payment preimages we've learned of. Preimages revealed by the remote party
+// when settling our outgoing HTLC's are added to the cache so that any
+// incoming HTLC's with the same payment hash can be claimed on-chain.
+type PreimageCache interface {
+	// AddPreimage adds the passed preimage to the cache, indexing it by
+	// its payment hash.
+	AddPreimage(preimage []byte) error
+}
+
+// TowerClient is an interface which represents a client of a watchtower. The
+// link hands the client the retribution information for each state revoked
+// by the remote party, allowing the tower to punish a breach of the channel
+// on our behalf while we're offline.
+type TowerClient interface {
+	// BackupState prepares the justice transaction for the revoked state
+	// described by the passed breach retribution, and uploads it to the
+	// tower.
+	BackupState(chanInfo *channeldb.ChannelSnapshot,
+		breachInfo *lnwallet.BreachRetribution) error
+}
+
+// ForwardingLog is an interface that represents a time series database which
+// keep track of all successfully completed payment circuits. Every few
+// seconds, the switch will collate and flush out all the successful payment
+// circuits during the last interval.
+type ForwardingLog interface {
+	// AddForwardingEvents is a method that should write out the set of
+	// forwarding events in a batch to persistent storage. Outside
+	// sub-systems can then query the contents of the log for analysis,
+	// visualizations, etc.
+	AddForwardingEvents([]channeldb.ForwardingEvent) error
 }
 
 // ChannelLink is an interface which represents the subsystem for managing the
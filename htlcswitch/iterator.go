@@ -2,10 +2,12 @@ package htlcswitch
 
 import (
 	"encoding/binary"
+	"fmt"
 	"io"
 
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
 )
 
 // NetworkHop indicates the blockchain network that is intended to be the next
@@ -40,6 +42,9 @@ var (
 	// exitHop is a special "hop" which denotes that an incoming HTLC is
 	// meant to pay finally to the receiving node.
 	exitHop lnwire.ShortChannelID
+
+	// exitHopAddress is the next address within the frame of the exit hop.
+	exitHopAddress [8]byte
 )
 
 // ForwardingInfo contains all the information that is necessary to forward and
@@ -65,6 +70,17 @@ type ForwardingInfo struct {
 	// in the outgoing HTLC.
 	OutgoingCTLV uint32
 
+	// TotalAmount is the total amount of the payment this HTLC is a shard
+	// of. This is only set for the exit hop, and only if the sender split
+	// the payment across multiple routes, in which case the HTLC should be
+	// held until HTLCs adding up to this amount have arrived.
+	TotalAmount lnwire.MilliSatoshi
+
+	// CustomRecords is the set of custom records the sender included
+	// within the payload of the exit hop. This is only set for the exit
+	// hop.
+	CustomRecords CustomRecords
+
 	// TODO(roasbeef): modify sphinx logic to not just discard the
 	// remaining bytes, instead should include the rest as excess
 }
@@ -98,6 +114,10 @@ type sphinxHopIterator struct {
 	// includes the information required to properly forward the packet to
 	// the next hop.
 	processedPacket *sphinx.ProcessedPacket
+
+	// customRecords is the set of custom records carried within the
+	// additional frames that follow the frame of the exit hop.
+	customRecords CustomRecords
 }
 
 // A compile time check to ensure sphinxHopIterator implements the HopIterator
@@ -120,10 +140,19 @@ func (r *sphinxHopIterator) EncodeNextHop(w io.Writer) error {
 func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 	fwdInst := r.processedPacket.ForwardingInstructions
 
-	var nextHop lnwire.ShortChannelID
+	var (
+		nextHop     lnwire.ShortChannelID
+		totalAmount lnwire.MilliSatoshi
+	)
 	switch r.processedPacket.Action {
 	case sphinx.ExitNode:
 		nextHop = exitHop
+
+		// The total amount of a multi-path payment is carried within
+		// the padding bytes of the exit hop's payload.
+		totalAmount = lnwire.MilliSatoshi(
+			binary.BigEndian.Uint64(fwdInst.ExtraBytes[:8]),
+		)
 	case sphinx.MoreHops:
 		s := binary.BigEndian.Uint64(fwdInst.NextAddress[:])
 		nextHop = lnwire.NewShortChanIDFromInt(s)
@@ -134,9 +163,62 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 		NextHop:         nextHop,
 		AmountToForward: lnwire.MilliSatoshi(fwdInst.ForwardAmount),
 		OutgoingCTLV:    fwdInst.OutgoingCltv,
+		TotalAmount:     totalAmount,
+		CustomRecords:   r.customRecords,
 	}
 }
 
+// OnionRouter is the set of onion operations that require the node's onion
+// private key. Abstracting them allows the key to be held by an external
+// signer instead of the node itself.
+type OnionRouter interface {
+	// ProcessOnionPacket processes an incoming onion packet, peeling off
+	// the layer of encryption that is addressed to this node.
+	ProcessOnionPacket(onionPkt *sphinx.OnionPacket,
+		assocData []byte) (*sphinx.ProcessedPacket, error)
+
+	// NewOnionErrorEncrypter creates the encrypter used to obfuscate
+	// failures sent back to the origin of the onion packet with the passed
+	// ephemeral key.
+	NewOnionErrorEncrypter(
+		ephemeralKey *btcec.PublicKey) (*sphinx.OnionErrorEncrypter, error)
+}
+
+// sphinxRouter is an implementation of the OnionRouter interface which is
+// backed by a sphinx router holding the onion private key in memory.
+type sphinxRouter struct {
+	router *sphinx.Router
+}
+
+// A compile time check to ensure sphinxRouter meets the OnionRouter interface.
+var _ OnionRouter = (*sphinxRouter)(nil)
+
+// NewSphinxRouter returns an OnionRouter which is backed by the passed sphinx
+// router.
+func NewSphinxRouter(router *sphinx.Router) OnionRouter {
+	return &sphinxRouter{router}
+}
+
+// ProcessOnionPacket processes an incoming onion packet, peeling off the layer
+// of encryption that is addressed to this node.
+//
+// NOTE: Part of the OnionRouter interface.
+func (r *sphinxRouter) ProcessOnionPacket(onionPkt *sphinx.OnionPacket,
+	assocData []byte) (*sphinx.ProcessedPacket, error) {
+
+	return r.router.ProcessOnionPacket(onionPkt, assocData)
+}
+
+// NewOnionErrorEncrypter creates the encrypter used to obfuscate failures sent
+// back to the origin of the onion packet with the passed ephemeral key.
+//
+// NOTE: Part of the OnionRouter interface.
+func (r *sphinxRouter) NewOnionErrorEncrypter(
+	ephemeralKey *btcec.PublicKey) (*sphinx.OnionErrorEncrypter, error) {
+
+	return sphinx.NewOnionErrorEncrypter(r.router, ephemeralKey)
+}
+
 // OnionProcessor is responsible for keeping all sphinx dependent parts inside
 // and expose only decoding function. With such approach we give freedom for
 // subsystems which wants to decode sphinx path to not be dependable from
@@ -147,11 +229,11 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 // the hop iterator should contain sphinx router which makes their creations in
 // tests dependent from the sphinx internal parts.
 type OnionProcessor struct {
-	router *sphinx.Router
+	router OnionRouter
 }
 
 // NewOnionProcessor creates new instance of decoder.
-func NewOnionProcessor(router *sphinx.Router) *OnionProcessor {
+func NewOnionProcessor(router OnionRouter) *OnionProcessor {
 	return &OnionProcessor{router}
 }
 
@@ -194,10 +276,60 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte) (HopIterat
 		}
 	}
 
-	return &sphinxHopIterator{
+	iterator := &sphinxHopIterator{
 		nextPacket:      sphinxPacket.NextPacket,
 		processedPacket: sphinxPacket,
-	}, lnwire.CodeNone
+	}
+
+	// If the packet has more hops, yet no next channel, then we're the
+	// exit hop, and the frames that follow ours carry our custom records.
+	// As these frames are also encrypted to us, we'll peel them off one
+	// by one until we reach the final frame.
+	fwdInst := sphinxPacket.ForwardingInstructions
+	if sphinxPacket.Action == sphinx.MoreHops &&
+		fwdInst.NextAddress == exitHopAddress {
+
+		records, err := p.peelExtraFrames(sphinxPacket.NextPacket, rHash)
+		if err != nil {
+			log.Errorf("unable to decode custom records: %v", err)
+			return nil, lnwire.CodeTemporaryChannelFailure
+		}
+
+		// With the custom records extracted, we'll mark the packet as
+		// having reached its destination.
+		sphinxPacket.Action = sphinx.ExitNode
+		iterator.nextPacket = nil
+		iterator.customRecords = records
+	}
+
+	return iterator, lnwire.CodeNone
+}
+
+// peelExtraFrames processes the frames of the passed onion packet which carry
+// the custom records of the exit hop, returning the decoded records.
+func (p *OnionProcessor) peelExtraFrames(onionPkt *sphinx.OnionPacket,
+	rHash []byte) (CustomRecords, error) {
+
+	var frames []sphinx.HopData
+	for {
+		sphinxPacket, err := p.router.ProcessOnionPacket(onionPkt, rHash)
+		if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, sphinxPacket.ForwardingInstructions)
+
+		if sphinxPacket.Action == sphinx.ExitNode {
+			break
+		}
+		if len(frames) >= sphinx.NumMaxHops {
+			return nil, fmt.Errorf("too many frames")
+		}
+
+		onionPkt = sphinxPacket.NextPacket
+	}
+
+	return decodeExtraFrames(frames)
 }
 
 // ExtractErrorEncrypter takes an io.Reader which should contain the onion
@@ -220,8 +352,17 @@ func (p *OnionProcessor) ExtractErrorEncrypter(r io.Reader) (ErrorEncrypter, lnw
 		}
 	}
 
-	onionObfuscator, err := sphinx.NewOnionErrorEncrypter(p.router,
-		onionPkt.EphemeralKey)
+	return p.ExtractErrorEncrypterFromKey(onionPkt.EphemeralKey)
+}
+
+// ExtractErrorEncrypterFromKey creates an ErrorEncrypter instance from the
+// ephemeral key of an onion packet. It's used directly to re-create the
+// encrypters of circuits read back from disk, whose onion packets are no
+// longer available.
+func (p *OnionProcessor) ExtractErrorEncrypterFromKey(
+	ephemeralKey *btcec.PublicKey) (ErrorEncrypter, lnwire.FailCode) {
+
+	onionObfuscator, err := p.router.NewOnionErrorEncrypter(ephemeralKey)
 	if err != nil {
 		switch err {
 		case sphinx.ErrInvalidOnionVersion:
@@ -238,5 +379,6 @@ func (p *OnionProcessor) ExtractErrorEncrypter(r io.Reader) (ErrorEncrypter, lnw
 
 	return &SphinxErrorEncrypter{
 		OnionErrorEncrypter: onionObfuscator,
+		EphemeralKey:        ephemeralKey,
 	}, lnwire.CodeNone
 }
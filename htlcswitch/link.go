@@ -2,6 +2,7 @@ package htlcswitch
 
 import (
 	"bytes"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -29,6 +32,27 @@ const (
 	//
 	// TODO(roasbeef): must be < default delta
 	expiryGraceDelta = 2
+
+	// heldHTLCCancelMargin is the number of blocks we leave between
+	// failing back an expiring held HTLC, and the height at which the
+	// remote party would go on-chain to time it out. This gives the
+	// failure time to be locked in, even if the remote party is slow to
+	// respond.
+	heldHTLCCancelMargin = 6
+
+	// heldHTLCCancelDelta is the number of blocks before the timeout of an
+	// HTLC held for an invoice at which we'll cancel the invoice, failing
+	// the HTLC back. It's strictly larger than the delta at which the
+	// remote party goes on-chain to time out its outgoing HTLC, so the
+	// HTLC is removed before either of us has to go to the chain.
+	heldHTLCCancelDelta = contractcourt.DefaultOutgoingBroadcastDelta +
+		heldHTLCCancelMargin
+)
+
+var (
+	// ErrLinkShuttingDown is returned when the resolution of a held HTLC
+	// can't be handed to the link, as it's shutting down.
+	ErrLinkShuttingDown = errors.New("link shutting down")
 )
 
 // ForwardingPolicy describes the set of constraints that a given ChannelLink
@@ -118,6 +142,17 @@ type ChannelLinkConfig struct {
 	// in thread-safe manner.
 	Registry InvoiceDatabase
 
+	// PreimageCache is a persistent store of payment preimages. Each
+	// preimage the remote party reveals to settle one of our outgoing
+	// HTLC's is added to the cache, allowing the corresponding incoming
+	// HTLC to be swept on-chain if necessary. If nil, then the learned
+	// preimages won't be persisted.
+	PreimageCache PreimageCache
+
+	// TowerClient, if non-nil, is used to back up each state revoked by
+	// the remote party to a watchtower.
+	TowerClient TowerClient
+
 	// FeeEstimator is an instance of a live fee estimator which will be
 	// used to dynamically regulate the current fee of the commitment
 	// transaction to ensure timely confirmation.
@@ -147,6 +182,11 @@ type ChannelLinkConfig struct {
 	// NOTE: HodlHTLC should be active in conjunction with DebugHTLC.
 	HodlHTLC bool
 
+	// AcceptKeySend should be active if we're to accept spontaneous
+	// payments, which carry their own preimage within the custom records
+	// of the onion payload rather than paying a prior invoice.
+	AcceptKeySend bool
+
 	// SyncStates is used to indicate that we need send the channel
 	// reestablishment message to the remote peer. It should be done if our
 	// clients have been restarted, or remote peer have been reconnected.
@@ -216,6 +256,15 @@ type channelLink struct {
 	logCommitTimer *time.Timer
 	logCommitTick  <-chan time.Time
 
+	// htlcResolutions is a channel over which the invoice registry
+	// delivers the resolutions of the HTLCs held by this link.
+	htlcResolutions chan HTLCResolution
+
+	// heldHTLCs maps the index of each HTLC held for an invoice, either as
+	// a shard of a multi-path payment or for a hold invoice, to the
+	// details required to cancel or fail it.
+	heldHTLCs map[uint64]*heldHTLC
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -231,10 +280,12 @@ func NewChannelLink(cfg ChannelLinkConfig, channel *lnwallet.LightningChannel,
 		mailBox:     newMemoryMailBox(),
 		linkControl: make(chan interface{}),
 		// TODO(roasbeef): just do reserve here?
-		logCommitTimer: time.NewTimer(300 * time.Millisecond),
-		overflowQueue:  newPacketQueue(lnwallet.MaxHTLCNumber / 2),
-		bestHeight:     currentHeight,
-		quit:           make(chan struct{}),
+		logCommitTimer:  time.NewTimer(300 * time.Millisecond),
+		overflowQueue:   newPacketQueue(lnwallet.MaxHTLCNumber / 2),
+		bestHeight:      currentHeight,
+		htlcResolutions: make(chan HTLCResolution),
+		heldHTLCs:       make(map[uint64]*heldHTLC),
+		quit:            make(chan struct{}),
 	}
 
 	link.upstream = link.mailBox.MessageOutBox()
@@ -260,6 +311,23 @@ func (l *channelLink) Start() error {
 
 	log.Infof("ChannelLink(%v) is starting", l)
 
+	// Before any new adds are processed, we'll trim the circuits of adds
+	// that were opened before we went down, but never committed to. Their
+	// HTLC IDs will be reused by new adds, so the switch must fail them
+	// back instead.
+	localHtlcIndex, err := l.channel.NextLocalHtlcIndex()
+	if err != nil {
+		return errors.Errorf("unable to retrieve next local htlc "+
+			"index: %v", err)
+	}
+	err = l.cfg.Switch.circuits.TrimOpenCircuits(
+		l.ShortChanID(), localHtlcIndex,
+	)
+	if err != nil {
+		return errors.Errorf("unable to trim circuits above local "+
+			"htlc index %d: %v", localHtlcIndex, err)
+	}
+
 	l.mailBox.Start()
 	l.overflowQueue.Start()
 
@@ -442,6 +510,14 @@ func (l *channelLink) htlcManager() {
 		}
 	}
 
+	// Any HTLCs that were held by a prior instance of this link are still
+	// held by the invoice registry, so we'll take them over, such that
+	// their resolutions are handed to us.
+	if err := l.resumeHeldHTLCs(); err != nil {
+		l.fail("unable to resume held htlcs: %v", err)
+		return
+	}
+
 	// TODO(roasbeef): check to see if able to settle any currently pending
 	// HTLCs
 	//   * also need signals when new invoices are added by the
@@ -464,6 +540,8 @@ out:
 
 			l.bestHeight = uint32(blockEpoch.Height)
 
+			l.cancelExpiringHTLCs()
+
 			// If we're not the initiator of the channel, don't we
 			// don't control the fees, so we can ignore this.
 			if !l.channel.IsInitiator() {
@@ -595,6 +673,14 @@ out:
 		case msg := <-l.upstream:
 			l.handleUpstreamMsg(msg)
 
+		// The invoice registry has decided the fate of an HTLC we've
+		// been holding on to, so we'll either settle or fail it.
+		case res := <-l.htlcResolutions:
+			if err := l.resolveHeldHTLC(res); err != nil {
+				l.fail("unable to resolve held htlc: %v", err)
+				break out
+			}
+
 		// TODO(roasbeef): make distinct goroutine to handle?
 		case cmd := <-l.linkControl:
 
@@ -664,47 +750,7 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket, isReProcess bool) {
 			default:
 				log.Warnf("Unable to handle downstream add HTLC: %v", err)
 
-				var (
-					localFailure = false
-					reason       lnwire.OpaqueReason
-				)
-
-				failure := lnwire.NewTemporaryChannelFailure(nil)
-
-				// Encrypt the error back to the source unless the payment was
-				// generated locally.
-				if pkt.obfuscator == nil {
-					var b bytes.Buffer
-					err := lnwire.EncodeFailure(&b, failure, 0)
-					if err != nil {
-						log.Errorf("unable to encode failure: %v", err)
-						return
-					}
-					reason = lnwire.OpaqueReason(b.Bytes())
-					localFailure = true
-				} else {
-					var err error
-					reason, err = pkt.obfuscator.EncryptFirstHop(failure)
-					if err != nil {
-						log.Errorf("unable to obfuscate error: %v", err)
-						return
-					}
-				}
-
-				failPkt := &htlcPacket{
-					incomingChanID: pkt.incomingChanID,
-					incomingHTLCID: pkt.incomingHTLCID,
-					amount:         htlc.Amount,
-					isRouted:       true,
-					localFailure:   localFailure,
-					htlc: &lnwire.UpdateFailHTLC{
-						Reason: reason,
-					},
-				}
-
-				// TODO(roasbeef): need to identify if sent
-				// from switch so don't need to obfuscate
-				go l.cfg.Switch.forward(failPkt)
+				l.failDownstreamAdd(pkt, htlc)
 				return
 			}
 		}
@@ -714,15 +760,31 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket, isReProcess bool) {
 			htlc.PaymentHash[:], index, l.batchCounter+1)
 
 		// Create circuit (remember the path) in order to forward settle/fail
-		// packet back.
-		l.cfg.Switch.addCircuit(&PaymentCircuit{
+		// packet back. The circuit is opened before the add is sent to
+		// the remote peer, so the keystone is on disk before the HTLC
+		// can be locked in.
+		err = l.cfg.Switch.addCircuit(&PaymentCircuit{
 			PaymentHash:    htlc.PaymentHash,
 			IncomingChanID: pkt.incomingChanID,
 			IncomingHTLCID: pkt.incomingHTLCID,
 			OutgoingChanID: l.ShortChanID(),
 			OutgoingHTLCID: index,
+			IncomingAmount: pkt.incomingAmount,
+			OutgoingAmount: htlc.Amount,
 			ErrorEncrypter: pkt.obfuscator,
 		})
+		if err != nil {
+			// Without the circuit, a settle or fail of the HTLC
+			// couldn't be routed back, so the HTLC must never
+			// reach the remote peer. We cancel the payment
+			// upstream, and as the add is already within our local
+			// update log, we fail the link so the channel state
+			// is restored from disk without it.
+			l.failDownstreamAdd(pkt, htlc)
+			l.fail("unable to open circuit for htlc with "+
+				"hash(%x): %v", htlc.PaymentHash[:], err)
+			return
+		}
 
 		htlc.ID = index
 		l.cfg.Peer.SendMessage(htlc)
@@ -783,6 +845,55 @@ func (l *channelLink) handleDownStreamPkt(pkt *htlcPacket, isReProcess bool) {
 	}
 }
 
+// failDownstreamAdd cancels an HTLC add that was sent by the switch, but
+// couldn't be forwarded over this link, by sending a temporary channel failure
+// back to its source.
+func (l *channelLink) failDownstreamAdd(pkt *htlcPacket,
+	htlc *lnwire.UpdateAddHTLC) {
+
+	var (
+		localFailure = false
+		reason       lnwire.OpaqueReason
+	)
+
+	failure := lnwire.NewTemporaryChannelFailure(nil)
+
+	// Encrypt the error back to the source unless the payment was
+	// generated locally.
+	if pkt.obfuscator == nil {
+		var b bytes.Buffer
+		err := lnwire.EncodeFailure(&b, failure, 0)
+		if err != nil {
+			log.Errorf("unable to encode failure: %v", err)
+			return
+		}
+		reason = lnwire.OpaqueReason(b.Bytes())
+		localFailure = true
+	} else {
+		var err error
+		reason, err = pkt.obfuscator.EncryptFirstHop(failure)
+		if err != nil {
+			log.Errorf("unable to obfuscate error: %v", err)
+			return
+		}
+	}
+
+	failPkt := &htlcPacket{
+		incomingChanID: pkt.incomingChanID,
+		incomingHTLCID: pkt.incomingHTLCID,
+		amount:         htlc.Amount,
+		isRouted:       true,
+		localFailure:   localFailure,
+		htlc: &lnwire.UpdateFailHTLC{
+			Reason: reason,
+		},
+	}
+
+	// TODO(roasbeef): need to identify if sent
+	// from switch so don't need to obfuscate
+	go l.cfg.Switch.forward(failPkt)
+}
+
 // handleUpstreamMsg processes wire messages related to commitment state
 // updates from the upstream peer. The upstream peer is the peer whom we have a
 // direct channel with, updating our respective commitment chains.
@@ -796,9 +907,42 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 		// so we'll process the message  in order to determine if we
 		// need to re-transmit any messages to the remote party.
 		msgsToReSend, err := l.channel.ProcessChanSyncMsg(msg)
-		if err != nil {
-			// TODO(roasbeef): check concrete type of error, act
-			// accordingly
+		switch {
+		// If we've lost state, then our latest commitment has likely
+		// been revoked, so we can't force close the channel ourselves.
+		// Instead, we'll ask the remote party to fail the channel by
+		// sending them an error, and remove the link so no further
+		// updates are processed. We stay connected to the peer so the
+		// error is delivered.
+		case err == lnwallet.ErrCommitSyncDataLoss:
+			log.Errorf("ChannelPoint(%v) has lost state, requesting "+
+				"that the remote party force close",
+				l.channel.ChannelPoint())
+
+			l.cfg.Peer.SendMessage(&lnwire.Error{
+				ChanID: l.ChanID(),
+				Data:   lnwire.ErrorData(err.Error()),
+			})
+
+			go func() {
+				chanPoint := l.channel.ChannelPoint()
+				if err := l.cfg.Peer.WipeChannel(chanPoint); err != nil {
+					log.Errorf("unable to wipe channel %v", err)
+				}
+			}()
+			return
+
+		// If the remote party has lost state, then they'll fail the
+		// channel once they process our ChannelReestablish message,
+		// requesting that we force close. We'll stay connected to the
+		// peer so their request is delivered, but won't process any
+		// further updates.
+		case err == lnwallet.ErrCommitSyncRemoteDataLoss:
+			log.Warnf("Remote party of ChannelPoint(%v) has lost "+
+				"state", l.channel.ChannelPoint())
+			return
+
+		case err != nil:
 			l.fail("unable to handle upstream reestablish "+
 				"message: %v", err)
 			return
@@ -841,8 +985,17 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
-		// TODO(roasbeef): add preimage to DB in order to swipe
-		// repeated r-values
+		// Now that the remote party has revealed the preimage, we'll
+		// add it to the preimage cache, so we're able to claim the
+		// incoming HTLC on-chain in the case that the upstream
+		// channel is force closed before we can settle it off-chain.
+		if l.cfg.PreimageCache != nil {
+			if err := l.cfg.PreimageCache.AddPreimage(pre[:]); err != nil {
+				l.fail("unable to add preimage=%x to cache: %v",
+					pre[:], err)
+				return
+			}
+		}
 
 	case *lnwire.UpdateFailMalformedHTLC:
 		// Convert the failure type encoded within the HTLC fail
@@ -951,6 +1104,13 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 			return
 		}
 
+		// Now that the remote party has revoked their prior state,
+		// we'll back it up to our watchtower, allowing it to punish a
+		// breach while we're offline.
+		if l.cfg.TowerClient != nil {
+			l.backupRevokedState()
+		}
+
 		// After we treat HTLCs as included in both remote/local
 		// commitment transactions they might be safely propagated over
 		// htlc switch or settled if our node was last node in htlc
@@ -980,6 +1140,25 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 	}
 }
 
+// backupRevokedState hands the retribution information for the state most
+// recently revoked by the remote party to the watchtower client. A failure to
+// back up the state isn't fatal to the link, as we're still able to punish a
+// breach ourselves while online.
+func (l *channelLink) backupRevokedState() {
+	breachInfo, err := l.channel.LastRevokedRetribution()
+	if err != nil {
+		log.Errorf("channel link(%v): unable to create retribution "+
+			"for revoked state: %v", l, err)
+		return
+	}
+
+	chanInfo := l.channel.StateSnapshot()
+	if err := l.cfg.TowerClient.BackupState(chanInfo, breachInfo); err != nil {
+		log.Errorf("channel link(%v): unable to backup revoked "+
+			"state=%v: %v", l, breachInfo.RevokedStateNum, err)
+	}
+}
+
 // updateCommitTx signs, then sends an update to the remote peer adding a new
 // commitment to their commitment chain which includes all the latest updates
 // we've received+processed up to this point.
@@ -1298,6 +1477,23 @@ func (l *channelLink) processLockedInHtlcs(
 					continue
 				}
 
+				// If the sender included a preimage within the
+				// onion, then this is a spontaneous payment, so
+				// we'll create an invoice for it on the fly.
+				_, isKeySend := fwdInfo.CustomRecords[KeySendType]
+				if isKeySend && l.cfg.AcceptKeySend {
+					err := l.addKeySendInvoice(pd, fwdInfo)
+					if err != nil {
+						log.Errorf("unable to accept "+
+							"keysend htlc(%x): %v",
+							pd.RHash[:], err)
+						failure := lnwire.FailUnknownPaymentHash{}
+						l.sendHTLCError(pd.HtlcIndex, failure, obfuscator)
+						needUpdate = true
+						continue
+					}
+				}
+
 				// We're the designated payment destination.
 				// Therefore we attempt to see if we have an
 				// invoice locally which'll allow us to settle
@@ -1313,13 +1509,50 @@ func (l *channelLink) processLockedInHtlcs(
 					continue
 				}
 
+				// An invoice that has been canceled or has
+				// expired can no longer be paid.
+				if invoice.Terms.State == channeldb.ContractCanceled ||
+					invoice.Terms.State == channeldb.ContractExpired {
+
+					log.Errorf("rejecting htlc(%x) paying "+
+						"to %v invoice", pd.RHash[:],
+						invoice.Terms.State)
+					failure := lnwire.FailUnknownPaymentHash{}
+					l.sendHTLCError(pd.HtlcIndex, failure, obfuscator)
+					needUpdate = true
+					continue
+				}
+
+				// If the sender split the payment across
+				// multiple routes, then the HTLC only needs to
+				// carry its own shard, but the total of the
+				// payment must match the invoice.
+				isShard := fwdInfo.TotalAmount != 0
+				if isShard && !l.cfg.DebugHTLC &&
+					(fwdInfo.TotalAmount != invoice.Terms.Value ||
+						pd.Amount < fwdInfo.AmountToForward) {
+
+					log.Errorf("rejecting shard of htlc(%x) "+
+						"due to incorrect amount: "+
+						"expected total %v, got %v, "+
+						"received %v", pd.RHash[:],
+						invoice.Terms.Value,
+						fwdInfo.TotalAmount, pd.Amount)
+					failure := lnwire.FailIncorrectPaymentAmount{}
+					l.sendHTLCError(pd.HtlcIndex, failure, obfuscator)
+					needUpdate = true
+					continue
+				}
+
 				// If we're not currently in debug mode, and
 				// the extended htlc doesn't meet the value
 				// requested, then we'll fail the htlc.
 				// Otherwise, we settle this htlc within our
 				// local state update log, then send the update
 				// entry to the remote party.
-				if !l.cfg.DebugHTLC && pd.Amount < invoice.Terms.Value {
+				if !isShard && !l.cfg.DebugHTLC &&
+					pd.Amount < invoice.Terms.Value {
+
 					log.Errorf("rejecting htlc due to incorrect "+
 						"amount: expected %v, received %v",
 						invoice.Terms.Value, pd.Amount)
@@ -1334,7 +1567,7 @@ func (l *channelLink) processLockedInHtlcs(
 				// ensure that it was crafted correctly by the
 				// sender and matches the HTLC we were
 				// extended.
-				if !l.cfg.DebugHTLC &&
+				if !isShard && !l.cfg.DebugHTLC &&
 					fwdInfo.AmountToForward != invoice.Terms.Value {
 
 					log.Errorf("Onion payload of incoming "+
@@ -1391,6 +1624,43 @@ func (l *channelLink) processLockedInHtlcs(
 					continue
 				}
 
+				// A shard of a multi-path payment is held
+				// until the invoice registry has seen the
+				// remaining shards arrive, and an HTLC paying
+				// to a hold invoice until the invoice is
+				// either settled or canceled. Either way, the
+				// registry will hand us the resolution of the
+				// HTLC.
+				isHold := invoice.Terms.PaymentPreimage ==
+					channeldb.UnknownPreimage
+				if isShard || isHold {
+					l.heldHTLCs[pd.HtlcIndex] = &heldHTLC{
+						obfuscator: obfuscator,
+						hash:       invoiceHash,
+						expiry:     pd.Timeout,
+					}
+
+					htlc := channeldb.InvoiceHTLC{
+						ChanID:     l.ShortChanID(),
+						HtlcID:     pd.HtlcIndex,
+						Amt:        pd.Amount,
+						AcceptTime: time.Now(),
+					}
+					err := l.cfg.Registry.HoldInvoiceHTLC(
+						invoiceHash, htlc, l.deliverHTLCResolution,
+					)
+					if err != nil {
+						log.Errorf("unable to hold "+
+							"htlc(%x): %v", pd.RHash[:],
+							err)
+						delete(l.heldHTLCs, pd.HtlcIndex)
+						failure := lnwire.FailUnknownPaymentHash{}
+						l.sendHTLCError(pd.HtlcIndex, failure, obfuscator)
+						needUpdate = true
+					}
+					continue
+				}
+
 				preimage := invoice.Terms.PaymentPreimage
 				err = l.channel.SettleHTLC(preimage, pd.HtlcIndex)
 				if err != nil {
@@ -1578,6 +1848,7 @@ func (l *channelLink) processLockedInHtlcs(
 					incomingHTLCID: pd.HtlcIndex,
 					outgoingChanID: fwdInfo.NextHop,
 					amount:         addMsg.Amount,
+					incomingAmount: pd.Amount,
 					htlc:           addMsg,
 					obfuscator:     obfuscator,
 				}
@@ -1599,6 +1870,188 @@ func (l *channelLink) processLockedInHtlcs(
 	return packetsToForward
 }
 
+// heldHTLC tracks an HTLC held by the link until the invoice registry hands
+// over its resolution.
+type heldHTLC struct {
+	// obfuscator is the error encrypter required to fail the HTLC back to
+	// the sender.
+	obfuscator ErrorEncrypter
+
+	// hash is the payment hash of the invoice the HTLC pays to.
+	hash chainhash.Hash
+
+	// expiry is the absolute height at which the HTLC times out.
+	expiry uint32
+}
+
+// deliverHTLCResolution hands the resolution of a held HTLC to the link's main
+// goroutine. It's passed to the invoice registry as the callback for each HTLC
+// the link holds. If the link is shutting down, then ErrLinkShuttingDown is
+// returned, and the registry keeps holding the HTLC until the next instance
+// of the link resumes it.
+func (l *channelLink) deliverHTLCResolution(res HTLCResolution) error {
+	select {
+	case l.htlcResolutions <- res:
+		return nil
+	case <-l.quit:
+		return ErrLinkShuttingDown
+	}
+}
+
+// resumeHeldHTLCs takes over the HTLCs of this channel that are held by the
+// invoice registry, restoring the details required to fail or cancel them
+// from the HTLCs on our commitment.
+func (l *channelLink) resumeHeldHTLCs() error {
+	held, err := l.cfg.Registry.ResumeHeldHTLCs(
+		l.ShortChanID(), l.deliverHTLCResolution,
+	)
+	if err != nil {
+		return err
+	}
+	if len(held) == 0 {
+		return nil
+	}
+
+	incoming := make(map[uint64]channeldb.HTLC)
+	for _, htlc := range l.channel.StateSnapshot().Htlcs {
+		if htlc.Incoming {
+			incoming[htlc.HtlcIndex] = htlc
+		}
+	}
+
+	for htlcID, hash := range held {
+		htlc, ok := incoming[htlcID]
+		if !ok {
+			log.Warnf("ChannelPoint(%v): held htlc %v not found "+
+				"on commitment", l.channel.ChannelPoint(),
+				htlcID)
+			continue
+		}
+
+		obfuscator, failureCode := l.cfg.DecodeOnionObfuscator(
+			bytes.NewReader(htlc.OnionBlob),
+		)
+		if failureCode != lnwire.CodeNone {
+			log.Errorf("ChannelPoint(%v): unable to decode onion "+
+				"obfuscator of held htlc %v: %v",
+				l.channel.ChannelPoint(), htlcID, failureCode)
+			continue
+		}
+
+		l.heldHTLCs[htlcID] = &heldHTLC{
+			obfuscator: obfuscator,
+			hash:       hash,
+			expiry:     htlc.RefundTimeout,
+		}
+	}
+
+	log.Infof("ChannelPoint(%v): resumed %v held htlcs",
+		l.channel.ChannelPoint(), len(l.heldHTLCs))
+
+	// Some of the HTLCs may have come close to their expiry while the
+	// link was offline.
+	l.cancelExpiringHTLCs()
+
+	return nil
+}
+
+// cancelExpiringHTLCs cancels the invoices of all held HTLCs that time out
+// within heldHTLCCancelDelta blocks. The registry then fails the HTLCs back
+// through their resolutions, ensuring that we never have to go to the chain
+// to time out an HTLC we've been holding on to.
+func (l *channelLink) cancelExpiringHTLCs() {
+	for htlcID, htlc := range l.heldHTLCs {
+		if htlc.expiry > l.bestHeight+heldHTLCCancelDelta {
+			continue
+		}
+
+		log.Infof("ChannelPoint(%v): canceling invoice %x as held "+
+			"htlc %v expires at height %v",
+			l.channel.ChannelPoint(), htlc.hash[:], htlcID,
+			htlc.expiry)
+
+		err := l.cfg.Registry.CancelInvoice(htlc.hash)
+		if err != nil {
+			log.Errorf("unable to cancel invoice %x: %v",
+				htlc.hash[:], err)
+		}
+	}
+}
+
+// resolveHeldHTLC settles or fails a held HTLC as dictated by the passed
+// resolution, and then initiates a state transition to lock in the update.
+func (l *channelLink) resolveHeldHTLC(res HTLCResolution) error {
+	htlc, ok := l.heldHTLCs[res.HtlcID]
+	if !ok {
+		log.Warnf("ChannelPoint(%v): received resolution for unknown "+
+			"held htlc %v", l.channel.ChannelPoint(), res.HtlcID)
+		return nil
+	}
+	delete(l.heldHTLCs, res.HtlcID)
+
+	if !res.Settle {
+		log.Infof("ChannelPoint(%v): failing held htlc %v: %v",
+			l.channel.ChannelPoint(), res.HtlcID, res.Failure)
+
+		l.sendHTLCError(res.HtlcID, res.Failure, htlc.obfuscator)
+		return l.updateCommitTx()
+	}
+
+	if err := l.channel.SettleHTLC(res.Preimage, res.HtlcID); err != nil {
+		return err
+	}
+
+	l.cfg.Peer.SendMessage(&lnwire.UpdateFufillHTLC{
+		ChanID:          l.ChanID(),
+		ID:              res.HtlcID,
+		PaymentPreimage: res.Preimage,
+	})
+
+	return l.updateCommitTx()
+}
+
+// addKeySendInvoice creates an invoice for a spontaneous payment, using the
+// preimage carried within the custom records of the onion payload. The
+// preimage must match the payment hash of the HTLC. If an invoice for the
+// payment hash already exists, then it's left untouched.
+func (l *channelLink) addKeySendInvoice(pd *lnwallet.PaymentDescriptor,
+	fwdInfo ForwardingInfo) error {
+
+	preimageBytes := fwdInfo.CustomRecords[KeySendType]
+	if len(preimageBytes) != 32 {
+		return fmt.Errorf("invalid preimage length %v",
+			len(preimageBytes))
+	}
+
+	var preimage [32]byte
+	copy(preimage[:], preimageBytes)
+	if sha256.Sum256(preimage[:]) != pd.RHash {
+		return fmt.Errorf("preimage doesn't match payment hash")
+	}
+
+	_, err := l.cfg.Registry.LookupInvoice(chainhash.Hash(pd.RHash))
+	if err == nil {
+		return nil
+	}
+
+	// The invoice is for the full amount of the payment, which may have
+	// been split across several HTLCs.
+	value := fwdInfo.AmountToForward
+	if fwdInfo.TotalAmount != 0 {
+		value = fwdInfo.TotalAmount
+	}
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			PaymentPreimage: preimage,
+			Value:           value,
+		},
+	}
+
+	return l.cfg.Registry.AddInvoice(invoice)
+}
+
 // sendHTLCError functions cancels HTLC and send cancel message back to the
 // peer from which HTLC was received.
 func (l *channelLink) sendHTLCError(htlcIndex uint64,
@@ -13,10 +13,12 @@ import (
 
 	"math"
 
+	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -233,7 +235,7 @@ func TestChannelLinkSingleHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("alice invoice wasn't settled")
 	}
 
@@ -458,7 +460,7 @@ func TestChannelLinkMultiHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get inveoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -798,7 +800,7 @@ func TestUpdateForwardingPolicy(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -914,7 +916,7 @@ func TestChannelLinkMultiHopInsufficientPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get inveoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -985,13 +987,13 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 	invoice.Terms.PaymentPreimage[0] ^= byte(255)
 
 	// Check who is last in the route and add invoice to server registry.
-	if err := n.carolServer.registry.AddInvoice(*invoice); err != nil {
+	if err := n.carolServer.registry.AddInvoice(invoice); err != nil {
 		t.Fatalf("unable to add invoice in carol registry: %v", err)
 	}
 
 	// Send payment and expose err channel.
-	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
-		newMockDeobfuscator())
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(),
+		nextPaymentID(), htlc, newMockDeobfuscator())
 	if err.Error() != lnwire.CodeUnknownPaymentHash.String() {
 		t.Fatal("error haven't been received")
 	}
@@ -1001,7 +1003,7 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 
 	// Check that alice invoice wasn't settled and bandwidth of htlc
 	// links hasn't been changed.
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("alice invoice was settled")
 	}
 
@@ -1078,7 +1080,7 @@ func TestChannelLinkMultiHopUnknownNextHop(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get inveoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -1166,7 +1168,7 @@ func TestChannelLinkMultiHopDecodeError(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get inveoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -1984,7 +1986,7 @@ func TestChannelRetransmission(t *testing.T) {
 				err = errors.Errorf("unable to get invoice: %v", err)
 				continue
 			}
-			if !invoice.Terms.Settled {
+			if invoice.Terms.State != channeldb.ContractSettled {
 				err = errors.Errorf("alice invoice haven't been settled")
 				continue
 			}
@@ -2265,3 +2267,320 @@ func TestChannelLinkUpdateCommitFee(t *testing.T) {
 			aliceFeeRate, bobFeeRate)
 	}
 }
+
+// holdPaymentExpiry returns the expiry of the HTLC sent by makeHoldPayment.
+// It lies heldHTLCCancelDelta blocks past the earliest expiry Bob accepts, so
+// the HTLC isn't canceled before any blocks are mined.
+func holdPaymentExpiry(n *threeHopNetwork) uint32 {
+	return testStartingHeight + heldHTLCCancelDelta +
+		n.firstBobChannelLink.cfg.FwrdingPolicy.TimeLockDelta
+}
+
+// makeHoldPayment sends a payment from Alice to a hold invoice within Bob's
+// registry, returning the preimage of the invoice and the amount of the HTLC,
+// along with the channel the result of the payment is delivered over.
+func makeHoldPayment(t *testing.T, n *threeHopNetwork) ([32]byte,
+	lnwire.MilliSatoshi, chan error) {
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount,
+		testStartingHeight+heldHTLCCancelDelta, n.firstBobChannelLink)
+
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatalf("unable to generate route: %v", err)
+	}
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatalf("unable to generate payment: %v", err)
+	}
+
+	// Bob doesn't know the preimage of a hold invoice until it's settled.
+	preimage := invoice.Terms.PaymentPreimage
+	invoice.Terms.PaymentHash = fastsha256.Sum256(preimage[:])
+	invoice.Terms.PaymentPreimage = channeldb.UnknownPreimage
+	if err := n.bobServer.registry.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), nextPaymentID(), htlc,
+			newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	return preimage, htlcAmt, paymentErr
+}
+
+// waitForInvoiceState waits for the invoice with the passed payment hash to
+// reach the expected state.
+func waitForInvoiceState(t *testing.T, registry *mockInvoiceRegistry,
+	rhash chainhash.Hash, state channeldb.ContractState) {
+
+	var invoice channeldb.Invoice
+	for i := 0; i < 50; i++ {
+		var err error
+		invoice, err = registry.LookupInvoice(rhash)
+		if err != nil {
+			t.Fatalf("unable to get invoice: %v", err)
+		}
+		if invoice.Terms.State == state {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("expected invoice to be %v, instead it's %v", state,
+		invoice.Terms.State)
+}
+
+// TestChannelLinkHoldInvoiceSettle asserts that an HTLC paying to a hold
+// invoice is held by the link until the invoice is settled.
+func TestChannelLinkHoldInvoiceSettle(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	preimage, _, paymentErr := makeHoldPayment(t, n)
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	// Once the HTLC has been accepted, it's held by Bob, so the payment
+	// shouldn't complete yet.
+	waitForInvoiceState(t, n.bobServer.registry, rhash,
+		channeldb.ContractAccepted)
+
+	select {
+	case err := <-paymentErr:
+		t.Fatalf("payment completed before settling: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if err := n.bobServer.registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle hold invoice: %v", err)
+	}
+
+	select {
+	case err := <-paymentErr:
+		if err != nil {
+			t.Fatalf("unable to make the payment: %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't completed")
+	}
+}
+
+// TestChannelLinkHoldInvoiceCancel asserts that an HTLC paying to a hold
+// invoice is failed back once the invoice is canceled.
+func TestChannelLinkHoldInvoiceCancel(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	aliceBandwidthBefore := n.aliceChannelLink.Bandwidth()
+
+	preimage, _, paymentErr := makeHoldPayment(t, n)
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	waitForInvoiceState(t, n.bobServer.registry, rhash,
+		channeldb.ContractAccepted)
+
+	if err := n.bobServer.registry.CancelInvoice(rhash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+
+	select {
+	case err := <-paymentErr:
+		if err == nil {
+			t.Fatalf("payment succeeded despite canceled invoice")
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't failed")
+	}
+
+	// Wait for the failure to be locked in, after which Alice should've
+	// regained the full bandwidth of the channel.
+	time.Sleep(100 * time.Millisecond)
+
+	if n.aliceChannelLink.Bandwidth() != aliceBandwidthBefore {
+		t.Fatalf("alice bandwidth should be restored: expected %v, "+
+			"got %v", aliceBandwidthBefore,
+			n.aliceChannelLink.Bandwidth())
+	}
+}
+
+// TestChannelLinkHoldInvoiceRestart asserts that an HTLC paying to a hold
+// invoice is resumed by Bob's link once it's restarted, such that settling the
+// invoice afterwards settles the HTLC.
+func TestChannelLinkHoldInvoiceRestart(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, restoreChannelsFromDb, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*5,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	bobBandwidthBefore := n.firstBobChannelLink.Bandwidth()
+
+	preimage, htlcAmt, _ := makeHoldPayment(t, n)
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	bobRegistry := n.bobServer.registry
+	waitForInvoiceState(t, bobRegistry, rhash, channeldb.ContractAccepted)
+
+	// Restart the network with the channel states on disk. Bob's
+	// registry is carried over, as it would be persisted.
+	n.stop()
+
+	channels, err = restoreChannelsFromDb()
+	if err != nil {
+		t.Fatalf("unable to restore channels from database: %v", err)
+	}
+
+	n = newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	n.firstBobChannelLink.cfg.Registry = bobRegistry
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	if err := bobRegistry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle hold invoice: %v", err)
+	}
+
+	// The restarted link should settle the HTLC, crediting Bob with its
+	// amount.
+	bobExpectedBandwidth := bobBandwidthBefore + htlcAmt
+	for i := 0; i < 50; i++ {
+		if n.firstBobChannelLink.Bandwidth() == bobExpectedBandwidth {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("expected bob to have %v, instead has %v",
+		bobExpectedBandwidth, n.firstBobChannelLink.Bandwidth())
+}
+
+// TestChannelLinkHoldInvoiceExpiry asserts that an HTLC paying to a hold
+// invoice is failed back once it's within heldHTLCCancelDelta blocks of its
+// expiry, which is before Alice would go on-chain to time it out.
+func TestChannelLinkHoldInvoiceExpiry(t *testing.T) {
+	t.Parallel()
+
+	if heldHTLCCancelDelta <= contractcourt.DefaultOutgoingBroadcastDelta {
+		t.Fatalf("held htlcs are canceled %v blocks before expiry, "+
+			"which doesn't precede the outgoing broadcast delta "+
+			"of %v", heldHTLCCancelDelta,
+			contractcourt.DefaultOutgoingBroadcastDelta)
+	}
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	preimage, _, paymentErr := makeHoldPayment(t, n)
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+
+	waitForInvoiceState(t, n.bobServer.registry, rhash,
+		channeldb.ContractAccepted)
+
+	notifyBlock := func(height uint32) {
+		select {
+		case n.bobFirstBlockEpoch <- &chainntnfs.BlockEpoch{
+			Height: int32(height),
+		}:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block %v wasn't received by bob", height)
+		}
+	}
+
+	// One block short of the cancel delta, the HTLC should still be held.
+	cancelHeight := holdPaymentExpiry(n) - heldHTLCCancelDelta
+	notifyBlock(cancelHeight - 1)
+
+	select {
+	case err := <-paymentErr:
+		t.Fatalf("payment completed before reaching the cancel "+
+			"height: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	invoice, err := n.bobServer.registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if invoice.Terms.State != channeldb.ContractAccepted {
+		t.Fatalf("invoice was %v before reaching the cancel height",
+			invoice.Terms.State)
+	}
+
+	// At the cancel height, the invoice is canceled and the HTLC failed
+	// back to Alice.
+	notifyBlock(cancelHeight)
+
+	waitForInvoiceState(t, n.bobServer.registry, rhash,
+		channeldb.ContractCanceled)
+
+	select {
+	case err := <-paymentErr:
+		if err == nil {
+			t.Fatalf("payment succeeded despite expiring htlc")
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't failed")
+	}
+}
@@ -226,6 +226,18 @@ func (o *mockObfuscator) IntermediateEncrypt(reason lnwire.OpaqueReason) lnwire.
 
 }
 
+func (o *mockObfuscator) Type() EncrypterType {
+	return EncrypterTypeMock
+}
+
+func (o *mockObfuscator) Encode(w io.Writer) error {
+	return nil
+}
+
+func (o *mockObfuscator) Decode(r io.Reader) error {
+	return nil
+}
+
 // mockDeobfuscator mock implementation of the failure deobfuscator which
 // only decodes the failure do not makes any onion obfuscation.
 type mockDeobfuscator struct{}
@@ -428,6 +440,8 @@ func (f *mockChannelLink) HandleSwitchPacket(packet *htlcPacket) {
 			IncomingHTLCID: packet.incomingHTLCID,
 			OutgoingChanID: f.shortChanID,
 			OutgoingHTLCID: f.htlcID,
+			IncomingAmount: packet.incomingAmount,
+			OutgoingAmount: htlc.Amount,
 			ErrorEncrypter: packet.obfuscator,
 		})
 		f.htlcID++
@@ -450,20 +464,32 @@ func (f *mockChannelLink) ChanID() lnwire.ChannelID           { return f.chanID
 func (f *mockChannelLink) ShortChanID() lnwire.ShortChannelID { return f.shortChanID }
 func (f *mockChannelLink) Bandwidth() lnwire.MilliSatoshi     { return 99999999 }
 func (f *mockChannelLink) Peer() Peer                         { return f.peer }
-func (f *mockChannelLink) Start() error                       { return nil }
 func (f *mockChannelLink) Stop()                              {}
 func (f *mockChannelLink) EligibleToForward() bool            { return f.eligible }
 
+// Start trims the circuits of all HTLCs the mock link hasn't assigned an ID
+// to, mirroring the trimming of uncommitted adds done by the real link.
+func (f *mockChannelLink) Start() error {
+	return f.htlcSwitch.circuits.TrimOpenCircuits(f.shortChanID, f.htlcID)
+}
+
 var _ ChannelLink = (*mockChannelLink)(nil)
 
+type mockHeldHTLC struct {
+	htlc    channeldb.InvoiceHTLC
+	resolve func(HTLCResolution) error
+}
+
 type mockInvoiceRegistry struct {
 	sync.Mutex
-	invoices map[chainhash.Hash]channeldb.Invoice
+	invoices  map[chainhash.Hash]channeldb.Invoice
+	heldHTLCs map[chainhash.Hash][]*mockHeldHTLC
 }
 
 func newMockRegistry() *mockInvoiceRegistry {
 	return &mockInvoiceRegistry{
-		invoices: make(map[chainhash.Hash]channeldb.Invoice),
+		invoices:  make(map[chainhash.Hash]channeldb.Invoice),
+		heldHTLCs: make(map[chainhash.Hash][]*mockHeldHTLC),
 	}
 }
 
@@ -488,23 +514,215 @@ func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash) error {
 		return errors.New("can't find mock invoice")
 	}
 
-	invoice.Terms.Settled = true
+	invoice.Terms.State = channeldb.ContractSettled
 	i.invoices[rhash] = invoice
 
 	return nil
 }
 
-func (i *mockInvoiceRegistry) AddInvoice(invoice channeldb.Invoice) error {
+// resolveHeld hands the resolution to the held HTLC. Like the registry, the
+// HTLC is only released once its link has received the resolution, otherwise
+// it remains held until it's resumed.
+//
+// NOTE: This method MUST be called with the mutex held.
+func (i *mockInvoiceRegistry) resolveHeld(rhash chainhash.Hash,
+	h *mockHeldHTLC, res HTLCResolution) {
+
+	res.HtlcID = h.htlc.HtlcID
+	resolve := h.resolve
+
+	go func() {
+		if err := resolve(res); err != nil {
+			return
+		}
+
+		i.Lock()
+		defer i.Unlock()
+
+		held := i.heldHTLCs[rhash]
+		for idx, other := range held {
+			if other == h {
+				held = append(held[:idx], held[idx+1:]...)
+				break
+			}
+		}
+		if len(held) == 0 {
+			delete(i.heldHTLCs, rhash)
+		} else {
+			i.heldHTLCs[rhash] = held
+		}
+	}()
+}
+
+func (i *mockInvoiceRegistry) HoldInvoiceHTLC(rhash chainhash.Hash,
+	htlc channeldb.InvoiceHTLC, resolve func(HTLCResolution) error) error {
+
 	i.Lock()
 	defer i.Unlock()
 
-	rhash := fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
-	i.invoices[chainhash.Hash(rhash)] = invoice
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return errors.New("can't find mock invoice")
+	}
+
+	held := append(i.heldHTLCs[rhash], &mockHeldHTLC{htlc, resolve})
+	i.heldHTLCs[rhash] = held
+
+	var total lnwire.MilliSatoshi
+	for _, h := range held {
+		total += h.htlc.Amt
+	}
+	if total < invoice.Terms.Value {
+		return nil
+	}
+
+	invoice.Htlcs = nil
+	for _, h := range held {
+		invoice.Htlcs = append(invoice.Htlcs, h.htlc)
+	}
+
+	// The HTLCs of a hold invoice are held until the invoice is either
+	// settled or canceled.
+	if invoice.Terms.PaymentPreimage == channeldb.UnknownPreimage {
+		invoice.Terms.State = channeldb.ContractAccepted
+		i.invoices[rhash] = invoice
+		return nil
+	}
+
+	// Once all HTLCs have arrived, we'll settle the invoice and release
+	// all of them.
+	invoice.Terms.State = channeldb.ContractSettled
+	for _, h := range held {
+		i.resolveHeld(rhash, h, HTLCResolution{
+			Settle:   true,
+			Preimage: invoice.Terms.PaymentPreimage,
+		})
+	}
+	i.invoices[rhash] = invoice
+
+	return nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice that pays to the hash of
+// the passed preimage, releasing all of its held HTLCs.
+func (i *mockInvoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	i.Lock()
+	defer i.Unlock()
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return errors.New("can't find mock invoice")
+	}
+	if invoice.Terms.State != channeldb.ContractAccepted {
+		return errors.New("mock invoice not accepted")
+	}
+
+	invoice.Terms.State = channeldb.ContractSettled
+	invoice.Terms.PaymentPreimage = preimage
+	i.invoices[rhash] = invoice
+
+	for _, h := range i.heldHTLCs[rhash] {
+		i.resolveHeld(rhash, h, HTLCResolution{
+			Settle:   true,
+			Preimage: preimage,
+		})
+	}
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) CancelInvoice(rhash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return errors.New("can't find mock invoice")
+	}
+
+	invoice.Terms.State = channeldb.ContractCanceled
+	i.invoices[rhash] = invoice
+
+	for _, h := range i.heldHTLCs[rhash] {
+		i.resolveHeld(rhash, h, HTLCResolution{
+			Failure: lnwire.FailUnknownPaymentHash{},
+		})
+	}
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) ResumeHeldHTLCs(chanID lnwire.ShortChannelID,
+	resolve func(HTLCResolution) error) (map[uint64]chainhash.Hash, error) {
+
+	i.Lock()
+	defer i.Unlock()
+
+	resumed := make(map[uint64]chainhash.Hash)
+	for rhash, held := range i.heldHTLCs {
+		invoice := i.invoices[rhash]
+
+		for _, h := range held {
+			if h.htlc.ChanID != chanID {
+				continue
+			}
+			h.resolve = resolve
+			resumed[h.htlc.HtlcID] = rhash
+
+			// HTLCs that were resolved while their link was
+			// offline are handed their resolution right away.
+			switch invoice.Terms.State {
+			case channeldb.ContractSettled:
+				i.resolveHeld(rhash, h, HTLCResolution{
+					Settle:   true,
+					Preimage: invoice.Terms.PaymentPreimage,
+				})
+
+			case channeldb.ContractCanceled:
+				i.resolveHeld(rhash, h, HTLCResolution{
+					Failure: lnwire.FailUnknownPaymentHash{},
+				})
+			}
+		}
+	}
+
+	return resumed, nil
+}
+
+func (i *mockInvoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
+	i.Lock()
+	defer i.Unlock()
+
+	// The payment hash of a hold invoice is set by the caller, as its
+	// preimage isn't known yet.
+	rhash := invoice.Terms.PaymentHash
+	if invoice.Terms.PaymentPreimage != channeldb.UnknownPreimage {
+		rhash = fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	}
+	i.invoices[chainhash.Hash(rhash)] = *invoice
 	return nil
 }
 
 var _ InvoiceDatabase = (*mockInvoiceRegistry)(nil)
 
+type mockForwardingLog struct {
+	sync.Mutex
+	events []channeldb.ForwardingEvent
+}
+
+func (m *mockForwardingLog) AddForwardingEvents(
+	events []channeldb.ForwardingEvent) error {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.events = append(m.events, events...)
+	return nil
+}
+
+var _ ForwardingLog = (*mockForwardingLog)(nil)
+
 type mockSigner struct {
 	key *btcec.PrivateKey
 }
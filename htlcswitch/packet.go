@@ -30,6 +30,11 @@ type htlcPacket struct {
 	// amount is the value of the HTLC that is being created or modified.
 	amount lnwire.MilliSatoshi
 
+	// incomingAmount is the value of the incoming HTLC of a forwarded add.
+	// Together with amount, this determines the fee earned by forwarding
+	// the HTLC.
+	incomingAmount lnwire.MilliSatoshi
+
 	// htlc lnwire message type of which depends on switch request type.
 	htlc lnwire.Message
 
@@ -0,0 +1,178 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// paymentResultKey is the key of the top-level bucket which stores the
+	// results of locally initiated HTLCs that were resolved while nobody
+	// was waiting for them, as the node restarted after they were sent.
+	// Each result is kept until it's requested using GetPaymentResult.
+	//
+	// maps: paymentID -> resultType || localFailure || preimage/reason
+	paymentResultKey = []byte("payment-results")
+
+	// ErrPaymentIDNotFound is returned when the result of a payment ID is
+	// requested that the switch has no record of. This means that the
+	// HTLC of the payment never left the node.
+	ErrPaymentIDNotFound = errors.New("payment ID not found")
+
+	// errPaymentResultNotFound is returned when no result has been stored
+	// for a payment ID.
+	errPaymentResultNotFound = errors.New("payment result not found")
+)
+
+// paymentResultType denotes whether a stored payment result is a settle or a
+// fail.
+type paymentResultType byte
+
+const (
+	// paymentResultSettle denotes a settled HTLC.
+	paymentResultSettle paymentResultType = 0
+
+	// paymentResultFail denotes a failed HTLC.
+	paymentResultFail paymentResultType = 1
+)
+
+// paymentResult is the settle or fail message that resolved a locally
+// initiated HTLC.
+type paymentResult struct {
+	// htlc is either an UpdateFufillHTLC or an UpdateFailHTLC message.
+	htlc lnwire.Message
+
+	// localFailure is true if the HTLC was failed by our own node, in
+	// which case the failure reason isn't encrypted.
+	localFailure bool
+}
+
+// encode writes the payment result to the passed writer.
+func (r *paymentResult) encode(w io.Writer) error {
+	var localFailure byte
+	if r.localFailure {
+		localFailure = 1
+	}
+
+	switch htlc := r.htlc.(type) {
+	case *lnwire.UpdateFufillHTLC:
+		_, err := w.Write([]byte{byte(paymentResultSettle), localFailure})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(htlc.PaymentPreimage[:])
+		return err
+
+	case *lnwire.UpdateFailHTLC:
+		_, err := w.Write([]byte{byte(paymentResultFail), localFailure})
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(htlc.Reason)
+		return err
+
+	default:
+		return errors.Errorf("unknown payment result message %T",
+			r.htlc)
+	}
+}
+
+// decode reads a payment result written by encode from the passed bytes.
+func (r *paymentResult) decode(b []byte) error {
+	if len(b) < 2 {
+		return errors.New("payment result too short")
+	}
+	r.localFailure = b[1] == 1
+
+	switch paymentResultType(b[0]) {
+	case paymentResultSettle:
+		settle := &lnwire.UpdateFufillHTLC{}
+		if len(b[2:]) != len(settle.PaymentPreimage) {
+			return errors.New("invalid payment preimage length")
+		}
+		copy(settle.PaymentPreimage[:], b[2:])
+		r.htlc = settle
+
+	case paymentResultFail:
+		r.htlc = &lnwire.UpdateFailHTLC{
+			Reason: lnwire.OpaqueReason(
+				append([]byte(nil), b[2:]...),
+			),
+		}
+
+	default:
+		return errors.Errorf("unknown payment result type %v", b[0])
+	}
+
+	return nil
+}
+
+// storePaymentResult persists the result of the locally initiated HTLC with
+// the passed payment ID, so it can be retrieved once the router requests it.
+func (s *Switch) storePaymentResult(paymentID uint64,
+	result *paymentResult) error {
+
+	if s.cfg.DB == nil {
+		return errors.Errorf("Cannot find pending payment with ID %d",
+			paymentID)
+	}
+
+	var b bytes.Buffer
+	if err := result.encode(&b); err != nil {
+		return err
+	}
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], paymentID)
+
+	return s.cfg.DB.Update(func(tx *bolt.Tx) error {
+		results, err := tx.CreateBucketIfNotExists(paymentResultKey)
+		if err != nil {
+			return err
+		}
+
+		return results.Put(k[:], b.Bytes())
+	})
+}
+
+// takePaymentResult fetches and removes the stored result of the locally
+// initiated HTLC with the passed payment ID. If no result has been stored,
+// errPaymentResultNotFound is returned.
+func (s *Switch) takePaymentResult(paymentID uint64) (*paymentResult, error) {
+	if s.cfg.DB == nil {
+		return nil, errPaymentResultNotFound
+	}
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], paymentID)
+
+	result := &paymentResult{}
+	err := s.cfg.DB.Update(func(tx *bolt.Tx) error {
+		results := tx.Bucket(paymentResultKey)
+		if results == nil {
+			return errPaymentResultNotFound
+		}
+
+		b := results.Get(k[:])
+		if b == nil {
+			return errPaymentResultNotFound
+		}
+
+		if err := result.decode(b); err != nil {
+			return err
+		}
+
+		return results.Delete(k[:])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
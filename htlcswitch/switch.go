@@ -13,6 +13,8 @@ import (
 	"github.com/roasbeef/btcd/btcec"
 
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -20,6 +22,12 @@ import (
 	"github.com/roasbeef/btcutil"
 )
 
+const (
+	// fwdEventInterval is the interval at which the forwarding events
+	// collected by the switch are written out to the forwarding log.
+	fwdEventInterval = 15 * time.Second
+)
+
 var (
 	// ErrChannelLinkNotFound is used when channel link hasn't been found.
 	ErrChannelLinkNotFound = errors.New("channel link not found")
@@ -102,6 +110,21 @@ type Config struct {
 	// forced unilateral closure of the channel initiated by a local
 	// subsystem.
 	LocalChannelClose func(pubKey []byte, request *ChanClose)
+
+	// DB is the channeldb instance that payment circuits are persisted
+	// to, allowing forwarded HTLCs to be resolved across restarts.
+	DB *channeldb.DB
+
+	// ExtractErrorEncrypter is used to re-create the error encrypters of
+	// payment circuits restored from disk.
+	ExtractErrorEncrypter ErrorEncrypterExtracter
+
+	// FwdingLog is an interface that will be used by the switch to log
+	// forwarding events. A forwarding event happens each time a payment
+	// circuit is successfully completed. So when we forward an HTLC, and a
+	// settle is eventually received. If nil, forwarding events aren't
+	// logged.
+	FwdingLog ForwardingLog
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -128,7 +151,6 @@ type Switch struct {
 	// integer ID when it is created.
 	pendingPayments map[uint64]*pendingPayment
 	pendingMutex    sync.RWMutex
-	nextPendingID   uint64
 
 	// circuits is storage for payment circuits which are used to
 	// forward the settle/fail htlc updates back to the add htlc initiator.
@@ -164,13 +186,19 @@ type Switch struct {
 	// linkControl is a channel used to propagate add/remove/get htlc
 	// switch handler commands.
 	linkControl chan interface{}
+
+	// pendingFwdingEvents is the set of forwarding events which have been
+	// collected during the current interval, but hasn't yet been written
+	// to the forwarding log. This slice is only accessed by the
+	// htlcForwarder goroutine.
+	pendingFwdingEvents []channeldb.ForwardingEvent
 }
 
 // New creates the new instance of htlc switch.
 func New(cfg Config) *Switch {
 	return &Switch{
 		cfg:               &cfg,
-		circuits:          NewCircuitMap(),
+		circuits:          NewCircuitMap(cfg.DB, cfg.ExtractErrorEncrypter),
 		linkIndex:         make(map[lnwire.ChannelID]ChannelLink),
 		forwardingIndex:   make(map[lnwire.ShortChannelID]ChannelLink),
 		interfaceIndex:    make(map[[33]byte]map[ChannelLink]struct{}),
@@ -183,8 +211,11 @@ func New(cfg Config) *Switch {
 }
 
 // SendHTLC is used by other subsystems which aren't belong to htlc switch
-// package in order to send the htlc update.
-func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
+// package in order to send the htlc update. The payment ID must be unique
+// among all HTLCs ever sent by the node, as it's used to identify the HTLC
+// across restarts.
+func (s *Switch) SendHTLC(nextNode [33]byte, paymentID uint64,
+	htlc *lnwire.UpdateAddHTLC,
 	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
 
 	// Create payment and add to the map of payment in order later to be
@@ -198,8 +229,11 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 	}
 
 	s.pendingMutex.Lock()
-	paymentID := s.nextPendingID
-	s.nextPendingID++
+	if _, ok := s.pendingPayments[paymentID]; ok {
+		s.pendingMutex.Unlock()
+		return zeroPreimage, errors.Errorf("payment with ID %d is "+
+			"already pending", paymentID)
+	}
 	s.pendingPayments[paymentID] = payment
 	s.pendingMutex.Unlock()
 
@@ -216,6 +250,103 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 		return zeroPreimage, err
 	}
 
+	return s.waitForPaymentResult(payment)
+}
+
+// getPaymentResultCmd is a command used to register interest in the result
+// of a locally initiated HTLC that was sent before a restart.
+type getPaymentResultCmd struct {
+	paymentID uint64
+	payment   *pendingPayment
+	err       chan error
+}
+
+// GetPaymentResult blocks until the locally initiated HTLC with the passed
+// payment ID has been resolved, returning the preimage if it was settled. It
+// allows the router to resume waiting for the HTLCs of payments that were in
+// flight when the node was restarted. If the switch has no record of the
+// payment ID, then the HTLC never left the node, and ErrPaymentIDNotFound is
+// returned.
+func (s *Switch) GetPaymentResult(paymentID uint64,
+	paymentHash lnwallet.PaymentHash,
+	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
+
+	payment := &pendingPayment{
+		err:          make(chan error, 1),
+		preimage:     make(chan [sha256.Size]byte, 1),
+		paymentHash:  paymentHash,
+		deobfuscator: deobfuscator,
+	}
+
+	command := &getPaymentResultCmd{
+		paymentID: paymentID,
+		payment:   payment,
+		err:       make(chan error, 1),
+	}
+
+	select {
+	case s.linkControl <- command:
+	case <-s.quit:
+		return zeroPreimage, errors.New("Htlc Switch was stopped")
+	}
+
+	select {
+	case err := <-command.err:
+		if err != nil {
+			return zeroPreimage, err
+		}
+	case <-s.quit:
+		return zeroPreimage, errors.New("Htlc Switch was stopped")
+	}
+
+	return s.waitForPaymentResult(payment)
+}
+
+// registerPaymentResult handles a getPaymentResultCmd. If the result of the
+// HTLC has been stored, it's delivered to the payment right away. Otherwise,
+// the payment is registered so it's notified once the HTLC is resolved.
+//
+// NOTE: This MUST be called from the htlcForwarder goroutine, so that it's
+// serialized with the resolution of the circuit.
+func (s *Switch) registerPaymentResult(cmd *getPaymentResultCmd) error {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+
+	if _, ok := s.pendingPayments[cmd.paymentID]; ok {
+		return errors.Errorf("payment with ID %d is already pending",
+			cmd.paymentID)
+	}
+
+	result, err := s.takePaymentResult(cmd.paymentID)
+	switch {
+	case err == nil:
+		preimage, err := s.parsePaymentResult(
+			result.htlc, result.localFailure, cmd.payment,
+		)
+		cmd.payment.err <- err
+		cmd.payment.preimage <- preimage
+		return nil
+
+	case err != errPaymentResultNotFound:
+		return err
+	}
+
+	// Without a stored result, the HTLC can only still be outstanding if
+	// its circuit is open. Should the HTLC never have been locked in before
+	// the restart, its circuit is trimmed once the outgoing link starts,
+	// which resolves the payment registered here as failed.
+	if s.circuits.LookupLocal(cmd.paymentID) == nil {
+		return ErrPaymentIDNotFound
+	}
+
+	s.pendingPayments[cmd.paymentID] = cmd.payment
+	return nil
+}
+
+// waitForPaymentResult blocks until the passed payment has been resolved.
+func (s *Switch) waitForPaymentResult(
+	payment *pendingPayment) ([sha256.Size]byte, error) {
+
 	// Returns channels so that other subsystem might wait/skip the
 	// waiting of handling of payment.
 	var preimage [sha256.Size]byte
@@ -240,6 +371,48 @@ func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
 	return preimage, err
 }
 
+// ProcessContractResolution is called by active contract resolvers once a
+// contract they are watching over has been fully resolved on-chain. The
+// resolution is turned into a settle or fail packet for the outgoing HTLC it
+// identifies, which is then propagated back through the circuit as if the
+// link had received it from the remote party.
+func (s *Switch) ProcessContractResolution(msg contractcourt.ResolutionMsg) error {
+	packet := &htlcPacket{
+		outgoingChanID: msg.SourceChan,
+		outgoingHTLCID: msg.HtlcIndex,
+	}
+
+	switch {
+	// If the HTLC was settled on-chain, then we'll relay the preimage
+	// backwards so the incoming HTLC can be settled as well.
+	case msg.PreImage != nil:
+		packet.htlc = &lnwire.UpdateFufillHTLC{
+			PaymentPreimage: *msg.PreImage,
+		}
+
+	// Otherwise, the HTLC was timed out on-chain. As the failure was
+	// generated by us, we'll encode it in plaintext, and mark it as a
+	// local failure so it's encrypted as the first hop.
+	case msg.Failure != nil:
+		var b bytes.Buffer
+		if err := lnwire.EncodeFailure(&b, msg.Failure, 0); err != nil {
+			return err
+		}
+
+		packet.localFailure = true
+		packet.htlc = &lnwire.UpdateFailHTLC{
+			Reason: lnwire.OpaqueReason(b.Bytes()),
+		}
+
+	default:
+		return fmt.Errorf("resolution for ChannelID(%v), HTLC(%v) "+
+			"has neither a preimage nor a failure", msg.SourceChan,
+			msg.HtlcIndex)
+	}
+
+	return s.forward(packet)
+}
+
 // UpdateForwardingPolicies sends a message to the switch to update the
 // forwarding policies for the set of target channels. If the set of targeted
 // channels is nil, then the forwarding policies for all active channels with
@@ -351,12 +524,17 @@ func (s *Switch) forward(packet *htlcPacket) error {
 //   Alice         Bob         Carol
 //
 func (s *Switch) handleLocalDispatch(packet *htlcPacket) error {
+	// A settle or fail completes the payment, whose initiator might not
+	// be waiting for it if we restarted after the payment was sent.
+	if _, ok := packet.htlc.(*lnwire.UpdateAddHTLC); !ok {
+		return s.completePayment(packet)
+	}
+
 	// Pending payments use a special interpretation of the incomingChanID and
 	// incomingHTLCID fields on packet where the channel ID is blank and the
 	// HTLC ID is the payment ID. The switch basically views the users of the
 	// node as a special channel that also offers a sequence of HTLCs.
-	payment, err := s.findPayment(packet.incomingHTLCID)
-	if err != nil {
+	if _, err := s.findPayment(packet.incomingHTLCID); err != nil {
 		return err
 	}
 
@@ -425,19 +603,61 @@ func (s *Switch) handleLocalDispatch(packet *htlcPacket) error {
 		destination.HandleSwitchPacket(packet)
 		return nil
 
+	default:
+		return errors.New("wrong update type")
+	}
+}
+
+// completePayment delivers the settle or fail of a locally initiated HTLC to
+// the pending payment waiting for it. If nobody is waiting for the result, as
+// the node was restarted after the HTLC was sent, then the result is stored
+// until it's requested using GetPaymentResult.
+func (s *Switch) completePayment(packet *htlcPacket) error {
+	paymentID := packet.incomingHTLCID
+
+	s.pendingMutex.Lock()
+	payment, ok := s.pendingPayments[paymentID]
+	if !ok {
+		defer s.pendingMutex.Unlock()
+
+		log.Infof("Storing result of payment with ID %d, as nobody is "+
+			"waiting for it", paymentID)
+
+		return s.storePaymentResult(paymentID, &paymentResult{
+			htlc:         packet.htlc,
+			localFailure: packet.localFailure,
+		})
+	}
+	delete(s.pendingPayments, paymentID)
+	s.pendingMutex.Unlock()
+
+	preimage, err := s.parsePaymentResult(
+		packet.htlc, packet.localFailure, payment,
+	)
+	payment.err <- err
+	payment.preimage <- preimage
+
+	return nil
+}
+
+// parsePaymentResult interprets the settle or fail that resolved the HTLC of
+// the passed payment, returning the preimage of a settle, or the decrypted
+// failure of a fail.
+func (s *Switch) parsePaymentResult(msg lnwire.Message, localFailure bool,
+	payment *pendingPayment) ([sha256.Size]byte, error) {
+
+	switch htlc := msg.(type) {
+
 	// We've just received a settle update which means we can finalize the
 	// user payment and return successful response.
 	case *lnwire.UpdateFufillHTLC:
-		// Notify the user that his payment was successfully proceed.
-		payment.err <- nil
-		payment.preimage <- htlc.PaymentPreimage
-		s.removePendingPayment(packet.incomingHTLCID)
+		return htlc.PaymentPreimage, nil
 
 	// We've just received a fail update which means we can finalize the
 	// user payment and return fail response.
 	case *lnwire.UpdateFailHTLC:
 		var failure *ForwardingError
-		if packet.localFailure {
+		if localFailure {
 			var userErr string
 			r := bytes.NewReader(htlc.Reason)
 			failureMsg, err := lnwire.DecodeFailure(r, 0)
@@ -455,6 +675,7 @@ func (s *Switch) handleLocalDispatch(packet *htlcPacket) error {
 		} else {
 			// We'll attempt to fully decrypt the onion encrypted error. If
 			// we're unable to then we'll bail early.
+			var err error
 			failure, err = payment.deobfuscator.DecryptError(htlc.Reason)
 			if err != nil {
 				userErr := fmt.Sprintf("unable to de-obfuscate onion failure, "+
@@ -468,15 +689,11 @@ func (s *Switch) handleLocalDispatch(packet *htlcPacket) error {
 			}
 		}
 
-		payment.err <- failure
-		payment.preimage <- zeroPreimage
-		s.removePendingPayment(packet.incomingHTLCID)
+		return zeroPreimage, failure
 
 	default:
-		return errors.New("wrong update type")
+		return zeroPreimage, errors.New("wrong update type")
 	}
-
-	return nil
 }
 
 // handlePacketForward is used in cases when we need forward the htlc update
@@ -581,6 +798,24 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return err
 		}
 
+		// Before handing the add to the destination link, we'll commit
+		// the half circuit to disk. If we go down before the outgoing
+		// link opens the circuit, we'll know to fail the HTLC back.
+		err = s.circuits.Commit(&PaymentCircuit{
+			PaymentHash:    htlc.PaymentHash,
+			IncomingChanID: packet.incomingChanID,
+			IncomingHTLCID: packet.incomingHTLCID,
+			IncomingAmount: packet.incomingAmount,
+			OutgoingAmount: htlc.Amount,
+			ErrorEncrypter: packet.obfuscator,
+		})
+		if err != nil {
+			err := errors.Errorf("unable to commit circuit for "+
+				"htlc with hash(%x): %v", htlc.PaymentHash[:], err)
+			log.Error(err)
+			return err
+		}
+
 		// Send the packet to the destination channel link which
 		// manages the channel.
 		destination.HandleSwitchPacket(packet)
@@ -620,12 +855,33 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			packet.incomingChanID = circuit.IncomingChanID
 			packet.incomingHTLCID = circuit.IncomingHTLCID
 
+			// A settle of a circuit we forwarded completes the
+			// circuit, so we'll queue a forwarding event for it to
+			// be written to the forwarding log.
+			if _, ok := htlc.(*lnwire.UpdateFufillHTLC); ok &&
+				!circuit.isLocal() {
+
+				s.pendingFwdingEvents = append(
+					s.pendingFwdingEvents,
+					channeldb.ForwardingEvent{
+						Timestamp:      time.Now(),
+						IncomingChanID: circuit.IncomingChanID,
+						OutgoingChanID: circuit.OutgoingChanID,
+						AmtIn:          circuit.IncomingAmount,
+						AmtOut:         circuit.OutgoingAmount,
+					},
+				)
+			}
+
 			// Obfuscate the error message for fail updates before sending back
 			// through the circuit unless the payment was generated locally.
 			if circuit.ErrorEncrypter != nil {
 				if htlc, ok := htlc.(*lnwire.UpdateFailHTLC); ok {
-					htlc.Reason = circuit.ErrorEncrypter.IntermediateEncrypt(
-						htlc.Reason)
+					err := s.encryptFailure(circuit, packet, htlc)
+					if err != nil {
+						log.Error(err)
+						return err
+					}
 				}
 			}
 		}
@@ -636,6 +892,17 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.handleLocalDispatch(packet)
 		}
 
+		// A routed fail from an outgoing link means the add was rejected
+		// before the circuit could be opened, so the half circuit we
+		// committed is no longer needed.
+		if packet.isRouted {
+			err := s.circuits.Delete(packet.incomingChanID,
+				packet.incomingHTLCID)
+			if err != nil {
+				log.Errorf("unable to delete circuit: %v", err)
+			}
+		}
+
 		source, err := s.getLinkByShortID(packet.incomingChanID)
 		if err != nil {
 			err := errors.Errorf("Unable to get source channel link to "+
@@ -652,6 +919,37 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 	}
 }
 
+// encryptFailure wraps the failure reason of a fail packet being sent back
+// through the passed circuit. Failures that originated at this hop, such as an
+// HTLC timed out on-chain, are encoded in plaintext and need to be encrypted as
+// the first hop, while failures from further along the route only need an
+// additional layer of obfuscation.
+func (s *Switch) encryptFailure(circuit *PaymentCircuit, packet *htlcPacket,
+	htlc *lnwire.UpdateFailHTLC) error {
+
+	if !packet.localFailure {
+		htlc.Reason = circuit.ErrorEncrypter.IntermediateEncrypt(
+			htlc.Reason)
+		return nil
+	}
+
+	failure, err := lnwire.DecodeFailure(bytes.NewReader(htlc.Reason), 0)
+	if err != nil {
+		return errors.Errorf("unable to decode local failure for "+
+			"htlc with hash(%x): %v", circuit.PaymentHash[:], err)
+	}
+
+	reason, err := circuit.ErrorEncrypter.EncryptFirstHop(failure)
+	if err != nil {
+		return errors.Errorf("unable to obfuscate error: %v", err)
+	}
+
+	htlc.Reason = reason
+	packet.localFailure = false
+
+	return nil
+}
+
 // CloseLink creates and sends the close channel command to the target link
 // directing the specified closure type. If the closure type if CloseRegular,
 // then the last parameter should be the ideal fee-per-kw that will be used as
@@ -697,7 +995,8 @@ func (s *Switch) CloseLink(chanPoint *wire.OutPoint,
 func (s *Switch) htlcForwarder() {
 	defer s.wg.Done()
 
-	// Remove all links once we've been signalled for shutdown.
+	// Remove all links once we've been signalled for shutdown, and write
+	// out any forwarding events that are still pending.
 	defer func() {
 		for _, link := range s.linkIndex {
 			if err := s.removeLink(link.ChanID()); err != nil {
@@ -705,6 +1004,11 @@ func (s *Switch) htlcForwarder() {
 					"channel link on stop: %v", err)
 			}
 		}
+
+		if err := s.flushForwardingEvents(); err != nil {
+			log.Errorf("unable to flush forwarding events on "+
+				"stop: %v", err)
+		}
 	}()
 
 	// TODO(roasbeef): cleared vs settled distinction
@@ -716,6 +1020,12 @@ func (s *Switch) htlcForwarder() {
 	logTicker := time.NewTicker(10 * time.Second)
 	defer logTicker.Stop()
 
+	// Forwarding events are batched in memory, and written to the
+	// forwarding log once per interval, so the database isn't hit for
+	// each settled HTLC.
+	fwdEventTicker := time.NewTicker(fwdEventInterval)
+	defer fwdEventTicker.Stop()
+
 	for {
 		select {
 		// A local close request has arrived, we'll forward this to the
@@ -742,6 +1052,14 @@ func (s *Switch) htlcForwarder() {
 		case cmd := <-s.htlcPlex:
 			cmd.err <- s.handlePacketForward(cmd.pkt)
 
+		// The forwarding event ticker has fired, so we'll write out
+		// all the events that were collected since the last tick.
+		case <-fwdEventTicker.C:
+			if err := s.flushForwardingEvents(); err != nil {
+				log.Errorf("unable to flush forwarding "+
+					"events: %v", err)
+			}
+
 		// The log ticker has fired, so we'll calculate some forwarding
 		// stats for the last 10 seconds to display within the logs to
 		// users.
@@ -822,6 +1140,8 @@ func (s *Switch) htlcForwarder() {
 				links, err := s.getLinks(cmd.peer)
 				cmd.done <- links
 				cmd.err <- err
+			case *getPaymentResultCmd:
+				cmd.err <- s.registerPaymentResult(cmd)
 			}
 
 		case <-s.quit:
@@ -830,6 +1150,29 @@ func (s *Switch) htlcForwarder() {
 	}
 }
 
+// flushForwardingEvents writes out all pending forwarding events to the
+// forwarding log. If the write fails, the events are kept so that the next
+// flush can retry them.
+//
+// NOTE: This MUST be called from the htlcForwarder goroutine.
+func (s *Switch) flushForwardingEvents() error {
+	if s.cfg.FwdingLog == nil || len(s.pendingFwdingEvents) == 0 {
+		s.pendingFwdingEvents = nil
+		return nil
+	}
+
+	log.Debugf("Flushing %v forwarding events",
+		len(s.pendingFwdingEvents))
+
+	err := s.cfg.FwdingLog.AddForwardingEvents(s.pendingFwdingEvents)
+	if err != nil {
+		return err
+	}
+
+	s.pendingFwdingEvents = nil
+	return nil
+}
+
 // Start starts all helper goroutines required for the operation of the switch.
 func (s *Switch) Start() error {
 	if !atomic.CompareAndSwapInt32(&s.started, 0, 1) {
@@ -839,6 +1182,16 @@ func (s *Switch) Start() error {
 
 	log.Infof("Starting HTLC Switch")
 
+	// Load all circuits which were still open when we last shut down, so
+	// that any settles or fails for them can be routed back.
+	if err := s.circuits.Restore(); err != nil {
+		return err
+	}
+
+	// Locally initiated HTLCs whose circuits were never opened don't
+	// depend on any link, so their payments are failed right away.
+	s.failLocalOrphans()
+
 	s.wg.Add(1)
 	go s.htlcForwarder()
 
@@ -910,9 +1263,107 @@ func (s *Switch) addLink(link ChannelLink) error {
 	log.Infof("Added channel link with chan_id=%v, short_chan_id=(%v)",
 		link.ChanID(), spew.Sdump(link.ShortChanID()))
 
+	// Finally, any HTLCs forwarded to us before our last shutdown that
+	// never made it into their outgoing channel, including those whose
+	// circuits were trimmed as the link started, are failed back now.
+	s.failOrphanedCircuits()
+
 	return nil
 }
 
+// failOrphanedCircuits fails back the incoming HTLCs of all orphaned half
+// circuits that originated from an active link. The adds of these circuits
+// were committed, but never locked in by the outgoing link, so it's safe to
+// cancel them. Orphans of links that aren't active yet are failed back once
+// their link is added.
+func (s *Switch) failOrphanedCircuits() {
+	for chanID, link := range s.forwardingIndex {
+		for _, circuit := range s.circuits.Orphans(chanID) {
+			s.failOrphanedCircuit(link, circuit)
+		}
+	}
+
+	s.failLocalOrphans()
+}
+
+// failOrphanedCircuit fails back the incoming HTLC of a single orphaned
+// circuit to the link it originated from.
+func (s *Switch) failOrphanedCircuit(link ChannelLink,
+	circuit *PaymentCircuit) {
+
+	log.Infof("Failing back orphaned circuit for %x: (%s, %d)",
+		circuit.PaymentHash, circuit.IncomingChanID,
+		circuit.IncomingHTLCID)
+
+	failure := lnwire.NewTemporaryChannelFailure(nil)
+
+	var (
+		reason lnwire.OpaqueReason
+		err    error
+	)
+	if circuit.ErrorEncrypter != nil {
+		reason, err = circuit.ErrorEncrypter.EncryptFirstHop(failure)
+		if err != nil {
+			log.Errorf("unable to obfuscate error: %v", err)
+			return
+		}
+	}
+
+	err = s.circuits.Delete(circuit.IncomingChanID, circuit.IncomingHTLCID)
+	if err != nil {
+		log.Errorf("unable to delete orphaned circuit: %v", err)
+		return
+	}
+
+	link.HandleSwitchPacket(&htlcPacket{
+		incomingChanID: circuit.IncomingChanID,
+		incomingHTLCID: circuit.IncomingHTLCID,
+		isRouted:       true,
+		htlc: &lnwire.UpdateFailHTLC{
+			Reason: reason,
+		},
+	})
+}
+
+// failLocalOrphans resolves the locally initiated HTLCs of all orphaned
+// circuits as failed. As these have no incoming link to be failed back to,
+// the failure is handed to the payment waiting for the HTLC, or stored until
+// it's requested using GetPaymentResult.
+func (s *Switch) failLocalOrphans() {
+	for _, circuit := range s.circuits.Orphans(lnwire.ShortChannelID{}) {
+		paymentID := circuit.IncomingHTLCID
+
+		log.Infof("Failing orphaned circuit of payment with ID %d "+
+			"for %x", paymentID, circuit.PaymentHash)
+
+		var b bytes.Buffer
+		failure := lnwire.NewTemporaryChannelFailure(nil)
+		if err := lnwire.EncodeFailure(&b, failure, 0); err != nil {
+			log.Errorf("unable to encode failure: %v", err)
+			continue
+		}
+
+		err := s.circuits.Delete(circuit.IncomingChanID, paymentID)
+		if err != nil {
+			log.Errorf("unable to delete orphaned circuit: %v", err)
+			continue
+		}
+
+		err = s.completePayment(&htlcPacket{
+			incomingHTLCID: paymentID,
+			amount:         circuit.OutgoingAmount,
+			localFailure:   true,
+			htlc: &lnwire.UpdateFailHTLC{
+				Reason: lnwire.OpaqueReason(b.Bytes()),
+			},
+		})
+		if err != nil {
+			log.Errorf("unable to fail payment with ID %d: %v",
+				paymentID, err)
+		}
+	}
+}
+
 // getLinkCmd is a get link command wrapper, it is used to propagate handler
 // parameters and return handler error.
 type getLinkCmd struct {
@@ -1080,7 +1531,8 @@ func (s *Switch) numPendingPayments() int {
 	return len(s.pendingPayments)
 }
 
-// addCircuit adds a circuit to the switch's in-memory mapping.
-func (s *Switch) addCircuit(circuit *PaymentCircuit) {
-	s.circuits.Add(circuit)
+// addCircuit opens a circuit within the switch's circuit map, persisting it so
+// that the HTLC can be resolved after a restart.
+func (s *Switch) addCircuit(circuit *PaymentCircuit) error {
+	return s.circuits.Add(circuit)
 }
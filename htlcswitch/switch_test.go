@@ -3,11 +3,14 @@ package htlcswitch
 import (
 	"bytes"
 	"crypto/sha256"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/fastsha256"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -28,14 +31,17 @@ var (
 )
 
 // TestSwitchForward checks the ability of htlc switch to forward add/settle
-// requests.
+// requests, and that the settled circuit is written to the forwarding log.
 func TestSwitchForward(t *testing.T) {
 	t.Parallel()
 
 	alicePeer := newMockServer(t, "alice")
 	bobPeer := newMockServer(t, "bob")
 
-	s := New(Config{})
+	fwdingLog := &mockForwardingLog{}
+	s := New(Config{
+		FwdingLog: fwdingLog,
+	})
 	s.Start()
 
 	aliceChannelLink := newMockChannelLink(
@@ -59,6 +65,8 @@ func TestSwitchForward(t *testing.T) {
 		incomingChanID: aliceChannelLink.ShortChanID(),
 		incomingHTLCID: 0,
 		outgoingChanID: bobChannelLink.ShortChanID(),
+		amount:         1,
+		incomingAmount: 2,
 		obfuscator:     newMockObfuscator(),
 		htlc: &lnwire.UpdateAddHTLC{
 			PaymentHash: rhash,
@@ -109,6 +117,31 @@ func TestSwitchForward(t *testing.T) {
 	if s.circuits.pending() != 0 {
 		t.Fatal("wrong amount of circuits")
 	}
+
+	// Stopping the switch should flush the forwarding event of the
+	// settled circuit, which should carry the amounts of both HTLCs.
+	if err := s.Stop(); err != nil {
+		t.Fatalf("unable to stop switch: %v", err)
+	}
+
+	fwdingLog.Lock()
+	defer fwdingLog.Unlock()
+
+	if len(fwdingLog.events) != 1 {
+		t.Fatalf("expected 1 forwarding event, got %v",
+			len(fwdingLog.events))
+	}
+	event := fwdingLog.events[0]
+	if event.IncomingChanID != aliceChanID ||
+		event.OutgoingChanID != bobChanID {
+
+		t.Fatalf("wrong channels in forwarding event: %v -> %v",
+			event.IncomingChanID, event.OutgoingChanID)
+	}
+	if event.AmtIn != 2 || event.AmtOut != 1 {
+		t.Fatalf("wrong amounts in forwarding event: in=%v, out=%v",
+			event.AmtIn, event.AmtOut)
+	}
 }
 
 // TestSkipIneligibleLinksMultiHopForward tests that if a multi-hop HTLC comes
@@ -198,7 +231,7 @@ func TestSkipIneligibleLinksLocalForward(t *testing.T) {
 	// outgoing link. This should fail as Alice isn't yet able to forward
 	// any active HTLC's.
 	alicePub := aliceChannelLink.Peer().PubKey()
-	_, err := s.SendHTLC(alicePub, addMsg, nil)
+	_, err := s.SendHTLC(alicePub, nextPaymentID(), addMsg, nil)
 	if err == nil {
 		t.Fatalf("local forward should fail due to inactive link")
 	}
@@ -444,16 +477,16 @@ func TestSwitchSendPayment(t *testing.T) {
 	// Handle the request and checks that bob channel link received it.
 	errChan := make(chan error)
 	go func() {
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			nextPaymentID(), update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
 	go func() {
 		// Send the payment with the same payment hash and same
 		// amount and check that it will be propagated successfully
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			nextPaymentID(), update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
@@ -542,3 +575,380 @@ func TestSwitchSendPayment(t *testing.T) {
 		t.Fatal("wrong amount of pending payments")
 	}
 }
+
+// TestSwitchGetPaymentResult checks that the result of a locally initiated
+// HTLC that is resolved after a restart is kept by the switch until it's
+// requested.
+func TestSwitchGetPaymentResult(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "switchdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	alicePeer := newMockServer(t, "alice")
+
+	s := New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	preimage := [sha256.Size]byte{1}
+	update := &lnwire.UpdateAddHTLC{
+		PaymentHash: fastsha256.Sum256(preimage[:]),
+		Amount:      1,
+	}
+
+	// Send a payment, and wait for it to reach the link.
+	paymentID := nextPaymentID()
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			paymentID, update, newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case <-aliceChannelLink.packets:
+	case err := <-errChan:
+		t.Fatalf("unable to send payment: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	// Restart the switch, which should abort the pending payment.
+	s.Stop()
+	select {
+	case err := <-errChan:
+		if err == nil {
+			t.Fatalf("expected payment to be aborted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payment wasn't aborted")
+	}
+
+	s = New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to restart switch: %v", err)
+	}
+	defer s.Stop()
+
+	// The HTLC was locked in before the restart, so the restored link
+	// must not trim its circuit.
+	aliceChannelLink = newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	aliceChannelLink.htlcID = 1
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	// The settle arrives before anybody asks for the result, so it must
+	// be stored by the switch.
+	packet := &htlcPacket{
+		outgoingChanID: aliceChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFufillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("can't forward htlc packet: %v", err)
+	}
+
+	result, err := s.GetPaymentResult(
+		paymentID, update.PaymentHash, newMockDeobfuscator(),
+	)
+	if err != nil {
+		t.Fatalf("unable to get payment result: %v", err)
+	}
+	if result != preimage {
+		t.Fatalf("expected preimage %x, got %x", preimage, result)
+	}
+
+	// Now that the result has been delivered, the switch shouldn't know
+	// of the payment anymore.
+	_, err = s.GetPaymentResult(
+		paymentID, update.PaymentHash, newMockDeobfuscator(),
+	)
+	if err != ErrPaymentIDNotFound {
+		t.Fatalf("expected ErrPaymentIDNotFound, got %v", err)
+	}
+}
+
+// TestSwitchTrimUncommittedCircuits checks that the circuit of an HTLC which
+// was added to the outgoing link, but never locked in before a restart, is
+// trimmed once the outgoing link starts, and that the incoming HTLC is failed
+// back.
+func TestSwitchTrimUncommittedCircuits(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "switchdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	alicePeer := newMockServer(t, "alice")
+	bobPeer := newMockServer(t, "bob")
+
+	s := New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	// Forward an add from Alice to Bob, which opens the circuit once Bob's
+	// link assigns an HTLC ID to it.
+	preimage := [sha256.Size]byte{1}
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 0,
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		amount:         1,
+		incomingAmount: 2,
+		obfuscator:     newMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			PaymentHash: fastsha256.Sum256(preimage[:]),
+			Amount:      1,
+		},
+	}
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("unable to forward htlc: %v", err)
+	}
+
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	if s.circuits.pending() != 1 {
+		t.Fatal("wrong amount of circuits")
+	}
+
+	// Restart the switch. Bob's restored link starts out at HTLC ID 0,
+	// meaning the add was never locked in.
+	if err := s.Stop(); err != nil {
+		t.Fatalf("unable to stop switch: %v", err)
+	}
+
+	s = New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to restart switch: %v", err)
+	}
+	defer s.Stop()
+
+	if s.circuits.pending() != 1 {
+		t.Fatal("open circuit wasn't restored")
+	}
+
+	aliceChannelLink = newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink = newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+
+	// Adding Bob's link trims the circuit. As Alice's link isn't active
+	// yet, the incoming HTLC can only be failed back once it's added.
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+	if s.circuits.pending() != 0 {
+		t.Fatal("uncommitted circuit wasn't trimmed")
+	}
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if _, ok := pkt.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", pkt.htlc)
+		}
+		if pkt.incomingHTLCID != 0 {
+			t.Fatalf("wrong htlc failed: %v", pkt.incomingHTLCID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("incoming htlc wasn't failed back")
+	}
+
+	// A new add reusing Bob's HTLC ID must open a fresh circuit, and its
+	// settle must be routed back to the new incoming HTLC.
+	packet.incomingHTLCID = 1
+	if err := s.forward(packet); err != nil {
+		t.Fatalf("unable to forward htlc: %v", err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	settle := &htlcPacket{
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		outgoingHTLCID: 0,
+		amount:         1,
+		htlc: &lnwire.UpdateFufillHTLC{
+			PaymentPreimage: preimage,
+		},
+	}
+	if err := s.forward(settle); err != nil {
+		t.Fatalf("unable to forward settle: %v", err)
+	}
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if pkt.incomingHTLCID != 1 {
+			t.Fatalf("settle routed to wrong htlc: %v",
+				pkt.incomingHTLCID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("settle wasn't routed back")
+	}
+}
+
+// TestSwitchGetPaymentResultTrimmed checks that a locally initiated HTLC that
+// was never locked in before a restart is resolved as failed once the circuit
+// is trimmed by the outgoing link, so the router stops waiting for it.
+func TestSwitchGetPaymentResultTrimmed(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "switchdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	alicePeer := newMockServer(t, "alice")
+
+	s := New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	preimage := [sha256.Size]byte{1}
+	update := &lnwire.UpdateAddHTLC{
+		PaymentHash: fastsha256.Sum256(preimage[:]),
+		Amount:      1,
+	}
+
+	// Send a payment, and wait for it to reach the link.
+	paymentID := nextPaymentID()
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(),
+			paymentID, update, newMockDeobfuscator())
+		errChan <- err
+	}()
+
+	select {
+	case <-aliceChannelLink.packets:
+	case err := <-errChan:
+		t.Fatalf("unable to send payment: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("request was not propagated to destination")
+	}
+
+	s.Stop()
+	select {
+	case <-errChan:
+	case <-time.After(time.Second):
+		t.Fatal("payment wasn't aborted")
+	}
+
+	s = New(Config{DB: db})
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to restart switch: %v", err)
+	}
+	defer s.Stop()
+
+	// Start waiting for the result while the circuit is still open, as
+	// the router does when resuming payments on start up.
+	resultChan := make(chan error, 1)
+	go func() {
+		_, err := s.GetPaymentResult(
+			paymentID, update.PaymentHash, newMockDeobfuscator(),
+		)
+		resultChan <- err
+	}()
+
+	// The restored link starts out at HTLC ID 0, so the add was never
+	// locked in, and its circuit is trimmed as the link is added.
+	aliceChannelLink = newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add link: %v", err)
+	}
+
+	select {
+	case err := <-resultChan:
+		fErr, ok := err.(*ForwardingError)
+		if !ok {
+			t.Fatalf("expected ForwardingError, got %v", err)
+		}
+		_, ok = fErr.FailureMessage.(*lnwire.FailTemporaryChannelFailure)
+		if !ok {
+			t.Fatalf("unexpected failure: %v", fErr.FailureMessage)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("payment result wasn't delivered")
+	}
+
+	// The failure has been delivered, so the switch shouldn't know of the
+	// payment anymore, allowing it to be retried.
+	_, err = s.GetPaymentResult(
+		paymentID, update.PaymentHash, newMockDeobfuscator(),
+	)
+	if err != ErrPaymentIDNotFound {
+		t.Fatalf("expected ErrPaymentIDNotFound, got %v", err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"math/big"
 
 	"net"
+	"sync/atomic"
 
 	"github.com/btcsuite/fastsha256"
 	"github.com/go-errors/errors"
@@ -52,8 +53,17 @@ var (
 		"5445068131219452686511677818569431", 10)
 	_, _ = testSig.S.SetString("1880105606924982582529128710493133386286603"+
 		"3135609736119018462340006816851118", 10)
+
+	// paymentIDCounter is used to assign a unique payment ID to each HTLC
+	// sent within the tests.
+	paymentIDCounter uint64
 )
 
+// nextPaymentID returns a payment ID that hasn't been used yet.
+func nextPaymentID() uint64 {
+	return atomic.AddUint64(&paymentIDCounter, 1)
+}
+
 // mockGetChanUpdateMessage helper function which returns topology update
 // of the channel
 func mockGetChanUpdateMessage() (*lnwire.ChannelUpdate, error) {
@@ -563,7 +573,7 @@ func (n *threeHopNetwork) makePayment(sendingPeer, receivingPeer Peer,
 	rhash = fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
 
 	// Check who is last in the route and add invoice to server registry.
-	if err := receiver.registry.AddInvoice(*invoice); err != nil {
+	if err := receiver.registry.AddInvoice(invoice); err != nil {
 		paymentErr <- err
 		return &paymentResponse{
 			rhash: rhash,
@@ -573,8 +583,8 @@ func (n *threeHopNetwork) makePayment(sendingPeer, receivingPeer Peer,
 
 	// Send payment and expose err channel.
 	go func() {
-		_, err := sender.htlcSwitch.SendHTLC(firstHopPub, htlc,
-			newMockDeobfuscator())
+		_, err := sender.htlcSwitch.SendHTLC(firstHopPub,
+			nextPaymentID(), htlc, newMockDeobfuscator())
 		paymentErr <- err
 	}()
 
@@ -3,12 +3,15 @@ package main
 import (
 	"bytes"
 	"crypto/sha256"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 )
@@ -24,6 +27,45 @@ var (
 	debugHash = chainhash.Hash(sha256.Sum256(debugPre[:]))
 )
 
+// multiPathTimeout is the amount of time we'll hold on to the shards of a
+// multi-path payment while waiting for its remaining shards to arrive. Once
+// the timeout expires, all held shards of the payment are failed.
+const multiPathTimeout = 60 * time.Second
+
+// errNoHeldHTLCs is returned when attempting to settle a hold invoice for
+// which no HTLCs are held.
+var errNoHeldHTLCs = fmt.Errorf("no htlcs are held for the invoice")
+
+// heldHTLC is an HTLC paying to an invoice that is held until its fate has
+// been decided, either as it's a shard of a multi-path payment, or as it pays
+// to a hold invoice. Held HTLCs are persisted until their resolution has been
+// handed to the link of their channel.
+type heldHTLC struct {
+	htlc channeldb.InvoiceHTLC
+
+	// resolve hands the resolution of the HTLC to the link of its
+	// channel. It's nil for HTLCs that were restored from disk until the
+	// link resumes them.
+	resolve func(htlcswitch.HTLCResolution) error
+}
+
+// heldHTLCSet is the set of HTLCs paying to a single invoice which are
+// currently being held.
+type heldHTLCSet struct {
+	htlcs []*heldHTLC
+	amt   lnwire.MilliSatoshi
+
+	// timeout fails the HTLCs of the set if its remaining HTLCs don't
+	// arrive in time. It's nil for sets that were accepted prior to a
+	// restart.
+	timeout *time.Timer
+
+	// accepted is true once the HTLCs of the set pay the full value of a
+	// hold invoice, after which they're held until the invoice is either
+	// settled or canceled.
+	accepted bool
+}
+
 // invoiceRegistry is a central registry of all the outstanding invoices
 // created by the daemon. The registry is a thin wrapper around a map in order
 // to ensure that all updates/reads are thread safe.
@@ -40,6 +82,26 @@ type invoiceRegistry struct {
 	// should be only created/used when manual tests require an invoice
 	// that *all* nodes are able to fully settle.
 	debugInvoices map[chainhash.Hash]*channeldb.Invoice
+
+	// heldSets tracks the HTLCs that are currently being held, indexed by
+	// the payment hash of the invoice they pay to. The sets of open and
+	// accepted invoices are restored from disk on start up.
+	heldSets map[chainhash.Hash]*heldHTLCSet
+	heldMtx  sync.Mutex
+
+	// mppTimeout is the amount of time we'll wait for the remaining HTLCs
+	// of a set to arrive. It's set to multiPathTimeout, and only lowered
+	// within tests.
+	mppTimeout time.Duration
+
+	// expiryTimers holds a timer for each open invoice that will mark the
+	// invoice as expired once its expiry has passed, indexed by the
+	// payment hash of the invoice.
+	//
+	// NOTE: If both heldMtx and expiryMtx are to be held, then heldMtx
+	// must be acquired first.
+	expiryTimers map[chainhash.Hash]*time.Timer
+	expiryMtx    sync.Mutex
 }
 
 // newInvoiceRegistry creates a new invoice registry. The invoice registry
@@ -51,9 +113,279 @@ func newInvoiceRegistry(cdb *channeldb.DB) *invoiceRegistry {
 		cdb:                 cdb,
 		debugInvoices:       make(map[chainhash.Hash]*channeldb.Invoice),
 		notificationClients: make(map[uint32]*invoiceSubscription),
+		heldSets:            make(map[chainhash.Hash]*heldHTLCSet),
+		mppTimeout:          multiPathTimeout,
+		expiryTimers:        make(map[chainhash.Hash]*time.Timer),
+	}
+}
+
+// Start restores the HTLCs that were held prior to a restart, and starts the
+// registry's expiry watcher, which marks each of the open invoices within the
+// database as expired once its expiry has passed. Open invoices whose expiry
+// passed while we were offline are expired right away.
+func (i *invoiceRegistry) Start() error {
+	if err := i.restoreHeldSets(); err != nil {
+		return err
+	}
+
+	invoices, err := i.cdb.FetchAllInvoices(true)
+	if err != nil {
+		return err
+	}
+
+	i.expiryMtx.Lock()
+	defer i.expiryMtx.Unlock()
+
+	for _, invoice := range invoices {
+		if invoice.Terms.State != channeldb.ContractOpen {
+			continue
+		}
+
+		i.scheduleExpiry(invoice)
+	}
+
+	ltndLog.Debugf("Watching expiry of %v open invoices",
+		len(i.expiryTimers))
+
+	return nil
+}
+
+// Stop stops the expiry timers of all open invoices.
+func (i *invoiceRegistry) Stop() {
+	i.expiryMtx.Lock()
+	defer i.expiryMtx.Unlock()
+
+	for rHash, timer := range i.expiryTimers {
+		timer.Stop()
+		delete(i.expiryTimers, rHash)
+	}
+}
+
+// restoreHeldSets restores the sets of HTLCs that were held for open and
+// accepted invoices prior to a restart. The HTLCs of an open invoice have to
+// be completed within multiPathTimeout once again. HTLCs held for invoices
+// that have been resolved since are handed their resolution once their link
+// resumes them.
+func (i *invoiceRegistry) restoreHeldSets() error {
+	held, err := i.cdb.FetchHeldHTLCs()
+	if err != nil {
+		return err
+	}
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	for hash, htlcs := range held {
+		rHash := chainhash.Hash(hash)
+
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			ltndLog.Errorf("unable to find invoice %x of held "+
+				"htlcs: %v", rHash[:], err)
+			continue
+		}
+
+		set := &heldHTLCSet{}
+		switch invoice.Terms.State {
+		case channeldb.ContractOpen:
+			set.timeout = time.AfterFunc(i.mppTimeout, func() {
+				i.expireHeldHTLCs(rHash, set)
+			})
+
+		case channeldb.ContractAccepted:
+			set.accepted = true
+
+		default:
+			continue
+		}
+
+		for _, htlc := range htlcs {
+			set.htlcs = append(set.htlcs, &heldHTLC{htlc: htlc})
+			set.amt += htlc.Amt
+		}
+		i.heldSets[rHash] = set
+	}
+
+	ltndLog.Debugf("Restored %v sets of held htlcs", len(i.heldSets))
+
+	return nil
+}
+
+// ResumeHeldHTLCs hands the passed resolve callback to all HTLCs that are held
+// for the channel with the passed short channel ID, as the channel's link has
+// been restarted. HTLCs that were resolved while the link was offline are
+// handed their resolution right away. The payment hash of each of the HTLCs
+// is returned, indexed by the index of the HTLC.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) ResumeHeldHTLCs(chanID lnwire.ShortChannelID,
+	resolve func(htlcswitch.HTLCResolution) error) (
+	map[uint64]chainhash.Hash, error) {
+
+	held, err := i.cdb.FetchHeldHTLCs()
+	if err != nil {
+		return nil, err
+	}
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	resumed := make(map[uint64]chainhash.Hash)
+	for hash, htlcs := range held {
+		rHash := chainhash.Hash(hash)
+
+		for _, htlc := range htlcs {
+			if htlc.ChanID != chanID {
+				continue
+			}
+			resumed[htlc.HtlcID] = rHash
+
+			// If the HTLC is part of a set that's still held,
+			// then its resolution will be handed to the new link
+			// once the fate of the set has been decided.
+			if held := i.heldSets[rHash].find(htlc); held != nil {
+				held.resolve = resolve
+				continue
+			}
+
+			// Otherwise, the HTLC was resolved while its link was
+			// offline, so we'll derive its resolution from the
+			// invoice.
+			res := htlcswitch.HTLCResolution{
+				Failure: lnwire.FailUnknownPaymentHash{},
+			}
+			invoice, err := i.cdb.LookupInvoice(rHash)
+			switch {
+			case err != nil:
+				ltndLog.Errorf("unable to find invoice %x of "+
+					"held htlc: %v", rHash[:], err)
+
+			case invoice.Terms.State == channeldb.ContractSettled:
+				res = htlcswitch.HTLCResolution{
+					Settle:   true,
+					Preimage: invoice.Terms.PaymentPreimage,
+				}
+
+			// The set of an invoice that's still open has failed
+			// to complete in time.
+			case invoice.Terms.State == channeldb.ContractOpen:
+				res.Failure = lnwire.FailMPPTimeout{}
+			}
+
+			i.resolveHeldHTLC(rHash, &heldHTLC{
+				htlc:    htlc,
+				resolve: resolve,
+			}, res)
+		}
+	}
+
+	return resumed, nil
+}
+
+// invoiceExpiryTime returns the time at which the passed invoice expires, and
+// whether it expires at all. Invoices that were added before their expiry was
+// stored fall back to the expiry within their payment request.
+func invoiceExpiryTime(invoice *channeldb.Invoice) (time.Time, bool) {
+	if expiryTime, ok := invoice.ExpiryTime(); ok {
+		return expiryTime, true
+	}
+
+	if len(invoice.PaymentRequest) == 0 {
+		return time.Time{}, false
+	}
+
+	payReq, err := zpay32.Decode(string(invoice.PaymentRequest))
+	if err != nil {
+		ltndLog.Errorf("unable to decode payment request of invoice "+
+			"%x: %v", invoice.Terms.PaymentHash[:], err)
+		return time.Time{}, false
+	}
+
+	return payReq.Timestamp.Add(payReq.Expiry()), true
+}
+
+// scheduleExpiry starts a timer that expires the passed open invoice once its
+// expiry has passed.
+//
+// NOTE: This method MUST be called with the expiryMtx held.
+func (i *invoiceRegistry) scheduleExpiry(invoice *channeldb.Invoice) {
+	expiryTime, ok := invoiceExpiryTime(invoice)
+	if !ok {
+		return
+	}
+
+	rHash := chainhash.Hash(invoice.Terms.PaymentHash)
+	if timer, ok := i.expiryTimers[rHash]; ok {
+		timer.Stop()
+	}
+	i.expiryTimers[rHash] = time.AfterFunc(
+		time.Until(expiryTime), func() {
+			if err := i.expireInvoice(rHash); err != nil {
+				ltndLog.Errorf("unable to expire invoice "+
+					"%x: %v", rHash[:], err)
+			}
+		},
+	)
+}
+
+// stopExpiry stops the expiry timer of the invoice with the passed payment
+// hash, as it can no longer expire.
+func (i *invoiceRegistry) stopExpiry(rHash chainhash.Hash) {
+	i.expiryMtx.Lock()
+	defer i.expiryMtx.Unlock()
+
+	if timer, ok := i.expiryTimers[rHash]; ok {
+		timer.Stop()
+		delete(i.expiryTimers, rHash)
 	}
 }
 
+// expireInvoice marks the invoice with the passed payment hash as expired if
+// it's still open, and fails any HTLCs that are held for it. An invoice that
+// is no longer open is left untouched.
+func (i *invoiceRegistry) expireInvoice(rHash chainhash.Hash) error {
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	i.stopExpiry(rHash)
+
+	err := i.cdb.ExpireInvoice(rHash)
+	switch {
+	// The invoice has been paid or canceled in the meantime, so there's
+	// nothing left to do.
+	case err == channeldb.ErrInvoiceNotOpen:
+		return nil
+
+	case err != nil:
+		return err
+	}
+
+	ltndLog.Infof("Invoice %x has expired", rHash[:])
+
+	// Any shards of a multi-path payment that arrived before the invoice
+	// expired can no longer be completed.
+	if set, ok := i.heldSets[rHash]; ok {
+		set.stopTimeout()
+		delete(i.heldSets, rHash)
+
+		i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+			Failure: lnwire.FailUnknownPaymentHash{},
+		})
+	}
+
+	go func() {
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			ltndLog.Errorf("unable to find invoice: %v", err)
+			return
+		}
+
+		i.notifyClients(invoice, false)
+	}()
+
+	return nil
+}
+
 // addDebugInvoice adds a debug invoice for the specified amount, identified
 // by the passed preimage. Once this invoice is added, subsystems within the
 // daemon add/forward HTLCs are able to obtain the proper preimage required
@@ -89,14 +421,27 @@ func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
 	}))
 
 	// TODO(roasbeef): also check in memory for quick lookups/settles?
-	return i.cdb.AddInvoice(invoice)
+	if err := i.cdb.AddInvoice(invoice); err != nil {
+		return err
+	}
 
-	// TODO(roasbeef): re-enable?
-	//go i.notifyClients(invoice, false)
+	i.expiryMtx.Lock()
+	i.scheduleExpiry(invoice)
+	i.expiryMtx.Unlock()
+
+	// Now that the invoice has been assigned its add index, we'll notify
+	// all clients of it. A copy is handed to them, as the caller retains
+	// ownership of the passed invoice.
+	invoiceCopy := *invoice
+	go i.notifyClients(&invoiceCopy, true)
+
+	return nil
 }
 
 // lookupInvoice looks up an invoice by its payment hash (R-Hash), if found
-// then we're able to pull the funds pending within an HTLC.
+// then we're able to pull the funds pending within an HTLC. An open invoice
+// whose expiry has passed is expired before it's returned, so that it won't
+// be paid even if its expiry timer hasn't fired yet.
 // TODO(roasbeef): ignore if settled?
 func (i *invoiceRegistry) LookupInvoice(rHash chainhash.Hash) (channeldb.Invoice, error) {
 	// First check the in-memory debug invoice index to see if this is an
@@ -117,6 +462,24 @@ func (i *invoiceRegistry) LookupInvoice(rHash chainhash.Hash) (channeldb.Invoice
 		return channeldb.Invoice{}, err
 	}
 
+	if invoice.Terms.State != channeldb.ContractOpen {
+		return *invoice, nil
+	}
+
+	expiryTime, ok := invoiceExpiryTime(invoice)
+	if !ok || time.Now().Before(expiryTime) {
+		return *invoice, nil
+	}
+
+	if err := i.expireInvoice(rHash); err != nil {
+		return channeldb.Invoice{}, err
+	}
+
+	invoice, err = i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return channeldb.Invoice{}, err
+	}
+
 	return *invoice, nil
 }
 
@@ -143,6 +506,7 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 	if err := i.cdb.SettleInvoice(rHash); err != nil {
 		return err
 	}
+	i.stopExpiry(rHash)
 
 	// Launch a new goroutine to notify any/all registered invoice
 	// notification clients.
@@ -155,43 +519,512 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 
 		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
 
-		i.notifyClients(invoice, true)
+		i.notifyClients(invoice, false)
 	}()
 
 	return nil
 }
 
-// notifyClients notifies all currently registered invoice notification clients
-// of a newly added/settled invoice.
-func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool) {
-	i.clientMtx.Lock()
-	defer i.clientMtx.Unlock()
+// HoldInvoiceHTLC registers an HTLC paying to the invoice identified by the
+// passed payment hash that is to be held until its fate has been decided.
+// Once the held HTLCs add up to the value of the invoice, they're recorded
+// with the invoice. A regular invoice is then settled, and all HTLCs are
+// resolved as settled. A hold invoice is instead marked as accepted, and its
+// HTLCs are held until either SettleHoldInvoice or CancelInvoice is called.
+// If the remaining HTLCs don't arrive within multiPathTimeout, then all HTLCs
+// of the payment are resolved as failed.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) HoldInvoiceHTLC(rHash chainhash.Hash,
+	htlc channeldb.InvoiceHTLC,
+	resolve func(htlcswitch.HTLCResolution) error) error {
 
-	for _, client := range i.notificationClients {
-		var eventChan chan *channeldb.Invoice
-		if settle {
-			eventChan = client.SettledInvoices
-		} else {
-			eventChan = client.NewInvoices
+	invoice, err := i.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	isHold := invoice.Terms.PaymentPreimage == channeldb.UnknownPreimage
+
+	switch {
+	// An invoice that has been canceled or has expired can no longer be
+	// paid.
+	case invoice.Terms.State == channeldb.ContractCanceled:
+		return channeldb.ErrInvoiceAlreadyCanceled
+
+	case invoice.Terms.State == channeldb.ContractExpired:
+		return channeldb.ErrInvoiceAlreadyExpired
+
+	// If the invoice has already been paid in full, then there's nothing
+	// to wait for, so we'll settle the HTLC straight away.
+	case invoice.Terms.State == channeldb.ContractSettled:
+		go resolve(htlcswitch.HTLCResolution{
+			HtlcID:   htlc.HtlcID,
+			Settle:   true,
+			Preimage: invoice.Terms.PaymentPreimage,
+		})
+		return nil
+	}
+
+	i.RLock()
+	_, isDebug := i.debugInvoices[rHash]
+	i.RUnlock()
+
+	// The HTLC is persisted before it's held, so it can be resumed if
+	// we're restarted before its fate has been decided.
+	if !isDebug {
+		if err := i.cdb.AddHeldHTLC(rHash, htlc); err != nil {
+			return err
+		}
+	}
+
+	set, ok := i.heldSets[rHash]
+	if !ok {
+		set = &heldHTLCSet{}
+		set.timeout = time.AfterFunc(i.mppTimeout, func() {
+			i.expireHeldHTLCs(rHash, set)
+		})
+		i.heldSets[rHash] = set
+	}
+	set.htlcs = append(set.htlcs, &heldHTLC{
+		htlc:    htlc,
+		resolve: resolve,
+	})
+	set.amt += htlc.Amt
+
+	ltndLog.Debugf("Holding htlc of %v for invoice %x, %v of %v "+
+		"received", htlc.Amt, rHash[:], set.amt, invoice.Terms.Value)
+
+	if set.accepted || set.amt < invoice.Terms.Value {
+		return nil
+	}
+
+	// All HTLCs of the payment have arrived, so we'll record them along
+	// with the invoice.
+	set.stopTimeout()
+
+	err = i.completeHeldSet(rHash, set, &invoice, isHold, isDebug)
+	if err != nil {
+		// As the timeout of the set has been stopped, its HTLCs would
+		// otherwise be held on to until they expire. The HTLC that was
+		// just added is failed by the caller as we return an error, so
+		// we'll fail the remaining ones.
+		ltndLog.Errorf("unable to complete htlc set of invoice %x, "+
+			"failing %v htlcs: %v", rHash[:], len(set.htlcs), err)
+
+		delete(i.heldSets, rHash)
+		set.htlcs = set.htlcs[:len(set.htlcs)-1]
+		if !isDebug {
+			err := i.cdb.DeleteHeldHTLC(
+				rHash, htlc.ChanID, htlc.HtlcID,
+			)
+			if err != nil {
+				ltndLog.Errorf("unable to delete held htlc: "+
+					"%v", err)
+			}
 		}
 
+		i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+			Failure: lnwire.FailUnknownPaymentHash{},
+		})
+
+		return err
+	}
+
+	return nil
+}
+
+// completeHeldSet records the HTLCs of the passed set, which pay the full value
+// of the invoice, along with the invoice. A regular invoice is then settled,
+// along with the HTLCs of the set, while a hold invoice is marked as accepted.
+//
+// NOTE: This method MUST be called with the heldMtx held.
+func (i *invoiceRegistry) completeHeldSet(rHash chainhash.Hash,
+	set *heldHTLCSet, invoice *channeldb.Invoice, isHold,
+	isDebug bool) error {
+
+	if !isDebug {
+		for _, held := range set.htlcs {
+			err := i.cdb.AddInvoiceHTLC(rHash, held.htlc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The HTLCs paying to a hold invoice will remain held until the
+	// invoice is either settled or canceled.
+	if isHold {
+		if err := i.cdb.AcceptInvoice(rHash); err != nil {
+			return err
+		}
+		set.accepted = true
+
+		// Now that its HTLCs are held, the invoice will remain
+		// accepted until it's either settled or canceled.
+		i.stopExpiry(rHash)
+
 		go func() {
-			eventChan <- invoice
+			invoice, err := i.cdb.LookupInvoice(rHash)
+			if err != nil {
+				ltndLog.Errorf("unable to find invoice: %v", err)
+				return
+			}
+
+			ltndLog.Infof("Hold invoice accepted: %v",
+				spew.Sdump(invoice))
+
+			i.notifyClients(invoice, false)
 		}()
+
+		return nil
+	}
+
+	if err := i.SettleInvoice(rHash); err != nil {
+		return err
+	}
+	delete(i.heldSets, rHash)
+
+	i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+		Settle:   true,
+		Preimage: invoice.Terms.PaymentPreimage,
+	})
+
+	return nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice that pays to the hash of
+// the passed preimage, and settles all HTLCs that are held for it. If no HTLCs
+// are held for the invoice, then it isn't settled, as we wouldn't be able to
+// claim its payment.
+func (i *invoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	rHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+
+	ltndLog.Debugf("Settling hold invoice %x", rHash[:])
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	set, ok := i.heldSets[rHash]
+	if !ok || !set.accepted {
+		return errNoHeldHTLCs
+	}
+
+	if err := i.cdb.SettleHoldInvoice(preimage); err != nil {
+		return err
+	}
+
+	delete(i.heldSets, rHash)
+	i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+		Settle:   true,
+		Preimage: preimage,
+	})
+
+	go func() {
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			ltndLog.Errorf("unable to find invoice: %v", err)
+			return
+		}
+
+		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+
+		i.notifyClients(invoice, false)
+	}()
+
+	return nil
+}
+
+// CancelInvoice cancels the invoice identified by the passed payment hash, and
+// fails all HTLCs that are held for it. Invoices that have already been
+// settled can't be canceled.
+func (i *invoiceRegistry) CancelInvoice(rHash chainhash.Hash) error {
+	ltndLog.Debugf("Canceling invoice %x", rHash[:])
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	if err := i.cdb.CancelInvoice(rHash); err != nil {
+		return err
+	}
+	i.stopExpiry(rHash)
+
+	if set, ok := i.heldSets[rHash]; ok {
+		set.stopTimeout()
+		delete(i.heldSets, rHash)
+
+		i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+			Failure: lnwire.FailUnknownPaymentHash{},
+		})
+	}
+
+	go func() {
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			ltndLog.Errorf("unable to find invoice: %v", err)
+			return
+		}
+
+		i.notifyClients(invoice, false)
+	}()
+
+	return nil
+}
+
+// DeleteCanceledInvoices deletes all invoices that have either been canceled
+// or have expired from the database, returning the number of deleted
+// invoices.
+func (i *invoiceRegistry) DeleteCanceledInvoices() (int, error) {
+	numDeleted, err := i.cdb.DeleteCanceledInvoices()
+	if err != nil {
+		return 0, err
+	}
+
+	ltndLog.Infof("Deleted %v canceled and expired invoices", numDeleted)
+
+	return numDeleted, nil
+}
+
+// expireHeldHTLCs fails all HTLCs of the passed set, as the remaining HTLCs of
+// the payment didn't arrive in time.
+func (i *invoiceRegistry) expireHeldHTLCs(rHash chainhash.Hash,
+	set *heldHTLCSet) {
+
+	i.heldMtx.Lock()
+	defer i.heldMtx.Unlock()
+
+	// If the set was completed while the timer fired, then there's nothing
+	// left to do.
+	if i.heldSets[rHash] != set || set.accepted {
+		return
+	}
+	delete(i.heldSets, rHash)
+
+	ltndLog.Infof("Failing %v htlcs of payment to invoice %x, only %v "+
+		"was received in time", len(set.htlcs), rHash[:], set.amt)
+
+	i.resolveHeldSet(rHash, set, htlcswitch.HTLCResolution{
+		Failure: lnwire.FailMPPTimeout{},
+	})
+}
+
+// resolveHeldSet hands the passed resolution to each HTLC of the set.
+//
+// NOTE: This method MUST be called with the heldMtx held.
+func (i *invoiceRegistry) resolveHeldSet(rHash chainhash.Hash,
+	set *heldHTLCSet, res htlcswitch.HTLCResolution) {
+
+	for _, held := range set.htlcs {
+		i.resolveHeldHTLC(rHash, held, res)
 	}
 }
 
-// invoiceSubscription represents an intent to receive updates for newly added
-// or settled invoices. For each newly added invoice, a copy of the invoice
-// will be sent over the NewInvoices channel. Similarly, for each newly settled
-// invoice, a copy of the invoice will be sent over the SettledInvoices
-// channel.
+// resolveHeldHTLC hands the passed resolution to the link of the HTLC. Once
+// the link has received it, the HTLC is no longer persisted as held. If the
+// link is offline, then the HTLC remains persisted, and is handed its
+// resolution once the link resumes it.
+//
+// NOTE: This method MUST be called with the heldMtx held.
+func (i *invoiceRegistry) resolveHeldHTLC(rHash chainhash.Hash,
+	held *heldHTLC, res htlcswitch.HTLCResolution) {
+
+	if held.resolve == nil {
+		return
+	}
+
+	res.HtlcID = held.htlc.HtlcID
+	resolve := held.resolve
+
+	go func() {
+		if err := resolve(res); err != nil {
+			ltndLog.Debugf("unable to resolve held htlc %v of "+
+				"ChannelID(%v): %v", held.htlc.HtlcID,
+				held.htlc.ChanID, err)
+			return
+		}
+
+		err := i.cdb.DeleteHeldHTLC(
+			rHash, held.htlc.ChanID, held.htlc.HtlcID,
+		)
+		if err != nil {
+			ltndLog.Errorf("unable to delete held htlc %v of "+
+				"ChannelID(%v): %v", held.htlc.HtlcID,
+				held.htlc.ChanID, err)
+		}
+	}()
+}
+
+// stopTimeout stops the timeout of the set, if it has one.
+func (s *heldHTLCSet) stopTimeout() {
+	if s.timeout != nil {
+		s.timeout.Stop()
+	}
+}
+
+// find returns the HTLC within the set that matches the passed HTLC, or nil if
+// the set doesn't contain it. It's safe to call on a nil set.
+func (s *heldHTLCSet) find(htlc channeldb.InvoiceHTLC) *heldHTLC {
+	if s == nil {
+		return nil
+	}
+
+	for _, held := range s.htlcs {
+		if held.htlc.ChanID == htlc.ChanID &&
+			held.htlc.HtlcID == htlc.HtlcID {
+
+			return held
+		}
+	}
+
+	return nil
+}
+
+// notifyClients notifies all currently registered invoice notification clients
+// of a newly added, accepted, settled, canceled or expired invoice. If isNew is
+// true, then the invoice has just been added, and is delivered as such
+// regardless of its current state.
+func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice,
+	isNew bool) {
+
+	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	for _, client := range i.notificationClients {
+		client.enqueue(&invoiceEvent{
+			invoice: invoice,
+			isNew:   isNew,
+		})
+	}
+}
+
+// invoiceEvent is a single notification that's queued for delivery to an
+// invoice subscription.
+type invoiceEvent struct {
+	invoice *channeldb.Invoice
+	isNew   bool
+
+	// replayed is true if the invoice was fetched from the database when
+	// the client subscribed, rather than being a live notification.
+	replayed bool
+}
+
+// invoiceSubscription represents an intent to receive updates for newly added,
+// accepted, settled, canceled or expired invoices. For each newly added
+// invoice, a copy of the invoice will be sent over the NewInvoices channel.
+// Similarly, for each newly settled invoice, a copy of the invoice will be
+// sent over the SettledInvoices channel, and for each hold invoice whose HTLCs
+// have been accepted, over the AcceptedInvoices channel. Invoices that have
+// been canceled or have expired are sent over the CanceledInvoices and
+// ExpiredInvoices channels respectively.
+//
+// Notifications are delivered in the order they were queued. As all channels
+// are unbuffered, the caller must read from each of them until the
+// subscription is canceled.
 type invoiceSubscription struct {
-	NewInvoices     chan *channeldb.Invoice
-	SettledInvoices chan *channeldb.Invoice
+	NewInvoices      chan *channeldb.Invoice
+	SettledInvoices  chan *channeldb.Invoice
+	AcceptedInvoices chan *channeldb.Invoice
+	CanceledInvoices chan *channeldb.Invoice
+	ExpiredInvoices  chan *channeldb.Invoice
+
+	// addIndex and settleIndex are the highest add and settle indexes of
+	// the invoices that were replayed to the client when it subscribed.
+	// Live notifications of invoices at or below these indexes have
+	// already been delivered, so they're skipped.
+	addIndex    uint64
+	settleIndex uint64
+
+	// ntfnQueue holds the notifications that are yet to be delivered to
+	// the client, and queueSignal is signaled each time a notification is
+	// added to it.
+	ntfnQueue   []*invoiceEvent
+	queueMtx    sync.Mutex
+	queueSignal chan struct{}
 
 	inv *invoiceRegistry
 	id  uint32
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// enqueue queues the passed notification for delivery to the client.
+func (i *invoiceSubscription) enqueue(event *invoiceEvent) {
+	i.queueMtx.Lock()
+	i.ntfnQueue = append(i.ntfnQueue, event)
+	i.queueMtx.Unlock()
+
+	select {
+	case i.queueSignal <- struct{}{}:
+	default:
+	}
+}
+
+// notificationDispatcher delivers the queued notifications to the client in
+// order, skipping those that were already delivered when the client
+// subscribed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (i *invoiceSubscription) notificationDispatcher() {
+	defer i.wg.Done()
+
+	for {
+		i.queueMtx.Lock()
+		if len(i.ntfnQueue) == 0 {
+			i.queueMtx.Unlock()
+
+			select {
+			case <-i.queueSignal:
+				continue
+			case <-i.quit:
+				return
+			}
+		}
+		event := i.ntfnQueue[0]
+		i.ntfnQueue[0] = nil
+		i.ntfnQueue = i.ntfnQueue[1:]
+		i.queueMtx.Unlock()
+
+		invoice := event.invoice
+
+		var eventChan chan *channeldb.Invoice
+		switch {
+		case event.isNew:
+			if !event.replayed && invoice.AddIndex <= i.addIndex {
+				continue
+			}
+			eventChan = i.NewInvoices
+
+		case invoice.Terms.State == channeldb.ContractSettled:
+			if !event.replayed &&
+				invoice.SettleIndex <= i.settleIndex {
+
+				continue
+			}
+			eventChan = i.SettledInvoices
+
+		case invoice.Terms.State == channeldb.ContractAccepted:
+			eventChan = i.AcceptedInvoices
+
+		case invoice.Terms.State == channeldb.ContractCanceled:
+			eventChan = i.CanceledInvoices
+
+		case invoice.Terms.State == channeldb.ContractExpired:
+			eventChan = i.ExpiredInvoices
+
+		default:
+			continue
+		}
+
+		select {
+		case eventChan <- invoice:
+		case <-i.quit:
+			return
+		}
+	}
 }
 
 // Cancel unregisters the invoiceSubscription, freeing any previously allocated
@@ -200,23 +1033,87 @@ func (i *invoiceSubscription) Cancel() {
 	i.inv.clientMtx.Lock()
 	delete(i.inv.notificationClients, i.id)
 	i.inv.clientMtx.Unlock()
+
+	close(i.quit)
+	i.wg.Wait()
 }
 
 // SubscribeNotifications returns an invoiceSubscription which allows the
-// caller to receive async notifications when any invoices are settled or
-// added.
-func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
+// caller to receive async notifications when any invoices are added, or
+// change state. If addIndex is non-zero, then all invoices added since that
+// add index are delivered over the NewInvoices channel before any live
+// notifications. Similarly, if settleIndex is non-zero, all invoices settled
+// since that settle index are first delivered over the SettledInvoices
+// channel. This allows a client to catch up on the events it missed while it
+// wasn't subscribed, without any event being delivered twice.
+func (i *invoiceRegistry) SubscribeNotifications(addIndex,
+	settleIndex uint64) (*invoiceSubscription, error) {
+
 	client := &invoiceSubscription{
-		NewInvoices:     make(chan *channeldb.Invoice),
-		SettledInvoices: make(chan *channeldb.Invoice),
-		inv:             i,
+		NewInvoices:      make(chan *channeldb.Invoice),
+		SettledInvoices:  make(chan *channeldb.Invoice),
+		AcceptedInvoices: make(chan *channeldb.Invoice),
+		CanceledInvoices: make(chan *channeldb.Invoice),
+		ExpiredInvoices:  make(chan *channeldb.Invoice),
+		addIndex:         addIndex,
+		settleIndex:      settleIndex,
+		queueSignal:      make(chan struct{}, 1),
+		inv:              i,
+		quit:             make(chan struct{}),
 	}
 
+	// We'll hold the client mutex while fetching the invoices to be
+	// replayed, so that any notification sent after they were fetched is
+	// queued after them. Notifications of the replayed invoices that are
+	// sent afterwards are skipped by the dispatcher.
 	i.clientMtx.Lock()
+	defer i.clientMtx.Unlock()
+
+	if addIndex != 0 {
+		added, err := i.cdb.InvoicesAddedSince(addIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, invoice := range added {
+			client.ntfnQueue = append(client.ntfnQueue,
+				&invoiceEvent{
+					invoice:  invoice,
+					isNew:    true,
+					replayed: true,
+				},
+			)
+		}
+		if len(added) > 0 {
+			client.addIndex = added[len(added)-1].AddIndex
+		}
+	}
+
+	if settleIndex != 0 {
+		settled, err := i.cdb.InvoicesSettledSince(settleIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, invoice := range settled {
+			client.ntfnQueue = append(client.ntfnQueue,
+				&invoiceEvent{
+					invoice:  invoice,
+					replayed: true,
+				},
+			)
+		}
+		if len(settled) > 0 {
+			client.settleIndex = settled[len(settled)-1].SettleIndex
+		}
+	}
+
 	i.notificationClients[i.nextClientID] = client
 	client.id = i.nextClientID
 	i.nextClientID++
-	i.clientMtx.Unlock()
 
-	return client
+	client.wg.Add(1)
+	go client.notificationDispatcher()
+
+	return client, nil
 }
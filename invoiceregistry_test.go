@@ -0,0 +1,422 @@
+// +build !rpctest
+
+package main
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// testInvoiceValue is the value of the invoices used within the invoice
+// registry tests. They're paid using two shards of half its value.
+const testInvoiceValue = lnwire.MilliSatoshi(100000)
+
+// newTestRegistry creates an invoice registry backed by a fresh channel
+// database, along with a closure that tears it down.
+func newTestRegistry(t *testing.T) (*invoiceRegistry, func()) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+
+	registry := newInvoiceRegistry(cdb)
+	if err := registry.Start(); err != nil {
+		cleanUp()
+		t.Fatalf("unable to start registry: %v", err)
+	}
+
+	return registry, func() {
+		registry.Stop()
+		cleanUp()
+	}
+}
+
+// restartTestRegistry stops the passed registry, and starts a new one backed
+// by the same channel database, as would happen if we were restarted.
+func restartTestRegistry(t *testing.T,
+	registry *invoiceRegistry) *invoiceRegistry {
+
+	registry.Stop()
+
+	registry = newInvoiceRegistry(registry.cdb)
+	if err := registry.Start(); err != nil {
+		t.Fatalf("unable to start registry: %v", err)
+	}
+
+	return registry
+}
+
+// addTestInvoice adds an invoice of testInvoiceValue to the registry, with
+// the passed HTLCs already recorded along with it, and returns its payment
+// hash.
+func addTestInvoice(t *testing.T, registry *invoiceRegistry, preimageByte byte,
+	htlcs []channeldb.InvoiceHTLC) chainhash.Hash {
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value: testInvoiceValue,
+		},
+		Htlcs: htlcs,
+	}
+	invoice.Terms.PaymentPreimage[0] = preimageByte
+
+	if err := registry.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	return chainhash.Hash(invoice.Terms.PaymentHash)
+}
+
+// addTestHoldInvoice adds a hold invoice of testInvoiceValue to the registry,
+// and returns its preimage along with its payment hash.
+func addTestHoldInvoice(t *testing.T, registry *invoiceRegistry,
+	preimageByte byte) ([32]byte, chainhash.Hash) {
+
+	preimage := [32]byte{preimageByte}
+	rHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:       testInvoiceValue,
+			PaymentHash: rHash,
+		},
+	}
+	if err := registry.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	return preimage, rHash
+}
+
+// resolveFunc returns a resolve callback for held HTLCs which delivers their
+// resolutions over the passed channel.
+func resolveFunc(
+	resolutions chan htlcswitch.HTLCResolution) func(
+	htlcswitch.HTLCResolution) error {
+
+	return func(res htlcswitch.HTLCResolution) error {
+		resolutions <- res
+		return nil
+	}
+}
+
+// holdShard hands a shard of half the invoice value to the registry, returning
+// the channel its resolution will be delivered over.
+func holdShard(registry *invoiceRegistry, rHash chainhash.Hash,
+	htlcID uint64) (chan htlcswitch.HTLCResolution, error) {
+
+	resolutions := make(chan htlcswitch.HTLCResolution, 1)
+	htlc := channeldb.InvoiceHTLC{
+		HtlcID:     htlcID,
+		Amt:        testInvoiceValue / 2,
+		AcceptTime: time.Now(),
+	}
+	err := registry.HoldInvoiceHTLC(rHash, htlc, resolveFunc(resolutions))
+
+	return resolutions, err
+}
+
+// assertHTLCSettled asserts that the HTLC with the passed ID is settled using
+// the passed preimage.
+func assertHTLCSettled(t *testing.T,
+	resolutions chan htlcswitch.HTLCResolution, htlcID uint64,
+	preimage [32]byte) {
+
+	select {
+	case res := <-resolutions:
+		if !res.Settle {
+			t.Fatalf("expected htlc %v to settle, it failed "+
+				"with: %v", htlcID, res.Failure)
+		}
+		if res.HtlcID != htlcID {
+			t.Fatalf("expected resolution of htlc %v, got %v",
+				htlcID, res.HtlcID)
+		}
+		if res.Preimage != preimage {
+			t.Fatalf("expected preimage %x, got %x", preimage,
+				res.Preimage)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("htlc %v wasn't resolved", htlcID)
+	}
+}
+
+// assertInvoiceState asserts that the invoice with the passed payment hash is
+// in the expected state.
+func assertInvoiceState(t *testing.T, registry *invoiceRegistry,
+	rHash chainhash.Hash, state channeldb.ContractState) {
+
+	invoice, err := registry.LookupInvoice(rHash)
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if invoice.Terms.State != state {
+		t.Fatalf("expected invoice to be %v, got %v", state,
+			invoice.Terms.State)
+	}
+}
+
+// assertNumHeldHTLCs asserts that the expected number of HTLCs are persisted
+// as held.
+func assertNumHeldHTLCs(t *testing.T, registry *invoiceRegistry,
+	expected int) {
+
+	var numHeld int
+	for i := 0; i < 50; i++ {
+		held, err := registry.cdb.FetchHeldHTLCs()
+		if err != nil {
+			t.Fatalf("unable to fetch held htlcs: %v", err)
+		}
+
+		numHeld = 0
+		for _, htlcs := range held {
+			numHeld += len(htlcs)
+		}
+		if numHeld == expected {
+			return
+		}
+
+		// Held HTLCs are deleted once their resolution has been
+		// delivered, so we'll give the registry some time.
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("expected %v held htlcs, found %v", expected, numHeld)
+}
+
+// assertHTLCFailed asserts that a failure is delivered for the HTLC with the
+// passed ID.
+func assertHTLCFailed(t *testing.T,
+	resolutions chan htlcswitch.HTLCResolution, htlcID uint64,
+	failure lnwire.FailureMessage) {
+
+	select {
+	case res := <-resolutions:
+		if res.Settle {
+			t.Fatalf("expected htlc %v to fail, it was settled",
+				htlcID)
+		}
+		if res.HtlcID != htlcID {
+			t.Fatalf("expected resolution of htlc %v, got %v",
+				htlcID, res.HtlcID)
+		}
+		if res.Failure.Code() != failure.Code() {
+			t.Fatalf("expected failure %v, got %v", failure,
+				res.Failure)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("htlc %v wasn't resolved", htlcID)
+	}
+}
+
+// assertNoHeldSets asserts that the registry isn't holding any HTLCs.
+func assertNoHeldSets(t *testing.T, registry *invoiceRegistry) {
+	registry.heldMtx.Lock()
+	defer registry.heldMtx.Unlock()
+
+	if len(registry.heldSets) != 0 {
+		t.Fatalf("expected no held htlc sets, found %v",
+			len(registry.heldSets))
+	}
+}
+
+// TestInvoiceRegistryMPPTimeout asserts that the shards of a multi-path
+// payment are failed once the remaining shards don't arrive in time, leaving
+// the invoice open.
+func TestInvoiceRegistryMPPTimeout(t *testing.T) {
+	t.Parallel()
+
+	registry, cleanUp := newTestRegistry(t)
+	defer cleanUp()
+
+	registry.mppTimeout = 100 * time.Millisecond
+
+	rHash := addTestInvoice(t, registry, 1, nil)
+
+	resolutions, err := holdShard(registry, rHash, 0)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+
+	assertHTLCFailed(t, resolutions, 0, lnwire.FailMPPTimeout{})
+	assertNoHeldSets(t, registry)
+	assertNumHeldHTLCs(t, registry, 0)
+	assertInvoiceState(t, registry, rHash, channeldb.ContractOpen)
+}
+
+// TestInvoiceRegistryHeldSetFailure asserts that if the HTLCs of a complete
+// set can't be recorded with the invoice, then all HTLCs of the set are
+// failed, rather than being held until they expire.
+func TestInvoiceRegistryHeldSetFailure(t *testing.T) {
+	t.Parallel()
+
+	registry, cleanUp := newTestRegistry(t)
+	defer cleanUp()
+
+	// The invoice already has the max number of HTLCs recorded, so the
+	// shards of the payment can't be recorded once the set is complete.
+	htlcs := make([]channeldb.InvoiceHTLC, channeldb.MaxInvoiceHTLCs)
+	rHash := addTestInvoice(t, registry, 2, htlcs)
+
+	resolutions, err := holdShard(registry, rHash, 0)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+
+	// The second shard completes the set. As it can't be recorded, an
+	// error is returned for it, and the first shard is failed.
+	if _, err := holdShard(registry, rHash, 1); err == nil {
+		t.Fatalf("expected failure to complete htlc set")
+	}
+
+	assertHTLCFailed(t, resolutions, 0, lnwire.FailUnknownPaymentHash{})
+	assertNoHeldSets(t, registry)
+}
+
+// TestInvoiceRegistryHoldInvoice asserts that the HTLCs of a hold invoice are
+// held once they pay its full value, until the invoice is either settled or
+// canceled.
+func TestInvoiceRegistryHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	registry, cleanUp := newTestRegistry(t)
+	defer cleanUp()
+
+	// A hold invoice without any held HTLCs can't be settled.
+	preimage, rHash := addTestHoldInvoice(t, registry, 3)
+	if err := registry.SettleHoldInvoice(preimage); err != errNoHeldHTLCs {
+		t.Fatalf("expected errNoHeldHTLCs, got %v", err)
+	}
+
+	resolutions1, err := holdShard(registry, rHash, 0)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+	resolutions2, err := holdShard(registry, rHash, 1)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+
+	// With the full value held, the invoice is accepted, and its HTLCs
+	// remain held until it's settled.
+	assertInvoiceState(t, registry, rHash, channeldb.ContractAccepted)
+	assertNumHeldHTLCs(t, registry, 2)
+
+	if err := registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle hold invoice: %v", err)
+	}
+
+	assertHTLCSettled(t, resolutions1, 0, preimage)
+	assertHTLCSettled(t, resolutions2, 1, preimage)
+	assertInvoiceState(t, registry, rHash, channeldb.ContractSettled)
+	assertNoHeldSets(t, registry)
+	assertNumHeldHTLCs(t, registry, 0)
+
+	// A second hold invoice is canceled instead, which fails its HTLCs.
+	_, rHash = addTestHoldInvoice(t, registry, 4)
+
+	resolutions1, err = holdShard(registry, rHash, 2)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+	resolutions2, err = holdShard(registry, rHash, 3)
+	if err != nil {
+		t.Fatalf("unable to hold htlc: %v", err)
+	}
+	assertInvoiceState(t, registry, rHash, channeldb.ContractAccepted)
+
+	if err := registry.CancelInvoice(rHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+
+	failure := lnwire.FailUnknownPaymentHash{}
+	assertHTLCFailed(t, resolutions1, 2, failure)
+	assertHTLCFailed(t, resolutions2, 3, failure)
+	assertInvoiceState(t, registry, rHash, channeldb.ContractCanceled)
+	assertNoHeldSets(t, registry)
+	assertNumHeldHTLCs(t, registry, 0)
+}
+
+// TestInvoiceRegistryHoldInvoiceRestart asserts that the HTLCs held for a hold
+// invoice survive a restart, and are settled once the links holding them have
+// resumed them, even if the invoice was settled before then.
+func TestInvoiceRegistryHoldInvoiceRestart(t *testing.T) {
+	t.Parallel()
+
+	registry, cleanUp := newTestRegistry(t)
+	defer cleanUp()
+
+	chanID1 := lnwire.NewShortChanIDFromInt(1)
+	chanID2 := lnwire.NewShortChanIDFromInt(2)
+
+	// We'll hold an HTLC over each of the channels, which together pay
+	// the full value of the hold invoice.
+	preimage, rHash := addTestHoldInvoice(t, registry, 5)
+	for i, chanID := range []lnwire.ShortChannelID{chanID1, chanID2} {
+		htlc := channeldb.InvoiceHTLC{
+			ChanID:     chanID,
+			HtlcID:     uint64(i),
+			Amt:        testInvoiceValue / 2,
+			AcceptTime: time.Now(),
+		}
+		err := registry.HoldInvoiceHTLC(
+			rHash, htlc, func(htlcswitch.HTLCResolution) error {
+				t.Fatalf("htlc resolved prior to restart")
+				return nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to hold htlc: %v", err)
+		}
+	}
+	assertInvoiceState(t, registry, rHash, channeldb.ContractAccepted)
+
+	registry = restartTestRegistry(t, registry)
+
+	// Only the link of the first channel has resumed its HTLC by the time
+	// the invoice is settled.
+	resolutions1 := make(chan htlcswitch.HTLCResolution, 1)
+	resumed, err := registry.ResumeHeldHTLCs(
+		chanID1, resolveFunc(resolutions1),
+	)
+	if err != nil {
+		t.Fatalf("unable to resume held htlcs: %v", err)
+	}
+	if len(resumed) != 1 || resumed[0] != rHash {
+		t.Fatalf("expected htlc 0 to be resumed, got %v", resumed)
+	}
+
+	if err := registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle hold invoice: %v", err)
+	}
+	assertHTLCSettled(t, resolutions1, 0, preimage)
+	assertNumHeldHTLCs(t, registry, 1)
+
+	// The HTLC of the second channel is settled as soon as its link has
+	// resumed it.
+	resolutions2 := make(chan htlcswitch.HTLCResolution, 1)
+	resumed, err = registry.ResumeHeldHTLCs(
+		chanID2, resolveFunc(resolutions2),
+	)
+	if err != nil {
+		t.Fatalf("unable to resume held htlcs: %v", err)
+	}
+	if len(resumed) != 1 || resumed[1] != rHash {
+		t.Fatalf("expected htlc 1 to be resumed, got %v", resumed)
+	}
+
+	assertHTLCSettled(t, resolutions2, 1, preimage)
+	assertNumHeldHTLCs(t, registry, 0)
+
+	registry.Stop()
+}
@@ -33,8 +33,11 @@ import (
 
 	flags "github.com/btcsuite/go-flags"
 	proxy "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/cipherseed"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -193,24 +196,30 @@ func lndMain() error {
 
 	// We wait until the user provides a password over RPC. In case lnd is
 	// started with the --noencryptwallet flag, we use the default password
-	// "hello" for wallet encryption.
+	// "hello" for wallet encryption. A watch-only wallet holds no private
+	// keys, so it's never encrypted.
 	privateWalletPw := []byte("hello")
 	publicWalletPw := []byte("public")
-	if !cfg.NoEncryptWallet {
-		privateWalletPw, publicWalletPw, err = waitForWalletPassword(
+	var walletSeed *cipherseed.CipherSeed
+	if !cfg.NoEncryptWallet && !cfg.RemoteSigner.Active {
+		walletInitParams, err := waitForWalletPassword(
 			grpcEndpoint, restEndpoint, serverOpts, proxyOpts,
 			tlsConf, macaroonService,
 		)
 		if err != nil {
 			return err
 		}
+
+		privateWalletPw = walletInitParams.password
+		publicWalletPw = walletInitParams.password
+		walletSeed = walletInitParams.walletSeed
 	}
 
 	// With the information parsed from the configuration, create valid
 	// instances of the pertinent interfaces required to operate the
 	// Lightning Network Daemon.
 	activeChainControl, chainCleanUp, err := newChainControlFromConfig(cfg,
-		chanDB, privateWalletPw, publicWalletPw)
+		chanDB, privateWalletPw, publicWalletPw, walletSeed)
 	if err != nil {
 		fmt.Printf("unable to create chain control: %v\n", err)
 		return err
@@ -225,19 +234,59 @@ func lndMain() error {
 	primaryChain := registeredChains.PrimaryChain()
 	registeredChains.RegisterChain(primaryChain, activeChainControl)
 
-	idPrivKey, err := activeChainControl.wallet.GetIdentitykey()
-	if err != nil {
-		return err
+	// All operations with our identity key are performed through the
+	// remote signer if we're watch-only, in which case the signer also
+	// hands us a dedicated key to encrypt our channel backups under.
+	// Otherwise, the identity key is derived from our wallet, and used
+	// for both.
+	var (
+		identityKey nodeKeyRing
+		onionRouter htlcswitch.OnionRouter
+		backupKey   *btcec.PrivateKey
+	)
+	if activeChainControl.remoteSigner != nil {
+		signerClient := activeChainControl.remoteSigner
+		identityKey, err = signerClient.NodeKey()
+		if err != nil {
+			return fmt.Errorf("unable to fetch identity key from "+
+				"remote signer: %v", err)
+		}
+		backupKey, err = signerClient.DeriveBackupKey()
+		if err != nil {
+			return fmt.Errorf("unable to fetch backup key from "+
+				"remote signer: %v", err)
+		}
+		onionRouter = signerClient
+	} else {
+		idPrivKey, err := activeChainControl.wallet.GetIdentitykey()
+		if err != nil {
+			return err
+		}
+		idPrivKey.Curve = btcec.S256()
+
+		identityKey = newNodeSigner(idPrivKey)
+		onionRouter = htlcswitch.NewSphinxRouter(
+			sphinx.NewRouter(idPrivKey, activeNetParams.Params),
+		)
+		backupKey = idPrivKey
 	}
-	idPrivKey.Curve = btcec.S256()
+	idPubKey := identityKey.PubKey()
 
 	// Set up the core server which will listen for incoming peer
-	// connections.
+	// connections. If our only public address is an onion service, then
+	// we'll only listen locally so that the Tor server is the sole way of
+	// reaching us.
+	listenHost := ""
+	if (cfg.Tor.V2 || cfg.Tor.V3) && len(cfg.ExternalIPs) == 0 {
+		listenHost = "localhost"
+	}
 	defaultListenAddrs := []string{
-		net.JoinHostPort("", strconv.Itoa(cfg.PeerPort)),
+		net.JoinHostPort(listenHost, strconv.Itoa(cfg.PeerPort)),
 	}
-	server, err := newServer(defaultListenAddrs, chanDB, activeChainControl,
-		idPrivKey)
+	server, err := newServer(
+		defaultListenAddrs, chanDB, activeChainControl, identityKey,
+		onionRouter, backupKey,
+	)
 	if err != nil {
 		srvrLog.Errorf("unable to create server: %v\n", err)
 		return err
@@ -245,21 +294,20 @@ func lndMain() error {
 
 	// Next, we'll initialize the funding manager itself so it can answer
 	// queries while the wallet+chain are still syncing.
-	nodeSigner := newNodeSigner(idPrivKey)
 	var chanIDSeed [32]byte
 	if _, err := rand.Read(chanIDSeed[:]); err != nil {
 		return err
 	}
 	fundingMgr, err := newFundingManager(fundingConfig{
-		IDKey:        idPrivKey.PubKey(),
+		IDKey:        idPubKey,
 		Wallet:       activeChainControl.wallet,
 		Notifier:     activeChainControl.chainNotifier,
 		FeeEstimator: activeChainControl.feeEstimator,
 		SignMessage: func(pubKey *btcec.PublicKey,
 			msg []byte) (*btcec.Signature, error) {
 
-			if pubKey.IsEqual(idPrivKey.PubKey()) {
-				return nodeSigner.SignMessage(pubKey, msg)
+			if pubKey.IsEqual(idPubKey) {
+				return identityKey.SignMessage(pubKey, msg)
 			}
 
 			return activeChainControl.msgSigner.SignMessage(
@@ -271,7 +319,7 @@ func lndMain() error {
 		},
 		SendAnnouncement: func(msg lnwire.Message) error {
 			errChan := server.authGossiper.ProcessLocalAnnouncement(msg,
-				idPrivKey.PubKey())
+				idPubKey)
 			return <-errChan
 		},
 		ArbiterChan:      server.breachArbiter.newContracts,
@@ -309,6 +357,8 @@ func lndMain() error {
 			// configuration
 			return 4
 		},
+		PsbtFundingTimeout: defaultPsbtFundingTimeout,
+		ChannelsChanged:    server.chanSubSwapper.ChannelsChanged,
 	})
 	if err != nil {
 		return err
@@ -625,12 +675,25 @@ func genMacaroons(svc *bakery.Service, admFile, roFile string) error {
 	return nil
 }
 
+// walletUnlockParams holds the parameters provided by the user over the
+// WalletUnlocker service which are required to create or open the wallet.
+type walletUnlockParams struct {
+	// password is the password used to encrypt the wallet.
+	password []byte
+
+	// walletSeed is the cipher seed the wallet should be created from. It
+	// is only set if the user created the wallet from a seed, either one
+	// freshly obtained from GenSeed, or one being restored.
+	walletSeed *cipherseed.CipherSeed
+}
+
 // waitForWalletPassword will spin up gRPC and REST endpoints for the
 // WalletUnlocker server, and block until a password is provided by
 // the user to this RPC server.
 func waitForWalletPassword(grpcEndpoint, restEndpoint string,
 	serverOpts []grpc.ServerOption, proxyOpts []grpc.DialOption,
-	tlsConf *tls.Config, macaroonService *bakery.Service) ([]byte, []byte, error) {
+	tlsConf *tls.Config,
+	macaroonService *bakery.Service) (*walletUnlockParams, error) {
 
 	// Set up a new PasswordService, which will listen
 	// for passwords provided over RPC.
@@ -649,7 +712,7 @@ func waitForWalletPassword(grpcEndpoint, restEndpoint string,
 	lis, err := net.Listen("tcp", grpcEndpoint)
 	if err != nil {
 		fmt.Printf("failed to listen: %v", err)
-		return nil, nil, err
+		return nil, err
 	}
 	defer lis.Close()
 
@@ -675,7 +738,7 @@ func waitForWalletPassword(grpcEndpoint, restEndpoint string,
 	err = lnrpc.RegisterWalletUnlockerHandlerFromEndpoint(ctx, mux,
 		grpcEndpoint, proxyOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	srv := &http.Server{Handler: mux}
 	defer func() {
@@ -711,13 +774,20 @@ func waitForWalletPassword(grpcEndpoint, restEndpoint string,
 
 	// We currently don't distinguish between getting a password to
 	// be used for creation or unlocking, as a new wallet db will be
-	// created if none exists when creating the chain control.
+	// created if none exists when creating the chain control. If the
+	// wallet is being created from a seed, then we'll also pass it
+	// along, so it's used to derive all the keys of the wallet.
 	select {
-	case walletPw := <-pwService.CreatePasswords:
-		return walletPw, walletPw, nil
+	case initMsg := <-pwService.InitMsgs:
+		return &walletUnlockParams{
+			password:   initMsg.Passphrase,
+			walletSeed: initMsg.WalletSeed,
+		}, nil
 	case walletPw := <-pwService.UnlockPasswords:
-		return walletPw, walletPw, nil
+		return &walletUnlockParams{
+			password: walletPw,
+		}, nil
 	case <-shutdownChannel:
-		return nil, nil, fmt.Errorf("shutting down")
+		return nil, fmt.Errorf("shutting down")
 	}
 }
@@ -9,13 +9,36 @@ It is generated from these files:
 	rpc.proto
 
 It has these top-level messages:
+	GenSeedRequest
+	GenSeedResponse
 	CreateWalletRequest
 	CreateWalletResponse
 	UnlockWalletRequest
 	UnlockWalletResponse
+	DeriveNextKeyRequest
+	DeriveNextKeyResponse
+	SignOutputRawRequest
+	SignOutputRawResponse
+	ComputeInputScriptRequest
+	ComputeInputScriptResponse
+	SignMessageWithKeyRequest
+	SignMessageWithKeyResponse
+	SignDigestCompactRequest
+	SignDigestCompactResponse
+	DeriveRevocationRootRequest
+	DeriveRevocationRootResponse
+	IdentityPubKeyRequest
+	IdentityPubKeyResponse
+	DeriveSharedKeyRequest
+	DeriveSharedKeyResponse
+	ProcessOnionPacketRequest
+	ProcessOnionPacketResponse
+	DeriveBackupKeyRequest
+	DeriveBackupKeyResponse
 	Transaction
 	GetTransactionsRequest
 	TransactionDetails
+	FeeLimit
 	SendRequest
 	SendResponse
 	ChannelPoint
@@ -24,6 +47,18 @@ It has these top-level messages:
 	SendManyResponse
 	SendCoinsRequest
 	SendCoinsResponse
+	BumpFeeRequest
+	BumpFeeResponse
+	Utxo
+	ListUnspentRequest
+	ListUnspentResponse
+	LeaseOutputRequest
+	LeaseOutputResponse
+	ReleaseOutputRequest
+	ReleaseOutputResponse
+	OutputLease
+	ListLeasesRequest
+	ListLeasesResponse
 	NewAddressRequest
 	NewWitnessAddressRequest
 	NewAddressResponse
@@ -50,11 +85,18 @@ It has these top-level messages:
 	CloseChannelRequest
 	CloseStatusUpdate
 	PendingUpdate
+	ReadyForPsbtFunding
+	FundingPsbtFinalizeRequest
+	FundingPsbtFinalizeResponse
+	FundingPsbtCancelRequest
+	FundingPsbtCancelResponse
 	OpenChannelRequest
 	OpenStatusUpdate
 	PendingHTLC
 	PendingChannelRequest
 	PendingChannelResponse
+	PendingSweepsRequest
+	PendingSweepsResponse
 	WalletBalanceRequest
 	WalletBalanceResponse
 	ChannelBalanceRequest
@@ -63,6 +105,13 @@ It has these top-level messages:
 	QueryRoutesResponse
 	Hop
 	Route
+	MissionControlResult
+	QueryMissionControlRequest
+	QueryMissionControlResponse
+	ImportMissionControlRequest
+	ImportMissionControlResponse
+	ResetMissionControlRequest
+	ResetMissionControlResponse
 	NodeInfoRequest
 	NodeInfo
 	LightningNode
@@ -84,14 +133,24 @@ It has these top-level messages:
 	SetAliasRequest
 	SetAliasResponse
 	Invoice
+	InvoiceHTLC
 	AddInvoiceResponse
+	AddHoldInvoiceRequest
+	SettleInvoiceMsg
+	SettleInvoiceResp
+	CancelInvoiceMsg
+	CancelInvoiceResp
+	DeleteCanceledInvoicesRequest
+	DeleteCanceledInvoicesResponse
 	PaymentHash
 	ListInvoiceRequest
 	ListInvoiceResponse
 	InvoiceSubscription
 	Payment
+	PaymentShard
 	ListPaymentsRequest
 	ListPaymentsResponse
+	TrackPaymentRequest
 	DeleteAllPaymentsRequest
 	DeleteAllPaymentsResponse
 	DebugLevelRequest
@@ -103,6 +162,14 @@ It has these top-level messages:
 	FeeReportResponse
 	FeeUpdateRequest
 	FeeUpdateResponse
+	ForwardingHistoryRequest
+	ForwardingEvent
+	ForwardingHistoryResponse
+	ChanBackupExportRequest
+	ChanBackupSnapshot
+	VerifyChanBackupResponse
+	RestoreChanBackupRequest
+	RestoreBackupResponse
 */
 package lnrpc
 
@@ -127,6 +194,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ProcessOnionPacketResponse_OnionFailure int32
+
+const (
+	ProcessOnionPacketResponse_NONE            ProcessOnionPacketResponse_OnionFailure = 0
+	ProcessOnionPacketResponse_INVALID_VERSION ProcessOnionPacketResponse_OnionFailure = 1
+	ProcessOnionPacketResponse_INVALID_HMAC    ProcessOnionPacketResponse_OnionFailure = 2
+	ProcessOnionPacketResponse_INVALID_KEY     ProcessOnionPacketResponse_OnionFailure = 3
+)
+
+var ProcessOnionPacketResponse_OnionFailure_name = map[int32]string{
+	0: "NONE",
+	1: "INVALID_VERSION",
+	2: "INVALID_HMAC",
+	3: "INVALID_KEY",
+}
+var ProcessOnionPacketResponse_OnionFailure_value = map[string]int32{
+	"NONE":            0,
+	"INVALID_VERSION": 1,
+	"INVALID_HMAC":    2,
+	"INVALID_KEY":     3,
+}
+
+func (x ProcessOnionPacketResponse_OnionFailure) String() string {
+	return proto.EnumName(ProcessOnionPacketResponse_OnionFailure_name, int32(x))
+}
+func (ProcessOnionPacketResponse_OnionFailure) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{23, 0}
+}
+
 type NewAddressRequest_AddressType int32
 
 const (
@@ -150,17 +246,174 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{15, 0}
+	return fileDescriptor0, []int{50, 0}
+}
+
+type Invoice_InvoiceState int32
+
+const (
+	Invoice_OPEN     Invoice_InvoiceState = 0
+	Invoice_SETTLED  Invoice_InvoiceState = 1
+	Invoice_CANCELED Invoice_InvoiceState = 2
+	Invoice_ACCEPTED Invoice_InvoiceState = 3
+	Invoice_EXPIRED  Invoice_InvoiceState = 4
+)
+
+var Invoice_InvoiceState_name = map[int32]string{
+	0: "OPEN",
+	1: "SETTLED",
+	2: "CANCELED",
+	3: "ACCEPTED",
+	4: "EXPIRED",
+}
+var Invoice_InvoiceState_value = map[string]int32{
+	"OPEN":     0,
+	"SETTLED":  1,
+	"CANCELED": 2,
+	"ACCEPTED": 3,
+	"EXPIRED":  4,
+}
+
+func (x Invoice_InvoiceState) String() string {
+	return proto.EnumName(Invoice_InvoiceState_name, int32(x))
+}
+func (Invoice_InvoiceState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{123, 0}
+}
+
+type Payment_PaymentStatus int32
+
+const (
+	Payment_UNKNOWN   Payment_PaymentStatus = 0
+	Payment_IN_FLIGHT Payment_PaymentStatus = 1
+	Payment_SUCCEEDED Payment_PaymentStatus = 2
+	Payment_FAILED    Payment_PaymentStatus = 3
+)
+
+var Payment_PaymentStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "IN_FLIGHT",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var Payment_PaymentStatus_value = map[string]int32{
+	"UNKNOWN":   0,
+	"IN_FLIGHT": 1,
+	"SUCCEEDED": 2,
+	"FAILED":    3,
+}
+
+func (x Payment_PaymentStatus) String() string {
+	return proto.EnumName(Payment_PaymentStatus_name, int32(x))
+}
+func (Payment_PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{137, 0}
+}
+
+type Payment_FailureReason int32
+
+const (
+	Payment_FAILURE_REASON_NONE                      Payment_FailureReason = 0
+	Payment_FAILURE_REASON_ERROR                     Payment_FailureReason = 1
+	Payment_FAILURE_REASON_NO_ROUTE                  Payment_FailureReason = 2
+	Payment_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS Payment_FailureReason = 3
+	Payment_FAILURE_REASON_INTERRUPTED               Payment_FailureReason = 4
+)
+
+var Payment_FailureReason_name = map[int32]string{
+	0: "FAILURE_REASON_NONE",
+	1: "FAILURE_REASON_ERROR",
+	2: "FAILURE_REASON_NO_ROUTE",
+	3: "FAILURE_REASON_INCORRECT_PAYMENT_DETAILS",
+	4: "FAILURE_REASON_INTERRUPTED",
+}
+var Payment_FailureReason_value = map[string]int32{
+	"FAILURE_REASON_NONE":                      0,
+	"FAILURE_REASON_ERROR":                     1,
+	"FAILURE_REASON_NO_ROUTE":                  2,
+	"FAILURE_REASON_INCORRECT_PAYMENT_DETAILS": 3,
+	"FAILURE_REASON_INTERRUPTED":               4,
+}
+
+func (x Payment_FailureReason) String() string {
+	return proto.EnumName(Payment_FailureReason_name, int32(x))
+}
+func (Payment_FailureReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{137, 1}
+}
+
+type GenSeedRequest struct {
+	// *
+	// The optional passphrase used to encrypt the cipher seed. If unset, a
+	// default passphrase is used.
+	CipherSeedPassphrase []byte `protobuf:"bytes,1,opt,name=cipher_seed_passphrase,proto3" json:"cipher_seed_passphrase,omitempty"`
+	// *
+	// The optional 16 bytes of entropy to use when generating the seed. If unset,
+	// fresh entropy will be generated.
+	SeedEntropy []byte `protobuf:"bytes,2,opt,name=seed_entropy,proto3" json:"seed_entropy,omitempty"`
+}
+
+func (m *GenSeedRequest) Reset()                    { *m = GenSeedRequest{} }
+func (m *GenSeedRequest) String() string            { return proto.CompactTextString(m) }
+func (*GenSeedRequest) ProtoMessage()               {}
+func (*GenSeedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *GenSeedRequest) GetCipherSeedPassphrase() []byte {
+	if m != nil {
+		return m.CipherSeedPassphrase
+	}
+	return nil
+}
+
+func (m *GenSeedRequest) GetSeedEntropy() []byte {
+	if m != nil {
+		return m.SeedEntropy
+	}
+	return nil
+}
+
+type GenSeedResponse struct {
+	// *
+	// The 24 word mnemonic that encodes the enciphered seed. It should be written
+	// down by the user, then passed to CreateWallet.
+	CipherSeedMnemonic []string `protobuf:"bytes,1,rep,name=cipher_seed_mnemonic" json:"cipher_seed_mnemonic,omitempty"`
+	// / The raw bytes of the enciphered seed encoded by the mnemonic.
+	EncipheredSeed []byte `protobuf:"bytes,2,opt,name=enciphered_seed,proto3" json:"enciphered_seed,omitempty"`
+}
+
+func (m *GenSeedResponse) Reset()                    { *m = GenSeedResponse{} }
+func (m *GenSeedResponse) String() string            { return proto.CompactTextString(m) }
+func (*GenSeedResponse) ProtoMessage()               {}
+func (*GenSeedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *GenSeedResponse) GetCipherSeedMnemonic() []string {
+	if m != nil {
+		return m.CipherSeedMnemonic
+	}
+	return nil
+}
+
+func (m *GenSeedResponse) GetEncipheredSeed() []byte {
+	if m != nil {
+		return m.EncipheredSeed
+	}
+	return nil
 }
 
 type CreateWalletRequest struct {
 	Password []byte `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// *
+	// An optional 24 word cipher seed mnemonic, obtained either from GenSeed,
+	// or from a prior instance of lnd when restoring a wallet.
+	CipherSeedMnemonic []string `protobuf:"bytes,2,rep,name=cipher_seed_mnemonic" json:"cipher_seed_mnemonic,omitempty"`
+	// / The optional passphrase that the cipher seed mnemonic is encrypted under.
+	CipherSeedPassphrase []byte `protobuf:"bytes,3,opt,name=cipher_seed_passphrase,proto3" json:"cipher_seed_passphrase,omitempty"`
 }
 
 func (m *CreateWalletRequest) Reset()                    { *m = CreateWalletRequest{} }
 func (m *CreateWalletRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateWalletRequest) ProtoMessage()               {}
-func (*CreateWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*CreateWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *CreateWalletRequest) GetPassword() []byte {
 	if m != nil {
@@ -169,13 +422,27 @@ func (m *CreateWalletRequest) GetPassword() []byte {
 	return nil
 }
 
+func (m *CreateWalletRequest) GetCipherSeedMnemonic() []string {
+	if m != nil {
+		return m.CipherSeedMnemonic
+	}
+	return nil
+}
+
+func (m *CreateWalletRequest) GetCipherSeedPassphrase() []byte {
+	if m != nil {
+		return m.CipherSeedPassphrase
+	}
+	return nil
+}
+
 type CreateWalletResponse struct {
 }
 
 func (m *CreateWalletResponse) Reset()                    { *m = CreateWalletResponse{} }
 func (m *CreateWalletResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateWalletResponse) ProtoMessage()               {}
-func (*CreateWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*CreateWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 type UnlockWalletRequest struct {
 	Password []byte `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
@@ -184,7 +451,7 @@ type UnlockWalletRequest struct {
 func (m *UnlockWalletRequest) Reset()                    { *m = UnlockWalletRequest{} }
 func (m *UnlockWalletRequest) String() string            { return proto.CompactTextString(m) }
 func (*UnlockWalletRequest) ProtoMessage()               {}
-func (*UnlockWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*UnlockWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *UnlockWalletRequest) GetPassword() []byte {
 	if m != nil {
@@ -199,384 +466,1276 @@ type UnlockWalletResponse struct {
 func (m *UnlockWalletResponse) Reset()                    { *m = UnlockWalletResponse{} }
 func (m *UnlockWalletResponse) String() string            { return proto.CompactTextString(m) }
 func (*UnlockWalletResponse) ProtoMessage()               {}
-func (*UnlockWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*UnlockWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-type Transaction struct {
-	// / The transaction hash
-	TxHash string `protobuf:"bytes,1,opt,name=tx_hash" json:"tx_hash,omitempty"`
-	// / The transaction ammount, denominated in satoshis
-	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
-	// / The number of confirmations
-	NumConfirmations int32 `protobuf:"varint,3,opt,name=num_confirmations" json:"num_confirmations,omitempty"`
-	// / The hash of the block this transaction was included in
-	BlockHash string `protobuf:"bytes,4,opt,name=block_hash" json:"block_hash,omitempty"`
-	// / The height of the block this transaction was included in
-	BlockHeight int32 `protobuf:"varint,5,opt,name=block_height" json:"block_height,omitempty"`
-	// / Timestamp of this transaction
-	TimeStamp int64 `protobuf:"varint,6,opt,name=time_stamp" json:"time_stamp,omitempty"`
-	// / Fees paid for this transaction
-	TotalFees int64 `protobuf:"varint,7,opt,name=total_fees" json:"total_fees,omitempty"`
-	// / Addresses that received funds for this transaction
-	DestAddresses []string `protobuf:"bytes,8,rep,name=dest_addresses" json:"dest_addresses,omitempty"`
+type DeriveNextKeyRequest struct {
+	// / The family of the key to derive.
+	KeyFamily uint32 `protobuf:"varint,1,opt,name=key_family" json:"key_family,omitempty"`
 }
 
-func (m *Transaction) Reset()                    { *m = Transaction{} }
-func (m *Transaction) String() string            { return proto.CompactTextString(m) }
-func (*Transaction) ProtoMessage()               {}
-func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
-
-func (m *Transaction) GetTxHash() string {
-	if m != nil {
-		return m.TxHash
-	}
-	return ""
-}
+func (m *DeriveNextKeyRequest) Reset()                    { *m = DeriveNextKeyRequest{} }
+func (m *DeriveNextKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveNextKeyRequest) ProtoMessage()               {}
+func (*DeriveNextKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-func (m *Transaction) GetAmount() int64 {
+func (m *DeriveNextKeyRequest) GetKeyFamily() uint32 {
 	if m != nil {
-		return m.Amount
+		return m.KeyFamily
 	}
 	return 0
 }
 
-func (m *Transaction) GetNumConfirmations() int32 {
-	if m != nil {
-		return m.NumConfirmations
-	}
-	return 0
+type DeriveNextKeyResponse struct {
+	// / The compressed public key of the derived key.
+	RawKeyBytes []byte `protobuf:"bytes,1,opt,name=raw_key_bytes,proto3" json:"raw_key_bytes,omitempty"`
+	// / The family of the derived key.
+	KeyFamily uint32 `protobuf:"varint,2,opt,name=key_family" json:"key_family,omitempty"`
+	// / The index of the derived key within its family.
+	KeyIndex uint32 `protobuf:"varint,3,opt,name=key_index" json:"key_index,omitempty"`
 }
 
-func (m *Transaction) GetBlockHash() string {
+func (m *DeriveNextKeyResponse) Reset()                    { *m = DeriveNextKeyResponse{} }
+func (m *DeriveNextKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeriveNextKeyResponse) ProtoMessage()               {}
+func (*DeriveNextKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DeriveNextKeyResponse) GetRawKeyBytes() []byte {
 	if m != nil {
-		return m.BlockHash
+		return m.RawKeyBytes
 	}
-	return ""
+	return nil
 }
 
-func (m *Transaction) GetBlockHeight() int32 {
+func (m *DeriveNextKeyResponse) GetKeyFamily() uint32 {
 	if m != nil {
-		return m.BlockHeight
+		return m.KeyFamily
 	}
 	return 0
 }
 
-func (m *Transaction) GetTimeStamp() int64 {
+func (m *DeriveNextKeyResponse) GetKeyIndex() uint32 {
 	if m != nil {
-		return m.TimeStamp
+		return m.KeyIndex
 	}
 	return 0
 }
 
-func (m *Transaction) GetTotalFees() int64 {
+type SignOutputRawRequest struct {
+	// / The serialized transaction to sign an input of.
+	RawTxBytes []byte `protobuf:"bytes,1,opt,name=raw_tx_bytes,proto3" json:"raw_tx_bytes,omitempty"`
+	// / The serialized sign descriptor of the input.
+	SignDesc []byte `protobuf:"bytes,2,opt,name=sign_desc,proto3" json:"sign_desc,omitempty"`
+	// / The index of the input to sign.
+	InputIndex int32 `protobuf:"varint,3,opt,name=input_index" json:"input_index,omitempty"`
+}
+
+func (m *SignOutputRawRequest) Reset()                    { *m = SignOutputRawRequest{} }
+func (m *SignOutputRawRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignOutputRawRequest) ProtoMessage()               {}
+func (*SignOutputRawRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *SignOutputRawRequest) GetRawTxBytes() []byte {
 	if m != nil {
-		return m.TotalFees
+		return m.RawTxBytes
 	}
-	return 0
+	return nil
 }
 
-func (m *Transaction) GetDestAddresses() []string {
+func (m *SignOutputRawRequest) GetSignDesc() []byte {
 	if m != nil {
-		return m.DestAddresses
+		return m.SignDesc
 	}
 	return nil
 }
 
-type GetTransactionsRequest struct {
+func (m *SignOutputRawRequest) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
 }
 
-func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
-func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionsRequest) ProtoMessage()               {}
-func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
-
-type TransactionDetails struct {
-	// / The list of transactions relevant to the wallet.
-	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+type SignOutputRawResponse struct {
+	// / The DER encoded signature, without a sighash flag.
+	Sig []byte `protobuf:"bytes,1,opt,name=sig,proto3" json:"sig,omitempty"`
 }
 
-func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
-func (m *TransactionDetails) String() string            { return proto.CompactTextString(m) }
-func (*TransactionDetails) ProtoMessage()               {}
-func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *SignOutputRawResponse) Reset()                    { *m = SignOutputRawResponse{} }
+func (m *SignOutputRawResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignOutputRawResponse) ProtoMessage()               {}
+func (*SignOutputRawResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-func (m *TransactionDetails) GetTransactions() []*Transaction {
+func (m *SignOutputRawResponse) GetSig() []byte {
 	if m != nil {
-		return m.Transactions
+		return m.Sig
 	}
 	return nil
 }
 
-type SendRequest struct {
-	// / The identity pubkey of the payment recipient
-	Dest []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
-	// / The hex-encoded identity pubkey of the payment recipient
-	DestString string `protobuf:"bytes,2,opt,name=dest_string,json=destString" json:"dest_string,omitempty"`
-	// / Number of satoshis to send.
-	Amt int64 `protobuf:"varint,3,opt,name=amt" json:"amt,omitempty"`
-	// / The hash to use within the payment's HTLC
-	PaymentHash []byte `protobuf:"bytes,4,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
-	// / The hex-encoded hash to use within the payment's HTLC
-	PaymentHashString string `protobuf:"bytes,5,opt,name=payment_hash_string,json=paymentHashString" json:"payment_hash_string,omitempty"`
-	// *
-	// A bare-bones invoice for a payment within the Lightning Network.  With the
-	// details of the invoice, the sender has all the data necessary to send a
-	// payment to the recipient.
-	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
+type ComputeInputScriptRequest struct {
+	// / The serialized transaction to sign an input of.
+	RawTxBytes []byte `protobuf:"bytes,1,opt,name=raw_tx_bytes,proto3" json:"raw_tx_bytes,omitempty"`
+	// / The serialized sign descriptor of the input.
+	SignDesc []byte `protobuf:"bytes,2,opt,name=sign_desc,proto3" json:"sign_desc,omitempty"`
+	// / The index of the input to sign.
+	InputIndex int32 `protobuf:"varint,3,opt,name=input_index" json:"input_index,omitempty"`
 }
 
-func (m *SendRequest) Reset()                    { *m = SendRequest{} }
-func (m *SendRequest) String() string            { return proto.CompactTextString(m) }
-func (*SendRequest) ProtoMessage()               {}
-func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (m *ComputeInputScriptRequest) Reset()                    { *m = ComputeInputScriptRequest{} }
+func (m *ComputeInputScriptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ComputeInputScriptRequest) ProtoMessage()               {}
+func (*ComputeInputScriptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
-func (m *SendRequest) GetDest() []byte {
+func (m *ComputeInputScriptRequest) GetRawTxBytes() []byte {
 	if m != nil {
-		return m.Dest
+		return m.RawTxBytes
 	}
 	return nil
 }
 
-func (m *SendRequest) GetDestString() string {
+func (m *ComputeInputScriptRequest) GetSignDesc() []byte {
 	if m != nil {
-		return m.DestString
+		return m.SignDesc
 	}
-	return ""
+	return nil
 }
 
-func (m *SendRequest) GetAmt() int64 {
+func (m *ComputeInputScriptRequest) GetInputIndex() int32 {
 	if m != nil {
-		return m.Amt
+		return m.InputIndex
 	}
 	return 0
 }
 
-func (m *SendRequest) GetPaymentHash() []byte {
-	if m != nil {
-		return m.PaymentHash
-	}
-	return nil
+type ComputeInputScriptResponse struct {
+	// / The witness of the input.
+	Witness [][]byte `protobuf:"bytes,1,rep,name=witness,proto3" json:"witness,omitempty"`
+	// / The signature script of the input, only set for nested outputs.
+	SigScript []byte `protobuf:"bytes,2,opt,name=sig_script,proto3" json:"sig_script,omitempty"`
 }
 
-func (m *SendRequest) GetPaymentHashString() string {
+func (m *ComputeInputScriptResponse) Reset()                    { *m = ComputeInputScriptResponse{} }
+func (m *ComputeInputScriptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ComputeInputScriptResponse) ProtoMessage()               {}
+func (*ComputeInputScriptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ComputeInputScriptResponse) GetWitness() [][]byte {
 	if m != nil {
-		return m.PaymentHashString
+		return m.Witness
 	}
-	return ""
+	return nil
 }
 
-func (m *SendRequest) GetPaymentRequest() string {
+func (m *ComputeInputScriptResponse) GetSigScript() []byte {
 	if m != nil {
-		return m.PaymentRequest
+		return m.SigScript
 	}
-	return ""
+	return nil
 }
 
-type SendResponse struct {
-	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
-	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
-	PaymentRoute    *Route `protobuf:"bytes,3,opt,name=payment_route" json:"payment_route,omitempty"`
+type SignMessageWithKeyRequest struct {
+	// / The compressed public key of the key to sign with.
+	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,proto3" json:"pub_key,omitempty"`
+	// / The message to sign.
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
 }
 
-func (m *SendResponse) Reset()                    { *m = SendResponse{} }
-func (m *SendResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendResponse) ProtoMessage()               {}
-func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *SignMessageWithKeyRequest) Reset()                    { *m = SignMessageWithKeyRequest{} }
+func (m *SignMessageWithKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageWithKeyRequest) ProtoMessage()               {}
+func (*SignMessageWithKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
-func (m *SendResponse) GetPaymentError() string {
+func (m *SignMessageWithKeyRequest) GetPubKey() []byte {
 	if m != nil {
-		return m.PaymentError
+		return m.PubKey
 	}
-	return ""
+	return nil
 }
 
-func (m *SendResponse) GetPaymentPreimage() []byte {
+func (m *SignMessageWithKeyRequest) GetMsg() []byte {
 	if m != nil {
-		return m.PaymentPreimage
+		return m.Msg
 	}
 	return nil
 }
 
-func (m *SendResponse) GetPaymentRoute() *Route {
+type SignMessageWithKeyResponse struct {
+	// / The DER encoded signature of the message.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignMessageWithKeyResponse) Reset()                    { *m = SignMessageWithKeyResponse{} }
+func (m *SignMessageWithKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageWithKeyResponse) ProtoMessage()               {}
+func (*SignMessageWithKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *SignMessageWithKeyResponse) GetSignature() []byte {
 	if m != nil {
-		return m.PaymentRoute
+		return m.Signature
 	}
 	return nil
 }
 
-type ChannelPoint struct {
-	// / Txid of the funding transaction
-	FundingTxid []byte `protobuf:"bytes,1,opt,name=funding_txid,proto3" json:"funding_txid,omitempty"`
-	// / Hex-encoded string representing the funding transaction
-	FundingTxidStr string `protobuf:"bytes,2,opt,name=funding_txid_str" json:"funding_txid_str,omitempty"`
-	// / The index of the output of the funding transaction
-	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index" json:"output_index,omitempty"`
+type SignDigestCompactRequest struct {
+	// / The 32 byte digest to sign.
+	Digest []byte `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
-func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
-func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
-func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *SignDigestCompactRequest) Reset()                    { *m = SignDigestCompactRequest{} }
+func (m *SignDigestCompactRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignDigestCompactRequest) ProtoMessage()               {}
+func (*SignDigestCompactRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
-func (m *ChannelPoint) GetFundingTxid() []byte {
+func (m *SignDigestCompactRequest) GetDigest() []byte {
 	if m != nil {
-		return m.FundingTxid
+		return m.Digest
 	}
 	return nil
 }
 
-func (m *ChannelPoint) GetFundingTxidStr() string {
-	if m != nil {
-		return m.FundingTxidStr
-	}
-	return ""
+type SignDigestCompactResponse struct {
+	// / The compact signature of the digest.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *ChannelPoint) GetOutputIndex() uint32 {
+func (m *SignDigestCompactResponse) Reset()                    { *m = SignDigestCompactResponse{} }
+func (m *SignDigestCompactResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignDigestCompactResponse) ProtoMessage()               {}
+func (*SignDigestCompactResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SignDigestCompactResponse) GetSignature() []byte {
 	if m != nil {
-		return m.OutputIndex
+		return m.Signature
 	}
-	return 0
+	return nil
 }
 
-type LightningAddress struct {
-	// / The identity pubkey of the Lightning node
-	Pubkey string `protobuf:"bytes,1,opt,name=pubkey" json:"pubkey,omitempty"`
-	// / The network location of the lightning node, e.g. `69.69.69.69:1337` or `localhost:10011`
-	Host string `protobuf:"bytes,2,opt,name=host" json:"host,omitempty"`
+type DeriveRevocationRootRequest struct {
+	// / The hash of the block the channel funding began at.
+	BlockHash []byte `protobuf:"bytes,1,opt,name=block_hash,proto3" json:"block_hash,omitempty"`
+	// / The compressed identity public key of the channel peer.
+	NodePubKey []byte `protobuf:"bytes,2,opt,name=node_pub_key,proto3" json:"node_pub_key,omitempty"`
 }
 
-func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
-func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
-func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *DeriveRevocationRootRequest) Reset()                    { *m = DeriveRevocationRootRequest{} }
+func (m *DeriveRevocationRootRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveRevocationRootRequest) ProtoMessage()               {}
+func (*DeriveRevocationRootRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
-func (m *LightningAddress) GetPubkey() string {
+func (m *DeriveRevocationRootRequest) GetBlockHash() []byte {
 	if m != nil {
-		return m.Pubkey
+		return m.BlockHash
 	}
-	return ""
+	return nil
 }
 
-func (m *LightningAddress) GetHost() string {
+func (m *DeriveRevocationRootRequest) GetNodePubKey() []byte {
 	if m != nil {
-		return m.Host
+		return m.NodePubKey
 	}
-	return ""
+	return nil
 }
 
-type SendManyRequest struct {
-	// / The map from addresses to amounts
-	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	// / The target number of blocks that this transaction should be confirmed by.
-	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
-	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
+type DeriveRevocationRootResponse struct {
+	// / The root of the revocation tree of the channel.
+	RevocationRoot []byte `protobuf:"bytes,1,opt,name=revocation_root,proto3" json:"revocation_root,omitempty"`
+}
+
+func (m *DeriveRevocationRootResponse) Reset()                    { *m = DeriveRevocationRootResponse{} }
+func (m *DeriveRevocationRootResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeriveRevocationRootResponse) ProtoMessage()               {}
+func (*DeriveRevocationRootResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *DeriveRevocationRootResponse) GetRevocationRoot() []byte {
+	if m != nil {
+		return m.RevocationRoot
+	}
+	return nil
+}
+
+type IdentityPubKeyRequest struct {
+}
+
+func (m *IdentityPubKeyRequest) Reset()                    { *m = IdentityPubKeyRequest{} }
+func (m *IdentityPubKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*IdentityPubKeyRequest) ProtoMessage()               {}
+func (*IdentityPubKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type IdentityPubKeyResponse struct {
+	// / The compressed identity public key.
+	IdentityPubkey []byte `protobuf:"bytes,1,opt,name=identity_pubkey,proto3" json:"identity_pubkey,omitempty"`
+}
+
+func (m *IdentityPubKeyResponse) Reset()                    { *m = IdentityPubKeyResponse{} }
+func (m *IdentityPubKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*IdentityPubKeyResponse) ProtoMessage()               {}
+func (*IdentityPubKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *IdentityPubKeyResponse) GetIdentityPubkey() []byte {
+	if m != nil {
+		return m.IdentityPubkey
+	}
+	return nil
+}
+
+type DeriveSharedKeyRequest struct {
+	// / The compressed public key to perform the ECDH operation with.
+	EphemeralPubkey []byte `protobuf:"bytes,1,opt,name=ephemeral_pubkey,proto3" json:"ephemeral_pubkey,omitempty"`
+}
+
+func (m *DeriveSharedKeyRequest) Reset()                    { *m = DeriveSharedKeyRequest{} }
+func (m *DeriveSharedKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveSharedKeyRequest) ProtoMessage()               {}
+func (*DeriveSharedKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *DeriveSharedKeyRequest) GetEphemeralPubkey() []byte {
+	if m != nil {
+		return m.EphemeralPubkey
+	}
+	return nil
+}
+
+type DeriveSharedKeyResponse struct {
+	// / The SHA-256 of the compressed shared point.
+	SharedKey []byte `protobuf:"bytes,1,opt,name=shared_key,proto3" json:"shared_key,omitempty"`
+}
+
+func (m *DeriveSharedKeyResponse) Reset()                    { *m = DeriveSharedKeyResponse{} }
+func (m *DeriveSharedKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeriveSharedKeyResponse) ProtoMessage()               {}
+func (*DeriveSharedKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *DeriveSharedKeyResponse) GetSharedKey() []byte {
+	if m != nil {
+		return m.SharedKey
+	}
+	return nil
+}
+
+type ProcessOnionPacketRequest struct {
+	// / The serialized onion packet.
+	OnionPacket []byte `protobuf:"bytes,1,opt,name=onion_packet,proto3" json:"onion_packet,omitempty"`
+	// / The associated data that the onion packet commits to.
+	AssocData []byte `protobuf:"bytes,2,opt,name=assoc_data,proto3" json:"assoc_data,omitempty"`
+}
+
+func (m *ProcessOnionPacketRequest) Reset()                    { *m = ProcessOnionPacketRequest{} }
+func (m *ProcessOnionPacketRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProcessOnionPacketRequest) ProtoMessage()               {}
+func (*ProcessOnionPacketRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ProcessOnionPacketRequest) GetOnionPacket() []byte {
+	if m != nil {
+		return m.OnionPacket
+	}
+	return nil
+}
+
+func (m *ProcessOnionPacketRequest) GetAssocData() []byte {
+	if m != nil {
+		return m.AssocData
+	}
+	return nil
+}
+
+type ProcessOnionPacketResponse struct {
+	// / The reason the onion packet couldn't be processed, if any.
+	Failure ProcessOnionPacketResponse_OnionFailure `protobuf:"varint,1,opt,name=failure,enum=lnrpc.ProcessOnionPacketResponse_OnionFailure" json:"failure,omitempty"`
+	// / The action to take after processing the packet.
+	Action uint32 `protobuf:"varint,2,opt,name=action" json:"action,omitempty"`
+	// / The serialized hop data addressed to us.
+	HopData []byte `protobuf:"bytes,3,opt,name=hop_data,proto3" json:"hop_data,omitempty"`
+	// / The serialized onion packet to forward to the next hop.
+	NextPacket []byte `protobuf:"bytes,4,opt,name=next_packet,proto3" json:"next_packet,omitempty"`
+}
+
+func (m *ProcessOnionPacketResponse) Reset()                    { *m = ProcessOnionPacketResponse{} }
+func (m *ProcessOnionPacketResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProcessOnionPacketResponse) ProtoMessage()               {}
+func (*ProcessOnionPacketResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ProcessOnionPacketResponse) GetFailure() ProcessOnionPacketResponse_OnionFailure {
+	if m != nil {
+		return m.Failure
+	}
+	return ProcessOnionPacketResponse_NONE
+}
+
+func (m *ProcessOnionPacketResponse) GetAction() uint32 {
+	if m != nil {
+		return m.Action
+	}
+	return 0
+}
+
+func (m *ProcessOnionPacketResponse) GetHopData() []byte {
+	if m != nil {
+		return m.HopData
+	}
+	return nil
+}
+
+func (m *ProcessOnionPacketResponse) GetNextPacket() []byte {
+	if m != nil {
+		return m.NextPacket
+	}
+	return nil
+}
+
+type DeriveBackupKeyRequest struct {
+}
+
+func (m *DeriveBackupKeyRequest) Reset()                    { *m = DeriveBackupKeyRequest{} }
+func (m *DeriveBackupKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeriveBackupKeyRequest) ProtoMessage()               {}
+func (*DeriveBackupKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+type DeriveBackupKeyResponse struct {
+	// / The raw private key that channel backups are encrypted under.
+	BackupKey []byte `protobuf:"bytes,1,opt,name=backup_key,proto3" json:"backup_key,omitempty"`
+}
+
+func (m *DeriveBackupKeyResponse) Reset()                    { *m = DeriveBackupKeyResponse{} }
+func (m *DeriveBackupKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeriveBackupKeyResponse) ProtoMessage()               {}
+func (*DeriveBackupKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *DeriveBackupKeyResponse) GetBackupKey() []byte {
+	if m != nil {
+		return m.BackupKey
+	}
+	return nil
+}
+
+type Transaction struct {
+	// / The transaction hash
+	TxHash string `protobuf:"bytes,1,opt,name=tx_hash" json:"tx_hash,omitempty"`
+	// / The transaction ammount, denominated in satoshis
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// / The number of confirmations
+	NumConfirmations int32 `protobuf:"varint,3,opt,name=num_confirmations" json:"num_confirmations,omitempty"`
+	// / The hash of the block this transaction was included in
+	BlockHash string `protobuf:"bytes,4,opt,name=block_hash" json:"block_hash,omitempty"`
+	// / The height of the block this transaction was included in
+	BlockHeight int32 `protobuf:"varint,5,opt,name=block_height" json:"block_height,omitempty"`
+	// / Timestamp of this transaction
+	TimeStamp int64 `protobuf:"varint,6,opt,name=time_stamp" json:"time_stamp,omitempty"`
+	// / Fees paid for this transaction
+	TotalFees int64 `protobuf:"varint,7,opt,name=total_fees" json:"total_fees,omitempty"`
+	// / Addresses that received funds for this transaction
+	DestAddresses []string `protobuf:"bytes,8,rep,name=dest_addresses" json:"dest_addresses,omitempty"`
+}
+
+func (m *Transaction) Reset()                    { *m = Transaction{} }
+func (m *Transaction) String() string            { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()               {}
+func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *Transaction) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *Transaction) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Transaction) GetNumConfirmations() int32 {
+	if m != nil {
+		return m.NumConfirmations
+	}
+	return 0
+}
+
+func (m *Transaction) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *Transaction) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *Transaction) GetTimeStamp() int64 {
+	if m != nil {
+		return m.TimeStamp
+	}
+	return 0
+}
+
+func (m *Transaction) GetTotalFees() int64 {
+	if m != nil {
+		return m.TotalFees
+	}
+	return 0
+}
+
+func (m *Transaction) GetDestAddresses() []string {
+	if m != nil {
+		return m.DestAddresses
+	}
+	return nil
+}
+
+type GetTransactionsRequest struct {
+}
+
+func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
+func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetTransactionsRequest) ProtoMessage()               {}
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type TransactionDetails struct {
+	// / The list of transactions relevant to the wallet.
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+}
+
+func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
+func (m *TransactionDetails) String() string            { return proto.CompactTextString(m) }
+func (*TransactionDetails) ProtoMessage()               {}
+func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *TransactionDetails) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type FeeLimit struct {
+	// Types that are valid to be assigned to Limit:
+	//	*FeeLimit_Fixed
+	//	*FeeLimit_Percent
+	Limit isFeeLimit_Limit `protobuf_oneof:"limit"`
+}
+
+func (m *FeeLimit) Reset()                    { *m = FeeLimit{} }
+func (m *FeeLimit) String() string            { return proto.CompactTextString(m) }
+func (*FeeLimit) ProtoMessage()               {}
+func (*FeeLimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+type isFeeLimit_Limit interface {
+	isFeeLimit_Limit()
+}
+
+type FeeLimit_Fixed struct {
+	Fixed int64 `protobuf:"varint,1,opt,name=fixed,oneof"`
+}
+type FeeLimit_Percent struct {
+	Percent int64 `protobuf:"varint,2,opt,name=percent,oneof"`
+}
+
+func (*FeeLimit_Fixed) isFeeLimit_Limit()   {}
+func (*FeeLimit_Percent) isFeeLimit_Limit() {}
+
+func (m *FeeLimit) GetLimit() isFeeLimit_Limit {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *FeeLimit) GetFixed() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_Fixed); ok {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (m *FeeLimit) GetPercent() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_Percent); ok {
+		return x.Percent
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*FeeLimit) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _FeeLimit_OneofMarshaler, _FeeLimit_OneofUnmarshaler, _FeeLimit_OneofSizer, []interface{}{
+		(*FeeLimit_Fixed)(nil),
+		(*FeeLimit_Percent)(nil),
+	}
+}
+
+func _FeeLimit_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_Fixed:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Fixed))
+	case *FeeLimit_Percent:
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		return fmt.Errorf("FeeLimit.Limit has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _FeeLimit_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*FeeLimit)
+	switch tag {
+	case 1: // limit.fixed
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_Fixed{int64(x)}
+		return true, err
+	case 2: // limit.percent
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_Percent{int64(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _FeeLimit_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_Fixed:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Fixed))
+	case *FeeLimit_Percent:
+		n += proto.SizeVarint(2<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type SendRequest struct {
+	// / The identity pubkey of the payment recipient
+	Dest []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	// / The hex-encoded identity pubkey of the payment recipient
+	DestString string `protobuf:"bytes,2,opt,name=dest_string,json=destString" json:"dest_string,omitempty"`
+	// / Number of satoshis to send.
+	Amt int64 `protobuf:"varint,3,opt,name=amt" json:"amt,omitempty"`
+	// / The hash to use within the payment's HTLC
+	PaymentHash []byte `protobuf:"bytes,4,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// / The hex-encoded hash to use within the payment's HTLC
+	PaymentHashString string `protobuf:"bytes,5,opt,name=payment_hash_string,json=paymentHashString" json:"payment_hash_string,omitempty"`
+	// *
+	// A bare-bones invoice for a payment within the Lightning Network.  With the
+	// details of the invoice, the sender has all the data necessary to send a
+	// payment to the recipient.
+	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
+	// *
+	// The maximum total fee that may be paid to the nodes along the route. If
+	// unset, no fee limit is enforced.
+	FeeLimit *FeeLimit `protobuf:"bytes,7,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	// *
+	// The maximum total time lock of the route, relative to the current block
+	// height and including the final CLTV delta. If zero, no CLTV limit is
+	// enforced.
+	CltvLimit uint32 `protobuf:"varint,8,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// *
+	// If set, a spontaneous payment is made which doesn't require an invoice. A
+	// random preimage is generated and carried to the destination within the
+	// onion, so any payment hash specified is ignored, and a payment request
+	// can't be used. The destination must accept spontaneous payments.
+	KeySend bool `protobuf:"varint,9,opt,name=key_send,json=keySend" json:"key_send,omitempty"`
+}
+
+func (m *SendRequest) Reset()                    { *m = SendRequest{} }
+func (m *SendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()               {}
+func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *SendRequest) GetDest() []byte {
+	if m != nil {
+		return m.Dest
+	}
+	return nil
+}
+
+func (m *SendRequest) GetDestString() string {
+	if m != nil {
+		return m.DestString
+	}
+	return ""
+}
+
+func (m *SendRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *SendRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendRequest) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *SendRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *SendRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetKeySend() bool {
+	if m != nil {
+		return m.KeySend
+	}
+	return false
+}
+
+type SendResponse struct {
+	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
+	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
+	PaymentRoute    *Route `protobuf:"bytes,3,opt,name=payment_route" json:"payment_route,omitempty"`
+	// *
+	// The routes the shards of the payment took, if the payment was split across
+	// multiple routes. In this case, payment_route is the route of the first
+	// shard.
+	ShardRoutes []*Route `protobuf:"bytes,4,rep,name=shard_routes" json:"shard_routes,omitempty"`
+}
+
+func (m *SendResponse) Reset()                    { *m = SendResponse{} }
+func (m *SendResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendResponse) ProtoMessage()               {}
+func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *SendResponse) GetPaymentError() string {
+	if m != nil {
+		return m.PaymentError
+	}
+	return ""
+}
+
+func (m *SendResponse) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
+}
+
+func (m *SendResponse) GetPaymentRoute() *Route {
+	if m != nil {
+		return m.PaymentRoute
+	}
+	return nil
+}
+
+func (m *SendResponse) GetShardRoutes() []*Route {
+	if m != nil {
+		return m.ShardRoutes
+	}
+	return nil
+}
+
+type ChannelPoint struct {
+	// / Txid of the funding transaction
+	FundingTxid []byte `protobuf:"bytes,1,opt,name=funding_txid,proto3" json:"funding_txid,omitempty"`
+	// / Hex-encoded string representing the funding transaction
+	FundingTxidStr string `protobuf:"bytes,2,opt,name=funding_txid_str" json:"funding_txid_str,omitempty"`
+	// / The index of the output of the funding transaction
+	OutputIndex uint32 `protobuf:"varint,3,opt,name=output_index" json:"output_index,omitempty"`
+}
+
+func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
+func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
+func (*ChannelPoint) ProtoMessage()               {}
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *ChannelPoint) GetFundingTxid() []byte {
+	if m != nil {
+		return m.FundingTxid
+	}
+	return nil
+}
+
+func (m *ChannelPoint) GetFundingTxidStr() string {
+	if m != nil {
+		return m.FundingTxidStr
+	}
+	return ""
+}
+
+func (m *ChannelPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type LightningAddress struct {
+	// / The identity pubkey of the Lightning node
+	Pubkey string `protobuf:"bytes,1,opt,name=pubkey" json:"pubkey,omitempty"`
+	// / The network location of the lightning node, e.g. `69.69.69.69:1337` or `localhost:10011`
+	Host string `protobuf:"bytes,2,opt,name=host" json:"host,omitempty"`
+}
+
+func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
+func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
+func (*LightningAddress) ProtoMessage()               {}
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *LightningAddress) GetPubkey() string {
+	if m != nil {
+		return m.Pubkey
+	}
+	return ""
+}
+
+func (m *LightningAddress) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+type SendManyRequest struct {
+	// / The map from addresses to amounts
+	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// / The target number of blocks that this transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
 	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// / The outpoints of the wallet outputs to spend, in the form txid:index. If set, then exactly these outputs are spent.
+	Outpoints []string `protobuf:"bytes,6,rep,name=outpoints" json:"outpoints,omitempty"`
 }
 
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
 func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	if m != nil {
 		return m.AddrToAmount
 	}
-	return nil
+	return nil
+}
+
+func (m *SendManyRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetOutpoints() []string {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+type SendManyResponse struct {
+	// / The id of the transaction
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
+func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendManyResponse) ProtoMessage()               {}
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SendManyResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type SendCoinsRequest struct {
+	// / The address to send coins to
+	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	// / The amount in satoshis to send
+	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	// / The target number of blocks that this transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
+	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// / The outpoints of the wallet outputs to spend, in the form txid:index. If set, then exactly these outputs are spent.
+	Outpoints []string `protobuf:"bytes,6,rep,name=outpoints" json:"outpoints,omitempty"`
+}
+
+func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
+func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendCoinsRequest) ProtoMessage()               {}
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *SendCoinsRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *SendCoinsRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *SendCoinsRequest) GetOutpoints() []string {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+type SendCoinsResponse struct {
+	// / The transaction ID of the transaction
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
+func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendCoinsResponse) ProtoMessage()               {}
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SendCoinsResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type BumpFeeRequest struct {
+	// / The outpoint spent by the child transaction, in the form txid:index
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The target number of blocks that the transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
+	// / A manual fee rate set in sat/byte that the transaction and its child should pay.
+	SatPerByte int64 `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeRequest) Reset()                    { *m = BumpFeeRequest{} }
+func (m *BumpFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeRequest) ProtoMessage()               {}
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *BumpFeeRequest) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *BumpFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+func (m *BumpFeeRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BumpFeeResponse struct {
+	// / The transaction ID of the child transaction
+	ChildTxid string `protobuf:"bytes,1,opt,name=child_txid" json:"child_txid,omitempty"`
+	// / The fee rate in sat/byte of the transaction and its child combined
+	PackageSatPerByte int64 `protobuf:"varint,2,opt,name=package_sat_per_byte" json:"package_sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeResponse) Reset()                    { *m = BumpFeeResponse{} }
+func (m *BumpFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeResponse) ProtoMessage()               {}
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *BumpFeeResponse) GetChildTxid() string {
+	if m != nil {
+		return m.ChildTxid
+	}
+	return ""
+}
+
+func (m *BumpFeeResponse) GetPackageSatPerByte() int64 {
+	if m != nil {
+		return m.PackageSatPerByte
+	}
+	return 0
+}
+
+type Utxo struct {
+	// / The outpoint of the output, in the form txid:index
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The address the output pays to
+	Address string `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	// / The value of the output in satoshis
+	AmountSat int64 `protobuf:"varint,3,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	// / The hex-encoded pkScript of the output
+	PkScript string `protobuf:"bytes,4,opt,name=pk_script" json:"pk_script,omitempty"`
+	// / The number of confirmations of the output
+	Confirmations int64 `protobuf:"varint,5,opt,name=confirmations" json:"confirmations,omitempty"`
+}
+
+func (m *Utxo) Reset()                    { *m = Utxo{} }
+func (m *Utxo) String() string            { return proto.CompactTextString(m) }
+func (*Utxo) ProtoMessage()               {}
+func (*Utxo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *Utxo) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *Utxo) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Utxo) GetAmountSat() int64 {
+	if m != nil {
+		return m.AmountSat
+	}
+	return 0
+}
+
+func (m *Utxo) GetPkScript() string {
+	if m != nil {
+		return m.PkScript
+	}
+	return ""
+}
+
+func (m *Utxo) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
 }
 
-func (m *SendManyRequest) GetTargetConf() int32 {
+type ListUnspentRequest struct {
+	// / The minimum number of confirmations of the returned outputs
+	MinConfs int32 `protobuf:"varint,1,opt,name=min_confs,json=minConfs" json:"min_confs,omitempty"`
+	// / The maximum number of confirmations of the returned outputs, or zero for no maximum
+	MaxConfs int32 `protobuf:"varint,2,opt,name=max_confs,json=maxConfs" json:"max_confs,omitempty"`
+}
+
+func (m *ListUnspentRequest) Reset()                    { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()               {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ListUnspentRequest) GetMinConfs() int32 {
 	if m != nil {
-		return m.TargetConf
+		return m.MinConfs
 	}
 	return 0
 }
 
-func (m *SendManyRequest) GetSatPerByte() int64 {
+func (m *ListUnspentRequest) GetMaxConfs() int32 {
 	if m != nil {
-		return m.SatPerByte
+		return m.MaxConfs
 	}
 	return 0
 }
 
-type SendManyResponse struct {
-	// / The id of the transaction
-	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+type ListUnspentResponse struct {
+	// / The unspent outputs of the wallet
+	Utxos []*Utxo `protobuf:"bytes,1,rep,name=utxos" json:"utxos,omitempty"`
 }
 
-func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
-func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *ListUnspentResponse) Reset()                    { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()               {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
-func (m *SendManyResponse) GetTxid() string {
+func (m *ListUnspentResponse) GetUtxos() []*Utxo {
 	if m != nil {
-		return m.Txid
+		return m.Utxos
 	}
-	return ""
+	return nil
 }
 
-type SendCoinsRequest struct {
-	// / The address to send coins to
-	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
-	// / The amount in satoshis to send
-	Amount int64 `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
-	// / The target number of blocks that this transaction should be confirmed by.
-	TargetConf int32 `protobuf:"varint,3,opt,name=target_conf,json=targetConf" json:"target_conf,omitempty"`
-	// / A manual fee rate set in sat/byte that should be used when crafting the transaction.
-	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+type LeaseOutputRequest struct {
+	// / The outpoint of the output to lease, in the form txid:index
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The duration of the lease in seconds. If zero, then the default duration of 10 minutes is used.
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds" json:"duration_seconds,omitempty"`
 }
 
-func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
-func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
-func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *LeaseOutputRequest) Reset()                    { *m = LeaseOutputRequest{} }
+func (m *LeaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputRequest) ProtoMessage()               {}
+func (*LeaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
-func (m *SendCoinsRequest) GetAddr() string {
+func (m *LeaseOutputRequest) GetOutpoint() string {
 	if m != nil {
-		return m.Addr
+		return m.Outpoint
 	}
 	return ""
 }
 
-func (m *SendCoinsRequest) GetAmount() int64 {
+func (m *LeaseOutputRequest) GetDurationSeconds() int64 {
 	if m != nil {
-		return m.Amount
+		return m.DurationSeconds
 	}
 	return 0
 }
 
-func (m *SendCoinsRequest) GetTargetConf() int32 {
+type LeaseOutputResponse struct {
+	// / The unix timestamp at which the lease expires
+	Expiration int64 `protobuf:"varint,1,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *LeaseOutputResponse) Reset()                    { *m = LeaseOutputResponse{} }
+func (m *LeaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputResponse) ProtoMessage()               {}
+func (*LeaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *LeaseOutputResponse) GetExpiration() int64 {
 	if m != nil {
-		return m.TargetConf
+		return m.Expiration
 	}
 	return 0
 }
 
-func (m *SendCoinsRequest) GetSatPerByte() int64 {
+type ReleaseOutputRequest struct {
+	// / The outpoint of the leased output, in the form txid:index
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+}
+
+func (m *ReleaseOutputRequest) Reset()                    { *m = ReleaseOutputRequest{} }
+func (m *ReleaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputRequest) ProtoMessage()               {}
+func (*ReleaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ReleaseOutputRequest) GetOutpoint() string {
 	if m != nil {
-		return m.SatPerByte
+		return m.Outpoint
 	}
-	return 0
+	return ""
 }
 
-type SendCoinsResponse struct {
-	// / The transaction ID of the transaction
-	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+type ReleaseOutputResponse struct {
 }
 
-func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
-func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *ReleaseOutputResponse) Reset()                    { *m = ReleaseOutputResponse{} }
+func (m *ReleaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputResponse) ProtoMessage()               {}
+func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
-func (m *SendCoinsResponse) GetTxid() string {
+type OutputLease struct {
+	// / The outpoint of the leased output, in the form txid:index
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The unix timestamp at which the lease expires
+	Expiration int64 `protobuf:"varint,2,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *OutputLease) Reset()                    { *m = OutputLease{} }
+func (m *OutputLease) String() string            { return proto.CompactTextString(m) }
+func (*OutputLease) ProtoMessage()               {}
+func (*OutputLease) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *OutputLease) GetOutpoint() string {
 	if m != nil {
-		return m.Txid
+		return m.Outpoint
 	}
 	return ""
 }
 
+func (m *OutputLease) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+type ListLeasesRequest struct {
+}
+
+func (m *ListLeasesRequest) Reset()                    { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()               {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+type ListLeasesResponse struct {
+	// / The currently leased outputs
+	Leases []*OutputLease `protobuf:"bytes,1,rep,name=leases" json:"leases,omitempty"`
+}
+
+func (m *ListLeasesResponse) Reset()                    { *m = ListLeasesResponse{} }
+func (m *ListLeasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesResponse) ProtoMessage()               {}
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *ListLeasesResponse) GetLeases() []*OutputLease {
+	if m != nil {
+		return m.Leases
+	}
+	return nil
+}
+
 // *
 // `AddressType` has to be one of:
 //
@@ -591,7 +1750,7 @@ type NewAddressRequest struct {
 func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
 	if m != nil {
@@ -606,7 +1765,7 @@ type NewWitnessAddressRequest struct {
 func (m *NewWitnessAddressRequest) Reset()                    { *m = NewWitnessAddressRequest{} }
 func (m *NewWitnessAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewWitnessAddressRequest) ProtoMessage()               {}
-func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 type NewAddressResponse struct {
 	// / The newly generated wallet address
@@ -616,7 +1775,7 @@ type NewAddressResponse struct {
 func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
@@ -633,7 +1792,7 @@ type SignMessageRequest struct {
 func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
 func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageRequest) ProtoMessage()               {}
-func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *SignMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -650,7 +1809,7 @@ type SignMessageResponse struct {
 func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
 func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageResponse) ProtoMessage()               {}
-func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 func (m *SignMessageResponse) GetSignature() string {
 	if m != nil {
@@ -669,7 +1828,7 @@ type VerifyMessageRequest struct {
 func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
 func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageRequest) ProtoMessage()               {}
-func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *VerifyMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -695,7 +1854,7 @@ type VerifyMessageResponse struct {
 func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
 func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageResponse) ProtoMessage()               {}
-func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *VerifyMessageResponse) GetValid() bool {
 	if m != nil {
@@ -722,7 +1881,7 @@ type ConnectPeerRequest struct {
 func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
 func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
 	if m != nil {
@@ -746,7 +1905,7 @@ type ConnectPeerResponse struct {
 func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
 func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *ConnectPeerResponse) GetPeerId() int32 {
 	if m != nil {
@@ -763,7 +1922,7 @@ type DisconnectPeerRequest struct {
 func (m *DisconnectPeerRequest) Reset()                    { *m = DisconnectPeerRequest{} }
 func (m *DisconnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerRequest) ProtoMessage()               {}
-func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *DisconnectPeerRequest) GetPubKey() string {
 	if m != nil {
@@ -778,7 +1937,7 @@ type DisconnectPeerResponse struct {
 func (m *DisconnectPeerResponse) Reset()                    { *m = DisconnectPeerResponse{} }
 func (m *DisconnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerResponse) ProtoMessage()               {}
-func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 type HTLC struct {
 	Incoming         bool   `protobuf:"varint,1,opt,name=incoming" json:"incoming,omitempty"`
@@ -790,7 +1949,7 @@ type HTLC struct {
 func (m *HTLC) Reset()                    { *m = HTLC{} }
 func (m *HTLC) String() string            { return proto.CompactTextString(m) }
 func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
 func (m *HTLC) GetIncoming() bool {
 	if m != nil {
@@ -878,7 +2037,7 @@ type ActiveChannel struct {
 func (m *ActiveChannel) Reset()                    { *m = ActiveChannel{} }
 func (m *ActiveChannel) String() string            { return proto.CompactTextString(m) }
 func (*ActiveChannel) ProtoMessage()               {}
-func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
 
 func (m *ActiveChannel) GetActive() bool {
 	if m != nil {
@@ -998,7 +2157,7 @@ type ListChannelsRequest struct {
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
 func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsRequest) ProtoMessage()               {}
-func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
 
 type ListChannelsResponse struct {
 	// / The list of active channels
@@ -1008,7 +2167,7 @@ type ListChannelsResponse struct {
 func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
 func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsResponse) ProtoMessage()               {}
-func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
 
 func (m *ListChannelsResponse) GetChannels() []*ActiveChannel {
 	if m != nil {
@@ -1041,7 +2200,7 @@ type Peer struct {
 func (m *Peer) Reset()                    { *m = Peer{} }
 func (m *Peer) String() string            { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
 
 func (m *Peer) GetPubKey() string {
 	if m != nil {
@@ -1112,7 +2271,7 @@ type ListPeersRequest struct {
 func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
 func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
 
 type ListPeersResponse struct {
 	// / The list of currently connected peers
@@ -1122,7 +2281,7 @@ type ListPeersResponse struct {
 func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
 func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
 
 func (m *ListPeersResponse) GetPeers() []*Peer {
 	if m != nil {
@@ -1137,7 +2296,7 @@ type GetInfoRequest struct {
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
 
 type GetInfoResponse struct {
 	// / The identity pubkey of the current node.
@@ -1165,7 +2324,7 @@ type GetInfoResponse struct {
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -1246,7 +2405,7 @@ type ConfirmationUpdate struct {
 func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
 func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *ConfirmationUpdate) GetBlockSha() []byte {
 	if m != nil {
@@ -1276,7 +2435,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1293,7 +2452,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -1326,7 +2485,7 @@ type CloseChannelRequest struct {
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1367,7 +2526,7 @@ type CloseStatusUpdate struct {
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 type isCloseStatusUpdate_Update interface {
 	isCloseStatusUpdate_Update()
@@ -1516,7 +2675,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -1532,6 +2691,118 @@ func (m *PendingUpdate) GetOutputIndex() uint32 {
 	return 0
 }
 
+type ReadyForPsbtFunding struct {
+	// / The pending channel ID to pass to FundingPsbtFinalize
+	PendingChanId []byte `protobuf:"bytes,1,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// / The P2WSH address of the funding output
+	FundingAddress string `protobuf:"bytes,2,opt,name=funding_address" json:"funding_address,omitempty"`
+	// / The amount in satoshis that must be paid to the funding address
+	FundingAmount int64 `protobuf:"varint,3,opt,name=funding_amount" json:"funding_amount,omitempty"`
+	// / The serialized PSBT template that only pays to the funding output
+	Psbt []byte `protobuf:"bytes,4,opt,name=psbt,proto3" json:"psbt,omitempty"`
+}
+
+func (m *ReadyForPsbtFunding) Reset()                    { *m = ReadyForPsbtFunding{} }
+func (m *ReadyForPsbtFunding) String() string            { return proto.CompactTextString(m) }
+func (*ReadyForPsbtFunding) ProtoMessage()               {}
+func (*ReadyForPsbtFunding) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+func (m *ReadyForPsbtFunding) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
+	}
+	return nil
+}
+
+func (m *ReadyForPsbtFunding) GetFundingAddress() string {
+	if m != nil {
+		return m.FundingAddress
+	}
+	return ""
+}
+
+func (m *ReadyForPsbtFunding) GetFundingAmount() int64 {
+	if m != nil {
+		return m.FundingAmount
+	}
+	return 0
+}
+
+func (m *ReadyForPsbtFunding) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+type FundingPsbtFinalizeRequest struct {
+	// / The pending channel ID of the paused funding flow
+	PendingChanId []byte `protobuf:"bytes,1,opt,name=pending_chan_id,json=pendingChanId,proto3" json:"pending_chan_id,omitempty"`
+	// / The serialized PSBT that funds the channel, with all inputs finalized
+	SignedPsbt []byte `protobuf:"bytes,2,opt,name=signed_psbt,json=signedPsbt,proto3" json:"signed_psbt,omitempty"`
+}
+
+func (m *FundingPsbtFinalizeRequest) Reset()                    { *m = FundingPsbtFinalizeRequest{} }
+func (m *FundingPsbtFinalizeRequest) String() string            { return proto.CompactTextString(m) }
+func (*FundingPsbtFinalizeRequest) ProtoMessage()               {}
+func (*FundingPsbtFinalizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+func (m *FundingPsbtFinalizeRequest) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
+	}
+	return nil
+}
+
+func (m *FundingPsbtFinalizeRequest) GetSignedPsbt() []byte {
+	if m != nil {
+		return m.SignedPsbt
+	}
+	return nil
+}
+
+type FundingPsbtFinalizeResponse struct {
+	// / The transaction ID of the funding transaction
+	FundingTxid string `protobuf:"bytes,1,opt,name=funding_txid" json:"funding_txid,omitempty"`
+}
+
+func (m *FundingPsbtFinalizeResponse) Reset()                    { *m = FundingPsbtFinalizeResponse{} }
+func (m *FundingPsbtFinalizeResponse) String() string            { return proto.CompactTextString(m) }
+func (*FundingPsbtFinalizeResponse) ProtoMessage()               {}
+func (*FundingPsbtFinalizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+func (m *FundingPsbtFinalizeResponse) GetFundingTxid() string {
+	if m != nil {
+		return m.FundingTxid
+	}
+	return ""
+}
+
+type FundingPsbtCancelRequest struct {
+	// / The pending channel ID of the paused funding flow
+	PendingChanId []byte `protobuf:"bytes,1,opt,name=pending_chan_id,json=pendingChanId,proto3" json:"pending_chan_id,omitempty"`
+}
+
+func (m *FundingPsbtCancelRequest) Reset()                    { *m = FundingPsbtCancelRequest{} }
+func (m *FundingPsbtCancelRequest) String() string            { return proto.CompactTextString(m) }
+func (*FundingPsbtCancelRequest) ProtoMessage()               {}
+func (*FundingPsbtCancelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *FundingPsbtCancelRequest) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
+	}
+	return nil
+}
+
+type FundingPsbtCancelResponse struct {
+}
+
+func (m *FundingPsbtCancelResponse) Reset()                    { *m = FundingPsbtCancelResponse{} }
+func (m *FundingPsbtCancelResponse) String() string            { return proto.CompactTextString(m) }
+func (*FundingPsbtCancelResponse) ProtoMessage()               {}
+func (*FundingPsbtCancelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
 type OpenChannelRequest struct {
 	// / The peer_id of the node to open a channel with
 	TargetPeerId int32 `protobuf:"varint,1,opt,name=target_peer_id" json:"target_peer_id,omitempty"`
@@ -1549,12 +2820,16 @@ type OpenChannelRequest struct {
 	SatPerByte int64 `protobuf:"varint,7,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
 	// / Whether this channel should be private, not announced to the greater network.
 	Private bool `protobuf:"varint,8,opt,name=private" json:"private,omitempty"`
+	// / The outpoints of the wallet outputs that should fund the channel, in the form txid:index. If set, then exactly these outputs are spent.
+	Outpoints []string `protobuf:"bytes,9,rep,name=outpoints" json:"outpoints,omitempty"`
+	// / Whether the funding transaction is assembled by an external wallet from a PSBT, rather than funded by the internal wallet.
+	Psbt bool `protobuf:"varint,10,opt,name=psbt" json:"psbt,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 func (m *OpenChannelRequest) GetTargetPeerId() int32 {
 	if m != nil {
@@ -1612,18 +2887,33 @@ func (m *OpenChannelRequest) GetPrivate() bool {
 	return false
 }
 
+func (m *OpenChannelRequest) GetOutpoints() []string {
+	if m != nil {
+		return m.Outpoints
+	}
+	return nil
+}
+
+func (m *OpenChannelRequest) GetPsbt() bool {
+	if m != nil {
+		return m.Psbt
+	}
+	return false
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
 	//	*OpenStatusUpdate_Confirmation
 	//	*OpenStatusUpdate_ChanOpen
+	//	*OpenStatusUpdate_PsbtFund
 	Update isOpenStatusUpdate_Update `protobuf_oneof:"update"`
 }
 
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
 
 type isOpenStatusUpdate_Update interface {
 	isOpenStatusUpdate_Update()
@@ -1638,10 +2928,14 @@ type OpenStatusUpdate_Confirmation struct {
 type OpenStatusUpdate_ChanOpen struct {
 	ChanOpen *ChannelOpenUpdate `protobuf:"bytes,3,opt,name=chan_open,oneof"`
 }
+type OpenStatusUpdate_PsbtFund struct {
+	PsbtFund *ReadyForPsbtFunding `protobuf:"bytes,4,opt,name=psbt_fund,oneof"`
+}
 
 func (*OpenStatusUpdate_ChanPending) isOpenStatusUpdate_Update()  {}
 func (*OpenStatusUpdate_Confirmation) isOpenStatusUpdate_Update() {}
 func (*OpenStatusUpdate_ChanOpen) isOpenStatusUpdate_Update()     {}
+func (*OpenStatusUpdate_PsbtFund) isOpenStatusUpdate_Update()     {}
 
 func (m *OpenStatusUpdate) GetUpdate() isOpenStatusUpdate_Update {
 	if m != nil {
@@ -1671,6 +2965,13 @@ func (m *OpenStatusUpdate) GetChanOpen() *ChannelOpenUpdate {
 	return nil
 }
 
+func (m *OpenStatusUpdate) GetPsbtFund() *ReadyForPsbtFunding {
+	if x, ok := m.GetUpdate().(*OpenStatusUpdate_PsbtFund); ok {
+		return x.PsbtFund
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*OpenStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _OpenStatusUpdate_OneofMarshaler, _OpenStatusUpdate_OneofUnmarshaler, _OpenStatusUpdate_OneofSizer, []interface{}{
@@ -1785,7 +3086,7 @@ type PendingHTLC struct {
 func (m *PendingHTLC) Reset()                    { *m = PendingHTLC{} }
 func (m *PendingHTLC) String() string            { return proto.CompactTextString(m) }
 func (*PendingHTLC) ProtoMessage()               {}
-func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 func (m *PendingHTLC) GetIncoming() bool {
 	if m != nil {
@@ -1835,7 +3136,7 @@ type PendingChannelRequest struct {
 func (m *PendingChannelRequest) Reset()                    { *m = PendingChannelRequest{} }
 func (m *PendingChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelRequest) ProtoMessage()               {}
-func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 type PendingChannelResponse struct {
 	// / The balance in satoshis encumbered in pending channels
@@ -1851,7 +3152,7 @@ type PendingChannelResponse struct {
 func (m *PendingChannelResponse) Reset()                    { *m = PendingChannelResponse{} }
 func (m *PendingChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelResponse) ProtoMessage()               {}
-func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *PendingChannelResponse) GetTotalLimboBalance() int64 {
 	if m != nil {
@@ -1893,7 +3194,7 @@ func (m *PendingChannelResponse_PendingChannel) Reset()         { *m = PendingCh
 func (m *PendingChannelResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{45, 0}
+	return fileDescriptor0, []int{85, 0}
 }
 
 func (m *PendingChannelResponse_PendingChannel) GetRemoteNodePub() string {
@@ -1931,6 +3232,52 @@ func (m *PendingChannelResponse_PendingChannel) GetRemoteBalance() int64 {
 	return 0
 }
 
+type PendingChannelResponse_FeeBump struct {
+	// / The transaction ID of the child transaction
+	ChildTxid string `protobuf:"bytes,1,opt,name=child_txid" json:"child_txid,omitempty"`
+	// / The fee paid by the child transaction in satoshis
+	ChildFeeSat int64 `protobuf:"varint,2,opt,name=child_fee_sat" json:"child_fee_sat,omitempty"`
+	// / The fee rate in sat/byte of the transaction and its child combined
+	PackageSatPerByte int64 `protobuf:"varint,3,opt,name=package_sat_per_byte" json:"package_sat_per_byte,omitempty"`
+	// / Whether the child transaction has confirmed
+	Confirmed bool `protobuf:"varint,4,opt,name=confirmed" json:"confirmed,omitempty"`
+}
+
+func (m *PendingChannelResponse_FeeBump) Reset()         { *m = PendingChannelResponse_FeeBump{} }
+func (m *PendingChannelResponse_FeeBump) String() string { return proto.CompactTextString(m) }
+func (*PendingChannelResponse_FeeBump) ProtoMessage()    {}
+func (*PendingChannelResponse_FeeBump) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{85, 1}
+}
+
+func (m *PendingChannelResponse_FeeBump) GetChildTxid() string {
+	if m != nil {
+		return m.ChildTxid
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_FeeBump) GetChildFeeSat() int64 {
+	if m != nil {
+		return m.ChildFeeSat
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_FeeBump) GetPackageSatPerByte() int64 {
+	if m != nil {
+		return m.PackageSatPerByte
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_FeeBump) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
 type PendingChannelResponse_PendingOpenChannel struct {
 	// / The pending channel
 	Channel *PendingChannelResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
@@ -1952,6 +3299,8 @@ type PendingChannelResponse_PendingOpenChannel struct {
 	// pay at all times, for both the funding transaction and commitment
 	// transaction. This value can later be updated once the channel is open.
 	FeePerKw int64 `protobuf:"varint,6,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// / The fee bump of the funding transaction, if any
+	FeeBump *PendingChannelResponse_FeeBump `protobuf:"bytes,7,opt,name=fee_bump" json:"fee_bump,omitempty"`
 }
 
 func (m *PendingChannelResponse_PendingOpenChannel) Reset() {
@@ -1960,7 +3309,7 @@ func (m *PendingChannelResponse_PendingOpenChannel) Reset() {
 func (m *PendingChannelResponse_PendingOpenChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_PendingOpenChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_PendingOpenChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{45, 1}
+	return fileDescriptor0, []int{85, 2}
 }
 
 func (m *PendingChannelResponse_PendingOpenChannel) GetChannel() *PendingChannelResponse_PendingChannel {
@@ -2005,18 +3354,27 @@ func (m *PendingChannelResponse_PendingOpenChannel) GetFeePerKw() int64 {
 	return 0
 }
 
+func (m *PendingChannelResponse_PendingOpenChannel) GetFeeBump() *PendingChannelResponse_FeeBump {
+	if m != nil {
+		return m.FeeBump
+	}
+	return nil
+}
+
 type PendingChannelResponse_ClosedChannel struct {
 	// / The pending channel to be closed
 	Channel *PendingChannelResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
 	// / The transaction id of the closing transaction
 	ClosingTxid string `protobuf:"bytes,2,opt,name=closing_txid" json:"closing_txid,omitempty"`
+	// / The fee bump of the closing transaction, if any
+	FeeBump *PendingChannelResponse_FeeBump `protobuf:"bytes,3,opt,name=fee_bump" json:"fee_bump,omitempty"`
 }
 
 func (m *PendingChannelResponse_ClosedChannel) Reset()         { *m = PendingChannelResponse_ClosedChannel{} }
 func (m *PendingChannelResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_ClosedChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_ClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{45, 2}
+	return fileDescriptor0, []int{85, 3}
 }
 
 func (m *PendingChannelResponse_ClosedChannel) GetChannel() *PendingChannelResponse_PendingChannel {
@@ -2033,6 +3391,13 @@ func (m *PendingChannelResponse_ClosedChannel) GetClosingTxid() string {
 	return ""
 }
 
+func (m *PendingChannelResponse_ClosedChannel) GetFeeBump() *PendingChannelResponse_FeeBump {
+	if m != nil {
+		return m.FeeBump
+	}
+	return nil
+}
+
 type PendingChannelResponse_ForceClosedChannel struct {
 	// / The pending channel to be force closed
 	Channel *PendingChannelResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
@@ -2050,6 +3415,8 @@ type PendingChannelResponse_ForceClosedChannel struct {
 	// / The total value of funds successfully recovered from this channel
 	RecoveredBalance int64          `protobuf:"varint,6,opt,name=recovered_balance" json:"recovered_balance,omitempty"`
 	PendingHtlcs     []*PendingHTLC `protobuf:"bytes,8,rep,name=pending_htlcs" json:"pending_htlcs,omitempty"`
+	// / The fee bump of the closing transaction, if any
+	FeeBump *PendingChannelResponse_FeeBump `protobuf:"bytes,9,opt,name=fee_bump" json:"fee_bump,omitempty"`
 }
 
 func (m *PendingChannelResponse_ForceClosedChannel) Reset() {
@@ -2058,56 +3425,172 @@ func (m *PendingChannelResponse_ForceClosedChannel) Reset() {
 func (m *PendingChannelResponse_ForceClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelResponse_ForceClosedChannel) ProtoMessage()    {}
 func (*PendingChannelResponse_ForceClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{45, 3}
+	return fileDescriptor0, []int{85, 4}
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetChannel() *PendingChannelResponse_PendingChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetClosingTxid() string {
+	if m != nil {
+		return m.ClosingTxid
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetLimboBalance() int64 {
+	if m != nil {
+		return m.LimboBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetMaturityHeight() uint32 {
+	if m != nil {
+		return m.MaturityHeight
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetBlocksTilMaturity() int32 {
+	if m != nil {
+		return m.BlocksTilMaturity
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetRecoveredBalance() int64 {
+	if m != nil {
+		return m.RecoveredBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetPendingHtlcs() []*PendingHTLC {
+	if m != nil {
+		return m.PendingHtlcs
+	}
+	return nil
+}
+
+func (m *PendingChannelResponse_ForceClosedChannel) GetFeeBump() *PendingChannelResponse_FeeBump {
+	if m != nil {
+		return m.FeeBump
+	}
+	return nil
+}
+
+type PendingSweepsRequest struct {
+}
+
+func (m *PendingSweepsRequest) Reset()                    { *m = PendingSweepsRequest{} }
+func (m *PendingSweepsRequest) String() string            { return proto.CompactTextString(m) }
+func (*PendingSweepsRequest) ProtoMessage()               {}
+func (*PendingSweepsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+type PendingSweepsResponse struct {
+	// / The outputs that are waiting to be swept back into the wallet
+	PendingSweeps []*PendingSweepsResponse_PendingSweep `protobuf:"bytes,1,rep,name=pending_sweeps" json:"pending_sweeps,omitempty"`
+}
+
+func (m *PendingSweepsResponse) Reset()                    { *m = PendingSweepsResponse{} }
+func (m *PendingSweepsResponse) String() string            { return proto.CompactTextString(m) }
+func (*PendingSweepsResponse) ProtoMessage()               {}
+func (*PendingSweepsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+func (m *PendingSweepsResponse) GetPendingSweeps() []*PendingSweepsResponse_PendingSweep {
+	if m != nil {
+		return m.PendingSweeps
+	}
+	return nil
+}
+
+type PendingSweepsResponse_PendingSweep struct {
+	// / The outpoint of the output that's being swept
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// / The value of the output in satoshis
+	AmountSat int64 `protobuf:"varint,2,opt,name=amount_sat" json:"amount_sat,omitempty"`
+	// / The type of witness that spends the output
+	WitnessType string `protobuf:"bytes,3,opt,name=witness_type" json:"witness_type,omitempty"`
+	// / The number of blocks within which the sweep should confirm
+	ConfTarget uint32 `protobuf:"varint,4,opt,name=conf_target" json:"conf_target,omitempty"`
+	// *
+	// The fee rate in satoshis per byte of the last sweep transaction that
+	// was broadcast for the output
+	SatPerByte int64 `protobuf:"varint,5,opt,name=sat_per_byte" json:"sat_per_byte,omitempty"`
+	// / The number of times a sweep of the output was attempted
+	BroadcastAttempts uint32 `protobuf:"varint,6,opt,name=broadcast_attempts" json:"broadcast_attempts,omitempty"`
+	// / The height at which the last sweep of the output was broadcast
+	LastBroadcastHeight uint32 `protobuf:"varint,7,opt,name=last_broadcast_height" json:"last_broadcast_height,omitempty"`
+	// / The txid of the current sweep transaction spending the output
+	SweepTxid string `protobuf:"bytes,8,opt,name=sweep_txid" json:"sweep_txid,omitempty"`
+}
+
+func (m *PendingSweepsResponse_PendingSweep) Reset()         { *m = PendingSweepsResponse_PendingSweep{} }
+func (m *PendingSweepsResponse_PendingSweep) String() string { return proto.CompactTextString(m) }
+func (*PendingSweepsResponse_PendingSweep) ProtoMessage()    {}
+func (*PendingSweepsResponse_PendingSweep) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{87, 0}
+}
+
+func (m *PendingSweepsResponse_PendingSweep) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetChannel() *PendingChannelResponse_PendingChannel {
+func (m *PendingSweepsResponse_PendingSweep) GetAmountSat() int64 {
 	if m != nil {
-		return m.Channel
+		return m.AmountSat
 	}
-	return nil
+	return 0
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetClosingTxid() string {
+func (m *PendingSweepsResponse_PendingSweep) GetWitnessType() string {
 	if m != nil {
-		return m.ClosingTxid
+		return m.WitnessType
 	}
 	return ""
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetLimboBalance() int64 {
+func (m *PendingSweepsResponse_PendingSweep) GetConfTarget() uint32 {
 	if m != nil {
-		return m.LimboBalance
+		return m.ConfTarget
 	}
 	return 0
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetMaturityHeight() uint32 {
+func (m *PendingSweepsResponse_PendingSweep) GetSatPerByte() int64 {
 	if m != nil {
-		return m.MaturityHeight
+		return m.SatPerByte
 	}
 	return 0
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetBlocksTilMaturity() int32 {
+func (m *PendingSweepsResponse_PendingSweep) GetBroadcastAttempts() uint32 {
 	if m != nil {
-		return m.BlocksTilMaturity
+		return m.BroadcastAttempts
 	}
 	return 0
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetRecoveredBalance() int64 {
+func (m *PendingSweepsResponse_PendingSweep) GetLastBroadcastHeight() uint32 {
 	if m != nil {
-		return m.RecoveredBalance
+		return m.LastBroadcastHeight
 	}
 	return 0
 }
 
-func (m *PendingChannelResponse_ForceClosedChannel) GetPendingHtlcs() []*PendingHTLC {
+func (m *PendingSweepsResponse_PendingSweep) GetSweepTxid() string {
 	if m != nil {
-		return m.PendingHtlcs
+		return m.SweepTxid
 	}
-	return nil
+	return ""
 }
 
 type WalletBalanceRequest struct {
@@ -2118,7 +3601,7 @@ type WalletBalanceRequest struct {
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 func (m *WalletBalanceRequest) GetWitnessOnly() bool {
 	if m != nil {
@@ -2139,7 +3622,7 @@ type WalletBalanceResponse struct {
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *WalletBalanceResponse) GetTotalBalance() int64 {
 	if m != nil {
@@ -2168,7 +3651,7 @@ type ChannelBalanceRequest struct {
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 type ChannelBalanceResponse struct {
 	// / Sum of channels balances denominated in satoshis
@@ -2178,7 +3661,7 @@ type ChannelBalanceResponse struct {
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -2192,12 +3675,21 @@ type QueryRoutesRequest struct {
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
 	// / The amount to send expressed in satoshis
 	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// *
+	// The maximum total fee that may be paid to the nodes along each route. If
+	// unset, no fee limit is enforced.
+	FeeLimit *FeeLimit `protobuf:"bytes,3,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	// *
+	// The maximum total time lock of each route, relative to the current block
+	// height and including the final CLTV delta. If zero, no CLTV limit is
+	// enforced.
+	CltvLimit uint32 `protobuf:"varint,4,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
 }
 
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
 func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesRequest) ProtoMessage()               {}
-func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
 func (m *QueryRoutesRequest) GetPubKey() string {
 	if m != nil {
@@ -2213,6 +3705,20 @@ func (m *QueryRoutesRequest) GetAmt() int64 {
 	return 0
 }
 
+func (m *QueryRoutesRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
 type QueryRoutesResponse struct {
 	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
 }
@@ -2220,7 +3726,7 @@ type QueryRoutesResponse struct {
 func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
 func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesResponse) ProtoMessage()               {}
-func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 func (m *QueryRoutesResponse) GetRoutes() []*Route {
 	if m != nil {
@@ -2244,7 +3750,7 @@ type Hop struct {
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -2314,7 +3820,7 @@ type Route struct {
 func (m *Route) Reset()                    { *m = Route{} }
 func (m *Route) String() string            { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 func (m *Route) GetTotalTimeLock() uint32 {
 	if m != nil {
@@ -2344,6 +3850,129 @@ func (m *Route) GetHops() []*Hop {
 	return nil
 }
 
+type MissionControlResult struct {
+	// *
+	// The unique channel ID of the channel the result pertains to. If the result
+	// is localized to a node rather than a channel, this is zero.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// *
+	// The compressed public key of the node the result pertains to. This is only
+	// set if chan_id is zero.
+	Node []byte `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	// / The amount in milli-satoshis that was sent over the channel or to the node
+	AmtMsat int64 `protobuf:"varint,3,opt,name=amt_msat" json:"amt_msat,omitempty"`
+	// / Whether the attempt succeeded
+	Success bool `protobuf:"varint,4,opt,name=success" json:"success,omitempty"`
+	// / The unix timestamp in seconds at which the result was recorded
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *MissionControlResult) Reset()                    { *m = MissionControlResult{} }
+func (m *MissionControlResult) String() string            { return proto.CompactTextString(m) }
+func (*MissionControlResult) ProtoMessage()               {}
+func (*MissionControlResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+func (m *MissionControlResult) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *MissionControlResult) GetNode() []byte {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *MissionControlResult) GetAmtMsat() int64 {
+	if m != nil {
+		return m.AmtMsat
+	}
+	return 0
+}
+
+func (m *MissionControlResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *MissionControlResult) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type QueryMissionControlRequest struct {
+}
+
+func (m *QueryMissionControlRequest) Reset()                    { *m = QueryMissionControlRequest{} }
+func (m *QueryMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlRequest) ProtoMessage()               {}
+func (*QueryMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+type QueryMissionControlResponse struct {
+	// / The payment attempt results, ordered from oldest to newest
+	Results []*MissionControlResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *QueryMissionControlResponse) Reset()                    { *m = QueryMissionControlResponse{} }
+func (m *QueryMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlResponse) ProtoMessage()               {}
+func (*QueryMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+func (m *QueryMissionControlResponse) GetResults() []*MissionControlResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ImportMissionControlRequest struct {
+	// / The payment attempt results to add to the history of mission control
+	Results []*MissionControlResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *ImportMissionControlRequest) Reset()                    { *m = ImportMissionControlRequest{} }
+func (m *ImportMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportMissionControlRequest) ProtoMessage()               {}
+func (*ImportMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *ImportMissionControlRequest) GetResults() []*MissionControlResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ImportMissionControlResponse struct {
+}
+
+func (m *ImportMissionControlResponse) Reset()                    { *m = ImportMissionControlResponse{} }
+func (m *ImportMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportMissionControlResponse) ProtoMessage()               {}
+func (*ImportMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+type ResetMissionControlRequest struct {
+}
+
+func (m *ResetMissionControlRequest) Reset()                    { *m = ResetMissionControlRequest{} }
+func (m *ResetMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlRequest) ProtoMessage()               {}
+func (*ResetMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+type ResetMissionControlResponse struct {
+}
+
+func (m *ResetMissionControlResponse) Reset()                    { *m = ResetMissionControlResponse{} }
+func (m *ResetMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlResponse) ProtoMessage()               {}
+func (*ResetMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
 type NodeInfoRequest struct {
 	// / The 33-byte hex-encoded compressed public of the target node
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
@@ -2352,7 +3981,7 @@ type NodeInfoRequest struct {
 func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
 
 func (m *NodeInfoRequest) GetPubKey() string {
 	if m != nil {
@@ -2375,7 +4004,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -2414,7 +4043,7 @@ type LightningNode struct {
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -2459,7 +4088,7 @@ type NodeAddress struct {
 func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
 func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()               {}
-func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
 
 func (m *NodeAddress) GetNetwork() string {
 	if m != nil {
@@ -2485,7 +4114,7 @@ type RoutingPolicy struct {
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -2539,7 +4168,7 @@ type ChannelEdge struct {
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -2603,7 +4232,7 @@ type ChannelGraphRequest struct {
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
 
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
@@ -2616,7 +4245,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -2643,7 +4272,7 @@ type ChanInfoRequest struct {
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -2658,7 +4287,7 @@ type NetworkInfoRequest struct {
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
 
 type NetworkInfo struct {
 	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
@@ -2675,7 +4304,7 @@ type NetworkInfo struct {
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -2746,7 +4375,7 @@ type StopRequest struct {
 func (m *StopRequest) Reset()                    { *m = StopRequest{} }
 func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
 
 type StopResponse struct {
 }
@@ -2754,7 +4383,7 @@ type StopResponse struct {
 func (m *StopResponse) Reset()                    { *m = StopResponse{} }
 func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
 
 type GraphTopologySubscription struct {
 }
@@ -2762,7 +4391,7 @@ type GraphTopologySubscription struct {
 func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
 func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologySubscription) ProtoMessage()               {}
-func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
 type GraphTopologyUpdate struct {
 	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
@@ -2773,7 +4402,7 @@ type GraphTopologyUpdate struct {
 func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
 func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
 func (*GraphTopologyUpdate) ProtoMessage()               {}
-func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
 
 func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
 	if m != nil {
@@ -2806,7 +4435,7 @@ type NodeUpdate struct {
 func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
 func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*NodeUpdate) ProtoMessage()               {}
-func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
 
 func (m *NodeUpdate) GetAddresses() []string {
 	if m != nil {
@@ -2852,7 +4481,7 @@ type ChannelEdgeUpdate struct {
 func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
 func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdgeUpdate) ProtoMessage()               {}
-func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
 
 func (m *ChannelEdgeUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -2910,7 +4539,7 @@ type ClosedChannelUpdate struct {
 func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
 func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelUpdate) ProtoMessage()               {}
-func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
 func (m *ClosedChannelUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -2947,7 +4576,7 @@ type SetAliasRequest struct {
 func (m *SetAliasRequest) Reset()                    { *m = SetAliasRequest{} }
 func (m *SetAliasRequest) String() string            { return proto.CompactTextString(m) }
 func (*SetAliasRequest) ProtoMessage()               {}
-func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*SetAliasRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
 
 func (m *SetAliasRequest) GetNewAlias() string {
 	if m != nil {
@@ -2962,7 +4591,7 @@ type SetAliasResponse struct {
 func (m *SetAliasResponse) Reset()                    { *m = SetAliasResponse{} }
 func (m *SetAliasResponse) String() string            { return proto.CompactTextString(m) }
 func (*SetAliasResponse) ProtoMessage()               {}
-func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*SetAliasResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
 
 type Invoice struct {
 	// *
@@ -3003,12 +4632,33 @@ type Invoice struct {
 	FallbackAddr string `protobuf:"bytes,12,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
 	// / Delta to use for the time-lock of the CLTV extended to the final hop.
 	CltvExpiry uint64 `protobuf:"varint,13,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	// *
+	// The HTLCs that were held for this invoice, either as they paid the shards
+	// of a multi-path payment, or as the invoice is a hold invoice. If the
+	// invoice was paid using a single HTLC, this list is empty.
+	Htlcs []*InvoiceHTLC `protobuf:"bytes,14,rep,name=htlcs" json:"htlcs,omitempty"`
+	// / The amount that has been paid to this invoice in satoshis.
+	AmtPaid int64 `protobuf:"varint,15,opt,name=amt_paid" json:"amt_paid,omitempty"`
+	// / The state the invoice is in.
+	State Invoice_InvoiceState `protobuf:"varint,16,opt,name=state,enum=lnrpc.Invoice_InvoiceState" json:"state,omitempty"`
+	// *
+	// The "add" index of this invoice. Each newly created invoice will increment
+	// this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all added
+	// invoices with an add_index greater than this one.
+	AddIndex uint64 `protobuf:"varint,17,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// The "settle" index of this invoice. Each newly settled invoice will
+	// increment this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all
+	// settled invoices with an settle_index greater than this one.
+	SettleIndex uint64 `protobuf:"varint,18,opt,name=settle_index" json:"settle_index,omitempty"`
 }
 
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -3094,39 +4744,289 @@ func (m *Invoice) GetFallbackAddr() string {
 	return ""
 }
 
-func (m *Invoice) GetCltvExpiry() uint64 {
+func (m *Invoice) GetCltvExpiry() uint64 {
+	if m != nil {
+		return m.CltvExpiry
+	}
+	return 0
+}
+
+func (m *Invoice) GetHtlcs() []*InvoiceHTLC {
+	if m != nil {
+		return m.Htlcs
+	}
+	return nil
+}
+
+func (m *Invoice) GetAmtPaid() int64 {
+	if m != nil {
+		return m.AmtPaid
+	}
+	return 0
+}
+
+func (m *Invoice) GetState() Invoice_InvoiceState {
+	if m != nil {
+		return m.State
+	}
+	return Invoice_OPEN
+}
+
+func (m *Invoice) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
+// / Details of an HTLC that paid a shard of a multi-path payment to an invoice.
+type InvoiceHTLC struct {
+	// / The short channel id of the channel the HTLC arrived over.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The index of the HTLC within the channel.
+	HtlcIndex uint64 `protobuf:"varint,2,opt,name=htlc_index" json:"htlc_index,omitempty"`
+	// / The amount paid by the HTLC in milli-satoshis.
+	AmtMsat uint64 `protobuf:"varint,3,opt,name=amt_msat" json:"amt_msat,omitempty"`
+	// / The time the HTLC was accepted.
+	AcceptTime int64 `protobuf:"varint,4,opt,name=accept_time" json:"accept_time,omitempty"`
+}
+
+func (m *InvoiceHTLC) Reset()                    { *m = InvoiceHTLC{} }
+func (m *InvoiceHTLC) String() string            { return proto.CompactTextString(m) }
+func (*InvoiceHTLC) ProtoMessage()               {}
+func (*InvoiceHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+func (m *InvoiceHTLC) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *InvoiceHTLC) GetHtlcIndex() uint64 {
+	if m != nil {
+		return m.HtlcIndex
+	}
+	return 0
+}
+
+func (m *InvoiceHTLC) GetAmtMsat() uint64 {
+	if m != nil {
+		return m.AmtMsat
+	}
+	return 0
+}
+
+func (m *InvoiceHTLC) GetAcceptTime() int64 {
+	if m != nil {
+		return m.AcceptTime
+	}
+	return 0
+}
+
+type AddInvoiceResponse struct {
+	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+	// *
+	// A bare-bones invoice for a payment within the Lightning Network.  With the
+	// details of the invoice, the sender has all the data necessary to send a
+	// payment to the recipient.
+	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+	// *
+	// The "add" index of this invoice. Each newly created invoice will increment
+	// this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all added
+	// invoices with an add_index greater than this one.
+	AddIndex uint64 `protobuf:"varint,16,opt,name=add_index" json:"add_index,omitempty"`
+}
+
+func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
+func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddInvoiceResponse) ProtoMessage()               {}
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+func (m *AddInvoiceResponse) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+func (m *AddInvoiceResponse) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *AddInvoiceResponse) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+type AddHoldInvoiceRequest struct {
+	// *
+	// An optional memo to attach along with the invoice. Used for record keeping
+	// purposes for the invoice's creator, and will also be set in the description
+	// field of the encoded payment request if the description_hash field is not
+	// being used.
+	Memo string `protobuf:"bytes,1,opt,name=memo" json:"memo,omitempty"`
+	// / The hash of the preimage, which is only revealed once settled.
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// / The value of this invoice in satoshis
+	Value int64 `protobuf:"varint,3,opt,name=value" json:"value,omitempty"`
+	// *
+	// Hash (SHA-256) of a description of the payment. Used if the description of
+	// payment (memo) is too long to naturally fit within the description field
+	// of an encoded payment request.
+	DescriptionHash []byte `protobuf:"bytes,4,opt,name=description_hash,proto3" json:"description_hash,omitempty"`
+	// / Payment request expiry time in seconds. Default is 3600 (1 hour).
+	Expiry int64 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// / Fallback on-chain address.
+	FallbackAddr string `protobuf:"bytes,6,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
+	// / Delta to use for the time-lock of the CLTV extended to the final hop.
+	CltvExpiry uint64 `protobuf:"varint,7,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+}
+
+func (m *AddHoldInvoiceRequest) Reset()                    { *m = AddHoldInvoiceRequest{} }
+func (m *AddHoldInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddHoldInvoiceRequest) ProtoMessage()               {}
+func (*AddHoldInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
+
+func (m *AddHoldInvoiceRequest) GetMemo() string {
+	if m != nil {
+		return m.Memo
+	}
+	return ""
+}
+
+func (m *AddHoldInvoiceRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *AddHoldInvoiceRequest) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *AddHoldInvoiceRequest) GetDescriptionHash() []byte {
+	if m != nil {
+		return m.DescriptionHash
+	}
+	return nil
+}
+
+func (m *AddHoldInvoiceRequest) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *AddHoldInvoiceRequest) GetFallbackAddr() string {
+	if m != nil {
+		return m.FallbackAddr
+	}
+	return ""
+}
+
+func (m *AddHoldInvoiceRequest) GetCltvExpiry() uint64 {
+	if m != nil {
+		return m.CltvExpiry
+	}
+	return 0
+}
+
+type SettleInvoiceMsg struct {
+	// / The preimage of the payment hash of the hold invoice to settle.
+	Preimage []byte `protobuf:"bytes,1,opt,name=preimage,proto3" json:"preimage,omitempty"`
+}
+
+func (m *SettleInvoiceMsg) Reset()                    { *m = SettleInvoiceMsg{} }
+func (m *SettleInvoiceMsg) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceMsg) ProtoMessage()               {}
+func (*SettleInvoiceMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+func (m *SettleInvoiceMsg) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+type SettleInvoiceResp struct {
+}
+
+func (m *SettleInvoiceResp) Reset()                    { *m = SettleInvoiceResp{} }
+func (m *SettleInvoiceResp) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceResp) ProtoMessage()               {}
+func (*SettleInvoiceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
+
+type CancelInvoiceMsg struct {
+	// / The payment hash of the invoice to cancel.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *CancelInvoiceMsg) Reset()                    { *m = CancelInvoiceMsg{} }
+func (m *CancelInvoiceMsg) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceMsg) ProtoMessage()               {}
+func (*CancelInvoiceMsg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
+
+func (m *CancelInvoiceMsg) GetPaymentHash() []byte {
 	if m != nil {
-		return m.CltvExpiry
+		return m.PaymentHash
 	}
-	return 0
+	return nil
 }
 
-type AddInvoiceResponse struct {
-	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
-	// *
-	// A bare-bones invoice for a payment within the Lightning Network.  With the
-	// details of the invoice, the sender has all the data necessary to send a
-	// payment to the recipient.
-	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+type CancelInvoiceResp struct {
 }
 
-func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
-func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
-func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (m *CancelInvoiceResp) Reset()                    { *m = CancelInvoiceResp{} }
+func (m *CancelInvoiceResp) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceResp) ProtoMessage()               {}
+func (*CancelInvoiceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
 
-func (m *AddInvoiceResponse) GetRHash() []byte {
-	if m != nil {
-		return m.RHash
-	}
-	return nil
+type DeleteCanceledInvoicesRequest struct {
 }
 
-func (m *AddInvoiceResponse) GetPaymentRequest() string {
+func (m *DeleteCanceledInvoicesRequest) Reset()         { *m = DeleteCanceledInvoicesRequest{} }
+func (m *DeleteCanceledInvoicesRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCanceledInvoicesRequest) ProtoMessage()    {}
+func (*DeleteCanceledInvoicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{131}
+}
+
+type DeleteCanceledInvoicesResponse struct {
+	// / The number of invoices that were deleted.
+	NumDeleted int64 `protobuf:"varint,1,opt,name=num_deleted" json:"num_deleted,omitempty"`
+}
+
+func (m *DeleteCanceledInvoicesResponse) Reset()         { *m = DeleteCanceledInvoicesResponse{} }
+func (m *DeleteCanceledInvoicesResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCanceledInvoicesResponse) ProtoMessage()    {}
+func (*DeleteCanceledInvoicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{132}
+}
+
+func (m *DeleteCanceledInvoicesResponse) GetNumDeleted() int64 {
 	if m != nil {
-		return m.PaymentRequest
+		return m.NumDeleted
 	}
-	return ""
+	return 0
 }
 
 type PaymentHash struct {
@@ -3141,7 +5041,7 @@ type PaymentHash struct {
 func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
 func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
 func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
 
 func (m *PaymentHash) GetRHashStr() string {
 	if m != nil {
@@ -3160,12 +5060,22 @@ func (m *PaymentHash) GetRHash() []byte {
 type ListInvoiceRequest struct {
 	// / Toggles if all invoices should be returned, or only those that are currently unsettled.
 	PendingOnly bool `protobuf:"varint,1,opt,name=pending_only,json=pendingOnly" json:"pending_only,omitempty"`
+	// *
+	// The index of an invoice that will be used as either the start or end of a
+	// query to determine which invoices should be returned in the response.
+	IndexOffset uint64 `protobuf:"varint,4,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The max number of invoices to return in the response to this query.
+	NumMaxInvoices uint64 `protobuf:"varint,5,opt,name=num_max_invoices" json:"num_max_invoices,omitempty"`
+	// *
+	// If set, the invoices returned will result from seeking backwards from the
+	// specified index offset. This can be used to paginate backwards.
+	Reversed bool `protobuf:"varint,6,opt,name=reversed" json:"reversed,omitempty"`
 }
 
 func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
 func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
 
 func (m *ListInvoiceRequest) GetPendingOnly() bool {
 	if m != nil {
@@ -3174,14 +5084,46 @@ func (m *ListInvoiceRequest) GetPendingOnly() bool {
 	return false
 }
 
+func (m *ListInvoiceRequest) GetIndexOffset() uint64 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetNumMaxInvoices() uint64 {
+	if m != nil {
+		return m.NumMaxInvoices
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetReversed() bool {
+	if m != nil {
+		return m.Reversed
+	}
+	return false
+}
+
 type ListInvoiceResponse struct {
+	// *
+	// A list of invoices from the time slice of the time series specified in the
+	// request.
 	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
+	// *
+	// The index of the last item in the set of returned invoices. This can be used
+	// to seek further, pagination style.
+	LastIndexOffset uint64 `protobuf:"varint,2,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
+	// *
+	// The index of the first item in the set of returned invoices. This can be
+	// used to seek backwards, pagination style.
+	FirstIndexOffset uint64 `protobuf:"varint,3,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
 }
 
 func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
 func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
 
 func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 	if m != nil {
@@ -3190,13 +5132,53 @@ func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 	return nil
 }
 
+func (m *ListInvoiceResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
+	}
+	return 0
+}
+
 type InvoiceSubscription struct {
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all added indexes with an add_index greater than this
+	// value. This allows callers to catch up on any events they missed while they
+	// weren't connected to the streaming RPC.
+	AddIndex uint64 `protobuf:"varint,1,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all settled indexes with an settle_index greater than
+	// this value. This allows callers to catch up on any events they missed while
+	// they weren't connected to the streaming RPC.
+	SettleIndex uint64 `protobuf:"varint,2,opt,name=settle_index" json:"settle_index,omitempty"`
 }
 
 func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
 func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
 func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *InvoiceSubscription) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *InvoiceSubscription) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
 
 type Payment struct {
 	// / The payment hash
@@ -3209,12 +5191,26 @@ type Payment struct {
 	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
 	// / The fee paid for this payment in satoshis
 	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
+	// *
+	// The shards this payment was split into, if it was sent over multiple
+	// routes. In this case, path is the path of the first shard.
+	Shards []*PaymentShard `protobuf:"bytes,6,rep,name=shards" json:"shards,omitempty"`
+	// / The status of the payment
+	Status Payment_PaymentStatus `protobuf:"varint,7,opt,name=status,enum=lnrpc.Payment_PaymentStatus" json:"status,omitempty"`
+	// / The reason the payment failed, if it has failed
+	FailureReason Payment_FailureReason `protobuf:"varint,8,opt,name=failure_reason,enum=lnrpc.Payment_FailureReason" json:"failure_reason,omitempty"`
+	// / A description of the error the payment failed with, if it has failed
+	FailureMessage string `protobuf:"bytes,9,opt,name=failure_message" json:"failure_message,omitempty"`
+	// / The preimage of the payment, if it has succeeded and is known
+	PaymentPreimage string `protobuf:"bytes,10,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
+	// / The payment request the payment was made to, if any
+	PaymentRequest string `protobuf:"bytes,11,opt,name=payment_request" json:"payment_request,omitempty"`
 }
 
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
 
 func (m *Payment) GetPaymentHash() string {
 	if m != nil {
@@ -3251,13 +5247,102 @@ func (m *Payment) GetFee() int64 {
 	return 0
 }
 
+func (m *Payment) GetShards() []*PaymentShard {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+func (m *Payment) GetStatus() Payment_PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return Payment_UNKNOWN
+}
+
+func (m *Payment) GetFailureReason() Payment_FailureReason {
+	if m != nil {
+		return m.FailureReason
+	}
+	return Payment_FAILURE_REASON_NONE
+}
+
+func (m *Payment) GetFailureMessage() string {
+	if m != nil {
+		return m.FailureMessage
+	}
+	return ""
+}
+
+func (m *Payment) GetPaymentPreimage() string {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return ""
+}
+
+func (m *Payment) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+// / A single shard of a payment that was split across multiple routes.
+type PaymentShard struct {
+	// / The value delivered by this shard in satoshis
+	Value int64 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	// / The fee paid for routing this shard in satoshis
+	Fee int64 `protobuf:"varint,2,opt,name=fee" json:"fee,omitempty"`
+	// / The path this shard took
+	Path []string `protobuf:"bytes,3,rep,name=path" json:"path,omitempty"`
+}
+
+func (m *PaymentShard) Reset()                    { *m = PaymentShard{} }
+func (m *PaymentShard) String() string            { return proto.CompactTextString(m) }
+func (*PaymentShard) ProtoMessage()               {}
+func (*PaymentShard) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *PaymentShard) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *PaymentShard) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *PaymentShard) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
 type ListPaymentsRequest struct {
+	// *
+	// If set, payments that failed or are still in flight are returned along
+	// with the successful ones.
+	IncludeIncomplete bool `protobuf:"varint,1,opt,name=include_incomplete" json:"include_incomplete,omitempty"`
 }
 
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+func (m *ListPaymentsRequest) GetIncludeIncomplete() bool {
+	if m != nil {
+		return m.IncludeIncomplete
+	}
+	return false
+}
 
 type ListPaymentsResponse struct {
 	// / The list of payments
@@ -3267,7 +5352,7 @@ type ListPaymentsResponse struct {
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -3276,13 +5361,39 @@ func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	return nil
 }
 
+type TrackPaymentRequest struct {
+	// / The hex-encoded payment hash of the payment to track
+	PaymentHashStr string `protobuf:"bytes,1,opt,name=payment_hash_str" json:"payment_hash_str,omitempty"`
+	// / The payment hash of the payment to track
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *TrackPaymentRequest) GetPaymentHashStr() string {
+	if m != nil {
+		return m.PaymentHashStr
+	}
+	return ""
+}
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
 type DeleteAllPaymentsRequest struct {
 }
 
 func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
 func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
 
 type DeleteAllPaymentsResponse struct {
 }
@@ -3290,7 +5401,7 @@ type DeleteAllPaymentsResponse struct {
 func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
 func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
 
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
@@ -3300,7 +5411,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -3323,7 +5434,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -3340,7 +5451,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{146} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -3359,12 +5470,13 @@ type PayReq struct {
 	DescriptionHash string `protobuf:"bytes,7,opt,name=description_hash" json:"description_hash,omitempty"`
 	FallbackAddr    string `protobuf:"bytes,8,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
 	CltvExpiry      int64  `protobuf:"varint,9,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	MultiPath       bool   `protobuf:"varint,10,opt,name=multi_path" json:"multi_path,omitempty"`
 }
 
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{147} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -3429,13 +5541,20 @@ func (m *PayReq) GetCltvExpiry() int64 {
 	return 0
 }
 
+func (m *PayReq) GetMultiPath() bool {
+	if m != nil {
+		return m.MultiPath
+	}
+	return false
+}
+
 type FeeReportRequest struct {
 }
 
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{148} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -3451,7 +5570,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{149} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -3489,7 +5608,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{150} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -3512,7 +5631,7 @@ type FeeUpdateRequest struct {
 func (m *FeeUpdateRequest) Reset()                    { *m = FeeUpdateRequest{} }
 func (m *FeeUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeUpdateRequest) ProtoMessage()               {}
-func (*FeeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+func (*FeeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{151} }
 
 type isFeeUpdateRequest_Scope interface {
 	isFeeUpdateRequest_Scope()
@@ -3642,16 +5761,247 @@ type FeeUpdateResponse struct {
 func (m *FeeUpdateResponse) Reset()                    { *m = FeeUpdateResponse{} }
 func (m *FeeUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeUpdateResponse) ProtoMessage()               {}
-func (*FeeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+func (*FeeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{152} }
+
+type ForwardingHistoryRequest struct {
+	// / Start time is the starting point of the forwarding history request. All records beyond this point will be included, respecting the end time, and the index offset.
+	StartTime uint64 `protobuf:"varint,1,opt,name=start_time" json:"start_time,omitempty"`
+	// / End time is the end point of the forwarding history request. The response will carry at most 50k records between the start time and the end time. The index offset can be used to implement pagination.
+	EndTime uint64 `protobuf:"varint,2,opt,name=end_time" json:"end_time,omitempty"`
+	// / Index offset is the offset in the time series to start at. As each response can only contain 50k records, callers can use this to skip around within a packed time series.
+	IndexOffset uint32 `protobuf:"varint,3,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The max number of events to return in the response to this query.
+	NumMaxEvents uint32 `protobuf:"varint,4,opt,name=num_max_events" json:"num_max_events,omitempty"`
+}
+
+func (m *ForwardingHistoryRequest) Reset()                    { *m = ForwardingHistoryRequest{} }
+func (m *ForwardingHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForwardingHistoryRequest) ProtoMessage()               {}
+func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{153} }
+
+func (m *ForwardingHistoryRequest) GetStartTime() uint64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ForwardingHistoryRequest) GetEndTime() uint64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ForwardingHistoryRequest) GetIndexOffset() uint32 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *ForwardingHistoryRequest) GetNumMaxEvents() uint32 {
+	if m != nil {
+		return m.NumMaxEvents
+	}
+	return 0
+}
+
+type ForwardingEvent struct {
+	// / Timestamp is the time (unix epoch offset) that this circuit was completed.
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// / The incoming channel ID that carried the HTLC that created the circuit.
+	ChanIdIn uint64 `protobuf:"varint,2,opt,name=chan_id_in" json:"chan_id_in,omitempty"`
+	// / The outgoing channel ID that carried the preimage that completed the circuit.
+	ChanIdOut uint64 `protobuf:"varint,3,opt,name=chan_id_out" json:"chan_id_out,omitempty"`
+	// / The total amount of the incoming HTLC that created half the circuit, in satoshis.
+	AmtIn uint64 `protobuf:"varint,4,opt,name=amt_in" json:"amt_in,omitempty"`
+	// / The total amount of the outgoing HTLC that created the second half of the circuit, in satoshis.
+	AmtOut uint64 `protobuf:"varint,5,opt,name=amt_out" json:"amt_out,omitempty"`
+	// / The total fee that this payment circuit carried, in satoshis.
+	Fee uint64 `protobuf:"varint,6,opt,name=fee" json:"fee,omitempty"`
+	// / The total fee that this payment circuit carried, in milli-satoshis.
+	FeeMsat uint64 `protobuf:"varint,7,opt,name=fee_msat" json:"fee_msat,omitempty"`
+}
+
+func (m *ForwardingEvent) Reset()                    { *m = ForwardingEvent{} }
+func (m *ForwardingEvent) String() string            { return proto.CompactTextString(m) }
+func (*ForwardingEvent) ProtoMessage()               {}
+func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{154} }
+
+func (m *ForwardingEvent) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetChanIdIn() uint64 {
+	if m != nil {
+		return m.ChanIdIn
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetChanIdOut() uint64 {
+	if m != nil {
+		return m.ChanIdOut
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetAmtIn() uint64 {
+	if m != nil {
+		return m.AmtIn
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetAmtOut() uint64 {
+	if m != nil {
+		return m.AmtOut
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetFeeMsat() uint64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+type ForwardingHistoryResponse struct {
+	// / A list of forwarding events from the time slice of the time series specified in the request.
+	ForwardingEvents []*ForwardingEvent `protobuf:"bytes,1,rep,name=forwarding_events" json:"forwarding_events,omitempty"`
+	// / The index of the last time in the set of returned forwarding events. Can be used to seek further, pagination style.
+	LastOffsetIndex uint32 `protobuf:"varint,2,opt,name=last_offset_index" json:"last_offset_index,omitempty"`
+}
+
+func (m *ForwardingHistoryResponse) Reset()                    { *m = ForwardingHistoryResponse{} }
+func (m *ForwardingHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForwardingHistoryResponse) ProtoMessage()               {}
+func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{155} }
+
+func (m *ForwardingHistoryResponse) GetForwardingEvents() []*ForwardingEvent {
+	if m != nil {
+		return m.ForwardingEvents
+	}
+	return nil
+}
+
+func (m *ForwardingHistoryResponse) GetLastOffsetIndex() uint32 {
+	if m != nil {
+		return m.LastOffsetIndex
+	}
+	return 0
+}
+
+type ChanBackupExportRequest struct {
+}
+
+func (m *ChanBackupExportRequest) Reset()                    { *m = ChanBackupExportRequest{} }
+func (m *ChanBackupExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupExportRequest) ProtoMessage()               {}
+func (*ChanBackupExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{156} }
+
+type ChanBackupSnapshot struct {
+	// / The set of channels included within the backup.
+	ChanPoints []*ChannelPoint `protobuf:"bytes,1,rep,name=chan_points" json:"chan_points,omitempty"`
+	// / The encrypted static channel backup of all the channels above.
+	MultiChanBackup []byte `protobuf:"bytes,2,opt,name=multi_chan_backup,proto3" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *ChanBackupSnapshot) Reset()                    { *m = ChanBackupSnapshot{} }
+func (m *ChanBackupSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ChanBackupSnapshot) ProtoMessage()               {}
+func (*ChanBackupSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{157} }
+
+func (m *ChanBackupSnapshot) GetChanPoints() []*ChannelPoint {
+	if m != nil {
+		return m.ChanPoints
+	}
+	return nil
+}
+
+func (m *ChanBackupSnapshot) GetMultiChanBackup() []byte {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type VerifyChanBackupResponse struct {
+}
+
+func (m *VerifyChanBackupResponse) Reset()                    { *m = VerifyChanBackupResponse{} }
+func (m *VerifyChanBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*VerifyChanBackupResponse) ProtoMessage()               {}
+func (*VerifyChanBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{158} }
+
+type RestoreChanBackupRequest struct {
+	// / The encrypted static channel backup to restore the channels from.
+	MultiChanBackup []byte `protobuf:"bytes,1,opt,name=multi_chan_backup,proto3" json:"multi_chan_backup,omitempty"`
+}
+
+func (m *RestoreChanBackupRequest) Reset()                    { *m = RestoreChanBackupRequest{} }
+func (m *RestoreChanBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreChanBackupRequest) ProtoMessage()               {}
+func (*RestoreChanBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{159} }
+
+func (m *RestoreChanBackupRequest) GetMultiChanBackup() []byte {
+	if m != nil {
+		return m.MultiChanBackup
+	}
+	return nil
+}
+
+type RestoreBackupResponse struct {
+}
+
+func (m *RestoreBackupResponse) Reset()                    { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()               {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{160} }
 
 func init() {
+	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
+	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
 	proto.RegisterType((*CreateWalletRequest)(nil), "lnrpc.CreateWalletRequest")
 	proto.RegisterType((*CreateWalletResponse)(nil), "lnrpc.CreateWalletResponse")
 	proto.RegisterType((*UnlockWalletRequest)(nil), "lnrpc.UnlockWalletRequest")
 	proto.RegisterType((*UnlockWalletResponse)(nil), "lnrpc.UnlockWalletResponse")
+	proto.RegisterType((*DeriveNextKeyRequest)(nil), "lnrpc.DeriveNextKeyRequest")
+	proto.RegisterType((*DeriveNextKeyResponse)(nil), "lnrpc.DeriveNextKeyResponse")
+	proto.RegisterType((*SignOutputRawRequest)(nil), "lnrpc.SignOutputRawRequest")
+	proto.RegisterType((*SignOutputRawResponse)(nil), "lnrpc.SignOutputRawResponse")
+	proto.RegisterType((*ComputeInputScriptRequest)(nil), "lnrpc.ComputeInputScriptRequest")
+	proto.RegisterType((*ComputeInputScriptResponse)(nil), "lnrpc.ComputeInputScriptResponse")
+	proto.RegisterType((*SignMessageWithKeyRequest)(nil), "lnrpc.SignMessageWithKeyRequest")
+	proto.RegisterType((*SignMessageWithKeyResponse)(nil), "lnrpc.SignMessageWithKeyResponse")
+	proto.RegisterType((*SignDigestCompactRequest)(nil), "lnrpc.SignDigestCompactRequest")
+	proto.RegisterType((*SignDigestCompactResponse)(nil), "lnrpc.SignDigestCompactResponse")
+	proto.RegisterType((*DeriveRevocationRootRequest)(nil), "lnrpc.DeriveRevocationRootRequest")
+	proto.RegisterType((*DeriveRevocationRootResponse)(nil), "lnrpc.DeriveRevocationRootResponse")
+	proto.RegisterType((*IdentityPubKeyRequest)(nil), "lnrpc.IdentityPubKeyRequest")
+	proto.RegisterType((*IdentityPubKeyResponse)(nil), "lnrpc.IdentityPubKeyResponse")
+	proto.RegisterType((*DeriveSharedKeyRequest)(nil), "lnrpc.DeriveSharedKeyRequest")
+	proto.RegisterType((*DeriveSharedKeyResponse)(nil), "lnrpc.DeriveSharedKeyResponse")
+	proto.RegisterType((*ProcessOnionPacketRequest)(nil), "lnrpc.ProcessOnionPacketRequest")
+	proto.RegisterType((*ProcessOnionPacketResponse)(nil), "lnrpc.ProcessOnionPacketResponse")
+	proto.RegisterType((*DeriveBackupKeyRequest)(nil), "lnrpc.DeriveBackupKeyRequest")
+	proto.RegisterType((*DeriveBackupKeyResponse)(nil), "lnrpc.DeriveBackupKeyResponse")
 	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
 	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
 	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
+	proto.RegisterType((*FeeLimit)(nil), "lnrpc.FeeLimit")
 	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
 	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
 	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
@@ -3660,6 +6010,18 @@ func init() {
 	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
 	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
 	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*BumpFeeRequest)(nil), "lnrpc.BumpFeeRequest")
+	proto.RegisterType((*BumpFeeResponse)(nil), "lnrpc.BumpFeeResponse")
+	proto.RegisterType((*Utxo)(nil), "lnrpc.Utxo")
+	proto.RegisterType((*ListUnspentRequest)(nil), "lnrpc.ListUnspentRequest")
+	proto.RegisterType((*ListUnspentResponse)(nil), "lnrpc.ListUnspentResponse")
+	proto.RegisterType((*LeaseOutputRequest)(nil), "lnrpc.LeaseOutputRequest")
+	proto.RegisterType((*LeaseOutputResponse)(nil), "lnrpc.LeaseOutputResponse")
+	proto.RegisterType((*ReleaseOutputRequest)(nil), "lnrpc.ReleaseOutputRequest")
+	proto.RegisterType((*ReleaseOutputResponse)(nil), "lnrpc.ReleaseOutputResponse")
+	proto.RegisterType((*OutputLease)(nil), "lnrpc.OutputLease")
+	proto.RegisterType((*ListLeasesRequest)(nil), "lnrpc.ListLeasesRequest")
+	proto.RegisterType((*ListLeasesResponse)(nil), "lnrpc.ListLeasesResponse")
 	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
 	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
 	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
@@ -3686,15 +6048,24 @@ func init() {
 	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
 	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
 	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
+	proto.RegisterType((*ReadyForPsbtFunding)(nil), "lnrpc.ReadyForPsbtFunding")
+	proto.RegisterType((*FundingPsbtFinalizeRequest)(nil), "lnrpc.FundingPsbtFinalizeRequest")
+	proto.RegisterType((*FundingPsbtFinalizeResponse)(nil), "lnrpc.FundingPsbtFinalizeResponse")
+	proto.RegisterType((*FundingPsbtCancelRequest)(nil), "lnrpc.FundingPsbtCancelRequest")
+	proto.RegisterType((*FundingPsbtCancelResponse)(nil), "lnrpc.FundingPsbtCancelResponse")
 	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
 	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
 	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
 	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
 	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
 	proto.RegisterType((*PendingChannelResponse_PendingChannel)(nil), "lnrpc.PendingChannelResponse.PendingChannel")
+	proto.RegisterType((*PendingChannelResponse_FeeBump)(nil), "lnrpc.PendingChannelResponse.FeeBump")
 	proto.RegisterType((*PendingChannelResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelResponse.PendingOpenChannel")
 	proto.RegisterType((*PendingChannelResponse_ClosedChannel)(nil), "lnrpc.PendingChannelResponse.ClosedChannel")
 	proto.RegisterType((*PendingChannelResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelResponse.ForceClosedChannel")
+	proto.RegisterType((*PendingSweepsRequest)(nil), "lnrpc.PendingSweepsRequest")
+	proto.RegisterType((*PendingSweepsResponse)(nil), "lnrpc.PendingSweepsResponse")
+	proto.RegisterType((*PendingSweepsResponse_PendingSweep)(nil), "lnrpc.PendingSweepsResponse.PendingSweep")
 	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
 	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
 	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
@@ -3703,6 +6074,13 @@ func init() {
 	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
 	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
 	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*MissionControlResult)(nil), "lnrpc.MissionControlResult")
+	proto.RegisterType((*QueryMissionControlRequest)(nil), "lnrpc.QueryMissionControlRequest")
+	proto.RegisterType((*QueryMissionControlResponse)(nil), "lnrpc.QueryMissionControlResponse")
+	proto.RegisterType((*ImportMissionControlRequest)(nil), "lnrpc.ImportMissionControlRequest")
+	proto.RegisterType((*ImportMissionControlResponse)(nil), "lnrpc.ImportMissionControlResponse")
+	proto.RegisterType((*ResetMissionControlRequest)(nil), "lnrpc.ResetMissionControlRequest")
+	proto.RegisterType((*ResetMissionControlResponse)(nil), "lnrpc.ResetMissionControlResponse")
 	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
 	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
 	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
@@ -3724,14 +6102,24 @@ func init() {
 	proto.RegisterType((*SetAliasRequest)(nil), "lnrpc.SetAliasRequest")
 	proto.RegisterType((*SetAliasResponse)(nil), "lnrpc.SetAliasResponse")
 	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
+	proto.RegisterType((*InvoiceHTLC)(nil), "lnrpc.InvoiceHTLC")
 	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
+	proto.RegisterType((*AddHoldInvoiceRequest)(nil), "lnrpc.AddHoldInvoiceRequest")
+	proto.RegisterType((*SettleInvoiceMsg)(nil), "lnrpc.SettleInvoiceMsg")
+	proto.RegisterType((*SettleInvoiceResp)(nil), "lnrpc.SettleInvoiceResp")
+	proto.RegisterType((*CancelInvoiceMsg)(nil), "lnrpc.CancelInvoiceMsg")
+	proto.RegisterType((*CancelInvoiceResp)(nil), "lnrpc.CancelInvoiceResp")
+	proto.RegisterType((*DeleteCanceledInvoicesRequest)(nil), "lnrpc.DeleteCanceledInvoicesRequest")
+	proto.RegisterType((*DeleteCanceledInvoicesResponse)(nil), "lnrpc.DeleteCanceledInvoicesResponse")
 	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
 	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
 	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
 	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
 	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*PaymentShard)(nil), "lnrpc.PaymentShard")
 	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
 	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
 	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
 	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
 	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
@@ -3743,7 +6131,19 @@ func init() {
 	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
 	proto.RegisterType((*FeeUpdateRequest)(nil), "lnrpc.FeeUpdateRequest")
 	proto.RegisterType((*FeeUpdateResponse)(nil), "lnrpc.FeeUpdateResponse")
+	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
+	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
+	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
+	proto.RegisterType((*ChanBackupExportRequest)(nil), "lnrpc.ChanBackupExportRequest")
+	proto.RegisterType((*ChanBackupSnapshot)(nil), "lnrpc.ChanBackupSnapshot")
+	proto.RegisterType((*VerifyChanBackupResponse)(nil), "lnrpc.VerifyChanBackupResponse")
+	proto.RegisterType((*RestoreChanBackupRequest)(nil), "lnrpc.RestoreChanBackupRequest")
+	proto.RegisterType((*RestoreBackupResponse)(nil), "lnrpc.RestoreBackupResponse")
+	proto.RegisterEnum("lnrpc.ProcessOnionPacketResponse_OnionFailure", ProcessOnionPacketResponse_OnionFailure_name, ProcessOnionPacketResponse_OnionFailure_value)
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Invoice_InvoiceState", Invoice_InvoiceState_name, Invoice_InvoiceState_value)
+	proto.RegisterEnum("lnrpc.Payment_PaymentStatus", Payment_PaymentStatus_name, Payment_PaymentStatus_value)
+	proto.RegisterEnum("lnrpc.Payment_FailureReason", Payment_FailureReason_name, Payment_FailureReason_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3757,9 +6157,20 @@ const _ = grpc.SupportPackageIsVersion4
 // Client API for WalletUnlocker service
 
 type WalletUnlockerClient interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new cipher seed
+	// mnemonic, along with its enciphered form, that can be passed to
+	// CreateWallet. The seed is encrypted under the optional passphrase, and also
+	// encodes the birthday of the wallet. GenSeed doesn't create a wallet, so
+	// the mnemonic must be confirmed by passing it to CreateWallet.
+	GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error)
 	// * lncli: `create`
 	// CreateWallet is used at lnd startup to set the encryption password for
-	// the wallet database.
+	// the wallet database. If a cipher seed mnemonic is specified, then the
+	// wallet, along with all of lnd's keys, will be derived from it. In that
+	// case, the wallet is rescanned from the birthday of the seed in order to
+	// recover all on-chain funds. Otherwise, a fresh seed will be generated.
 	CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error)
 	// * lncli: `unlock`
 	// UnlockWallet is used at startup of lnd to provide a password to unlock
@@ -3775,6 +6186,15 @@ func NewWalletUnlockerClient(cc *grpc.ClientConn) WalletUnlockerClient {
 	return &walletUnlockerClient{cc}
 }
 
+func (c *walletUnlockerClient) GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error) {
+	out := new(GenSeedResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/GenSeed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletUnlockerClient) CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*CreateWalletResponse, error) {
 	out := new(CreateWalletResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/CreateWallet", in, out, c.cc, opts...)
@@ -3796,9 +6216,20 @@ func (c *walletUnlockerClient) UnlockWallet(ctx context.Context, in *UnlockWalle
 // Server API for WalletUnlocker service
 
 type WalletUnlockerServer interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new cipher seed
+	// mnemonic, along with its enciphered form, that can be passed to
+	// CreateWallet. The seed is encrypted under the optional passphrase, and also
+	// encodes the birthday of the wallet. GenSeed doesn't create a wallet, so
+	// the mnemonic must be confirmed by passing it to CreateWallet.
+	GenSeed(context.Context, *GenSeedRequest) (*GenSeedResponse, error)
 	// * lncli: `create`
 	// CreateWallet is used at lnd startup to set the encryption password for
-	// the wallet database.
+	// the wallet database. If a cipher seed mnemonic is specified, then the
+	// wallet, along with all of lnd's keys, will be derived from it. In that
+	// case, the wallet is rescanned from the birthday of the seed in order to
+	// recover all on-chain funds. Otherwise, a fresh seed will be generated.
 	CreateWallet(context.Context, *CreateWalletRequest) (*CreateWalletResponse, error)
 	// * lncli: `unlock`
 	// UnlockWallet is used at startup of lnd to provide a password to unlock
@@ -3806,57 +6237,512 @@ type WalletUnlockerServer interface {
 	UnlockWallet(context.Context, *UnlockWalletRequest) (*UnlockWalletResponse, error)
 }
 
-func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
-	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
+	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+}
+
+func _WalletUnlocker_GenSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/GenSeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, req.(*GenSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).CreateWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/CreateWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).CreateWallet(ctx, req.(*CreateWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.WalletUnlocker",
+	HandlerType: (*WalletUnlockerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenSeed",
+			Handler:    _WalletUnlocker_GenSeed_Handler,
+		},
+		{
+			MethodName: "CreateWallet",
+			Handler:    _WalletUnlocker_CreateWallet_Handler,
+		},
+		{
+			MethodName: "UnlockWallet",
+			Handler:    _WalletUnlocker_UnlockWallet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for RemoteSigner service
+
+type RemoteSignerClient interface {
+	// *
+	// DeriveNextKey derives a fresh key of the given key family and returns its
+	// public key. The watch-only node uses these keys for its side of new
+	// channels. The signer only ever signs with channel keys it issued this way.
+	DeriveNextKey(ctx context.Context, in *DeriveNextKeyRequest, opts ...grpc.CallOption) (*DeriveNextKeyResponse, error)
+	// *
+	// SignOutputRaw generates a signature for an input of the passed transaction
+	// according to the passed sign descriptor. The returned signature doesn't
+	// carry a sighash flag.
+	SignOutputRaw(ctx context.Context, in *SignOutputRawRequest, opts ...grpc.CallOption) (*SignOutputRawResponse, error)
+	// *
+	// ComputeInputScript generates the complete input script of an input of the
+	// passed transaction which spends an output of the watch-only node's
+	// on-chain wallet.
+	ComputeInputScript(ctx context.Context, in *ComputeInputScriptRequest, opts ...grpc.CallOption) (*ComputeInputScriptResponse, error)
+	// *
+	// SignMessageWithKey signs the double SHA-256 of the passed message under
+	// the private key of the given public key. Any message may be signed under
+	// the identity key, while channel keys only sign the announcements of the
+	// channels they fund.
+	SignMessageWithKey(ctx context.Context, in *SignMessageWithKeyRequest, opts ...grpc.CallOption) (*SignMessageWithKeyResponse, error)
+	// *
+	// SignDigestCompact generates a compact signature of the passed digest under
+	// the identity key.
+	SignDigestCompact(ctx context.Context, in *SignDigestCompactRequest, opts ...grpc.CallOption) (*SignDigestCompactResponse, error)
+	// *
+	// DeriveRevocationRoot derives the root of the revocation tree of a channel
+	// with the given node, salted by the hash of the block the channel funding
+	// began at.
+	DeriveRevocationRoot(ctx context.Context, in *DeriveRevocationRootRequest, opts ...grpc.CallOption) (*DeriveRevocationRootResponse, error)
+	// *
+	// IdentityPubKey returns the identity public key of the watch-only node.
+	IdentityPubKey(ctx context.Context, in *IdentityPubKeyRequest, opts ...grpc.CallOption) (*IdentityPubKeyResponse, error)
+	// *
+	// DeriveSharedKey performs an ECDH operation between the passed public key
+	// and the identity key, returning the SHA-256 of the compressed shared
+	// point.
+	DeriveSharedKey(ctx context.Context, in *DeriveSharedKeyRequest, opts ...grpc.CallOption) (*DeriveSharedKeyResponse, error)
+	// *
+	// ProcessOnionPacket peels the layer of the passed onion packet that is
+	// addressed to the identity key.
+	ProcessOnionPacket(ctx context.Context, in *ProcessOnionPacketRequest, opts ...grpc.CallOption) (*ProcessOnionPacketResponse, error)
+	// *
+	// DeriveBackupKey returns the key that the static channel backups of the
+	// watch-only node are encrypted under. The key isn't used for anything else.
+	DeriveBackupKey(ctx context.Context, in *DeriveBackupKeyRequest, opts ...grpc.CallOption) (*DeriveBackupKeyResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRemoteSignerClient(cc *grpc.ClientConn) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) DeriveNextKey(ctx context.Context, in *DeriveNextKeyRequest, opts ...grpc.CallOption) (*DeriveNextKeyResponse, error) {
+	out := new(DeriveNextKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/DeriveNextKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) SignOutputRaw(ctx context.Context, in *SignOutputRawRequest, opts ...grpc.CallOption) (*SignOutputRawResponse, error) {
+	out := new(SignOutputRawResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/SignOutputRaw", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ComputeInputScript(ctx context.Context, in *ComputeInputScriptRequest, opts ...grpc.CallOption) (*ComputeInputScriptResponse, error) {
+	out := new(ComputeInputScriptResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/ComputeInputScript", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) SignMessageWithKey(ctx context.Context, in *SignMessageWithKeyRequest, opts ...grpc.CallOption) (*SignMessageWithKeyResponse, error) {
+	out := new(SignMessageWithKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/SignMessageWithKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) SignDigestCompact(ctx context.Context, in *SignDigestCompactRequest, opts ...grpc.CallOption) (*SignDigestCompactResponse, error) {
+	out := new(SignDigestCompactResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/SignDigestCompact", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) DeriveRevocationRoot(ctx context.Context, in *DeriveRevocationRootRequest, opts ...grpc.CallOption) (*DeriveRevocationRootResponse, error) {
+	out := new(DeriveRevocationRootResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/DeriveRevocationRoot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) IdentityPubKey(ctx context.Context, in *IdentityPubKeyRequest, opts ...grpc.CallOption) (*IdentityPubKeyResponse, error) {
+	out := new(IdentityPubKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/IdentityPubKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) DeriveSharedKey(ctx context.Context, in *DeriveSharedKeyRequest, opts ...grpc.CallOption) (*DeriveSharedKeyResponse, error) {
+	out := new(DeriveSharedKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/DeriveSharedKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) ProcessOnionPacket(ctx context.Context, in *ProcessOnionPacketRequest, opts ...grpc.CallOption) (*ProcessOnionPacketResponse, error) {
+	out := new(ProcessOnionPacketResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/ProcessOnionPacket", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) DeriveBackupKey(ctx context.Context, in *DeriveBackupKeyRequest, opts ...grpc.CallOption) (*DeriveBackupKeyResponse, error) {
+	out := new(DeriveBackupKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.RemoteSigner/DeriveBackupKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RemoteSigner service
+
+type RemoteSignerServer interface {
+	// *
+	// DeriveNextKey derives a fresh key of the given key family and returns its
+	// public key. The watch-only node uses these keys for its side of new
+	// channels. The signer only ever signs with channel keys it issued this way.
+	DeriveNextKey(context.Context, *DeriveNextKeyRequest) (*DeriveNextKeyResponse, error)
+	// *
+	// SignOutputRaw generates a signature for an input of the passed transaction
+	// according to the passed sign descriptor. The returned signature doesn't
+	// carry a sighash flag.
+	SignOutputRaw(context.Context, *SignOutputRawRequest) (*SignOutputRawResponse, error)
+	// *
+	// ComputeInputScript generates the complete input script of an input of the
+	// passed transaction which spends an output of the watch-only node's
+	// on-chain wallet.
+	ComputeInputScript(context.Context, *ComputeInputScriptRequest) (*ComputeInputScriptResponse, error)
+	// *
+	// SignMessageWithKey signs the double SHA-256 of the passed message under
+	// the private key of the given public key. Any message may be signed under
+	// the identity key, while channel keys only sign the announcements of the
+	// channels they fund.
+	SignMessageWithKey(context.Context, *SignMessageWithKeyRequest) (*SignMessageWithKeyResponse, error)
+	// *
+	// SignDigestCompact generates a compact signature of the passed digest under
+	// the identity key.
+	SignDigestCompact(context.Context, *SignDigestCompactRequest) (*SignDigestCompactResponse, error)
+	// *
+	// DeriveRevocationRoot derives the root of the revocation tree of a channel
+	// with the given node, salted by the hash of the block the channel funding
+	// began at.
+	DeriveRevocationRoot(context.Context, *DeriveRevocationRootRequest) (*DeriveRevocationRootResponse, error)
+	// *
+	// IdentityPubKey returns the identity public key of the watch-only node.
+	IdentityPubKey(context.Context, *IdentityPubKeyRequest) (*IdentityPubKeyResponse, error)
+	// *
+	// DeriveSharedKey performs an ECDH operation between the passed public key
+	// and the identity key, returning the SHA-256 of the compressed shared
+	// point.
+	DeriveSharedKey(context.Context, *DeriveSharedKeyRequest) (*DeriveSharedKeyResponse, error)
+	// *
+	// ProcessOnionPacket peels the layer of the passed onion packet that is
+	// addressed to the identity key.
+	ProcessOnionPacket(context.Context, *ProcessOnionPacketRequest) (*ProcessOnionPacketResponse, error)
+	// *
+	// DeriveBackupKey returns the key that the static channel backups of the
+	// watch-only node are encrypted under. The key isn't used for anything else.
+	DeriveBackupKey(context.Context, *DeriveBackupKeyRequest) (*DeriveBackupKeyResponse, error)
+}
+
+func RegisterRemoteSignerServer(s *grpc.Server, srv RemoteSignerServer) {
+	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
+}
+
+func _RemoteSigner_DeriveNextKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveNextKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DeriveNextKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/DeriveNextKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DeriveNextKey(ctx, req.(*DeriveNextKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignOutputRawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).SignOutputRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/SignOutputRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).SignOutputRaw(ctx, req.(*SignOutputRawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_ComputeInputScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComputeInputScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).ComputeInputScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/ComputeInputScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).ComputeInputScript(ctx, req.(*ComputeInputScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_SignMessageWithKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageWithKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).SignMessageWithKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/SignMessageWithKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).SignMessageWithKey(ctx, req.(*SignMessageWithKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_SignDigestCompact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignDigestCompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).SignDigestCompact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/SignDigestCompact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).SignDigestCompact(ctx, req.(*SignDigestCompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_DeriveRevocationRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveRevocationRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DeriveRevocationRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/DeriveRevocationRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DeriveRevocationRoot(ctx, req.(*DeriveRevocationRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_IdentityPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityPubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).IdentityPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/IdentityPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).IdentityPubKey(ctx, req.(*IdentityPubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_DeriveSharedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveSharedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).DeriveSharedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.RemoteSigner/DeriveSharedKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).DeriveSharedKey(ctx, req.(*DeriveSharedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletUnlocker_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateWalletRequest)
+func _RemoteSigner_ProcessOnionPacket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessOnionPacketRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletUnlockerServer).CreateWallet(ctx, in)
+		return srv.(RemoteSignerServer).ProcessOnionPacket(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/CreateWallet",
+		FullMethod: "/lnrpc.RemoteSigner/ProcessOnionPacket",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).CreateWallet(ctx, req.(*CreateWalletRequest))
+		return srv.(RemoteSignerServer).ProcessOnionPacket(ctx, req.(*ProcessOnionPacketRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnlockWalletRequest)
+func _RemoteSigner_DeriveBackupKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveBackupKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+		return srv.(RemoteSignerServer).DeriveBackupKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+		FullMethod: "/lnrpc.RemoteSigner/DeriveBackupKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+		return srv.(RemoteSignerServer).DeriveBackupKey(ctx, req.(*DeriveBackupKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "lnrpc.WalletUnlocker",
-	HandlerType: (*WalletUnlockerServer)(nil),
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateWallet",
-			Handler:    _WalletUnlocker_CreateWallet_Handler,
+			MethodName: "DeriveNextKey",
+			Handler:    _RemoteSigner_DeriveNextKey_Handler,
 		},
 		{
-			MethodName: "UnlockWallet",
-			Handler:    _WalletUnlocker_UnlockWallet_Handler,
+			MethodName: "SignOutputRaw",
+			Handler:    _RemoteSigner_SignOutputRaw_Handler,
+		},
+		{
+			MethodName: "ComputeInputScript",
+			Handler:    _RemoteSigner_ComputeInputScript_Handler,
+		},
+		{
+			MethodName: "SignMessageWithKey",
+			Handler:    _RemoteSigner_SignMessageWithKey_Handler,
+		},
+		{
+			MethodName: "SignDigestCompact",
+			Handler:    _RemoteSigner_SignDigestCompact_Handler,
+		},
+		{
+			MethodName: "DeriveRevocationRoot",
+			Handler:    _RemoteSigner_DeriveRevocationRoot_Handler,
+		},
+		{
+			MethodName: "IdentityPubKey",
+			Handler:    _RemoteSigner_IdentityPubKey_Handler,
+		},
+		{
+			MethodName: "DeriveSharedKey",
+			Handler:    _RemoteSigner_DeriveSharedKey_Handler,
+		},
+		{
+			MethodName: "ProcessOnionPacket",
+			Handler:    _RemoteSigner_ProcessOnionPacket_Handler,
+		},
+		{
+			MethodName: "DeriveBackupKey",
+			Handler:    _RemoteSigner_DeriveBackupKey_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -3897,6 +6783,31 @@ type LightningClient interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee raises the fee rate of an unconfirmed transaction, such as a stuck
+	// funding or closing transaction. The passed outpoint must either be an
+	// unconfirmed output of the wallet, in which case it's spent by a child
+	// transaction paying for its parent (CPFP), or an output that's being swept,
+	// in which case its sweep is replaced. If neither target_conf, or
+	// sat_per_byte are set, then the fee rate for the default confirmation
+	// target is used.
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
+	// * lncli: `listunspent`
+	// ListUnspent returns the unspent outputs of the wallet that have between
+	// min_confs and max_confs confirmations. Leased outputs aren't included.
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	// * lncli: `leaseoutput`
+	// LeaseOutput excludes an unspent output of the wallet from automatic coin
+	// selection until the lease expires or is released. A leased output is only
+	// spent when explicitly selected as an input. Leases persist across restarts.
+	LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error)
+	// * lncli: `releaseoutput`
+	// ReleaseOutput releases the lease of an output, making it eligible for
+	// automatic coin selection again.
+	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
+	// * lncli: `listleases`
+	// ListLeases returns all currently leased outputs.
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
@@ -3938,6 +6849,11 @@ type LightningClient interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error)
+	// * lncli: `pendingsweeps`
+	// PendingSweeps returns a list of all the outputs recovered from closed
+	// channels that are waiting to be swept back into the wallet, along with
+	// the state of their sweep.
+	PendingSweeps(ctx context.Context, in *PendingSweepsRequest, opts ...grpc.CallOption) (*PendingSweepsResponse, error)
 	// * lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
@@ -3953,8 +6869,22 @@ type LightningClient interface {
 	// request to a remote peer. Users are able to specify a target number of
 	// blocks that the funding transaction should be confirmed in, or a manual fee
 	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
+	// lax block confirmation target is used. If psbt is set, then the funding
+	// transaction is assembled by an external wallet instead: a psbt_fund update
+	// carrying a PSBT that pays to the funding output is sent, and the funding
+	// flow pauses until the signed PSBT is passed to FundingPsbtFinalize.
 	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// * lncli: `psbtfinalize`
+	// FundingPsbtFinalize resumes a channel funding flow that's waiting for an
+	// external wallet to fund the channel. The signed PSBT must pay the funding
+	// amount to the funding address, and all of its inputs must be finalized
+	// witness inputs. The funding transaction is only broadcast once the remote
+	// peer has signed our commitment transaction.
+	FundingPsbtFinalize(ctx context.Context, in *FundingPsbtFinalizeRequest, opts ...grpc.CallOption) (*FundingPsbtFinalizeResponse, error)
+	// * lncli: `psbtcancel`
+	// FundingPsbtCancel aborts a channel funding flow that's waiting for an
+	// external wallet to fund the channel.
+	FundingPsbtCancel(ctx context.Context, in *FundingPsbtCancelRequest, opts ...grpc.CallOption) (*FundingPsbtCancelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -3981,9 +6911,33 @@ type LightningClient interface {
 	// duplicated invoices are rejected, therefore all invoices *must* have a
 	// unique payment preimage.
 	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// * lncli: `addholdinvoice`
+	// AddHoldInvoice adds a new hold invoice to the invoice database. Unlike
+	// regular invoices, only the payment hash of a hold invoice is known, and
+	// the HTLCs paying to it are held in the accepted state until the invoice
+	// is either settled using SettleInvoice, or canceled using CancelInvoice.
+	AddHoldInvoice(ctx context.Context, in *AddHoldInvoiceRequest, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the preimage of its
+	// payment hash, settling all HTLCs that are held for it.
+	SettleInvoice(ctx context.Context, in *SettleInvoiceMsg, opts ...grpc.CallOption) (*SettleInvoiceResp, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels an invoice that hasn't been settled yet, failing
+	// all HTLCs that are held for it. A canceled invoice can no longer be paid.
+	CancelInvoice(ctx context.Context, in *CancelInvoiceMsg, opts ...grpc.CallOption) (*CancelInvoiceResp, error)
+	// * lncli: `deletecanceledinvoices`
+	// DeleteCanceledInvoices deletes all invoices that have either been canceled
+	// or have expired from the database.
+	DeleteCanceledInvoices(ctx context.Context, in *DeleteCanceledInvoicesRequest, opts ...grpc.CallOption) (*DeleteCanceledInvoicesResponse, error)
 	// * lncli: `listinvoices`
 	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
+	// database. Any active debug invoices are ignored. It has full support for
+	// paginated responses, allowing users to query for specific invoices through
+	// their add_index. This can be done by using either the first_index_offset or
+	// last_index_offset fields included in the response as the index_offset of the
+	// next request. Set the reversed flag in order to paginate backwards from the
+	// most recently added invoices. If num_max_invoices isn't specified, then all
+	// matching invoices will be returned.
 	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
 	// * lncli: `lookupinvoice`
 	// LookupInvoice attemps to look up an invoice according to its payment hash.
@@ -3992,7 +6946,10 @@ type LightningClient interface {
 	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
 	// *
 	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
+	// notifying the client of newly added, accepted, settled, canceled and
+	// expired invoices. If the add_index or settle_index of the request are set,
+	// then all invoices added or settled since those indexes are sent first,
+	// allowing a client that reconnects to catch up on the events it missed.
 	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
 	// * lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
@@ -4000,8 +6957,14 @@ type LightningClient interface {
 	// payment request.
 	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
 	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
+	// ListPayments returns a list of all outgoing payments. Payments that failed
+	// or are still in flight are only included if requested.
 	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of the
+	// state of an outgoing payment. The current state of the payment is sent
+	// first, followed by each update until the payment has succeeded or failed.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
@@ -4030,6 +6993,19 @@ type LightningClient interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsualted within the HTLC.
 	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	// * lncli: `querymc`
+	// QueryMissionControl returns the payment attempt results that the router's
+	// mission control currently bases its channel success probabilities on.
+	QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error)
+	// * lncli: `importmc`
+	// ImportMissionControl adds a set of payment attempt results, such as those
+	// exported from another node with QueryMissionControl, to the history of
+	// mission control.
+	ImportMissionControl(ctx context.Context, in *ImportMissionControlRequest, opts ...grpc.CallOption) (*ImportMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all payment attempt results from mission
+	// control, returning it to a state as if no payments have been attempted.
+	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
@@ -4063,6 +7039,30 @@ type LightningClient interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(ctx context.Context, in *FeeUpdateRequest, opts ...grpc.CallOption) (*FeeUpdateResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the last 24
+	// hours of events are returned. The response is paginated, and the returned
+	// last_offset_index can be used as the index_offset of a subsequent query
+	// to continue where the response left off.
+	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportAllChannelBackups returns an encrypted static channel backup of all
+	// currently open channels. The backup is encrypted with a key derived from
+	// the node's identity key, and can later be used to recover the funds within
+	// the channels should the node lose its state.
+	ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a static
+	// channel backup, ensuring that it can be decrypted and parsed by this node.
+	VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts an encrypted static channel backup. For each
+	// channel within the backup, we'll connect to the remote party and ask them
+	// to force close the channel, then sweep our output from their commitment
+	// transaction once it confirms.
+	RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
 }
 
 type lightningClient struct {
@@ -4150,6 +7150,51 @@ func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opt
 	return out, nil
 }
 
+func (c *lightningClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListUnspent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error) {
+	out := new(LeaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LeaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error) {
+	out := new(ReleaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ReleaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error) {
+	out := new(ListLeasesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
 	out := new(NewAddressResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
@@ -4231,6 +7276,15 @@ func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChanne
 	return out, nil
 }
 
+func (c *lightningClient) PendingSweeps(ctx context.Context, in *PendingSweepsRequest, opts ...grpc.CallOption) (*PendingSweepsResponse, error) {
+	out := new(PendingSweepsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingSweeps", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
 	out := new(ListChannelsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
@@ -4281,6 +7335,24 @@ func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
 	return m, nil
 }
 
+func (c *lightningClient) FundingPsbtFinalize(ctx context.Context, in *FundingPsbtFinalizeRequest, opts ...grpc.CallOption) (*FundingPsbtFinalizeResponse, error) {
+	out := new(FundingPsbtFinalizeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FundingPsbtFinalize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FundingPsbtCancel(ctx context.Context, in *FundingPsbtCancelRequest, opts ...grpc.CallOption) (*FundingPsbtCancelResponse, error) {
+	out := new(FundingPsbtCancelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FundingPsbtCancel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
 	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
 	if err != nil {
@@ -4362,6 +7434,42 @@ func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...g
 	return out, nil
 }
 
+func (c *lightningClient) AddHoldInvoice(ctx context.Context, in *AddHoldInvoiceRequest, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
+	out := new(AddInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddHoldInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SettleInvoice(ctx context.Context, in *SettleInvoiceMsg, opts ...grpc.CallOption) (*SettleInvoiceResp, error) {
+	out := new(SettleInvoiceResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SettleInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CancelInvoice(ctx context.Context, in *CancelInvoiceMsg, opts ...grpc.CallOption) (*CancelInvoiceResp, error) {
+	out := new(CancelInvoiceResp)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CancelInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteCanceledInvoices(ctx context.Context, in *DeleteCanceledInvoicesRequest, opts ...grpc.CallOption) (*DeleteCanceledInvoicesResponse, error) {
+	out := new(DeleteCanceledInvoicesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteCanceledInvoices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
 	out := new(ListInvoiceResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
@@ -4430,6 +7538,38 @@ func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequ
 	return out, nil
 }
 
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*Payment, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*Payment, error) {
+	m := new(Payment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
 	out := new(DeleteAllPaymentsResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
@@ -4475,6 +7615,33 @@ func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesReques
 	return out, nil
 }
 
+func (c *lightningClient) QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error) {
+	out := new(QueryMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportMissionControl(ctx context.Context, in *ImportMissionControlRequest, opts ...grpc.CallOption) (*ImportMissionControlResponse, error) {
+	out := new(ImportMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error) {
+	out := new(ResetMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ResetMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
 	out := new(NetworkInfo)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
@@ -4494,7 +7661,7 @@ func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts
 }
 
 func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4561,6 +7728,42 @@ func (c *lightningClient) UpdateFees(ctx context.Context, in *FeeUpdateRequest,
 	return out, nil
 }
 
+func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
+	out := new(ForwardingHistoryResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportAllChannelBackups(ctx context.Context, in *ChanBackupExportRequest, opts ...grpc.CallOption) (*ChanBackupSnapshot, error) {
+	out := new(ChanBackupSnapshot)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportAllChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyChanBackup(ctx context.Context, in *ChanBackupSnapshot, opts ...grpc.CallOption) (*VerifyChanBackupResponse, error) {
+	out := new(VerifyChanBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyChanBackup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RestoreChannelBackups(ctx context.Context, in *RestoreChanBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	out := new(RestoreBackupResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/RestoreChannelBackups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Lightning service
 
 type LightningServer interface {
@@ -4595,6 +7798,31 @@ type LightningServer interface {
 	// the internal wallet will consult its fee model to determine a fee for the
 	// default confirmation target.
 	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	// * lncli: `bumpfee`
+	// BumpFee raises the fee rate of an unconfirmed transaction, such as a stuck
+	// funding or closing transaction. The passed outpoint must either be an
+	// unconfirmed output of the wallet, in which case it's spent by a child
+	// transaction paying for its parent (CPFP), or an output that's being swept,
+	// in which case its sweep is replaced. If neither target_conf, or
+	// sat_per_byte are set, then the fee rate for the default confirmation
+	// target is used.
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	// * lncli: `listunspent`
+	// ListUnspent returns the unspent outputs of the wallet that have between
+	// min_confs and max_confs confirmations. Leased outputs aren't included.
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	// * lncli: `leaseoutput`
+	// LeaseOutput excludes an unspent output of the wallet from automatic coin
+	// selection until the lease expires or is released. A leased output is only
+	// spent when explicitly selected as an input. Leases persist across restarts.
+	LeaseOutput(context.Context, *LeaseOutputRequest) (*LeaseOutputResponse, error)
+	// * lncli: `releaseoutput`
+	// ReleaseOutput releases the lease of an output, making it eligible for
+	// automatic coin selection again.
+	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
+	// * lncli: `listleases`
+	// ListLeases returns all currently leased outputs.
+	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
 	// * lncli: `newaddress`
 	// NewAddress creates a new address under control of the local wallet.
 	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
@@ -4636,6 +7864,11 @@ type LightningServer interface {
 	// workflow and is waiting for confirmations for the funding txn, or is in the
 	// process of closure, either initiated cooperatively or non-cooperatively.
 	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
+	// * lncli: `pendingsweeps`
+	// PendingSweeps returns a list of all the outputs recovered from closed
+	// channels that are waiting to be swept back into the wallet, along with
+	// the state of their sweep.
+	PendingSweeps(context.Context, *PendingSweepsRequest) (*PendingSweepsResponse, error)
 	// * lncli: `listchannels`
 	// ListChannels returns a description of all the open channels that this node
 	// is a participant in.
@@ -4651,8 +7884,22 @@ type LightningServer interface {
 	// request to a remote peer. Users are able to specify a target number of
 	// blocks that the funding transaction should be confirmed in, or a manual fee
 	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
+	// lax block confirmation target is used. If psbt is set, then the funding
+	// transaction is assembled by an external wallet instead: a psbt_fund update
+	// carrying a PSBT that pays to the funding output is sent, and the funding
+	// flow pauses until the signed PSBT is passed to FundingPsbtFinalize.
 	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// * lncli: `psbtfinalize`
+	// FundingPsbtFinalize resumes a channel funding flow that's waiting for an
+	// external wallet to fund the channel. The signed PSBT must pay the funding
+	// amount to the funding address, and all of its inputs must be finalized
+	// witness inputs. The funding transaction is only broadcast once the remote
+	// peer has signed our commitment transaction.
+	FundingPsbtFinalize(context.Context, *FundingPsbtFinalizeRequest) (*FundingPsbtFinalizeResponse, error)
+	// * lncli: `psbtcancel`
+	// FundingPsbtCancel aborts a channel funding flow that's waiting for an
+	// external wallet to fund the channel.
+	FundingPsbtCancel(context.Context, *FundingPsbtCancelRequest) (*FundingPsbtCancelResponse, error)
 	// * lncli: `closechannel`
 	// CloseChannel attempts to close an active channel identified by its channel
 	// outpoint (ChannelPoint). The actions of this method can additionally be
@@ -4679,9 +7926,33 @@ type LightningServer interface {
 	// duplicated invoices are rejected, therefore all invoices *must* have a
 	// unique payment preimage.
 	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
+	// * lncli: `addholdinvoice`
+	// AddHoldInvoice adds a new hold invoice to the invoice database. Unlike
+	// regular invoices, only the payment hash of a hold invoice is known, and
+	// the HTLCs paying to it are held in the accepted state until the invoice
+	// is either settled using SettleInvoice, or canceled using CancelInvoice.
+	AddHoldInvoice(context.Context, *AddHoldInvoiceRequest) (*AddInvoiceResponse, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the preimage of its
+	// payment hash, settling all HTLCs that are held for it.
+	SettleInvoice(context.Context, *SettleInvoiceMsg) (*SettleInvoiceResp, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels an invoice that hasn't been settled yet, failing
+	// all HTLCs that are held for it. A canceled invoice can no longer be paid.
+	CancelInvoice(context.Context, *CancelInvoiceMsg) (*CancelInvoiceResp, error)
+	// * lncli: `deletecanceledinvoices`
+	// DeleteCanceledInvoices deletes all invoices that have either been canceled
+	// or have expired from the database.
+	DeleteCanceledInvoices(context.Context, *DeleteCanceledInvoicesRequest) (*DeleteCanceledInvoicesResponse, error)
 	// * lncli: `listinvoices`
 	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
+	// database. Any active debug invoices are ignored. It has full support for
+	// paginated responses, allowing users to query for specific invoices through
+	// their add_index. This can be done by using either the first_index_offset or
+	// last_index_offset fields included in the response as the index_offset of the
+	// next request. Set the reversed flag in order to paginate backwards from the
+	// most recently added invoices. If num_max_invoices isn't specified, then all
+	// matching invoices will be returned.
 	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
 	// * lncli: `lookupinvoice`
 	// LookupInvoice attemps to look up an invoice according to its payment hash.
@@ -4690,7 +7961,10 @@ type LightningServer interface {
 	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
 	// *
 	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
+	// notifying the client of newly added, accepted, settled, canceled and
+	// expired invoices. If the add_index or settle_index of the request are set,
+	// then all invoices added or settled since those indexes are sent first,
+	// allowing a client that reconnects to catch up on the events it missed.
 	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
 	// * lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
@@ -4698,8 +7972,14 @@ type LightningServer interface {
 	// payment request.
 	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
 	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
+	// ListPayments returns a list of all outgoing payments. Payments that failed
+	// or are still in flight are only included if requested.
 	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of the
+	// state of an outgoing payment. The current state of the payment is sent
+	// first, followed by each update until the payment has succeeded or failed.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
@@ -4728,6 +8008,19 @@ type LightningServer interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsualted within the HTLC.
 	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	// * lncli: `querymc`
+	// QueryMissionControl returns the payment attempt results that the router's
+	// mission control currently bases its channel success probabilities on.
+	QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error)
+	// * lncli: `importmc`
+	// ImportMissionControl adds a set of payment attempt results, such as those
+	// exported from another node with QueryMissionControl, to the history of
+	// mission control.
+	ImportMissionControl(context.Context, *ImportMissionControlRequest) (*ImportMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all payment attempt results from mission
+	// control, returning it to a state as if no payments have been attempted.
+	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
@@ -4761,6 +8054,30 @@ type LightningServer interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(context.Context, *FeeUpdateRequest) (*FeeUpdateResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the last 24
+	// hours of events are returned. The response is paginated, and the returned
+	// last_offset_index can be used as the index_offset of a subsequent query
+	// to continue where the response left off.
+	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// * lncli: `exportchanbackup`
+	// ExportAllChannelBackups returns an encrypted static channel backup of all
+	// currently open channels. The backup is encrypted with a key derived from
+	// the node's identity key, and can later be used to recover the funds within
+	// the channels should the node lose its state.
+	ExportAllChannelBackups(context.Context, *ChanBackupExportRequest) (*ChanBackupSnapshot, error)
+	// * lncli: `verifychanbackup`
+	// VerifyChanBackup allows a caller to verify the integrity of a static
+	// channel backup, ensuring that it can be decrypted and parsed by this node.
+	VerifyChanBackup(context.Context, *ChanBackupSnapshot) (*VerifyChanBackupResponse, error)
+	// * lncli: `restorechanbackup`
+	// RestoreChannelBackups accepts an encrypted static channel backup. For each
+	// channel within the backup, we'll connect to the remote party and ask them
+	// to force close the channel, then sweep our output from their commitment
+	// transaction once it confirms.
+	RestoreChannelBackups(context.Context, *RestoreChanBackupRequest) (*RestoreBackupResponse, error)
 }
 
 func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
@@ -4856,24 +8173,114 @@ type lightningSubscribeTransactionsServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
-	return x.ServerStream.SendMsg(m)
+func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListUnspent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LeaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LeaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LeaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LeaseOutput(ctx, req.(*LeaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ReleaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ReleaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ReleaseOutput(ctx, req.(*ReleaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendManyRequest)
+func _Lightning_ListLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeasesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendMany(ctx, in)
+		return srv.(LightningServer).ListLeases(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendMany",
+		FullMethod: "/lnrpc.Lightning/ListLeases",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+		return srv.(LightningServer).ListLeases(ctx, req.(*ListLeasesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -5040,6 +8447,24 @@ func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_PendingSweeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingSweepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PendingSweeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingSweeps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingSweeps(ctx, req.(*PendingSweepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListChannelsRequest)
 	if err := dec(in); err != nil {
@@ -5097,6 +8522,42 @@ func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Lightning_FundingPsbtFinalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingPsbtFinalizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).FundingPsbtFinalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/FundingPsbtFinalize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).FundingPsbtFinalize(ctx, req.(*FundingPsbtFinalizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_FundingPsbtCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundingPsbtCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).FundingPsbtCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/FundingPsbtCancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).FundingPsbtCancel(ctx, req.(*FundingPsbtCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(CloseChannelRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -5180,6 +8641,78 @@ func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_AddHoldInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddHoldInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AddHoldInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AddHoldInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AddHoldInvoice(ctx, req.(*AddHoldInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SettleInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettleInvoiceMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SettleInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SettleInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SettleInvoice(ctx, req.(*SettleInvoiceMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_CancelInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelInvoiceMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).CancelInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/CancelInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).CancelInvoice(ctx, req.(*CancelInvoiceMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeleteCanceledInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCanceledInvoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeleteCanceledInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeleteCanceledInvoices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeleteCanceledInvoices(ctx, req.(*DeleteCanceledInvoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListInvoiceRequest)
 	if err := dec(in); err != nil {
@@ -5273,6 +8806,27 @@ func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
+}
+
+type Lightning_TrackPaymentServer interface {
+	Send(*Payment) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentServer) Send(m *Payment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAllPaymentsRequest)
 	if err := dec(in); err != nil {
@@ -5363,6 +8917,60 @@ func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_QueryMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).QueryMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryMissionControl(ctx, req.(*QueryMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ImportMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportMissionControl(ctx, req.(*ImportMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ResetMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ResetMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ResetMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ResetMissionControl(ctx, req.(*ResetMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NetworkInfoRequest)
 	if err := dec(in); err != nil {
@@ -5492,6 +9100,78 @@ func _Lightning_UpdateFees_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ForwardingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ForwardingHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ForwardingHistory(ctx, req.(*ForwardingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ExportAllChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportAllChannelBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportAllChannelBackups(ctx, req.(*ChanBackupExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_VerifyChanBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanBackupSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).VerifyChanBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/VerifyChanBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).VerifyChanBackup(ctx, req.(*ChanBackupSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_RestoreChannelBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChanBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/RestoreChannelBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).RestoreChannelBackups(ctx, req.(*RestoreChanBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Lightning_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lnrpc.Lightning",
 	HandlerType: (*LightningServer)(nil),
@@ -5516,6 +9196,26 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendMany",
 			Handler:    _Lightning_SendMany_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Lightning_BumpFee_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _Lightning_ListUnspent_Handler,
+		},
+		{
+			MethodName: "LeaseOutput",
+			Handler:    _Lightning_LeaseOutput_Handler,
+		},
+		{
+			MethodName: "ReleaseOutput",
+			Handler:    _Lightning_ReleaseOutput_Handler,
+		},
+		{
+			MethodName: "ListLeases",
+			Handler:    _Lightning_ListLeases_Handler,
+		},
 		{
 			MethodName: "NewAddress",
 			Handler:    _Lightning_NewAddress_Handler,
@@ -5552,6 +9252,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
 		},
+		{
+			MethodName: "PendingSweeps",
+			Handler:    _Lightning_PendingSweeps_Handler,
+		},
 		{
 			MethodName: "ListChannels",
 			Handler:    _Lightning_ListChannels_Handler,
@@ -5560,6 +9264,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "OpenChannelSync",
 			Handler:    _Lightning_OpenChannelSync_Handler,
 		},
+		{
+			MethodName: "FundingPsbtFinalize",
+			Handler:    _Lightning_FundingPsbtFinalize_Handler,
+		},
+		{
+			MethodName: "FundingPsbtCancel",
+			Handler:    _Lightning_FundingPsbtCancel_Handler,
+		},
 		{
 			MethodName: "SendPaymentSync",
 			Handler:    _Lightning_SendPaymentSync_Handler,
@@ -5568,6 +9280,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddInvoice",
 			Handler:    _Lightning_AddInvoice_Handler,
 		},
+		{
+			MethodName: "AddHoldInvoice",
+			Handler:    _Lightning_AddHoldInvoice_Handler,
+		},
+		{
+			MethodName: "SettleInvoice",
+			Handler:    _Lightning_SettleInvoice_Handler,
+		},
+		{
+			MethodName: "CancelInvoice",
+			Handler:    _Lightning_CancelInvoice_Handler,
+		},
+		{
+			MethodName: "DeleteCanceledInvoices",
+			Handler:    _Lightning_DeleteCanceledInvoices_Handler,
+		},
 		{
 			MethodName: "ListInvoices",
 			Handler:    _Lightning_ListInvoices_Handler,
@@ -5604,6 +9332,18 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRoutes",
 			Handler:    _Lightning_QueryRoutes_Handler,
 		},
+		{
+			MethodName: "QueryMissionControl",
+			Handler:    _Lightning_QueryMissionControl_Handler,
+		},
+		{
+			MethodName: "ImportMissionControl",
+			Handler:    _Lightning_ImportMissionControl_Handler,
+		},
+		{
+			MethodName: "ResetMissionControl",
+			Handler:    _Lightning_ResetMissionControl_Handler,
+		},
 		{
 			MethodName: "GetNetworkInfo",
 			Handler:    _Lightning_GetNetworkInfo_Handler,
@@ -5628,6 +9368,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFees",
 			Handler:    _Lightning_UpdateFees_Handler,
 		},
+		{
+			MethodName: "ForwardingHistory",
+			Handler:    _Lightning_ForwardingHistory_Handler,
+		},
+		{
+			MethodName: "ExportAllChannelBackups",
+			Handler:    _Lightning_ExportAllChannelBackups_Handler,
+		},
+		{
+			MethodName: "VerifyChanBackup",
+			Handler:    _Lightning_VerifyChanBackup_Handler,
+		},
+		{
+			MethodName: "RestoreChannelBackups",
+			Handler:    _Lightning_RestoreChannelBackups_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -5656,6 +9412,11 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeInvoices_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeChannelGraph",
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
@@ -5668,312 +9429,507 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 4899 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3b, 0x4d, 0x8f, 0x1c, 0x49,
-	0x56, 0xce, 0xea, 0xea, 0xee, 0xaa, 0x57, 0x55, 0xfd, 0x11, 0xdd, 0x6e, 0x97, 0xcb, 0x9e, 0x59,
-	0x3b, 0x76, 0x34, 0xd3, 0x78, 0x47, 0x6e, 0x4f, 0x2f, 0x3b, 0xcc, 0x8e, 0x81, 0x91, 0x3f, 0xa7,
-	0x87, 0xed, 0xf1, 0xf4, 0x66, 0x7b, 0xc6, 0xb0, 0x2b, 0x54, 0x64, 0x57, 0x45, 0x57, 0xe7, 0x3a,
-	0x2b, 0x33, 0x27, 0x33, 0xaa, 0xdb, 0xb5, 0x96, 0x25, 0x58, 0x10, 0x27, 0xd0, 0x1e, 0x40, 0xa0,
-	0x3d, 0x2c, 0x42, 0x42, 0x48, 0x70, 0x80, 0x3f, 0x80, 0xc4, 0x0f, 0x40, 0x42, 0x20, 0xed, 0x09,
-	0x89, 0x1b, 0x9c, 0xf6, 0xc2, 0x89, 0x3b, 0x7a, 0xf1, 0x95, 0x11, 0x99, 0xd9, 0xb6, 0x97, 0x5d,
-	0xb8, 0x55, 0xbc, 0x78, 0xf9, 0x22, 0xe2, 0xc5, 0x8b, 0xf7, 0x5d, 0xd0, 0xce, 0xd2, 0xd1, 0xcd,
-	0x34, 0x4b, 0x78, 0x42, 0x16, 0xa3, 0x38, 0x4b, 0x47, 0x83, 0xab, 0x93, 0x24, 0x99, 0x44, 0x6c,
-	0x27, 0x48, 0xc3, 0x9d, 0x20, 0x8e, 0x13, 0x1e, 0xf0, 0x30, 0x89, 0x73, 0x89, 0x44, 0xdf, 0x83,
-	0x8d, 0x7b, 0x19, 0x0b, 0x38, 0x7b, 0x12, 0x44, 0x11, 0xe3, 0x3e, 0xfb, 0x72, 0xc6, 0x72, 0x4e,
-	0x06, 0xd0, 0x4a, 0x83, 0x3c, 0x3f, 0x4b, 0xb2, 0x71, 0xdf, 0xbb, 0xe6, 0x6d, 0x77, 0x7d, 0x33,
-	0xa6, 0x5b, 0xb0, 0xe9, 0x7e, 0x92, 0xa7, 0x49, 0x9c, 0x33, 0x24, 0xf5, 0x79, 0x1c, 0x25, 0xa3,
-	0xa7, 0x3f, 0x13, 0x29, 0xf7, 0x13, 0x45, 0xea, 0x47, 0x0d, 0xe8, 0x3c, 0xce, 0x82, 0x38, 0x0f,
-	0x46, 0xb8, 0x59, 0xd2, 0x87, 0x65, 0xfe, 0x6c, 0x78, 0x12, 0xe4, 0x27, 0x82, 0x44, 0xdb, 0xd7,
-	0x43, 0xb2, 0x05, 0x4b, 0xc1, 0x34, 0x99, 0xc5, 0xbc, 0xdf, 0xb8, 0xe6, 0x6d, 0x2f, 0xf8, 0x6a,
-	0x44, 0xde, 0x85, 0xf5, 0x78, 0x36, 0x1d, 0x8e, 0x92, 0xf8, 0x38, 0xcc, 0xa6, 0xf2, 0xc8, 0xfd,
-	0x85, 0x6b, 0xde, 0xf6, 0xa2, 0x5f, 0x9d, 0x20, 0x6f, 0x02, 0x1c, 0xe1, 0x36, 0xe4, 0x12, 0x4d,
-	0xb1, 0x84, 0x05, 0x21, 0x14, 0xba, 0x6a, 0xc4, 0xc2, 0xc9, 0x09, 0xef, 0x2f, 0x0a, 0x42, 0x0e,
-	0x0c, 0x69, 0xf0, 0x70, 0xca, 0x86, 0x39, 0x0f, 0xa6, 0x69, 0x7f, 0x49, 0xec, 0xc6, 0x82, 0x88,
-	0xf9, 0x84, 0x07, 0xd1, 0xf0, 0x98, 0xb1, 0xbc, 0xbf, 0xac, 0xe6, 0x0d, 0x84, 0xbc, 0x0d, 0x2b,
-	0x63, 0x96, 0xf3, 0x61, 0x30, 0x1e, 0x67, 0x2c, 0xcf, 0x59, 0xde, 0x6f, 0x5d, 0x5b, 0xd8, 0x6e,
-	0xfb, 0x25, 0x28, 0xed, 0xc3, 0xd6, 0xc7, 0x8c, 0x5b, 0xdc, 0xc9, 0x15, 0xa7, 0xe9, 0x3e, 0x10,
-	0x0b, 0x7c, 0x9f, 0xf1, 0x20, 0x8c, 0x72, 0xf2, 0x3e, 0x74, 0xb9, 0x85, 0xdc, 0xf7, 0xae, 0x2d,
-	0x6c, 0x77, 0x76, 0xc9, 0x4d, 0x21, 0x1d, 0x37, 0xad, 0x0f, 0x7c, 0x07, 0x8f, 0xfe, 0xab, 0x07,
-	0x9d, 0x43, 0x16, 0x8f, 0xf5, 0x3d, 0x12, 0x68, 0xe2, 0x4e, 0xd4, 0x1d, 0x8a, 0xdf, 0xe4, 0x2b,
-	0xd0, 0x11, 0xbb, 0xcb, 0x79, 0x16, 0xc6, 0x13, 0x71, 0x05, 0x6d, 0x1f, 0x10, 0x74, 0x28, 0x20,
-	0x64, 0x0d, 0x16, 0x82, 0x29, 0x17, 0x8c, 0x5f, 0xf0, 0xf1, 0x27, 0xb9, 0x0e, 0xdd, 0x34, 0x98,
-	0x4f, 0x59, 0xcc, 0x0b, 0x66, 0x77, 0xfd, 0x8e, 0x82, 0xed, 0x21, 0xb7, 0x6f, 0xc2, 0x86, 0x8d,
-	0xa2, 0xa9, 0x2f, 0x0a, 0xea, 0xeb, 0x16, 0xa6, 0x5a, 0xe4, 0x1d, 0x58, 0xd5, 0xf8, 0x99, 0xdc,
-	0xac, 0x60, 0x7f, 0xdb, 0x5f, 0x51, 0x60, 0xcd, 0xa0, 0x3f, 0xf5, 0xa0, 0x2b, 0x8f, 0x24, 0xe5,
-	0x8c, 0xbc, 0x05, 0x3d, 0xfd, 0x25, 0xcb, 0xb2, 0x24, 0x53, 0xd2, 0xe5, 0x02, 0xc9, 0x0d, 0x58,
-	0xd3, 0x80, 0x34, 0x63, 0xe1, 0x34, 0x98, 0x30, 0x71, 0xd4, 0xae, 0x5f, 0x81, 0x93, 0xdd, 0x82,
-	0x62, 0x96, 0xcc, 0x38, 0x13, 0x47, 0xef, 0xec, 0x76, 0x15, 0xbb, 0x7d, 0x84, 0xf9, 0x2e, 0x0a,
-	0xfd, 0x81, 0x07, 0xdd, 0x7b, 0x27, 0x41, 0x1c, 0xb3, 0xe8, 0x20, 0x09, 0x63, 0x8e, 0xe2, 0x76,
-	0x3c, 0x8b, 0xc7, 0x61, 0x3c, 0x19, 0xf2, 0x67, 0xa1, 0x7e, 0x36, 0x0e, 0x0c, 0x37, 0x65, 0x8f,
-	0x91, 0x49, 0x8a, 0xff, 0x15, 0x38, 0xd2, 0x4b, 0x66, 0x3c, 0x9d, 0xf1, 0x61, 0x18, 0x8f, 0xd9,
-	0x33, 0xb1, 0xa7, 0x9e, 0xef, 0xc0, 0xe8, 0xaf, 0xc3, 0xda, 0x3e, 0xca, 0x71, 0x1c, 0xc6, 0x93,
-	0x3b, 0x52, 0xd8, 0xf0, 0x71, 0xa5, 0xb3, 0xa3, 0xa7, 0x6c, 0xae, 0xf8, 0xa2, 0x46, 0x28, 0x0a,
-	0x27, 0x49, 0xce, 0xd5, 0x7a, 0xe2, 0x37, 0xfd, 0x0f, 0x0f, 0x56, 0x91, 0xb7, 0x9f, 0x06, 0xf1,
-	0x5c, 0x8b, 0xcc, 0x3e, 0x74, 0x91, 0xd4, 0xe3, 0xe4, 0x8e, 0x7c, 0xa2, 0x52, 0xf4, 0xb6, 0x15,
-	0x2f, 0x4a, 0xd8, 0x37, 0x6d, 0xd4, 0x07, 0x31, 0xcf, 0xe6, 0xbe, 0xf3, 0x35, 0x0a, 0x1b, 0x0f,
-	0xb2, 0x09, 0xe3, 0xe2, 0xf1, 0xaa, 0xc7, 0x0c, 0x12, 0x74, 0x2f, 0x89, 0x8f, 0xc9, 0x35, 0xe8,
-	0xe6, 0x01, 0x1f, 0xa6, 0x2c, 0x1b, 0x1e, 0xcd, 0x39, 0x13, 0x02, 0xb3, 0xe0, 0x43, 0x1e, 0xf0,
-	0x03, 0x96, 0xdd, 0x9d, 0x73, 0x36, 0xf8, 0x08, 0xd6, 0x2b, 0xab, 0xa0, 0x8c, 0x16, 0x47, 0xc4,
-	0x9f, 0x64, 0x13, 0x16, 0x4f, 0x83, 0x68, 0xc6, 0x94, 0x4e, 0x91, 0x83, 0x0f, 0x1b, 0x1f, 0x78,
-	0xf4, 0x6d, 0x58, 0x2b, 0xb6, 0xad, 0x84, 0x88, 0x40, 0xd3, 0xdc, 0x52, 0xdb, 0x17, 0xbf, 0xe9,
-	0xef, 0x79, 0x12, 0xf1, 0x5e, 0x12, 0x9a, 0xf7, 0x89, 0x88, 0xf8, 0x8c, 0x35, 0x22, 0xfe, 0x3e,
-	0x57, 0x7f, 0xfd, 0xfc, 0x87, 0xa5, 0xef, 0xc0, 0xba, 0xb5, 0x85, 0x97, 0x6c, 0xf6, 0x2f, 0x3c,
-	0x58, 0x7f, 0xc4, 0xce, 0xd4, 0xad, 0xeb, 0xdd, 0x7e, 0x00, 0x4d, 0x3e, 0x4f, 0x99, 0xc0, 0x5c,
-	0xd9, 0x7d, 0x4b, 0x5d, 0x5a, 0x05, 0xef, 0xa6, 0x1a, 0x3e, 0x9e, 0xa7, 0xcc, 0x17, 0x5f, 0xd0,
-	0xcf, 0xa0, 0x63, 0x01, 0xc9, 0x25, 0xd8, 0x78, 0xf2, 0xc9, 0xe3, 0x47, 0x0f, 0x0e, 0x0f, 0x87,
-	0x07, 0x9f, 0xdf, 0xfd, 0xd6, 0x83, 0xdf, 0x1a, 0xee, 0xdd, 0x39, 0xdc, 0x5b, 0xbb, 0x40, 0xb6,
-	0x80, 0x3c, 0x7a, 0x70, 0xf8, 0xf8, 0xc1, 0x7d, 0x07, 0xee, 0x91, 0x55, 0xe8, 0xd8, 0x80, 0x06,
-	0x1d, 0x40, 0xff, 0x11, 0x3b, 0x7b, 0x12, 0xf2, 0x98, 0xe5, 0xb9, 0xbb, 0x3c, 0xbd, 0x09, 0xc4,
-	0xde, 0x93, 0x3a, 0x66, 0x1f, 0x96, 0x95, 0xc6, 0xd4, 0x06, 0x43, 0x0d, 0xe9, 0xdb, 0x40, 0x0e,
-	0xc3, 0x49, 0xfc, 0x29, 0xcb, 0xf3, 0x60, 0xc2, 0xf4, 0x61, 0xd7, 0x60, 0x61, 0x9a, 0x4f, 0xd4,
-	0x43, 0xc3, 0x9f, 0xf4, 0xeb, 0xb0, 0xe1, 0xe0, 0x29, 0xc2, 0x57, 0xa1, 0x9d, 0x87, 0x93, 0x38,
-	0xe0, 0xb3, 0x8c, 0x29, 0xd2, 0x05, 0x80, 0x3e, 0x84, 0xcd, 0x2f, 0x58, 0x16, 0x1e, 0xcf, 0x5f,
-	0x45, 0xde, 0xa5, 0xd3, 0x28, 0xd3, 0x79, 0x00, 0x17, 0x4b, 0x74, 0xd4, 0xf2, 0x52, 0x32, 0xd5,
-	0xfd, 0xb5, 0x7c, 0x39, 0xb0, 0xde, 0x69, 0xc3, 0x7e, 0xa7, 0xf4, 0x73, 0x20, 0xf7, 0x92, 0x38,
-	0x66, 0x23, 0x7e, 0xc0, 0x58, 0xa6, 0x37, 0xf3, 0x35, 0x4b, 0x0c, 0x3b, 0xbb, 0x97, 0xd4, 0xc5,
-	0x96, 0x1f, 0xbf, 0x92, 0x4f, 0x02, 0xcd, 0x94, 0x65, 0x53, 0x41, 0xb8, 0xe5, 0x8b, 0xdf, 0x74,
-	0x07, 0x36, 0x1c, 0xb2, 0x05, 0xcf, 0x53, 0xc6, 0xb2, 0xa1, 0xda, 0xdd, 0xa2, 0xaf, 0x87, 0xf4,
-	0x3d, 0xb8, 0x78, 0x3f, 0xcc, 0x47, 0xd5, 0xad, 0xe0, 0x27, 0xb3, 0xa3, 0x61, 0xf1, 0xfc, 0xf4,
-	0x10, 0xad, 0x5c, 0xf9, 0x13, 0xe5, 0x1b, 0xfc, 0xa1, 0x07, 0xcd, 0xbd, 0xc7, 0xfb, 0xf7, 0xd0,
-	0xb1, 0x08, 0xe3, 0x51, 0x32, 0x45, 0xdb, 0x20, 0xd9, 0x61, 0xc6, 0xe7, 0x3e, 0xab, 0xab, 0xd0,
-	0x16, 0x26, 0x05, 0x0d, 0xb7, 0x78, 0x54, 0x5d, 0xbf, 0x00, 0xa0, 0xd3, 0xc0, 0x9e, 0xa5, 0x61,
-	0x26, 0xbc, 0x02, 0x6d, 0xeb, 0x9b, 0x42, 0x59, 0x56, 0x27, 0xe8, 0x4f, 0x9b, 0xd0, 0xbb, 0x33,
-	0xe2, 0xe1, 0x29, 0x53, 0xca, 0x5b, 0xac, 0x2a, 0x00, 0x6a, 0x3f, 0x6a, 0x84, 0x66, 0x26, 0x63,
-	0xd3, 0x84, 0xb3, 0xa1, 0x73, 0x4d, 0x2e, 0x10, 0xb1, 0x46, 0x92, 0xd0, 0x30, 0x45, 0x33, 0x20,
-	0xf6, 0xd7, 0xf6, 0x5d, 0x20, 0xb2, 0x0c, 0x01, 0xc8, 0x65, 0xdc, 0x59, 0xd3, 0xd7, 0x43, 0xe4,
-	0xc7, 0x28, 0x48, 0x83, 0x51, 0xc8, 0xe7, 0x4a, 0x1b, 0x98, 0x31, 0xd2, 0x8e, 0x92, 0x51, 0x10,
-	0x0d, 0x8f, 0x82, 0x28, 0x88, 0x47, 0x4c, 0xf9, 0x27, 0x2e, 0x10, 0x5d, 0x10, 0xb5, 0x25, 0x8d,
-	0x26, 0xdd, 0x94, 0x12, 0x14, 0x5d, 0x99, 0x51, 0x32, 0x9d, 0x86, 0x1c, 0x3d, 0x97, 0x7e, 0x4b,
-	0x6a, 0x9e, 0x02, 0x22, 0x4e, 0x22, 0x47, 0x67, 0x92, 0x87, 0x6d, 0xb9, 0x9a, 0x03, 0x44, 0x2a,
-	0xc7, 0x8c, 0x09, 0x0d, 0xf6, 0xf4, 0xac, 0x0f, 0x92, 0x4a, 0x01, 0xc1, 0xdb, 0x98, 0xc5, 0x39,
-	0xe3, 0x3c, 0x62, 0x63, 0xb3, 0xa1, 0x8e, 0x40, 0xab, 0x4e, 0x90, 0x5b, 0xb0, 0x21, 0x9d, 0xa9,
-	0x3c, 0xe0, 0x49, 0x7e, 0x12, 0xe6, 0xc3, 0x9c, 0xc5, 0xbc, 0xdf, 0x15, 0xf8, 0x75, 0x53, 0xe4,
-	0x03, 0xb8, 0x54, 0x02, 0x67, 0x6c, 0xc4, 0xc2, 0x53, 0x36, 0xee, 0xf7, 0xc4, 0x57, 0xe7, 0x4d,
-	0x93, 0x6b, 0xd0, 0x41, 0x1f, 0x72, 0x96, 0x8e, 0x03, 0xce, 0xf2, 0xfe, 0x8a, 0xb8, 0x07, 0x1b,
-	0x44, 0xde, 0x83, 0x5e, 0xca, 0xa4, 0x15, 0x3e, 0xe1, 0xd1, 0x28, 0xef, 0xaf, 0x0a, 0xd3, 0xd7,
-	0x51, 0x8f, 0x0d, 0xe5, 0xd7, 0x77, 0x31, 0x50, 0x34, 0x47, 0xf9, 0xe9, 0x70, 0xcc, 0xa2, 0x60,
-	0xde, 0x5f, 0x13, 0x42, 0x57, 0x00, 0xe8, 0x45, 0xd8, 0xd8, 0x0f, 0x73, 0xae, 0x24, 0xcd, 0x68,
-	0xbf, 0x3d, 0xd8, 0x74, 0xc1, 0xea, 0x2d, 0xde, 0x82, 0x96, 0x12, 0x9b, 0xbc, 0xdf, 0x11, 0x4b,
-	0x6f, 0xaa, 0xa5, 0x1d, 0x89, 0xf5, 0x0d, 0x16, 0xfd, 0x83, 0x06, 0x34, 0xf1, 0x9d, 0x9d, 0xff,
-	0x26, 0xed, 0x07, 0xde, 0x70, 0x1e, 0xb8, 0xad, 0x6e, 0x17, 0x1c, 0x75, 0x2b, 0x3c, 0xeb, 0x39,
-	0x67, 0xea, 0x36, 0xa4, 0xc4, 0x5a, 0x90, 0x62, 0x3e, 0x63, 0xa3, 0x53, 0x21, 0xb6, 0x66, 0x1e,
-	0x21, 0x28, 0xd4, 0x68, 0xe6, 0xc4, 0xd7, 0x52, 0x66, 0xcd, 0x58, 0xcf, 0x89, 0x2f, 0x97, 0x8b,
-	0x39, 0xf1, 0x5d, 0x1f, 0x96, 0xc3, 0xf8, 0x28, 0x99, 0xc5, 0x63, 0x21, 0x9f, 0x2d, 0x5f, 0x0f,
-	0x91, 0xcf, 0xa9, 0xf0, 0x8e, 0xc2, 0x29, 0x53, 0x82, 0x59, 0x00, 0x28, 0x41, 0x37, 0x28, 0x17,
-	0x1a, 0xc7, 0x30, 0xf9, 0x7d, 0x58, 0xb7, 0x60, 0x8a, 0xc3, 0xd7, 0x61, 0x11, 0x4f, 0xaf, 0xfd,
-	0x69, 0x7d, 0xb3, 0x42, 0x55, 0xc9, 0x19, 0xba, 0x06, 0x2b, 0x1f, 0x33, 0xfe, 0x49, 0x7c, 0x9c,
-	0x68, 0x4a, 0xff, 0xdd, 0x80, 0x55, 0x03, 0x52, 0x84, 0xb6, 0x61, 0x35, 0x1c, 0xb3, 0x98, 0x87,
-	0x7c, 0x3e, 0x74, 0xbc, 0xad, 0x32, 0x18, 0x95, 0x7f, 0x10, 0x85, 0x41, 0xae, 0xd4, 0x87, 0x1c,
-	0x90, 0x5d, 0xd8, 0x44, 0xc9, 0xd3, 0xc2, 0x64, 0xae, 0x5d, 0x3a, 0x79, 0xb5, 0x73, 0xf8, 0x58,
-	0x10, 0x2e, 0xd5, 0x53, 0xf1, 0x89, 0x54, 0x75, 0x75, 0x53, 0xc8, 0x35, 0x49, 0x09, 0x8f, 0xbc,
-	0x28, 0xa5, 0xd3, 0x00, 0x2a, 0xf1, 0xd1, 0x92, 0x74, 0x30, 0xcb, 0xf1, 0x91, 0x15, 0x63, 0xb5,
-	0x2a, 0x31, 0xd6, 0x36, 0xac, 0xe6, 0xf3, 0x78, 0xc4, 0xc6, 0x43, 0x9e, 0xe0, 0xba, 0x61, 0x2c,
-	0x6e, 0xa7, 0xe5, 0x97, 0xc1, 0x22, 0x1a, 0x64, 0x39, 0x8f, 0x19, 0x17, 0x5a, 0xa3, 0xe5, 0xeb,
-	0x21, 0x2a, 0x60, 0x81, 0x22, 0x85, 0xbe, 0xed, 0xab, 0x11, 0xfd, 0xbe, 0x30, 0x84, 0x26, 0xe0,
-	0xfb, 0x5c, 0xbc, 0x52, 0x72, 0x05, 0xda, 0x72, 0xfd, 0xfc, 0x24, 0xd0, 0xa1, 0xa9, 0x00, 0x1c,
-	0x9e, 0x04, 0x18, 0xa7, 0x38, 0x47, 0x92, 0x12, 0xdf, 0x11, 0xb0, 0x3d, 0x79, 0xa2, 0xb7, 0x60,
-	0x45, 0x87, 0x92, 0xf9, 0x30, 0x62, 0xc7, 0x5c, 0x3b, 0xd6, 0xf1, 0x6c, 0x8a, 0xcb, 0xe5, 0xfb,
-	0xec, 0x98, 0xd3, 0x47, 0xb0, 0xae, 0x5e, 0xdb, 0x67, 0x29, 0xd3, 0x4b, 0x7f, 0xb3, 0xac, 0xeb,
-	0xa5, 0x31, 0xde, 0x50, 0x52, 0x64, 0x47, 0x03, 0x25, 0x03, 0x40, 0x7d, 0x20, 0x6a, 0xfa, 0x5e,
-	0x94, 0xe4, 0x4c, 0x11, 0xa4, 0xd0, 0x1d, 0x45, 0x49, 0x5e, 0x0e, 0x19, 0x6c, 0x18, 0xf2, 0x2d,
-	0x9f, 0x8d, 0x46, 0xf8, 0x4a, 0xa5, 0x39, 0xd7, 0x43, 0xfa, 0x37, 0x1e, 0x6c, 0x08, 0x6a, 0x5a,
-	0x2f, 0x18, 0x1f, 0xf0, 0xf5, 0xb7, 0xd9, 0x1d, 0xd9, 0x21, 0xcc, 0x26, 0x2c, 0x1e, 0x27, 0xd9,
-	0x88, 0xa9, 0x95, 0xe4, 0xe0, 0x17, 0xe1, 0xd5, 0xfe, 0x9b, 0x07, 0xeb, 0x62, 0xab, 0x87, 0x3c,
-	0xe0, 0xb3, 0x5c, 0x1d, 0xff, 0x57, 0xa1, 0x87, 0x47, 0x65, 0x5a, 0xd4, 0xd5, 0x46, 0x37, 0xcd,
-	0xab, 0x14, 0x50, 0x89, 0xbc, 0x77, 0xc1, 0x77, 0x91, 0xc9, 0x47, 0xd0, 0xb5, 0xf3, 0x01, 0x62,
-	0xcf, 0x9d, 0xdd, 0xcb, 0xfa, 0x94, 0x15, 0xc9, 0xd9, 0xbb, 0xe0, 0x3b, 0x1f, 0x90, 0xdb, 0x00,
-	0xc2, 0x0a, 0x0b, 0xb2, 0x2a, 0xe4, 0xbb, 0xec, 0x32, 0xc9, 0xba, 0xac, 0xbd, 0x0b, 0xbe, 0x85,
-	0x7e, 0xb7, 0x05, 0x4b, 0xd2, 0x6c, 0xd0, 0x8f, 0xa1, 0xe7, 0xec, 0xd4, 0xf1, 0xd6, 0xbb, 0xd2,
-	0x5b, 0xaf, 0x04, 0x73, 0x8d, 0x9a, 0x60, 0xee, 0x1f, 0x1a, 0x40, 0x50, 0xda, 0x4a, 0xd7, 0xf9,
-	0x36, 0xac, 0x28, 0xf6, 0xbb, 0x8e, 0x5a, 0x09, 0x2a, 0xec, 0x5b, 0x32, 0x76, 0xbc, 0x95, 0xae,
-	0x6f, 0x83, 0xc8, 0x4d, 0x20, 0xd6, 0x50, 0x47, 0xe8, 0x52, 0xf7, 0xd7, 0xcc, 0xa0, 0x92, 0x92,
-	0xae, 0x86, 0x8e, 0x4d, 0x95, 0x77, 0xd6, 0x14, 0xf7, 0x5b, 0x3b, 0x27, 0x12, 0x47, 0x33, 0x0c,
-	0xff, 0x03, 0xae, 0xfd, 0x19, 0x3d, 0x2e, 0x0b, 0xd2, 0xd2, 0x2b, 0x05, 0x69, 0xb9, 0x2c, 0x48,
-	0xc2, 0x9a, 0x65, 0xe1, 0x69, 0xc0, 0x99, 0xb6, 0x10, 0x6a, 0x48, 0x7f, 0xe2, 0xc1, 0x1a, 0x72,
-	0xcf, 0x91, 0xb0, 0x0f, 0x41, 0x08, 0xf8, 0x6b, 0x0a, 0x98, 0x83, 0xfb, 0xf3, 0xcb, 0xd7, 0x07,
-	0xd0, 0x16, 0x04, 0x93, 0x94, 0xc5, 0x4a, 0xbc, 0xfa, 0xae, 0x78, 0x15, 0xba, 0x65, 0xef, 0x82,
-	0x5f, 0x20, 0x5b, 0xc2, 0xf5, 0x2f, 0x1e, 0x74, 0xd4, 0x36, 0xff, 0xd7, 0xee, 0xf3, 0x00, 0x5a,
-	0x28, 0x67, 0x96, 0x77, 0x6a, 0xc6, 0xa8, 0xbf, 0xa7, 0x18, 0xbd, 0xa0, 0xc1, 0x72, 0x5c, 0xe7,
-	0x32, 0x18, 0xad, 0x8f, 0x50, 0xa3, 0xf9, 0x90, 0x87, 0xd1, 0x50, 0xcf, 0xaa, 0xa4, 0x5a, 0xdd,
-	0x14, 0x6a, 0x93, 0x9c, 0x07, 0x13, 0xa6, 0x0c, 0x8b, 0x1c, 0xd0, 0x4b, 0x70, 0x51, 0x1d, 0xc8,
-	0x95, 0x73, 0xfa, 0x5f, 0x00, 0x5b, 0xe5, 0x19, 0xe3, 0x18, 0x29, 0x5f, 0x30, 0x0a, 0xa7, 0x47,
-	0x89, 0x71, 0x2b, 0x3d, 0xdb, 0x4d, 0x74, 0xa6, 0xc8, 0x31, 0x5c, 0xd4, 0xf6, 0x13, 0x39, 0x5a,
-	0x58, 0xcb, 0x86, 0x30, 0xfc, 0xb7, 0x5c, 0x09, 0x28, 0xad, 0xa7, 0xc1, 0xf6, 0x63, 0xac, 0x27,
-	0x47, 0x26, 0xd0, 0x37, 0x76, 0x5a, 0x69, 0x6d, 0xcb, 0x96, 0xe3, 0x52, 0x5f, 0x7b, 0xf9, 0x52,
-	0x42, 0xc3, 0x8c, 0x35, 0xf4, 0x5c, 0x62, 0xe4, 0x19, 0xbc, 0xa9, 0xe7, 0x84, 0x56, 0xae, 0x2e,
-	0xd7, 0x7c, 0x9d, 0x93, 0x3d, 0xc4, 0x6f, 0xdd, 0x35, 0x5f, 0x41, 0x77, 0xf0, 0x4f, 0x1e, 0xac,
-	0xb8, 0xd4, 0x50, 0x6a, 0x54, 0x70, 0xa1, 0xb5, 0x86, 0xf6, 0x7e, 0x4a, 0xe0, 0x6a, 0x78, 0xd4,
-	0xa8, 0x0b, 0x8f, 0xec, 0x20, 0x68, 0xe1, 0x55, 0x41, 0x50, 0xf3, 0xf5, 0x82, 0xa0, 0xc5, 0xba,
-	0x20, 0x68, 0xf0, 0x97, 0x0d, 0x20, 0xd5, 0xdb, 0x25, 0x0f, 0x65, 0x7c, 0x16, 0xb3, 0x48, 0xa9,
-	0x88, 0x77, 0x5f, 0x4b, 0x40, 0x34, 0x58, 0x7f, 0x8c, 0x82, 0x6a, 0xab, 0x00, 0xdb, 0x0d, 0xe9,
-	0xf9, 0x75, 0x53, 0xe4, 0x06, 0xac, 0x15, 0x6f, 0x27, 0x2a, 0x74, 0xc5, 0xa2, 0x5f, 0x81, 0x97,
-	0x22, 0xb8, 0xe6, 0xab, 0x23, 0xb8, 0xc5, 0x57, 0x47, 0x70, 0x4b, 0xe5, 0x08, 0x6e, 0xf0, 0x1c,
-	0x7a, 0x8e, 0x80, 0xfc, 0xc2, 0x98, 0x53, 0xf6, 0x76, 0xa4, 0x28, 0x38, 0xb0, 0xc1, 0x4f, 0x1b,
-	0x40, 0xaa, 0x32, 0xfa, 0xff, 0xb9, 0x05, 0x21, 0x70, 0x8e, 0x9a, 0x59, 0x50, 0x02, 0xe7, 0x28,
-	0x98, 0xff, 0x4b, 0xc5, 0xf9, 0x2e, 0xac, 0x67, 0x6c, 0x94, 0x9c, 0xb2, 0xcc, 0x8a, 0xa1, 0xe5,
-	0x45, 0x55, 0x27, 0xd0, 0xdd, 0x73, 0xa3, 0xd6, 0x96, 0x53, 0x2b, 0xb0, 0xac, 0x47, 0x29, 0x78,
-	0xa5, 0xdf, 0x84, 0x4d, 0x59, 0xc2, 0xb9, 0x2b, 0x49, 0x69, 0x8f, 0xe3, 0x3a, 0x74, 0xcf, 0x64,
-	0xda, 0x6e, 0x98, 0xc4, 0xd1, 0x5c, 0x19, 0x9a, 0x8e, 0x82, 0x7d, 0x16, 0x47, 0x73, 0xfa, 0x63,
-	0x0f, 0x2e, 0x96, 0xbe, 0x2d, 0xb2, 0xf3, 0x52, 0x21, 0xbb, 0x5a, 0xda, 0x05, 0xe2, 0x11, 0xd5,
-	0x6b, 0xb0, 0x8e, 0x28, 0xcd, 0x56, 0x75, 0x02, 0x59, 0x38, 0x8b, 0xab, 0xf8, 0xf2, 0x62, 0xea,
-	0xa6, 0xd0, 0xca, 0xa8, 0xcb, 0x77, 0xcf, 0x46, 0x77, 0x61, 0xab, 0x3c, 0x51, 0x64, 0xc2, 0xdc,
-	0x2d, 0xeb, 0x21, 0xfd, 0x08, 0xc8, 0xb7, 0x67, 0x2c, 0x9b, 0x8b, 0x3a, 0x80, 0x49, 0xb5, 0x5e,
-	0x2a, 0x87, 0xdc, 0x4b, 0xe9, 0xec, 0xe8, 0x5b, 0x6c, 0xae, 0xcb, 0x27, 0x0d, 0x53, 0x3e, 0xa1,
-	0xb7, 0x61, 0xc3, 0x21, 0x60, 0x58, 0xb5, 0x24, 0x6a, 0x09, 0x3a, 0x1c, 0x75, 0xeb, 0x0d, 0x6a,
-	0x8e, 0xfe, 0xb9, 0x07, 0x0b, 0x7b, 0x49, 0x6a, 0xe7, 0x90, 0x3c, 0x37, 0x87, 0xa4, 0x94, 0xec,
-	0xd0, 0xe8, 0xd0, 0x86, 0x7a, 0xf7, 0x36, 0x10, 0x55, 0x64, 0x30, 0xe5, 0x18, 0x90, 0x1d, 0x27,
-	0xd9, 0x59, 0x90, 0x8d, 0x15, 0xff, 0x4a, 0x50, 0xdc, 0x7e, 0xa1, 0x5e, 0xf0, 0x27, 0x3a, 0x16,
-	0x22, 0x91, 0x36, 0x57, 0x31, 0xa4, 0x1a, 0xd1, 0x1f, 0x7a, 0xb0, 0x28, 0xf6, 0x8a, 0xaf, 0x41,
-	0xde, 0xaf, 0x28, 0x9d, 0x89, 0x3c, 0x9d, 0x27, 0x5f, 0x43, 0x09, 0x5c, 0x2a, 0xa8, 0x35, 0x2a,
-	0x05, 0xb5, 0xab, 0xd0, 0x96, 0xa3, 0xa2, 0x02, 0x55, 0x00, 0xc8, 0x9b, 0xd0, 0x3c, 0x49, 0x52,
-	0x6d, 0xeb, 0x40, 0x27, 0x66, 0x92, 0xd4, 0x17, 0x70, 0x7a, 0x03, 0x56, 0x1f, 0x25, 0x63, 0x66,
-	0x45, 0xef, 0xe7, 0x5e, 0x13, 0xfd, 0x5d, 0x0f, 0x5a, 0x1a, 0x99, 0x6c, 0x43, 0x13, 0x6d, 0x56,
-	0xc9, 0x41, 0x34, 0xe9, 0x55, 0xc4, 0xf3, 0x05, 0x06, 0xaa, 0x10, 0x11, 0x3f, 0x16, 0x0e, 0x85,
-	0x8e, 0x1e, 0x0b, 0x63, 0x8d, 0x2e, 0xbb, 0xd8, 0x73, 0xc9, 0xaa, 0x95, 0xa0, 0xf4, 0x6f, 0x3d,
-	0xe8, 0x39, 0x6b, 0xa0, 0x13, 0x1f, 0x05, 0x39, 0x57, 0x29, 0x29, 0xc5, 0x44, 0x1b, 0x64, 0x67,
-	0x7a, 0x1a, 0x6e, 0xa6, 0xc7, 0x64, 0x1a, 0x16, 0xec, 0x4c, 0xc3, 0x2d, 0x68, 0x17, 0xc5, 0xc9,
-	0xa6, 0xa3, 0x1a, 0x70, 0x45, 0x9d, 0x38, 0x2e, 0x90, 0x90, 0xce, 0x28, 0x89, 0x92, 0x4c, 0xd5,
-	0xee, 0xe4, 0x80, 0xde, 0x86, 0x8e, 0x85, 0x8f, 0xdb, 0x88, 0x19, 0x3f, 0x4b, 0xb2, 0xa7, 0x3a,
-	0xe1, 0xa4, 0x86, 0xa6, 0x60, 0xd2, 0x28, 0x0a, 0x26, 0xf4, 0xef, 0x3c, 0xe8, 0xa1, 0xa4, 0x84,
-	0xf1, 0xe4, 0x20, 0x89, 0xc2, 0xd1, 0x5c, 0x48, 0x8c, 0x16, 0x8a, 0xe1, 0x98, 0x45, 0x3c, 0x30,
-	0x12, 0xe3, 0x82, 0xd1, 0x39, 0x98, 0x86, 0xb1, 0x50, 0x59, 0x4a, 0x5e, 0xcc, 0x18, 0x25, 0x1f,
-	0x2d, 0xd7, 0x51, 0x90, 0xb3, 0xe1, 0x14, 0x43, 0x0e, 0xa5, 0xab, 0x1d, 0x20, 0xaa, 0x0f, 0x04,
-	0x64, 0x01, 0x67, 0xc3, 0x69, 0x18, 0x45, 0xa1, 0xc4, 0x95, 0x12, 0x5e, 0x37, 0x85, 0xa1, 0x58,
-	0x47, 0xa9, 0x89, 0x07, 0xe3, 0x89, 0xcc, 0x9d, 0x2a, 0x8f, 0xc5, 0x3c, 0x3f, 0x0b, 0xa2, 0xe7,
-	0x1d, 0x1f, 0xc7, 0x82, 0x94, 0xaf, 0x75, 0xa1, 0x7a, 0xad, 0x57, 0xa1, 0x8d, 0xe2, 0xf5, 0x9e,
-	0x70, 0xa6, 0x64, 0x2d, 0xbb, 0x00, 0xe8, 0xd9, 0x5d, 0x31, 0xbb, 0x58, 0xcc, 0x0a, 0x80, 0xe3,
-	0x3e, 0x2d, 0x95, 0xdc, 0xa7, 0x0f, 0xa0, 0xab, 0xc8, 0x08, 0xbe, 0x8b, 0x90, 0xaa, 0x10, 0x70,
-	0xe7, 0x4e, 0x7c, 0x07, 0x53, 0x7f, 0xb9, 0xab, 0xbf, 0x6c, 0xbd, 0xea, 0x4b, 0x8d, 0x49, 0x2f,
-	0xc2, 0x86, 0x62, 0xde, 0xc7, 0x59, 0x90, 0x9e, 0x68, 0xd5, 0x3b, 0x36, 0x05, 0x53, 0x01, 0x26,
-	0x37, 0x60, 0x11, 0x3f, 0xd3, 0xda, 0xaf, 0xfe, 0xd1, 0x49, 0x14, 0xb2, 0x0d, 0x8b, 0x6c, 0x3c,
-	0x61, 0xda, 0x7f, 0x27, 0x6e, 0x1c, 0x85, 0x77, 0xe4, 0x4b, 0x04, 0x54, 0x01, 0x08, 0x2d, 0xa9,
-	0x00, 0x57, 0x73, 0x2e, 0xe1, 0xf0, 0x93, 0x31, 0xdd, 0x04, 0xf2, 0x48, 0x4a, 0xad, 0x9d, 0xef,
-	0xfb, 0xfd, 0x05, 0xe8, 0x58, 0x60, 0x7c, 0xcd, 0x13, 0xdc, 0xf0, 0x70, 0x1c, 0x06, 0x53, 0xc6,
-	0x59, 0xa6, 0x24, 0xb5, 0x04, 0x15, 0x0a, 0xf6, 0x74, 0x32, 0x4c, 0x66, 0x7c, 0x38, 0x66, 0x93,
-	0x8c, 0x49, 0x83, 0xe6, 0xf9, 0x25, 0x28, 0xe2, 0x4d, 0x83, 0x67, 0x36, 0x9e, 0x94, 0x87, 0x12,
-	0x54, 0x67, 0xef, 0x24, 0x8f, 0x9a, 0x45, 0xf6, 0x4e, 0x72, 0xa4, 0xac, 0x87, 0x16, 0x6b, 0xf4,
-	0xd0, 0xfb, 0xb0, 0x25, 0x35, 0x8e, 0x7a, 0x9b, 0xc3, 0x92, 0x98, 0x9c, 0x33, 0x8b, 0x4e, 0x29,
-	0xee, 0x59, 0x0b, 0x78, 0x1e, 0x7e, 0x5f, 0xc6, 0xe2, 0x9e, 0x5f, 0x81, 0x23, 0x2e, 0x3e, 0x47,
-	0x07, 0x57, 0x16, 0x17, 0x2a, 0x70, 0x81, 0x1b, 0x3c, 0x73, 0x71, 0xdb, 0x0a, 0xb7, 0x04, 0xa7,
-	0x3d, 0xe8, 0x1c, 0xf2, 0x24, 0xd5, 0x97, 0xb2, 0x02, 0x5d, 0x39, 0x54, 0x05, 0xa5, 0x2b, 0x70,
-	0x59, 0x48, 0xd1, 0xe3, 0x24, 0x4d, 0xa2, 0x64, 0x32, 0x3f, 0x9c, 0x1d, 0xe5, 0xa3, 0x2c, 0x4c,
-	0xd1, 0xb7, 0xa6, 0xff, 0xec, 0xc1, 0x86, 0x33, 0xab, 0xd2, 0x01, 0xbf, 0x2c, 0x45, 0xda, 0xd4,
-	0x00, 0xa4, 0xe0, 0xad, 0x5b, 0xea, 0x50, 0x22, 0xca, 0xb4, 0xc9, 0xe7, 0xaa, 0x2c, 0x70, 0x07,
-	0x56, 0xf5, 0xce, 0xf4, 0x87, 0x52, 0x0a, 0xfb, 0x55, 0x29, 0x54, 0xdf, 0xaf, 0xa8, 0x0f, 0x34,
-	0x89, 0x5f, 0x93, 0x7e, 0x27, 0x1b, 0x8b, 0x33, 0xea, 0xd0, 0x70, 0xa0, 0xbf, 0xb7, 0x7d, 0x5d,
-	0xbd, 0x83, 0x91, 0x01, 0xe6, 0xf4, 0x8f, 0x3c, 0x80, 0x62, 0x77, 0x28, 0x18, 0x85, 0x4a, 0xf7,
-	0x44, 0xce, 0xd4, 0x52, 0xdf, 0xd7, 0xa1, 0x6b, 0x72, 0xd0, 0x85, 0x95, 0xe8, 0x68, 0x18, 0x7a,
-	0x28, 0xef, 0xc0, 0xea, 0x24, 0x4a, 0x8e, 0x84, 0xcd, 0x15, 0xb5, 0xcb, 0x5c, 0x95, 0xd5, 0x56,
-	0x24, 0xf8, 0xa1, 0x82, 0x16, 0x26, 0xa5, 0x69, 0x99, 0x14, 0xfa, 0xc7, 0x0d, 0x93, 0x1d, 0x2d,
-	0xce, 0x7c, 0xee, 0x2b, 0x23, 0xbb, 0x15, 0xe5, 0x78, 0x4e, 0x32, 0x52, 0x64, 0x40, 0x0e, 0x5e,
-	0x19, 0x11, 0xde, 0x86, 0x95, 0x4c, 0x6a, 0x1f, 0xad, 0x9a, 0x9a, 0x2f, 0x51, 0x4d, 0xbd, 0xcc,
-	0xb1, 0x3b, 0xbf, 0x04, 0x6b, 0xc1, 0xf8, 0x94, 0x65, 0x3c, 0x14, 0x1e, 0xbf, 0x30, 0xfa, 0x52,
-	0xa1, 0xae, 0x5a, 0x70, 0x61, 0x8b, 0xdf, 0x81, 0x55, 0x55, 0xca, 0x34, 0x98, 0xaa, 0x43, 0xa5,
-	0x00, 0x23, 0x22, 0xfd, 0x2b, 0x9d, 0x88, 0x75, 0xef, 0xf0, 0x7c, 0x8e, 0xd8, 0xa7, 0x6b, 0x94,
-	0x4e, 0xf7, 0x55, 0x95, 0x14, 0x1d, 0xeb, 0xb0, 0x42, 0xa5, 0xa7, 0x25, 0x50, 0x25, 0xb1, 0x5d,
-	0x96, 0x36, 0x5f, 0x87, 0xa5, 0xf4, 0x26, 0xac, 0x1e, 0x32, 0x7e, 0x07, 0x6f, 0x50, 0x2b, 0xc6,
-	0x2b, 0xd0, 0x8e, 0xd9, 0xd9, 0x50, 0x5e, 0xb1, 0x34, 0xe3, 0xad, 0x98, 0x9d, 0x09, 0x1c, 0x4a,
-	0x60, 0xad, 0xc0, 0x57, 0xaf, 0xee, 0xc7, 0x0b, 0xb0, 0xfc, 0x49, 0x7c, 0x9a, 0x84, 0x23, 0x91,
-	0xe6, 0x9c, 0xb2, 0x69, 0xa2, 0x9b, 0x12, 0xf0, 0x37, 0x7a, 0x05, 0xa2, 0xde, 0x96, 0x72, 0x95,
-	0x7f, 0xd4, 0x43, 0xb4, 0x90, 0x59, 0xd1, 0x88, 0x23, 0xa5, 0xcd, 0x82, 0xa0, 0x8f, 0x99, 0xd9,
-	0xbd, 0x45, 0x6a, 0x54, 0x74, 0x75, 0x2c, 0x5a, 0x5d, 0x1d, 0x22, 0x29, 0x2e, 0x4b, 0x89, 0xe2,
-	0x4a, 0x5a, 0xbe, 0x1e, 0x0a, 0x5f, 0x38, 0x63, 0x32, 0xc4, 0x16, 0xb6, 0x76, 0x59, 0xf9, 0xc2,
-	0x36, 0x10, 0xed, 0xb1, 0xfc, 0x40, 0xe2, 0x48, 0x7d, 0x65, 0x83, 0xd0, 0x3f, 0x29, 0xb7, 0x27,
-	0xb5, 0xa5, 0x98, 0x94, 0xc0, 0xa8, 0xd4, 0xc6, 0xcc, 0xe8, 0x1e, 0x79, 0x06, 0x90, 0x8d, 0x46,
-	0x65, 0xb8, 0xe5, 0x49, 0xcb, 0x92, 0xa8, 0x1a, 0x09, 0x3f, 0x26, 0x88, 0xa2, 0xa3, 0x60, 0xf4,
-	0x54, 0x34, 0x8d, 0x89, 0x0a, 0x68, 0xdb, 0x77, 0x81, 0xb8, 0xeb, 0x51, 0xc4, 0x4f, 0x87, 0x8a,
-	0x44, 0x4f, 0x56, 0x30, 0x2d, 0x10, 0xfd, 0x02, 0xc8, 0x9d, 0xf1, 0x58, 0xdd, 0x90, 0x89, 0x33,
-	0x0a, 0xde, 0x7a, 0x0e, 0x6f, 0x6b, 0xce, 0xd8, 0xa8, 0x3d, 0x23, 0x7d, 0x00, 0x9d, 0x03, 0xab,
-	0xd7, 0x4b, 0x5c, 0xa6, 0xee, 0xf2, 0x52, 0x02, 0x60, 0x41, 0xac, 0x05, 0x1b, 0xf6, 0x82, 0xf4,
-	0x57, 0x80, 0xec, 0x87, 0x39, 0x37, 0xfb, 0x33, 0xe1, 0xa6, 0x49, 0xae, 0x59, 0xe1, 0xa6, 0x82,
-	0x89, 0x70, 0xf3, 0x8e, 0x2c, 0xa4, 0x96, 0x0f, 0x76, 0x03, 0x5a, 0xa1, 0x04, 0x69, 0x5d, 0xbe,
-	0xa2, 0x1e, 0x81, 0xc6, 0x34, 0xf3, 0xe8, 0x94, 0x28, 0xa0, 0x63, 0x2a, 0x7e, 0xe8, 0xc1, 0xb2,
-	0x3a, 0x1a, 0x9a, 0x54, 0xa7, 0xcb, 0x4d, 0x1e, 0xcc, 0x81, 0xd5, 0x77, 0x19, 0x55, 0xa5, 0x6e,
-	0xa1, 0x4e, 0xea, 0x08, 0x34, 0xd3, 0x80, 0x9f, 0x08, 0x2f, 0xbc, 0xed, 0x8b, 0xdf, 0x3a, 0xda,
-	0x5a, 0x34, 0xd1, 0x96, 0x2e, 0x1a, 0xab, 0x4d, 0x99, 0x7a, 0xe6, 0x5d, 0x59, 0x34, 0x2e, 0xc0,
-	0x05, 0x0f, 0xd4, 0x06, 0xcb, 0x3c, 0x50, 0xa8, 0xbe, 0x99, 0xa7, 0x03, 0xe8, 0xdf, 0x67, 0x11,
-	0xe3, 0xec, 0x4e, 0x14, 0x95, 0xe9, 0x5f, 0x81, 0xcb, 0x35, 0x73, 0xea, 0xdd, 0x3f, 0x84, 0xf5,
-	0xfb, 0xec, 0x68, 0x36, 0xd9, 0x67, 0xa7, 0x45, 0x61, 0x82, 0x40, 0x33, 0x3f, 0x49, 0xce, 0xd4,
-	0x7d, 0x89, 0xdf, 0xe4, 0x0d, 0x80, 0x08, 0x71, 0x86, 0x79, 0xca, 0x46, 0xba, 0x45, 0x46, 0x40,
-	0x0e, 0x53, 0x36, 0xa2, 0xef, 0x03, 0xb1, 0xe9, 0xa8, 0x23, 0xe0, 0x6b, 0x9c, 0x1d, 0x0d, 0xf3,
-	0x79, 0xce, 0xd9, 0x54, 0x2b, 0x22, 0x1b, 0x44, 0xdf, 0x81, 0xee, 0x41, 0x30, 0xf7, 0xd9, 0x97,
-	0xaa, 0x79, 0x10, 0x83, 0xba, 0x60, 0x8e, 0xe2, 0x69, 0x82, 0x3a, 0x31, 0x4d, 0xff, 0xb1, 0x01,
-	0x4b, 0x12, 0x13, 0xa9, 0x8e, 0x59, 0xce, 0xc3, 0x58, 0xa6, 0xef, 0x15, 0x55, 0x0b, 0x54, 0xb9,
-	0xef, 0x46, 0xcd, 0x7d, 0x2b, 0x37, 0x4b, 0xb7, 0x13, 0xa8, 0x8b, 0x75, 0x60, 0x22, 0x66, 0x0d,
-	0xa7, 0x4c, 0xf6, 0x90, 0x36, 0x55, 0xcc, 0xaa, 0x01, 0xa5, 0xe8, 0xb9, 0x78, 0xf3, 0x72, 0x7f,
-	0x5a, 0x10, 0x95, 0x69, 0xb1, 0x41, 0xb5, 0x9a, 0x65, 0x59, 0x76, 0x0b, 0x56, 0x34, 0x4b, 0x45,
-	0x83, 0xb4, 0x5e, 0x43, 0x83, 0x48, 0xdf, 0xcb, 0xd1, 0x20, 0x04, 0xd6, 0x1e, 0x32, 0xe6, 0xb3,
-	0x34, 0xc9, 0x4c, 0x07, 0xe6, 0x8f, 0x3c, 0x58, 0x53, 0x56, 0xc5, 0xcc, 0x91, 0xeb, 0x8e, 0x09,
-	0xf2, 0xea, 0xd2, 0xba, 0x6f, 0x41, 0x4f, 0x04, 0x61, 0x18, 0x61, 0x89, 0x88, 0x4b, 0xe5, 0x25,
-	0x1c, 0x20, 0xee, 0x49, 0x67, 0x1f, 0xa7, 0x61, 0xa4, 0x18, 0x6c, 0x83, 0xd0, 0x5c, 0xea, 0x20,
-	0x4d, 0xb0, 0xd7, 0xf3, 0xcd, 0x98, 0x1e, 0xc0, 0xba, 0xb5, 0x5f, 0x25, 0x50, 0xb7, 0x41, 0xd7,
-	0x35, 0x65, 0x9a, 0x41, 0xbe, 0x8b, 0x4b, 0xae, 0x81, 0x2c, 0x3e, 0x73, 0x90, 0xe9, 0xdf, 0x7b,
-	0x82, 0x05, 0xca, 0x0f, 0x33, 0x3d, 0x4f, 0x4b, 0xd2, 0x35, 0x92, 0xd2, 0xbe, 0x77, 0xc1, 0x57,
-	0x63, 0xf2, 0x8d, 0xd7, 0xf4, 0x6e, 0x4c, 0xfd, 0xf0, 0x1c, 0xde, 0x2c, 0xd4, 0xf1, 0xe6, 0x25,
-	0x27, 0xbf, 0xbb, 0x0c, 0x8b, 0xf9, 0x28, 0x49, 0x19, 0xdd, 0x10, 0x2c, 0xd0, 0xfb, 0x95, 0x2c,
-	0xd8, 0xfd, 0x77, 0x0f, 0x56, 0x64, 0x82, 0x4e, 0xf6, 0x6a, 0xb3, 0x8c, 0x60, 0xfc, 0x65, 0xb5,
-	0x80, 0x13, 0xe3, 0x7e, 0x56, 0x5b, 0xc9, 0x07, 0x57, 0x6a, 0xe7, 0xb4, 0xef, 0xfd, 0x83, 0x9f,
-	0xfc, 0xe7, 0x9f, 0x34, 0x2e, 0xd2, 0xb5, 0x9d, 0xd3, 0xf7, 0x76, 0x84, 0x8a, 0x63, 0x67, 0x02,
-	0xe3, 0x43, 0xef, 0x06, 0xae, 0x62, 0x77, 0x87, 0x9b, 0x55, 0x6a, 0xba, 0xcc, 0xcd, 0x2a, 0xb5,
-	0xed, 0xe4, 0xce, 0x2a, 0x33, 0x81, 0x61, 0x56, 0xd9, 0xfd, 0xeb, 0x2b, 0xd0, 0x36, 0x81, 0x22,
-	0xf9, 0x1e, 0xf4, 0x9c, 0x64, 0x24, 0xd1, 0x84, 0xeb, 0xd2, 0x9b, 0x83, 0xab, 0xf5, 0x93, 0x6a,
-	0xd9, 0x37, 0xc5, 0xb2, 0x7d, 0xb2, 0x85, 0xcb, 0xaa, 0x0c, 0xe0, 0x8e, 0xc8, 0xd2, 0xca, 0x3e,
-	0x86, 0xa7, 0xb0, 0xe2, 0x26, 0x10, 0xc9, 0x55, 0xf7, 0xb6, 0x4b, 0xab, 0xbd, 0x71, 0xce, 0xac,
-	0x5a, 0xee, 0xaa, 0x58, 0x6e, 0x8b, 0x6c, 0xda, 0xcb, 0x99, 0x00, 0x8e, 0x89, 0xce, 0x13, 0xbb,
-	0x6d, 0x9c, 0x68, 0x7a, 0xf5, 0xed, 0xe4, 0x83, 0xcb, 0xd5, 0x16, 0x71, 0xd5, 0x53, 0x4e, 0xfb,
-	0x62, 0x29, 0x42, 0x04, 0x43, 0xed, 0xae, 0x71, 0xf2, 0x5d, 0x68, 0x9b, 0xa6, 0x53, 0x72, 0xc9,
-	0xea, 0xf4, 0xb5, 0x3b, 0x61, 0x07, 0xfd, 0xea, 0x44, 0xdd, 0x55, 0xd9, 0x94, 0x51, 0x20, 0xf6,
-	0xe1, 0xa2, 0xb2, 0xb8, 0x47, 0xec, 0x67, 0x39, 0x49, 0x4d, 0xb3, 0xfb, 0x2d, 0x8f, 0xdc, 0x86,
-	0x96, 0xee, 0xe5, 0x25, 0x5b, 0xf5, 0x3d, 0xc9, 0x83, 0x4b, 0x15, 0xb8, 0xd2, 0x0b, 0x77, 0x00,
-	0x8a, 0xb6, 0x53, 0xd2, 0x3f, 0xaf, 0x3b, 0xd6, 0x30, 0xb1, 0xa6, 0x47, 0x75, 0x22, 0xba, 0x6e,
-	0xdd, 0xae, 0x56, 0xf2, 0x95, 0x02, 0xbf, 0xb6, 0xdf, 0xf5, 0x25, 0x04, 0xe9, 0x96, 0xe0, 0xdd,
-	0x1a, 0x59, 0x41, 0xde, 0xc5, 0xec, 0x4c, 0xf7, 0x60, 0xdd, 0x87, 0x8e, 0xd5, 0xca, 0x4a, 0x34,
-	0x85, 0x6a, 0x1b, 0xec, 0x60, 0x50, 0x37, 0xa5, 0xb6, 0xfb, 0x1b, 0xd0, 0x73, 0x7a, 0x52, 0xcd,
-	0xcb, 0xa8, 0xeb, 0x78, 0x35, 0x2f, 0xa3, 0xbe, 0x8d, 0xf5, 0x3b, 0xd0, 0xb1, 0x3a, 0x48, 0x89,
-	0x55, 0x09, 0x2f, 0x75, 0x88, 0x9a, 0x1d, 0xd5, 0x34, 0x9c, 0xd2, 0x4d, 0x71, 0xde, 0x15, 0xda,
-	0xc6, 0xf3, 0x8a, 0x46, 0x24, 0x14, 0x92, 0xef, 0xc1, 0x8a, 0xdb, 0x39, 0x6a, 0x5e, 0x55, 0x6d,
-	0x0f, 0xaa, 0x79, 0x55, 0xe7, 0xb4, 0x9b, 0x2a, 0x81, 0xbc, 0xb1, 0x61, 0x16, 0xd9, 0x79, 0xae,
-	0xd2, 0xa4, 0x2f, 0xc8, 0xb7, 0x51, 0x75, 0xa8, 0xce, 0x30, 0x52, 0x74, 0xd2, 0xba, 0xfd, 0x63,
-	0x46, 0xda, 0x2b, 0x4d, 0x64, 0x74, 0x5d, 0x10, 0xef, 0x90, 0xe2, 0x04, 0xe4, 0x53, 0x58, 0x56,
-	0x1d, 0x62, 0xe4, 0x62, 0x21, 0xd5, 0x56, 0x52, 0x69, 0xb0, 0x55, 0x06, 0x2b, 0x62, 0x1b, 0x82,
-	0x58, 0x8f, 0x74, 0x90, 0xd8, 0x84, 0xf1, 0x10, 0x69, 0x44, 0xb0, 0xea, 0x56, 0xb0, 0x72, 0xc3,
-	0x8e, 0xda, 0xda, 0xb9, 0x61, 0x47, 0x7d, 0x39, 0xcc, 0x55, 0x32, 0x5a, 0xb9, 0xec, 0xe8, 0x46,
-	0x87, 0xdf, 0x86, 0xae, 0xdd, 0x8e, 0x68, 0x34, 0x76, 0x4d, 0xeb, 0xa2, 0xd1, 0xd8, 0x75, 0xfd,
-	0x8b, 0xfa, 0x6a, 0x49, 0xd7, 0x5e, 0x86, 0x7c, 0x07, 0x56, 0xad, 0x52, 0xeb, 0xe1, 0x3c, 0x1e,
-	0x19, 0xd1, 0xa9, 0x76, 0xbb, 0x0c, 0xea, 0x4c, 0x27, 0xbd, 0x24, 0x08, 0xaf, 0x53, 0x87, 0x30,
-	0x8a, 0xcd, 0x3d, 0xe8, 0xd8, 0x65, 0xdc, 0x97, 0xd0, 0xbd, 0x64, 0x4d, 0xd9, 0x2d, 0x22, 0xb7,
-	0x3c, 0xf2, 0x67, 0x1e, 0x74, 0xed, 0x3e, 0x2a, 0xe2, 0xe4, 0x65, 0x4a, 0x74, 0xfa, 0xf6, 0x9c,
-	0x4d, 0x88, 0x3e, 0x12, 0x9b, 0xdc, 0xbb, 0xf1, 0xd0, 0x61, 0xf2, 0x73, 0xc7, 0x25, 0xba, 0x69,
-	0xff, 0xcb, 0xe3, 0x45, 0x79, 0xd2, 0xee, 0x06, 0x7a, 0x71, 0xcb, 0x23, 0x1f, 0xca, 0xff, 0xf2,
-	0xe8, 0xf0, 0x84, 0x58, 0x6a, 0xad, 0xcc, 0x2e, 0xfb, 0x0f, 0x32, 0xdb, 0xde, 0x2d, 0x8f, 0xfc,
-	0x8e, 0xfc, 0x63, 0x87, 0xfa, 0x56, 0x70, 0xfd, 0x75, 0xbf, 0xa7, 0x6f, 0x89, 0x93, 0xbc, 0x49,
-	0x2f, 0x3b, 0x27, 0x29, 0xeb, 0xf5, 0x03, 0x80, 0x22, 0xd6, 0x24, 0xa5, 0xc0, 0xcb, 0x68, 0xbc,
-	0x6a, 0x38, 0xea, 0xde, 0xa6, 0x8e, 0xcf, 0xa4, 0x12, 0xe8, 0x5a, 0x51, 0x5e, 0x6e, 0xae, 0xb3,
-	0x1a, 0x33, 0x0e, 0x06, 0x75, 0x53, 0x8a, 0xfe, 0x57, 0x05, 0xfd, 0x37, 0xc8, 0x15, 0x9b, 0xfe,
-	0xce, 0x73, 0x3b, 0xc6, 0x7c, 0x41, 0xbe, 0x80, 0xde, 0x7e, 0x92, 0x3c, 0x9d, 0xa5, 0x26, 0x9d,
-	0xe1, 0x46, 0x4d, 0x18, 0xe7, 0x0e, 0x4a, 0x87, 0xa2, 0xd7, 0x05, 0xe5, 0x2b, 0xe4, 0xb2, 0x4b,
-	0xb9, 0x88, 0x7c, 0x5f, 0x90, 0x00, 0xd6, 0x8d, 0xb5, 0x33, 0x07, 0x19, 0xb8, 0x74, 0xec, 0x00,
-	0xb4, 0xb2, 0x86, 0xe3, 0x7f, 0x98, 0x35, 0x72, 0x4d, 0xf3, 0x96, 0x47, 0x0e, 0xa0, 0x7b, 0x9f,
-	0x8d, 0x92, 0x31, 0x53, 0x81, 0xce, 0x46, 0xb1, 0x73, 0x13, 0x21, 0x0d, 0x7a, 0x0e, 0xd0, 0xd5,
-	0x00, 0x69, 0x30, 0xcf, 0xd8, 0x97, 0x3b, 0xcf, 0x55, 0x08, 0xf5, 0x42, 0x6b, 0x00, 0x1d, 0xf6,
-	0x39, 0x1a, 0xa0, 0x14, 0x27, 0x3a, 0x1a, 0xa0, 0x12, 0x27, 0x3a, 0x1a, 0x40, 0x87, 0x9d, 0x24,
-	0xc2, 0xe8, 0xb1, 0x14, 0x5a, 0x1a, 0x9b, 0x79, 0x5e, 0x40, 0x3a, 0xb8, 0x76, 0x3e, 0x82, 0xbb,
-	0xda, 0x0d, 0x77, 0xb5, 0x43, 0xe8, 0xdd, 0x67, 0x92, 0x59, 0xb2, 0xce, 0x30, 0x70, 0x55, 0x8a,
-	0x5d, 0x93, 0x28, 0xab, 0x1b, 0x31, 0xe7, 0x2a, 0x78, 0x91, 0xe4, 0x27, 0xdf, 0x85, 0xce, 0xc7,
-	0x8c, 0xeb, 0xc2, 0x82, 0xf1, 0x3c, 0x4a, 0x95, 0x86, 0x41, 0x4d, 0x5d, 0x82, 0x5e, 0x13, 0xd4,
-	0x06, 0xa4, 0x6f, 0xa8, 0xed, 0xb0, 0xf1, 0x84, 0xc9, 0xc7, 0x3f, 0x0c, 0xc7, 0x2f, 0xc8, 0x6f,
-	0x0a, 0xe2, 0xa6, 0x16, 0xb9, 0x65, 0xe5, 0xa3, 0x6d, 0xe2, 0xab, 0x25, 0x78, 0x1d, 0xe5, 0x38,
-	0x19, 0x33, 0xcb, 0xd4, 0xc5, 0xd0, 0xb1, 0x0a, 0xcf, 0xe6, 0x41, 0x55, 0xab, 0xd9, 0xe6, 0x41,
-	0xd5, 0xd4, 0xa9, 0xe9, 0xb6, 0x58, 0x87, 0x92, 0x6b, 0xc5, 0x3a, 0xb2, 0x36, 0x5d, 0xac, 0xb4,
-	0xf3, 0x3c, 0x98, 0xf2, 0x17, 0xe4, 0x89, 0x68, 0x9e, 0xb6, 0x8b, 0x27, 0x85, 0xe7, 0x53, 0xae,
-	0xb3, 0x18, 0x66, 0x59, 0x53, 0xae, 0x37, 0x24, 0x97, 0x12, 0x16, 0xf1, 0x1b, 0x00, 0x87, 0x3c,
-	0x49, 0xef, 0x07, 0x6c, 0x9a, 0xc4, 0x85, 0x26, 0x2b, 0x0a, 0x04, 0x85, 0x26, 0xb3, 0xaa, 0x04,
-	0xe4, 0x89, 0xe5, 0x7b, 0x3a, 0xb5, 0x27, 0x2d, 0x5c, 0xe7, 0xd6, 0x10, 0x0c, 0x43, 0x6a, 0xea,
-	0x08, 0xda, 0x0d, 0x95, 0xc9, 0x51, 0xcb, 0x0d, 0x75, 0xb2, 0xab, 0x96, 0x1b, 0xea, 0x66, 0x51,
-	0xd1, 0x0d, 0x2d, 0xb2, 0x20, 0xc6, 0x0d, 0xad, 0x24, 0x58, 0x8c, 0x0e, 0xad, 0x49, 0x99, 0x1c,
-	0x40, 0xbb, 0x08, 0xc5, 0xf5, 0x42, 0xe5, 0xc0, 0xdd, 0x18, 0xab, 0x4a, 0x84, 0x4c, 0xd7, 0x04,
-	0x9f, 0x81, 0xb4, 0x90, 0xcf, 0xa2, 0xf0, 0xfe, 0x18, 0x40, 0x9e, 0xee, 0x21, 0x8e, 0x2c, 0x92,
-	0x4e, 0x20, 0x6c, 0x93, 0x74, 0x23, 0x4e, 0xed, 0xc9, 0x50, 0x43, 0xf2, 0x43, 0xef, 0xc6, 0xd1,
-	0x92, 0xf8, 0xc3, 0xf2, 0xd7, 0xff, 0x27, 0x00, 0x00, 0xff, 0xff, 0x6a, 0xd9, 0x34, 0x9a, 0xe2,
-	0x3c, 0x00, 0x00,
+	// 8024 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7d, 0x5b, 0x6c, 0x1c, 0xc9,
+	0xb5, 0x98, 0xe6, 0xc1, 0xc7, 0x9c, 0x19, 0xbe, 0x8a, 0x14, 0x39, 0x6c, 0x52, 0x5a, 0xa9, 0xf6,
+	0x25, 0xcb, 0x1b, 0x51, 0x4b, 0x7b, 0x17, 0xbb, 0xda, 0x24, 0x0e, 0x45, 0x0e, 0x45, 0x7a, 0x25,
+	0x8a, 0x6e, 0x52, 0x2b, 0xaf, 0x17, 0xc6, 0xb8, 0x39, 0x53, 0x24, 0xdb, 0x9a, 0xe9, 0x9e, 0xed,
+	0xee, 0x21, 0xc5, 0x15, 0xe4, 0x24, 0x8e, 0x93, 0xaf, 0x24, 0xfe, 0x08, 0x90, 0xc4, 0x1f, 0x79,
+	0x23, 0x88, 0x83, 0xc0, 0x41, 0x3e, 0x83, 0x04, 0xc8, 0x67, 0x3e, 0x02, 0x04, 0x41, 0x90, 0xaf,
+	0x7c, 0x24, 0xc0, 0x85, 0xef, 0x97, 0x81, 0xfb, 0x79, 0xff, 0x2f, 0x4e, 0xbd, 0xba, 0xaa, 0xbb,
+	0x87, 0xd2, 0xee, 0xb5, 0xfd, 0xc5, 0xa9, 0x73, 0xaa, 0x4f, 0xbd, 0x4e, 0x9d, 0x3a, 0xaf, 0x2a,
+	0x42, 0x2d, 0x1a, 0x74, 0xee, 0x0c, 0xa2, 0x30, 0x09, 0xc9, 0x58, 0x2f, 0x88, 0x06, 0x1d, 0x67,
+	0xf5, 0x24, 0x0c, 0x4f, 0x7a, 0x6c, 0xcd, 0x1b, 0xf8, 0x6b, 0x5e, 0x10, 0x84, 0x89, 0x97, 0xf8,
+	0x61, 0x10, 0x8b, 0x4a, 0xb4, 0x07, 0xd3, 0x0f, 0x58, 0x70, 0xc0, 0x58, 0xd7, 0x65, 0x5f, 0x0e,
+	0x59, 0x9c, 0x90, 0x0f, 0x61, 0xb1, 0xe3, 0x0f, 0x4e, 0x59, 0xd4, 0x8e, 0x19, 0xeb, 0xb6, 0x07,
+	0x5e, 0x1c, 0x0f, 0x4e, 0x23, 0x2f, 0x66, 0xcd, 0xd2, 0x8d, 0xd2, 0xad, 0x86, 0x3b, 0x02, 0x4b,
+	0x28, 0x34, 0x38, 0x88, 0x05, 0x49, 0x14, 0x0e, 0x2e, 0x9a, 0x65, 0x5e, 0xdb, 0x82, 0xd1, 0x10,
+	0x66, 0x74, 0x6b, 0xf1, 0x20, 0x0c, 0x62, 0x46, 0xd6, 0x61, 0xc1, 0x24, 0xd8, 0x0f, 0x58, 0x3f,
+	0x0c, 0xfc, 0x4e, 0xb3, 0x74, 0xa3, 0x72, 0xab, 0xe6, 0x16, 0xe2, 0xc8, 0x2d, 0x98, 0x61, 0x81,
+	0xc0, 0xb0, 0x2e, 0xc7, 0xc9, 0xd6, 0xb2, 0x60, 0xfa, 0xcf, 0x4a, 0x30, 0xbf, 0x19, 0x31, 0x2f,
+	0x61, 0x4f, 0xbd, 0x5e, 0x8f, 0x25, 0x6a, 0x90, 0x0e, 0x4c, 0x62, 0xd7, 0xcf, 0xc3, 0xa8, 0x2b,
+	0x87, 0xa5, 0xcb, 0x23, 0x7b, 0x54, 0xbe, 0xa4, 0x47, 0xa3, 0x27, 0xad, 0x72, 0xd9, 0xa4, 0xd1,
+	0x45, 0x58, 0xb0, 0xbb, 0x27, 0x66, 0x85, 0xbe, 0x0f, 0xf3, 0x4f, 0x82, 0x5e, 0xd8, 0x79, 0xf6,
+	0xda, 0xdd, 0x46, 0x52, 0xf6, 0x27, 0x92, 0xd4, 0x87, 0xb0, 0xb0, 0xc5, 0x22, 0xff, 0x8c, 0xed,
+	0xb1, 0xe7, 0xc9, 0xa7, 0xec, 0x42, 0xd1, 0xba, 0x0e, 0xf0, 0x8c, 0x5d, 0xb4, 0x8f, 0xbd, 0xbe,
+	0xdf, 0xbb, 0xe0, 0xd4, 0xa6, 0x5c, 0x03, 0x42, 0x5f, 0xc0, 0xd5, 0xcc, 0x77, 0x72, 0xc5, 0xde,
+	0x82, 0xa9, 0xc8, 0x3b, 0x6f, 0x63, 0xd5, 0xa3, 0x8b, 0x84, 0xc5, 0xb2, 0x27, 0x36, 0x30, 0x43,
+	0xbe, 0x9c, 0x25, 0x4f, 0x56, 0xa1, 0x86, 0x25, 0x3f, 0xe8, 0xb2, 0xe7, 0x7c, 0x92, 0xa6, 0xdc,
+	0x14, 0x40, 0xbf, 0x82, 0x85, 0x03, 0xff, 0x24, 0x78, 0x3c, 0x4c, 0x06, 0xc3, 0xc4, 0xf5, 0xce,
+	0x55, 0xa7, 0x29, 0x34, 0xb0, 0x99, 0xe4, 0xb9, 0xd5, 0xb4, 0x05, 0x43, 0xca, 0xb1, 0x7f, 0x12,
+	0xb4, 0xbb, 0x2c, 0xee, 0x48, 0xbe, 0x48, 0x01, 0xe4, 0x06, 0xd4, 0xfd, 0x60, 0x30, 0x4c, 0x8c,
+	0x96, 0xc7, 0x5c, 0x13, 0x44, 0xbf, 0x05, 0x57, 0x33, 0x6d, 0xcb, 0x81, 0xcf, 0x42, 0x25, 0xf6,
+	0x4f, 0x64, 0x9b, 0xf8, 0x93, 0xfe, 0x4d, 0x58, 0xde, 0x0c, 0xfb, 0x83, 0x61, 0xc2, 0x76, 0x91,
+	0xc0, 0x41, 0x27, 0xf2, 0x07, 0xc9, 0x1f, 0xb3, 0xaf, 0x9f, 0x81, 0x53, 0xd4, 0x01, 0xd9, 0xe1,
+	0x26, 0x4c, 0x9c, 0xfb, 0x49, 0xc0, 0xe2, 0x98, 0x6f, 0xa7, 0x86, 0xab, 0x8a, 0xb8, 0x3a, 0xb1,
+	0x7f, 0xd2, 0x8e, 0x79, 0x7d, 0xd9, 0xb0, 0x01, 0xa1, 0x0f, 0x60, 0x19, 0xe7, 0xe0, 0x11, 0x8b,
+	0x63, 0xef, 0x84, 0x3d, 0xf5, 0x93, 0x53, 0x83, 0x73, 0x9a, 0x30, 0x31, 0x18, 0x1e, 0xe1, 0x5a,
+	0xcb, 0x31, 0xa9, 0x22, 0xce, 0x50, 0x3f, 0x3e, 0x91, 0xf4, 0xf0, 0x27, 0xbd, 0x07, 0x4e, 0x11,
+	0x21, 0xd9, 0x41, 0x39, 0x7c, 0x2f, 0x19, 0x46, 0x4a, 0xbc, 0xa4, 0x00, 0xba, 0x0e, 0x4d, 0xfc,
+	0x76, 0xcb, 0x3f, 0x61, 0x71, 0x82, 0xc3, 0xf4, 0x3a, 0x7a, 0x72, 0x17, 0x61, 0xbc, 0xcb, 0xe1,
+	0xf2, 0x33, 0x59, 0xa2, 0x1f, 0xc3, 0x72, 0xc1, 0x37, 0xaf, 0xd5, 0x9c, 0x07, 0x2b, 0x82, 0xe1,
+	0x5d, 0x76, 0x16, 0x76, 0xb8, 0x94, 0x74, 0xc3, 0x30, 0x31, 0xf6, 0xcb, 0x11, 0x6e, 0xaf, 0xf6,
+	0xa9, 0x17, 0x9f, 0xca, 0xaf, 0x0d, 0x08, 0x2e, 0x77, 0x10, 0x76, 0x59, 0x5b, 0x4d, 0x8d, 0x94,
+	0x7f, 0x26, 0x8c, 0xee, 0xc0, 0x6a, 0x71, 0x13, 0xb2, 0x83, 0xb7, 0x60, 0x26, 0xd2, 0x98, 0x76,
+	0x14, 0x86, 0x6a, 0x78, 0x59, 0x30, 0x5d, 0x82, 0xab, 0xbb, 0x5d, 0x16, 0x24, 0x7e, 0x72, 0xb1,
+	0x3f, 0x3c, 0x4a, 0x17, 0x87, 0xde, 0x87, 0xc5, 0x2c, 0x22, 0x25, 0xee, 0x4b, 0x0c, 0x76, 0x28,
+	0x5d, 0xbe, 0x2c, 0x98, 0x6e, 0xc1, 0xa2, 0xe8, 0xe6, 0xc1, 0xa9, 0x17, 0xb1, 0xae, 0xb1, 0xf4,
+	0xb7, 0x61, 0x96, 0x0d, 0x4e, 0x59, 0x9f, 0x45, 0x5e, 0xcf, 0x26, 0x92, 0x83, 0xd3, 0x8f, 0x61,
+	0x29, 0x47, 0x45, 0x76, 0x05, 0xd9, 0x8f, 0x03, 0x0d, 0x26, 0x32, 0x20, 0xb4, 0x0d, 0xcb, 0xfb,
+	0x51, 0xd8, 0x61, 0x71, 0xfc, 0x38, 0xf0, 0xc3, 0x60, 0xdf, 0xeb, 0x3c, 0x63, 0xe6, 0xbe, 0x0a,
+	0x11, 0xda, 0x1e, 0x70, 0xb0, 0xda, 0x57, 0x26, 0x0c, 0x1b, 0xf0, 0xe2, 0x38, 0xec, 0xb4, 0xbb,
+	0x5e, 0xe2, 0x29, 0xfe, 0x4e, 0x21, 0xf4, 0x1f, 0x96, 0xc1, 0x29, 0x6a, 0x41, 0xf6, 0x6f, 0x07,
+	0x26, 0x8e, 0x3d, 0xbf, 0xa7, 0xd8, 0x64, 0x7a, 0xfd, 0xce, 0x1d, 0x7e, 0x98, 0xde, 0x19, 0xfd,
+	0xcd, 0x1d, 0x0e, 0xdb, 0x16, 0x5f, 0xb9, 0xea, 0x73, 0xe4, 0x53, 0xaf, 0x83, 0xcb, 0x26, 0x45,
+	0xa0, 0x2c, 0xa1, 0x24, 0x3f, 0x0d, 0x07, 0xa2, 0x7b, 0xe2, 0x88, 0xd0, 0x65, 0xdc, 0xf6, 0x01,
+	0x7b, 0x9e, 0xa8, 0xf1, 0x55, 0x39, 0xda, 0x04, 0xd1, 0x7d, 0x68, 0x98, 0xcd, 0x91, 0x49, 0xa8,
+	0xee, 0x3d, 0xde, 0x6b, 0xcd, 0x5e, 0x21, 0xf3, 0x30, 0xb3, 0xbb, 0xf7, 0xd9, 0xc6, 0xc3, 0xdd,
+	0xad, 0xf6, 0x67, 0x2d, 0xf7, 0x60, 0xf7, 0xf1, 0xde, 0x6c, 0x89, 0xcc, 0x42, 0x43, 0x01, 0x77,
+	0x1e, 0x6d, 0x6c, 0xce, 0x96, 0xc9, 0x0c, 0xd4, 0x15, 0xe4, 0xd3, 0xd6, 0xe7, 0xb3, 0x15, 0xda,
+	0x54, 0x4b, 0x7e, 0xdf, 0xeb, 0x3c, 0x1b, 0x0e, 0x0c, 0x86, 0xd2, 0xcb, 0x68, 0x60, 0xd2, 0x65,
+	0x3c, 0xe2, 0x40, 0x73, 0x19, 0x53, 0x08, 0xfd, 0x55, 0x19, 0xea, 0x87, 0x91, 0x17, 0xc4, 0x72,
+	0xd0, 0x4d, 0x98, 0x48, 0x9e, 0xa7, 0xfb, 0xa7, 0xe6, 0xaa, 0x22, 0x9f, 0xa6, 0x7e, 0x38, 0x0c,
+	0x84, 0x2c, 0xaa, 0xb8, 0xb2, 0x44, 0xde, 0x83, 0xb9, 0x60, 0xd8, 0x6f, 0x77, 0xc2, 0xe0, 0xd8,
+	0x8f, 0xfa, 0x42, 0x73, 0x91, 0x72, 0x30, 0x8f, 0xc8, 0x6c, 0xd1, 0x2a, 0x6f, 0x22, 0xb3, 0x45,
+	0x65, 0x89, 0xf9, 0x27, 0xa7, 0x49, 0x73, 0x8c, 0x13, 0xb2, 0x60, 0x48, 0x23, 0xf1, 0xfb, 0xac,
+	0x1d, 0x27, 0x5e, 0x7f, 0xd0, 0x1c, 0xe7, 0xbd, 0x31, 0x20, 0x1c, 0x1f, 0x26, 0x5e, 0xaf, 0x7d,
+	0xcc, 0x58, 0xdc, 0x9c, 0x90, 0x78, 0x0d, 0x21, 0xef, 0xc0, 0x74, 0x97, 0xc5, 0x49, 0xdb, 0xeb,
+	0x76, 0x23, 0x16, 0xc7, 0x2c, 0x6e, 0x4e, 0x72, 0xbd, 0x21, 0x03, 0xc5, 0x09, 0x7f, 0xc0, 0x12,
+	0x63, 0x76, 0x62, 0x35, 0xe1, 0x0f, 0x81, 0x18, 0xe0, 0x2d, 0x96, 0x78, 0x7e, 0x2f, 0x26, 0x1f,
+	0x42, 0x23, 0x31, 0x2a, 0x73, 0x81, 0x5e, 0x5f, 0x27, 0x92, 0x2f, 0x8d, 0x0f, 0x5c, 0xab, 0x1e,
+	0x7d, 0x00, 0x93, 0xdb, 0x8c, 0x3d, 0xf4, 0xfb, 0x3e, 0x0a, 0xcd, 0xb1, 0x63, 0xff, 0x39, 0x13,
+	0xba, 0x43, 0x65, 0xe7, 0x8a, 0x2b, 0x8a, 0xc4, 0x81, 0x89, 0x01, 0x8b, 0x3a, 0x4c, 0x4d, 0xff,
+	0xce, 0x15, 0x57, 0x01, 0xee, 0x4f, 0xc0, 0x58, 0x0f, 0x3f, 0xa6, 0xff, 0xb9, 0x0c, 0xf5, 0x03,
+	0x16, 0x68, 0x3d, 0x91, 0x40, 0xb5, 0x9b, 0xca, 0x5f, 0xfe, 0x9b, 0xbc, 0x01, 0x75, 0x3e, 0xcc,
+	0x38, 0x89, 0xfc, 0x40, 0x9c, 0x03, 0x35, 0x17, 0x10, 0x74, 0xc0, 0x21, 0x78, 0x40, 0x78, 0xfd,
+	0x84, 0xaf, 0x60, 0xc5, 0xc5, 0x9f, 0xe4, 0x26, 0x34, 0x06, 0xde, 0x45, 0x9f, 0x05, 0x49, 0xba,
+	0x6a, 0x0d, 0xb7, 0x2e, 0x61, 0x3b, 0xb8, 0x6c, 0x77, 0x60, 0xde, 0xac, 0xa2, 0xa8, 0x8f, 0x71,
+	0xea, 0x73, 0x46, 0x4d, 0xd9, 0xc8, 0xbb, 0x30, 0xa3, 0xea, 0x47, 0xa2, 0xb3, 0x7c, 0x1d, 0x6b,
+	0xee, 0xb4, 0x04, 0xab, 0x21, 0xbc, 0x07, 0xb5, 0x63, 0xc6, 0xda, 0x7c, 0x7c, 0x7c, 0x29, 0xeb,
+	0xeb, 0x33, 0x72, 0x42, 0xd5, 0x9c, 0xb9, 0x93, 0xc7, 0xf2, 0x17, 0xb9, 0x06, 0xd0, 0xe9, 0x25,
+	0x67, 0xb2, 0xfa, 0xa4, 0x50, 0x59, 0x10, 0x22, 0xd0, 0xcb, 0x30, 0x89, 0xfa, 0x4b, 0xcc, 0x82,
+	0x6e, 0xb3, 0x76, 0xa3, 0x74, 0x6b, 0xd2, 0x9d, 0x78, 0xc6, 0x2e, 0x70, 0xc6, 0xe8, 0x7f, 0x2f,
+	0x41, 0x43, 0x4c, 0x5d, 0xaa, 0x42, 0xa9, 0x1e, 0xb2, 0x28, 0x0a, 0x23, 0xb9, 0x1d, 0x6c, 0x20,
+	0x0a, 0x5b, 0x05, 0x18, 0x44, 0xcc, 0xef, 0x7b, 0x27, 0x4c, 0x8a, 0xb2, 0x1c, 0x9c, 0xac, 0xa7,
+	0x14, 0xa3, 0x70, 0x98, 0x08, 0xbd, 0xb3, 0xbe, 0xde, 0x90, 0xc3, 0x71, 0x11, 0xe6, 0xda, 0x55,
+	0xc8, 0x5d, 0x68, 0xa0, 0xcc, 0xed, 0x8a, 0x62, 0xdc, 0xac, 0xde, 0xa8, 0xe4, 0x3e, 0xb1, 0x6a,
+	0xd0, 0x9f, 0x97, 0xa0, 0xb1, 0x79, 0xea, 0x05, 0x01, 0xeb, 0xed, 0x87, 0x7e, 0xc0, 0x65, 0xf1,
+	0xf1, 0x30, 0xe8, 0xfa, 0xc1, 0x49, 0x3b, 0x79, 0xee, 0x2b, 0xa5, 0xd4, 0x82, 0xe1, 0x30, 0xcc,
+	0x32, 0x2e, 0x9f, 0xe4, 0x8c, 0x1c, 0x9c, 0xcb, 0xf6, 0x61, 0xa2, 0xf5, 0x1b, 0xa9, 0x18, 0x5a,
+	0x30, 0xfa, 0xd7, 0x61, 0xf6, 0x21, 0x6e, 0xd5, 0xc0, 0x0f, 0x4e, 0x36, 0xc4, 0x7e, 0x42, 0xf9,
+	0x61, 0x9c, 0x46, 0x35, 0x57, 0x96, 0x90, 0x49, 0x4f, 0xc3, 0x38, 0x91, 0xed, 0xf1, 0xdf, 0xf4,
+	0x6f, 0x97, 0x61, 0x06, 0x57, 0xe3, 0x91, 0x17, 0xe8, 0x73, 0xed, 0x21, 0x34, 0x90, 0xd4, 0x61,
+	0xb8, 0x21, 0xa4, 0x90, 0xd8, 0x5d, 0xb7, 0xe4, 0x54, 0x64, 0x6a, 0xdf, 0x31, 0xab, 0xb6, 0x82,
+	0x24, 0xba, 0x70, 0xad, 0xaf, 0x71, 0x1b, 0x24, 0x5e, 0x74, 0xc2, 0x12, 0x2e, 0x9f, 0xa4, 0xbc,
+	0x02, 0x01, 0xda, 0x0c, 0x83, 0x63, 0x72, 0x03, 0x1a, 0xb1, 0x97, 0xb4, 0x07, 0x2c, 0xe2, 0x7a,
+	0x20, 0x67, 0xe5, 0x8a, 0x0b, 0xb1, 0x97, 0xec, 0xb3, 0xe8, 0xfe, 0x45, 0xc2, 0x55, 0x15, 0x1c,
+	0x34, 0x4e, 0x72, 0xdc, 0x1c, 0xe7, 0x12, 0x24, 0x05, 0x38, 0xdf, 0x83, 0xb9, 0x5c, 0x1f, 0x70,
+	0x6f, 0xa5, 0x13, 0x80, 0x3f, 0xc9, 0x02, 0x8c, 0x9d, 0x79, 0xbd, 0x21, 0x93, 0x42, 0x55, 0x14,
+	0xee, 0x95, 0x3f, 0x2a, 0xd1, 0x77, 0x60, 0x36, 0x1d, 0x94, 0x64, 0x4a, 0x02, 0x55, 0xbd, 0x86,
+	0x35, 0x97, 0xff, 0xa6, 0xff, 0xa2, 0x24, 0x2a, 0x6e, 0x86, 0xbe, 0x16, 0x50, 0x58, 0x11, 0xe5,
+	0x98, 0xaa, 0x88, 0xbf, 0x47, 0x0a, 0xf0, 0x3f, 0xf4, 0x54, 0xd0, 0x77, 0x61, 0xce, 0xe8, 0xe0,
+	0x25, 0x43, 0x09, 0x61, 0xfa, 0xfe, 0xb0, 0x3f, 0xd8, 0x66, 0xcc, 0xb0, 0xa6, 0x14, 0x1d, 0x59,
+	0x53, 0x97, 0xb3, 0xfd, 0x2e, 0xbf, 0xb2, 0xdf, 0x95, 0x6c, 0xbf, 0x29, 0x83, 0x19, 0xdd, 0x60,
+	0x7a, 0x60, 0x76, 0x4e, 0xfd, 0x5e, 0xb7, 0x6d, 0xf4, 0xce, 0x80, 0xa0, 0xe9, 0x89, 0x27, 0xbc,
+	0x77, 0xc2, 0xda, 0x16, 0x71, 0x31, 0xa7, 0x85, 0x38, 0xfa, 0x2f, 0x4b, 0x50, 0x7d, 0x92, 0x3c,
+	0x0f, 0x2f, 0x1d, 0x4e, 0x13, 0x26, 0xe4, 0xd1, 0x23, 0xb7, 0x82, 0x2a, 0x72, 0x4d, 0x89, 0x2f,
+	0x15, 0x52, 0x55, 0xa3, 0x48, 0x21, 0x38, 0xfb, 0x83, 0x67, 0xca, 0x50, 0x10, 0x47, 0x6a, 0x0a,
+	0x40, 0x41, 0x66, 0x9f, 0xcd, 0x62, 0xf9, 0x6c, 0x20, 0xdd, 0x03, 0xf2, 0xd0, 0x8f, 0x93, 0x27,
+	0x41, 0x3c, 0x30, 0xa4, 0xef, 0x0a, 0xd4, 0xfa, 0x7e, 0xc0, 0xe7, 0x57, 0x18, 0x47, 0x63, 0xee,
+	0x64, 0xdf, 0x0f, 0x70, 0x76, 0x63, 0x8e, 0xf4, 0x9e, 0x4b, 0x64, 0x59, 0x22, 0xbd, 0xe7, 0x1c,
+	0x49, 0x3f, 0x82, 0x79, 0x8b, 0x9e, 0x9c, 0xdd, 0x9b, 0x30, 0x36, 0x4c, 0x9e, 0x87, 0xea, 0x6c,
+	0xac, 0xcb, 0xdd, 0x8b, 0x93, 0xe3, 0x0a, 0x0c, 0xfd, 0x02, 0xc8, 0x43, 0xe6, 0xc5, 0x4c, 0x1a,
+	0x77, 0xaf, 0xc1, 0x08, 0xdf, 0x82, 0xd9, 0xee, 0x30, 0x12, 0x9a, 0x77, 0xcc, 0x3a, 0x61, 0xd0,
+	0x8d, 0xe5, 0x72, 0xcc, 0x28, 0xf8, 0x81, 0x00, 0xd3, 0x0f, 0x60, 0xde, 0x22, 0x9e, 0x2e, 0x3a,
+	0x7b, 0x3e, 0xf0, 0x45, 0x5d, 0x71, 0xf4, 0xba, 0x06, 0x84, 0xae, 0xc3, 0x82, 0xcb, 0x7a, 0x5f,
+	0xab, 0x57, 0xa8, 0xfe, 0x67, 0xbe, 0x91, 0xd6, 0xfe, 0x2e, 0xd4, 0x05, 0x84, 0xf7, 0xe4, 0xd2,
+	0x91, 0xd9, 0xfd, 0x2a, 0xe7, 0xfa, 0x35, 0x0f, 0x73, 0x38, 0xcb, 0x9c, 0x90, 0x56, 0x4e, 0xfe,
+	0x06, 0x10, 0x13, 0x28, 0x87, 0x78, 0x1b, 0xc6, 0x79, 0x67, 0xb2, 0x6a, 0x89, 0xd1, 0x15, 0x57,
+	0xd6, 0xa0, 0xff, 0xbc, 0x04, 0x73, 0x7b, 0xec, 0x5c, 0x4a, 0x6e, 0x35, 0xd8, 0x8f, 0xa0, 0x9a,
+	0x5c, 0x0c, 0x94, 0xba, 0xfd, 0x96, 0xfc, 0x3e, 0x57, 0xef, 0x8e, 0x2c, 0x1e, 0x5e, 0x0c, 0x98,
+	0xcb, 0xbf, 0xa0, 0x8f, 0xa1, 0x6e, 0x00, 0xc9, 0x12, 0xcc, 0x3f, 0xdd, 0x3d, 0xdc, 0x6b, 0x1d,
+	0x1c, 0xb4, 0xf7, 0x9f, 0xdc, 0xff, 0xb4, 0xf5, 0x79, 0x7b, 0x67, 0xe3, 0x60, 0x67, 0xf6, 0x0a,
+	0x59, 0x04, 0xb2, 0xd7, 0x3a, 0x38, 0x6c, 0x6d, 0x59, 0xf0, 0x12, 0xaa, 0xc2, 0x26, 0xa0, 0x4c,
+	0x1d, 0x68, 0xee, 0xb1, 0xf3, 0xa7, 0xc2, 0x52, 0xb6, 0x9b, 0xa7, 0x77, 0x80, 0x98, 0x7d, 0x4a,
+	0xed, 0x6c, 0xb5, 0xbb, 0x4a, 0xd6, 0xee, 0xa2, 0xef, 0x00, 0x31, 0xcc, 0x5f, 0x35, 0x58, 0x69,
+	0x26, 0x97, 0x52, 0x33, 0xf9, 0x3b, 0x30, 0x6f, 0xd5, 0x1b, 0x65, 0xb0, 0xd6, 0x4c, 0x83, 0x75,
+	0x1b, 0x16, 0x3e, 0x63, 0x91, 0x7f, 0x7c, 0xf1, 0x2a, 0xf2, 0x36, 0x9d, 0x72, 0x96, 0x4e, 0x0b,
+	0xae, 0x66, 0xe8, 0xc8, 0xe6, 0xc5, 0xf9, 0x21, 0x25, 0xd5, 0xa4, 0x2b, 0x0a, 0xc6, 0x59, 0x5b,
+	0x36, 0xcf, 0x5a, 0xfa, 0x04, 0xc8, 0x66, 0x18, 0x04, 0xac, 0x93, 0xec, 0x33, 0x16, 0xa9, 0xce,
+	0x7c, 0xdb, 0x38, 0x2c, 0xea, 0xeb, 0x4b, 0x72, 0x61, 0xb3, 0x07, 0xb8, 0x3c, 0x45, 0x08, 0x54,
+	0x07, 0x2c, 0xea, 0x73, 0xc2, 0x93, 0x2e, 0xff, 0x4d, 0xd7, 0x60, 0xde, 0x22, 0x9b, 0xce, 0xf9,
+	0x80, 0xb1, 0xa8, 0x2d, 0x7b, 0x37, 0xe6, 0xaa, 0x22, 0x7d, 0x1f, 0xae, 0x6e, 0xf9, 0x71, 0x27,
+	0xdf, 0x95, 0x8c, 0xdf, 0xa2, 0xa6, 0xfd, 0x16, 0xdc, 0xfa, 0xc9, 0x7c, 0x22, 0xf7, 0xd3, 0xdf,
+	0x2b, 0x41, 0x75, 0xe7, 0xf0, 0xe1, 0x26, 0xee, 0x24, 0x3f, 0xe8, 0x84, 0x7d, 0xd4, 0x3c, 0xc5,
+	0x74, 0xe8, 0xf2, 0xc8, 0xc3, 0x6f, 0x15, 0x6a, 0x5c, 0x61, 0x45, 0xfb, 0x42, 0x5a, 0x79, 0x29,
+	0x00, 0x6d, 0x9b, 0x74, 0xb7, 0x29, 0x93, 0xa4, 0xca, 0x15, 0x9e, 0x3c, 0x82, 0xfe, 0xae, 0x0a,
+	0x53, 0x1b, 0x9d, 0xc4, 0x3f, 0x63, 0x52, 0x01, 0x53, 0xa6, 0xe5, 0x19, 0x93, 0xfd, 0x91, 0x25,
+	0xee, 0x9f, 0x63, 0xfd, 0x30, 0x61, 0x6d, 0x6b, 0x99, 0x6c, 0x20, 0xd6, 0xea, 0x08, 0x42, 0x6d,
+	0x21, 0x1e, 0x2a, 0xa2, 0x96, 0x05, 0xc4, 0x29, 0x43, 0x00, 0xce, 0x32, 0xf6, 0xac, 0xea, 0xaa,
+	0x22, 0xce, 0x47, 0xc7, 0x1b, 0x78, 0x1d, 0x3f, 0xb9, 0x90, 0x42, 0x5f, 0x97, 0x91, 0x76, 0x2f,
+	0xec, 0x78, 0xbd, 0xf6, 0x91, 0xd7, 0xf3, 0x82, 0x0e, 0x93, 0x66, 0x94, 0x0d, 0x44, 0x4b, 0x49,
+	0x76, 0x49, 0x55, 0x13, 0xd6, 0x54, 0x06, 0xca, 0x0f, 0xcd, 0xb0, 0xdf, 0xf7, 0x13, 0x34, 0xb0,
+	0xb8, 0xde, 0x5d, 0x71, 0x0d, 0x88, 0x38, 0x83, 0x78, 0xe9, 0x5c, 0xcc, 0x61, 0x4d, 0x9d, 0x41,
+	0x06, 0x10, 0xa9, 0xa0, 0xae, 0x8f, 0xc7, 0xe6, 0xb3, 0xf3, 0x26, 0x08, 0x2a, 0x29, 0x04, 0x57,
+	0x63, 0x18, 0xc4, 0x2c, 0x49, 0x7a, 0xac, 0xab, 0x3b, 0x54, 0xe7, 0xd5, 0xf2, 0x08, 0x72, 0x17,
+	0xe6, 0x85, 0xcd, 0x17, 0x7b, 0x49, 0x18, 0x9f, 0xfa, 0x71, 0x3b, 0x46, 0xeb, 0xa9, 0xc1, 0xeb,
+	0x17, 0xa1, 0xc8, 0x47, 0xb0, 0x94, 0x01, 0x47, 0xac, 0xc3, 0xfc, 0x33, 0xd6, 0x6d, 0x4e, 0xf1,
+	0xaf, 0x46, 0xa1, 0xb9, 0x3b, 0x60, 0xd8, 0x6f, 0x0f, 0x07, 0x5d, 0x0f, 0xb5, 0xf4, 0x69, 0xbe,
+	0x0e, 0x26, 0x88, 0xbc, 0x0f, 0x53, 0x03, 0x26, 0x34, 0xe9, 0xd3, 0xa4, 0xd7, 0x89, 0x9b, 0x33,
+	0xd6, 0x01, 0x88, 0xfc, 0xeb, 0xda, 0x35, 0x90, 0x35, 0x3b, 0xf1, 0x59, 0xbb, 0xcb, 0x7a, 0xde,
+	0x45, 0x73, 0x56, 0xda, 0x32, 0x0a, 0x40, 0xaf, 0x8a, 0x03, 0x56, 0x72, 0x9a, 0x96, 0x7e, 0x3b,
+	0xb0, 0x60, 0x83, 0xe5, 0x5e, 0xbc, 0x0b, 0x93, 0x92, 0x6d, 0xe2, 0x66, 0x9d, 0x37, 0xbd, 0x20,
+	0x9b, 0xb6, 0x38, 0xd6, 0xd5, 0xb5, 0xe8, 0x2f, 0xca, 0x50, 0xc5, 0x7d, 0x36, 0x7a, 0x4f, 0x9a,
+	0x1b, 0xbc, 0x6c, 0x6d, 0x70, 0x53, 0xdc, 0x56, 0x72, 0xca, 0x0c, 0xf7, 0xab, 0x8a, 0xd5, 0x10,
+	0x1c, 0x6b, 0x40, 0x52, 0x7c, 0xc4, 0x3a, 0x67, 0xcd, 0x31, 0x13, 0x8f, 0x10, 0x64, 0x6a, 0xd4,
+	0xad, 0xf8, 0xd7, 0x82, 0x67, 0x75, 0x59, 0xe1, 0xf8, 0x97, 0x13, 0x29, 0x8e, 0x7f, 0xd7, 0x84,
+	0x09, 0x3f, 0x38, 0x0a, 0x87, 0x41, 0x97, 0xf3, 0xe7, 0xa4, 0xab, 0x8a, 0x5c, 0x7d, 0xe2, 0x16,
+	0x8e, 0xdf, 0x67, 0x92, 0x31, 0x53, 0x00, 0x25, 0x68, 0xca, 0xc4, 0x5c, 0xe2, 0xe8, 0x49, 0xfe,
+	0x10, 0xe6, 0x0c, 0x58, 0xaa, 0xda, 0xe0, 0xe8, 0xb3, 0xaa, 0x0d, 0x56, 0x72, 0x05, 0x86, 0xce,
+	0x62, 0x24, 0x27, 0xd9, 0x0d, 0x8e, 0x43, 0x45, 0xe9, 0xcf, 0xcb, 0x30, 0xa3, 0x41, 0x97, 0x3b,
+	0x01, 0x6b, 0x39, 0x27, 0x20, 0x0a, 0x7f, 0xaf, 0xe7, 0x7b, 0x4a, 0x61, 0x14, 0x05, 0xd4, 0x50,
+	0x91, 0xf3, 0x14, 0x33, 0xe9, 0x65, 0x17, 0x86, 0x5a, 0x21, 0x0e, 0x37, 0x0b, 0xc2, 0x85, 0x78,
+	0x4a, 0x3f, 0x11, 0xa2, 0xae, 0x08, 0x85, 0xb3, 0x26, 0x28, 0xe1, 0x90, 0xc7, 0x04, 0x77, 0x6a,
+	0x40, 0xce, 0x8d, 0x33, 0x2e, 0x8c, 0xc4, 0xac, 0x1b, 0xc7, 0x70, 0x05, 0x4d, 0xe6, 0x5c, 0x41,
+	0xb7, 0x60, 0x26, 0xbe, 0x08, 0x3a, 0xac, 0xdb, 0x4e, 0x42, 0x6c, 0xd7, 0x0f, 0xa4, 0xd1, 0x9e,
+	0x05, 0xe3, 0xda, 0x26, 0x2c, 0x4e, 0x02, 0x96, 0x70, 0xa9, 0x31, 0xe9, 0xaa, 0x22, 0x0a, 0x60,
+	0x5e, 0x45, 0x30, 0x7d, 0xcd, 0x95, 0x25, 0xfa, 0x15, 0x3f, 0x08, 0xb5, 0xfe, 0xfb, 0x84, 0xef,
+	0x52, 0xd4, 0x68, 0x45, 0xfb, 0xf1, 0xa9, 0xa7, 0x82, 0x37, 0x1c, 0x70, 0x70, 0xea, 0xa1, 0x17,
+	0xc4, 0x1a, 0x92, 0xe0, 0xf8, 0x3a, 0x87, 0xed, 0x88, 0x11, 0xbd, 0x05, 0xd3, 0xca, 0xe3, 0x15,
+	0xb7, 0x7b, 0xec, 0x38, 0x51, 0xc6, 0x71, 0x30, 0xec, 0x63, 0x73, 0xf1, 0x43, 0x76, 0x9c, 0xd0,
+	0x3d, 0x98, 0x93, 0xbb, 0xed, 0xf1, 0x80, 0xa9, 0xa6, 0x3f, 0xce, 0xca, 0x7a, 0x71, 0x18, 0xcf,
+	0x4b, 0x2e, 0x32, 0x2d, 0xfa, 0xcc, 0x01, 0x40, 0x5d, 0x20, 0x12, 0xbd, 0xd9, 0x0b, 0x63, 0x26,
+	0x09, 0x52, 0x68, 0x74, 0x7a, 0x61, 0x9c, 0x35, 0xfb, 0x4d, 0x18, 0xce, 0x5b, 0x3c, 0xec, 0x74,
+	0x94, 0xc9, 0x31, 0xe9, 0xaa, 0x22, 0xfd, 0x35, 0x06, 0xe5, 0x90, 0x9a, 0x92, 0x0b, 0x5a, 0x07,
+	0x7c, 0xfd, 0x6e, 0x36, 0x3a, 0x46, 0x09, 0x79, 0xf5, 0x38, 0x8c, 0x3a, 0x4c, 0xb6, 0x24, 0x0a,
+	0xbf, 0x07, 0xdb, 0x93, 0xfe, 0xdf, 0x12, 0xcc, 0xf1, 0xae, 0x1e, 0x24, 0x5e, 0x32, 0x8c, 0xe5,
+	0xf0, 0xff, 0x2a, 0x4c, 0xe1, 0x50, 0x99, 0x62, 0x75, 0xd9, 0xd1, 0x05, 0xbd, 0x2b, 0x39, 0x54,
+	0x54, 0xde, 0xb9, 0xe2, 0xda, 0x95, 0xc9, 0xf7, 0xa0, 0x61, 0x9a, 0x47, 0xbc, 0xcf, 0xf5, 0xf5,
+	0x65, 0x35, 0xca, 0x1c, 0xe7, 0xec, 0x5c, 0x71, 0xad, 0x0f, 0xc8, 0x27, 0x68, 0x45, 0x7a, 0x41,
+	0x9b, 0x93, 0x6d, 0x56, 0xec, 0xcf, 0x73, 0x8b, 0xb5, 0x73, 0xc5, 0x35, 0xaa, 0xdf, 0x9f, 0x84,
+	0x71, 0x71, 0x6c, 0xd0, 0x07, 0x30, 0x65, 0xf5, 0xd4, 0xb2, 0x9a, 0x1b, 0xc2, 0x6a, 0xce, 0x39,
+	0x64, 0xca, 0x05, 0x0e, 0x99, 0x7f, 0x5b, 0x82, 0x79, 0x97, 0x79, 0xdd, 0x8b, 0xed, 0x30, 0xda,
+	0x8f, 0x8f, 0x92, 0x6d, 0xe1, 0xd5, 0xc1, 0x3d, 0x66, 0xca, 0x82, 0xb6, 0x26, 0x9d, 0x05, 0x63,
+	0x4d, 0xe5, 0x0a, 0xb2, 0xcd, 0xd4, 0x2c, 0x18, 0x95, 0x06, 0x0d, 0x12, 0x2a, 0x97, 0x30, 0x59,
+	0x33, 0x50, 0xae, 0x49, 0xc6, 0x47, 0xca, 0x79, 0xce, 0x7f, 0x53, 0x06, 0x8e, 0xec, 0x1a, 0xef,
+	0xa5, 0x1f, 0x78, 0x3d, 0xff, 0x2b, 0xad, 0x35, 0xbf, 0x33, 0xaa, 0xb7, 0xea, 0xe4, 0xc4, 0xa9,
+	0xdd, 0xed, 0x22, 0x57, 0xa1, 0xea, 0x8c, 0x71, 0x5c, 0x6c, 0x20, 0x8d, 0x9d, 0x05, 0xac, 0x8b,
+	0x74, 0xe9, 0x06, 0xac, 0x14, 0x36, 0x23, 0x25, 0x70, 0x91, 0xcb, 0xac, 0x66, 0xbb, 0xcc, 0xe8,
+	0x7d, 0x68, 0x1a, 0x24, 0x36, 0x51, 0xe5, 0xe8, 0x7d, 0xcd, 0x7e, 0xd2, 0x15, 0x58, 0x2e, 0xa0,
+	0x21, 0xd5, 0xda, 0x3f, 0x2b, 0x03, 0x41, 0x01, 0x91, 0xd9, 0x81, 0xef, 0xc0, 0xb4, 0xdc, 0x31,
+	0xb6, 0x6e, 0x9d, 0x81, 0x72, 0x95, 0x44, 0xc6, 0xb5, 0xd2, 0x50, 0x97, 0x09, 0x22, 0x77, 0x80,
+	0x18, 0x45, 0xe5, 0xb2, 0x15, 0xc7, 0x75, 0x01, 0x06, 0xcf, 0x15, 0xa1, 0x1d, 0x66, 0x56, 0xb7,
+	0x2a, 0x3c, 0x1f, 0x45, 0x38, 0x1e, 0x0d, 0x1f, 0xa2, 0x3f, 0xd8, 0x4b, 0x94, 0x0a, 0xaa, 0xca,
+	0xd9, 0xbd, 0x3f, 0xfe, 0xca, 0xbd, 0x3f, 0x91, 0xf3, 0x3b, 0xa1, 0x02, 0x12, 0xf9, 0x67, 0x5e,
+	0xc2, 0xd4, 0xa1, 0x2e, 0x8b, 0xb6, 0x47, 0xaa, 0x96, 0xf1, 0x48, 0x69, 0xd6, 0x03, 0x69, 0xc4,
+	0x20, 0x4f, 0xfc, 0xb2, 0x0c, 0xb3, 0x38, 0xdf, 0x96, 0x18, 0xb9, 0x07, 0x5c, 0x8a, 0xbd, 0xa6,
+	0x14, 0xb1, 0xea, 0xfe, 0xe5, 0x85, 0xc8, 0x47, 0x50, 0xe3, 0x04, 0xc3, 0x01, 0x0b, 0xa4, 0x0c,
+	0x69, 0xda, 0x32, 0x24, 0x3d, 0x40, 0x76, 0xae, 0xb8, 0x69, 0x65, 0x72, 0x0f, 0x6a, 0x38, 0x26,
+	0xbe, 0x1a, 0x7c, 0x7d, 0xea, 0xeb, 0x8e, 0xfc, 0xb2, 0x40, 0x0a, 0xe0, 0xb7, 0xba, 0xba, 0x21,
+	0x7d, 0xfe, 0x57, 0x09, 0xea, 0x72, 0x88, 0xdf, 0xd8, 0xbe, 0x32, 0xbd, 0x1b, 0x95, 0x8c, 0x77,
+	0xe3, 0x16, 0xcc, 0xf4, 0xd1, 0xbc, 0x45, 0x8d, 0xc6, 0xb2, 0xad, 0xb2, 0x60, 0x54, 0x4f, 0xf8,
+	0x39, 0x1b, 0xb7, 0x13, 0xbf, 0xd7, 0x56, 0x58, 0x19, 0x1c, 0x2a, 0x42, 0xe1, 0x71, 0x13, 0x27,
+	0xe8, 0x8d, 0x17, 0x9a, 0x87, 0x28, 0xa0, 0x4f, 0x66, 0x3f, 0xdd, 0x81, 0xe9, 0xae, 0xa2, 0xff,
+	0x6f, 0x0a, 0x16, 0xb3, 0x18, 0xad, 0x39, 0x4b, 0x63, 0xa1, 0xe7, 0xf7, 0x8f, 0x42, 0x6d, 0x77,
+	0x94, 0x4c, 0x3b, 0xc2, 0x42, 0x91, 0x63, 0xb8, 0xaa, 0xb6, 0x3f, 0xae, 0x46, 0xaa, 0x4e, 0x95,
+	0xb9, 0x66, 0x78, 0xd7, 0xe6, 0x9e, 0x4c, 0x7b, 0x0a, 0x6c, 0x6e, 0xfd, 0x62, 0x72, 0xe4, 0x04,
+	0x9a, 0x0a, 0xa1, 0x8e, 0x75, 0x43, 0xd9, 0xc3, 0xa6, 0xbe, 0x7d, 0x79, 0x53, 0xfc, 0x08, 0xea,
+	0x2a, 0xe8, 0x48, 0x62, 0xe4, 0x39, 0x5c, 0x57, 0x38, 0x7e, 0x6c, 0xe7, 0x9b, 0xab, 0xbe, 0xce,
+	0xc8, 0xb6, 0xf1, 0x5b, 0xbb, 0xcd, 0x57, 0xd0, 0x75, 0xfe, 0x47, 0x09, 0xa6, 0x6d, 0x6a, 0x22,
+	0x00, 0xcf, 0xad, 0x4f, 0x25, 0xa3, 0x94, 0x7a, 0x9c, 0x01, 0xe7, 0xed, 0xe7, 0x72, 0x91, 0xfd,
+	0x6c, 0x5a, 0xc9, 0x95, 0x57, 0x59, 0xc9, 0xd5, 0xd7, 0xb3, 0x92, 0xc7, 0x8a, 0xac, 0x64, 0xe7,
+	0x5f, 0x95, 0x60, 0x62, 0x9b, 0x31, 0xf4, 0x38, 0xbf, 0xd2, 0xcd, 0xcc, 0xfb, 0x8e, 0x25, 0xb4,
+	0x7f, 0x63, 0x4f, 0xf4, 0xbd, 0xe2, 0xda, 0xc0, 0x91, 0xce, 0xe8, 0xca, 0x68, 0x67, 0x34, 0x37,
+	0x2b, 0x85, 0x94, 0x61, 0x42, 0x36, 0x4c, 0xba, 0x29, 0xc0, 0xf9, 0x6d, 0x19, 0x48, 0x9e, 0x03,
+	0xc9, 0xb6, 0x70, 0x32, 0x04, 0xac, 0x27, 0x45, 0xe0, 0x7b, 0xaf, 0xc5, 0xc4, 0x0a, 0xac, 0x3e,
+	0xc6, 0xcd, 0x64, 0x8a, 0x38, 0x53, 0x97, 0x9e, 0x72, 0x8b, 0x50, 0x18, 0x9a, 0x4a, 0xf7, 0x77,
+	0x2f, 0x95, 0x85, 0x63, 0x6e, 0x0e, 0x9e, 0x71, 0x43, 0x54, 0x5f, 0xed, 0x86, 0x18, 0x7b, 0xb5,
+	0x1b, 0x62, 0x3c, 0xe7, 0x86, 0xd8, 0x00, 0x0c, 0x38, 0xb6, 0x8f, 0x86, 0xfd, 0x81, 0x8c, 0x48,
+	0xbe, 0xfd, 0x0a, 0xbe, 0x17, 0x6b, 0xee, 0xea, 0xcf, 0x9c, 0xff, 0x56, 0x82, 0x29, 0x6b, 0x23,
+	0xfc, 0xde, 0x26, 0x38, 0xab, 0xf6, 0x0b, 0x96, 0xb7, 0x60, 0xd6, 0x00, 0x2a, 0xdf, 0x6c, 0x00,
+	0xbf, 0xa9, 0x00, 0xc9, 0x6f, 0xe7, 0x3f, 0xea, 0x28, 0x70, 0x6f, 0x5a, 0x12, 0xb9, 0x22, 0xf7,
+	0xa6, 0x09, 0xfc, 0x83, 0x9e, 0x31, 0xef, 0xc1, 0x5c, 0xc4, 0x3a, 0xe1, 0x19, 0xcf, 0x65, 0xb4,
+	0xfd, 0x68, 0x79, 0x04, 0x9a, 0x4e, 0xb6, 0x07, 0x68, 0xd2, 0xf2, 0xc3, 0x1b, 0x07, 0x6d, 0xd6,
+	0x11, 0x64, 0xae, 0x57, 0xed, 0x1b, 0xad, 0x17, 0x66, 0x1e, 0xca, 0xba, 0x07, 0xe7, 0x8c, 0x0d,
+	0xb4, 0x27, 0xe3, 0xdf, 0x55, 0xe0, 0x6a, 0x06, 0x21, 0x8f, 0xbd, 0x1f, 0xc0, 0xb4, 0xea, 0x45,
+	0xcc, 0x31, 0xd2, 0xaf, 0xf1, 0x2d, 0xbb, 0x69, 0xfb, 0x2b, 0x0b, 0xea, 0x66, 0x08, 0x38, 0xff,
+	0xa5, 0x0c, 0x0d, 0xb3, 0xc2, 0xab, 0x42, 0x1f, 0x46, 0xd0, 0xab, 0x9c, 0x0b, 0x7a, 0x51, 0x68,
+	0xc8, 0x4c, 0xb9, 0x36, 0x8f, 0x4a, 0x08, 0xe5, 0xc2, 0x82, 0xa1, 0x0e, 0x8c, 0x22, 0xa5, 0x2d,
+	0x74, 0x4a, 0xb9, 0xf0, 0x26, 0x08, 0xa9, 0x14, 0x98, 0x97, 0x16, 0x0c, 0xf5, 0xe4, 0xa3, 0x28,
+	0xf4, 0xba, 0x1d, 0x2f, 0x4e, 0xda, 0x5e, 0x92, 0xb0, 0xfe, 0x80, 0x47, 0x39, 0x91, 0x58, 0x01,
+	0x86, 0x7c, 0x17, 0xae, 0xf6, 0x10, 0x90, 0xa2, 0x24, 0xe3, 0x4d, 0xf0, 0x4f, 0x8a, 0x91, 0x38,
+	0x5e, 0x3e, 0x4d, 0x82, 0xe1, 0xa5, 0x37, 0x24, 0x85, 0xd0, 0x8f, 0x61, 0x41, 0x64, 0x8d, 0xde,
+	0x17, 0xfc, 0xa4, 0xec, 0x81, 0x9b, 0xe9, 0x3c, 0x84, 0x81, 0xcc, 0x12, 0x9d, 0x74, 0xeb, 0x12,
+	0xf6, 0x38, 0xe8, 0x5d, 0x60, 0x86, 0xed, 0xd5, 0xcc, 0xb7, 0x69, 0x92, 0x83, 0x50, 0x60, 0x6c,
+	0xad, 0xc6, 0x06, 0x22, 0x9f, 0xeb, 0x03, 0x42, 0xd7, 0x14, 0x2b, 0x92, 0x47, 0xe0, 0x3e, 0x1a,
+	0x06, 0xf9, 0xfa, 0x62, 0x77, 0x16, 0xa1, 0x50, 0x2b, 0x93, 0x1c, 0x6c, 0x8f, 0x8d, 0xae, 0xc3,
+	0x62, 0x16, 0x91, 0x86, 0x16, 0xec, 0x2e, 0xab, 0x22, 0xfd, 0xfb, 0x25, 0x20, 0x3f, 0x18, 0xb2,
+	0xe8, 0x82, 0x27, 0x47, 0xe8, 0xe0, 0xd5, 0x52, 0xd6, 0x89, 0x89, 0x21, 0x91, 0x4f, 0xd9, 0x85,
+	0x4a, 0x77, 0x29, 0xa7, 0xe9, 0x2e, 0x56, 0xca, 0x49, 0xe5, 0xeb, 0xa5, 0x9c, 0x54, 0x33, 0x29,
+	0x27, 0xf4, 0x13, 0x98, 0xb7, 0x7a, 0xa3, 0x27, 0x7e, 0x5c, 0x66, 0x74, 0x94, 0x0a, 0x32, 0x3a,
+	0x24, 0x8e, 0xfe, 0x93, 0x12, 0x54, 0x76, 0xc2, 0x81, 0xe9, 0xe2, 0x2f, 0xd9, 0x2e, 0x7e, 0xa9,
+	0xe2, 0xb4, 0xb5, 0x06, 0xa3, 0xd5, 0x04, 0x03, 0x88, 0x0a, 0x8a, 0xd7, 0x4f, 0xd0, 0x5f, 0x76,
+	0x1c, 0x46, 0xe7, 0x5e, 0xd4, 0x55, 0x16, 0xb9, 0x0d, 0xc5, 0xb9, 0x48, 0x0f, 0x4e, 0xfc, 0x89,
+	0x6a, 0x3d, 0x8f, 0x73, 0x5c, 0x48, 0x17, 0x9f, 0x2c, 0xd1, 0x5f, 0x96, 0x60, 0x8c, 0xf7, 0x15,
+	0x05, 0xac, 0xe0, 0x16, 0x9e, 0x80, 0xc5, 0xc3, 0x28, 0x22, 0x51, 0x39, 0x0b, 0xce, 0xa4, 0x65,
+	0x95, 0x73, 0x69, 0x59, 0xab, 0x50, 0x13, 0xa5, 0x34, 0xfd, 0x28, 0x05, 0x90, 0xeb, 0x98, 0x26,
+	0x32, 0x50, 0x9a, 0x26, 0x28, 0xbf, 0x79, 0x38, 0x70, 0x39, 0x9c, 0xfe, 0xd3, 0x12, 0x2c, 0x3c,
+	0xf2, 0xe3, 0xd8, 0x0f, 0x31, 0x3c, 0x9d, 0x44, 0x21, 0x8a, 0xc3, 0x61, 0x2f, 0xb9, 0x64, 0xf2,
+	0x08, 0x54, 0x51, 0x57, 0x94, 0xb6, 0x31, 0xff, 0x8d, 0x22, 0x09, 0x27, 0xa5, 0x9f, 0x46, 0xda,
+	0x75, 0xd9, 0x74, 0x97, 0x55, 0x2d, 0x77, 0x19, 0xef, 0xba, 0xdf, 0x67, 0x22, 0x21, 0x6d, 0x4c,
+	0x76, 0x5d, 0x01, 0xe8, 0x2a, 0x38, 0x9c, 0x07, 0xb2, 0xdd, 0x13, 0x4c, 0x7e, 0x08, 0x2b, 0x85,
+	0x58, 0xc9, 0x29, 0x1f, 0xc0, 0x44, 0xc4, 0x07, 0xa2, 0x58, 0x65, 0x45, 0x0e, 0xbd, 0x68, 0xb0,
+	0xae, 0xaa, 0x8b, 0x54, 0x77, 0xfb, 0x83, 0x30, 0x4a, 0x0a, 0x1b, 0xfd, 0xa6, 0x54, 0xaf, 0xc3,
+	0x6a, 0x31, 0x55, 0xe9, 0xb3, 0x58, 0x05, 0xc7, 0x65, 0x31, 0x2b, 0x6e, 0x94, 0x5e, 0x83, 0x95,
+	0x42, 0xac, 0xfc, 0xf8, 0x36, 0xcc, 0xec, 0x85, 0x5d, 0x66, 0xb8, 0xc7, 0x47, 0xee, 0x5a, 0xfa,
+	0xb7, 0x4a, 0x30, 0xa9, 0x2a, 0x93, 0x5b, 0x72, 0x1d, 0x6d, 0xe3, 0x5c, 0xc7, 0x2f, 0xb1, 0x9e,
+	0x5c, 0x5d, 0x4c, 0x00, 0x46, 0x07, 0x6d, 0x6a, 0x90, 0x29, 0xf7, 0xac, 0x86, 0x71, 0x07, 0x0b,
+	0xe7, 0xba, 0x8c, 0x55, 0x90, 0x81, 0xd2, 0x7f, 0x5f, 0x82, 0x29, 0xab, 0x0d, 0x3c, 0x6e, 0xb8,
+	0x6c, 0x17, 0xe6, 0xb3, 0xdc, 0x06, 0x26, 0xc8, 0x0c, 0xa5, 0x94, 0xed, 0x50, 0x8a, 0x76, 0xe5,
+	0x57, 0x4c, 0x57, 0xfe, 0x5d, 0xa8, 0xa5, 0x49, 0x8a, 0x55, 0x4b, 0x5f, 0xc0, 0x16, 0x55, 0x64,
+	0x36, 0xad, 0x84, 0x74, 0x3a, 0x61, 0x2f, 0x8c, 0x64, 0xea, 0x9d, 0x28, 0xd0, 0x4f, 0xa0, 0x6e,
+	0xd4, 0xc7, 0x6e, 0x04, 0x2c, 0x39, 0x0f, 0xa3, 0x67, 0x2a, 0xa2, 0x23, 0x8b, 0x3a, 0x6f, 0xa8,
+	0x9c, 0xe6, 0x0d, 0xd1, 0xdf, 0x94, 0x60, 0x0a, 0xf7, 0x3a, 0xba, 0xa9, 0xc2, 0x9e, 0xdf, 0xb9,
+	0xe0, 0x7b, 0x5e, 0x6d, 0x6b, 0x0c, 0x47, 0x25, 0x9e, 0xde, 0xf3, 0x36, 0x18, 0xb7, 0x13, 0x26,
+	0x90, 0xa0, 0x1e, 0x23, 0x77, 0xbc, 0x2e, 0xa3, 0xec, 0xe2, 0xfa, 0x89, 0x17, 0x33, 0x73, 0xbf,
+	0xd9, 0x40, 0x3c, 0x4e, 0x10, 0x10, 0x79, 0x09, 0x6b, 0xf7, 0xfd, 0x5e, 0xcf, 0x17, 0x75, 0x85,
+	0x8c, 0x2a, 0x42, 0xd1, 0xff, 0x5a, 0x86, 0xba, 0x3c, 0x36, 0x5a, 0xdd, 0x13, 0x99, 0xd1, 0xc3,
+	0x8b, 0xa9, 0x0c, 0x30, 0x20, 0x0a, 0x6f, 0xd9, 0x88, 0x06, 0x24, 0xbb, 0xac, 0x95, 0xfc, 0xb2,
+	0x62, 0x2c, 0x24, 0xec, 0xb2, 0xf7, 0xb9, 0x31, 0x2a, 0x13, 0x70, 0x34, 0x40, 0x61, 0xd7, 0x39,
+	0x76, 0x2c, 0xc5, 0x72, 0x80, 0x65, 0x7e, 0x8e, 0x67, 0xcc, 0xcf, 0x8f, 0x44, 0xbe, 0xfa, 0xfb,
+	0xed, 0x01, 0x9f, 0xf7, 0xe6, 0x84, 0xc5, 0xe0, 0xd6, 0x9a, 0xb8, 0x56, 0x4d, 0xf5, 0xe5, 0xba,
+	0xfa, 0x72, 0xf2, 0x55, 0x5f, 0xaa, 0x9a, 0x18, 0x57, 0x94, 0x93, 0xf7, 0x20, 0xf2, 0x06, 0xa7,
+	0x6a, 0xef, 0x76, 0xa1, 0x61, 0x82, 0xc9, 0x6d, 0x18, 0xc3, 0xcf, 0x94, 0xf8, 0x28, 0xde, 0x74,
+	0xa2, 0x0a, 0xb9, 0x05, 0x63, 0xac, 0x7b, 0xc2, 0x94, 0xff, 0x83, 0xd8, 0x3e, 0x2c, 0x5c, 0x23,
+	0x57, 0x54, 0x40, 0x11, 0x80, 0xd0, 0x8c, 0x08, 0xb0, 0xc5, 0xf7, 0x78, 0x47, 0x38, 0x4f, 0x17,
+	0x30, 0xcf, 0x83, 0x73, 0xad, 0x51, 0x9d, 0xfe, 0x9d, 0x0a, 0xd4, 0x0d, 0x30, 0xee, 0xe6, 0x13,
+	0xec, 0x70, 0xbb, 0xeb, 0x7b, 0x7d, 0x96, 0xb0, 0x48, 0x72, 0x6a, 0x06, 0x8a, 0xf5, 0xbc, 0xb3,
+	0x93, 0x76, 0x38, 0x4c, 0xda, 0x5d, 0x76, 0x12, 0x31, 0x71, 0x2a, 0x94, 0xdc, 0x0c, 0x14, 0xeb,
+	0x61, 0xd2, 0x93, 0x51, 0x4f, 0xf0, 0x43, 0x06, 0xaa, 0xc2, 0x63, 0x62, 0x8e, 0xaa, 0x69, 0x78,
+	0x4c, 0xcc, 0x48, 0x56, 0x0e, 0x8d, 0x15, 0xc8, 0xa1, 0x0f, 0x61, 0x51, 0x48, 0x1c, 0xb9, 0x37,
+	0xdb, 0x19, 0x36, 0x19, 0x81, 0x45, 0x83, 0x19, 0xfb, 0xac, 0x18, 0x3c, 0xf6, 0xbf, 0x12, 0x9e,
+	0xd3, 0x92, 0x9b, 0x83, 0x63, 0x5d, 0x9e, 0xdf, 0x65, 0xd6, 0x15, 0xd1, 0xfb, 0x1c, 0x9c, 0xd7,
+	0xf5, 0x9e, 0x5b, 0x30, 0x19, 0x2d, 0xcd, 0xc1, 0xe9, 0x14, 0xd4, 0x0f, 0x92, 0x70, 0xa0, 0x16,
+	0x65, 0x1a, 0x1a, 0xa2, 0x28, 0x25, 0xfd, 0x0a, 0x2c, 0x73, 0x2e, 0x3a, 0x0c, 0x07, 0x61, 0x2f,
+	0x3c, 0xb9, 0x38, 0x18, 0x1e, 0x89, 0x64, 0x35, 0xcc, 0x69, 0xfa, 0x9f, 0x25, 0x98, 0xb7, 0xb0,
+	0xd2, 0x15, 0xfb, 0x5d, 0x79, 0x79, 0x43, 0x05, 0xd9, 0x05, 0xe3, 0xcd, 0x19, 0xe2, 0x50, 0x54,
+	0x14, 0x4e, 0x6e, 0xf1, 0x1b, 0x6d, 0xa7, 0x19, 0xd5, 0x33, 0xf5, 0xa1, 0xe0, 0xc2, 0x66, 0x9e,
+	0x0b, 0xe5, 0xf7, 0xd3, 0xf2, 0x03, 0x45, 0xe2, 0xaf, 0x09, 0x63, 0x94, 0x75, 0xf9, 0x18, 0x95,
+	0x6b, 0x4d, 0xf9, 0x53, 0x2d, 0x03, 0x58, 0xf5, 0xa0, 0xa3, 0x81, 0x31, 0x2a, 0xa4, 0x90, 0xf6,
+	0x0e, 0x19, 0x23, 0x15, 0xe9, 0xe2, 0x06, 0x5d, 0x0a, 0x40, 0x6d, 0x5e, 0x07, 0x79, 0xd3, 0x53,
+	0xa2, 0xae, 0x60, 0xa8, 0xb0, 0xbe, 0x0b, 0x33, 0x27, 0xbd, 0xf0, 0x88, 0x6b, 0x4d, 0x3c, 0x39,
+	0x28, 0x96, 0x79, 0x2b, 0xd3, 0x02, 0xbc, 0x2d, 0xa1, 0xe9, 0x91, 0x52, 0x35, 0x8e, 0x14, 0xfa,
+	0x0f, 0xca, 0x30, 0x97, 0x1b, 0xf3, 0xc8, 0x5d, 0x46, 0xd6, 0x73, 0xc2, 0x71, 0x44, 0xb4, 0x8f,
+	0x7b, 0x9f, 0xf7, 0x5f, 0xe9, 0x51, 0xfb, 0x04, 0xa6, 0x23, 0x21, 0x7d, 0x94, 0x68, 0xaa, 0x5e,
+	0x22, 0x9a, 0xa6, 0x22, 0xb3, 0x88, 0x89, 0x7e, 0x5e, 0xf7, 0x8c, 0x45, 0x89, 0xcf, 0xdd, 0x00,
+	0xfc, 0xd0, 0x17, 0x02, 0x75, 0xc6, 0x80, 0xf3, 0xb3, 0xf8, 0x5d, 0x98, 0x91, 0xb9, 0x42, 0xba,
+	0xa6, 0x4c, 0x30, 0x4f, 0xc1, 0x58, 0x91, 0xfe, 0x1b, 0x15, 0xe9, 0xb4, 0xd7, 0x70, 0xf4, 0x8c,
+	0x98, 0xa3, 0x2b, 0x67, 0x46, 0xf7, 0xa6, 0x8c, 0x3a, 0x76, 0x95, 0xc9, 0x27, 0xe3, 0xbf, 0x02,
+	0x28, 0xa3, 0xc4, 0xf6, 0x94, 0x56, 0x5f, 0x67, 0x4a, 0xe9, 0x1d, 0xcc, 0x87, 0x4e, 0x36, 0x70,
+	0x05, 0x8d, 0xdc, 0xcc, 0x80, 0x9d, 0xb7, 0xc5, 0x12, 0x4b, 0xeb, 0x39, 0x60, 0xe7, 0xbc, 0x0e,
+	0x66, 0x2d, 0xa4, 0xf5, 0xe5, 0xae, 0xfb, 0xf5, 0x18, 0x4c, 0xec, 0x06, 0x67, 0xa1, 0xdf, 0xe1,
+	0x71, 0xc4, 0x3e, 0xeb, 0x87, 0xf2, 0x3b, 0xfe, 0x1b, 0xb5, 0x02, 0x9e, 0xd0, 0xa2, 0x6f, 0x9b,
+	0xa9, 0x22, 0x9e, 0x90, 0x51, 0x9a, 0xdf, 0x2e, 0xb8, 0xcd, 0x80, 0xa0, 0x95, 0x10, 0x99, 0x57,
+	0x03, 0x64, 0x29, 0x4d, 0x6e, 0x1e, 0x33, 0x92, 0x9b, 0xb1, 0x1d, 0x99, 0xab, 0xd3, 0x1c, 0x97,
+	0x6a, 0xb4, 0x28, 0x72, 0x6b, 0x26, 0x62, 0xc2, 0xfd, 0xc7, 0xcf, 0xda, 0x09, 0x69, 0xcd, 0x98,
+	0x40, 0x3c, 0x8f, 0xc5, 0x07, 0xa2, 0x8e, 0x90, 0x57, 0x26, 0x88, 0x47, 0x35, 0x33, 0xb7, 0x0b,
+	0x6a, 0x82, 0x4d, 0x32, 0x60, 0x14, 0x6a, 0x5d, 0xa6, 0x65, 0x8f, 0x18, 0x03, 0x88, 0xfc, 0xfd,
+	0x2c, 0xdc, 0xb0, 0x85, 0x44, 0xce, 0x91, 0x2c, 0x71, 0x3d, 0xc6, 0xeb, 0xf5, 0xf0, 0x52, 0x0d,
+	0x8f, 0x81, 0xf2, 0x14, 0xa3, 0x9a, 0x6b, 0x03, 0xb1, 0xd7, 0xdc, 0x4e, 0x94, 0x24, 0xa6, 0x44,
+	0x8a, 0x90, 0x01, 0xc2, 0x63, 0x52, 0x38, 0x86, 0xa6, 0xad, 0x63, 0x52, 0x2e, 0x19, 0x77, 0x0c,
+	0x89, 0x0a, 0xca, 0x48, 0x19, 0x78, 0x7e, 0xb7, 0x39, 0x93, 0x1a, 0x29, 0x58, 0x26, 0xef, 0xf3,
+	0xc0, 0x47, 0xc2, 0x78, 0xc6, 0xd0, 0xf4, 0xfa, 0x8a, 0x4d, 0x45, 0xfd, 0xc5, 0x20, 0x17, 0x73,
+	0x45, 0x4d, 0x29, 0x92, 0x64, 0xf4, 0x78, 0x8e, 0x77, 0x2c, 0x05, 0x88, 0x4b, 0xc3, 0x7c, 0x6e,
+	0x45, 0x05, 0xc2, 0x2b, 0x58, 0x30, 0xba, 0x07, 0x0d, 0x93, 0x30, 0x5e, 0x76, 0x7a, 0xbc, 0xdf,
+	0xda, 0x9b, 0xbd, 0x42, 0xea, 0x30, 0x71, 0xd0, 0x3a, 0x3c, 0x7c, 0xd8, 0xda, 0x9a, 0x2d, 0x91,
+	0x06, 0x4c, 0x6e, 0x6e, 0xec, 0x6d, 0xb6, 0xb0, 0x54, 0xc6, 0xd2, 0xc6, 0xe6, 0x66, 0x6b, 0xff,
+	0xb0, 0xb5, 0x35, 0x5b, 0xc1, 0x8a, 0xad, 0x1f, 0xee, 0xef, 0xba, 0xad, 0xad, 0xd9, 0x2a, 0xfd,
+	0xbb, 0x25, 0xa8, 0x1b, 0xe3, 0xbe, 0xc4, 0x86, 0xbb, 0x0e, 0x80, 0x73, 0x62, 0x84, 0xbe, 0xab,
+	0xae, 0x01, 0xc9, 0xd9, 0x73, 0x55, 0xc3, 0x9e, 0xbb, 0x01, 0x75, 0xaf, 0xd3, 0x61, 0x83, 0x44,
+	0xa4, 0xfe, 0x08, 0x95, 0xd2, 0x04, 0xd1, 0x04, 0xc8, 0x46, 0xb7, 0x2b, 0x7b, 0xa2, 0x4d, 0xb2,
+	0x94, 0xdd, 0x4b, 0x16, 0xbb, 0x17, 0xb0, 0x5d, 0xb9, 0x98, 0xed, 0xac, 0x19, 0x9f, 0xcd, 0xcc,
+	0x38, 0xfd, 0x93, 0x12, 0x5c, 0xdd, 0xe8, 0x76, 0x77, 0xc2, 0x5e, 0xda, 0xb4, 0x4e, 0xeb, 0xcf,
+	0x6d, 0x5b, 0xbc, 0x3f, 0x81, 0x7d, 0x91, 0x56, 0xac, 0xbd, 0xf1, 0x2a, 0xe6, 0xc6, 0x2b, 0x62,
+	0xf6, 0xea, 0x2b, 0x99, 0x7d, 0xec, 0x72, 0x66, 0x1f, 0x7f, 0x0d, 0x66, 0x9f, 0xc8, 0x31, 0x3b,
+	0xbd, 0xc3, 0x05, 0x54, 0xd2, 0x63, 0x72, 0x84, 0x8f, 0xe2, 0x13, 0x1e, 0x2c, 0x56, 0x42, 0x46,
+	0x5d, 0x9d, 0x96, 0x65, 0xcc, 0x74, 0xb6, 0xea, 0xe3, 0x62, 0xd0, 0x0f, 0x61, 0x56, 0x04, 0xcd,
+	0x0d, 0x22, 0x34, 0x73, 0x59, 0x49, 0x10, 0xb2, 0x60, 0x48, 0xcc, 0xfa, 0x8e, 0x13, 0x7b, 0x03,
+	0xae, 0x6d, 0xb1, 0x1e, 0x4b, 0x98, 0x40, 0x31, 0x35, 0xf7, 0x71, 0x7a, 0x6d, 0xf3, 0xfa, 0xa8,
+	0x0a, 0x92, 0x31, 0x64, 0x1a, 0x60, 0x97, 0xd7, 0x92, 0x57, 0xb8, 0x5c, 0x13, 0x44, 0x5b, 0x50,
+	0xdf, 0x37, 0xae, 0x4d, 0x71, 0xc1, 0xaa, 0x2e, 0x4c, 0xa9, 0x28, 0x50, 0x0a, 0x31, 0x38, 0xad,
+	0x6c, 0x72, 0x1a, 0x1e, 0x5a, 0x3c, 0xc7, 0x3b, 0xc3, 0x1e, 0x78, 0x51, 0x4b, 0x45, 0x0a, 0x0d,
+	0x5f, 0xa0, 0x84, 0xa1, 0x2f, 0x10, 0xa7, 0x87, 0x33, 0x59, 0x3b, 0x3c, 0x3e, 0x8e, 0x99, 0x4a,
+	0xc0, 0xb3, 0x60, 0xc8, 0x27, 0xd8, 0x67, 0xd4, 0xea, 0x7c, 0x39, 0x44, 0x99, 0x88, 0x97, 0x83,
+	0xe3, 0x9a, 0x45, 0xec, 0x8c, 0x45, 0xb1, 0x96, 0xe6, 0xba, 0x8c, 0x37, 0x53, 0xe6, 0xad, 0x5e,
+	0xea, 0x54, 0xf4, 0x49, 0x4d, 0x57, 0x68, 0x71, 0xd3, 0xb6, 0x94, 0x72, 0x35, 0x1e, 0x7d, 0x8f,
+	0xdc, 0xd2, 0xb2, 0x3a, 0x2d, 0xb6, 0x79, 0x1e, 0x81, 0xae, 0xda, 0x63, 0x3f, 0xca, 0x56, 0x17,
+	0xfb, 0xbe, 0x00, 0x43, 0x9f, 0xc2, 0xbc, 0x92, 0x5b, 0x86, 0x0a, 0x6a, 0x6f, 0xcf, 0xd2, 0xab,
+	0x04, 0x62, 0xb9, 0x40, 0x20, 0xfe, 0xa7, 0x31, 0x98, 0x90, 0x0b, 0x5d, 0xc8, 0x91, 0x35, 0x9b,
+	0x23, 0x8b, 0xaf, 0x01, 0xe5, 0xcf, 0xc3, 0x4a, 0xd1, 0x79, 0x88, 0xc9, 0x0c, 0x5e, 0x72, 0xca,
+	0xfd, 0x03, 0x35, 0x97, 0xff, 0x56, 0x9e, 0xbc, 0xb1, 0xd4, 0x93, 0xf7, 0x6d, 0x18, 0xe7, 0xf7,
+	0xc4, 0xc4, 0xfd, 0x9c, 0x54, 0xe3, 0x90, 0xbd, 0xc4, 0x0b, 0xc0, 0x5d, 0x57, 0x56, 0x21, 0xdf,
+	0x85, 0xf1, 0x98, 0xa7, 0x41, 0xf0, 0xad, 0x3b, 0xbd, 0xbe, 0x6a, 0x57, 0xd6, 0x1f, 0xf1, 0x3a,
+	0xae, 0xac, 0x4b, 0xb6, 0x60, 0x5a, 0xde, 0xa9, 0x6d, 0x47, 0xcc, 0x8b, 0xc3, 0xa0, 0x39, 0x59,
+	0xf8, 0xb5, 0xba, 0x81, 0xcb, 0xeb, 0xb8, 0x99, 0x6f, 0x78, 0xa2, 0x91, 0x84, 0xf4, 0x45, 0xb2,
+	0xbb, 0x3a, 0xbc, 0x33, 0xe0, 0xc2, 0xcb, 0x77, 0xc0, 0xab, 0xe6, 0xe0, 0x45, 0xb2, 0xb9, 0x5e,
+	0x28, 0x9b, 0xe9, 0x36, 0x4c, 0x59, 0xc3, 0xc3, 0x93, 0xe9, 0xc9, 0xde, 0xa7, 0x7b, 0x8f, 0x9f,
+	0xe2, 0x79, 0x36, 0x05, 0xb5, 0xdd, 0xbd, 0xf6, 0xf6, 0xc3, 0xdd, 0x07, 0x3b, 0x87, 0xb3, 0x25,
+	0x2c, 0x1e, 0x3c, 0xd9, 0xdc, 0x6c, 0xb5, 0xb6, 0xf8, 0x91, 0x06, 0x30, 0xbe, 0xbd, 0xb1, 0x8b,
+	0xc7, 0x5b, 0x85, 0xbb, 0x4d, 0xac, 0x91, 0xe2, 0xbd, 0x07, 0xc4, 0x3e, 0x71, 0x5b, 0x6d, 0xb7,
+	0xb5, 0x71, 0xf0, 0x78, 0xaf, 0x2d, 0x6f, 0x04, 0x37, 0x61, 0x21, 0x83, 0x68, 0xb9, 0xee, 0x63,
+	0x77, 0xb6, 0x44, 0x56, 0x60, 0x29, 0xf7, 0x49, 0xdb, 0x7d, 0xfc, 0xe4, 0xb0, 0x35, 0x5b, 0x26,
+	0xef, 0xc1, 0xad, 0x0c, 0x72, 0x77, 0x6f, 0xf3, 0xb1, 0xeb, 0xb6, 0x36, 0x0f, 0xdb, 0xfb, 0x1b,
+	0x9f, 0x3f, 0x6a, 0xed, 0x1d, 0xb6, 0xb7, 0x5a, 0x87, 0x1b, 0xbb, 0x0f, 0x0f, 0x66, 0x2b, 0xe4,
+	0x3a, 0x38, 0xb9, 0xda, 0x87, 0x2d, 0xd7, 0x7d, 0xc2, 0x0f, 0xe0, 0x2a, 0xfd, 0x3e, 0x34, 0x4c,
+	0x5e, 0x48, 0x59, 0xb2, 0x64, 0xb2, 0xa4, 0x64, 0xac, 0x72, 0xca, 0x58, 0x8a, 0xfd, 0x2a, 0x29,
+	0xfb, 0xd1, 0x96, 0xd8, 0xf8, 0x92, 0x9e, 0x56, 0x59, 0xef, 0x00, 0xf1, 0x83, 0x4e, 0x6f, 0xd8,
+	0xc5, 0x6d, 0xd2, 0x09, 0xfb, 0x03, 0x14, 0x8a, 0x52, 0x4a, 0x15, 0x60, 0xe8, 0x7d, 0x58, 0xb0,
+	0xc9, 0xa4, 0x02, 0x44, 0xae, 0x5a, 0x56, 0x80, 0xc8, 0xaa, 0xae, 0xc6, 0x53, 0x06, 0xf3, 0x87,
+	0x91, 0xd7, 0x79, 0xb6, 0x6f, 0xdf, 0x2b, 0x35, 0x78, 0x27, 0x23, 0x7f, 0x73, 0xf0, 0xdc, 0x06,
+	0x2e, 0x17, 0x1c, 0x29, 0x0e, 0x34, 0xc5, 0xe1, 0xb0, 0xd1, 0xeb, 0x65, 0x86, 0x8d, 0xe6, 0x6e,
+	0x01, 0x4e, 0x6a, 0xe5, 0xdb, 0x30, 0xb7, 0xc5, 0x8e, 0x86, 0x27, 0x0f, 0xd9, 0x59, 0x9a, 0xe4,
+	0x45, 0xa0, 0x1a, 0x9f, 0x86, 0xe7, 0x72, 0x6a, 0xf8, 0x6f, 0x0c, 0x34, 0xf4, 0xb0, 0x4e, 0x3b,
+	0x1e, 0xb0, 0x8e, 0xba, 0x21, 0xc2, 0x21, 0x07, 0x03, 0xd6, 0xa1, 0x1f, 0x02, 0x31, 0xe9, 0xa4,
+	0x27, 0x52, 0x3c, 0x3c, 0x6a, 0xc7, 0x17, 0x71, 0xc2, 0xfa, 0xca, 0x4c, 0x30, 0x41, 0xf4, 0x5d,
+	0xbe, 0xec, 0x2e, 0xfb, 0x52, 0xde, 0xcc, 0x45, 0x97, 0xab, 0x77, 0x81, 0xbb, 0x41, 0xbb, 0x5c,
+	0x39, 0x9a, 0xfe, 0xff, 0x32, 0x8c, 0x8b, 0x9a, 0x48, 0xb5, 0xcb, 0xe2, 0xc4, 0x0f, 0xd2, 0xfb,
+	0x52, 0x35, 0xd7, 0x04, 0x15, 0x4e, 0x59, 0x56, 0xe6, 0x49, 0x27, 0x88, 0xca, 0xa6, 0x97, 0xc2,
+	0xcd, 0x82, 0xd9, 0x8e, 0xf5, 0x6a, 0xc6, 0xb1, 0x3e, 0x52, 0x49, 0x11, 0xfd, 0x53, 0xe2, 0x5c,
+	0xaa, 0x28, 0x26, 0xa8, 0x50, 0x15, 0x9a, 0x10, 0xcb, 0x9f, 0x85, 0xe7, 0x55, 0x9e, 0xc9, 0xd7,
+	0x50, 0x79, 0x84, 0x67, 0xc4, 0x04, 0xe1, 0x61, 0xdf, 0x1f, 0xf6, 0x12, 0xbf, 0xcd, 0xb7, 0x8b,
+	0x48, 0x3d, 0x33, 0x20, 0x68, 0xb3, 0xf1, 0x8b, 0x88, 0xe8, 0x5f, 0x57, 0xac, 0xf3, 0xab, 0x12,
+	0xcc, 0x4a, 0x9b, 0x50, 0xe3, 0xc8, 0x4d, 0xcb, 0x80, 0x2c, 0x15, 0x25, 0xb5, 0xbc, 0x05, 0x53,
+	0xdc, 0x85, 0x8a, 0xfe, 0xd1, 0xbe, 0x91, 0x3e, 0x62, 0x01, 0xb1, 0xcf, 0x2a, 0xaf, 0xa1, 0xef,
+	0xf7, 0xe4, 0x02, 0x98, 0x20, 0x3c, 0xde, 0x95, 0x8b, 0x95, 0x4f, 0x7f, 0xc9, 0xd5, 0x65, 0xba,
+	0x0f, 0x73, 0x46, 0x7f, 0x25, 0xc3, 0x7d, 0x02, 0x2a, 0xed, 0x57, 0x84, 0x79, 0xc4, 0xf6, 0x5c,
+	0xb2, 0xcd, 0xdb, 0xf4, 0x33, 0xab, 0x32, 0xfd, 0x8f, 0x25, 0x3e, 0x05, 0xd2, 0x8b, 0xa2, 0xaf,
+	0x04, 0x8d, 0x0b, 0xc7, 0x86, 0xd8, 0x0d, 0x3b, 0x57, 0x5c, 0x59, 0x26, 0x1f, 0xbc, 0xa6, 0x6f,
+	0x42, 0xa7, 0xd7, 0x8e, 0x98, 0x9b, 0x4a, 0xd1, 0xdc, 0x5c, 0x32, 0x72, 0xbc, 0x70, 0x1f, 0x77,
+	0xc2, 0x01, 0xd7, 0x4a, 0x8d, 0xfe, 0xca, 0x1d, 0xfd, 0xaf, 0x4b, 0xd0, 0xdc, 0x16, 0x11, 0x35,
+	0x0c, 0xe8, 0xfb, 0x71, 0x12, 0x46, 0xe6, 0x93, 0x3e, 0x71, 0xe2, 0x45, 0xd2, 0xe4, 0x90, 0xce,
+	0xe8, 0x14, 0x82, 0xcd, 0xb2, 0xa0, 0x2b, 0xb0, 0x42, 0xb1, 0xd0, 0xe5, 0x9c, 0xee, 0x26, 0x9d,
+	0x0b, 0x26, 0x0c, 0xfd, 0x93, 0x4a, 0x47, 0x63, 0x67, 0x5c, 0x40, 0x0a, 0xe7, 0x63, 0x06, 0x4a,
+	0xff, 0x77, 0x09, 0x66, 0xd2, 0x4e, 0xb6, 0x10, 0x68, 0x6f, 0x36, 0xa9, 0xf6, 0x68, 0x80, 0x76,
+	0x93, 0xfb, 0xa8, 0x07, 0x29, 0x4b, 0x2b, 0x85, 0xf0, 0x0d, 0x20, 0x4b, 0xe1, 0x50, 0x29, 0x5d,
+	0x26, 0x48, 0xe4, 0x08, 0xa2, 0x06, 0x26, 0xb5, 0x4e, 0x59, 0xe2, 0x97, 0x45, 0xfa, 0x09, 0xff,
+	0x4a, 0xa8, 0x99, 0xaa, 0xa8, 0x4e, 0x9b, 0x71, 0x0e, 0xc5, 0x9f, 0x6a, 0x59, 0xf8, 0xba, 0x09,
+	0xb3, 0x42, 0x97, 0x31, 0x28, 0xb9, 0x5c, 0x30, 0xf1, 0x92, 0x33, 0xb7, 0x60, 0xee, 0x58, 0x23,
+	0xd5, 0xe4, 0x08, 0xf6, 0x5c, 0x54, 0xe1, 0x5d, 0x7b, 0x42, 0xdc, 0xfc, 0x07, 0x5a, 0x1f, 0x15,
+	0xd3, 0x6d, 0x65, 0x5c, 0xe7, 0x11, 0x74, 0x19, 0x96, 0x90, 0x11, 0xc5, 0xb3, 0x1c, 0xad, 0xe7,
+	0xe6, 0xce, 0xbe, 0x00, 0x92, 0xa2, 0x0e, 0x02, 0x6f, 0x10, 0x9f, 0x86, 0x18, 0x97, 0xab, 0xa7,
+	0x9c, 0xaa, 0xba, 0x57, 0xe8, 0x1c, 0x32, 0xeb, 0x61, 0xaf, 0x84, 0x20, 0xe1, 0x40, 0xf1, 0xbc,
+	0x87, 0x3c, 0xa6, 0xf2, 0x08, 0x3c, 0xab, 0xc4, 0x65, 0xc2, 0xb4, 0x03, 0x9a, 0x79, 0x77, 0xa0,
+	0xe9, 0x32, 0x9c, 0x38, 0x66, 0x22, 0xd5, 0x5b, 0x0c, 0x05, 0xad, 0x94, 0x46, 0xb5, 0xc2, 0xef,
+	0xbf, 0x72, 0x4a, 0x76, 0x13, 0xeb, 0xff, 0xa1, 0x0c, 0xd3, 0x22, 0x1d, 0x41, 0x3c, 0x86, 0xc5,
+	0x22, 0xf2, 0x08, 0x26, 0xe4, 0xa3, 0x63, 0xe4, 0xaa, 0x1c, 0xac, 0xfd, 0xe4, 0x99, 0xb3, 0x98,
+	0x05, 0xcb, 0xfe, 0xce, 0xff, 0xfc, 0xff, 0xfc, 0xe9, 0x3f, 0x2a, 0x4f, 0x91, 0xfa, 0xda, 0xd9,
+	0xfb, 0x6b, 0x27, 0x2c, 0x88, 0x91, 0x06, 0x06, 0x2b, 0x8c, 0x27, 0xbb, 0x88, 0xf6, 0xd5, 0xe6,
+	0x9f, 0x19, 0x73, 0x56, 0x0a, 0x71, 0xca, 0x51, 0xcd, 0xa9, 0x5f, 0xa5, 0xb3, 0x48, 0x9d, 0x6b,
+	0xdd, 0xec, 0x9c, 0xd7, 0xb8, 0x57, 0xba, 0x8d, 0xad, 0x98, 0xaf, 0x79, 0xe9, 0x56, 0x0a, 0x5e,
+	0x05, 0x73, 0x56, 0x0a, 0x71, 0x45, 0xad, 0x0c, 0x79, 0x0d, 0xdd, 0xca, 0xfa, 0x6f, 0xc7, 0xa1,
+	0xe1, 0xf2, 0x3c, 0x42, 0xbc, 0x7d, 0xca, 0x22, 0xf2, 0x7d, 0x98, 0xb2, 0x1e, 0xfd, 0x22, 0x8a,
+	0x76, 0xd1, 0x13, 0x62, 0xce, 0x6a, 0x31, 0x52, 0xee, 0x89, 0xef, 0xc3, 0x94, 0xf5, 0x8e, 0x96,
+	0xa6, 0x55, 0xf4, 0xb2, 0x97, 0xb3, 0x5a, 0x8c, 0x94, 0xb4, 0x3e, 0x07, 0x92, 0x7f, 0xe7, 0x8a,
+	0xdc, 0x50, 0xd3, 0x3b, 0xea, 0x0d, 0x2e, 0xe7, 0xe6, 0x25, 0x35, 0x52, 0xd2, 0xf9, 0x17, 0xaa,
+	0x34, 0xe9, 0x91, 0xaf, 0x60, 0x39, 0x37, 0x2f, 0xa9, 0x21, 0x49, 0x7f, 0x06, 0x73, 0xb9, 0xc7,
+	0xa8, 0xc8, 0x1b, 0xc6, 0x77, 0x45, 0x4f, 0x5b, 0x39, 0x37, 0x46, 0x57, 0x90, 0x74, 0xdb, 0xb0,
+	0x50, 0xf4, 0x8c, 0x14, 0xa1, 0xd6, 0x7a, 0x14, 0x3e, 0x63, 0xe5, 0xbc, 0x79, 0x69, 0x1d, 0xd9,
+	0xc0, 0x23, 0x98, 0xb6, 0x1f, 0x91, 0x22, 0x6a, 0x79, 0x0a, 0x1f, 0x9d, 0x72, 0xae, 0x8d, 0xc0,
+	0x4a, 0x72, 0xfb, 0x30, 0x93, 0x79, 0x09, 0x8a, 0x5c, 0xb3, 0xba, 0x91, 0x7d, 0x67, 0xca, 0xb9,
+	0x3e, 0x0a, 0x9d, 0x2e, 0x5a, 0xfe, 0x29, 0x26, 0xbd, 0x68, 0x23, 0xdf, 0x8e, 0x72, 0x6e, 0x5e,
+	0x52, 0x23, 0xdb, 0x59, 0xfd, 0xde, 0x51, 0xa6, 0xb3, 0xd9, 0x17, 0x92, 0x9c, 0xeb, 0xa3, 0xd0,
+	0x52, 0x26, 0xfd, 0xee, 0x3d, 0xa8, 0xe9, 0xd8, 0x25, 0xf9, 0x29, 0x4c, 0x59, 0xf9, 0x52, 0x7a,
+	0x5b, 0x14, 0x65, 0x60, 0x39, 0xab, 0xc5, 0x48, 0xb9, 0xb9, 0xaf, 0xf3, 0xcd, 0xdd, 0x24, 0x8b,
+	0xb8, 0xb9, 0x65, 0x92, 0xd2, 0x1a, 0xcf, 0x26, 0x14, 0x77, 0xd7, 0x9e, 0xc1, 0xb4, 0x9d, 0xe3,
+	0xa4, 0xd7, 0xb1, 0x30, 0x27, 0xca, 0xb9, 0x36, 0x02, 0xab, 0x32, 0x30, 0x78, 0x73, 0x8b, 0x64,
+	0xc1, 0x6c, 0x4e, 0xc7, 0x14, 0x19, 0xbf, 0x6d, 0x68, 0xbe, 0x68, 0xa4, 0x27, 0xae, 0xf8, 0xa5,
+	0x23, 0x67, 0x39, 0xff, 0x7a, 0x91, 0x7c, 0xee, 0x88, 0x36, 0x79, 0x53, 0x84, 0x70, 0xb1, 0x65,
+	0x3e, 0x68, 0x44, 0xbe, 0x80, 0x9a, 0x7e, 0xf0, 0x83, 0x2c, 0x19, 0x2f, 0xb4, 0x98, 0x6f, 0x94,
+	0x38, 0xcd, 0x3c, 0xa2, 0x48, 0x20, 0x9a, 0x94, 0x51, 0xec, 0x3e, 0x84, 0xab, 0xd2, 0x59, 0x73,
+	0xc4, 0xbe, 0xce, 0x48, 0x0a, 0xde, 0x61, 0xba, 0x5b, 0x22, 0x9f, 0xc0, 0xa4, 0x7a, 0x65, 0x85,
+	0x2c, 0x16, 0xbf, 0x25, 0xe3, 0x2c, 0xe5, 0xe0, 0x92, 0x0f, 0x7f, 0x0c, 0x13, 0xf2, 0xf9, 0x10,
+	0x7d, 0x6c, 0xd9, 0xef, 0x97, 0x38, 0x8b, 0x59, 0xb0, 0x1c, 0xe1, 0x9b, 0x7c, 0x84, 0xd7, 0x68,
+	0x33, 0x3b, 0xc2, 0x35, 0x4c, 0xd7, 0x3c, 0x66, 0x0c, 0x47, 0xfa, 0x14, 0xea, 0xc6, 0x1b, 0x1a,
+	0x64, 0x59, 0xc7, 0xd8, 0xb3, 0xef, 0x74, 0x38, 0x4e, 0x11, 0x4a, 0x36, 0x35, 0xc7, 0x9b, 0xaa,
+	0x93, 0x1a, 0x3f, 0x5d, 0xf0, 0x89, 0x0d, 0xf2, 0x13, 0xa8, 0x1b, 0xaf, 0x60, 0xa4, 0x84, 0x73,
+	0x0f, 0x5c, 0x38, 0x4e, 0x11, 0x4a, 0x12, 0x76, 0x38, 0xe1, 0x05, 0x3a, 0xa3, 0x09, 0xaf, 0xf1,
+	0xf7, 0x23, 0xb0, 0xeb, 0xa7, 0x30, 0x65, 0x3d, 0x7e, 0xa1, 0x77, 0x50, 0xd1, 0x33, 0x1a, 0xce,
+	0x6a, 0x31, 0xd2, 0x66, 0x69, 0x3a, 0x97, 0xb6, 0x13, 0x31, 0xdd, 0xd2, 0x17, 0x00, 0xe9, 0x6b,
+	0x17, 0xa4, 0x69, 0x4c, 0x84, 0xf5, 0x2a, 0x86, 0xb3, 0x5c, 0x80, 0x91, 0x0d, 0x58, 0x8c, 0x6c,
+	0x0c, 0x04, 0xa3, 0xc7, 0x90, 0xbe, 0x25, 0xa1, 0x89, 0xe7, 0x9e, 0xbc, 0x70, 0x96, 0x0b, 0x30,
+	0x92, 0x47, 0x4e, 0x60, 0x2e, 0xf7, 0x54, 0x85, 0x3e, 0x60, 0x46, 0x3d, 0x62, 0x71, 0x09, 0x41,
+	0xba, 0xc8, 0x7b, 0x3b, 0x4b, 0xa6, 0xb1, 0xb7, 0x01, 0x3b, 0x57, 0xd7, 0xee, 0xb6, 0xa0, 0x6e,
+	0x9c, 0x73, 0x7a, 0x51, 0xf3, 0x6f, 0x5b, 0x38, 0x4e, 0x11, 0x2a, 0xd5, 0x08, 0xac, 0x87, 0x26,
+	0xf4, 0xc2, 0x15, 0x3d, 0x63, 0xe1, 0xac, 0x16, 0x23, 0x25, 0xad, 0x1f, 0x41, 0xdd, 0x78, 0x16,
+	0x82, 0x18, 0x37, 0x9f, 0x32, 0xcf, 0x3e, 0x38, 0x4e, 0x11, 0x4a, 0x8e, 0x77, 0x81, 0x8f, 0x77,
+	0x9a, 0x72, 0xfe, 0xe5, 0xb7, 0x8b, 0x71, 0xd9, 0x7f, 0x0a, 0xd3, 0xf6, 0x73, 0x10, 0x5a, 0x6c,
+	0x16, 0x3e, 0x2c, 0xe1, 0x5c, 0x1b, 0x81, 0xb5, 0x25, 0xce, 0xed, 0x79, 0xdd, 0xc8, 0xda, 0x0b,
+	0x99, 0x9a, 0xf5, 0x92, 0xfc, 0x00, 0x6a, 0xfa, 0xba, 0x37, 0x59, 0x32, 0xf8, 0xc8, 0xbc, 0x14,
+	0xee, 0x34, 0xf3, 0x88, 0xa2, 0x1d, 0xc8, 0x89, 0x0b, 0x85, 0x97, 0x5f, 0xfb, 0x36, 0x14, 0x5e,
+	0xf3, 0x66, 0xb8, 0xb3, 0x98, 0x05, 0x17, 0x2b, 0xbc, 0x89, 0x8f, 0x34, 0x7a, 0x30, 0x63, 0xa7,
+	0x82, 0xc7, 0x7a, 0x3a, 0x0a, 0xef, 0x3b, 0x39, 0xd7, 0x46, 0x60, 0x8b, 0x4e, 0x11, 0x75, 0x7a,
+	0xac, 0xa9, 0x8b, 0x6d, 0xc7, 0x30, 0x65, 0xa6, 0x71, 0xc7, 0x9a, 0x47, 0x8a, 0x52, 0xcc, 0x9d,
+	0xd5, 0xcb, 0x12, 0xc6, 0x95, 0x10, 0x21, 0x04, 0x5b, 0x12, 0x79, 0xe2, 0xba, 0x9d, 0x1f, 0x43,
+	0xc3, 0x7c, 0xcb, 0x80, 0x98, 0x52, 0x2e, 0xf3, 0xee, 0x81, 0xb3, 0x52, 0x88, 0xb3, 0x59, 0x88,
+	0x34, 0xcc, 0xe1, 0x90, 0x1f, 0xc1, 0x8c, 0x71, 0xc5, 0xe5, 0xe0, 0x22, 0xe8, 0x68, 0x16, 0xcd,
+	0xdf, 0xbb, 0x74, 0x8a, 0x8c, 0x30, 0xba, 0xc4, 0x09, 0xcf, 0x51, 0x8b, 0x30, 0xb2, 0xe7, 0x26,
+	0xd4, 0x0d, 0x1a, 0x97, 0xd1, 0x5d, 0x32, 0x50, 0xe6, 0xd5, 0xc3, 0xbb, 0x25, 0xf2, 0xf3, 0x12,
+	0xcc, 0x17, 0x5c, 0x53, 0x25, 0x4a, 0x43, 0x1a, 0x7d, 0x53, 0xd6, 0xa1, 0x97, 0x55, 0x91, 0xb3,
+	0xf2, 0x16, 0xef, 0xfc, 0x75, 0xba, 0x8c, 0x9d, 0x97, 0xf7, 0x36, 0xd7, 0xf0, 0x1e, 0xe0, 0xda,
+	0xb1, 0xac, 0x8a, 0x23, 0xf9, 0x0a, 0xe6, 0x72, 0x77, 0x54, 0xb5, 0xfc, 0x1a, 0x75, 0x03, 0xd6,
+	0xb9, 0x31, 0xba, 0x82, 0x6c, 0x9d, 0xf2, 0xd6, 0x57, 0xe9, 0x52, 0xae, 0xf5, 0x0e, 0xaf, 0x88,
+	0x6d, 0xff, 0x63, 0x7c, 0xcb, 0xce, 0xb8, 0x85, 0x4e, 0xac, 0xa4, 0x9b, 0xcc, 0x44, 0x36, 0x4d,
+	0x9c, 0x39, 0x93, 0x74, 0x8f, 0x37, 0xb5, 0x73, 0x7b, 0xdb, 0xe2, 0xe6, 0x17, 0x96, 0xc7, 0xec,
+	0x8e, 0x79, 0xb9, 0xf7, 0x65, 0x16, 0x69, 0xde, 0xa5, 0x7e, 0x79, 0xb7, 0x44, 0xee, 0x89, 0x77,
+	0x16, 0x55, 0x84, 0x87, 0x18, 0x0a, 0x42, 0x96, 0x5f, 0xcc, 0x47, 0x05, 0x6f, 0x95, 0xee, 0x96,
+	0xc8, 0x4f, 0x60, 0xc6, 0xf8, 0x96, 0xb3, 0xdd, 0xeb, 0x7e, 0x6f, 0x2f, 0x99, 0x1e, 0x49, 0x56,
+	0x43, 0xda, 0x07, 0x48, 0xa3, 0xd6, 0x24, 0x13, 0x5b, 0xd3, 0x47, 0x4b, 0x3e, 0xb0, 0xad, 0xd8,
+	0xf9, 0x5e, 0xe9, 0xb6, 0xe0, 0x68, 0x1d, 0x85, 0x3b, 0x81, 0x69, 0x3b, 0x20, 0xad, 0xc5, 0x4b,
+	0x61, 0x9c, 0xfa, 0xb2, 0x36, 0xa4, 0x68, 0xc1, 0x36, 0xe6, 0xcc, 0x36, 0xd6, 0x4e, 0xc3, 0x6e,
+	0x8f, 0x1c, 0xc1, 0x94, 0x15, 0xe6, 0x35, 0xb4, 0x47, 0x3b, 0x58, 0xec, 0x34, 0x8b, 0x10, 0x3c,
+	0x90, 0x2b, 0x35, 0x6e, 0x6c, 0x61, 0xde, 0x6a, 0x41, 0x44, 0xe8, 0xb0, 0x0d, 0x2b, 0xfa, 0xab,
+	0xdb, 0xc8, 0xc6, 0x92, 0x9d, 0x66, 0x11, 0xc2, 0x6c, 0x23, 0xd3, 0x40, 0xca, 0xb9, 0x2f, 0x60,
+	0x51, 0xb8, 0xfc, 0xb3, 0xb1, 0x62, 0xf2, 0x96, 0xb6, 0x44, 0x2e, 0x89, 0x35, 0x3b, 0x6f, 0xbf,
+	0xa2, 0x96, 0x2d, 0xd8, 0x6e, 0xdb, 0xab, 0xf5, 0x53, 0x21, 0x37, 0x75, 0x93, 0xa6, 0xea, 0x93,
+	0x59, 0x28, 0xa7, 0x08, 0x65, 0xeb, 0xa8, 0x64, 0xc5, 0x1a, 0xe3, 0x0b, 0x33, 0xc2, 0xfc, 0x92,
+	0x7c, 0x06, 0x53, 0x0f, 0xc3, 0xf0, 0xd9, 0x70, 0xa0, 0x26, 0x93, 0xd8, 0x91, 0x18, 0x0c, 0x73,
+	0x3b, 0x19, 0x16, 0xa4, 0x37, 0x39, 0xe5, 0x15, 0xb2, 0x6c, 0x53, 0x4e, 0x03, 0xdf, 0x2f, 0x89,
+	0x07, 0x73, 0x5a, 0xcb, 0xd7, 0x03, 0x71, 0x6c, 0x3a, 0x66, 0xcc, 0x36, 0xd7, 0x86, 0x65, 0x77,
+	0xa5, 0x2c, 0xa0, 0x68, 0xde, 0x2d, 0x91, 0x7d, 0x68, 0x6c, 0xb1, 0x4e, 0xd8, 0x65, 0x32, 0xaa,
+	0x61, 0x44, 0x44, 0x75, 0x38, 0xc4, 0x99, 0xb2, 0x80, 0xf6, 0xc1, 0x38, 0xf0, 0x2e, 0x22, 0xf6,
+	0xe5, 0xda, 0x0b, 0x19, 0x2f, 0x79, 0xa9, 0x0e, 0x2c, 0x39, 0x74, 0xfb, 0xc0, 0xca, 0x04, 0x85,
+	0x9c, 0x95, 0x42, 0x5c, 0xd1, 0x81, 0xa5, 0x42, 0x59, 0xe4, 0x19, 0x34, 0xcc, 0x50, 0x96, 0x26,
+	0x5f, 0x10, 0xdf, 0x72, 0x32, 0x01, 0x31, 0xfa, 0x57, 0x38, 0xc5, 0x77, 0xc9, 0xdb, 0x26, 0x45,
+	0x94, 0x1c, 0x9d, 0x67, 0x6b, 0x2f, 0x64, 0x39, 0x9d, 0xfe, 0xbb, 0x25, 0xd2, 0x83, 0x39, 0xc1,
+	0x7c, 0x46, 0xd0, 0x4a, 0xcb, 0xfd, 0x51, 0xa1, 0x2e, 0xe7, 0xc6, 0xe8, 0x0a, 0x45, 0x2c, 0xab,
+	0x87, 0x76, 0x80, 0x4e, 0x2d, 0xb1, 0x32, 0x22, 0xbf, 0xd8, 0xb1, 0x8f, 0x5b, 0x33, 0x17, 0xd9,
+	0x99, 0x2f, 0xc0, 0xd9, 0x4a, 0x16, 0x4f, 0xee, 0x25, 0x5f, 0x40, 0xfd, 0x01, 0x4b, 0x54, 0x42,
+	0xb1, 0x36, 0xef, 0x32, 0x19, 0xc6, 0x4e, 0x41, 0x3e, 0x32, 0xbd, 0xc1, 0xa9, 0x39, 0xa4, 0xa9,
+	0xa9, 0xad, 0xb1, 0xee, 0x09, 0x13, 0xe7, 0x42, 0xdb, 0xef, 0xbe, 0x24, 0x3f, 0xe4, 0xc4, 0xf5,
+	0x1d, 0x84, 0x45, 0x23, 0x0f, 0xd5, 0x24, 0x3e, 0x93, 0x81, 0x17, 0x51, 0x0e, 0xc2, 0x2e, 0x33,
+	0xd4, 0xcd, 0x00, 0xea, 0xc6, 0x95, 0x21, 0xbd, 0x7b, 0xf3, 0x97, 0x9a, 0x1c, 0xa7, 0x08, 0x25,
+	0xe7, 0xf9, 0x16, 0x6f, 0x87, 0x92, 0x1b, 0x69, 0x3b, 0xe2, 0x56, 0x51, 0xda, 0xd2, 0xda, 0x0b,
+	0xaf, 0x9f, 0xbc, 0x24, 0x3f, 0x93, 0x57, 0x94, 0xec, 0x6b, 0x19, 0x5a, 0xcb, 0x18, 0x7d, 0x75,
+	0xc5, 0xa1, 0x97, 0x55, 0x91, 0xfd, 0x28, 0x18, 0x6f, 0x5f, 0xd4, 0xec, 0xc8, 0x86, 0x7e, 0x51,
+	0x82, 0x85, 0xa2, 0x5b, 0x25, 0xda, 0x57, 0x76, 0xc9, 0x45, 0x16, 0xe7, 0xcd, 0x4b, 0xeb, 0x14,
+	0x59, 0xdb, 0x45, 0x7d, 0x40, 0x91, 0xfd, 0x33, 0x98, 0x2f, 0xb8, 0x9d, 0xa2, 0xa7, 0x61, 0xf4,
+	0xbd, 0x16, 0x87, 0x5e, 0x56, 0xc5, 0x9e, 0x86, 0xdb, 0xa3, 0xa7, 0xe1, 0x29, 0x7f, 0x1c, 0xca,
+	0xcc, 0x5d, 0x4f, 0x8d, 0xc0, 0x6c, 0x9a, 0xbb, 0x43, 0xf2, 0x28, 0xdb, 0x30, 0x14, 0x4d, 0x70,
+	0xe3, 0xe0, 0x03, 0x00, 0xcc, 0xbe, 0xde, 0xf2, 0x58, 0x3f, 0x0c, 0x52, 0x5d, 0x23, 0xcd, 0xcf,
+	0x76, 0xe6, 0x2d, 0x98, 0xb4, 0xde, 0x9e, 0x1a, 0x7e, 0x16, 0x2b, 0xf5, 0x5f, 0xed, 0xf1, 0x91,
+	0x29, 0xdc, 0x8e, 0x53, 0x54, 0x43, 0xab, 0xb5, 0xdc, 0xe5, 0x22, 0x72, 0x53, 0x0d, 0x97, 0x8b,
+	0x95, 0xdc, 0xea, 0x2c, 0xe5, 0xe0, 0xb2, 0x57, 0x1b, 0x00, 0x69, 0x98, 0x5b, 0x5b, 0xe4, 0xb9,
+	0x08, 0xba, 0xb3, 0x5c, 0x80, 0xd1, 0xde, 0xc3, 0x5a, 0x1a, 0x4b, 0x5d, 0x4a, 0x6f, 0xf6, 0x59,
+	0x91, 0x57, 0xa7, 0x99, 0x47, 0xc8, 0xa5, 0x9c, 0xe5, 0xf3, 0x0c, 0x64, 0x92, 0x6b, 0xae, 0x8c,
+	0xc5, 0xe4, 0x10, 0x40, 0x8c, 0x6e, 0x1b, 0x4b, 0x06, 0x49, 0x2b, 0x92, 0xe9, 0x34, 0xf3, 0x08,
+	0xdb, 0xa8, 0xa3, 0x9a, 0x24, 0x32, 0x64, 0x1f, 0xe6, 0x72, 0xd1, 0xac, 0x54, 0xf3, 0x1e, 0x11,
+	0x60, 0x74, 0x6e, 0x8c, 0xae, 0x20, 0x1b, 0xbb, 0xca, 0x1b, 0x9b, 0x41, 0xfd, 0x08, 0x84, 0xd5,
+	0xe5, 0x27, 0x9d, 0x53, 0xf2, 0x25, 0x2c, 0x89, 0x08, 0xd5, 0x46, 0xaf, 0xa7, 0x9d, 0x8b, 0xe8,
+	0x27, 0x8d, 0xc9, 0x75, 0x43, 0x42, 0x16, 0xc4, 0xb2, 0x9c, 0xe5, 0x1c, 0x5e, 0x05, 0xb4, 0x94,
+	0x61, 0x4d, 0xe6, 0x2d, 0x8d, 0x55, 0x84, 0x88, 0xc8, 0x10, 0x66, 0xb3, 0x81, 0x28, 0x32, 0x9a,
+	0x96, 0xf3, 0x86, 0xe5, 0x6d, 0x28, 0x08, 0x5e, 0xbd, 0xcd, 0x1b, 0x7b, 0x03, 0x47, 0xe6, 0x14,
+	0xb4, 0xb7, 0x76, 0xc6, 0x3f, 0x24, 0x3f, 0xd3, 0x91, 0xa9, 0xcc, 0x38, 0xdf, 0x48, 0x37, 0x72,
+	0x61, 0x04, 0xcc, 0x59, 0xb5, 0x2b, 0x64, 0x9a, 0x7f, 0x87, 0x37, 0x7f, 0x83, 0xae, 0x14, 0xb5,
+	0x1d, 0x89, 0x4f, 0xee, 0x95, 0x6e, 0x1f, 0x8d, 0xf3, 0xff, 0xeb, 0xf3, 0x9d, 0xbf, 0x18, 0x00,
+	0xa0, 0xbf, 0xed, 0x4f, 0x09, 0x68, 0x00, 0x00,
 }
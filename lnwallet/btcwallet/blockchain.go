@@ -39,6 +39,9 @@ func (b *BtcWallet) GetBestBlock() (*chainhash.Hash, int32, error) {
 	case *chain.RPCClient:
 		return backend.GetBestBlock()
 
+	case *chain.BitcoindClient:
+		return backend.GetBestBlock()
+
 	default:
 		return nil, -1, fmt.Errorf("unknown backend")
 	}
@@ -87,6 +90,26 @@ func (b *BtcWallet) GetUtxo(op *wire.OutPoint, heightHint uint32) (*wire.TxOut,
 			PkScript: pkScript,
 		}, nil
 
+	case *chain.BitcoindClient:
+		txout, err := backend.GetTxOut(&op.Hash, op.Index, false)
+		if err != nil {
+			return nil, err
+		} else if txout == nil {
+			return nil, ErrOutputSpent
+		}
+
+		pkScript, err := hex.DecodeString(txout.ScriptPubKey.Hex)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wire.TxOut{
+			// Sadly, gettxout returns the output value in BTC
+			// instead of satoshis.
+			Value:    int64(txout.Value * 1e8),
+			PkScript: pkScript,
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("unknown backend")
 	}
@@ -114,6 +137,14 @@ func (b *BtcWallet) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error)
 
 		return block, nil
 
+	case *chain.BitcoindClient:
+		block, err := backend.GetBlock(blockHash)
+		if err != nil {
+			return nil, err
+		}
+
+		return block, nil
+
 	default:
 		return nil, fmt.Errorf("unknown backend")
 	}
@@ -144,6 +175,14 @@ func (b *BtcWallet) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
 
 		return blockHash, nil
 
+	case *chain.BitcoindClient:
+		blockHash, err := backend.GetBlockHash(blockHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		return blockHash, nil
+
 	default:
 		return nil, fmt.Errorf("unknown backend")
 	}
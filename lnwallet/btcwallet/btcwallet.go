@@ -2,6 +2,7 @@ package btcwallet
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -70,21 +71,36 @@ func New(cfg Config) (*BtcWallet, error) {
 		pubPass = cfg.PublicPass
 	}
 
-	loader := base.NewLoader(cfg.NetParams, netDir)
+	// If a recovery window is set, then the wallet will scan ahead of the
+	// last known address in each branch for any addresses that have been
+	// used on-chain. This allows a wallet restored from its seed to
+	// recover all of its funds.
+	loader := base.NewLoader(cfg.NetParams, netDir, cfg.RecoveryWindow)
 	walletExists, err := loader.WalletExists()
 	if err != nil {
 		return nil, err
 	}
 
 	var wallet *base.Wallet
-	if !walletExists {
-		// Wallet has never been created, perform initial set up.
+	switch {
+	// A watch-only wallet can't be created from a seed here, as that would
+	// leave its private keys on this node.
+	case !walletExists && cfg.WatchOnly:
+		return nil, fmt.Errorf("watch-only wallet not found in %v, "+
+			"it must be exported by the remote signer", netDir)
+
+	case !walletExists:
+		// Wallet has never been created, perform initial set up. The
+		// birthday ensures that the initial rescan of the wallet
+		// starts from the point in the chain where the seed was
+		// created.
 		wallet, err = loader.CreateNewWallet(pubPass, cfg.PrivatePass,
-			cfg.HdSeed)
+			cfg.HdSeed, cfg.Birthday)
 		if err != nil {
 			return nil, err
 		}
-	} else {
+
+	default:
 		// Wallet has been created and been initialized at this point,
 		// open it along with all the required DB namepsaces, and the
 		// DB itself.
@@ -109,14 +125,27 @@ func New(cfg Config) (*BtcWallet, error) {
 		return nil, err
 	}
 
-	return &BtcWallet{
+	btcWallet := &BtcWallet{
 		cfg:       &cfg,
 		wallet:    wallet,
 		db:        db,
 		chain:     cfg.ChainSource,
 		netParams: cfg.NetParams,
 		utxoCache: make(map[wire.OutPoint]*wire.TxOut),
-	}, nil
+	}
+
+	// If we just created the wallet, then we'll derive the root key
+	// address now, before the wallet has a chance to sync. Otherwise, a
+	// wallet being restored from its seed may have advanced its address
+	// index during recovery, which would result in a root key that
+	// differs from that of the original wallet.
+	if !walletExists {
+		if _, err := btcWallet.initRootAddr(); err != nil {
+			return nil, err
+		}
+	}
+
+	return btcWallet, nil
 }
 
 // Start initializes the underlying rpc connection, the wallet itself, and
@@ -137,6 +166,11 @@ func (b *BtcWallet) Start() error {
 	// current main chain.
 	b.wallet.SynchronizeRPC(b.chain)
 
+	// A watch-only wallet has no private keys to unlock.
+	if b.cfg.WatchOnly {
+		return nil
+	}
+
 	if err := b.wallet.Unlock(b.cfg.PrivatePass, nil); err != nil {
 		return err
 	}
@@ -144,6 +178,50 @@ func (b *BtcWallet) Start() error {
 	return nil
 }
 
+// CreateWatchOnly creates a watch-only wallet within dataDir from the passed
+// HD seed. The private keys of the wallet are discarded once its account keys
+// have been derived, such that the wallet can be handed to a node whose keys
+// are held by a remote signer. The public passphrase of the wallet is the
+// default one.
+func CreateWatchOnly(dataDir string, netParams *chaincfg.Params,
+	hdSeed []byte, birthday time.Time) error {
+
+	netDir := NetworkDir(dataDir, netParams)
+	loader := base.NewLoader(netParams, netDir, 0)
+	walletExists, err := loader.WalletExists()
+	if err != nil {
+		return err
+	}
+	if walletExists {
+		return fmt.Errorf("wallet already exists in %v", netDir)
+	}
+
+	// The private passphrase is never used again, as the private keys it
+	// encrypts are removed below.
+	var privPass [32]byte
+	if _, err := rand.Read(privPass[:]); err != nil {
+		return err
+	}
+
+	wallet, err := loader.CreateNewWallet(
+		defaultPubPassphrase, privPass[:], hdSeed, birthday,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = walletdb.Update(wallet.Database(), func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return wallet.Manager.ConvertToWatchingOnly(addrmgrNs)
+	})
+	if err != nil {
+		loader.UnloadWallet()
+		return err
+	}
+
+	return loader.UnloadWallet()
+}
+
 // Stop signals the wallet for shutdown. Shutdown may entail closing
 // any active sockets, database handles, stopping goroutines, etc.
 //
@@ -241,6 +319,32 @@ func (b *BtcWallet) NewRawKey() (*btcec.PublicKey, error) {
 	return b.wallet.PubKeyForAddress(addr)
 }
 
+// initRootAddr derives the next external address of the wallet, and stores
+// its hash160 within the database as the address of the root key. The hash of
+// the root address is returned.
+func (b *BtcWallet) initRootAddr() ([]byte, error) {
+	var rootAddrHash []byte
+	err := walletdb.Update(b.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		addrs, err := b.wallet.Manager.NextExternalAddresses(addrmgrNs,
+			defaultAccount, 1, waddrmgr.WitnessPubKey)
+		if err != nil {
+			return err
+		}
+		rootAddr := addrs[0].Address()
+
+		lnBucket := tx.ReadWriteBucket(lnNamespace)
+
+		rootAddrHash = rootAddr.ScriptAddress()
+		return lnBucket.Put(rootKey, rootAddrHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rootAddrHash, nil
+}
+
 // FetchRootKey returns a root key which is intended to be used as an initial
 // seed/salt to generate any Lightning specific secrets.
 //
@@ -263,20 +367,9 @@ func (b *BtcWallet) FetchRootKey() (*btcec.PrivateKey, error) {
 		// Otherwise, we need to generate a fresh address from the
 		// wallet, then stores it's hash160 within the database so we
 		// can look up the exact key later.
-		if err := walletdb.Update(b.db, func(tx walletdb.ReadWriteTx) error {
-			addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
-			addrs, err := b.wallet.Manager.NextExternalAddresses(addrmgrNs,
-				defaultAccount, 1, waddrmgr.WitnessPubKey)
-			if err != nil {
-				return err
-			}
-			rootAddr := addrs[0].Address()
-
-			lnBucket := tx.ReadWriteBucket(lnNamespace)
-
-			rootAddrHash = rootAddr.ScriptAddress()
-			return lnBucket.Put(rootKey, rootAddrHash)
-		}); err != nil {
+		var err error
+		rootAddrHash, err = b.initRootAddr()
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -371,9 +464,10 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 			}
 
 			utxo := &lnwallet.Utxo{
-				AddressType: addressType,
-				Value:       btcutil.Amount(output.Amount * 1e8),
-				PkScript:    pkScript,
+				AddressType:   addressType,
+				Value:         btcutil.Amount(output.Amount * 1e8),
+				PkScript:      pkScript,
+				Confirmations: output.Confirmations,
 				OutPoint: wire.OutPoint{
 					Hash:  *txid,
 					Index: output.Vout,
@@ -449,6 +543,7 @@ func minedTransactionsToDetails(
 			Timestamp:        block.Timestamp,
 			TotalFees:        int64(tx.Fee),
 			DestAddresses:    destAddresses,
+			RawTx:            wireTx,
 		}
 
 		balanceDelta, err := extractBalanceDelta(tx, wireTx)
@@ -479,6 +574,7 @@ func unminedTransactionsToDetail(
 		Hash:      *summary.Hash,
 		TotalFees: int64(summary.Fee),
 		Timestamp: summary.Timestamp,
+		RawTx:     wireTx,
 	}
 
 	balanceDelta, err := extractBalanceDelta(summary, wireTx)
@@ -684,6 +780,12 @@ func (b *BtcWallet) IsSynced() (bool, error) {
 		if err != nil {
 			return false, err
 		}
+
+	case *chain.BitcoindClient:
+		bestHash, bestHeight, err = backend.GetBestBlock()
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// If the wallet hasn't yet fully synced to the node's best chain tip,
@@ -711,6 +813,12 @@ func (b *BtcWallet) IsSynced() (bool, error) {
 		if err != nil {
 			return false, err
 		}
+
+	case *chain.BitcoindClient:
+		blockHeader, err = backend.GetBlockHeader(bestHash)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// If the timestamp no the best header is more than 2 hours in the
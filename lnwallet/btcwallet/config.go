@@ -2,6 +2,7 @@ package btcwallet
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/chaincfg"
@@ -63,6 +64,21 @@ type Config struct {
 	// unspecified, a new seed will be generated.
 	HdSeed []byte
 
+	// Birthday specifies the time at which this wallet was initially
+	// created. It is used to bound rescans for used addresses when a
+	// wallet is restored from its seed.
+	Birthday time.Time
+
+	// RecoveryWindow specifies the address look-ahead to use when
+	// recovering the funds of a wallet restored from its seed. A value of
+	// zero disables recovery.
+	RecoveryWindow uint32
+
+	// WatchOnly denotes that the wallet holds no private keys, as they're
+	// held by a remote signer instead. A watch-only wallet is never
+	// created by New, it must be exported by CreateWatchOnly beforehand.
+	WatchOnly bool
+
 	// ChainSource is the primary chain interface. This is used to operate
 	// the wallet and do things such as rescanning, sending transactions,
 	// notifications for received funds, etc.
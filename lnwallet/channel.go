@@ -67,6 +67,32 @@ var (
 	// our current known height.
 	ErrCommitSyncDataLoss = fmt.Errorf("possible commitment state data " +
 		"loss")
+
+	// ErrCommitSyncRemoteDataLoss is returned in the case that we receive
+	// a valid commit secret within the ChannelReestablish message from the
+	// remote node, yet they advertise a RemoteCommitTailHeight lower than
+	// what we know to be their current height. In this case, the remote
+	// party has likely lost state.
+	ErrCommitSyncRemoteDataLoss = fmt.Errorf("remote party has possibly " +
+		"lost commitment state data")
+
+	// ErrForceCloseLocalDataLoss is returned when attempting to force close
+	// a channel that we've detected has lost state. In this case, our
+	// latest commitment transaction has likely been revoked, so
+	// broadcasting it would forfeit our funds.
+	ErrForceCloseLocalDataLoss = fmt.Errorf("cannot force close channel " +
+		"with local data loss")
+
+	// ErrNotCommitmentTx is returned when the transaction spending the
+	// funding output of a channel isn't a commitment transaction, as is
+	// the case for a cooperative close.
+	ErrNotCommitmentTx = fmt.Errorf("spending transaction isn't a " +
+		"commitment transaction")
+
+	// ErrRevokedCommitment is returned when a commitment transaction
+	// broadcast by the remote party is of a revoked state.
+	ErrRevokedCommitment = fmt.Errorf("commitment transaction is of a " +
+		"revoked state")
 )
 
 // channelState is an enum like type which represents the current state of a
@@ -1185,8 +1211,9 @@ type LightningChannel struct {
 	UnilateralCloseSignal chan struct{}
 
 	// UnilateralClose is a channel that will be sent upon by the close
-	// observer once the unilateral close of a channel is detected.
-	UnilateralClose chan *UnilateralCloseSummary
+	// observer once the unilateral close of a channel by the remote party
+	// is detected.
+	UnilateralClose chan *chainntnfs.SpendDetail
 
 	// ContractBreach is a channel that is used to communicate the data
 	// necessary to fully resolve the channel in the case that a contract
@@ -1229,18 +1256,7 @@ func NewLightningChannel(signer Signer, events chainntnfs.ChainNotifier,
 		return nil, err
 	}
 
-	var stateHint [StateHintSize]byte
-	if state.IsInitiator {
-		stateHint = deriveStateHintObfuscator(
-			state.LocalChanCfg.PaymentBasePoint,
-			state.RemoteChanCfg.PaymentBasePoint,
-		)
-	} else {
-		stateHint = deriveStateHintObfuscator(
-			state.RemoteChanCfg.PaymentBasePoint,
-			state.LocalChanCfg.PaymentBasePoint,
-		)
-	}
+	stateHint := createStateHintObfuscator(state)
 
 	localCommit := state.LocalCommitment
 	remoteCommit := state.RemoteCommitment
@@ -1272,7 +1288,7 @@ func NewLightningChannel(signer Signer, events chainntnfs.ChainNotifier,
 		Capacity:              state.Capacity,
 		FundingWitnessScript:  multiSigScript,
 		ForceCloseSignal:      make(chan struct{}),
-		UnilateralClose:       make(chan *UnilateralCloseSummary, 1),
+		UnilateralClose:       make(chan *chainntnfs.SpendDetail, 1),
 		UnilateralCloseSignal: make(chan struct{}),
 		ContractBreach:        make(chan *BreachRetribution, 1),
 		LocalFundingKey:       state.LocalChanCfg.MultiSigKey,
@@ -1936,6 +1952,37 @@ func newBreachRetribution(chanState *channeldb.OpenChannel, stateNum uint64,
 	}, nil
 }
 
+// LastRevokedRetribution returns a fully populated BreachRetribution which
+// targets the most recently revoked commitment transaction of the remote
+// party. This allows callers to prepare a justice transaction for each state
+// as soon as it's revoked, rather than once a breach is detected on-chain.
+// An error is returned if the remote party has yet to revoke a state.
+func (lc *LightningChannel) LastRevokedRetribution() (*BreachRetribution, error) {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	// The tail of the remote commitment chain is their current commitment,
+	// so the most recently revoked state is the one directly below it.
+	tailHeight := lc.remoteCommitChain.tail().height
+	if tailHeight == 0 {
+		return nil, fmt.Errorf("no revoked states for "+
+			"ChannelPoint(%v)", lc.channelState.FundingOutpoint)
+	}
+	stateNum := tailHeight - 1
+
+	// The revocation log contains the exact commitment transaction of the
+	// revoked state, which we'll need in order to locate each of the
+	// outputs within it.
+	revokedCommit, err := lc.channelState.FindPreviousState(stateNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBreachRetribution(
+		lc.channelState, stateNum, revokedCommit.CommitTx,
+	)
+}
+
 // closeObserver is a goroutine which watches the network for any spends of the
 // multi-sig funding output. A spend from the multi-sig output may occur under
 // the following three scenarios: a cooperative close, a unilateral close, and
@@ -2027,107 +2074,14 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 		walletLog.Infof("Unilateral close of ChannelPoint(%v) "+
 			"detected", lc.channelState.FundingOutpoint)
 
-		// As we've detected that the channel has been closed,
-		// immediately delete the state from disk, creating a close
-		// summary for future usage by related sub-systems.
-		//
-		// TODO(roasbeef): include HTLC's
-		//  * and time-locked balance, NEED TO???
-		closeSummary := channeldb.ChannelCloseSummary{
-			ChanPoint:      lc.channelState.FundingOutpoint,
-			ChainHash:      lc.channelState.ChainHash,
-			ClosingTXID:    *commitSpend.SpenderTxHash,
-			RemotePub:      lc.channelState.IdentityPub,
-			Capacity:       lc.Capacity,
-			SettledBalance: lc.channelState.LocalCommitment.LocalBalance.ToSatoshis(),
-			CloseType:      channeldb.ForceClose,
-			IsPending:      true,
-		}
-		if err := lc.DeleteState(&closeSummary); err != nil {
-			walletLog.Errorf("unable to delete channel state: %v",
-				err)
-		}
-
-		// TODO(roasbeef): need to handle case of if >
-
-		// First, we'll generate the commitment point and the
-		// revocation point so we can re-construct the HTLC state and
-		// also our payment key.
-		commitPoint := lc.channelState.RemoteCurrentRevocation
-		keyRing := deriveCommitmentKeys(commitPoint, false,
-			lc.localChanCfg, lc.remoteChanCfg)
-
-		// Next, we'll obtain HTLC resolutions for all the outgoing
-		// HTLC's we had on their commitment transaction.
-		htlcResolutions, err := extractHtlcResolutions(
-			lc.channelState.LocalCommitment.FeePerKw, false,
-			lc.signer, lc.channelState.LocalCommitment.Htlcs,
-			keyRing, lc.localChanCfg, lc.remoteChanCfg,
-			*commitSpend.SpenderTxHash)
-		if err != nil {
-			walletLog.Errorf("unable to create htlc "+
-				"resolutions: %v", err)
-			return
-		}
-
-		// Before we can generate the proper sign descriptor, we'll
-		// need to locate the output index of our non-delayed output on
-		// the commitment transaction.
-		selfP2WKH, err := commitScriptUnencumbered(keyRing.noDelayKey)
-		if err != nil {
-			walletLog.Errorf("unable to create self commit "+
-				"script: %v", err)
-			return
-		}
-		var selfPoint *wire.OutPoint
-		for outputIndex, txOut := range commitTxBroadcast.TxOut {
-			if bytes.Equal(txOut.PkScript, selfP2WKH) {
-				selfPoint = &wire.OutPoint{
-					Hash:  *commitSpend.SpenderTxHash,
-					Index: uint32(outputIndex),
-				}
-				break
-			}
-		}
-
-		// With the HTLC's taken care of, we'll generate the sign
-		// descriptor necessary to sweep our commitment output, but
-		// only if we had a non-trimmed balance.
-		var selfSignDesc *SignDescriptor
-		if selfPoint != nil {
-			localPayBase := lc.localChanCfg.PaymentBasePoint
-			localBalance := lc.channelState.LocalCommitment.LocalBalance.ToSatoshis()
-			selfSignDesc = &SignDescriptor{
-				PubKey:        localPayBase,
-				SingleTweak:   keyRing.localCommitKeyTweak,
-				WitnessScript: selfP2WKH,
-				Output: &wire.TxOut{
-					Value:    int64(localBalance),
-					PkScript: selfP2WKH,
-				},
-				HashType: txscript.SigHashAll,
-			}
-		}
-
-		// TODO(roasbeef): send msg before writing to disk
-		//  * need to ensure proper fault tolerance in all cases
-		//  * get ACK from the consumer of the ntfn before writing to disk?
-		//  * no harm in repeated ntfns: at least once semantics
-
-		// Notify any subscribers that we've detected a unilateral
-		// commitment transaction broadcast.
+		// The channel arbitrator of this channel watches for the
+		// remote commitment itself, as it's responsible for sweeping
+		// our output, resolving any HTLC's left on the commitment, and
+		// deleting the state of the channel. We'll only notify any
+		// subscribers, so they can clean up the state they have for
+		// this channel.
 		close(lc.UnilateralCloseSignal)
-
-		// We'll also send all the details necessary to re-claim funds
-		// that are suspended within any contracts.
-		lc.UnilateralClose <- &UnilateralCloseSummary{
-			SpendDetail:         commitSpend,
-			ChannelCloseSummary: closeSummary,
-			SelfOutPoint:        selfPoint,
-			SelfOutputSignDesc:  selfSignDesc,
-			MaturityDelay:       uint32(lc.remoteChanCfg.CsvDelay),
-			HtlcResolutions:     htlcResolutions,
-		}
+		lc.UnilateralClose <- commitSpend
 
 	// If the state number broadcast is lower than the remote node's
 	// current un-revoked height, then THEY'RE ATTEMPTING TO VIOLATE THE
@@ -3182,10 +3136,32 @@ func (lc *LightningChannel) ProcessChanSyncMsg(msg *lnwire.ChannelReestablish) (
 		hasRecoveryOptions && commitSecretCorrect):
 
 		// In this case, we've likely lost data and shouldn't proceed
-		// with channel updates. So we'll return the appropriate error
-		// to signal to the caller the current state.
+		// with channel updates. Our latest commitment has likely been
+		// revoked, so we'll store the remote party's commitment point
+		// which will allow us to sweep our output once they close the
+		// channel, and mark the channel such that we'll never
+		// broadcast our own commitment.
+		err := lc.channelState.MarkDataLoss(
+			msg.LocalUnrevokedCommitPoint,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// We'll return the appropriate error to signal to the caller
+		// the current state.
 		return nil, ErrCommitSyncDataLoss
 
+	// If the remote party's view of our chain tail is more than one state
+	// behind ours, yet they've sent a valid commit secret for that height,
+	// then they've likely lost data. They'll be unable to continue, so
+	// we'll return the appropriate error to signal that the channel
+	// should be failed.
+	case (msg.RemoteCommitTailHeight+1 < localChainTail.height &&
+		hasRecoveryOptions && commitSecretCorrect):
+
+		return nil, ErrCommitSyncRemoteDataLoss
+
 	// If we don't owe them a revocation, and the height of our commitment
 	// chain reported by the remote party is not equal to our chain tail,
 	// then we cannot sync.
@@ -4244,10 +4220,191 @@ type UnilateralCloseSummary struct {
 	// transaction.
 	MaturityDelay uint32
 
-	// HtlcResolutions is a slice of HTLC resolutions which allows the
-	// local node to sweep any outgoing HTLC"s after the timeout period has
-	// passed.
-	HtlcResolutions []OutgoingHtlcResolution
+	// HtlcResolutions contains a fully populated HtlcResolutions struct
+	// which contains all the data required to sweep any outgoing HTLC's,
+	// and also any incoming HTLC's that we know the pre-image to.
+	HtlcResolutions *HtlcResolutions
+}
+
+// NewUnilateralCloseSummary creates the UnilateralCloseSummary of a
+// commitment transaction broadcast by the remote party, which spends the
+// funding output of the passed channel. If the spending transaction isn't a
+// commitment transaction, then ErrNotCommitmentTx is returned, and if it's a
+// commitment of a revoked state, then ErrRevokedCommitment is returned.
+//
+// If we've lost state, then the passed data loss commitment point should be
+// the one the remote party sent us once we detected it. In that case, we're
+// only able to locate our own output, so no HTLC resolutions are returned.
+func NewUnilateralCloseSummary(chanState *channeldb.OpenChannel, signer Signer,
+	commitSpend *chainntnfs.SpendDetail,
+	dataLossPoint *btcec.PublicKey) (*UnilateralCloseSummary, error) {
+
+	// A commitment transaction always has its lock time set above the
+	// time lock shift, in contrast to a cooperative close transaction.
+	commitTxBroadcast := commitSpend.SpendingTx
+	if commitTxBroadcast.LockTime&^0xFFFFFF != TimelockShift {
+		return nil, ErrNotCommitmentTx
+	}
+
+	// We'll handle the case of the remote party broadcasting their
+	// commitment transaction which is one height above ours, in addition
+	// to their current one. Any state below it has been revoked.
+	obfuscator := createStateHintObfuscator(chanState)
+	broadcastStateNum := GetStateNumHint(commitTxBroadcast, obfuscator)
+	remoteCommit := chanState.RemoteCommitment
+	if broadcastStateNum < remoteCommit.CommitHeight {
+		return nil, ErrRevokedCommitment
+	}
+
+	// TODO(roasbeef): include time-locked balance, NEED TO???
+	closeSummary := channeldb.ChannelCloseSummary{
+		ChanPoint:      chanState.FundingOutpoint,
+		ChainHash:      chanState.ChainHash,
+		ClosingTXID:    *commitSpend.SpenderTxHash,
+		RemotePub:      chanState.IdentityPub,
+		Capacity:       chanState.Capacity,
+		SettledBalance: remoteCommit.LocalBalance.ToSatoshis(),
+		CloseType:      channeldb.ForceClose,
+		IsPending:      true,
+	}
+	maturityDelay := uint32(chanState.RemoteChanCfg.CsvDelay)
+
+	// As we don't know the HTLC's on the commitment they broadcast if
+	// we've lost state, we can only attempt to sweep our output using the
+	// commitment point they sent us.
+	if dataLossPoint != nil {
+		selfPoint, selfSignDesc, err := NewRemoteCommitSelfOutput(
+			commitTxBroadcast, dataLossPoint,
+			chanState.LocalChanCfg.PaymentBasePoint,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnilateralCloseSummary{
+			SpendDetail:         commitSpend,
+			ChannelCloseSummary: closeSummary,
+			SelfOutPoint:        selfPoint,
+			SelfOutputSignDesc:  selfSignDesc,
+			MaturityDelay:       maturityDelay,
+			HtlcResolutions:     &HtlcResolutions{},
+		}, nil
+	}
+
+	// First, we'll generate the commitment point and the revocation point
+	// so we can re-construct the HTLC state and also our payment key.
+	localChanCfg := &chanState.LocalChanCfg
+	remoteChanCfg := &chanState.RemoteChanCfg
+	keyRing := deriveCommitmentKeys(
+		chanState.RemoteCurrentRevocation, false, localChanCfg,
+		remoteChanCfg,
+	)
+
+	// Next, we'll obtain HTLC resolutions for all the incoming and
+	// outgoing HTLC's we had on their commitment transaction.
+	htlcResolutions, err := extractHtlcResolutions(
+		remoteCommit.FeePerKw, false, signer, remoteCommit.Htlcs,
+		keyRing, localChanCfg, remoteChanCfg,
+		*commitSpend.SpenderTxHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Before we can generate the proper sign descriptor, we'll need to
+	// locate the output index of our non-delayed output on the commitment
+	// transaction.
+	selfP2WKH, err := commitScriptUnencumbered(keyRing.noDelayKey)
+	if err != nil {
+		return nil, err
+	}
+	var selfPoint *wire.OutPoint
+	for outputIndex, txOut := range commitTxBroadcast.TxOut {
+		if bytes.Equal(txOut.PkScript, selfP2WKH) {
+			selfPoint = &wire.OutPoint{
+				Hash:  *commitSpend.SpenderTxHash,
+				Index: uint32(outputIndex),
+			}
+			break
+		}
+	}
+
+	// With the HTLC's taken care of, we'll generate the sign descriptor
+	// necessary to sweep our commitment output, but only if we had a
+	// non-trimmed balance.
+	var selfSignDesc *SignDescriptor
+	if selfPoint != nil {
+		localBalance := remoteCommit.LocalBalance.ToSatoshis()
+		selfSignDesc = &SignDescriptor{
+			PubKey:        localChanCfg.PaymentBasePoint,
+			SingleTweak:   keyRing.localCommitKeyTweak,
+			WitnessScript: selfP2WKH,
+			Output: &wire.TxOut{
+				Value:    int64(localBalance),
+				PkScript: selfP2WKH,
+			},
+			HashType: txscript.SigHashAll,
+		}
+	}
+
+	return &UnilateralCloseSummary{
+		SpendDetail:         commitSpend,
+		ChannelCloseSummary: closeSummary,
+		SelfOutPoint:        selfPoint,
+		SelfOutputSignDesc:  selfSignDesc,
+		MaturityDelay:       maturityDelay,
+		HtlcResolutions:     htlcResolutions,
+	}, nil
+}
+
+// NewRemoteCommitSelfOutput locates our non-delayed output within a commitment
+// transaction broadcast by the remote party, given the commitment point that
+// was used to create it, along with our payment base point. If found, the
+// outpoint of the output is returned along with a sign descriptor capable of
+// sweeping it. If we had no output above dust within the commitment
+// transaction, then a nil outpoint and sign descriptor are returned.
+//
+// NOTE: This is used to sweep our funds from a channel for which we no longer
+// have the full state, e.g. a channel that's been restored from a backup.
+func NewRemoteCommitSelfOutput(commitTx *wire.MsgTx,
+	commitPoint, localPaymentBase *btcec.PublicKey) (*wire.OutPoint,
+	*SignDescriptor, error) {
+
+	// Our output on the remote party's commitment transaction pays to our
+	// payment base point, tweaked by their commitment point.
+	noDelayKey := TweakPubKey(localPaymentBase, commitPoint)
+	selfP2WKH, err := commitScriptUnencumbered(noDelayKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitTxHash := commitTx.TxHash()
+	for outputIndex, txOut := range commitTx.TxOut {
+		if !bytes.Equal(txOut.PkScript, selfP2WKH) {
+			continue
+		}
+
+		selfPoint := &wire.OutPoint{
+			Hash:  commitTxHash,
+			Index: uint32(outputIndex),
+		}
+		signDesc := &SignDescriptor{
+			PubKey: localPaymentBase,
+			SingleTweak: SingleTweakBytes(
+				commitPoint, localPaymentBase,
+			),
+			WitnessScript: selfP2WKH,
+			Output: &wire.TxOut{
+				Value:    txOut.Value,
+				PkScript: selfP2WKH,
+			},
+			HashType: txscript.SigHashAll,
+		}
+
+		return selfPoint, signDesc, nil
+	}
+
+	return nil, nil, nil
 }
 
 // OutgoingHtlcResolution houses the information necessary to sweep any outgoing
@@ -4260,24 +4417,106 @@ type OutgoingHtlcResolution struct {
 	// block height, meaning after this height the HLTC can be swept.
 	Expiry uint32
 
+	// PayHash is the payment hash of the HTLC. It's used to recognize the
+	// preimage if the remote party claims the HTLC on-chain.
+	PayHash [32]byte
+
+	// HtlcIndex is the index of the HTLC within the channel, it identifies
+	// the HTLC to the switch once it has been resolved.
+	HtlcIndex uint64
+
 	// SignedTimeoutTx is the fully signed HTLC timeout transaction. This
 	// must be broadcast immediately after timeout has passed. Once this
 	// has been confirmed, the HTLC output will transition into the
 	// delay+claim state.
+	//
+	// NOTE: If the HTLC is located on the remote party's commitment
+	// transaction, then this will be nil, as the HTLC output can be swept
+	// directly once it has expired.
 	SignedTimeoutTx *wire.MsgTx
 
+	// CsvDelay is the relative time lock (expressed in blocks) that must
+	// pass after the SignedTimeoutTx is confirmed in the chain before the
+	// output can be swept.
+	//
+	// NOTE: If SignedTimeoutTx is nil, then this field will be zero.
+	CsvDelay uint32
+
+	// ClaimOutpoint is the final outpoint that needs to be spent in order
+	// to fully sweep the HTLC. If SignedTimeoutTx is nil, then this is
+	// the HTLC output on the commitment transaction itself, otherwise it's
+	// the output of the timeout transaction.
+	ClaimOutpoint wire.OutPoint
+
 	// SweepSignDesc is a sign descriptor that has been populated with the
 	// necessary items required to spend the sole output of the above
-	// transaction.
+	// transaction, or the HTLC output on the remote commitment
+	// transaction if SignedTimeoutTx is nil.
 	SweepSignDesc SignDescriptor
 }
 
-// newHtlcResolution generates a new HTLC resolution capable of allowing the
-// caller to sweep an outgoing HTLC present on either their, or the remote
-// party's commitment transaction.
-func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
+// IncomingHtlcResolution houses the information required to sweep any
+// incoming HTLC's that we know the preimage to. We'll need to sweep an HTLC
+// that's incoming to us once we either force close the commitment, or the
+// remote party unilaterally closes with their version of the commitment
+// transaction.
+type IncomingHtlcResolution struct {
+	// PayHash is the payment hash of the HTLC. The preimage to this hash
+	// is required to sweep the HTLC.
+	PayHash [32]byte
+
+	// Expiry is the absolute timeout of the HTLC. After this height the
+	// remote party is able to reclaim the HTLC, so we must claim it before
+	// then.
+	Expiry uint32
+
+	// SignedSuccessTx is the fully signed HTLC success transaction, save
+	// for the preimage, which must be placed at index 3 of the witness of
+	// its sole input once known.
+	//
+	// NOTE: If the HTLC is located on the remote party's commitment
+	// transaction, then this will be nil, as the HTLC output can be swept
+	// directly with the preimage.
+	SignedSuccessTx *wire.MsgTx
+
+	// CsvDelay is the relative time lock (expressed in blocks) that must
+	// pass after the SignedSuccessTx is confirmed in the chain before the
+	// output can be swept.
+	//
+	// NOTE: If SignedSuccessTx is nil, then this field will be zero.
+	CsvDelay uint32
+
+	// ClaimOutpoint is the final outpoint that needs to be spent in order
+	// to fully sweep the HTLC. If SignedSuccessTx is nil, then this is the
+	// HTLC output on the commitment transaction itself, otherwise it's the
+	// output of the success transaction.
+	ClaimOutpoint wire.OutPoint
+
+	// SweepSignDesc is a sign descriptor that has been populated with the
+	// necessary items required to spend the ClaimOutpoint.
+	SweepSignDesc SignDescriptor
+}
+
+// HtlcResolutions contains the items necessary to sweep HTLC's on chain
+// directly from a commitment transaction. We'll use this in case either party
+// goes broadcasts a commitment transaction with live HTLC's.
+type HtlcResolutions struct {
+	// IncomingHTLCs contains a set of structs that can be used to sweep
+	// all the incoming HTL'C that we know the preimage to.
+	IncomingHTLCs []IncomingHtlcResolution
+
+	// OutgoingHTLCs contains a set of structs that contains all the info
+	// needed to sweep an outgoing HTLC we've sent to the remote party
+	// after an absolute delay has expired.
+	OutgoingHTLCs []OutgoingHtlcResolution
+}
+
+// newOutgoingHtlcResolution generates a new HTLC resolution capable of
+// allowing the caller to sweep an outgoing HTLC present on either their, or
+// the remote party's commitment transaction.
+func newOutgoingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 	commitHash chainhash.Hash, htlc *channeldb.HTLC, keyRing *commitmentKeyRing,
-	feePewKw, dustLimit btcutil.Amount, csvDelay uint32,
+	feePewKw btcutil.Amount, csvDelay uint32, localCommit bool,
 ) (*OutgoingHtlcResolution, error) {
 
 	op := wire.OutPoint{
@@ -4285,6 +4524,41 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 		Index: uint32(htlc.OutputIndex),
 	}
 
+	// If we're resolving an HTLC on the remote party's commitment
+	// transaction, then we'll be able to sweep the HTLC output directly
+	// once the timeout has passed, using the timeout clause of the
+	// receiver's version of the HTLC script.
+	if !localCommit {
+		htlcReceiverScript, err := receiverHTLCScript(htlc.RefundTimeout,
+			keyRing.localHtlcKey, keyRing.remoteHtlcKey,
+			keyRing.revocationKey, htlc.RHash[:],
+		)
+		if err != nil {
+			return nil, err
+		}
+		htlcScriptHash, err := witnessScriptHash(htlcReceiverScript)
+		if err != nil {
+			return nil, err
+		}
+
+		return &OutgoingHtlcResolution{
+			Expiry:        htlc.RefundTimeout,
+			PayHash:       htlc.RHash,
+			HtlcIndex:     htlc.HtlcIndex,
+			ClaimOutpoint: op,
+			SweepSignDesc: SignDescriptor{
+				PubKey:        localChanCfg.HtlcBasePoint,
+				SingleTweak:   keyRing.localHtlcKeyTweak,
+				WitnessScript: htlcReceiverScript,
+				Output: &wire.TxOut{
+					PkScript: htlcScriptHash,
+					Value:    int64(htlc.Amt.ToSatoshis()),
+				},
+				HashType: txscript.SigHashAll,
+			},
+		}, nil
+	}
+
 	// In order to properly reconstruct the HTLC transaction, we'll need to
 	// re-calculate the fee required at this state, so we can add the
 	// correct output value amount to the transaction.
@@ -4301,8 +4575,6 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 		return nil, err
 	}
 
-	// TODO(roasbeef): branch based on local vs remote commit
-
 	// With the transaction created, we can generate a sign descriptor
 	// that's capable of generating the signature required to spend the
 	// HTLC output using the timeout transaction.
@@ -4346,14 +4618,18 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 		return nil, err
 	}
 
-	// TODO(roasbeef): signing with the delay key is wrong for remote
-	// commitments
-	//  * would instead be signing with local htlc key
 	localDelayTweak := SingleTweakBytes(keyRing.commitPoint,
 		localChanCfg.DelayBasePoint)
 	return &OutgoingHtlcResolution{
 		Expiry:          htlc.RefundTimeout,
+		PayHash:         htlc.RHash,
+		HtlcIndex:       htlc.HtlcIndex,
 		SignedTimeoutTx: timeoutTx,
+		CsvDelay:        csvDelay,
+		ClaimOutpoint: wire.OutPoint{
+			Hash:  timeoutTx.TxHash(),
+			Index: 0,
+		},
 		SweepSignDesc: SignDescriptor{
 			PubKey:        localChanCfg.DelayBasePoint,
 			SingleTweak:   localDelayTweak,
@@ -4367,14 +4643,143 @@ func newHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
 	}, nil
 }
 
-// extractHtlcResolutions creates a series of outgoing HTLC resolutions, and
-// the local key used when generating the HTLC scrips. This function is to be
-// used in two cases: force close, or a unilateral close.
+// newIncomingHtlcResolution creates a new HTLC resolution capable of allowing
+// the caller to sweep an incoming HTLC present on either their, or the remote
+// party's commitment transaction, once the preimage is known.
+func newIncomingHtlcResolution(signer Signer, localChanCfg *channeldb.ChannelConfig,
+	commitHash chainhash.Hash, htlc *channeldb.HTLC, keyRing *commitmentKeyRing,
+	feePewKw btcutil.Amount, csvDelay uint32, localCommit bool,
+) (*IncomingHtlcResolution, error) {
+
+	op := wire.OutPoint{
+		Hash:  commitHash,
+		Index: uint32(htlc.OutputIndex),
+	}
+
+	// If this is the remote party's commitment, then the HTLC output uses
+	// the sender's version of the script, which we're able to spend
+	// directly with our HTLC key and the preimage.
+	if !localCommit {
+		htlcSenderScript, err := senderHTLCScript(keyRing.remoteHtlcKey,
+			keyRing.localHtlcKey, keyRing.revocationKey,
+			htlc.RHash[:],
+		)
+		if err != nil {
+			return nil, err
+		}
+		htlcScriptHash, err := witnessScriptHash(htlcSenderScript)
+		if err != nil {
+			return nil, err
+		}
+
+		return &IncomingHtlcResolution{
+			PayHash:       htlc.RHash,
+			Expiry:        htlc.RefundTimeout,
+			ClaimOutpoint: op,
+			SweepSignDesc: SignDescriptor{
+				PubKey:        localChanCfg.HtlcBasePoint,
+				SingleTweak:   keyRing.localHtlcKeyTweak,
+				WitnessScript: htlcSenderScript,
+				Output: &wire.TxOut{
+					PkScript: htlcScriptHash,
+					Value:    int64(htlc.Amt.ToSatoshis()),
+				},
+				HashType: txscript.SigHashAll,
+			},
+		}, nil
+	}
+
+	// Otherwise, we'll need to go through the second-level success
+	// transaction, so we'll re-construct it using the fee rate of the
+	// commitment, just as the remote party did when signing it for us.
+	htlcFee := htlcSuccessFee(feePewKw)
+	secondLevelOutputAmt := htlc.Amt.ToSatoshis() - htlcFee
+
+	successTx, err := createHtlcSuccessTx(
+		op, secondLevelOutputAmt, csvDelay,
+		keyRing.revocationKey, keyRing.delayKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the transaction created, we'll generate our half of the
+	// 2-of-2 multi-sig spend of the HTLC output. We leave a blank
+	// preimage within the witness, which will be filled in once known.
+	htlcCreationScript, err := receiverHTLCScript(htlc.RefundTimeout,
+		keyRing.remoteHtlcKey, keyRing.localHtlcKey,
+		keyRing.revocationKey, htlc.RHash[:],
+	)
+	if err != nil {
+		return nil, err
+	}
+	successSignDesc := SignDescriptor{
+		PubKey:        localChanCfg.HtlcBasePoint,
+		SingleTweak:   keyRing.localHtlcKeyTweak,
+		WitnessScript: htlcCreationScript,
+		Output: &wire.TxOut{
+			Value: int64(htlc.Amt.ToSatoshis()),
+		},
+		HashType:   txscript.SigHashAll,
+		SigHashes:  txscript.NewTxSigHashes(successTx),
+		InputIndex: 0,
+	}
+	successWitness, err := receiverHtlcSpendRedeem(
+		htlc.Signature, nil, signer, &successSignDesc, successTx,
+	)
+	if err != nil {
+		return nil, err
+	}
+	successTx.TxIn[0].Witness = successWitness
+
+	// Finally, we'll generate the sign descriptor required to sweep the
+	// output of the success transaction after the CSV delay.
+	htlcSweepScript, err := secondLevelHtlcScript(
+		keyRing.revocationKey, keyRing.delayKey, csvDelay,
+	)
+	if err != nil {
+		return nil, err
+	}
+	htlcScriptHash, err := witnessScriptHash(htlcSweepScript)
+	if err != nil {
+		return nil, err
+	}
+
+	localDelayTweak := SingleTweakBytes(keyRing.commitPoint,
+		localChanCfg.DelayBasePoint)
+	return &IncomingHtlcResolution{
+		PayHash:         htlc.RHash,
+		Expiry:          htlc.RefundTimeout,
+		SignedSuccessTx: successTx,
+		CsvDelay:        csvDelay,
+		ClaimOutpoint: wire.OutPoint{
+			Hash:  successTx.TxHash(),
+			Index: 0,
+		},
+		SweepSignDesc: SignDescriptor{
+			PubKey:        localChanCfg.DelayBasePoint,
+			SingleTweak:   localDelayTweak,
+			WitnessScript: htlcSweepScript,
+			Output: &wire.TxOut{
+				PkScript: htlcScriptHash,
+				Value:    int64(secondLevelOutputAmt),
+			},
+			HashType: txscript.SigHashAll,
+		},
+	}, nil
+}
+
+// extractHtlcResolutions creates a series of incoming and outgoing HTLC
+// resolutions for all the non-dust HTLC's present on the target commitment
+// transaction. This function is to be used in two cases: force close, or a
+// unilateral close.
 func extractHtlcResolutions(feePerKw btcutil.Amount, ourCommit bool,
 	signer Signer, htlcs []channeldb.HTLC, keyRing *commitmentKeyRing,
 	localChanCfg, remoteChanCfg *channeldb.ChannelConfig,
-	commitHash chainhash.Hash) ([]OutgoingHtlcResolution, error) {
+	commitHash chainhash.Hash) (*HtlcResolutions, error) {
 
+	// The dust limit and CSV delay of the second-level transactions both
+	// depend on whose commitment transaction we're resolving.
 	dustLimit := remoteChanCfg.DustLimit
 	csvDelay := remoteChanCfg.CsvDelay
 	if ourCommit {
@@ -4382,16 +4787,12 @@ func extractHtlcResolutions(feePerKw btcutil.Amount, ourCommit bool,
 		csvDelay = localChanCfg.CsvDelay
 	}
 
-	htlcResolutions := make([]OutgoingHtlcResolution, 0, len(htlcs))
-	for _, htlc := range htlcs {
-		// Skip any incoming HTLC's, as unless we have the pre-image to
-		// spend them, they'll eventually be swept by the party that
-		// offered the HTLC after the timeout.
-		if htlc.Incoming {
-			continue
-		}
+	incomingResolutions := make([]IncomingHtlcResolution, 0, len(htlcs))
+	outgoingResolutions := make([]OutgoingHtlcResolution, 0, len(htlcs))
+	for i := range htlcs {
+		htlc := htlcs[i]
 
-		// We'll also skip any HTLC's which were dust on the commitment
+		// We'll skip any HTLC's which were dust on the commitment
 		// transaction, as these don't have a corresponding output
 		// within the commitment transaction.
 		if htlcIsDust(htlc.Incoming, ourCommit, feePerKw,
@@ -4399,19 +4800,34 @@ func extractHtlcResolutions(feePerKw btcutil.Amount, ourCommit bool,
 			continue
 		}
 
-		ohr, err := newHtlcResolution(
+		if htlc.Incoming {
+			ihr, err := newIncomingHtlcResolution(
+				signer, localChanCfg, commitHash, &htlc,
+				keyRing, feePerKw, uint32(csvDelay), ourCommit,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			incomingResolutions = append(incomingResolutions, *ihr)
+			continue
+		}
+
+		ohr, err := newOutgoingHtlcResolution(
 			signer, localChanCfg, commitHash, &htlc, keyRing,
-			feePerKw, dustLimit, uint32(csvDelay),
+			feePerKw, uint32(csvDelay), ourCommit,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		// TODO(roasbeef): needs to point to proper amount including
-		htlcResolutions = append(htlcResolutions, *ohr)
+		outgoingResolutions = append(outgoingResolutions, *ohr)
 	}
 
-	return htlcResolutions, nil
+	return &HtlcResolutions{
+		IncomingHTLCs: incomingResolutions,
+		OutgoingHTLCs: outgoingResolutions,
+	}, nil
 }
 
 // ForceCloseSummary describes the final commitment state before the channel is
@@ -4444,10 +4860,25 @@ type ForceCloseSummary struct {
 	// output can be claimed.
 	SelfOutputMaturity uint32
 
-	// HtlcResolutions is a slice of HTLC resolutions which allows the
-	// local node to sweep any outgoing HTLC"s after the timeout period has
-	// passed.
-	HtlcResolutions []OutgoingHtlcResolution
+	// HtlcResolutions contains a fully populated HtlcResolutions struct
+	// which contains all the data required to sweep any outgoing HTLC's,
+	// and also any incoming HTLC's that we know the pre-image to.
+	HtlcResolutions *HtlcResolutions
+}
+
+// HasDataLoss returns true if we've detected that we've lost state for this
+// channel while re-establishing it with the remote party. If so, our latest
+// commitment transaction must never be broadcast.
+func (lc *LightningChannel) HasDataLoss() (bool, error) {
+	_, err := lc.channelState.DataLossCommitPoint()
+	switch {
+	case err == channeldb.ErrNoCommitPoint:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
 }
 
 // ForceClose executes a unilateral closure of the transaction at the current
@@ -4464,6 +4895,17 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 	lc.Lock()
 	defer lc.Unlock()
 
+	// If we've previously detected that we've lost state, then our
+	// latest commitment has likely been revoked, so we'll refuse to
+	// broadcast it.
+	dataLoss, err := lc.HasDataLoss()
+	if err != nil {
+		return nil, err
+	}
+	if dataLoss {
+		return nil, ErrForceCloseLocalDataLoss
+	}
+
 	// Set the channel state to indicate that the channel is now in a
 	// contested state.
 	lc.status = channelDispute
@@ -4541,7 +4983,7 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 
 	// Once the delay output has been found (if it exists), then we'll also
 	// need to create a series of sign descriptors for any lingering
-	// outgoing HTLC's that we'll need to claim as well.
+	// incoming and outgoing HTLC's that we'll need to claim as well.
 	txHash := commitTx.TxHash()
 	htlcResolutions, err := extractHtlcResolutions(
 		localCommitment.FeePerKw, true, lc.signer, localCommitment.Htlcs,
@@ -5091,6 +5533,17 @@ func (lc *LightningChannel) RemoteNextRevocation() *btcec.PublicKey {
 	return lc.channelState.RemoteNextRevocation
 }
 
+// NextLocalHtlcIndex returns the index the next HTLC we offer will be
+// assigned, not counting any adds that haven't yet been committed to. After
+// the channel has been restored from disk, this is the first HTLC index that
+// may be reused.
+func (lc *LightningChannel) NextLocalHtlcIndex() (uint64, error) {
+	lc.RLock()
+	defer lc.RUnlock()
+
+	return lc.channelState.NextLocalHtlcIndex()
+}
+
 // IsInitiator returns true if we were the ones that initiated the funding
 // workflow which led to the creation of this channel. Otherwise, it returns
 // false.
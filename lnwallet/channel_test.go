@@ -873,7 +873,7 @@ func TestForceClose(t *testing.T) {
 	// will still be present within the broadcast commitment transaction.
 	// We'll ensure that the HTLC amount is above Alice's dust limit.
 	htlcAmount := lnwire.NewMSatFromSatoshis(20000)
-	htlc, _ := createHTLC(0, htlcAmount)
+	htlc, preimage := createHTLC(0, htlcAmount)
 	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
 		t.Fatalf("alice unable to add htlc: %v", err)
 	}
@@ -891,11 +891,17 @@ func TestForceClose(t *testing.T) {
 		t.Fatalf("unable to force close channel: %v", err)
 	}
 
-	// Alice's force close summary should have a single HTLC resolution.
-	if len(closeSummary.HtlcResolutions) != 1 {
+	// Alice's force close summary should have a single outgoing HTLC
+	// resolution, and no incoming ones.
+	if len(closeSummary.HtlcResolutions.OutgoingHTLCs) != 1 {
 		t.Fatalf("alice htlc resolutions not populated: expected %v "+
 			"htlcs, got %v htlcs",
-			1, len(closeSummary.HtlcResolutions))
+			1, len(closeSummary.HtlcResolutions.OutgoingHTLCs))
+	}
+	if len(closeSummary.HtlcResolutions.IncomingHTLCs) != 0 {
+		t.Fatalf("alice incoming htlc resolutions populated: "+
+			"expected %v htlcs, got %v htlcs", 0,
+			len(closeSummary.HtlcResolutions.IncomingHTLCs))
 	}
 
 	// The SelfOutputSignDesc should be non-nil since the output to-self is
@@ -951,7 +957,7 @@ func TestForceClose(t *testing.T) {
 
 	// First, verify that the second level transaction can properly spend
 	// the multi-sig clause within the
-	htlcResolution := closeSummary.HtlcResolutions[0]
+	htlcResolution := closeSummary.HtlcResolutions.OutgoingHTLCs[0]
 	timeoutTx := htlcResolution.SignedTimeoutTx
 	vm, err := txscript.NewEngine(senderHtlcPkScript,
 		timeoutTx, 0, txscript.StandardVerifyFlags, nil,
@@ -1041,6 +1047,45 @@ func TestForceClose(t *testing.T) {
 	if !bytes.Equal(closeTxHash[:], commitTxHash[:]) {
 		t.Fatalf("bob: incorrect close transaction txid")
 	}
+
+	// Bob's summary should contain a single incoming HTLC resolution for
+	// the HTLC Alice sent him.
+	if len(closeSummary.HtlcResolutions.IncomingHTLCs) != 1 {
+		t.Fatalf("bob htlc resolutions not populated: expected %v "+
+			"htlcs, got %v htlcs", 1,
+			len(closeSummary.HtlcResolutions.IncomingHTLCs))
+	}
+	if len(closeSummary.HtlcResolutions.OutgoingHTLCs) != 0 {
+		t.Fatalf("bob outgoing htlc resolutions populated: "+
+			"expected %v htlcs, got %v htlcs", 0,
+			len(closeSummary.HtlcResolutions.OutgoingHTLCs))
+	}
+
+	var receiverHtlcPkScript []byte
+	for _, txOut := range closeSummary.CloseTx.TxOut {
+		if txOut.Value == int64(htlcAmount.ToSatoshis()) {
+			receiverHtlcPkScript = txOut.PkScript
+			break
+		}
+	}
+	if receiverHtlcPkScript == nil {
+		t.Fatalf("unable to find htlc script")
+	}
+
+	// Once Bob fills in the preimage, the second-level success
+	// transaction should be able to spend the HTLC output.
+	inHtlcResolution := closeSummary.HtlcResolutions.IncomingHTLCs[0]
+	successTx := inHtlcResolution.SignedSuccessTx
+	successTx.TxIn[0].Witness[3] = preimage[:]
+	vm, err = txscript.NewEngine(receiverHtlcPkScript,
+		successTx, 0, txscript.StandardVerifyFlags, nil,
+		nil, int64(htlcAmount.ToSatoshis()))
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("htlc success spend is invalid: %v", err)
+	}
 }
 
 // TestForceCloseDustOutput tests that if either side force closes with an
@@ -3755,6 +3800,94 @@ func TestChanSyncInvalidLastSecret(t *testing.T) {
 	}
 }
 
+// TestChanSyncDataLossProtect tests that if Alice restarts with a prior state
+// of the channel, then she detects that she's lost data, stores Bob's current
+// commitment point, and refuses to force close the channel. Bob should
+// detect that Alice has lost data as well.
+func TestChanSyncDataLossProtect(t *testing.T) {
+	t.Parallel()
+
+	// Create a test channel which will be used for the duration of this
+	// unittest. The channel will be funded evenly with Alice having 5 BTC,
+	// and Bob having 5 BTC.
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(1)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// We'll create a new instance of Alice before doing any state updates
+	// such that we have the initial in memory state at the start of the
+	// channel.
+	aliceOld, err := restartChannel(aliceChannel)
+	if err != nil {
+		t.Fatalf("unable to restart alice")
+	}
+
+	// Next, we'll add two HTLC's, with a state transition after each, so
+	// Alice's former self is more than a single state behind.
+	for i := 0; i < 2; i++ {
+		var paymentPreimage [32]byte
+		copy(paymentPreimage[:], bytes.Repeat([]byte{byte(i)}, 32))
+		htlc := &lnwire.UpdateAddHTLC{
+			PaymentHash: sha256.Sum256(paymentPreimage[:]),
+			Amount:      lnwire.NewMSatFromSatoshis(100000),
+			Expiry:      uint32(5),
+		}
+		if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+			t.Fatalf("unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+			t.Fatalf("unable to recv htlc: %v", err)
+		}
+		err := forceStateTransition(aliceChannel, bobChannel)
+		if err != nil {
+			t.Fatalf("unable to complete state transition: %v", err)
+		}
+	}
+
+	aliceOldChanSync, err := aliceOld.ChanSyncMsg()
+	if err != nil {
+		t.Fatalf("unable to generate chan sync msg: %v", err)
+	}
+	bobChanSync, err := bobChannel.ChanSyncMsg()
+	if err != nil {
+		t.Fatalf("unable to generate chan sync msg: %v", err)
+	}
+
+	// Alice's former self should conclude that she has lost data, as Bob
+	// has sent a valid secret for a state she doesn't know of.
+	_, err = aliceOld.ProcessChanSyncMsg(bobChanSync)
+	if err != ErrCommitSyncDataLoss {
+		t.Fatalf("wrong error, expected ErrCommitSyncDataLoss "+
+			"instead got: %v", err)
+	}
+
+	// She should have stored Bob's current commitment point, allowing her
+	// to sweep her output once Bob closes the channel.
+	commitPoint, err := aliceOld.channelState.DataLossCommitPoint()
+	if err != nil {
+		t.Fatalf("unable to fetch commit point: %v", err)
+	}
+	if !commitPoint.IsEqual(bobChanSync.LocalUnrevokedCommitPoint) {
+		t.Fatalf("wrong commit point stored")
+	}
+
+	// As her latest commitment has been revoked, she should refuse to
+	// broadcast it.
+	if _, err := aliceOld.ForceClose(); err != ErrForceCloseLocalDataLoss {
+		t.Fatalf("wrong error, expected ErrForceCloseLocalDataLoss "+
+			"instead got: %v", err)
+	}
+
+	// Bob, on the other hand, should detect that Alice has lost data.
+	_, err = bobChannel.ProcessChanSyncMsg(aliceOldChanSync)
+	if err != ErrCommitSyncRemoteDataLoss {
+		t.Fatalf("wrong error, expected ErrCommitSyncRemoteDataLoss "+
+			"instead got: %v", err)
+	}
+}
+
 // TestChanAvailableBandwidth tests the accuracy of the AvailableBalance()
 // method. The value returned from this message should reflect the value
 // returned within the commitment state of a channel after the transition is
@@ -0,0 +1,545 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/txsort"
+)
+
+const (
+	// DefaultLeaseDuration is the duration of an output lease, if the
+	// caller doesn't ask for a particular one.
+	DefaultLeaseDuration = 10 * time.Minute
+
+	// leaseExpiryInterval is the interval in which expired output leases
+	// are released.
+	leaseExpiryInterval = time.Minute
+)
+
+var (
+	// ErrOutputLocked is returned when leasing or explicitly spending an
+	// output that's reserved for the funding transaction of a pending
+	// channel.
+	ErrOutputLocked = errors.New("output is reserved by a pending channel")
+)
+
+// LeaseOutput leases the passed wallet output for the passed duration,
+// excluding it from automatic coin selection until the lease expires or is
+// released. A leased output can still be spent by selecting it explicitly.
+// Leasing an output that's already leased extends its lease. The lease is
+// persisted, so it survives restarts. The expiration of the lease is returned.
+func (l *LightningWallet) LeaseOutput(outpoint wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	if _, ok := l.lockedOutPoints[outpoint]; ok {
+		return time.Time{}, ErrOutputLocked
+	}
+
+	// Unless the output is leased already, it must be among the unlocked
+	// unspent outputs of the wallet.
+	if _, ok := l.leasedOutPoints[outpoint]; !ok {
+		utxos, err := l.ListUnspentWitness(0)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		var found bool
+		for _, utxo := range utxos {
+			if utxo.OutPoint == outpoint {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return time.Time{}, fmt.Errorf("output %v is not an "+
+				"unspent output of the wallet", outpoint)
+		}
+	}
+
+	lease := &channeldb.OutputLease{
+		OutPoint:   outpoint,
+		Expiration: time.Unix(time.Now().Add(duration).Unix(), 0),
+	}
+	if err := l.Cfg.Database.AddOutputLease(lease); err != nil {
+		return time.Time{}, err
+	}
+
+	l.leasedOutPoints[outpoint] = lease.Expiration
+	l.LockOutpoint(outpoint)
+
+	walletLog.Infof("Leased output %v until %v", outpoint, lease.Expiration)
+
+	return lease.Expiration, nil
+}
+
+// ReleaseOutput releases the lease of the passed output, making it eligible
+// for automatic coin selection again. channeldb.ErrOutputLeaseNotFound is
+// returned if the output isn't leased.
+func (l *LightningWallet) ReleaseOutput(outpoint wire.OutPoint) error {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	if _, ok := l.leasedOutPoints[outpoint]; !ok {
+		return channeldb.ErrOutputLeaseNotFound
+	}
+
+	if err := l.Cfg.Database.RemoveOutputLease(&outpoint); err != nil {
+		return err
+	}
+	l.releaseLease(outpoint)
+
+	walletLog.Infof("Released output %v", outpoint)
+
+	return nil
+}
+
+// ListLeases returns the leases of all currently leased outputs.
+func (l *LightningWallet) ListLeases() []*channeldb.OutputLease {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	leases := make([]*channeldb.OutputLease, 0, len(l.leasedOutPoints))
+	for outpoint, expiration := range l.leasedOutPoints {
+		leases = append(leases, &channeldb.OutputLease{
+			OutPoint:   outpoint,
+			Expiration: expiration,
+		})
+	}
+
+	return leases
+}
+
+// restoreLeases locks all outputs whose lease hasn't expired yet, and removes
+// the leases that expired while we were offline.
+func (l *LightningWallet) restoreLeases() error {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	leases, err := l.Cfg.Database.FetchOutputLeases()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, lease := range leases {
+		if !now.Before(lease.Expiration) {
+			err := l.Cfg.Database.RemoveOutputLease(&lease.OutPoint)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		l.leasedOutPoints[lease.OutPoint] = lease.Expiration
+		l.LockOutpoint(lease.OutPoint)
+	}
+
+	return nil
+}
+
+// leaseExpiryHandler periodically releases all expired output leases.
+//
+// NOTE: This MUST be run as a goroutine.
+func (l *LightningWallet) leaseExpiryHandler() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(leaseExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			l.releaseExpiredLeases(now)
+
+		case <-l.quit:
+			return
+		}
+	}
+}
+
+// releaseExpiredLeases releases all leases that expired before the passed
+// time.
+func (l *LightningWallet) releaseExpiredLeases(now time.Time) {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	for outpoint, expiration := range l.leasedOutPoints {
+		if now.Before(expiration) {
+			continue
+		}
+
+		err := l.Cfg.Database.RemoveOutputLease(&outpoint)
+		if err != nil && err != channeldb.ErrOutputLeaseNotFound {
+			walletLog.Errorf("Unable to remove expired lease of "+
+				"output %v: %v", outpoint, err)
+			continue
+		}
+		l.releaseLease(outpoint)
+
+		walletLog.Infof("Lease of output %v expired", outpoint)
+	}
+}
+
+// releaseLease forgets the lease of the passed output, unlocking it unless
+// it's reserved for a pending channel.
+//
+// NOTE: This MUST be called with the coinSelectMtx held.
+func (l *LightningWallet) releaseLease(outpoint wire.OutPoint) {
+	delete(l.leasedOutPoints, outpoint)
+
+	if _, ok := l.lockedOutPoints[outpoint]; !ok {
+		l.UnlockOutpoint(outpoint)
+	}
+}
+
+// removeLease deletes the lease of the passed output, if any, once it has been
+// spent.
+//
+// NOTE: This MUST be called with the coinSelectMtx held.
+func (l *LightningWallet) removeLease(outpoint wire.OutPoint) {
+	if _, ok := l.leasedOutPoints[outpoint]; !ok {
+		return
+	}
+
+	err := l.Cfg.Database.RemoveOutputLease(&outpoint)
+	if err != nil && err != channeldb.ErrOutputLeaseNotFound {
+		walletLog.Errorf("Unable to remove lease of spent output %v: "+
+			"%v", outpoint, err)
+	}
+	delete(l.leasedOutPoints, outpoint)
+}
+
+// fetchExplicitInputs returns the wallet outputs with the passed outpoints, to
+// be spent as the explicitly selected inputs of a transaction. Each of them
+// must either be an unlocked unspent output of the wallet, or be leased, and
+// have at least minConfs confirmations.
+//
+// NOTE: This MUST be called with the coinSelectMtx held.
+func (l *LightningWallet) fetchExplicitInputs(outpoints []wire.OutPoint,
+	minConfs int32) ([]*Utxo, error) {
+
+	utxos, err := l.ListUnspentWitness(minConfs)
+	if err != nil {
+		return nil, err
+	}
+	unspent := make(map[wire.OutPoint]*Utxo, len(utxos))
+	for _, utxo := range utxos {
+		unspent[utxo.OutPoint] = utxo
+	}
+
+	var (
+		coins     = make([]*Utxo, 0, len(outpoints))
+		selected  = make(map[wire.OutPoint]struct{}, len(outpoints))
+		txDetails map[chainhash.Hash]*TransactionDetail
+	)
+	for _, outpoint := range outpoints {
+		if _, ok := selected[outpoint]; ok {
+			return nil, fmt.Errorf("input %v selected more than "+
+				"once", outpoint)
+		}
+		selected[outpoint] = struct{}{}
+
+		if _, ok := l.lockedOutPoints[outpoint]; ok {
+			return nil, fmt.Errorf("unable to spend input %v: %v",
+				outpoint, ErrOutputLocked)
+		}
+
+		if utxo, ok := unspent[outpoint]; ok {
+			coins = append(coins, utxo)
+			continue
+		}
+
+		// Leased outputs are locked within the wallet, so they aren't
+		// among the unspent outputs, and we'll have to look them up
+		// individually.
+		if _, ok := l.leasedOutPoints[outpoint]; !ok {
+			return nil, fmt.Errorf("input %v is not an unspent "+
+				"output of the wallet with at least %d "+
+				"confirmations", outpoint, minConfs)
+		}
+
+		if txDetails == nil {
+			details, err := l.ListTransactionDetails()
+			if err != nil {
+				return nil, err
+			}
+
+			txDetails = make(map[chainhash.Hash]*TransactionDetail)
+			for _, detail := range details {
+				txDetails[detail.Hash] = detail
+			}
+		}
+
+		utxo, err := l.fetchLeasedUtxo(outpoint, txDetails)
+		if err != nil {
+			return nil, err
+		}
+		if utxo.Confirmations < int64(minConfs) {
+			return nil, fmt.Errorf("leased input %v has %d "+
+				"confirmations, need at least %d", outpoint,
+				utxo.Confirmations, minConfs)
+		}
+
+		coins = append(coins, utxo)
+	}
+
+	return coins, nil
+}
+
+// fetchLeasedUtxo reconstructs the unspent output description of a leased
+// wallet output.
+func (l *LightningWallet) fetchLeasedUtxo(outpoint wire.OutPoint,
+	txDetails map[chainhash.Hash]*TransactionDetail) (*Utxo, error) {
+
+	txOut, err := l.FetchInputInfo(&outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var addressType AddressType
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(txOut.PkScript):
+		addressType = WitnessPubKey
+	case txscript.IsPayToScriptHash(txOut.PkScript):
+		addressType = NestedWitnessPubKey
+	default:
+		return nil, fmt.Errorf("leased input %v is not a witness "+
+			"output", outpoint)
+	}
+
+	utxo := &Utxo{
+		AddressType: addressType,
+		Value:       btcutil.Amount(txOut.Value),
+		PkScript:    txOut.PkScript,
+		OutPoint:    outpoint,
+	}
+	if detail, ok := txDetails[outpoint.Hash]; ok {
+		utxo.Confirmations = int64(detail.NumConfirmations)
+	}
+
+	return utxo, nil
+}
+
+// explicitInputsChange returns the change that remains after funding amt
+// satoshis plus the required fee from explicitly selected inputs worth
+// totalSat satoshis. If the change would be dust, then it's added to the fee
+// instead, and zero is returned.
+func explicitInputsChange(totalSat, amt,
+	requiredFee btcutil.Amount) (btcutil.Amount, error) {
+
+	if totalSat < amt+requiredFee {
+		return 0, &ErrInsufficientFunds{amt + requiredFee, totalSat}
+	}
+
+	changeAmt := totalSat - amt - requiredFee
+	if changeAmt < DefaultDustLimit() {
+		return 0, nil
+	}
+
+	return changeAmt, nil
+}
+
+// SendOutputsFromInputs creates and broadcasts a transaction that spends
+// exactly the passed wallet outputs to the passed outputs, sending any
+// remaining change back to the wallet. Each input must have at least one
+// confirmation, and may be leased. If the inputs don't cover the outputs plus
+// the fee at the passed fee rate in sat/byte, then ErrInsufficientFunds is
+// returned.
+func (l *LightningWallet) SendOutputsFromInputs(inputs []wire.OutPoint,
+	outputs []*wire.TxOut, feeSatPerByte btcutil.Amount) (*chainhash.Hash,
+	error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.fetchExplicitInputs(inputs, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		weightEstimate TxWeightEstimator
+		outputAmt      btcutil.Amount
+	)
+	for _, txOut := range outputs {
+		weightEstimate.AddTxOutput(txOut)
+		outputAmt += btcutil.Amount(txOut.Value)
+	}
+
+	// Assume that change output is a P2WKH output.
+	weightEstimate.AddP2WKHOutput()
+	totalSat, err := addCoinsWeight(&weightEstimate, coins)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee rate is expressed in sat/byte, so we'll apply it to the
+	// virtual size of the transaction.
+	vsize := (weightEstimate.Weight() + blockchain.WitnessScaleFactor - 1) /
+		blockchain.WitnessScaleFactor
+	requiredFee := btcutil.Amount(vsize) * feeSatPerByte
+
+	changeAmt, err := explicitInputsChange(totalSat, outputAmt, requiredFee)
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := l.signAndPublish(coins, outputs, changeAmt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, outpoint := range inputs {
+		l.removeLease(outpoint)
+	}
+
+	return txid, nil
+}
+
+// SendOutputs funds, signs, and broadcasts a transaction paying to the passed
+// outputs at the passed fee rate in sat/byte. Unless the wallet is watch-only,
+// this is left to the WalletController. Otherwise the underlying wallet holds
+// no private keys, so the coins are selected here, and the inputs are signed
+// by the configured Signer.
+func (l *LightningWallet) SendOutputs(outputs []*wire.TxOut,
+	feeSatPerByte btcutil.Amount) (*chainhash.Hash, error) {
+
+	if !l.Cfg.WatchOnly {
+		return l.WalletController.SendOutputs(outputs, feeSatPerByte)
+	}
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.ListUnspentWitness(1)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputAmt btcutil.Amount
+	for _, txOut := range outputs {
+		outputAmt += btcutil.Amount(txOut.Value)
+	}
+
+	amtNeeded := outputAmt
+	for {
+		totalSat, selectedCoins, err := selectInputs(amtNeeded, coins)
+		if err != nil {
+			return nil, err
+		}
+
+		var weightEstimate TxWeightEstimator
+		for _, txOut := range outputs {
+			weightEstimate.AddTxOutput(txOut)
+		}
+
+		// Assume that change output is a P2WKH output.
+		weightEstimate.AddP2WKHOutput()
+		if _, err := addCoinsWeight(&weightEstimate, selectedCoins); err != nil {
+			return nil, err
+		}
+
+		vsize := (weightEstimate.Weight() +
+			blockchain.WitnessScaleFactor - 1) /
+			blockchain.WitnessScaleFactor
+		requiredFee := btcutil.Amount(vsize) * feeSatPerByte
+
+		// If the selected coins don't cover the fee, then we'll
+		// perform another round of coin selection, now including the
+		// fee in the amount needed.
+		if totalSat < outputAmt+requiredFee {
+			amtNeeded = outputAmt + requiredFee
+			continue
+		}
+
+		changeAmt, err := explicitInputsChange(
+			totalSat, outputAmt, requiredFee,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return l.signAndPublish(selectedCoins, outputs, changeAmt)
+	}
+}
+
+// signAndPublish creates a transaction that spends the passed wallet coins to
+// the passed outputs, plus a change output back to the wallet if changeAmt is
+// non-zero. Every input is signed by the configured Signer, after which the
+// transaction is broadcast.
+func (l *LightningWallet) signAndPublish(coins []*Utxo, outputs []*wire.TxOut,
+	changeAmt btcutil.Amount) (*chainhash.Hash, error) {
+
+	tx := wire.NewMsgTx(2)
+	coinsByOutpoint := make(map[wire.OutPoint]*Utxo, len(coins))
+	for _, coin := range coins {
+		tx.AddTxIn(wire.NewTxIn(&coin.OutPoint, nil, nil))
+		coinsByOutpoint[coin.OutPoint] = coin
+	}
+	for _, txOut := range outputs {
+		tx.AddTxOut(txOut)
+	}
+	if changeAmt != 0 {
+		changeAddr, err := l.NewAddress(WitnessPubKey, true)
+		if err != nil {
+			return nil, err
+		}
+		changeScript, err := txscript.PayToAddrScript(changeAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.AddTxOut(&wire.TxOut{
+			Value:    int64(changeAmt),
+			PkScript: changeScript,
+		})
+	}
+	txsort.InPlaceSort(tx)
+
+	signDesc := SignDescriptor{
+		HashType:  txscript.SigHashAll,
+		SigHashes: txscript.NewTxSigHashes(tx),
+	}
+	for i, txIn := range tx.TxIn {
+		coin := coinsByOutpoint[txIn.PreviousOutPoint]
+		signDesc.Output = &wire.TxOut{
+			Value:    int64(coin.Value),
+			PkScript: coin.PkScript,
+		}
+		signDesc.InputIndex = i
+
+		inputScript, err := l.Cfg.Signer.ComputeInputScript(tx, &signDesc)
+		if err != nil {
+			return nil, err
+		}
+		if inputScript == nil {
+			return nil, fmt.Errorf("unable to sign input %v",
+				txIn.PreviousOutPoint)
+		}
+
+		txIn.SignatureScript = inputScript.ScriptSig
+		txIn.Witness = inputScript.Witness
+	}
+
+	if err := blockchain.CheckTransactionSanity(btcutil.NewTx(tx)); err != nil {
+		return nil, err
+	}
+
+	if err := l.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxHash()
+	return &txid, nil
+}
@@ -32,6 +32,18 @@ type Config struct {
 	// update the commitment state.
 	Signer Signer
 
+	// KeyRing, if set, derives the keys and revocation roots of new
+	// channels in place of the HD chain of the WalletController. When the
+	// private keys of the KeyRing are held elsewhere, the Signer MUST
+	// forward the signing of channel outputs to the same place.
+	KeyRing KeyRing
+
+	// WatchOnly denotes that the WalletController holds no private keys.
+	// In this case the KeyRing and Signer MUST be set, as all keys and
+	// signatures are sourced from them, including those of the wallet's
+	// own outputs.
+	WatchOnly bool
+
 	// FeeEstimator is the implementation that the wallet will use for the
 	// calculation of on-chain transaction fees.
 	FeeEstimator FeeEstimator
@@ -1,6 +1,10 @@
 package lnwallet
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/rpcclient"
 	"github.com/roasbeef/btcutil"
@@ -200,3 +204,154 @@ func (b *BtcdFeeEstimator) fetchEstimatePerByte(confTarget uint32) (btcutil.Amou
 // A compile-time assertion to ensure that BtcdFeeEstimator implements the
 // FeeEstimator interface.
 var _ FeeEstimator = (*BtcdFeeEstimator)(nil)
+
+// BitcoindFeeEstimator is an implementation of the FeeEstimator interface
+// backed by the RPC interface of an active bitcoind node. This implementation
+// will proxy any fee estimation requests to bitcoind's RPC interface.
+type BitcoindFeeEstimator struct {
+	// fallBackFeeRate is the fall back fee rate in satoshis per byte that
+	// is returned if the fee estimator does not yet have enough data to
+	// actually produce fee estimates.
+	fallBackFeeRate btcutil.Amount
+
+	bitcoindConn *rpcclient.Client
+}
+
+// NewBitcoindFeeEstimator creates a new BitcoindFeeEstimator given a fully
+// populated rpc config that is able to successfully connect and authenticate
+// with the bitcoind node, and also a fall back fee rate. The fallback fee rate
+// is used in the occasion that the estimator has insufficient data, or returns
+// zero for a fee estimate.
+func NewBitcoindFeeEstimator(rpcConfig rpcclient.ConnConfig,
+	fallBackFeeRate btcutil.Amount) (*BitcoindFeeEstimator, error) {
+
+	// As bitcoind doesn't support websockets, all requests are made using
+	// HTTP POST, which requires no persistent connection.
+	rpcConfig.DisableConnectOnNew = true
+	rpcConfig.DisableAutoReconnect = false
+	rpcConfig.DisableTLS = true
+	rpcConfig.HTTPPostMode = true
+	chainConn, err := rpcclient.New(&rpcConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitcoindFeeEstimator{
+		fallBackFeeRate: fallBackFeeRate,
+		bitcoindConn:    chainConn,
+	}, nil
+}
+
+// Start signals the FeeEstimator to start any processes or goroutines
+// it needs to perform its duty.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (b *BitcoindFeeEstimator) Start() error {
+	return nil
+}
+
+// Stop stops any spawned goroutines and cleans up the resources used
+// by the fee estimator.
+//
+// NOTE: This method is part of the FeeEstimator interface.
+func (b *BitcoindFeeEstimator) Stop() error {
+	b.bitcoindConn.Shutdown()
+
+	return nil
+}
+
+// EstimateFeePerByte takes in a target for the number of blocks until an
+// initial confirmation and returns the estimated fee expressed in
+// satoshis/byte.
+func (b *BitcoindFeeEstimator) EstimateFeePerByte(numBlocks uint32) (btcutil.Amount, error) {
+	feeEstimate, err := b.fetchEstimatePerByte(numBlocks)
+	switch {
+	// If the estimator doesn't have enough data, or returns an error, then
+	// to return a proper value, then we'll return the default fall back
+	// fee rate.
+	case err != nil:
+		walletLog.Errorf("unable to query estimator: %v", err)
+		fallthrough
+
+	case feeEstimate == 0:
+		return b.fallBackFeeRate, nil
+	}
+
+	return feeEstimate, nil
+}
+
+// EstimateFeePerWeight takes in a target for the number of blocks until an
+// initial confirmation and returns the estimated fee expressed in
+// satoshis/weight.
+func (b *BitcoindFeeEstimator) EstimateFeePerWeight(numBlocks uint32) (btcutil.Amount, error) {
+	feePerByte, err := b.EstimateFeePerByte(numBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	// We'll scale down the fee per byte to fee per weight, as for each raw
+	// byte, there's 1/4 unit of weight mapped to it.
+	satWeight := feePerByte / blockchain.WitnessScaleFactor
+
+	// If this ends up scaling down to a zero sat/weight amount, then we'll
+	// use the default fallback fee rate.
+	if satWeight == 0 {
+		return b.fallBackFeeRate / blockchain.WitnessScaleFactor, nil
+	}
+
+	return satWeight, nil
+}
+
+// fetchEstimatePerByte returns a fee estimate for a transaction be be confirmed
+// in confTarget blocks. The estimate is returned in sat/byte.
+func (b *BitcoindFeeEstimator) fetchEstimatePerByte(confTarget uint32) (btcutil.Amount, error) {
+	// As the rpcclient doesn't yet have a wrapper for bitcoind's
+	// estimatesmartfee call, we'll issue the raw request ourselves.
+	target, err := json.Marshal(uint64(confTarget))
+	if err != nil {
+		return 0, err
+	}
+	resp, err := b.bitcoindConn.RawRequest(
+		"estimatesmartfee", []json.RawMessage{target},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Next, we'll parse the response to get the BTC per KB.
+	feeEstimate := struct {
+		FeeRate float64  `json:"feerate"`
+		Errors  []string `json:"errors"`
+	}{}
+	if err := json.Unmarshal(resp, &feeEstimate); err != nil {
+		return 0, err
+	}
+
+	// If bitcoind doesn't yet have enough data to produce an estimate,
+	// then it will omit the fee rate, and instead return an error.
+	if len(feeEstimate.Errors) != 0 {
+		return 0, fmt.Errorf("unable to estimate fee: %v",
+			strings.Join(feeEstimate.Errors, ", "))
+	}
+
+	// Next, we'll convert the returned value to satoshis, as it's
+	// currently returned in BTC.
+	satPerKB, err := btcutil.NewAmount(feeEstimate.FeeRate)
+	if err != nil {
+		return 0, err
+	}
+
+	// The value returned is expressed in fees per KB, while we want
+	// fee-per-byte, so we'll divide by 1024 to map to satoshis-per-byte
+	// before returning the estimate.
+	satPerByte := satPerKB / 1024
+
+	walletLog.Debugf("Returning %v sat/byte for conf target of %v",
+		int64(satPerByte), confTarget)
+
+	return satPerByte, nil
+}
+
+// A compile-time assertion to ensure that BitcoindFeeEstimator implements the
+// FeeEstimator interface.
+var _ FeeEstimator = (*BitcoindFeeEstimator)(nil)
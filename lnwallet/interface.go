@@ -43,6 +43,7 @@ type Utxo struct {
 	PkScript      []byte
 	RedeemScript  []byte
 	WitnessScript []byte
+	Confirmations int64
 	wire.OutPoint
 }
 
@@ -82,6 +83,9 @@ type TransactionDetail struct {
 
 	// DestAddresses are the destinations for a transaction
 	DestAddresses []btcutil.Address
+
+	// RawTx is the raw transaction itself.
+	RawTx *wire.MsgTx
 }
 
 // TransactionSubscription is an interface which describes an object capable of
@@ -273,6 +277,70 @@ type MessageSigner interface {
 	SignMessage(pubKey *btcec.PublicKey, msg []byte) (*btcec.Signature, error)
 }
 
+// KeyFamily denotes the purpose of a key handed out by a KeyRing. A remote
+// signer uses the family of a key to decide which transactions it may sign
+// with it.
+type KeyFamily uint32
+
+const (
+	// KeyFamilyMultiSig is the family of the keys used within the 2-of-2
+	// multi-sig funding output of a channel.
+	KeyFamilyMultiSig KeyFamily = 0
+
+	// KeyFamilyRevocationBase is the family of the base points used to
+	// derive the revocation keys of a channel.
+	KeyFamilyRevocationBase KeyFamily = 1
+
+	// KeyFamilyHtlcBase is the family of the base points used to derive
+	// the keys within the HTLC scripts of a channel.
+	KeyFamilyHtlcBase KeyFamily = 2
+
+	// KeyFamilyPaymentBase is the family of the base points used to derive
+	// the key of the non-delayed output of the remote commitment.
+	KeyFamilyPaymentBase KeyFamily = 3
+
+	// KeyFamilyDelayBase is the family of the base points used to derive
+	// the key of the delayed output of the local commitment.
+	KeyFamilyDelayBase KeyFamily = 4
+)
+
+// String returns a human readable version of the KeyFamily.
+func (k KeyFamily) String() string {
+	switch k {
+	case KeyFamilyMultiSig:
+		return "MultiSig"
+	case KeyFamilyRevocationBase:
+		return "RevocationBase"
+	case KeyFamilyHtlcBase:
+		return "HtlcBase"
+	case KeyFamilyPaymentBase:
+		return "PaymentBase"
+	case KeyFamilyDelayBase:
+		return "DelayBase"
+	default:
+		return fmt.Sprintf("KeyFamily(%d)", uint32(k))
+	}
+}
+
+// KeyRing represents an abstract source of the keys and secrets backing our
+// side of a channel. By default these are derived from the HD chain of the
+// wallet itself, but they may also be held by a remote signer, in which case
+// the private keys never touch the node.
+type KeyRing interface {
+	// DeriveNextKey returns the public key of a fresh key of the passed
+	// family controlled by the key ring. These keys are used for the
+	// funding output of a channel, as well as the base points of its
+	// commitment transactions.
+	DeriveNextKey(family KeyFamily) (*btcec.PublicKey, error)
+
+	// DeriveRevocationRoot derives the root of the revocation tree for a
+	// channel with the passed node, salted by the hash of the block at
+	// which the funding of the channel began. Given these two items, the
+	// key ring MUST always derive the same root.
+	DeriveRevocationRoot(blockSalt chainhash.Hash,
+		nodePubKey *btcec.PublicKey) (chainhash.Hash, error)
+}
+
 // WalletDriver represents a "driver" for a particular concrete
 // WalletController implementation. A driver is identified by a globally unique
 // string identifier along with a 'New()' method which is responsible for
@@ -267,7 +267,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	feePerKw := feePerWeight * 1000
 	aliceChanReservation, err := alice.InitChannelReservation(
 		fundingAmount*2, fundingAmount, 0, feePerKw, feePerKw,
-		bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel)
+		bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -291,7 +291,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness,
 	// the funding process.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmount*2,
 		fundingAmount, 0, feePerKw, feePerKw, alicePub, aliceAddr,
-		chainHash, lnwire.FFAnnounceChannel)
+		chainHash, lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("bob unable to init channel reservation: %v", err)
 	}
@@ -416,7 +416,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	feePerKw := feePerWeight * 1000
 	_, err = alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feePerKw, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel,
+		lnwire.FFAnnounceChannel, nil, false,
 	)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation 1: %v", err)
@@ -427,7 +427,7 @@ func testFundingTransactionLockedOutputs(miner *rpctest.Harness,
 	// that aren't locked, so this should fail.
 	amt := btcutil.Amount(900 * 1e8)
 	failedReservation, err := alice.InitChannelReservation(amt, amt, 0,
-		feePerKw, feePerKw, bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel)
+		feePerKw, feePerKw, bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel, nil, false)
 	if err == nil {
 		t.Fatalf("not error returned, should fail on coin selection")
 	}
@@ -452,7 +452,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	fundingAmount := btcutil.Amount(44 * 1e8)
 	chanReservation, err := alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feePerKw, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -460,7 +460,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 	// Attempt to create another channel with 44 BTC, this should fail.
 	_, err = alice.InitChannelReservation(fundingAmount,
 		fundingAmount, 0, feePerKw, feePerKw, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel,
+		lnwire.FFAnnounceChannel, nil, false,
 	)
 	if _, ok := err.(*lnwallet.ErrInsufficientFunds); !ok {
 		t.Fatalf("coin selection succeded should have insufficient funds: %v",
@@ -491,7 +491,7 @@ func testFundingCancellationNotEnoughFunds(miner *rpctest.Harness,
 
 	// Request to fund a new channel should now succeed.
 	_, err = alice.InitChannelReservation(fundingAmount, fundingAmount, 0,
-		feePerKw, feePerKw, bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel)
+		feePerKw, feePerKw, bobPub, bobAddr, chainHash, lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("unable to initialize funding reservation: %v", err)
 	}
@@ -530,7 +530,7 @@ func testReservationInitiatorBalanceBelowDustCancel(miner *rpctest.Harness,
 	feePerKw := btcutil.Amount(btcutil.SatoshiPerBitcoin * 10)
 	_, err := alice.InitChannelReservation(
 		fundingAmount, fundingAmount, 0, feePerKw, feePerKw, bobPub,
-		bobAddr, chainHash, lnwire.FFAnnounceChannel,
+		bobAddr, chainHash, lnwire.FFAnnounceChannel, nil, false,
 	)
 	switch {
 	case err == nil:
@@ -603,7 +603,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	feePerKw := feePerWeight * 1000
 	aliceChanReservation, err := alice.InitChannelReservation(fundingAmt,
 		fundingAmt, pushAmt, feePerKw, feePerKw, bobPub, bobAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("unable to init channel reservation: %v", err)
 	}
@@ -628,7 +628,7 @@ func testSingleFunderReservationWorkflow(miner *rpctest.Harness,
 	// reservation initiation, then consume Alice's contribution.
 	bobChanReservation, err := bob.InitChannelReservation(fundingAmt, 0,
 		pushAmt, feePerKw, feePerKw, alicePub, aliceAddr, chainHash,
-		lnwire.FFAnnounceChannel)
+		lnwire.FFAnnounceChannel, nil, false)
 	if err != nil {
 		t.Fatalf("unable to create bob reservation: %v", err)
 	}
@@ -97,6 +97,10 @@ type ChannelReservation struct {
 	// fundingTx is the funding transaction for this pending channel.
 	fundingTx *wire.MsgTx
 
+	// externalFunding indicates that our side of the funding transaction
+	// is assembled and signed by an external wallet.
+	externalFunding bool
+
 	// In order of sorted inputs. Sorting is done in accordance
 	// to BIP-69: https://github.com/bitcoin/bips/blob/master/bip-0069.mediawiki.
 	ourFundingInputScripts   []*InputScript
@@ -110,7 +114,7 @@ type ChannelReservation struct {
 	theirContribution *ChannelContribution
 
 	partialState *channeldb.OpenChannel
-	nodeAddr     *net.TCPAddr
+	nodeAddr     net.Addr
 
 	// The ID of this reservation, used to uniquely track the reservation
 	// throughout its lifetime.
@@ -347,6 +351,54 @@ func (r *ChannelReservation) ProcessContribution(theirContribution *ChannelContr
 	return <-errChan
 }
 
+// IsExternallyFunded returns true if our side of the funding transaction is
+// assembled and signed by an external wallet. Such reservations are advanced
+// via .ProcessExternalContribution() rather than .ProcessContribution().
+func (r *ChannelReservation) IsExternallyFunded() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return r.externalFunding
+}
+
+// FundingOutput returns the 2-of-2 multi-sig output that the funding
+// transaction must pay the full channel capacity to, given the multi-sig key
+// of the counterparty. This allows an external wallet to assemble the funding
+// transaction before the counterparty's contribution is processed.
+func (r *ChannelReservation) FundingOutput(
+	theirKey *btcec.PublicKey) (*wire.TxOut, error) {
+
+	r.RLock()
+	defer r.RUnlock()
+
+	ourKey := r.ourContribution.MultiSigKey
+	_, multiSigOut, err := GenFundingPkScript(
+		ourKey.SerializeCompressed(), theirKey.SerializeCompressed(),
+		int64(r.partialState.Capacity),
+	)
+	return multiSigOut, err
+}
+
+// ProcessExternalContribution is the counterpart of .ProcessContribution()
+// for externally funded reservations. Rather than building the funding
+// transaction from our wallet's inputs, the passed fully signed funding
+// transaction is used, after verifying that it pays the funding output. The
+// funding transaction is only broadcast once the counterparty's signature for
+// our commitment transaction has been verified by .CompleteReservation().
+func (r *ChannelReservation) ProcessExternalContribution(
+	theirContribution *ChannelContribution, fundingTx *wire.MsgTx) error {
+
+	errChan := make(chan error, 1)
+
+	r.wallet.msgChan <- &addContributionMsg{
+		pendingFundingID: r.reservationID,
+		contribution:     theirContribution,
+		fundingTx:        fundingTx,
+		err:              errChan,
+	}
+
+	return <-errChan
+}
+
 // ProcessSingleContribution verifies, and records the initiator's contribution
 // to this pending single funder channel. Internally, no further action is
 // taken other than recording the initiator's contribution to the single funder
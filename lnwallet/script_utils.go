@@ -344,6 +344,15 @@ func senderHtlcSpendRedeem(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// SenderHtlcSpendRedeem exposes the public witness generation function for
+// redeeming an HTLC that was offered to us on the remote party's commitment
+// transaction, using the payment preimage.
+func SenderHtlcSpendRedeem(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx, paymentPreimage []byte) (wire.TxWitness, error) {
+
+	return senderHtlcSpendRedeem(signer, signDesc, sweepTx, paymentPreimage)
+}
+
 // senderHtlcSpendTimeout constructs a valid witness allowing the sender of an
 // HTLC to activate the time locked covenant clause of a soon to be expired
 // HTLC.  This script simply spends the multi-sig output using the
@@ -603,6 +612,18 @@ func receiverHtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// ReceiverHtlcSpendTimeout exposes the public witness generation function for
+// reclaiming an HTLC we offered that's located on the remote party's
+// commitment transaction, once its absolute timeout has passed.
+//
+// NOTE: The lock time of the passed transaction will be set to cltvExpiry, so
+// the caller MUST re-compute the sig hash cache if it was set beforehand.
+func ReceiverHtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx, cltvExpiry uint32) (wire.TxWitness, error) {
+
+	return receiverHtlcSpendTimeout(signer, signDesc, sweepTx, cltvExpiry)
+}
+
 // createHtlcTimeoutTx creates a transaction that spends the HTLC output on the
 // commitment transaction of the peer that created an HTLC (the sender). This
 // transaction essentially acts as an off-chain covenant as it spends a 2-of-2
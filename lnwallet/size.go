@@ -280,6 +280,15 @@ const (
 	//    - witness_script (accepted_htlc_script)
 	AcceptedHtlcPenaltyWitnessSize = 1 + 1 + 73 + 1 + 33 + 1 + AcceptedHtlcScriptSize
 
+	// AcceptedHtlcTimeoutWitnessSize 216 bytes
+	//    - number_of_witness_elements: 1 byte
+	//    - sender_sig_length: 1 byte
+	//    - sender_sig: 73 bytes
+	//    - nil_length: 1 byte
+	//    - witness_script_length: 1 byte
+	//    - witness_script (accepted_htlc_script)
+	AcceptedHtlcTimeoutWitnessSize = 1 + 1 + 73 + 1 + 1 + AcceptedHtlcScriptSize
+
 	// OfferedHtlcScriptSize 133 bytes
 	//      - OP_DUP: 1 byte
 	//      - OP_HASH160: 1 byte
@@ -336,6 +345,16 @@ const (
 	// - witness_script (offered_htlc_script)
 	OfferedHtlcTimeoutWitnessSize = 1 + 1 + 1 + 73 + 1 + 73 + 1 + 1 + OfferedHtlcScriptSize
 
+	// OfferedHtlcSuccessWitnessSize 242 bytes
+	//    - number_of_witness_elements: 1 byte
+	//    - receiver_sig_length: 1 byte
+	//    - receiver_sig: 73 bytes
+	//    - payment_preimage_length: 1 byte
+	//    - payment_preimage: 32 bytes
+	//    - witness_script_length: 1 byte
+	//    - witness_script (offered_htlc_script)
+	OfferedHtlcSuccessWitnessSize = 1 + 1 + 73 + 1 + 32 + 1 + OfferedHtlcScriptSize
+
 	// OfferedHtlcPenaltyWitnessSize 243 bytes
 	//      - number_of_witness_elements: 1 byte
 	//      - revocation_sig_length: 1 byte
@@ -446,6 +465,13 @@ func (twe *TxWeightEstimator) AddP2SHOutput() {
 	twe.outputCount++
 }
 
+// AddTxOutput updates the weight estimate to account for an additional
+// output of any type.
+func (twe *TxWeightEstimator) AddTxOutput(txOut *wire.TxOut) {
+	twe.outputSize += txOut.SerializeSize()
+	twe.outputCount++
+}
+
 // Weight gets the estimated weight of the transaction.
 func (twe *TxWeightEstimator) Weight() int {
 	txSizeStripped := BaseTxSize +
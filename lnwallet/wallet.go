@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
@@ -46,6 +47,10 @@ var (
 	lightningNamespaceKey = []byte("ln-wallet")
 	waddrmgrNamespaceKey  = []byte("waddrmgr")
 	wtxmgrNamespaceKey    = []byte("wtxmgr")
+
+	// ErrWatchOnly is returned when a secret is requested from the HD
+	// chain of a watch-only wallet, which holds no private keys.
+	ErrWatchOnly = fmt.Errorf("wallet is watch-only")
 )
 
 // ErrInsufficientFunds is a type matching the error interface which is
@@ -85,7 +90,7 @@ type initFundingReserveMsg struct {
 	// nodeAddr is the IP address plus port that we used to either
 	// establish or accept the connection which led to the negotiation of
 	// this funding workflow.
-	nodeAddr *net.TCPAddr
+	nodeAddr net.Addr
 
 	// fundingAmount is the amount of funds requested for this channel.
 	fundingAmount btcutil.Amount
@@ -112,6 +117,15 @@ type initFundingReserveMsg struct {
 	// open_channel message.
 	flags lnwire.FundingFlag
 
+	// fundingInputs is the set of wallet outputs that should fund the
+	// channel. If empty, then the inputs are selected automatically.
+	fundingInputs []wire.OutPoint
+
+	// externalFunding indicates that our side of the funding transaction
+	// is assembled and signed by an external wallet, so no coins of our
+	// own wallet should be selected.
+	externalFunding bool
+
 	// err is a channel in which all errors will be sent across. Will be
 	// nil if this initial set is successful.
 	//
@@ -150,6 +164,10 @@ type addContributionMsg struct {
 	// TODO(roasbeef): Should also carry SPV proofs in we're in SPV mode
 	contribution *ChannelContribution
 
+	// fundingTx is the fully signed funding transaction of an externally
+	// funded reservation. It's nil for reservations funded by our wallet.
+	fundingTx *wire.MsgTx
+
 	// NOTE: In order to avoid deadlocks, this channel MUST be buffered.
 	err chan error
 }
@@ -282,6 +300,12 @@ type LightningWallet struct {
 	// the currently locked outpoints.
 	lockedOutPoints map[wire.OutPoint]struct{}
 
+	// leasedOutPoints maps each output leased by the user to the
+	// expiration of its lease. Leased outputs are locked within the
+	// underlying wallet, so they're only spent when explicitly selected.
+	// It MUST only be accessed with the coinSelectMtx held.
+	leasedOutPoints map[wire.OutPoint]time.Time
+
 	started  int32
 	shutdown int32
 	quit     chan struct{}
@@ -295,6 +319,10 @@ type LightningWallet struct {
 // If the wallet has never been created (according to the passed dataDir), first-time
 // setup is executed.
 func NewLightningWallet(Cfg Config) (*LightningWallet, error) {
+	if Cfg.WatchOnly && (Cfg.KeyRing == nil || Cfg.Signer == nil) {
+		return nil, fmt.Errorf("a watch-only wallet requires both a " +
+			"key ring and a signer")
+	}
 
 	return &LightningWallet{
 		Cfg:              Cfg,
@@ -303,6 +331,7 @@ func NewLightningWallet(Cfg Config) (*LightningWallet, error) {
 		nextFundingID:    0,
 		fundingLimbo:     make(map[uint64]*ChannelReservation),
 		lockedOutPoints:  make(map[wire.OutPoint]struct{}),
+		leasedOutPoints:  make(map[wire.OutPoint]time.Time),
 		quit:             make(chan struct{}),
 	}, nil
 }
@@ -321,22 +350,35 @@ func (l *LightningWallet) Startup() error {
 	}
 
 	// Fetch the root derivation key from the wallet's HD chain. We'll use
-	// this to generate specific Lightning related secrets on the fly.
-	rootKey, err := l.FetchRootKey()
-	if err != nil {
-		return err
+	// this to generate specific Lightning related secrets on the fly. A
+	// watch-only wallet has no private keys, in which case all secrets
+	// are sourced from the KeyRing instead.
+	if !l.Cfg.WatchOnly {
+		rootKey, err := l.FetchRootKey()
+		if err != nil {
+			return err
+		}
+
+		// TODO(roasbeef): always re-derive on the fly?
+		rootKeyRaw := rootKey.Serialize()
+		l.rootKey, err = hdkeychain.NewMaster(
+			rootKeyRaw, &l.Cfg.NetParams,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
-	// TODO(roasbeef): always re-derive on the fly?
-	rootKeyRaw := rootKey.Serialize()
-	l.rootKey, err = hdkeychain.NewMaster(rootKeyRaw, &l.Cfg.NetParams)
-	if err != nil {
+	// Lock the outputs leased before the restart once again, as the
+	// underlying wallet doesn't persist its locks.
+	if err := l.restoreLeases(); err != nil {
 		return err
 	}
 
-	l.wg.Add(1)
+	l.wg.Add(2)
 	// TODO(roasbeef): multiple request handlers?
 	go l.requestHandler()
+	go l.leaseExpiryHandler()
 
 	return nil
 }
@@ -374,7 +416,13 @@ func (l *LightningWallet) ResetReservations() {
 	l.nextFundingID = 0
 	l.fundingLimbo = make(map[uint64]*ChannelReservation)
 
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
 	for outpoint := range l.lockedOutPoints {
+		if _, ok := l.leasedOutPoints[outpoint]; ok {
+			continue
+		}
 		l.UnlockOutpoint(outpoint)
 	}
 	l.lockedOutPoints = make(map[wire.OutPoint]struct{})
@@ -394,6 +442,10 @@ func (l *LightningWallet) ActiveReservations() []*ChannelReservation {
 // GetIdentitykey returns the identity private key of the wallet.
 // TODO(roasbeef): should be moved elsewhere
 func (l *LightningWallet) GetIdentitykey() (*btcec.PrivateKey, error) {
+	if l.Cfg.WatchOnly {
+		return nil, ErrWatchOnly
+	}
+
 	identityKey, err := l.rootKey.Child(identityKeyIndex)
 	if err != nil {
 		return nil, err
@@ -449,11 +501,22 @@ out:
 // and final step verifies all signatures for the inputs of the funding
 // transaction, and that the signature we records for our version of the
 // commitment transaction is valid.
+//
+// If fundingInputs is non-empty, then exactly those wallet outputs fund our
+// side of the channel, instead of automatically selected ones. If they don't
+// cover the funding amount plus fees, then ErrInsufficientFunds is returned.
+//
+// If externalFunding is set, then our side of the funding transaction is
+// assembled and signed by an external wallet instead. Such a reservation is
+// advanced via ProcessExternalContribution, which takes the final funding
+// transaction in place of our own inputs.
 func (l *LightningWallet) InitChannelReservation(
 	capacity, ourFundAmt btcutil.Amount, pushMSat lnwire.MilliSatoshi,
 	commitFeePerKw, fundingFeePerWeight btcutil.Amount,
-	theirID *btcec.PublicKey, theirAddr *net.TCPAddr,
-	chainHash *chainhash.Hash, flags lnwire.FundingFlag) (*ChannelReservation, error) {
+	theirID *btcec.PublicKey, theirAddr net.Addr,
+	chainHash *chainhash.Hash, flags lnwire.FundingFlag,
+	fundingInputs []wire.OutPoint,
+	externalFunding bool) (*ChannelReservation, error) {
 
 	errChan := make(chan error, 1)
 	respChan := make(chan *ChannelReservation, 1)
@@ -468,6 +531,8 @@ func (l *LightningWallet) InitChannelReservation(
 		fundingFeePerWeight: fundingFeePerWeight,
 		pushMSat:            pushMSat,
 		flags:               flags,
+		fundingInputs:       fundingInputs,
+		externalFunding:     externalFunding,
 		err:                 errChan,
 		resp:                respChan,
 	}
@@ -496,6 +561,24 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 		return
 	}
 
+	// Only the initiator of a single funder workflow has a side of the
+	// funding transaction that an external wallet could fund.
+	if req.externalFunding {
+		switch {
+		case req.fundingAmount == 0:
+			req.err <- fmt.Errorf("cannot externally fund a " +
+				"channel we don't contribute to")
+			req.resp <- nil
+			return
+
+		case len(req.fundingInputs) != 0:
+			req.err <- fmt.Errorf("cannot select funding inputs " +
+				"for an externally funded channel")
+			req.resp <- nil
+			return
+		}
+	}
+
 	id := atomic.AddUint64(&l.nextFundingID, 1)
 	reservation, err := NewChannelReservation(req.capacity, req.fundingAmount,
 		req.commitFeePerKw, l, id, req.pushMSat,
@@ -512,16 +595,18 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 
 	reservation.nodeAddr = req.nodeAddr
 	reservation.partialState.IdentityPub = req.nodeID
+	reservation.externalFunding = req.externalFunding
 
-	// If we're on the receiving end of a single funder channel then we
-	// don't need to perform any coin selection. Otherwise, attempt to
-	// obtain enough coins to meet the required funding amount.
-	if req.fundingAmount != 0 {
+	// If we're on the receiving end of a single funder channel, or the
+	// external wallet funds our side, then we don't need to perform any
+	// coin selection. Otherwise, attempt to obtain enough coins to meet
+	// the required funding amount.
+	if req.fundingAmount != 0 && !req.externalFunding {
 		// Coin selection is done on the basis of sat-per-weight, we'll
 		// use the passed sat/byte passed in to perform coin selection.
 		err := l.selectCoinsAndChange(
 			req.fundingFeePerWeight, req.fundingAmount,
-			reservation.ourContribution,
+			reservation.ourContribution, req.fundingInputs,
 		)
 		if err != nil {
 			req.err <- err
@@ -530,23 +615,30 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 		}
 	}
 
-	// Next, we'll grab a series of keys from the wallet which will be used
-	// for the duration of the channel. The keys include: our multi-sig
-	// key, the base revocation key, the base htlc key,the base payment
-	// key, and the delayed payment key.
-	reservation.ourContribution.MultiSigKey, err = l.NewRawKey()
+	// Next, we'll grab a series of keys from our key ring which will be
+	// used for the duration of the channel. The keys include: our
+	// multi-sig key, the base revocation key, the base htlc key,the base
+	// payment key, and the delayed payment key.
+	keyRing := l.keyRing()
+	reservation.ourContribution.MultiSigKey, err = keyRing.DeriveNextKey(
+		KeyFamilyMultiSig,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	reservation.ourContribution.RevocationBasePoint, err = l.NewRawKey()
+	reservation.ourContribution.RevocationBasePoint, err = keyRing.DeriveNextKey(
+		KeyFamilyRevocationBase,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	reservation.ourContribution.HtlcBasePoint, err = l.NewRawKey()
+	reservation.ourContribution.HtlcBasePoint, err = keyRing.DeriveNextKey(
+		KeyFamilyHtlcBase,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
@@ -555,13 +647,17 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	// TODO(roasbeef); allow for querying to extract key distinct from HD
 	// chain
 	//  * allows for offline commitment keys
-	reservation.ourContribution.PaymentBasePoint, err = l.NewRawKey()
+	reservation.ourContribution.PaymentBasePoint, err = keyRing.DeriveNextKey(
+		KeyFamilyPaymentBase,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	reservation.ourContribution.DelayBasePoint, err = l.NewRawKey()
+	reservation.ourContribution.DelayBasePoint, err = keyRing.DeriveNextKey(
+		KeyFamilyDelayBase,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
@@ -575,20 +671,20 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	// channel with. In the event of a recovery, given these two items and
 	// the initialize wallet HD seed, we can derive all of our revocation
 	// secrets.
-	masterElkremRoot, err := l.deriveMasterRevocationRoot()
+	bestHash, _, err := l.Cfg.ChainIO.GetBestBlock()
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	bestHash, _, err := l.Cfg.ChainIO.GetBestBlock()
+	revocationRoot, err := keyRing.DeriveRevocationRoot(
+		*bestHash, req.nodeID,
+	)
 	if err != nil {
 		req.err <- err
 		req.resp <- nil
 		return
 	}
-	revocationRoot := DeriveRevocationRoot(masterElkremRoot, *bestHash,
-		req.nodeID)
 
 	// Once we have the root, we can then generate our shachain producer
 	// and from that generate the per-commitment point.
@@ -642,11 +738,17 @@ func (l *LightningWallet) handleFundingCancelRequest(req *fundingReserveCancelMs
 	defer pendingReservation.Unlock()
 
 	// Mark all previously locked outpoints as useable for future funding
-	// requests.
+	// requests. Leased outputs remain locked until their lease expires.
+	l.coinSelectMtx.Lock()
 	for _, unusedInput := range pendingReservation.ourContribution.Inputs {
-		delete(l.lockedOutPoints, unusedInput.PreviousOutPoint)
-		l.UnlockOutpoint(unusedInput.PreviousOutPoint)
+		outpoint := unusedInput.PreviousOutPoint
+		delete(l.lockedOutPoints, outpoint)
+		if _, ok := l.leasedOutPoints[outpoint]; ok {
+			continue
+		}
+		l.UnlockOutpoint(outpoint)
 	}
+	l.coinSelectMtx.Unlock()
 
 	// TODO(roasbeef): is it even worth it to keep track of unused keys?
 
@@ -719,34 +821,23 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	pendingReservation.Lock()
 	defer pendingReservation.Unlock()
 
-	// Create a blank, fresh transaction. Soon to be a complete funding
-	// transaction which will allow opening a lightning channel.
-	pendingReservation.fundingTx = wire.NewMsgTx(1)
-	fundingTx := pendingReservation.fundingTx
+	// An externally funded reservation can only proceed once the external
+	// wallet has handed us the final funding transaction, and vice versa.
+	if pendingReservation.externalFunding != (req.fundingTx != nil) {
+		req.err <- fmt.Errorf("funding transaction must be provided " +
+			"iff the reservation is externally funded")
+		return
+	}
 
 	// Some temporary variables to cut down on the resolution verbosity.
 	pendingReservation.theirContribution = req.contribution
 	theirContribution := req.contribution
 	ourContribution := pendingReservation.ourContribution
 
-	// Add all multi-party inputs and outputs to the transaction.
-	for _, ourInput := range ourContribution.Inputs {
-		fundingTx.AddTxIn(ourInput)
-	}
-	for _, theirInput := range theirContribution.Inputs {
-		fundingTx.AddTxIn(theirInput)
-	}
-	for _, ourChangeOutput := range ourContribution.ChangeOutputs {
-		fundingTx.AddTxOut(ourChangeOutput)
-	}
-	for _, theirChangeOutput := range theirContribution.ChangeOutputs {
-		fundingTx.AddTxOut(theirChangeOutput)
-	}
-
 	ourKey := pendingReservation.ourContribution.MultiSigKey
 	theirKey := theirContribution.MultiSigKey
 
-	// Finally, add the 2-of-2 multi-sig output which will set up the lightning
+	// Generate the 2-of-2 multi-sig output which will set up the lightning
 	// channel.
 	channelCapacity := int64(pendingReservation.partialState.Capacity)
 	witnessScript, multiSigOut, err := GenFundingPkScript(ourKey.SerializeCompressed(),
@@ -756,45 +847,27 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 		return
 	}
 
-	// Sort the transaction. Since both side agree to a canonical ordering,
-	// by sorting we no longer need to send the entire transaction. Only
-	// signatures will be exchanged.
-	fundingTx.AddTxOut(multiSigOut)
-	txsort.InPlaceSort(pendingReservation.fundingTx)
-
-	// Next, sign all inputs that are ours, collecting the signatures in
-	// order of the inputs.
-	pendingReservation.ourFundingInputScripts = make([]*InputScript, 0,
-		len(ourContribution.Inputs))
-	signDesc := SignDescriptor{
-		HashType:  txscript.SigHashAll,
-		SigHashes: txscript.NewTxSigHashes(fundingTx),
-	}
-	for i, txIn := range fundingTx.TxIn {
-		info, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
-		if err == ErrNotMine {
-			continue
-		} else if err != nil {
+	var fundingTx *wire.MsgTx
+	if req.fundingTx != nil {
+		// The external wallet has already signed the funding
+		// transaction, so all that's left is ensuring that it
+		// actually funds the channel.
+		err := verifyExternalFundingTx(req.fundingTx, multiSigOut)
+		if err != nil {
 			req.err <- err
 			return
 		}
 
-		signDesc.Output = info
-		signDesc.InputIndex = i
-
-		inputScript, err := l.Cfg.Signer.ComputeInputScript(fundingTx,
-			&signDesc)
+		fundingTx = req.fundingTx.Copy()
+		pendingReservation.fundingTx = fundingTx
+	} else {
+		fundingTx, err = l.signFundingTx(
+			pendingReservation, multiSigOut,
+		)
 		if err != nil {
 			req.err <- err
 			return
 		}
-
-		txIn.SignatureScript = inputScript.ScriptSig
-		txIn.Witness = inputScript.Witness
-		pendingReservation.ourFundingInputScripts = append(
-			pendingReservation.ourFundingInputScripts,
-			inputScript,
-		)
 	}
 
 	// Locate the index of the multi-sig outpoint in order to record it
@@ -884,7 +957,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 
 	// Generate a signature for their version of the initial commitment
 	// transaction.
-	signDesc = SignDescriptor{
+	signDesc := SignDescriptor{
 		WitnessScript: witnessScript,
 		PubKey:        ourKey,
 		Output:        multiSigOut,
@@ -902,6 +975,118 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	req.err <- nil
 }
 
+// signFundingTx assembles the funding transaction from the inputs and change
+// outputs of both parties, along with the passed multi-sig output, and signs
+// all inputs that belong to our wallet. The signatures are recorded within
+// the reservation.
+//
+// NOTE: This MUST be called with the reservation's mutex held.
+func (l *LightningWallet) signFundingTx(res *ChannelReservation,
+	multiSigOut *wire.TxOut) (*wire.MsgTx, error) {
+
+	// Create a blank, fresh transaction. Soon to be a complete funding
+	// transaction which will allow opening a lightning channel.
+	res.fundingTx = wire.NewMsgTx(1)
+	fundingTx := res.fundingTx
+
+	ourContribution := res.ourContribution
+	theirContribution := res.theirContribution
+
+	// Add all multi-party inputs and outputs to the transaction.
+	for _, ourInput := range ourContribution.Inputs {
+		fundingTx.AddTxIn(ourInput)
+	}
+	for _, theirInput := range theirContribution.Inputs {
+		fundingTx.AddTxIn(theirInput)
+	}
+	for _, ourChangeOutput := range ourContribution.ChangeOutputs {
+		fundingTx.AddTxOut(ourChangeOutput)
+	}
+	for _, theirChangeOutput := range theirContribution.ChangeOutputs {
+		fundingTx.AddTxOut(theirChangeOutput)
+	}
+
+	// Sort the transaction. Since both side agree to a canonical ordering,
+	// by sorting we no longer need to send the entire transaction. Only
+	// signatures will be exchanged.
+	fundingTx.AddTxOut(multiSigOut)
+	txsort.InPlaceSort(fundingTx)
+
+	// Next, sign all inputs that are ours, collecting the signatures in
+	// order of the inputs.
+	res.ourFundingInputScripts = make([]*InputScript, 0,
+		len(ourContribution.Inputs))
+	signDesc := SignDescriptor{
+		HashType:  txscript.SigHashAll,
+		SigHashes: txscript.NewTxSigHashes(fundingTx),
+	}
+	for i, txIn := range fundingTx.TxIn {
+		info, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err == ErrNotMine {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		signDesc.Output = info
+		signDesc.InputIndex = i
+
+		inputScript, err := l.Cfg.Signer.ComputeInputScript(fundingTx,
+			&signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		txIn.SignatureScript = inputScript.ScriptSig
+		txIn.Witness = inputScript.Witness
+		res.ourFundingInputScripts = append(
+			res.ourFundingInputScripts, inputScript,
+		)
+	}
+
+	return fundingTx, nil
+}
+
+// verifyExternalFundingTx ensures that a funding transaction assembled by an
+// external wallet pays the full channel capacity to the multi-sig output
+// exactly once, and that all of its inputs carry a witness. Inputs without a
+// witness would allow third parties to malleate the txid of the funding
+// transaction after the commitment transactions have been signed.
+func verifyExternalFundingTx(fundingTx *wire.MsgTx,
+	multiSigOut *wire.TxOut) error {
+
+	if len(fundingTx.TxIn) == 0 {
+		return fmt.Errorf("funding transaction has no inputs")
+	}
+	for i, txIn := range fundingTx.TxIn {
+		if len(txIn.Witness) == 0 {
+			return fmt.Errorf("input %d of funding transaction "+
+				"has no witness", i)
+		}
+	}
+
+	var numFundingOutputs int
+	for _, txOut := range fundingTx.TxOut {
+		if !bytes.Equal(txOut.PkScript, multiSigOut.PkScript) {
+			continue
+		}
+
+		if txOut.Value != multiSigOut.Value {
+			return fmt.Errorf("funding output pays %v, expected "+
+				"%v", btcutil.Amount(txOut.Value),
+				btcutil.Amount(multiSigOut.Value))
+		}
+		numFundingOutputs++
+	}
+	if numFundingOutputs != 1 {
+		return fmt.Errorf("funding transaction must pay to the "+
+			"funding output exactly once, found %d",
+			numFundingOutputs)
+	}
+
+	return blockchain.CheckTransactionSanity(btcutil.NewTx(fundingTx))
+}
+
 // handleSingleContribution is called as the second step to a single funder
 // workflow to which we are the responder. It simply saves the remote peer's
 // contribution to the channel, as solely the remote peer will contribute any
@@ -1112,6 +1297,14 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 		}
 	}
 
+	// Now that the funding transaction spends our inputs, their leases,
+	// if any, are no longer needed.
+	l.coinSelectMtx.Lock()
+	for _, txIn := range res.ourContribution.Inputs {
+		l.removeLease(txIn.PreviousOutPoint)
+	}
+	l.coinSelectMtx.Unlock()
+
 	msg.completeChan <- res.partialState
 	msg.err <- nil
 }
@@ -1274,7 +1467,8 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 // also be generated.
 // TODO(roasbeef): remove hardcoded fees and req'd confs for outputs.
 func (l *LightningWallet) selectCoinsAndChange(feeRatePerWeight btcutil.Amount,
-	amt btcutil.Amount, contribution *ChannelContribution) error {
+	amt btcutil.Amount, contribution *ChannelContribution,
+	fundingInputs []wire.OutPoint) error {
 
 	// We hold the coin select mutex while querying for outputs, and
 	// performing coin selection in order to avoid inadvertent double
@@ -1282,23 +1476,59 @@ func (l *LightningWallet) selectCoinsAndChange(feeRatePerWeight btcutil.Amount,
 	l.coinSelectMtx.Lock()
 	defer l.coinSelectMtx.Unlock()
 
-	walletLog.Infof("Performing funding tx coin selection using %v "+
-		"sat/weight as fee rate", int64(feeRatePerWeight))
+	var (
+		selectedCoins []*Utxo
+		changeAmt     btcutil.Amount
+		err           error
+	)
+	if len(fundingInputs) != 0 {
+		walletLog.Infof("Funding channel with %d explicit inputs using "+
+			"%v sat/weight as fee rate", len(fundingInputs),
+			int64(feeRatePerWeight))
+
+		// The explicit inputs must all be spent, so the funding amount
+		// and fees must be covered by them alone.
+		selectedCoins, err = l.fetchExplicitInputs(fundingInputs, 1)
+		if err != nil {
+			return err
+		}
 
-	// Find all unlocked unspent witness outputs with greater than 1
-	// confirmation.
-	// TODO(roasbeef): make num confs a configuration parameter
-	coins, err := l.ListUnspentWitness(1)
-	if err != nil {
-		return err
-	}
+		var weightEstimate TxWeightEstimator
+		weightEstimate.AddP2WSHOutput()
+		weightEstimate.AddP2WKHOutput()
+		totalSat, err := addCoinsWeight(&weightEstimate, selectedCoins)
+		if err != nil {
+			return err
+		}
 
-	// Perform coin selection over our available, unlocked unspent outputs
-	// in order to find enough coins to meet the funding amount
-	// requirements.
-	selectedCoins, changeAmt, err := coinSelect(feeRatePerWeight, amt, coins)
-	if err != nil {
-		return err
+		requiredFee := btcutil.Amount(
+			uint64(weightEstimate.Weight()) * uint64(feeRatePerWeight),
+		)
+		changeAmt, err = explicitInputsChange(totalSat, amt, requiredFee)
+		if err != nil {
+			return err
+		}
+	} else {
+		walletLog.Infof("Performing funding tx coin selection using %v "+
+			"sat/weight as fee rate", int64(feeRatePerWeight))
+
+		// Find all unlocked unspent witness outputs with greater than
+		// 1 confirmation.
+		// TODO(roasbeef): make num confs a configuration parameter
+		coins, err := l.ListUnspentWitness(1)
+		if err != nil {
+			return err
+		}
+
+		// Perform coin selection over our available, unlocked unspent
+		// outputs in order to find enough coins to meet the funding
+		// amount requirements.
+		selectedCoins, changeAmt, err = coinSelect(
+			feeRatePerWeight, amt, coins,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Lock the selected coins. These coins are now "reserved", this
@@ -1341,6 +1571,10 @@ func (l *LightningWallet) selectCoinsAndChange(feeRatePerWeight btcutil.Amount,
 // producer root. This master secret is used as the secret input to a HKDF to
 // generate revocation secrets based on random, but public data.
 func (l *LightningWallet) deriveMasterRevocationRoot() (*btcec.PrivateKey, error) {
+	if l.Cfg.WatchOnly {
+		return nil, ErrWatchOnly
+	}
+
 	masterElkremRoot, err := l.rootKey.Child(revocationRootIndex)
 	if err != nil {
 		return nil, err
@@ -1349,6 +1583,44 @@ func (l *LightningWallet) deriveMasterRevocationRoot() (*btcec.PrivateKey, error
 	return masterElkremRoot.ECPrivKey()
 }
 
+// DeriveNextKey returns a fresh raw key of the wallet. The wallet doesn't
+// segregate its keys by family, so the family is ignored.
+//
+// NOTE: This is part of the KeyRing interface.
+func (l *LightningWallet) DeriveNextKey(_ KeyFamily) (*btcec.PublicKey, error) {
+	return l.NewRawKey()
+}
+
+// DeriveRevocationRoot derives the root of the revocation tree for a channel
+// with the passed node from the master revocation root of the wallet's HD
+// chain, salted by the passed block hash.
+//
+// NOTE: This is part of the KeyRing interface.
+func (l *LightningWallet) DeriveRevocationRoot(blockSalt chainhash.Hash,
+	nodePubKey *btcec.PublicKey) (chainhash.Hash, error) {
+
+	masterElkremRoot, err := l.deriveMasterRevocationRoot()
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	return DeriveRevocationRoot(masterElkremRoot, blockSalt, nodePubKey), nil
+}
+
+// A compile time check to ensure that LightningWallet implements the KeyRing
+// interface.
+var _ KeyRing = (*LightningWallet)(nil)
+
+// keyRing returns the KeyRing backing our side of new channels, which is the
+// wallet itself unless an external one has been configured.
+func (l *LightningWallet) keyRing() KeyRing {
+	if l.Cfg.KeyRing != nil {
+		return l.Cfg.KeyRing
+	}
+
+	return l
+}
+
 // deriveStateHintObfuscator derives the bytes to be used for obfuscating the
 // state hints from the root to be used for a new channel. The obsfucsator is
 // generated via the following computation:
@@ -1370,6 +1642,23 @@ func deriveStateHintObfuscator(key1, key2 *btcec.PublicKey) [StateHintSize]byte
 	return obfuscator
 }
 
+// createStateHintObfuscator derives the obfuscator of the state hints within
+// the commitment transactions of the passed channel. The payment base point
+// of the initiator of the channel is always used as the first key.
+func createStateHintObfuscator(state *channeldb.OpenChannel) [StateHintSize]byte {
+	if state.IsInitiator {
+		return deriveStateHintObfuscator(
+			state.LocalChanCfg.PaymentBasePoint,
+			state.RemoteChanCfg.PaymentBasePoint,
+		)
+	}
+
+	return deriveStateHintObfuscator(
+		state.RemoteChanCfg.PaymentBasePoint,
+		state.LocalChanCfg.PaymentBasePoint,
+	)
+}
+
 // initStateHints properly sets the obsfucated state hints on both commitment
 // transactions using the passed obfuscator.
 func initStateHints(commit1, commit2 *wire.MsgTx,
@@ -1401,6 +1690,31 @@ func selectInputs(amt btcutil.Amount, coins []*Utxo) (btcutil.Amount, []*Utxo, e
 	return 0, nil, &ErrInsufficientFunds{amt, satSelected}
 }
 
+// addCoinsWeight updates the weight estimate to account for spending all of
+// the passed coins, returning their total value.
+func addCoinsWeight(weightEstimate *TxWeightEstimator,
+	coins []*Utxo) (btcutil.Amount, error) {
+
+	var totalSat btcutil.Amount
+	for _, utxo := range coins {
+		switch utxo.AddressType {
+		case WitnessPubKey:
+			weightEstimate.AddP2WKHInput()
+		case NestedWitnessPubKey:
+			weightEstimate.AddNestedP2WKHInput()
+		case PubKeyHash:
+			weightEstimate.AddP2PKHInput()
+		default:
+			return 0, fmt.Errorf("Unsupported address type: %v",
+				utxo.AddressType)
+		}
+
+		totalSat += utxo.Value
+	}
+
+	return totalSat, nil
+}
+
 // coinSelect attempts to select a sufficient amount of coins, including a
 // change output to fund amt satoshis, adhering to the specified fee rate. The
 // specified fee rate should be expressed in sat/byte for coin selection to
@@ -1418,19 +1732,9 @@ func coinSelect(feeRatePerWeight, amt btcutil.Amount,
 		}
 
 		var weightEstimate TxWeightEstimator
-
-		for _, utxo := range selectedUtxos {
-			switch utxo.AddressType {
-			case WitnessPubKey:
-				weightEstimate.AddP2WKHInput()
-			case NestedWitnessPubKey:
-				weightEstimate.AddNestedP2WKHInput()
-			case PubKeyHash:
-				weightEstimate.AddP2PKHInput()
-			default:
-				return nil, 0, fmt.Errorf("Unsupported address type: %v",
-					utxo.AddressType)
-			}
+		_, err = addCoinsWeight(&weightEstimate, selectedUtxos)
+		if err != nil {
+			return nil, 0, err
 		}
 
 		// Channel funding multisig output is P2WSH.
@@ -45,6 +45,35 @@ const (
 	HtlcAcceptedSuccess WitnessType = 6
 )
 
+// String returns a human readable version of the target WitnessType.
+func (wt WitnessType) String() string {
+	switch wt {
+	case CommitmentTimeLock:
+		return "CommitmentTimeLock"
+
+	case CommitmentNoDelay:
+		return "CommitmentNoDelay"
+
+	case CommitmentRevoke:
+		return "CommitmentRevoke"
+
+	case HtlcOfferedRevoke:
+		return "HtlcOfferedRevoke"
+
+	case HtlcAcceptedRevoke:
+		return "HtlcAcceptedRevoke"
+
+	case HtlcOfferedTimeout:
+		return "HtlcOfferedTimeout"
+
+	case HtlcAcceptedSuccess:
+		return "HtlcAcceptedSuccess"
+
+	default:
+		return fmt.Sprintf("Unknown WitnessType: %d", uint16(wt))
+	}
+}
+
 // WitnessGenerator represents a function which is able to generate the final
 // witness for a particular public key script. This function acts as an
 // abstraction layer, hiding the details of the underlying script.
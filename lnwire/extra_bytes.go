@@ -0,0 +1,83 @@
+package lnwire
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// ExtraOpaqueData is the set of data that was appended to the end of a
+// message. It's expected to be a TLV stream of extension fields, which allows
+// new optional fields to be added to a message without breaking older peers.
+// The raw bytes are retained so that any records unknown to us are preserved
+// when the message is re-encoded.
+type ExtraOpaqueData []byte
+
+// Encode attempts to encode the raw extra bytes into the passed io.Writer.
+func (e *ExtraOpaqueData) Encode(w io.Writer) error {
+	_, err := w.Write(*e)
+	return err
+}
+
+// Decode attempts to unpack the raw bytes encoded in the passed io.Reader as
+// a set of extra opaque data. As the extra data is always at the end of a
+// message, all the remaining bytes of the reader are consumed.
+func (e *ExtraOpaqueData) Decode(r io.Reader) error {
+	extraBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	// We'll only set the extra data if there was some, so that a message
+	// without any extension fields is identical to one created locally.
+	if len(extraBytes) > 0 {
+		*e = ExtraOpaqueData(extraBytes)
+	} else {
+		*e = nil
+	}
+
+	return nil
+}
+
+// PackRecords encodes the passed records as a TLV stream, replacing the
+// existing extra data. The records are sorted by type before being encoded.
+func (e *ExtraOpaqueData) PackRecords(records ...tlv.Record) error {
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := stream.Encode(&b); err != nil {
+		return err
+	}
+
+	if b.Len() > 0 {
+		*e = ExtraOpaqueData(b.Bytes())
+	} else {
+		*e = nil
+	}
+
+	return nil
+}
+
+// ExtractRecords decodes the extra data as a TLV stream, populating the passed
+// records. The set of types that were parsed is returned, which includes the
+// raw values of any optional records unknown to the caller. An error is
+// returned if the stream contains a required record that isn't known.
+func (e *ExtraOpaqueData) ExtractRecords(
+	records ...tlv.Record) (tlv.TypeMap, error) {
+
+	tlv.SortRecords(records)
+
+	stream, err := tlv.NewStream(records...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.DecodeWithParsedTypes(bytes.NewReader(*e))
+}
@@ -0,0 +1,105 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+// TestExtraOpaqueDataPackExtractRecords tests that records packed into the
+// extra data of a message can be extracted once again, and that unknown
+// optional records are returned to the caller.
+func TestExtraOpaqueDataPackExtractRecords(t *testing.T) {
+	t.Parallel()
+
+	var (
+		knownValue   = uint32(0xdeadbeef)
+		unknownValue = []byte{0x01, 0x02, 0x03}
+		extraData    ExtraOpaqueData
+	)
+
+	// The records are deliberately passed out of order, as they should be
+	// sorted before being packed.
+	err := extraData.PackRecords(
+		tlv.MakePrimitiveRecord(5, &unknownValue),
+		tlv.MakePrimitiveRecord(2, &knownValue),
+	)
+	if err != nil {
+		t.Fatalf("unable to pack records: %v", err)
+	}
+
+	var extractedValue uint32
+	parsedTypes, err := extraData.ExtractRecords(
+		tlv.MakePrimitiveRecord(2, &extractedValue),
+	)
+	if err != nil {
+		t.Fatalf("unable to extract records: %v", err)
+	}
+
+	if extractedValue != knownValue {
+		t.Fatalf("expected %x, got %x", knownValue, extractedValue)
+	}
+
+	expectedTypes := tlv.TypeMap{
+		2: nil,
+		5: unknownValue,
+	}
+	if !reflect.DeepEqual(parsedTypes, expectedTypes) {
+		t.Fatalf("expected parsed types %v, got %v", expectedTypes,
+			parsedTypes)
+	}
+}
+
+// TestExtraOpaqueDataUnknownRequired tests that extracting records from extra
+// data which contains a required record unknown to the caller fails.
+func TestExtraOpaqueDataUnknownRequired(t *testing.T) {
+	t.Parallel()
+
+	value := uint8(1)
+
+	var extraData ExtraOpaqueData
+	err := extraData.PackRecords(tlv.MakePrimitiveRecord(4, &value))
+	if err != nil {
+		t.Fatalf("unable to pack records: %v", err)
+	}
+
+	_, err = extraData.ExtractRecords()
+	if err != tlv.ErrUnknownRequiredType(4) {
+		t.Fatalf("expected ErrUnknownRequiredType, instead got %v", err)
+	}
+}
+
+// TestExtraOpaqueDataRoundTrip tests that a message carrying extension fields
+// unknown to us is re-encoded exactly as it was received.
+func TestExtraOpaqueDataRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	msg := NewInitMessage(NewRawFeatureVector(), NewRawFeatureVector())
+	msg.ExtraData = ExtraOpaqueData{0x01, 0x02, 0xaa, 0xbb}
+
+	var b bytes.Buffer
+	if _, err := WriteMessage(&b, msg, 0); err != nil {
+		t.Fatalf("unable to write message: %v", err)
+	}
+	encoded := append([]byte(nil), b.Bytes()...)
+
+	newMsg, err := ReadMessage(&b, 0)
+	if err != nil {
+		t.Fatalf("unable to read message: %v", err)
+	}
+	if !reflect.DeepEqual(msg, newMsg) {
+		t.Fatalf("messages don't match: expected %v, got %v", msg,
+			newMsg)
+	}
+
+	var b2 bytes.Buffer
+	if _, err := WriteMessage(&b2, newMsg, 0); err != nil {
+		t.Fatalf("unable to write message: %v", err)
+	}
+	if !bytes.Equal(encoded, b2.Bytes()) {
+		t.Fatalf("re-encoded message doesn't match: expected %x, "+
+			"got %x", encoded, b2.Bytes())
+	}
+}
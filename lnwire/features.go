@@ -16,11 +16,53 @@ import (
 type FeatureBit uint16
 
 const (
+	// DataLossProtectRequired is a local feature bit that indicates that
+	// the sending peer requires the optional fields of the
+	// ChannelReestablish message, allowing either party to detect that it
+	// has lost channel state.
+	DataLossProtectRequired FeatureBit = 0
+
+	// DataLossProtectOptional is an optional local feature bit that
+	// indicates that the sending peer understands the optional fields of
+	// the ChannelReestablish message.
+	DataLossProtectOptional FeatureBit = 1
+
 	// InitialRoutingSync is a local feature bit meaning that the receiving
 	// node should send a complete dump of routing information when a new
 	// connection is established.
 	InitialRoutingSync FeatureBit = 3
 
+	// GossipQueriesRequired is a local feature bit that indicates that
+	// the sending peer requires the gossip query messages of BOLT-07,
+	// allowing the graph to be synchronized by querying for the channels
+	// that are missing, rather than receiving a full dump of the graph.
+	GossipQueriesRequired FeatureBit = 6
+
+	// GossipQueriesOptional is an optional local feature bit that
+	// indicates that the sending peer understands the gossip query
+	// messages of BOLT-07.
+	GossipQueriesOptional FeatureBit = 7
+
+	// MultiPathRequired is a global feature bit that indicates that the
+	// node requires payments to it to be able to be split across multiple
+	// routes. The node will hold the partial HTLCs of a payment until the
+	// full amount has arrived.
+	MultiPathRequired FeatureBit = 16
+
+	// MultiPathOptional is a global feature bit that indicates that the
+	// node accepts payments which have been split across multiple routes.
+	MultiPathOptional FeatureBit = 17
+
+	// KeySendRequired is a global feature bit that indicates that the node
+	// requires payments to it to carry their own preimage within the
+	// onion, rather than paying a prior invoice.
+	KeySendRequired FeatureBit = 18
+
+	// KeySendOptional is a global feature bit that indicates that the node
+	// accepts spontaneous payments which carry their own preimage within
+	// the onion.
+	KeySendOptional FeatureBit = 19
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -42,14 +84,23 @@ const (
 // not advertised to the entire network. A full description of these feature
 // bits is provided in the BOLT-09 specification.
 var LocalFeatures = map[FeatureBit]string{
-	InitialRoutingSync: "initial-routing-sync",
+	DataLossProtectRequired: "data-loss-protect",
+	DataLossProtectOptional: "data-loss-protect",
+	InitialRoutingSync:      "initial-routing-sync",
+	GossipQueriesRequired:   "gossip-queries",
+	GossipQueriesOptional:   "gossip-queries",
 }
 
 // GlobalFeatures is a mapping of known global feature bits to a descriptive
 // name. All known global feature bits must be assigned a name in this mapping.
 // Global features are those which are advertised to the entire network. A full
 // description of these feature bits is provided in the BOLT-09 specification.
-var GlobalFeatures map[FeatureBit]string
+var GlobalFeatures = map[FeatureBit]string{
+	MultiPathRequired: "multi-path-payments",
+	MultiPathOptional: "multi-path-payments",
+	KeySendRequired:   "spontaneous-payments",
+	KeySendOptional:   "spontaneous-payments",
+}
 
 // RawFeatureVector represents a set of feature bits as defined in BOLT-09.  A
 // RawFeatureVector itself just stores a set of bit flags but can be used to
@@ -160,6 +211,60 @@ func (fv *RawFeatureVector) Decode(r io.Reader) error {
 	return nil
 }
 
+// SerializeSizeBase32 returns the number of 5-bit groups needed to represent
+// the feature vector in base32 format.
+func (fv *RawFeatureVector) SerializeSizeBase32() int {
+	// Find the largest feature bit index
+	max := -1
+	for feature := range fv.features {
+		index := int(feature)
+		if index > max {
+			max = index
+		}
+	}
+	if max == -1 {
+		return 0
+	}
+
+	return max/5 + 1
+}
+
+// EncodeBase32 writes the feature vector in base32 representation, as used
+// within payment requests. Each byte written carries a single 5-bit group,
+// and the bit vector is serialized using the least number of groups, with the
+// group holding the lowest feature bits written last.
+func (fv *RawFeatureVector) EncodeBase32(w io.Writer) error {
+	length := fv.SerializeSizeBase32()
+	data := make([]byte, length)
+	for feature := range fv.features {
+		groupIndex := int(feature / 5)
+		bitIndex := feature % 5
+		data[length-groupIndex-1] |= 1 << bitIndex
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// DecodeBase32 reads a feature vector of the given number of 5-bit groups
+// from its base32 representation, as written by EncodeBase32.
+func (fv *RawFeatureVector) DecodeBase32(r io.Reader, length int) error {
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return err
+	}
+
+	for i := 0; i < length*5; i++ {
+		groupIndex := i / 5
+		bitIndex := uint(i % 5)
+		if (data[length-groupIndex-1]>>bitIndex)&1 == 1 {
+			fv.Set(FeatureBit(i))
+		}
+	}
+
+	return nil
+}
+
 // FeatureVector represents a set of enabled features. The set stores
 // information on enabled flags and metadata about the feature names. A feature
 // vector is serializable to a compact byte representation that is included in
@@ -158,6 +158,59 @@ func TestFeatureVectorEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestFeatureVectorEncodeDecodeBase32(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		bits            []FeatureBit
+		expectedEncoded []byte
+	}{
+		{
+			bits:            nil,
+			expectedEncoded: []byte{},
+		},
+		{
+			bits:            []FeatureBit{2, 3, 4},
+			expectedEncoded: []byte{0x1C},
+		},
+		{
+			bits:            []FeatureBit{1, 17},
+			expectedEncoded: []byte{0x04, 0x00, 0x00, 0x02},
+		},
+	}
+
+	for i, test := range tests {
+		fv := NewRawFeatureVector(test.bits...)
+
+		// Test that EncodeBase32 produces the correct serialization.
+		buffer := new(bytes.Buffer)
+		if err := fv.EncodeBase32(buffer); err != nil {
+			t.Errorf("Failed to encode feature vector in case %d: %v", i, err)
+			continue
+		}
+
+		encoded := buffer.Bytes()
+		if !bytes.Equal(encoded, test.expectedEncoded) {
+			t.Errorf("Wrong encoding in case %d: got %v, expected %v",
+				i, encoded, test.expectedEncoded)
+			continue
+		}
+
+		// Test that decoding yields the same set of feature bits.
+		fv2 := NewRawFeatureVector()
+		err := fv2.DecodeBase32(bytes.NewReader(encoded), len(encoded))
+		if err != nil {
+			t.Errorf("Failed to decode feature vector in case %d: %v", i, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(fv, fv2) {
+			t.Errorf("Wrong decoding in case %d: got %v, expected %v",
+				i, fv2.features, fv.features)
+		}
+	}
+}
+
 func TestFeatureVectorUnknownFeatures(t *testing.T) {
 	t.Parallel()
 
@@ -14,6 +14,11 @@ type Init struct {
 	// LocalFeatures is feature vector which only affect the protocol
 	// between two nodes.
 	LocalFeatures *RawFeatureVector
+
+	// ExtraData is the set of data that was appended to this message to
+	// fill out the full maximum transport message size. These fields can
+	// be used to specify optional data such as custom TLV fields.
+	ExtraData ExtraOpaqueData
 }
 
 // NewInitMessage creates new instance of init message object.
@@ -36,6 +41,7 @@ func (msg *Init) Decode(r io.Reader, pver uint32) error {
 	return readElements(r,
 		&msg.GlobalFeatures,
 		&msg.LocalFeatures,
+		&msg.ExtraData,
 	)
 }
 
@@ -47,6 +53,7 @@ func (msg *Init) Encode(w io.Writer, pver uint32) error {
 	return writeElements(w,
 		msg.GlobalFeatures,
 		msg.LocalFeatures,
+		msg.ExtraData,
 	)
 }
 
@@ -63,5 +70,5 @@ func (msg *Init) MsgType() MessageType {
 //
 // This is part of the lnwire.Message interface.
 func (msg *Init) MaxPayloadLength(uint32) uint32 {
-	return 2 + maxAllowedSize + 2 + maxAllowedSize
+	return MaxMessagePayload
 }
@@ -11,6 +11,7 @@ import (
 	"net"
 
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -300,7 +301,6 @@ func writeElement(w io.Writer, element interface{}) error {
 			return fmt.Errorf("cannot write nil TCPAddr")
 		}
 
-		// TODO(roasbeef): account for onion types too
 		if e.IP.To4() != nil {
 			var descriptor [1]byte
 			descriptor[0] = uint8(tcp4Addr)
@@ -331,6 +331,42 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case *tor.OnionAddr:
+		if e == nil {
+			return fmt.Errorf("cannot write nil onion address")
+		}
+
+		// The onion service is encoded as the raw bytes of its base32
+		// decoded host, so we'll determine its type from its length.
+		var descriptor [1]byte
+		host := e.OnionService
+		switch len(host) {
+		case tor.V2Len:
+			descriptor[0] = uint8(v2OnionAddr)
+		case tor.V3Len:
+			descriptor[0] = uint8(v3OnionAddr)
+		default:
+			return fmt.Errorf("invalid onion service %v", host)
+		}
+		if _, err := w.Write(descriptor[:]); err != nil {
+			return err
+		}
+
+		host = host[:len(host)-tor.OnionSuffixLen]
+		service, err := tor.Base32Encoding.DecodeString(host)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(service); err != nil {
+			return err
+		}
+
+		var port [2]byte
+		binary.BigEndian.PutUint16(port[:], uint16(e.Port))
+		if _, err := w.Write(port[:]); err != nil {
+			return err
+		}
+
 	case []net.Addr:
 		// First, we'll encode all the addresses into an intermediate
 		// buffer. We need to do this in order to compute the total
@@ -371,6 +407,9 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case ExtraOpaqueData:
+		return e.Encode(w)
+
 	default:
 		return fmt.Errorf("Unknown type in writeElement: %T", e)
 	}
@@ -635,7 +674,7 @@ func readElement(r io.Reader, element interface{}) error {
 
 			addrBytesRead++
 
-			address := &net.TCPAddr{}
+			var address net.Addr
 			aType := addressType(descriptor[0])
 			switch aType {
 
@@ -648,14 +687,16 @@ func readElement(r io.Reader, element interface{}) error {
 				if _, err = io.ReadFull(addrBuf, ip[:]); err != nil {
 					return err
 				}
-				address.IP = (net.IP)(ip[:])
 
 				var port [2]byte
 				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
 					return err
 				}
 
-				address.Port = int(binary.BigEndian.Uint16(port[:]))
+				address = &net.TCPAddr{
+					IP:   (net.IP)(ip[:]),
+					Port: int(binary.BigEndian.Uint16(port[:])),
+				}
 
 				addrBytesRead += aType.AddrLen()
 
@@ -664,23 +705,36 @@ func readElement(r io.Reader, element interface{}) error {
 				if _, err = io.ReadFull(addrBuf, ip[:]); err != nil {
 					return err
 				}
-				address.IP = (net.IP)(ip[:])
 
 				var port [2]byte
 				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
 					return err
 				}
-				address.Port = int(binary.BigEndian.Uint16(port[:]))
+
+				address = &net.TCPAddr{
+					IP:   (net.IP)(ip[:]),
+					Port: int(binary.BigEndian.Uint16(port[:])),
+				}
 
 				addrBytesRead += aType.AddrLen()
 
 			case v2OnionAddr:
+				var service [tor.V2DecodedLen]byte
+				address, err = readOnionAddr(addrBuf, service[:])
+				if err != nil {
+					return err
+				}
+
 				addrBytesRead += aType.AddrLen()
-				continue
 
 			case v3OnionAddr:
+				var service [tor.V3DecodedLen]byte
+				address, err = readOnionAddr(addrBuf, service[:])
+				if err != nil {
+					return err
+				}
+
 				addrBytesRead += aType.AddrLen()
-				continue
 
 			default:
 				return fmt.Errorf("unknown address type: %v", aType)
@@ -714,6 +768,8 @@ func readElement(r io.Reader, element interface{}) error {
 			return err
 		}
 		*e = addrBytes[:length]
+	case *ExtraOpaqueData:
+		return e.Decode(r)
 	default:
 		return fmt.Errorf("Unknown type in readElement: %T", e)
 	}
@@ -733,3 +789,24 @@ func readElements(r io.Reader, elements ...interface{}) error {
 	}
 	return nil
 }
+
+// readOnionAddr reads an onion address whose service is encoded as raw bytes of
+// the same length as the passed buffer, followed by its port.
+func readOnionAddr(r io.Reader, service []byte) (*tor.OnionAddr, error) {
+	if _, err := io.ReadFull(r, service); err != nil {
+		return nil, err
+	}
+
+	var port [2]byte
+	if _, err := io.ReadFull(r, port[:]); err != nil {
+		return nil, err
+	}
+
+	onionService := tor.Base32Encoding.EncodeToString(service)
+	onionService += tor.OnionSuffix
+
+	return &tor.OnionAddr{
+		OnionService: onionService,
+		Port:         int(binary.BigEndian.Uint16(port[:])),
+	}, nil
+}
@@ -13,6 +13,8 @@ import (
 	"testing/quick"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/tlv"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -37,10 +39,14 @@ var (
 	_, _ = testSig.R.SetString("63724406601629180062774974542967536251589935445068131219452686511677818569431", 10)
 	_, _ = testSig.S.SetString("18801056069249825825291287104931333862866033135609736119018462340006816851118", 10)
 
-	// TODO(roasbeef): randomly generate from three types of addrs
-	a1        = &net.TCPAddr{IP: (net.IP)([]byte{0x7f, 0x0, 0x0, 0x1}), Port: 8333}
-	a2, _     = net.ResolveTCPAddr("tcp", "[2001:db8:85a3:0:0:8a2e:370:7334]:80")
-	testAddrs = []net.Addr{a1, a2}
+	a1    = &net.TCPAddr{IP: (net.IP)([]byte{0x7f, 0x0, 0x0, 0x1}), Port: 8333}
+	a2, _ = net.ResolveTCPAddr("tcp", "[2001:db8:85a3:0:0:8a2e:370:7334]:80")
+	a3    = &tor.OnionAddr{OnionService: "3g2upl4pq6kufc4m.onion", Port: 9735}
+	a4    = &tor.OnionAddr{
+		OnionService: "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion",
+		Port:         80,
+	}
+	testAddrs = []net.Addr{a1, a2, a3, a4}
 )
 
 func randPubKey() (*btcec.PublicKey, error) {
@@ -52,6 +58,20 @@ func randPubKey() (*btcec.PublicKey, error) {
 	return priv.PubKey(), nil
 }
 
+func randShortChanIDs(r *rand.Rand) []ShortChannelID {
+	numChanIDs := r.Intn(5000)
+	if numChanIDs == 0 {
+		return nil
+	}
+
+	shortChanIDs := make([]ShortChannelID, numChanIDs)
+	for i := range shortChanIDs {
+		shortChanIDs[i] = NewShortChanIDFromInt(uint64(r.Int63()))
+	}
+
+	return shortChanIDs
+}
+
 func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	featureVec := NewRawFeatureVector()
 	for i := 0; i < 10000; i++ {
@@ -62,6 +82,32 @@ func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	return featureVec
 }
 
+// randExtraOpaqueData returns either no extra data, or a TLV stream containing
+// a single optional record of random length.
+func randExtraOpaqueData(r *rand.Rand) (ExtraOpaqueData, error) {
+	if r.Int31n(2) == 0 {
+		return nil, nil
+	}
+
+	value := make([]byte, r.Intn(100))
+	if _, err := r.Read(value); err != nil {
+		return nil, err
+	}
+
+	// The record is given an odd type, as peers may safely ignore it.
+	recordType := tlv.Type(r.Int63()) | 1
+
+	var extraData ExtraOpaqueData
+	err := extraData.PackRecords(
+		tlv.MakePrimitiveRecord(recordType, &value),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return extraData, nil
+}
+
 func TestMaxOutPointIndex(t *testing.T) {
 	t.Parallel()
 
@@ -142,6 +188,13 @@ func TestLightningWireProtocol(t *testing.T) {
 				randRawFeatureVector(r),
 			)
 
+			var err error
+			req.ExtraData, err = randExtraOpaqueData(r)
+			if err != nil {
+				t.Fatalf("unable to generate extra data: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(*req)
 		},
 		MsgOpenChannel: func(v []reflect.Value, r *rand.Rand) {
@@ -199,6 +252,11 @@ func TestLightningWireProtocol(t *testing.T) {
 				t.Fatalf("unable to generate key: %v", err)
 				return
 			}
+			req.ExtraData, err = randExtraOpaqueData(r)
+			if err != nil {
+				t.Fatalf("unable to generate extra data: %v", err)
+				return
+			}
 
 			v[0] = reflect.ValueOf(req)
 		},
@@ -249,6 +307,11 @@ func TestLightningWireProtocol(t *testing.T) {
 				t.Fatalf("unable to generate key: %v", err)
 				return
 			}
+			req.ExtraData, err = randExtraOpaqueData(r)
+			if err != nil {
+				t.Fatalf("unable to generate extra data: %v", err)
+				return
+			}
 
 			v[0] = reflect.ValueOf(req)
 		},
@@ -477,6 +540,35 @@ func TestLightningWireProtocol(t *testing.T) {
 				}
 			}
 
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgQueryShortChanIDs: func(v []reflect.Value, r *rand.Rand) {
+			req := QueryShortChanIDs{
+				EncodingType: ShortChanIDEncoding(r.Intn(2)),
+				ShortChanIDs: randShortChanIDs(r),
+			}
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to generate chain hash: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgReplyChannelRange: func(v []reflect.Value, r *rand.Rand) {
+			req := ReplyChannelRange{
+				QueryChannelRange: QueryChannelRange{
+					FirstBlockHeight: uint32(r.Int31()),
+					NumBlocks:        uint32(r.Int31()),
+				},
+				Complete:     uint8(r.Int31()),
+				EncodingType: ShortChanIDEncoding(r.Intn(2)),
+				ShortChanIDs: randShortChanIDs(r),
+			}
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to generate chain hash: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 	}
@@ -629,6 +721,36 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgQueryShortChanIDs,
+			scenario: func(m QueryShortChanIDs) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyShortChanIDsEnd,
+			scenario: func(m ReplyShortChanIDsEnd) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgQueryChannelRange,
+			scenario: func(m QueryChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyChannelRange,
+			scenario: func(m ReplyChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgGossipTimestampRange,
+			scenario: func(m GossipTimestampRange) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
@@ -11,7 +11,8 @@ import (
 // NetAddress represents information pertaining to the identity and network
 // reachability of a peer. Information stored includes the node's identity
 // public key for establishing a confidential+authenticated connection, the
-// service bits it supports, and a TCP address the node is reachable at.
+// service bits it supports, and a TCP or onion address the node is reachable
+// at.
 //
 // TODO(roasbeef): merge with LinkNode in some fashion
 type NetAddress struct {
@@ -22,8 +23,9 @@ type NetAddress struct {
 	// the node.
 	IdentityKey *btcec.PublicKey
 
-	// Address is is the IP address and port of the node.
-	Address *net.TCPAddr
+	// Address is the IP address or onion service, along with the port of
+	// the node.
+	Address net.Addr
 
 	// ChainNet is the Bitcoin network this node is associated with.
 	// TODO(roasbeef): make a slice in the future for multi-chain
@@ -76,6 +76,7 @@ const (
 	CodeFinalExpiryTooSoon            FailCode = 17
 	CodeFinalIncorrectCltvExpiry      FailCode = 18
 	CodeFinalIncorrectHtlcAmount      FailCode = 19
+	CodeMPPTimeout                    FailCode = 23
 )
 
 // String returns the string representation of the failure code.
@@ -144,6 +145,9 @@ func (c FailCode) String() string {
 	case CodeFinalIncorrectHtlcAmount:
 		return "FinalIncorrectHtlcAmount"
 
+	case CodeMPPTimeout:
+		return "MPPTimeout"
+
 	default:
 		return "<unknown>"
 	}
@@ -990,6 +994,27 @@ func (f *FailFinalIncorrectHtlcAmount) Encode(w io.Writer, pver uint32) error {
 	return writeElement(w, f.IncomingHTLCAmount)
 }
 
+// FailMPPTimeout is returned if the complete amount of a multi-path payment
+// wasn't received within a reasonable time. All HTLCs that were held for the
+// payment are failed back with this error.
+//
+// NOTE: May only be returned by the final node in the path.
+type FailMPPTimeout struct{}
+
+// Code returns the failure unique code.
+//
+// NOTE: Part of the FailureMessage interface.
+func (f FailMPPTimeout) Code() FailCode {
+	return CodeMPPTimeout
+}
+
+// Returns a human readable string describing the target FailureMessage.
+//
+// NOTE: Implements the error interface.
+func (f FailMPPTimeout) Error() string {
+	return f.Code().String()
+}
+
 // DecodeFailure decodes, validates, and parses the lnwire onion failure, for
 // the provided protocol version.
 func DecodeFailure(r io.Reader, pver uint32) (FailureMessage, error) {
@@ -1151,6 +1176,9 @@ func makeEmptyOnionError(code FailCode) (FailureMessage, error) {
 
 	case CodeFinalIncorrectHtlcAmount:
 		return &FailFinalIncorrectHtlcAmount{}, nil
+
+	case CodeMPPTimeout:
+		return &FailMPPTimeout{}, nil
 	default:
 		return nil, errors.Errorf("unknown error code: %v", code)
 	}
@@ -0,0 +1,239 @@
+package lnwire
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// ShortChanIDEncoding is an enum-like type that represents exactly how a set
+// of short channel ID's is encoded on the wire. The set of encodings allows
+// callers to trade off the size of the encoding against the CPU required to
+// decode it.
+type ShortChanIDEncoding uint8
+
+const (
+	// EncodingSortedPlain signals that the set of short channel ID's is
+	// encoded using the regular encoding, in a sorted order.
+	EncodingSortedPlain ShortChanIDEncoding = 0
+
+	// EncodingSortedZlib signals that the set of short channel ID's is
+	// encoded by first sorting the set of channel ID's, then compressing
+	// them using zlib.
+	EncodingSortedZlib ShortChanIDEncoding = 1
+)
+
+// ErrUnknownShortChanIDEncoding is a parametrized error that indicates that
+// we came across an unknown short channel ID encoding, and therefore were
+// unable to continue parsing.
+func ErrUnknownShortChanIDEncoding(encoding ShortChanIDEncoding) error {
+	return fmt.Errorf("unknown short chan id encoding: %v", encoding)
+}
+
+// QueryShortChanIDs is a message that allows the sender to query a set of
+// channel announcement and channel update messages that correspond to the set
+// of encoded short channel ID's. The remote peer responds with the announcements
+// it knows of, followed by a ReplyShortChanIDsEnd message.
+type QueryShortChanIDs struct {
+	// ChainHash denotes the target chain that we're querying for the
+	// channel ID's of.
+	ChainHash chainhash.Hash
+
+	// EncodingType is a signal to the receiver of the message that
+	// indicates exactly how the set of short channel ID's that follow have
+	// been encoded.
+	EncodingType ShortChanIDEncoding
+
+	// ShortChanIDs is a slice of decoded short channel ID's.
+	ShortChanIDs []ShortChannelID
+}
+
+// NewQueryShortChanIDs creates a new QueryShortChanIDs message.
+func NewQueryShortChanIDs(h chainhash.Hash, e ShortChanIDEncoding,
+	s []ShortChannelID) *QueryShortChanIDs {
+
+	return &QueryShortChanIDs{
+		ChainHash:    h,
+		EncodingType: e,
+		ShortChanIDs: s,
+	}
+}
+
+// A compile time check to ensure QueryShortChanIDs implements the
+// lnwire.Message interface.
+var _ Message = (*QueryShortChanIDs)(nil)
+
+// Decode deserializes a serialized QueryShortChanIDs message stored in the
+// passed io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Decode(r io.Reader, pver uint32) error {
+	if _, err := io.ReadFull(r, q.ChainHash[:]); err != nil {
+		return err
+	}
+
+	var err error
+	q.EncodingType, q.ShortChanIDs, err = decodeShortChanIDs(r)
+	return err
+}
+
+// decodeShortChanIDs decodes a set of short channel ID's that have been
+// encoded. The first two bytes hold the length of the encoded set, which is
+// then prefixed by a byte that denotes its encoding type.
+func decodeShortChanIDs(r io.Reader) (ShortChanIDEncoding,
+	[]ShortChannelID, error) {
+
+	var numBytesResp uint16
+	if err := readElement(r, &numBytesResp); err != nil {
+		return 0, nil, err
+	}
+
+	// An empty set carries no encoding type, so we'll exit early.
+	if numBytesResp == 0 {
+		return EncodingSortedPlain, nil, nil
+	}
+
+	queryBody := make([]byte, numBytesResp)
+	if _, err := io.ReadFull(r, queryBody); err != nil {
+		return 0, nil, err
+	}
+
+	// The first byte is the encoding type, with the encoded short channel
+	// ID's making up the remainder of the body.
+	encodingType := ShortChanIDEncoding(queryBody[0])
+	queryBody = queryBody[1:]
+
+	switch encodingType {
+	case EncodingSortedPlain:
+
+	// If the ID's have been compressed, then we'll need to inflate them
+	// before we're able to parse them. The inflated size is bounded by
+	// the max message size, to protect us from decompression bombs.
+	case EncodingSortedZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(queryBody))
+		if err != nil {
+			return 0, nil, err
+		}
+		defer zr.Close()
+
+		limitedReader := io.LimitReader(zr, MaxMessagePayload)
+		queryBody, err = ioutil.ReadAll(limitedReader)
+		if err != nil {
+			return 0, nil, err
+		}
+
+	default:
+		return 0, nil, ErrUnknownShortChanIDEncoding(encodingType)
+	}
+
+	// Each short channel ID is 8 bytes, so the remaining body must be a
+	// multiple of that.
+	if len(queryBody)%8 != 0 {
+		return 0, nil, fmt.Errorf("whole number of short chan ID's "+
+			"cannot be encoded in len=%v", len(queryBody))
+	}
+
+	numShortChanIDs := len(queryBody) / 8
+	if numShortChanIDs == 0 {
+		return encodingType, nil, nil
+	}
+
+	shortChanIDs := make([]ShortChannelID, numShortChanIDs)
+	bodyReader := bytes.NewReader(queryBody)
+	for i := 0; i < numShortChanIDs; i++ {
+		if err := readElement(bodyReader, &shortChanIDs[i]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return encodingType, shortChanIDs, nil
+}
+
+// Encode serializes the target QueryShortChanIDs into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Encode(w io.Writer, pver uint32) error {
+	if _, err := w.Write(q.ChainHash[:]); err != nil {
+		return err
+	}
+
+	return encodeShortChanIDs(w, q.EncodingType, q.ShortChanIDs)
+}
+
+// encodeShortChanIDs sorts the passed short channel ID's, then encodes them
+// using the target encoding type, prefixed by the length of the encoded set.
+func encodeShortChanIDs(w io.Writer, encodingType ShortChanIDEncoding,
+	shortChanIDs []ShortChannelID) error {
+
+	// The receiver expects the set of ID's to be sorted, so we'll sort
+	// them in place before encoding.
+	sort.Slice(shortChanIDs, func(i, j int) bool {
+		return shortChanIDs[i].ToUint64() < shortChanIDs[j].ToUint64()
+	})
+
+	var body bytes.Buffer
+	switch encodingType {
+	case EncodingSortedPlain:
+		for _, chanID := range shortChanIDs {
+			if err := writeElement(&body, chanID); err != nil {
+				return err
+			}
+		}
+
+	case EncodingSortedZlib:
+		zw := zlib.NewWriter(&body)
+		for _, chanID := range shortChanIDs {
+			if err := writeElement(zw, chanID); err != nil {
+				return err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+	default:
+		return ErrUnknownShortChanIDEncoding(encodingType)
+	}
+
+	// The encoded set is made up of the encoding type, followed by the
+	// encoded body, so we'll account for the type byte in the length.
+	numBytesBody := body.Len() + 1
+	if numBytesBody > MaxMessagePayload {
+		return fmt.Errorf("encoded short chan ID's of len=%v exceed "+
+			"max message size", numBytesBody)
+	}
+
+	var header [3]byte
+	binary.BigEndian.PutUint16(header[:2], uint16(numBytesBody))
+	header[2] = uint8(encodingType)
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(body.Bytes())
+	return err
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MsgType() MessageType {
+	return MsgQueryShortChanIDs
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// QueryShortChanIDs complete message observing the specified protocol
+// version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
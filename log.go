@@ -13,11 +13,15 @@ import (
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/remotesigner"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/roasbeef/btcd/connmgr"
 )
 
@@ -70,6 +74,12 @@ var (
 	crtrLog = backendLog.Logger("CRTR")
 	btcnLog = backendLog.Logger("BTCN")
 	atplLog = backendLog.Logger("ATPL")
+	cnctLog = backendLog.Logger("CNCT")
+	wtwrLog = backendLog.Logger("WTWR")
+	chbuLog = backendLog.Logger("CHBU")
+	swprLog = backendLog.Logger("SWPR")
+	cpfpLog = backendLog.Logger("CPFP")
+	rsgnLog = backendLog.Logger("RSGN")
 )
 
 // Initialize package-global logger variables.
@@ -83,6 +93,10 @@ func init() {
 	routing.UseLogger(crtrLog)
 	neutrino.UseLogger(btcnLog)
 	autopilot.UseLogger(atplLog)
+	contractcourt.UseLogger(cnctLog)
+	watchtower.UseLogger(wtwrLog)
+	chanbackup.UseLogger(chbuLog)
+	remotesigner.UseLogger(rsgnLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -103,6 +117,12 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"CRTR": crtrLog,
 	"BTCN": btcnLog,
 	"ATPL": atplLog,
+	"CNCT": cnctLog,
+	"WTWR": wtwrLog,
+	"CHBU": chbuLog,
+	"SWPR": swprLog,
+	"CPFP": cpfpLog,
+	"RSGN": rsgnLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
@@ -3,12 +3,31 @@ package main
 import (
 	"fmt"
 
+	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 )
 
-// nodeSigner is an implementation of the MessageSigner interface backed by the
+// nodeKeyRing abstracts all operations with the identity private key of the
+// running lnd node. This allows the key to be held either by the node itself,
+// or by a remote signer.
+type nodeKeyRing interface {
+	brontide.SingleKeyECDH
+	lnwallet.MessageSigner
+
+	// SignCompact signs a double-sha256 digest of the msg parameter under
+	// the identity key. The returned signature is a pubkey-recoverable
+	// signature.
+	SignCompact(msg []byte) ([]byte, error)
+
+	// SignDigestCompact signs the provided message digest under the
+	// identity key. The returned signature is a pubkey-recoverable
+	// signature.
+	SignDigestCompact(hash []byte) ([]byte, error)
+}
+
+// nodeSigner is an implementation of the nodeKeyRing interface backed by the
 // identity private key of running lnd node.
 type nodeSigner struct {
 	privKey *btcec.PrivateKey
@@ -27,6 +46,21 @@ func newNodeSigner(key *btcec.PrivateKey) *nodeSigner {
 	}
 }
 
+// PubKey returns the identity public key of the node.
+//
+// NOTE: Part of the brontide.SingleKeyECDH interface.
+func (n *nodeSigner) PubKey() *btcec.PublicKey {
+	return n.privKey.PubKey()
+}
+
+// ECDH performs an ECDH operation between the passed public key and the
+// node's identity private key.
+//
+// NOTE: Part of the brontide.SingleKeyECDH interface.
+func (n *nodeSigner) ECDH(pubKey *btcec.PublicKey) ([32]byte, error) {
+	return (&brontide.PrivKeyECDH{PrivKey: n.privKey}).ECDH(pubKey)
+}
+
 // SignMessage signs a double-sha256 digest of the passed msg under the
 // resident node's private key. If the target public key is _not_ the node's
 // private key, then an error will be returned.
@@ -76,6 +110,6 @@ func (n *nodeSigner) SignDigestCompact(hash []byte) ([]byte, error) {
 	return sig, nil
 }
 
-// A compile time check to ensure that nodeSigner implements the MessageSigner
+// A compile time check to ensure that nodeSigner implements the nodeKeyRing
 // interface.
-var _ lnwallet.MessageSigner = (*nodeSigner)(nil)
+var _ nodeKeyRing = (*nodeSigner)(nil)
@@ -344,7 +344,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 		// TODO(roasbeef): can add helper method to get policy for
 		// particular channel.
 		var selfPolicy *channeldb.ChannelEdgePolicy
-		if info != nil && info.NodeKey1.IsEqual(p.server.identityPriv.PubKey()) {
+		if info != nil && info.NodeKey1.IsEqual(p.server.identityKey.PubKey()) {
 			selfPolicy = p1
 		} else {
 			selfPolicy = p2
@@ -379,7 +379,10 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			SettledContracts: p.server.breachArbiter.settledContracts,
 			DebugHTLC:        cfg.DebugHTLC,
 			HodlHTLC:         cfg.HodlHTLC,
+			AcceptKeySend:    cfg.AcceptKeySend,
 			Registry:         p.server.invoices,
+			PreimageCache:    p.server.chanDB,
+			TowerClient:      p.server.justiceBackup,
 			Switch:           p.server.htlcSwitch,
 			FwrdingPolicy:    *forwardingPolicy,
 			FeeEstimator:     p.server.cc.feeEstimator,
@@ -720,6 +723,18 @@ out:
 			}
 
 		case *lnwire.Error:
+			// If the error references one of our active channels,
+			// then the remote party has failed the channel, so
+			// we'll force close it. Otherwise, the error relates
+			// to a pending channel within the funding manager.
+			p.activeChanMtx.RLock()
+			channel, ok := p.activeChannels[msg.ChanID]
+			p.activeChanMtx.RUnlock()
+			if ok {
+				go p.failActiveChannel(channel, msg)
+				break
+			}
+
 			p.server.fundingMgr.processFundingError(msg, p.addr)
 
 		// TODO(roasbeef): create ChanUpdater interface for the below
@@ -745,13 +760,27 @@ out:
 			isChanUpdate = true
 			targetChan = msg.ChanID
 		case *lnwire.ChannelReestablish:
+			// If this channel was restored from a static backup,
+			// then there's no link to deliver the message to, so
+			// we'll hand it to the channel restorer instead.
+			peerPub := p.addr.IdentityKey
+			restorer := p.server.chanRestorer
+			if restorer.ProcessChanReestablish(msg, peerPub) {
+				break
+			}
+
 			isChanUpdate = true
 			targetChan = msg.ChanID
 
 		case *lnwire.ChannelUpdate,
 			*lnwire.ChannelAnnouncement,
 			*lnwire.NodeAnnouncement,
-			*lnwire.AnnounceSignatures:
+			*lnwire.AnnounceSignatures,
+			*lnwire.GossipTimestampRange,
+			*lnwire.QueryShortChanIDs,
+			*lnwire.QueryChannelRange,
+			*lnwire.ReplyChannelRange,
+			*lnwire.ReplyShortChanIDsEnd:
 
 			discStream.AddMsg(msg)
 
@@ -1262,7 +1291,10 @@ out:
 				SettledContracts: p.server.breachArbiter.settledContracts,
 				DebugHTLC:        cfg.DebugHTLC,
 				HodlHTLC:         cfg.HodlHTLC,
+				AcceptKeySend:    cfg.AcceptKeySend,
 				Registry:         p.server.invoices,
+				PreimageCache:    p.server.chanDB,
+				TowerClient:      p.server.justiceBackup,
 				Switch:           p.server.htlcSwitch,
 				FwrdingPolicy:    p.server.cc.routingPolicy,
 				FeeEstimator:     p.server.cc.feeEstimator,
@@ -1536,6 +1568,10 @@ func (p *peer) finalizeChanClosure(chanCloser *channelCloser) {
 	chanCloser.cfg.channel.Stop()
 	chanCloser.cfg.channel.CancelObserver()
 
+	// As the channel's state has been deleted from disk, we'll now update
+	// the channel backup to no longer include it.
+	p.server.chanSubSwapper.ChannelsChanged()
+
 	// Next, we'll launch a goroutine which will request to be notified by
 	// the ChainNotifier once the closure
 	// transaction obtains a single confirmation.
@@ -1640,6 +1676,28 @@ func waitForChanToClose(bestHeight uint32, notifier chainntnfs.ChainNotifier,
 	cb()
 }
 
+// failActiveChannel force closes an active channel after the remote party sent
+// an error referencing it. This is the case when the remote party has lost
+// its state and restored the channel from a static backup, and is now unable
+// to close the channel itself.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *peer) failActiveChannel(channel *lnwallet.LightningChannel,
+	msg *lnwire.Error) {
+
+	chanPoint := *channel.ChannelPoint()
+
+	peerLog.Warnf("ChannelPoint(%v) failed by peer %v: %v, force closing",
+		chanPoint, p, string(msg.Data))
+
+	// The chain arbitrator will remove the channel from our indexes and
+	// the switch before broadcasting our latest commitment transaction.
+	if _, err := p.server.chainArb.ForceCloseContract(chanPoint); err != nil {
+		peerLog.Errorf("unable to force close ChannelPoint(%v): %v",
+			chanPoint, err)
+	}
+}
+
 // WipeChannel removes the passed channel point from all indexes associated
 // with the peer, and the switch.
 func (p *peer) WipeChannel(chanPoint *wire.OutPoint) error {
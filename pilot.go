@@ -7,6 +7,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -49,18 +50,28 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 		// advertised IP addresses, or have made a connection.
 		var connected bool
 		for _, addr := range addrs {
+			switch a := addr.(type) {
 			// If the address doesn't already have a port, then
 			// we'll assume the current default port.
-			tcpAddr, ok := addr.(*net.TCPAddr)
-			if !ok {
-				return fmt.Errorf("TCP address required instead "+
-					"have %T", addr)
-			}
-			if tcpAddr.Port == 0 {
-				tcpAddr.Port = defaultPeerPort
+			case *net.TCPAddr:
+				if a.Port == 0 {
+					a.Port = defaultPeerPort
+				}
+
+			// Onion services can only be reached if our
+			// connections are routed through Tor, so we'll skip
+			// them otherwise.
+			case *tor.OnionAddr:
+				if !cfg.Tor.Active {
+					continue
+				}
+
+			default:
+				return fmt.Errorf("TCP or onion address "+
+					"required instead have %T", addr)
 			}
 
-			lnAddr.Address = tcpAddr
+			lnAddr.Address = addr
 
 			// TODO(roasbeef): make perm connection in server after
 			// chan open?
@@ -90,7 +101,7 @@ func (c *chanController) OpenChannel(target *btcec.PublicKey,
 		return err
 	}
 	updateStream, errChan := c.server.OpenChannel(-1, target, amt, 0,
-		feePerWeight, false)
+		feePerWeight, false, nil, false)
 
 	select {
 	case err := <-errChan:
@@ -136,7 +147,7 @@ func initAutoPilot(svr *server, cfg *autoPilotConfig) (*autopilot.Agent, error)
 
 	// With the heuristic itself created, we can now populate the remainder
 	// of the items that the autopilot agent needs to perform its duties.
-	self := svr.identityPriv.PubKey()
+	self := svr.identityKey.PubKey()
 	pilotCfg := autopilot.Config{
 		Self:           self,
 		Heuristic:      prefAttachment,
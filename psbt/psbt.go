@@ -0,0 +1,496 @@
+// Package psbt implements the subset of BIP-174 partially signed bitcoin
+// transactions needed to hand a transaction template to an external wallet,
+// and to extract the final transaction once that wallet has funded and signed
+// it. Fields that aren't interpreted are preserved verbatim, such that a
+// packet survives a parse and serialize round trip.
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// magic is the prefix of every serialized packet: the bytes "psbt" followed by
+// the 0xff separator.
+var magic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+const (
+	// globalUnsignedTxType is the key type of the unsigned transaction
+	// within the global map.
+	globalUnsignedTxType = 0x00
+
+	// inNonWitnessUtxoType is the key type of the full transaction that
+	// created an input.
+	inNonWitnessUtxoType = 0x00
+
+	// inWitnessUtxoType is the key type of the output spent by a witness
+	// input.
+	inWitnessUtxoType = 0x01
+
+	// inFinalScriptSigType is the key type of the finalized scriptSig of
+	// an input.
+	inFinalScriptSigType = 0x07
+
+	// inFinalScriptWitnessType is the key type of the finalized witness
+	// of an input.
+	inFinalScriptWitnessType = 0x08
+
+	// maxPsbtSize is the maximum size of a packet we're willing to parse.
+	maxPsbtSize = 4000000
+
+	// maxWitnessItems is the maximum number of items of a final witness
+	// we're willing to parse.
+	maxWitnessItems = 500
+)
+
+var (
+	// ErrInvalidMagic is returned when a packet doesn't begin with the
+	// psbt magic bytes.
+	ErrInvalidMagic = errors.New("invalid psbt magic bytes")
+
+	// ErrDuplicateKey is returned when a key appears more than once
+	// within a single map of a packet.
+	ErrDuplicateKey = errors.New("duplicate key within psbt map")
+
+	// ErrSignedUnsignedTx is returned when the unsigned transaction of a
+	// packet carries scriptSigs or witnesses.
+	ErrSignedUnsignedTx = errors.New("unsigned transaction of psbt " +
+		"has signatures")
+
+	// ErrIncompletePSBT is returned when extracting the final transaction
+	// from a packet whose inputs aren't all finalized.
+	ErrIncompletePSBT = errors.New("psbt has inputs that aren't finalized")
+)
+
+// Unknown is a key-value pair of a map that isn't interpreted by this
+// package. The key includes the leading key type byte.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PInput holds the fields of the map of a single input of a packet.
+type PInput struct {
+	// NonWitnessUtxo is the full transaction that created the output
+	// spent by this input, if known.
+	NonWitnessUtxo *wire.MsgTx
+
+	// WitnessUtxo is the output spent by this input, if it's a witness
+	// input.
+	WitnessUtxo *wire.TxOut
+
+	// FinalScriptSig is the finalized scriptSig of this input.
+	FinalScriptSig []byte
+
+	// FinalScriptWitness is the finalized witness of this input.
+	FinalScriptWitness wire.TxWitness
+
+	// Unknowns are all other fields of this input.
+	Unknowns []*Unknown
+}
+
+// IsFinalized returns true if the input carries a final scriptSig or witness.
+func (pi *PInput) IsFinalized() bool {
+	return len(pi.FinalScriptSig) != 0 || len(pi.FinalScriptWitness) != 0
+}
+
+// POutput holds the fields of the map of a single output of a packet. None of
+// them are interpreted.
+type POutput struct {
+	// Unknowns are all fields of this output.
+	Unknowns []*Unknown
+}
+
+// Packet is a partially signed bitcoin transaction.
+type Packet struct {
+	// UnsignedTx is the transaction being signed, with all scriptSigs and
+	// witnesses empty.
+	UnsignedTx *wire.MsgTx
+
+	// Inputs holds a map for each input of the unsigned transaction.
+	Inputs []PInput
+
+	// Outputs holds a map for each output of the unsigned transaction.
+	Outputs []POutput
+
+	// Unknowns are all global fields other than the unsigned transaction.
+	Unknowns []*Unknown
+}
+
+// NewFromUnsignedTx creates a packet for the passed unsigned transaction,
+// with empty maps for all its inputs and outputs.
+func NewFromUnsignedTx(tx *wire.MsgTx) (*Packet, error) {
+	for _, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return nil, ErrSignedUnsignedTx
+		}
+	}
+
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// Parse decodes a packet from its binary serialization.
+func Parse(r io.Reader) (*Packet, error) {
+	var m [5]byte
+	if _, err := io.ReadFull(r, m[:]); err != nil {
+		return nil, err
+	}
+	if m != magic {
+		return nil, ErrInvalidMagic
+	}
+
+	// The global map must include the unsigned transaction, which
+	// determines the number of input and output maps that follow.
+	globals, err := readMap(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Packet
+	for _, kv := range globals {
+		if kv.Key[0] != globalUnsignedTxType {
+			p.Unknowns = append(p.Unknowns, kv)
+			continue
+		}
+		if len(kv.Key) != 1 {
+			return nil, fmt.Errorf("invalid unsigned tx key")
+		}
+
+		tx := wire.NewMsgTx(2)
+		err := tx.DeserializeNoWitness(bytes.NewReader(kv.Value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned tx: %v", err)
+		}
+		p.UnsignedTx = tx
+	}
+	if p.UnsignedTx == nil {
+		return nil, fmt.Errorf("psbt is missing the unsigned tx")
+	}
+	for _, txIn := range p.UnsignedTx.TxIn {
+		if len(txIn.SignatureScript) != 0 {
+			return nil, ErrSignedUnsignedTx
+		}
+		txIn.SignatureScript = nil
+	}
+
+	p.Inputs = make([]PInput, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		kvs, err := readMap(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Inputs[i].decode(kvs); err != nil {
+			return nil, fmt.Errorf("invalid input %d: %v", i, err)
+		}
+	}
+
+	p.Outputs = make([]POutput, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		kvs, err := readMap(r)
+		if err != nil {
+			return nil, err
+		}
+		p.Outputs[i].Unknowns = kvs
+	}
+
+	return &p, nil
+}
+
+// ParseBase64 decodes a packet from its base64 encoded serialization.
+func ParseBase64(s string) (*Packet, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(b))
+}
+
+// Serialize writes the binary serialization of the packet to w.
+func (p *Packet) Serialize(w io.Writer) error {
+	if len(p.Inputs) != len(p.UnsignedTx.TxIn) ||
+		len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+
+		return fmt.Errorf("psbt maps don't match the unsigned tx")
+	}
+
+	if _, err := w.Write(magic[:]); err != nil {
+		return err
+	}
+
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		return err
+	}
+	globals := append([]*Unknown{{
+		Key:   []byte{globalUnsignedTxType},
+		Value: tx.Bytes(),
+	}}, p.Unknowns...)
+	if err := writeMap(w, globals); err != nil {
+		return err
+	}
+
+	for i := range p.Inputs {
+		kvs, err := p.Inputs[i].encode()
+		if err != nil {
+			return err
+		}
+		if err := writeMap(w, kvs); err != nil {
+			return err
+		}
+	}
+
+	for _, output := range p.Outputs {
+		if err := writeMap(w, output.Unknowns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// B64Encode returns the base64 encoded serialization of the packet.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// IsComplete returns true if all inputs of the packet are finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].IsFinalized() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Extract returns the final, fully signed transaction of a complete packet.
+func (p *Packet) Extract() (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncompletePSBT
+	}
+
+	tx := p.UnsignedTx.Copy()
+	for i, txIn := range tx.TxIn {
+		txIn.SignatureScript = p.Inputs[i].FinalScriptSig
+		txIn.Witness = p.Inputs[i].FinalScriptWitness
+	}
+
+	return tx, nil
+}
+
+// decode populates the input from the key-value pairs of its map.
+func (pi *PInput) decode(kvs []*Unknown) error {
+	for _, kv := range kvs {
+		keyType := kv.Key[0]
+		switch keyType {
+		case inNonWitnessUtxoType, inWitnessUtxoType,
+			inFinalScriptSigType, inFinalScriptWitnessType:
+
+			if len(kv.Key) != 1 {
+				return fmt.Errorf("invalid key of type %d",
+					keyType)
+			}
+
+		default:
+			pi.Unknowns = append(pi.Unknowns, kv)
+			continue
+		}
+
+		r := bytes.NewReader(kv.Value)
+		switch keyType {
+		case inNonWitnessUtxoType:
+			tx := wire.NewMsgTx(2)
+			if err := tx.Deserialize(r); err != nil {
+				return err
+			}
+			pi.NonWitnessUtxo = tx
+
+		case inWitnessUtxoType:
+			txOut, err := readTxOut(r)
+			if err != nil {
+				return err
+			}
+			pi.WitnessUtxo = txOut
+
+		case inFinalScriptSigType:
+			pi.FinalScriptSig = kv.Value
+
+		case inFinalScriptWitnessType:
+			witness, err := readWitness(r)
+			if err != nil {
+				return err
+			}
+			pi.FinalScriptWitness = witness
+		}
+	}
+
+	return nil
+}
+
+// encode returns the key-value pairs of the map of the input.
+func (pi *PInput) encode() ([]*Unknown, error) {
+	var kvs []*Unknown
+
+	if pi.NonWitnessUtxo != nil {
+		var b bytes.Buffer
+		if err := pi.NonWitnessUtxo.Serialize(&b); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &Unknown{
+			Key:   []byte{inNonWitnessUtxoType},
+			Value: b.Bytes(),
+		})
+	}
+
+	if pi.WitnessUtxo != nil {
+		var b bytes.Buffer
+		if err := wire.WriteTxOut(&b, 0, 0, pi.WitnessUtxo); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &Unknown{
+			Key:   []byte{inWitnessUtxoType},
+			Value: b.Bytes(),
+		})
+	}
+
+	if len(pi.FinalScriptSig) != 0 {
+		kvs = append(kvs, &Unknown{
+			Key:   []byte{inFinalScriptSigType},
+			Value: pi.FinalScriptSig,
+		})
+	}
+
+	if len(pi.FinalScriptWitness) != 0 {
+		var b bytes.Buffer
+		if err := writeWitness(&b, pi.FinalScriptWitness); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &Unknown{
+			Key:   []byte{inFinalScriptWitnessType},
+			Value: b.Bytes(),
+		})
+	}
+
+	return append(kvs, pi.Unknowns...), nil
+}
+
+// readMap reads the key-value pairs of a single map, up to and including its
+// terminating separator.
+func readMap(r io.Reader) ([]*Unknown, error) {
+	var (
+		kvs  []*Unknown
+		keys = make(map[string]struct{})
+	)
+	for {
+		key, err := wire.ReadVarBytes(r, 0, maxPsbtSize, "psbt key")
+		if err != nil {
+			return nil, err
+		}
+
+		// A zero length key marks the end of the map.
+		if len(key) == 0 {
+			return kvs, nil
+		}
+
+		if _, ok := keys[string(key)]; ok {
+			return nil, ErrDuplicateKey
+		}
+		keys[string(key)] = struct{}{}
+
+		value, err := wire.ReadVarBytes(r, 0, maxPsbtSize, "psbt value")
+		if err != nil {
+			return nil, err
+		}
+
+		kvs = append(kvs, &Unknown{Key: key, Value: value})
+	}
+}
+
+// writeMap writes the passed key-value pairs as a single map, followed by its
+// terminating separator.
+func writeMap(w io.Writer, kvs []*Unknown) error {
+	for _, kv := range kvs {
+		if err := wire.WriteVarBytes(w, 0, kv.Key); err != nil {
+			return err
+		}
+		if err := wire.WriteVarBytes(w, 0, kv.Value); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0x00})
+	return err
+}
+
+// readTxOut decodes an output as serialized within transactions.
+func readTxOut(r io.Reader) (*wire.TxOut, error) {
+	var value [8]byte
+	if _, err := io.ReadFull(r, value[:]); err != nil {
+		return nil, err
+	}
+
+	pkScript, err := wire.ReadVarBytes(r, 0, maxPsbtSize, "pkScript")
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.NewTxOut(
+		int64(binary.LittleEndian.Uint64(value[:])), pkScript,
+	), nil
+}
+
+// readWitness decodes a witness stack as serialized within transactions.
+func readWitness(r io.Reader) (wire.TxWitness, error) {
+	numItems, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numItems > maxWitnessItems {
+		return nil, fmt.Errorf("too many witness items: %d", numItems)
+	}
+
+	witness := make(wire.TxWitness, 0, numItems)
+	for i := uint64(0); i < numItems; i++ {
+		item, err := wire.ReadVarBytes(
+			r, 0, maxPsbtSize, "witness item",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		witness = append(witness, item)
+	}
+
+	return witness, nil
+}
+
+// writeWitness encodes a witness stack as serialized within transactions.
+func writeWitness(w io.Writer, witness wire.TxWitness) error {
+	if err := wire.WriteVarInt(w, 0, uint64(len(witness))); err != nil {
+		return err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(w, 0, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
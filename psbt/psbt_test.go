@@ -0,0 +1,238 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	testPkScript = []byte{
+		0x00, 0x20, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12,
+		0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c,
+		0x1d, 0x1e, 0x1f, 0x20,
+	}
+
+	testPrevHash = chainhash.Hash{
+		0x51, 0xb4, 0xbc, 0xd2, 0x34, 0xe0, 0xa7, 0x4f,
+		0x1b, 0x31, 0xa9, 0x0c, 0x71, 0x47, 0x20, 0x96,
+		0x3e, 0x39, 0x8f, 0x44, 0x13, 0xaf, 0x5e, 0x21,
+		0x93, 0x20, 0x72, 0x2b, 0x3b, 0xd8, 0xf4, 0x1b,
+	}
+)
+
+// makeTemplate returns a transaction template that only pays to a single
+// output, as handed to an external wallet for funding.
+func makeTemplate() *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxOut(wire.NewTxOut(100000, testPkScript))
+
+	return tx
+}
+
+// fundTemplate adds a finalized input and a change output to the packet, the
+// way an external wallet would fund and sign it.
+func fundTemplate(t *testing.T, p *Packet) *Packet {
+	tx := p.UnsignedTx.Copy()
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&testPrevHash, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(50000, testPkScript[:22]))
+
+	funded, err := NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	funded.Outputs[0] = p.Outputs[0]
+	funded.Unknowns = p.Unknowns
+	funded.Inputs[0] = PInput{
+		WitnessUtxo:        wire.NewTxOut(200000, testPkScript[:22]),
+		FinalScriptWitness: wire.TxWitness{{0x30, 0x01}, {0x02, 0x03}},
+		Unknowns: []*Unknown{{
+			Key:   []byte{0x03},
+			Value: []byte{0x01, 0x00, 0x00, 0x00},
+		}},
+	}
+
+	return funded
+}
+
+// TestPacketSerialization asserts that packets, including their uninterpreted
+// fields, survive a serialization round trip.
+func TestPacketSerialization(t *testing.T) {
+	template, err := NewFromUnsignedTx(makeTemplate())
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	template.Unknowns = []*Unknown{{
+		Key:   []byte{0xfb},
+		Value: []byte{0x00, 0x00, 0x00, 0x00},
+	}}
+	template.Outputs[0].Unknowns = []*Unknown{{
+		Key:   []byte{0x00},
+		Value: []byte{0x51},
+	}}
+
+	tests := []struct {
+		name   string
+		packet *Packet
+	}{
+		{
+			name:   "template without inputs",
+			packet: template,
+		},
+		{
+			name:   "funded and finalized",
+			packet: fundTemplate(t, template),
+		},
+	}
+
+	for _, test := range tests {
+		b64, err := test.packet.B64Encode()
+		if err != nil {
+			t.Fatalf("%s: unable to encode: %v", test.name, err)
+		}
+
+		packet, err := ParseBase64(b64)
+		if err != nil {
+			t.Fatalf("%s: unable to parse: %v", test.name, err)
+		}
+
+		if !reflect.DeepEqual(packet, test.packet) {
+			t.Fatalf("%s: packet mismatch, want %v, got %v",
+				test.name, test.packet, packet)
+		}
+	}
+}
+
+// TestPacketExtract asserts that the final transaction can only be extracted
+// once all inputs are finalized, and that it carries their final scripts.
+func TestPacketExtract(t *testing.T) {
+	template, err := NewFromUnsignedTx(makeTemplate())
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	funded := fundTemplate(t, template)
+
+	// Without its final witness, the input isn't finalized.
+	witness := funded.Inputs[0].FinalScriptWitness
+	funded.Inputs[0].FinalScriptWitness = nil
+	if funded.IsComplete() {
+		t.Fatalf("packet with unfinalized input is complete")
+	}
+	if _, err := funded.Extract(); err != ErrIncompletePSBT {
+		t.Fatalf("expected ErrIncompletePSBT, got %v", err)
+	}
+
+	funded.Inputs[0].FinalScriptWitness = witness
+	if !funded.IsComplete() {
+		t.Fatalf("packet with finalized inputs is incomplete")
+	}
+	tx, err := funded.Extract()
+	if err != nil {
+		t.Fatalf("unable to extract tx: %v", err)
+	}
+
+	if !reflect.DeepEqual(tx.TxIn[0].Witness, witness) {
+		t.Fatalf("extracted tx has witness %v, want %v",
+			tx.TxIn[0].Witness, witness)
+	}
+	if tx.TxHash() != funded.UnsignedTx.TxHash() {
+		t.Fatalf("extracted tx has a different txid")
+	}
+
+	// Extracting the transaction mustn't modify the unsigned one.
+	if len(funded.UnsignedTx.TxIn[0].Witness) != 0 {
+		t.Fatalf("unsigned tx was modified")
+	}
+}
+
+// TestPacketParseErrors asserts that malformed packets are rejected.
+func TestPacketParseErrors(t *testing.T) {
+	packet, err := NewFromUnsignedTx(makeTemplate())
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+	var valid bytes.Buffer
+	if err := packet.Serialize(&valid); err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+
+	// The unsigned tx is the first entry of the global map, directly after
+	// the magic bytes, so a duplicate can be inserted right behind it.
+	var txEntry bytes.Buffer
+	if err := writeMap(&txEntry, unsignedTxEntry(t, packet)); err != nil {
+		t.Fatalf("unable to write map: %v", err)
+	}
+	entry := txEntry.Bytes()[:txEntry.Len()-1]
+	duplicate := append(append(append([]byte{}, magic[:]...), entry...),
+		valid.Bytes()[len(magic):]...)
+
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{
+			name: "invalid magic",
+			raw: append([]byte{0x70, 0x73, 0x62, 0x74, 0x00},
+				valid.Bytes()[len(magic):]...),
+			err: ErrInvalidMagic,
+		},
+		{
+			name: "duplicate key",
+			raw:  duplicate,
+			err:  ErrDuplicateKey,
+		},
+		{
+			name: "missing output map",
+			raw:  valid.Bytes()[:valid.Len()-1],
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(bytes.NewReader(test.raw))
+		if err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+		if test.err != nil && err != test.err {
+			t.Fatalf("%s: expected %v, got %v", test.name,
+				test.err, err)
+		}
+	}
+
+	// A signed transaction can't be used as the unsigned one.
+	tx := makeTemplate()
+	tx.AddTxIn(wire.NewTxIn(
+		wire.NewOutPoint(&testPrevHash, 0), []byte{0x51}, nil,
+	))
+	if _, err := NewFromUnsignedTx(tx); err != ErrSignedUnsignedTx {
+		t.Fatalf("expected ErrSignedUnsignedTx, got %v", err)
+	}
+
+	if _, err := ParseBase64("cHNidP8"); err == nil {
+		t.Fatalf("expected error for truncated base64")
+	}
+	if _, err := ParseBase64(base64.StdEncoding.EncodeToString(
+		magic[:])); err == nil {
+
+		t.Fatalf("expected error for missing global map")
+	}
+}
+
+// unsignedTxEntry returns the global map entry holding the unsigned tx of the
+// packet.
+func unsignedTxEntry(t *testing.T, p *Packet) []*Unknown {
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		t.Fatalf("unable to serialize tx: %v", err)
+	}
+
+	return []*Unknown{{
+		Key:   []byte{globalUnsignedTxType},
+		Value: tx.Bytes(),
+	}}
+}
@@ -0,0 +1,478 @@
+package remotesigner
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/macaroon.v1"
+)
+
+// ClientConfig houses the parameters required by a watch-only node to connect
+// to its remote signer.
+type ClientConfig struct {
+	// RPCHost is the host:port of the remote signer's RPC interface.
+	RPCHost string
+
+	// TLSCertPath is the path to the TLS certificate of the remote
+	// signer's RPC interface.
+	TLSCertPath string
+
+	// MacaroonPath is the path to the macaroon that authenticates the node
+	// to the remote signer.
+	MacaroonPath string
+
+	// Timeout is the maximum time to wait for the response to a single
+	// request to the remote signer.
+	Timeout time.Duration
+}
+
+// Client forwards the derivation of all of the node's keys, all signing with
+// them, and all ECDH operations with its identity key to a remote signer over
+// an authenticated gRPC connection. This way, the node only ever holds public
+// keys.
+type Client struct {
+	cfg *ClientConfig
+
+	conn   *grpc.ClientConn
+	signer lnrpc.RemoteSignerClient
+}
+
+// A compile time check to ensure that Client implements the lnwallet.Signer,
+// lnwallet.MessageSigner, lnwallet.KeyRing and htlcswitch.OnionRouter
+// interfaces.
+var _ lnwallet.Signer = (*Client)(nil)
+var _ lnwallet.MessageSigner = (*Client)(nil)
+var _ lnwallet.KeyRing = (*Client)(nil)
+var _ htlcswitch.OnionRouter = (*Client)(nil)
+
+// NewClient creates a new client of the remote signer described by the passed
+// config. The connection to the signer is established lazily, so the signer
+// doesn't need to be reachable yet.
+func NewClient(cfg *ClientConfig) (*Client, error) {
+	creds, err := credentials.NewClientTLSFromFile(cfg.TLSCertPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read TLS certificate of "+
+			"remote signer: %v", err)
+	}
+
+	macBytes, err := ioutil.ReadFile(cfg.MacaroonPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read macaroon of remote "+
+			"signer: %v", err)
+	}
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return nil, fmt.Errorf("unable to decode macaroon of remote "+
+			"signer: %v", err)
+	}
+
+	// As the connection to the signer is long lived, we don't add a
+	// timeout constraint to the macaroon, as it would expire while the
+	// connection is still in use.
+	conn, err := grpc.Dial(
+		cfg.RPCHost, grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(
+			macaroons.NewMacaroonCredential(mac),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote signer: "+
+			"%v", err)
+	}
+
+	return &Client{
+		cfg:    cfg,
+		conn:   conn,
+		signer: lnrpc.NewRemoteSignerClient(conn),
+	}, nil
+}
+
+// Close closes the connection to the remote signer.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// DeriveNextKey derives a fresh key of the passed family on the remote signer
+// and returns its public key.
+//
+// NOTE: This is part of the lnwallet.KeyRing interface.
+func (c *Client) DeriveNextKey(
+	family lnwallet.KeyFamily) (*btcec.PublicKey, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.DeriveNextKey(ctx, &lnrpc.DeriveNextKeyRequest{
+		KeyFamily: uint32(family),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Remote signer issued %v key %v",
+		lnwallet.KeyFamily(resp.KeyFamily), resp.KeyIndex)
+
+	return btcec.ParsePubKey(resp.RawKeyBytes, btcec.S256())
+}
+
+// DeriveRevocationRoot has the remote signer derive the root of the revocation
+// tree for a channel with the passed node.
+//
+// NOTE: This is part of the lnwallet.KeyRing interface.
+func (c *Client) DeriveRevocationRoot(blockSalt chainhash.Hash,
+	nodePubKey *btcec.PublicKey) (chainhash.Hash, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.DeriveRevocationRoot(
+		ctx, &lnrpc.DeriveRevocationRootRequest{
+			BlockHash:  blockSalt[:],
+			NodePubKey: nodePubKey.SerializeCompressed(),
+		},
+	)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	root, err := chainhash.NewHash(resp.RevocationRoot)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	return *root, nil
+}
+
+// serializeSignRequest serializes the passed transaction and sign descriptor.
+// The sighash midstate isn't part of the serialized sign descriptor, as the
+// signer computes it from the transaction itself.
+func serializeSignRequest(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, []byte, error) {
+
+	var rawTx bytes.Buffer
+	if err := tx.Serialize(&rawTx); err != nil {
+		return nil, nil, err
+	}
+
+	var rawSignDesc bytes.Buffer
+	err := lnwallet.WriteSignDescriptor(&rawSignDesc, signDesc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rawTx.Bytes(), rawSignDesc.Bytes(), nil
+}
+
+// SignOutputRaw has the remote signer generate a signature for the passed
+// transaction according to the data within the passed SignDescriptor.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (c *Client) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	rawTx, rawSignDesc, err := serializeSignRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.SignOutputRaw(ctx, &lnrpc.SignOutputRawRequest{
+		RawTxBytes: rawTx,
+		SignDesc:   rawSignDesc,
+		InputIndex: int32(signDesc.InputIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Sig, nil
+}
+
+// ComputeInputScript has the remote signer generate a complete input script
+// for an input of the node's on-chain wallet.
+//
+// NOTE: This is part of the lnwallet.Signer interface.
+func (c *Client) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	rawTx, rawSignDesc, err := serializeSignRequest(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.ComputeInputScript(
+		ctx, &lnrpc.ComputeInputScriptRequest{
+			RawTxBytes: rawTx,
+			SignDesc:   rawSignDesc,
+			InputIndex: int32(signDesc.InputIndex),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwallet.InputScript{
+		Witness:   resp.Witness,
+		ScriptSig: resp.SigScript,
+	}, nil
+}
+
+// SignMessage has the remote signer sign the double SHA-256 of the passed
+// message under the private key of the passed public key.
+//
+// NOTE: This is part of the lnwallet.MessageSigner interface.
+func (c *Client) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.SignMessageWithKey(
+		ctx, &lnrpc.SignMessageWithKeyRequest{
+			PubKey: pubKey.SerializeCompressed(),
+			Msg:    msg,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParseDERSignature(resp.Signature, btcec.S256())
+}
+
+// ProcessOnionPacket has the remote signer peel the layer of the passed onion
+// packet that is addressed to the node's identity key.
+//
+// NOTE: This is part of the htlcswitch.OnionRouter interface.
+func (c *Client) ProcessOnionPacket(onionPkt *sphinx.OnionPacket,
+	assocData []byte) (*sphinx.ProcessedPacket, error) {
+
+	var rawPacket bytes.Buffer
+	if err := onionPkt.Encode(&rawPacket); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.ProcessOnionPacket(
+		ctx, &lnrpc.ProcessOnionPacketRequest{
+			OnionPacket: rawPacket.Bytes(),
+			AssocData:   assocData,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The failures that the caller acts upon are mapped back to the
+	// errors of the onion router.
+	switch resp.Failure {
+	case lnrpc.ProcessOnionPacketResponse_NONE:
+	case lnrpc.ProcessOnionPacketResponse_INVALID_VERSION:
+		return nil, sphinx.ErrInvalidOnionVersion
+	case lnrpc.ProcessOnionPacketResponse_INVALID_HMAC:
+		return nil, sphinx.ErrInvalidOnionHMAC
+	case lnrpc.ProcessOnionPacketResponse_INVALID_KEY:
+		return nil, sphinx.ErrInvalidOnionKey
+	default:
+		return nil, fmt.Errorf("unknown onion failure: %v",
+			resp.Failure)
+	}
+
+	packet := &sphinx.ProcessedPacket{
+		Action: sphinx.ProcessCode(resp.Action),
+	}
+	err = packet.ForwardingInstructions.Decode(
+		bytes.NewReader(resp.HopData),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.NextPacket) != 0 {
+		packet.NextPacket = &sphinx.OnionPacket{}
+		err := packet.NextPacket.Decode(bytes.NewReader(resp.NextPacket))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return packet, nil
+}
+
+// deriveSharedKey has the remote signer perform an ECDH operation between the
+// passed public key and the node's identity key.
+func (c *Client) deriveSharedKey(pubKey *btcec.PublicKey) ([32]byte, error) {
+	var sharedKey [32]byte
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.DeriveSharedKey(
+		ctx, &lnrpc.DeriveSharedKeyRequest{
+			EphemeralPubkey: pubKey.SerializeCompressed(),
+		},
+	)
+	if err != nil {
+		return sharedKey, err
+	}
+	if len(resp.SharedKey) != len(sharedKey) {
+		return sharedKey, fmt.Errorf("invalid shared key length %v",
+			len(resp.SharedKey))
+	}
+	copy(sharedKey[:], resp.SharedKey)
+
+	return sharedKey, nil
+}
+
+// NewOnionErrorEncrypter creates the encrypter used to obfuscate failures sent
+// back to the origin of the onion packet with the passed ephemeral key. The
+// encrypter is keyed by the shared secret of the ephemeral key and the node's
+// identity key, which is derived by the remote signer.
+//
+// NOTE: This is part of the htlcswitch.OnionRouter interface.
+func (c *Client) NewOnionErrorEncrypter(
+	ephemeralKey *btcec.PublicKey) (*sphinx.OnionErrorEncrypter, error) {
+
+	sharedKey, err := c.deriveSharedKey(ephemeralKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypter := &sphinx.OnionErrorEncrypter{}
+	err = encrypter.Decode(bytes.NewReader(sharedKey[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return encrypter, nil
+}
+
+// DeriveBackupKey fetches the key that the static channel backups of the node
+// are encrypted under from the remote signer.
+func (c *Client) DeriveBackupKey() (*btcec.PrivateKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.DeriveBackupKey(
+		ctx, &lnrpc.DeriveBackupKeyRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backupKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), resp.BackupKey)
+	return backupKey, nil
+}
+
+// NodeKey fetches the node's identity public key from the remote signer, and
+// returns the NodeKey through which all operations with the identity private
+// key are forwarded to the signer.
+func (c *Client) NodeKey() (*NodeKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	resp, err := c.signer.IdentityPubKey(
+		ctx, &lnrpc.IdentityPubKeyRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := btcec.ParsePubKey(resp.IdentityPubkey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeKey{
+		client: c,
+		pubKey: pubKey,
+	}, nil
+}
+
+// NodeKey represents the identity key of a node which is held by a remote
+// signer.
+type NodeKey struct {
+	client *Client
+	pubKey *btcec.PublicKey
+}
+
+// A compile time check to ensure that NodeKey implements the
+// brontide.SingleKeyECDH and lnwallet.MessageSigner interfaces.
+var _ brontide.SingleKeyECDH = (*NodeKey)(nil)
+var _ lnwallet.MessageSigner = (*NodeKey)(nil)
+
+// PubKey returns the identity public key of the node.
+//
+// NOTE: This is part of the brontide.SingleKeyECDH interface.
+func (n *NodeKey) PubKey() *btcec.PublicKey {
+	return n.pubKey
+}
+
+// ECDH has the remote signer perform an ECDH operation between the passed
+// public key and the identity key.
+//
+// NOTE: This is part of the brontide.SingleKeyECDH interface.
+func (n *NodeKey) ECDH(pubKey *btcec.PublicKey) ([32]byte, error) {
+	return n.client.deriveSharedKey(pubKey)
+}
+
+// SignMessage has the remote signer sign the double SHA-256 of the passed
+// message under the identity key, which must match the passed public key.
+//
+// NOTE: This is part of the lnwallet.MessageSigner interface.
+func (n *NodeKey) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	if !pubKey.IsEqual(n.pubKey) {
+		return nil, fmt.Errorf("unknown public key")
+	}
+
+	return n.client.SignMessage(pubKey, msg)
+}
+
+// SignCompact has the remote signer generate a compact signature of the double
+// SHA-256 of the passed message under the identity key.
+func (n *NodeKey) SignCompact(msg []byte) ([]byte, error) {
+	return n.SignDigestCompact(chainhash.DoubleHashB(msg))
+}
+
+// SignDigestCompact has the remote signer generate a compact signature of the
+// passed digest under the identity key.
+func (n *NodeKey) SignDigestCompact(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), n.client.cfg.Timeout,
+	)
+	defer cancel()
+
+	resp, err := n.client.signer.SignDigestCompact(
+		ctx, &lnrpc.SignDigestCompactRequest{
+			Digest: hash,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Signature, nil
+}
@@ -0,0 +1,521 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+const (
+	// channelKeyPurpose is the BIP-0043 purpose of the keys the signer
+	// derives for the watch-only node. All of these are found at
+	// m/1017'/coin'/family'/0/index.
+	channelKeyPurpose = 1017
+
+	// walletKeyPurpose is the BIP-0044 purpose of the keys of the
+	// watch-only node's on-chain wallet. btcwallet derives these at
+	// m/44'/coin'/0'/branch/index.
+	walletKeyPurpose = 44
+
+	// walletKeyWindow is the number of keys of each wallet branch that are
+	// derived at once when looking up a wallet key.
+	walletKeyWindow = 1000
+
+	// maxWalletKeyIndex is the index of each wallet branch beyond which the
+	// signer won't look for wallet keys.
+	maxWalletKeyIndex = 1 << 14
+
+	// keyFamilyRevocationRoot is the family of the key that the revocation
+	// roots of all channels are derived from.
+	keyFamilyRevocationRoot lnwallet.KeyFamily = 5
+
+	// keyFamilyNodeKey is the family of the identity key of the
+	// watch-only node.
+	keyFamilyNodeKey lnwallet.KeyFamily = 6
+
+	// keyFamilyBackup is the family of the key that the static channel
+	// backups of the watch-only node are encrypted under.
+	keyFamilyBackup lnwallet.KeyFamily = 7
+)
+
+var (
+	// keyIndexBucket stores the index of the next key to issue of every
+	// key family.
+	//
+	// maps: family -> next index
+	keyIndexBucket = []byte("key-index")
+
+	// issuedKeysBucket stores every key the signer issued to the
+	// watch-only node, along with the channel it has been bound to.
+	//
+	// maps: compressed pubkey -> family || index || [chanpoint || capacity]
+	issuedKeysBucket = []byte("issued-keys")
+
+	// ErrUnknownKey is returned when a key is requested that the signer
+	// didn't issue.
+	ErrUnknownKey = errors.New("key wasn't issued by the signer")
+)
+
+// IssuedKey describes a key the signer issued to the watch-only node through
+// DeriveNextKey.
+type IssuedKey struct {
+	// PubKey is the public key of the key.
+	PubKey *btcec.PublicKey
+
+	// Family is the family the key was issued for.
+	Family lnwallet.KeyFamily
+
+	// Index is the index of the key within its family.
+	Index uint32
+
+	// ChanPoint is the funding outpoint of the channel a multi-sig key has
+	// been bound to. It's nil until the key is first used to sign.
+	ChanPoint *wire.OutPoint
+
+	// Capacity is the capacity of the channel a multi-sig key has been
+	// bound to.
+	Capacity btcutil.Amount
+}
+
+// encode serializes the issued key, without its public key, to the passed
+// io.Writer.
+func (k *IssuedKey) encode(w io.Writer) error {
+	var scratch [8]byte
+	binary.BigEndian.PutUint32(scratch[:4], uint32(k.Family))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(scratch[:4], k.Index)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	if k.ChanPoint == nil {
+		return nil
+	}
+
+	if _, err := w.Write(k.ChanPoint.Hash[:]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(scratch[:4], k.ChanPoint.Index)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint64(scratch[:], uint64(k.Capacity))
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+// decode deserializes the issued key, without its public key, from the passed
+// io.Reader.
+func (k *IssuedKey) decode(r *bytes.Reader) error {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return err
+	}
+	k.Family = lnwallet.KeyFamily(binary.BigEndian.Uint32(scratch[:4]))
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return err
+	}
+	k.Index = binary.BigEndian.Uint32(scratch[:4])
+
+	if r.Len() == 0 {
+		return nil
+	}
+
+	k.ChanPoint = &wire.OutPoint{}
+	if _, err := io.ReadFull(r, k.ChanPoint.Hash[:]); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return err
+	}
+	k.ChanPoint.Index = binary.BigEndian.Uint32(scratch[:4])
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return err
+	}
+	k.Capacity = btcutil.Amount(binary.BigEndian.Uint64(scratch[:]))
+
+	return nil
+}
+
+// KeyStore holds the master key of the signer. It derives all keys of the
+// watch-only node, and keeps track of the channel keys it issued, such that
+// the signer never signs with a key it didn't hand out for that purpose.
+type KeyStore struct {
+	db *bolt.DB
+
+	masterKey *hdkeychain.ExtendedKey
+	netParams *chaincfg.Params
+
+	// walletKeys maps the hash160 of the public key of every wallet key
+	// derived so far to its private key, and nestedWalletKeys maps the
+	// hash160 of the nested p2wkh script of every such key to it.
+	walletKeys       map[[20]byte]*btcec.PrivateKey
+	nestedWalletKeys map[[20]byte]*btcec.PrivateKey
+
+	// nextWalletIndex is the index of the next key to derive of the
+	// external and internal wallet branches.
+	nextWalletIndex [2]uint32
+	walletMtx       sync.Mutex
+}
+
+// NewKeyStore creates a new KeyStore which derives its keys from the passed
+// HD seed, and persists the keys it issued within the passed database.
+func NewKeyStore(db *bolt.DB, seed []byte,
+	netParams *chaincfg.Params) (*KeyStore, error) {
+
+	masterKey, err := hdkeychain.NewMaster(seed, netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(keyIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(issuedKeysBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyStore{
+		db:               db,
+		masterKey:        masterKey,
+		netParams:        netParams,
+		walletKeys:       make(map[[20]byte]*btcec.PrivateKey),
+		nestedWalletKeys: make(map[[20]byte]*btcec.PrivateKey),
+	}, nil
+}
+
+// deriveBranch derives the extended key at m/purpose'/coin'/account'/branch.
+func (k *KeyStore) deriveBranch(purpose, account,
+	branch uint32) (*hdkeychain.ExtendedKey, error) {
+
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + purpose,
+		hdkeychain.HardenedKeyStart + k.netParams.HDCoinType,
+		hdkeychain.HardenedKeyStart + account,
+		branch,
+	}
+
+	key := k.masterKey
+	for _, i := range path {
+		var err error
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// deriveKey derives the private key at m/purpose'/coin'/account'/branch/index.
+func (k *KeyStore) deriveKey(purpose, account, branch,
+	index uint32) (*btcec.PrivateKey, error) {
+
+	branchKey, err := k.deriveBranch(purpose, account, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := branchKey.Child(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.ECPrivKey()
+}
+
+// deriveChannelKey derives the private key at the passed index of the passed
+// key family.
+func (k *KeyStore) deriveChannelKey(family lnwallet.KeyFamily,
+	index uint32) (*btcec.PrivateKey, error) {
+
+	return k.deriveKey(channelKeyPurpose, uint32(family), 0, index)
+}
+
+// DeriveNextKey derives the next key of the passed channel key family, and
+// records it as issued.
+func (k *KeyStore) DeriveNextKey(family lnwallet.KeyFamily) (*IssuedKey,
+	error) {
+
+	if family > lnwallet.KeyFamilyDelayBase {
+		return nil, fmt.Errorf("keys of family %v can't be issued",
+			family)
+	}
+
+	var issuedKey *IssuedKey
+	err := k.db.Update(func(tx *bolt.Tx) error {
+		indexes := tx.Bucket(keyIndexBucket)
+		issuedKeys := tx.Bucket(issuedKeysBucket)
+
+		var familyKey [4]byte
+		binary.BigEndian.PutUint32(familyKey[:], uint32(family))
+
+		var index uint32
+		if v := indexes.Get(familyKey[:]); v != nil {
+			index = binary.BigEndian.Uint32(v)
+		}
+
+		// A small fraction of indexes don't result in a valid key,
+		// in which case we'll skip to the next one.
+		var (
+			privKey *btcec.PrivateKey
+			err     error
+		)
+		for {
+			privKey, err = k.deriveChannelKey(family, index)
+			if err != hdkeychain.ErrInvalidChild {
+				break
+			}
+			index++
+		}
+		if err != nil {
+			return err
+		}
+
+		issuedKey = &IssuedKey{
+			PubKey: privKey.PubKey(),
+			Family: family,
+			Index:  index,
+		}
+
+		var nextIndex [4]byte
+		binary.BigEndian.PutUint32(nextIndex[:], index+1)
+		if err := indexes.Put(familyKey[:], nextIndex[:]); err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := issuedKey.encode(&b); err != nil {
+			return err
+		}
+
+		return issuedKeys.Put(
+			issuedKey.PubKey.SerializeCompressed(), b.Bytes(),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issuedKey, nil
+}
+
+// FetchIssuedKey returns the description of the issued key with the passed
+// public key. If the signer didn't issue the key, then ErrUnknownKey is
+// returned.
+func (k *KeyStore) FetchIssuedKey(pubKey *btcec.PublicKey) (*IssuedKey,
+	error) {
+
+	issuedKey := &IssuedKey{
+		PubKey: pubKey,
+	}
+	err := k.db.View(func(tx *bolt.Tx) error {
+		issuedKeys := tx.Bucket(issuedKeysBucket)
+
+		v := issuedKeys.Get(pubKey.SerializeCompressed())
+		if v == nil {
+			return ErrUnknownKey
+		}
+
+		return issuedKey.decode(bytes.NewReader(v))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issuedKey, nil
+}
+
+// BindChannel binds the issued multi-sig key with the passed public key to the
+// channel with the passed funding outpoint and capacity. A key can only be
+// bound once, so an error is returned if the key is already bound to another
+// channel.
+func (k *KeyStore) BindChannel(pubKey *btcec.PublicKey,
+	chanPoint wire.OutPoint, capacity btcutil.Amount) error {
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		issuedKeys := tx.Bucket(issuedKeysBucket)
+
+		keyBytes := pubKey.SerializeCompressed()
+		v := issuedKeys.Get(keyBytes)
+		if v == nil {
+			return ErrUnknownKey
+		}
+
+		issuedKey := &IssuedKey{}
+		if err := issuedKey.decode(bytes.NewReader(v)); err != nil {
+			return err
+		}
+
+		if issuedKey.Family != lnwallet.KeyFamilyMultiSig {
+			return fmt.Errorf("%v key can't be bound to a channel",
+				issuedKey.Family)
+		}
+
+		if issuedKey.ChanPoint != nil {
+			if *issuedKey.ChanPoint != chanPoint ||
+				issuedKey.Capacity != capacity {
+
+				return fmt.Errorf("key is bound to channel "+
+					"%v with capacity %v", issuedKey.ChanPoint,
+					issuedKey.Capacity)
+			}
+
+			return nil
+		}
+
+		issuedKey.ChanPoint = &chanPoint
+		issuedKey.Capacity = capacity
+
+		var b bytes.Buffer
+		if err := issuedKey.encode(&b); err != nil {
+			return err
+		}
+
+		return issuedKeys.Put(keyBytes, b.Bytes())
+	})
+}
+
+// DerivePrivKey derives the private key of the passed issued key.
+func (k *KeyStore) DerivePrivKey(key *IssuedKey) (*btcec.PrivateKey, error) {
+	return k.deriveChannelKey(key.Family, key.Index)
+}
+
+// IdentityKey derives the identity key of the watch-only node.
+func (k *KeyStore) IdentityKey() (*btcec.PrivateKey, error) {
+	return k.deriveChannelKey(keyFamilyNodeKey, 0)
+}
+
+// RevocationRootKey derives the key that the revocation roots of all channels
+// are derived from.
+func (k *KeyStore) RevocationRootKey() (*btcec.PrivateKey, error) {
+	return k.deriveChannelKey(keyFamilyRevocationRoot, 0)
+}
+
+// BackupKey derives the key that the static channel backups of the watch-only
+// node are encrypted under.
+func (k *KeyStore) BackupKey() (*btcec.PrivateKey, error) {
+	return k.deriveChannelKey(keyFamilyBackup, 0)
+}
+
+// FetchWalletKey returns the private key of the wallet output with the passed
+// script, which must either be a p2wkh or a nested p2wkh script. If the output
+// isn't one of the wallet's, then ErrUnknownKey is returned.
+func (k *KeyStore) FetchWalletKey(pkScript []byte) (*btcec.PrivateKey, error) {
+	var (
+		hash   [20]byte
+		lookup map[[20]byte]*btcec.PrivateKey
+	)
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+		copy(hash[:], pkScript[2:22])
+		lookup = k.walletKeys
+
+	case txscript.IsPayToScriptHash(pkScript):
+		copy(hash[:], pkScript[2:22])
+		lookup = k.nestedWalletKeys
+
+	default:
+		return nil, ErrUnknownKey
+	}
+
+	k.walletMtx.Lock()
+	defer k.walletMtx.Unlock()
+
+	for {
+		if privKey, ok := lookup[hash]; ok {
+			return privKey, nil
+		}
+
+		// The key hasn't been derived yet, so we'll derive the next
+		// window of both wallet branches, unless we've already looked
+		// as far ahead as we're willing to.
+		if k.nextWalletIndex[0] >= maxWalletKeyIndex {
+			return nil, ErrUnknownKey
+		}
+		if err := k.deriveWalletKeys(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// IsWalletScript returns true if the passed script pays to the wallet of the
+// watch-only node.
+func (k *KeyStore) IsWalletScript(pkScript []byte) (bool, error) {
+	_, err := k.FetchWalletKey(pkScript)
+	switch {
+	case err == ErrUnknownKey:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deriveWalletKeys derives the next window of keys of the external and
+// internal wallet branches.
+//
+// NOTE: This method MUST be called with the walletMtx held.
+func (k *KeyStore) deriveWalletKeys() error {
+	for branch := uint32(0); branch < 2; branch++ {
+		branchKey, err := k.deriveBranch(walletKeyPurpose, 0, branch)
+		if err != nil {
+			return err
+		}
+
+		start := k.nextWalletIndex[branch]
+		for i := start; i < start+walletKeyWindow; i++ {
+			key, err := branchKey.Child(i)
+			if err == hdkeychain.ErrInvalidChild {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			privKey, err := key.ECPrivKey()
+			if err != nil {
+				return err
+			}
+
+			pubKeyHash := btcutil.Hash160(
+				privKey.PubKey().SerializeCompressed(),
+			)
+			witnessProgram, err := txscript.NewScriptBuilder().
+				AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+			if err != nil {
+				return err
+			}
+
+			var keyHash, scriptHash [20]byte
+			copy(keyHash[:], pubKeyHash)
+			copy(scriptHash[:], btcutil.Hash160(witnessProgram))
+			k.walletKeys[keyHash] = privKey
+			k.nestedWalletKeys[scriptHash] = privKey
+		}
+
+		k.nextWalletIndex[branch] = start + walletKeyWindow
+	}
+
+	return nil
+}
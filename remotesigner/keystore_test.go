@@ -0,0 +1,241 @@
+package remotesigner
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// testSeed is the HD seed of the key stores created by the tests.
+var testSeed = bytes.Repeat([]byte{0x01}, 32)
+
+// openTestKeyStore opens a key store backed by the database within the passed
+// directory.
+func openTestKeyStore(t *testing.T, dir string) (*KeyStore, func()) {
+	db, err := bolt.Open(filepath.Join(dir, "signer.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	keys, err := NewKeyStore(db, testSeed, &chaincfg.SimNetParams)
+	if err != nil {
+		db.Close()
+		t.Fatalf("unable to create key store: %v", err)
+	}
+
+	return keys, func() {
+		db.Close()
+	}
+}
+
+// TestKeyStoreDeriveNextKey asserts that issued keys are persisted along with
+// their family and index, and that indexes aren't reused after a restart.
+func TestKeyStoreDeriveNextKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys, closeDB := openTestKeyStore(t, dir)
+
+	first, err := keys.DeriveNextKey(lnwallet.KeyFamilyMultiSig)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	second, err := keys.DeriveNextKey(lnwallet.KeyFamilyMultiSig)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	if first.Index != 0 || second.Index != 1 {
+		t.Fatalf("expected indexes 0 and 1, got %v and %v",
+			first.Index, second.Index)
+	}
+	if first.PubKey.IsEqual(second.PubKey) {
+		t.Fatalf("same key issued twice")
+	}
+
+	// The private key must match the issued public key.
+	privKey, err := keys.DerivePrivKey(second)
+	if err != nil {
+		t.Fatalf("unable to derive private key: %v", err)
+	}
+	if !privKey.PubKey().IsEqual(second.PubKey) {
+		t.Fatalf("private key doesn't match issued key")
+	}
+
+	issued, err := keys.FetchIssuedKey(second.PubKey)
+	if err != nil {
+		t.Fatalf("unable to fetch issued key: %v", err)
+	}
+	if issued.Family != lnwallet.KeyFamilyMultiSig || issued.Index != 1 ||
+		issued.ChanPoint != nil {
+
+		t.Fatalf("unexpected issued key: %+v", issued)
+	}
+
+	// Keys that weren't issued, such as the identity key, must be
+	// unknown.
+	identityKey, err := keys.IdentityKey()
+	if err != nil {
+		t.Fatalf("unable to derive identity key: %v", err)
+	}
+	_, err = keys.FetchIssuedKey(identityKey.PubKey())
+	if err != ErrUnknownKey {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+
+	// Nor can the internal families be issued.
+	if _, err := keys.DeriveNextKey(keyFamilyNodeKey); err == nil {
+		t.Fatalf("node key issued")
+	}
+
+	// After a restart, the next key must follow the ones issued before.
+	closeDB()
+	keys, closeDB = openTestKeyStore(t, dir)
+	defer closeDB()
+
+	third, err := keys.DeriveNextKey(lnwallet.KeyFamilyMultiSig)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	if third.Index != 2 {
+		t.Fatalf("expected index 2, got %v", third.Index)
+	}
+}
+
+// TestKeyStoreBindChannel asserts that a multi-sig key is bound to the first
+// channel it's bound to, and can't be bound to any other.
+func TestKeyStoreBindChannel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys, closeDB := openTestKeyStore(t, dir)
+	defer closeDB()
+
+	multiSigKey, err := keys.DeriveNextKey(lnwallet.KeyFamilyMultiSig)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+
+	chanPoint := wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 1}
+	const capacity = btcutil.SatoshiPerBitcoin
+	err = keys.BindChannel(multiSigKey.PubKey, chanPoint, capacity)
+	if err != nil {
+		t.Fatalf("unable to bind key: %v", err)
+	}
+
+	issued, err := keys.FetchIssuedKey(multiSigKey.PubKey)
+	if err != nil {
+		t.Fatalf("unable to fetch issued key: %v", err)
+	}
+	if issued.ChanPoint == nil || *issued.ChanPoint != chanPoint ||
+		issued.Capacity != capacity {
+
+		t.Fatalf("key not bound to channel: %+v", issued)
+	}
+
+	// Binding the key to the same channel again is a noop, while binding
+	// it to any other channel must fail.
+	err = keys.BindChannel(multiSigKey.PubKey, chanPoint, capacity)
+	if err != nil {
+		t.Fatalf("unable to rebind key: %v", err)
+	}
+	otherChanPoint := wire.OutPoint{Hash: chainhash.Hash{0x02}}
+	err = keys.BindChannel(multiSigKey.PubKey, otherChanPoint, capacity)
+	if err == nil {
+		t.Fatalf("key bound to second channel")
+	}
+	err = keys.BindChannel(multiSigKey.PubKey, chanPoint, capacity+1)
+	if err == nil {
+		t.Fatalf("key bound with second capacity")
+	}
+
+	// Only multi-sig keys can be bound to a channel.
+	baseKey, err := keys.DeriveNextKey(lnwallet.KeyFamilyPaymentBase)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	err = keys.BindChannel(baseKey.PubKey, chanPoint, capacity)
+	if err == nil {
+		t.Fatalf("base point bound to channel")
+	}
+}
+
+// TestKeyStoreFetchWalletKey asserts that the keys of both p2wkh and nested
+// p2wkh wallet outputs are found, including those beyond the first window of
+// derived keys.
+func TestKeyStoreFetchWalletKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys, closeDB := openTestKeyStore(t, dir)
+	defer closeDB()
+
+	walletKey, err := keys.deriveKey(
+		walletKeyPurpose, 0, 1, walletKeyWindow+1,
+	)
+	if err != nil {
+		t.Fatalf("unable to derive wallet key: %v", err)
+	}
+	pubKeyHash := btcutil.Hash160(walletKey.PubKey().SerializeCompressed())
+
+	witnessAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		pubKeyHash, &chaincfg.SimNetParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	witnessProgram, err := txscript.PayToAddrScript(witnessAddr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	nestedAddr, err := btcutil.NewAddressScriptHash(
+		witnessProgram, &chaincfg.SimNetParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	nestedScript, err := txscript.PayToAddrScript(nestedAddr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	for _, pkScript := range [][]byte{witnessProgram, nestedScript} {
+		privKey, err := keys.FetchWalletKey(pkScript)
+		if err != nil {
+			t.Fatalf("unable to fetch wallet key: %v", err)
+		}
+		if !privKey.PubKey().IsEqual(walletKey.PubKey()) {
+			t.Fatalf("wrong wallet key returned")
+		}
+	}
+
+	// A p2wsh output never belongs to the wallet.
+	ours, err := keys.IsWalletScript(
+		append([]byte{txscript.OP_0, 0x20}, make([]byte, 32)...),
+	)
+	if err != nil {
+		t.Fatalf("unable to check script: %v", err)
+	}
+	if ours {
+		t.Fatalf("p2wsh output reported as wallet output")
+	}
+}
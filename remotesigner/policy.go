@@ -0,0 +1,386 @@
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// Policy describes the requests that a remote signer is willing to serve. As
+// the watch-only node that issues the requests is exposed to the network, the
+// signer can't assume that all of them are legitimate. The policy rules out
+// those that no honest node would ever make.
+type Policy struct {
+	// ChainHash is the genesis hash of the chain the signer operates on.
+	// Channel announcements for any other chain won't be signed.
+	ChainHash chainhash.Hash
+
+	// AllowedPeers, if non-empty, is the set of nodes that channels may be
+	// held with. Revocation roots, which are needed to open a channel,
+	// are only derived for channels with these nodes, and only their
+	// channels are announced.
+	AllowedPeers []*btcec.PublicKey
+}
+
+// isAllowedPeer returns true if channels may be held with the passed node.
+func (p *Policy) isAllowedPeer(nodePubKey *btcec.PublicKey) bool {
+	if len(p.AllowedPeers) == 0 {
+		return true
+	}
+
+	for _, peer := range p.AllowedPeers {
+		if peer.IsEqual(nodePubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckPeer checks that a channel may be held with the passed node.
+func (p *Policy) CheckPeer(nodePubKey *btcec.PublicKey) error {
+	if !p.isAllowedPeer(nodePubKey) {
+		return fmt.Errorf("channels with node %x aren't allowed",
+			nodePubKey.SerializeCompressed())
+	}
+
+	return nil
+}
+
+// CheckSignDescriptor checks that an input of the passed transaction may be
+// signed according to the passed sign descriptor, with the passed issued key.
+// Every signature lnd generates for a channel output commits to the entire
+// transaction, so any other sighash type is rejected, as it would allow the
+// transaction to be altered once signed.
+//
+// The family the key was issued for determines the transactions it may sign.
+// A multi-sig key only signs the commitment and closing transactions of its
+// channel, which spend the funding output and pay no more than the capacity of
+// the channel. A base point key only signs transactions that pay back to the
+// wallet of the watch-only node, with the exception of second-level HTLC
+// transactions, which are signed with the HTLC key.
+//
+// The signer doesn't track the balance of each channel, so it can't tell the
+// latest commitment apart from one that pays the remote party more. Likewise,
+// the value of a second-level HTLC transaction isn't checked against the HTLC
+// it spends. Both require the cooperation of the remote party to exploit.
+func (p *Policy) CheckSignDescriptor(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor, key *IssuedKey,
+	isWalletScript func([]byte) (bool, error)) error {
+
+	if signDesc.HashType != txscript.SigHashAll {
+		return fmt.Errorf("sighash type %v isn't allowed",
+			signDesc.HashType)
+	}
+
+	if signDesc.InputIndex < 0 || signDesc.InputIndex >= len(tx.TxIn) {
+		return fmt.Errorf("input index %v out of range, tx has %v "+
+			"inputs", signDesc.InputIndex, len(tx.TxIn))
+	}
+
+	if signDesc.Output == nil {
+		return fmt.Errorf("sign descriptor has no output")
+	}
+
+	// The key that ends up signing must be committed to by the script
+	// being spent, and the script must be that of the output.
+	signKey, err := tweakedPubKey(signDesc, key)
+	if err != nil {
+		return err
+	}
+	if !scriptHasKey(signDesc.WitnessScript, signKey) {
+		return fmt.Errorf("witness script doesn't contain the %v key",
+			key.Family)
+	}
+	if err := checkPkScript(signDesc); err != nil {
+		return err
+	}
+
+	if key.Family == lnwallet.KeyFamilyMultiSig {
+		return checkFundingSpend(tx, signDesc, key)
+	}
+
+	// All outputs of a transaction signed with a base point key must pay
+	// back to our wallet.
+	toWallet := true
+	for _, txOut := range tx.TxOut {
+		ours, err := isWalletScript(txOut.PkScript)
+		if err != nil {
+			return err
+		}
+		if !ours {
+			toWallet = false
+			break
+		}
+	}
+	if toWallet {
+		return nil
+	}
+
+	// The exception are second-level HTLC transactions, which have a
+	// single input and a single p2wsh output, paying to the delayed
+	// output of the commitment's owner.
+	if key.Family == lnwallet.KeyFamilyHtlcBase && len(tx.TxIn) == 1 &&
+		len(tx.TxOut) == 1 &&
+		txscript.IsPayToWitnessScriptHash(tx.TxOut[0].PkScript) &&
+		tx.TxOut[0].Value <= signDesc.Output.Value {
+
+		return nil
+	}
+
+	return fmt.Errorf("tx signed with %v key doesn't pay to the wallet",
+		key.Family)
+}
+
+// tweakedPubKey returns the public key that signs according to the passed
+// sign descriptor. Each key family is always tweaked the same way by lnd, so
+// any other tweak is rejected.
+func tweakedPubKey(signDesc *lnwallet.SignDescriptor,
+	key *IssuedKey) (*btcec.PublicKey, error) {
+
+	switch key.Family {
+	case lnwallet.KeyFamilyMultiSig:
+		if signDesc.SingleTweak != nil || signDesc.DoubleTweak != nil {
+			return nil, fmt.Errorf("%v key can't be tweaked",
+				key.Family)
+		}
+
+		return key.PubKey, nil
+
+	case lnwallet.KeyFamilyRevocationBase:
+		if signDesc.SingleTweak != nil || signDesc.DoubleTweak == nil {
+			return nil, fmt.Errorf("%v key must be double tweaked",
+				key.Family)
+		}
+
+		return lnwallet.DeriveRevocationPubkey(
+			key.PubKey, signDesc.DoubleTweak.PubKey(),
+		), nil
+
+	default:
+		if signDesc.SingleTweak == nil || signDesc.DoubleTweak != nil {
+			return nil, fmt.Errorf("%v key must be single tweaked",
+				key.Family)
+		}
+
+		return lnwallet.TweakPubKeyWithTweak(
+			key.PubKey, signDesc.SingleTweak,
+		), nil
+	}
+}
+
+// scriptHasKey returns true if the passed script pushes either the passed key
+// or its hash160, the latter being the case for p2wkh witness programs.
+func scriptHasKey(script []byte, pubKey *btcec.PublicKey) bool {
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return false
+	}
+
+	keyBytes := pubKey.SerializeCompressed()
+	keyHash := btcutil.Hash160(keyBytes)
+	for _, push := range pushes {
+		if bytes.Equal(push, keyBytes) || bytes.Equal(push, keyHash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPkScript checks that the output script of the sign descriptor, if set,
+// is that of its witness script.
+func checkPkScript(signDesc *lnwallet.SignDescriptor) error {
+	pkScript := signDesc.Output.PkScript
+	if len(pkScript) == 0 {
+		return nil
+	}
+
+	// A p2wkh output is signed with its witness program as the witness
+	// script.
+	if txscript.IsPayToWitnessPubKeyHash(signDesc.WitnessScript) {
+		if !bytes.Equal(pkScript, signDesc.WitnessScript) {
+			return fmt.Errorf("output script doesn't match witness " +
+				"program")
+		}
+
+		return nil
+	}
+
+	scriptHash := sha256.Sum256(signDesc.WitnessScript)
+	p2wsh, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+		AddData(scriptHash[:]).Script()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pkScript, p2wsh) {
+		return fmt.Errorf("output script doesn't match witness script")
+	}
+
+	return nil
+}
+
+// checkFundingSpend checks that a transaction signed with the passed multi-sig
+// key spends the funding output of its channel, and pays no more than the
+// capacity of the channel. If the key isn't bound to a channel yet, then it's
+// about to be bound to the channel being spent.
+func checkFundingSpend(tx *wire.MsgTx, signDesc *lnwallet.SignDescriptor,
+	key *IssuedKey) error {
+
+	pushes, err := txscript.PushedData(signDesc.WitnessScript)
+	if err != nil {
+		return err
+	}
+	if len(pushes) != 2 {
+		return fmt.Errorf("witness script isn't a funding script")
+	}
+	fundingScript, _, err := lnwallet.GenFundingPkScript(
+		pushes[0], pushes[1], signDesc.Output.Value,
+	)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(fundingScript, signDesc.WitnessScript) {
+		return fmt.Errorf("witness script isn't a funding script")
+	}
+
+	// Commitment and closing transactions only ever spend the funding
+	// output.
+	if len(tx.TxIn) != 1 {
+		return fmt.Errorf("tx spending funding output has %v inputs",
+			len(tx.TxIn))
+	}
+
+	chanPoint := tx.TxIn[0].PreviousOutPoint
+	capacity := btcutil.Amount(signDesc.Output.Value)
+	if key.ChanPoint != nil && (*key.ChanPoint != chanPoint ||
+		key.Capacity != capacity) {
+
+		return fmt.Errorf("key is bound to channel %v with capacity "+
+			"%v, not %v with capacity %v", key.ChanPoint,
+			key.Capacity, chanPoint, capacity)
+	}
+
+	var totalOut int64
+	for _, txOut := range tx.TxOut {
+		totalOut += txOut.Value
+	}
+	if totalOut > signDesc.Output.Value {
+		return fmt.Errorf("tx pays %v, more than the channel "+
+			"capacity %v", btcutil.Amount(totalOut), capacity)
+	}
+
+	return nil
+}
+
+// CheckMessage checks that the passed message may be signed under the passed
+// issued key. The only messages the node signs with the keys issued by the
+// signer are channel announcements, which it signs under the multi-sig key of
+// its side of the channel. Restricting signing to these ensures that the
+// signer can't be tricked into signing the sighash of a transaction, which is
+// also a double SHA-256 digest.
+func (p *Policy) CheckMessage(key *IssuedKey, msg []byte) error {
+	if key.Family != lnwallet.KeyFamilyMultiSig {
+		return fmt.Errorf("messages can't be signed with %v key",
+			key.Family)
+	}
+
+	// The key must have signed the commitment of its channel, before
+	// the channel can be announced.
+	if key.ChanPoint == nil {
+		return fmt.Errorf("key isn't bound to a channel")
+	}
+
+	chanAnn, err := parseChanAnnData(msg)
+	if err != nil {
+		return fmt.Errorf("message isn't a channel announcement: %v",
+			err)
+	}
+
+	if chanAnn.ChainHash != p.ChainHash {
+		return fmt.Errorf("channel announcement is for chain %v",
+			chanAnn.ChainHash)
+	}
+
+	if !key.PubKey.IsEqual(chanAnn.BitcoinKey1) &&
+		!key.PubKey.IsEqual(chanAnn.BitcoinKey2) {
+
+		return fmt.Errorf("key %x isn't a bitcoin key of channel %v",
+			key.PubKey.SerializeCompressed(),
+			chanAnn.ShortChannelID)
+	}
+
+	if uint32(chanAnn.ShortChannelID.TxPosition) != key.ChanPoint.Index {
+		return fmt.Errorf("channel %v doesn't match funding output %v",
+			chanAnn.ShortChannelID, key.ChanPoint)
+	}
+
+	// As the signer doesn't know which of the two nodes is the watch-only
+	// node, we require that at least one of them is an allowed peer.
+	if !p.isAllowedPeer(chanAnn.NodeID1) &&
+		!p.isAllowedPeer(chanAnn.NodeID2) {
+
+		return fmt.Errorf("channel %v isn't with an allowed peer",
+			chanAnn.ShortChannelID)
+	}
+
+	return nil
+}
+
+// parseChanAnnData parses the portion of a channel announcement which is
+// covered by its signatures, as returned by its DataToSign method. All fields
+// but the signatures of the returned announcement are populated.
+func parseChanAnnData(data []byte) (*lnwire.ChannelAnnouncement, error) {
+	r := bytes.NewReader(data)
+
+	chanAnn := &lnwire.ChannelAnnouncement{
+		Features: lnwire.NewRawFeatureVector(),
+	}
+	if err := chanAnn.Features.Decode(r); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, chanAnn.ChainHash[:]); err != nil {
+		return nil, err
+	}
+
+	var chanID [8]byte
+	if _, err := io.ReadFull(r, chanID[:]); err != nil {
+		return nil, err
+	}
+	chanAnn.ShortChannelID = lnwire.NewShortChanIDFromInt(
+		binary.BigEndian.Uint64(chanID[:]),
+	)
+
+	keys := []**btcec.PublicKey{
+		&chanAnn.NodeID1, &chanAnn.NodeID2,
+		&chanAnn.BitcoinKey1, &chanAnn.BitcoinKey2,
+	}
+	for _, key := range keys {
+		var keyBytes [33]byte
+		if _, err := io.ReadFull(r, keyBytes[:]); err != nil {
+			return nil, err
+		}
+
+		pubKey, err := btcec.ParsePubKey(keyBytes[:], btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		*key = pubKey
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%v trailing bytes", r.Len())
+	}
+
+	return chanAnn, nil
+}
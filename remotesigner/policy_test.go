@@ -0,0 +1,509 @@
+package remotesigner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// newTestKey returns a fresh public key.
+func newTestKey(t *testing.T) *btcec.PublicKey {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	return priv.PubKey()
+}
+
+// p2wkhScript returns the p2wkh witness program of the passed key.
+func p2wkhScript(t *testing.T, pubKey *btcec.PublicKey) []byte {
+	script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(pubKey.SerializeCompressed())).Script()
+	if err != nil {
+		t.Fatalf("unable to create p2wkh script: %v", err)
+	}
+
+	return script
+}
+
+// TestPolicyCheckMessage asserts that only announcements of the channel a
+// multi-sig key is bound to are signed, and only under one of their bitcoin
+// keys.
+func TestPolicyCheckMessage(t *testing.T) {
+	ourNode, theirNode := newTestKey(t), newTestKey(t)
+	ourKey, theirKey := newTestKey(t), newTestKey(t)
+
+	chanAnn := &lnwire.ChannelAnnouncement{
+		Features:  lnwire.NewRawFeatureVector(),
+		ChainHash: *chaincfg.TestNet3Params.GenesisHash,
+		ShortChannelID: lnwire.ShortChannelID{
+			BlockHeight: 1000,
+			TxIndex:     5,
+			TxPosition:  1,
+		},
+		NodeID1:     ourNode,
+		NodeID2:     theirNode,
+		BitcoinKey1: ourKey,
+		BitcoinKey2: theirKey,
+	}
+	msg, err := chanAnn.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+
+	issuedKey := &IssuedKey{
+		PubKey:    ourKey,
+		Family:    lnwallet.KeyFamilyMultiSig,
+		ChanPoint: &wire.OutPoint{Index: 1},
+		Capacity:  btcutil.SatoshiPerBitcoin,
+	}
+
+	policy := &Policy{
+		ChainHash: *chaincfg.TestNet3Params.GenesisHash,
+	}
+	if err := policy.CheckMessage(issuedKey, msg); err != nil {
+		t.Fatalf("channel announcement rejected: %v", err)
+	}
+
+	// A message that isn't a channel announcement, such as the digest
+	// of a transaction, must be rejected.
+	if err := policy.CheckMessage(issuedKey, msg[:32]); err == nil {
+		t.Fatalf("truncated announcement accepted")
+	}
+	if err := policy.CheckMessage(issuedKey, append(msg, 0x00)); err == nil {
+		t.Fatalf("announcement with trailing data accepted")
+	}
+
+	// A key of another family can't sign announcements, even if it's a
+	// bitcoin key of the channel.
+	baseKey := *issuedKey
+	baseKey.Family = lnwallet.KeyFamilyPaymentBase
+	if err := policy.CheckMessage(&baseKey, msg); err == nil {
+		t.Fatalf("announcement signed under payment base point")
+	}
+
+	// Neither can a multi-sig key that isn't bound to a channel yet, or
+	// one that is bound to another channel.
+	unboundKey := *issuedKey
+	unboundKey.ChanPoint = nil
+	if err := policy.CheckMessage(&unboundKey, msg); err == nil {
+		t.Fatalf("announcement signed under unbound key")
+	}
+	otherChanKey := *issuedKey
+	otherChanKey.ChanPoint = &wire.OutPoint{Index: 0}
+	if err := policy.CheckMessage(&otherChanKey, msg); err == nil {
+		t.Fatalf("announcement signed under key of other channel")
+	}
+
+	// The remote bitcoin key isn't ours.
+	theirIssuedKey := *issuedKey
+	theirIssuedKey.PubKey = ourNode
+	if err := policy.CheckMessage(&theirIssuedKey, msg); err == nil {
+		t.Fatalf("announcement signed under node key")
+	}
+
+	mainnetPolicy := &Policy{
+		ChainHash: *chaincfg.MainNetParams.GenesisHash,
+	}
+	if err := mainnetPolicy.CheckMessage(issuedKey, msg); err == nil {
+		t.Fatalf("announcement for other chain accepted")
+	}
+
+	// Once peers are restricted, the channel must be with one of them.
+	policy.AllowedPeers = []*btcec.PublicKey{newTestKey(t)}
+	if err := policy.CheckMessage(issuedKey, msg); err == nil {
+		t.Fatalf("announcement of channel with unknown peer accepted")
+	}
+	policy.AllowedPeers = append(policy.AllowedPeers, theirNode)
+	if err := policy.CheckMessage(issuedKey, msg); err != nil {
+		t.Fatalf("announcement of channel with allowed peer "+
+			"rejected: %v", err)
+	}
+}
+
+// TestPolicyCheckPeer asserts that channels are only allowed with the allowed
+// peers, or any peer if none are set.
+func TestPolicyCheckPeer(t *testing.T) {
+	allowed, other := newTestKey(t), newTestKey(t)
+
+	policy := &Policy{}
+	if err := policy.CheckPeer(other); err != nil {
+		t.Fatalf("peer rejected without restriction: %v", err)
+	}
+
+	policy.AllowedPeers = []*btcec.PublicKey{allowed}
+	if err := policy.CheckPeer(allowed); err != nil {
+		t.Fatalf("allowed peer rejected: %v", err)
+	}
+	if err := policy.CheckPeer(other); err == nil {
+		t.Fatalf("unknown peer accepted")
+	}
+}
+
+// TestPolicyCheckSignDescriptor asserts that issued keys only sign for the
+// purpose they were issued for: multi-sig keys only sign transactions spending
+// the funding output of their channel, and base point keys only sign
+// transactions paying back to the wallet.
+func TestPolicyCheckSignDescriptor(t *testing.T) {
+	const capacity = btcutil.SatoshiPerBitcoin
+
+	walletScript := p2wkhScript(t, newTestKey(t))
+	isWalletScript := func(pkScript []byte) (bool, error) {
+		return bytes.Equal(pkScript, walletScript), nil
+	}
+
+	multiSigKey := &IssuedKey{
+		PubKey: newTestKey(t),
+		Family: lnwallet.KeyFamilyMultiSig,
+	}
+	fundingScript, fundingOutput, err := lnwallet.GenFundingPkScript(
+		multiSigKey.PubKey.SerializeCompressed(),
+		newTestKey(t).SerializeCompressed(), capacity,
+	)
+	if err != nil {
+		t.Fatalf("unable to create funding script: %v", err)
+	}
+	chanPoint := wire.OutPoint{Hash: chainhash.Hash{0x01}}
+	otherChanPoint := wire.OutPoint{Hash: chainhash.Hash{0x02}}
+
+	// The payment base point is tweaked by the commitment point, and
+	// signs the p2wkh output of the commitment that pays to us.
+	paymentKey := &IssuedKey{
+		PubKey: newTestKey(t),
+		Family: lnwallet.KeyFamilyPaymentBase,
+	}
+	singleTweak := lnwallet.SingleTweakBytes(
+		newTestKey(t), paymentKey.PubKey,
+	)
+	paymentScript := p2wkhScript(
+		t, lnwallet.TweakPubKeyWithTweak(paymentKey.PubKey, singleTweak),
+	)
+
+	htlcKey := &IssuedKey{
+		PubKey: newTestKey(t),
+		Family: lnwallet.KeyFamilyHtlcBase,
+	}
+	htlcScript := p2wkhScript(
+		t, lnwallet.TweakPubKeyWithTweak(htlcKey.PubKey, singleTweak),
+	)
+	secondLevelScript := fundingOutput.PkScript
+
+	// newTx returns a transaction spending the passed outpoints to the
+	// passed outputs.
+	newTx := func(prevOuts []wire.OutPoint,
+		txOuts ...*wire.TxOut) *wire.MsgTx {
+
+		tx := wire.NewMsgTx(2)
+		for _, prevOut := range prevOuts {
+			tx.AddTxIn(&wire.TxIn{PreviousOutPoint: prevOut})
+		}
+		for _, txOut := range txOuts {
+			tx.AddTxOut(txOut)
+		}
+
+		return tx
+	}
+
+	fundingSignDesc := func() *lnwallet.SignDescriptor {
+		return &lnwallet.SignDescriptor{
+			PubKey:        multiSigKey.PubKey,
+			WitnessScript: fundingScript,
+			Output:        fundingOutput,
+			HashType:      txscript.SigHashAll,
+		}
+	}
+	commitTx := newTx(
+		[]wire.OutPoint{chanPoint},
+		&wire.TxOut{Value: capacity / 2, PkScript: walletScript},
+		&wire.TxOut{Value: capacity / 4, PkScript: secondLevelScript},
+	)
+
+	tests := []struct {
+		name     string
+		tx       *wire.MsgTx
+		key      *IssuedKey
+		signDesc func() *lnwallet.SignDescriptor
+		valid    bool
+	}{
+		{
+			name:     "commitment",
+			tx:       commitTx,
+			key:      multiSigKey,
+			signDesc: fundingSignDesc,
+			valid:    true,
+		},
+		{
+			name: "commitment of bound channel",
+			tx:   commitTx,
+			key: &IssuedKey{
+				PubKey:    multiSigKey.PubKey,
+				Family:    lnwallet.KeyFamilyMultiSig,
+				ChanPoint: &chanPoint,
+				Capacity:  capacity,
+			},
+			signDesc: fundingSignDesc,
+			valid:    true,
+		},
+		{
+			name: "commitment of other channel",
+			tx:   commitTx,
+			key: &IssuedKey{
+				PubKey:    multiSigKey.PubKey,
+				Family:    lnwallet.KeyFamilyMultiSig,
+				ChanPoint: &otherChanPoint,
+				Capacity:  capacity,
+			},
+			signDesc: fundingSignDesc,
+		},
+		{
+			name: "sighash single",
+			tx:   commitTx,
+			key:  multiSigKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				signDesc := fundingSignDesc()
+				signDesc.HashType = txscript.SigHashSingle
+				return signDesc
+			},
+		},
+		{
+			name: "sighash all anyone can pay",
+			tx:   commitTx,
+			key:  multiSigKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				signDesc := fundingSignDesc()
+				signDesc.HashType = txscript.SigHashAll |
+					txscript.SigHashAnyOneCanPay
+				return signDesc
+			},
+		},
+		{
+			name: "input out of range",
+			tx:   commitTx,
+			key:  multiSigKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				signDesc := fundingSignDesc()
+				signDesc.InputIndex = 1
+				return signDesc
+			},
+		},
+		{
+			name: "tweaked multi-sig key",
+			tx:   commitTx,
+			key:  multiSigKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				signDesc := fundingSignDesc()
+				signDesc.SingleTweak = singleTweak
+				return signDesc
+			},
+		},
+		{
+			name: "funding spend with extra input",
+			tx: newTx(
+				[]wire.OutPoint{chanPoint, otherChanPoint},
+				&wire.TxOut{
+					Value:    capacity,
+					PkScript: walletScript,
+				},
+			),
+			key:      multiSigKey,
+			signDesc: fundingSignDesc,
+		},
+		{
+			name: "funding spend paying more than capacity",
+			tx: newTx(
+				[]wire.OutPoint{chanPoint},
+				&wire.TxOut{
+					Value:    capacity + 1,
+					PkScript: walletScript,
+				},
+			),
+			key:      multiSigKey,
+			signDesc: fundingSignDesc,
+		},
+		{
+			name: "multi-sig key in other script",
+			tx:   commitTx,
+			key:  multiSigKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				signDesc := fundingSignDesc()
+				signDesc.WitnessScript = p2wkhScript(
+					t, multiSigKey.PubKey,
+				)
+				signDesc.Output = &wire.TxOut{
+					Value:    capacity,
+					PkScript: signDesc.WitnessScript,
+				}
+				return signDesc
+			},
+		},
+		{
+			name: "unissued key in funding script",
+			tx:   commitTx,
+			key: &IssuedKey{
+				PubKey: newTestKey(t),
+				Family: lnwallet.KeyFamilyMultiSig,
+			},
+			signDesc: fundingSignDesc,
+		},
+		{
+			name: "payment output swept to wallet",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 1}},
+				&wire.TxOut{
+					Value:    capacity / 2,
+					PkScript: walletScript,
+				},
+			),
+			key: paymentKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				return &lnwallet.SignDescriptor{
+					PubKey:        paymentKey.PubKey,
+					SingleTweak:   singleTweak,
+					WitnessScript: paymentScript,
+					Output: &wire.TxOut{
+						Value:    capacity / 2,
+						PkScript: paymentScript,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+			valid: true,
+		},
+		{
+			name: "payment output swept elsewhere",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 1}},
+				&wire.TxOut{
+					Value:    capacity / 2,
+					PkScript: secondLevelScript,
+				},
+			),
+			key: paymentKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				return &lnwallet.SignDescriptor{
+					PubKey:        paymentKey.PubKey,
+					SingleTweak:   singleTweak,
+					WitnessScript: paymentScript,
+					Output: &wire.TxOut{
+						Value:    capacity / 2,
+						PkScript: paymentScript,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+		},
+		{
+			name: "untweaked payment key",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 1}},
+				&wire.TxOut{
+					Value:    capacity / 2,
+					PkScript: walletScript,
+				},
+			),
+			key: paymentKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				script := p2wkhScript(t, paymentKey.PubKey)
+				return &lnwallet.SignDescriptor{
+					PubKey:        paymentKey.PubKey,
+					WitnessScript: script,
+					Output: &wire.TxOut{
+						Value:    capacity / 2,
+						PkScript: script,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+		},
+		{
+			name: "output script not matching witness script",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 1}},
+				&wire.TxOut{
+					Value:    capacity / 2,
+					PkScript: walletScript,
+				},
+			),
+			key: paymentKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				return &lnwallet.SignDescriptor{
+					PubKey:        paymentKey.PubKey,
+					SingleTweak:   singleTweak,
+					WitnessScript: paymentScript,
+					Output: &wire.TxOut{
+						Value:    capacity / 2,
+						PkScript: walletScript,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+		},
+		{
+			name: "second-level htlc transaction",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 2}},
+				&wire.TxOut{
+					Value:    capacity / 4,
+					PkScript: secondLevelScript,
+				},
+			),
+			key: htlcKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				return &lnwallet.SignDescriptor{
+					PubKey:        htlcKey.PubKey,
+					SingleTweak:   singleTweak,
+					WitnessScript: htlcScript,
+					Output: &wire.TxOut{
+						Value: capacity / 4,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+			valid: true,
+		},
+		{
+			name: "second-level htlc transaction paying more",
+			tx: newTx(
+				[]wire.OutPoint{{Index: 2}},
+				&wire.TxOut{
+					Value:    capacity/4 + 1,
+					PkScript: secondLevelScript,
+				},
+			),
+			key: htlcKey,
+			signDesc: func() *lnwallet.SignDescriptor {
+				return &lnwallet.SignDescriptor{
+					PubKey:        htlcKey.PubKey,
+					SingleTweak:   singleTweak,
+					WitnessScript: htlcScript,
+					Output: &wire.TxOut{
+						Value: capacity / 4,
+					},
+					HashType: txscript.SigHashAll,
+				}
+			},
+		},
+	}
+
+	policy := &Policy{}
+	for _, test := range tests {
+		err := policy.CheckSignDescriptor(
+			test.tx, test.signDesc(), test.key, isWalletScript,
+		)
+		if test.valid && err != nil {
+			t.Fatalf("%s: rejected: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("%s: accepted", test.name)
+		}
+	}
+}
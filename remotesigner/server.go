@@ -0,0 +1,496 @@
+package remotesigner
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+)
+
+// ServerConfig houses the parameters required by the signing side of a remote
+// signer.
+type ServerConfig struct {
+	// Keys holds the keys of the watch-only node, and tracks the channel
+	// keys the signer issued.
+	Keys *KeyStore
+
+	// NetParams is the set of parameters of the chain the watch-only node
+	// operates on.
+	NetParams *chaincfg.Params
+
+	// Policy restricts the requests that the signer is willing to serve.
+	Policy *Policy
+
+	// AuthSvc, if non-nil, is used to check the macaroon of every request.
+	AuthSvc *bakery.Service
+}
+
+// Server implements the RemoteSigner service, which holds all private keys of
+// a watch-only node.
+type Server struct {
+	cfg *ServerConfig
+
+	identityKey *btcec.PrivateKey
+	onionRouter *sphinx.Router
+}
+
+// A compile time check to ensure that Server implements the
+// lnrpc.RemoteSignerServer interface.
+var _ lnrpc.RemoteSignerServer = (*Server)(nil)
+
+// NewServer creates a new instance of the RemoteSigner service.
+func NewServer(cfg *ServerConfig) (*Server, error) {
+	identityKey, err := cfg.Keys.IdentityKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		cfg:         cfg,
+		identityKey: identityKey,
+		onionRouter: sphinx.NewRouter(identityKey, cfg.NetParams),
+	}, nil
+}
+
+// checkMacaroon checks whether the macaroon of the request permits the passed
+// method.
+func (s *Server) checkMacaroon(ctx context.Context, method string) error {
+	if s.cfg.AuthSvc == nil {
+		return nil
+	}
+
+	return macaroons.ValidateMacaroon(ctx, method, s.cfg.AuthSvc)
+}
+
+// DeriveNextKey derives a fresh key of the requested family and returns its
+// public key. The signer only ever signs with channel keys issued this way.
+func (s *Server) DeriveNextKey(ctx context.Context,
+	in *lnrpc.DeriveNextKeyRequest) (*lnrpc.DeriveNextKeyResponse, error) {
+
+	if err := s.checkMacaroon(ctx, "derivenextkey"); err != nil {
+		return nil, err
+	}
+
+	key, err := s.cfg.Keys.DeriveNextKey(lnwallet.KeyFamily(in.KeyFamily))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Issued %v key %v: %x", key.Family, key.Index,
+		key.PubKey.SerializeCompressed())
+
+	return &lnrpc.DeriveNextKeyResponse{
+		RawKeyBytes: key.PubKey.SerializeCompressed(),
+		KeyFamily:   uint32(key.Family),
+		KeyIndex:    key.Index,
+	}, nil
+}
+
+// decodeSignRequest decodes the transaction and sign descriptor of a request
+// to sign an input.
+func decodeSignRequest(rawTx, rawSignDesc []byte,
+	inputIndex int32) (*wire.MsgTx, *lnwallet.SignDescriptor, error) {
+
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode tx: %v", err)
+	}
+
+	signDesc := &lnwallet.SignDescriptor{}
+	err := lnwallet.ReadSignDescriptor(
+		bytes.NewReader(rawSignDesc), signDesc,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode sign "+
+			"descriptor: %v", err)
+	}
+	signDesc.InputIndex = int(inputIndex)
+
+	return tx, signDesc, nil
+}
+
+// SignOutputRaw generates a signature for an input of the passed transaction
+// according to the passed sign descriptor, if the policy permits it.
+func (s *Server) SignOutputRaw(ctx context.Context,
+	in *lnrpc.SignOutputRawRequest) (*lnrpc.SignOutputRawResponse, error) {
+
+	if err := s.checkMacaroon(ctx, "signoutputraw"); err != nil {
+		return nil, err
+	}
+
+	tx, signDesc, err := decodeSignRequest(
+		in.RawTxBytes, in.SignDesc, in.InputIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only keys that we issued to the node are used to sign, and only
+	// for the purpose they were issued for.
+	key, err := s.cfg.Keys.FetchIssuedKey(signDesc.PubKey)
+	if err != nil {
+		log.Warnf("Refusing to sign input %v of tx %v: %v",
+			in.InputIndex, tx.TxHash(), err)
+		return nil, err
+	}
+
+	err = s.cfg.Policy.CheckSignDescriptor(
+		tx, signDesc, key, s.cfg.Keys.IsWalletScript,
+	)
+	if err != nil {
+		log.Warnf("Refusing to sign input %v of tx %v with %v key "+
+			"%v: %v", in.InputIndex, tx.TxHash(), key.Family,
+			key.Index, err)
+		return nil, err
+	}
+
+	// A multi-sig key is bound to the channel it first signs for, such
+	// that it never signs for any other.
+	if key.Family == lnwallet.KeyFamilyMultiSig {
+		err := s.cfg.Keys.BindChannel(
+			key.PubKey, tx.TxIn[0].PreviousOutPoint,
+			btcutil.Amount(signDesc.Output.Value),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	privKey, err := s.cfg.Keys.DerivePrivKey(key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case signDesc.SingleTweak != nil:
+		privKey = lnwallet.TweakPrivKey(privKey, signDesc.SingleTweak)
+	case signDesc.DoubleTweak != nil:
+		privKey = lnwallet.DeriveRevocationPrivKey(
+			privKey, signDesc.DoubleTweak,
+		)
+	}
+
+	sig, err := txscript.RawTxInWitnessSignature(
+		tx, txscript.NewTxSigHashes(tx), signDesc.InputIndex,
+		signDesc.Output.Value, signDesc.WitnessScript,
+		signDesc.HashType, privKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Tracef("Signed input %v of tx %v with %v key %v",
+		in.InputIndex, tx.TxHash(), key.Family, key.Index)
+
+	// Chop off the sighash flag at the end of the signature.
+	return &lnrpc.SignOutputRawResponse{
+		Sig: sig[:len(sig)-1],
+	}, nil
+}
+
+// ComputeInputScript generates the complete input script of an input that
+// spends an output of the watch-only node's on-chain wallet.
+func (s *Server) ComputeInputScript(ctx context.Context,
+	in *lnrpc.ComputeInputScriptRequest) (*lnrpc.ComputeInputScriptResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "computeinputscript"); err != nil {
+		return nil, err
+	}
+
+	tx, signDesc, err := decodeSignRequest(
+		in.RawTxBytes, in.SignDesc, in.InputIndex,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if signDesc.HashType != txscript.SigHashAll {
+		return nil, fmt.Errorf("sighash type %v isn't allowed",
+			signDesc.HashType)
+	}
+	if signDesc.InputIndex < 0 || signDesc.InputIndex >= len(tx.TxIn) {
+		return nil, fmt.Errorf("input index %v out of range",
+			signDesc.InputIndex)
+	}
+	if signDesc.Output == nil {
+		return nil, fmt.Errorf("sign descriptor has no output")
+	}
+
+	outputScript := signDesc.Output.PkScript
+	privKey, err := s.cfg.Keys.FetchWalletKey(outputScript)
+	if err != nil {
+		log.Warnf("Refusing to sign input %v of tx %v: %v",
+			in.InputIndex, tx.TxHash(), err)
+		return nil, err
+	}
+
+	pubKeyHash := btcutil.Hash160(privKey.PubKey().SerializeCompressed())
+	witnessProgram, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	// If we're spending a p2wkh output nested within a p2sh output, then
+	// the sigScript is a single push of the witness program.
+	resp := &lnrpc.ComputeInputScriptResponse{}
+	if txscript.IsPayToScriptHash(outputScript) {
+		resp.SigScript, err = txscript.NewScriptBuilder().
+			AddData(witnessProgram).Script()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	witness, err := txscript.WitnessSignature(
+		tx, txscript.NewTxSigHashes(tx), signDesc.InputIndex,
+		signDesc.Output.Value, witnessProgram, signDesc.HashType,
+		privKey, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.Witness = witness
+
+	log.Tracef("Signed wallet input %v of tx %v", in.InputIndex,
+		tx.TxHash())
+
+	return resp, nil
+}
+
+// SignMessageWithKey signs the double SHA-256 of the passed message under the
+// passed key. The identity key signs any message, as the node exposes the
+// signing of arbitrary messages over its own RPC interface, and the key holds
+// no funds. Any other key only signs if the policy permits it.
+func (s *Server) SignMessageWithKey(ctx context.Context,
+	in *lnrpc.SignMessageWithKeyRequest) (*lnrpc.SignMessageWithKeyResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "signmessagewithkey"); err != nil {
+		return nil, err
+	}
+
+	pubKey, err := btcec.ParsePubKey(in.PubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode pubkey: %v", err)
+	}
+
+	privKey := s.identityKey
+	if !pubKey.IsEqual(s.identityKey.PubKey()) {
+		key, err := s.cfg.Keys.FetchIssuedKey(pubKey)
+		if err != nil {
+			log.Warnf("Refusing to sign message under key %x: %v",
+				in.PubKey, err)
+			return nil, err
+		}
+
+		if err := s.cfg.Policy.CheckMessage(key, in.Msg); err != nil {
+			log.Warnf("Refusing to sign message under key %x: %v",
+				in.PubKey, err)
+			return nil, err
+		}
+
+		privKey, err = s.cfg.Keys.DerivePrivKey(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sig, err := privKey.Sign(chainhash.DoubleHashB(in.Msg))
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SignMessageWithKeyResponse{
+		Signature: sig.Serialize(),
+	}, nil
+}
+
+// SignDigestCompact generates a compact signature of the passed digest under
+// the identity key.
+func (s *Server) SignDigestCompact(ctx context.Context,
+	in *lnrpc.SignDigestCompactRequest) (*lnrpc.SignDigestCompactResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "signdigestcompact"); err != nil {
+		return nil, err
+	}
+
+	if len(in.Digest) != chainhash.HashSize {
+		return nil, fmt.Errorf("digest must be %v bytes",
+			chainhash.HashSize)
+	}
+
+	sig, err := btcec.SignCompact(
+		btcec.S256(), s.identityKey, in.Digest, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SignDigestCompactResponse{
+		Signature: sig,
+	}, nil
+}
+
+// DeriveRevocationRoot derives the root of the revocation tree of a channel
+// with the passed node, if the policy permits channels with it.
+func (s *Server) DeriveRevocationRoot(ctx context.Context,
+	in *lnrpc.DeriveRevocationRootRequest) (
+	*lnrpc.DeriveRevocationRootResponse, error) {
+
+	if err := s.checkMacaroon(ctx, "deriverevocationroot"); err != nil {
+		return nil, err
+	}
+
+	blockSalt, err := chainhash.NewHash(in.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	nodePubKey, err := btcec.ParsePubKey(in.NodePubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode pubkey: %v", err)
+	}
+
+	if err := s.cfg.Policy.CheckPeer(nodePubKey); err != nil {
+		log.Warnf("Refusing to derive revocation root: %v", err)
+		return nil, err
+	}
+
+	rootKey, err := s.cfg.Keys.RevocationRootKey()
+	if err != nil {
+		return nil, err
+	}
+	root := lnwallet.DeriveRevocationRoot(rootKey, *blockSalt, nodePubKey)
+
+	log.Debugf("Derived revocation root for channel with node %x",
+		in.NodePubKey)
+
+	return &lnrpc.DeriveRevocationRootResponse{
+		RevocationRoot: root[:],
+	}, nil
+}
+
+// IdentityPubKey returns the identity public key of the watch-only node.
+func (s *Server) IdentityPubKey(ctx context.Context,
+	in *lnrpc.IdentityPubKeyRequest) (*lnrpc.IdentityPubKeyResponse, error) {
+
+	if err := s.checkMacaroon(ctx, "identitypubkey"); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.IdentityPubKeyResponse{
+		IdentityPubkey: s.identityKey.PubKey().SerializeCompressed(),
+	}, nil
+}
+
+// DeriveSharedKey performs an ECDH operation between the passed public key and
+// the identity key.
+func (s *Server) DeriveSharedKey(ctx context.Context,
+	in *lnrpc.DeriveSharedKeyRequest) (*lnrpc.DeriveSharedKeyResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "derivesharedkey"); err != nil {
+		return nil, err
+	}
+
+	pubKey, err := btcec.ParsePubKey(in.EphemeralPubkey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode pubkey: %v", err)
+	}
+
+	identityECDH := &brontide.PrivKeyECDH{PrivKey: s.identityKey}
+	sharedKey, err := identityECDH.ECDH(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeriveSharedKeyResponse{
+		SharedKey: sharedKey[:],
+	}, nil
+}
+
+// ProcessOnionPacket peels the layer of the passed onion packet that is
+// addressed to the identity key.
+func (s *Server) ProcessOnionPacket(ctx context.Context,
+	in *lnrpc.ProcessOnionPacketRequest) (*lnrpc.ProcessOnionPacketResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "processonionpacket"); err != nil {
+		return nil, err
+	}
+
+	onionPkt := &sphinx.OnionPacket{}
+	if err := onionPkt.Decode(bytes.NewReader(in.OnionPacket)); err != nil {
+		return nil, err
+	}
+
+	packet, err := s.onionRouter.ProcessOnionPacket(onionPkt, in.AssocData)
+	switch err {
+	case nil:
+	case sphinx.ErrInvalidOnionVersion:
+		return &lnrpc.ProcessOnionPacketResponse{
+			Failure: lnrpc.ProcessOnionPacketResponse_INVALID_VERSION,
+		}, nil
+	case sphinx.ErrInvalidOnionHMAC:
+		return &lnrpc.ProcessOnionPacketResponse{
+			Failure: lnrpc.ProcessOnionPacketResponse_INVALID_HMAC,
+		}, nil
+	case sphinx.ErrInvalidOnionKey:
+		return &lnrpc.ProcessOnionPacketResponse{
+			Failure: lnrpc.ProcessOnionPacketResponse_INVALID_KEY,
+		}, nil
+	default:
+		return nil, err
+	}
+
+	var hopData bytes.Buffer
+	if err := packet.ForwardingInstructions.Encode(&hopData); err != nil {
+		return nil, err
+	}
+
+	var nextPacket bytes.Buffer
+	if packet.NextPacket != nil {
+		if err := packet.NextPacket.Encode(&nextPacket); err != nil {
+			return nil, err
+		}
+	}
+
+	return &lnrpc.ProcessOnionPacketResponse{
+		Action:     uint32(packet.Action),
+		HopData:    hopData.Bytes(),
+		NextPacket: nextPacket.Bytes(),
+	}, nil
+}
+
+// DeriveBackupKey returns the key that the static channel backups of the
+// watch-only node are encrypted under.
+func (s *Server) DeriveBackupKey(ctx context.Context,
+	in *lnrpc.DeriveBackupKeyRequest) (*lnrpc.DeriveBackupKeyResponse,
+	error) {
+
+	if err := s.checkMacaroon(ctx, "derivebackupkey"); err != nil {
+		return nil, err
+	}
+
+	backupKey, err := s.cfg.Keys.BackupKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeriveBackupKeyResponse{
+		BackupKey: backupKey.Serialize(),
+	}, nil
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -25,7 +27,9 @@ import (
 )
 
 var (
-	netParams = &chaincfg.SimNetParams
+	// netParams is the regtest network, as it's the only test network
+	// which is supported by btcd and bitcoind alike.
+	netParams = &chaincfg.RegressionNetParams
 
 	testPrivKey = []byte{
 		0x81, 0xb6, 0x37, 0xd8, 0xfc, 0xd2, 0xc6, 0xda,
@@ -652,6 +656,61 @@ func testFilterBlockDisconnected(node *rpctest.Harness,
 	time.Sleep(time.Millisecond * 500)
 }
 
+// startBitcoind launches a new regtest bitcoind instance which connects to
+// the node at the passed p2p address, and publishes raw blocks and
+// transactions over ZMQ. The RPC config and ZMQ address of the instance are
+// returned, along with a function to tear it down.
+func startBitcoind(p2pAddr string) (func(), *rpcclient.ConnConfig,
+	string, error) {
+
+	tempBitcoindDir, err := ioutil.TempDir("", "bitcoind")
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	zmqPath := "ipc:///" + tempBitcoindDir + "/weks.socket"
+	rpcPort := rand.Int()%(65536-1024) + 1024
+	bitcoind := exec.Command(
+		"bitcoind",
+		"-datadir="+tempBitcoindDir,
+		"-regtest",
+		"-connect="+p2pAddr,
+		"-txindex",
+		"-rpcuser=weks",
+		"-rpcpassword=weks",
+		fmt.Sprintf("-rpcport=%d", rpcPort),
+		"-disablewallet",
+		"-zmqpubrawblock="+zmqPath,
+		"-zmqpubrawtx="+zmqPath,
+	)
+	if err := bitcoind.Start(); err != nil {
+		os.RemoveAll(tempBitcoindDir)
+		return nil, nil, "", err
+	}
+
+	cleanUp := func() {
+		bitcoind.Process.Kill()
+		bitcoind.Wait()
+		os.RemoveAll(tempBitcoindDir)
+	}
+
+	// Give bitcoind a chance to start up before any clients attempt to
+	// connect to it.
+	time.Sleep(time.Second)
+
+	rpcConfig := &rpcclient.ConnConfig{
+		Host:                 fmt.Sprintf("127.0.0.1:%d", rpcPort),
+		User:                 "weks",
+		Pass:                 "weks",
+		DisableAutoReconnect: false,
+		DisableConnectOnNew:  true,
+		DisableTLS:           true,
+		HTTPPostMode:         true,
+	}
+
+	return cleanUp, rpcConfig, zmqPath, nil
+}
+
 type chainViewInitFunc func(rpcInfo rpcclient.ConnConfig,
 	p2pAddr string) (func(), FilteredChainView, error)
 
@@ -732,6 +791,25 @@ var interfaceImpls = []struct {
 			return cleanUp, chainView, nil
 		},
 	},
+	{
+		name: "bitcoind_zmq",
+		chainViewInit: func(_ rpcclient.ConnConfig, p2pAddr string) (func(), FilteredChainView, error) {
+			cleanUp, rpcConfig, zmqPath, err := startBitcoind(p2pAddr)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			chainView, err := NewBitcoindFilteredChainView(
+				*rpcConfig, zmqPath, *netParams,
+			)
+			if err != nil {
+				cleanUp()
+				return nil, nil, err
+			}
+
+			return cleanUp, chainView, nil
+		},
+	},
 	{
 		name: "btcd_websockets",
 		chainViewInit: func(config rpcclient.ConnConfig, _ string) (func(), FilteredChainView, error) {
@@ -0,0 +1,291 @@
+package routing
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// ControlTower tracks the life cycle of all payments sent by the router. It
+// prevents a payment hash from being paid more than once, and keeps a record
+// of the in-flight HTLCs of each payment so they can be resumed after a
+// restart.
+type ControlTower interface {
+	// InitPayment records that a new payment is about to be sent. It
+	// returns channeldb.ErrPaymentInFlight or channeldb.ErrAlreadyPaid if
+	// the payment hash is already being paid, or has been paid.
+	InitPayment(info *channeldb.PaymentCreationInfo) error
+
+	// RegisterAttempt records an attempt of an in-flight payment, which
+	// assigns the payment ID the attempt's HTLC is to be sent with.
+	RegisterAttempt(paymentHash [32]byte,
+		attempt *channeldb.PaymentAttemptInfo) error
+
+	// FailAttempt records that the HTLC of an attempt has failed.
+	FailAttempt(paymentHash [32]byte, paymentID uint64) error
+
+	// Success records that a payment has been settled by its destination.
+	Success(paymentHash, preimage [32]byte) error
+
+	// Fail records that a payment has failed, once none of its HTLCs are
+	// outstanding anymore.
+	Fail(paymentHash [32]byte, reason channeldb.FailureReason,
+		msg string) error
+
+	// FetchPayment returns the current state of a payment.
+	FetchPayment(paymentHash [32]byte) (*channeldb.TrackedPayment, error)
+
+	// FetchInFlightPayments returns all payments that are in flight.
+	FetchInFlightPayments() ([]*channeldb.TrackedPayment, error)
+
+	// SubscribePayment returns a subscription to the state updates of a
+	// payment.
+	SubscribePayment(paymentHash [32]byte) (*PaymentSubscription, error)
+}
+
+// PaymentSubscription is a client's subscription to the state updates of a
+// single payment.
+type PaymentSubscription struct {
+	// Updates receives the state of the payment each time it changes,
+	// starting with its current state. Only the most recent state is
+	// guaranteed to be delivered to a slow reader. The channel is closed
+	// once the payment has succeeded or failed, or the subscription is
+	// canceled.
+	Updates <-chan *channeldb.TrackedPayment
+
+	// Cancel should be called once the client is no longer interested in
+	// updates of the payment.
+	Cancel func()
+}
+
+// paymentSubscriber is the control tower's end of a PaymentSubscription.
+type paymentSubscriber struct {
+	id      uint64
+	updates chan *channeldb.TrackedPayment
+}
+
+// notify delivers the latest state of the payment to the subscriber,
+// replacing any state that hasn't been read yet.
+//
+// NOTE: This MUST be called with the control tower's mutex held.
+func (s *paymentSubscriber) notify(payment *channeldb.TrackedPayment) {
+	select {
+	case <-s.updates:
+	default:
+	}
+
+	s.updates <- payment
+}
+
+// controlTower is the ControlTower implementation backed by the payment
+// control of a channeldb instance.
+type controlTower struct {
+	db *channeldb.DB
+
+	// mtx serializes all updates of payments, so that subscribers are
+	// notified of them in the order they were written.
+	mtx              sync.Mutex
+	subscribers      map[[32]byte]map[uint64]*paymentSubscriber
+	nextSubscriberID uint64
+}
+
+// A compile time check to ensure controlTower meets the ControlTower
+// interface.
+var _ ControlTower = (*controlTower)(nil)
+
+// NewControlTower creates a new ControlTower which records payments within
+// the passed database.
+func NewControlTower(db *channeldb.DB) ControlTower {
+	return &controlTower{
+		db:          db,
+		subscribers: make(map[[32]byte]map[uint64]*paymentSubscriber),
+	}
+}
+
+// InitPayment records that a new payment is about to be sent.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) InitPayment(info *channeldb.PaymentCreationInfo) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.db.InitPayment(info); err != nil {
+		return err
+	}
+
+	c.notifySubscribers(info.PaymentHash)
+	return nil
+}
+
+// RegisterAttempt records an attempt of an in-flight payment.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) RegisterAttempt(paymentHash [32]byte,
+	attempt *channeldb.PaymentAttemptInfo) error {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.db.RegisterPaymentAttempt(paymentHash, attempt); err != nil {
+		return err
+	}
+
+	c.notifySubscribers(paymentHash)
+	return nil
+}
+
+// FailAttempt records that the HTLC of an attempt has failed.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) FailAttempt(paymentHash [32]byte,
+	paymentID uint64) error {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.db.FailPaymentAttempt(paymentHash, paymentID); err != nil {
+		return err
+	}
+
+	c.notifySubscribers(paymentHash)
+	return nil
+}
+
+// Success records that a payment has been settled by its destination.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) Success(paymentHash, preimage [32]byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.db.SettlePayment(paymentHash, preimage); err != nil {
+		return err
+	}
+
+	c.notifySubscribers(paymentHash)
+	return nil
+}
+
+// Fail records that a payment has failed.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) Fail(paymentHash [32]byte,
+	reason channeldb.FailureReason, msg string) error {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err := c.db.FailPayment(paymentHash, reason, msg); err != nil {
+		return err
+	}
+
+	c.notifySubscribers(paymentHash)
+	return nil
+}
+
+// FetchPayment returns the current state of a payment.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) FetchPayment(
+	paymentHash [32]byte) (*channeldb.TrackedPayment, error) {
+
+	return c.db.FetchTrackedPayment(paymentHash)
+}
+
+// FetchInFlightPayments returns all payments that are in flight.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) FetchInFlightPayments() ([]*channeldb.TrackedPayment,
+	error) {
+
+	return c.db.FetchInFlightPayments()
+}
+
+// SubscribePayment returns a subscription to the state updates of a payment.
+// If no payment has been initiated for the payment hash, then
+// channeldb.ErrPaymentNotInitiated is returned.
+//
+// NOTE: Part of the ControlTower interface.
+func (c *controlTower) SubscribePayment(
+	paymentHash [32]byte) (*PaymentSubscription, error) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	payment, err := c.db.FetchTrackedPayment(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &paymentSubscriber{
+		id:      c.nextSubscriberID,
+		updates: make(chan *channeldb.TrackedPayment, 1),
+	}
+	c.nextSubscriberID++
+
+	sub.notify(payment)
+
+	// If the payment has already completed, then there won't be any
+	// further updates.
+	if payment.Status != channeldb.StatusInFlight {
+		close(sub.updates)
+		return &PaymentSubscription{
+			Updates: sub.updates,
+			Cancel:  func() {},
+		}, nil
+	}
+
+	if _, ok := c.subscribers[paymentHash]; !ok {
+		c.subscribers[paymentHash] = make(map[uint64]*paymentSubscriber)
+	}
+	c.subscribers[paymentHash][sub.id] = sub
+
+	return &PaymentSubscription{
+		Updates: sub.updates,
+		Cancel: func() {
+			c.mtx.Lock()
+			defer c.mtx.Unlock()
+
+			subs := c.subscribers[paymentHash]
+			if _, ok := subs[sub.id]; !ok {
+				return
+			}
+
+			delete(subs, sub.id)
+			if len(subs) == 0 {
+				delete(c.subscribers, paymentHash)
+			}
+			close(sub.updates)
+		},
+	}, nil
+}
+
+// notifySubscribers sends the current state of the payment to all of its
+// subscribers. Once the payment has completed, its subscriptions are closed.
+//
+// NOTE: This MUST be called with the mutex held.
+func (c *controlTower) notifySubscribers(paymentHash [32]byte) {
+	subs, ok := c.subscribers[paymentHash]
+	if !ok {
+		return
+	}
+
+	payment, err := c.db.FetchTrackedPayment(paymentHash)
+	if err != nil {
+		log.Errorf("Unable to fetch payment %x: %v", paymentHash, err)
+		return
+	}
+
+	for _, sub := range subs {
+		sub.notify(payment)
+	}
+
+	if payment.Status == channeldb.StatusInFlight {
+		return
+	}
+
+	for _, sub := range subs {
+		close(sub.updates)
+	}
+	delete(c.subscribers, paymentHash)
+}
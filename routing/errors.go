@@ -38,6 +38,14 @@ const (
 	// this update can't bring us something new, or because a node
 	// announcement was given for node not found in any channel.
 	ErrIgnored
+
+	// ErrFeeLimitExceeded is returned when the total fee of a route
+	// exceeds the fee limit of the payment.
+	ErrFeeLimitExceeded
+
+	// ErrCltvLimitExceeded is returned when the total time lock of a
+	// route exceeds the CLTV limit of the payment.
+	ErrCltvLimitExceeded
 )
 
 // routerError is a structure that represent the error inside the routing package,
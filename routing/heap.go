@@ -1,6 +1,9 @@
 package routing
 
-import "github.com/lightningnetwork/lnd/channeldb"
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
 
 // nodeWithDist is a helper struct that couples the distance from the current
 // source to a node with a pointer to the node itself.
@@ -12,6 +15,14 @@ type nodeWithDist struct {
 	// node is the vertex itself. This pointer can be used to explore all
 	// the outgoing edges (channels) emanating from a node.
 	node *channeldb.LightningNode
+
+	// fee is the total fee charged by the nodes along the path from the
+	// source node to this node.
+	fee lnwire.MilliSatoshi
+
+	// timeLock is the sum of the time lock deltas required by the nodes
+	// along the path from the source node to this node.
+	timeLock uint32
 }
 
 // distanceHeap is a min-distance heap that's used within our path finding
@@ -1,105 +1,270 @@
 package routing
 
 import (
+	"math"
 	"sync"
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
 )
 
 const (
-	// vertexDecay is the decay period of colored vertexes added to
-	// missionControl. Once vertexDecay passes after an entry has been
-	// added to the prune view, it is garbage collected. This value is
-	// larger than edgeDecay as an edge failure typical indicates an
-	// unbalanced channel, while a vertex failure indicates a node is not
-	// online and active.
-	vertexDecay = time.Duration(time.Minute * 5)
-
-	// edgeDecay is the decay period of colored edges added to
-	// missionControl. Once edgeDecay passed after an entry has been added,
-	// it is garbage collected. This value is smaller than vertexDecay as
-	// an edge related failure during payment sending typically indicates
-	// that a channel was unbalanced, a condition which may quickly change.
-	//
-	// TODO(roasbeef): instead use random delay on each?
-	edgeDecay = time.Duration(time.Second * 5)
+	// aprioriHopProbability is the success probability we assign to a
+	// channel for which we have no relevant history. As a channel's
+	// balance is unknown to us, this is a coin flip weighted slightly
+	// towards success, as most channels are able to carry small amounts.
+	aprioriHopProbability = 0.6
+
+	// prevSuccessProbability is the success probability we assign to a
+	// channel that has recently carried an amount at least as large as
+	// the amount we're about to route through it.
+	prevSuccessProbability = 0.95
+
+	// penaltyHalfLife is the time after which a failure has been half
+	// forgotten. Directly after a failure the probability of the failed
+	// channel or node is zero, after which it recovers towards
+	// aprioriHopProbability. A failure that is localized to a channel
+	// typically indicates that the channel was unbalanced, a condition
+	// which may change once other payments flow through it.
+	penaltyHalfLife = time.Hour
+
+	// maxMissionControlResults is the maximum number of payment attempt
+	// results that are kept on disk. Once this limit is reached, the
+	// oldest results are discarded.
+	maxMissionControlResults = 1000
 )
 
+// edgeHistory summarizes the most recent results reported for a single
+// channel.
+type edgeHistory struct {
+	// lastFail is the time of the last reported failure for the channel,
+	// and failAmt the amount that we attempted to route through it at
+	// that time.
+	lastFail time.Time
+	failAmt  lnwire.MilliSatoshi
+
+	// lastSuccess is the time the channel last carried an HTLC, and
+	// successAmt the amount it carried.
+	lastSuccess time.Time
+	successAmt  lnwire.MilliSatoshi
+}
+
 // missionControl contains state which summarizes the past attempts of HTLC
 // routing by external callers when sending payments throughout the network.
 // missionControl remembers the outcome of these past routing attempts (success
 // and failure), and is able to provide hints/guidance to future HTLC routing
-// attempts.  missionControl maintains a decaying network view of the
-// edges/vertexes that should be marked as "pruned" during path finding. This
-// graph view acts as a shared memory during HTLC payment routing attempts.
-// With each execution, if an error is encountered, based on the type of error
-// and the location of the error within the route, an edge or vertex is added
-// to the view. Later sending attempts will then query the view for all the
-// vertexes/edges that should be ignored. Items in the view decay after a set
-// period of time, allowing the view to be dynamic w.r.t network changes.
+// attempts. Each result is persisted to the database, so the view survives a
+// restart of the daemon. From these results, missionControl derives a success
+// probability for each edge in the graph, which path finding takes into
+// account when weighing the edges. The effect of a failure decays over time,
+// allowing the view to be dynamic w.r.t network changes.
 type missionControl struct {
-	// failedEdges maps a short channel ID to be pruned, to the time that
-	// it was added to the prune view. Edges are added to this map if a
-	// caller reports to missionControl a failure localized to that edge
-	// when sending a payment.
-	failedEdges map[uint64]time.Time
-
-	// failedVertexes maps a node's public key that should be pruned, to
-	// the time that it was added to the prune view. Vertexes are added to
-	// this map if a caller reports to missionControl a failure localized
-	// to that particular vertex.
+	// edges maps a short channel ID to the summary of the results that
+	// have been reported for that channel.
+	edges map[uint64]*edgeHistory
+
+	// failedVertexes maps a node's public key to the time of the last
+	// failure that was localized to that particular vertex.
 	failedVertexes map[Vertex]time.Time
 
 	graph *channeldb.ChannelGraph
 
 	selfNode *channeldb.LightningNode
 
-	sync.Mutex
+	// now is used to obtain the current time. It is a field so that tests
+	// can control the passage of time.
+	now func() time.Time
 
-	// TODO(roasbeef): further counters, if vertex continually unavailable,
-	// add to another generation
+	sync.Mutex
 
 	// TODO(roasbeef): also add favorable metrics for nodes
 }
 
-// newMissionControl returns a new instance of missionControl.
-//
-// TODO(roasbeef): persist memory
+// newMissionControl returns a new instance of missionControl, populated with
+// the payment attempt results that were persisted by prior executions.
 func newMissionControl(g *channeldb.ChannelGraph,
-	selfNode *channeldb.LightningNode) *missionControl {
+	selfNode *channeldb.LightningNode) (*missionControl, error) {
 
-	return &missionControl{
-		failedEdges:    make(map[uint64]time.Time),
+	m := &missionControl{
+		edges:          make(map[uint64]*edgeHistory),
 		failedVertexes: make(map[Vertex]time.Time),
 		selfNode:       selfNode,
 		graph:          g,
+		now:            time.Now,
+	}
+
+	results, err := g.Database().FetchMissionControlResults()
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		m.applyResult(result)
 	}
+
+	log.Debugf("Mission Control loaded %v payment attempt results",
+		len(results))
+
+	return m, nil
 }
 
-// ReportVertexFailure adds a vertex to the graph prune view after a client
-// reports a routing failure localized to the vertex. The time the vertex was
-// added is noted, as it'll be pruned from the view after a period of
-// vertexDecay.
-func (m *missionControl) ReportVertexFailure(v Vertex) {
-	log.Debugf("Reporting vertex %v failure to Mission Control", v)
+// applyResult updates the in-memory view with a single payment attempt
+// result. Results that are older than what we already know of are ignored.
+//
+// NOTE: The caller MUST hold the missionControl mutex, unless the instance
+// has yet to be shared.
+func (m *missionControl) applyResult(result *channeldb.MissionControlResult) {
+	if result.ChanID == 0 {
+		// Only failures are recorded for vertexes, as a successful
+		// forward is attributed to the channels of the route.
+		v := Vertex(result.Node)
+		if result.Timestamp.After(m.failedVertexes[v]) {
+			m.failedVertexes[v] = result.Timestamp
+		}
+		return
+	}
+
+	history, ok := m.edges[result.ChanID]
+	if !ok {
+		history = &edgeHistory{}
+		m.edges[result.ChanID] = history
+	}
+
+	switch {
+	case result.Success && result.Timestamp.After(history.lastSuccess):
+		history.lastSuccess = result.Timestamp
+		history.successAmt = result.Amt
+
+	case !result.Success && result.Timestamp.After(history.lastFail):
+		history.lastFail = result.Timestamp
+		history.failAmt = result.Amt
+	}
+}
+
+// reportResults applies a set of new payment attempt results to the in-memory
+// view and persists them to disk within a single database transaction.
+func (m *missionControl) reportResults(
+	results ...*channeldb.MissionControlResult) {
 
 	m.Lock()
-	m.failedVertexes[v] = time.Now()
+	for _, result := range results {
+		m.applyResult(result)
+	}
 	m.Unlock()
+
+	err := m.graph.Database().AddMissionControlResults(
+		results, maxMissionControlResults,
+	)
+	if err != nil {
+		log.Errorf("Unable to persist mission control results: %v", err)
+	}
+}
+
+// ReportVertexFailure records a routing failure localized to the vertex. The
+// failure lowers the success probability of all edges leading to the vertex,
+// recovering gradually with a half life of penaltyHalfLife.
+func (m *missionControl) ReportVertexFailure(v Vertex,
+	amt lnwire.MilliSatoshi) {
+
+	log.Debugf("Reporting vertex %x failure to Mission Control", v[:])
+
+	m.reportResults(&channeldb.MissionControlResult{
+		Node:      v,
+		Amt:       amt,
+		Timestamp: m.now(),
+	})
 }
 
-// ReportChannelFailure adds a channel to the graph prune view. The time the
-// channel was added is noted, as it'll be pruned from the view after a period
-// of edgeDecay.
+// ReportChannelFailure records a failure to route amt through the channel.
+// Until the failure decays, the success probability of the channel is lowered
+// for amounts that are at least as large as amt.
+func (m *missionControl) ReportChannelFailure(e uint64,
+	amt lnwire.MilliSatoshi) {
+
+	log.Debugf("Reporting edge %v failure of %v to Mission Control", e, amt)
+
+	m.reportResults(&channeldb.MissionControlResult{
+		ChanID:    e,
+		Amt:       amt,
+		Timestamp: m.now(),
+	})
+}
+
+// ReportRouteSuccess records that each of the channels within the route was
+// able to carry the amount that was sent over it when the payment succeeded.
+func (m *missionControl) ReportRouteSuccess(route *Route) {
+	log.Debugf("Reporting route success to Mission Control")
+
+	now := m.now()
+	results := make([]*channeldb.MissionControlResult, len(route.Hops))
+	for i, hop := range route.Hops {
+		results[i] = &channeldb.MissionControlResult{
+			ChanID:    hop.Channel.ChannelID,
+			Amt:       hop.AmtToForward + hop.Fee,
+			Success:   true,
+			Timestamp: now,
+		}
+	}
+
+	m.reportResults(results...)
+}
+
+// decayedProbability returns the success probability for a channel or node
+// that last failed at the given time. Directly after the failure the
+// probability is zero, after which it approaches aprioriHopProbability.
+func (m *missionControl) decayedProbability(lastFail time.Time) float64 {
+	age := m.now().Sub(lastFail)
+	if age < 0 {
+		return 0
+	}
+
+	exp := -age.Hours() / penaltyHalfLife.Hours()
+	return aprioriHopProbability * (1 - math.Pow(2, exp))
+}
+
+// EdgeProbability returns the estimated probability that the channel with
+// the passed ID, leading to the passed vertex, is able to carry amt.
 //
-// TODO(roasbeef): also add value attempted to send and capacity of channel
-func (m *missionControl) ReportChannelFailure(e uint64) {
-	log.Debugf("Reporting edge %v failure to Mission Control", e)
+// NOTE: This function is safe for concurrent access.
+func (m *missionControl) EdgeProbability(chanID uint64, toNode Vertex,
+	amt lnwire.MilliSatoshi) float64 {
 
 	m.Lock()
-	m.failedEdges[e] = time.Now()
-	m.Unlock()
+	defer m.Unlock()
+
+	probability := aprioriHopProbability
+
+	// First, we'll look at the results that have been reported for the
+	// channel itself. A failure only applies to amounts at least as
+	// large as the amount that failed, while a more recent success tells
+	// us the channel is likely to carry any amount up to the amount that
+	// succeeded.
+	var lastSuccess time.Time
+	if history, ok := m.edges[chanID]; ok {
+		lastSuccess = history.lastSuccess
+
+		switch {
+		case history.lastSuccess.After(history.lastFail) &&
+			amt <= history.successAmt:
+
+			probability = prevSuccessProbability
+
+		case !history.lastFail.IsZero() && amt >= history.failAmt:
+			probability = m.decayedProbability(history.lastFail)
+		}
+	}
+
+	// If the node at the other end of the channel failed after the
+	// channel last succeeded, then we'll also take that failure into
+	// account.
+	lastFail, ok := m.failedVertexes[toNode]
+	if ok && lastFail.After(lastSuccess) {
+		probability = math.Min(
+			probability, m.decayedProbability(lastFail),
+		)
+	}
+
+	return probability
 }
 
 // RequestRoute returns a route which is likely to be capable for successfully
@@ -111,19 +276,35 @@ func (m *missionControl) ReportChannelFailure(e uint64) {
 //
 // NOTE: This function is safe for concurrent access.
 func (m *missionControl) RequestRoute(payment *LightningPayment,
-	height uint32, finalCltvDelta uint16) (*Route, error) {
-
-	// First, we'll query mission control for it's current recommendation
-	// on the edges/vertexes to ignore during path finding.
-	pruneView := m.GraphPruneView()
+	height uint32, finalCltvDelta uint16,
+	weights WeightParams) (*Route, error) {
 
 	// TODO(roasbeef): sync logic amongst dist sys
 
-	// Taking into account this prune view, we'll attempt to locate a path
-	// to our destination, respecting the recommendations from
-	// missionControl.
+	// The CLTV limit of the payment includes the final CLTV delta, while
+	// path finding only accounts for the deltas of the intermediate hops.
+	params := &findPathParams{
+		weights:         weights,
+		edgeProbability: m.EdgeProbability,
+		feeLimit:        payment.FeeLimit,
+		additionalEdges: hintEdges(payment.Target, payment.RouteHints),
+	}
+	if payment.CltvLimit != nil {
+		if *payment.CltvLimit < uint32(finalCltvDelta) {
+			return nil, newErrf(ErrCltvLimitExceeded, "CLTV limit "+
+				"of %v is below the final CLTV delta of %v",
+				*payment.CltvLimit, finalCltvDelta)
+		}
+
+		cltvLimit := *payment.CltvLimit - uint32(finalCltvDelta)
+		params.cltvLimit = &cltvLimit
+	}
+
+	// We'll attempt to locate a path to our destination within the
+	// payment's limits, weighing each edge by its fee, time lock and the
+	// success probability that missionControl assigns to it.
 	path, err := findPath(nil, m.graph, m.selfNode, payment.Target,
-		pruneView.vertexes, pruneView.edges, payment.Amount)
+		nil, nil, payment.Amount, params)
 	if err != nil {
 		return nil, err
 	}
@@ -139,79 +320,53 @@ func (m *missionControl) RequestRoute(payment *LightningPayment,
 		return nil, err
 	}
 
-	return route, err
+	// As path finding estimates the fee of each hop using the payment
+	// amount rather than the amount including the fees of later hops,
+	// we'll verify the final route against the limits once more.
+	err = checkRouteLimits(route, height, payment.FeeLimit, payment.CltvLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return route, nil
 }
 
-// GraphPruneView returns a new graphPruneView instance which is to be
-// consulted during path finding. If a vertex/edge is found within the returned
-// prune view, it is to be ignored as a goroutine has had issues routing
-// through it successfully. Within this method the main view of the
-// missionControl is garbage collected as entires are detected to be "stale".
-func (m *missionControl) GraphPruneView() *graphPruneView {
-	// First, we'll grab the current time, this value will be used to
-	// determine if an entry is stale or not.
-	now := time.Now()
+// FetchResults returns all payment attempt results that are currently
+// persisted, ordered from oldest to newest.
+func (m *missionControl) FetchResults() ([]*channeldb.MissionControlResult,
+	error) {
 
-	m.Lock()
+	return m.graph.Database().FetchMissionControlResults()
+}
 
-	// For each of the vertexes that have been added to the prune view, if
-	// it is now "stale", then we'll ignore it and avoid adding it to the
-	// view we'll return.
-	vertexes := make(map[Vertex]struct{})
-	for vertex, pruneTime := range m.failedVertexes {
-		if now.Sub(pruneTime) >= vertexDecay {
-			log.Tracef("Pruning decayed failure report for vertex %v "+
-				"from Mission Control", vertex)
-
-			delete(m.failedVertexes, vertex)
-			continue
-		}
+// ImportResults adds a set of payment attempt results, for example exported
+// from another node, to our history.
+func (m *missionControl) ImportResults(
+	results []*channeldb.MissionControlResult) error {
 
-		vertexes[vertex] = struct{}{}
+	err := m.graph.Database().AddMissionControlResults(
+		results, maxMissionControlResults,
+	)
+	if err != nil {
+		return err
 	}
 
-	// We'll also do the same for edges, but use the edgeDecay this time
-	// rather than the decay for vertexes.
-	edges := make(map[uint64]struct{})
-	for edge, pruneTime := range m.failedEdges {
-		if now.Sub(pruneTime) >= edgeDecay {
-			log.Tracef("Pruning decayed failure report for edge %v "+
-				"from Mission Control", edge)
-
-			delete(m.failedEdges, edge)
-			continue
-		}
-
-		edges[edge] = struct{}{}
+	m.Lock()
+	for _, result := range results {
+		m.applyResult(result)
 	}
-
 	m.Unlock()
 
-	log.Debugf("Mission Control returning prune view of %v edges, %v "+
-		"vertexes", len(edges), len(vertexes))
-
-	return &graphPruneView{
-		edges:    edges,
-		vertexes: vertexes,
-	}
-}
-
-// graphPruneView is a filter of sorts that path finding routines should
-// consult during the execution. Any edges or vertexes within the view should
-// be ignored during path finding. The contents of the view reflect the current
-// state of the wider network from the PoV of mission control compiled via HTLC
-// routing attempts in the past.
-type graphPruneView struct {
-	edges map[uint64]struct{}
-
-	vertexes map[Vertex]struct{}
+	return nil
 }
 
 // ResetHistory resets the history of missionControl returning it to a state as
 // if no payment attempts have been made.
-func (m *missionControl) ResetHistory() {
+func (m *missionControl) ResetHistory() error {
 	m.Lock()
-	m.failedEdges = make(map[uint64]time.Time)
+	m.edges = make(map[uint64]*edgeHistory)
 	m.failedVertexes = make(map[Vertex]time.Time)
 	m.Unlock()
+
+	return m.graph.Database().ResetMissionControl()
 }
@@ -0,0 +1,101 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestMissionControlProbability asserts that the success probability of an
+// edge reflects the results reported for the edge and the node it leads to,
+// and that the history survives a restart of mission control.
+func TestMissionControlProbability(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to create test graph: %v", err)
+	}
+	defer cleanUp()
+
+	mc, err := newMissionControl(graph, nil)
+	if err != nil {
+		t.Fatalf("unable to create mission control: %v", err)
+	}
+
+	now := time.Unix(1000000, 0)
+	mc.now = func() time.Time { return now }
+
+	const chanID = 1
+	var node Vertex
+	node[0] = 0x02
+
+	assertProbability := func(amt lnwire.MilliSatoshi, expected float64) {
+		p := mc.EdgeProbability(chanID, node, amt)
+		if p < expected-0.001 || p > expected+0.001 {
+			t.Fatalf("expected probability %v for amt %v, got %v",
+				expected, amt, p)
+		}
+	}
+
+	// Without any history, we should fall back to the a-priori
+	// probability.
+	assertProbability(1000, aprioriHopProbability)
+
+	// Directly after a failure, the edge should be considered unusable for
+	// the failed amount and anything larger, but not for smaller amounts.
+	mc.ReportChannelFailure(chanID, 1000)
+	assertProbability(1000, 0)
+	assertProbability(2000, 0)
+	assertProbability(500, aprioriHopProbability)
+
+	// After one half life, the failure should be half forgotten.
+	now = now.Add(penaltyHalfLife)
+	assertProbability(1000, aprioriHopProbability/2)
+
+	// A success for a smaller amount should make us confident about that
+	// amount, while the failure still applies to the larger amount.
+	mc.ReportRouteSuccess(&Route{
+		Hops: []*Hop{{
+			Channel: &ChannelHop{
+				ChannelEdgePolicy: &channeldb.ChannelEdgePolicy{
+					ChannelID: chanID,
+				},
+			},
+			AmtToForward: 400,
+			Fee:          100,
+		}},
+	})
+	assertProbability(500, prevSuccessProbability)
+	assertProbability(1000, aprioriHopProbability/2)
+
+	// A subsequent failure of the node the edge leads to should override
+	// the prior success.
+	now = now.Add(time.Second)
+	mc.ReportVertexFailure(node, 500)
+	assertProbability(500, 0)
+
+	// Restarting mission control should restore the same view from disk.
+	mc, err = newMissionControl(graph, nil)
+	if err != nil {
+		t.Fatalf("unable to create mission control: %v", err)
+	}
+	mc.now = func() time.Time { return now }
+	assertProbability(500, 0)
+
+	// Finally, once the history has been reset, we should be back to the
+	// a-priori probability, both before and after a restart.
+	if err := mc.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset history: %v", err)
+	}
+	assertProbability(500, aprioriHopProbability)
+
+	mc, err = newMissionControl(graph, nil)
+	if err != nil {
+		t.Fatalf("unable to create mission control: %v", err)
+	}
+	mc.now = func() time.Time { return now }
+	assertProbability(500, aprioriHopProbability)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -25,6 +26,11 @@ const (
 
 	// infinity is used as a starting distance in our shortest path search.
 	infinity = math.MaxFloat64
+
+	// hintEdgeCapacity is the capacity assumed for the private channels
+	// described by route hints. As their actual capacity is unknown, we'll
+	// optimistically assume that they're able to carry any payment.
+	hintEdgeCapacity = btcutil.MaxSatoshi
 )
 
 // ChannelHop is an intermediate hop within the network with a greater
@@ -120,6 +126,19 @@ type Route struct {
 	// amount of fees.
 	TotalAmount lnwire.MilliSatoshi
 
+	// PaymentTotal is the total amount of the payment this route carries a
+	// shard of. If non-zero, the payment has been split across several
+	// routes, and the final hop is to hold the HTLC until HTLCs adding up
+	// to this amount have arrived. This value is communicated to the final
+	// hop within its per-hop payload.
+	PaymentTotal lnwire.MilliSatoshi
+
+	// CustomRecords is the set of custom records to be delivered to the
+	// final hop within its onion payload. As the payload of each hop is
+	// of a fixed size, these records are carried within additional frames
+	// of the onion that are encrypted to the final hop.
+	CustomRecords htlcswitch.CustomRecords
+
 	// Hops contains details concerning the specific forwarding details at
 	// each hop.
 	Hops []*Hop
@@ -154,6 +173,20 @@ func (r *Route) nextHopChannel(n *btcec.PublicKey) (uint64, bool) {
 	return hop.ChannelID, ok
 }
 
+// channelAmount returns the amount that is sent over the channel with the
+// passed ID within the route. This is the amount the hop at the end of the
+// channel forwards, plus the fee it collects for doing so. If the channel
+// isn't part of the route, then zero is returned.
+func (r *Route) channelAmount(chanID uint64) lnwire.MilliSatoshi {
+	for _, hop := range r.Hops {
+		if hop.Channel.ChannelID == chanID {
+			return hop.AmtToForward + hop.Fee
+		}
+	}
+
+	return 0
+}
+
 // containsNode returns true if a node is present in the target route, and
 // false otherwise.
 func (r *Route) containsNode(v Vertex) bool {
@@ -169,6 +202,17 @@ func (r *Route) containsChannel(chanID uint64) bool {
 	return ok
 }
 
+// nodePath returns the compressed public keys of the nodes along the route,
+// excluding our own node.
+func (r *Route) nodePath() [][33]byte {
+	path := make([][33]byte, len(r.Hops))
+	for i, hop := range r.Hops {
+		copy(path[i][:], hop.Channel.Node.PubKey.SerializeCompressed())
+	}
+
+	return path
+}
+
 // ToHopPayloads converts a complete route into the series of per-hop payloads
 // that is to be encoded within each HTLC using an opaque Sphinx packet.
 func (r *Route) ToHopPayloads() []sphinx.HopData {
@@ -200,6 +244,15 @@ func (r *Route) ToHopPayloads() []sphinx.HopData {
 			nextHop)
 	}
 
+	// If this route carries a single shard of a larger payment, then the
+	// total amount of the payment is placed within the padding bytes of
+	// the final hop's payload.
+	if r.PaymentTotal != 0 {
+		finalHop := &hopPayloads[len(hopPayloads)-1]
+		binary.BigEndian.PutUint64(finalHop.ExtraBytes[:8],
+			uint64(r.PaymentTotal))
+	}
+
 	return hopPayloads
 }
 
@@ -375,14 +428,141 @@ type edgeWithPrev struct {
 	prevNode *btcec.PublicKey
 }
 
+// minProbability is the lowest success probability an edge may have for it
+// to still be considered during path finding. Edges that have failed very
+// recently fall below this threshold, and are skipped entirely.
+const minProbability = 0.01
+
+// edgeProbabilitySource is a function that returns the estimated probability
+// that the channel with the given ID, leading to the target vertex, is able to
+// carry the specified amount.
+type edgeProbabilitySource func(chanID uint64, toNode Vertex,
+	amt lnwire.MilliSatoshi) float64
+
+// WeightParams are the parameters of the cost function that path finding
+// uses to weigh the edges of the channel graph against each other.
+type WeightParams struct {
+	// RiskFactorBillionths is the cost of having a payment locked up for a
+	// single block, expressed in parts per billion of the payment amount.
+	// The higher this value, the more path finding will prefer routes with
+	// a low total time lock over cheaper routes.
+	RiskFactorBillionths uint64
+
+	// AttemptCost is the virtual cost of a payment attempt. It is used to
+	// translate the success probability of an edge into a cost that can be
+	// weighed against fees: an edge with a success probability of p costs
+	// AttemptCost/p. If zero, success probabilities are only used to skip
+	// edges that have failed very recently.
+	AttemptCost lnwire.MilliSatoshi
+}
+
+// DefaultWeightParams is the set of weight parameters used if none are
+// specified.
+var DefaultWeightParams = WeightParams{
+	RiskFactorBillionths: 15,
+	AttemptCost:          lnwire.NewMSatFromSatoshis(100),
+}
+
 // edgeWeight computes the weight of an edge. This value is used when searching
-// for the shortest path within the channel graph between two nodes. Currently
-// this is just 1 + the cltv delta value required at this hop, this value
-// should be tuned with experimental and empirical data.
-//
-// TODO(roasbeef): compute robust weight metric
-func edgeWeight(e *channeldb.ChannelEdgePolicy) float64 {
-	return float64(1 + e.TimeLockDelta)
+// for the shortest path within the channel graph between two nodes. The weight
+// is the fee charged for forwarding amt over the edge, plus a penalty for the
+// time the amount will be locked up due to the edge's time lock delta. Both
+// are expressed in milli-satoshis.
+func edgeWeight(amt, fee lnwire.MilliSatoshi, timeLockDelta uint16,
+	params *WeightParams) float64 {
+
+	timeLockPenalty := float64(amt) * float64(timeLockDelta) *
+		float64(params.RiskFactorBillionths) / 1000000000
+
+	return float64(fee) + timeLockPenalty
+}
+
+// findPathParams wraps the optional parameters that steer findPath: the
+// weights of the cost function, the success probability of each edge, and
+// the limits the found path must adhere to.
+type findPathParams struct {
+	// weights are the parameters of the cost function used to weigh each
+	// edge.
+	weights WeightParams
+
+	// edgeProbability, if non-nil, returns the success probability of an
+	// edge, which is added to the edge's weight as described in
+	// WeightParams.
+	edgeProbability edgeProbabilitySource
+
+	// feeLimit, if non-nil, is the maximum total fee the path may charge.
+	feeLimit *lnwire.MilliSatoshi
+
+	// cltvLimit, if non-nil, is the maximum sum of the time lock deltas
+	// along the path. This excludes the delta of the final hop.
+	cltvLimit *uint32
+
+	// additionalEdges is a set of edges that aren't part of the channel
+	// graph, indexed by the node they emanate from. These are usually the
+	// private channels described by the route hints of an invoice.
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy
+}
+
+// HopHint describes a private channel that is unknown to the channel graph,
+// yet may be used to route a payment to its destination. Hints are obtained
+// from the invoice of the payment.
+type HopHint struct {
+	// NodeID is the public key of the node at the start of the channel.
+	NodeID *btcec.PublicKey
+
+	// ChannelID is the short channel ID of the channel.
+	ChannelID uint64
+
+	// FeeBaseMSat is the base fee charged by NodeID for forwarding over
+	// the channel.
+	FeeBaseMSat uint32
+
+	// FeeProportionalMillionths is the fee rate charged by NodeID for
+	// forwarding over the channel.
+	FeeProportionalMillionths uint32
+
+	// CLTVExpiryDelta is the time lock delta required by NodeID for
+	// forwarding over the channel.
+	CLTVExpiryDelta uint16
+}
+
+// hintEdges converts the passed route hints into the set of additional edges
+// used during path finding. Each route hint describes a path towards the
+// target, so the channel of each hop leads to the node of the next hop, and
+// the channel of the last hop to the target itself.
+func hintEdges(target *btcec.PublicKey,
+	routeHints [][]HopHint) map[Vertex][]*channeldb.ChannelEdgePolicy {
+
+	if len(routeHints) == 0 {
+		return nil
+	}
+
+	edges := make(map[Vertex][]*channeldb.ChannelEdgePolicy)
+	for _, routeHint := range routeHints {
+		for i, hop := range routeHint {
+			toNode := *target
+			if i < len(routeHint)-1 {
+				toNode = *routeHint[i+1].NodeID
+			}
+
+			// The key is copied, as newRoute strips the curve of
+			// the keys within the path.
+			edge := &channeldb.ChannelEdgePolicy{
+				Node: &channeldb.LightningNode{
+					PubKey: &toNode,
+				},
+				ChannelID:                 hop.ChannelID,
+				FeeBaseMSat:               lnwire.MilliSatoshi(hop.FeeBaseMSat),
+				FeeProportionalMillionths: lnwire.MilliSatoshi(hop.FeeProportionalMillionths),
+				TimeLockDelta:             hop.CLTVExpiryDelta,
+			}
+
+			from := NewVertex(hop.NodeID)
+			edges[from] = append(edges[from], edge)
+		}
+	}
+
+	return edges
 }
 
 // findPath attempts to find a path from the source node within the
@@ -393,10 +573,18 @@ func edgeWeight(e *channeldb.ChannelEdgePolicy) float64 {
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
 // from the target to the source.
+//
+// The passed params determine how edges are weighed, which limits the path
+// must adhere to, and which edges outside of the graph may be used. If nil,
+// each edge is weighed solely by its fee, and no limits are enforced.
 func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
 	ignoredNodes map[Vertex]struct{}, ignoredEdges map[uint64]struct{},
-	amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, params *findPathParams) ([]*ChannelHop, error) {
+
+	if params == nil {
+		params = &findPathParams{}
+	}
 
 	var err error
 	if tx == nil {
@@ -428,6 +616,23 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		return nil, err
 	}
 
+	// The additional edges may lead to nodes that aren't part of the
+	// graph, such as a destination that only has private channels, so
+	// we'll add those to the distance map as well.
+	for _, edges := range params.additionalEdges {
+		for _, edge := range edges {
+			v := NewVertex(edge.Node.PubKey)
+			if _, ok := distance[v]; ok {
+				continue
+			}
+
+			distance[v] = nodeWithDist{
+				dist: infinity,
+				node: edge.Node,
+			}
+		}
+	}
+
 	// TODO(roasbeef): also add path caching
 	//  * similar to route caching, but doesn't factor in the amount
 
@@ -435,6 +640,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// distance map with with a distance of 0. This indicates our starting
 	// point in the graph traversal.
 	sourceVertex := NewVertex(sourceNode.PubKey)
+	targetVertex := NewVertex(target)
 	distance[sourceVertex] = nodeWithDist{
 		dist: 0,
 		node: sourceNode,
@@ -460,13 +666,11 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			break
 		}
 
-		// Now that we've found the next potential step to take we'll
-		// examine all the outgoing edge (channels) from this node to
-		// further our graph traversal.
+		// processEdge relaxes the passed outgoing edge of the current
+		// pivot, given the capacity of its channel.
 		pivot := NewVertex(bestNode.PubKey)
-		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
-			edgeInfo *channeldb.ChannelEdgeInfo,
-			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+		processEdge := func(outEdge *channeldb.ChannelEdgePolicy,
+			capacity btcutil.Amount) {
 
 			v := NewVertex(outEdge.Node.PubKey)
 
@@ -475,23 +679,76 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// through it.
 			edgeFlags := lnwire.ChanUpdateFlag(outEdge.Flags)
 			if edgeFlags&lnwire.ChanUpdateDisabled == lnwire.ChanUpdateDisabled {
-				return nil
+				return
 			}
 
 			// If this Vertex or edge has been black listed, then
 			// we'll skip exploring this edge during this
 			// iteration.
 			if _, ok := ignoredNodes[v]; ok {
-				return nil
+				return
 			}
 			if _, ok := ignoredEdges[outEdge.ChannelID]; ok {
-				return nil
+				return
+			}
+
+			// The fee of an edge is charged by the node that
+			// forwards the HTLC over it, so as we don't charge
+			// ourselves, edges emanating from the source are free.
+			// Mirroring newRoute, the time lock delta of each edge
+			// other than the one reaching the target adds to the
+			// total time lock, as the final hop uses the final
+			// CLTV delta instead.
+			var (
+				fee           lnwire.MilliSatoshi
+				timeLockDelta uint16
+			)
+			if pivot != sourceVertex {
+				fee = computeFee(amt, &ChannelHop{
+					ChannelEdgePolicy: outEdge,
+				})
+			}
+			if v != targetVertex {
+				timeLockDelta = outEdge.TimeLockDelta
+			}
+
+			// If taking this edge would exceed either the fee or
+			// the time lock limit, then we won't explore it any
+			// further.
+			totalFee := distance[pivot].fee + fee
+			if params.feeLimit != nil && totalFee > *params.feeLimit {
+				return
+			}
+			totalTimeLock := distance[pivot].timeLock +
+				uint32(timeLockDelta)
+			if params.cltvLimit != nil &&
+				totalTimeLock > *params.cltvLimit {
+
+				return
+			}
+
+			// If we have an estimate of the success probability of
+			// this edge, then we'll skip it if a payment is
+			// unlikely to succeed, and otherwise add the cost of
+			// a failed attempt to its weight.
+			weight := edgeWeight(amt, fee, timeLockDelta,
+				&params.weights)
+			if params.edgeProbability != nil {
+				probability := params.edgeProbability(
+					outEdge.ChannelID, v, amt,
+				)
+				if probability < minProbability {
+					return
+				}
+
+				weight += float64(params.weights.AttemptCost) /
+					probability
 			}
 
 			// Compute the tentative distance to this new
 			// channel/edge which is the distance to our current
 			// pivot node plus the weight of this edge.
-			tempDist := distance[pivot].dist + edgeWeight(outEdge)
+			tempDist := distance[pivot].dist + weight
 
 			// If this new tentative distance is better than the
 			// current best known distance to this node, then we
@@ -501,12 +758,14 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// capacity of an edge and clearing their min-htlc
 			// amount to our relaxation condition.
 			if tempDist < distance[v].dist &&
-				edgeInfo.Capacity >= amt.ToSatoshis() &&
+				capacity >= amt.ToSatoshis() &&
 				amt >= outEdge.MinHTLC {
 
 				distance[v] = nodeWithDist{
-					dist: tempDist,
-					node: outEdge.Node,
+					dist:     tempDist,
+					node:     outEdge.Node,
+					fee:      totalFee,
+					timeLock: totalTimeLock,
 				}
 				prev[v] = edgeWithPrev{
 					// We'll use the *incoming* edge here
@@ -515,7 +774,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 					// connects to.
 					edge: &ChannelHop{
 						ChannelEdgePolicy: outEdge,
-						Capacity:          edgeInfo.Capacity,
+						Capacity:          capacity,
 					},
 					prevNode: bestNode.PubKey,
 				}
@@ -526,17 +785,32 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			}
 
 			// TODO(roasbeef): return min HTLC as error in end?
+		}
 
+		// Now that we've found the next potential step to take we'll
+		// examine all the outgoing edge (channels) from this node to
+		// further our graph traversal.
+		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
+			edgeInfo *channeldb.ChannelEdgeInfo,
+			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
+
+			processEdge(outEdge, edgeInfo.Capacity)
 			return nil
 		})
 		if err != nil {
 			return nil, err
 		}
+
+		// We'll also examine the edges of this node that aren't part
+		// of the graph.
+		for _, outEdge := range params.additionalEdges[pivot] {
+			processEdge(outEdge, hintEdgeCapacity)
+		}
 	}
 
 	// If the target node isn't found in the prev hop map, then a path
 	// doesn't exist, so we terminate in an error.
-	if _, ok := prev[NewVertex(target)]; !ok {
+	if _, ok := prev[targetVertex]; !ok {
 		return nil, newErrf(ErrNoPathFound, "unable to find a path to "+
 			"destination")
 	}
@@ -546,7 +820,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// in the reverse direction which we'll use to properly calculate the
 	// timelock and fee values.
 	pathEdges := make([]*ChannelHop, 0, len(prev))
-	prevNode := NewVertex(target)
+	prevNode := targetVertex
 	for prevNode != sourceVertex { // TODO(roasbeef): assumes no cycles
 		// Add the current hop to the limit of path edges then walk
 		// backwards from this hop via the prev pointer for this hop
@@ -590,12 +864,18 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 // algorithm in a block box manner.
 func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	source *channeldb.LightningNode, target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi) ([][]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, weights WeightParams,
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy) (
+	[][]*ChannelHop, error) {
 
 	// TODO(roasbeef): take in db tx
 
 	ignoredEdges := make(map[uint64]struct{})
 	ignoredVertexes := make(map[Vertex]struct{})
+	params := &findPathParams{
+		weights:         weights,
+		additionalEdges: additionalEdges,
+	}
 
 	// TODO(roasbeef): modifying ordering within heap to eliminate final
 	// sorting step?
@@ -608,7 +888,7 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// selfNode) to the target destination that's capable of carrying amt
 	// satoshis along the path before fees are calculated.
 	startingPath, err := findPath(tx, graph, source, target,
-		ignoredVertexes, ignoredEdges, amt)
+		ignoredVertexes, ignoredEdges, amt, params)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
 		return nil, err
@@ -680,7 +960,7 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// root path removed, we'll attempt to find another
 			// shortest path from the spur node to the destination.
 			spurPath, err := findPath(tx, graph, spurNode, target,
-				ignoredVertexes, ignoredEdges, amt)
+				ignoredVertexes, ignoredEdges, amt, params)
 
 			// If we weren't able to find a path, we'll continue to
 			// the next round.
@@ -317,7 +317,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["sophon"]
 	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -416,7 +416,7 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// should be selected.
 	target = aliases["luoji"]
 	path, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -473,7 +473,8 @@ func TestKShortestPathFinding(t *testing.T) {
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["luoji"]
-	paths, err := findPaths(nil, graph, sourceNode, target, paymentAmt)
+	paths, err := findPaths(nil, graph, sourceNode, target, paymentAmt,
+		DefaultWeightParams, nil)
 	if err != nil {
 		t.Fatalf("unable to find paths between roasbeef and "+
 			"luo ji: %v", err)
@@ -535,7 +536,7 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// Alice should be able to find a valid route to ursula.
 	target := aliases["ursula"]
 	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+		ignoredEdges, paymentAmt, nil)
 	if err != nil {
 		t.Fatalf("path should have been found")
 	}
@@ -544,7 +545,7 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// presented to Alice.
 	target = aliases["vincent"]
 	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+		ignoredEdges, paymentAmt, nil)
 	if err == nil {
 		t.Fatalf("should not have been able to find path, supposed to be "+
 			"greater than 20 hops, found route with %v hops",
@@ -584,12 +585,84 @@ func TestPathNotAvailable(t *testing.T) {
 	}
 
 	_, err = findPath(nil, graph, sourceNode, unknownNode, ignoredVertexes,
-		ignoredEdges, 100)
+		ignoredEdges, 100, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("path shouldn't have been found: %v", err)
 	}
 }
 
+// TestPathFindingRouteHints tests that a target which is unknown to the graph
+// can be reached through the private channels described by route hints, and
+// that the policies of the hints are applied to the resulting route.
+func TestPathFindingRouteHints(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	privateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	target := privateKey.PubKey()
+
+	// Without any hints, the private target can't be reached.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, nil)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("path shouldn't have been found: %v", err)
+	}
+
+	// We'll now add a hint for a private channel between sophon and the
+	// target, which should extend the path to sophon by a single hop.
+	const privateChanID = 999999
+	routeHints := [][]HopHint{{{
+		NodeID:                    aliases["sophon"],
+		ChannelID:                 privateChanID,
+		FeeBaseMSat:               1000,
+		FeeProportionalMillionths: 0,
+		CLTVExpiryDelta:           40,
+	}}}
+	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
+		ignoredEdges, paymentAmt, &findPathParams{
+			weights:         DefaultWeightParams,
+			additionalEdges: hintEdges(target, routeHints),
+		})
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+	if len(path) != 3 {
+		t.Fatalf("expected path of length 3, instead got %v", len(path))
+	}
+	if path[2].ChannelID != privateChanID {
+		t.Fatalf("expected last hop over channel %v, got %v",
+			privateChanID, path[2].ChannelID)
+	}
+
+	// The route should pay sophon the fee of the private channel.
+	sourceVertex := NewVertex(sourceNode.PubKey)
+	route, err := newRoute(paymentAmt, sourceVertex, path, 100,
+		DefaultFinalCLTVDelta)
+	if err != nil {
+		t.Fatalf("unable to create route: %v", err)
+	}
+	if route.Hops[1].Fee != 1000 {
+		t.Fatalf("expected sophon to charge 1000 msat, got %v",
+			route.Hops[1].Fee)
+	}
+}
+
 func TestPathInsufficientCapacity(t *testing.T) {
 	t.Parallel()
 
@@ -618,12 +691,67 @@ func TestPathInsufficientCapacity(t *testing.T) {
 
 	const payAmt = btcutil.SatoshiPerBitcoin
 	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+		ignoredEdges, payAmt, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
 }
 
+// TestPathFindingLimits tests that path finding doesn't return paths that
+// exceed the passed fee or CLTV limit.
+func TestPathFindingLimits(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	ignoredEdges := make(map[uint64]struct{})
+	ignoredVertexes := make(map[Vertex]struct{})
+
+	// The only path from roasbeef to sophon passes through songoku, which
+	// charges a fee for forwarding the payment. The time lock delta of
+	// the first hop also adds to the total time lock of the path.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+	fee := lnwire.MilliSatoshi(10 + 100000*1000/1000000)
+
+	findPathWithLimits := func(feeLimit lnwire.MilliSatoshi,
+		cltvLimit uint32) error {
+
+		_, err := findPath(nil, graph, sourceNode, target,
+			ignoredVertexes, ignoredEdges, paymentAmt,
+			&findPathParams{
+				weights:   DefaultWeightParams,
+				feeLimit:  &feeLimit,
+				cltvLimit: &cltvLimit,
+			})
+		return err
+	}
+
+	// With limits that the path satisfies exactly, we should be able to
+	// find it.
+	if err := findPathWithLimits(fee, 1); err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+
+	// However, if we lower either limit, then no path should be found.
+	err = findPathWithLimits(fee-1, 1)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("path shouldn't be found below fee limit: %v", err)
+	}
+	err = findPathWithLimits(fee, 0)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("path shouldn't be found below CLTV limit: %v", err)
+	}
+}
+
 // TestRouteFailMinHTLC tests that if we attempt to route an HTLC which is
 // smaller than the advertised minHTLC of an edge, then path finding fails.
 func TestRouteFailMinHTLC(t *testing.T) {
@@ -646,7 +774,7 @@ func TestRouteFailMinHTLC(t *testing.T) {
 	target := aliases["songoku"]
 	payAmt := lnwire.MilliSatoshi(10)
 	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+		ignoredEdges, payAmt, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -674,7 +802,7 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	target := aliases["songoku"]
 	payAmt := lnwire.NewMSatFromSatoshis(10000)
 	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+		ignoredEdges, payAmt, nil)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -693,7 +821,7 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	// Now, if we attempt to route through that edge, we should get a
 	// failure as it is no longer elligble.
 	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+		ignoredEdges, payAmt, nil)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -744,7 +872,7 @@ func TestPathFindSpecExample(t *testing.T) {
 	// Query for a route of 4,999,999 mSAT to carol.
 	carol := ctx.aliases["C"]
 	const amt lnwire.MilliSatoshi = 4999999
-	routes, err := ctx.router.FindRoutes(carol, amt)
+	routes, err := ctx.router.FindRoutes(carol, amt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -804,7 +932,7 @@ func TestPathFindSpecExample(t *testing.T) {
 
 	// We'll now request a route from A -> B -> C.
 	ctx.router.routeCache = make(map[routeTuple][]*Route)
-	routes, err = ctx.router.FindRoutes(carol, amt)
+	routes, err = ctx.router.FindRoutes(carol, amt, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unable to find routes: %v", err)
 	}
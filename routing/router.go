@@ -115,11 +115,25 @@ type Config struct {
 
 	// SendToSwitch is a function that directs a link-layer switch to
 	// forward a fully encoded payment to the first hop in the route
-	// denoted by its public key. A non-nil error is to be returned if the
+	// denoted by its public key. The HTLC is identified within the switch
+	// by the passed payment ID. A non-nil error is to be returned if the
 	// payment was unsuccessful.
-	SendToSwitch func(firstHop *btcec.PublicKey, htlcAdd *lnwire.UpdateAddHTLC,
+	SendToSwitch func(firstHop *btcec.PublicKey, paymentID uint64,
+		htlcAdd *lnwire.UpdateAddHTLC,
 		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
 
+	// GetPaymentResult blocks until the HTLC with the passed payment ID,
+	// which was sent before the last restart, has been resolved by the
+	// switch. The passed circuit is used to decrypt the failure of the
+	// HTLC. If the switch has no record of the HTLC, then
+	// htlcswitch.ErrPaymentIDNotFound is to be returned.
+	GetPaymentResult func(paymentID uint64, paymentHash [32]byte,
+		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
+
+	// Control keeps track of the life cycle of all payments sent by the
+	// router, so that in-flight payments can be resumed after a restart.
+	Control ControlTower
+
 	// ChannelPruneExpiry is the duration used to determine if a channel
 	// should be pruned or not. If the delta between now and when the
 	// channel was last updated is greater than ChannelPruneExpiry, then
@@ -129,6 +143,10 @@ type Config struct {
 	// GraphPruneInterval is used as an interval to determine how often we
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
+
+	// PathWeights are the parameters of the cost function that is used to
+	// weigh channels against each other during path finding.
+	PathWeights WeightParams
 }
 
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
@@ -241,12 +259,17 @@ func New(cfg Config) (*ChannelRouter, error) {
 		return nil, err
 	}
 
+	missionControl, err := newMissionControl(cfg.Graph, selfNode)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ChannelRouter{
 		cfg:               &cfg,
 		networkUpdates:    make(chan *routingMsg),
 		topologyClients:   make(map[uint64]*topologyClient),
 		ntfnClientUpdates: make(chan *topologyClientUpdate),
-		missionControl:    newMissionControl(cfg.Graph, selfNode),
+		missionControl:    missionControl,
 		selfNode:          selfNode,
 		routeCache:        make(map[routeTuple][]*Route),
 		quit:              make(chan struct{}),
@@ -325,6 +348,21 @@ func (r *ChannelRouter) Start() error {
 		return err
 	}
 
+	// Any payments that were still in flight when we last shut down are
+	// resumed, so that their outcome is recorded once the switch resolves
+	// their HTLCs.
+	payments, err := r.cfg.Control.FetchInFlightPayments()
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		log.Infof("Resuming in-flight payment %x with %d outstanding "+
+			"HTLCs", payment.Info.PaymentHash, len(payment.Attempts))
+
+		r.wg.Add(1)
+		go r.resumePayment(payment)
+	}
+
 	r.wg.Add(1)
 	go r.networkHandler()
 
@@ -1120,9 +1158,14 @@ func pruneChannelFromRoutes(routes []*Route, skipChan uint64) []*Route {
 // inner loop.  Once we have a set of candidate routes, we calculate the
 // required fee and time lock values running backwards along the route. The
 // route that will be ranked the highest is the one with the lowest cumulative
-// fee along the route.
+// fee along the route. If a non-nil feeLimit or cltvLimit is passed, then only
+// routes within those limits are returned. The passed route hints describe
+// private channels towards the target which may be used in addition to the
+// channel graph.
 func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, finalExpiry ...uint16) ([]*Route, error) {
+	amt lnwire.MilliSatoshi, feeLimit *lnwire.MilliSatoshi,
+	cltvLimit *uint32, routeHints [][]HopHint,
+	finalExpiry ...uint16) ([]*Route, error) {
 
 	var finalCLTVDelta uint16
 	if len(finalExpiry) == 0 {
@@ -1138,16 +1181,33 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 
 	// Before attempting to perform a series of graph traversals to find
 	// the k-shortest paths to the destination, we'll first consult our
-	// path cache
+	// path cache. As the cache only covers routes within the graph, it's
+	// bypassed if any route hints were passed.
 	rt := newRouteTuple(amt, dest)
-	r.routeCacheMtx.RLock()
-	routes, ok := r.routeCache[rt]
-	r.routeCacheMtx.RUnlock()
+	var (
+		routes []*Route
+		ok     bool
+	)
+	if len(routeHints) == 0 {
+		r.routeCacheMtx.RLock()
+		routes, ok = r.routeCache[rt]
+		r.routeCacheMtx.RUnlock()
+	}
+
+	// We'll also fetch the current block height so we can properly
+	// calculate the required HTLC time locks within the route, and check
+	// them against the CLTV limit.
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
 
 	// If we already have a cached route, then we'll return it directly as
 	// there's no need to repeat the computation.
 	if ok {
-		return routes, nil
+		return filterRoutes(
+			routes, uint32(currentHeight), feeLimit, cltvLimit,
+		)
 	}
 
 	// If we don't have a set of routes cached, we'll query the graph for a
@@ -1156,21 +1216,18 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// returned.
 
 	// We can short circuit the routing by opportunistically checking to
-	// see if the target vertex event exists in the current graph.
-	if _, exists, err := r.cfg.Graph.HasLightningNode(target); err != nil {
+	// see if the target vertex event exists in the current graph. If we
+	// have route hints however, the target may only be reachable through
+	// its private channels.
+	additionalEdges := hintEdges(target, routeHints)
+	_, exists, err := r.cfg.Graph.HasLightningNode(target)
+	if err != nil {
 		return nil, err
-	} else if !exists {
+	} else if !exists && len(additionalEdges) == 0 {
 		log.Debugf("Target %x is not in known graph", dest)
 		return nil, newErrf(ErrTargetNotInNetwork, "target not found")
 	}
 
-	// We'll also fetch the current block height so we can properly
-	// calculate the required HTLC time locks within the route.
-	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
-	if err != nil {
-		return nil, err
-	}
-
 	tx, err := r.cfg.Graph.Database().Begin(false)
 	if err != nil {
 		tx.Rollback()
@@ -1181,7 +1238,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// we'll execute our KSP algorithm to find the k-shortest paths from
 	// our source to the destination.
 	shortestPaths, err := findPaths(tx, r.cfg.Graph, r.selfNode, target,
-		amt)
+		amt, r.cfg.PathWeights, additionalEdges)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -1242,12 +1299,63 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	)
 
 	// Populate the cache with this set of fresh routes so we can
-	// reuse them in the future.
-	r.routeCacheMtx.Lock()
-	r.routeCache[rt] = validRoutes
-	r.routeCacheMtx.Unlock()
+	// reuse them in the future, unless they depend on route hints.
+	if len(routeHints) == 0 {
+		r.routeCacheMtx.Lock()
+		r.routeCache[rt] = validRoutes
+		r.routeCacheMtx.Unlock()
+	}
+
+	return filterRoutes(
+		validRoutes, uint32(currentHeight), feeLimit, cltvLimit,
+	)
+}
+
+// checkRouteLimits returns an error if the route charges a total fee above
+// the passed fee limit, or if its total time lock relative to the passed
+// height exceeds the passed CLTV limit. Nil limits aren't enforced.
+func checkRouteLimits(route *Route, height uint32,
+	feeLimit *lnwire.MilliSatoshi, cltvLimit *uint32) error {
+
+	if feeLimit != nil && route.TotalFees > *feeLimit {
+		return newErrf(ErrFeeLimitExceeded, "total route fee of %v "+
+			"exceeds fee limit of %v", route.TotalFees, *feeLimit)
+	}
+
+	timeLock := route.TotalTimeLock - height
+	if cltvLimit != nil && timeLock > *cltvLimit {
+		return newErrf(ErrCltvLimitExceeded, "total route time lock "+
+			"of %v exceeds CLTV limit of %v", timeLock, *cltvLimit)
+	}
+
+	return nil
+}
+
+// filterRoutes returns the subset of the passed routes that lie within the
+// fee and CLTV limits. If no routes remain, then ErrNoRouteFound is returned.
+func filterRoutes(routes []*Route, height uint32,
+	feeLimit *lnwire.MilliSatoshi, cltvLimit *uint32) ([]*Route, error) {
+
+	if feeLimit == nil && cltvLimit == nil {
+		return routes, nil
+	}
+
+	filteredRoutes := make([]*Route, 0, len(routes))
+	for _, route := range routes {
+		err := checkRouteLimits(route, height, feeLimit, cltvLimit)
+		if err != nil {
+			continue
+		}
+
+		filteredRoutes = append(filteredRoutes, route)
+	}
+
+	if len(filteredRoutes) == 0 {
+		return nil, newErr(ErrNoRouteFound, "unable to find a route "+
+			"within the fee and CLTV limits")
+	}
 
-	return validRoutes, nil
+	return filteredRoutes, nil
 }
 
 // generateSphinxPacket generates then encodes a sphinx packet which encodes
@@ -1276,6 +1384,16 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 	// properly forward the payment.
 	hopPayloads := route.ToHopPayloads()
 
+	// If the final hop is to receive any custom records, then the frames
+	// which carry them are appended to the onion, each of which is
+	// encrypted to the final hop.
+	hopPayloads, onionPath, err := htlcswitch.AddCustomRecords(
+		hopPayloads, nodes, route.CustomRecords,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	log.Tracef("Constructed per-hop payloads for payment_hash=%x: %v",
 		paymentHash[:], spew.Sdump(hopPayloads))
 
@@ -1286,7 +1404,7 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 
 	// Next generate the onion routing packet which allows us to perform
 	// privacy preserving source routing across the network.
-	sphinxPacket, err := sphinx.NewOnionPacket(nodes, sessionKey,
+	sphinxPacket, err := sphinx.NewOnionPacket(onionPath, sessionKey,
 		hopPayloads, paymentHash)
 	if err != nil {
 		return nil, nil, err
@@ -1335,17 +1453,69 @@ type LightningPayment struct {
 	// used.
 	FinalCLTVDelta *uint16
 
+	// FeeLimit is the maximum total fee that may be paid to the nodes
+	// along the route. If nil, then no fee limit is enforced.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// CltvLimit is the maximum total time lock of the route, relative to
+	// the current block height and including the final CLTV delta. If
+	// nil, then no CLTV limit is enforced.
+	CltvLimit *uint32
+
+	// RouteHints describes the private channels, obtained from the
+	// invoice, that may be used to reach the target in addition to the
+	// channel graph.
+	RouteHints [][]HopHint
+
+	// MaxShards is the maximum number of shards the payment may be split
+	// into when no single route is able to carry the full amount. A value
+	// of zero or one disables splitting, which should only be enabled if
+	// the destination supports multi-path payments.
+	MaxShards uint32
+
+	// CustomRecords is the set of custom records to be delivered to the
+	// destination within the onion. This is used to include the preimage
+	// of a spontaneous payment.
+	CustomRecords htlcswitch.CustomRecords
+
+	// PaymentRequest is the encoded payment request the payment is made
+	// to, if any. It's recorded along with the payment.
+	PaymentRequest []byte
+
 	// TODO(roasbeef): add e2e message?
 }
 
+// minShardAmount is the smallest amount that a payment will be split into
+// when it's sent over multiple routes.
+const minShardAmount = lnwire.MilliSatoshi(10000)
+
+// shardResult is the outcome of sending a single shard of a payment.
+type shardResult struct {
+	amt      lnwire.MilliSatoshi
+	preImage [32]byte
+	route    *Route
+	err      error
+}
+
 // SendPayment attempts to send a payment as described within the passed
 // LightningPayment. This function is blocking and will return either: when the
 // payment is successful, or all candidates routes have been attempted and
-// resulted in a failed payment. If the payment succeeds, then a non-nil Route
-// will be returned which describes the path the successful payment traversed
-// within the network to reach the destination. Additionally, the payment
-// preimage will also be returned.
-func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
+// resulted in a failed payment. If the payment succeeds, then the set of
+// routes the payment traversed within the network to reach the destination
+// will be returned. If the payment wasn't split, then this set holds a single
+// route. Additionally, the payment preimage will also be returned.
+//
+// If the payment's MaxShards is greater than one, then the payment is first
+// attempted in full. Each time a shard of the payment can't be routed, it's
+// split into two halves which are sent concurrently, until either every shard
+// has arrived, or no further split is possible.
+//
+// The life cycle of the payment is recorded by the control tower, which
+// rejects the payment if its payment hash is already being paid, or has been
+// paid before.
+func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte,
+	[]*Route, error) {
+
 	log.Tracef("Dispatching route for lightning payment: %v",
 		newLogClosure(func() string {
 			payment.Target.Curve = nil
@@ -1353,16 +1523,51 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		}),
 	)
 
-	var (
-		preImage  [32]byte
-		sendError error
-	)
+	err := r.cfg.Control.InitPayment(&channeldb.PaymentCreationInfo{
+		PaymentHash:    payment.PaymentHash,
+		Value:          payment.Amount,
+		CreationDate:   time.Now(),
+		PaymentRequest: payment.PaymentRequest,
+	})
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	preImage, routes, err := r.sendPayment(payment)
+
+	// With the payment completed, we'll record its outcome. A payment
+	// that failed with some of its HTLCs possibly still outstanding, such
+	// as when the switch is shutting down, is left in flight so it can be
+	// resumed once we restart.
+	switch {
+	case len(routes) != 0:
+		if err := r.cfg.Control.Success(
+			payment.PaymentHash, preImage,
+		); err != nil {
+			log.Errorf("Unable to record success of payment %x: %v",
+				payment.PaymentHash, err)
+		}
+
+	case r.hasOutstandingHTLCs(payment.PaymentHash):
+		log.Warnf("Payment %x failed with HTLCs outstanding, leaving "+
+			"it in flight: %v", payment.PaymentHash, err)
+
+	default:
+		r.failPayment(payment.PaymentHash, err)
+	}
+
+	return preImage, routes, err
+}
+
+// sendPayment sends the passed payment, as described by SendPayment.
+func (r *ChannelRouter) sendPayment(payment *LightningPayment) ([32]byte,
+	[]*Route, error) {
 
 	// We'll also fetch the current block height so we can properly
 	// calculate the required HTLC time locks within the route.
 	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
 	if err != nil {
-		return preImage, nil, err
+		return [32]byte{}, nil, err
 	}
 
 	var finalCLTVDelta uint16
@@ -1372,6 +1577,118 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		finalCLTVDelta = *payment.FinalCLTVDelta
 	}
 
+	// If the payment may not be split, then we'll send the full amount
+	// along a single route.
+	if payment.MaxShards <= 1 {
+		preImage, route, err := r.sendShard(payment, payment.Amount, 0,
+			uint32(currentHeight), finalCLTVDelta)
+		if err != nil {
+			return preImage, nil, err
+		}
+
+		return preImage, []*Route{route}, nil
+	}
+
+	// Otherwise, each shard is sent within its own goroutine, as the
+	// destination won't settle any of them until all have arrived. As we
+	// never have more than MaxShards shards in flight, the results channel
+	// is buffered accordingly.
+	results := make(chan *shardResult, payment.MaxShards)
+	launchShard := func(amt lnwire.MilliSatoshi) {
+		go func() {
+			preImage, route, err := r.sendShard(payment, amt,
+				payment.Amount, uint32(currentHeight),
+				finalCLTVDelta)
+			results <- &shardResult{
+				amt:      amt,
+				preImage: preImage,
+				route:    route,
+				err:      err,
+			}
+		}()
+	}
+
+	var (
+		numShards uint32 = 1
+		inFlight         = 1
+		preImage  [32]byte
+		routes    []*Route
+		payErr    error
+	)
+	launchShard(payment.Amount)
+
+	for inFlight > 0 {
+		result := <-results
+		inFlight--
+
+		if result.err == nil {
+			preImage = result.preImage
+			routes = append(routes, result.route)
+			continue
+		}
+
+		// Once the payment has failed, we'll only wait for the
+		// remaining shards to be resolved.
+		if payErr != nil {
+			continue
+		}
+
+		// If no route could be found for this shard, then we'll split
+		// it into two halves, as long as we're within the shard limit
+		// and the halves aren't too small to be worth sending.
+		half := result.amt / 2
+		canSplit := IsError(result.err, ErrNoPathFound,
+			ErrNoRouteFound, ErrInsufficientCapacity) &&
+			numShards < payment.MaxShards && half >= minShardAmount
+		if !canSplit {
+			payErr = result.err
+			continue
+		}
+
+		log.Debugf("Splitting shard of %v for payment %x into two "+
+			"shards", result.amt, payment.PaymentHash)
+
+		numShards++
+		inFlight += 2
+		launchShard(half)
+		launchShard(result.amt - half)
+	}
+
+	// As the destination only settles the shards of a payment once all of
+	// them have arrived, a single settled shard means that the payment as
+	// a whole succeeded.
+	if len(routes) == 0 {
+		return [32]byte{}, nil, payErr
+	}
+
+	return preImage, routes, nil
+}
+
+// sendShard attempts to send amt of the passed payment along a single route,
+// trying alternative routes until either the HTLC is settled, or we encounter
+// a critical error. If total is non-zero, then the payment has been split
+// into several shards, and total is communicated to the destination so it can
+// wait for the remaining shards to arrive. The fee limit of the payment is
+// applied proportionally to the shard's share of the payment.
+func (r *ChannelRouter) sendShard(payment *LightningPayment,
+	amt, total lnwire.MilliSatoshi, currentHeight uint32,
+	finalCLTVDelta uint16) ([32]byte, *Route, error) {
+
+	var (
+		preImage  [32]byte
+		sendError error
+	)
+
+	shard := *payment
+	shard.Amount = amt
+	if payment.FeeLimit != nil && amt != payment.Amount {
+		feeLimit := lnwire.MilliSatoshi(
+			float64(*payment.FeeLimit) * float64(amt) /
+				float64(payment.Amount),
+		)
+		shard.FeeLimit = &feeLimit
+	}
+
 	// We'll continue until either our payment succeeds, or we encounter a
 	// critical error during path finding.
 	for {
@@ -1379,19 +1696,21 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		// control, which will incoroporate the current best known
 		// state of the channel graph and our past HTLC routing
 		// successes/failures.
-		route, err := r.missionControl.RequestRoute(payment,
-			uint32(currentHeight), finalCLTVDelta)
+		route, err := r.missionControl.RequestRoute(&shard,
+			currentHeight, finalCLTVDelta, r.cfg.PathWeights)
 		if err != nil {
 			// If we're unable to successfully make a payment using
 			// any of the routes we've found, then return an error.
 			if sendError != nil {
-				return [32]byte{}, nil, fmt.Errorf("unable to "+
-					"route payment to destination: %v",
-					sendError)
+				return [32]byte{}, nil, newErrf(ErrNoRouteFound,
+					"unable to route payment to "+
+						"destination: %v", sendError)
 			}
 
 			return preImage, nil, err
 		}
+		route.PaymentTotal = total
+		route.CustomRecords = payment.CustomRecords
 
 		log.Tracef("Attempting to send payment %x, using route: %v",
 			payment.PaymentHash, newLogClosure(func() string {
@@ -1418,12 +1737,28 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		}
 		copy(htlcAdd.OnionBlob[:], onionBlob)
 
+		// Before handing the HTLC to the switch, we'll register the
+		// attempt with the control tower, which assigns the payment ID
+		// of the HTLC. This allows us to resume the attempt should we
+		// restart before it has been resolved.
+		attempt := &channeldb.PaymentAttemptInfo{
+			SessionKey: circuit.SessionKey,
+			Amt:        route.TotalAmount - route.TotalFees,
+			Fee:        route.TotalFees,
+			TimeLock:   route.TotalTimeLock,
+			Path:       route.nodePath(),
+		}
+		err = r.cfg.Control.RegisterAttempt(payment.PaymentHash, attempt)
+		if err != nil {
+			return preImage, nil, err
+		}
+
 		// Attempt to send this payment through the network to complete
 		// the payment. If this attempt fails, then we'll continue on
 		// to the next available route.
 		firstHop := route.Hops[0].Channel.Node.PubKey
-		preImage, sendError = r.cfg.SendToSwitch(firstHop, htlcAdd,
-			circuit)
+		preImage, sendError = r.cfg.SendToSwitch(firstHop,
+			attempt.PaymentID, htlcAdd, circuit)
 		if sendError != nil {
 			// An error occurred when attempting to send the
 			// payment, depending on the error type, we'll either
@@ -1432,11 +1767,21 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			log.Errorf("Attempt to send payment %x failed: %v",
 				payment.PaymentHash, sendError)
 
+			// Only a forwarding error tells us that the HTLC has
+			// failed. Any other error leaves the attempt in
+			// flight, as its HTLC may still be outstanding.
 			fErr, ok := sendError.(*htlcswitch.ForwardingError)
 			if !ok {
 				return preImage, nil, sendError
 			}
 
+			err := r.cfg.Control.FailAttempt(
+				payment.PaymentHash, attempt.PaymentID,
+			)
+			if err != nil {
+				return [32]byte{}, nil, err
+			}
+
 			errSource := fErr.ErrorSource
 
 			switch onionErr := fErr.FailureMessage.(type) {
@@ -1546,7 +1891,9 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 				// If the channel was found, then we'll inform
 				// mission control of this failure so future
 				// attempts avoid this link temporarily.
-				r.missionControl.ReportChannelFailure(badChan)
+				r.missionControl.ReportChannelFailure(
+					badChan, route.channelAmount(badChan),
+				)
 				continue
 
 			// If the send fail due to a node not having the
@@ -1581,7 +1928,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 				// Once we've located the vertex, we'll report
 				// this failure to missionControl and restart
 				// path finding.
-				r.missionControl.ReportVertexFailure(missingNode)
+				r.reportVertexFailure(route, errSource, missingNode)
 				continue
 
 			// If the node wasn't able to forward for which ever
@@ -1593,7 +1940,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 					continue
 				}
 
-				r.missionControl.ReportVertexFailure(missingNode)
+				r.reportVertexFailure(route, errSource, missingNode)
 				continue
 
 			// If we get a permanent channel or node failure, then
@@ -1611,10 +1958,248 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			}
 		}
 
+		// As the payment succeeded, we'll let mission control know
+		// that each channel in the route was able to carry it.
+		r.missionControl.ReportRouteSuccess(route)
+
 		return preImage, route, nil
 	}
 }
 
+// reportVertexFailure reports a failure localized to the vertex that follows
+// errSource within the route to mission control, along with the amount that
+// was sent to it.
+func (r *ChannelRouter) reportVertexFailure(route *Route,
+	errSource *btcec.PublicKey, v Vertex) {
+
+	var amt lnwire.MilliSatoshi
+	if chanID, ok := route.nextHopChannel(errSource); ok {
+		amt = route.channelAmount(chanID)
+	}
+
+	r.missionControl.ReportVertexFailure(v, amt)
+}
+
+// hasOutstandingHTLCs returns true if the control tower knows of attempts of
+// the payment to the passed payment hash whose HTLCs haven't failed.
+func (r *ChannelRouter) hasOutstandingHTLCs(paymentHash [32]byte) bool {
+	payment, err := r.cfg.Control.FetchPayment(paymentHash)
+	if err != nil {
+		log.Errorf("Unable to fetch payment %x: %v", paymentHash, err)
+		return false
+	}
+
+	return len(payment.Attempts) != 0
+}
+
+// failPayment records the failure of the payment to the passed payment hash
+// with the control tower.
+func (r *ChannelRouter) failPayment(paymentHash [32]byte, payErr error) {
+	err := r.cfg.Control.Fail(
+		paymentHash, paymentFailureReason(payErr), payErr.Error(),
+	)
+	if err != nil {
+		log.Errorf("Unable to record failure of payment %x: %v",
+			paymentHash, err)
+	}
+}
+
+// paymentFailureReason maps the error a payment failed with to the reason
+// recorded for the failure.
+func paymentFailureReason(err error) channeldb.FailureReason {
+	if IsError(err, ErrNoPathFound, ErrNoRouteFound,
+		ErrInsufficientCapacity, ErrMaxHopsExceeded,
+		ErrTargetNotInNetwork, ErrFeeLimitExceeded,
+		ErrCltvLimitExceeded) {
+
+		return channeldb.FailureReasonNoRoute
+	}
+
+	fErr, ok := err.(*htlcswitch.ForwardingError)
+	if !ok {
+		return channeldb.FailureReasonError
+	}
+
+	switch fErr.FailureMessage.(type) {
+	case *lnwire.FailUnknownPaymentHash,
+		*lnwire.FailIncorrectPaymentAmount,
+		*lnwire.FailFinalIncorrectCltvExpiry,
+		*lnwire.FailFinalIncorrectHtlcAmount,
+		*lnwire.FailFinalExpiryTooSoon:
+
+		return channeldb.FailureReasonIncorrectPaymentDetails
+
+	default:
+		return channeldb.FailureReasonError
+	}
+}
+
+// attemptResult is the outcome of a payment attempt that was resumed after a
+// restart.
+type attemptResult struct {
+	attempt  *channeldb.PaymentAttemptInfo
+	preImage [32]byte
+	err      error
+}
+
+// resumePayment waits for the outstanding HTLCs of a payment that was in
+// flight when we last shut down, and records the outcome of the payment once
+// they have all been resolved. As the parameters the payment was sent with
+// aren't known anymore, failed HTLCs aren't retried.
+//
+// NOTE: This MUST be run as a goroutine.
+func (r *ChannelRouter) resumePayment(payment *channeldb.TrackedPayment) {
+	defer r.wg.Done()
+
+	paymentHash := payment.Info.PaymentHash
+
+	// If we shut down between attempts, then there's nothing to wait for.
+	if len(payment.Attempts) == 0 {
+		err := r.cfg.Control.Fail(
+			paymentHash, channeldb.FailureReasonInterrupted,
+			"payment was interrupted by a restart",
+		)
+		if err != nil {
+			log.Errorf("Unable to record failure of payment %x: %v",
+				paymentHash, err)
+		}
+		return
+	}
+
+	// The result of each HTLC is awaited within its own goroutine. As
+	// these only return once the switch has resolved the HTLC, or is
+	// shutting down, the results channel is buffered so they never block
+	// should we exit first.
+	results := make(chan *attemptResult, len(payment.Attempts))
+	for _, attempt := range payment.Attempts {
+		go func(attempt *channeldb.PaymentAttemptInfo) {
+			circuit, err := attemptCircuit(attempt)
+			if err != nil {
+				results <- &attemptResult{attempt: attempt, err: err}
+				return
+			}
+
+			preImage, err := r.cfg.GetPaymentResult(
+				attempt.PaymentID, paymentHash, circuit,
+			)
+			results <- &attemptResult{
+				attempt:  attempt,
+				preImage: preImage,
+				err:      err,
+			}
+		}(attempt)
+	}
+
+	var (
+		settled  bool
+		preImage [32]byte
+		payErr   error
+	)
+	for range payment.Attempts {
+		var result *attemptResult
+		select {
+		case result = <-results:
+		case <-r.quit:
+			return
+		}
+
+		if result.err == nil {
+			settled = true
+			preImage = result.preImage
+			continue
+		}
+
+		// Unless the switch told us that the HTLC failed, or never
+		// left the node, the HTLC may still be outstanding, so the
+		// payment is left in flight.
+		_, failed := result.err.(*htlcswitch.ForwardingError)
+		if !failed && result.err != htlcswitch.ErrPaymentIDNotFound {
+			log.Errorf("Unable to resume attempt %d of payment %x: "+
+				"%v", result.attempt.PaymentID, paymentHash,
+				result.err)
+			return
+		}
+
+		log.Debugf("Resumed attempt %d of payment %x failed: %v",
+			result.attempt.PaymentID, paymentHash, result.err)
+
+		err := r.cfg.Control.FailAttempt(
+			paymentHash, result.attempt.PaymentID,
+		)
+		if err != nil {
+			log.Errorf("Unable to record failure of attempt %d of "+
+				"payment %x: %v", result.attempt.PaymentID,
+				paymentHash, err)
+			return
+		}
+		payErr = result.err
+	}
+
+	if !settled {
+		r.failPayment(paymentHash, payErr)
+		return
+	}
+
+	log.Infof("Resumed payment %x has succeeded", paymentHash)
+
+	if err := r.cfg.Control.Success(paymentHash, preImage); err != nil {
+		log.Errorf("Unable to record success of payment %x: %v",
+			paymentHash, err)
+	}
+}
+
+// attemptCircuit re-creates the sphinx circuit of a payment attempt, which is
+// needed to decrypt the failure of its HTLC.
+func attemptCircuit(
+	attempt *channeldb.PaymentAttemptInfo) (*sphinx.Circuit, error) {
+
+	path := make([]*btcec.PublicKey, len(attempt.Path))
+	for i, hop := range attempt.Path {
+		pub, err := btcec.ParsePubKey(hop[:], btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		path[i] = pub
+	}
+
+	return &sphinx.Circuit{
+		SessionKey:  attempt.SessionKey,
+		PaymentPath: path,
+	}, nil
+}
+
+// SubscribePayment returns a subscription to the state updates of the payment
+// to the passed payment hash, starting with its current state.
+func (r *ChannelRouter) SubscribePayment(
+	paymentHash [32]byte) (*PaymentSubscription, error) {
+
+	return r.cfg.Control.SubscribePayment(paymentHash)
+}
+
+// QueryMissionControl returns all payment attempt results that mission
+// control currently bases its success probabilities on, ordered from oldest
+// to newest.
+func (r *ChannelRouter) QueryMissionControl() ([]*channeldb.MissionControlResult,
+	error) {
+
+	return r.missionControl.FetchResults()
+}
+
+// ImportMissionControl adds the passed payment attempt results to the history
+// of mission control. Results that are older than what mission control
+// already knows of for a channel or node don't override the existing view.
+func (r *ChannelRouter) ImportMissionControl(
+	results []*channeldb.MissionControlResult) error {
+
+	return r.missionControl.ImportResults(results)
+}
+
+// ResetMissionControl wipes all payment attempt results from mission control,
+// both in memory and on disk.
+func (r *ChannelRouter) ResetMissionControl() error {
+	return r.missionControl.ResetHistory()
+}
+
 // applyChannelUpdate applies a channel update directly to the database,
 // skipping preliminary validation.
 func (r *ChannelRouter) applyChannelUpdate(msg *lnwire.ChannelUpdate) error {
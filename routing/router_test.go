@@ -2,6 +2,7 @@ package routing
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"image/color"
 	"math/rand"
@@ -42,10 +43,15 @@ func (c *testCtx) RestartRouter() error {
 		Graph:     c.graph,
 		Chain:     c.chain,
 		ChainView: c.chainView,
-		SendToSwitch: func(_ *btcec.PublicKey,
+		SendToSwitch: func(_ *btcec.PublicKey, _ uint64,
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
+		GetPaymentResult: func(_ uint64, _ [32]byte,
+			_ *sphinx.Circuit) ([32]byte, error) {
+			return [32]byte{}, htlcswitch.ErrPaymentIDNotFound
+		},
+		Control:            NewControlTower(c.graph.Database()),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -117,10 +123,15 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 		Graph:     graph,
 		Chain:     chain,
 		ChainView: chainView,
-		SendToSwitch: func(_ *btcec.PublicKey,
+		SendToSwitch: func(_ *btcec.PublicKey, _ uint64,
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
+		GetPaymentResult: func(_ uint64, _ [32]byte,
+			_ *sphinx.Circuit) ([32]byte, error) {
+			return [32]byte{}, htlcswitch.ErrPaymentIDNotFound
+		},
+		Control:            NewControlTower(graph.Database()),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -167,7 +178,7 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	// Execute a query for all possible routes between roasbeef and luo ji.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := ctx.aliases["luoji"]
-	routes, err := ctx.router.FindRoutes(target, paymentAmt,
+	routes, err := ctx.router.FindRoutes(target, paymentAmt, nil, nil, nil,
 		DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -230,7 +241,7 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	// router's configuration to ignore the path that has luo ji as the
 	// first hop. This should force the router to instead take the
 	// available two hop path (through satoshi).
-	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if ctx.aliases["luoji"].IsEqual(n) {
@@ -246,11 +257,18 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 
 	// Send off the payment request to the router, route through satoshi
 	// should've been selected as a fall back and succeeded correctly.
-	paymentPreImage, route, err := ctx.router.SendPayment(&payment)
+	paymentPreImage, routes, err := ctx.router.SendPayment(&payment)
 	if err != nil {
 		t.Fatalf("unable to send payment: %v", err)
 	}
 
+	// As the payment may not be split, only a single route should've been
+	// used.
+	if len(routes) != 1 {
+		t.Fatalf("expected a single route, got %v", len(routes))
+	}
+	route := routes[0]
+
 	// The route selected should have two hops
 	if len(route.Hops) != 2 {
 		t.Fatalf("incorrect route length: expected %v got %v", 2,
@@ -269,6 +287,207 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 			"instead passes through: %v",
 			route.Hops[0].Channel.Node.Alias)
 	}
+
+	// The control tower should have recorded the payment as succeeded,
+	// so sending it again must fail.
+	trackedPayment, err := ctx.router.cfg.Control.FetchPayment(payHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if trackedPayment.Status != channeldb.StatusSucceeded {
+		t.Fatalf("expected payment to have succeeded, got %v",
+			trackedPayment.Status)
+	}
+	if _, _, err := ctx.router.SendPayment(&payment); err != channeldb.ErrAlreadyPaid {
+		t.Fatalf("expected ErrAlreadyPaid, got %v", err)
+	}
+}
+
+// TestRouterResumePayment tests that a payment which was in flight when the
+// router was restarted is resolved once the switch returns the result of its
+// HTLC.
+func TestRouterResumePayment(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Record a payment with a single attempt in flight, as if we had shut
+	// down while waiting for its HTLC.
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+	payHash := sha256.Sum256(preImage[:])
+
+	control := ctx.router.cfg.Control
+	err = control.InitPayment(&channeldb.PaymentCreationInfo{
+		PaymentHash:  payHash,
+		Value:        lnwire.NewMSatFromSatoshis(1000),
+		CreationDate: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create session key: %v", err)
+	}
+	var hop [33]byte
+	copy(hop[:], ctx.aliases["luoji"].SerializeCompressed())
+	attempt := &channeldb.PaymentAttemptInfo{
+		SessionKey: sessionKey,
+		Amt:        lnwire.NewMSatFromSatoshis(1000),
+		Path:       [][33]byte{hop},
+	}
+	if err := control.RegisterAttempt(payHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+
+	sub, err := control.SubscribePayment(payHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to payment: %v", err)
+	}
+	defer sub.Cancel()
+
+	// Once restarted, the router should ask the switch for the result of
+	// the attempt, and record the payment as succeeded.
+	ctx.router.Stop()
+	ctx.chainView.Reset()
+
+	results := make(chan uint64, 1)
+	router, err := New(Config{
+		Graph:     ctx.graph,
+		Chain:     ctx.chain,
+		ChainView: ctx.chainView,
+		SendToSwitch: func(_ *btcec.PublicKey, _ uint64,
+			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+			return [32]byte{}, nil
+		},
+		GetPaymentResult: func(paymentID uint64, _ [32]byte,
+			_ *sphinx.Circuit) ([32]byte, error) {
+
+			results <- paymentID
+			return preImage, nil
+		},
+		Control:            control,
+		ChannelPruneExpiry: time.Hour * 24,
+		GraphPruneInterval: time.Hour * 2,
+	})
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+	if err := router.Start(); err != nil {
+		t.Fatalf("unable to start router: %v", err)
+	}
+	defer router.Stop()
+
+	select {
+	case paymentID := <-results:
+		if paymentID != attempt.PaymentID {
+			t.Fatalf("expected result of payment ID %v to be "+
+				"requested, got %v", attempt.PaymentID, paymentID)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("payment result wasn't requested")
+	}
+
+	timeout := time.After(time.Second * 5)
+	for {
+		select {
+		case payment, ok := <-sub.Updates:
+			if !ok {
+				t.Fatalf("subscription closed before payment " +
+					"succeeded")
+			}
+			if payment.Status != channeldb.StatusSucceeded {
+				continue
+			}
+			if *payment.Preimage != preImage {
+				t.Fatalf("expected preimage %x, got %x",
+					preImage, *payment.Preimage)
+			}
+			return
+
+		case <-timeout:
+			t.Fatalf("payment wasn't resumed")
+		}
+	}
+}
+
+// TestSendPaymentMultiPath tests that a payment which is too large to be
+// carried by any single route is split into several shards, each of which
+// carries the total amount of the payment, once the payment is allowed to be
+// split.
+func TestSendPaymentMultiPath(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// The largest route from roasbeef to satoshi goes through luo ji, and
+	// is limited by the 50k satoshi channel between luo ji and satoshi. A
+	// payment of 60k satoshis therefore doesn't fit within any route.
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["satoshi"],
+		Amount:      lnwire.NewMSatFromSatoshis(60000),
+		PaymentHash: payHash,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey, _ uint64,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		return preImage, nil
+	}
+
+	// Without allowing the payment to be split, it should fail.
+	if _, _, err := ctx.router.SendPayment(&payment); err == nil {
+		t.Fatalf("expected payment to fail")
+	}
+
+	// Once we allow the payment to be split, it should succeed over
+	// several routes.
+	payment.MaxShards = 4
+	paymentPreImage, routes, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if len(routes) < 2 {
+		t.Fatalf("expected payment to be split, got %v routes",
+			len(routes))
+	}
+
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// The amounts delivered by all routes should add up to the payment
+	// amount, and each route should carry the payment's total.
+	var delivered lnwire.MilliSatoshi
+	for _, route := range routes {
+		if route.PaymentTotal != payment.Amount {
+			t.Fatalf("expected payment total %v, got %v",
+				payment.Amount, route.PaymentTotal)
+		}
+
+		delivered += route.Hops[len(route.Hops)-1].AmtToForward
+	}
+	if delivered != payment.Amount {
+		t.Fatalf("expected %v to be delivered, got %v",
+			payment.Amount, delivered)
+	}
 }
 
 // TestSendPaymentErrorPathPruning tests that the send of candidate routes
@@ -307,7 +526,7 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 	//
 	// TODO(roasbeef): filtering should be intelligent enough so just not
 	// go through satoshi at all at this point.
-	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if ctx.aliases["luoji"].IsEqual(n) {
@@ -353,7 +572,7 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 	// Next, we'll modify the SendToSwitch method to indicate that luo ji
 	// wasn't originally online. This should also halt the send all
 	// together as all paths contain luoji and he can't be reached.
-	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if ctx.aliases["luoji"].IsEqual(n) {
@@ -380,7 +599,7 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 
 	// Finally, we'll modify the SendToSwitch function to indicate that the
 	// roasbeef -> luoji channel has insufficient capacity.
-	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey,
+	ctx.router.cfg.SendToSwitch = func(n *btcec.PublicKey, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 		if ctx.aliases["luoji"].IsEqual(n) {
 			// We'll first simulate an error from the first
@@ -394,10 +613,14 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		return preImage, nil
 	}
 
-	paymentPreImage, route, err := ctx.router.SendPayment(&payment)
+	paymentPreImage, routes, err := ctx.router.SendPayment(&payment)
 	if err != nil {
 		t.Fatalf("unable to send payment: %v", err)
 	}
+	if len(routes) != 1 {
+		t.Fatalf("expected a single route, got %v", len(routes))
+	}
+	route := routes[0]
 
 	// This should succeed finally.  The route selected should have two
 	// hops.
@@ -701,7 +924,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 	// We should now be able to find one route to node 2.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	targetNode := priv2.PubKey()
-	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt,
+	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt, nil, nil, nil,
 		DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -744,7 +967,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 
 	// Should still be able to find the route, and the info should be
 	// updated.
-	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt,
+	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt, nil, nil, nil,
 		DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -940,10 +1163,15 @@ func TestWakeUpOnStaleBranch(t *testing.T) {
 		Graph:     ctx.graph,
 		Chain:     ctx.chain,
 		ChainView: ctx.chainView,
-		SendToSwitch: func(_ *btcec.PublicKey,
+		SendToSwitch: func(_ *btcec.PublicKey, _ uint64,
 			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 			return [32]byte{}, nil
 		},
+		GetPaymentResult: func(_ uint64, _ [32]byte,
+			_ *sphinx.Circuit) ([32]byte, error) {
+			return [32]byte{}, htlcswitch.ErrPaymentIDNotFound
+		},
+		Control:            NewControlTower(ctx.graph.Database()),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -20,13 +21,16 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/psbt"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/tor"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
@@ -58,10 +62,17 @@ var (
 		"getchaninfo",
 		"getnodeinfo",
 		"queryroutes",
+		"querymissioncontrol",
 		"getnetworkinfo",
 		"listpayments",
+		"trackpayment",
 		"decodepayreq",
 		"feereport",
+		"fwdinghistory",
+		"verifychanbackup",
+		"pendingsweeps",
+		"listunspent",
+		"listleases",
 	}
 )
 
@@ -69,6 +80,10 @@ const (
 	// maxPaymentMSat is the maximum allowed payment permitted currently as
 	// defined in BOLT-0002.
 	maxPaymentMSat = lnwire.MilliSatoshi(math.MaxUint32)
+
+	// maxPaymentShards is the maximum number of shards a payment will be
+	// split into if the destination supports multi-path payments.
+	maxPaymentShards = 16
 )
 
 // rpcServer is a gRPC, RPC front end to the lnd daemon.
@@ -146,15 +161,23 @@ func addrPairsToOutputs(addrPairs map[string]int64) ([]*wire.TxOut, error) {
 
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
+// address to a specified output value to be sent to that address. If any
+// inputs are passed, then exactly those wallet outputs are spent.
 func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
-	feePerByte btcutil.Amount) (*chainhash.Hash, error) {
+	feePerByte btcutil.Amount, inputs []wire.OutPoint) (*chainhash.Hash,
+	error) {
 
 	outputs, err := addrPairsToOutputs(paymentMap)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(inputs) != 0 {
+		return r.server.cc.wallet.SendOutputsFromInputs(
+			inputs, outputs, feePerByte,
+		)
+	}
+
 	return r.server.cc.wallet.SendOutputs(outputs, feePerByte)
 }
 
@@ -218,11 +241,16 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 		return nil, err
 	}
 
-	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v, sat/byte=%v",
-		in.Addr, btcutil.Amount(in.Amount), int64(feePerByte))
+	inputs, err := parseOutPoints(in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v, sat/byte=%v, inputs=%v",
+		in.Addr, btcutil.Amount(in.Amount), int64(feePerByte), inputs)
 
 	paymentMap := map[string]int64{in.Addr: in.Amount}
-	txid, err := r.sendCoinsOnChain(paymentMap, feePerByte)
+	txid, err := r.sendCoinsOnChain(paymentMap, feePerByte, inputs)
 	if err != nil {
 		return nil, err
 	}
@@ -254,10 +282,15 @@ func (r *rpcServer) SendMany(ctx context.Context,
 		return nil, err
 	}
 
-	rpcsLog.Infof("[sendmany] outputs=%v, sat/byte=%v",
-		spew.Sdump(in.AddrToAmount), int64(feePerByte))
+	inputs, err := parseOutPoints(in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[sendmany] outputs=%v, sat/byte=%v, inputs=%v",
+		spew.Sdump(in.AddrToAmount), int64(feePerByte), inputs)
 
-	txid, err := r.sendCoinsOnChain(in.AddrToAmount, feePerByte)
+	txid, err := r.sendCoinsOnChain(in.AddrToAmount, feePerByte, inputs)
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +300,253 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	return &lnrpc.SendManyResponse{Txid: txid.String()}, nil
 }
 
+// parseOutPoint parses an outpoint of the form txid:index.
+func parseOutPoint(s string) (*wire.OutPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("outpoint %q should be of the form "+
+			"txid:index", s)
+	}
+
+	txid, err := chainhash.NewHashFromStr(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid txid: %v", err)
+	}
+
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output index: %v", err)
+	}
+
+	return wire.NewOutPoint(txid, uint32(index)), nil
+}
+
+// parseOutPoints parses a list of outpoints of the form txid:index.
+func parseOutPoints(strs []string) ([]wire.OutPoint, error) {
+	if len(strs) == 0 {
+		return nil, nil
+	}
+
+	outpoints := make([]wire.OutPoint, 0, len(strs))
+	for _, s := range strs {
+		outpoint, err := parseOutPoint(s)
+		if err != nil {
+			return nil, err
+		}
+
+		outpoints = append(outpoints, *outpoint)
+	}
+
+	return outpoints, nil
+}
+
+// BumpFee raises the fee rate of the unconfirmed transaction that created the
+// passed outpoint, either by spending the outpoint with a child transaction,
+// or by replacing its sweep.
+func (r *rpcServer) BumpFee(ctx context.Context,
+	in *lnrpc.BumpFeeRequest) (*lnrpc.BumpFeeResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "sendcoins",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	outpoint, err := parseOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.TargetConf < 0 || in.SatPerByte < 0 {
+		return nil, fmt.Errorf("target_conf and sat_per_byte must " +
+			"not be negative")
+	}
+	if in.TargetConf != 0 && in.SatPerByte != 0 {
+		return nil, fmt.Errorf("either target_conf or sat_per_byte " +
+			"may be set, not both")
+	}
+
+	// The fee bumper works with fee rates per weight unit, so we'll scale
+	// down a manual sat/byte fee rate.
+	feePerWeight := btcutil.Amount(in.SatPerByte) /
+		blockchain.WitnessScaleFactor
+	if in.SatPerByte != 0 && feePerWeight == 0 {
+		return nil, fmt.Errorf("fee rate of %v sat/byte is too low",
+			in.SatPerByte)
+	}
+
+	rpcsLog.Infof("[bumpfee] outpoint=%v, target_conf=%v, sat/byte=%v",
+		outpoint, in.TargetConf, in.SatPerByte)
+
+	bump, err := r.server.feeBumper.BumpFee(
+		*outpoint, uint32(in.TargetConf), feePerWeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[bumpfee] bumped tx %v with child tx %v",
+		bump.parentTxid, bump.childTxid)
+
+	packageFeeRate := bump.packageFeeRate * blockchain.WitnessScaleFactor
+
+	return &lnrpc.BumpFeeResponse{
+		ChildTxid:         bump.childTxid.String(),
+		PackageSatPerByte: int64(packageFeeRate),
+	}, nil
+}
+
+// ListUnspent returns the unspent outputs of the wallet with between the
+// passed minimum and maximum number of confirmations. Leased outputs are
+// locked within the wallet, so they aren't returned.
+func (r *rpcServer) ListUnspent(ctx context.Context,
+	in *lnrpc.ListUnspentRequest) (*lnrpc.ListUnspentResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listunspent",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.MinConfs < 0 || in.MaxConfs < 0 {
+		return nil, fmt.Errorf("min_confs and max_confs must not be " +
+			"negative")
+	}
+	if in.MaxConfs != 0 && in.MaxConfs < in.MinConfs {
+		return nil, fmt.Errorf("max_confs must not be below min_confs")
+	}
+
+	utxos, err := r.server.cc.wallet.ListUnspentWitness(in.MinConfs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListUnspentResponse{
+		Utxos: make([]*lnrpc.Utxo, 0, len(utxos)),
+	}
+	for _, utxo := range utxos {
+		if in.MaxConfs != 0 && utxo.Confirmations > int64(in.MaxConfs) {
+			continue
+		}
+
+		// An output of our own wallet always pays to a single
+		// address.
+		var address string
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			utxo.PkScript, activeNetParams.Params,
+		)
+		if err == nil && len(addrs) == 1 {
+			address = addrs[0].String()
+		}
+
+		resp.Utxos = append(resp.Utxos, &lnrpc.Utxo{
+			Outpoint:      utxo.OutPoint.String(),
+			Address:       address,
+			AmountSat:     int64(utxo.Value),
+			PkScript:      hex.EncodeToString(utxo.PkScript),
+			Confirmations: utxo.Confirmations,
+		})
+	}
+
+	return resp, nil
+}
+
+// LeaseOutput excludes the passed wallet output from automatic coin selection
+// until the lease expires or is released.
+func (r *rpcServer) LeaseOutput(ctx context.Context,
+	in *lnrpc.LeaseOutputRequest) (*lnrpc.LeaseOutputResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "leaseoutput",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	outpoint, err := parseOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := lnwallet.DefaultLeaseDuration
+	switch {
+	case in.DurationSeconds < 0:
+		return nil, fmt.Errorf("lease duration must not be negative")
+	case in.DurationSeconds != 0:
+		duration = time.Duration(in.DurationSeconds) * time.Second
+	}
+
+	rpcsLog.Infof("[leaseoutput] outpoint=%v, duration=%v", outpoint,
+		duration)
+
+	expiration, err := r.server.cc.wallet.LeaseOutput(*outpoint, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.LeaseOutputResponse{
+		Expiration: expiration.Unix(),
+	}, nil
+}
+
+// ReleaseOutput releases the lease of the passed output.
+func (r *rpcServer) ReleaseOutput(ctx context.Context,
+	in *lnrpc.ReleaseOutputRequest) (*lnrpc.ReleaseOutputResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "releaseoutput",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	outpoint, err := parseOutPoint(in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[releaseoutput] outpoint=%v", outpoint)
+
+	if err := r.server.cc.wallet.ReleaseOutput(*outpoint); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ReleaseOutputResponse{}, nil
+}
+
+// ListLeases returns all currently leased outputs.
+func (r *rpcServer) ListLeases(ctx context.Context,
+	in *lnrpc.ListLeasesRequest) (*lnrpc.ListLeasesResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listleases",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	leases := r.server.cc.wallet.ListLeases()
+
+	resp := &lnrpc.ListLeasesResponse{
+		Leases: make([]*lnrpc.OutputLease, 0, len(leases)),
+	}
+	for _, lease := range leases {
+		resp.Leases = append(resp.Leases, &lnrpc.OutputLease{
+			Outpoint:   lease.OutPoint.String(),
+			Expiration: lease.Expiration.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
 // NewAddress creates a new address under control of the local wallet.
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
@@ -343,7 +623,7 @@ func (r *rpcServer) SignMessage(ctx context.Context,
 		return nil, fmt.Errorf("need a message to sign")
 	}
 
-	sigBytes, err := r.server.nodeSigner.SignCompact(in.Msg)
+	sigBytes, err := r.server.identityKey.SignCompact(in.Msg)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +715,7 @@ func (r *rpcServer) ConnectPeer(ctx context.Context,
 	}
 
 	// Connections to ourselves are disallowed for obvious reasons.
-	if pubKey.IsEqual(r.server.identityPriv.PubKey()) {
+	if pubKey.IsEqual(r.server.identityKey.PubKey()) {
 		return nil, fmt.Errorf("cannot make connection to self")
 	}
 
@@ -449,7 +729,7 @@ func (r *rpcServer) ConnectPeer(ctx context.Context,
 		addr = in.Addr.Host
 	}
 
-	host, err := net.ResolveTCPAddr("tcp", addr)
+	host, err := tor.ParseAddr(addr, cfg.net)
 	if err != nil {
 		return nil, err
 	}
@@ -602,7 +882,7 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 
 		// Making a channel to ourselves wouldn't be of any use, so we
 		// explicitly disallow them.
-		if nodePubKey.IsEqual(r.server.identityPriv.PubKey()) {
+		if nodePubKey.IsEqual(r.server.identityKey.PubKey()) {
 			return fmt.Errorf("cannot open channel to self")
 		}
 
@@ -621,13 +901,22 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	rpcsLog.Debugf("[openchannel]: using fee of %v sat/byte for funding "+
 		"tx", int64(feePerByte))
 
+	fundingInputs, err := parseOutPoints(in.Outpoints)
+	if err != nil {
+		return err
+	}
+	if in.Psbt && len(fundingInputs) != 0 {
+		return fmt.Errorf("outpoints cannot be selected for a " +
+			"channel funded through a PSBT")
+	}
+
 	// Instruct the server to trigger the necessary events to attempt to
 	// open a new channel. A stream is returned in place, this stream will
 	// be used to consume updates of the state of the pending channel.
 	updateChan, errChan := r.server.OpenChannel(
 		in.TargetPeerId, nodePubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		feePerByte, in.Private,
+		feePerByte, in.Private, fundingInputs, in.Psbt,
 	)
 
 	var outpoint wire.OutPoint
@@ -743,10 +1032,22 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	rpcsLog.Tracef("[openchannel] target sat/byte for funding tx: %v",
 		int64(feePerByte))
 
+	// Funding through a PSBT requires a stream of updates, so it's only
+	// supported by the OpenChannel call.
+	if in.Psbt {
+		return nil, fmt.Errorf("psbt funding isn't supported by " +
+			"OpenChannelSync, use OpenChannel instead")
+	}
+
+	fundingInputs, err := parseOutPoints(in.Outpoints)
+	if err != nil {
+		return nil, err
+	}
+
 	updateChan, errChan := r.server.OpenChannel(
 		in.TargetPeerId, nodepubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		feePerByte, in.Private,
+		feePerByte, in.Private, fundingInputs, false,
 	)
 
 	select {
@@ -777,6 +1078,75 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	}
 }
 
+// FundingPsbtFinalize resumes a channel funding flow that's waiting for an
+// external wallet to fund the channel, using the passed signed PSBT.
+func (r *rpcServer) FundingPsbtFinalize(ctx context.Context,
+	in *lnrpc.FundingPsbtFinalizeRequest) (*lnrpc.FundingPsbtFinalizeResponse,
+	error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "openchannel",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.PendingChanId) != 32 {
+		return nil, fmt.Errorf("pending channel ID must be 32 bytes")
+	}
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], in.PendingChanId)
+
+	packet, err := psbt.Parse(bytes.NewReader(in.SignedPsbt))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse psbt: %v", err)
+	}
+
+	rpcsLog.Infof("[psbtfinalize] pending_chan_id=%x", pendingChanID[:])
+
+	fundingTxID, err := r.server.fundingMgr.FinalizePsbtFunding(
+		pendingChanID, packet,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.FundingPsbtFinalizeResponse{
+		FundingTxid: fundingTxID.String(),
+	}, nil
+}
+
+// FundingPsbtCancel aborts a channel funding flow that's waiting for an
+// external wallet to fund the channel.
+func (r *rpcServer) FundingPsbtCancel(ctx context.Context,
+	in *lnrpc.FundingPsbtCancelRequest) (*lnrpc.FundingPsbtCancelResponse,
+	error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "openchannel",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.PendingChanId) != 32 {
+		return nil, fmt.Errorf("pending channel ID must be 32 bytes")
+	}
+	var pendingChanID [32]byte
+	copy(pendingChanID[:], in.PendingChanId)
+
+	rpcsLog.Infof("[psbtcancel] pending_chan_id=%x", pendingChanID[:])
+
+	err := r.server.fundingMgr.CancelPsbtFunding(pendingChanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.FundingPsbtCancelResponse{}, nil
+}
+
 // CloseLink attempts to close an active channel identified by its channel
 // point. The actions of this method can additionally be augmented to attempt
 // a force close after a timeout period in the case of an inactive peer.
@@ -810,55 +1180,26 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 
 	// TODO(roasbeef): if force and peer online then don't force?
 
-	// If a force closure was requested, then we'll handle all the details
-	// around the creation and broadcast of the unilateral closure
-	// transaction here rather than going to the switch as we don't require
-	// interaction from the peer.
+	// If a force closure was requested, then we'll hand the request off
+	// to the chain arbitrator rather than going to the switch as we don't
+	// require interaction from the peer.
 	if force {
-		// As the first part of the force closure, we first fetch the
-		// channel from the database, then execute a direct force
-		// closure broadcasting our current commitment transaction.
-		channel, err := r.fetchActiveChannel(*chanPoint)
-		if err != nil {
-			return err
-		}
-		defer func() {
-			channel.Stop()
-		}()
-
 		_, bestHeight, err := r.server.cc.chainIO.GetBestBlock()
 		if err != nil {
 			return err
 		}
 
-		// As we're force closing this channel, as a precaution, we'll
-		// ensure that the switch doesn't continue to see this channel
-		// as eligible for forwarding HTLC's. If the peer is online,
-		// then we'll also purge all of its indexes.
-		remotePub := &channel.StateSnapshot().RemoteIdentity
-		if peer, err := r.server.FindPeer(remotePub); err == nil {
-			// TODO(roasbeef): actually get the active channel
-			// instead too?
-			//  * so only need to grab from database
-			peer.WipeChannel(channel.ChannelPoint())
-		} else {
-			chanID := lnwire.NewChanIDFromOutPoint(channel.ChannelPoint())
-			r.server.htlcSwitch.RemoveLink(chanID)
-		}
-
-		select {
-		case r.server.breachArbiter.settledContracts <- chanPoint:
-		case <-r.quit:
-			return fmt.Errorf("server shutting down")
-		}
-
-		// With the necessary indexes cleaned up, we'll now force close
-		// the channel.
-		closingTxid, closeSummary, err := r.forceCloseChan(channel)
+		// The chain arbitrator will broadcast our latest commitment
+		// transaction, and then resolve any HTLC's within it
+		// on-chain.
+		closeSummary, err := r.server.chainArb.ForceCloseContract(
+			*chanPoint,
+		)
 		if err != nil {
 			rpcsLog.Errorf("unable to force close transaction: %v", err)
 			return err
 		}
+		closingTxid := closeSummary.CloseTx.TxHash()
 
 		// With the transaction broadcast, we send our first update to
 		// the client.
@@ -871,12 +1212,10 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 			},
 		}
 
-		channel.CancelObserver()
-
 		errChan = make(chan error, 1)
 		notifier := r.server.cc.chainNotifier
 		go waitForChanToClose(uint32(bestHeight), notifier, errChan, chanPoint,
-			closingTxid, func() {
+			&closingTxid, func() {
 				// Respond to the local subsystem which
 				// requested the channel closure.
 				updateChan <- &lnrpc.CloseStatusUpdate{
@@ -889,11 +1228,14 @@ func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
 				}
 
 				// If we didn't have an output active on the
-				// commitment transaction, and had no outgoing
-				// HTLC's then we can mark the channels as
-				// closed as there are no funds to be swept.
+				// commitment transaction, and had no HTLC's
+				// then we can mark the channels as closed as
+				// there are no funds to be swept.
+				htlcs := closeSummary.HtlcResolutions
 				if closeSummary.SelfOutputSignDesc == nil &&
-					len(closeSummary.HtlcResolutions) == 0 {
+					len(htlcs.OutgoingHTLCs) == 0 &&
+					len(htlcs.IncomingHTLCs) == 0 {
+
 					err := r.server.chanDB.MarkChanFullyClosed(chanPoint)
 					if err != nil {
 						rpcsLog.Errorf("unable to "+
@@ -969,105 +1311,6 @@ out:
 	return nil
 }
 
-// fetchActiveChannel attempts to locate a channel identified by it's channel
-// point from the database's set of all currently opened channels.
-func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
-	dbChannels, err := r.server.chanDB.FetchAllChannels()
-	if err != nil {
-		return nil, err
-	}
-
-	// With the channels fetched, attempt to locate the target channel
-	// according to its channel point.
-	var dbChan *channeldb.OpenChannel
-	for _, dbChannel := range dbChannels {
-		if dbChannel.FundingOutpoint == chanPoint {
-			dbChan = dbChannel
-			break
-		}
-	}
-
-	// If the channel cannot be located, then we exit with an error to the
-	// caller.
-	if dbChan == nil {
-		return nil, fmt.Errorf("unable to find channel")
-	}
-
-	// Otherwise, we create a fully populated channel state machine which
-	// uses the db channel as backing storage.
-	return lnwallet.NewLightningChannel(r.server.cc.wallet.Cfg.Signer, nil,
-		r.server.cc.feeEstimator, dbChan)
-}
-
-// forceCloseChan executes a unilateral close of the target channel by
-// broadcasting the current commitment state directly on-chain. Once the
-// commitment transaction has been broadcast, a struct describing the final
-// state of the channel is sent to the utxoNursery in order to ultimately sweep
-// the immature outputs.
-func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel) (*chainhash.Hash, *lnwallet.ForceCloseSummary, error) {
-
-	// Execute a unilateral close shutting down all further channel
-	// operation.
-	closeSummary, err := channel.ForceClose()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	closeTx := closeSummary.CloseTx
-	txid := closeTx.TxHash()
-
-	// With the close transaction in hand, broadcast the transaction to the
-	// network, thereby entering the postk channel resolution state.
-	rpcsLog.Infof("Broadcasting force close transaction, ChannelPoint(%v): %v",
-		channel.ChannelPoint(), newLogClosure(func() string {
-			return spew.Sdump(closeTx)
-		}))
-	if err := r.server.cc.wallet.PublishTransaction(closeTx); err != nil {
-		return nil, nil, err
-	}
-
-	// Now that the closing transaction has been broadcast successfully,
-	// we'll mark this channel as being in the pending closed state. The
-	// UTXO nursery will mark the channel as fully closed once all the
-	// outputs have been swept.
-	//
-	// TODO(roasbeef): don't set local balance if close summary detects
-	// dust output?
-	chanPoint := channel.ChannelPoint()
-	chanInfo := channel.StateSnapshot()
-	closeInfo := &channeldb.ChannelCloseSummary{
-		ChanPoint:   *chanPoint,
-		ChainHash:   chanInfo.ChainHash,
-		ClosingTXID: closeTx.TxHash(),
-		RemotePub:   &chanInfo.RemoteIdentity,
-		Capacity:    chanInfo.Capacity,
-		CloseType:   channeldb.ForceClose,
-		IsPending:   true,
-	}
-
-	// If our commitment output isn't dust or we have active HTLC's on the
-	// commitment transaction, then we'll populate the balances on the
-	// close channel summary.
-	if closeSummary.SelfOutputSignDesc != nil ||
-		len(closeSummary.HtlcResolutions) == 0 {
-
-		closeInfo.SettledBalance = chanInfo.LocalBalance.ToSatoshis()
-		closeInfo.TimeLockedBalance = chanInfo.LocalBalance.ToSatoshis()
-	}
-
-	if err := channel.DeleteState(closeInfo); err != nil {
-		return nil, nil, err
-	}
-
-	// Send the closed channel summary over to the utxoNursery in order to
-	// have its outputs swept back into the wallet once they're mature.
-	if err := r.server.utxoNursery.IncubateOutputs(closeSummary); err != nil {
-		return nil, nil, err
-	}
-
-	return &txid, closeSummary, nil
-}
-
 // GetInfo returns general information concerning the lightning node including
 // it's identity pubkey, alias, the chains it is connected to, and information
 // concerning the number of open+pending channels.
@@ -1095,7 +1338,7 @@ func (r *rpcServer) GetInfo(ctx context.Context,
 	}
 	nPendingChannels := uint32(len(pendingChannels))
 
-	idPub := r.server.identityPriv.PubKey().SerializeCompressed()
+	idPub := r.server.identityKey.PubKey().SerializeCompressed()
 
 	bestHash, bestHeight, err := r.server.cc.chainIO.GetBestBlock()
 	if err != nil {
@@ -1314,6 +1557,9 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 			CommitFee:      int64(localCommitment.CommitFee),
 			FeePerKw:       int64(localCommitment.FeePerKw),
 			BlocksTillOpen: blocksTillOpen,
+			FeeBump: r.marshallFeeBump(
+				pendingChan.FundingOutpoint.Hash,
+			),
 			// TODO(roasbeef): need to track confirmation height
 		}
 	}
@@ -1342,6 +1588,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 		}
 
 		closeTXID := pendingClose.ClosingTXID.String()
+		feeBump := r.marshallFeeBump(pendingClose.ClosingTXID)
 
 		switch pendingClose.CloseType {
 
@@ -1353,6 +1600,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 				&lnrpc.PendingChannelResponse_ClosedChannel{
 					Channel:     channel,
 					ClosingTxid: closeTXID,
+					FeeBump:     feeBump,
 				},
 			)
 
@@ -1364,6 +1612,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 			forceClose := &lnrpc.PendingChannelResponse_ForceClosedChannel{
 				Channel:     channel,
 				ClosingTxid: closeTXID,
+				FeeBump:     feeBump,
 			}
 
 			// Query for the maturity state for this force closed
@@ -1428,16 +1677,85 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 	return resp, nil
 }
 
-// ListChannels returns a description of all the open channels that this node
-// is a participant in.
-func (r *rpcServer) ListChannels(ctx context.Context,
-	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+// marshallFeeBump returns the RPC representation of the most recent fee bump
+// of the transaction with the passed txid, or nil if it wasn't bumped.
+func (r *rpcServer) marshallFeeBump(
+	txid chainhash.Hash) *lnrpc.PendingChannelResponse_FeeBump {
 
-	// Check macaroon to see if this is allowed.
-	if r.authSvc != nil {
-		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
-			r.authSvc); err != nil {
-			return nil, err
+	bump := r.server.feeBumper.FeeBump(txid)
+	if bump == nil {
+		return nil
+	}
+
+	packageFeeRate := bump.packageFeeRate * blockchain.WitnessScaleFactor
+
+	return &lnrpc.PendingChannelResponse_FeeBump{
+		ChildTxid:         bump.childTxid.String(),
+		ChildFeeSat:       int64(bump.childFee),
+		PackageSatPerByte: int64(packageFeeRate),
+		Confirmed:         bump.confirmed,
+	}
+}
+
+// PendingSweeps returns a list of all the outputs currently being swept by the
+// utxo sweeper, along with the fee rate and state of their sweep.
+func (r *rpcServer) PendingSweeps(ctx context.Context,
+	in *lnrpc.PendingSweepsRequest) (*lnrpc.PendingSweepsResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "pendingsweeps",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	reports, err := r.server.sweeper.PendingSweeps()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.PendingSweepsResponse{
+		PendingSweeps: make(
+			[]*lnrpc.PendingSweepsResponse_PendingSweep, 0,
+			len(reports),
+		),
+	}
+	for _, report := range reports {
+		// The sweeper tracks fee rates in satoshis per weight unit, so
+		// we'll scale it up to the sat/byte the rest of the RPC
+		// interface uses.
+		feeRate := report.feeRate * blockchain.WitnessScaleFactor
+
+		pendingSweep := &lnrpc.PendingSweepsResponse_PendingSweep{
+			Outpoint:            report.outPoint.String(),
+			AmountSat:           int64(report.amount),
+			WitnessType:         report.witnessType.String(),
+			ConfTarget:          report.confTarget,
+			SatPerByte:          int64(feeRate),
+			BroadcastAttempts:   report.publishAttempts,
+			LastBroadcastHeight: report.lastBroadcastHeight,
+		}
+		if report.sweepTxid != nil {
+			pendingSweep.SweepTxid = report.sweepTxid.String()
+		}
+
+		resp.PendingSweeps = append(resp.PendingSweeps, pendingSweep)
+	}
+
+	return resp, nil
+}
+
+// ListChannels returns a description of all the open channels that this node
+// is a participant in.
+func (r *rpcServer) ListChannels(ctx context.Context,
+	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "listchannels",
+			r.authSvc); err != nil {
+			return nil, err
 		}
 	}
 
@@ -1541,13 +1859,19 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 }
 
 // savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, rHash []byte) error {
-
-	paymentPath := make([][33]byte, len(route.Hops))
-	for i, hop := range route.Hops {
-		hopPub := hop.Channel.Node.PubKey.SerializeCompressed()
-		copy(paymentPath[i][:], hopPub)
+// historical record keeping. If the payment was split across multiple routes,
+// then the fees of all shards are summed, the path of the first shard is
+// recorded as the path of the payment, and each shard is recorded as well.
+func (r *rpcServer) savePayment(routes []*routing.Route,
+	amount lnwire.MilliSatoshi, rHash []byte) error {
+
+	routePath := func(route *routing.Route) [][33]byte {
+		path := make([][33]byte, len(route.Hops))
+		for i, hop := range route.Hops {
+			hopPub := hop.Channel.Node.PubKey.SerializeCompressed()
+			copy(path[i][:], hopPub)
+		}
+		return path
 	}
 
 	payment := &channeldb.OutgoingPayment{
@@ -1557,15 +1881,173 @@ func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi
 			},
 			CreationDate: time.Now(),
 		},
-		Path:           paymentPath,
-		Fee:            route.TotalFees,
-		TimeLockLength: route.TotalTimeLock,
+		Path: routePath(routes[0]),
 	}
 	copy(payment.PaymentHash[:], rHash)
 
+	for _, route := range routes {
+		payment.Fee += route.TotalFees
+		if route.TotalTimeLock > payment.TimeLockLength {
+			payment.TimeLockLength = route.TotalTimeLock
+		}
+
+		if len(routes) == 1 {
+			break
+		}
+
+		payment.Shards = append(payment.Shards, channeldb.PaymentShard{
+			Amt:            route.TotalAmount - route.TotalFees,
+			Fee:            route.TotalFees,
+			TimeLockLength: route.TotalTimeLock,
+			Path:           routePath(route),
+		})
+	}
+
 	return r.server.chanDB.AddPayment(payment)
 }
 
+// payReqMaxShards returns the maximum number of shards a payment to the
+// passed payment request may be split into. Payments are only split if the
+// recipient signals support for multi-path payments.
+func payReqMaxShards(payReq *zpay32.Invoice) uint32 {
+	if payReq.Features == nil {
+		return 0
+	}
+
+	if !payReq.Features.IsSet(lnwire.MultiPathOptional) &&
+		!payReq.Features.IsSet(lnwire.MultiPathRequired) {
+
+		return 0
+	}
+
+	return maxPaymentShards
+}
+
+// maxHopHints is the maximum number of route hints that will be added to an
+// invoice, which keeps the encoded payment request reasonably sized.
+const maxHopHints = 20
+
+// selectHopHints returns a route hint for each of our active private channels
+// that has enough inbound capacity to receive the passed amount. Each hint
+// describes the single hop from the remote peer to us, using the forwarding
+// policy the peer advertised for the channel.
+func (r *rpcServer) selectHopHints(
+	amtMSat lnwire.MilliSatoshi) ([]zpay32.ExtraRoutingInfo, error) {
+
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+	selfPub := r.server.identityKey.PubKey()
+
+	var hopHints []zpay32.ExtraRoutingInfo
+	for _, dbChannel := range dbChannels {
+		if len(hopHints) >= maxHopHints {
+			break
+		}
+
+		// Public channels are already known to the payer, so we'll
+		// only consider private channels that are fully open.
+		if dbChannel.IsPending ||
+			dbChannel.ChannelFlags&lnwire.FFAnnounceChannel != 0 {
+
+			continue
+		}
+
+		// The remote party must be able to send the full amount to us
+		// over the channel.
+		if dbChannel.LocalCommitment.RemoteBalance < amtMSat {
+			continue
+		}
+
+		// We'll also skip any channel that isn't currently able to
+		// forward payments.
+		chanPoint := dbChannel.FundingOutpoint
+		chanID := lnwire.NewChanIDFromOutPoint(&chanPoint)
+		link, err := r.server.htlcSwitch.GetLink(chanID)
+		if err != nil || !link.EligibleToForward() {
+			continue
+		}
+
+		// Finally, we'll need the policy of the remote party, which
+		// is the edge of the channel that leads to us. If we haven't
+		// received it yet, then the hint can't be constructed.
+		_, e1, e2, err := graph.FetchChannelEdgesByOutpoint(&chanPoint)
+		if err != nil {
+			continue
+		}
+		var remotePolicy *channeldb.ChannelEdgePolicy
+		switch {
+		case e1 != nil && e1.Node.PubKey.IsEqual(selfPub):
+			remotePolicy = e1
+		case e2 != nil && e2.Node.PubKey.IsEqual(selfPub):
+			remotePolicy = e2
+		default:
+			continue
+		}
+
+		hopHints = append(hopHints, zpay32.ExtraRoutingInfo{
+			PubKey:      dbChannel.IdentityPub,
+			ShortChanID: dbChannel.ShortChanID.ToUint64(),
+			FeeBaseMsat: uint32(remotePolicy.FeeBaseMSat),
+			FeeProportionalMillionths: uint32(
+				remotePolicy.FeeProportionalMillionths,
+			),
+			CltvExpDelta: remotePolicy.TimeLockDelta,
+		})
+	}
+
+	return hopHints, nil
+}
+
+// payReqRouteHints converts the route hints of the passed payment request into
+// the hop hints used by the channel router to reach private destinations.
+func payReqRouteHints(payReq *zpay32.Invoice) [][]routing.HopHint {
+	if len(payReq.RouteHints) == 0 {
+		return nil
+	}
+
+	routeHints := make([][]routing.HopHint, 0, len(payReq.RouteHints))
+	for _, routeHint := range payReq.RouteHints {
+		hopHints := make([]routing.HopHint, 0, len(routeHint))
+		for _, hop := range routeHint {
+			hopHints = append(hopHints, routing.HopHint{
+				NodeID:                    hop.PubKey,
+				ChannelID:                 hop.ShortChanID,
+				FeeBaseMSat:               hop.FeeBaseMsat,
+				FeeProportionalMillionths: hop.FeeProportionalMillionths,
+				CLTVExpiryDelta:           hop.CltvExpDelta,
+			})
+		}
+
+		routeHints = append(routeHints, hopHints)
+	}
+
+	return routeHints
+}
+
+// marshallSendResponse creates the response to a successful payment that was
+// sent along the passed routes.
+func marshallSendResponse(preImage [32]byte,
+	routes []*routing.Route) *lnrpc.SendResponse {
+
+	resp := &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshallRoute(routes[0]),
+	}
+	if len(routes) > 1 {
+		for _, route := range routes {
+			resp.ShardRoutes = append(
+				resp.ShardRoutes, marshallRoute(route),
+			)
+		}
+	}
+
+	return resp
+}
+
 // validatePayReqExpiry checks if the passed payment request has expired. In
 // the case it has expired, an error will be returned.
 func validatePayReqExpiry(payReq *zpay32.Invoice) error {
@@ -1594,17 +2076,20 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 	// For each payment we need to know the msat amount, the destination
 	// public key, and the payment hash.
 	type payment struct {
-		msat      lnwire.MilliSatoshi
-		dest      []byte
-		pHash     []byte
-		cltvDelta uint16
+		msat       lnwire.MilliSatoshi
+		dest       []byte
+		pHash      []byte
+		cltvDelta  uint16
+		feeLimit   *lnwire.MilliSatoshi
+		cltvLimit  *uint32
+		maxShards  uint32
+		routeHints [][]routing.HopHint
+		records    htlcswitch.CustomRecords
+		payReq     []byte
 	}
 	payChan := make(chan *payment)
 	errChan := make(chan error, 1)
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
-
 	// We don't allow payments to be sent while the daemon itself is still
 	// syncing as we may be trying to sent a payment over a "stale"
 	// channel.
@@ -1706,6 +2191,9 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 					p.msat = *payReq.MilliSat
 					p.pHash = payReq.PaymentHash[:]
 					p.cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+					p.maxShards = payReqMaxShards(payReq)
+					p.routeHints = payReqRouteHints(payReq)
+					p.payReq = []byte(nextPayment.PaymentRequest)
 				} else {
 					// If the payment request field was not
 					// specified, construct the payment from
@@ -1717,6 +2205,41 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 					p.pHash = nextPayment.PaymentHash
 				}
 
+				// If this is a spontaneous payment, then we'll
+				// generate the preimage ourselves, and pay to
+				// its hash instead.
+				if nextPayment.KeySend {
+					rHash, records, err := newKeySendRecords(
+						nextPayment,
+					)
+					if err != nil {
+						select {
+						case errChan <- err:
+						case <-reqQuit:
+						}
+						return
+					}
+					p.pHash = rHash[:]
+					p.records = records
+				}
+
+				// Finally, we'll apply the limits the route
+				// used for this payment must adhere to.
+				p.feeLimit, err = calculateFeeLimit(
+					nextPayment.FeeLimit, p.msat,
+				)
+				if err != nil {
+					select {
+					case errChan <- err:
+					case <-reqQuit:
+					}
+					return
+				}
+				if nextPayment.CltvLimit != 0 {
+					cltvLimit := nextPayment.CltvLimit
+					p.cltvLimit = &cltvLimit
+				}
+
 				select {
 				case payChan <- p:
 				case <-reqQuit:
@@ -1785,14 +2308,20 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// returned. Otherwise, we'll get a non-nil
 				// error.
 				payment := &routing.LightningPayment{
-					Target:      destNode,
-					Amount:      p.msat,
-					PaymentHash: rHash,
+					Target:         destNode,
+					Amount:         p.msat,
+					PaymentHash:    rHash,
+					FeeLimit:       p.feeLimit,
+					CltvLimit:      p.cltvLimit,
+					MaxShards:      p.maxShards,
+					RouteHints:     p.routeHints,
+					CustomRecords:  p.records,
+					PaymentRequest: p.payReq,
 				}
 				if p.cltvDelta != 0 {
 					payment.FinalCLTVDelta = &p.cltvDelta
 				}
-				preImage, route, err := r.server.chanRouter.SendPayment(payment)
+				preImage, routes, err := r.server.chanRouter.SendPayment(payment)
 				if err != nil {
 					// If we receive payment error than,
 					// instead of terminating the stream,
@@ -1808,15 +2337,14 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 				// Save the completed payment to the database
 				// for record keeping purposes.
-				if err := r.savePayment(route, p.msat, rHash[:]); err != nil {
+				if err := r.savePayment(routes, p.msat, rHash[:]); err != nil {
 					errChan <- err
 					return
 				}
 
-				err = paymentStream.Send(&lnrpc.SendResponse{
-					PaymentPreimage: preImage[:],
-					PaymentRoute:    marshallRoute(route),
-				})
+				err = paymentStream.Send(
+					marshallSendResponse(preImage, routes),
+				)
 				if err != nil {
 					errChan <- err
 					return
@@ -1826,6 +2354,60 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 	}
 }
 
+// calculateFeeLimit returns the maximum fee in milli-satoshis that may be paid
+// to route a payment of amt, as specified by the passed fee limit. If no fee
+// limit is specified, then nil is returned.
+func calculateFeeLimit(feeLimit *lnrpc.FeeLimit,
+	amt lnwire.MilliSatoshi) (*lnwire.MilliSatoshi, error) {
+
+	var limit lnwire.MilliSatoshi
+	switch feeLimit.GetLimit().(type) {
+	case *lnrpc.FeeLimit_Fixed:
+		if feeLimit.GetFixed() < 0 {
+			return nil, fmt.Errorf("fee limit must not be negative")
+		}
+
+		limit = lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(feeLimit.GetFixed()),
+		)
+
+	case *lnrpc.FeeLimit_Percent:
+		if feeLimit.GetPercent() < 0 {
+			return nil, fmt.Errorf("fee limit must not be negative")
+		}
+
+		limit = amt * lnwire.MilliSatoshi(feeLimit.GetPercent()) / 100
+
+	default:
+		return nil, nil
+	}
+
+	return &limit, nil
+}
+
+// newKeySendRecords generates a fresh preimage for a spontaneous payment,
+// returning its hash along with the custom records that carry the preimage to
+// the destination within the onion.
+func newKeySendRecords(req *lnrpc.SendRequest) ([32]byte,
+	htlcswitch.CustomRecords, error) {
+
+	if req.PaymentRequest != "" {
+		return [32]byte{}, nil, fmt.Errorf("a spontaneous payment " +
+			"can't pay a payment request")
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	records := htlcswitch.CustomRecords{
+		htlcswitch.KeySendType: preimage[:],
+	}
+
+	return sha256.Sum256(preimage[:]), records, nil
+}
+
 // SendPaymentSync is the synchronous non-streaming version of SendPayment.
 // This RPC is intended to be consumed by clients of the REST proxy.
 // Additionally, this RPC expects the destination's public key and the payment
@@ -1841,9 +2423,6 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		}
 	}
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
-
 	// We don't allow payments to be sent while the daemon itself is still
 	// syncing as we may be trying to sent a payment over a "stale"
 	// channel.
@@ -1853,10 +2432,13 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	}
 
 	var (
-		destPub   *btcec.PublicKey
-		amtMSat   lnwire.MilliSatoshi
-		rHash     [32]byte
-		cltvDelta uint16
+		destPub    *btcec.PublicKey
+		amtMSat    lnwire.MilliSatoshi
+		rHash      [32]byte
+		cltvDelta  uint16
+		maxShards  uint32
+		routeHints [][]routing.HopHint
+		records    htlcswitch.CustomRecords
 	)
 
 	// If the proto request has an encoded payment request, then we we'll
@@ -1881,6 +2463,8 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		amtMSat = *payReq.MilliSat
 		rHash = *payReq.PaymentHash
 		cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+		maxShards = payReqMaxShards(payReq)
+		routeHints = payReqRouteHints(payReq)
 
 		// Otherwise, the payment conditions have been manually
 		// specified in the proto.
@@ -1913,6 +2497,16 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		)
 	}
 
+	// If this is a spontaneous payment, then we'll generate the preimage
+	// ourselves, and pay to its hash instead.
+	if nextPayment.KeySend {
+		var err error
+		rHash, records, err = newKeySendRecords(nextPayment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Currently, within the bootstrap phase of the network, we limit the
 	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
 	// satoshis.
@@ -1924,18 +2518,33 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		}, nil
 	}
 
+	feeLimit, err := calculateFeeLimit(nextPayment.FeeLimit, amtMSat)
+	if err != nil {
+		return nil, err
+	}
+
 	// Finally, send a payment request to the channel router. If the
 	// payment succeeds, then the returned route will be that was used
 	// successfully within the payment.
 	payment := &routing.LightningPayment{
-		Target:      destPub,
-		Amount:      amtMSat,
-		PaymentHash: rHash,
+		Target:        destPub,
+		Amount:        amtMSat,
+		PaymentHash:   rHash,
+		FeeLimit:      feeLimit,
+		MaxShards:     maxShards,
+		RouteHints:    routeHints,
+		CustomRecords: records,
+	}
+	if nextPayment.PaymentRequest != "" {
+		payment.PaymentRequest = []byte(nextPayment.PaymentRequest)
 	}
 	if cltvDelta != 0 {
 		payment.FinalCLTVDelta = &cltvDelta
 	}
-	preImage, route, err := r.server.chanRouter.SendPayment(payment)
+	if nextPayment.CltvLimit != 0 {
+		payment.CltvLimit = &nextPayment.CltvLimit
+	}
+	preImage, routes, err := r.server.chanRouter.SendPayment(payment)
 	if err != nil {
 		return &lnrpc.SendResponse{
 			PaymentError: err.Error(),
@@ -1944,14 +2553,11 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 
 	// With the payment completed successfully, we now ave the details of
 	// the completed payment to the database for historical record keeping.
-	if err := r.savePayment(route, amtMSat, rHash[:]); err != nil {
+	if err := r.savePayment(routes, amtMSat, rHash[:]); err != nil {
 		return nil, err
 	}
 
-	return &lnrpc.SendResponse{
-		PaymentPreimage: preImage[:],
-		PaymentRoute:    marshallRoute(route),
-	}, nil
+	return marshallSendResponse(preImage, routes), nil
 }
 
 // AddInvoice attempts to add a new invoice to the invoice database. Any
@@ -1968,9 +2574,55 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		}
 	}
 
+	return r.addInvoice(invoice, nil)
+}
+
+// AddHoldInvoice adds a new hold invoice to the invoice database. Only the
+// payment hash of a hold invoice is known, so the HTLCs paying to it are held
+// until the invoice is either settled or canceled.
+func (r *rpcServer) AddHoldInvoice(ctx context.Context,
+	req *lnrpc.AddHoldInvoiceRequest) (*lnrpc.AddInvoiceResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "addinvoice",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.Hash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(req.Hash))
+	}
+	var paymentHash [32]byte
+	copy(paymentHash[:], req.Hash)
+
+	invoice := &lnrpc.Invoice{
+		Memo:            req.Memo,
+		Value:           req.Value,
+		DescriptionHash: req.DescriptionHash,
+		Expiry:          req.Expiry,
+		FallbackAddr:    req.FallbackAddr,
+		CltvExpiry:      req.CltvExpiry,
+	}
+
+	return r.addInvoice(invoice, &paymentHash)
+}
+
+// addInvoice adds the passed invoice to the invoice database. If holdHash is
+// non-nil, then the invoice is added as a hold invoice paying to that hash.
+// Otherwise, the invoice pays to the hash of its preimage.
+func (r *rpcServer) addInvoice(invoice *lnrpc.Invoice,
+	holdHash *[32]byte) (*lnrpc.AddInvoiceResponse, error) {
+
 	var paymentPreimage [32]byte
 
 	switch {
+	// The preimage of a hold invoice isn't known until it's settled.
+	case holdHash != nil:
+		paymentPreimage = channeldb.UnknownPreimage
+
 	// If a preimage wasn't specified, then we'll generate a new preimage
 	// from fresh cryptographic randomness.
 	case len(invoice.RPreimage) == 0:
@@ -2019,9 +2671,13 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
-	// Next, generate the payment hash itself from the preimage. This will
-	// be used by clients to query for the state of a particular invoice.
+	// Next, generate the payment hash itself from the preimage, unless it
+	// was given for a hold invoice. This will be used by clients to query
+	// for the state of a particular invoice.
 	rHash := sha256.Sum256(paymentPreimage[:])
+	if holdHash != nil {
+		rHash = *holdHash
+	}
 
 	// We also create an encoded payment request which allows the
 	// caller to compactly send the invoice to the payer. We'll create a
@@ -2080,6 +2736,26 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		options = append(options, zpay32.CLTVExpiry(uint64(defaultDelta)))
 	}
 
+	// As the invoice registry is able to hold the shards of a payment
+	// until the full amount has arrived, we'll signal to the payer that
+	// the payment may be split across multiple routes.
+	options = append(options, zpay32.Features(
+		lnwire.NewRawFeatureVector(lnwire.MultiPathOptional),
+	))
+
+	// If we have any private channels able to carry the payment, then
+	// we'll include a route hint for each of them, as the payer would
+	// otherwise be unaware of them.
+	hopHints, err := r.selectHopHints(amtMSat)
+	if err != nil {
+		return nil, err
+	}
+	for _, hopHint := range hopHints {
+		options = append(options, zpay32.RouteHint(
+			[]zpay32.ExtraRoutingInfo{hopHint},
+		))
+	}
+
 	// Create and encode the payment request as a bech32 (zpay32) string.
 	creationDate := time.Now()
 	payReq, err := zpay32.NewInvoice(
@@ -2094,7 +2770,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 
 	payReqString, err := payReq.Encode(
 		zpay32.MessageSigner{
-			SignCompact: r.server.nodeSigner.SignDigestCompact,
+			SignCompact: r.server.identityKey.SignDigestCompact,
 		},
 	)
 	if err != nil {
@@ -2106,8 +2782,10 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		Memo:           []byte(invoice.Memo),
 		Receipt:        invoice.Receipt,
 		PaymentRequest: []byte(payReqString),
+		Expiry:         payReq.Expiry(),
 		Terms: channeldb.ContractTerm{
-			Value: amtMSat,
+			PaymentHash: rHash,
+			Value:       amtMSat,
 		},
 	}
 	copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
@@ -2126,6 +2804,7 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	return &lnrpc.AddInvoiceResponse{
 		RHash:          rHash[:],
 		PaymentRequest: payReqString,
+		AddIndex:       i.AddIndex,
 	}, nil
 }
 
@@ -2163,6 +2842,16 @@ func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
 	preimage := invoice.Terms.PaymentPreimage
 	satAmt := invoice.Terms.Value.ToSatoshis()
 
+	var htlcs []*lnrpc.InvoiceHTLC
+	for _, htlc := range invoice.Htlcs {
+		htlcs = append(htlcs, &lnrpc.InvoiceHTLC{
+			ChanId:     htlc.ChanID.ToUint64(),
+			HtlcIndex:  htlc.HtlcID,
+			AmtMsat:    uint64(htlc.Amt),
+			AcceptTime: htlc.AcceptTime.Unix(),
+		})
+	}
+
 	return &lnrpc.Invoice{
 		Memo:            string(invoice.Memo[:]),
 		Receipt:         invoice.Receipt[:],
@@ -2171,12 +2860,97 @@ func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
 		Value:           int64(satAmt),
 		CreationDate:    invoice.CreationDate.Unix(),
 		SettleDate:      settleDate,
-		Settled:         invoice.Terms.Settled,
+		Settled:         invoice.Terms.State == channeldb.ContractSettled,
 		PaymentRequest:  paymentRequest,
 		DescriptionHash: descHash,
 		Expiry:          expiry,
 		CltvExpiry:      cltvExpiry,
 		FallbackAddr:    fallbackAddr,
+		Htlcs:           htlcs,
+		AmtPaid:         int64(invoice.AmtPaid().ToSatoshis()),
+		State:           lnrpc.Invoice_InvoiceState(invoice.Terms.State),
+		AddIndex:        invoice.AddIndex,
+		SettleIndex:     invoice.SettleIndex,
+	}, nil
+}
+
+// SettleInvoice settles an accepted hold invoice using the preimage of its
+// payment hash.
+func (r *rpcServer) SettleInvoice(ctx context.Context,
+	req *lnrpc.SettleInvoiceMsg) (*lnrpc.SettleInvoiceResp, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "settleinvoice",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.Preimage) != 32 {
+		return nil, fmt.Errorf("payment preimage must be exactly "+
+			"32 bytes, is instead %v", len(req.Preimage))
+	}
+	var preimage [32]byte
+	copy(preimage[:], req.Preimage)
+
+	if err := r.server.invoices.SettleHoldInvoice(preimage); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SettleInvoiceResp{}, nil
+}
+
+// CancelInvoice cancels an invoice that hasn't been settled yet, failing all
+// HTLCs that are held for it.
+func (r *rpcServer) CancelInvoice(ctx context.Context,
+	req *lnrpc.CancelInvoiceMsg) (*lnrpc.CancelInvoiceResp, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "cancelinvoice",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.PaymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(req.PaymentHash))
+	}
+	var paymentHash chainhash.Hash
+	copy(paymentHash[:], req.PaymentHash)
+
+	if err := r.server.invoices.CancelInvoice(paymentHash); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.CancelInvoiceResp{}, nil
+}
+
+// DeleteCanceledInvoices deletes all invoices that have either been canceled
+// or have expired from the database.
+func (r *rpcServer) DeleteCanceledInvoices(ctx context.Context,
+	_ *lnrpc.DeleteCanceledInvoicesRequest) (
+	*lnrpc.DeleteCanceledInvoicesResponse, error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx,
+			"deletecanceledinvoices", r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Debugf("[DeleteCanceledInvoices]")
+
+	numDeleted, err := r.server.invoices.DeleteCanceledInvoices()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeleteCanceledInvoicesResponse{
+		NumDeleted: int64(numDeleted),
 	}, nil
 }
 
@@ -2251,13 +3025,19 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 		}
 	}
 
-	dbInvoices, err := r.server.chanDB.FetchAllInvoices(req.PendingOnly)
+	q := channeldb.InvoiceQuery{
+		IndexOffset:    req.IndexOffset,
+		NumMaxInvoices: req.NumMaxInvoices,
+		PendingOnly:    req.PendingOnly,
+		Reversed:       req.Reversed,
+	}
+	invoiceSlice, err := r.server.chanDB.QueryInvoices(q)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to query invoices: %v", err)
 	}
 
-	invoices := make([]*lnrpc.Invoice, len(dbInvoices))
-	for i, dbInvoice := range dbInvoices {
+	invoices := make([]*lnrpc.Invoice, len(invoiceSlice.Invoices))
+	for i, dbInvoice := range invoiceSlice.Invoices {
 
 		rpcInvoice, err := createRPCInvoice(dbInvoice)
 		if err != nil {
@@ -2268,12 +3048,16 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 	}
 
 	return &lnrpc.ListInvoiceResponse{
-		Invoices: invoices,
+		Invoices:         invoices,
+		FirstIndexOffset: invoiceSlice.FirstIndexOffset,
+		LastIndexOffset:  invoiceSlice.LastIndexOffset,
 	}, nil
 }
 
 // SubscribeInvoices returns a uni-directional stream (sever -> client) for
-// notifying the client of newly added/settled invoices.
+// notifying the client of newly added, accepted, settled, canceled and expired
+// invoices. All invoices added or settled since the add and settle indexes of
+// the request are sent first.
 func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
 
@@ -2285,12 +3069,26 @@ func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 		}
 	}
 
-	invoiceClient := r.server.invoices.SubscribeNotifications()
+	invoiceClient, err := r.server.invoices.SubscribeNotifications(
+		req.AddIndex, req.SettleIndex,
+	)
+	if err != nil {
+		return err
+	}
 	defer invoiceClient.Cancel()
 
 	for {
 		select {
-		// TODO(roasbeef): include newly added invoices?
+		case newInvoice := <-invoiceClient.NewInvoices:
+			rpcInvoice, err := createRPCInvoice(newInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
 		case settledInvoice := <-invoiceClient.SettledInvoices:
 
 			rpcInvoice, err := createRPCInvoice(settledInvoice)
@@ -2301,6 +3099,41 @@ func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 			if err := updateStream.Send(rpcInvoice); err != nil {
 				return err
 			}
+
+		// A hold invoice has been paid in full, and its HTLCs are
+		// held until it's either settled or canceled.
+		case acceptedInvoice := <-invoiceClient.AcceptedInvoices:
+			rpcInvoice, err := createRPCInvoice(acceptedInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
+		// An invoice has been canceled, or its expiry has passed
+		// before it was paid.
+		case canceledInvoice := <-invoiceClient.CanceledInvoices:
+			rpcInvoice, err := createRPCInvoice(canceledInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
+		case expiredInvoice := <-invoiceClient.ExpiredInvoices:
+			rpcInvoice, err := createRPCInvoice(expiredInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
 		case <-r.quit:
 			return nil
 		}
@@ -2626,7 +3459,7 @@ func (r *rpcServer) GetNodeInfo(ctx context.Context,
 // within the HTLC.
 //
 // TODO(roasbeef): should return a slice of routes in reality
-//  * create separate PR to send based on well formatted route
+//   - create separate PR to send based on well formatted route
 func (r *rpcServer) QueryRoutes(ctx context.Context,
 	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
 
@@ -2659,10 +3492,21 @@ func (r *rpcServer) QueryRoutes(ctx context.Context,
 			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
+	feeLimit, err := calculateFeeLimit(in.FeeLimit, amtMSat)
+	if err != nil {
+		return nil, err
+	}
+	var cltvLimit *uint32
+	if in.CltvLimit != 0 {
+		cltvLimit = &in.CltvLimit
+	}
+
 	// Query the channel router for a possible path to the destination that
 	// can carry `in.Amt` satoshis _including_ the total fee required on
-	// the route.
-	routes, err := r.server.chanRouter.FindRoutes(pubKey, amtMSat)
+	// the route, and lies within the requested limits.
+	routes, err := r.server.chanRouter.FindRoutes(
+		pubKey, amtMSat, feeLimit, cltvLimit, nil,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -2699,6 +3543,116 @@ func marshallRoute(route *routing.Route) *lnrpc.Route {
 	return resp
 }
 
+// QueryMissionControl returns the payment attempt results that mission
+// control currently bases its channel success probabilities on.
+func (r *rpcServer) QueryMissionControl(ctx context.Context,
+	_ *lnrpc.QueryMissionControlRequest) (*lnrpc.QueryMissionControlResponse,
+	error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "querymissioncontrol",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := r.server.chanRouter.QueryMissionControl()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.QueryMissionControlResponse{
+		Results: make([]*lnrpc.MissionControlResult, len(results)),
+	}
+	for i, result := range results {
+		rpcResult := &lnrpc.MissionControlResult{
+			ChanId:    result.ChanID,
+			AmtMsat:   int64(result.Amt),
+			Success:   result.Success,
+			Timestamp: result.Timestamp.Unix(),
+		}
+		if result.ChanID == 0 {
+			rpcResult.Node = result.Node[:]
+		}
+
+		resp.Results[i] = rpcResult
+	}
+
+	return resp, nil
+}
+
+// ImportMissionControl adds the passed payment attempt results to the history
+// of mission control.
+func (r *rpcServer) ImportMissionControl(ctx context.Context,
+	in *lnrpc.ImportMissionControlRequest) (*lnrpc.ImportMissionControlResponse,
+	error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "importmissioncontrol",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	// Before importing anything, we'll ensure that every result is
+	// localized to either a channel or a valid node.
+	results := make([]*channeldb.MissionControlResult, len(in.Results))
+	for i, rpcResult := range in.Results {
+		result := &channeldb.MissionControlResult{
+			ChanID:    rpcResult.ChanId,
+			Amt:       lnwire.MilliSatoshi(rpcResult.AmtMsat),
+			Success:   rpcResult.Success,
+			Timestamp: time.Unix(rpcResult.Timestamp, 0),
+		}
+
+		if result.ChanID == 0 {
+			if _, err := btcec.ParsePubKey(rpcResult.Node,
+				btcec.S256()); err != nil {
+
+				return nil, fmt.Errorf("result %v has neither "+
+					"a channel ID nor a valid node: %v", i,
+					err)
+			}
+			copy(result.Node[:], rpcResult.Node)
+		}
+
+		results[i] = result
+	}
+
+	rpcsLog.Debugf("[importmissioncontrol] num_results=%v", len(results))
+
+	if err := r.server.chanRouter.ImportMissionControl(results); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ImportMissionControlResponse{}, nil
+}
+
+// ResetMissionControl clears all payment attempt results from mission
+// control.
+func (r *rpcServer) ResetMissionControl(ctx context.Context,
+	_ *lnrpc.ResetMissionControlRequest) (*lnrpc.ResetMissionControlResponse,
+	error) {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "resetmissioncontrol",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Debugf("[resetmissioncontrol]")
+
+	if err := r.server.chanRouter.ResetMissionControl(); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ResetMissionControlResponse{}, nil
+}
+
 // GetNetworkInfo returns some basic stats about the known channel graph from
 // the PoV of the node.
 func (r *rpcServer) GetNetworkInfo(ctx context.Context,
@@ -2965,9 +3919,10 @@ func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopol
 	}
 }
 
-// ListPayments returns a list of all outgoing payments.
+// ListPayments returns a list of all outgoing payments. Payments that failed
+// or are still in flight are only returned if the request asks for them.
 func (r *rpcServer) ListPayments(ctx context.Context,
-	_ *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+	req *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
 
 	// Check macaroon to see if this is allowed.
 	if r.authSvc != nil {
@@ -2984,26 +3939,215 @@ func (r *rpcServer) ListPayments(ctx context.Context,
 		return nil, err
 	}
 
-	paymentsResp := &lnrpc.ListPaymentsResponse{
-		Payments: make([]*lnrpc.Payment, len(payments)),
+	// The payment control tracks the status of every payment sent through
+	// the router, so we'll use it to fill in the preimages of completed
+	// payments, and to add the payments that haven't completed.
+	trackedPayments, err := r.server.chanDB.FetchTrackedPayments()
+	if err != nil {
+		return nil, err
+	}
+	tracked := make(map[[32]byte]*channeldb.TrackedPayment)
+	for _, payment := range trackedPayments {
+		tracked[payment.Info.PaymentHash] = payment
 	}
-	for i, payment := range payments {
-		path := make([]string, len(payment.Path))
-		for i, hop := range payment.Path {
-			path[i] = hex.EncodeToString(hop[:])
+
+	paymentsResp := &lnrpc.ListPaymentsResponse{
+		Payments: make([]*lnrpc.Payment, 0, len(payments)),
+	}
+	completed := make(map[[32]byte]struct{})
+	for _, payment := range payments {
+		var shards []*lnrpc.PaymentShard
+		for _, shard := range payment.Shards {
+			shards = append(shards, &lnrpc.PaymentShard{
+				Value: int64(shard.Amt.ToSatoshis()),
+				Fee:   int64(shard.Fee.ToSatoshis()),
+				Path:  encodePaymentPath(shard.Path),
+			})
 		}
 
-		paymentsResp.Payments[i] = &lnrpc.Payment{
+		rpcPayment := &lnrpc.Payment{
 			PaymentHash:  hex.EncodeToString(payment.PaymentHash[:]),
 			Value:        int64(payment.Terms.Value.ToSatoshis()),
 			CreationDate: payment.CreationDate.Unix(),
-			Path:         path,
+			Path:         encodePaymentPath(payment.Path),
+			Shards:       shards,
+			Status:       lnrpc.Payment_SUCCEEDED,
+		}
+		if t, ok := tracked[payment.PaymentHash]; ok {
+			if t.Preimage != nil {
+				rpcPayment.PaymentPreimage = hex.EncodeToString(
+					t.Preimage[:],
+				)
+			}
+			rpcPayment.PaymentRequest = string(t.Info.PaymentRequest)
+		}
+
+		paymentsResp.Payments = append(paymentsResp.Payments, rpcPayment)
+		completed[payment.PaymentHash] = struct{}{}
+	}
+
+	for _, payment := range trackedPayments {
+		if _, ok := completed[payment.Info.PaymentHash]; ok {
+			continue
+		}
+
+		if payment.Status != channeldb.StatusSucceeded &&
+			!req.IncludeIncomplete {
+
+			continue
 		}
+
+		paymentsResp.Payments = append(
+			paymentsResp.Payments, marshallTrackedPayment(payment),
+		)
 	}
 
 	return paymentsResp, nil
 }
 
+// TrackPayment returns a uni-directional stream (server -> client) of the
+// state of an outgoing payment. The current state of the payment is sent
+// first, followed by each update until the payment has succeeded or failed.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	// Check macaroon to see if this is allowed.
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(updateStream.Context(),
+			"trackpayment", r.authSvc); err != nil {
+			return err
+		}
+	}
+
+	paymentHash := req.PaymentHash
+	if req.PaymentHashStr != "" {
+		var err error
+		paymentHash, err = hex.DecodeString(req.PaymentHashStr)
+		if err != nil {
+			return err
+		}
+	}
+	if len(paymentHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, "+
+			"is instead %v", len(paymentHash))
+	}
+
+	var hash [32]byte
+	copy(hash[:], paymentHash)
+
+	rpcsLog.Debugf("[TrackPayment] hash=%x", hash)
+
+	sub, err := r.server.chanRouter.SubscribePayment(hash)
+	if err != nil {
+		return err
+	}
+	defer sub.Cancel()
+
+	for {
+		select {
+		case payment, ok := <-sub.Updates:
+			// Once the payment has completed, the subscription is
+			// closed, which ends the stream.
+			if !ok {
+				return nil
+			}
+
+			err := updateStream.Send(marshallTrackedPayment(payment))
+			if err != nil {
+				return err
+			}
+
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// encodePaymentPath hex encodes the public keys of the nodes along the path of
+// a payment.
+func encodePaymentPath(hops [][33]byte) []string {
+	path := make([]string, len(hops))
+	for i, hop := range hops {
+		path[i] = hex.EncodeToString(hop[:])
+	}
+	return path
+}
+
+// marshallTrackedPayment converts a payment tracked by the payment control
+// into its RPC representation. The path of the payment is the path of its
+// first outstanding or settled HTLC.
+func marshallTrackedPayment(payment *channeldb.TrackedPayment) *lnrpc.Payment {
+	rpcPayment := &lnrpc.Payment{
+		PaymentHash:    hex.EncodeToString(payment.Info.PaymentHash[:]),
+		Value:          int64(payment.Info.Value.ToSatoshis()),
+		CreationDate:   payment.Info.CreationDate.Unix(),
+		PaymentRequest: string(payment.Info.PaymentRequest),
+	}
+
+	var fee lnwire.MilliSatoshi
+	for _, attempt := range payment.Attempts {
+		fee += attempt.Fee
+		rpcPayment.Shards = append(rpcPayment.Shards, &lnrpc.PaymentShard{
+			Value: int64(attempt.Amt.ToSatoshis()),
+			Fee:   int64(attempt.Fee.ToSatoshis()),
+			Path:  encodePaymentPath(attempt.Path),
+		})
+	}
+	rpcPayment.Fee = int64(fee.ToSatoshis())
+
+	if len(payment.Attempts) > 0 {
+		rpcPayment.Path = encodePaymentPath(payment.Attempts[0].Path)
+	}
+
+	// Shards are only reported for payments that were split.
+	if len(rpcPayment.Shards) < 2 {
+		rpcPayment.Shards = nil
+	}
+
+	switch payment.Status {
+	case channeldb.StatusInFlight:
+		rpcPayment.Status = lnrpc.Payment_IN_FLIGHT
+
+	case channeldb.StatusSucceeded:
+		rpcPayment.Status = lnrpc.Payment_SUCCEEDED
+
+	case channeldb.StatusFailed:
+		rpcPayment.Status = lnrpc.Payment_FAILED
+
+	default:
+		rpcPayment.Status = lnrpc.Payment_UNKNOWN
+	}
+
+	if payment.Preimage != nil {
+		rpcPayment.PaymentPreimage = hex.EncodeToString(
+			payment.Preimage[:],
+		)
+	}
+
+	if payment.Failure != nil {
+		rpcPayment.FailureMessage = payment.Failure.Message
+
+		switch payment.Failure.Reason {
+		case channeldb.FailureReasonNoRoute:
+			rpcPayment.FailureReason = lnrpc.Payment_FAILURE_REASON_NO_ROUTE
+
+		case channeldb.FailureReasonIncorrectPaymentDetails:
+			rpcPayment.FailureReason = lnrpc.Payment_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS
+
+		case channeldb.FailureReasonInterrupted:
+			rpcPayment.FailureReason = lnrpc.Payment_FAILURE_REASON_INTERRUPTED
+
+		default:
+			rpcPayment.FailureReason = lnrpc.Payment_FAILURE_REASON_ERROR
+		}
+	}
+
+	return rpcPayment
+}
+
 // DeleteAllPayments deletes all outgoing payments from DB.
 func (r *rpcServer) DeleteAllPayments(ctx context.Context,
 	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
@@ -3133,6 +4277,7 @@ func (r *rpcServer) DecodePayReq(ctx context.Context,
 		FallbackAddr:    fallbackAddr,
 		Expiry:          expiry,
 		CltvExpiry:      int64(payReq.MinFinalCLTVExpiry()),
+		MultiPath:       payReqMaxShards(payReq) > 1,
 	}, nil
 }
 
@@ -3285,3 +4430,187 @@ func (r *rpcServer) UpdateFees(ctx context.Context,
 
 	return &lnrpc.FeeUpdateResponse{}, nil
 }
+
+// ForwardingHistory allows the caller to query the htlcswitch for a record of
+// all HTLC's forwarded within the target time range, and integer offset
+// within that time range. If no time-range is specified, then the last 24
+// hours of events are returned.
+func (r *rpcServer) ForwardingHistory(ctx context.Context,
+	req *lnrpc.ForwardingHistoryRequest) (*lnrpc.ForwardingHistoryResponse, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "fwdinghistory",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	// If no end time was specified, we'll query up until now. If no start
+	// time was specified, then we'll default to the day before the end
+	// time.
+	var startTime, endTime time.Time
+	if req.EndTime == 0 {
+		endTime = time.Now()
+	} else {
+		endTime = time.Unix(int64(req.EndTime), 0)
+	}
+	if req.StartTime == 0 {
+		startTime = endTime.Add(-time.Hour * 24)
+	} else {
+		startTime = time.Unix(int64(req.StartTime), 0)
+	}
+	if startTime.After(endTime) {
+		return nil, fmt.Errorf("start time %v is after end time %v",
+			startTime, endTime)
+	}
+
+	rpcsLog.Debugf("[fwdinghistory] start_time=%v, end_time=%v, "+
+		"index_offset=%v, num_max_events=%v", startTime, endTime,
+		req.IndexOffset, req.NumMaxEvents)
+
+	timeSlice, err := r.server.chanDB.QueryForwardingLog(
+		channeldb.ForwardingEventQuery{
+			StartTime:    startTime,
+			EndTime:      endTime,
+			IndexOffset:  req.IndexOffset,
+			NumMaxEvents: req.NumMaxEvents,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query forwarding log: %v",
+			err)
+	}
+
+	// Now that we have the response from the forwarding log, we'll map
+	// the events into their RPC representation.
+	resp := &lnrpc.ForwardingHistoryResponse{
+		ForwardingEvents: make(
+			[]*lnrpc.ForwardingEvent, len(timeSlice.ForwardingEvents),
+		),
+		LastOffsetIndex: timeSlice.LastIndexOffset,
+	}
+	for i, event := range timeSlice.ForwardingEvents {
+		fee := event.AmtIn - event.AmtOut
+
+		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
+			Timestamp: uint64(event.Timestamp.Unix()),
+			ChanIdIn:  event.IncomingChanID.ToUint64(),
+			ChanIdOut: event.OutgoingChanID.ToUint64(),
+			AmtIn:     uint64(event.AmtIn.ToSatoshis()),
+			AmtOut:    uint64(event.AmtOut.ToSatoshis()),
+			Fee:       uint64(fee.ToSatoshis()),
+			FeeMsat:   uint64(fee),
+		}
+	}
+
+	return resp, nil
+}
+
+// ExportAllChannelBackups returns an encrypted static channel backup of all
+// currently open channels.
+func (r *rpcServer) ExportAllChannelBackups(ctx context.Context,
+	in *lnrpc.ChanBackupExportRequest) (*lnrpc.ChanBackupSnapshot, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "exportchanbackup",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	// First, we'll fetch a static backup for each of our open channels,
+	// using the channel database as the source.
+	chanBackups, err := chanbackup.FetchStaticChanBackups(r.server.chanDB)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch all static chan "+
+			"backups: %v", err)
+	}
+
+	// With the backups obtained, we'll pack them into a multi backup,
+	// encrypted using our backup key.
+	multi := chanbackup.Multi{
+		Version:       chanbackup.DefaultMultiVersion,
+		StaticBackups: chanBackups,
+	}
+	var b bytes.Buffer
+	if err := multi.PackToWriter(&b, r.server.backupKey); err != nil {
+		return nil, fmt.Errorf("unable to pack multi backup: %v", err)
+	}
+
+	chanPoints := make([]*lnrpc.ChannelPoint, 0, len(chanBackups))
+	for _, chanBackup := range chanBackups {
+		chanPoint := chanBackup.FundingOutpoint
+		chanPoints = append(chanPoints, &lnrpc.ChannelPoint{
+			FundingTxid: chanPoint.Hash[:],
+			OutputIndex: chanPoint.Index,
+		})
+	}
+
+	rpcsLog.Debugf("[exportchanbackup] exported backup of %v channels",
+		len(chanBackups))
+
+	return &lnrpc.ChanBackupSnapshot{
+		ChanPoints:      chanPoints,
+		MultiChanBackup: b.Bytes(),
+	}, nil
+}
+
+// VerifyChanBackup allows a caller to verify the integrity of a static
+// channel backup, ensuring that it can be decrypted and parsed by this node.
+func (r *rpcServer) VerifyChanBackup(ctx context.Context,
+	in *lnrpc.ChanBackupSnapshot) (*lnrpc.VerifyChanBackupResponse, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "verifychanbackup",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.MultiChanBackup) == 0 {
+		return nil, fmt.Errorf("multi chan backup must be set")
+	}
+
+	// If we're able to decrypt and parse the backup, then it's valid.
+	packedMulti := chanbackup.PackedMulti(in.MultiChanBackup)
+	if _, err := packedMulti.Unpack(r.server.backupKey); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.VerifyChanBackupResponse{}, nil
+}
+
+// RestoreChannelBackups accepts an encrypted static channel backup. For each
+// channel within the backup, we'll connect to the remote party and ask them
+// to force close the channel, then sweep our output from their commitment
+// transaction once it confirms.
+func (r *rpcServer) RestoreChannelBackups(ctx context.Context,
+	in *lnrpc.RestoreChanBackupRequest) (*lnrpc.RestoreBackupResponse, error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "restorechanbackup",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.MultiChanBackup) == 0 {
+		return nil, fmt.Errorf("multi chan backup must be set")
+	}
+
+	rpcsLog.Infof("[restorechanbackup] restoring channels from backup")
+
+	// The channel restorer will persist each channel, and watch for the
+	// remote party's commitment transaction, while the server itself will
+	// connect out to each of the remote parties.
+	packedMulti := chanbackup.PackedMulti(in.MultiChanBackup)
+	err := chanbackup.UnpackAndRecoverMulti(
+		packedMulti, r.server.backupKey, r.server.chanRestorer,
+		r.server,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore backup: %v", err)
+	}
+
+	return &lnrpc.RestoreBackupResponse{}, nil
+}
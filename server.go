@@ -13,14 +13,20 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chanbackup"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/lightningnetwork/lnd/watchtower"
 	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
@@ -50,13 +56,11 @@ type server struct {
 	started  int32 // atomic
 	shutdown int32 // atomic
 
-	// identityPriv is the private key used to authenticate any incoming
-	// connections.
-	identityPriv *btcec.PrivateKey
-
-	// nodeSigner is an implementation of the MessageSigner implementation
-	// that's backed by the identity private key of the running lnd node.
-	nodeSigner *nodeSigner
+	// identityKey performs all operations with the identity private key,
+	// which is used to authenticate any incoming connections, and to sign
+	// our announcements. The key is either held by the node itself, or by
+	// a remote signer.
+	identityKey nodeKeyRing
 
 	// lightningID is the sha256 of the public key corresponding to our
 	// long-term identity private key.
@@ -82,6 +86,10 @@ type server struct {
 
 	cc *chainControl
 
+	// torController is the controller used to create our onion service
+	// through the Tor server. It is nil if no onion service was requested.
+	torController *tor.Controller
+
 	fundingMgr *fundingManager
 
 	chanDB *channeldb.DB
@@ -96,6 +104,35 @@ type server struct {
 
 	utxoNursery *utxoNursery
 
+	sweeper *utxoSweeper
+
+	feeBumper *feeBumper
+
+	chainArb *contractcourt.ChainArbitrator
+
+	// towerServer is the watchtower server, which is only non-nil if the
+	// node is configured to act as a tower for others.
+	towerServer *watchtower.Server
+
+	// towerClient backs up the justice transaction for each revoked state
+	// to a watchtower. It's only non-nil if a tower has been configured.
+	towerClient   *watchtower.Client
+	justiceBackup htlcswitch.TowerClient
+
+	// backupKey is the key that the static channel backups are encrypted
+	// under. It's the identity private key, unless the node is watch-only,
+	// in which case it's a dedicated key handed out by the remote signer.
+	backupKey *btcec.PrivateKey
+
+	// chanSubSwapper atomically rewrites the static channel backup on disk
+	// each time the set of open channels changes.
+	chanSubSwapper *chanbackup.SubSwapper
+
+	// chanRestorer drives the recovery of channels restored from a static
+	// channel backup, requesting that the remote party force close each
+	// channel, then sweeping our output once they do.
+	chanRestorer *chanRestorer
+
 	sphinx *htlcswitch.OnionProcessor
 
 	connMgr *connmgr.ConnManager
@@ -115,36 +152,41 @@ type server struct {
 }
 
 // newServer creates a new instance of the server which is to listen using the
-// passed listener address.
+// passed listener address. Onion packets are processed by the passed onion
+// router, and the static channel backups are encrypted under the passed backup
+// key.
 func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
-	privKey *btcec.PrivateKey) (*server, error) {
+	identityKey nodeKeyRing, onionRouter htlcswitch.OnionRouter,
+	backupKey *btcec.PrivateKey) (*server, error) {
 
 	var err error
 
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, addr := range listenAddrs {
-		listeners[i], err = brontide.NewListener(privKey, addr)
+		listeners[i], err = brontide.NewListener(identityKey, addr)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	globalFeatures := lnwire.NewRawFeatureVector()
+	if cfg.AcceptKeySend {
+		globalFeatures.Set(lnwire.KeySendOptional)
+	}
 
-	serializedPubKey := privKey.PubKey().SerializeCompressed()
+	serializedPubKey := identityKey.PubKey().SerializeCompressed()
 	s := &server{
 		chanDB: chanDB,
 		cc:     cc,
 
 		invoices: newInvoiceRegistry(chanDB),
 
-		identityPriv: privKey,
-		nodeSigner:   newNodeSigner(privKey),
+		identityKey: identityKey,
+		backupKey:   backupKey,
 
 		// TODO(roasbeef): derive proper onion key based on rotation
 		// schedule
-		sphinx: htlcswitch.NewOnionProcessor(
-			sphinx.NewRouter(privKey, activeNetParams.Params)),
+		sphinx:      htlcswitch.NewOnionProcessor(onionRouter),
 		lightningID: sha256.Sum256(serializedPubKey),
 
 		persistentPeers:       make(map[string]struct{}),
@@ -173,7 +215,10 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	}
 
 	s.htlcSwitch = htlcswitch.New(htlcswitch.Config{
-		SelfKey: s.identityPriv.PubKey(),
+		SelfKey:               s.identityKey.PubKey(),
+		DB:                    chanDB,
+		FwdingLog:             chanDB,
+		ExtractErrorEncrypter: s.sphinx.ExtractErrorEncrypterFromKey,
 		LocalChannelClose: func(pubKey []byte,
 			request *htlcswitch.ChanClose) {
 
@@ -210,7 +255,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			addr = ip
 		}
 
-		lnAddr, err := net.ResolveTCPAddr("tcp", addr)
+		lnAddr, err := tor.ParseAddr(addr, cfg.net)
 		if err != nil {
 			return nil, err
 		}
@@ -218,6 +263,38 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		selfAddrs = append(selfAddrs, lnAddr)
 	}
 
+	// If we were requested to automatically create an onion service, then
+	// we'll do so now through the Tor server's control port, and advertise
+	// it along with any other external addresses.
+	if cfg.Tor.V2 || cfg.Tor.V3 {
+		s.torController = tor.NewController(cfg.Tor.Control)
+		if err := s.torController.Start(); err != nil {
+			return nil, err
+		}
+
+		onionType := tor.V2
+		if cfg.Tor.V3 {
+			onionType = tor.V3
+		}
+
+		onionAddr, err := s.torController.AddOnion(tor.AddOnionConfig{
+			Type:           onionType,
+			VirtualPort:    defaultPeerPort,
+			TargetPorts:    []int{cfg.PeerPort},
+			PrivateKeyPath: cfg.Tor.PrivateKeyPath,
+		})
+		if err != nil {
+			s.torController.Stop()
+			return nil, fmt.Errorf("unable to create onion "+
+				"service: %v", err)
+		}
+
+		srvrLog.Infof("Listening for inbound connections on onion "+
+			"service %v", onionAddr)
+
+		selfAddrs = append(selfAddrs, onionAddr)
+	}
+
 	chanGraph := chanDB.ChannelGraph()
 
 	defaultColor := color.RGBA{ // #3399FF
@@ -235,7 +312,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		HaveNodeAnnouncement: true,
 		LastUpdate:           time.Now(),
 		Addresses:            selfAddrs,
-		PubKey:               privKey.PubKey(),
+		PubKey:               identityKey.PubKey(),
 		Alias:                alias.String(),
 		Features:             s.globalFeatures,
 		Color:                defaultColor,
@@ -254,8 +331,8 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		Features:  selfNode.Features.RawFeatureVector,
 		RGBColor:  defaultColor,
 	}
-	selfNode.AuthSig, err = discovery.SignAnnouncement(s.nodeSigner,
-		s.identityPriv.PubKey(), nodeAnn,
+	selfNode.AuthSig, err = discovery.SignAnnouncement(s.identityKey,
+		s.identityKey.PubKey(), nodeAnn,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate signature for "+
@@ -273,7 +350,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		Graph:     chanGraph,
 		Chain:     cc.chainIO,
 		ChainView: cc.chainView,
-		SendToSwitch: func(firstHop *btcec.PublicKey,
+		SendToSwitch: func(firstHop *btcec.PublicKey, paymentID uint64,
 			htlcAdd *lnwire.UpdateAddHTLC,
 			circuit *sphinx.Circuit) ([32]byte, error) {
 
@@ -287,10 +364,30 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			var firstHopPub [33]byte
 			copy(firstHopPub[:], firstHop.SerializeCompressed())
 
-			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
+			return s.htlcSwitch.SendHTLC(
+				firstHopPub, paymentID, htlcAdd, errorDecryptor,
+			)
+		},
+		GetPaymentResult: func(paymentID uint64, paymentHash [32]byte,
+			circuit *sphinx.Circuit) ([32]byte, error) {
+
+			errorDecryptor := &htlcswitch.SphinxErrorDecrypter{
+				OnionErrorDecrypter: sphinx.NewOnionErrorDecrypter(circuit),
+			}
+
+			return s.htlcSwitch.GetPaymentResult(
+				paymentID, paymentHash, errorDecryptor,
+			)
 		},
+		Control:            routing.NewControlTower(chanDB),
 		ChannelPruneExpiry: time.Duration(time.Hour * 24 * 14),
 		GraphPruneInterval: time.Duration(time.Hour),
+		PathWeights: routing.WeightParams{
+			RiskFactorBillionths: cfg.Routing.RiskFactor,
+			AttemptCost: lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(cfg.Routing.AttemptCost),
+			),
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
@@ -307,9 +404,10 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		TrickleDelay:     time.Millisecond * time.Duration(cfg.TrickleDelay),
 		RetransmitDelay:  time.Minute * 30,
 		DB:               chanDB,
-		AnnSigner:        s.nodeSigner,
+		AnnSigner:        s.identityKey,
+		ChanSeries:       discovery.NewChanSeries(chanDB.ChannelGraph()),
 	},
-		s.identityPriv.PubKey(),
+		s.identityKey.PubKey(),
 	)
 	if err != nil {
 		return nil, err
@@ -321,18 +419,42 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		return nil, err
 	}
 
-	s.utxoNursery = newUtxoNursery(&NurseryConfig{
-		ChainIO:   cc.chainIO,
-		ConfDepth: 1,
-		DB:        chanDB,
-		Estimator: cc.feeEstimator,
+	s.sweeper = newUtxoSweeper(&SweeperConfig{
+		ChainIO:    cc.chainIO,
+		Estimator:  cc.feeEstimator,
+		MaxFeeRate: defaultMaxSweepFeeRate,
 		GenSweepScript: func() ([]byte, error) {
 			return newSweepPkScript(cc.wallet)
 		},
 		Notifier:           cc.chainNotifier,
 		PublishTransaction: cc.wallet.PublishTransaction,
 		Signer:             cc.wallet.Cfg.Signer,
+		Store:              newSweeperStore(chanDB),
+	})
+
+	s.feeBumper = newFeeBumper(&FeeBumperConfig{
+		Wallet:    cc.wallet,
+		Signer:    cc.wallet.Cfg.Signer,
+		Estimator: cc.feeEstimator,
+		ChainIO:   cc.chainIO,
+		Notifier:  cc.chainNotifier,
+		BumpSweep: s.sweeper.BumpFee,
+		FetchChannelCapacity: func(chanPoint wire.OutPoint) (
+			btcutil.Amount, error) {
+
+			return fetchChannelCapacity(chanDB, chanPoint)
+		},
+		Store: newFeeBumpStore(chanDB),
+	})
+
+	s.utxoNursery = newUtxoNursery(&NurseryConfig{
+		ChainIO:            cc.chainIO,
+		ConfDepth:          1,
+		DB:                 chanDB,
+		Notifier:           cc.chainNotifier,
+		PublishTransaction: cc.wallet.PublishTransaction,
 		Store:              utxnStore,
+		SweepInput:         s.sweeper.SweepInput,
 	})
 
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
@@ -343,6 +465,84 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		s.htlcSwitch.CloseLink(chanPoint, closureType, 0)
 	}
 
+	s.chainArb = contractcourt.NewChainArbitrator(contractcourt.ChainArbitratorConfig{
+		ChainHash:              *activeNetParams.GenesisHash,
+		IncomingBroadcastDelta: contractcourt.DefaultIncomingBroadcastDelta,
+		OutgoingBroadcastDelta: contractcourt.DefaultOutgoingBroadcastDelta,
+		ForceCloseChan:         s.forceCloseChan,
+		DeliverResolutionMsg:   s.htlcSwitch.ProcessContractResolution,
+		PreimageDB: &preimageBeacon{
+			invoices: s.invoices,
+			chanDB:   chanDB,
+		},
+		GenSweepScript: func() ([]byte, error) {
+			return newSweepPkScript(cc.wallet)
+		},
+		Signer:            cc.wallet.Cfg.Signer,
+		PublishTx:         cc.wallet.PublishTransaction,
+		Notifier:          cc.chainNotifier,
+		ChainIO:           cc.chainIO,
+		FeeEstimator:      cc.feeEstimator,
+		DB:                chanDB,
+		SweepCommitOutput: s.sweepCommitOutput,
+		ChannelsChanged: func() {
+			s.chanSubSwapper.ChannelsChanged()
+		},
+	})
+
+	if cfg.Watchtower.Active {
+		towerListenAddrs := cfg.Watchtower.Listeners
+		if len(towerListenAddrs) == 0 {
+			towerListenAddrs = []string{
+				net.JoinHostPort(
+					"", strconv.Itoa(defaultWatchtowerPort),
+				),
+			}
+		}
+
+		s.towerServer, err = watchtower.NewServer(&watchtower.ServerConfig{
+			NodeKeyECDH:       identityKey,
+			ListenAddrs:       towerListenAddrs,
+			DB:                watchtower.NewBoltTowerDB(chanDB),
+			MaxSessionUpdates: watchtower.DefaultMaxSessionUpdates,
+			Notifier:          cc.chainNotifier,
+			ChainIO:           cc.chainIO,
+			PublishTx:         cc.wallet.PublishTransaction,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.WtClient.Tower != "" {
+		towerAddr, err := parseTowerAddr(cfg.WtClient.Tower)
+		if err != nil {
+			return nil, err
+		}
+
+		s.towerClient = watchtower.NewClient(&watchtower.ClientConfig{
+			NodeKeyECDH: identityKey,
+			TowerAddr:   towerAddr,
+			Dial:        cfg.net.Dial,
+			DB:          watchtower.NewBoltClientDB(chanDB),
+		})
+
+		s.justiceBackup, err = newTowerClient(s.towerClient, cc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.chanSubSwapper = chanbackup.NewSubSwapper(
+		chanDB, backupKey, chanbackup.NewMultiFile(cfg.BackupFilePath),
+	)
+	s.chanRestorer = newChanRestorer(&chanRestorerConfig{
+		Store:      newChanRecoveryStore(chanDB),
+		Notifier:   cc.chainNotifier,
+		SendToPeer: s.SendToPeer,
+		SweepInput: s.sweeper.SweepInput,
+	})
+
 	s.breachArbiter = newBreachArbiter(&BreachConfig{
 		ChainIO:   s.cc.chainIO,
 		CloseLink: closeLink,
@@ -355,6 +555,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		PublishTransaction: cc.wallet.PublishTransaction,
 		Signer:             cc.wallet.Cfg.Signer,
 		Store:              newRetributionStore(chanDB),
+		ChannelsChanged:    s.chanSubSwapper.ChannelsChanged,
 	})
 
 	// Create the connection manager which will be responsible for
@@ -365,7 +566,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		OnAccept:       s.InboundPeerConnected,
 		RetryDuration:  time.Second * 5,
 		TargetOutbound: 100,
-		Dial:           noiseDial(s.identityPriv),
+		Dial:           noiseDial(s.identityKey, cfg.net),
 		OnConnection:   s.OutboundPeerConnected,
 	})
 	if err != nil {
@@ -400,15 +601,43 @@ func (s *server) Start() error {
 		return err
 	}
 
+	if err := s.invoices.Start(); err != nil {
+		return err
+	}
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+	if err := s.sweeper.Start(); err != nil {
+		return err
+	}
+	if err := s.feeBumper.Start(); err != nil {
+		return err
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
 	if err := s.breachArbiter.Start(); err != nil {
 		return err
 	}
+	if err := s.chainArb.Start(); err != nil {
+		return err
+	}
+	if s.towerServer != nil {
+		if err := s.towerServer.Start(); err != nil {
+			return err
+		}
+	}
+	if s.towerClient != nil {
+		if err := s.towerClient.Start(); err != nil {
+			return err
+		}
+	}
+	if err := s.chanSubSwapper.Start(); err != nil {
+		return err
+	}
+	if err := s.chanRestorer.Start(); err != nil {
+		return err
+	}
 	if err := s.authGossiper.Start(); err != nil {
 		return err
 	}
@@ -459,13 +688,28 @@ func (s *server) Stop() error {
 	s.cc.chainNotifier.Stop()
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
+	s.invoices.Stop()
 	s.utxoNursery.Stop()
 	s.breachArbiter.Stop()
+	s.chainArb.Stop()
+	if s.towerServer != nil {
+		s.towerServer.Stop()
+	}
+	if s.towerClient != nil {
+		s.towerClient.Stop()
+	}
+	s.chanSubSwapper.Stop()
+	s.chanRestorer.Stop()
+	s.feeBumper.Stop()
+	s.sweeper.Stop()
 	s.authGossiper.Stop()
 	s.cc.wallet.Shutdown()
 	s.cc.chainView.Stop()
 	s.connMgr.Stop()
 	s.cc.feeEstimator.Stop()
+	if s.torController != nil {
+		s.torController.Stop()
+	}
 
 	// Disconnect from each active peers to ensure that
 	// peerTerminationWatchers signal completion to each peer.
@@ -520,7 +764,7 @@ func initNetworkBootstrappers(s *server) ([]discovery.NetworkPeerBootstrapper, e
 				"seeds: %v", dnsSeeds)
 
 			dnsBootStrapper, err := discovery.NewDNSSeedBootstrapper(
-				dnsSeeds,
+				dnsSeeds, cfg.net,
 			)
 			if err != nil {
 				return nil, err
@@ -563,7 +807,9 @@ func (s *server) peerBootstrapper(numTargetPeers uint32,
 	// below to sample how many of these connections succeeded.
 	for _, addr := range bootStrapAddrs {
 		go func(a *lnwire.NetAddress) {
-			conn, err := brontide.Dial(s.identityPriv, a)
+			conn, err := brontide.Dial(
+				s.identityKey, a, cfg.net.Dial,
+			)
 			if err != nil {
 				srvrLog.Errorf("unable to connect to %v: %v",
 					a, err)
@@ -666,7 +912,9 @@ func (s *server) peerBootstrapper(numTargetPeers uint32,
 				go func(a *lnwire.NetAddress) {
 					// TODO(roasbeef): can do AS, subnet,
 					// country diversity, etc
-					conn, err := brontide.Dial(s.identityPriv, a)
+					conn, err := brontide.Dial(
+						s.identityKey, a, cfg.net.Dial,
+					)
 					if err != nil {
 						srvrLog.Errorf("unable to connect "+
 							"to %v: %v", a, err)
@@ -704,7 +952,7 @@ func (s *server) genNodeAnnouncement(
 
 	s.currentNodeAnn.Timestamp = newStamp
 	s.currentNodeAnn.Signature, err = discovery.SignAnnouncement(
-		s.nodeSigner, s.identityPriv.PubKey(), s.currentNodeAnn,
+		s.identityKey, s.identityKey.PubKey(), s.currentNodeAnn,
 	)
 
 	return *s.currentNodeAnn, err
@@ -712,7 +960,7 @@ func (s *server) genNodeAnnouncement(
 
 type nodeAddresses struct {
 	pubKey    *btcec.PublicKey
-	addresses []*net.TCPAddr
+	addresses []net.Addr
 }
 
 // establishPersistentConnections attempts to establish persistent connections
@@ -735,8 +983,9 @@ func (s *server) establishPersistentConnections() error {
 	}
 	for _, node := range linkNodes {
 		for _, address := range node.Addresses {
-			if address.Port == 0 {
-				address.Port = defaultPeerPort
+			tcpAddr, ok := address.(*net.TCPAddr)
+			if ok && tcpAddr.Port == 0 {
+				tcpAddr.Port = defaultPeerPort
 			}
 		}
 		pubStr := string(node.IdentityPub.SerializeCompressed())
@@ -769,14 +1018,17 @@ func (s *server) establishPersistentConnections() error {
 		// list of addresses we'll connect to. If there are duplicates
 		// that have different ports specified, the port from the
 		// channel graph should supersede the port from the link node.
-		var addrs []*net.TCPAddr
+		var addrs []net.Addr
 		linkNodeAddrs, ok := nodeAddrsMap[pubStr]
 		if ok {
 			for _, lnAddress := range linkNodeAddrs.addresses {
 				var addrMatched bool
+				lnTCPAddr, isTCP := lnAddress.(*net.TCPAddr)
 				for _, polAddress := range policy.Node.Addresses {
 					polTCPAddr, ok := polAddress.(*net.TCPAddr)
-					if ok && polTCPAddr.IP.Equal(lnAddress.IP) {
+					if isTCP && ok &&
+						polTCPAddr.IP.Equal(lnTCPAddr.IP) {
+
 						addrMatched = true
 						addrs = append(addrs, polTCPAddr)
 					}
@@ -787,9 +1039,16 @@ func (s *server) establishPersistentConnections() error {
 			}
 		} else {
 			for _, addr := range policy.Node.Addresses {
-				polTCPAddr, ok := addr.(*net.TCPAddr)
-				if ok {
-					addrs = append(addrs, polTCPAddr)
+				switch addr.(type) {
+				case *net.TCPAddr:
+					addrs = append(addrs, addr)
+
+				// Onion services can only be reached if our
+				// connections are routed through Tor.
+				case *tor.OnionAddr:
+					if cfg.Tor.Active {
+						addrs = append(addrs, addr)
+					}
 				}
 			}
 		}
@@ -1159,13 +1418,19 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 	brontideConn := conn.(*brontide.Conn)
 	peerAddr := &lnwire.NetAddress{
 		IdentityKey: brontideConn.RemotePub(),
-		Address:     conn.RemoteAddr().(*net.TCPAddr),
+		Address:     conn.RemoteAddr(),
 		ChainNet:    activeNetParams.Net,
 	}
 
 	// With the brontide connection established, we'll now craft the local
-	// feature vector to advertise to the remote node.
-	localFeatures := lnwire.NewRawFeatureVector()
+	// feature vector to advertise to the remote node. We always signal
+	// that we understand the data loss protection fields of the
+	// ChannelReestablish message, and that we're able to answer gossip
+	// queries.
+	localFeatures := lnwire.NewRawFeatureVector(
+		lnwire.DataLossProtectOptional,
+		lnwire.GossipQueriesOptional,
+	)
 
 	// We'll only request a full channel graph sync if we detect that that
 	// we aren't fully synced yet.
@@ -1237,7 +1502,7 @@ func (s *server) InboundPeerConnected(conn net.Conn) {
 
 	srvrLog.Infof("New inbound connection from %v", conn.RemoteAddr())
 
-	localPub := s.identityPriv.PubKey()
+	localPub := s.identityKey.PubKey()
 
 	// Check to see if we already have a connection with this peer. If so,
 	// we may need to drop our existing connection. This prevents us from
@@ -1298,7 +1563,7 @@ func (s *server) OutboundPeerConnected(connReq *connmgr.ConnReq, conn net.Conn)
 		return
 	}
 
-	localPub := s.identityPriv.PubKey()
+	localPub := s.identityKey.PubKey()
 	nodePub := conn.(*brontide.Conn).RemotePub()
 	pubStr := string(nodePub.SerializeCompressed())
 
@@ -1414,10 +1679,21 @@ func (s *server) addPeer(p *peer) {
 	s.wg.Add(1)
 	go s.peerTerminationWatcher(p)
 
-	// If the remote peer has the initial sync feature bit set, then we'll
-	// being the synchronization protocol to exchange authenticated channel
-	// graph edges/vertexes
-	if p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync) {
+	// If the remote peer understands gossip queries, then we'll hand it
+	// off to a gossipSyncer which will only exchange the channels each
+	// side is missing, and filter any live gossip by the peer's update
+	// horizon. Otherwise, if the remote peer has the initial sync feature
+	// bit set, then we'll being the legacy synchronization protocol to
+	// exchange authenticated channel graph edges/vertexes.
+	switch {
+	case p.remoteLocalFeatures.HasFeature(lnwire.GossipQueriesOptional):
+		err := s.authGossiper.InitSyncState(p.addr.IdentityKey, true)
+		if err != nil {
+			srvrLog.Errorf("unable to init gossip sync state for "+
+				"peer %v: %v", p, err)
+		}
+
+	case p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync):
 		go s.authGossiper.SynchronizeNode(p.addr.IdentityKey)
 	}
 
@@ -1456,6 +1732,9 @@ func (s *server) removePeer(p *peer) {
 	delete(s.peersByID, p.id)
 	delete(s.peersByPub, pubStr)
 
+	// The peer's gossipSyncer, if it has one, is no longer needed.
+	s.authGossiper.PruneSyncState(p.addr.IdentityKey)
+
 	if p.inbound {
 		delete(s.inboundPeers, pubStr)
 	} else {
@@ -1481,6 +1760,14 @@ type openChanReq struct {
 
 	private bool
 
+	// fundingInputs is the set of wallet outputs that should fund the
+	// channel. If empty, then the inputs are selected automatically.
+	fundingInputs []wire.OutPoint
+
+	// psbtFunding indicates that the funding transaction is assembled and
+	// signed by an external wallet through a PSBT.
+	psbtFunding bool
+
 	// TODO(roasbeef): add ability to specify channel constraints as well
 
 	updates chan *lnrpc.OpenStatusUpdate
@@ -1544,7 +1831,7 @@ func (s *server) ConnectToPeer(addr *lnwire.NetAddress, perm bool) error {
 	// connect to the target peer. If the we can't make the connection, or
 	// the crypto negotiation breaks down, then return an error to the
 	// caller.
-	conn, err := brontide.Dial(s.identityPriv, addr)
+	conn, err := brontide.Dial(s.identityKey, addr, cfg.net.Dial)
 	if err != nil {
 		return err
 	}
@@ -1598,8 +1885,9 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 // NOTE: This function is safe for concurrent access.
 func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
 	localAmt btcutil.Amount, pushAmt lnwire.MilliSatoshi,
-	fundingFeePerByte btcutil.Amount,
-	private bool) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	fundingFeePerByte btcutil.Amount, private bool,
+	fundingInputs []wire.OutPoint,
+	psbtFunding bool) (chan *lnrpc.OpenStatusUpdate, chan error) {
 
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
 	errChan := make(chan error, 1)
@@ -1660,6 +1948,8 @@ func (s *server) OpenChannel(peerID int32, nodeKey *btcec.PublicKey,
 		fundingFeePerWeight: fundingFeePerWeight,
 		pushAmt:             pushAmt,
 		private:             private,
+		fundingInputs:       fundingInputs,
+		psbtFunding:         psbtFunding,
 		updates:             updateChan,
 		err:                 errChan,
 	}
@@ -1685,3 +1975,180 @@ func (s *server) Peers() []*peer {
 
 	return peers
 }
+
+// fetchActiveChannel attempts to locate a channel identified by it's channel
+// point from the database's set of all currently opened channels.
+func (s *server) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
+	dbChannels, err := s.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	// With the channels fetched, attempt to locate the target channel
+	// according to its channel point.
+	var dbChan *channeldb.OpenChannel
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == chanPoint {
+			dbChan = dbChannel
+			break
+		}
+	}
+
+	// If the channel cannot be located, then we exit with an error to the
+	// caller.
+	if dbChan == nil {
+		return nil, fmt.Errorf("unable to find channel")
+	}
+
+	// Otherwise, we create a fully populated channel state machine which
+	// uses the db channel as backing storage.
+	return lnwallet.NewLightningChannel(s.cc.wallet.Cfg.Signer, nil,
+		s.cc.feeEstimator, dbChan)
+}
+
+// sweepCommitOutput hands our output within a commitment transaction broadcast
+// by the remote party off to the utxo sweeper, which sweeps it back into the
+// wallet. If we had no output above dust, then there's nothing to sweep.
+func (s *server) sweepCommitOutput(
+	closeInfo *lnwallet.UnilateralCloseSummary) error {
+
+	if closeInfo.SelfOutPoint == nil {
+		return nil
+	}
+
+	selfOutput := makeBreachedOutput(
+		closeInfo.SelfOutPoint, lnwallet.CommitmentNoDelay,
+		closeInfo.SelfOutputSignDesc,
+	)
+
+	srvrLog.Infof("Sweeping commitment output %v", selfOutput.OutPoint())
+
+	// The sweeper persists the output, so there's no need to wait for
+	// its sweep to confirm.
+	_, err := s.sweeper.SweepInput(&selfOutput, 0)
+	return err
+}
+
+// forceCloseChan executes a unilateral close of the target channel by
+// broadcasting the current commitment state directly on-chain. Once the
+// commitment transaction has been broadcast, a struct describing the final
+// state of the channel is sent to the utxoNursery in order to ultimately sweep
+// the immature outputs. This method is used by the chain arbitrator whenever
+// it decides that it needs to go on-chain.
+func (s *server) forceCloseChan(chanPoint wire.OutPoint) (*lnwallet.ForceCloseSummary, error) {
+	// As the first part of the force closure, we first fetch the channel
+	// from the database, then execute a direct force closure broadcasting
+	// our current commitment transaction.
+	channel, err := s.fetchActiveChannel(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+	defer channel.Stop()
+
+	// If we've lost state for this channel, then broadcasting our latest
+	// commitment would likely forfeit our funds. We'll bail out before
+	// tearing down any of the channel's indexes, as we still need to
+	// watch for the remote party's commitment in order to sweep our
+	// output.
+	dataLoss, err := channel.HasDataLoss()
+	if err != nil {
+		return nil, err
+	}
+	if dataLoss {
+		return nil, lnwallet.ErrForceCloseLocalDataLoss
+	}
+
+	// As we're force closing this channel, as a precaution, we'll ensure
+	// that the switch doesn't continue to see this channel as eligible for
+	// forwarding HTLC's. If the peer is online, then we'll also purge all
+	// of its indexes.
+	remotePub := &channel.StateSnapshot().RemoteIdentity
+	if peer, err := s.FindPeer(remotePub); err == nil {
+		// TODO(roasbeef): actually get the active channel
+		// instead too?
+		//  * so only need to grab from database
+		peer.WipeChannel(channel.ChannelPoint())
+	} else {
+		chanID := lnwire.NewChanIDFromOutPoint(channel.ChannelPoint())
+		s.htlcSwitch.RemoveLink(chanID)
+	}
+
+	// With the necessary indexes cleaned up, we'll now execute a unilateral
+	// close shutting down all further channel operation.
+	closeSummary, err := channel.ForceClose()
+	if err != nil {
+		return nil, err
+	}
+	channel.CancelObserver()
+
+	closeTx := closeSummary.CloseTx
+
+	// With the close transaction in hand, broadcast the transaction to the
+	// network, thereby entering the post channel resolution state.
+	srvrLog.Infof("Broadcasting force close transaction, ChannelPoint(%v): %v",
+		chanPoint, newLogClosure(func() string {
+			return spew.Sdump(closeTx)
+		}))
+	if err := s.cc.wallet.PublishTransaction(closeTx); err != nil {
+		return nil, err
+	}
+
+	// Until our commitment confirms, the remote party may still get their
+	// own commitment, or a revoked one, confirmed instead. We'll keep the
+	// breach arbiter watching the channel until then, so it's able to
+	// punish them for the latter.
+	_, bestHeight, err := s.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	closeTxid := closeTx.TxHash()
+	go waitForChanToClose(uint32(bestHeight), s.cc.chainNotifier, nil,
+		&chanPoint, &closeTxid, func() {
+			select {
+			case s.breachArbiter.settledContracts <- &chanPoint:
+			case <-s.quit:
+			}
+		})
+
+	// Now that the closing transaction has been broadcast successfully,
+	// we'll mark this channel as being in the pending closed state. The
+	// UTXO nursery will mark the channel as fully closed once all the
+	// outputs have been swept.
+	//
+	// TODO(roasbeef): don't set local balance if close summary detects
+	// dust output?
+	chanInfo := channel.StateSnapshot()
+	closeInfo := &channeldb.ChannelCloseSummary{
+		ChanPoint:   chanPoint,
+		ChainHash:   chanInfo.ChainHash,
+		ClosingTXID: closeTx.TxHash(),
+		RemotePub:   &chanInfo.RemoteIdentity,
+		Capacity:    chanInfo.Capacity,
+		CloseType:   channeldb.ForceClose,
+		IsPending:   true,
+	}
+
+	// If our commitment output isn't dust or we have active HTLC's on the
+	// commitment transaction, then we'll populate the balances on the
+	// close channel summary.
+	htlcs := closeSummary.HtlcResolutions
+	if closeSummary.SelfOutputSignDesc != nil ||
+		len(htlcs.OutgoingHTLCs) != 0 || len(htlcs.IncomingHTLCs) != 0 {
+
+		closeInfo.SettledBalance = chanInfo.LocalBalance.ToSatoshis()
+		closeInfo.TimeLockedBalance = chanInfo.LocalBalance.ToSatoshis()
+	}
+
+	if err := channel.DeleteState(closeInfo); err != nil {
+		return nil, err
+	}
+	s.chanSubSwapper.ChannelsChanged()
+
+	// Send the closed channel summary over to the utxoNursery in order to
+	// have its outputs swept back into the wallet once they're mature.
+	if err := s.utxoNursery.IncubateOutputs(closeSummary); err != nil {
+		return nil, err
+	}
+
+	return closeSummary, nil
+}
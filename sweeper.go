@@ -0,0 +1,1028 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/blockchain"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// defaultSweepConfTarget is the number of blocks within which the
+	// sweep of an input should confirm, if its producer doesn't ask for a
+	// particular confirmation target.
+	defaultSweepConfTarget = 6
+
+	// maxSweepPublishFailures is the number of consecutive times the
+	// publication of a sweep spending an input may fail, before the input
+	// is considered unspendable and dropped.
+	maxSweepPublishFailures = 10
+
+	// sweepFeeBumpDivisor determines the increase of the fee rate when a
+	// sweep is replaced, as a fraction of its previous fee rate.
+	sweepFeeBumpDivisor = 4
+
+	// defaultMaxSweepFeeRate is the maximum fee rate in satoshis per
+	// weight unit a sweep may pay, which amounts to 200 sat/byte.
+	defaultMaxSweepFeeRate btcutil.Amount = 50
+)
+
+var (
+	// ErrRemoteSpend is sent as the result of a sweep if the input was
+	// spent by a transaction that wasn't published by the sweeper.
+	ErrRemoteSpend = fmt.Errorf("input spent by a third party")
+
+	// ErrInputUnspendable is sent as the result of a sweep if the input was
+	// dropped by the sweeper, as it could not be spent.
+	ErrInputUnspendable = fmt.Errorf("input can't be swept")
+
+	// ErrSweeperShuttingDown is returned if an input is offered to the
+	// sweeper while it's shutting down.
+	ErrSweeperShuttingDown = fmt.Errorf("utxo sweeper shutting down")
+
+	// ErrUnknownSweepInput is returned when bumping the fee of an input
+	// that the sweeper isn't sweeping.
+	ErrUnknownSweepInput = fmt.Errorf("input isn't being swept")
+)
+
+// SweeperConfig abstracts the required subsystems used by the utxo sweeper. An
+// instance of SweeperConfig is passed to newUtxoSweeper during instantiation.
+type SweeperConfig struct {
+	// ChainIO is used to determine the current block height on start up.
+	ChainIO lnwallet.BlockChainIO
+
+	// Estimator is used to determine the fee rate of sweep transactions
+	// based on the confirmation target of their inputs.
+	Estimator lnwallet.FeeEstimator
+
+	// MaxFeeRate is the maximum fee rate in satoshis per weight unit that
+	// a sweep transaction may pay, no matter how often it was replaced.
+	MaxFeeRate btcutil.Amount
+
+	// GenSweepScript generates a P2WKH script belonging to the wallet where
+	// funds can be swept.
+	GenSweepScript func() ([]byte, error)
+
+	// Notifier is used to watch for new blocks, which trigger the sweeps,
+	// and for the spends of the inputs.
+	Notifier chainntnfs.ChainNotifier
+
+	// PublishTransaction facilitates the process of broadcasting a signed
+	// transaction to the appropriate network.
+	PublishTransaction func(*wire.MsgTx) error
+
+	// Signer is used to generate the witnesses of the swept inputs.
+	Signer lnwallet.Signer
+
+	// Store persists the inputs of the sweeper, and the sweep transactions
+	// it has published.
+	Store SweeperStore
+}
+
+// sweepResult is sent to the producer of an input once the input has been
+// spent, or the sweeper gave up on sweeping it.
+type sweepResult struct {
+	// Tx is the transaction that spent the input. It's nil if the input
+	// was dropped by the sweeper.
+	Tx *wire.MsgTx
+
+	// Err is nil if the input was spent by a sweep of the sweeper. It's
+	// ErrRemoteSpend if it was spent by another transaction, and
+	// ErrInputUnspendable if it was dropped.
+	Err error
+}
+
+// sweepInput is an input that's waiting to be swept, along with the state of
+// its sweep. The input itself is stored as a kidOutput, as that captures all
+// information required to spend both regular and CSV delayed outputs.
+type sweepInput struct {
+	kidOutput
+
+	// heightHint is the height from which the spend of the input is
+	// watched for.
+	heightHint uint32
+
+	// confTarget is the number of blocks within which the sweep of the
+	// input should confirm. If it doesn't, the sweep is replaced by one
+	// paying a higher fee rate.
+	confTarget uint32
+
+	// feeRate is the fee rate per weight unit of the last sweep published
+	// for the input, or zero if no sweep has been published.
+	feeRate btcutil.Amount
+
+	// minFeeRate is the fee rate per weight unit the sweep of the input
+	// must pay at least, as requested by the user when bumping its fee.
+	// It's zero if no such request was made.
+	minFeeRate btcutil.Amount
+
+	// publishAttempts is the total number of times a sweep spending the
+	// input was attempted to be published.
+	publishAttempts uint32
+
+	// publishFailures is the number of consecutive times the publication
+	// of a sweep spending the input failed.
+	publishFailures uint32
+
+	// lastBroadcastHeight is the height at which the last sweep spending
+	// the input was published.
+	lastBroadcastHeight uint32
+
+	// sweepTxid is the txid of the last sweep spending the input that was
+	// published since the sweeper started. It's nil if the input still
+	// needs to be swept.
+	sweepTxid *chainhash.Hash
+
+	// listeners receive the result of the sweep once the input is spent.
+	listeners []chan *sweepResult
+
+	spendNtfn   *chainntnfs.SpendEvent
+	spendCancel chan struct{}
+}
+
+// Encode writes the sweep input to the given io.Writer.
+func (s *sweepInput) Encode(w io.Writer) error {
+	var scratch [8]byte
+
+	for _, v := range []uint32{
+		s.heightHint, s.confTarget, s.publishAttempts,
+		s.publishFailures, s.lastBroadcastHeight,
+	} {
+		byteOrder.PutUint32(scratch[:4], v)
+		if _, err := w.Write(scratch[:4]); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range []btcutil.Amount{s.feeRate, s.minFeeRate} {
+		byteOrder.PutUint64(scratch[:], uint64(v))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+	}
+
+	return s.kidOutput.Encode(w)
+}
+
+// Decode reconstructs a sweep input using the provided io.Reader.
+func (s *sweepInput) Decode(r io.Reader) error {
+	var scratch [8]byte
+
+	for _, v := range []*uint32{
+		&s.heightHint, &s.confTarget, &s.publishAttempts,
+		&s.publishFailures, &s.lastBroadcastHeight,
+	} {
+		if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+			return err
+		}
+		*v = byteOrder.Uint32(scratch[:4])
+	}
+
+	for _, v := range []*btcutil.Amount{&s.feeRate, &s.minFeeRate} {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return err
+		}
+		*v = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	}
+
+	return s.kidOutput.Decode(r)
+}
+
+// pendingSweepReport describes an input the sweeper is waiting to sweep.
+type pendingSweepReport struct {
+	outPoint            wire.OutPoint
+	amount              btcutil.Amount
+	witnessType         lnwallet.WitnessType
+	confTarget          uint32
+	feeRate             btcutil.Amount
+	publishAttempts     uint32
+	lastBroadcastHeight uint32
+	sweepTxid           *chainhash.Hash
+}
+
+// sweepRequest is a request to sweep an input, sent to the collector.
+type sweepRequest struct {
+	input      SpendableOutput
+	confTarget uint32
+	result     chan *sweepResult
+	err        chan error
+}
+
+// bumpSweepRequest is a request to bump the fee of a pending sweep, sent to
+// the collector.
+type bumpSweepRequest struct {
+	outpoint   wire.OutPoint
+	confTarget uint32
+	feeRate    btcutil.Amount
+	resp       chan *pendingSweepReport
+	err        chan error
+}
+
+// utxoSweeper is the single subsystem responsible for sweeping the outputs
+// that the other subsystems, such as the utxo nursery and the breach arbiter,
+// recover from closed channels. The inputs offered to the sweeper are batched
+// across channels into a single transaction each block. If a sweep doesn't
+// confirm within the confirmation target of its inputs, it's replaced by a
+// sweep paying a higher fee rate.
+type utxoSweeper struct {
+	started uint32
+	stopped uint32
+
+	cfg *SweeperConfig
+
+	newInputs         chan *sweepRequest
+	spentInputs       chan *chainntnfs.SpendDetail
+	pendingSweepsReqs chan chan []*pendingSweepReport
+	bumpSweepReqs     chan *bumpSweepRequest
+
+	// The following fields are only accessed by the collector goroutine.
+
+	// pendingInputs is the set of inputs that are waiting to be swept.
+	pendingInputs map[wire.OutPoint]*sweepInput
+
+	// sweepScript is the script the sweeps pay to. It's reused until a
+	// sweep paying to it confirms, such that replacing a sweep doesn't
+	// generate a new address each time.
+	sweepScript []byte
+
+	currentHeight uint32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newUtxoSweeper creates a new instance of the utxoSweeper from the passed
+// config.
+func newUtxoSweeper(cfg *SweeperConfig) *utxoSweeper {
+	return &utxoSweeper{
+		cfg:               cfg,
+		newInputs:         make(chan *sweepRequest),
+		spentInputs:       make(chan *chainntnfs.SpendDetail),
+		pendingSweepsReqs: make(chan chan []*pendingSweepReport),
+		bumpSweepReqs:     make(chan *bumpSweepRequest),
+		pendingInputs:     make(map[wire.OutPoint]*sweepInput),
+		quit:              make(chan struct{}),
+	}
+}
+
+// Start reloads the inputs that were still to be swept before a restart, and
+// launches the goroutine that sweeps them.
+func (s *utxoSweeper) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	swprLog.Tracef("Starting UTXO sweeper")
+
+	// We register for block notifications before retrieving the current
+	// height, to ensure that no blocks are missed in between.
+	newBlockChan, err := s.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	_, bestHeight, err := s.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		newBlockChan.Cancel()
+		return err
+	}
+	s.currentHeight = uint32(bestHeight)
+
+	inputs, err := s.cfg.Store.FetchInputs()
+	if err != nil {
+		newBlockChan.Cancel()
+		return err
+	}
+
+	for _, input := range inputs {
+		if err := s.watchInput(input); err != nil {
+			newBlockChan.Cancel()
+			close(s.quit)
+			s.wg.Wait()
+			return err
+		}
+	}
+
+	if len(inputs) > 0 {
+		swprLog.Infof("Resuming sweep of %d inputs", len(inputs))
+	}
+
+	s.wg.Add(1)
+	go s.collector(newBlockChan)
+
+	return nil
+}
+
+// Stop gracefully shuts down the sweeper.
+func (s *utxoSweeper) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	swprLog.Infof("UTXO sweeper shutting down")
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// SweepInput hands the passed input to the sweeper, which sweeps it along
+// with other inputs in the next block, aiming for it to confirm within the
+// passed number of blocks. If zero, the default confirmation target is used.
+// The input is persisted, so it'll be swept even if the node restarts. The
+// returned channel receives the result of the sweep once the input is spent.
+// Offering an input that's already pending attaches another listener to it.
+func (s *utxoSweeper) SweepInput(input SpendableOutput,
+	confTarget uint32) (chan *sweepResult, error) {
+
+	if confTarget == 0 {
+		confTarget = defaultSweepConfTarget
+	}
+
+	req := &sweepRequest{
+		input:      input,
+		confTarget: confTarget,
+		result:     make(chan *sweepResult, 1),
+		err:        make(chan error, 1),
+	}
+
+	select {
+	case s.newInputs <- req:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	select {
+	case err := <-req.err:
+		if err != nil {
+			return nil, err
+		}
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	return req.result, nil
+}
+
+// PendingSweeps returns a report of all inputs the sweeper is waiting to
+// sweep.
+func (s *utxoSweeper) PendingSweeps() ([]*pendingSweepReport, error) {
+	respChan := make(chan []*pendingSweepReport, 1)
+
+	select {
+	case s.pendingSweepsReqs <- respChan:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	select {
+	case reports := <-respChan:
+		return reports, nil
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+}
+
+// BumpFee immediately replaces the sweep of the passed input by one aiming to
+// confirm within the passed number of blocks, or paying at least the passed
+// fee rate per weight unit. A zero value leaves the respective preference of
+// the input unchanged. The report of the input is returned once the
+// replacement has been published. ErrUnknownSweepInput is returned if the
+// input isn't being swept.
+func (s *utxoSweeper) BumpFee(outpoint wire.OutPoint, confTarget uint32,
+	feeRate btcutil.Amount) (*pendingSweepReport, error) {
+
+	req := &bumpSweepRequest{
+		outpoint:   outpoint,
+		confTarget: confTarget,
+		feeRate:    feeRate,
+		resp:       make(chan *pendingSweepReport, 1),
+		err:        make(chan error, 1),
+	}
+
+	select {
+	case s.bumpSweepReqs <- req:
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+
+	select {
+	case report := <-req.resp:
+		return report, nil
+	case err := <-req.err:
+		return nil, err
+	case <-s.quit:
+		return nil, ErrSweeperShuttingDown
+	}
+}
+
+// collector is the goroutine that manages the set of pending inputs. Each
+// time a new block arrives, it sweeps all inputs that haven't been swept yet,
+// or whose sweep failed to confirm in time.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *utxoSweeper) collector(newBlockChan *chainntnfs.BlockEpochEvent) {
+	defer s.wg.Done()
+	defer newBlockChan.Cancel()
+
+	for {
+		select {
+		case req := <-s.newInputs:
+			req.err <- s.addInput(req)
+
+		case spend := <-s.spentInputs:
+			s.handleSpend(spend)
+
+		case respChan := <-s.pendingSweepsReqs:
+			respChan <- s.pendingSweeps()
+
+		case req := <-s.bumpSweepReqs:
+			report, err := s.bumpFee(req)
+			if err != nil {
+				req.err <- err
+				continue
+			}
+
+			req.resp <- report
+
+		case epoch, ok := <-newBlockChan.Epochs:
+			if !ok {
+				return
+			}
+
+			s.currentHeight = uint32(epoch.Height)
+			s.sweepPendingInputs(false)
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// addInput adds the input of the passed request to the set of pending inputs,
+// persisting it, and watching for its spend.
+func (s *utxoSweeper) addInput(req *sweepRequest) error {
+	outpoint := *req.input.OutPoint()
+
+	// If the input is already pending, we'll only add the listener, and
+	// adopt the confirmation target if it's more urgent.
+	if input, ok := s.pendingInputs[outpoint]; ok {
+		input.listeners = append(input.listeners, req.result)
+		if req.confTarget >= input.confTarget {
+			return nil
+		}
+
+		input.confTarget = req.confTarget
+		return s.cfg.Store.AddInput(input)
+	}
+
+	if _, err := sweepWitnessSize(req.input.WitnessType()); err != nil {
+		return err
+	}
+
+	input := &sweepInput{
+		heightHint: s.currentHeight,
+		confTarget: req.confTarget,
+		listeners:  []chan *sweepResult{req.result},
+	}
+	switch in := req.input.(type) {
+	case CsvSpendableOutput:
+		input.kidOutput = makeKidOutput(
+			in.OutPoint(), in.OriginChanPoint(),
+			in.BlocksToMaturity(), in.WitnessType(), in.SignDesc(),
+		)
+		input.SetConfHeight(in.ConfHeight())
+
+	default:
+		input.kidOutput = makeKidOutput(
+			in.OutPoint(), &wire.OutPoint{}, 0, in.WitnessType(),
+			in.SignDesc(),
+		)
+	}
+
+	if err := s.cfg.Store.AddInput(input); err != nil {
+		return err
+	}
+
+	swprLog.Infof("Sweeping input %v (%v) with conf target %d", outpoint,
+		input.Amount(), input.confTarget)
+
+	return s.watchInput(input)
+}
+
+// watchInput adds the input to the set of pending inputs, and registers for
+// the notification of its spend.
+func (s *utxoSweeper) watchInput(input *sweepInput) error {
+	spendNtfn, err := s.cfg.Notifier.RegisterSpendNtfn(
+		input.OutPoint(), input.heightHint,
+	)
+	if err != nil {
+		return err
+	}
+
+	input.spendNtfn = spendNtfn
+	input.spendCancel = make(chan struct{})
+	s.pendingInputs[*input.OutPoint()] = input
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case spend, ok := <-spendNtfn.Spend:
+			if !ok {
+				return
+			}
+
+			select {
+			case s.spentInputs <- spend:
+			case <-s.quit:
+			}
+
+		case <-input.spendCancel:
+		case <-s.quit:
+		}
+	}()
+
+	return nil
+}
+
+// removeInput removes the input from the set of pending inputs, and sends the
+// result of its sweep to its listeners.
+func (s *utxoSweeper) removeInput(input *sweepInput, result *sweepResult) {
+	delete(s.pendingInputs, *input.OutPoint())
+	input.spendNtfn.Cancel()
+	close(input.spendCancel)
+
+	if err := s.cfg.Store.RemoveInput(input.OutPoint()); err != nil {
+		swprLog.Errorf("Unable to remove input %v: %v",
+			input.OutPoint(), err)
+	}
+
+	for _, listener := range input.listeners {
+		listener <- result
+	}
+}
+
+// handleSpend resolves all pending inputs spent by the transaction of the
+// passed spend.
+func (s *utxoSweeper) handleSpend(spend *chainntnfs.SpendDetail) {
+	isOurTx, err := s.cfg.Store.IsOurTx(*spend.SpenderTxHash)
+	if err != nil {
+		swprLog.Errorf("Unable to determine if tx %v is a sweep: %v",
+			spend.SpenderTxHash, err)
+		return
+	}
+
+	// Once a sweep paying to the current sweep script confirms, the next
+	// sweep will pay to a fresh one.
+	if isOurTx && len(spend.SpendingTx.TxOut) > 0 &&
+		bytes.Equal(spend.SpendingTx.TxOut[0].PkScript, s.sweepScript) {
+
+		s.sweepScript = nil
+	}
+
+	for _, txIn := range spend.SpendingTx.TxIn {
+		input, ok := s.pendingInputs[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+
+		result := &sweepResult{Tx: spend.SpendingTx}
+		if isOurTx {
+			swprLog.Infof("Input %v swept by tx %v",
+				txIn.PreviousOutPoint, spend.SpenderTxHash)
+		} else {
+			swprLog.Warnf("Input %v spent by third party tx %v",
+				txIn.PreviousOutPoint, spend.SpenderTxHash)
+			result.Err = ErrRemoteSpend
+		}
+
+		s.removeInput(input, result)
+	}
+}
+
+// pendingSweeps returns a report of all inputs that are waiting to be swept.
+func (s *utxoSweeper) pendingSweeps() []*pendingSweepReport {
+	reports := make([]*pendingSweepReport, 0, len(s.pendingInputs))
+	for _, input := range s.pendingInputs {
+		reports = append(reports, newPendingSweepReport(input))
+	}
+
+	return reports
+}
+
+// newPendingSweepReport creates a report of the current state of the sweep of
+// the passed input.
+func newPendingSweepReport(input *sweepInput) *pendingSweepReport {
+	return &pendingSweepReport{
+		outPoint:            *input.OutPoint(),
+		amount:              input.Amount(),
+		witnessType:         input.WitnessType(),
+		confTarget:          input.confTarget,
+		feeRate:             input.feeRate,
+		publishAttempts:     input.publishAttempts,
+		lastBroadcastHeight: input.lastBroadcastHeight,
+		sweepTxid:           input.sweepTxid,
+	}
+}
+
+// bumpFee updates the fee preferences of the input of the passed request, and
+// publishes a sweep satisfying them right away.
+func (s *utxoSweeper) bumpFee(req *bumpSweepRequest) (*pendingSweepReport,
+	error) {
+
+	input, ok := s.pendingInputs[req.outpoint]
+	if !ok {
+		return nil, ErrUnknownSweepInput
+	}
+
+	if req.confTarget != 0 {
+		input.confTarget = req.confTarget
+	}
+	if req.feeRate != 0 {
+		input.minFeeRate = req.feeRate
+	}
+	if err := s.cfg.Store.AddInput(input); err != nil {
+		return nil, err
+	}
+
+	swprLog.Infof("Bumping fee of input %v to conf target %d, min fee "+
+		"rate %v sat/kw", req.outpoint, input.confTarget,
+		int64(input.minFeeRate)*1000)
+
+	prevSweepTxid := input.sweepTxid
+	s.sweepPendingInputs(true)
+
+	// The input may have been dropped, or the replacement may not have
+	// been published, in which case the sweep of the input is unchanged.
+	input, ok = s.pendingInputs[req.outpoint]
+	if !ok {
+		return nil, ErrInputUnspendable
+	}
+	if input.sweepTxid == prevSweepTxid {
+		return nil, fmt.Errorf("unable to publish replacement sweep "+
+			"of input %v", req.outpoint)
+	}
+
+	return newPendingSweepReport(input), nil
+}
+
+// sweepFeeRate determines the fee rate of the next sweep. It's based on the
+// most urgent confirmation target of the pending inputs, but exceeds the fee
+// rate of any earlier sweep of the inputs, as the next sweep can only replace
+// those if it pays a higher fee rate. A fee rate explicitly requested for one
+// of the inputs takes precedence over the configured maximum.
+func (s *utxoSweeper) sweepFeeRate() (btcutil.Amount, error) {
+	var confTarget uint32
+	for _, input := range s.pendingInputs {
+		if confTarget == 0 || input.confTarget < confTarget {
+			confTarget = input.confTarget
+		}
+	}
+
+	feeRate, err := s.cfg.Estimator.EstimateFeePerWeight(confTarget)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, input := range s.pendingInputs {
+		if input.feeRate == 0 {
+			continue
+		}
+
+		bump := input.feeRate / sweepFeeBumpDivisor
+		if bump == 0 {
+			bump = 1
+		}
+		if input.feeRate+bump > feeRate {
+			feeRate = input.feeRate + bump
+		}
+	}
+
+	if s.cfg.MaxFeeRate != 0 && feeRate > s.cfg.MaxFeeRate {
+		feeRate = s.cfg.MaxFeeRate
+	}
+
+	for _, input := range s.pendingInputs {
+		if input.minFeeRate > feeRate {
+			feeRate = input.minFeeRate
+		}
+	}
+
+	return feeRate, nil
+}
+
+// sweepPendingInputs publishes a sweep of all pending inputs, if any of them
+// hasn't been swept yet, or if the sweep of any of them failed to confirm
+// within its confirmation target or pays less than requested. If force is
+// true, a sweep is published regardless. The new sweep spends every pending
+// input, so it replaces all earlier sweeps that are still unconfirmed. Inputs
+// whose last sweep failed to be published are swept on their own instead, so
+// a single input that can't be spent doesn't hold back the others.
+func (s *utxoSweeper) sweepPendingInputs(force bool) {
+	if len(s.pendingInputs) == 0 {
+		return
+	}
+
+	feeRate, err := s.sweepFeeRate()
+	if err != nil {
+		swprLog.Errorf("Unable to determine sweep fee rate: %v", err)
+		return
+	}
+
+	// A sweep published since we started can only be replaced by one
+	// paying a higher fee rate, which isn't possible once the maximum fee
+	// rate has been reached. We'll leave such sweeps untouched, which
+	// means that none of the inputs they spend can be swept again.
+	stuckSweeps := make(map[chainhash.Hash]struct{})
+	for _, input := range s.pendingInputs {
+		if input.sweepTxid != nil && feeRate <= input.feeRate {
+			stuckSweeps[*input.sweepTxid] = struct{}{}
+		}
+	}
+
+	var (
+		inputs          []SpendableOutput
+		failingInputs   []SpendableOutput
+		batchNeedsSweep = force
+	)
+	for _, input := range s.pendingInputs {
+		// Inputs that are worth less than the fees required to spend
+		// them are left for later, when fees may have dropped.
+		witnessSize, err := sweepWitnessSize(input.WitnessType())
+		if err != nil {
+			continue
+		}
+		inputWeight := lnwallet.InputSize*blockchain.WitnessScaleFactor +
+			witnessSize
+		if input.Amount() <= btcutil.Amount(inputWeight)*feeRate {
+			swprLog.Debugf("Skipping input %v, as its value %v "+
+				"doesn't cover its fee", input.OutPoint(),
+				input.Amount())
+			continue
+		}
+
+		if input.sweepTxid != nil {
+			if _, ok := stuckSweeps[*input.sweepTxid]; ok {
+				swprLog.Debugf("Sweep of input %v can't be "+
+					"replaced, as its fee rate reached "+
+					"the maximum", input.OutPoint())
+				continue
+			}
+		}
+
+		needsSweep := force || input.sweepTxid == nil ||
+			input.feeRate < input.minFeeRate ||
+			s.currentHeight >= input.lastBroadcastHeight+
+				input.confTarget
+
+		switch {
+		case input.publishFailures > 0 && needsSweep:
+			failingInputs = append(failingInputs, input)
+
+		case input.publishFailures == 0:
+			inputs = append(inputs, input)
+			batchNeedsSweep = batchNeedsSweep || needsSweep
+		}
+	}
+	if !batchNeedsSweep && len(failingInputs) == 0 {
+		return
+	}
+
+	if s.sweepScript == nil {
+		s.sweepScript, err = s.cfg.GenSweepScript()
+		if err != nil {
+			swprLog.Errorf("Unable to generate sweep script: %v",
+				err)
+			return
+		}
+	}
+
+	if batchNeedsSweep && len(inputs) > 0 {
+		s.sweepInputs(inputs, feeRate)
+	}
+	for _, input := range failingInputs {
+		s.sweepInputs([]SpendableOutput{input}, feeRate)
+	}
+}
+
+// sweepInputs publishes a sweep of the passed inputs at the passed fee rate.
+// If the sweep fails to be published, the inputs are split in half and each
+// half is swept separately, until the inputs causing the failure are isolated.
+// Only the inputs whose sweep fails on its own are charged with the failure,
+// and are dropped once they reach maxSweepPublishFailures.
+func (s *utxoSweeper) sweepInputs(inputs []SpendableOutput,
+	feeRate btcutil.Amount) {
+
+	sweepTx, err := createSweepTx(
+		inputs, s.sweepScript, feeRate, s.cfg.Signer,
+	)
+
+	// If the witness of one of the inputs couldn't be generated, then the
+	// input can't be spent. We'll drop it, and try again at the next
+	// block.
+	if witnessErr, ok := err.(*errSweepWitness); ok {
+		swprLog.Errorf("Dropping input %v: %v", witnessErr.outpoint,
+			witnessErr.err)
+
+		input := s.pendingInputs[witnessErr.outpoint]
+		s.removeInput(input, &sweepResult{Err: ErrInputUnspendable})
+		return
+	}
+	if err != nil {
+		swprLog.Errorf("Unable to create sweep tx: %v", err)
+		return
+	}
+
+	sweepTxid := sweepTx.TxHash()
+	swprLog.Infof("Sweeping %d inputs with tx %v at %v sat/kw: %v",
+		len(inputs), sweepTxid, int64(feeRate)*1000,
+		newLogClosure(func() string {
+			return spew.Sdump(sweepTx)
+		}),
+	)
+
+	// We record the tx before publishing it, so we'll recognize its spend
+	// of our inputs even if we restart right after it's published.
+	if err := s.cfg.Store.NotifyPublishTx(sweepTx); err != nil {
+		swprLog.Errorf("Unable to store sweep tx: %v", err)
+		return
+	}
+
+	publishErr := s.cfg.PublishTransaction(sweepTx)
+	if publishErr != nil {
+		swprLog.Errorf("Unable to publish sweep tx %v: %v", sweepTxid,
+			publishErr)
+
+		if len(inputs) > 1 {
+			s.sweepInputs(inputs[:len(inputs)/2], feeRate)
+			s.sweepInputs(inputs[len(inputs)/2:], feeRate)
+			return
+		}
+	}
+
+	for _, in := range inputs {
+		input := in.(*sweepInput)
+		input.publishAttempts++
+
+		if publishErr == nil {
+			input.feeRate = feeRate
+			input.publishFailures = 0
+			input.lastBroadcastHeight = s.currentHeight
+			input.sweepTxid = &sweepTxid
+		} else {
+			input.publishFailures++
+		}
+
+		// An input that keeps failing to be published is most likely
+		// spent already by a transaction that hasn't confirmed, or
+		// isn't spendable at all.
+		if input.publishFailures >= maxSweepPublishFailures {
+			swprLog.Errorf("Dropping input %v after %d failed "+
+				"sweeps", input.OutPoint(),
+				input.publishFailures)
+
+			s.removeInput(
+				input, &sweepResult{Err: ErrInputUnspendable},
+			)
+			continue
+		}
+
+		if err := s.cfg.Store.AddInput(input); err != nil {
+			swprLog.Errorf("Unable to update input %v: %v",
+				input.OutPoint(), err)
+		}
+	}
+}
+
+// sweepWitnessSize returns the estimated size of the witness that spends an
+// output of the passed witness type.
+func sweepWitnessSize(witnessType lnwallet.WitnessType) (int, error) {
+	switch witnessType {
+	case lnwallet.CommitmentTimeLock:
+		return lnwallet.ToLocalTimeoutWitnessSize, nil
+
+	case lnwallet.CommitmentNoDelay:
+		return lnwallet.P2WKHWitnessSize, nil
+
+	case lnwallet.CommitmentRevoke:
+		return lnwallet.ToLocalPenaltyWitnessSize, nil
+
+	case lnwallet.HtlcOfferedRevoke:
+		return lnwallet.OfferedHtlcPenaltyWitnessSize, nil
+
+	case lnwallet.HtlcAcceptedRevoke:
+		return lnwallet.AcceptedHtlcPenaltyWitnessSize, nil
+
+	case lnwallet.HtlcOfferedTimeout:
+		return lnwallet.OfferedHtlcTimeoutWitnessSize, nil
+
+	default:
+		return 0, fmt.Errorf("unable to sweep output of unknown "+
+			"witness type %v", witnessType)
+	}
+}
+
+// errSweepWitness is returned by createSweepTx if the witness of one of the
+// inputs couldn't be generated.
+type errSweepWitness struct {
+	outpoint wire.OutPoint
+	err      error
+}
+
+// Error returns a human readable description of the error.
+func (e *errSweepWitness) Error() string {
+	return fmt.Sprintf("unable to generate witness for input %v: %v",
+		e.outpoint, e.err)
+}
+
+// createSweepTx creates a fully signed transaction that sweeps the passed
+// inputs into a single output paying to the passed script, at the passed fee
+// rate per weight unit. The relative time lock of CSV delayed inputs is set
+// within the sequence number of their transaction input.
+func createSweepTx(inputs []SpendableOutput, pkScript []byte,
+	feePerWeight btcutil.Amount, signer lnwallet.Signer) (*wire.MsgTx,
+	error) {
+
+	// Our sweep transaction will pay to a single segwit p2wkh address,
+	// and we'll use the witness type of each input to estimate the size
+	// of its witness.
+	var (
+		weightEstimate lnwallet.TxWeightEstimator
+		totalAmt       btcutil.Amount
+	)
+	weightEstimate.AddP2WKHOutput()
+	for _, input := range inputs {
+		witnessSize, err := sweepWitnessSize(input.WitnessType())
+		if err != nil {
+			return nil, err
+		}
+		weightEstimate.AddWitnessInput(witnessSize)
+
+		totalAmt += input.Amount()
+	}
+
+	txFee := btcutil.Amount(weightEstimate.Weight()) * feePerWeight
+	sweepAmt := totalAmt - txFee
+	if sweepAmt <= 0 {
+		return nil, fmt.Errorf("value of inputs %v doesn't cover the "+
+			"fee %v", totalAmt, txFee)
+	}
+
+	// We use version 2 as it is required for CSV.
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxOut(&wire.TxOut{
+		PkScript: pkScript,
+		Value:    int64(sweepAmt),
+	})
+
+	for _, input := range inputs {
+		txIn := &wire.TxIn{
+			PreviousOutPoint: *input.OutPoint(),
+		}
+
+		// TODO(roasbeef): assumes pure block delays
+		if csvInput, ok := input.(CsvSpendableOutput); ok {
+			txIn.Sequence = csvInput.BlocksToMaturity()
+		}
+
+		sweepTx.AddTxIn(txIn)
+	}
+
+	// Before signing the transaction, check to ensure that it meets some
+	// basic validity requirements.
+	btx := btcutil.NewTx(sweepTx)
+	if err := blockchain.CheckTransactionSanity(btx); err != nil {
+		return nil, err
+	}
+
+	hashCache := txscript.NewTxSigHashes(sweepTx)
+
+	// With all the inputs in place, use each output's unique witness
+	// function to generate the final witness required for spending.
+	for i, input := range inputs {
+		witness, err := input.BuildWitness(signer, sweepTx, hashCache, i)
+		if err != nil {
+			return nil, &errSweepWitness{
+				outpoint: *input.OutPoint(),
+				err:      err,
+			}
+		}
+
+		sweepTx.TxIn[i].Witness = witness
+	}
+
+	return sweepTx, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// sweeperInputsBucket stores every input that has been handed to the
+	// sweeper, and hasn't been spent yet. Persisting the inputs ensures
+	// that they're swept even if the node restarts before their sweep
+	// confirms.
+	//
+	// maps: outpoint -> sweepInput
+	sweeperInputsBucket = []byte("sweeper-inputs")
+
+	// sweeperTxHashesBucket stores the txid of every sweep transaction the
+	// sweeper has published. This allows the sweeper to tell whether an
+	// input was spent by one of its own sweeps, or by a third party.
+	//
+	// maps: txid -> nil
+	sweeperTxHashesBucket = []byte("sweeper-tx-hashes")
+)
+
+// SweeperStore provides an interface for persisting the state of the utxo
+// sweeper, such that no inputs are lost if the node restarts.
+type SweeperStore interface {
+	// AddInput persists the passed input, overwriting any existing record
+	// of the same outpoint.
+	AddInput(input *sweepInput) error
+
+	// RemoveInput deletes the input with the passed outpoint, if any
+	// exists.
+	RemoveInput(outpoint *wire.OutPoint) error
+
+	// FetchInputs returns all inputs that are still to be swept.
+	FetchInputs() ([]*sweepInput, error)
+
+	// NotifyPublishTx records that the passed sweep transaction has been
+	// published.
+	NotifyPublishTx(tx *wire.MsgTx) error
+
+	// IsOurTx returns true if the transaction with the passed txid is a
+	// sweep transaction published by the sweeper.
+	IsOurTx(txid chainhash.Hash) (bool, error)
+}
+
+// sweeperStore is a concrete instantiation of a SweeperStore that is backed by
+// a channeldb.DB instance.
+type sweeperStore struct {
+	db *channeldb.DB
+}
+
+// A compile time check to ensure sweeperStore meets the SweeperStore
+// interface.
+var _ SweeperStore = (*sweeperStore)(nil)
+
+// newSweeperStore creates a new instance of a sweeperStore.
+func newSweeperStore(db *channeldb.DB) *sweeperStore {
+	return &sweeperStore{
+		db: db,
+	}
+}
+
+// AddInput persists the passed input, overwriting any existing record of the
+// same outpoint.
+//
+// NOTE: Part of the SweeperStore interface.
+func (s *sweeperStore) AddInput(input *sweepInput) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, input.OutPoint()); err != nil {
+		return err
+	}
+
+	var v bytes.Buffer
+	if err := input.Encode(&v); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		inputs, err := tx.CreateBucketIfNotExists(sweeperInputsBucket)
+		if err != nil {
+			return err
+		}
+
+		return inputs.Put(k.Bytes(), v.Bytes())
+	})
+}
+
+// RemoveInput deletes the input with the passed outpoint, if any exists.
+//
+// NOTE: Part of the SweeperStore interface.
+func (s *sweeperStore) RemoveInput(outpoint *wire.OutPoint) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, outpoint); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		inputs := tx.Bucket(sweeperInputsBucket)
+		if inputs == nil {
+			return nil
+		}
+
+		return inputs.Delete(k.Bytes())
+	})
+}
+
+// FetchInputs returns all inputs that are still to be swept.
+//
+// NOTE: Part of the SweeperStore interface.
+func (s *sweeperStore) FetchInputs() ([]*sweepInput, error) {
+	var inputs []*sweepInput
+	err := s.db.View(func(tx *bolt.Tx) error {
+		inputsBucket := tx.Bucket(sweeperInputsBucket)
+		if inputsBucket == nil {
+			return nil
+		}
+
+		return inputsBucket.ForEach(func(_, v []byte) error {
+			input := &sweepInput{}
+			if err := input.Decode(bytes.NewReader(v)); err != nil {
+				return err
+			}
+
+			inputs = append(inputs, input)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inputs, nil
+}
+
+// NotifyPublishTx records that the passed sweep transaction has been
+// published.
+//
+// NOTE: Part of the SweeperStore interface.
+func (s *sweeperStore) NotifyPublishTx(sweepTx *wire.MsgTx) error {
+	txid := sweepTx.TxHash()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		txHashes, err := tx.CreateBucketIfNotExists(
+			sweeperTxHashesBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		return txHashes.Put(txid[:], []byte{})
+	})
+}
+
+// IsOurTx returns true if the transaction with the passed txid is a sweep
+// transaction published by the sweeper.
+//
+// NOTE: Part of the SweeperStore interface.
+func (s *sweeperStore) IsOurTx(txid chainhash.Hash) (bool, error) {
+	var ours bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		txHashes := tx.Bucket(sweeperTxHashesBucket)
+		if txHashes == nil {
+			return nil
+		}
+
+		ours = txHashes.Get(txid[:]) != nil
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ours, nil
+}
@@ -0,0 +1,183 @@
+// +build !rpctest
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// makeSweepInputs creates a sweep input for each of the kid output test
+// vectors, populated with distinct sweep state.
+func makeSweepInputs() []*sweepInput {
+	inputs := make([]*sweepInput, 0, len(kidOutputs))
+	for i, kid := range kidOutputs {
+		inputs = append(inputs, &sweepInput{
+			kidOutput:           kid,
+			heightHint:          uint32(100 + i),
+			confTarget:          uint32(6 + i),
+			feeRate:             25,
+			minFeeRate:          btcutil.Amount(i * 10),
+			publishAttempts:     uint32(i),
+			publishFailures:     uint32(i % 2),
+			lastBroadcastHeight: uint32(200 + i),
+		})
+	}
+
+	return inputs
+}
+
+// TestSweepInputSerialization asserts that the persisted state of a sweep
+// input survives a serialization round trip.
+func TestSweepInputSerialization(t *testing.T) {
+	for i, input := range makeSweepInputs() {
+		var b bytes.Buffer
+		if err := input.Encode(&b); err != nil {
+			t.Fatalf("Encode #%d: unable to serialize "+
+				"sweep input: %v", i, err)
+		}
+
+		var deserializedInput sweepInput
+		if err := deserializedInput.Decode(&b); err != nil {
+			t.Fatalf("Decode #%d: unable to deserialize "+
+				"sweep input: %v", i, err)
+		}
+
+		if !reflect.DeepEqual(*input, deserializedInput) {
+			t.Fatalf("DeepEqual #%d: unexpected sweepInput, "+
+				"want %+v, got %+v",
+				i, input, deserializedInput)
+		}
+	}
+}
+
+// TestSweeperStoreInputs asserts that inputs added to the sweeper store are
+// returned until they're removed, and that re-adding an input overwrites its
+// previous state.
+func TestSweeperStoreInputs(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+	defer cleanUp()
+
+	store := newSweeperStore(cdb)
+
+	// A fresh store shouldn't hold any inputs.
+	assertNumSweepInputs(t, store, 0)
+
+	inputs := makeSweepInputs()
+	for _, input := range inputs {
+		if err := store.AddInput(input); err != nil {
+			t.Fatalf("unable to add input: %v", err)
+		}
+	}
+	assertNumSweepInputs(t, store, len(inputs))
+
+	// Updating the state of an input shouldn't result in a duplicate
+	// record.
+	inputs[0].publishAttempts++
+	if err := store.AddInput(inputs[0]); err != nil {
+		t.Fatalf("unable to update input: %v", err)
+	}
+	stored := assertNumSweepInputs(t, store, len(inputs))
+
+	var found bool
+	for _, input := range stored {
+		if *input.OutPoint() != *inputs[0].OutPoint() {
+			continue
+		}
+
+		found = true
+		if input.publishAttempts != inputs[0].publishAttempts {
+			t.Fatalf("expected %v publish attempts, got %v",
+				inputs[0].publishAttempts,
+				input.publishAttempts)
+		}
+	}
+	if !found {
+		t.Fatalf("updated input not found")
+	}
+
+	// Finally, removing all inputs should leave the store empty again.
+	// Removing an unknown input should be a noop.
+	for _, input := range inputs {
+		if err := store.RemoveInput(input.OutPoint()); err != nil {
+			t.Fatalf("unable to remove input: %v", err)
+		}
+	}
+	if err := store.RemoveInput(inputs[0].OutPoint()); err != nil {
+		t.Fatalf("unable to remove unknown input: %v", err)
+	}
+	assertNumSweepInputs(t, store, 0)
+}
+
+// TestSweeperStoreTxHashes asserts that the sweeper store only recognizes the
+// transactions published through it.
+func TestSweeperStoreTxHashes(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+	defer cleanUp()
+
+	store := newSweeperStore(cdb)
+
+	sweepTx := wire.NewMsgTx(2)
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *kidOutputs[0].OutPoint(),
+	})
+	otherTx := wire.NewMsgTx(2)
+	otherTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *kidOutputs[1].OutPoint(),
+	})
+
+	ours, err := store.IsOurTx(sweepTx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to query tx: %v", err)
+	}
+	if ours {
+		t.Fatalf("tx recognized before being published")
+	}
+
+	if err := store.NotifyPublishTx(sweepTx); err != nil {
+		t.Fatalf("unable to notify publish: %v", err)
+	}
+
+	ours, err = store.IsOurTx(sweepTx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to query tx: %v", err)
+	}
+	if !ours {
+		t.Fatalf("published tx not recognized")
+	}
+
+	ours, err = store.IsOurTx(otherTx.TxHash())
+	if err != nil {
+		t.Fatalf("unable to query tx: %v", err)
+	}
+	if ours {
+		t.Fatalf("unknown tx recognized as ours")
+	}
+}
+
+// assertNumSweepInputs checks that the sweeper store holds the expected number
+// of inputs, and returns them.
+func assertNumSweepInputs(t *testing.T, store SweeperStore,
+	expected int) []*sweepInput {
+
+	inputs, err := store.FetchInputs()
+	if err != nil {
+		t.Fatalf("unable to fetch inputs: %v", err)
+	}
+
+	if len(inputs) != expected {
+		t.Fatalf("expected %d inputs, found %d", expected, len(inputs))
+	}
+
+	return inputs
+}
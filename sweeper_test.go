@@ -0,0 +1,626 @@
+// +build !rpctest
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// sweeperTestFeeRate is the fee rate per weight unit returned by the fee
+// estimator of the sweeper under test.
+const sweeperTestFeeRate = 10
+
+// sweeperTestPrivKey is the key the inputs of the sweeper under test are
+// locked to.
+var sweeperTestPrivKey, sweeperTestPubKey = btcec.PrivKeyFromBytes(
+	btcec.S256(), bytes.Repeat([]byte{0x01}, 32),
+)
+
+// sweeperTestNotifier is a chain notifier that lets the test trigger new
+// blocks, and the spends of the inputs the sweeper is watching.
+type sweeperTestNotifier struct {
+	epochChan chan *chainntnfs.BlockEpoch
+
+	mu         sync.Mutex
+	spendChans map[wire.OutPoint]chan *chainntnfs.SpendDetail
+}
+
+func (m *sweeperTestNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
+	numConfs, heightHint uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed: make(chan *chainntnfs.TxConfirmation),
+	}, nil
+}
+
+func (m *sweeperTestNotifier) RegisterBlockEpochNtfn() (
+	*chainntnfs.BlockEpochEvent, error) {
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: m.epochChan,
+		Cancel: func() {},
+	}, nil
+}
+
+func (m *sweeperTestNotifier) Start() error {
+	return nil
+}
+
+func (m *sweeperTestNotifier) Stop() error {
+	return nil
+}
+
+func (m *sweeperTestNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
+	heightHint uint32) (*chainntnfs.SpendEvent, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spendChan := make(chan *chainntnfs.SpendDetail, 1)
+	m.spendChans[*outpoint] = spendChan
+
+	return &chainntnfs.SpendEvent{
+		Spend:  spendChan,
+		Cancel: func() {},
+	}, nil
+}
+
+// sweeperTestSigner is a signer producing dummy signatures, which suffice as
+// the sweeps are never validated.
+type sweeperTestSigner struct{}
+
+func (s *sweeperTestSigner) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	return bytes.Repeat([]byte{0x01}, 71), nil
+}
+
+func (s *sweeperTestSigner) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	return &lnwallet.InputScript{}, nil
+}
+
+// sweeperTestContext bundles a sweeper with the mocked subsystems it's backed
+// by.
+type sweeperTestContext struct {
+	t *testing.T
+
+	sweeper   *utxoSweeper
+	store     SweeperStore
+	notifier  *sweeperTestNotifier
+	published chan *wire.MsgTx
+
+	mu            sync.Mutex
+	failingInputs map[wire.OutPoint]struct{}
+
+	cleanUp func()
+}
+
+// newSweeperTestContext creates and starts a sweeper backed by a fresh store,
+// whose maximum fee rate is set to the passed value.
+func newSweeperTestContext(t *testing.T,
+	maxFeeRate btcutil.Amount) *sweeperTestContext {
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+
+	ctx := &sweeperTestContext{
+		t:     t,
+		store: newSweeperStore(cdb),
+		notifier: &sweeperTestNotifier{
+			epochChan: make(chan *chainntnfs.BlockEpoch),
+			spendChans: make(
+				map[wire.OutPoint]chan *chainntnfs.SpendDetail,
+			),
+		},
+		published:     make(chan *wire.MsgTx, 100),
+		failingInputs: make(map[wire.OutPoint]struct{}),
+	}
+	ctx.startSweeper(maxFeeRate)
+
+	ctx.cleanUp = func() {
+		ctx.sweeper.Stop()
+		cleanUp()
+	}
+
+	return ctx
+}
+
+// startSweeper creates and starts a new sweeper on top of the store of the
+// test context.
+func (ctx *sweeperTestContext) startSweeper(maxFeeRate btcutil.Amount) {
+	ctx.sweeper = newUtxoSweeper(&SweeperConfig{
+		ChainIO: &mockChainIO{},
+		Estimator: lnwallet.StaticFeeEstimator{
+			FeeRate: sweeperTestFeeRate * 4,
+		},
+		MaxFeeRate: maxFeeRate,
+		GenSweepScript: func() ([]byte, error) {
+			script := append([]byte{0x00, 0x14}, make([]byte, 20)...)
+			return script, nil
+		},
+		Notifier:           ctx.notifier,
+		PublishTransaction: ctx.publishTransaction,
+		Signer:             &sweeperTestSigner{},
+		Store:              ctx.store,
+	})
+	if err := ctx.sweeper.Start(); err != nil {
+		ctx.t.Fatalf("unable to start sweeper: %v", err)
+	}
+}
+
+// publishTransaction publishes the passed sweep, unless it spends one of the
+// inputs that are set to fail.
+func (ctx *sweeperTestContext) publishTransaction(tx *wire.MsgTx) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	for _, txIn := range tx.TxIn {
+		_, ok := ctx.failingInputs[txIn.PreviousOutPoint]
+		if ok {
+			return fmt.Errorf("input %v already spent",
+				txIn.PreviousOutPoint)
+		}
+	}
+
+	ctx.published <- tx
+	return nil
+}
+
+// failInput makes the publication of any sweep spending the passed input
+// fail.
+func (ctx *sweeperTestContext) failInput(outpoint wire.OutPoint) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	ctx.failingInputs[outpoint] = struct{}{}
+}
+
+// notifyEpoch notifies the sweeper of a new block at the passed height, and
+// waits until the sweeper has processed it.
+func (ctx *sweeperTestContext) notifyEpoch(height int32) {
+	select {
+	case ctx.notifier.epochChan <- &chainntnfs.BlockEpoch{Height: height}:
+	case <-time.After(5 * time.Second):
+		ctx.t.Fatalf("block epoch not consumed")
+	}
+
+	// As the collector handles one request at a time, the block has been
+	// processed once the pending sweeps are reported.
+	ctx.pendingSweeps()
+}
+
+// notifySpend notifies the sweeper of the spends of all inputs spent by the
+// passed transaction.
+func (ctx *sweeperTestContext) notifySpend(tx *wire.MsgTx) {
+	ctx.notifier.mu.Lock()
+	defer ctx.notifier.mu.Unlock()
+
+	txid := tx.TxHash()
+	for i, txIn := range tx.TxIn {
+		spendChan, ok := ctx.notifier.spendChans[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+
+		outpoint := txIn.PreviousOutPoint
+		spendChan <- &chainntnfs.SpendDetail{
+			SpentOutPoint:     &outpoint,
+			SpenderTxHash:     &txid,
+			SpendingTx:        tx,
+			SpenderInputIndex: uint32(i),
+		}
+	}
+}
+
+// pendingSweeps returns the reports of the inputs the sweeper is sweeping,
+// indexed by their outpoint.
+func (ctx *sweeperTestContext) pendingSweeps() map[wire.OutPoint]*pendingSweepReport {
+	reports, err := ctx.sweeper.PendingSweeps()
+	if err != nil {
+		ctx.t.Fatalf("unable to fetch pending sweeps: %v", err)
+	}
+
+	pending := make(map[wire.OutPoint]*pendingSweepReport)
+	for _, report := range reports {
+		pending[report.outPoint] = report
+	}
+
+	return pending
+}
+
+// sweepInput offers the passed input to the sweeper.
+func (ctx *sweeperTestContext) sweepInput(input *kidOutput,
+	confTarget uint32) chan *sweepResult {
+
+	resultChan, err := ctx.sweeper.SweepInput(input, confTarget)
+	if err != nil {
+		ctx.t.Fatalf("unable to sweep input: %v", err)
+	}
+
+	return resultChan
+}
+
+// receiveTx returns the next sweep that has been published, failing the test
+// if there is none.
+func (ctx *sweeperTestContext) receiveTx() *wire.MsgTx {
+	select {
+	case tx := <-ctx.published:
+		return tx
+	default:
+		ctx.t.Fatalf("no sweep published")
+		return nil
+	}
+}
+
+// assertNoTx asserts that no sweep has been published.
+func (ctx *sweeperTestContext) assertNoTx() {
+	select {
+	case tx := <-ctx.published:
+		ctx.t.Fatalf("unexpected sweep %v published", tx.TxHash())
+	default:
+	}
+}
+
+// makeSweeperTestInput creates a CSV delayed input worth the passed amount,
+// with an outpoint that's unique to the passed index.
+func makeSweeperTestInput(index int, amt btcutil.Amount) *kidOutput {
+	kid := makeKidOutput(
+		&wire.OutPoint{Hash: chainhash.Hash{byte(index + 1)}},
+		&wire.OutPoint{}, 144, lnwallet.CommitmentTimeLock,
+		&lnwallet.SignDescriptor{
+			PubKey:        sweeperTestPubKey,
+			WitnessScript: []byte{txscript.OP_TRUE},
+			Output:        &wire.TxOut{Value: int64(amt)},
+			HashType:      txscript.SigHashAll,
+		},
+	)
+
+	return &kid
+}
+
+// assertSweepResult asserts that the passed result channel receives the
+// result of the sweep of an input, and that it carries the expected error.
+func assertSweepResult(t *testing.T, resultChan chan *sweepResult,
+	expectedErr error) *sweepResult {
+
+	select {
+	case result := <-resultChan:
+		if result.Err != expectedErr {
+			t.Fatalf("expected sweep result error %v, got %v",
+				expectedErr, result.Err)
+		}
+		return result
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no sweep result received")
+		return nil
+	}
+}
+
+// assertSpends asserts that the passed transaction spends exactly the passed
+// inputs.
+func assertSpends(t *testing.T, tx *wire.MsgTx, inputs ...*kidOutput) {
+	if len(tx.TxIn) != len(inputs) {
+		t.Fatalf("expected tx to spend %d inputs, it spends %d",
+			len(inputs), len(tx.TxIn))
+	}
+
+	spent := make(map[wire.OutPoint]struct{})
+	for _, txIn := range tx.TxIn {
+		spent[txIn.PreviousOutPoint] = struct{}{}
+	}
+	for _, input := range inputs {
+		if _, ok := spent[*input.OutPoint()]; !ok {
+			t.Fatalf("tx doesn't spend input %v", input.OutPoint())
+		}
+	}
+}
+
+// TestSweeperCollector asserts that an input offered twice is swept once, with
+// the more urgent confirmation target, that all its listeners are notified of
+// the result, and that pending inputs are resumed after a restart.
+func TestSweeperCollector(t *testing.T) {
+	ctx := newSweeperTestContext(t, 0)
+	defer ctx.cleanUp()
+
+	input := makeSweeperTestInput(0, 1e6)
+	resultChan1 := ctx.sweepInput(input, 6)
+	resultChan2 := ctx.sweepInput(input, 3)
+
+	pending := ctx.pendingSweeps()
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending sweep, got %d", len(pending))
+	}
+	if pending[*input.OutPoint()].confTarget != 3 {
+		t.Fatalf("expected conf target 3, got %d",
+			pending[*input.OutPoint()].confTarget)
+	}
+
+	// The second input is only offered to the sweeper before it's
+	// restarted. It should be picked up again once restarted, and swept
+	// along with the first input at the next block.
+	input2 := makeSweeperTestInput(1, 1e6)
+	ctx.sweepInput(input2, 6)
+
+	ctx.sweeper.Stop()
+	ctx.startSweeper(0)
+
+	if len(ctx.pendingSweeps()) != 2 {
+		t.Fatalf("expected 2 pending sweeps after restart")
+	}
+
+	// The listeners attached before the restart are gone, so we'll offer
+	// the first input once more.
+	resultChan3 := ctx.sweepInput(input, 0)
+
+	ctx.notifyEpoch(fundingBroadcastHeight + 1)
+	sweepTx := ctx.receiveTx()
+	assertSpends(t, sweepTx, input, input2)
+
+	ctx.notifySpend(sweepTx)
+	result := assertSweepResult(t, resultChan3, nil)
+	if result.Tx.TxHash() != sweepTx.TxHash() {
+		t.Fatalf("result carries wrong sweep tx")
+	}
+
+	select {
+	case <-resultChan1:
+		t.Fatalf("listener from before the restart notified")
+	case <-resultChan2:
+		t.Fatalf("listener from before the restart notified")
+	default:
+	}
+
+	if len(ctx.pendingSweeps()) != 0 {
+		t.Fatalf("expected no pending sweeps")
+	}
+}
+
+// TestSweeperBatching asserts that all pending inputs are swept by a single
+// transaction, except for those worth less than the fee required to spend
+// them.
+func TestSweeperBatching(t *testing.T) {
+	ctx := newSweeperTestContext(t, 0)
+	defer ctx.cleanUp()
+
+	inputs := []*kidOutput{
+		makeSweeperTestInput(0, 1e6),
+		makeSweeperTestInput(1, 2e6),
+		makeSweeperTestInput(2, 3e6),
+	}
+	var resultChans []chan *sweepResult
+	for _, input := range inputs {
+		resultChans = append(resultChans, ctx.sweepInput(input, 6))
+	}
+
+	dust := makeSweeperTestInput(3, 1000)
+	dustResultChan := ctx.sweepInput(dust, 6)
+
+	// No sweep should be published before the next block.
+	ctx.assertNoTx()
+
+	ctx.notifyEpoch(fundingBroadcastHeight + 1)
+	sweepTx := ctx.receiveTx()
+	assertSpends(t, sweepTx, inputs...)
+	ctx.assertNoTx()
+
+	// A block within the confirmation target of the inputs shouldn't
+	// replace their sweep.
+	ctx.notifyEpoch(fundingBroadcastHeight + 2)
+	ctx.assertNoTx()
+
+	ctx.notifySpend(sweepTx)
+	for _, resultChan := range resultChans {
+		assertSweepResult(t, resultChan, nil)
+	}
+
+	// The dust input should still be waiting for fees to drop.
+	pending := ctx.pendingSweeps()
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending sweep, got %d", len(pending))
+	}
+	if _, ok := pending[*dust.OutPoint()]; !ok {
+		t.Fatalf("dust input isn't pending")
+	}
+
+	// Finally, a spend of the dust input by a third party should be
+	// reported as such.
+	remoteTx := wire.NewMsgTx(2)
+	remoteTx.AddTxIn(&wire.TxIn{PreviousOutPoint: *dust.OutPoint()})
+	ctx.notifySpend(remoteTx)
+	assertSweepResult(t, dustResultChan, ErrRemoteSpend)
+}
+
+// TestSweeperRBF asserts that a sweep that fails to confirm within the
+// confirmation target of its inputs is replaced by one paying a higher fee
+// rate, and that an input whose sweep can't be replaced anymore doesn't hold
+// back the sweep of other inputs.
+func TestSweeperRBF(t *testing.T) {
+	const maxFeeRate = 2 * sweeperTestFeeRate
+
+	ctx := newSweeperTestContext(t, maxFeeRate)
+	defer ctx.cleanUp()
+
+	input := makeSweeperTestInput(0, 1e6)
+	resultChan := ctx.sweepInput(input, 2)
+
+	height := int32(fundingBroadcastHeight + 1)
+	ctx.notifyEpoch(height)
+	sweepTx := ctx.receiveTx()
+	assertSpends(t, sweepTx, input)
+
+	// The sweep is only replaced once it failed to confirm within two
+	// blocks, by one paying a higher fee.
+	height++
+	ctx.notifyEpoch(height)
+	ctx.assertNoTx()
+
+	height++
+	ctx.notifyEpoch(height)
+	replacementTx := ctx.receiveTx()
+	assertSpends(t, replacementTx, input)
+	if replacementTx.TxOut[0].Value >= sweepTx.TxOut[0].Value {
+		t.Fatalf("replacement doesn't pay a higher fee")
+	}
+
+	report := ctx.pendingSweeps()[*input.OutPoint()]
+	if report.feeRate <= sweeperTestFeeRate {
+		t.Fatalf("expected fee rate above %v, got %v",
+			sweeperTestFeeRate, report.feeRate)
+	}
+	if report.publishAttempts != 2 {
+		t.Fatalf("expected 2 publish attempts, got %d",
+			report.publishAttempts)
+	}
+	if *report.sweepTxid != replacementTx.TxHash() {
+		t.Fatalf("report doesn't carry the replacement sweep")
+	}
+
+	// Keep replacing the sweep until the maximum fee rate is reached.
+	for report.feeRate < maxFeeRate {
+		height += 2
+		ctx.notifyEpoch(height)
+		replacementTx = ctx.receiveTx()
+		assertSpends(t, replacementTx, input)
+
+		report = ctx.pendingSweeps()[*input.OutPoint()]
+	}
+
+	// Now that the sweep can't be replaced anymore, a new input should be
+	// swept on its own.
+	input2 := makeSweeperTestInput(1, 1e6)
+	resultChan2 := ctx.sweepInput(input2, 6)
+
+	height += 2
+	ctx.notifyEpoch(height)
+	sweepTx2 := ctx.receiveTx()
+	assertSpends(t, sweepTx2, input2)
+	ctx.assertNoTx()
+
+	ctx.notifySpend(replacementTx)
+	assertSweepResult(t, resultChan, nil)
+	ctx.notifySpend(sweepTx2)
+	assertSweepResult(t, resultChan2, nil)
+}
+
+// TestSweeperBumpFee asserts that bumping the fee of an input immediately
+// replaces its sweep by one paying at least the requested fee rate.
+func TestSweeperBumpFee(t *testing.T) {
+	ctx := newSweeperTestContext(t, 0)
+	defer ctx.cleanUp()
+
+	input := makeSweeperTestInput(0, 1e6)
+	ctx.sweepInput(input, 6)
+
+	ctx.notifyEpoch(fundingBroadcastHeight + 1)
+	ctx.receiveTx()
+
+	_, err := ctx.sweeper.BumpFee(wire.OutPoint{}, 0, 50)
+	if err != ErrUnknownSweepInput {
+		t.Fatalf("expected ErrUnknownSweepInput, got %v", err)
+	}
+
+	report, err := ctx.sweeper.BumpFee(*input.OutPoint(), 0, 50)
+	if err != nil {
+		t.Fatalf("unable to bump fee: %v", err)
+	}
+	if report.feeRate < 50 {
+		t.Fatalf("expected fee rate of at least 50, got %v",
+			report.feeRate)
+	}
+
+	bumpTx := ctx.receiveTx()
+	assertSpends(t, bumpTx, input)
+	if *report.sweepTxid != bumpTx.TxHash() {
+		t.Fatalf("report doesn't carry the bumped sweep")
+	}
+}
+
+// TestSweeperFailingInput asserts that an input whose sweeps fail to be
+// published doesn't prevent the other inputs from being swept, and that it's
+// dropped after maxSweepPublishFailures attempts.
+func TestSweeperFailingInput(t *testing.T) {
+	ctx := newSweeperTestContext(t, 0)
+	defer ctx.cleanUp()
+
+	inputs := []*kidOutput{
+		makeSweeperTestInput(0, 1e6),
+		makeSweeperTestInput(1, 2e6),
+		makeSweeperTestInput(2, 3e6),
+	}
+	failingInput := inputs[1]
+	ctx.failInput(*failingInput.OutPoint())
+
+	var resultChans []chan *sweepResult
+	for _, input := range inputs {
+		resultChans = append(resultChans, ctx.sweepInput(input, 20))
+	}
+
+	// The sweep of all inputs fails, after which the inputs are swept in
+	// smaller sets, so the healthy inputs should still be swept.
+	height := int32(fundingBroadcastHeight + 1)
+	ctx.notifyEpoch(height)
+
+	swept := make(map[wire.OutPoint]*wire.MsgTx)
+	for len(ctx.published) > 0 {
+		tx := ctx.receiveTx()
+		for _, txIn := range tx.TxIn {
+			swept[txIn.PreviousOutPoint] = tx
+		}
+	}
+	if len(swept) != 2 {
+		t.Fatalf("expected 2 inputs to be swept, got %d", len(swept))
+	}
+	if _, ok := swept[*failingInput.OutPoint()]; ok {
+		t.Fatalf("failing input was swept")
+	}
+
+	pending := ctx.pendingSweeps()
+	if pending[*failingInput.OutPoint()].sweepTxid != nil {
+		t.Fatalf("failing input has a sweep")
+	}
+
+	// The failing input is retried on its own at each block, which
+	// shouldn't cause the sweep of the healthy inputs to be replaced, until
+	// it's dropped.
+	for i := 1; i < maxSweepPublishFailures; i++ {
+		select {
+		case <-resultChans[1]:
+			t.Fatalf("failing input dropped after %d failures", i)
+		default:
+		}
+
+		height++
+		ctx.notifyEpoch(height)
+		ctx.assertNoTx()
+	}
+	assertSweepResult(t, resultChans[1], ErrInputUnspendable)
+
+	// The healthy inputs may have been swept by the same transaction, so
+	// we'll only notify each sweep once.
+	notified := make(map[chainhash.Hash]struct{})
+	for _, i := range []int{0, 2} {
+		sweepTx := swept[*inputs[i].OutPoint()]
+		if _, ok := notified[sweepTx.TxHash()]; !ok {
+			ctx.notifySpend(sweepTx)
+			notified[sweepTx.TxHash()] = struct{}{}
+		}
+		assertSweepResult(t, resultChans[i], nil)
+	}
+}
@@ -0,0 +1,146 @@
+package tlv
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// Type is the 64-bit identifier of a TLV record. Following the "it's OK to be
+// odd" rule, records with an even type must be understood by the reader of a
+// stream, while records with an odd type may be safely ignored.
+type Type uint64
+
+// IsRequired returns true if a record of this type must be understood by the
+// reader of a stream.
+func (t Type) IsRequired() bool {
+	return t%2 == 0
+}
+
+// TypeMap is a map of the types parsed from a stream. Types which the stream
+// had a record for map to nil, while types unknown to the stream map to their
+// raw value, allowing them to be retained and later re-encoded.
+type TypeMap map[Type][]byte
+
+// Encoder is a signature for methods that can encode TLV values. An error
+// should be returned if the Encoder cannot support the underlying type of val.
+type Encoder func(w io.Writer, val interface{}) error
+
+// Decoder is a signature for methods that can decode TLV values. An error
+// should be returned if the Decoder cannot support the underlying type of val,
+// or if the length l isn't valid for the type.
+type Decoder func(r io.Reader, val interface{}, l uint64) error
+
+// SizeFunc is a function that returns the length of a record's value once
+// encoded.
+type SizeFunc func() uint64
+
+// Record holds the type, encoder and decoder of a single TLV record, along
+// with a pointer to the value that is to be encoded from, or decoded into.
+type Record struct {
+	value    interface{}
+	typ      Type
+	sizeFunc SizeFunc
+	encoder  Encoder
+	decoder  Decoder
+}
+
+// Type returns the type of the record.
+func (f *Record) Type() Type {
+	return f.typ
+}
+
+// Size returns the length of the record's value once encoded.
+func (f *Record) Size() uint64 {
+	return f.sizeFunc()
+}
+
+// Encode writes the value of the record to w.
+func (f *Record) Encode(w io.Writer) error {
+	return f.encoder(w, f.value)
+}
+
+// Decode reads the value of the record, which is l bytes long, from r.
+func (f *Record) Decode(r io.Reader, l uint64) error {
+	return f.decoder(r, f.value, l)
+}
+
+// MakeStaticRecord creates a record for a value whose encoded length is
+// always the passed size.
+func MakeStaticRecord(typ Type, val interface{}, size uint64,
+	encoder Encoder, decoder Decoder) Record {
+
+	return Record{
+		value: val,
+		typ:   typ,
+		sizeFunc: func() uint64 {
+			return size
+		},
+		encoder: encoder,
+		decoder: decoder,
+	}
+}
+
+// MakeDynamicRecord creates a record for a value whose encoded length depends
+// on the value itself, and is computed by the passed sizeFunc.
+func MakeDynamicRecord(typ Type, val interface{}, sizeFunc SizeFunc,
+	encoder Encoder, decoder Decoder) Record {
+
+	return Record{
+		value:    val,
+		typ:      typ,
+		sizeFunc: sizeFunc,
+		encoder:  encoder,
+		decoder:  decoder,
+	}
+}
+
+// MakePrimitiveRecord creates a record for one of the primitive types known
+// to this package. The passed value must be a pointer to one of: uint8,
+// uint16, uint32, uint64, [32]byte, [33]byte, *btcec.PublicKey or []byte.
+//
+// NOTE: This method will panic if the type of val isn't supported, as this
+// indicates a programming error rather than a malformed stream.
+func MakePrimitiveRecord(typ Type, val interface{}) Record {
+	switch e := val.(type) {
+	case *uint8:
+		return MakeStaticRecord(typ, e, 1, EUint8, DUint8)
+
+	case *uint16:
+		return MakeStaticRecord(typ, e, 2, EUint16, DUint16)
+
+	case *uint32:
+		return MakeStaticRecord(typ, e, 4, EUint32, DUint32)
+
+	case *uint64:
+		return MakeStaticRecord(typ, e, 8, EUint64, DUint64)
+
+	case *[32]byte:
+		return MakeStaticRecord(typ, e, 32, EBytes32, DBytes32)
+
+	case *[33]byte:
+		return MakeStaticRecord(typ, e, 33, EBytes33, DBytes33)
+
+	case **btcec.PublicKey:
+		return MakeStaticRecord(typ, e, 33, EPubKey, DPubKey)
+
+	case *[]byte:
+		sizeFunc := func() uint64 {
+			return uint64(len(*e))
+		}
+		return MakeDynamicRecord(typ, e, sizeFunc, EVarBytes, DVarBytes)
+
+	default:
+		panic(fmt.Sprintf("unknown primitive type: %T", val))
+	}
+}
+
+// SortRecords sorts the passed records in place by increasing type, which is
+// the order in which they must appear within a stream.
+func SortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].typ < records[j].typ
+	})
+}
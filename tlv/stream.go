@@ -0,0 +1,201 @@
+package tlv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// MaxRecordSize is the maximum length of the value of a single record. As a
+// stream is always carried within a lightning message or onion, no record can
+// ever be larger than this.
+const MaxRecordSize = 65535
+
+var (
+	// ErrStreamNotCanonical signals that a stream's records don't appear
+	// in strictly increasing order of type, meaning a type has either been
+	// repeated or is out of order.
+	ErrStreamNotCanonical = errors.New("tlv stream is not canonical")
+
+	// ErrRecordTooLarge signals that a record's length exceeds
+	// MaxRecordSize.
+	ErrRecordTooLarge = errors.New("record is too large")
+)
+
+// ErrUnknownRequiredType is returned when decoding a stream that contains a
+// record with an even type that the stream doesn't have a record for.
+type ErrUnknownRequiredType Type
+
+// Error returns a human-readable description of the unknown type.
+func (t ErrUnknownRequiredType) Error() string {
+	return fmt.Sprintf("unknown required type: %d", Type(t))
+}
+
+// Stream is an ordered set of records, which can be encoded to, or decoded
+// from, a type-length-value stream. When decoding, records with types unknown
+// to the stream are skipped if odd, and rejected if even.
+type Stream struct {
+	records []Record
+}
+
+// NewStream creates a new stream from the passed records. An error is returned
+// if the records aren't in strictly increasing order of type.
+func NewStream(records ...Record) (*Stream, error) {
+	for i := 1; i < len(records); i++ {
+		if records[i].typ <= records[i-1].typ {
+			return nil, ErrStreamNotCanonical
+		}
+	}
+
+	return &Stream{
+		records: records,
+	}, nil
+}
+
+// MustNewStream creates a new stream from the passed records, panicking if the
+// records aren't in strictly increasing order of type. It should only be used
+// with a static set of records.
+func MustNewStream(records ...Record) *Stream {
+	stream, err := NewStream(records...)
+	if err != nil {
+		panic(err)
+	}
+
+	return stream
+}
+
+// Encode writes each of the stream's records to w as its type and length,
+// both encoded as varints, followed by its value.
+func (s *Stream) Encode(w io.Writer) error {
+	for i := range s.records {
+		record := &s.records[i]
+
+		if err := WriteVarInt(w, uint64(record.Type())); err != nil {
+			return err
+		}
+		if err := WriteVarInt(w, record.Size()); err != nil {
+			return err
+		}
+		if err := record.Encode(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode reads records from r until it's exhausted, decoding the value of
+// each record known to the stream. Unknown records with an odd type are
+// skipped, while an unknown record with an even type results in an
+// ErrUnknownRequiredType error.
+func (s *Stream) Decode(r io.Reader) error {
+	return s.decode(r, nil)
+}
+
+// DecodeWithParsedTypes is identical to Decode, but also returns the set of
+// types that were parsed. The raw values of any unknown records are retained
+// within the returned map, so that they may be re-encoded later on.
+func (s *Stream) DecodeWithParsedTypes(r io.Reader) (TypeMap, error) {
+	parsedTypes := make(TypeMap)
+	if err := s.decode(r, parsedTypes); err != nil {
+		return nil, err
+	}
+
+	return parsedTypes, nil
+}
+
+// decode is the shared implementation of Decode and DecodeWithParsedTypes. If
+// parsedTypes is non-nil, then each parsed type is added to it.
+func (s *Stream) decode(r io.Reader, parsedTypes TypeMap) error {
+	var (
+		prevType  Type
+		first     = true
+		recordIdx int
+	)
+	for {
+		// We'll read the type of the next record. If we've reached the
+		// end of the stream, then we're done.
+		t, err := ReadVarInt(r)
+		switch {
+		case err == io.EOF:
+			return nil
+
+		case err != nil:
+			return err
+		}
+		typ := Type(t)
+
+		// Each record must have a greater type than the last in order
+		// to ensure that there's only a single encoding of a stream.
+		if !first && typ <= prevType {
+			return ErrStreamNotCanonical
+		}
+		first = false
+		prevType = typ
+
+		length, err := ReadVarInt(r)
+		switch {
+		case err == io.EOF:
+			return io.ErrUnexpectedEOF
+
+		case err != nil:
+			return err
+		}
+		if length > MaxRecordSize {
+			return ErrRecordTooLarge
+		}
+
+		// As both the records and the stream are sorted, we only need
+		// to advance past the records with a lesser type to find out
+		// whether we know about this one.
+		for recordIdx < len(s.records) &&
+			s.records[recordIdx].typ < typ {
+
+			recordIdx++
+		}
+
+		switch {
+		// This is a record we know of, so we'll decode its value,
+		// ensuring that the decoder consumes exactly the length of
+		// the record.
+		case recordIdx < len(s.records) &&
+			s.records[recordIdx].typ == typ:
+
+			lr := &io.LimitedReader{R: r, N: int64(length)}
+			if err := s.records[recordIdx].Decode(lr, length); err != nil {
+				return err
+			}
+			if lr.N != 0 {
+				return fmt.Errorf("record of type %d has %d "+
+					"trailing bytes", typ, lr.N)
+			}
+
+			if parsedTypes != nil {
+				parsedTypes[typ] = nil
+			}
+
+		// The record is unknown to us, but we're required to
+		// understand it, so we must fail.
+		case typ.IsRequired():
+			return ErrUnknownRequiredType(typ)
+
+		// Otherwise, the record is unknown but optional, so we'll hold
+		// on to its value if requested, and skip it otherwise.
+		case parsedTypes != nil:
+			value := make([]byte, length)
+			if err := readFull(r, value); err != nil {
+				return err
+			}
+			parsedTypes[typ] = value
+
+		default:
+			_, err := io.CopyN(ioutil.Discard, r, int64(length))
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// testRecords is a set of values covering each of the primitive types, along
+// with the stream used to encode and decode them.
+type testRecords struct {
+	u8     uint8
+	u16    uint16
+	u32    uint32
+	u64    uint64
+	b32    [32]byte
+	b33    [33]byte
+	pubKey *btcec.PublicKey
+	bytes  []byte
+}
+
+func (t *testRecords) stream() *Stream {
+	return MustNewStream(
+		MakePrimitiveRecord(1, &t.u8),
+		MakePrimitiveRecord(2, &t.u16),
+		MakePrimitiveRecord(3, &t.u32),
+		MakePrimitiveRecord(4, &t.u64),
+		MakePrimitiveRecord(5, &t.b32),
+		MakePrimitiveRecord(6, &t.b33),
+		MakePrimitiveRecord(7, &t.pubKey),
+		MakePrimitiveRecord(0xfd00, &t.bytes),
+	)
+}
+
+// TestStreamEncodeDecode tests that a stream of records of each primitive
+// type survives a round trip.
+func TestStreamEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	records := &testRecords{
+		u8:     0x01,
+		u16:    0x0203,
+		u32:    0x04050607,
+		u64:    0x08090a0b0c0d0e0f,
+		pubKey: privKey.PubKey(),
+		bytes:  bytes.Repeat([]byte{0xaa}, 300),
+	}
+	records.b32[0] = 0x10
+	records.b33[32] = 0x11
+
+	var b bytes.Buffer
+	if err := records.stream().Encode(&b); err != nil {
+		t.Fatalf("unable to encode stream: %v", err)
+	}
+
+	decoded := &testRecords{}
+	if err := decoded.stream().Decode(&b); err != nil {
+		t.Fatalf("unable to decode stream: %v", err)
+	}
+	if !reflect.DeepEqual(records, decoded) {
+		t.Fatalf("records don't match: expected %v, got %v",
+			records, decoded)
+	}
+}
+
+// TestStreamDecodeUnknownTypes tests that unknown records are skipped if
+// their type is odd, and rejected if it's even, and that the raw values of
+// unknown records are retained when the parsed types are requested.
+func TestStreamDecodeUnknownTypes(t *testing.T) {
+	t.Parallel()
+
+	var u8 uint8
+	stream := MustNewStream(MakePrimitiveRecord(2, &u8))
+
+	// The first record has an unknown odd type, which should be skipped,
+	// while the second is known.
+	encoded, _ := hex.DecodeString("0102aabb020101")
+
+	parsedTypes, err := stream.DecodeWithParsedTypes(
+		bytes.NewReader(encoded),
+	)
+	if err != nil {
+		t.Fatalf("unable to decode stream: %v", err)
+	}
+	if u8 != 1 {
+		t.Fatalf("expected known record to be decoded, got %v", u8)
+	}
+
+	expectedTypes := TypeMap{
+		1: {0xaa, 0xbb},
+		2: nil,
+	}
+	if !reflect.DeepEqual(parsedTypes, expectedTypes) {
+		t.Fatalf("expected parsed types %v, got %v", expectedTypes,
+			parsedTypes)
+	}
+
+	// An unknown even type, on the other hand, must be understood, so the
+	// stream should be rejected.
+	encoded, _ = hex.DecodeString("020101040100")
+	err = stream.Decode(bytes.NewReader(encoded))
+	if err != ErrUnknownRequiredType(4) {
+		t.Fatalf("expected ErrUnknownRequiredType, instead got %v", err)
+	}
+}
+
+// TestStreamDecodeFailures tests that streams which are malformed or not
+// canonically encoded are rejected.
+func TestStreamDecodeFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		encoded string
+		err     error
+	}{
+		{
+			name:    "repeated type",
+			encoded: "0100" + "0100",
+			err:     ErrStreamNotCanonical,
+		},
+		{
+			name:    "out of order",
+			encoded: "0300" + "0100",
+			err:     ErrStreamNotCanonical,
+		},
+		{
+			name:    "missing length",
+			encoded: "01",
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "truncated value",
+			encoded: "0102aa",
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "truncated known value",
+			encoded: "0208000000",
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "record too large",
+			encoded: "01fe00010000",
+			err:     ErrRecordTooLarge,
+		},
+		{
+			name:    "non-canonical type",
+			encoded: "fd000100",
+			err:     ErrVarIntNotCanonical,
+		},
+	}
+
+	for _, test := range tests {
+		var u64 uint64
+		stream := MustNewStream(MakePrimitiveRecord(2, &u64))
+
+		encoded, _ := hex.DecodeString(test.encoded)
+		err := stream.Decode(bytes.NewReader(encoded))
+		if err != test.err {
+			t.Fatalf("%v: expected %v, instead got %v", test.name,
+				test.err, err)
+		}
+	}
+
+	// A known record with an incorrect length should also be rejected.
+	var u64 uint64
+	stream := MustNewStream(MakePrimitiveRecord(2, &u64))
+	encoded, _ := hex.DecodeString("020400000000")
+	err := stream.Decode(bytes.NewReader(encoded))
+	if _, ok := err.(ErrTypeForDecoding); !ok {
+		t.Fatalf("expected ErrTypeForDecoding, instead got %v", err)
+	}
+}
+
+// TestNewStreamUnsorted tests that a stream can't be created from records
+// which aren't sorted by type.
+func TestNewStreamUnsorted(t *testing.T) {
+	t.Parallel()
+
+	var a, b uint8
+	records := []Record{
+		MakePrimitiveRecord(2, &a),
+		MakePrimitiveRecord(1, &b),
+	}
+
+	if _, err := NewStream(records...); err != ErrStreamNotCanonical {
+		t.Fatalf("expected ErrStreamNotCanonical, instead got %v", err)
+	}
+
+	SortRecords(records)
+	if _, err := NewStream(records...); err != nil {
+		t.Fatalf("unable to create stream from sorted records: %v", err)
+	}
+}
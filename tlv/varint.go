@@ -0,0 +1,106 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrVarIntNotCanonical signals that the decoded varint was not minimally
+// encoded.
+var ErrVarIntNotCanonical = errors.New("decoded varint is not canonical")
+
+// WriteVarInt serializes val to w using a variable number of bytes depending
+// on its value. Integers below 0xfd are written as a single byte, otherwise
+// the discriminant 0xfd, 0xfe or 0xff is followed by the big-endian encoding
+// of the integer as a uint16, uint32 or uint64 respectively.
+func WriteVarInt(w io.Writer, val uint64) error {
+	var b []byte
+	switch {
+	case val < 0xfd:
+		b = []byte{byte(val)}
+
+	case val <= 0xffff:
+		b = make([]byte, 3)
+		b[0] = 0xfd
+		binary.BigEndian.PutUint16(b[1:], uint16(val))
+
+	case val <= 0xffffffff:
+		b = make([]byte, 5)
+		b[0] = 0xfe
+		binary.BigEndian.PutUint32(b[1:], uint32(val))
+
+	default:
+		b = make([]byte, 9)
+		b[0] = 0xff
+		binary.BigEndian.PutUint64(b[1:], val)
+	}
+
+	_, err := w.Write(b)
+	return err
+}
+
+// ReadVarInt reads a variable length integer from r and returns it as a
+// uint64. An error is returned if the integer isn't minimally encoded. If r is
+// exhausted before any bytes are read, then io.EOF is returned, while
+// io.ErrUnexpectedEOF is returned if r is exhausted part way through the
+// integer.
+func ReadVarInt(r io.Reader) (uint64, error) {
+	var discriminant [1]byte
+	if _, err := io.ReadFull(r, discriminant[:]); err != nil {
+		return 0, err
+	}
+
+	var (
+		size int
+		min  uint64
+	)
+	switch discriminant[0] {
+	case 0xff:
+		size, min = 8, 0x100000000
+	case 0xfe:
+		size, min = 4, 0x10000
+	case 0xfd:
+		size, min = 2, 0xfd
+	default:
+		return uint64(discriminant[0]), nil
+	}
+
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+
+	var val uint64
+	switch size {
+	case 8:
+		val = binary.BigEndian.Uint64(buf[:8])
+	case 4:
+		val = uint64(binary.BigEndian.Uint32(buf[:4]))
+	case 2:
+		val = uint64(binary.BigEndian.Uint16(buf[:2]))
+	}
+
+	if val < min {
+		return 0, ErrVarIntNotCanonical
+	}
+
+	return val, nil
+}
+
+// VarIntSize returns the number of bytes required to encode val as a varint.
+func VarIntSize(val uint64) uint64 {
+	switch {
+	case val < 0xfd:
+		return 1
+	case val <= 0xffff:
+		return 3
+	case val <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
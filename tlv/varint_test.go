@@ -0,0 +1,82 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+)
+
+// TestVarIntEncoding tests that integers at the boundaries of each size are
+// encoded as expected, and survive a round trip.
+func TestVarIntEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   uint64
+		encoded string
+	}{
+		{0, "00"},
+		{0xfc, "fc"},
+		{0xfd, "fd00fd"},
+		{0xffff, "fdffff"},
+		{0x10000, "fe00010000"},
+		{0xffffffff, "feffffffff"},
+		{0x100000000, "ff0000000100000000"},
+		{0xffffffffffffffff, "ffffffffffffffffff"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := WriteVarInt(&b, test.value); err != nil {
+			t.Fatalf("unable to write varint: %v", err)
+		}
+
+		encoded := hex.EncodeToString(b.Bytes())
+		if encoded != test.encoded {
+			t.Fatalf("expected %v to be encoded as %v, instead "+
+				"got %v", test.value, test.encoded, encoded)
+		}
+		if uint64(b.Len()) != VarIntSize(test.value) {
+			t.Fatalf("expected size %v, got %v",
+				VarIntSize(test.value), b.Len())
+		}
+
+		value, err := ReadVarInt(&b)
+		if err != nil {
+			t.Fatalf("unable to read varint: %v", err)
+		}
+		if value != test.value {
+			t.Fatalf("expected %v, got %v", test.value, value)
+		}
+	}
+}
+
+// TestVarIntDecodingFailures tests that varints which aren't minimally encoded
+// or are truncated are rejected.
+func TestVarIntDecodingFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		encoded string
+		err     error
+	}{
+		{"fd00fc", ErrVarIntNotCanonical},
+		{"fe0000ffff", ErrVarIntNotCanonical},
+		{"ff00000000ffffffff", ErrVarIntNotCanonical},
+		{"", io.EOF},
+		{"fd00", io.ErrUnexpectedEOF},
+		{"fe", io.ErrUnexpectedEOF},
+		{"ff00000000", io.ErrUnexpectedEOF},
+	}
+
+	for _, test := range tests {
+		encoded, _ := hex.DecodeString(test.encoded)
+
+		_, err := ReadVarInt(bytes.NewReader(encoded))
+		if err != test.err {
+			t.Fatalf("expected %v when decoding %v, instead got %v",
+				test.err, test.encoded, err)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package tor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// success is the Tor Control response code representing a successful
+	// request.
+	success = 250
+
+	// nonceLen is the length of a nonce generated by either the controller
+	// or the Tor server
+	nonceLen = 32
+
+	// cookieLen is the length of the authentication cookie.
+	cookieLen = 32
+
+	// ProtocolInfoVersion is the `protocolinfo` version currently supported
+	// by the Tor server.
+	ProtocolInfoVersion = 1
+)
+
+var (
+	// serverKey is the key used when computing the HMAC-SHA256 of a
+	// message from the server.
+	serverKey = []byte("Tor safe cookie authentication " +
+		"server-to-controller hash")
+
+	// controllerKey is the key used when computing the HMAC-SHA256 of a
+	// message from the controller.
+	controllerKey = []byte("Tor safe cookie authentication " +
+		"controller-to-server hash")
+
+	// errNoAuthMethod is returned when the Tor server doesn't support any
+	// of the authentication methods known to the controller.
+	errNoAuthMethod = errors.New("no supported authentication method")
+)
+
+// OnionType denotes the type of the onion service.
+type OnionType int
+
+const (
+	// V2 denotes that the onion service is V2.
+	V2 OnionType = iota
+
+	// V3 denotes that the onion service is V3.
+	V3
+)
+
+// AddOnionConfig houses all of the required parameters in order to
+// successfully create a new onion service or restore an existing one.
+type AddOnionConfig struct {
+	// Type denotes the type of the onion service that should be created.
+	Type OnionType
+
+	// VirtualPort is the externally reachable port of the onion address.
+	VirtualPort int
+
+	// TargetPorts is the set of ports that the virtual port will be mapped
+	// to on the local host. Tor load balances between the target ports if
+	// there's more than one.
+	TargetPorts []int
+
+	// PrivateKeyPath is the full path to where the onion service's private
+	// key is stored. If the file doesn't exist, then a new onion service
+	// is created and its private key is written to this path, otherwise
+	// the existing onion service is restored from it.
+	PrivateKeyPath string
+}
+
+// Controller is an implementation of the Tor Control protocol. This is used in
+// order to communicate with a Tor server. Its only supported method of
+// authentication is the SAFECOOKIE method, along with no authentication at
+// all.
+//
+// NOTE: The connection to the Tor server must be authenticated before
+// proceeding to send commands. Otherwise, the connection will be closed.
+type Controller struct {
+	// started is used atomically in order to prevent multiple calls to
+	// Start.
+	started int32
+
+	// stopped is used atomically in order to prevent multiple calls to
+	// Stop.
+	stopped int32
+
+	// conn is the underlying connection between the controller and the
+	// Tor server. It provides read and write methods to simplify the
+	// text-based messages within the connection.
+	conn *textproto.Conn
+
+	// controlAddr is the host:port the Tor server is listening locally for
+	// controller connections on.
+	controlAddr string
+}
+
+// NewController returns a new Tor controller that will be able to interact
+// with a Tor server.
+func NewController(controlAddr string) *Controller {
+	return &Controller{controlAddr: controlAddr}
+}
+
+// Start establishes and authenticates the connection between the controller
+// and a Tor server. Once done, the controller will be able to send commands
+// and expect responses.
+func (c *Controller) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	conn, err := textproto.Dial("tcp", c.controlAddr)
+	if err != nil {
+		return fmt.Errorf("unable to connect to Tor server: %v", err)
+	}
+
+	c.conn = conn
+
+	return c.authenticate()
+}
+
+// Stop closes the connection between the controller and the Tor server. Any
+// onion services created through the controller are removed by the Tor
+// server once the connection is closed.
+func (c *Controller) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// sendCommand sends a command to the Tor server and returns its response code
+// along with its reply. The lines of a multi-line reply are joined by
+// newlines.
+func (c *Controller) sendCommand(command string) (int, string, error) {
+	if err := c.conn.Writer.PrintfLine(command); err != nil {
+		return 0, "", err
+	}
+
+	// We'll use ReadResponse as it has built-in support for multi-line
+	// text protocol responses.
+	return c.conn.Reader.ReadResponse(success)
+}
+
+// parseTorReply parses the reply from the Tor server after receiving a command
+// from a controller. This will parse the relevant reply parameters into a map
+// of keys and values.
+func parseTorReply(reply string) map[string]string {
+	params := make(map[string]string)
+
+	// Replies may span multiple lines, and values may be quoted strings
+	// containing spaces, so we'll scan the reply one character at a time
+	// to split it into its key-value pairs.
+	var (
+		token    bytes.Buffer
+		inQuotes bool
+		escaped  bool
+	)
+	addParam := func() {
+		defer token.Reset()
+
+		parts := strings.SplitN(token.String(), "=", 2)
+		if len(parts) != 2 {
+			return
+		}
+		params[parts[0]] = parts[1]
+	}
+	for _, r := range reply {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+
+		case inQuotes && r == '\\':
+			escaped = true
+
+		case r == '"':
+			inQuotes = !inQuotes
+
+		case !inQuotes && (r == ' ' || r == '\n'):
+			addParam()
+
+		default:
+			token.WriteRune(r)
+		}
+	}
+	addParam()
+
+	return params
+}
+
+// authenticate authenticates the connection between the controller and the
+// Tor server using the SAFECOOKIE authentication method if required, or no
+// authentication at all if the server allows it.
+func (c *Controller) authenticate() error {
+	// Before proceeding to authenticate the connection, we'll retrieve
+	// the supported authentication methods of the Tor server.
+	cmd := fmt.Sprintf("PROTOCOLINFO %d", ProtocolInfoVersion)
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	info := parseTorReply(reply)
+	methods := strings.Split(info["METHODS"], ",")
+
+	supports := func(method string) bool {
+		for _, m := range methods {
+			if m == method {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	// If the Tor server doesn't require any authentication, then we can
+	// authenticate straight away.
+	case supports("NULL"):
+		_, _, err := c.sendCommand("AUTHENTICATE")
+		return err
+
+	// Otherwise, we'll use the authentication cookie to prove to the
+	// server that we're able to read its files.
+	case supports("SAFECOOKIE"):
+		return c.authenticateSafeCookie(info["COOKIEFILE"])
+
+	default:
+		return errNoAuthMethod
+	}
+}
+
+// authenticateSafeCookie authenticates the connection using the SAFECOOKIE
+// method, which has both the controller and server prove knowledge of the
+// cookie stored at the passed path without revealing it.
+func (c *Controller) authenticateSafeCookie(cookieFilePath string) error {
+	cookie, err := ioutil.ReadFile(cookieFilePath)
+	if err != nil {
+		return err
+	}
+	if len(cookie) != cookieLen {
+		return fmt.Errorf("invalid authentication cookie length %v",
+			len(cookie))
+	}
+
+	// Authenticating using the SAFECOOKIE method is a two step process.
+	// We'll kick it off by sending an AUTHCHALLENGE with our nonce.
+	var clientNonce [nonceLen]byte
+	if _, err := rand.Read(clientNonce[:]); err != nil {
+		return fmt.Errorf("unable to generate client nonce: %v", err)
+	}
+
+	cmd := fmt.Sprintf("AUTHCHALLENGE SAFECOOKIE %x", clientNonce[:])
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	// If successful, the reply from the server should contain the
+	// server's hash and nonce.
+	challenge := parseTorReply(reply)
+	serverHash, err := hex.DecodeString(challenge["SERVERHASH"])
+	if err != nil {
+		return fmt.Errorf("invalid server hash: %v", err)
+	}
+	serverNonce, err := hex.DecodeString(challenge["SERVERNONCE"])
+	if err != nil {
+		return fmt.Errorf("invalid server nonce: %v", err)
+	}
+	if len(serverNonce) != nonceLen {
+		return fmt.Errorf("invalid server nonce length %v",
+			len(serverNonce))
+	}
+
+	// The server hash proves that the server knows the cookie, so we'll
+	// verify it before revealing our own proof.
+	var msg bytes.Buffer
+	msg.Write(cookie)
+	msg.Write(clientNonce[:])
+	msg.Write(serverNonce)
+
+	expectedServerHash := computeHMAC256(serverKey, msg.Bytes())
+	if !hmac.Equal(serverHash, expectedServerHash) {
+		return fmt.Errorf("expected server hash %x, got %x",
+			expectedServerHash, serverHash)
+	}
+
+	// Finally, we'll send our own hash to complete the authentication.
+	clientHash := computeHMAC256(controllerKey, msg.Bytes())
+	cmd = fmt.Sprintf("AUTHENTICATE %x", clientHash)
+	_, _, err = c.sendCommand(cmd)
+
+	return err
+}
+
+// computeHMAC256 computes the HMAC-SHA256 of a key and message.
+func computeHMAC256(key, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// AddOnion creates an onion service and returns its onion address. Once
+// created, the new onion service will remain active until the connection
+// between the controller and the Tor server is closed. The private key of the
+// onion service is stored on disk, so that the same onion address is used
+// across restarts.
+func (c *Controller) AddOnion(cfg AddOnionConfig) (*OnionAddr, error) {
+	// We'll start by deciding whether to create a new onion service, or
+	// restore the existing one from its private key on disk.
+	var keyParam string
+	privateKey, err := ioutil.ReadFile(cfg.PrivateKeyPath)
+	switch {
+	case err == nil:
+		keyParam = string(bytes.TrimSpace(privateKey))
+
+	case os.IsNotExist(err):
+		switch cfg.Type {
+		case V2:
+			keyParam = "NEW:RSA1024"
+		case V3:
+			keyParam = "NEW:ED25519-V3"
+		default:
+			return nil, fmt.Errorf("unknown onion type %v",
+				cfg.Type)
+		}
+
+	default:
+		return nil, err
+	}
+
+	// Now, we'll map the virtual port of the onion service to each of the
+	// target ports on the local host.
+	if len(cfg.TargetPorts) == 0 {
+		return nil, errors.New("no target ports for onion service")
+	}
+	virtPort := strconv.Itoa(cfg.VirtualPort)
+
+	cmd := fmt.Sprintf("ADD_ONION %s", keyParam)
+	for _, targetPort := range cfg.TargetPorts {
+		cmd += fmt.Sprintf(" Port=%s,%d", virtPort, targetPort)
+	}
+
+	_, reply, err := c.sendCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// If successful, the reply from the server should be of the following
+	// format:
+	//
+	//	"250-ServiceID=serviceID"
+	//	"250-PrivateKey=privateKey" (only if a new service was created)
+	//	"250 OK"
+	params := parseTorReply(reply)
+	serviceID, ok := params["ServiceID"]
+	if !ok {
+		return nil, errors.New("service id not found in reply")
+	}
+
+	// If a new onion service was created, then we'll write its private
+	// key to disk so that it can be restored later on.
+	if newKey, ok := params["PrivateKey"]; ok {
+		err := ioutil.WriteFile(
+			cfg.PrivateKeyPath, []byte(newKey), 0600,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to write private key "+
+				"to file: %v", err)
+		}
+	}
+
+	return &OnionAddr{
+		OnionService: serviceID + OnionSuffix,
+		Port:         cfg.VirtualPort,
+	}, nil
+}
@@ -0,0 +1,212 @@
+package tor
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseTorReply tests that the parameters of a multi-line reply, including
+// quoted values, are parsed correctly.
+func TestParseTorReply(t *testing.T) {
+	t.Parallel()
+
+	reply := "PROTOCOLINFO 1\n" +
+		"AUTH METHODS=COOKIE,SAFECOOKIE " +
+		"COOKIEFILE=\"/home/user/tor data/control_auth_cookie\"\n" +
+		"VERSION Tor=\"0.3.3.7\"\n" +
+		"OK"
+
+	params := parseTorReply(reply)
+
+	expected := map[string]string{
+		"METHODS":    "COOKIE,SAFECOOKIE",
+		"COOKIEFILE": "/home/user/tor data/control_auth_cookie",
+		"Tor":        "0.3.3.7",
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Fatalf("expected %v=%v, got %v", key, value,
+				params[key])
+		}
+	}
+}
+
+// mockTorServer answers the commands of a controller with canned replies,
+// recording each command it receives.
+func mockTorServer(t *testing.T, conn net.Conn, replies map[string]string,
+	commands chan<- string) {
+
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		commands <- line
+
+		command := strings.SplitN(line, " ", 2)[0]
+		reply, ok := replies[command]
+		if !ok {
+			reply = "510 Unrecognized command\r\n"
+		}
+		if _, err := fmt.Fprint(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// TestControllerAddOnion tests that an onion service is created through the
+// controller, and that its private key is written to disk and used to restore
+// the same onion service later on.
+func TestControllerAddOnion(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "tor")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	const (
+		serviceID  = "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd"
+		privateKey = "ED25519-V3:base64key"
+	)
+	replies := map[string]string{
+		"PROTOCOLINFO": "250-PROTOCOLINFO 1\r\n" +
+			"250-AUTH METHODS=NULL\r\n" +
+			"250-VERSION Tor=\"0.3.3.7\"\r\n" +
+			"250 OK\r\n",
+		"AUTHENTICATE": "250 OK\r\n",
+		"ADD_ONION": "250-ServiceID=" + serviceID + "\r\n" +
+			"250-PrivateKey=" + privateKey + "\r\n" +
+			"250 OK\r\n",
+	}
+
+	client, server := net.Pipe()
+	commands := make(chan string, 10)
+	go mockTorServer(t, server, replies, commands)
+
+	controller := &Controller{conn: textproto.NewConn(client)}
+	defer controller.Stop()
+
+	if err := controller.authenticate(); err != nil {
+		t.Fatalf("unable to authenticate: %v", err)
+	}
+
+	keyPath := filepath.Join(tempDir, "onion_private_key")
+	cfg := AddOnionConfig{
+		Type:           V3,
+		VirtualPort:    9735,
+		TargetPorts:    []int{9735},
+		PrivateKeyPath: keyPath,
+	}
+	addr, err := controller.AddOnion(cfg)
+	if err != nil {
+		t.Fatalf("unable to add onion: %v", err)
+	}
+
+	expectedAddr := serviceID + ".onion:9735"
+	if addr.String() != expectedAddr {
+		t.Fatalf("expected %v, got %v", expectedAddr, addr)
+	}
+
+	// The controller should have asked for a new key, and written it to
+	// disk.
+	expectedCommands := []string{
+		"PROTOCOLINFO 1",
+		"AUTHENTICATE",
+		"ADD_ONION NEW:ED25519-V3 Port=9735,9735",
+	}
+	for _, expected := range expectedCommands {
+		if command := <-commands; command != expected {
+			t.Fatalf("expected command %q, got %q", expected,
+				command)
+		}
+	}
+
+	storedKey, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("unable to read private key: %v", err)
+	}
+	if string(storedKey) != privateKey {
+		t.Fatalf("expected private key %v, got %v", privateKey,
+			string(storedKey))
+	}
+
+	// Adding the onion service once again should restore it from the
+	// stored key.
+	if _, err := controller.AddOnion(cfg); err != nil {
+		t.Fatalf("unable to add onion: %v", err)
+	}
+	expected := "ADD_ONION " + privateKey + " Port=9735,9735"
+	if command := <-commands; command != expected {
+		t.Fatalf("expected command %q, got %q", expected, command)
+	}
+}
+
+// TestControllerSafeCookie tests that the controller authenticates using the
+// SAFECOOKIE method, and refuses to reveal its proof to a server that can't
+// prove knowledge of the cookie.
+func TestControllerSafeCookie(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "tor")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cookiePath := filepath.Join(tempDir, "control_auth_cookie")
+	cookie := make([]byte, cookieLen)
+	if err := ioutil.WriteFile(cookiePath, cookie, 0600); err != nil {
+		t.Fatalf("unable to write cookie: %v", err)
+	}
+
+	// The server's hash is computed over a client nonce that we can't
+	// know in advance, so the controller must reject it.
+	serverNonce := make([]byte, nonceLen)
+	replies := map[string]string{
+		"PROTOCOLINFO": "250-PROTOCOLINFO 1\r\n" +
+			"250-AUTH METHODS=SAFECOOKIE " +
+			"COOKIEFILE=\"" + cookiePath + "\"\r\n" +
+			"250 OK\r\n",
+		"AUTHCHALLENGE": "250 AUTHCHALLENGE SERVERHASH=" +
+			hex.EncodeToString(make([]byte, 32)) + " SERVERNONCE=" +
+			hex.EncodeToString(serverNonce) + "\r\n",
+	}
+
+	client, server := net.Pipe()
+	commands := make(chan string, 10)
+	go mockTorServer(t, server, replies, commands)
+
+	controller := &Controller{conn: textproto.NewConn(client)}
+	defer controller.Stop()
+
+	if err := controller.authenticate(); err == nil {
+		t.Fatalf("expected authentication with invalid server hash " +
+			"to fail")
+	}
+
+	<-commands
+	challenge := <-commands
+	if !strings.HasPrefix(challenge, "AUTHCHALLENGE SAFECOOKIE ") {
+		t.Fatalf("expected AUTHCHALLENGE, got %q", challenge)
+	}
+	select {
+	case command := <-commands:
+		t.Fatalf("unexpected command after failed challenge: %q",
+			command)
+	default:
+	}
+}
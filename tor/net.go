@@ -0,0 +1,123 @@
+package tor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ErrOnionWithoutTor is returned when attempting to connect to an onion
+// service without routing the connection through Tor.
+var ErrOnionWithoutTor = errors.New("onion services can only be reached " +
+	"when Tor is active")
+
+// Net is an interface housing a Dial function and several DNS functions, to
+// abstract the implementation of these functions over both Regular and Tor
+// networks.
+type Net interface {
+	// Dial accepts a network and address and returns a connection to a
+	// remote peer.
+	Dial(network, address string) (net.Conn, error)
+
+	// LookupHost performs DNS resolution on a given host and returns its
+	// addresses.
+	LookupHost(host string) ([]string, error)
+
+	// LookupSRV tries to resolve an SRV query of the given service,
+	// protocol, and domain name.
+	LookupSRV(service, proto, name string) (string, []*net.SRV, error)
+
+	// ResolveTCPAddr resolves TCP addresses.
+	ResolveTCPAddr(network, address string) (*net.TCPAddr, error)
+}
+
+// ClearNet is an implementation of the Net interface that defines behaviour
+// for regular network connections.
+type ClearNet struct{}
+
+// A compile-time check to ensure that ClearNet implements the Net interface.
+var _ Net = (*ClearNet)(nil)
+
+// Dial on the regular network uses net.Dial. Onion services can't be reached
+// without Tor, so they're rejected.
+func (r *ClearNet) Dial(network, address string) (net.Conn, error) {
+	if isOnionAddr(address) {
+		return nil, ErrOnionWithoutTor
+	}
+
+	return net.Dial(network, address)
+}
+
+// LookupHost for regular network uses the net.LookupHost function.
+func (r *ClearNet) LookupHost(host string) ([]string, error) {
+	return net.LookupHost(host)
+}
+
+// LookupSRV for regular network uses net.LookupSRV function.
+func (r *ClearNet) LookupSRV(service, proto, name string) (string,
+	[]*net.SRV, error) {
+
+	return net.LookupSRV(service, proto, name)
+}
+
+// ResolveTCPAddr for regular network uses net.ResolveTCPAddr function.
+func (r *ClearNet) ResolveTCPAddr(network, address string) (*net.TCPAddr,
+	error) {
+
+	return net.ResolveTCPAddr(network, address)
+}
+
+// ProxyNet is an implementation of the Net interface that defines behaviour
+// for Tor network connections.
+type ProxyNet struct {
+	// SOCKS is the host:port which Tor's exposed SOCKS5 proxy is listening
+	// on.
+	SOCKS string
+
+	// DNS is the host:port of the DNS server for Tor to use for SRV
+	// queries.
+	DNS string
+}
+
+// A compile-time check to ensure that ProxyNet implements the Net interface.
+var _ Net = (*ProxyNet)(nil)
+
+// Dial uses the Tor Dial function in order to establish connections through
+// Tor. Since Tor only supports TCP connections, only TCP networks are allowed.
+func (p *ProxyNet) Dial(network, address string) (net.Conn, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("cannot dial non-tcp network via Tor")
+	}
+
+	return Dial(address, p.SOCKS)
+}
+
+// LookupHost uses the Tor LookupHost function in order to resolve hosts over
+// Tor.
+func (p *ProxyNet) LookupHost(host string) ([]string, error) {
+	return LookupHost(host, p.SOCKS)
+}
+
+// LookupSRV uses the Tor LookupSRV function in order to resolve SRV DNS
+// queries over Tor.
+func (p *ProxyNet) LookupSRV(service, proto, name string) (string,
+	[]*net.SRV, error) {
+
+	return LookupSRV(service, proto, name, p.SOCKS, p.DNS)
+}
+
+// ResolveTCPAddr uses the Tor ResolveTCPAddr function in order to resolve TCP
+// addresses over Tor.
+func (p *ProxyNet) ResolveTCPAddr(network, address string) (*net.TCPAddr,
+	error) {
+
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("cannot resolve non-tcp network via Tor")
+	}
+
+	return ResolveTCPAddr(address, p.SOCKS)
+}
@@ -0,0 +1,278 @@
+package tor
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/roasbeef/btcd/connmgr"
+	"golang.org/x/net/proxy"
+)
+
+const (
+	// OnionSuffix is the ".onion" suffix for v2 and v3 onion addresses.
+	OnionSuffix = ".onion"
+
+	// OnionSuffixLen is the length of the ".onion" suffix.
+	OnionSuffixLen = len(OnionSuffix)
+
+	// V2DecodedLen is the length of a decoded v2 onion service.
+	V2DecodedLen = 10
+
+	// V2Len is the length of a v2 onion service including the ".onion"
+	// suffix.
+	V2Len = 22
+
+	// V3DecodedLen is the length of a decoded v3 onion service.
+	V3DecodedLen = 35
+
+	// V3Len is the length of a v3 onion service including the ".onion"
+	// suffix.
+	V3Len = 62
+)
+
+var (
+	// Base32Encoding represents the Tor's base32-encoding scheme for v2 and
+	// v3 onion addresses.
+	Base32Encoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567")
+
+	// ErrOnionHost is returned when attempting to resolve an onion
+	// service, as the Tor network is responsible for routing to it.
+	ErrOnionHost = errors.New("onion services can't be resolved")
+)
+
+// OnionAddr represents a Tor network end point onion address.
+type OnionAddr struct {
+	// OnionService is the host of the onion address, including the
+	// ".onion" suffix.
+	OnionService string
+
+	// Port is the port of the onion address.
+	Port int
+}
+
+// A compile-time check to ensure that OnionAddr implements the net.Addr
+// interface.
+var _ net.Addr = (*OnionAddr)(nil)
+
+// String returns the string representation of an onion address.
+func (o *OnionAddr) String() string {
+	return net.JoinHostPort(o.OnionService, strconv.Itoa(o.Port))
+}
+
+// Network returns the network that this implementation of net.Addr will use.
+// In this case, because Tor only allows TCP connections, the network is "tcp".
+func (o *OnionAddr) Network() string {
+	return "tcp"
+}
+
+// IsOnionHost determines whether a host is part of the Tor network.
+func IsOnionHost(host string) bool {
+	// Note the starting index of the onion suffix in the host depending
+	// on its length.
+	var suffixIndex int
+	switch len(host) {
+	case V2Len:
+		suffixIndex = V2Len - OnionSuffixLen
+	case V3Len:
+		suffixIndex = V3Len - OnionSuffixLen
+	default:
+		return false
+	}
+
+	// Make sure the host ends with the ".onion" suffix.
+	if host[suffixIndex:] != OnionSuffix {
+		return false
+	}
+
+	// We'll now attempt to decode the host without its suffix, as the
+	// suffix includes invalid characters. This will tell us if the host
+	// is actually valid if successful.
+	host = host[:suffixIndex]
+	if _, err := Base32Encoding.DecodeString(host); err != nil {
+		return false
+	}
+
+	return true
+}
+
+// ParseOnionAddr parses an onion address of the form host:port, returning an
+// error if the host isn't a valid v2 or v3 onion service.
+func ParseOnionAddr(address string) (*OnionAddr, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsOnionHost(host) {
+		return nil, fmt.Errorf("invalid onion service %v", host)
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, err
+	}
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %v", port)
+	}
+
+	return &OnionAddr{
+		OnionService: host,
+		Port:         port,
+	}, nil
+}
+
+// Dial establishes a connection to the address via Tor's SOCKS proxy. Only TCP
+// is supported over Tor.
+func Dial(address, socksAddr string) (net.Conn, error) {
+	dialer, err := proxy.SOCKS5("tcp", socksAddr, nil, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+
+	return dialer.Dial("tcp", address)
+}
+
+// LookupHost performs DNS resolution on a given host via Tor's native resolver.
+// Only IPv4 addresses are returned for hosts that aren't already IP addresses.
+func LookupHost(host, socksAddr string) ([]string, error) {
+	if IsOnionHost(host) {
+		return nil, ErrOnionHost
+	}
+
+	// There's no need to query Tor for hosts that are already IP
+	// addresses.
+	if ip := net.ParseIP(host); ip != nil {
+		return []string{ip.String()}, nil
+	}
+
+	ips, err := connmgr.TorLookupIP(host, socksAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the resolved IPs into strings to match the signature of
+	// net.LookupHost.
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, ip.String())
+	}
+
+	return addrs, nil
+}
+
+// LookupSRV uses Tor's SOCKS proxy to route DNS SRV queries. Tor does not
+// natively support SRV queries, so we must route all SRV queries through the
+// proxy by connecting directly to a DNS server and querying it over TCP. The
+// DNS server must have TCP resolution enabled for the given port.
+func LookupSRV(service, proto, name, socksAddr,
+	dnsServer string) (string, []*net.SRV, error) {
+
+	// Connect to the DNS server we'll be using to query SRV records.
+	conn, err := Dial(dnsServer, socksAddr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dnsConn := &dns.Conn{Conn: conn}
+	defer dnsConn.Close()
+
+	// Once connected, we'll construct the SRV request for the host
+	// following the format _service._proto.name. as described in RFC
+	// 2782.
+	host := fmt.Sprintf("_%s._%s.%s", service, proto, name)
+	msg := new(dns.Msg).SetQuestion(dns.Fqdn(host), dns.TypeSRV)
+
+	// Send the request to the DNS server and read its response.
+	if err := dnsConn.WriteMsg(msg); err != nil {
+		return "", nil, err
+	}
+	resp, err := dnsConn.ReadMsg()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// We'll fail if we were unable to query the DNS server for our record.
+	if resp.Rcode != dns.RcodeSuccess {
+		return "", nil, fmt.Errorf("unable to query for SRV records: "+
+			"%s", dns.RcodeToString[resp.Rcode])
+	}
+
+	// Retrieve the RR(s) of the Answer section, and convert them to the
+	// format that net.LookupSRV would normally return.
+	var rrs []*net.SRV
+	for _, rr := range resp.Answer {
+		srv, ok := rr.(*dns.SRV)
+		if !ok {
+			continue
+		}
+
+		rrs = append(rrs, &net.SRV{
+			Target:   srv.Target,
+			Port:     srv.Port,
+			Priority: srv.Priority,
+			Weight:   srv.Weight,
+		})
+	}
+
+	return "", rrs, nil
+}
+
+// ResolveTCPAddr uses Tor's proxy to resolve TCP addresses instead of the
+// standard system resolver provided in the `net` package.
+func ResolveTCPAddr(address, socksAddr string) (*net.TCPAddr, error) {
+	// Split host:port since the lookup function does not take a port.
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ip, err := LookupHost(host, socksAddr)
+	if err != nil {
+		return nil, err
+	}
+	if len(ip) == 0 {
+		return nil, fmt.Errorf("no addresses found for %v", host)
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, err
+	}
+
+	return &net.TCPAddr{
+		IP:   net.ParseIP(ip[0]),
+		Port: p,
+	}, nil
+}
+
+// ParseAddr parses an address of the form host:port, returning an onion
+// address if the host is an onion service, or otherwise resolving it as a TCP
+// address using the passed network.
+func ParseAddr(address string, netCfg Net) (net.Addr, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsOnionHost(host) {
+		return ParseOnionAddr(address)
+	}
+
+	return netCfg.ResolveTCPAddr("tcp", address)
+}
+
+// isOnionAddr returns true if the host of the passed address is an onion
+// service.
+func isOnionAddr(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return strings.HasSuffix(address, OnionSuffix)
+	}
+
+	return IsOnionHost(host)
+}
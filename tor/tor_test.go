@@ -0,0 +1,70 @@
+package tor
+
+import "testing"
+
+// TestParseOnionAddr tests that valid v2 and v3 onion addresses are parsed,
+// and that invalid ones are rejected.
+func TestParseOnionAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		valid   bool
+	}{
+		{
+			address: "3g2upl4pq6kufc4m.onion:9735",
+			valid:   true,
+		},
+		{
+			address: "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3" +
+				"ah7ngmcopnpyyd.onion:9735",
+			valid: true,
+		},
+		{
+			// The host includes a character that isn't part of
+			// Tor's base32 alphabet.
+			address: "3g2upl4pq6kufc41.onion:9735",
+			valid:   false,
+		},
+		{
+			// The host is of the wrong length.
+			address: "3g2upl4pq6kufc4.onion:9735",
+			valid:   false,
+		},
+		{
+			address: "3g2upl4pq6kufc4m.onion:0",
+			valid:   false,
+		},
+		{
+			address: "127.0.0.1:9735",
+			valid:   false,
+		},
+	}
+
+	for _, test := range tests {
+		addr, err := ParseOnionAddr(test.address)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("unable to parse %v: %v", test.address, err)
+
+		case !test.valid && err == nil:
+			t.Fatalf("expected %v to be invalid", test.address)
+
+		case test.valid && addr.String() != test.address:
+			t.Fatalf("expected %v, got %v", test.address,
+				addr.String())
+		}
+	}
+}
+
+// TestClearNetRejectsOnion tests that we refuse to connect to onion services
+// without Tor, as doing so would leak the address to the system resolver.
+func TestClearNetRejectsOnion(t *testing.T) {
+	t.Parallel()
+
+	var clearNet ClearNet
+	_, err := clearNet.Dial("tcp", "3g2upl4pq6kufc4m.onion:9735")
+	if err != ErrOnionWithoutTor {
+		t.Fatalf("expected ErrOnionWithoutTor, instead got %v", err)
+	}
+}
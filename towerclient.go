@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/tor"
+	"github.com/lightningnetwork/lnd/watchtower"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// towerClient bridges the channel links and the watchtower client. For each
+// state revoked by the remote party, it crafts the justice transaction which
+// sweeps all outputs of the revoked commitment, and hands it off to the
+// watchtower client to be uploaded.
+type towerClient struct {
+	client *watchtower.Client
+
+	// justiceArb is used solely to craft justice transactions, it's never
+	// started.
+	justiceArb *breachArbiter
+}
+
+// A compile time check to ensure towerClient meets the
+// htlcswitch.TowerClient interface.
+var _ htlcswitch.TowerClient = (*towerClient)(nil)
+
+// newTowerClient creates a new towerClient which uploads justice transactions
+// using the passed watchtower client.
+func newTowerClient(client *watchtower.Client,
+	cc *chainControl) (*towerClient, error) {
+
+	// A single sweep address is used for all justice transactions handed
+	// to the tower, as otherwise we'd generate a new address for each
+	// state update, the vast majority of which will never be used.
+	sweepScript, err := newSweepPkScript(cc.wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	justiceArb := newBreachArbiter(&BreachConfig{
+		Estimator: cc.feeEstimator,
+		GenSweepScript: func() ([]byte, error) {
+			return sweepScript, nil
+		},
+		Signer: cc.wallet.Cfg.Signer,
+	})
+
+	return &towerClient{
+		client:     client,
+		justiceArb: justiceArb,
+	}, nil
+}
+
+// BackupState crafts the justice transaction for the passed revoked state,
+// and queues it for upload to the tower.
+//
+// NOTE: Part of the htlcswitch.TowerClient interface.
+func (t *towerClient) BackupState(chanInfo *channeldb.ChannelSnapshot,
+	breachInfo *lnwallet.BreachRetribution) error {
+
+	retInfo := newRetributionInfo(
+		&chanInfo.ChannelPoint, breachInfo, chanInfo,
+	)
+	justiceTx, err := t.justiceArb.createJusticeTx(retInfo)
+	if err != nil {
+		return err
+	}
+
+	breachTxID := breachInfo.BreachTransaction.TxHash()
+	return t.client.BackupState(&breachTxID, justiceTx)
+}
+
+// parseTowerAddr parses a watchtower address of the form
+// <pubkey>@<host>[:<port>]. If no port is specified, then the default
+// watchtower port is assumed.
+func parseTowerAddr(towerAddr string) (*lnwire.NetAddress, error) {
+	parts := strings.Split(towerAddr, "@")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid watchtower address %v, "+
+			"expected <pubkey>@<host>[:<port>]", towerAddr)
+	}
+
+	pubKeyBytes, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	// If the address doesn't already have a port, we'll assume the
+	// default watchtower port.
+	addr := parts[1]
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(
+			addr, strconv.Itoa(defaultWatchtowerPort),
+		)
+	}
+
+	host, err := tor.ParseAddr(addr, cfg.net)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwire.NetAddress{
+		IdentityKey: pubKey,
+		Address:     host,
+		ChainNet:    activeNetParams.Net,
+	}, nil
+}
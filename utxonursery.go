@@ -13,7 +13,6 @@ import (
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/roasbeef/btcd/blockchain"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -66,19 +65,15 @@ import (
 //    height has been fully determined. This results from having received
 //    confirmation of the UTXO we are trying to spend, contained in either the
 //    commitment txn or htlc timeout txn. Once the maturity height is reached,
-//    the utxo nursery will sweep all KNDR outputs scheduled for that height
-//    using a single txn.
+//    the utxo nursery hands all KNDR outputs scheduled for that height to the
+//    utxo sweeper, which batches them with any other outputs it's sweeping.
 //
-//    NOTE: Due to the fact that KNDR outputs can be dynamically aggregated and
-//    swept, we make precautions to finalize the KNDR outputs at a particular
-//    height on our first attempt to sweep it. Finalizing involves signing the
-//    sweep transaction and persisting it in the nursery store, and recording
-//    the last finalized height. Any attempts to replay an already finalized
-//    height will result in broadcasting the already finalized txn, ensuring the
-//    nursery does not broadcast different txids for the same batch of KNDR
-//    outputs. The reason txids may change is due to the probabilistic nature of
-//    generating the pkscript in the sweep txn's output, even if the set of
-//    inputs remains static across attempts.
+//    NOTE: The sweeper persists the outputs handed to it, and takes care of
+//    replacing the sweep transaction if it doesn't confirm in time. Replaying
+//    a height therefore only hands the same outputs to the sweeper once
+//    again, which it ignores as it's already sweeping them. The nursery
+//    still records the last finalized height, but no longer persists a sweep
+//    txn for it.
 //
 //  - GRAD (kidOutput) outputs are KNDR outputs that have successfully been
 //    swept into the user's wallet. A channel is considered mature once all of
@@ -184,14 +179,6 @@ type NurseryConfig struct {
 	// fully closed after incubation has concluded.
 	DB *channeldb.DB
 
-	// Estimator is used when crafting sweep transactions to estimate the
-	// necessary fee relative to the expected size of the sweep transaction.
-	Estimator lnwallet.FeeEstimator
-
-	// GenSweepScript generates a P2WKH script belonging to the wallet where
-	// funds can be swept.
-	GenSweepScript func() ([]byte, error)
-
 	// Notifier provides the utxo nursery the ability to subscribe to
 	// transaction confirmation events, which advance outputs through their
 	// persistence state transitions.
@@ -201,13 +188,14 @@ type NurseryConfig struct {
 	// transaction to the appropriate network.
 	PublishTransaction func(*wire.MsgTx) error
 
-	// Signer is used by the utxo nursery to generate valid witnesses at the
-	// time the incubated outputs need to be spent.
-	Signer lnwallet.Signer
-
 	// Store provides access to and modification of the persistent state
 	// maintained about the utxo nursery's incubating outputs.
 	Store NurseryStore
+
+	// SweepInput hands a mature output to the utxo sweeper, which sweeps
+	// it back into the wallet. The returned channel receives the result
+	// once the output has been spent.
+	SweepInput func(SpendableOutput, uint32) (chan *sweepResult, error)
 }
 
 // utxoNursery is a system dedicated to incubating time-locked outputs created
@@ -340,7 +328,7 @@ func (u *utxoNursery) Stop() error {
 func (u *utxoNursery) IncubateOutputs(
 	closeSummary *lnwallet.ForceCloseSummary) error {
 
-	nHtlcs := len(closeSummary.HtlcResolutions)
+	nHtlcs := len(closeSummary.HtlcResolutions.OutgoingHTLCs)
 
 	var (
 		commOutput  *kidOutput
@@ -369,16 +357,18 @@ func (u *utxoNursery) IncubateOutputs(
 		}
 	}
 
-	for i := range closeSummary.HtlcResolutions {
-		htlcRes := closeSummary.HtlcResolutions[i]
-
-		htlcOutpoint := &wire.OutPoint{
-			Hash:  htlcRes.SignedTimeoutTx.TxHash(),
-			Index: 0,
+	// Only the outgoing HTLC's on our commitment transaction need to be
+	// incubated, as they're claimed via a second-level timeout
+	// transaction. Incoming HTLC's are resolved by the contract court,
+	// which sweeps them once the preimage is known.
+	for i := range closeSummary.HtlcResolutions.OutgoingHTLCs {
+		htlcRes := closeSummary.HtlcResolutions.OutgoingHTLCs[i]
+		if htlcRes.SignedTimeoutTx == nil {
+			continue
 		}
 
 		htlcOutput := makeBabyOutput(
-			htlcOutpoint,
+			&htlcRes.ClaimOutpoint,
 			&closeSummary.ChanPoint,
 			closeSummary.SelfOutputMaturity,
 			lnwallet.HtlcOfferedTimeout,
@@ -617,24 +607,23 @@ func (u *utxoNursery) reloadClasses(lastGradHeight uint32) error {
 // transactions or signing are done as a result of this step.
 func (u *utxoNursery) regraduateClass(classHeight uint32) error {
 	// Fetch all information about the crib and kindergarten outputs at this
-	// height. In addition to the outputs, we also retrieve the finalized
-	// kindergarten sweep txn, which will be nil if we have not attempted
-	// this height before, or if no kindergarten outputs exist at this
 	// height.
-	finalTx, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
+	_, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
 		classHeight)
 	if err != nil {
 		return err
 	}
 
-	if finalTx != nil {
-		utxnLog.Infof("Re-registering confirmation for kindergarten "+
-			"sweep transaction at height=%d ", classHeight)
+	// The sweeper persists the outputs handed to it, so offering them
+	// once again only allows us to wait for the result of their sweep.
+	if len(kgtnOutputs) > 0 {
+		utxnLog.Infof("Re-registering sweep of kindergarten outputs "+
+			"at height=%d ", classHeight)
 
-		err = u.registerSweepConf(finalTx, kgtnOutputs, classHeight)
+		err = u.sweepGraduatingKinders(classHeight, kgtnOutputs)
 		if err != nil {
-			utxnLog.Errorf("Failed to re-register for kindergarten "+
-				"sweep transaction at height=%d: %v",
+			utxnLog.Errorf("Failed to re-register sweep of "+
+				"kindergarten outputs at height=%d: %v",
 				classHeight, err)
 			return err
 		}
@@ -719,71 +708,44 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 	u.bestHeight = classHeight
 
 	// Fetch all information about the crib and kindergarten outputs at this
-	// height. In addition to the outputs, we also retrieve the finalized
-	// kindergarten sweep txn, which will be nil if we have not attempted
-	// this height before, or if no kindergarten outputs exist at this
 	// height.
-	finalTx, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
+	_, kgtnOutputs, cribOutputs, err := u.cfg.Store.FetchClass(
 		classHeight)
 	if err != nil {
 		return err
 	}
 
-	// Load the last finalized height, so we can determine if the
-	// kindergarten sweep txn should be crafted.
+	// Hand the graduating kindergarten outputs to the sweeper, which
+	// batches them with any other outputs it's sweeping. As the sweeper
+	// persists its inputs, and ignores inputs it's already sweeping, this
+	// is safe to repeat if we're replaying this height after a restart.
+	if len(kgtnOutputs) > 0 {
+		err := u.sweepGraduatingKinders(classHeight, kgtnOutputs)
+		if err != nil {
+			utxnLog.Errorf("Failed to sweep %d kindergarten outputs "+
+				"at height=%d: %v", len(kgtnOutputs), classHeight,
+				err)
+			return err
+		}
+	}
+
+	// Load the last finalized height, so we can determine if this height
+	// should be finalized.
 	lastFinalizedHeight, err := u.cfg.Store.LastFinalizedHeight()
 	if err != nil {
 		return err
 	}
 
-	// If we haven't processed this height before, we finalize the
-	// graduating kindergarten outputs, by signing a sweep transaction that
-	// spends from them. This txn is persisted such that we never broadcast
-	// a different txn for the same height. This allows us to recover from
-	// failures, and watch for the correct txid.
+	// The sweeper crafts the sweep transaction itself, so there is no
+	// transaction to persist when finalizing the height.
 	if classHeight > lastFinalizedHeight {
-		// If this height has never been finalized, we have never
-		// generated a sweep txn for this height. Generate one if there
-		// are kindergarten outputs to be spent.
-		if len(kgtnOutputs) > 0 {
-			finalTx, err = u.createSweepTx(kgtnOutputs)
-			if err != nil {
-				utxnLog.Errorf("Failed to create sweep txn at "+
-					"height=%d", classHeight)
-				return err
-			}
-		}
-
-		// Persist the kindergarten sweep txn to the nursery store. It
-		// is safe to store a nil finalTx, which happens if there are no
-		// graduating kindergarten outputs.
-		err = u.cfg.Store.FinalizeKinder(classHeight, finalTx)
+		err = u.cfg.Store.FinalizeKinder(classHeight, nil)
 		if err != nil {
 			utxnLog.Errorf("Failed to finalize kindergarten at "+
 				"height=%d", classHeight)
 
 			return err
 		}
-
-		// Log if the finalized transaction is non-trivial.
-		if finalTx != nil {
-			utxnLog.Infof("Finalized kindergarten at height=%d ",
-				classHeight)
-		}
-	}
-
-	// Now that the kindergarten sweep txn has either been finalized or
-	// restored, broadcast the txn, and set up notifications that will
-	// transition the swept kindergarten outputs into graduated outputs.
-	if finalTx != nil {
-		err := u.sweepGraduatingKinders(classHeight, finalTx,
-			kgtnOutputs)
-		if err != nil {
-			utxnLog.Errorf("Failed to sweep %d kindergarten outputs "+
-				"at height=%d: %v", len(kgtnOutputs), classHeight,
-				err)
-			return err
-		}
 	}
 
 	// Now, we broadcast all pre-signed htlc txns from the crib outputs at
@@ -802,224 +764,51 @@ func (u *utxoNursery) graduateClass(classHeight uint32) error {
 	return u.cfg.Store.GraduateHeight(classHeight)
 }
 
-// craftSweepTx accepts accepts a list of kindergarten outputs, and signs and
-// generates a signed txn that spends from them. This method also makes an
-// accurate fee estimate before generating the required witnesses.
-func (u *utxoNursery) createSweepTx(kgtnOutputs []kidOutput) (*wire.MsgTx, error) {
-	// Create a transaction which sweeps all the newly mature outputs into
-	// a output controlled by the wallet.
-	// TODO(roasbeef): can be more intelligent about buffering outputs to
-	// be more efficient on-chain.
-
-	// Assemble the kindergarten class into a slice csv spendable outputs,
-	// while also computing an estimate for the total transaction weight.
-	var (
-		csvSpendableOutputs []CsvSpendableOutput
-		weightEstimate      lnwallet.TxWeightEstimator
-	)
-
-	// Allocate enough room for each of the kindergarten outputs.
-	csvSpendableOutputs = make([]CsvSpendableOutput, 0, len(kgtnOutputs))
+// sweepGraduatingKinders hands the graduating kindergarten outputs to the
+// sweeper, which sweeps them back into the user's wallet. A goroutine is
+// spawned that waits for the outputs to be swept, and graduates the
+// kindergarten class within the nursery store.
+func (u *utxoNursery) sweepGraduatingKinders(classHeight uint32,
+	kgtnOutputs []kidOutput) error {
 
-	// Our sweep transaction will pay to a single segwit p2wkh address,
-	// ensure it contributes to our weight estimate.
-	weightEstimate.AddP2WKHOutput()
+	utxnLog.Infof("Sweeping %v CSV-delayed outputs at height=%d",
+		len(kgtnOutputs), classHeight)
 
-	// For each kindergarten output, use its witness type to determine the
-	// estimate weight of its witness.
+	resultChans := make([]chan *sweepResult, 0, len(kgtnOutputs))
 	for i := range kgtnOutputs {
-		input := &kgtnOutputs[i]
-
-		var witnessWeight int
-		switch input.WitnessType() {
-		case lnwallet.CommitmentTimeLock:
-			witnessWeight = lnwallet.ToLocalTimeoutWitnessSize
-
-		case lnwallet.HtlcOfferedTimeout:
-			witnessWeight = lnwallet.OfferedHtlcTimeoutWitnessSize
-
-		default:
-			utxnLog.Warnf("kindergarten output in nursery store "+
-				"contains unexpected witness type: %v",
-				input.WitnessType())
-			continue
-		}
-
-		// Add the kindergarten output's input and witness to our
-		// running estimate.
-		weightEstimate.AddWitnessInput(witnessWeight)
-
-		// Include this input in the transaction.
-		csvSpendableOutputs = append(csvSpendableOutputs, input)
-	}
-
-	txWeight := uint64(weightEstimate.Weight())
-	return u.sweepCsvSpendableOutputsTxn(txWeight, csvSpendableOutputs)
-}
-
-// sweepCsvSpendableOutputsTxn creates a final sweeping transaction with all
-// witnesses in place for all inputs using the provided txn fee. The created
-// transaction has a single output sending all the funds back to the source
-// wallet, after accounting for the fee estimate.
-func (u *utxoNursery) sweepCsvSpendableOutputsTxn(txWeight uint64,
-	inputs []CsvSpendableOutput) (*wire.MsgTx, error) {
-
-	// Generate the receiving script to which the funds will be swept.
-	pkScript, err := u.cfg.GenSweepScript()
-	if err != nil {
-		return nil, err
-	}
-
-	// Sum up the total value contained in the inputs.
-	var totalSum btcutil.Amount
-	for _, o := range inputs {
-		totalSum += o.Amount()
-	}
-
-	// Using the txn weight estimate, compute the required txn fee.
-	feePerWeight, err := u.cfg.Estimator.EstimateFeePerWeight(6)
-	if err != nil {
-		return nil, err
-	}
-	txFee := btcutil.Amount(txWeight) * feePerWeight
-
-	// Sweep as much possible, after subtracting txn fees.
-	sweepAmt := int64(totalSum - txFee)
-
-	// Create the sweep transaction that we will be building. We use
-	// version 2 as it is required for CSV. The txn will sweep the amount
-	// after fees to the pkscript generated above.
-	sweepTx := wire.NewMsgTx(2)
-	sweepTx.AddTxOut(&wire.TxOut{
-		PkScript: pkScript,
-		Value:    sweepAmt,
-	})
-
-	// Add all of our inputs, including the respective CSV delays.
-	for _, input := range inputs {
-		sweepTx.AddTxIn(&wire.TxIn{
-			PreviousOutPoint: *input.OutPoint(),
-			// TODO(roasbeef): assumes pure block delays
-			Sequence: input.BlocksToMaturity(),
-		})
-	}
-
-	// Before signing the transaction, check to ensure that it meets some
-	// basic validity requirements.
-	// TODO(conner): add more control to sanity checks, allowing us to delay
-	// spending "problem" outputs, e.g. possibly batching with other classes
-	// if fees are too low.
-	btx := btcutil.NewTx(sweepTx)
-	if err := blockchain.CheckTransactionSanity(btx); err != nil {
-		return nil, err
-	}
-
-	hashCache := txscript.NewTxSigHashes(sweepTx)
-
-	// With all the inputs in place, use each output's unique witness
-	// function to generate the final witness required for spending.
-	addWitness := func(idx int, tso CsvSpendableOutput) error {
-		witness, err := tso.BuildWitness(u.cfg.Signer, sweepTx, hashCache, idx)
+		resultChan, err := u.cfg.SweepInput(&kgtnOutputs[i], 0)
 		if err != nil {
 			return err
 		}
 
-		sweepTx.TxIn[idx].Witness = witness
-
-		return nil
-	}
-
-	for i, input := range inputs {
-		if err := addWitness(i, input); err != nil {
-			return nil, err
-		}
-	}
-
-	return sweepTx, nil
-}
-
-// sweepGraduatingKinders generates and broadcasts the transaction that
-// transfers control of funds from a channel commitment transaction to the
-// user's wallet.
-func (u *utxoNursery) sweepGraduatingKinders(classHeight uint32,
-	finalTx *wire.MsgTx, kgtnOutputs []kidOutput) error {
-
-	utxnLog.Infof("Sweeping %v CSV-delayed outputs with sweep tx "+
-		"(txid=%v): %v", len(kgtnOutputs), finalTx.TxHash(),
-		newLogClosure(func() string {
-			return spew.Sdump(finalTx)
-		}),
-	)
-
-	// With the sweep transaction fully signed, broadcast the transaction
-	// to the network. Additionally, we can stop tracking these outputs as
-	// they've just been swept.
-	// TODO(conner): handle concrete error types returned from publication
-	if err := u.cfg.PublishTransaction(finalTx); err != nil &&
-		!strings.Contains(err.Error(), "TX rejected:") {
-		utxnLog.Errorf("unable to broadcast sweep tx: %v, %v",
-			err, spew.Sdump(finalTx))
-		return err
-	}
-
-	return u.registerSweepConf(finalTx, kgtnOutputs, classHeight)
-}
-
-// registerSweepConf is responsible for registering a finalized kindergarten
-// sweep transaction for confirmation notifications. If the confirmation was
-// successfully registered, a goroutine will be spawned that waits for the
-// confirmation, and graduates the provided kindergarten class within the
-// nursery store.
-func (u *utxoNursery) registerSweepConf(finalTx *wire.MsgTx,
-	kgtnOutputs []kidOutput, heightHint uint32) error {
-
-	finalTxID := finalTx.TxHash()
-
-	confChan, err := u.cfg.Notifier.RegisterConfirmationsNtfn(
-		&finalTxID, u.cfg.ConfDepth, heightHint)
-	if err != nil {
-		utxnLog.Errorf("unable to register notification for "+
-			"sweep confirmation: %v", finalTxID)
-		return err
+		resultChans = append(resultChans, resultChan)
 	}
 
-	utxnLog.Infof("Registering sweep tx %v for confs at height=%d",
-		finalTxID, heightHint)
-
 	u.wg.Add(1)
-	go u.waitForSweepConf(heightHint, kgtnOutputs, confChan)
+	go u.waitForSweepConf(classHeight, kgtnOutputs, resultChans)
 
 	return nil
 }
 
-// waitForSweepConf watches for the confirmation of a sweep transaction
-// containing a batch of kindergarten outputs. Once confirmation has been
-// received, the nursery will mark those outputs as fully graduated, and proceed
-// to mark any mature channels as fully closed in channeldb.
+// waitForSweepConf waits until each output of a batch of kindergarten outputs
+// has been swept. Once all sweeps have confirmed, the nursery will mark those
+// outputs as fully graduated, and proceed to mark any mature channels as fully
+// closed in channeldb.
 // NOTE(conner): this method MUST be called as a go routine.
 func (u *utxoNursery) waitForSweepConf(classHeight uint32,
-	kgtnOutputs []kidOutput, confChan *chainntnfs.ConfirmationEvent) {
+	kgtnOutputs []kidOutput, resultChans []chan *sweepResult) {
 
 	defer u.wg.Done()
 
-	select {
-	case _, ok := <-confChan.Confirmed:
-		if !ok {
-			utxnLog.Errorf("Notification chan closed, can't"+
-				" advance %v graduating outputs",
-				len(kgtnOutputs))
+	for i, resultChan := range resultChans {
+		if !u.waitForSweep(&kgtnOutputs[i], resultChan) {
 			return
 		}
-
-	case <-u.quit:
-		return
 	}
 
 	u.mu.Lock()
 	defer u.mu.Unlock()
 
-	// TODO(conner): add retry logic?
-
 	// Mark the confirmed kindergarten outputs as graduated.
 	if err := u.cfg.Store.GraduateKinder(classHeight); err != nil {
 		utxnLog.Errorf("Unable to graduate %v kingdergarten outputs: "+
@@ -1049,6 +838,40 @@ func (u *utxoNursery) waitForSweepConf(classHeight uint32,
 	}
 }
 
+// waitForSweep waits until the passed kindergarten output has been swept,
+// returning false if the nursery is shutting down first. If the sweeper gives
+// up on the output, it's handed to the sweeper once more, as the output can't
+// graduate until it's swept.
+func (u *utxoNursery) waitForSweep(kid *kidOutput,
+	resultChan chan *sweepResult) bool {
+
+	for {
+		select {
+		case result := <-resultChan:
+			// As only we can spend the CSV-delayed outputs, a
+			// spend by a transaction the sweeper doesn't know of
+			// is still a sweep into our wallet.
+			if result.Err == nil || result.Err == ErrRemoteSpend {
+				return true
+			}
+
+			utxnLog.Errorf("Unable to sweep kindergarten output "+
+				"%v, retrying: %v", kid.OutPoint(), result.Err)
+
+		case <-u.quit:
+			return false
+		}
+
+		var err error
+		resultChan, err = u.cfg.SweepInput(kid, 0)
+		if err != nil {
+			utxnLog.Errorf("Unable to resweep kindergarten output "+
+				"%v: %v", kid.OutPoint(), err)
+			return false
+		}
+	}
+}
+
 // sweepCribOutput broadcasts the crib output's htlc timeout txn, and sets up a
 // notification that will advance it to the kindergarten bucket upon
 // confirmation.
@@ -2,7 +2,9 @@ package walletunlocker
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/lightningnetwork/lnd/cipherseed"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/roasbeef/btcd/chaincfg"
@@ -11,13 +13,28 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 )
 
+// WalletInitMsg is a message sent to the UnlockerService when a user wishes to
+// set up the internal wallet for the first time. The user MUST provide a
+// passphrase, but is also able to provide their own cipher seed in order to
+// restore a prior wallet.
+type WalletInitMsg struct {
+	// Passphrase is the passphrase that will be used to encrypt the wallet
+	// itself. This MUST be at least 8 characters.
+	Passphrase []byte
+
+	// WalletSeed is the deciphered cipher seed that the wallet should use
+	// to initialize itself. If nil, then the wallet will generate a fresh
+	// seed of its own.
+	WalletSeed *cipherseed.CipherSeed
+}
+
 // UnlockerService implements the WalletUnlocker service used to provide lnd
 // with a password for wallet encryption at startup.
 type UnlockerService struct {
-	// CreatePasswords is a channel where passwords provided by the rpc
-	// client to be used to initially create and encrypt a wallet will be
-	// sent.
-	CreatePasswords chan []byte
+	// InitMsgs is a channel that carries all wallet init messages, which
+	// contain the password and optional seed provided by the rpc client to
+	// be used to initially create and encrypt a wallet.
+	InitMsgs chan *WalletInitMsg
 
 	// UnlockPasswords is a channel where passwords provided by the rpc
 	// client to be used to unlock and decrypt an existing wallet will be
@@ -32,16 +49,75 @@ type UnlockerService struct {
 func New(authSvc *bakery.Service, chainDir string,
 	params *chaincfg.Params) *UnlockerService {
 	return &UnlockerService{
-		CreatePasswords: make(chan []byte, 1),
+		InitMsgs:        make(chan *WalletInitMsg, 1),
 		UnlockPasswords: make(chan []byte, 1),
 		chainDir:        chainDir,
 		netParams:       params,
 	}
 }
 
-// CreateWallet will read the password provided in the CreateWalletRequest and
-// send it over the CreatePasswords channel in case no wallet already exist in
-// the chain's wallet database directory.
+// GenSeed is the first method that should be used to instantiate a new lnd
+// instance. This method allows a caller to generate a new cipher seed
+// mnemonic, which can then be passed to CreateWallet. The seed is encrypted
+// under the optional passphrase, and encodes the current time as the birthday
+// of the wallet.
+func (u *UnlockerService) GenSeed(ctx context.Context,
+	in *lnrpc.GenSeedRequest) (*lnrpc.GenSeedResponse, error) {
+
+	// Before we generate a new seed, we'll ensure that a wallet doesn't
+	// already exist, as a fresh seed would then be of no use.
+	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
+	loader := wallet.NewLoader(u.netParams, netDir, 0)
+	walletExists, err := loader.WalletExists()
+	if err != nil {
+		return nil, err
+	}
+	if walletExists {
+		return nil, fmt.Errorf("wallet already exists")
+	}
+
+	// If the user specified their own entropy, then we'll use that to
+	// generate the seed. Otherwise, fresh entropy will be generated.
+	var entropy *[cipherseed.EntropySize]byte
+	if len(in.SeedEntropy) != 0 {
+		if len(in.SeedEntropy) != cipherseed.EntropySize {
+			return nil, fmt.Errorf("seed entropy must be %v bytes, "+
+				"instead got %v", cipherseed.EntropySize,
+				len(in.SeedEntropy))
+		}
+
+		entropy = new([cipherseed.EntropySize]byte)
+		copy(entropy[:], in.SeedEntropy)
+	}
+
+	cipherSeed, err := cipherseed.New(
+		cipherseed.CipherSeedVersion, entropy, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the seed created, we'll encipher it under the passphrase, and
+	// also map it to its mnemonic so it can be written down by the user.
+	encipheredSeed, err := cipherSeed.Encipher(in.CipherSeedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := cipherSeed.ToMnemonic(in.CipherSeedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.GenSeedResponse{
+		CipherSeedMnemonic: mnemonic[:],
+		EncipheredSeed:     encipheredSeed[:],
+	}, nil
+}
+
+// CreateWallet will read the password and optional cipher seed mnemonic
+// provided in the CreateWalletRequest, and send them over the InitMsgs
+// channel in case no wallet already exist in the chain's wallet database
+// directory.
 func (u *UnlockerService) CreateWallet(ctx context.Context,
 	in *lnrpc.CreateWalletRequest) (*lnrpc.CreateWalletResponse, error) {
 
@@ -54,7 +130,7 @@ func (u *UnlockerService) CreateWallet(ctx context.Context,
 	}
 
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
-	loader := wallet.NewLoader(u.netParams, netDir)
+	loader := wallet.NewLoader(u.netParams, netDir, 0)
 
 	// Check if wallet already exists.
 	walletExists, err := loader.WalletExists()
@@ -67,9 +143,35 @@ func (u *UnlockerService) CreateWallet(ctx context.Context,
 		return nil, fmt.Errorf("wallet already exists")
 	}
 
-	// We send the password over the CreatePasswords channel, such that it
-	// can be used by lnd to open or create the wallet.
-	u.CreatePasswords <- password
+	// If the user specified a cipher seed mnemonic, then we'll attempt to
+	// decipher it using the passphrase, so the wallet is restored from
+	// the seed.
+	var cipherSeed *cipherseed.CipherSeed
+	if len(in.CipherSeedMnemonic) != 0 {
+		if len(in.CipherSeedMnemonic) != cipherseed.NumMnemonicWords {
+			return nil, fmt.Errorf("mnemonic must be exactly %v "+
+				"words, instead got %v",
+				cipherseed.NumMnemonicWords,
+				len(in.CipherSeedMnemonic))
+		}
+
+		var mnemonic cipherseed.Mnemonic
+		copy(mnemonic[:], in.CipherSeedMnemonic)
+
+		cipherSeed, err = mnemonic.ToCipherSeed(
+			in.CipherSeedPassphrase,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// We send the password and the seed over the InitMsgs channel, such
+	// that they can be used by lnd to create the wallet.
+	u.InitMsgs <- &WalletInitMsg{
+		Passphrase: password,
+		WalletSeed: cipherSeed,
+	}
 
 	return &lnrpc.CreateWalletResponse{}, nil
 }
@@ -81,7 +183,7 @@ func (u *UnlockerService) UnlockWallet(ctx context.Context,
 	in *lnrpc.UnlockWalletRequest) (*lnrpc.UnlockWalletResponse, error) {
 
 	netDir := btcwallet.NetworkDir(u.chainDir, u.netParams)
-	loader := wallet.NewLoader(u.netParams, netDir)
+	loader := wallet.NewLoader(u.netParams, netDir, 0)
 
 	// Check if wallet already exists.
 	walletExists, err := loader.WalletExists()
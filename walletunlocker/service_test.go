@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lightningnetwork/lnd/cipherseed"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/walletunlocker"
@@ -23,13 +24,22 @@ var (
 	testPassword = []byte("test-password")
 	testSeed     = []byte("test-seed-123456789")
 
+	testEntropy = [cipherseed.EntropySize]byte{
+		0x81, 0xb6, 0x37, 0xd8,
+		0x63, 0x59, 0xe6, 0x96,
+		0x0d, 0xe7, 0x95, 0xe4,
+		0x1e, 0x0b, 0x4c, 0xfd,
+	}
+
 	testNetParams = &chaincfg.MainNetParams
 )
 
 func createTestWallet(t *testing.T, dir string, netParams *chaincfg.Params) {
 	netDir := btcwallet.NetworkDir(dir, netParams)
-	loader := wallet.NewLoader(netParams, netDir)
-	_, err := loader.CreateNewWallet(testPassword, testPassword, testSeed)
+	loader := wallet.NewLoader(netParams, netDir, 0)
+	_, err := loader.CreateNewWallet(
+		testPassword, testPassword, testSeed, time.Time{},
+	)
 	if err != nil {
 		t.Fatalf("failed creating wallet: %v", err)
 	}
@@ -66,12 +76,16 @@ func TestCreateWallet(t *testing.T) {
 		t.Fatalf("CreateWallet call failed: %v", err)
 	}
 
-	// Password should be sent over the channel.
+	// Password should be sent over the channel, without a seed as none
+	// was specified.
 	select {
-	case pw := <-service.CreatePasswords:
-		if !bytes.Equal(pw, testPassword) {
+	case msg := <-service.InitMsgs:
+		if !bytes.Equal(msg.Passphrase, testPassword) {
 			t.Fatalf("expected to receive password %x, got %x",
-				testPassword, pw)
+				testPassword, msg.Passphrase)
+		}
+		if msg.WalletSeed != nil {
+			t.Fatalf("expected no wallet seed")
 		}
 	case <-time.After(3 * time.Second):
 		t.Fatalf("password not received")
@@ -147,3 +161,87 @@ func TestUnlockWallet(t *testing.T) {
 	}
 
 }
+
+// TestGenSeedCreateWallet checks that a seed generated by GenSeed can be
+// passed to CreateWallet, and that the deciphered seed is sent over the
+// channel along with the password.
+func TestGenSeedCreateWallet(t *testing.T) {
+	t.Parallel()
+
+	testDir, err := ioutil.TempDir("", "testgenseed")
+	if err != nil {
+		t.Fatalf("unable to create temp directory: %v", err)
+	}
+	defer func() {
+		os.RemoveAll(testDir)
+	}()
+
+	service := walletunlocker.New(nil, testDir, testNetParams)
+
+	// We'll start by generating a new seed using our own entropy, as we
+	// should be able to recover it from the mnemonic.
+	ctx := context.Background()
+	seedPass := []byte("kek")
+	genReq := &lnrpc.GenSeedRequest{
+		CipherSeedPassphrase: seedPass,
+		SeedEntropy:          testEntropy[:],
+	}
+	genResp, err := service.GenSeed(ctx, genReq)
+	if err != nil {
+		t.Fatalf("unable to generate seed: %v", err)
+	}
+	if len(genResp.CipherSeedMnemonic) != cipherseed.NumMnemonicWords {
+		t.Fatalf("expected %v words, instead got %v",
+			cipherseed.NumMnemonicWords,
+			len(genResp.CipherSeedMnemonic))
+	}
+
+	// Entropy of an invalid size should be rejected.
+	_, err = service.GenSeed(ctx, &lnrpc.GenSeedRequest{
+		SeedEntropy: testEntropy[:5],
+	})
+	if err == nil {
+		t.Fatalf("expected GenSeed to fail with invalid entropy")
+	}
+
+	// Creating the wallet with the wrong seed passphrase should fail.
+	createReq := &lnrpc.CreateWalletRequest{
+		Password:             testPassword,
+		CipherSeedMnemonic:   genResp.CipherSeedMnemonic,
+		CipherSeedPassphrase: []byte("wrong"),
+	}
+	if _, err := service.CreateWallet(ctx, createReq); err == nil {
+		t.Fatalf("expected CreateWallet to fail with wrong passphrase")
+	}
+
+	// With the correct passphrase, the call should succeed.
+	createReq.CipherSeedPassphrase = seedPass
+	if _, err := service.CreateWallet(ctx, createReq); err != nil {
+		t.Fatalf("CreateWallet call failed: %v", err)
+	}
+
+	// The password and the seed we generated should be sent over the
+	// channel.
+	select {
+	case msg := <-service.InitMsgs:
+		if !bytes.Equal(msg.Passphrase, testPassword) {
+			t.Fatalf("expected to receive password %x, got %x",
+				testPassword, msg.Passphrase)
+		}
+		if msg.WalletSeed == nil {
+			t.Fatalf("wallet seed not received")
+		}
+		if !bytes.Equal(msg.WalletSeed.Entropy[:], testEntropy[:]) {
+			t.Fatalf("expected entropy %x, got %x", testEntropy[:],
+				msg.WalletSeed.Entropy[:])
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("password not received")
+	}
+
+	// Once a wallet exists, we shouldn't be able to generate a new seed.
+	createTestWallet(t, testDir, testNetParams)
+	if _, err := service.GenSeed(ctx, genReq); err == nil {
+		t.Fatalf("expected GenSeed to fail as the wallet exists")
+	}
+}
@@ -0,0 +1,116 @@
+package watchtower
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// BreachHintSize is the length of the txid prefix used to identify a
+// breaching commitment transaction.
+const BreachHintSize = 16
+
+// MaxBlobSize is the maximum size of an encrypted justice transaction that a
+// tower will accept. This bounds the storage a single client can consume,
+// and ensures an encrypted blob always fits within a single brontide message.
+const MaxBlobSize = 60000
+
+var (
+	// ErrBlobTooLarge is returned when an encrypted blob exceeds the
+	// maximum size accepted by the tower.
+	ErrBlobTooLarge = errors.New("encrypted blob exceeds max size")
+
+	// ErrBlobTooSmall is returned when an encrypted blob is too small to
+	// contain the nonce and authentication tag.
+	ErrBlobTooSmall = errors.New("encrypted blob is too small")
+)
+
+// BreachHint is the first half of the txid of a revoked commitment
+// transaction. The tower indexes each encrypted blob by its hint, allowing it
+// to efficiently match the transactions within each new block against the set
+// of blobs it holds, without learning the full txid until a breach occurs.
+type BreachHint [BreachHintSize]byte
+
+// NewBreachHint derives the breach hint of the passed commitment txid.
+func NewBreachHint(txid *chainhash.Hash) BreachHint {
+	var hint BreachHint
+	copy(hint[:], txid[:BreachHintSize])
+	return hint
+}
+
+// breachKey derives the key used to encrypt the justice transaction of the
+// commitment with the passed txid. As the key is derived from the full txid,
+// the tower is only able to decrypt a blob once the breaching transaction has
+// been broadcast.
+func breachKey(txid *chainhash.Hash) [32]byte {
+	return sha256.Sum256(txid[:])
+}
+
+// EncryptJusticeTx serializes the passed fully signed justice transaction,
+// and encrypts it using a key derived from the txid of the revoked commitment
+// transaction it spends from. The resulting blob is prefixed with the random
+// nonce used for encryption.
+func EncryptJusticeTx(breachTxID *chainhash.Hash,
+	justiceTx *wire.MsgTx) ([]byte, error) {
+
+	var b bytes.Buffer
+	if err := justiceTx.Serialize(&b); err != nil {
+		return nil, err
+	}
+
+	key := breachKey(breachTxID)
+	cipher, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [chacha20poly1305.NonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, err
+	}
+
+	blob := cipher.Seal(nonce[:], nonce[:], b.Bytes(), nil)
+	if len(blob) > MaxBlobSize {
+		return nil, ErrBlobTooLarge
+	}
+
+	return blob, nil
+}
+
+// DecryptJusticeTx decrypts a blob created by EncryptJusticeTx using the txid
+// of the breaching commitment transaction, returning the justice transaction
+// within it.
+func DecryptJusticeTx(breachTxID *chainhash.Hash,
+	blob []byte) (*wire.MsgTx, error) {
+
+	if len(blob) < chacha20poly1305.NonceSize+chacha20poly1305.Overhead {
+		return nil, ErrBlobTooSmall
+	}
+
+	key := breachKey(breachTxID)
+	cipher, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := blob[:chacha20poly1305.NonceSize]
+	plaintext, err := cipher.Open(
+		nil, nonce, blob[chacha20poly1305.NonceSize:], nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	justiceTx := &wire.MsgTx{}
+	if err := justiceTx.Deserialize(bytes.NewReader(plaintext)); err != nil {
+		return nil, err
+	}
+
+	return justiceTx, nil
+}
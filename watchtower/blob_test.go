@@ -0,0 +1,90 @@
+package watchtower
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	testBreachTxID = chainhash.Hash{
+		0x51, 0xb6, 0x37, 0xd8, 0xfc, 0xd2, 0xc6, 0xda,
+		0x48, 0x59, 0xe6, 0x96, 0x31, 0x13, 0xa1, 0x17,
+		0x2d, 0xe7, 0x93, 0xe4, 0xb7, 0x25, 0xb8, 0x4d,
+		0x1f, 0xb, 0x4c, 0xf9, 0x9e, 0xc5, 0x8c, 0xe9,
+	}
+
+	testJusticeTx = &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{
+			{
+				PreviousOutPoint: wire.OutPoint{
+					Hash:  testBreachTxID,
+					Index: 1,
+				},
+				Witness: [][]byte{
+					{0x01, 0x02, 0x03},
+					{0x04, 0x05},
+				},
+			},
+		},
+		TxOut: []*wire.TxOut{
+			{
+				Value:    100000,
+				PkScript: bytes.Repeat([]byte{0x00}, 22),
+			},
+		},
+	}
+)
+
+// TestJusticeTxEncryption asserts that an encrypted justice transaction can
+// only be decrypted using the txid of the breach transaction it was encrypted
+// with.
+func TestJusticeTxEncryption(t *testing.T) {
+	t.Parallel()
+
+	blob, err := EncryptJusticeTx(&testBreachTxID, testJusticeTx)
+	if err != nil {
+		t.Fatalf("unable to encrypt justice tx: %v", err)
+	}
+
+	justiceTx, err := DecryptJusticeTx(&testBreachTxID, blob)
+	if err != nil {
+		t.Fatalf("unable to decrypt justice tx: %v", err)
+	}
+	var expected, decrypted bytes.Buffer
+	if err := testJusticeTx.Serialize(&expected); err != nil {
+		t.Fatalf("unable to serialize justice tx: %v", err)
+	}
+	if err := justiceTx.Serialize(&decrypted); err != nil {
+		t.Fatalf("unable to serialize justice tx: %v", err)
+	}
+	if !bytes.Equal(expected.Bytes(), decrypted.Bytes()) {
+		t.Fatalf("justice tx mismatch: expected %v, got %v",
+			spew.Sdump(testJusticeTx), spew.Sdump(justiceTx))
+	}
+
+	// A different txid that shares the same breach hint shouldn't be able
+	// to decrypt the blob.
+	otherTxID := testBreachTxID
+	otherTxID[chainhash.HashSize-1] ^= 0x01
+	if NewBreachHint(&otherTxID) != NewBreachHint(&testBreachTxID) {
+		t.Fatalf("breach hints should match")
+	}
+	if _, err := DecryptJusticeTx(&otherTxID, blob); err == nil {
+		t.Fatalf("blob decrypted with incorrect txid")
+	}
+
+	// A blob that has been tampered with should also fail to decrypt.
+	blob[len(blob)-1] ^= 0x01
+	if _, err := DecryptJusticeTx(&testBreachTxID, blob); err == nil {
+		t.Fatalf("tampered blob decrypted")
+	}
+
+	if _, err := DecryptJusticeTx(&testBreachTxID, blob[:10]); err != ErrBlobTooSmall {
+		t.Fatalf("expected ErrBlobTooSmall, got %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+package watchtower
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// minRetryBackoff is the initial delay before the client attempts to
+	// reconnect to the tower after a failure.
+	minRetryBackoff = 5 * time.Second
+
+	// maxRetryBackoff is the maximum delay between attempts to reconnect
+	// to the tower.
+	maxRetryBackoff = 5 * time.Minute
+)
+
+// ClientConfig houses the parameters of a watchtower client.
+type ClientConfig struct {
+	// NodeKeyECDH is the static key used to authenticate the brontide
+	// connection with the tower.
+	NodeKeyECDH brontide.SingleKeyECDH
+
+	// TowerAddr is the address and identity of the tower that the client
+	// backs up its revoked states to.
+	TowerAddr *lnwire.NetAddress
+
+	// Dial connects to the given address over the passed network. This
+	// allows the connection to the tower to be routed through Tor.
+	Dial func(network, address string) (net.Conn, error)
+
+	// DB persists the updates that have yet to be acknowledged by the
+	// tower.
+	DB ClientDB
+}
+
+// Client is a watchtower client. Each time the remote party of one of our
+// channels revokes a state, the fully signed justice transaction for that
+// state is encrypted and uploaded to the tower. Should the revoked state ever
+// be broadcast, the tower is then able to dispatch justice on our behalf,
+// even if we're offline.
+type Client struct {
+	started uint32
+	stopped uint32
+
+	cfg *ClientConfig
+
+	// pendingUpdates is the queue of updates that have yet to be
+	// acknowledged by the tower. It mirrors the queue within the
+	// client's database.
+	pendingUpdates []*QueuedUpdate
+	updateMtx      sync.Mutex
+
+	// newUpdates is signalled each time a new update is queued.
+	newUpdates chan struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewClient creates a new watchtower client backed by the passed config.
+func NewClient(cfg *ClientConfig) *Client {
+	return &Client{
+		cfg:        cfg,
+		newUpdates: make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that uploads queued updates to the tower,
+// starting with any updates that weren't acknowledged before the client was
+// last stopped.
+func (c *Client) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting watchtower client, tower=%v", c.cfg.TowerAddr)
+
+	// The database holds every update queued so far, including any
+	// queued before we were started, so it replaces the in-memory queue.
+	c.updateMtx.Lock()
+	queued, err := c.cfg.DB.FetchQueuedUpdates()
+	if err != nil {
+		c.updateMtx.Unlock()
+		return err
+	}
+	c.pendingUpdates = queued
+	c.updateMtx.Unlock()
+
+	if len(queued) != 0 {
+		log.Infof("Resuming upload of %v queued updates to tower %v",
+			len(queued), c.cfg.TowerAddr)
+	}
+
+	c.wg.Add(1)
+	go c.backupHandler()
+
+	return nil
+}
+
+// Stop signals the client to exit, and waits for it to do so. Any updates
+// that have yet to be acknowledged by the tower remain queued within the
+// database, and are uploaded once the client is restarted.
+func (c *Client) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping watchtower client")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// BackupState encrypts the passed justice transaction, which spends from the
+// revoked commitment transaction with the passed txid, and durably queues it
+// for upload to the tower.
+func (c *Client) BackupState(breachTxID *chainhash.Hash,
+	justiceTx *wire.MsgTx) error {
+
+	blob, err := EncryptJusticeTx(breachTxID, justiceTx)
+	if err != nil {
+		return err
+	}
+
+	update := &StateUpdate{
+		Hint:          NewBreachHint(breachTxID),
+		EncryptedBlob: blob,
+	}
+
+	c.updateMtx.Lock()
+	seqNum, err := c.cfg.DB.QueueUpdate(update)
+	if err != nil {
+		c.updateMtx.Unlock()
+		return err
+	}
+	c.pendingUpdates = append(c.pendingUpdates, &QueuedUpdate{
+		SeqNum:      seqNum,
+		StateUpdate: update,
+	})
+	c.updateMtx.Unlock()
+
+	select {
+	case c.newUpdates <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// nextUpdate returns the oldest update which has yet to be acknowledged by the
+// tower, or nil if there are none.
+func (c *Client) nextUpdate() *QueuedUpdate {
+	c.updateMtx.Lock()
+	defer c.updateMtx.Unlock()
+
+	if len(c.pendingUpdates) == 0 {
+		return nil
+	}
+
+	return c.pendingUpdates[0]
+}
+
+// popUpdate removes the oldest update from the queue, once it has been
+// acknowledged by the tower. The update is removed from the in-memory queue
+// even if it can't be removed from the database, in which case it's uploaded
+// once more after a restart, replacing the identical blob at the tower.
+func (c *Client) popUpdate() error {
+	c.updateMtx.Lock()
+	defer c.updateMtx.Unlock()
+
+	update := c.pendingUpdates[0]
+	c.pendingUpdates[0] = nil
+	c.pendingUpdates = c.pendingUpdates[1:]
+
+	return c.cfg.DB.AckUpdate(update.SeqNum)
+}
+
+// backupHandler uploads queued updates to the tower in order, maintaining a
+// connection to the tower while there are updates to send. If the tower
+// can't be reached, the upload is retried with an exponential backoff.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *Client) backupHandler() {
+	defer c.wg.Done()
+
+	var (
+		conn    *brontide.Conn
+		backoff = minRetryBackoff
+	)
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for {
+		update := c.nextUpdate()
+		if update == nil {
+			// With no updates left to send, we'll close our
+			// connection until the next update is queued.
+			if conn != nil {
+				conn.Close()
+				conn = nil
+			}
+
+			select {
+			case <-c.newUpdates:
+				continue
+			case <-c.quit:
+				return
+			}
+		}
+
+		var err error
+		if conn == nil {
+			conn, err = brontide.Dial(
+				c.cfg.NodeKeyECDH, c.cfg.TowerAddr, c.cfg.Dial,
+			)
+		}
+		if err == nil {
+			err = c.sendUpdate(conn, update.StateUpdate)
+		}
+		if err != nil {
+			log.Errorf("unable to backup state to tower %v, "+
+				"retrying in %v: %v", c.cfg.TowerAddr, backoff,
+				err)
+
+			if conn != nil {
+				conn.Close()
+				conn = nil
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-c.quit:
+				return
+			}
+
+			backoff *= 2
+			if backoff > maxRetryBackoff {
+				backoff = maxRetryBackoff
+			}
+
+			continue
+		}
+
+		backoff = minRetryBackoff
+		if err := c.popUpdate(); err != nil {
+			log.Errorf("unable to remove update with hint=%x from "+
+				"queue: %v", update.Hint[:], err)
+		}
+	}
+}
+
+// sendUpdate sends the passed update to the tower, and waits for the tower to
+// acknowledge it. If the tower rejects the update, then it won't be retried.
+func (c *Client) sendUpdate(conn *brontide.Conn, update *StateUpdate) error {
+	if err := WriteMessage(conn, update); err != nil {
+		return err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	replyBytes, err := conn.ReadNextMessage()
+	if err != nil {
+		return err
+	}
+
+	msg, err := ReadMessage(replyBytes)
+	if err != nil {
+		return err
+	}
+
+	reply, ok := msg.(*StateUpdateReply)
+	if !ok {
+		return fmt.Errorf("unexpected message %v from tower",
+			msg.MsgType())
+	}
+	if reply.Hint != update.Hint {
+		return fmt.Errorf("reply for hint=%x doesn't match update "+
+			"with hint=%x", reply.Hint[:], update.Hint[:])
+	}
+
+	if reply.Code != CodeOK {
+		log.Errorf("tower %v rejected update for hint=%x: %v",
+			c.cfg.TowerAddr, update.Hint[:], reply.Code)
+		return nil
+	}
+
+	log.Debugf("Backed up state with hint=%x to tower %v",
+		update.Hint[:], c.cfg.TowerAddr)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+var (
+	// clientUpdatesBucket is the top-level bucket that houses the queue of
+	// state updates the client has yet to upload to its tower, keyed by
+	// their sequence number.
+	clientUpdatesBucket = []byte("watchtower-client-updates")
+)
+
+// QueuedUpdate is a state update within the queue of a watchtower client.
+type QueuedUpdate struct {
+	// SeqNum is the sequence number of the update within the queue.
+	// Updates are uploaded in the order of their sequence numbers.
+	SeqNum uint64
+
+	*StateUpdate
+}
+
+// ClientDB is the persistent storage of a watchtower client. It houses the
+// queue of state updates that have yet to be acknowledged by the tower, so
+// they're uploaded even if the client is restarted in the meantime.
+type ClientDB interface {
+	// QueueUpdate appends the passed update to the end of the queue,
+	// returning its sequence number.
+	QueueUpdate(update *StateUpdate) (uint64, error)
+
+	// FetchQueuedUpdates returns all updates within the queue, ordered by
+	// their sequence number.
+	FetchQueuedUpdates() ([]*QueuedUpdate, error)
+
+	// AckUpdate removes the update with the passed sequence number from
+	// the queue, once it has been acknowledged by the tower.
+	AckUpdate(seqNum uint64) error
+}
+
+// boltClientDB is an implementation of the ClientDB interface backed by the
+// channel database.
+type boltClientDB struct {
+	db *channeldb.DB
+}
+
+// NewBoltClientDB returns a new ClientDB backed by the passed channel
+// database.
+func NewBoltClientDB(db *channeldb.DB) ClientDB {
+	return &boltClientDB{db: db}
+}
+
+// A compile time check to ensure boltClientDB meets the ClientDB interface.
+var _ ClientDB = (*boltClientDB)(nil)
+
+// QueueUpdate appends the passed update to the end of the queue, returning
+// its sequence number.
+//
+// NOTE: Part of the ClientDB interface.
+func (b *boltClientDB) QueueUpdate(update *StateUpdate) (uint64, error) {
+	var updateBytes bytes.Buffer
+	if err := update.Encode(&updateBytes); err != nil {
+		return 0, err
+	}
+
+	var seqNum uint64
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		updates, err := tx.CreateBucketIfNotExists(clientUpdatesBucket)
+		if err != nil {
+			return err
+		}
+
+		seqNum, err = updates.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqKey [8]byte
+		binary.BigEndian.PutUint64(seqKey[:], seqNum)
+
+		return updates.Put(seqKey[:], updateBytes.Bytes())
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return seqNum, nil
+}
+
+// FetchQueuedUpdates returns all updates within the queue, ordered by their
+// sequence number.
+//
+// NOTE: Part of the ClientDB interface.
+func (b *boltClientDB) FetchQueuedUpdates() ([]*QueuedUpdate, error) {
+	var queued []*QueuedUpdate
+	err := b.db.View(func(tx *bolt.Tx) error {
+		updates := tx.Bucket(clientUpdatesBucket)
+		if updates == nil {
+			return nil
+		}
+
+		// As the sequence numbers are stored big endian, the cursor
+		// visits the updates in the order they were queued.
+		return updates.ForEach(func(k, v []byte) error {
+			update := &StateUpdate{}
+			if err := update.Decode(bytes.NewReader(v)); err != nil {
+				return err
+			}
+
+			queued = append(queued, &QueuedUpdate{
+				SeqNum:      binary.BigEndian.Uint64(k),
+				StateUpdate: update,
+			})
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return queued, nil
+}
+
+// AckUpdate removes the update with the passed sequence number from the
+// queue.
+//
+// NOTE: Part of the ClientDB interface.
+func (b *boltClientDB) AckUpdate(seqNum uint64) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		updates := tx.Bucket(clientUpdatesBucket)
+		if updates == nil {
+			return nil
+		}
+
+		var seqKey [8]byte
+		binary.BigEndian.PutUint64(seqKey[:], seqNum)
+
+		return updates.Delete(seqKey[:])
+	})
+}
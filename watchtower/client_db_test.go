@@ -0,0 +1,140 @@
+package watchtower
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestClientDBQueue asserts that queued updates are returned in the order
+// they were queued, until they've been acknowledged.
+func TestClientDBQueue(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	clientDB := NewBoltClientDB(db)
+
+	queued, err := clientDB.FetchQueuedUpdates()
+	if err != nil {
+		t.Fatalf("unable to fetch queued updates: %v", err)
+	}
+	if len(queued) != 0 {
+		t.Fatalf("expected empty queue, instead got %v updates",
+			len(queued))
+	}
+
+	var (
+		updates []*StateUpdate
+		seqNums []uint64
+	)
+	for i := 0; i < 3; i++ {
+		update := &StateUpdate{
+			Hint:          BreachHint{byte(i)},
+			EncryptedBlob: bytes.Repeat([]byte{byte(i)}, 100),
+		}
+		seqNum, err := clientDB.QueueUpdate(update)
+		if err != nil {
+			t.Fatalf("unable to queue update: %v", err)
+		}
+
+		updates = append(updates, update)
+		seqNums = append(seqNums, seqNum)
+	}
+
+	// Once the update in the middle has been acknowledged, only the first
+	// and last should remain, in order.
+	if err := clientDB.AckUpdate(seqNums[1]); err != nil {
+		t.Fatalf("unable to ack update: %v", err)
+	}
+
+	queued, err = clientDB.FetchQueuedUpdates()
+	if err != nil {
+		t.Fatalf("unable to fetch queued updates: %v", err)
+	}
+	if len(queued) != 2 {
+		t.Fatalf("expected 2 updates, instead got %v", len(queued))
+	}
+	for i, idx := range []int{0, 2} {
+		if queued[i].SeqNum != seqNums[idx] {
+			t.Fatalf("update #%v: expected seq num %v, got %v", i,
+				seqNums[idx], queued[i].SeqNum)
+		}
+		if queued[i].Hint != updates[idx].Hint ||
+			!bytes.Equal(queued[i].EncryptedBlob,
+				updates[idx].EncryptedBlob) {
+
+			t.Fatalf("update #%v doesn't match", i)
+		}
+	}
+}
+
+// TestClientResumesQueuedUpdates asserts that updates which weren't uploaded
+// before the client was stopped are uploaded once it's restarted.
+func TestClientResumesQueuedUpdates(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	// The tower is unreachable, so no update will ever be acknowledged.
+	cfg := &ClientConfig{
+		NodeKeyECDH: &brontide.PrivKeyECDH{PrivKey: priv},
+		TowerAddr: &lnwire.NetAddress{
+			IdentityKey: priv.PubKey(),
+			Address:     &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		},
+		Dial: func(string, string) (net.Conn, error) {
+			return nil, fmt.Errorf("tower unreachable")
+		},
+		DB: NewBoltClientDB(db),
+	}
+
+	client := NewClient(cfg)
+	if err := client.Start(); err != nil {
+		t.Fatalf("unable to start client: %v", err)
+	}
+
+	breachTxIDs := []chainhash.Hash{testBreachTxID, {0x01}}
+	for _, breachTxID := range breachTxIDs {
+		err := client.BackupState(&breachTxID, testJusticeTx)
+		if err != nil {
+			t.Fatalf("unable to backup state: %v", err)
+		}
+	}
+	client.Stop()
+
+	// A restarted client should pick up both updates, in the order they
+	// were queued.
+	client = NewClient(cfg)
+	if err := client.Start(); err != nil {
+		t.Fatalf("unable to start client: %v", err)
+	}
+	defer client.Stop()
+
+	client.updateMtx.Lock()
+	pending := client.pendingUpdates
+	client.updateMtx.Unlock()
+
+	if len(pending) != len(breachTxIDs) {
+		t.Fatalf("expected %v pending updates, instead got %v",
+			len(breachTxIDs), len(pending))
+	}
+	for i, breachTxID := range breachTxIDs {
+		if pending[i].Hint != NewBreachHint(&breachTxID) {
+			t.Fatalf("update #%v has wrong hint", i)
+		}
+	}
+}
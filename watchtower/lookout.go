@@ -0,0 +1,199 @@
+package watchtower
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// LookoutConfig houses all the interfaces and closures the lookout needs in
+// order to watch the chain for breaches.
+type LookoutConfig struct {
+	// DB is the tower's database of encrypted blobs.
+	DB TowerDB
+
+	// Notifier is used to receive a notification for each new block.
+	Notifier chainntnfs.ChainNotifier
+
+	// ChainIO is used to fetch the full contents of each new block.
+	ChainIO lnwallet.BlockChainIO
+
+	// PublishTx broadcasts a decrypted justice transaction to the
+	// network.
+	PublishTx func(*wire.MsgTx) error
+}
+
+// lookout scans each new block for a transaction whose breach hint matches an
+// encrypted blob held by the tower. Upon a match, the blob is decrypted using
+// the txid of the breaching transaction, and the justice transaction within
+// it is broadcast.
+type lookout struct {
+	started uint32
+	stopped uint32
+
+	cfg *LookoutConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newLookout creates a new lookout backed by the passed config.
+func newLookout(cfg *LookoutConfig) *lookout {
+	return &lookout{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that watches for new blocks.
+func (l *lookout) Start() error {
+	if !atomic.CompareAndSwapUint32(&l.started, 0, 1) {
+		return nil
+	}
+
+	blockEpochs, err := l.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	l.wg.Add(1)
+	go l.watchBlocks(blockEpochs)
+
+	return nil
+}
+
+// Stop signals the lookout to exit, and waits for it to do so.
+func (l *lookout) Stop() error {
+	if !atomic.CompareAndSwapUint32(&l.stopped, 0, 1) {
+		return nil
+	}
+
+	close(l.quit)
+	l.wg.Wait()
+
+	return nil
+}
+
+// watchBlocks processes each new block as it's connected to the main chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (l *lookout) watchBlocks(blockEpochs *chainntnfs.BlockEpochEvent) {
+	defer l.wg.Done()
+	defer blockEpochs.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-blockEpochs.Epochs:
+			if !ok {
+				return
+			}
+
+			l.processBlock(epoch)
+
+			// With any new breaches queued, we'll attempt to
+			// broadcast each justice transaction that hasn't gone
+			// out yet. Those that fail will be retried on the
+			// next block.
+			l.dispatchPendingJustice()
+
+		case <-l.quit:
+			return
+		}
+	}
+}
+
+// processBlock checks each transaction within the passed block against the
+// set of blobs held by the tower, queueing the justice transaction of any
+// matches to be broadcast.
+func (l *lookout) processBlock(epoch *chainntnfs.BlockEpoch) {
+	block, err := l.cfg.ChainIO.GetBlock(epoch.Hash)
+	if err != nil {
+		log.Errorf("unable to fetch block %v: %v", epoch.Hash, err)
+		return
+	}
+
+	log.Debugf("Scanning block %v at height=%v for breaches",
+		epoch.Hash, epoch.Height)
+
+	for _, tx := range block.Transactions {
+		txid := tx.TxHash()
+		hint := NewBreachHint(&txid)
+
+		blobs, err := l.cfg.DB.LookupBlobs(hint)
+		switch {
+		case err == ErrBlobNotFound:
+			continue
+		case err != nil:
+			log.Errorf("unable to lookup blobs for hint=%x: %v",
+				hint[:], err)
+			continue
+		}
+
+		// Several clients may have uploaded a blob under the same
+		// hint, so we'll attempt to decrypt each of them.
+		for _, clientBlob := range blobs {
+			l.queueJustice(&txid, hint, clientBlob)
+		}
+	}
+}
+
+// queueJustice attempts to decrypt the passed blob using the txid of the
+// breaching transaction, and adds the justice transaction within it to the
+// set of transactions to be broadcast.
+func (l *lookout) queueJustice(txid *chainhash.Hash, hint BreachHint,
+	clientBlob *ClientBlob) {
+
+	// As the hint only covers half of the txid, a match may be a false
+	// positive, in which case we'll be unable to decrypt the blob.
+	justiceTx, err := DecryptJusticeTx(txid, clientBlob.Blob)
+	if err != nil {
+		log.Debugf("unable to decrypt blob for hint=%x: %v",
+			hint[:], err)
+		return
+	}
+
+	log.Infof("Breach detected within txid=%v, queueing justice "+
+		"txid=%v", txid, justiceTx.TxHash())
+
+	err = l.cfg.DB.AddPendingJustice(&PendingJustice{
+		Hint:      hint,
+		ClientPub: clientBlob.ClientPub,
+		JusticeTx: justiceTx,
+	})
+	if err != nil {
+		log.Errorf("unable to queue justice tx %v: %v",
+			justiceTx.TxHash(), err)
+	}
+}
+
+// dispatchPendingJustice attempts to broadcast each justice transaction that
+// hasn't gone out yet. Once a justice transaction has been broadcast, it's
+// removed from the queue along with the blob it was decrypted from.
+func (l *lookout) dispatchPendingJustice() {
+	pending, err := l.cfg.DB.FetchPendingJustice()
+	if err != nil {
+		log.Errorf("unable to fetch pending justice txns: %v", err)
+		return
+	}
+
+	for _, p := range pending {
+		justiceTxid := p.JusticeTx.TxHash()
+
+		log.Infof("Broadcasting justice txid=%v", justiceTxid)
+
+		if err := l.cfg.PublishTx(p.JusticeTx); err != nil {
+			log.Errorf("unable to broadcast justice tx %v, will "+
+				"retry on next block: %v", justiceTxid, err)
+			continue
+		}
+
+		if err := l.cfg.DB.RemovePendingJustice(p); err != nil {
+			log.Errorf("unable to remove justice tx %v: %v",
+				justiceTxid, err)
+		}
+	}
+}
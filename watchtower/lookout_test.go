@@ -0,0 +1,167 @@
+package watchtower
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockChainIO is a mock implementation of the BlockChainIO interface that
+// serves a single block.
+type mockChainIO struct {
+	block *wire.MsgBlock
+}
+
+func (m *mockChainIO) GetBestBlock() (*chainhash.Hash, int32, error) {
+	return nil, 0, fmt.Errorf("not implemented")
+}
+
+func (m *mockChainIO) GetUtxo(op *wire.OutPoint,
+	heightHint uint32) (*wire.TxOut, error) {
+
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockChainIO) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockChainIO) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock,
+	error) {
+
+	return m.block, nil
+}
+
+// TestLookoutRetriesJustice asserts that a justice transaction that fails to
+// be broadcast is retried, and that only the blobs whose justice transaction
+// actually went out are removed.
+func TestLookoutRetriesJustice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	towerDB := NewBoltTowerDB(db)
+
+	priv1, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	priv2, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	client1, client2 := priv1.PubKey(), priv2.PubKey()
+
+	for _, clientPub := range []*btcec.PublicKey{client1, client2} {
+		err := towerDB.CreateSession(
+			clientPub, DefaultMaxSessionUpdates,
+		)
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+	}
+
+	// Both clients back up a justice transaction for the same breaching
+	// transaction.
+	breachTx := &wire.MsgTx{
+		Version: 2,
+		TxIn: []*wire.TxIn{
+			{PreviousOutPoint: wire.OutPoint{Index: 2}},
+		},
+	}
+	breachTxID := breachTx.TxHash()
+	hint := NewBreachHint(&breachTxID)
+
+	justiceTx1 := testJusticeTx.Copy()
+	justiceTx2 := testJusticeTx.Copy()
+	justiceTx2.LockTime = 1
+
+	blob1, err := EncryptJusticeTx(&breachTxID, justiceTx1)
+	if err != nil {
+		t.Fatalf("unable to encrypt justice tx: %v", err)
+	}
+	blob2, err := EncryptJusticeTx(&breachTxID, justiceTx2)
+	if err != nil {
+		t.Fatalf("unable to encrypt justice tx: %v", err)
+	}
+	if err := towerDB.InsertBlob(hint, client1, blob1); err != nil {
+		t.Fatalf("unable to insert blob: %v", err)
+	}
+	if err := towerDB.InsertBlob(hint, client2, blob2); err != nil {
+		t.Fatalf("unable to insert blob: %v", err)
+	}
+
+	// Only the transactions marked as publishable will be broadcast.
+	publishable := make(map[chainhash.Hash]bool)
+	published := make(map[chainhash.Hash]int)
+	l := newLookout(&LookoutConfig{
+		DB: towerDB,
+		ChainIO: &mockChainIO{
+			block: &wire.MsgBlock{
+				Transactions: []*wire.MsgTx{breachTx},
+			},
+		},
+		PublishTx: func(tx *wire.MsgTx) error {
+			txid := tx.TxHash()
+			published[txid]++
+			if !publishable[txid] {
+				return fmt.Errorf("unable to publish")
+			}
+			return nil
+		},
+	})
+
+	assertPending := func(expected int) {
+		pending, err := towerDB.FetchPendingJustice()
+		if err != nil {
+			t.Fatalf("unable to fetch pending justice: %v", err)
+		}
+		if len(pending) != expected {
+			t.Fatalf("expected %v pending justice txns, instead "+
+				"got %v", expected, len(pending))
+		}
+	}
+
+	// The breach is detected, but neither justice transaction can be
+	// broadcast. Both should remain queued, and both blobs retained.
+	l.processBlock(&chainntnfs.BlockEpoch{Hash: &chainhash.Hash{}})
+	l.dispatchPendingJustice()
+
+	assertPending(2)
+	assertBlobs(t, towerDB, hint, blob1, blob2)
+
+	// On the next block, only the justice transaction of the first
+	// client can be broadcast. Only its blob should be removed.
+	justiceTxid1, justiceTxid2 := justiceTx1.TxHash(), justiceTx2.TxHash()
+	publishable[justiceTxid1] = true
+	l.dispatchPendingJustice()
+
+	assertPending(1)
+	assertBlobs(t, towerDB, hint, blob2)
+
+	// Once the second justice transaction can be broadcast as well,
+	// nothing should remain.
+	publishable[justiceTxid2] = true
+	l.dispatchPendingJustice()
+
+	assertPending(0)
+	if _, err := towerDB.LookupBlobs(hint); err != ErrBlobNotFound {
+		t.Fatalf("expected ErrBlobNotFound, instead got %v", err)
+	}
+
+	// The first justice transaction should have been broadcast only
+	// until it went out.
+	if published[justiceTxid1] != 2 {
+		t.Fatalf("expected 2 broadcasts of first justice tx, "+
+			"instead got %v", published[justiceTxid1])
+	}
+	if published[justiceTxid2] != 3 {
+		t.Fatalf("expected 3 broadcasts of second justice tx, "+
+			"instead got %v", published[justiceTxid2])
+	}
+}
@@ -0,0 +1,241 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MessageType is the unique 2 byte big-endian integer that indicates the type
+// of message on the wire between a watchtower client and server.
+type MessageType uint16
+
+const (
+	// MsgStateUpdate is the type of a message sent by a client in order
+	// to back up the justice transaction of a newly revoked state.
+	MsgStateUpdate MessageType = 1
+
+	// MsgStateUpdateReply is the type of the tower's response to a
+	// StateUpdate.
+	MsgStateUpdateReply MessageType = 2
+)
+
+// String returns a human readable description of the message type.
+func (t MessageType) String() string {
+	switch t {
+	case MsgStateUpdate:
+		return "StateUpdate"
+	case MsgStateUpdateReply:
+		return "StateUpdateReply"
+	default:
+		return "<unknown>"
+	}
+}
+
+// ReplyCode is the status code returned by the tower in response to a
+// StateUpdate.
+type ReplyCode uint16
+
+const (
+	// CodeOK indicates that the tower has durably stored the blob.
+	CodeOK ReplyCode = 0
+
+	// CodeBlobTooLarge indicates that the blob exceeded the maximum size
+	// accepted by the tower.
+	CodeBlobTooLarge ReplyCode = 1
+
+	// CodeInternalError indicates that the tower was unable to store the
+	// blob.
+	CodeInternalError ReplyCode = 2
+
+	// CodeSessionExhausted indicates that the client has used up all the
+	// updates of its session, so the blob wasn't stored.
+	CodeSessionExhausted ReplyCode = 3
+)
+
+// String returns a human readable description of the reply code.
+func (c ReplyCode) String() string {
+	switch c {
+	case CodeOK:
+		return "CodeOK"
+	case CodeBlobTooLarge:
+		return "CodeBlobTooLarge"
+	case CodeInternalError:
+		return "CodeInternalError"
+	case CodeSessionExhausted:
+		return "CodeSessionExhausted"
+	default:
+		return fmt.Sprintf("<unknown code %d>", uint16(c))
+	}
+}
+
+// Message is an interface that defines a watchtower wire protocol message.
+// Each message is sent within a single brontide message, prefixed by its
+// type.
+type Message interface {
+	// Decode reads the bytes stream and converts it to the object.
+	Decode(io.Reader) error
+
+	// Encode converts object to the bytes stream and write it into the
+	// writer.
+	Encode(io.Writer) error
+
+	// MsgType returns the integer uniquely identifying this message type
+	// on the wire.
+	MsgType() MessageType
+}
+
+// StateUpdate is sent by a client to back up the encrypted justice
+// transaction of a revoked commitment transaction.
+type StateUpdate struct {
+	// Hint is the breach hint of the revoked commitment transaction.
+	Hint BreachHint
+
+	// EncryptedBlob is the justice transaction, encrypted using a key
+	// derived from the txid of the revoked commitment transaction.
+	EncryptedBlob []byte
+}
+
+// A compile time check to ensure StateUpdate implements the Message
+// interface.
+var _ Message = (*StateUpdate)(nil)
+
+// Decode deserializes a serialized StateUpdate message stored in the passed
+// io.Reader.
+//
+// This is part of the Message interface.
+func (s *StateUpdate) Decode(r io.Reader) error {
+	if _, err := io.ReadFull(r, s.Hint[:]); err != nil {
+		return err
+	}
+
+	var blobLen uint16
+	if err := binary.Read(r, binary.BigEndian, &blobLen); err != nil {
+		return err
+	}
+	if blobLen > MaxBlobSize {
+		return ErrBlobTooLarge
+	}
+
+	s.EncryptedBlob = make([]byte, blobLen)
+	_, err := io.ReadFull(r, s.EncryptedBlob)
+	return err
+}
+
+// Encode serializes the target StateUpdate into the passed io.Writer.
+//
+// This is part of the Message interface.
+func (s *StateUpdate) Encode(w io.Writer) error {
+	if len(s.EncryptedBlob) > MaxBlobSize {
+		return ErrBlobTooLarge
+	}
+
+	if _, err := w.Write(s.Hint[:]); err != nil {
+		return err
+	}
+
+	blobLen := uint16(len(s.EncryptedBlob))
+	if err := binary.Write(w, binary.BigEndian, blobLen); err != nil {
+		return err
+	}
+
+	_, err := w.Write(s.EncryptedBlob)
+	return err
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the Message interface.
+func (s *StateUpdate) MsgType() MessageType {
+	return MsgStateUpdate
+}
+
+// StateUpdateReply is sent by the tower in response to a StateUpdate.
+type StateUpdateReply struct {
+	// Hint is the breach hint of the StateUpdate being replied to.
+	Hint BreachHint
+
+	// Code indicates whether the tower accepted the StateUpdate.
+	Code ReplyCode
+}
+
+// A compile time check to ensure StateUpdateReply implements the Message
+// interface.
+var _ Message = (*StateUpdateReply)(nil)
+
+// Decode deserializes a serialized StateUpdateReply message stored in the
+// passed io.Reader.
+//
+// This is part of the Message interface.
+func (s *StateUpdateReply) Decode(r io.Reader) error {
+	if _, err := io.ReadFull(r, s.Hint[:]); err != nil {
+		return err
+	}
+
+	return binary.Read(r, binary.BigEndian, &s.Code)
+}
+
+// Encode serializes the target StateUpdateReply into the passed io.Writer.
+//
+// This is part of the Message interface.
+func (s *StateUpdateReply) Encode(w io.Writer) error {
+	if _, err := w.Write(s.Hint[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, binary.BigEndian, s.Code)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the Message interface.
+func (s *StateUpdateReply) MsgType() MessageType {
+	return MsgStateUpdateReply
+}
+
+// WriteMessage writes the passed message, prefixed by its type, to the
+// target writer. As each call to Write on a brontide connection results in a
+// single encrypted message, the message is first fully serialized.
+func WriteMessage(w io.Writer, msg Message) error {
+	var b bytes.Buffer
+	msgType := msg.MsgType()
+	if err := binary.Write(&b, binary.BigEndian, msgType); err != nil {
+		return err
+	}
+	if err := msg.Encode(&b); err != nil {
+		return err
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// ReadMessage parses a single serialized message, prefixed by its type, from
+// the passed buffer.
+func ReadMessage(msgBytes []byte) (Message, error) {
+	r := bytes.NewReader(msgBytes)
+
+	var msgType MessageType
+	if err := binary.Read(r, binary.BigEndian, &msgType); err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	switch msgType {
+	case MsgStateUpdate:
+		msg = &StateUpdate{}
+	case MsgStateUpdateReply:
+		msg = &StateUpdateReply{}
+	default:
+		return nil, fmt.Errorf("unknown message type: %v", msgType)
+	}
+
+	if err := msg.Decode(r); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,58 @@
+package watchtower
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestMessageEncodeDecode asserts that each watchtower message survives a
+// round trip through WriteMessage and ReadMessage.
+func TestMessageEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	hint := NewBreachHint(&testBreachTxID)
+	msgs := []Message{
+		&StateUpdate{
+			Hint:          hint,
+			EncryptedBlob: bytes.Repeat([]byte{0xaa}, 512),
+		},
+		&StateUpdateReply{
+			Hint: hint,
+			Code: CodeBlobTooLarge,
+		},
+	}
+
+	for _, msg := range msgs {
+		var b bytes.Buffer
+		if err := WriteMessage(&b, msg); err != nil {
+			t.Fatalf("unable to write %v: %v", msg.MsgType(), err)
+		}
+
+		decoded, err := ReadMessage(b.Bytes())
+		if err != nil {
+			t.Fatalf("unable to read %v: %v", msg.MsgType(), err)
+		}
+		if !reflect.DeepEqual(msg, decoded) {
+			t.Fatalf("message mismatch: expected %v, got %v",
+				spew.Sdump(msg), spew.Sdump(decoded))
+		}
+	}
+}
+
+// TestStateUpdateMaxBlobSize asserts that a StateUpdate with a blob exceeding
+// MaxBlobSize can't be encoded.
+func TestStateUpdateMaxBlobSize(t *testing.T) {
+	t.Parallel()
+
+	update := &StateUpdate{
+		EncryptedBlob: make([]byte, MaxBlobSize+1),
+	}
+
+	var b bytes.Buffer
+	if err := WriteMessage(&b, update); err != ErrBlobTooLarge {
+		t.Fatalf("expected ErrBlobTooLarge, got %v", err)
+	}
+}
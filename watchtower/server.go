@@ -0,0 +1,269 @@
+package watchtower
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// readTimeout is the amount of time the server will wait for the next
+// message from a client before closing the connection.
+const readTimeout = 5 * time.Minute
+
+// DefaultMaxSessionUpdates is the default number of updates a client may store
+// at the tower. Along with MaxBlobSize, this bounds the storage a single
+// client can consume.
+const DefaultMaxSessionUpdates = 1024
+
+// ServerConfig houses all the interfaces and closures the watchtower server
+// needs in order to accept encrypted blobs from clients, and dispatch justice
+// upon a breach.
+type ServerConfig struct {
+	// NodeKeyECDH is the static key used to authenticate brontide
+	// connections with the tower's clients.
+	NodeKeyECDH brontide.SingleKeyECDH
+
+	// ListenAddrs is the set of addresses the tower will accept client
+	// connections on.
+	ListenAddrs []string
+
+	// DB is the tower's database of encrypted blobs.
+	DB TowerDB
+
+	// MaxSessionUpdates is the maximum number of updates the session of a
+	// newly connected client allows it to store. Any further updates are
+	// rejected.
+	MaxSessionUpdates uint16
+
+	// Notifier is used to receive a notification for each new block.
+	Notifier chainntnfs.ChainNotifier
+
+	// ChainIO is used to fetch the full contents of each new block.
+	ChainIO lnwallet.BlockChainIO
+
+	// PublishTx broadcasts a decrypted justice transaction to the
+	// network.
+	PublishTx func(*wire.MsgTx) error
+}
+
+// Server is a watchtower server. It accepts encrypted justice transactions
+// from its clients over brontide connections, and watches the chain on their
+// behalf, dispatching justice for any breach of their channels, even while
+// the clients themselves are offline. Each client is granted a session upon
+// its first connection, which bounds the number of updates it may store.
+type Server struct {
+	started uint32
+	stopped uint32
+
+	cfg *ServerConfig
+
+	lookout *lookout
+
+	listeners []net.Listener
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewServer creates a new watchtower server backed by the passed config.
+func NewServer(cfg *ServerConfig) (*Server, error) {
+	listeners := make([]net.Listener, 0, len(cfg.ListenAddrs))
+	for _, addr := range cfg.ListenAddrs {
+		listener, err := brontide.NewListener(cfg.NodeKeyECDH, addr)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, err
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return &Server{
+		cfg: cfg,
+		lookout: newLookout(&LookoutConfig{
+			DB:        cfg.DB,
+			Notifier:  cfg.Notifier,
+			ChainIO:   cfg.ChainIO,
+			PublishTx: cfg.PublishTx,
+		}),
+		listeners: listeners,
+		quit:      make(chan struct{}),
+	}, nil
+}
+
+// Start begins accepting client connections, and watching the chain for
+// breaches.
+func (s *Server) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Starting watchtower server")
+
+	if err := s.lookout.Start(); err != nil {
+		return err
+	}
+
+	for _, listener := range s.listeners {
+		log.Infof("Watchtower listening on %v", listener.Addr())
+
+		s.wg.Add(1)
+		go s.acceptConnections(listener)
+	}
+
+	return nil
+}
+
+// Stop closes all listeners, and waits for all active connections to be
+// handled.
+func (s *Server) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	log.Infof("Stopping watchtower server")
+
+	close(s.quit)
+	for _, listener := range s.listeners {
+		listener.Close()
+	}
+	s.wg.Wait()
+
+	return s.lookout.Stop()
+}
+
+// acceptConnections accepts new client connections on the passed listener
+// until the server is shutting down.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *Server) acceptConnections(listener net.Listener) {
+	defer s.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+
+			log.Errorf("unable to accept connection: %v", err)
+			continue
+		}
+
+		brontideConn, ok := conn.(*brontide.Conn)
+		if !ok {
+			conn.Close()
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.handleClient(brontideConn)
+	}
+}
+
+// handleClient reads each StateUpdate sent by the client, and responds once
+// the blob within it has been stored.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *Server) handleClient(conn *brontide.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	clientPub := conn.RemotePub().SerializeCompressed()
+	log.Debugf("Accepted watchtower client %x", clientPub)
+
+	// If this is the first time the client connects, we'll grant it a
+	// fresh session. Otherwise, it continues to use up its existing one.
+	err := s.cfg.DB.CreateSession(conn.RemotePub(), s.cfg.MaxSessionUpdates)
+	if err != nil {
+		log.Errorf("unable to create session for client %x: %v",
+			clientPub, err)
+		return
+	}
+
+	// We'll close the connection once the server is shutting down, which
+	// will unblock any pending read.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-s.quit:
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		msgBytes, err := conn.ReadNextMessage()
+		if err != nil {
+			log.Debugf("Watchtower client %x disconnected: %v",
+				clientPub, err)
+			return
+		}
+
+		msg, err := ReadMessage(msgBytes)
+		if err != nil {
+			log.Errorf("unable to read message from client %x: %v",
+				clientPub, err)
+			return
+		}
+
+		update, ok := msg.(*StateUpdate)
+		if !ok {
+			log.Errorf("unexpected message %v from client %x",
+				msg.MsgType(), clientPub)
+			return
+		}
+
+		reply := &StateUpdateReply{
+			Hint: update.Hint,
+			Code: s.handleStateUpdate(conn.RemotePub(), update),
+		}
+		if err := WriteMessage(conn, reply); err != nil {
+			log.Errorf("unable to send reply to client %x: %v",
+				clientPub, err)
+			return
+		}
+	}
+}
+
+// handleStateUpdate stores the blob within the passed StateUpdate on behalf of
+// the client with the passed public key, returning the code that should be
+// sent to the client in reply.
+func (s *Server) handleStateUpdate(clientPub *btcec.PublicKey,
+	update *StateUpdate) ReplyCode {
+
+	if len(update.EncryptedBlob) > MaxBlobSize {
+		return CodeBlobTooLarge
+	}
+
+	err := s.cfg.DB.InsertBlob(update.Hint, clientPub, update.EncryptedBlob)
+	switch {
+	case err == ErrSessionExhausted:
+		log.Debugf("Rejecting blob for hint=%x, client %x has no "+
+			"updates left", update.Hint[:],
+			clientPub.SerializeCompressed())
+		return CodeSessionExhausted
+
+	case err != nil:
+		log.Errorf("unable to store blob for hint=%x: %v",
+			update.Hint[:], err)
+		return CodeInternalError
+	}
+
+	log.Tracef("Stored blob for hint=%x", update.Hint[:])
+
+	return CodeOK
+}
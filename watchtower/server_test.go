@@ -0,0 +1,76 @@
+package watchtower
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestServerSessionUpdateLimit asserts that the server rejects blobs that are
+// too large, as well as any update sent once the client's session has been
+// used up.
+func TestServerSessionUpdateLimit(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	const maxUpdates = 2
+	s := &Server{
+		cfg: &ServerConfig{
+			DB:                NewBoltTowerDB(db),
+			MaxSessionUpdates: maxUpdates,
+		},
+	}
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	clientPub := priv.PubKey()
+
+	err = s.cfg.DB.CreateSession(clientPub, s.cfg.MaxSessionUpdates)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		blob []byte
+		code ReplyCode
+	}{
+		{
+			name: "blob too large",
+			blob: make([]byte, MaxBlobSize+1),
+			code: CodeBlobTooLarge,
+		},
+		{
+			name: "first update",
+			blob: []byte("first"),
+			code: CodeOK,
+		},
+		{
+			name: "second update",
+			blob: []byte("second"),
+			code: CodeOK,
+		},
+		{
+			name: "session exhausted",
+			blob: []byte("third"),
+			code: CodeSessionExhausted,
+		},
+	}
+
+	for i, test := range tests {
+		update := &StateUpdate{
+			Hint:          BreachHint{byte(i)},
+			EncryptedBlob: test.blob,
+		}
+
+		code := s.handleStateUpdate(clientPub, update)
+		if code != test.code {
+			t.Fatalf("%v: expected %v, instead got %v", test.name,
+				test.code, code)
+		}
+	}
+}
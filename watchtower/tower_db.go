@@ -0,0 +1,384 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// blobBucket is the top-level bucket that houses all the encrypted
+	// blobs the tower has accepted. It holds a sub-bucket for each breach
+	// hint, which stores the blob of each client that uploaded one for
+	// the hint, keyed by the client's public key.
+	blobBucket = []byte("watchtower-blobs")
+
+	// pendingJusticeBucket is the top-level bucket that houses the
+	// justice transactions the tower has decrypted, but has yet to
+	// successfully broadcast, keyed by their txid.
+	pendingJusticeBucket = []byte("watchtower-pending-justice")
+
+	// sessionBucket is the top-level bucket that houses the session of
+	// each client, keyed by the client's public key.
+	sessionBucket = []byte("watchtower-sessions")
+
+	// ErrBlobNotFound is returned when no blob is stored for a breach
+	// hint.
+	ErrBlobNotFound = errors.New("blob not found")
+
+	// ErrSessionNotFound is returned when a client attempts to store a
+	// blob without a session.
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrSessionExhausted is returned when a client attempts to store a
+	// blob after it has used up all the updates of its session.
+	ErrSessionExhausted = errors.New("session has no updates left")
+)
+
+// Session tracks the number of updates a client has stored at the tower,
+// bounding the storage a single client can consume.
+type Session struct {
+	// MaxUpdates is the maximum number of updates the client may store.
+	MaxUpdates uint16
+
+	// NumUpdates is the number of updates the client has stored so far.
+	NumUpdates uint16
+}
+
+// ClientBlob is an encrypted blob stored by the tower on behalf of one of its
+// clients.
+type ClientBlob struct {
+	// ClientPub is the public key of the client that uploaded the blob.
+	ClientPub *btcec.PublicKey
+
+	// Blob is the encrypted justice transaction.
+	Blob []byte
+}
+
+// PendingJustice is a justice transaction the tower has decrypted upon a
+// breach, but has yet to successfully broadcast.
+type PendingJustice struct {
+	// Hint is the breach hint of the blob the justice transaction was
+	// decrypted from.
+	Hint BreachHint
+
+	// ClientPub is the public key of the client that uploaded the blob
+	// the justice transaction was decrypted from.
+	ClientPub *btcec.PublicKey
+
+	// JusticeTx is the fully signed justice transaction.
+	JusticeTx *wire.MsgTx
+}
+
+// TowerDB is the persistent storage of a watchtower server. It houses the
+// encrypted justice transactions uploaded by the tower's clients.
+type TowerDB interface {
+	// CreateSession creates a session for the client with the passed
+	// public key, allowing it to store up to maxUpdates blobs. If the
+	// client already has a session, it's left untouched.
+	CreateSession(clientPub *btcec.PublicKey, maxUpdates uint16) error
+
+	// InsertBlob stores the passed encrypted blob under its breach hint,
+	// on behalf of the client with the passed public key. If the client
+	// already stored a blob under the hint, it's overwritten. Blobs of
+	// other clients are never affected. Each call consumes one update of
+	// the client's session, and ErrSessionExhausted is returned once
+	// none are left.
+	InsertBlob(hint BreachHint, clientPub *btcec.PublicKey,
+		blob []byte) error
+
+	// LookupBlobs returns the encrypted blobs stored under the passed
+	// breach hint by all clients. If no such blob exists,
+	// ErrBlobNotFound is returned.
+	LookupBlobs(hint BreachHint) ([]*ClientBlob, error)
+
+	// AddPendingJustice adds the passed justice transaction to the set of
+	// transactions that have yet to be broadcast. Adding the same
+	// justice transaction twice is a noop.
+	AddPendingJustice(pending *PendingJustice) error
+
+	// FetchPendingJustice returns all justice transactions that have yet
+	// to be broadcast.
+	FetchPendingJustice() ([]*PendingJustice, error)
+
+	// RemovePendingJustice removes the passed justice transaction from
+	// the set of transactions that have yet to be broadcast, along with
+	// the blob it was decrypted from. Blobs uploaded by other clients
+	// under the same hint are left untouched.
+	RemovePendingJustice(pending *PendingJustice) error
+}
+
+// boltTowerDB is an implementation of the TowerDB interface backed by the
+// channel database.
+type boltTowerDB struct {
+	db *channeldb.DB
+}
+
+// NewBoltTowerDB returns a new TowerDB backed by the passed channel database.
+func NewBoltTowerDB(db *channeldb.DB) TowerDB {
+	return &boltTowerDB{db: db}
+}
+
+// A compile time check to ensure boltTowerDB meets the TowerDB interface.
+var _ TowerDB = (*boltTowerDB)(nil)
+
+// CreateSession creates a session for the client with the passed public key,
+// allowing it to store up to maxUpdates blobs.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) CreateSession(clientPub *btcec.PublicKey,
+	maxUpdates uint16) error {
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		sessions, err := tx.CreateBucketIfNotExists(sessionBucket)
+		if err != nil {
+			return err
+		}
+
+		sessionKey := clientPub.SerializeCompressed()
+		if sessions.Get(sessionKey) != nil {
+			return nil
+		}
+
+		return putSession(sessions, sessionKey, &Session{
+			MaxUpdates: maxUpdates,
+		})
+	})
+}
+
+// InsertBlob stores the passed encrypted blob under its breach hint, on
+// behalf of the client with the passed public key.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) InsertBlob(hint BreachHint, clientPub *btcec.PublicKey,
+	blob []byte) error {
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		sessions := tx.Bucket(sessionBucket)
+		if sessions == nil {
+			return ErrSessionNotFound
+		}
+
+		sessionKey := clientPub.SerializeCompressed()
+		sessionBytes := sessions.Get(sessionKey)
+		if sessionBytes == nil {
+			return ErrSessionNotFound
+		}
+
+		session := &Session{}
+		r := bytes.NewReader(sessionBytes)
+		err := binary.Read(r, binary.BigEndian, session)
+		if err != nil {
+			return err
+		}
+		if session.NumUpdates >= session.MaxUpdates {
+			return ErrSessionExhausted
+		}
+
+		blobs, err := tx.CreateBucketIfNotExists(blobBucket)
+		if err != nil {
+			return err
+		}
+
+		hintBlobs, err := blobs.CreateBucketIfNotExists(hint[:])
+		if err != nil {
+			return err
+		}
+
+		if err := hintBlobs.Put(sessionKey, blob); err != nil {
+			return err
+		}
+
+		session.NumUpdates++
+		return putSession(sessions, sessionKey, session)
+	})
+}
+
+// putSession writes the passed session to the sessions bucket under the
+// passed key.
+func putSession(sessions *bolt.Bucket, sessionKey []byte,
+	session *Session) error {
+
+	var sessionBytes bytes.Buffer
+	err := binary.Write(&sessionBytes, binary.BigEndian, session)
+	if err != nil {
+		return err
+	}
+
+	return sessions.Put(sessionKey, sessionBytes.Bytes())
+}
+
+// LookupBlobs returns the encrypted blobs stored under the passed breach hint
+// by all clients.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) LookupBlobs(hint BreachHint) ([]*ClientBlob, error) {
+	var hintBlobs []*ClientBlob
+	err := b.db.View(func(tx *bolt.Tx) error {
+		blobs := tx.Bucket(blobBucket)
+		if blobs == nil {
+			return ErrBlobNotFound
+		}
+
+		clientBlobs := blobs.Bucket(hint[:])
+		if clientBlobs == nil {
+			return ErrBlobNotFound
+		}
+
+		return clientBlobs.ForEach(func(k, blobBytes []byte) error {
+			clientPub, err := btcec.ParsePubKey(k, btcec.S256())
+			if err != nil {
+				return err
+			}
+
+			// The returned bytes are only valid for the lifetime
+			// of the transaction, so we'll make a copy.
+			blob := make([]byte, len(blobBytes))
+			copy(blob, blobBytes)
+
+			hintBlobs = append(hintBlobs, &ClientBlob{
+				ClientPub: clientPub,
+				Blob:      blob,
+			})
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hintBlobs) == 0 {
+		return nil, ErrBlobNotFound
+	}
+
+	return hintBlobs, nil
+}
+
+// AddPendingJustice adds the passed justice transaction to the set of
+// transactions that have yet to be broadcast.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) AddPendingJustice(pending *PendingJustice) error {
+	var pendingBytes bytes.Buffer
+	if _, err := pendingBytes.Write(pending.Hint[:]); err != nil {
+		return err
+	}
+	clientPub := pending.ClientPub.SerializeCompressed()
+	if _, err := pendingBytes.Write(clientPub); err != nil {
+		return err
+	}
+	if err := pending.JusticeTx.Serialize(&pendingBytes); err != nil {
+		return err
+	}
+
+	justiceTxid := pending.JusticeTx.TxHash()
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		pendingJustice, err := tx.CreateBucketIfNotExists(
+			pendingJusticeBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		return pendingJustice.Put(justiceTxid[:], pendingBytes.Bytes())
+	})
+}
+
+// FetchPendingJustice returns all justice transactions that have yet to be
+// broadcast.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) FetchPendingJustice() ([]*PendingJustice, error) {
+	var pending []*PendingJustice
+	err := b.db.View(func(tx *bolt.Tx) error {
+		pendingJustice := tx.Bucket(pendingJusticeBucket)
+		if pendingJustice == nil {
+			return nil
+		}
+
+		return pendingJustice.ForEach(func(_, v []byte) error {
+			p := &PendingJustice{}
+			r := bytes.NewReader(v)
+
+			if _, err := io.ReadFull(r, p.Hint[:]); err != nil {
+				return err
+			}
+
+			var clientPub [btcec.PubKeyBytesLenCompressed]byte
+			if _, err := io.ReadFull(r, clientPub[:]); err != nil {
+				return err
+			}
+			var err error
+			p.ClientPub, err = btcec.ParsePubKey(
+				clientPub[:], btcec.S256(),
+			)
+			if err != nil {
+				return err
+			}
+
+			p.JusticeTx = &wire.MsgTx{}
+			if err := p.JusticeTx.Deserialize(r); err != nil {
+				return err
+			}
+
+			pending = append(pending, p)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// RemovePendingJustice removes the passed justice transaction from the set of
+// transactions that have yet to be broadcast, along with the blob it was
+// decrypted from.
+//
+// NOTE: Part of the TowerDB interface.
+func (b *boltTowerDB) RemovePendingJustice(pending *PendingJustice) error {
+	justiceTxid := pending.JusticeTx.TxHash()
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		pendingJustice := tx.Bucket(pendingJusticeBucket)
+		if pendingJustice != nil {
+			err := pendingJustice.Delete(justiceTxid[:])
+			if err != nil {
+				return err
+			}
+		}
+
+		blobs := tx.Bucket(blobBucket)
+		if blobs == nil {
+			return nil
+		}
+
+		clientBlobs := blobs.Bucket(pending.Hint[:])
+		if clientBlobs == nil {
+			return nil
+		}
+
+		clientPub := pending.ClientPub.SerializeCompressed()
+		if err := clientBlobs.Delete(clientPub); err != nil {
+			return err
+		}
+
+		// If this was the last blob stored under the hint, we'll
+		// remove the hint altogether.
+		if k, _ := clientBlobs.Cursor().First(); k != nil {
+			return nil
+		}
+
+		return blobs.DeleteBucket(pending.Hint[:])
+	})
+}
@@ -0,0 +1,250 @@
+package watchtower
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// makeTestDB creates a new channel database within a temporary directory,
+// along with a closure that removes it once the test is done.
+func makeTestDB(t *testing.T) (*channeldb.DB, func()) {
+	tempDirName, err := ioutil.TempDir("", "towerdb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	db, err := channeldb.Open(tempDirName)
+	if err != nil {
+		os.RemoveAll(tempDirName)
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	cleanUp := func() {
+		db.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	return db, cleanUp
+}
+
+// assertBlobs asserts that exactly the expected blobs are stored under the
+// passed hint, in any order.
+func assertBlobs(t *testing.T, towerDB TowerDB, hint BreachHint,
+	expected ...[]byte) {
+
+	blobs, err := towerDB.LookupBlobs(hint)
+	if err != nil {
+		t.Fatalf("unable to lookup blobs: %v", err)
+	}
+	if len(blobs) != len(expected) {
+		t.Fatalf("expected %v blobs, instead got %v", len(expected),
+			len(blobs))
+	}
+
+	for _, exp := range expected {
+		var found bool
+		for _, blob := range blobs {
+			if bytes.Equal(blob.Blob, exp) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("blob %x not found", exp)
+		}
+	}
+}
+
+// TestTowerDBClientBlobs asserts that the blobs of distinct clients are
+// stored side by side, such that one client can't overwrite the blob that
+// another client uploaded under the same breach hint.
+func TestTowerDBClientBlobs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	towerDB := NewBoltTowerDB(db)
+
+	priv1, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	priv2, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	client1, client2 := priv1.PubKey(), priv2.PubKey()
+
+	for _, clientPub := range []*btcec.PublicKey{client1, client2} {
+		err := towerDB.CreateSession(
+			clientPub, DefaultMaxSessionUpdates,
+		)
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+	}
+
+	hint := NewBreachHint(&testBreachTxID)
+	if _, err := towerDB.LookupBlobs(hint); err != ErrBlobNotFound {
+		t.Fatalf("expected ErrBlobNotFound, instead got %v", err)
+	}
+
+	blob1 := []byte("first client blob")
+	if err := towerDB.InsertBlob(hint, client1, blob1); err != nil {
+		t.Fatalf("unable to insert blob: %v", err)
+	}
+
+	// The second client uploads a blob under the same hint. The blob of
+	// the first client must still be there.
+	blob2 := []byte("second client blob")
+	if err := towerDB.InsertBlob(hint, client2, blob2); err != nil {
+		t.Fatalf("unable to insert blob: %v", err)
+	}
+	assertBlobs(t, towerDB, hint, blob1, blob2)
+
+	// A client may replace its own blob though.
+	blob2 = []byte("replaced second client blob")
+	if err := towerDB.InsertBlob(hint, client2, blob2); err != nil {
+		t.Fatalf("unable to insert blob: %v", err)
+	}
+	assertBlobs(t, towerDB, hint, blob1, blob2)
+
+	// Once the justice transaction of the second client has been
+	// broadcast, only its blob is removed.
+	pending := &PendingJustice{
+		Hint:      hint,
+		ClientPub: client2,
+		JusticeTx: testJusticeTx,
+	}
+	if err := towerDB.AddPendingJustice(pending); err != nil {
+		t.Fatalf("unable to add pending justice: %v", err)
+	}
+	if err := towerDB.RemovePendingJustice(pending); err != nil {
+		t.Fatalf("unable to remove pending justice: %v", err)
+	}
+	assertBlobs(t, towerDB, hint, blob1)
+
+	// With the last blob removed, the hint is gone altogether.
+	pending.ClientPub = client1
+	if err := towerDB.RemovePendingJustice(pending); err != nil {
+		t.Fatalf("unable to remove pending justice: %v", err)
+	}
+	if _, err := towerDB.LookupBlobs(hint); err != ErrBlobNotFound {
+		t.Fatalf("expected ErrBlobNotFound, instead got %v", err)
+	}
+}
+
+// TestTowerDBPendingJustice asserts that pending justice transactions are
+// persisted until they're removed.
+func TestTowerDBPendingJustice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	towerDB := NewBoltTowerDB(db)
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	pending := &PendingJustice{
+		Hint:      NewBreachHint(&testBreachTxID),
+		ClientPub: priv.PubKey(),
+		JusticeTx: testJusticeTx,
+	}
+
+	// Adding the same justice transaction twice should only queue it
+	// once.
+	for i := 0; i < 2; i++ {
+		if err := towerDB.AddPendingJustice(pending); err != nil {
+			t.Fatalf("unable to add pending justice: %v", err)
+		}
+	}
+
+	fetched, err := towerDB.FetchPendingJustice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending justice: %v", err)
+	}
+	if len(fetched) != 1 {
+		t.Fatalf("expected 1 pending justice tx, instead got %v",
+			len(fetched))
+	}
+	if fetched[0].Hint != pending.Hint ||
+		!fetched[0].ClientPub.IsEqual(pending.ClientPub) ||
+		fetched[0].JusticeTx.TxHash() != pending.JusticeTx.TxHash() {
+
+		t.Fatalf("pending justice mismatch: expected %v, got %v",
+			spew.Sdump(pending), spew.Sdump(fetched[0]))
+	}
+
+	if err := towerDB.RemovePendingJustice(pending); err != nil {
+		t.Fatalf("unable to remove pending justice: %v", err)
+	}
+	fetched, err = towerDB.FetchPendingJustice()
+	if err != nil {
+		t.Fatalf("unable to fetch pending justice: %v", err)
+	}
+	if len(fetched) != 0 {
+		t.Fatalf("expected no pending justice txns, instead got %v",
+			len(fetched))
+	}
+}
+
+// TestTowerDBSessionLimit asserts that a client can't store more blobs than
+// its session allows, and that reconnecting doesn't grant it a fresh session.
+func TestTowerDBSessionLimit(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp := makeTestDB(t)
+	defer cleanUp()
+
+	towerDB := NewBoltTowerDB(db)
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	clientPub := priv.PubKey()
+
+	// Without a session, the client can't store anything.
+	hint := NewBreachHint(&testBreachTxID)
+	blob := []byte("client blob")
+	err = towerDB.InsertBlob(hint, clientPub, blob)
+	if err != ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, instead got %v", err)
+	}
+
+	const maxUpdates = 3
+	if err := towerDB.CreateSession(clientPub, maxUpdates); err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+
+	for i := 0; i < maxUpdates; i++ {
+		err := towerDB.InsertBlob(BreachHint{byte(i)}, clientPub, blob)
+		if err != nil {
+			t.Fatalf("unable to insert blob #%v: %v", i, err)
+		}
+	}
+
+	// Creating the session once more should leave the exhausted session
+	// untouched, so any further blob is rejected.
+	if err := towerDB.CreateSession(clientPub, maxUpdates); err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	err = towerDB.InsertBlob(hint, clientPub, blob)
+	if err != ErrSessionExhausted {
+		t.Fatalf("expected ErrSessionExhausted, instead got %v", err)
+	}
+	if _, err := towerDB.LookupBlobs(hint); err != ErrBlobNotFound {
+		t.Fatalf("expected ErrBlobNotFound, instead got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// preimageBeacon is an implementation of the contractcourt.PreimageDB
+// interface. It unifies the two sources of preimages known to the daemon: the
+// invoices we've created, and the preimages we've learned of when settling an
+// outgoing HTLC.
+type preimageBeacon struct {
+	invoices *invoiceRegistry
+
+	chanDB *channeldb.DB
+}
+
+// LookupPreimage attempts to look up the preimage for the target payment
+// hash. If the preimage is known, then it's returned along with a true value.
+//
+// NOTE: Part of the contractcourt.PreimageDB interface.
+func (p *preimageBeacon) LookupPreimage(payHash [32]byte) ([]byte, bool) {
+	// First, we'll check our set of invoices, as the HTLC may be paying to
+	// us directly.
+	invoice, err := p.invoices.LookupInvoice(chainhash.Hash(payHash))
+	if err == nil {
+		preimage := invoice.Terms.PaymentPreimage
+		return preimage[:], true
+	}
+
+	// Otherwise, we may have learned of the preimage when forwarding the
+	// HTLC, so we'll consult the preimage cache.
+	preimage, err := p.chanDB.LookupPreimage(payHash)
+	if err != nil {
+		return nil, false
+	}
+
+	return preimage, true
+}
+
+// AddPreimage adds a newly found preimage to the preimage cache.
+//
+// NOTE: Part of the contractcourt.PreimageDB interface.
+func (p *preimageBeacon) AddPreimage(preimage []byte) error {
+	ltndLog.Infof("Adding preimage=%x to witness cache", preimage)
+
+	return p.chanDB.AddPreimage(preimage)
+}
+
+// A compile time assertion to ensure preimageBeacon meets the
+// contractcourt.PreimageDB interface.
+var _ contractcourt.PreimageDB = (*preimageBeacon)(nil)
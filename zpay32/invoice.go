@@ -64,6 +64,10 @@ const (
 
 	// fieldTypeC contains an optional requested final CLTV delta.
 	fieldTypeC = 24
+
+	// fieldType9 contains the set of features supported by the recipient
+	// of the payment.
+	fieldType9 = 5
 )
 
 // MessageSigner is passed to the Encode method to provide a signature
@@ -137,10 +141,18 @@ type Invoice struct {
 	// Optional.
 	FallbackAddr btcutil.Address
 
-	// RoutingInfo is one or more entries containing extra routing
-	// information for a private route to the target node.
+	// RouteHints is a set of private routes to the target node. Each route
+	// is made up of one or more entries containing extra routing
+	// information for a private channel along the route, and is encoded
+	// within its own field.
+	// Optional.
+	RouteHints [][]ExtraRoutingInfo
+
+	// Features is the set of features the recipient of the payment
+	// supports, such as being able to receive a payment over multiple
+	// routes.
 	// Optional.
-	RoutingInfo []ExtraRoutingInfo
+	Features *lnwire.RawFeatureVector
 }
 
 // ExtraRoutingInfo holds the information needed to route a payment along one
@@ -152,8 +164,13 @@ type ExtraRoutingInfo struct {
 	// ShortChanID is the channel ID of the channel.
 	ShortChanID uint64
 
-	// Fee is the fee required for routing along this channel.
-	Fee uint64
+	// FeeBaseMsat is the base fee in millisatoshis required for routing
+	// along this channel.
+	FeeBaseMsat uint32
+
+	// FeeProportionalMillionths is the fee rate, in millionths of a
+	// satoshi, required for routing along this channel.
+	FeeProportionalMillionths uint32
 
 	// CltvExpDelta is this channel's cltv expiry delta.
 	CltvExpDelta uint16
@@ -222,12 +239,21 @@ func FallbackAddr(fallbackAddr btcutil.Address) func(*Invoice) {
 	}
 }
 
-// RoutingInfo is a functional option that allows callers of NewInvoice to set
-// one or more entries containing extra routing information for a private route
-// to the target node.
-func RoutingInfo(routingInfo []ExtraRoutingInfo) func(*Invoice) {
+// RouteHint is a functional option that allows callers of NewInvoice to add
+// a private route to the target node, made up of one or more entries
+// containing extra routing information. The option may be passed multiple
+// times to add several routes.
+func RouteHint(routingInfo []ExtraRoutingInfo) func(*Invoice) {
+	return func(i *Invoice) {
+		i.RouteHints = append(i.RouteHints, routingInfo)
+	}
+}
+
+// Features is a functional option that allows callers of NewInvoice to set
+// the feature bits the recipient of the payment supports.
+func Features(features *lnwire.RawFeatureVector) func(*Invoice) {
 	return func(i *Invoice) {
-		i.RoutingInfo = routingInfo
+		i.Features = features
 	}
 }
 
@@ -518,10 +544,12 @@ func validateInvoice(invoice *Invoice) error {
 		return fmt.Errorf("neither description nor description hash set")
 	}
 
-	// Can have at most 20 extra hops for routing.
-	if len(invoice.RoutingInfo) > 20 {
-		return fmt.Errorf("too many extra hops: %d",
-			len(invoice.RoutingInfo))
+	// Each route can have at most 20 extra hops for routing.
+	for _, routingInfo := range invoice.RouteHints {
+		if len(routingInfo) > 20 {
+			return fmt.Errorf("too many extra hops: %d",
+				len(routingInfo))
+		}
 	}
 
 	// Check that we support the field lengths.
@@ -759,18 +787,15 @@ func parseTaggedFields(invoice *Invoice, fields []byte, net *chaincfg.Params) er
 			}
 			invoice.FallbackAddr = addr
 		case fieldTypeR:
-			if invoice.RoutingInfo != nil {
-				// We skip the field if we have already seen a
-				// supported one.
-				continue
-			}
-
+			// Each field holds a single route, so unlike the
+			// other fields, we'll accept multiple of them.
 			base256Data, err := bech32.ConvertBits(base32Data, 5, 8,
 				false)
 			if err != nil {
 				return err
 			}
 
+			var routingInfo []ExtraRoutingInfo
 			for len(base256Data) > 0 {
 				info := ExtraRoutingInfo{}
 				info.PubKey, err = btcec.ParsePubKey(
@@ -780,14 +805,32 @@ func parseTaggedFields(invoice *Invoice, fields []byte, net *chaincfg.Params) er
 				}
 				info.ShortChanID = binary.BigEndian.Uint64(
 					base256Data[33:41])
-				info.Fee = binary.BigEndian.Uint64(
-					base256Data[41:49])
+				info.FeeBaseMsat = binary.BigEndian.Uint32(
+					base256Data[41:45])
+				info.FeeProportionalMillionths = binary.BigEndian.Uint32(
+					base256Data[45:49])
 				info.CltvExpDelta = binary.BigEndian.Uint16(
 					base256Data[49:51])
-				invoice.RoutingInfo = append(
-					invoice.RoutingInfo, info)
+				routingInfo = append(routingInfo, info)
 				base256Data = base256Data[51:]
 			}
+			invoice.RouteHints = append(
+				invoice.RouteHints, routingInfo)
+		case fieldType9:
+			if invoice.Features != nil {
+				// We skip the field if we have already seen a
+				// supported one.
+				continue
+			}
+
+			features := lnwire.NewRawFeatureVector()
+			err := features.DecodeBase32(
+				bytes.NewReader(base32Data), len(base32Data),
+			)
+			if err != nil {
+				return err
+			}
+			invoice.Features = features
 		default:
 			// Ignore unknown type.
 		}
@@ -892,14 +935,17 @@ func writeTaggedFields(bufferBase32 *bytes.Buffer, invoice *Invoice) error {
 		}
 	}
 
-	if len(invoice.RoutingInfo) > 0 {
+	for _, routingInfo := range invoice.RouteHints {
 		// Each extra routing info is encoded using 51 bytes.
-		routingDataBase256 := make([]byte, 0, 51*len(invoice.RoutingInfo))
-		for _, r := range invoice.RoutingInfo {
+		routingDataBase256 := make([]byte, 0, 51*len(routingInfo))
+		for _, r := range routingInfo {
 			base256 := make([]byte, 51)
 			copy(base256[:33], r.PubKey.SerializeCompressed())
 			binary.BigEndian.PutUint64(base256[33:41], r.ShortChanID)
-			binary.BigEndian.PutUint64(base256[41:49], r.Fee)
+			binary.BigEndian.PutUint32(base256[41:45], r.FeeBaseMsat)
+			binary.BigEndian.PutUint32(
+				base256[45:49], r.FeeProportionalMillionths,
+			)
 			binary.BigEndian.PutUint16(base256[49:51], r.CltvExpDelta)
 			routingDataBase256 = append(routingDataBase256, base256...)
 		}
@@ -915,6 +961,19 @@ func writeTaggedFields(bufferBase32 *bytes.Buffer, invoice *Invoice) error {
 		}
 	}
 
+	if invoice.Features != nil && invoice.Features.SerializeSizeBase32() > 0 {
+		var featuresBase32 bytes.Buffer
+		if err := invoice.Features.EncodeBase32(&featuresBase32); err != nil {
+			return err
+		}
+
+		err := writeTaggedField(bufferBase32, fieldType9,
+			featuresBase32.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
 	if invoice.Destination != nil {
 		// Convert 33 byte pubkey to 53 5-bit groups.
 		pubKeyBase32, err := bech32.ConvertBits(
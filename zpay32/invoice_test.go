@@ -313,12 +313,14 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo: []zpay32.ExtraRoutingInfo{
+					RouteHints: [][]zpay32.ExtraRoutingInfo{
 						{
-							PubKey:       testRoutingInfoPubkey,
-							ShortChanID:  0x0102030405060708,
-							Fee:          20,
-							CltvExpDelta: 3,
+							{
+								PubKey:                    testRoutingInfoPubkey,
+								ShortChanID:               0x0102030405060708,
+								FeeProportionalMillionths: 20,
+								CltvExpDelta:              3,
+							},
 						},
 					},
 				}
@@ -343,18 +345,20 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo: []zpay32.ExtraRoutingInfo{
-						{
-							PubKey:       testRoutingInfoPubkey,
-							ShortChanID:  0x0102030405060708,
-							Fee:          20,
-							CltvExpDelta: 3,
-						},
+					RouteHints: [][]zpay32.ExtraRoutingInfo{
 						{
-							PubKey:       testRoutingInfoPubkey2,
-							ShortChanID:  0x030405060708090a,
-							Fee:          30,
-							CltvExpDelta: 4,
+							{
+								PubKey:                    testRoutingInfoPubkey,
+								ShortChanID:               0x0102030405060708,
+								FeeProportionalMillionths: 20,
+								CltvExpDelta:              3,
+							},
+							{
+								PubKey:                    testRoutingInfoPubkey2,
+								ShortChanID:               0x030405060708090a,
+								FeeProportionalMillionths: 30,
+								CltvExpDelta:              4,
+							},
 						},
 					},
 				}
@@ -538,19 +542,19 @@ func TestNewInvoice(t *testing.T) {
 					zpay32.Amount(testMillisat20mBTC),
 					zpay32.DescriptionHash(testDescriptionHash),
 					zpay32.FallbackAddr(testRustyAddr),
-					zpay32.RoutingInfo(
+					zpay32.RouteHint(
 						[]zpay32.ExtraRoutingInfo{
 							{
-								PubKey:       testRoutingInfoPubkey,
-								ShortChanID:  0x0102030405060708,
-								Fee:          20,
-								CltvExpDelta: 3,
+								PubKey:                    testRoutingInfoPubkey,
+								ShortChanID:               0x0102030405060708,
+								FeeProportionalMillionths: 20,
+								CltvExpDelta:              3,
 							},
 							{
-								PubKey:       testRoutingInfoPubkey2,
-								ShortChanID:  0x030405060708090a,
-								Fee:          30,
-								CltvExpDelta: 4,
+								PubKey:                    testRoutingInfoPubkey2,
+								ShortChanID:               0x030405060708090a,
+								FeeProportionalMillionths: 30,
+								CltvExpDelta:              4,
 							},
 						},
 					),
@@ -581,6 +585,90 @@ func TestNewInvoice(t *testing.T) {
 	}
 }
 
+// TestInvoiceFeatures tests that the feature bits set on an invoice survive an
+// encode and decode round trip.
+func TestInvoiceFeatures(t *testing.T) {
+	t.Parallel()
+
+	features := lnwire.NewRawFeatureVector(lnwire.MultiPathOptional)
+	invoice, err := zpay32.NewInvoice(&chaincfg.MainNetParams,
+		testPaymentHash, time.Unix(1496314658, 0),
+		zpay32.Amount(testMillisat20mBTC),
+		zpay32.Description(testPleaseConsider),
+		zpay32.Destination(testPubKey),
+		zpay32.Features(features),
+	)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+
+	encoded, err := invoice.Encode(testMessageSigner)
+	if err != nil {
+		t.Fatalf("unable to encode invoice: %v", err)
+	}
+	decoded, err := zpay32.Decode(encoded)
+	if err != nil {
+		t.Fatalf("unable to decode invoice: %v", err)
+	}
+
+	if err := compareInvoices(invoice, decoded); err != nil {
+		t.Fatalf("invoice mismatch: %v", err)
+	}
+	if !decoded.Features.IsSet(lnwire.MultiPathOptional) {
+		t.Fatalf("expected multi-path feature to be set")
+	}
+}
+
+// TestInvoiceRouteHints tests that an invoice holding multiple private routes
+// to the target node can be encoded and decoded again.
+func TestInvoiceRouteHints(t *testing.T) {
+	t.Parallel()
+
+	routeHint1 := []zpay32.ExtraRoutingInfo{
+		{
+			PubKey:                    testRoutingInfoPubkey,
+			ShortChanID:               0x0102030405060708,
+			FeeBaseMsat:               1000,
+			FeeProportionalMillionths: 20,
+			CltvExpDelta:              3,
+		},
+	}
+	routeHint2 := []zpay32.ExtraRoutingInfo{
+		{
+			PubKey:                    testRoutingInfoPubkey2,
+			ShortChanID:               0x030405060708090a,
+			FeeBaseMsat:               2000,
+			FeeProportionalMillionths: 30,
+			CltvExpDelta:              4,
+		},
+	}
+
+	invoice, err := zpay32.NewInvoice(&chaincfg.MainNetParams,
+		testPaymentHash, time.Unix(1496314658, 0),
+		zpay32.Amount(testMillisat20mBTC),
+		zpay32.Description(testPleaseConsider),
+		zpay32.Destination(testPubKey),
+		zpay32.RouteHint(routeHint1),
+		zpay32.RouteHint(routeHint2),
+	)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+
+	encoded, err := invoice.Encode(testMessageSigner)
+	if err != nil {
+		t.Fatalf("unable to encode invoice: %v", err)
+	}
+	decoded, err := zpay32.Decode(encoded)
+	if err != nil {
+		t.Fatalf("unable to decode invoice: %v", err)
+	}
+
+	if err := compareInvoices(invoice, decoded); err != nil {
+		t.Fatalf("invoice mismatch: %v", err)
+	}
+}
+
 func compareInvoices(expected, actual *zpay32.Invoice) error {
 	if !reflect.DeepEqual(expected.Net, actual.Net) {
 		return fmt.Errorf("expected net %v, got %v",
@@ -626,35 +714,64 @@ func compareInvoices(expected, actual *zpay32.Invoice) error {
 			expected.FallbackAddr, actual.FallbackAddr)
 	}
 
-	if len(expected.RoutingInfo) != len(actual.RoutingInfo) {
-		return fmt.Errorf("expected len routingInfo %d, got %d",
-			len(expected.RoutingInfo), len(actual.RoutingInfo))
+	if len(expected.RouteHints) != len(actual.RouteHints) {
+		return fmt.Errorf("expected %d route hints, got %d",
+			len(expected.RouteHints), len(actual.RouteHints))
 	}
 
-	for i := 0; i < len(expected.RoutingInfo); i++ {
-		a := expected.RoutingInfo[i]
-		b := actual.RoutingInfo[i]
+	for i := 0; i < len(expected.RouteHints); i++ {
+		err := compareRoutingInfos(
+			expected.RouteHints[i], actual.RouteHints[i],
+		)
+		if err != nil {
+			return err
+		}
+	}
 
-		if !comparePubkeys(a.PubKey, b.PubKey) {
+	if !reflect.DeepEqual(expected.Features, actual.Features) {
+		return fmt.Errorf("expected features %v, got %v",
+			expected.Features, actual.Features)
+	}
+
+	return nil
+}
+
+func compareRoutingInfos(a, b []zpay32.ExtraRoutingInfo) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("expected len routingInfo %d, got %d",
+			len(a), len(b))
+	}
+
+	for i := 0; i < len(a); i++ {
+		if !comparePubkeys(a[i].PubKey, b[i].PubKey) {
 			return fmt.Errorf("expected routingInfo pubkey %x, "+
-				"got %x", a.PubKey, b.PubKey)
+				"got %x", a[i].PubKey, b[i].PubKey)
 		}
 
-		if a.ShortChanID != b.ShortChanID {
+		if a[i].ShortChanID != b[i].ShortChanID {
 			return fmt.Errorf("expected routingInfo shortChanID "+
-				"%d, got %d", a.ShortChanID, b.ShortChanID)
+				"%d, got %d", a[i].ShortChanID, b[i].ShortChanID)
+		}
+
+		if a[i].FeeBaseMsat != b[i].FeeBaseMsat {
+			return fmt.Errorf("expected routingInfo fee base "+
+				"%d, got %d", a[i].FeeBaseMsat, b[i].FeeBaseMsat)
 		}
 
-		if a.Fee != b.Fee {
-			return fmt.Errorf("expected routingInfo fee %d, got %d",
-				a.Fee, b.Fee)
+		if a[i].FeeProportionalMillionths !=
+			b[i].FeeProportionalMillionths {
+
+			return fmt.Errorf("expected routingInfo fee rate "+
+				"%d, got %d", a[i].FeeProportionalMillionths,
+				b[i].FeeProportionalMillionths)
 		}
 
-		if a.CltvExpDelta != b.CltvExpDelta {
+		if a[i].CltvExpDelta != b[i].CltvExpDelta {
 			return fmt.Errorf("expected routingInfo cltvExpDelta "+
-				"%d, got %d", a.CltvExpDelta, b.CltvExpDelta)
+				"%d, got %d", a[i].CltvExpDelta, b[i].CltvExpDelta)
 		}
 	}
+
 	return nil
 }
 